@@ -0,0 +1,27 @@
+// Command openapi writes the OpenAPI document of the API, run through go generate in
+// internal/openapi
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ngomez18/playlist-router/internal/openapi"
+)
+
+func main() {
+	output := flag.String("o", "openapi.json", "file the document is written to")
+	flag.Parse()
+
+	spec, err := openapi.Generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to generate openapi document: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*output, spec, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to write openapi document: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/backup"
+	"github.com/pocketbase/pocketbase"
+	"github.com/spf13/cobra"
+)
+
+// newRotateEncryptionKeysCommand re-wraps every user data key with the current master key.
+// Dependencies are resolved on execution, once the app has been bootstrapped.
+func newRotateEncryptionKeysCommand(deps *AppDependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:          "rotate-encryption-keys",
+		Short:        "Re-wraps user encryption keys with the current ENCRYPTION_KEY",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rotation, err := deps.services.encryptionKeyService.RotateKeys(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("rotated %d keys to version %d (%d failed)\n", rotation.KeysRotated, rotation.ToVersion, rotation.KeysFailed)
+			if rotation.KeysFailed > 0 {
+				return fmt.Errorf("%d keys could not be rotated", rotation.KeysFailed)
+			}
+
+			return nil
+		},
+	}
+}
+
+// newSupportBundleCommand writes the support bundle archive users attach to bug reports
+func newSupportBundleCommand(deps *AppDependencies) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:          "support-bundle",
+		Short:        "Packages redacted config, logs, schema versions and failed syncs for bug reports",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				output = fmt.Sprintf("support-bundle-%s.zip", time.Now().UTC().Format("20060102-150405"))
+			}
+
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			if err := deps.services.supportBundleService.WriteBundle(cmd.Context(), file); err != nil {
+				return err
+			}
+
+			fmt.Printf("support bundle written to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "archive path (defaults to support-bundle-<timestamp>.zip)")
+
+	return cmd
+}
+
+// newBackupCommand writes an encrypted snapshot of the PocketBase data directory to BACKUP_DIR,
+// uploading it when a backup bucket is configured
+func newBackupCommand(app *pocketbase.PocketBase, deps *AppDependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:          "backup",
+		Short:        "Creates an encrypted backup of the PocketBase data directory",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.config.Database.UsesPostgres() {
+				fmt.Println("warning: the app data is stored in postgres, back it up with pg_dump. Only the PocketBase data directory is backed up")
+			}
+
+			name, err := backup.NewManager(app, deps.config.Backup, app.Logger()).Create(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("backup %s written to %s\n", name, deps.config.Backup.Dir)
+			if deps.config.Backup.UploadsBackups() {
+				fmt.Printf("backup %s uploaded to the %s bucket %s\n", name, deps.config.Backup.Storage, deps.config.Backup.Bucket)
+			}
+
+			return nil
+		},
+	}
+}
+
+// newRestoreCommand replaces the PocketBase data directory with a backup. The server must be
+// stopped while it runs
+func newRestoreCommand(app *pocketbase.PocketBase, deps *AppDependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:          "restore <backup>",
+		Short:        "Replaces the PocketBase data directory with a backup, the server must be stopped",
+		Long:         "Restores a backup created by the backup command. The backup is a file path, a file name in BACKUP_DIR, or the name of a backup uploaded to the backup bucket.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := backup.NewManager(app, deps.config.Backup, app.Logger()).Restore(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+
+			fmt.Printf("backup %s restored to %s\n", args[0], app.DataDir())
+			return nil
+		},
+	}
+}
+
+// newSeedCommand provisions a demo user with playlists and sync history against the spotify mock,
+// so the frontend can be developed without linking a real spotify account
+func newSeedCommand(deps *AppDependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:          "seed",
+		Short:        "Provisions demo data for local development, requires SPOTIFY_MOCK",
+		Long:         "Creates a demo user linked to the spotify mock, with base playlists, child playlists of varied filter rules and past syncs. Log in through the mock to use it. The server must be stopped, the mock listens on the same address.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !deps.config.Auth.SpotifyMock {
+				return errors.New("the seed command only runs against the spotify mock, set SPOTIFY_MOCK=true")
+			}
+
+			demo, err := deps.services.demoDataService.SeedDemoData(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("seeded demo user %s (%s): %d base playlists, %d child playlists, %d sync events\n",
+				demo.User.Email, demo.User.ID, len(demo.BasePlaylists), len(demo.ChildPlaylists), len(demo.SyncEvents))
+			return nil
+		},
+	}
+}
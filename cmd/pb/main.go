@@ -1,25 +1,58 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/cache"
+	"github.com/ngomez18/playlist-router/internal/clients"
+	lastfmclient "github.com/ngomez18/playlist-router/internal/clients/lastfm"
+	mailclient "github.com/ngomez18/playlist-router/internal/clients/mail"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	notifierclient "github.com/ngomez18/playlist-router/internal/clients/notifier"
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/clients/spotify/spotifymock"
+	tidalclient "github.com/ngomez18/playlist-router/internal/clients/tidal"
+	youtubemusicclient "github.com/ngomez18/playlist-router/internal/clients/youtubemusic"
 	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/controllers"
+	"github.com/ngomez18/playlist-router/internal/grpcapi"
+	"github.com/ngomez18/playlist-router/internal/internalapi"
 	"github.com/ngomez18/playlist-router/internal/middleware"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/openapi"
 	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/realtime"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/repositories/pb"
+	"github.com/ngomez18/playlist-router/internal/repositories/postgres"
+	"github.com/ngomez18/playlist-router/internal/security"
 	"github.com/ngomez18/playlist-router/internal/services"
 	"github.com/ngomez18/playlist-router/internal/static"
+	"github.com/ngomez18/playlist-router/internal/tracing"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/apis"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/redis/go-redis/v9"
 )
 
 type AppDependencies struct {
 	config        *config.Config
+	logLevel      *slog.LevelVar
+	keyring       *security.Keyring
+	spotifyClient *spotifyclient.SpotifyClient
 	repositories  Repositories
 	services      Services
 	orchestrators Orchestrators
@@ -28,11 +61,30 @@ type AppDependencies struct {
 }
 
 type Repositories struct {
-	basePlaylistRepository       repositories.BasePlaylistRepository
-	childPlaylistRepository      repositories.ChildPlaylistRepository
-	userRepository               repositories.UserRepository
-	spotifyIntegrationRepository repositories.SpotifyIntegrationRepository
-	syncEventRepository          repositories.SyncEventRepository
+	basePlaylistRepository            repositories.BasePlaylistRepository
+	childPlaylistRepository           repositories.ChildPlaylistRepository
+	userRepository                    repositories.UserRepository
+	spotifyIntegrationRepository      repositories.SpotifyIntegrationRepository
+	youTubeMusicIntegrationRepository repositories.YouTubeMusicIntegrationRepository
+	tidalIntegrationRepository        repositories.TidalIntegrationRepository
+	lastFmIntegrationRepository       repositories.LastFmIntegrationRepository
+	syncEventRepository               repositories.SyncEventRepository
+	playlistSnapshotRepository        repositories.PlaylistSnapshotRepository
+	playlistMembershipRepository      repositories.PlaylistMembershipRepository
+	userEncryptionKeyRepository       repositories.UserEncryptionKeyRepository
+	keyRotationRepository             repositories.EncryptionKeyRotationRepository
+	filterRuleChangeRepository        repositories.FilterRuleChangeRepository
+	apiKeyRepository                  repositories.APIKeyRepository
+	diagnosticsRepository             repositories.DiagnosticsRepository
+	playlistWebhookRepository         repositories.PlaylistWebhookRepository
+	basePlaylistWatchRepository       repositories.BasePlaylistWatchRepository
+	templateRepository                repositories.ChildPlaylistTemplateRepository
+	blocklistRepository               repositories.BlocklistEntryRepository
+	routingReportRepository           repositories.RoutingReportRepository
+	routingCacheRepository            repositories.RoutingCacheRepository
+	notificationPreferencesRepository repositories.NotificationPreferencesRepository
+	featureFlagRepository             repositories.FeatureFlagRepository
+	transactor                        repositories.Transactor
 }
 
 type Services struct {
@@ -42,9 +94,39 @@ type Services struct {
 	childPlaylistService      services.ChildPlaylistServicer
 	spotifyIntegrationService services.SpotifyIntegrationServicer
 	spotifyApiService         services.SpotifyAPIServicer
+	spotifyAccountService     services.SpotifyAccountServicer
 	syncEventService          services.SyncEventServicer
+	playlistSnapshotService   services.PlaylistSnapshotServicer
 	trackAggregatorService    services.TrackAggregatorServicer
 	trackRouterService        services.TrackRouterServicer
+	encryptionKeyService      services.EncryptionKeyServicer
+	filterRuleHistoryService  services.FilterRuleHistoryServicer
+	playlistWidgetService     services.PlaylistWidgetServicer
+	apiKeyService             services.APIKeyServicer
+	automationService         services.AutomationServicer
+	supportBundleService      services.SupportBundleServicer
+	playlistWebhookService    services.PlaylistWebhookServicer
+	templateService           services.ChildPlaylistTemplateServicer
+	basePlaylistCloneService  services.BasePlaylistCloneServicer
+	routingConfigService      services.RoutingConfigServicer
+	playlistImportService     services.BasePlaylistImportServicer
+	childPlaylistStatsService services.ChildPlaylistStatsServicer
+	playlistExportService     services.ChildPlaylistExportServicer
+	overviewService           services.BasePlaylistOverviewServicer
+	unmatchedTracksService    services.UnmatchedTracksServicer
+	blocklistService          services.BlocklistServicer
+	adminService              services.AdminServicer
+	accountService            services.AccountServicer
+	notificationService       services.NotificationServicer
+	featureFlagService        services.FeatureFlagServicer
+	maintenanceService        services.MaintenanceServicer
+	healthService             services.HealthServicer
+	demoDataService           services.DemoDataServicer
+	spotifyTokenManager       *services.SpotifyTokenManager
+	realtimeHub               *realtime.Hub
+	youTubeMusicService       services.YouTubeMusicAccountServicer // nil when youtube music is disabled
+	tidalService              services.TidalAccountServicer        // nil when tidal is disabled
+	lastFmService             services.LastFmAccountServicer       // nil when last.fm is disabled
 }
 
 type Controllers struct {
@@ -53,6 +135,33 @@ type Controllers struct {
 	authController          controllers.AuthController
 	spotifyController       controllers.SpotifyController
 	syncController          controllers.SyncController
+	ruleHistoryController   controllers.FilterRuleHistoryController
+	widgetController        controllers.PlaylistWidgetController
+	hookController          controllers.HookController
+	apiKeyController        controllers.APIKeyController
+	automationController    controllers.AutomationController
+	supportController       controllers.SupportController
+	webhookController       controllers.PlaylistWebhookController
+	templateController      controllers.ChildPlaylistTemplateController
+	cloneController         controllers.BasePlaylistCloneController
+	routingConfigController controllers.RoutingConfigController
+	importController        controllers.BasePlaylistImportController
+	statsController         controllers.ChildPlaylistStatsController
+	exportController        controllers.ChildPlaylistExportController
+	overviewController      controllers.BasePlaylistOverviewController
+	unmatchedController     controllers.UnmatchedTracksController
+	blocklistController     controllers.BlocklistController
+	adminController         controllers.AdminController
+	accountController       controllers.AccountController
+	notificationController  controllers.NotificationSettingsController
+	featureFlagController   controllers.FeatureFlagController
+	maintenanceController   controllers.MaintenanceController
+	openAPIController       controllers.OpenAPIController
+	realtimeController      controllers.RealtimeController
+	healthController        controllers.HealthController
+	youTubeMusicController  *controllers.YouTubeMusicController // nil when youtube music is disabled
+	tidalController         *controllers.TidalController        // nil when tidal is disabled
+	lastFmController        *controllers.LastFmController       // nil when last.fm is disabled
 }
 
 type Orchestrators struct {
@@ -62,18 +171,64 @@ type Orchestrators struct {
 type Middleware struct {
 	auth        *middleware.AuthMiddleware
 	spotifyAuth *middleware.SpotifyAuthMiddleware
+	apiKey      *middleware.APIKeyMiddleware
+	rateLimit   *middleware.RateLimitMiddleware
+	maintenance *middleware.MaintenanceMiddleware
 }
 
 func main() {
 	var deps AppDependencies
-	app := pocketbase.New()
+	cfg := config.MustLoad()
+
+	keyring, err := security.NewKeyring(cfg.Auth.EncryptionKeyVersion, cfg.Auth.MasterKeys())
+	if err != nil {
+		log.Fatalf("invalid encryption keys: %v", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.AppEnv)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+
+	app := pocketbase.NewWithConfig(pocketbase.Config{
+		DefaultDataDir:   cfg.Database.DataDir,
+		DataMaxOpenConns: cfg.Database.MaxOpenConns,
+		DataMaxIdleConns: cfg.Database.MaxIdleConns,
+		DBConnect:        pb.NewDBConnect(cfg.Database),
+	})
+
+	if cfg.Auth.SpotifyMock {
+		if err := startSpotifyMock(app, cfg, &deps); err != nil {
+			log.Fatalf("failed to start spotify mock: %v", err)
+		}
+	}
 
 	app.OnBootstrap().BindFunc(func(e *core.BootstrapEvent) error {
 		if err := e.Next(); err != nil {
 			return err
 		}
 
-		deps = initAppDependencies(app)
+		readDB, err := pb.OpenReadDB(app.DataDir(), cfg.Database)
+		if err != nil {
+			return err
+		}
+
+		app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+			_ = readDB.Close()
+			return e.Next()
+		})
+
+		var pool, readPool *pgxpool.Pool
+		if cfg.Database.UsesPostgres() {
+			if pool, err = openPostgres(app, cfg.Database); err != nil {
+				return err
+			}
+			if readPool, err = openPostgresReadPool(app, cfg.Database); err != nil {
+				return err
+			}
+		}
+
+		deps = initAppDependencies(app, cfg, readDB, pool, readPool, keyring)
 
 		if err := pb.InitCollections(app, deps.config); err != nil {
 			return err
@@ -82,34 +237,140 @@ func main() {
 		return nil
 	})
 
+	// Flushes the spans still waiting to be exported
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		_ = shutdownTracing(context.Background())
+		return e.Next()
+	})
+
 	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		setupRequestID(e)
+		setupTracing(e)
 		setupCors(e, deps.config)
 		initAppRoutes(deps, e)
+		scheduleTokenRefresh(app, deps)
+		scheduleBasePlaylistChangePoll(app, deps)
+		scheduleDeletedPlaylistPurge(app, deps)
+		scheduleSyncEventPurge(app, deps)
+		scheduleWorkerHeartbeat(app, deps)
+		watchConfigReload(app, deps)
+
+		if deps.config.InternalAPI.Enabled() {
+			if err := startInternalAPI(app, deps); err != nil {
+				return err
+			}
+		}
+
+		if deps.config.GRPCAPI.Enabled() {
+			if err := startGRPCAPI(app, deps); err != nil {
+				return err
+			}
+		}
+
 		return e.Next()
 	})
 
+	app.RootCmd.AddCommand(newRotateEncryptionKeysCommand(&deps))
+	app.RootCmd.AddCommand(newSupportBundleCommand(&deps))
+	app.RootCmd.AddCommand(newBackupCommand(app, &deps))
+	app.RootCmd.AddCommand(newRestoreCommand(app, &deps))
+	app.RootCmd.AddCommand(newSeedCommand(&deps))
+
 	if err := app.Start(); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func initAppDependencies(app *pocketbase.PocketBase) AppDependencies {
-	logger := app.Logger()
-	cfg := config.MustLoad()
+// initAppDependencies wires the app, storing its data in Postgres when pool is set and in the
+// PocketBase collections otherwise
+func initAppDependencies(app *pocketbase.PocketBase, cfg *config.Config, readDB dbx.Builder, pool, readPool *pgxpool.Pool, keyring *security.Keyring) AppDependencies {
+	// Records logged with a request context carry its request ID. The level can change on reload
+	logLevel := new(slog.LevelVar)
+	if level, err := cfg.SlogLevel(); err == nil {
+		logLevel.Set(level)
+	}
+	logger := slog.New(requestcontext.NewLogHandler(app.Logger().Handler()).WithLevel(logLevel))
 
 	spotifyClient := spotifyclient.NewSpotifyClient(&cfg.Auth, logger)
+	cacheStore := newCacheStore(cfg.Cache)
+	if cacheStore != nil {
+		spotifyClient.WithCache(cacheStore)
+	}
+	// Playlist calls go to the streaming service their playlist lives on
+	musicProvider := musicprovider.NewRouter(spotifyClient)
 
-	repositories := Repositories{
-		basePlaylistRepository:       pb.NewBasePlaylistRepositoryPocketbase(app),
-		childPlaylistRepository:      pb.NewChildPlaylistRepositoryPocketbase(app),
-		userRepository:               pb.NewUserRepositoryPocketbase(app),
-		spotifyIntegrationRepository: pb.NewSpotifyIntegrationRepositoryPocketbase(app),
-		syncEventRepository:          pb.NewSyncEventRepositoryPocketbase(app),
+	var repositories Repositories
+	var encryptionKeyService *services.EncryptionKeyService
+	if pool != nil {
+		repositories, encryptionKeyService = newPostgresRepositories(app, pool, readPool, cfg.Database, keyring, logger)
+	} else {
+		repositories, encryptionKeyService = newPocketbaseRepositories(app, readDB, keyring, logger)
 	}
 
 	userService := services.NewUserService(repositories.userRepository, logger)
 	spotifyIntegrationService := services.NewSpotifyIntegrationService(repositories.spotifyIntegrationRepository, logger)
 	syncEventService := services.NewSyncEventService(repositories.syncEventRepository, logger)
+	spotifyTokenManager := services.NewSpotifyTokenManager(spotifyIntegrationService, spotifyClient, cfg.Auth.SpotifyTokenRefreshWindow, logger)
+	spotifyAuthMiddleware := middleware.NewSpotifyAuthMiddleware(spotifyTokenManager, logger)
+	spotifyAccountService := services.NewSpotifyAccountService(
+		repositories.userRepository,
+		spotifyIntegrationService,
+		repositories.basePlaylistRepository,
+		repositories.childPlaylistRepository,
+		logger,
+	)
+	// Events of the services and syncs pushed to the open app connections
+	realtimeHub := realtime.NewHub(logger)
+
+	var youTubeMusicService services.YouTubeMusicAccountServicer
+	if cfg.YouTubeMusic.Enabled() {
+		youTubeMusicClient := youtubemusicclient.NewYouTubeMusicClient(&cfg.YouTubeMusic, logger)
+		youTubeMusicTokenManager := services.NewYouTubeMusicTokenManager(
+			repositories.youTubeMusicIntegrationRepository,
+			youTubeMusicClient,
+			cfg.YouTubeMusic.TokenRefreshWindow,
+			logger,
+		)
+		musicProvider.WithProvider(models.MusicProviderYouTubeMusic, youTubeMusicClient.WithCredentials(youTubeMusicTokenManager))
+		youTubeMusicService = services.NewYouTubeMusicAccountService(
+			repositories.youTubeMusicIntegrationRepository,
+			repositories.basePlaylistRepository,
+			repositories.childPlaylistRepository,
+			youTubeMusicClient,
+			logger,
+		)
+	}
+
+	var tidalService services.TidalAccountServicer
+	if cfg.Tidal.Enabled() {
+		tidalClient := tidalclient.NewTidalClient(&cfg.Tidal, logger)
+		tidalTokenManager := services.NewTidalTokenManager(
+			repositories.tidalIntegrationRepository,
+			tidalClient,
+			cfg.Tidal.TokenRefreshWindow,
+			logger,
+		)
+		musicProvider.WithProvider(models.MusicProviderTidal, tidalClient.WithCredentials(tidalTokenManager))
+		tidalService = services.NewTidalAccountService(
+			repositories.tidalIntegrationRepository,
+			repositories.basePlaylistRepository,
+			repositories.childPlaylistRepository,
+			tidalClient,
+			logger,
+		)
+	}
+
+	// Scrobbles of the linked last.fm accounts enrich the tracks routed to child playlists
+	var lastFmService services.LastFmAccountServicer
+	var lastFmEnricher services.LastFmEnricher
+	if cfg.LastFm.Enabled() {
+		lastFmClient := lastfmclient.NewLastFmClient(&cfg.LastFm, logger)
+		if cacheStore != nil {
+			lastFmClient.WithCache(cacheStore)
+		}
+		lastFmService = services.NewLastFmAccountService(repositories.lastFmIntegrationRepository, lastFmClient, logger)
+		lastFmEnricher = services.NewLastFmEnrichmentService(repositories.lastFmIntegrationRepository, lastFmClient, logger)
+	}
 
 	serviceInstances := Services{
 		userService:               userService,
@@ -118,22 +379,24 @@ func initAppDependencies(app *pocketbase.PocketBase) AppDependencies {
 			spotifyIntegrationService, 
 			spotifyClient, 
 			logger,
-		),
+		).WithSpotifyAccounts(spotifyAccountService).WithTransactor(repositories.transactor),
 		basePlaylistService:       services.NewBasePlaylistService(
 			repositories.basePlaylistRepository, 
 			repositories.childPlaylistRepository, 
 			repositories.spotifyIntegrationRepository, 
-			spotifyClient, 
+			musicProvider, 
 			logger,
-		),
+		).WithSpotifyAuth(spotifyTokenManager).WithEvents(realtimeHub),
 		childPlaylistService:      services.NewChildPlaylistService(
 			repositories.childPlaylistRepository, 
 			repositories.basePlaylistRepository, 
 			repositories.spotifyIntegrationRepository, 
-			spotifyClient, 
+			musicProvider, 
+			repositories.filterRuleChangeRepository,
 			logger,
-		),
+		).WithSpotifyAuth(spotifyTokenManager).WithEvents(realtimeHub).WithTransactor(repositories.transactor),
 		spotifyIntegrationService: spotifyIntegrationService,
+		spotifyAccountService:     spotifyAccountService,
 		spotifyApiService:         services.NewSpotifyAPIService(
 			spotifyClient, 
 			repositories.basePlaylistRepository, 
@@ -141,15 +404,155 @@ func initAppDependencies(app *pocketbase.PocketBase) AppDependencies {
 			logger,
 		),
 		syncEventService:          syncEventService,
+		playlistSnapshotService:   services.NewPlaylistSnapshotService(repositories.playlistSnapshotRepository, repositories.playlistMembershipRepository, repositories.routingReportRepository, logger),
 		trackAggregatorService:    services.NewTrackAggregatorService(
-			spotifyClient, 
+			musicProvider, 
 			repositories.basePlaylistRepository, 
 			logger,
-		),
+		).WithLastFm(lastFmEnricher),
 		trackRouterService:        services.NewTrackRouterService(
+			repositories.blocklistRepository,
+			logger,
+		).WithRoutingCache(repositories.routingCacheRepository),
+		encryptionKeyService:      encryptionKeyService,
+		filterRuleHistoryService:  services.NewFilterRuleHistoryService(repositories.filterRuleChangeRepository, logger),
+		playlistWidgetService:     services.NewPlaylistWidgetService(
+			repositories.childPlaylistRepository,
+			repositories.userRepository,
+			syncEventService,
+			musicProvider,
+			spotifyTokenManager,
+			logger,
+		),
+		apiKeyService:             services.NewAPIKeyService(repositories.apiKeyRepository, logger),
+		automationService:         services.NewAutomationService(
+			syncEventService,
+			repositories.basePlaylistRepository,
+			repositories.childPlaylistRepository,
+			logger,
+		),
+		supportBundleService: services.NewSupportBundleService(
+			repositories.diagnosticsRepository,
+			repositories.syncEventRepository,
+			cfg,
 			logger,
 		),
+		playlistWebhookService: services.NewPlaylistWebhookService(
+			repositories.playlistWebhookRepository,
+			repositories.basePlaylistWatchRepository,
+			repositories.basePlaylistRepository,
+			repositories.userRepository,
+			musicProvider,
+			spotifyTokenManager,
+			clients.NewPublicHTTPClient(services.WEBHOOK_DELIVERY_TIMEOUT),
+			logger,
+		),
+		blocklistService: services.NewBlocklistService(
+			repositories.blocklistRepository,
+			repositories.basePlaylistRepository,
+			logger,
+		),
+		adminService: services.NewAdminService(
+			repositories.userRepository,
+			repositories.syncEventRepository,
+			logger,
+		),
+		accountService: services.NewAccountService(
+			repositories.userRepository,
+			repositories.spotifyIntegrationRepository,
+			repositories.basePlaylistRepository,
+			repositories.childPlaylistRepository,
+			repositories.syncEventRepository,
+			repositories.apiKeyRepository,
+			repositories.templateRepository,
+			repositories.blocklistRepository,
+			repositories.playlistWebhookRepository,
+			musicProvider,
+			logger,
+		).WithSpotifyAuth(spotifyTokenManager),
+		notificationService: services.NewNotificationService(
+			repositories.notificationPreferencesRepository,
+			repositories.syncEventRepository,
+			repositories.userRepository,
+			repositories.basePlaylistRepository,
+			repositories.childPlaylistRepository,
+			mailclient.NewPocketBaseMailer(app),
+			logger,
+		).WithNotifiers(
+			notifierclient.NewSlackNotifier(&http.Client{}),
+			notifierclient.NewDiscordNotifier(&http.Client{}),
+		),
+		spotifyTokenManager: spotifyTokenManager,
+		realtimeHub:         realtimeHub,
+		youTubeMusicService: youTubeMusicService,
+		tidalService:        tidalService,
+		lastFmService:       lastFmService,
+		healthService:       services.NewHealthService(repositories.diagnosticsRepository, spotifyClient, logger),
+		featureFlagService:  services.NewFeatureFlagService(repositories.featureFlagRepository, logger),
+		maintenanceService:  services.NewMaintenanceService(repositories.featureFlagRepository, logger),
 	}
+	serviceInstances.templateService = services.NewChildPlaylistTemplateService(
+		repositories.templateRepository,
+		serviceInstances.childPlaylistService,
+		logger,
+	)
+	serviceInstances.basePlaylistCloneService = services.NewBasePlaylistCloneService(
+		serviceInstances.basePlaylistService,
+		serviceInstances.childPlaylistService,
+		logger,
+	)
+	serviceInstances.routingConfigService = services.NewRoutingConfigService(
+		serviceInstances.basePlaylistService,
+		serviceInstances.childPlaylistService,
+		logger,
+	)
+	serviceInstances.childPlaylistStatsService = services.NewChildPlaylistStatsService(
+		serviceInstances.basePlaylistService,
+		serviceInstances.childPlaylistService,
+		serviceInstances.syncEventService,
+		serviceInstances.trackAggregatorService,
+		serviceInstances.trackRouterService,
+		musicProvider,
+		spotifyTokenManager,
+		logger,
+	)
+	serviceInstances.playlistImportService = services.NewBasePlaylistImportService(
+		serviceInstances.basePlaylistService,
+		musicProvider,
+		spotifyTokenManager,
+		logger,
+	)
+	serviceInstances.playlistExportService = services.NewChildPlaylistExportService(
+		serviceInstances.childPlaylistService,
+		musicProvider,
+		spotifyTokenManager,
+		logger,
+	)
+	serviceInstances.overviewService = services.NewBasePlaylistOverviewService(
+		serviceInstances.basePlaylistService,
+		serviceInstances.childPlaylistService,
+		serviceInstances.syncEventService,
+		musicProvider,
+		spotifyTokenManager,
+		logger,
+	)
+	serviceInstances.unmatchedTracksService = services.NewUnmatchedTracksService(
+		serviceInstances.basePlaylistService,
+		serviceInstances.childPlaylistService,
+		serviceInstances.trackAggregatorService,
+		serviceInstances.trackRouterService,
+		spotifyTokenManager,
+		logger,
+	)
+	serviceInstances.demoDataService = services.NewDemoDataService(
+		userService,
+		spotifyIntegrationService,
+		serviceInstances.basePlaylistService,
+		serviceInstances.childPlaylistService,
+		syncEventService,
+		spotifyClient,
+		logger,
+	)
 
 	orchestratorInstances := Orchestrators{
 		syncOrchestrator: orchestrators.NewDefaultSyncOrchestrator(
@@ -158,26 +561,94 @@ func initAppDependencies(app *pocketbase.PocketBase) AppDependencies {
 			serviceInstances.childPlaylistService,
 			serviceInstances.basePlaylistService,
 			serviceInstances.syncEventService,
-			spotifyClient,
+			serviceInstances.playlistSnapshotService,
+			serviceInstances.filterRuleHistoryService,
+			musicProvider,
 			logger,
-		),
+		).WithSpotifyAuth(spotifyTokenManager).
+			WithNotifications(serviceInstances.notificationService).
+			WithEvents(realtimeHub).
+			WithChildConcurrency(cfg.Sync.ChildConcurrency).
+			WithStreaming(cfg.Sync.StreamingMinTracks).
+			WithRoutingCache(cfg.Sync.RoutingCache).
+			WithFeatureFlags(serviceInstances.featureFlagService).
+			WithMaintenance(serviceInstances.maintenanceService),
+	}
+
+	var youTubeMusicController *controllers.YouTubeMusicController
+	if youTubeMusicService != nil {
+		youTubeMusicController = controllers.NewYouTubeMusicController(youTubeMusicService, cfg)
+	}
+
+	var tidalController *controllers.TidalController
+	if tidalService != nil {
+		tidalController = controllers.NewTidalController(tidalService)
+	}
+
+	var lastFmController *controllers.LastFmController
+	if lastFmService != nil {
+		lastFmController = controllers.NewLastFmController(lastFmService, cfg)
 	}
 
 	controllers := Controllers{
 		basePlaylistController:  *controllers.NewBasePlaylistController(serviceInstances.basePlaylistService),
 		childPlaylistController: *controllers.NewChildPlaylistController(serviceInstances.childPlaylistService),
 		authController:          *controllers.NewAuthController(serviceInstances.authService, cfg),
-		spotifyController:       *controllers.NewSpotifyController(serviceInstances.spotifyApiService),
-		syncController:          *controllers.NewSyncController(orchestratorInstances.syncOrchestrator),
+		spotifyController:       *controllers.NewSpotifyController(serviceInstances.spotifyApiService, serviceInstances.spotifyAccountService),
+		syncController:          *controllers.NewSyncController(orchestratorInstances.syncOrchestrator, serviceInstances.syncEventService),
+		ruleHistoryController:   *controllers.NewFilterRuleHistoryController(serviceInstances.filterRuleHistoryService, orchestratorInstances.syncOrchestrator),
+		widgetController:        *controllers.NewPlaylistWidgetController(serviceInstances.playlistWidgetService),
+		hookController: *controllers.NewHookController(
+			serviceInstances.basePlaylistService,
+			serviceInstances.syncEventService,
+			orchestratorInstances.syncOrchestrator,
+			spotifyTokenManager,
+			userService,
+		),
+		apiKeyController: *controllers.NewAPIKeyController(serviceInstances.apiKeyService),
+		automationController: *controllers.NewAutomationController(
+			serviceInstances.automationService,
+			serviceInstances.basePlaylistService,
+			serviceInstances.childPlaylistService,
+			orchestratorInstances.syncOrchestrator,
+		),
+		supportController: *controllers.NewSupportController(serviceInstances.supportBundleService),
+		webhookController: *controllers.NewPlaylistWebhookController(serviceInstances.playlistWebhookService),
+		templateController: *controllers.NewChildPlaylistTemplateController(serviceInstances.templateService),
+		cloneController:    *controllers.NewBasePlaylistCloneController(serviceInstances.basePlaylistCloneService),
+		routingConfigController: *controllers.NewRoutingConfigController(serviceInstances.routingConfigService),
+		importController:        *controllers.NewBasePlaylistImportController(serviceInstances.playlistImportService),
+		statsController:         *controllers.NewChildPlaylistStatsController(serviceInstances.childPlaylistStatsService),
+		exportController:        *controllers.NewChildPlaylistExportController(serviceInstances.playlistExportService),
+		overviewController:      *controllers.NewBasePlaylistOverviewController(serviceInstances.overviewService),
+		unmatchedController:     *controllers.NewUnmatchedTracksController(serviceInstances.unmatchedTracksService),
+		blocklistController: *controllers.NewBlocklistController(serviceInstances.blocklistService),
+		adminController: *controllers.NewAdminController(serviceInstances.adminService, orchestratorInstances.syncOrchestrator),
+		accountController: *controllers.NewAccountController(serviceInstances.accountService),
+		notificationController: *controllers.NewNotificationSettingsController(serviceInstances.notificationService),
+		featureFlagController:   *controllers.NewFeatureFlagController(serviceInstances.featureFlagService),
+		maintenanceController:   *controllers.NewMaintenanceController(serviceInstances.maintenanceService),
+		openAPIController:       *controllers.NewOpenAPIController(openapi.Spec(), "/api/openapi.json"),
+		realtimeController:      *controllers.NewRealtimeController(userService, realtimeHub, realtimeOrigins(cfg)),
+		healthController:        *controllers.NewHealthController(serviceInstances.healthService),
+		youTubeMusicController:  youTubeMusicController,
+		tidalController:         tidalController,
+		lastFmController:        lastFmController,
 	}
 
 	middleware := Middleware{
-		auth:        middleware.NewAuthMiddleware(userService),
-		spotifyAuth: middleware.NewSpotifyAuthMiddleware(spotifyIntegrationService, spotifyClient, logger),
+		auth:        middleware.NewAuthMiddleware(userService).WithAPIKeys(serviceInstances.apiKeyService),
+		spotifyAuth: spotifyAuthMiddleware,
+		apiKey:      middleware.NewAPIKeyMiddleware(serviceInstances.apiKeyService, userService),
+		rateLimit:   middleware.NewRateLimitMiddleware(cfg.RateLimit),
+		maintenance: middleware.NewMaintenanceMiddleware(serviceInstances.maintenanceService),
 	}
 
 	return AppDependencies{
 		config:        cfg,
+		logLevel:      logLevel,
+		keyring:       keyring,
+		spotifyClient: spotifyClient,
 		repositories:  repositories,
 		services:      serviceInstances,
 		orchestrators: orchestratorInstances,
@@ -186,16 +657,36 @@ func initAppDependencies(app *pocketbase.PocketBase) AppDependencies {
 	}
 }
 
+// newCacheStore returns the store spotify and last.fm reads are cached in, nil when caching is
+// disabled
+func newCacheStore(cfg config.CacheConfig) cache.Store {
+	switch cfg.Backend {
+	case config.CacheBackendMemory:
+		return cache.NewMemoryStore(cfg.MaxEntries)
+	case config.CacheBackendRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		})
+		return cache.NewRedisStore(client, "playlist-router:")
+	default:
+		return nil
+	}
+}
+
 func setupCors(e *core.ServeEvent, cfg *config.Config) {
 	e.Router.BindFunc(func(e *core.RequestEvent) error {
 		if cfg.AppEnv == "production" {
 			e.Response.Header().Set("Access-Control-Allow-Origin", cfg.Auth.FrontendURL)
 			e.Response.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
 			e.Response.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization")
+			e.Response.Header().Set("Access-Control-Expose-Headers", middleware.REQUEST_ID_HEADER)
 		} else {
 			e.Response.Header().Set("Access-Control-Allow-Origin", "*")
 			e.Response.Header().Set("Access-Control-Allow-Methods", "*")
 			e.Response.Header().Set("Access-Control-Allow-Headers", "*")
+			e.Response.Header().Set("Access-Control-Expose-Headers", "*")
 		}
 
 		if e.Request.Method == "OPTIONS" {
@@ -207,50 +698,576 @@ func setupCors(e *core.ServeEvent, cfg *config.Config) {
 	})
 }
 
+// setupRequestID tags every request with the ID returned in its X-Request-ID header
+func setupRequestID(e *core.ServeEvent) {
+	e.Router.BindFunc(apis.WrapStdMiddleware(middleware.RequestID))
+}
+
+// setupTracing opens a server span for every request but the long lived app connections, the
+// health checks and probes, and the frontend files
+func setupTracing(e *core.ServeEvent) {
+	e.Router.BindFunc(apis.WrapStdMiddleware(tracing.Middleware("GET /api/ws", "GET /health", "GET /healthz", "GET /readyz", "GET /{path...}")))
+}
+
+// realtimeOrigins are the origins allowed to open app connections, matching the CORS policy
+func realtimeOrigins(cfg *config.Config) []string {
+	if cfg.AppEnv == "production" {
+		return []string{cfg.Auth.FrontendURL}
+	}
+
+	return []string{"*"}
+}
+
 func initAppRoutes(deps AppDependencies, e *core.ServeEvent) {
 	// Auth routes
 	auth := e.Router.Group("/auth")
 	auth.GET("/spotify/login", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.authController.SpotifyLogin)))
 	auth.GET("/spotify/callback", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.authController.SpotifyCallback)))
 	auth.GET("/validate", apis.WrapStdHandler(deps.middleware.auth.RequireAuth(http.HandlerFunc(deps.controllers.authController.ValidateToken))))
+	auth.POST("/spotify/link", apis.WrapStdHandler(deps.middleware.auth.RequireAuth(http.HandlerFunc(deps.controllers.authController.SpotifyLink))))
+	auth.POST("/logout", apis.WrapStdHandler(deps.middleware.auth.RequireAuth(http.HandlerFunc(deps.controllers.authController.Logout))))
 
 	// Protected API routes (require authentication)
 	api := e.Router.Group("/api")
 	api.BindFunc(apis.WrapStdMiddleware(deps.middleware.auth.RequireAuth))
+	// Bound after auth, so requests are limited per user. Bound even when disabled, so a config reload
+	// can enable it
+	api.BindFunc(apis.WrapStdMiddleware(deps.middleware.rateLimit.Limit))
+	// Bound after auth, so admins can still make changes and turn maintenance mode off
+	api.BindFunc(apis.WrapStdMiddleware(deps.middleware.maintenance.RejectWrites))
+
+	// Routes scripts and CI jobs can call with an API key bearer token, the others only accept JWTs
+	allowAPIKey := deps.middleware.auth.AllowAPIKey
 
 	// Base Playlist routes
 	basePlaylist := api.Group("/base_playlist")
 	basePlaylist.POST("", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.basePlaylistController.Create))))
-	basePlaylist.GET("", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.GetByUserIDWithChilds)))
+	basePlaylist.GET("", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.basePlaylistController.GetByUserIDWithChilds))))
 	basePlaylist.GET("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.GetByID)))
+	basePlaylist.PUT("/{id}", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.basePlaylistController.Update))))
 	basePlaylist.DELETE("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.Delete)))
-	basePlaylist.POST("/{basePlaylistID}/sync", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.SyncBasePlaylist))))
+	basePlaylist.POST("/{id}/restore", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.Restore)))
+	basePlaylist.GET("/{id}/overview", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.overviewController.GetOverview))))
+	basePlaylist.GET("/{id}/unmatched", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.unmatchedController.List))))
+	basePlaylist.POST("/{id}/clone", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.cloneController.Clone))))
+	basePlaylist.POST("/import", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.routingConfigController.Import))))
+	basePlaylist.POST("/import_tracks", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.importController.ImportTracks))))
+	basePlaylist.GET("/{id}/config/export", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.routingConfigController.Export))))
+	basePlaylist.POST("/{id}/hooks", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.EnableHooks)))
+	basePlaylist.DELETE("/{id}/hooks", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.DisableHooks)))
+	basePlaylist.POST("/{id}/archive", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.Archive)))
+	basePlaylist.DELETE("/{id}/archive", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.basePlaylistController.Unarchive)))
+	basePlaylist.POST("/{basePlaylistID}/sync", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncWrite)(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.SyncBasePlaylist)))))
+	basePlaylist.GET("/{basePlaylistID}/sync_events", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.syncController.ListBasePlaylistSyncEvents))))
+	basePlaylist.DELETE("/{basePlaylistID}/sync_events", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncWrite)(http.HandlerFunc(deps.controllers.syncController.PruneBasePlaylistSyncEvents))))
+	basePlaylist.POST("/{basePlaylistID}/webhooks", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.webhookController.Create)))
+	basePlaylist.GET("/{basePlaylistID}/webhooks", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.webhookController.List)))
+	basePlaylist.POST("/{basePlaylistID}/blocklist", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.blocklistController.Create)))
+	basePlaylist.GET("/{basePlaylistID}/blocklist", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.blocklistController.List)))
+	basePlaylist.GET("/{basePlaylistID}/audit", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.AuditBasePlaylist))))
+	basePlaylist.POST("/{basePlaylistID}/filter_preview", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.PreviewFilters))))
 
 	// Child Playlist routes for a specific base playlist
 	basePlaylist.POST("/{basePlaylistID}/child_playlist", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.childPlaylistController.Create))))
 	basePlaylist.GET("/{basePlaylistID}/child_playlist", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.childPlaylistController.GetByBasePlaylistID)))
+	basePlaylist.PATCH("/{basePlaylistID}/child_playlist/order", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.childPlaylistController.Reorder)))
 
 	// Child Playlist routes by ID
 	childPlaylist := api.Group("/child_playlist")
 	childPlaylist.GET("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.childPlaylistController.GetByID)))
 	childPlaylist.PUT("/{id}", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.childPlaylistController.Update))))
 	childPlaylist.DELETE("/{id}", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.childPlaylistController.Delete))))
+	childPlaylist.POST("/{id}/restore", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.childPlaylistController.Restore))))
+	childPlaylist.GET("/{id}/stats", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.statsController.GetStats))))
+	childPlaylist.GET("/{id}/export", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.exportController.Export))))
+	childPlaylist.GET("/{id}/rule_history", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.ruleHistoryController.GetHistory)))
+	childPlaylist.POST("/{id}/rule_history/{changeID}/diff", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.ruleHistoryController.ComputeDiff))))
+	childPlaylist.POST("/{id}/share", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.childPlaylistController.Share)))
+	childPlaylist.DELETE("/{id}/share", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.childPlaylistController.Unshare)))
+	childPlaylist.POST("/{id}/pinned_tracks", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.childPlaylistController.PinTracks)))
+	childPlaylist.DELETE("/{id}/pinned_tracks/{trackURI}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.childPlaylistController.UnpinTrack)))
+	childPlaylist.PUT("/{id}/cover", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.childPlaylistController.UploadCover))))
+
+	// Sync routes
+	sync := api.Group("/sync")
+	sync.POST("/all", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncWrite)(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.SyncAllBasePlaylists)))))
+	sync.GET("/audit", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.AuditAllBasePlaylists))))
+	sync.POST("/migrate-in-place", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.MigrateToInPlace))))
+	sync.POST("/{syncEventID}/rollback", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.RollbackSync))))
+	sync.POST("/{syncEventID}/confirm", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.syncController.ConfirmSync))))
+	sync.GET("/{syncEventID}", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.syncController.GetSyncEvent))))
+	sync.GET("/{syncEventID}/report", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.syncController.GetRoutingReport))))
+
+	// Sync history
+	api.GET("/sync_events", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.syncController.ListSyncEvents))))
+	api.DELETE("/sync_events", apis.WrapStdHandler(allowAPIKey(models.APIKeyScopeSyncWrite)(http.HandlerFunc(deps.controllers.syncController.PruneSyncEvents))))
+
+	// API keys for automation platforms, scripts and CI jobs
+	apiKeys := api.Group("/keys")
+	apiKeys.POST("", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.apiKeyController.Create)))
+	apiKeys.GET("", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.apiKeyController.List)))
+	apiKeys.DELETE("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.apiKeyController.Delete)))
+
+	// Webhooks notified when a base playlist changes outside of the router
+	webhooks := api.Group("/webhooks")
+	webhooks.DELETE("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.webhookController.Delete)))
+
+	// Tracks and artists kept out of every child playlist of a base playlist
+	blocklist := api.Group("/blocklist")
+	blocklist.DELETE("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.blocklistController.Delete)))
+
+	// Child playlist templates, instantiated against any base playlist
+	templates := api.Group("/templates")
+	templates.GET("", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.templateController.List)))
+	templates.POST("", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.templateController.Create)))
+	templates.GET("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.templateController.GetByID)))
+	templates.DELETE("/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.templateController.Delete)))
+	templates.POST("/{id}/instantiate", apis.WrapStdHandler(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.templateController.Instantiate))))
+
+	// Account erasure and data export. Deletion doesn't go through the spotify auth middleware, so the
+	// account can be deleted even when its spotify tokens can no longer be refreshed
+	api.DELETE("/account", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.accountController.Delete)))
+	api.GET("/account/export", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.accountController.Export)))
+
+	// Notification preferences, e.g. emails about failing scheduled syncs
+	api.GET("/settings/notifications", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.notificationController.Get)))
+	api.PUT("/settings/notifications", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.notificationController.Update)))
+
+	// Disconnecting doesn't go through the spotify auth middleware, so it works even when the
+	// stored tokens can no longer be refreshed
+	api.DELETE("/spotify/integration", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.spotifyController.DisconnectIntegration)))
+	api.GET("/spotify/accounts", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.spotifyController.ListAccounts)))
+	api.DELETE("/spotify/accounts/{id}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.spotifyController.UnlinkAccount)))
 
 	// Spotify routes (protected)
 	spotify := api.Group("/spotify")
 	spotify.BindFunc(apis.WrapStdMiddleware(deps.middleware.spotifyAuth.RequireSpotifyAuth))
 	spotify.GET("/playlists", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.spotifyController.GetUserPlaylists)))
 
+	// YouTube Music account of the user, only served when the integration is configured. Linking goes
+	// through the auth group like the spotify accounts, Google redirects back there
+	if ytController := deps.controllers.youTubeMusicController; ytController != nil {
+		auth.POST("/youtube_music/link", apis.WrapStdHandler(deps.middleware.auth.RequireAuth(http.HandlerFunc(ytController.Link))))
+		auth.GET("/youtube_music/callback", apis.WrapStdHandler(http.HandlerFunc(ytController.Callback)))
+		api.GET("/youtube_music/account", apis.WrapStdHandler(http.HandlerFunc(ytController.GetAccount)))
+		api.DELETE("/youtube_music/account", apis.WrapStdHandler(http.HandlerFunc(ytController.Unlink)))
+		api.GET("/youtube_music/playlists", apis.WrapStdHandler(http.HandlerFunc(ytController.GetUserPlaylists)))
+	}
+
+	// Tidal account of the user, only served when the integration is configured. Accounts are linked
+	// through the device flow, the frontend polls the link while the user enters the code on tidal
+	if tidalController := deps.controllers.tidalController; tidalController != nil {
+		auth.POST("/tidal/link", apis.WrapStdHandler(deps.middleware.auth.RequireAuth(http.HandlerFunc(tidalController.StartLink))))
+		auth.POST("/tidal/link/poll", apis.WrapStdHandler(deps.middleware.auth.RequireAuth(http.HandlerFunc(tidalController.PollLink))))
+		api.GET("/tidal/account", apis.WrapStdHandler(http.HandlerFunc(tidalController.GetAccount)))
+		api.DELETE("/tidal/account", apis.WrapStdHandler(http.HandlerFunc(tidalController.Unlink)))
+		api.GET("/tidal/playlists", apis.WrapStdHandler(http.HandlerFunc(tidalController.GetUserPlaylists)))
+	}
+
+	// Last.fm account of the user, only served when the integration is configured. Last.fm redirects
+	// back to the auth group once the user approved the app
+	if lastFmController := deps.controllers.lastFmController; lastFmController != nil {
+		auth.POST("/lastfm/link", apis.WrapStdHandler(deps.middleware.auth.RequireAuth(http.HandlerFunc(lastFmController.Link))))
+		auth.GET("/lastfm/callback", apis.WrapStdHandler(http.HandlerFunc(lastFmController.Callback)))
+		api.GET("/lastfm/account", apis.WrapStdHandler(http.HandlerFunc(lastFmController.GetAccount)))
+		api.DELETE("/lastfm/account", apis.WrapStdHandler(http.HandlerFunc(lastFmController.Unlink)))
+	}
+
+	// Public embeddable widget of shared child playlists, authorized by the share token
+	e.Router.GET("/embed/child_playlist/{shareToken}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.widgetController.GetWidget)))
+
+	// Automation hooks for Apple Shortcuts and similar tools, authorized by the base playlist hook token
+	hooks := e.Router.Group("/hooks")
+	hooks.POST("/sync/{playlistToken}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.hookController.TriggerSync)))
+	hooks.GET("/sync/{playlistToken}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.hookController.GetStatus)))
+
+	// Zapier/IFTTT style polling triggers and actions, authorized by a scoped API key
+	requireScope := deps.middleware.apiKey.RequireScope
+	zapier := e.Router.Group("/zapier")
+	zapier.BindFunc(apis.WrapStdMiddleware(deps.middleware.apiKey.RequireAPIKey))
+	zapier.BindFunc(apis.WrapStdMiddleware(deps.middleware.maintenance.RejectWrites))
+	zapier.GET("/me", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.automationController.Me)))
+	zapier.GET("/triggers/sync_completed", apis.WrapStdHandler(requireScope(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.automationController.SyncCompleted))))
+	zapier.GET("/triggers/tracks_routed", apis.WrapStdHandler(requireScope(models.APIKeyScopeSyncRead)(http.HandlerFunc(deps.controllers.automationController.TracksRouted))))
+	zapier.POST("/actions/sync", apis.WrapStdHandler(requireScope(models.APIKeyScopeSyncWrite)(deps.middleware.spotifyAuth.RequireSpotifyAuth(http.HandlerFunc(deps.controllers.automationController.TriggerSync)))))
+	zapier.POST("/actions/exclusion", apis.WrapStdHandler(requireScope(models.APIKeyScopeRulesWrite)(http.HandlerFunc(deps.controllers.automationController.AddExclusion))))
+
+	// Admin API routes, restricted to the users granted the admin role
+	adminAPI := api.Group("/admin")
+	adminAPI.BindFunc(apis.WrapStdMiddleware(deps.middleware.auth.RequireRole(models.RoleAdmin)))
+	adminAPI.GET("/users", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.adminController.ListUsers)))
+	adminAPI.POST("/users/{id}/disable", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.adminController.DisableUser)))
+	adminAPI.POST("/users/{id}/enable", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.adminController.EnableUser)))
+	adminAPI.GET("/sync_events", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.adminController.ListSyncEvents)))
+	adminAPI.POST("/sync/{id}/retry", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.adminController.RetrySync)))
+	adminAPI.GET("/feature_flags", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.featureFlagController.List)))
+	adminAPI.PUT("/feature_flags/{key}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.featureFlagController.Update)))
+	adminAPI.DELETE("/feature_flags/{key}", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.featureFlagController.Delete)))
+	adminAPI.GET("/maintenance", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.maintenanceController.Get)))
+	adminAPI.PUT("/maintenance", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.maintenanceController.Update)))
+
+	// Operator routes, restricted to PocketBase superusers
+	admin := e.Router.Group("/admin")
+	admin.Bind(apis.RequireSuperuserAuth())
+	admin.GET("/support_bundle", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.supportController.DownloadBundle)))
+
+	// OpenAPI document and its Swagger UI page, public so clients can be generated from them. Bound
+	// on the root router, the auth of the api group doesn't apply
+	e.Router.GET("/api/openapi.json", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.openAPIController.GetSpec)))
+	e.Router.GET("/api/docs", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.openAPIController.GetDocs)))
+
+	// Live app events. Browsers can't send the auth header on a WebSocket, the connection sends
+	// the token as its first message instead
+	e.Router.GET("/api/ws", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.realtimeController.Connect)))
+
 	// Health check endpoint
 	e.Router.GET("/health", apis.WrapStdHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})))
 
+	// Liveness and readiness probes reporting the status of each component
+	e.Router.GET("/healthz", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.healthController.Liveness)))
+	e.Router.GET("/readyz", apis.WrapStdHandler(http.HandlerFunc(deps.controllers.healthController.Readiness)))
+
 	// Serve static files (must be after API routes)
 	setupStaticFileServer(e)
 }
 
+// scheduleTokenRefresh renews the spotify tokens about to expire every 15 minutes, so scheduled
+// syncs start with fresh tokens instead of refreshing them mid-burst
+func scheduleTokenRefresh(app *pocketbase.PocketBase, deps AppDependencies) {
+	app.Cron().MustAdd("refresh_spotify_tokens", "*/15 * * * *", func() {
+		_, err := deps.services.spotifyTokenManager.RefreshExpiringTokens(context.Background(), services.TOKEN_REFRESH_JOB_WINDOW)
+		if err != nil {
+			app.Logger().Error("spotify token refresh job failed", "error", err)
+		}
+	})
+}
+
+// scheduleBasePlaylistChangePoll checks the watched base playlists every 5 minutes and notifies
+// their webhooks of the tracks added or removed outside of the router
+func scheduleBasePlaylistChangePoll(app *pocketbase.PocketBase, deps AppDependencies) {
+	app.Cron().MustAdd("poll_base_playlist_changes", "*/5 * * * *", func() {
+		if pausedForMaintenance(app, deps, "poll_base_playlist_changes") {
+			return
+		}
+
+		_, err := deps.services.playlistWebhookService.PollBasePlaylistChanges(context.Background())
+		if err != nil {
+			app.Logger().Error("base playlist change poll failed", "error", err)
+		}
+	})
+}
+
+// scheduleDeletedPlaylistPurge permanently removes, once a day, the playlists deleted longer than
+// the retention period ago. Until then they can be restored
+func scheduleDeletedPlaylistPurge(app *pocketbase.PocketBase, deps AppDependencies) {
+	app.Cron().MustAdd("purge_deleted_playlists", "30 3 * * *", func() {
+		if pausedForMaintenance(app, deps, "purge_deleted_playlists") {
+			return
+		}
+
+		_, err := deps.services.basePlaylistService.PurgeDeletedPlaylists(context.Background(), services.DELETED_PLAYLIST_RETENTION)
+		if err != nil {
+			app.Logger().Error("deleted playlist purge failed", "error", err)
+		}
+	})
+}
+
+// scheduleSyncEventPurge deletes, once a day, the finished sync events older than the configured
+// retention. Nothing is deleted when the retention is 0
+func scheduleSyncEventPurge(app *pocketbase.PocketBase, deps AppDependencies) {
+	retention := deps.config.Sync.EventRetention()
+	if retention == 0 {
+		return
+	}
+
+	app.Cron().MustAdd("purge_expired_sync_events", "0 4 * * *", func() {
+		if pausedForMaintenance(app, deps, "purge_expired_sync_events") {
+			return
+		}
+
+		_, err := deps.services.syncEventService.PurgeExpiredSyncEvents(context.Background(), retention)
+		if err != nil {
+			app.Logger().Error("sync event purge failed", "error", err)
+		}
+	})
+}
+
+// pausedForMaintenance reports whether the scheduled job must be skipped because maintenance mode is
+// on. Jobs reading playlists from spotify or deleting data are skipped, the token refresh and heartbeat
+// keep running
+func pausedForMaintenance(app *pocketbase.PocketBase, deps AppDependencies, job string) bool {
+	if !deps.services.maintenanceService.Status(context.Background()).Enabled {
+		return false
+	}
+
+	app.Logger().Info("skipping scheduled job during maintenance", "job", job)
+	return true
+}
+
+// scheduleWorkerHeartbeat records a heartbeat every minute, the liveness probe fails once the
+// scheduler running the background jobs stops recording them
+func scheduleWorkerHeartbeat(app *pocketbase.PocketBase, deps AppDependencies) {
+	app.Cron().MustAdd("worker_heartbeat", "* * * * *", deps.services.healthService.RecordWorkerHeartbeat)
+}
+
+// watchConfigReload reloads the config on SIGHUP and, with a secrets manager, every
+// SECRETS_REFRESH_INTERVAL so rotated secrets are picked up
+func watchConfigReload(app *pocketbase.PocketBase, deps AppDependencies) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	var ticker *time.Ticker
+	var refresh <-chan time.Time
+	secretsManager := deps.config.SecretsManager
+	if secretsManager.FetchesSecrets() && secretsManager.RefreshInterval > 0 {
+		ticker = time.NewTicker(secretsManager.RefreshInterval)
+		refresh = ticker.C
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-signals:
+			case <-refresh:
+			case <-done:
+				return
+			}
+
+			reloadConfig(app, deps)
+		}
+	}()
+
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		signal.Stop(signals)
+		if ticker != nil {
+			ticker.Stop()
+		}
+		close(done)
+		return e.Next()
+	})
+}
+
+// reloadConfig applies the log level, rate limits and secrets of the reloaded config right away,
+// changes to other settings are logged as waiting for a restart. An invalid config is ignored
+func reloadConfig(app *pocketbase.PocketBase, deps AppDependencies) {
+	next, err := config.Load()
+	if err == nil {
+		err = next.Validate()
+	}
+	if err == nil && next.Auth.MasterKeys()[deps.config.Auth.EncryptionKeyVersion] != deps.config.Auth.EncryptionKey {
+		// The data keys still wrapped with it could no longer be unwrapped
+		err = fmt.Errorf("master key version %d must be kept in PREVIOUS_ENCRYPTION_KEYS until rotated", deps.config.Auth.EncryptionKeyVersion)
+	}
+	if err == nil {
+		err = deps.keyring.Update(next.Auth.EncryptionKeyVersion, next.Auth.MasterKeys())
+	}
+	if err != nil {
+		app.Logger().Error("config reload failed, keeping the current settings", "error", err)
+		return
+	}
+
+	// The spotify mock pointed the client at itself on start
+	if next.Auth.SpotifyMock && deps.config.Auth.SpotifyMock {
+		next.Auth.SpotifyAuthBaseURL = deps.config.Auth.SpotifyAuthBaseURL
+		next.Auth.SpotifyAPIBaseURL = deps.config.Auth.SpotifyAPIBaseURL
+	}
+
+	rotated := next.Auth.EncryptionKeyVersion != deps.config.Auth.EncryptionKeyVersion
+	applied, pending := deps.config.ApplyHotReload(next)
+	if level, err := deps.config.SlogLevel(); err == nil {
+		deps.logLevel.Set(level)
+	}
+	deps.middleware.rateLimit.Update(deps.config.RateLimit)
+	deps.spotifyClient.SetClientSecret(deps.config.Auth.SpotifyClientSecret)
+
+	if len(applied) > 0 || len(pending) > 0 {
+		app.Logger().Info("config reloaded", "applied", applied, "pending_restart", pending)
+	}
+
+	// New data keys are wrapped with the new master key, the existing ones are rewrapped with it
+	if rotated {
+		if _, err := deps.services.encryptionKeyService.RotateKeys(context.Background()); err != nil {
+			app.Logger().Error("encryption key rotation failed", "error", err)
+		}
+	}
+}
+
+func startInternalAPI(app *pocketbase.PocketBase, deps AppDependencies) error {
+	listener, err := net.Listen("tcp", ":"+deps.config.InternalAPI.Port)
+	if err != nil {
+		return err
+	}
+
+	server := internalapi.NewServer(
+		deps.orchestrators.syncOrchestrator,
+		deps.services.basePlaylistService,
+		deps.services.childPlaylistService,
+		deps.services.spotifyTokenManager,
+		deps.config.InternalAPI.Token,
+		app.Logger(),
+	)
+	grpcServer := server.NewGRPCServer()
+
+	go func() {
+		app.Logger().Info("internal api listening", "port", deps.config.InternalAPI.Port)
+		if err := grpcServer.Serve(listener); err != nil {
+			app.Logger().Error("internal api stopped", "error", err)
+		}
+	}()
+
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		grpcServer.GracefulStop()
+		return e.Next()
+	})
+
+	return nil
+}
+
+// startGRPCAPI serves the public gRPC API next to the HTTP one, on the same services
+func startGRPCAPI(app *pocketbase.PocketBase, deps AppDependencies) error {
+	listener, err := net.Listen("tcp", ":"+deps.config.GRPCAPI.Port)
+	if err != nil {
+		return err
+	}
+
+	server := grpcapi.NewServer(
+		deps.services.basePlaylistService,
+		deps.services.childPlaylistService,
+		deps.orchestrators.syncOrchestrator,
+		deps.services.realtimeHub,
+		deps.services.spotifyTokenManager,
+		deps.services.userService,
+		deps.services.apiKeyService,
+		deps.services.maintenanceService,
+		deps.middleware.rateLimit,
+		app.Logger(),
+	)
+	grpcServer := server.NewGRPCServer()
+
+	go func() {
+		app.Logger().Info("grpc api listening", "port", deps.config.GRPCAPI.Port)
+		if err := grpcServer.Serve(listener); err != nil {
+			app.Logger().Error("grpc api stopped", "error", err)
+		}
+	}()
+
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		grpcServer.GracefulStop()
+		return e.Next()
+	})
+
+	return nil
+}
+
+// startSpotifyMock serves a seeded in-memory fake of spotify and points the spotify client at it
+func startSpotifyMock(app *pocketbase.PocketBase, cfg *config.Config, deps *AppDependencies) error {
+	user := spotifyclient.SpotifyUserProfile{
+		ID:    "mockuser",
+		Email: "mockuser@example.com",
+		Name:  "Mock User",
+	}
+	server := spotifymock.NewServer(user, app.Logger())
+	server.Seed()
+
+	baseURL, err := server.Start(cfg.Auth.SpotifyMockAddr)
+	if err != nil {
+		return err
+	}
+
+	cfg.Auth.SpotifyAuthBaseURL = spotifymock.AuthBaseURL(baseURL)
+	cfg.Auth.SpotifyAPIBaseURL = spotifymock.APIBaseURL(baseURL)
+
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		_ = server.Shutdown(context.Background())
+		return e.Next()
+	})
+
+	app.OnServe().BindFunc(func(e *core.ServeEvent) error {
+		restored, err := restoreSpotifyMockPlaylists(context.Background(), server, user.ID, deps)
+		if err != nil {
+			app.Logger().Warn("failed to restore spotify mock playlists", "error", err.Error())
+		} else if restored > 0 {
+			app.Logger().Info("restored spotify mock playlists", "count", restored)
+		}
+		return e.Next()
+	})
+
+	return nil
+}
+
+// restoreSpotifyMockPlaylists adds the playlists of the mock user back to the mock, which forgets
+// them on restart, so the seeded and previously created playlists can still be synced
+func restoreSpotifyMockPlaylists(ctx context.Context, server *spotifymock.Server, spotifyID string, deps *AppDependencies) (int, error) {
+	integration, err := deps.services.spotifyIntegrationService.GetIntegrationBySpotifyID(ctx, spotifyID)
+	if errors.Is(err, repositories.ErrSpotifyIntegrationNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	basePlaylists, err := deps.services.basePlaylistService.GetBasePlaylistsByUserIDWithChilds(ctx, integration.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, basePlaylist := range basePlaylists {
+		if server.RestorePlaylist(basePlaylist.SpotifyPlaylistID, basePlaylist.Name) {
+			restored++
+		}
+		for _, childPlaylist := range basePlaylist.Childs {
+			if server.RestorePlaylist(childPlaylist.SpotifyPlaylistID, models.BuildChildPlaylistName(basePlaylist.Name, childPlaylist.Name)) {
+				restored++
+			}
+		}
+	}
+
+	return restored, nil
+}
+
+// openPostgres connects to the Postgres database of the repositories and brings its schema up to
+// date, closing the pool when the app terminates
+func openPostgres(app *pocketbase.PocketBase, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	ctx := context.Background()
+
+	pool, err := postgres.Open(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postgres.Migrate(ctx, pool, app.Logger()); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		pool.Close()
+		return e.Next()
+	})
+
+	return pool, nil
+}
+
+// openPostgresReadPool connects the read pool of the list queries, none without DB_POSTGRES_READ_URL
+func openPostgresReadPool(app *pocketbase.PocketBase, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	readPool, err := postgres.OpenRead(context.Background(), cfg)
+	if err != nil || readPool == nil {
+		return nil, err
+	}
+
+	app.OnTerminate().BindFunc(func(e *core.TerminateEvent) error {
+		readPool.Close()
+		return e.Next()
+	})
+
+	return readPool, nil
+}
+
 func setupStaticFileServer(e *core.ServeEvent) {
 	fsys, err := static.GetFrontendFS()
 	if err != nil {
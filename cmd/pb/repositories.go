@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/repositories/pb"
+	"github.com/ngomez18/playlist-router/internal/repositories/postgres"
+	"github.com/ngomez18/playlist-router/internal/security"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+)
+
+// newPocketbaseRepositories stores the app data in the PocketBase collections. The encryption key
+// service is built along, since the spotify integrations encrypt their tokens with it
+func newPocketbaseRepositories(app *pocketbase.PocketBase, readDB dbx.Builder, keyring *security.Keyring, logger *slog.Logger) (Repositories, *services.EncryptionKeyService) {
+	userEncryptionKeyRepository := pb.NewUserEncryptionKeyRepositoryPocketbase(app)
+	keyRotationRepository := pb.NewEncryptionKeyRotationRepositoryPocketbase(app)
+	encryptionKeyService := services.NewEncryptionKeyService(userEncryptionKeyRepository, keyRotationRepository, keyring, logger)
+
+	return Repositories{
+		basePlaylistRepository:            pb.NewBasePlaylistRepositoryPocketbase(app).WithReadDB(readDB),
+		childPlaylistRepository:           pb.NewChildPlaylistRepositoryPocketbase(app),
+		userRepository:                    pb.NewUserRepositoryPocketbase(app),
+		spotifyIntegrationRepository:      pb.NewSpotifyIntegrationRepositoryPocketbase(app).WithTokenCipher(encryptionKeyService),
+		youTubeMusicIntegrationRepository: pb.NewYouTubeMusicIntegrationRepositoryPocketbase(app).WithTokenCipher(encryptionKeyService),
+		tidalIntegrationRepository:        pb.NewTidalIntegrationRepositoryPocketbase(app).WithTokenCipher(encryptionKeyService),
+		lastFmIntegrationRepository:       pb.NewLastFmIntegrationRepositoryPocketbase(app).WithTokenCipher(encryptionKeyService),
+		syncEventRepository:               pb.NewSyncEventRepositoryPocketbase(app).WithReadDB(readDB),
+		playlistSnapshotRepository:        pb.NewPlaylistSnapshotRepositoryPocketbase(app),
+		playlistMembershipRepository:      pb.NewPlaylistMembershipRepositoryPocketbase(app),
+		userEncryptionKeyRepository:       userEncryptionKeyRepository,
+		keyRotationRepository:             keyRotationRepository,
+		filterRuleChangeRepository:        pb.NewFilterRuleChangeRepositoryPocketbase(app),
+		apiKeyRepository:                  pb.NewAPIKeyRepositoryPocketbase(app),
+		diagnosticsRepository:             pb.NewDiagnosticsRepositoryPocketbase(app),
+		playlistWebhookRepository:         pb.NewPlaylistWebhookRepositoryPocketbase(app),
+		basePlaylistWatchRepository:       pb.NewBasePlaylistWatchRepositoryPocketbase(app),
+		templateRepository:                pb.NewChildPlaylistTemplateRepositoryPocketbase(app),
+		blocklistRepository:               pb.NewBlocklistEntryRepositoryPocketbase(app),
+		routingReportRepository:           pb.NewRoutingReportRepositoryPocketbase(app),
+		routingCacheRepository:            pb.NewRoutingCacheRepositoryPocketbase(app),
+		notificationPreferencesRepository: pb.NewNotificationPreferencesRepositoryPocketbase(app),
+		featureFlagRepository:             pb.NewFeatureFlagRepositoryPocketbase(app),
+		transactor:                        pb.NewTransactorPocketbase(app),
+	}, encryptionKeyService
+}
+
+// newPostgresRepositories stores the app data in Postgres. PocketBase still keeps the logs and
+// superusers, so the diagnostics read the logs from it. The list queries go through readPool when set
+func newPostgresRepositories(app *pocketbase.PocketBase, pool, readPool *pgxpool.Pool, cfg config.DatabaseConfig, keyring *security.Keyring, logger *slog.Logger) (Repositories, *services.EncryptionKeyService) {
+	userEncryptionKeyRepository := postgres.NewUserEncryptionKeyRepositoryPostgres(pool, logger)
+	keyRotationRepository := postgres.NewEncryptionKeyRotationRepositoryPostgres(pool, logger)
+	encryptionKeyService := services.NewEncryptionKeyService(userEncryptionKeyRepository, keyRotationRepository, keyring, logger)
+
+	return Repositories{
+		basePlaylistRepository:            postgres.NewBasePlaylistRepositoryPostgres(pool, logger).WithReadPool(readPool),
+		childPlaylistRepository:           postgres.NewChildPlaylistRepositoryPostgres(pool, logger),
+		userRepository:                    postgres.NewUserRepositoryPostgres(pool, cfg.PostgresAuthSecret, logger),
+		spotifyIntegrationRepository:      postgres.NewSpotifyIntegrationRepositoryPostgres(pool, logger).WithTokenCipher(encryptionKeyService),
+		youTubeMusicIntegrationRepository: postgres.NewYouTubeMusicIntegrationRepositoryPostgres(pool, logger).WithTokenCipher(encryptionKeyService),
+		tidalIntegrationRepository:        postgres.NewTidalIntegrationRepositoryPostgres(pool, logger).WithTokenCipher(encryptionKeyService),
+		lastFmIntegrationRepository:       postgres.NewLastFmIntegrationRepositoryPostgres(pool, logger).WithTokenCipher(encryptionKeyService),
+		syncEventRepository:               postgres.NewSyncEventRepositoryPostgres(pool, logger).WithReadPool(readPool),
+		playlistSnapshotRepository:        postgres.NewPlaylistSnapshotRepositoryPostgres(pool, logger),
+		playlistMembershipRepository:      postgres.NewPlaylistMembershipRepositoryPostgres(pool, logger),
+		userEncryptionKeyRepository:       userEncryptionKeyRepository,
+		keyRotationRepository:             keyRotationRepository,
+		filterRuleChangeRepository:        postgres.NewFilterRuleChangeRepositoryPostgres(pool, logger),
+		apiKeyRepository:                  postgres.NewAPIKeyRepositoryPostgres(pool, logger),
+		diagnosticsRepository:             postgres.NewDiagnosticsRepositoryPostgres(pool, logger).WithLogReader(pb.NewDiagnosticsRepositoryPocketbase(app)),
+		playlistWebhookRepository:         postgres.NewPlaylistWebhookRepositoryPostgres(pool, logger),
+		basePlaylistWatchRepository:       postgres.NewBasePlaylistWatchRepositoryPostgres(pool, logger),
+		templateRepository:                postgres.NewChildPlaylistTemplateRepositoryPostgres(pool, logger),
+		blocklistRepository:               postgres.NewBlocklistEntryRepositoryPostgres(pool, logger),
+		routingReportRepository:           postgres.NewRoutingReportRepositoryPostgres(pool, logger),
+		routingCacheRepository:            postgres.NewRoutingCacheRepositoryPostgres(pool, logger),
+		notificationPreferencesRepository: postgres.NewNotificationPreferencesRepositoryPostgres(pool, logger),
+		featureFlagRepository:             postgres.NewFeatureFlagRepositoryPostgres(pool, logger),
+		transactor:                        postgres.NewTransactorPostgres(pool),
+	}, encryptionKeyService
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+)
+
+// MAX_PAGE_SIZE is the largest page the list endpoints serve
+const MAX_PAGE_SIZE = 100
+
+// REQUEST_TIMEOUT bounds a single request. Syncs run within the request, so it is generous
+const REQUEST_TIMEOUT = 10 * time.Minute
+
+// apiError is a problem the API responded with
+type apiError struct {
+	Status int
+	Code   problem.Code
+	Detail string
+}
+
+func (e *apiError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%s (%d)", e.Detail, e.Status)
+	}
+	if e.Detail == "" {
+		return fmt.Sprintf("%s (%d)", e.Code, e.Status)
+	}
+
+	return fmt.Sprintf("%s: %s (%d)", e.Code, e.Detail, e.Status)
+}
+
+// apiClient calls the HTTP API with an API key bearer token
+type apiClient struct {
+	server     string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newAPIClient(config *cliConfig) *apiClient {
+	return &apiClient{
+		server:     config.Server,
+		apiKey:     config.APIKey,
+		httpClient: &http.Client{Timeout: REQUEST_TIMEOUT},
+	}
+}
+
+// request sends the request and returns its response, which the caller must close. Failures are
+// returned as *apiError
+func (c *apiClient) request(ctx context.Context, method, path string, query url.Values) (*http.Response, error) {
+	if c.apiKey == "" {
+		return nil, errNotLoggedIn
+	}
+
+	endpoint := c.server + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	// Held back syncs respond 409 with the sync event rather than a problem
+	if resp.StatusCode >= http.StatusBadRequest && (isProblem(resp) || resp.StatusCode != http.StatusConflict) {
+		defer resp.Body.Close()
+		return nil, readProblem(resp)
+	}
+
+	return resp, nil
+}
+
+// getJSON decodes the response of the call into out
+func (c *apiClient) getJSON(ctx context.Context, method, path string, query url.Values, out any) error {
+	resp, err := c.request(ctx, method, path, query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// ListBasePlaylists returns every base playlist of the user with its child playlists, reading all
+// the pages
+func (c *apiClient) ListBasePlaylists(ctx context.Context) ([]*models.BasePlaylistWithChilds, error) {
+	var basePlaylists []*models.BasePlaylistWithChilds
+	for {
+		query := url.Values{
+			"sort":   {string(models.ListSortNameAsc)},
+			"limit":  {strconv.Itoa(MAX_PAGE_SIZE)},
+			"offset": {strconv.Itoa(len(basePlaylists))},
+		}
+
+		var page models.BasePlaylistPage
+		if err := c.getJSON(ctx, http.MethodGet, "/api/base_playlist", query, &page); err != nil {
+			return nil, err
+		}
+
+		basePlaylists = append(basePlaylists, page.Items...)
+		if len(page.Items) == 0 || len(basePlaylists) >= page.Total {
+			return basePlaylists, nil
+		}
+	}
+}
+
+// SyncBasePlaylist syncs the base playlist, returning once the sync finished. A sync held back for
+// confirmation is returned with its anomalies rather than as an error
+func (c *apiClient) SyncBasePlaylist(ctx context.Context, basePlaylistID string) (*models.SyncEvent, error) {
+	resp, err := c.request(ctx, http.MethodPost, "/api/base_playlist/"+url.PathEscape(basePlaylistID)+"/sync", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var syncEvent models.SyncEvent
+	if err := json.NewDecoder(resp.Body).Decode(&syncEvent); err != nil {
+		return nil, err
+	}
+
+	return &syncEvent, nil
+}
+
+// LatestSyncEvent returns the last sync started on the base playlist, nil when it was never synced
+func (c *apiClient) LatestSyncEvent(ctx context.Context, basePlaylistID string) (*models.SyncEvent, error) {
+	query := url.Values{"sort": {"-created"}, "limit": {"1"}}
+
+	var page models.SyncEventPage
+	if err := c.getJSON(ctx, http.MethodGet, "/api/base_playlist/"+url.PathEscape(basePlaylistID)+"/sync_events", query, &page); err != nil {
+		return nil, err
+	}
+	if len(page.Items) == 0 {
+		return nil, nil
+	}
+
+	return page.Items[0], nil
+}
+
+// ExportRoutingConfig writes the routing config of the base playlist to w, as json or yaml
+func (c *apiClient) ExportRoutingConfig(ctx context.Context, basePlaylistID, format string, w io.Writer) error {
+	resp, err := c.request(ctx, http.MethodGet, "/api/base_playlist/"+url.PathEscape(basePlaylistID)+"/config/export", url.Values{"format": {format}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func isProblem(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mediaType == problem.CONTENT_TYPE
+}
+
+// readProblem reads the problem of a failed call. Responses that aren't problems, e.g. from a proxy in
+// front of the server, are described by their status
+func readProblem(resp *http.Response) error {
+	var body problem.Problem
+	if !isProblem(resp) || json.NewDecoder(resp.Body).Decode(&body) != nil {
+		return &apiError{Status: resp.StatusCode, Detail: http.StatusText(resp.StatusCode)}
+	}
+
+	return &apiError{Status: body.Status, Code: body.Code, Detail: body.Detail}
+}
+
+// isAPIError reports whether err is a problem with the given code
+func isAPIError(err error, code problem.Code) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
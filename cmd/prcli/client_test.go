@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *apiClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer prk_test" {
+			problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidAPIKey, "invalid or expired api key")
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return newAPIClient(&cliConfig{Server: server.URL, APIKey: "prk_test"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestAPIClient_ListBasePlaylists(t *testing.T) {
+	assert := require.New(t)
+
+	var offsets []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/api/base_playlist", r.URL.Path)
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		items := make([]*models.BasePlaylistWithChilds, 0, MAX_PAGE_SIZE)
+		for i := offset; i < min(offset+MAX_PAGE_SIZE, 150); i++ {
+			items = append(items, &models.BasePlaylistWithChilds{BasePlaylist: &models.BasePlaylist{ID: "base" + strconv.Itoa(i)}})
+		}
+		writeJSON(w, http.StatusOK, models.BasePlaylistPage{Items: items, PageInfo: models.PageInfo{Total: 150, Limit: MAX_PAGE_SIZE, Offset: offset}})
+	})
+
+	basePlaylists, err := client.ListBasePlaylists(context.Background())
+	assert.NoError(err)
+	assert.Len(basePlaylists, 150)
+	assert.Equal("base149", basePlaylists[149].ID)
+	assert.Equal([]string{"0", "100"}, offsets)
+}
+
+func TestAPIClient_Problems(t *testing.T) {
+	assert := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, http.StatusForbidden, problem.CodeInsufficientScope, "api key is missing the sync:read scope")
+	})
+
+	_, err := client.ListBasePlaylists(context.Background())
+	assert.EqualError(err, "insufficient_scope: api key is missing the sync:read scope (403)")
+	assert.True(isAPIError(err, problem.CodeInsufficientScope))
+
+	client.apiKey = "prk_other"
+	_, err = client.LatestSyncEvent(context.Background(), "base123")
+	assert.True(isAPIError(err, problem.CodeInvalidAPIKey))
+
+	client.apiKey = ""
+	_, err = client.LatestSyncEvent(context.Background(), "base123")
+	assert.ErrorIs(err, errNotLoggedIn)
+}
+
+func TestAPIClient_SyncBasePlaylist(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		expectedStatus models.SyncStatus
+		expectedCode   problem.Code
+	}{
+		{name: "completed", status: http.StatusOK, expectedStatus: models.SyncStatusCompleted},
+		{name: "held back", status: http.StatusConflict, expectedStatus: models.SyncStatusNeedsConfirmation},
+		{name: "already syncing", status: http.StatusConflict, expectedCode: problem.CodeSyncInProgress},
+		{name: "not a problem", status: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(http.MethodPost, r.Method)
+				assert.Equal("/api/base_playlist/base123/sync", r.URL.Path)
+
+				switch {
+				case tt.expectedStatus != "":
+					writeJSON(w, tt.status, models.SyncEvent{ID: "sync123", Status: tt.expectedStatus})
+				case tt.expectedCode != "":
+					problem.Write(w, tt.status, tt.expectedCode, "sync already in progress")
+				default:
+					http.Error(w, "bad gateway", tt.status)
+				}
+			})
+
+			syncEvent, err := client.SyncBasePlaylist(context.Background(), "base123")
+			if tt.expectedStatus != "" {
+				assert.NoError(err)
+				assert.Equal(tt.expectedStatus, syncEvent.Status)
+				return
+			}
+
+			var apiErr *apiError
+			assert.ErrorAs(err, &apiErr)
+			assert.Equal(tt.status, apiErr.Status)
+			assert.Equal(tt.expectedCode, apiErr.Code)
+		})
+	}
+}
+
+func TestAPIClient_ExportRoutingConfig(t *testing.T) {
+	assert := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/api/base_playlist/base123/config/export", r.URL.Path)
+		assert.Equal("yaml", r.URL.Query().Get("format"))
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte("version: 1\n"))
+	})
+
+	var out bytes.Buffer
+	assert.NoError(client.ExportRoutingConfig(context.Background(), "base123", "yaml", &out))
+	assert.Equal("version: 1\n", out.String())
+}
+
+func TestPrintSyncResult(t *testing.T) {
+	assert := require.New(t)
+
+	durationMs := int64(1500)
+	errorMessage := "spotify down"
+	var out bytes.Buffer
+
+	err := printSyncResult(&out, &models.SyncEvent{
+		ID: "sync123", Status: models.SyncStatusCompleted, DurationMs: &durationMs, TracksProcessed: 10, TracksUnmatched: 2,
+		ChildSyncResults: []models.ChildSyncResult{{ChildPlaylistID: "child1", Status: models.SyncStatusCompleted, TracksAdded: 3, TracksRemoved: 1}},
+	})
+	assert.NoError(err)
+	assert.Equal("sync sync123 completed in 1.5s: 10 tracks processed, 2 unmatched\n  child1 completed +3 -1\n", out.String())
+
+	out.Reset()
+	err = printSyncResult(&out, &models.SyncEvent{ID: "sync123", Status: models.SyncStatusFailed, ErrorMessage: &errorMessage})
+	assert.EqualError(err, "sync sync123 failed: spotify down")
+
+	out.Reset()
+	err = printSyncResult(&out, &models.SyncEvent{
+		ID: "sync123", Status: models.SyncStatusNeedsConfirmation,
+		Anomalies: []models.SyncAnomaly{{Message: `child playlist "Chill" would drop from 500 to 3 tracks`}},
+	})
+	assert.EqualError(err, "sync sync123 needs to be confirmed in the app")
+	assert.Contains(out.String(), `"Chill" would drop`)
+}
+
+func TestSyncProgress_Tail(t *testing.T) {
+	assert := require.New(t)
+
+	phases := []models.SyncPhase{models.SyncPhaseFetchingTracks, models.SyncPhaseFetchingTracks, models.SyncPhaseRoutingTracks}
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/api/base_playlist/base123/sync_events", r.URL.Path)
+
+		syncEvent := &models.SyncEvent{ID: "sync123", Status: models.SyncStatusCompleted}
+		if polls < len(phases) {
+			syncEvent = &models.SyncEvent{ID: "sync123", Status: models.SyncStatusInProgress, Phase: phases[polls]}
+		}
+		polls++
+		writeJSON(w, http.StatusOK, models.SyncEventPage{Items: []*models.SyncEvent{syncEvent}})
+	})
+
+	var out bytes.Buffer
+	progress := &syncProgress{client: client, out: &out, basePlaylistID: "base123", interval: time.Millisecond}
+	syncEvent, err := progress.tail(context.Background())
+	assert.NoError(err)
+	assert.Equal(models.SyncStatusCompleted, syncEvent.Status)
+	assert.Equal(4, polls)
+	assert.Equal(2, strings.Count(out.String(), "\n"))
+	assert.Contains(out.String(), "sync sync123 routing_tracks")
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/spf13/cobra"
+)
+
+// newLoginCommand saves the server and API key the other commands use, once the key is accepted
+func newLoginCommand() *cobra.Command {
+	var server, apiKey string
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Saves the server and API key to use, the key is read from stdin when not given",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if apiKey == "" {
+				fmt.Fprint(cmd.ErrOrStderr(), "API key: ")
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && line == "" {
+					return fmt.Errorf("unable to read api key: %w", err)
+				}
+				apiKey = strings.TrimSpace(line)
+			}
+			if apiKey == "" {
+				return fmt.Errorf("an api key is required")
+			}
+
+			config := &cliConfig{Server: strings.TrimRight(server, "/"), APIKey: apiKey}
+
+			// Keys without the sync:read scope can't list playlists, but they are still valid
+			var page struct{}
+			err := newAPIClient(config).getJSON(cmd.Context(), http.MethodGet, "/api/base_playlist", url.Values{"limit": {"1"}}, &page)
+			if err != nil && !isAPIError(err, problem.CodeInsufficientScope) {
+				return fmt.Errorf("unable to log in to %s: %w", config.Server, err)
+			}
+
+			path, err := saveConfig(config)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "logged in to %s, saved to %s\n", config.Server, path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&server, "server", DEFAULT_SERVER, "URL of the playlist router server")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key created in the app, prk_...")
+
+	return cmd
+}
+
+func newLogoutCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Forgets the saved server and API key",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return removeConfig()
+		},
+	}
+}
+
+// newPlaylistsCommand lists the base playlists of the user along with their child playlists
+func newPlaylistsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "playlists",
+		Aliases: []string{"ls"},
+		Short:   "Lists the base playlists and their child playlists (sync:read)",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := loggedInClient()
+			if err != nil {
+				return err
+			}
+
+			basePlaylists, err := client.ListBasePlaylists(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tPROVIDER\tSTATUS\tCHILDS")
+			for _, basePlaylist := range basePlaylists {
+				status := "active"
+				switch {
+				case basePlaylist.Archived:
+					status = "archived"
+				case basePlaylist.Suspended:
+					status = "suspended"
+				case !basePlaylist.IsActive:
+					status = "inactive"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", basePlaylist.ID, basePlaylist.Name, basePlaylist.Provider, status, len(basePlaylist.Childs))
+
+				for _, childPlaylist := range basePlaylist.Childs {
+					fmt.Fprintf(w, "  %s\t  %s\t\t\t\n", childPlaylist.ID, childPlaylist.Name)
+				}
+			}
+
+			return w.Flush()
+		},
+	}
+}
+
+// newSyncCommand syncs a base playlist, printing its progress while it runs
+func newSyncCommand() *cobra.Command {
+	var follow bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "sync <base-playlist-id>",
+		Short: "Syncs a base playlist and waits for it to finish (sync:write, sync:read to follow)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := loggedInClient()
+			if err != nil {
+				return err
+			}
+
+			if follow {
+				progress := &syncProgress{client: client, out: cmd.ErrOrStderr(), basePlaylistID: args[0], interval: interval}
+				followCtx, stopFollowing := context.WithCancel(cmd.Context())
+				defer stopFollowing()
+				go progress.follow(followCtx)
+			}
+
+			syncEvent, err := client.SyncBasePlaylist(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return printSyncResult(cmd.OutOrStdout(), syncEvent)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "print the phases of the sync while it runs")
+	cmd.Flags().DurationVar(&interval, "interval", DEFAULT_POLL_INTERVAL, "how often the progress is polled")
+
+	return cmd
+}
+
+// newTailCommand follows a sync started elsewhere, e.g. by the change poller or another job
+func newTailCommand() *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "tail <base-playlist-id>",
+		Short: "Prints the progress of the latest sync of a base playlist until it finishes (sync:read)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := loggedInClient()
+			if err != nil {
+				return err
+			}
+
+			progress := &syncProgress{client: client, out: cmd.ErrOrStderr(), basePlaylistID: args[0], interval: interval}
+			syncEvent, err := progress.tail(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if syncEvent == nil {
+				fmt.Fprintln(cmd.OutOrStdout(), "the base playlist was never synced")
+				return nil
+			}
+
+			return printSyncResult(cmd.OutOrStdout(), syncEvent)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", DEFAULT_POLL_INTERVAL, "how often the progress is polled")
+
+	return cmd
+}
+
+// newExportCommand downloads the routing config of a base playlist, to back it up or import it
+// elsewhere
+func newExportCommand() *cobra.Command {
+	var format, output string
+
+	cmd := &cobra.Command{
+		Use:   "export <base-playlist-id>",
+		Short: "Exports the routing config of a base playlist as json or yaml (sync:read)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "json" && format != "yaml" {
+				return fmt.Errorf("format must be json or yaml")
+			}
+
+			client, err := loggedInClient()
+			if err != nil {
+				return err
+			}
+
+			if output == "" || output == "-" {
+				return client.ExportRoutingConfig(cmd.Context(), args[0], format, cmd.OutOrStdout())
+			}
+
+			file, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			if err := client.ExportRoutingConfig(cmd.Context(), args[0], format, file); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "routing config written to %s\n", output)
+			return file.Close()
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "yaml", "json or yaml")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file the config is written to, stdout by default")
+
+	return cmd
+}
+
+// loggedInClient returns a client for the saved login
+func loggedInClient() (*apiClient, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config.APIKey == "" {
+		return nil, errNotLoggedIn
+	}
+
+	return newAPIClient(config), nil
+}
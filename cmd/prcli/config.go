@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SERVER_ENV and API_KEY_ENV override the saved login, so cron jobs don't need a config file
+	SERVER_ENV  = "PRCLI_SERVER"
+	API_KEY_ENV = "PRCLI_API_KEY"
+
+	DEFAULT_SERVER = "http://localhost:8090"
+)
+
+var errNotLoggedIn = errors.New("not logged in, run prcli login or set " + API_KEY_ENV)
+
+// cliConfig is the login saved by prcli login
+type cliConfig struct {
+	Server string `json:"server"`
+	APIKey string `json:"api_key"`
+}
+
+// configPath is where the login is saved, under the config directory of the user
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate config directory: %w", err)
+	}
+
+	return filepath.Join(dir, "prcli", "config.json"), nil
+}
+
+// loadConfig reads the saved login, then applies the environment overrides. A missing config file
+// is an empty login
+func loadConfig() (*cliConfig, error) {
+	config := &cliConfig{}
+
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to read config: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", path, err)
+		}
+	}
+
+	if server := os.Getenv(SERVER_ENV); server != "" {
+		config.Server = server
+	}
+	if apiKey := os.Getenv(API_KEY_ENV); apiKey != "" {
+		config.APIKey = apiKey
+	}
+	if config.Server == "" {
+		config.Server = DEFAULT_SERVER
+	}
+	config.Server = strings.TrimRight(config.Server, "/")
+
+	return config, nil
+}
+
+// saveConfig writes the login, readable by the user only since it holds the api key
+func saveConfig(config *cliConfig) (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("unable to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("unable to write config: %w", err)
+	}
+
+	return path, nil
+}
+
+// removeConfig forgets the saved login
+func removeConfig() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+// Command prcli manages playlist router from the terminal through its HTTP API, authenticated with
+// an API key, so scripts and cron jobs can drive it without the web UI
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:          "prcli",
+		Short:        "Command-line client of the playlist router API",
+		SilenceUsage: true,
+	}
+
+	root.AddCommand(
+		newLoginCommand(),
+		newLogoutCommand(),
+		newPlaylistsCommand(),
+		newSyncCommand(),
+		newTailCommand(),
+		newExportCommand(),
+	)
+
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// DEFAULT_POLL_INTERVAL is how often the progress of a sync is polled
+const DEFAULT_POLL_INTERVAL = 2 * time.Second
+
+// syncProgress prints the in progress sync of a base playlist each time it moves to another phase
+type syncProgress struct {
+	client         *apiClient
+	out            io.Writer
+	basePlaylistID string
+	interval       time.Duration
+	last           string
+}
+
+// poll prints the latest sync event of the base playlist when it changed since the last poll, and
+// returns it
+func (p *syncProgress) poll(ctx context.Context) (*models.SyncEvent, error) {
+	syncEvent, err := p.client.LatestSyncEvent(ctx, p.basePlaylistID)
+	if err != nil || syncEvent == nil {
+		return syncEvent, err
+	}
+
+	state := syncEvent.ID + string(syncEvent.Status) + string(syncEvent.Phase)
+	if syncEvent.Status == models.SyncStatusInProgress && state != p.last {
+		p.last = state
+		phase := syncEvent.Phase
+		if phase == "" {
+			phase = "starting"
+		}
+		fmt.Fprintf(p.out, "%s sync %s %s\n", time.Now().Format(time.TimeOnly), syncEvent.ID, phase)
+	}
+
+	return syncEvent, nil
+}
+
+// follow prints the progress of the running sync until ctx is done. Failing polls are skipped, the
+// sync itself reports whether it failed
+func (p *syncProgress) follow(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tail prints the progress of the latest sync until it finishes, and returns it. It returns right
+// away when no sync is running
+func (p *syncProgress) tail(ctx context.Context) (*models.SyncEvent, error) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		syncEvent, err := p.poll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if syncEvent == nil || syncEvent.Status != models.SyncStatusInProgress {
+			return syncEvent, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// printSyncResult describes a finished sync, returning an error for the syncs that didn't complete
+// so scripts can tell from the exit code
+func printSyncResult(out io.Writer, syncEvent *models.SyncEvent) error {
+	switch syncEvent.Status {
+	case models.SyncStatusCompleted, models.SyncStatusConfirmed:
+		took := ""
+		if syncEvent.DurationMs != nil {
+			took = " in " + (time.Duration(*syncEvent.DurationMs) * time.Millisecond).String()
+		}
+		fmt.Fprintf(out, "sync %s completed%s: %d tracks processed, %d unmatched\n",
+			syncEvent.ID, took, syncEvent.TracksProcessed, syncEvent.TracksUnmatched)
+		printChildResults(out, syncEvent.ChildSyncResults)
+		return nil
+
+	case models.SyncStatusNeedsConfirmation:
+		fmt.Fprintf(out, "sync %s held back for confirmation:\n", syncEvent.ID)
+		for _, anomaly := range syncEvent.Anomalies {
+			fmt.Fprintf(out, "  %s\n", anomaly.Message)
+		}
+		return fmt.Errorf("sync %s needs to be confirmed in the app", syncEvent.ID)
+
+	case models.SyncStatusFailed:
+		printChildResults(out, syncEvent.ChildSyncResults)
+		message := "unknown error"
+		if syncEvent.ErrorMessage != nil {
+			message = *syncEvent.ErrorMessage
+		}
+		return fmt.Errorf("sync %s failed: %s", syncEvent.ID, message)
+
+	default:
+		return fmt.Errorf("sync %s is %s", syncEvent.ID, syncEvent.Status)
+	}
+}
+
+func printChildResults(out io.Writer, results []models.ChildSyncResult) {
+	for _, result := range results {
+		line := fmt.Sprintf("  %s %s +%d -%d", result.ChildPlaylistID, result.Status, result.TracksAdded, result.TracksRemoved)
+		if result.ErrorMessage != nil {
+			line += ": " + *result.ErrorMessage
+		}
+		fmt.Fprintln(out, line)
+	}
+}
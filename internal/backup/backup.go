@@ -0,0 +1,325 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/security"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/archive"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/osutils"
+	pbsecurity "github.com/pocketbase/pocketbase/tools/security"
+)
+
+// Backups are named playlist-router-<UTC timestamp>.zip.enc
+const (
+	BACKUP_FILE_PREFIX    = "playlist-router-"
+	BACKUP_FILE_EXTENSION = ".zip.enc"
+)
+
+var (
+	ErrMissingEncryptionKey = errors.New("BACKUP_ENCRYPTION_KEY is required to create and restore backups")
+	ErrBackupNotFound       = errors.New("backup not found")
+	ErrInvalidBackup        = errors.New("backup doesn't contain a PocketBase data directory")
+)
+
+// dataDirExclude are the entries of the data directory left out of backups and kept on restore:
+// the PocketBase backups, its temp files and the TLS certificates cache
+var dataDirExclude = []string{core.LocalBackupsDirName, core.LocalTempDirName, core.LocalAutocertCacheDirName}
+
+// Manager snapshots the PocketBase data directory into encrypted archives, written to BACKUP_DIR
+// and optionally uploaded to a bucket, and restores them
+type Manager struct {
+	app    core.App
+	config config.BackupConfig
+	logger *slog.Logger
+}
+
+func NewManager(app core.App, cfg config.BackupConfig, logger *slog.Logger) *Manager {
+	return &Manager{
+		app:    app,
+		config: cfg,
+		logger: logger.With("component", "BackupManager"),
+	}
+}
+
+// Create archives the data directory, encrypts it into BACKUP_DIR and uploads it when a bucket is
+// configured, returning the name of the backup. Writes are blocked while the archive is created, so
+// the databases are copied in a consistent state
+func (m *Manager) Create(ctx context.Context) (string, error) {
+	encryptor, err := m.encryptor()
+	if err != nil {
+		return "", err
+	}
+
+	tempDir, err := m.tempDir()
+	if err != nil {
+		return "", err
+	}
+
+	archivePath := filepath.Join(tempDir, "backup_"+pbsecurity.PseudorandomString(8)+".zip")
+	defer os.Remove(archivePath)
+
+	// Transactions run on the single writer connections, so nothing is written while archiving
+	err = m.app.RunInTransaction(func(txApp core.App) error {
+		return txApp.AuxRunInTransaction(func(txApp core.App) error {
+			// Moves the WAL into the database files, failing to is harmless since the WAL is archived too
+			_, _ = txApp.DB().NewQuery("PRAGMA wal_checkpoint(TRUNCATE)").Execute()
+			_, _ = txApp.AuxDB().NewQuery("PRAGMA wal_checkpoint(TRUNCATE)").Execute()
+
+			return archive.Create(txApp.DataDir(), archivePath, m.exclude()...)
+		})
+	})
+	if err != nil {
+		m.logger.ErrorContext(ctx, "failed to archive data directory", "error", err.Error())
+		return "", fmt.Errorf("failed to archive data directory: %w", err)
+	}
+
+	if err := os.MkdirAll(m.config.Dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	name := BACKUP_FILE_PREFIX + time.Now().UTC().Format("20060102-150405") + BACKUP_FILE_EXTENSION
+	backupPath := filepath.Join(m.config.Dir, name)
+	if err := encryptFile(encryptor, archivePath, backupPath); err != nil {
+		os.Remove(backupPath)
+		m.logger.ErrorContext(ctx, "failed to encrypt backup", "error", err.Error())
+		return "", fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+
+	if m.config.UploadsBackups() {
+		if err := m.upload(ctx, backupPath, name); err != nil {
+			m.logger.ErrorContext(ctx, "failed to upload backup", "name", name, "storage", m.config.Storage, "error", err.Error())
+			return name, fmt.Errorf("backup written to %s but failed to upload: %w", backupPath, err)
+		}
+	}
+
+	m.logger.InfoContext(ctx, "backup created", "name", name, "storage", m.config.Storage)
+	return name, nil
+}
+
+// Restore replaces the data directory with the backup, read from BACKUP_DIR or downloaded from the
+// bucket when it isn't there. The app must not be serving while it runs, its databases are closed
+// before the data directory is swapped. The replaced data is removed on the next start
+func (m *Manager) Restore(ctx context.Context, name string) error {
+	encryptor, err := m.encryptor()
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := m.tempDir()
+	if err != nil {
+		return err
+	}
+
+	backupPath, cleanup, err := m.find(ctx, name, tempDir)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	archivePath := filepath.Join(tempDir, "restore_"+pbsecurity.PseudorandomString(8)+".zip")
+	defer os.Remove(archivePath)
+
+	if err := decryptFile(encryptor, backupPath, archivePath); err != nil {
+		m.logger.ErrorContext(ctx, "failed to decrypt backup", "name", name, "error", err.Error())
+		return fmt.Errorf("failed to decrypt backup: %w", err)
+	}
+
+	extractedDir := filepath.Join(tempDir, "restore_"+pbsecurity.PseudorandomString(8))
+	defer os.RemoveAll(extractedDir)
+
+	if err := archive.Extract(archivePath, extractedDir); err != nil {
+		return fmt.Errorf("failed to extract backup: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(extractedDir, "data.db")); err != nil {
+		return ErrInvalidBackup
+	}
+
+	dataDir := m.app.DataDir()
+	exclude := m.exclude()
+	if err := m.app.ResetBootstrapState(); err != nil {
+		return fmt.Errorf("failed to close the databases: %w", err)
+	}
+
+	// The temp directory is emptied on the next start, taking the replaced data along
+	replacedDir := filepath.Join(tempDir, "replaced_"+pbsecurity.PseudorandomString(8))
+	if err := osutils.MoveDirContent(dataDir, replacedDir, exclude...); err != nil {
+		return fmt.Errorf("failed to move the current data directory aside: %w", err)
+	}
+
+	if err := osutils.MoveDirContent(extractedDir, dataDir, exclude...); err != nil {
+		if revertErr := osutils.MoveDirContent(replacedDir, dataDir, exclude...); revertErr != nil {
+			return fmt.Errorf("failed to restore backup: %w, and to put the current data back from %s: %w", err, replacedDir, revertErr)
+		}
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	m.logger.InfoContext(ctx, "backup restored", "name", name)
+	return nil
+}
+
+// exclude adds BACKUP_DIR to dataDirExclude when it is inside the data directory, so backups don't
+// contain the previous ones and are kept on restore
+func (m *Manager) exclude() []string {
+	dataDir, err := filepath.Abs(m.app.DataDir())
+	if err != nil {
+		return dataDirExclude
+	}
+	backupDir, err := filepath.Abs(m.config.Dir)
+	if err != nil {
+		return dataDirExclude
+	}
+
+	rel, err := filepath.Rel(dataDir, backupDir)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return dataDirExclude
+	}
+
+	return append(slices.Clone(dataDirExclude), strings.Split(rel, string(filepath.Separator))[0])
+}
+
+func (m *Manager) encryptor() (*security.Encryptor, error) {
+	if m.config.EncryptionKey == "" {
+		return nil, ErrMissingEncryptionKey
+	}
+
+	return security.NewEncryptor(m.config.EncryptionKey)
+}
+
+// tempDir is inside the data directory, so the restored data is moved without crossing devices
+func (m *Manager) tempDir() (string, error) {
+	tempDir := filepath.Join(m.app.DataDir(), core.LocalTempDirName)
+	if err := os.MkdirAll(tempDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	return tempDir, nil
+}
+
+// find returns the local path of the backup, downloading it to tempDir when it is only in the
+// bucket, along with a cleanup removing the download
+func (m *Manager) find(ctx context.Context, name, tempDir string) (string, func(), error) {
+	for _, candidate := range []string{name, filepath.Join(m.config.Dir, filepath.Base(name))} {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, func() {}, nil
+		}
+	}
+
+	if !m.config.UploadsBackups() {
+		return "", nil, fmt.Errorf("%w: %s", ErrBackupNotFound, name)
+	}
+
+	fsys, err := m.storage(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	defer fsys.Close()
+
+	reader, err := fsys.GetReader(m.objectKey(filepath.Base(name)))
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s: %s", ErrBackupNotFound, name, err.Error())
+	}
+	defer reader.Close()
+
+	download, err := os.CreateTemp(tempDir, "download_")
+	if err != nil {
+		return "", nil, err
+	}
+	defer download.Close()
+
+	cleanup := func() { os.Remove(download.Name()) }
+	if _, err := io.Copy(download, reader); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to download backup: %w", err)
+	}
+
+	return download.Name(), cleanup, nil
+}
+
+func (m *Manager) upload(ctx context.Context, backupPath, name string) error {
+	fsys, err := m.storage(ctx)
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	file, err := filesystem.NewFileFromPath(backupPath)
+	if err != nil {
+		return err
+	}
+
+	return fsys.UploadFile(file, m.objectKey(name))
+}
+
+func (m *Manager) storage(ctx context.Context) (*filesystem.System, error) {
+	fsys, err := filesystem.NewS3(
+		m.config.Bucket,
+		m.config.Region,
+		m.config.StorageEndpoint(),
+		m.config.AccessKey,
+		m.config.SecretKey,
+		m.config.ForcePathStyle,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup bucket: %w", err)
+	}
+
+	fsys.SetContext(ctx)
+	return fsys, nil
+}
+
+func (m *Manager) objectKey(name string) string {
+	return path.Join(m.config.Prefix, name)
+}
+
+func encryptFile(encryptor *security.Encryptor, src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := encryptor.EncryptStream(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+func decryptFile(encryptor *security.Encryptor, src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := encryptor.DecryptStream(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/security"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/stretchr/testify/require"
+)
+
+const testBackupKey = "0123456789abcdef0123456789abcdef"
+
+func newTestApp(t *testing.T, dataDir string) *pocketbase.PocketBase {
+	t.Helper()
+
+	app := pocketbase.NewWithConfig(pocketbase.Config{DefaultDataDir: dataDir})
+	if err := app.Bootstrap(); err != nil {
+		t.Fatalf("failed to bootstrap test app: %v", err)
+	}
+	t.Cleanup(func() { _ = app.ResetBootstrapState() })
+
+	return app
+}
+
+func newTestConfig(t *testing.T) config.BackupConfig {
+	return config.BackupConfig{
+		EncryptionKey: testBackupKey,
+		Dir:           filepath.Join(t.TempDir(), "backups"),
+		Storage:       config.BackupStorageLocal,
+	}
+}
+
+func createNote(t *testing.T, app core.App, collection *core.Collection, title string) {
+	t.Helper()
+
+	record := core.NewRecord(collection)
+	record.Set("title", title)
+	require.NoError(t, app.Save(record))
+}
+
+func TestManager_CreateAndRestore(t *testing.T) {
+	assert := require.New(t)
+
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	cfg := newTestConfig(t)
+
+	app := newTestApp(t, dataDir)
+	collection := core.NewBaseCollection("notes")
+	collection.Fields.Add(&core.TextField{Name: "title"})
+	assert.NoError(app.Save(collection))
+	createNote(t, app, collection, "backed up")
+
+	name, err := NewManager(app, cfg, slog.Default()).Create(ctx)
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(name, BACKUP_FILE_PREFIX))
+	assert.True(strings.HasSuffix(name, BACKUP_FILE_EXTENSION))
+
+	// The backup is encrypted
+	contents, err := os.ReadFile(filepath.Join(cfg.Dir, name))
+	assert.NoError(err)
+	assert.NotContains(string(contents), "SQLite format")
+
+	createNote(t, app, collection, "after the backup")
+
+	assert.NoError(NewManager(app, cfg, slog.Default()).Restore(ctx, name))
+
+	restored := newTestApp(t, dataDir)
+	records, err := restored.FindAllRecords("notes")
+	assert.NoError(err)
+	assert.Len(records, 1)
+	assert.Equal("backed up", records[0].GetString("title"))
+}
+
+func TestManager_Errors(t *testing.T) {
+	assert := require.New(t)
+
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	cfg := newTestConfig(t)
+	app := newTestApp(t, dataDir)
+
+	name, err := NewManager(app, cfg, slog.Default()).Create(ctx)
+	assert.NoError(err)
+
+	missingKey := cfg
+	missingKey.EncryptionKey = ""
+	_, err = NewManager(app, missingKey, slog.Default()).Create(ctx)
+	assert.ErrorIs(err, ErrMissingEncryptionKey)
+
+	err = NewManager(app, cfg, slog.Default()).Restore(ctx, "playlist-router-missing.zip.enc")
+	assert.ErrorIs(err, ErrBackupNotFound)
+
+	otherKey := cfg
+	otherKey.EncryptionKey = "fedcba9876543210fedcba9876543210"
+	err = NewManager(app, otherKey, slog.Default()).Restore(ctx, name)
+	assert.ErrorIs(err, security.ErrInvalidStream)
+
+	// The data directory is left untouched by failed restores
+	_, err = os.Stat(filepath.Join(dataDir, "data.db"))
+	assert.NoError(err)
+}
+
+func TestManager_BackupDirInsideDataDir(t *testing.T) {
+	assert := require.New(t)
+
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	cfg := newTestConfig(t)
+	cfg.Dir = filepath.Join(dataDir, "app_backups")
+	app := newTestApp(t, dataDir)
+
+	first, err := NewManager(app, cfg, slog.Default()).Create(ctx)
+	assert.NoError(err)
+
+	assert.NoError(NewManager(app, cfg, slog.Default()).Restore(ctx, first))
+
+	// The backups survive the restore of a backup that doesn't contain them
+	_, err = os.Stat(filepath.Join(cfg.Dir, first))
+	assert.NoError(err)
+	_, err = os.Stat(filepath.Join(dataDir, "data.db"))
+	assert.NoError(err)
+}
@@ -0,0 +1,17 @@
+// Package cache stores short lived copies of data read from external services. The store is
+// pluggable: in memory for a single instance, or Redis to share the entries between instances
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps values until their TTL runs out. A Store may drop entries earlier to bound its
+// memory, callers must always be able to recompute a value
+type Store interface {
+	// Get returns the value of key, reporting false when it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
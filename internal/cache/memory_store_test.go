@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryStore(maxEntries int, now *time.Time) *MemoryStore {
+	store := NewMemoryStore(maxEntries)
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestMemoryStore_GetSet(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	store := newTestMemoryStore(10, &now)
+
+	_, ok, err := store.Get(ctx, "playlist:1")
+	assert.NoError(err)
+	assert.False(ok)
+
+	assert.NoError(store.Set(ctx, "playlist:1", []byte("first"), time.Minute))
+	value, ok, err := store.Get(ctx, "playlist:1")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal([]byte("first"), value)
+
+	assert.NoError(store.Set(ctx, "playlist:1", []byte("second"), time.Minute))
+	value, _, _ = store.Get(ctx, "playlist:1")
+	assert.Equal([]byte("second"), value)
+	assert.Equal(1, store.Len())
+}
+
+func TestMemoryStore_Expires(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	store := newTestMemoryStore(10, &now)
+
+	assert.NoError(store.Set(ctx, "playlist:1", []byte("value"), time.Minute))
+
+	now = now.Add(time.Minute - time.Second)
+	_, ok, _ := store.Get(ctx, "playlist:1")
+	assert.True(ok)
+
+	now = now.Add(time.Second)
+	_, ok, _ = store.Get(ctx, "playlist:1")
+	assert.False(ok)
+	assert.Equal(0, store.Len())
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	store := newTestMemoryStore(2, &now)
+
+	assert.NoError(store.Set(ctx, "a", []byte("a"), time.Minute))
+	assert.NoError(store.Set(ctx, "b", []byte("b"), time.Minute))
+
+	// Reading a makes b the least recently used
+	_, ok, _ := store.Get(ctx, "a")
+	assert.True(ok)
+	assert.NoError(store.Set(ctx, "c", []byte("c"), time.Minute))
+
+	assert.Equal(2, store.Len())
+	_, ok, _ = store.Get(ctx, "b")
+	assert.False(ok)
+	_, ok, _ = store.Get(ctx, "a")
+	assert.True(ok)
+	_, ok, _ = store.Get(ctx, "c")
+	assert.True(ok)
+}
+
+func TestMemoryStore_Delete(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	store := newTestMemoryStore(10, &now)
+
+	assert.NoError(store.Set(ctx, "a", []byte("a"), time.Minute))
+	assert.NoError(store.Set(ctx, "b", []byte("b"), time.Minute))
+	assert.NoError(store.Delete(ctx, "a", "missing"))
+
+	_, ok, _ := store.Get(ctx, "a")
+	assert.False(ok)
+	_, ok, _ = store.Get(ctx, "b")
+	assert.True(ok)
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStore(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	store := NewRedisStore(client, "playlist-router:")
+
+	_, ok, err := store.Get(ctx, "playlist:1")
+	assert.NoError(err)
+	assert.False(ok)
+
+	assert.NoError(store.Set(ctx, "playlist:1", []byte("value"), time.Minute))
+	assert.NoError(store.Set(ctx, "playlist:2", []byte("other"), time.Minute))
+	assert.True(server.Exists("playlist-router:playlist:1"))
+
+	value, ok, err := store.Get(ctx, "playlist:1")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal([]byte("value"), value)
+
+	server.FastForward(time.Minute)
+	_, ok, err = store.Get(ctx, "playlist:1")
+	assert.NoError(err)
+	assert.False(ok)
+
+	assert.NoError(store.Set(ctx, "playlist:2", []byte("other"), time.Minute))
+	assert.NoError(store.Delete(ctx, "playlist:2"))
+	assert.NoError(store.Delete(ctx))
+	assert.False(server.Exists("playlist-router:playlist:2"))
+
+	server.Close()
+	_, _, err = store.Get(ctx, "playlist:1")
+	assert.Error(err)
+}
@@ -0,0 +1,8 @@
+package lastfmclient
+
+import "errors"
+
+var (
+	ErrRateLimited  = errors.New("lastfm rate limit exceeded")
+	ErrInvalidToken = errors.New("lastfm token is invalid, expired or not authorized")
+)
@@ -0,0 +1,292 @@
+package lastfmclient
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/cache"
+	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/tracing"
+)
+
+const (
+	// MAX_TRACK_TAGS bounds the top tags kept for a track, the ones after are rarely applied
+	MAX_TRACK_TAGS = 10
+
+	// Error codes of the Last.fm API
+	ERROR_INVALID_PARAMETERS = 6 // Also sent for tracks Last.fm doesn't know
+	ERROR_INVALID_TOKEN      = 4
+	ERROR_UNAUTHORIZED_TOKEN = 14
+	ERROR_EXPIRED_TOKEN      = 15
+	ERROR_RATE_LIMIT         = 29
+)
+
+//go:generate mockgen -source=lastfm_client.go -destination=mocks/mock_lastfm_client.go -package=mocks
+
+// LastFmAPI links Last.fm accounts through the web auth and reads the scrobbles of their users
+type LastFmAPI interface {
+	GenerateAuthURL(state string) string
+	GetSession(ctx context.Context, token string) (*Session, error)
+	GetTrackInfo(ctx context.Context, username, artist, track string) (*TrackInfo, error)
+}
+
+type LastFmClient struct {
+	HttpClient clients.HTTPClient
+	config     *config.LastFmConfig
+	logger     *slog.Logger
+	cache      cache.Store
+
+	apiBaseUrl string
+}
+
+var _ LastFmAPI = (*LastFmClient)(nil)
+
+func NewLastFmClient(config *config.LastFmConfig, logger *slog.Logger) *LastFmClient {
+	return &LastFmClient{
+		HttpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: tracing.Transport(&http.Transport{
+				MaxIdleConns:    10,
+				IdleConnTimeout: 30 * time.Second,
+			}),
+		},
+		config:     config,
+		logger:     logger.With("component", "LastFmClient"),
+		apiBaseUrl: strings.TrimSuffix(config.APIBaseURL, "/") + "/",
+	}
+}
+
+// WithCache keeps the track info read in store for the configured LASTFM_CACHE_TTL, so syncs of the
+// same playlist don't look every track up again. A failing store only causes cache misses
+func (c *LastFmClient) WithCache(store cache.Store) *LastFmClient {
+	c.cache = store
+	return c
+}
+
+// GenerateAuthURL returns the Last.fm page where the user approves the app. Last.fm redirects back
+// to the callback with a token, the state is added to the callback to identify the user
+func (c *LastFmClient) GenerateAuthURL(state string) string {
+	callbackURL, err := url.Parse(c.config.CallbackURL)
+	if err != nil {
+		// Validated with the config
+		return ""
+	}
+	query := callbackURL.Query()
+	query.Set("state", state)
+	callbackURL.RawQuery = query.Encode()
+
+	params := url.Values{"api_key": {c.config.APIKey}, "cb": {callbackURL.String()}}
+	return c.config.AuthURL + "?" + params.Encode()
+}
+
+// GetSession exchanges the token of an approved web auth for the session of the account
+func (c *LastFmClient) GetSession(ctx context.Context, token string) (*Session, error) {
+	c.logger.InfoContext(ctx, "fetching lastfm session")
+
+	params := url.Values{"method": {"auth.getSession"}, "token": {token}}
+	params.Set("api_key", c.config.APIKey)
+	params.Set("api_sig", c.signature(params))
+
+	var response lastFmSessionResponse
+	if err := c.call(ctx, params, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Session, nil
+}
+
+// GetTrackInfo returns the play count of the track for username and its top tags, lowercased.
+// Tracks Last.fm doesn't know are returned with no plays nor tags
+func (c *LastFmClient) GetTrackInfo(ctx context.Context, username, artist, track string) (*TrackInfo, error) {
+	key := trackInfoCacheKey(username, artist, track)
+	if info, ok := c.cachedTrackInfo(ctx, key); ok {
+		return info, nil
+	}
+
+	params := url.Values{
+		"method":      {"track.getInfo"},
+		"artist":      {artist},
+		"track":       {track},
+		"username":    {username},
+		"autocorrect": {"1"},
+	}
+
+	var response lastFmTrackResponse
+	err := c.call(ctx, params, &response)
+	if err != nil && !isErrorCode(err, ERROR_INVALID_PARAMETERS) {
+		return nil, fmt.Errorf("failed to get track info: %w", err)
+	}
+
+	info := &TrackInfo{UserPlayCount: int(response.Track.UserPlayCount), Tags: []string{}}
+	for _, tag := range response.Track.TopTags.Tag {
+		name := strings.ToLower(strings.TrimSpace(tag.Name))
+		if name != "" && !slices.Contains(info.Tags, name) {
+			info.Tags = append(info.Tags, name)
+		}
+		if len(info.Tags) == MAX_TRACK_TAGS {
+			break
+		}
+	}
+
+	c.cacheTrackInfo(ctx, key, info)
+	return info, nil
+}
+
+// call sends a request to the API, adding the api key, and decodes the response into out. Last.fm
+// reports errors in the body, sometimes along with an OK status
+func (c *LastFmClient) call(ctx context.Context, params url.Values, out any) error {
+	params.Set("api_key", c.config.APIKey)
+	params.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiBaseUrl+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to reach lastfm", "method", params.Get("method"), "error", err)
+		return fmt.Errorf("failed to request %s: %w", params.Get("method"), err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", params.Get("method"), err)
+	}
+
+	var apiErr lastFmError
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != 0 {
+		return c.apiError(ctx, params.Get("method"), apiErr)
+	}
+	if resp.StatusCode != http.StatusOK {
+		c.logger.ErrorContext(ctx, "lastfm request failed", "method", params.Get("method"), "status_code", resp.StatusCode, "response_body", string(body))
+		return fmt.Errorf("lastfm %s request failed (status %d): %s", params.Get("method"), resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", params.Get("method"), err)
+	}
+
+	return nil
+}
+
+func (c *LastFmClient) apiError(ctx context.Context, method string, apiErr lastFmError) error {
+	switch apiErr.Error {
+	case ERROR_RATE_LIMIT:
+		return ErrRateLimited
+	case ERROR_INVALID_TOKEN, ERROR_UNAUTHORIZED_TOKEN, ERROR_EXPIRED_TOKEN:
+		return ErrInvalidToken
+	case ERROR_INVALID_PARAMETERS:
+		// Unknown tracks are expected, they aren't logged
+		return &codeError{code: apiErr.Error, message: apiErr.Message}
+	}
+
+	c.logger.ErrorContext(ctx, "lastfm request failed", "method", method, "error_code", apiErr.Error, "message", apiErr.Message)
+	return &codeError{code: apiErr.Error, message: apiErr.Message}
+}
+
+// signature signs the parameters of an authenticated call with the shared secret: the md5 of the
+// parameters sorted by name and concatenated, followed by the secret
+func (c *LastFmClient) signature(params url.Values) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		if name != "format" && name != "callback" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var signed strings.Builder
+	for _, name := range names {
+		signed.WriteString(name)
+		signed.WriteString(params.Get(name))
+	}
+	signed.WriteString(c.config.SharedSecret)
+
+	sum := md5.Sum([]byte(signed.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *LastFmClient) cachedTrackInfo(ctx context.Context, key string) (*TrackInfo, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+
+	data, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to read lastfm cache", "key", key, "error", err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	var info TrackInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		c.logger.WarnContext(ctx, "failed to decode cached lastfm track info", "key", key, "error", err)
+		return nil, false
+	}
+
+	return &info, true
+}
+
+func (c *LastFmClient) cacheTrackInfo(ctx context.Context, key string, info *TrackInfo) {
+	if c.cache == nil {
+		return
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to encode lastfm track info", "key", key, "error", err)
+		return
+	}
+
+	if err := c.cache.Set(ctx, key, data, c.config.CacheTTL); err != nil {
+		c.logger.WarnContext(ctx, "failed to write lastfm cache", "key", key, "error", err)
+	}
+}
+
+func (c *LastFmClient) responseBodyCloser(ctx context.Context, resp *http.Response) {
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		c.logger.WarnContext(ctx, "failed to close response body", "error", closeErr)
+	}
+}
+
+// trackInfoCacheKey keys the track info by user, since play counts are per user. Names are
+// lowercased as Last.fm matches them ignoring case
+func trackInfoCacheKey(username, artist, track string) string {
+	parts := []string{username, artist, track}
+	for i, part := range parts {
+		parts[i] = url.QueryEscape(strings.ToLower(part))
+	}
+
+	return "lastfm:track:" + strings.Join(parts, ":")
+}
+
+// codeError is an error of the Last.fm API with no matching sentinel error
+type codeError struct {
+	code    int
+	message string
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("lastfm error %d: %s", e.code, e.message)
+}
+
+func isErrorCode(err error, code int) bool {
+	var apiErr *codeError
+	return errors.As(err, &apiErr) && apiErr.code == code
+}
@@ -0,0 +1,156 @@
+package lastfmclient
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastFmClient_GenerateAuthURL(t *testing.T) {
+	assert := require.New(t)
+	client := NewLastFmClient(testConfig(), createTestLogger())
+
+	authURL, err := url.Parse(client.GenerateAuthURL("state123"))
+
+	assert.NoError(err)
+	assert.Equal("www.last.fm", authURL.Host)
+	assert.Equal("/api/auth/", authURL.Path)
+	assert.Equal("api_key", authURL.Query().Get("api_key"))
+	assert.Equal("https://app.example.com/auth/lastfm/callback?state=state123", authURL.Query().Get("cb"))
+}
+
+func TestLastFmClient_GetSession(t *testing.T) {
+	t.Run("signed exchange", func(t *testing.T) {
+		assert := require.New(t)
+
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			query := req.URL.Query()
+			assert.Equal("auth.getSession", query.Get("method"))
+			assert.Equal("token123", query.Get("token"))
+			assert.Equal("json", query.Get("format"))
+
+			sum := md5.Sum([]byte("api_keyapi_keymethodauth.getSessiontokentoken123shared_secret"))
+			assert.Equal(hex.EncodeToString(sum[:]), query.Get("api_sig"))
+
+			return jsonResponse(http.StatusOK, map[string]any{
+				"session": map[string]any{"name": "listener", "key": "session_key", "subscriber": 0},
+			})
+		})
+
+		session, err := client.GetSession(context.Background(), "token123")
+
+		assert.NoError(err)
+		assert.Equal(&Session{Username: "listener", Key: "session_key"}, session)
+	})
+
+	t.Run("unauthorized token", func(t *testing.T) {
+		assert := require.New(t)
+
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			return jsonResponse(http.StatusForbidden, map[string]any{"error": 14, "message": "Unauthorized Token"})
+		})
+
+		session, err := client.GetSession(context.Background(), "token123")
+
+		assert.ErrorIs(err, ErrInvalidToken)
+		assert.Nil(session)
+	})
+}
+
+func TestLastFmClient_GetTrackInfo(t *testing.T) {
+	t.Run("play count and tags", func(t *testing.T) {
+		assert := require.New(t)
+
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			query := req.URL.Query()
+			assert.Equal("track.getInfo", query.Get("method"))
+			assert.Equal("Slowdive", query.Get("artist"))
+			assert.Equal("Alison", query.Get("track"))
+			assert.Equal("listener", query.Get("username"))
+			assert.Equal("api_key", query.Get("api_key"))
+
+			return jsonResponse(http.StatusOK, map[string]any{
+				"track": map[string]any{
+					"name":          "Alison",
+					"userplaycount": "42",
+					"toptags": map[string]any{"tag": []map[string]any{
+						{"name": "Shoegaze"}, {"name": "dream pop"}, {"name": "shoegaze"},
+					}},
+				},
+			})
+		})
+
+		info, err := client.GetTrackInfo(context.Background(), "listener", "Slowdive", "Alison")
+
+		assert.NoError(err)
+		assert.Equal(&TrackInfo{UserPlayCount: 42, Tags: []string{"shoegaze", "dream pop"}}, info)
+	})
+
+	t.Run("single tag sent as an object", func(t *testing.T) {
+		assert := require.New(t)
+
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			return jsonResponse(http.StatusOK, map[string]any{
+				"track": map[string]any{"userplaycount": 3, "toptags": map[string]any{"tag": map[string]any{"name": "rock"}}},
+			})
+		})
+
+		info, err := client.GetTrackInfo(context.Background(), "listener", "Artist", "Track")
+
+		assert.NoError(err)
+		assert.Equal(&TrackInfo{UserPlayCount: 3, Tags: []string{"rock"}}, info)
+	})
+
+	t.Run("unknown track has no plays", func(t *testing.T) {
+		assert := require.New(t)
+
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			return jsonResponse(http.StatusOK, map[string]any{"error": 6, "message": "Track not found"})
+		})
+
+		info, err := client.GetTrackInfo(context.Background(), "listener", "Artist", "Unknown")
+
+		assert.NoError(err)
+		assert.Equal(&TrackInfo{UserPlayCount: 0, Tags: []string{}}, info)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		assert := require.New(t)
+
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			return jsonResponse(http.StatusTooManyRequests, map[string]any{"error": 29, "message": "Rate limit exceeded"})
+		})
+
+		info, err := client.GetTrackInfo(context.Background(), "listener", "Artist", "Track")
+
+		assert.ErrorIs(err, ErrRateLimited)
+		assert.Nil(info)
+	})
+
+	t.Run("cached per user", func(t *testing.T) {
+		assert := require.New(t)
+
+		calls := 0
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			calls++
+			return jsonResponse(http.StatusOK, map[string]any{"track": map[string]any{"userplaycount": "7"}})
+		})
+		client.WithCache(cache.NewMemoryStore(10))
+
+		first, err := client.GetTrackInfo(context.Background(), "listener", "Artist", "Track")
+		assert.NoError(err)
+		second, err := client.GetTrackInfo(context.Background(), "listener", "artist", "TRACK")
+		assert.NoError(err)
+		_, err = client.GetTrackInfo(context.Background(), "other", "Artist", "Track")
+		assert.NoError(err)
+
+		assert.Equal(first, second)
+		assert.Equal(2, calls)
+	})
+}
@@ -0,0 +1,89 @@
+package lastfmclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Session is the web auth session of a Last.fm account
+type Session struct {
+	Username string `json:"name"`
+	Key      string `json:"key"`
+}
+
+// TrackInfo is what Last.fm knows of a track for a user: how many times they scrobbled it and the
+// tags listeners applied to it the most
+type TrackInfo struct {
+	UserPlayCount int      `json:"user_play_count"`
+	Tags          []string `json:"tags"`
+}
+
+// Responses of the Last.fm API, only the fields the client reads
+
+type lastFmError struct {
+	Error   int    `json:"error"`
+	Message string `json:"message"`
+}
+
+type lastFmSessionResponse struct {
+	Session Session `json:"session"`
+}
+
+type lastFmTrackResponse struct {
+	Track struct {
+		UserPlayCount lastFmInt `json:"userplaycount"`
+		TopTags       struct {
+			Tag lastFmTags `json:"tag"`
+		} `json:"toptags"`
+	} `json:"track"`
+}
+
+type lastFmTag struct {
+	Name string `json:"name"`
+}
+
+// lastFmInt reads the numbers Last.fm sends either as numbers or as strings
+type lastFmInt int
+
+func (n *lastFmInt) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*n = 0
+		return nil
+	}
+
+	value, err := strconv.Atoi(string(data))
+	if err != nil {
+		return err
+	}
+
+	*n = lastFmInt(value)
+	return nil
+}
+
+// lastFmTags reads tag lists, which Last.fm sends as a single object when there is only one tag
+// and as an empty string when there are none
+type lastFmTags []lastFmTag
+
+func (t *lastFmTags) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		var tags []lastFmTag
+		if err := json.Unmarshal(data, &tags); err != nil {
+			return err
+		}
+		*t = tags
+	case bytes.HasPrefix(data, []byte("{")):
+		var tag lastFmTag
+		if err := json.Unmarshal(data, &tag); err != nil {
+			return err
+		}
+		*t = lastFmTags{tag}
+	default:
+		*t = nil
+	}
+
+	return nil
+}
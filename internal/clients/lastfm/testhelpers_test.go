@@ -0,0 +1,53 @@
+package lastfmclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/config"
+)
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testConfig() *config.LastFmConfig {
+	return &config.LastFmConfig{
+		APIKey:       "api_key",
+		SharedSecret: "shared_secret",
+		CallbackURL:  "https://app.example.com/auth/lastfm/callback",
+		AuthURL:      "https://www.last.fm/api/auth/",
+		APIBaseURL:   "https://ws.audioscrobbler.com/2.0/",
+		CacheTTL:     time.Hour,
+	}
+}
+
+// setupClient returns a client whose requests are answered by handler
+func setupClient(t *testing.T, handler func(req *http.Request) *http.Response) *LastFmClient {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return handler(req), nil
+		}).
+		AnyTimes()
+
+	client := NewLastFmClient(testConfig(), createTestLogger())
+	client.HttpClient = mockHTTPClient
+	return client
+}
+
+func jsonResponse(status int, body any) *http.Response {
+	payload, _ := json.Marshal(body)
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(payload))}
+}
@@ -0,0 +1,47 @@
+package mailclient
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+)
+
+//go:generate mockgen -source=mail_client.go -destination=mocks/mock_mail_client.go -package=mocks
+
+// Mailer sends emails to users
+type Mailer interface {
+	SendEmail(ctx context.Context, to, subject, html string) error
+}
+
+// PocketBaseMailer sends emails with the mail settings of PocketBase: through the SMTP server
+// configured there, or the sendmail binary when SMTP is disabled
+type PocketBaseMailer struct {
+	app core.App
+}
+
+func NewPocketBaseMailer(app core.App) *PocketBaseMailer {
+	return &PocketBaseMailer{app: app}
+}
+
+func (m *PocketBaseMailer) SendEmail(ctx context.Context, to, subject, html string) error {
+	settings := m.app.Settings()
+
+	message := &mailer.Message{
+		From: mail.Address{
+			Name:    settings.Meta.SenderName,
+			Address: settings.Meta.SenderAddress,
+		},
+		To:      []mail.Address{{Address: to}},
+		Subject: subject,
+		HTML:    html,
+	}
+
+	if err := m.app.NewMailClient().Send(message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
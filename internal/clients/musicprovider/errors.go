@@ -0,0 +1,15 @@
+package musicprovider
+
+import "errors"
+
+// Errors every provider reports its failures as, wrapped with the provider name
+var (
+	ErrRateLimited        = errors.New("request budget exhausted, try again later")
+	ErrCoverImageTooLarge = errors.New("cover image too large")
+	ErrTrackNotFound      = errors.New("track not found")
+	ErrPlaylistNotFound   = errors.New("playlist not found")
+	// ErrNotSupported is returned for calls the streaming service has no equivalent of
+	ErrNotSupported = errors.New("not supported by the music provider")
+	// ErrProviderUnavailable is returned by the router for providers that aren't configured
+	ErrProviderUnavailable = errors.New("music provider not available")
+)
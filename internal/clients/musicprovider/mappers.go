@@ -1,8 +1,8 @@
-package spotifyclient
+package musicprovider
 
 import "github.com/ngomez18/playlist-router/internal/models"
 
-func ParseSpotifyPlaylist(p *SpotifyPlaylist) *models.SpotifyPlaylist {
+func ParsePlaylist(p *Playlist) *models.SpotifyPlaylist {
 	tracks := 0
 	if p.Tracks != nil {
 		tracks = p.Tracks.Total
@@ -15,16 +15,16 @@ func ParseSpotifyPlaylist(p *SpotifyPlaylist) *models.SpotifyPlaylist {
 	}
 }
 
-func ParseManySpotifyPlaylist(ps []*SpotifyPlaylist) []*models.SpotifyPlaylist {
+func ParseManyPlaylists(ps []*Playlist) []*models.SpotifyPlaylist {
 	parsed := make([]*models.SpotifyPlaylist, 0, len(ps))
 	for _, p := range ps {
-		parsed = append(parsed, ParseSpotifyPlaylist(p))
+		parsed = append(parsed, ParsePlaylist(p))
 	}
 
 	return parsed
 }
 
-func ParsePlaylistTrack(t SpotifyPlaylistTrack) models.TrackInfo {
+func ParsePlaylistTrack(t PlaylistTrack) models.TrackInfo {
 	artists := make([]string, 0, len(t.Track.Artists))
 	for _, a := range t.Track.Artists {
 		artists = append(artists, a.ID)
@@ -37,12 +37,13 @@ func ParsePlaylistTrack(t SpotifyPlaylistTrack) models.TrackInfo {
 		DurationMs: t.Track.DurationMs,
 		Popularity: t.Track.Popularity,
 		Explicit:   t.Track.Explicit,
+		AddedAt:    t.AddedAt,
 		Album:      *ParseAlbum(&t.Track.Album),
 		Artists:    artists,
 	}
 }
 
-func ParseManyPlaylistTracks(ts []SpotifyPlaylistTrack) []models.TrackInfo {
+func ParseManyPlaylistTracks(ts []PlaylistTrack) []models.TrackInfo {
 	parsed := make([]models.TrackInfo, 0, len(ts))
 	for _, t := range ts {
 		parsed = append(parsed, ParsePlaylistTrack(t))
@@ -51,7 +52,7 @@ func ParseManyPlaylistTracks(ts []SpotifyPlaylistTrack) []models.TrackInfo {
 	return parsed
 }
 
-func ParseAlbum(a *SpotifyAlbum) *models.AlbumInfo {
+func ParseAlbum(a *Album) *models.AlbumInfo {
 	return &models.AlbumInfo{
 		ID:          a.ID,
 		Name:        a.Name,
@@ -60,7 +61,7 @@ func ParseAlbum(a *SpotifyAlbum) *models.AlbumInfo {
 	}
 }
 
-func ParseArtist(a *SpotifyArtist) *models.ArtistInfo {
+func ParseArtist(a *Artist) *models.ArtistInfo {
 	return &models.ArtistInfo{
 		ID:         a.ID,
 		Name:       a.Name,
@@ -69,3 +70,19 @@ func ParseArtist(a *SpotifyArtist) *models.ArtistInfo {
 		URI:        a.URI,
 	}
 }
+
+func ParseAudioFeatures(a *AudioFeatures) *models.AudioFeatures {
+	return &models.AudioFeatures{
+		Tempo:            a.Tempo,
+		Energy:           a.Energy,
+		Danceability:     a.Danceability,
+		Valence:          a.Valence,
+		Acousticness:     a.Acousticness,
+		Instrumentalness: a.Instrumentalness,
+		Liveness:         a.Liveness,
+		Speechiness:      a.Speechiness,
+		Loudness:         a.Loudness,
+		Key:              a.Key,
+		Mode:             a.Mode,
+	}
+}
@@ -1,24 +1,25 @@
-package spotifyclient
+package musicprovider
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestParseSpotifyPlaylist(t *testing.T) {
+func TestParsePlaylist(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    *SpotifyPlaylist
+		input    *Playlist
 		expected *models.SpotifyPlaylist
 	}{
 		{
 			name: "complete playlist with tracks",
-			input: &SpotifyPlaylist{
+			input: &Playlist{
 				ID:   "playlist123",
 				Name: "My Awesome Playlist",
-				Tracks: &SpotifyPlaylistTracks{
+				Tracks: &PlaylistTracks{
 					Total: 42,
 				},
 			},
@@ -30,7 +31,7 @@ func TestParseSpotifyPlaylist(t *testing.T) {
 		},
 		{
 			name: "playlist with nil tracks",
-			input: &SpotifyPlaylist{
+			input: &Playlist{
 				ID:     "no_tracks_info",
 				Name:   "Unknown Track Count",
 				Tracks: nil,
@@ -47,7 +48,7 @@ func TestParseSpotifyPlaylist(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
 
-			result := ParseSpotifyPlaylist(tt.input)
+			result := ParsePlaylist(tt.input)
 
 			assert.Equal(tt.expected.ID, result.ID)
 			assert.Equal(tt.expected.Name, result.Name)
@@ -56,29 +57,29 @@ func TestParseSpotifyPlaylist(t *testing.T) {
 	}
 }
 
-func TestParseManySpotifyPlaylist(t *testing.T) {
+func TestParseManyPlaylists(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    []*SpotifyPlaylist
+		input    []*Playlist
 		expected []*models.SpotifyPlaylist
 	}{
 		{
 			name: "multiple playlists",
-			input: []*SpotifyPlaylist{
+			input: []*Playlist{
 				{
 					ID:     "playlist1",
 					Name:   "Rock Classics",
-					Tracks: &SpotifyPlaylistTracks{Total: 25},
+					Tracks: &PlaylistTracks{Total: 25},
 				},
 				{
 					ID:     "playlist2",
 					Name:   "Jazz Favorites",
-					Tracks: &SpotifyPlaylistTracks{Total: 18},
+					Tracks: &PlaylistTracks{Total: 18},
 				},
 				{
 					ID:     "playlist3",
 					Name:   "Empty List",
-					Tracks: &SpotifyPlaylistTracks{Total: 0},
+					Tracks: &PlaylistTracks{Total: 0},
 				},
 			},
 			expected: []*models.SpotifyPlaylist{
@@ -89,7 +90,7 @@ func TestParseManySpotifyPlaylist(t *testing.T) {
 		},
 		{
 			name:     "empty slice",
-			input:    []*SpotifyPlaylist{},
+			input:    []*Playlist{},
 			expected: []*models.SpotifyPlaylist{},
 		},
 		{
@@ -99,11 +100,11 @@ func TestParseManySpotifyPlaylist(t *testing.T) {
 		},
 		{
 			name: "single playlist",
-			input: []*SpotifyPlaylist{
+			input: []*Playlist{
 				{
 					ID:     "single",
 					Name:   "Solo Track",
-					Tracks: &SpotifyPlaylistTracks{Total: 1},
+					Tracks: &PlaylistTracks{Total: 1},
 				},
 			},
 			expected: []*models.SpotifyPlaylist{
@@ -116,7 +117,7 @@ func TestParseManySpotifyPlaylist(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
 
-			result := ParseManySpotifyPlaylist(tt.input)
+			result := ParseManyPlaylists(tt.input)
 
 			assert.Equal(len(tt.expected), len(result))
 
@@ -132,24 +133,25 @@ func TestParseManySpotifyPlaylist(t *testing.T) {
 func TestParsePlaylistTrack(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    SpotifyPlaylistTrack
+		input    PlaylistTrack
 		expected models.TrackInfo
 	}{
 		{
 			name: "track with multiple artists",
-			input: SpotifyPlaylistTrack{
-				Track: &SpotifyTrack{
+			input: PlaylistTrack{
+				AddedAt: time.Date(2024, time.March, 10, 8, 30, 0, 0, time.UTC),
+				Track: &Track{
 					ID:         "track123",
 					Name:       "Test Track",
 					URI:        "spotify:track:track123",
 					DurationMs: 180000,
 					Popularity: 75,
 					Explicit:   true,
-					Artists: []SpotifyArtist{
+					Artists: []Artist{
 						{ID: "artist1", Name: "Artist One"},
 						{ID: "artist2", Name: "Artist Two"},
 					},
-					Album: SpotifyAlbum{
+					Album: Album{
 						ID:          "album123",
 						Name:        "Test Album",
 						ReleaseDate: "2023-01-01",
@@ -164,6 +166,7 @@ func TestParsePlaylistTrack(t *testing.T) {
 				DurationMs: 180000,
 				Popularity: 75,
 				Explicit:   true,
+				AddedAt:    time.Date(2024, time.March, 10, 8, 30, 0, 0, time.UTC),
 				Artists:    []string{"artist1", "artist2"},
 				Album: models.AlbumInfo{
 					ID:          "album123",
@@ -175,18 +178,18 @@ func TestParsePlaylistTrack(t *testing.T) {
 		},
 		{
 			name: "track with single artist",
-			input: SpotifyPlaylistTrack{
-				Track: &SpotifyTrack{
+			input: PlaylistTrack{
+				Track: &Track{
 					ID:         "track456",
 					Name:       "Solo Track",
 					URI:        "spotify:track:track456",
 					DurationMs: 200000,
 					Popularity: 60,
 					Explicit:   false,
-					Artists: []SpotifyArtist{
+					Artists: []Artist{
 						{ID: "artist3", Name: "Solo Artist"},
 					},
-					Album: SpotifyAlbum{
+					Album: Album{
 						ID:          "album456",
 						Name:        "Solo Album",
 						ReleaseDate: "2022-05-15",
@@ -224,30 +227,30 @@ func TestParsePlaylistTrack(t *testing.T) {
 func TestParseManyPlaylistTracks(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    []SpotifyPlaylistTrack
+		input    []PlaylistTrack
 		expected []models.TrackInfo
 	}{
 		{
 			name: "multiple tracks",
-			input: []SpotifyPlaylistTrack{
+			input: []PlaylistTrack{
 				{
-					Track: &SpotifyTrack{
+					Track: &Track{
 						ID:         "track1",
 						Name:       "Track One",
 						URI:        "spotify:track:track1",
 						DurationMs: 180000,
-						Artists:    []SpotifyArtist{{ID: "artist1"}},
-						Album:      SpotifyAlbum{ID: "album1", Name: "Album One"},
+						Artists:    []Artist{{ID: "artist1"}},
+						Album:      Album{ID: "album1", Name: "Album One"},
 					},
 				},
 				{
-					Track: &SpotifyTrack{
+					Track: &Track{
 						ID:         "track2",
 						Name:       "Track Two",
 						URI:        "spotify:track:track2",
 						DurationMs: 200000,
-						Artists:    []SpotifyArtist{{ID: "artist2"}},
-						Album:      SpotifyAlbum{ID: "album2", Name: "Album Two"},
+						Artists:    []Artist{{ID: "artist2"}},
+						Album:      Album{ID: "album2", Name: "Album Two"},
 					},
 				},
 			},
@@ -272,7 +275,7 @@ func TestParseManyPlaylistTracks(t *testing.T) {
 		},
 		{
 			name:     "empty slice",
-			input:    []SpotifyPlaylistTrack{},
+			input:    []PlaylistTrack{},
 			expected: []models.TrackInfo{},
 		},
 	}
@@ -289,12 +292,12 @@ func TestParseManyPlaylistTracks(t *testing.T) {
 func TestParseAlbum(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    *SpotifyAlbum
+		input    *Album
 		expected *models.AlbumInfo
 	}{
 		{
 			name: "complete album",
-			input: &SpotifyAlbum{
+			input: &Album{
 				ID:          "album123",
 				Name:        "Test Album",
 				ReleaseDate: "2023-01-01",
@@ -309,7 +312,7 @@ func TestParseAlbum(t *testing.T) {
 		},
 		{
 			name: "album with empty fields",
-			input: &SpotifyAlbum{
+			input: &Album{
 				ID:          "",
 				Name:        "",
 				ReleaseDate: "",
@@ -336,12 +339,12 @@ func TestParseAlbum(t *testing.T) {
 func TestParseArtist(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    *SpotifyArtist
+		input    *Artist
 		expected *models.ArtistInfo
 	}{
 		{
 			name: "artist with genres",
-			input: &SpotifyArtist{
+			input: &Artist{
 				ID:         "artist123",
 				Name:       "Test Artist",
 				Genres:     []string{"pop", "rock", "indie"},
@@ -358,7 +361,7 @@ func TestParseArtist(t *testing.T) {
 		},
 		{
 			name: "artist without genres",
-			input: &SpotifyArtist{
+			input: &Artist{
 				ID:         "artist456",
 				Name:       "No Genre Artist",
 				Genres:     []string{},
@@ -375,7 +378,7 @@ func TestParseArtist(t *testing.T) {
 		},
 		{
 			name: "artist with nil genres",
-			input: &SpotifyArtist{
+			input: &Artist{
 				ID:         "artist789",
 				Name:       "Nil Genre Artist",
 				Genres:     nil,
@@ -400,3 +403,40 @@ func TestParseArtist(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAudioFeatures(t *testing.T) {
+	assert := assert.New(t)
+
+	input := &AudioFeatures{
+		ID:               "track123",
+		URI:              "spotify:track:track123",
+		Tempo:            128.5,
+		Energy:           0.82,
+		Danceability:     0.71,
+		Valence:          0.4,
+		Acousticness:     0.05,
+		Instrumentalness: 0.001,
+		Liveness:         0.12,
+		Speechiness:      0.04,
+		Loudness:         -5.3,
+		Key:              9,
+		Mode:             0,
+		TimeSignature:    4,
+	}
+
+	result := ParseAudioFeatures(input)
+
+	assert.Equal(&models.AudioFeatures{
+		Tempo:            128.5,
+		Energy:           0.82,
+		Danceability:     0.71,
+		Valence:          0.4,
+		Acousticness:     0.05,
+		Instrumentalness: 0.001,
+		Liveness:         0.12,
+		Speechiness:      0.04,
+		Loudness:         -5.3,
+		Key:              9,
+		Mode:             0,
+	}, result)
+}
@@ -0,0 +1,124 @@
+package musicprovider
+
+import "time"
+
+// The payloads keep the shape and json names of the Spotify Web API, the first provider. Other
+// providers map their responses into them
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserProfile struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"display_name"`
+}
+
+type Playlist struct {
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	URI           string           `json:"uri"`
+	Public        bool             `json:"public"`
+	Collaborative bool             `json:"collaborative"`
+	Description   string           `json:"description"`
+	Href          string           `json:"href"`
+	Images        []*PlaylistImage `json:"images"`
+	Tracks        *PlaylistTracks  `json:"tracks"`
+	SnapshotID    string           `json:"snapshot_id"`
+	Owner         *PlaylistOwner   `json:"owner,omitempty"`
+}
+
+// PlaylistOwner is the account that created the playlist
+type PlaylistOwner struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+type PlaylistImage struct {
+	URL    string `json:"url"`
+	Height int    `json:"height"`
+	Width  int    `json:"width"`
+}
+
+type PlaylistTracks struct {
+	Href  string `json:"href"`
+	Total int    `json:"total"`
+}
+
+// ReorderRequest moves the RangeLength tracks starting at RangeStart right before the track at
+// InsertBefore, positions counted before the move. SnapshotID, when set, makes the provider apply
+// the move to that version of the playlist
+type ReorderRequest struct {
+	RangeStart   int    `json:"range_start"`
+	InsertBefore int    `json:"insert_before"`
+	RangeLength  int    `json:"range_length,omitempty"` // Defaults to 1
+	SnapshotID   string `json:"snapshot_id,omitempty"`
+}
+
+type PlaylistTracksResponse struct {
+	Items  []PlaylistTrack `json:"items"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+	Next   *string         `json:"next"`
+}
+
+type PlaylistTrack struct {
+	AddedAt time.Time `json:"added_at"` // Zero for very old playlists, where the provider doesn't know it
+	Track   *Track    `json:"track"`
+}
+
+type Track struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	DurationMs int      `json:"duration_ms"`
+	Popularity int      `json:"popularity"`
+	Explicit   bool     `json:"explicit"`
+	Artists    []Artist `json:"artists"`
+	Album      Album    `json:"album"`
+	URI        string   `json:"uri"`
+	// ExternalIDs identify the track outside of the provider, nil when the provider doesn't send them
+	ExternalIDs *ExternalIDs `json:"external_ids,omitempty"`
+}
+
+// ExternalIDs are the industry codes of a track, matching it across streaming services
+type ExternalIDs struct {
+	ISRC string `json:"isrc,omitempty"`
+}
+
+type Artist struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Genres     []string `json:"genres"`
+	Popularity int      `json:"popularity"`
+	URI        string   `json:"uri"`
+}
+
+type AudioFeatures struct {
+	ID               string  `json:"id"`
+	URI              string  `json:"uri"`
+	Tempo            float64 `json:"tempo"`
+	Energy           float64 `json:"energy"`
+	Danceability     float64 `json:"danceability"`
+	Valence          float64 `json:"valence"`
+	Acousticness     float64 `json:"acousticness"`
+	Instrumentalness float64 `json:"instrumentalness"`
+	Liveness         float64 `json:"liveness"`
+	Speechiness      float64 `json:"speechiness"`
+	Loudness         float64 `json:"loudness"`
+	Key              int     `json:"key"`
+	Mode             int     `json:"mode"`
+	TimeSignature    int     `json:"time_signature"`
+}
+
+type Album struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ReleaseDate string `json:"release_date"`
+	URI         string `json:"uri"`
+}
@@ -0,0 +1,60 @@
+// Package musicprovider describes the streaming services the playlists live in: Spotify, YouTube
+// Music and Tidal. The services and the sync depend on MusicProvider so others can be added without
+// changing how tracks are routed
+package musicprovider
+
+import "context"
+
+//go:generate mockgen -source=provider.go -destination=mocks/mock_provider.go -package=mocks
+
+// MusicProvider is everything the router needs from a streaming service. The calls act on behalf of
+// the user whose credentials are in the context
+type MusicProvider interface {
+	Auth
+	Playlists
+	Tracks
+	Features
+}
+
+// Auth signs users in with their streaming service account through OAuth with PKCE
+type Auth interface {
+	GenerateAuthURL(state, codeChallenge string) string
+	ExchangeCodeForTokens(ctx context.Context, code, codeVerifier string) (*TokenResponse, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*TokenResponse, error)
+	GetUserProfile(ctx context.Context) (*UserProfile, error)
+	// Ping checks the provider is reachable, for the readiness probe
+	Ping(ctx context.Context) error
+}
+
+// Playlists manages the playlists of the user
+type Playlists interface {
+	GetPlaylist(ctx context.Context, playlistId string) (*Playlist, error)
+	GetAllUserPlaylists(ctx context.Context) ([]*Playlist, error)
+	CreatePlaylist(ctx context.Context, name, description string, public bool) (*Playlist, error)
+	DeletePlaylist(ctx context.Context, playlistId string) error
+	FollowPlaylist(ctx context.Context, playlistID string, public bool) error
+	UnfollowPlaylist(ctx context.Context, playlistID string) error
+	UpdatePlaylist(ctx context.Context, playlistId, name, description string) error
+	UploadPlaylistCover(ctx context.Context, playlistID string, jpegBytes []byte) error
+}
+
+// Tracks reads and writes the tracks of playlists, and looks tracks up in the catalog
+type Tracks interface {
+	GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*PlaylistTracksResponse, error)
+	GetPlaylistSnapshotID(ctx context.Context, playlistID string) (string, error)
+	AddTracksToPlaylist(ctx context.Context, playlistID string, trackURIs []string) error
+	ReplacePlaylistTracks(ctx context.Context, playlistID string, trackURIs []string) error
+	RemoveTracksFromPlaylist(ctx context.Context, playlistID string, trackURIs []string) error
+	ReorderPlaylistTracks(ctx context.Context, playlistID string, reorder ReorderRequest) (string, error)
+	GetTrack(ctx context.Context, trackID string) (*Track, error)
+	GetSeveralTracks(ctx context.Context, trackIDs []string) ([]*Track, error)
+	SearchTracks(ctx context.Context, query string, limit int) ([]*Track, error)
+}
+
+// Features reads what the filter rules match tracks on besides the track itself: the audio features
+// of the tracks and the genres of their artists
+type Features interface {
+	GetAudioFeatures(ctx context.Context, trackIDs []string) ([]*AudioFeatures, error)
+	GetSeveralArtists(ctx context.Context, artistIDs []string) ([]*Artist, error)
+	GetArtists(ctx context.Context, artistIDs []string) ([]*Artist, error)
+}
@@ -0,0 +1,236 @@
+package musicprovider
+
+import (
+	"context"
+	"fmt"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// Router is the MusicProvider of every streaming service. Each call goes to the provider picked for
+// its context with requestcontext.ContextWithMusicProvider, spotify when none is
+type Router struct {
+	providers map[models.MusicProvider]MusicProvider
+}
+
+func NewRouter(spotify MusicProvider) *Router {
+	return &Router{
+		providers: map[models.MusicProvider]MusicProvider{models.MusicProviderSpotify: spotify},
+	}
+}
+
+// WithProvider routes the calls of contexts picking name to provider
+func (r *Router) WithProvider(name models.MusicProvider, provider MusicProvider) *Router {
+	r.providers[name] = provider
+	return r
+}
+
+// Provider returns the provider the calls made with ctx go to
+func (r *Router) Provider(ctx context.Context) (MusicProvider, error) {
+	name := requestcontext.GetMusicProviderFromContext(ctx)
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderUnavailable, name)
+	}
+
+	return provider, nil
+}
+
+// GenerateAuthURL has no context to pick a provider with, it is the spotify login. Other providers
+// are linked through their own clients
+func (r *Router) GenerateAuthURL(state, codeChallenge string) string {
+	return r.providers[models.MusicProviderSpotify].GenerateAuthURL(state, codeChallenge)
+}
+
+func (r *Router) ExchangeCodeForTokens(ctx context.Context, code, codeVerifier string) (*TokenResponse, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.ExchangeCodeForTokens(ctx, code, codeVerifier)
+}
+
+func (r *Router) RefreshTokens(ctx context.Context, refreshToken string) (*TokenResponse, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.RefreshTokens(ctx, refreshToken)
+}
+
+func (r *Router) GetUserProfile(ctx context.Context) (*UserProfile, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetUserProfile(ctx)
+}
+
+func (r *Router) Ping(ctx context.Context) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.Ping(ctx)
+}
+
+func (r *Router) GetPlaylist(ctx context.Context, playlistId string) (*Playlist, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetPlaylist(ctx, playlistId)
+}
+
+func (r *Router) GetAllUserPlaylists(ctx context.Context) ([]*Playlist, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetAllUserPlaylists(ctx)
+}
+
+func (r *Router) CreatePlaylist(ctx context.Context, name, description string, public bool) (*Playlist, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.CreatePlaylist(ctx, name, description, public)
+}
+
+func (r *Router) DeletePlaylist(ctx context.Context, playlistId string) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.DeletePlaylist(ctx, playlistId)
+}
+
+func (r *Router) FollowPlaylist(ctx context.Context, playlistID string, public bool) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.FollowPlaylist(ctx, playlistID, public)
+}
+
+func (r *Router) UnfollowPlaylist(ctx context.Context, playlistID string) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.UnfollowPlaylist(ctx, playlistID)
+}
+
+func (r *Router) UpdatePlaylist(ctx context.Context, playlistId, name, description string) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.UpdatePlaylist(ctx, playlistId, name, description)
+}
+
+func (r *Router) UploadPlaylistCover(ctx context.Context, playlistID string, jpegBytes []byte) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.UploadPlaylistCover(ctx, playlistID, jpegBytes)
+}
+
+func (r *Router) GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*PlaylistTracksResponse, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetPlaylistTracks(ctx, playlistID, limit, offset)
+}
+
+func (r *Router) GetPlaylistSnapshotID(ctx context.Context, playlistID string) (string, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return "", err
+	}
+	return provider.GetPlaylistSnapshotID(ctx, playlistID)
+}
+
+func (r *Router) AddTracksToPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.AddTracksToPlaylist(ctx, playlistID, trackURIs)
+}
+
+func (r *Router) ReplacePlaylistTracks(ctx context.Context, playlistID string, trackURIs []string) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.ReplacePlaylistTracks(ctx, playlistID, trackURIs)
+}
+
+func (r *Router) RemoveTracksFromPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return err
+	}
+	return provider.RemoveTracksFromPlaylist(ctx, playlistID, trackURIs)
+}
+
+func (r *Router) ReorderPlaylistTracks(ctx context.Context, playlistID string, reorder ReorderRequest) (string, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return "", err
+	}
+	return provider.ReorderPlaylistTracks(ctx, playlistID, reorder)
+}
+
+func (r *Router) GetTrack(ctx context.Context, trackID string) (*Track, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetTrack(ctx, trackID)
+}
+
+func (r *Router) GetSeveralTracks(ctx context.Context, trackIDs []string) ([]*Track, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetSeveralTracks(ctx, trackIDs)
+}
+
+func (r *Router) SearchTracks(ctx context.Context, query string, limit int) ([]*Track, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.SearchTracks(ctx, query, limit)
+}
+
+func (r *Router) GetAudioFeatures(ctx context.Context, trackIDs []string) ([]*AudioFeatures, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetAudioFeatures(ctx, trackIDs)
+}
+
+func (r *Router) GetSeveralArtists(ctx context.Context, artistIDs []string) ([]*Artist, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetSeveralArtists(ctx, artistIDs)
+}
+
+func (r *Router) GetArtists(ctx context.Context, artistIDs []string) ([]*Artist, error) {
+	provider, err := r.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetArtists(ctx, artistIDs)
+}
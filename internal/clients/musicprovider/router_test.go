@@ -0,0 +1,51 @@
+package musicprovider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_RoutesByContextProvider(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	spotify := mocks.NewMockMusicProvider(ctrl)
+	youTubeMusic := mocks.NewMockMusicProvider(ctrl)
+	router := musicprovider.NewRouter(spotify).WithProvider(models.MusicProviderYouTubeMusic, youTubeMusic)
+
+	spotifyCtx := context.Background()
+	youTubeMusicCtx := requestcontext.ContextWithMusicProvider(spotifyCtx, models.MusicProviderYouTubeMusic)
+
+	spotify.EXPECT().GetPlaylist(spotifyCtx, "spotify_playlist").Return(&musicprovider.Playlist{ID: "spotify_playlist"}, nil)
+	youTubeMusic.EXPECT().GetPlaylist(youTubeMusicCtx, "youtube_playlist").Return(&musicprovider.Playlist{ID: "youtube_playlist"}, nil)
+	spotify.EXPECT().GenerateAuthURL("state", "challenge").Return("https://accounts.spotify.com/authorize")
+
+	playlist, err := router.GetPlaylist(spotifyCtx, "spotify_playlist")
+	assert.NoError(err)
+	assert.Equal("spotify_playlist", playlist.ID)
+
+	playlist, err = router.GetPlaylist(youTubeMusicCtx, "youtube_playlist")
+	assert.NoError(err)
+	assert.Equal("youtube_playlist", playlist.ID)
+
+	assert.Equal("https://accounts.spotify.com/authorize", router.GenerateAuthURL("state", "challenge"))
+}
+
+func TestRouter_ProviderUnavailable(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	router := musicprovider.NewRouter(mocks.NewMockMusicProvider(ctrl))
+	ctx := requestcontext.ContextWithMusicProvider(context.Background(), models.MusicProviderYouTubeMusic)
+
+	err := router.AddTracksToPlaylist(ctx, "playlist", []string{"track"})
+
+	assert.ErrorIs(err, musicprovider.ErrProviderUnavailable)
+}
@@ -0,0 +1,108 @@
+package notifierclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+const (
+	// MAX_DISCORD_EMBED_FIELDS is the most fields Discord accepts in an embed
+	MAX_DISCORD_EMBED_FIELDS = 25
+	// Discord rejects embeds with longer field names and values
+	MAX_DISCORD_FIELD_NAME_LENGTH  = 256
+	MAX_DISCORD_FIELD_VALUE_LENGTH = 1024
+
+	discordColorSuccess = 0x1DB954
+	discordColorWarning = 0xF5A623
+	discordColorFailure = 0xE22134
+)
+
+var discordWebhookURLPrefixes = []string{
+	"https://discord.com/api/webhooks/",
+	"https://discordapp.com/api/webhooks/",
+}
+
+// DiscordNotifier posts sync summaries to Discord webhooks as an embed, a field per child playlist
+type DiscordNotifier struct {
+	httpClient clients.HTTPClient
+}
+
+func NewDiscordNotifier(httpClient clients.HTTPClient) *DiscordNotifier {
+	return &DiscordNotifier{httpClient: httpClient}
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Color       int                 `json:"color"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (n *DiscordNotifier) Channel() models.NotificationChannel {
+	return models.NotificationChannelDiscord
+}
+
+func (n *DiscordNotifier) AcceptsWebhookURL(webhookURL string) bool {
+	return hasAnyPrefix(webhookURL, discordWebhookURLPrefixes...)
+}
+
+func (n *DiscordNotifier) NotifySync(ctx context.Context, webhookURL string, summary *models.SyncNotification) error {
+	if err := postJSON(ctx, n.httpClient, webhookURL, discordMessage{Embeds: []discordEmbed{discordSummaryEmbed(summary)}}); err != nil {
+		return fmt.Errorf("failed to post discord notification: %w", err)
+	}
+	return nil
+}
+
+func discordSummaryEmbed(summary *models.SyncNotification) discordEmbed {
+	embed := discordEmbed{
+		Title:       summaryTitle(summary),
+		Description: summaryStats(summary),
+		Color:       discordColorSuccess,
+	}
+
+	switch summary.Status {
+	case models.SyncStatusFailed:
+		embed.Color = discordColorFailure
+	case models.SyncStatusNeedsConfirmation:
+		embed.Color = discordColorWarning
+	}
+
+	if summary.ErrorMessage != "" {
+		embed.Fields = append(embed.Fields, discordEmbedField{Name: "Error", Value: truncate(summary.ErrorMessage, MAX_DISCORD_FIELD_VALUE_LENGTH)})
+	}
+
+	for _, child := range summary.Children {
+		if len(embed.Fields) == MAX_DISCORD_EMBED_FIELDS {
+			break
+		}
+		embed.Fields = append(embed.Fields, discordEmbedField{
+			Name:   truncate(child.Name, MAX_DISCORD_FIELD_NAME_LENGTH),
+			Value:  truncate(childSummary(child), MAX_DISCORD_FIELD_VALUE_LENGTH),
+			Inline: true,
+		})
+	}
+
+	return embed
+}
+
+// truncate cuts text to at most maxLength characters, ending it with an ellipsis when cut
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
@@ -0,0 +1,94 @@
+package notifierclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=notifier.go -destination=mocks/mock_notifier.go -package=mocks
+
+// NOTIFIER_TIMEOUT bounds each post, so a slow chat service can't hold the sync back
+const NOTIFIER_TIMEOUT = 5 * time.Second
+
+// Notifier posts sync summaries to a chat service through an incoming webhook of the user
+type Notifier interface {
+	Channel() models.NotificationChannel
+	// AcceptsWebhookURL tells whether the url is an incoming webhook of the chat service, so
+	// summaries are never posted anywhere else
+	AcceptsWebhookURL(webhookURL string) bool
+	NotifySync(ctx context.Context, webhookURL string, summary *models.SyncNotification) error
+}
+
+func postJSON(ctx context.Context, httpClient clients.HTTPClient, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, NOTIFIER_TIMEOUT)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func hasAnyPrefix(url string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(url, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func summaryTitle(summary *models.SyncNotification) string {
+	outcome := "completed"
+	switch summary.Status {
+	case models.SyncStatusFailed:
+		outcome = "failed"
+	case models.SyncStatusNeedsConfirmation:
+		outcome = "needs confirmation"
+	}
+
+	return fmt.Sprintf("Sync of %s %s", summary.BasePlaylistName, outcome)
+}
+
+func summaryStats(summary *models.SyncNotification) string {
+	return fmt.Sprintf("%d tracks processed, %d unmatched in %s",
+		summary.TracksProcessed,
+		summary.TracksUnmatched,
+		summary.Duration.Round(100*time.Millisecond),
+	)
+}
+
+func childSummary(child models.ChildSyncNotification) string {
+	if child.Status == models.SyncStatusFailed {
+		return "failed: " + child.ErrorMessage
+	}
+	return fmt.Sprintf("+%d / -%d tracks", child.TracksAdded, child.TracksRemoved)
+}
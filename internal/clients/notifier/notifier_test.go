@@ -0,0 +1,133 @@
+package notifierclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func testSyncNotification() *models.SyncNotification {
+	return &models.SyncNotification{
+		SyncEventID:      "sync123",
+		BasePlaylistID:   "base123",
+		BasePlaylistName: "Liked <Songs>",
+		Status:           models.SyncStatusFailed,
+		Duration:         12340 * time.Millisecond,
+		TracksProcessed:  150,
+		TracksUnmatched:  7,
+		Children: []models.ChildSyncNotification{
+			{Name: "Workout", Status: models.SyncStatusCompleted, TracksAdded: 5, TracksRemoved: 2},
+			{Name: "Chill", Status: models.SyncStatusFailed, ErrorMessage: "playlist not found"},
+		},
+		ErrorMessage: "1 child playlist failed to sync",
+	}
+}
+
+// expectPost captures the body posted to url, answering with status
+func expectPost(t *testing.T, url string, status int) (*mocks.MockHTTPClient, *[]byte) {
+	httpClient := mocks.NewMockHTTPClient(gomock.NewController(t))
+	body := []byte{}
+
+	httpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, url, req.URL.String())
+		require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+		var err error
+		body, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	return httpClient, &body
+}
+
+func TestSlackNotifier_NotifySync(t *testing.T) {
+	assert := require.New(t)
+	webhookURL := "https://hooks.slack.com/services/T000/B000/XXXX"
+	httpClient, body := expectPost(t, webhookURL, http.StatusOK)
+
+	err := NewSlackNotifier(httpClient).NotifySync(context.Background(), webhookURL, testSyncNotification())
+	assert.NoError(err)
+
+	var message slackMessage
+	assert.NoError(json.Unmarshal(*body, &message))
+	assert.Equal("*Sync of Liked &lt;Songs&gt; failed*\n"+
+		"150 tracks processed, 7 unmatched in 12.3s\n"+
+		"• Workout: +5 / -2 tracks\n"+
+		"• Chill: failed: playlist not found\n"+
+		">1 child playlist failed to sync", message.Text)
+}
+
+func TestDiscordNotifier_NotifySync(t *testing.T) {
+	assert := require.New(t)
+	webhookURL := "https://discord.com/api/webhooks/123/token"
+	httpClient, body := expectPost(t, webhookURL, http.StatusNoContent)
+
+	err := NewDiscordNotifier(httpClient).NotifySync(context.Background(), webhookURL, testSyncNotification())
+	assert.NoError(err)
+
+	var message discordMessage
+	assert.NoError(json.Unmarshal(*body, &message))
+	assert.Len(message.Embeds, 1)
+
+	embed := message.Embeds[0]
+	assert.Equal("Sync of Liked <Songs> failed", embed.Title)
+	assert.Equal(discordColorFailure, embed.Color)
+	assert.Equal([]discordEmbedField{
+		{Name: "Error", Value: "1 child playlist failed to sync"},
+		{Name: "Workout", Value: "+5 / -2 tracks", Inline: true},
+		{Name: "Chill", Value: "failed: playlist not found", Inline: true},
+	}, embed.Fields)
+}
+
+func TestNotifier_NotifySync_UnexpectedStatus(t *testing.T) {
+	assert := require.New(t)
+	webhookURL := "https://hooks.slack.com/services/T000/B000/XXXX"
+	httpClient, _ := expectPost(t, webhookURL, http.StatusNotFound)
+
+	err := NewSlackNotifier(httpClient).NotifySync(context.Background(), webhookURL, testSyncNotification())
+
+	assert.ErrorContains(err, "unexpected status code 404")
+}
+
+func TestNotifier_AcceptsWebhookURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		notifier   Notifier
+		webhookURL string
+		expected   bool
+	}{
+		{name: "slack", notifier: NewSlackNotifier(nil), webhookURL: "https://hooks.slack.com/services/T000/B000/XXXX", expected: true},
+		{name: "slack over http", notifier: NewSlackNotifier(nil), webhookURL: "http://hooks.slack.com/services/T000/B000/XXXX"},
+		{name: "slack lookalike host", notifier: NewSlackNotifier(nil), webhookURL: "https://hooks.slack.com.example.com/services"},
+		{name: "discord", notifier: NewDiscordNotifier(nil), webhookURL: "https://discord.com/api/webhooks/123/token", expected: true},
+		{name: "legacy discord domain", notifier: NewDiscordNotifier(nil), webhookURL: "https://discordapp.com/api/webhooks/123/token", expected: true},
+		{name: "discord url for slack", notifier: NewSlackNotifier(nil), webhookURL: "https://discord.com/api/webhooks/123/token"},
+		{name: "internal address", notifier: NewDiscordNotifier(nil), webhookURL: "http://169.254.169.254/latest/meta-data"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.notifier.AcceptsWebhookURL(tt.webhookURL))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal("short", truncate("short", 10))
+	assert.Equal("abcd…", truncate("abcdefgh", 5))
+	assert.Equal("ñññ…", truncate("ñññññ", 4))
+}
@@ -0,0 +1,60 @@
+package notifierclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+const SLACK_WEBHOOK_URL_PREFIX = "https://hooks.slack.com/"
+
+// SlackNotifier posts sync summaries to Slack incoming webhooks, formatted with mrkdwn
+type SlackNotifier struct {
+	httpClient clients.HTTPClient
+}
+
+func NewSlackNotifier(httpClient clients.HTTPClient) *SlackNotifier {
+	return &SlackNotifier{httpClient: httpClient}
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func (n *SlackNotifier) Channel() models.NotificationChannel {
+	return models.NotificationChannelSlack
+}
+
+func (n *SlackNotifier) AcceptsWebhookURL(webhookURL string) bool {
+	return hasAnyPrefix(webhookURL, SLACK_WEBHOOK_URL_PREFIX)
+}
+
+func (n *SlackNotifier) NotifySync(ctx context.Context, webhookURL string, summary *models.SyncNotification) error {
+	if err := postJSON(ctx, n.httpClient, webhookURL, slackMessage{Text: slackText(summary)}); err != nil {
+		return fmt.Errorf("failed to post slack notification: %w", err)
+	}
+	return nil
+}
+
+func slackText(summary *models.SyncNotification) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%s*\n%s", slackEscape(summaryTitle(summary)), summaryStats(summary))
+
+	for _, child := range summary.Children {
+		fmt.Fprintf(&text, "\n• %s: %s", slackEscape(child.Name), slackEscape(childSummary(child)))
+	}
+
+	if summary.ErrorMessage != "" {
+		fmt.Fprintf(&text, "\n>%s", slackEscape(summary.ErrorMessage))
+	}
+
+	return text.String()
+}
+
+// slackEscape escapes the characters Slack reads as markup, as its docs require
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
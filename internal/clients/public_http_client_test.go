@@ -0,0 +1,84 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := f[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestValidatePublicURL(t *testing.T) {
+	resolver := fakeResolver{
+		"hooks.example.com":    {"93.184.216.34"},
+		"internal.example.com": {"10.0.0.5"},
+		"mixed.example.com":    {"93.184.216.34", "127.0.0.1"},
+	}
+
+	tests := []struct {
+		name  string
+		url   string
+		valid bool
+	}{
+		{name: "public host", url: "https://hooks.example.com/hook", valid: true},
+		{name: "public ip", url: "https://93.184.216.34/hook", valid: true},
+		{name: "plain http", url: "http://hooks.example.com/hook"},
+		{name: "loopback", url: "https://127.0.0.1:8090/api"},
+		{name: "ipv6 loopback", url: "https://[::1]/hook"},
+		{name: "ipv4 mapped loopback", url: "https://[::ffff:127.0.0.1]/hook"},
+		{name: "private network", url: "https://192.168.1.10/hook"},
+		{name: "cloud metadata", url: "https://169.254.169.254/latest/meta-data"},
+		{name: "carrier-grade nat", url: "https://100.64.0.1/hook"},
+		{name: "unspecified", url: "https://0.0.0.0/hook"},
+		{name: "host resolving to a private address", url: "https://internal.example.com/hook"},
+		{name: "host resolving to any internal address", url: "https://mixed.example.com/hook"},
+		{name: "unresolvable host", url: "https://missing.example.com/hook"},
+		{name: "not an url", url: "https://"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePublicURL(context.Background(), resolver, tt.url)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrNonPublicAddress)
+			}
+		})
+	}
+}
+
+func TestNewPublicHTTPClient_RefusesInternalAddresses(t *testing.T) {
+	assert := require.New(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewPublicHTTPClient(time.Second)
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	assert.NoError(err)
+
+	// The test server listens on loopback, which the dialer refuses whatever the url looked like
+	_, err = client.Do(req)
+	assert.ErrorIs(err, ErrNonPublicAddress)
+}
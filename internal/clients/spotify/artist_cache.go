@@ -0,0 +1,83 @@
+package spotifyclient
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// ARTIST_CACHE_TTL bounds how stale cached artist genres and popularity can get
+	ARTIST_CACHE_TTL = 24 * time.Hour
+	// ARTIST_CACHE_MAX_ENTRIES bounds the memory used by the artist cache
+	ARTIST_CACHE_MAX_ENTRIES = 20000
+)
+
+type cachedArtist struct {
+	artist    *SpotifyArtist
+	expiresAt time.Time
+}
+
+// artistCache keeps artists looked up from Spotify in memory. Artist data is public, so
+// entries are shared between users
+type artistCache struct {
+	mu      sync.Mutex
+	artists map[string]cachedArtist
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func newArtistCache(ttl time.Duration) *artistCache {
+	return &artistCache{
+		artists: make(map[string]cachedArtist),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// get returns the cached artists and the IDs that have to be fetched from Spotify
+func (ac *artistCache) get(artistIDs []string) ([]*SpotifyArtist, []string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	now := ac.now()
+	found := make([]*SpotifyArtist, 0, len(artistIDs))
+	missing := make([]string, 0, len(artistIDs))
+
+	for _, artistID := range artistIDs {
+		entry, ok := ac.artists[artistID]
+		if !ok || now.After(entry.expiresAt) {
+			missing = append(missing, artistID)
+			continue
+		}
+
+		found = append(found, entry.artist)
+	}
+
+	return found, missing
+}
+
+func (ac *artistCache) set(artists []*SpotifyArtist) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	now := ac.now()
+	if len(ac.artists)+len(artists) > ARTIST_CACHE_MAX_ENTRIES {
+		ac.evictExpired(now)
+	}
+	if len(ac.artists)+len(artists) > ARTIST_CACHE_MAX_ENTRIES {
+		ac.artists = make(map[string]cachedArtist)
+	}
+
+	expiresAt := now.Add(ac.ttl)
+	for _, artist := range artists {
+		ac.artists[artist.ID] = cachedArtist{artist: artist, expiresAt: expiresAt}
+	}
+}
+
+func (ac *artistCache) evictExpired(now time.Time) {
+	for artistID, entry := range ac.artists {
+		if now.After(entry.expiresAt) {
+			delete(ac.artists, artistID)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package spotifyclient
+
+import (
+	"context"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// CredentialsProvider resolves the Spotify credentials a client request is sent with. Every
+// method of SpotifyAPI acting on behalf of a user gets them this way, none takes a token
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (*models.SpotifyIntegration, error)
+}
+
+// ContextCredentialsProvider reads the Spotify integration stored in the request context, by
+// the auth middleware for API requests or ContextWithSpotifyAuth for background jobs
+type ContextCredentialsProvider struct{}
+
+func (ContextCredentialsProvider) Credentials(ctx context.Context) (*models.SpotifyIntegration, error) {
+	integration, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
+	if !ok {
+		return nil, ErrSpotifyCredentialsNotFound
+	}
+
+	return integration, nil
+}
@@ -0,0 +1,78 @@
+package spotifyclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+type staticCredentialsProvider struct {
+	integration *models.SpotifyIntegration
+	err         error
+}
+
+func (p staticCredentialsProvider) Credentials(ctx context.Context) (*models.SpotifyIntegration, error) {
+	return p.integration, p.err
+}
+
+func TestContextCredentialsProvider_Credentials(t *testing.T) {
+	assert := require.New(t)
+
+	provider := ContextCredentialsProvider{}
+
+	integration, err := provider.Credentials(contextWithTokenAndID("valid_token", "user123"))
+	assert.NoError(err)
+	assert.Equal("valid_token", integration.AccessToken)
+	assert.Equal("user123", integration.UserID)
+
+	integration, err = provider.Credentials(context.Background())
+	assert.ErrorIs(err, ErrSpotifyCredentialsNotFound)
+	assert.Nil(integration)
+}
+
+func TestSpotifyClient_CredentialsProvider(t *testing.T) {
+	t.Run("requests are sent with the provided credentials", func(t *testing.T) {
+		assert := require.New(t)
+
+		ctrl := setupMockController(t)
+		mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+
+		client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+		client.HttpClient = mockHTTPClient
+		client.credentials = staticCredentialsProvider{integration: &models.SpotifyIntegration{AccessToken: "provided_token", UserID: "user123"}}
+
+		mockHTTPClient.EXPECT().
+			Do(gomock.Any()).
+			DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal("Bearer provided_token", req.Header.Get("Authorization"))
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}).
+			Times(1)
+
+		assert.NoError(client.UnfollowPlaylist(context.Background(), "playlist456"))
+	})
+
+	t.Run("provider errors are returned without sending the request", func(t *testing.T) {
+		assert := require.New(t)
+
+		ctrl := setupMockController(t)
+		mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+
+		providerErr := errors.New("credentials unavailable")
+		client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+		client.HttpClient = mockHTTPClient
+		client.credentials = staticCredentialsProvider{err: providerErr}
+
+		_, err := client.GetUserProfile(context.Background())
+		assert.ErrorIs(err, providerErr)
+	})
+}
@@ -1,7 +1,16 @@
 package spotifyclient
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+)
 
 var (
 	ErrSpotifyCredentialsNotFound = errors.New("spotify credentials not found in context")
+	ErrRateLimited                = fmt.Errorf("spotify %w", musicprovider.ErrRateLimited)
+	ErrCoverImageTooLarge         = musicprovider.ErrCoverImageTooLarge
+	ErrTrackNotFound              = fmt.Errorf("spotify %w", musicprovider.ErrTrackNotFound)
+	ErrPlaylistNotFound           = fmt.Errorf("spotify %w", musicprovider.ErrPlaylistNotFound)
 )
@@ -9,7 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	musicprovider "github.com/ngomez18/playlist-router/internal/clients/musicprovider"
 )
 
 // MockSpotifyAPI is a mock of SpotifyAPI interface.
@@ -50,10 +50,10 @@ func (mr *MockSpotifyAPIMockRecorder) AddTracksToPlaylist(ctx, playlistID, track
 }
 
 // CreatePlaylist mocks base method.
-func (m *MockSpotifyAPI) CreatePlaylist(ctx context.Context, name, description string, public bool) (*spotifyclient.SpotifyPlaylist, error) {
+func (m *MockSpotifyAPI) CreatePlaylist(ctx context.Context, name, description string, public bool) (*musicprovider.Playlist, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, name, description, public)
-	ret0, _ := ret[0].(*spotifyclient.SpotifyPlaylist)
+	ret0, _ := ret[0].(*musicprovider.Playlist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -79,39 +79,53 @@ func (mr *MockSpotifyAPIMockRecorder) DeletePlaylist(ctx, playlistId interface{}
 }
 
 // ExchangeCodeForTokens mocks base method.
-func (m *MockSpotifyAPI) ExchangeCodeForTokens(ctx context.Context, code string) (*spotifyclient.SpotifyTokenResponse, error) {
+func (m *MockSpotifyAPI) ExchangeCodeForTokens(ctx context.Context, code, codeVerifier string) (*musicprovider.TokenResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExchangeCodeForTokens", ctx, code)
-	ret0, _ := ret[0].(*spotifyclient.SpotifyTokenResponse)
+	ret := m.ctrl.Call(m, "ExchangeCodeForTokens", ctx, code, codeVerifier)
+	ret0, _ := ret[0].(*musicprovider.TokenResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ExchangeCodeForTokens indicates an expected call of ExchangeCodeForTokens.
-func (mr *MockSpotifyAPIMockRecorder) ExchangeCodeForTokens(ctx, code interface{}) *gomock.Call {
+func (mr *MockSpotifyAPIMockRecorder) ExchangeCodeForTokens(ctx, code, codeVerifier interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeCodeForTokens", reflect.TypeOf((*MockSpotifyAPI)(nil).ExchangeCodeForTokens), ctx, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeCodeForTokens", reflect.TypeOf((*MockSpotifyAPI)(nil).ExchangeCodeForTokens), ctx, code, codeVerifier)
+}
+
+// FollowPlaylist mocks base method.
+func (m *MockSpotifyAPI) FollowPlaylist(ctx context.Context, playlistID string, public bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowPlaylist", ctx, playlistID, public)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowPlaylist indicates an expected call of FollowPlaylist.
+func (mr *MockSpotifyAPIMockRecorder) FollowPlaylist(ctx, playlistID, public interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowPlaylist", reflect.TypeOf((*MockSpotifyAPI)(nil).FollowPlaylist), ctx, playlistID, public)
 }
 
 // GenerateAuthURL mocks base method.
-func (m *MockSpotifyAPI) GenerateAuthURL(state string) string {
+func (m *MockSpotifyAPI) GenerateAuthURL(state, codeChallenge string) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateAuthURL", state)
+	ret := m.ctrl.Call(m, "GenerateAuthURL", state, codeChallenge)
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
 // GenerateAuthURL indicates an expected call of GenerateAuthURL.
-func (mr *MockSpotifyAPIMockRecorder) GenerateAuthURL(state interface{}) *gomock.Call {
+func (mr *MockSpotifyAPIMockRecorder) GenerateAuthURL(state, codeChallenge interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAuthURL", reflect.TypeOf((*MockSpotifyAPI)(nil).GenerateAuthURL), state)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAuthURL", reflect.TypeOf((*MockSpotifyAPI)(nil).GenerateAuthURL), state, codeChallenge)
 }
 
 // GetAllUserPlaylists mocks base method.
-func (m *MockSpotifyAPI) GetAllUserPlaylists(ctx context.Context) ([]*spotifyclient.SpotifyPlaylist, error) {
+func (m *MockSpotifyAPI) GetAllUserPlaylists(ctx context.Context) ([]*musicprovider.Playlist, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllUserPlaylists", ctx)
-	ret0, _ := ret[0].([]*spotifyclient.SpotifyPlaylist)
+	ret0, _ := ret[0].([]*musicprovider.Playlist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -122,11 +136,86 @@ func (mr *MockSpotifyAPIMockRecorder) GetAllUserPlaylists(ctx interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserPlaylists", reflect.TypeOf((*MockSpotifyAPI)(nil).GetAllUserPlaylists), ctx)
 }
 
+// GetArtists mocks base method.
+func (m *MockSpotifyAPI) GetArtists(ctx context.Context, artistIDs []string) ([]*musicprovider.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtists", ctx, artistIDs)
+	ret0, _ := ret[0].([]*musicprovider.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtists indicates an expected call of GetArtists.
+func (mr *MockSpotifyAPIMockRecorder) GetArtists(ctx, artistIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtists", reflect.TypeOf((*MockSpotifyAPI)(nil).GetArtists), ctx, artistIDs)
+}
+
+// GetAudioFeatures mocks base method.
+func (m *MockSpotifyAPI) GetAudioFeatures(ctx context.Context, trackIDs []string) ([]*musicprovider.AudioFeatures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAudioFeatures", ctx, trackIDs)
+	ret0, _ := ret[0].([]*musicprovider.AudioFeatures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAudioFeatures indicates an expected call of GetAudioFeatures.
+func (mr *MockSpotifyAPIMockRecorder) GetAudioFeatures(ctx, trackIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAudioFeatures", reflect.TypeOf((*MockSpotifyAPI)(nil).GetAudioFeatures), ctx, trackIDs)
+}
+
+// GetPlaylistSnapshotID mocks base method.
+func (m *MockSpotifyAPI) GetPlaylistSnapshotID(ctx context.Context, playlistID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistSnapshotID", ctx, playlistID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistSnapshotID indicates an expected call of GetPlaylistSnapshotID.
+func (mr *MockSpotifyAPIMockRecorder) GetPlaylistSnapshotID(ctx, playlistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistSnapshotID", reflect.TypeOf((*MockSpotifyAPI)(nil).GetPlaylistSnapshotID), ctx, playlistID)
+}
+
+// GetSeveralTracks mocks base method.
+func (m *MockSpotifyAPI) GetSeveralTracks(ctx context.Context, trackIDs []string) ([]*musicprovider.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeveralTracks", ctx, trackIDs)
+	ret0, _ := ret[0].([]*musicprovider.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeveralTracks indicates an expected call of GetSeveralTracks.
+func (mr *MockSpotifyAPIMockRecorder) GetSeveralTracks(ctx, trackIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeveralTracks", reflect.TypeOf((*MockSpotifyAPI)(nil).GetSeveralTracks), ctx, trackIDs)
+}
+
+// GetTrack mocks base method.
+func (m *MockSpotifyAPI) GetTrack(ctx context.Context, trackID string) (*musicprovider.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrack", ctx, trackID)
+	ret0, _ := ret[0].(*musicprovider.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrack indicates an expected call of GetTrack.
+func (mr *MockSpotifyAPIMockRecorder) GetTrack(ctx, trackID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrack", reflect.TypeOf((*MockSpotifyAPI)(nil).GetTrack), ctx, trackID)
+}
+
 // GetPlaylist mocks base method.
-func (m *MockSpotifyAPI) GetPlaylist(ctx context.Context, playlistId string) (*spotifyclient.SpotifyPlaylist, error) {
+func (m *MockSpotifyAPI) GetPlaylist(ctx context.Context, playlistId string) (*musicprovider.Playlist, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPlaylist", ctx, playlistId)
-	ret0, _ := ret[0].(*spotifyclient.SpotifyPlaylist)
+	ret0, _ := ret[0].(*musicprovider.Playlist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -138,10 +227,10 @@ func (mr *MockSpotifyAPIMockRecorder) GetPlaylist(ctx, playlistId interface{}) *
 }
 
 // GetPlaylistTracks mocks base method.
-func (m *MockSpotifyAPI) GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*spotifyclient.SpotifyPlaylistTracksResponse, error) {
+func (m *MockSpotifyAPI) GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*musicprovider.PlaylistTracksResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPlaylistTracks", ctx, playlistID, limit, offset)
-	ret0, _ := ret[0].(*spotifyclient.SpotifyPlaylistTracksResponse)
+	ret0, _ := ret[0].(*musicprovider.PlaylistTracksResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -153,10 +242,10 @@ func (mr *MockSpotifyAPIMockRecorder) GetPlaylistTracks(ctx, playlistID, limit,
 }
 
 // GetSeveralArtists mocks base method.
-func (m *MockSpotifyAPI) GetSeveralArtists(ctx context.Context, artistIDs []string) ([]*spotifyclient.SpotifyArtist, error) {
+func (m *MockSpotifyAPI) GetSeveralArtists(ctx context.Context, artistIDs []string) ([]*musicprovider.Artist, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSeveralArtists", ctx, artistIDs)
-	ret0, _ := ret[0].([]*spotifyclient.SpotifyArtist)
+	ret0, _ := ret[0].([]*musicprovider.Artist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -168,25 +257,39 @@ func (mr *MockSpotifyAPIMockRecorder) GetSeveralArtists(ctx, artistIDs interface
 }
 
 // GetUserProfile mocks base method.
-func (m *MockSpotifyAPI) GetUserProfile(ctx context.Context, accessToken string) (*spotifyclient.SpotifyUserProfile, error) {
+func (m *MockSpotifyAPI) GetUserProfile(ctx context.Context) (*musicprovider.UserProfile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserProfile", ctx, accessToken)
-	ret0, _ := ret[0].(*spotifyclient.SpotifyUserProfile)
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx)
+	ret0, _ := ret[0].(*musicprovider.UserProfile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserProfile indicates an expected call of GetUserProfile.
-func (mr *MockSpotifyAPIMockRecorder) GetUserProfile(ctx, accessToken interface{}) *gomock.Call {
+func (mr *MockSpotifyAPIMockRecorder) GetUserProfile(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockSpotifyAPI)(nil).GetUserProfile), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockSpotifyAPI) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockSpotifyAPIMockRecorder) Ping(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockSpotifyAPI)(nil).GetUserProfile), ctx, accessToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockSpotifyAPI)(nil).Ping), ctx)
 }
 
 // RefreshTokens mocks base method.
-func (m *MockSpotifyAPI) RefreshTokens(ctx context.Context, refreshToken string) (*spotifyclient.SpotifyTokenResponse, error) {
+func (m *MockSpotifyAPI) RefreshTokens(ctx context.Context, refreshToken string) (*musicprovider.TokenResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RefreshTokens", ctx, refreshToken)
-	ret0, _ := ret[0].(*spotifyclient.SpotifyTokenResponse)
+	ret0, _ := ret[0].(*musicprovider.TokenResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -197,6 +300,78 @@ func (mr *MockSpotifyAPIMockRecorder) RefreshTokens(ctx, refreshToken interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockSpotifyAPI)(nil).RefreshTokens), ctx, refreshToken)
 }
 
+// RemoveTracksFromPlaylist mocks base method.
+func (m *MockSpotifyAPI) RemoveTracksFromPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTracksFromPlaylist", ctx, playlistID, trackURIs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTracksFromPlaylist indicates an expected call of RemoveTracksFromPlaylist.
+func (mr *MockSpotifyAPIMockRecorder) RemoveTracksFromPlaylist(ctx, playlistID, trackURIs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTracksFromPlaylist", reflect.TypeOf((*MockSpotifyAPI)(nil).RemoveTracksFromPlaylist), ctx, playlistID, trackURIs)
+}
+
+// ReorderPlaylistTracks mocks base method.
+func (m *MockSpotifyAPI) ReorderPlaylistTracks(ctx context.Context, playlistID string, reorder musicprovider.ReorderRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderPlaylistTracks", ctx, playlistID, reorder)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderPlaylistTracks indicates an expected call of ReorderPlaylistTracks.
+func (mr *MockSpotifyAPIMockRecorder) ReorderPlaylistTracks(ctx, playlistID, reorder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderPlaylistTracks", reflect.TypeOf((*MockSpotifyAPI)(nil).ReorderPlaylistTracks), ctx, playlistID, reorder)
+}
+
+// ReplacePlaylistTracks mocks base method.
+func (m *MockSpotifyAPI) ReplacePlaylistTracks(ctx context.Context, playlistID string, trackURIs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePlaylistTracks", ctx, playlistID, trackURIs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePlaylistTracks indicates an expected call of ReplacePlaylistTracks.
+func (mr *MockSpotifyAPIMockRecorder) ReplacePlaylistTracks(ctx, playlistID, trackURIs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePlaylistTracks", reflect.TypeOf((*MockSpotifyAPI)(nil).ReplacePlaylistTracks), ctx, playlistID, trackURIs)
+}
+
+// SearchTracks mocks base method.
+func (m *MockSpotifyAPI) SearchTracks(ctx context.Context, query string, limit int) ([]*musicprovider.Track, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTracks", ctx, query, limit)
+	ret0, _ := ret[0].([]*musicprovider.Track)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTracks indicates an expected call of SearchTracks.
+func (mr *MockSpotifyAPIMockRecorder) SearchTracks(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTracks", reflect.TypeOf((*MockSpotifyAPI)(nil).SearchTracks), ctx, query, limit)
+}
+
+// UnfollowPlaylist mocks base method.
+func (m *MockSpotifyAPI) UnfollowPlaylist(ctx context.Context, playlistID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowPlaylist", ctx, playlistID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowPlaylist indicates an expected call of UnfollowPlaylist.
+func (mr *MockSpotifyAPIMockRecorder) UnfollowPlaylist(ctx, playlistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowPlaylist", reflect.TypeOf((*MockSpotifyAPI)(nil).UnfollowPlaylist), ctx, playlistID)
+}
+
 // UpdatePlaylist mocks base method.
 func (m *MockSpotifyAPI) UpdatePlaylist(ctx context.Context, playlistId, name, description string) error {
 	m.ctrl.T.Helper()
@@ -210,3 +385,17 @@ func (mr *MockSpotifyAPIMockRecorder) UpdatePlaylist(ctx, playlistId, name, desc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaylist", reflect.TypeOf((*MockSpotifyAPI)(nil).UpdatePlaylist), ctx, playlistId, name, description)
 }
+
+// UploadPlaylistCover mocks base method.
+func (m *MockSpotifyAPI) UploadPlaylistCover(ctx context.Context, playlistID string, jpegBytes []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPlaylistCover", ctx, playlistID, jpegBytes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadPlaylistCover indicates an expected call of UploadPlaylistCover.
+func (mr *MockSpotifyAPIMockRecorder) UploadPlaylistCover(ctx, playlistID, jpegBytes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPlaylistCover", reflect.TypeOf((*MockSpotifyAPI)(nil).UploadPlaylistCover), ctx, playlistID, jpegBytes)
+}
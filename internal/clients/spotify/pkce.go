@@ -0,0 +1,27 @@
+package spotifyclient
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// PKCE_CHALLENGE_METHOD is the only code challenge method spotify supports
+const PKCE_CHALLENGE_METHOD = "S256"
+
+// GeneratePKCEVerifier returns a random code verifier for the Authorization Code with PKCE flow.
+// 32 random bytes encode to 43 characters, the shortest verifier RFC 7636 allows
+func GeneratePKCEVerifier() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// PKCEChallenge derives the S256 code challenge sent in the authorization URL from the verifier
+func PKCEChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,29 @@
+package spotifyclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePKCEVerifier(t *testing.T) {
+	assert := require.New(t)
+
+	verifier, err := GeneratePKCEVerifier()
+	assert.NoError(err)
+	assert.Len(verifier, 43)
+	assert.Regexp(`^[A-Za-z0-9_-]+$`, verifier)
+
+	other, err := GeneratePKCEVerifier()
+	assert.NoError(err)
+	assert.NotEqual(verifier, other)
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	assert := require.New(t)
+
+	// BASE64URL(SHA256(verifier)) without padding
+	challenge := PKCEChallenge("dBjftJeZ4CVP-mJ0Y1bqlgSzYhtK2Y8Ejrdbf3l2jXg")
+
+	assert.Equal("-duDkiTJ4dOSajsRyzIldhLZmCbcpIkOF2ZatJCoH0U", challenge)
+}
@@ -0,0 +1,135 @@
+package spotifyclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/profiling"
+)
+
+const (
+	// MAX_RATE_LIMIT_RETRIES bounds the retries of a request answered with 429 Too Many Requests
+	MAX_RATE_LIMIT_RETRIES = 3
+	// MAX_RETRY_AFTER is the longest Retry-After honored, requests asked to wait longer fail right away
+	MAX_RETRY_AFTER = 60 * time.Second
+	// RATE_LIMIT_BASE_BACKOFF is the wait before the first retry, doubled on every retry. Spotify
+	// asking for a longer wait through Retry-After takes precedence
+	RATE_LIMIT_BASE_BACKOFF = time.Second
+)
+
+type rateLimitStatsContextKey struct{}
+
+// RateLimitStats counts the rate limiting met by the requests of a context, so a sync can report
+// how much Spotify slowed it down. The zero value is ready to use
+type RateLimitStats struct {
+	mu    sync.Mutex
+	stats models.SyncRateLimitStats
+}
+
+// ContextWithRateLimitStats makes the requests done with ctx record the rate limiting they meet in stats
+func ContextWithRateLimitStats(ctx context.Context, stats *RateLimitStats) context.Context {
+	return context.WithValue(ctx, rateLimitStatsContextKey{}, stats)
+}
+
+// Summary returns the recorded rate limiting, nil when the requests never met any
+func (s *RateLimitStats) Summary() *models.SyncRateLimitStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stats == (models.SyncRateLimitStats{}) {
+		return nil
+	}
+
+	summary := s.stats
+	return &summary
+}
+
+func (s *RateLimitStats) record(update func(stats *models.SyncRateLimitStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update(&s.stats)
+}
+
+func recordRateLimit(ctx context.Context, update func(stats *models.SyncRateLimitStats)) {
+	if stats, ok := ctx.Value(rateLimitStatsContextKey{}).(*RateLimitStats); ok {
+		stats.record(update)
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request answered with 429, or false when
+// it should not be retried. Waits are jittered so concurrent requests don't all retry at once
+func (c *SpotifyClient) retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if attempt >= MAX_RATE_LIMIT_RETRIES {
+		return 0, false
+	}
+
+	wait := c.rateLimitBackoff << attempt
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		if retryAfter > MAX_RETRY_AFTER {
+			return 0, false
+		}
+		wait = max(wait, retryAfter)
+	}
+
+	if jitter := int64(wait / 4); jitter > 0 {
+		wait += time.Duration(rand.Int64N(jitter))
+	}
+
+	return wait, true
+}
+
+// parseRetryAfter reads a Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// waitToRetry sleeps before a rate limited request is retried and rewinds its body
+func (c *SpotifyClient) waitToRetry(req *http.Request, wait time.Duration) (*http.Request, error) {
+	ctx := req.Context()
+
+	_, endSpan := profiling.StartSpan(ctx, "rate_limit_retry")
+	defer endSpan()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(wait):
+	}
+
+	recordRateLimit(ctx, func(stats *models.SyncRateLimitStats) {
+		stats.Retries++
+		stats.WaitMs += wait.Milliseconds()
+	})
+
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+
+	return retry, nil
+}
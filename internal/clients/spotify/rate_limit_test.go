@@ -0,0 +1,208 @@
+package spotifyclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedTestClient(t *testing.T) (*SpotifyClient, *mocks.MockHTTPClient) {
+	mockHTTPClient := mocks.NewMockHTTPClient(setupMockController(t))
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+	client.HttpClient = mockHTTPClient
+	client.rateLimitBackoff = time.Millisecond
+
+	return client, mockHTTPClient
+}
+
+func tooManyRequestsResponse(retryAfter string) *http.Response {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader([]byte(`{"error":{"status":429,"message":"API rate limit exceeded"}}`))),
+	}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
+
+func TestSpotifyClient_RateLimit_RetriesAfterTooManyRequests(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newRateLimitedTestClient(t)
+	gomock.InOrder(
+		mockHTTPClient.EXPECT().Do(gomock.Any()).Return(tooManyRequestsResponse("0"), nil),
+		mockHTTPClient.EXPECT().Do(gomock.Any()).Return(tooManyRequestsResponse(""), nil),
+		mockHTTPClient.EXPECT().Do(gomock.Any()).Return(playlistResponse(), nil),
+	)
+
+	stats := &RateLimitStats{}
+	ctx := ContextWithRateLimitStats(contextWithToken("valid_token"), stats)
+
+	playlist, err := client.GetPlaylist(ctx, "playlist123")
+
+	assert.NoError(err)
+	assert.Equal("playlist123", playlist.ID)
+
+	summary := stats.Summary()
+	assert.NotNil(summary)
+	assert.Equal(2, summary.RateLimitedResponses)
+	assert.Equal(2, summary.Retries)
+	assert.Zero(summary.BudgetWaits)
+}
+
+func TestSpotifyClient_RateLimit_GivesUpAfterMaxRetries(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newRateLimitedTestClient(t)
+	mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) {
+		return tooManyRequestsResponse("0"), nil
+	}).Times(MAX_RATE_LIMIT_RETRIES + 1)
+
+	stats := &RateLimitStats{}
+	ctx := ContextWithRateLimitStats(contextWithToken("valid_token"), stats)
+
+	_, err := client.GetPlaylist(ctx, "playlist123")
+
+	assert.Error(err)
+	assert.Contains(err.Error(), "429")
+	assert.Equal(MAX_RATE_LIMIT_RETRIES+1, stats.Summary().RateLimitedResponses)
+	assert.Equal(MAX_RATE_LIMIT_RETRIES, stats.Summary().Retries)
+}
+
+func TestSpotifyClient_RateLimit_RetryAfterTooLong(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newRateLimitedTestClient(t)
+	mockHTTPClient.EXPECT().Do(gomock.Any()).Return(tooManyRequestsResponse("3600"), nil).Times(1)
+
+	_, err := client.GetPlaylist(contextWithToken("valid_token"), "playlist123")
+
+	assert.Error(err)
+	assert.Contains(err.Error(), "429")
+}
+
+func TestSpotifyClient_RateLimit_ReplaysRequestBody(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newRateLimitedTestClient(t)
+
+	var bodies []string
+	mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(err)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) == 1 {
+			return tooManyRequestsResponse("0"), nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"snapshot_id":"snapshot123"}`)),
+		}, nil
+	}).Times(2)
+
+	err := client.AddTracksToPlaylist(contextWithToken("valid_token"), "playlist123", []string{"spotify:track:1"})
+
+	assert.NoError(err)
+	assert.Len(bodies, 2)
+	assert.Equal(bodies[0], bodies[1])
+	assert.Contains(bodies[1], "spotify:track:1")
+}
+
+func TestSpotifyClient_RateLimit_RetryCancelled(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newRateLimitedTestClient(t)
+	client.rateLimitBackoff = time.Hour
+	mockHTTPClient.EXPECT().Do(gomock.Any()).Return(tooManyRequestsResponse(""), nil).Times(1)
+
+	ctx, cancel := context.WithTimeout(contextWithToken("valid_token"), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetPlaylist(ctx, "playlist123")
+
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestSpotifyClient_RateLimit_RecordsBudgetWaits(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newBudgetedTestClient(t, newRequestBudget(1, 20*time.Millisecond))
+	mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) {
+		return playlistResponse(), nil
+	}).Times(2)
+
+	stats := &RateLimitStats{}
+	ctx := ContextWithRateLimitStats(ContextWithQuotaQueue(contextWithToken("valid_token"), nil), stats)
+
+	for range 2 {
+		_, err := client.GetPlaylist(ctx, "playlist123")
+		assert.NoError(err)
+	}
+
+	summary := stats.Summary()
+	assert.NotNil(summary)
+	assert.Equal(1, summary.BudgetWaits)
+	assert.Zero(summary.RateLimitedResponses)
+}
+
+func TestRateLimitStats_Summary(t *testing.T) {
+	assert := require.New(t)
+
+	stats := &RateLimitStats{}
+	assert.Nil(stats.Summary())
+
+	recordRateLimit(ContextWithRateLimitStats(context.Background(), stats), func(stats *models.SyncRateLimitStats) {
+		stats.RateLimitedResponses++
+	})
+	assert.Equal(&models.SyncRateLimitStats{RateLimitedResponses: 1}, stats.Summary())
+
+	// Contexts without stats ignore the rate limiting
+	recordRateLimit(context.Background(), func(stats *models.SyncRateLimitStats) {
+		stats.RateLimitedResponses++
+	})
+	assert.Equal(1, stats.Summary().RateLimitedResponses)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "missing", header: ""},
+		{name: "seconds", header: "5", expected: 5 * time.Second, ok: true},
+		{name: "zero seconds", header: "0", ok: true},
+		{name: "negative seconds", header: "-1"},
+		{name: "http date", header: "Sat, 15 Jun 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "http date in the past", header: "Sat, 15 Jun 2024 11:59:00 GMT", ok: true},
+		{name: "invalid", header: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			wait, ok := parseRetryAfter(tt.header, now)
+
+			assert.Equal(tt.ok, ok)
+			assert.Equal(tt.expected, wait)
+		})
+	}
+}
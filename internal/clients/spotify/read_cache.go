@@ -0,0 +1,140 @@
+package spotifyclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/cache"
+)
+
+const (
+	// PLAYLIST_CACHE_TTL bounds how long a playlist changed outside of the router, e.g. renamed
+	// in the spotify app, can be served stale. Changes made through the client drop it right away
+	PLAYLIST_CACHE_TTL = 2 * time.Minute
+	// PLAYLIST_TRACKS_CACHE_TTL only frees space: track pages are keyed by the snapshot of their
+	// playlist, which changes with every edit, so a cached page is never stale
+	PLAYLIST_TRACKS_CACHE_TTL = time.Hour
+	// AUDIO_FEATURES_CACHE_TTL is long since the analysis of a track doesn't change
+	AUDIO_FEATURES_CACHE_TTL = 7 * 24 * time.Hour
+)
+
+// readCache keeps spotify reads in a cache store. Playlist reads are keyed by the user and spotify
+// account they were made with, since other accounts may not see a private playlist; artists and
+// audio features are public and shared between users. A failing store only causes cache misses
+type readCache struct {
+	store  cache.Store
+	logger *slog.Logger
+}
+
+// get decodes the cached value of key into value, reporting whether there was one
+func (rc *readCache) get(ctx context.Context, key string, value any) bool {
+	data, ok, err := rc.store.Get(ctx, key)
+	if err != nil {
+		rc.logger.WarnContext(ctx, "failed to read spotify cache", "key", key, "error", err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		rc.logger.WarnContext(ctx, "failed to decode cached spotify read", "key", key, "error", err)
+		return false
+	}
+
+	return true
+}
+
+func (rc *readCache) set(ctx context.Context, key string, value any, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		rc.logger.WarnContext(ctx, "failed to encode spotify read", "key", key, "error", err)
+		return
+	}
+
+	if err := rc.store.Set(ctx, key, data, ttl); err != nil {
+		rc.logger.WarnContext(ctx, "failed to write spotify cache", "key", key, "error", err)
+	}
+}
+
+func (rc *readCache) delete(ctx context.Context, keys ...string) {
+	if err := rc.store.Delete(ctx, keys...); err != nil {
+		rc.logger.WarnContext(ctx, "failed to invalidate spotify cache", "keys", keys, "error", err)
+	}
+}
+
+// cachedRead serves key from the read cache of c, calling fetch and caching its result on a miss.
+// Without a read cache fetch is always called
+func cachedRead[T any](ctx context.Context, c *SpotifyClient, key string, ttl time.Duration, fetch func() (T, error)) (T, error) {
+	var value T
+	if c.cache != nil && c.cache.get(ctx, key, &value) {
+		return value, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	if c.cache != nil {
+		c.cache.set(ctx, key, value, ttl)
+	}
+	return value, nil
+}
+
+// cacheAccount identifies the user and spotify account of the request in the cache keys. It
+// reports false without a read cache
+func (c *SpotifyClient) cacheAccount(ctx context.Context) (string, bool) {
+	if c.cache == nil {
+		return "", false
+	}
+
+	integration, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		return "", false
+	}
+
+	return integration.UserID + ":" + integration.SpotifyID, true
+}
+
+// invalidatePlaylist drops the cached reads a change to the playlist makes stale for the account of
+// the request, its library listing included
+func (c *SpotifyClient) invalidatePlaylist(ctx context.Context, playlistID string) {
+	account, ok := c.cacheAccount(ctx)
+	if !ok {
+		return
+	}
+
+	c.cache.delete(ctx,
+		playlistCacheKey(account, playlistID),
+		playlistSnapshotCacheKey(account, playlistID),
+		userPlaylistsCacheKey(account),
+	)
+}
+
+func playlistCacheKey(account, playlistID string) string {
+	return fmt.Sprintf("spotify:playlist:%s:%s", account, playlistID)
+}
+
+func playlistSnapshotCacheKey(account, playlistID string) string {
+	return fmt.Sprintf("spotify:playlist_snapshot:%s:%s", account, playlistID)
+}
+
+func playlistTracksCacheKey(account, playlistID, snapshotID string, limit, offset int) string {
+	return fmt.Sprintf("spotify:playlist_tracks:%s:%s:%s:%d:%d", account, playlistID, snapshotID, limit, offset)
+}
+
+func userPlaylistsCacheKey(account string) string {
+	return fmt.Sprintf("spotify:user_playlists:%s", account)
+}
+
+func audioFeaturesCacheKey(trackID string) string {
+	return fmt.Sprintf("spotify:audio_features:%s", trackID)
+}
+
+func artistCacheKey(artistID string) string {
+	return fmt.Sprintf("spotify:artist:%s", artistID)
+}
@@ -0,0 +1,208 @@
+package spotifyclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/cache"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachedTestClient(t *testing.T) (*SpotifyClient, *mocks.MockHTTPClient, context.Context) {
+	mockHTTPClient := mocks.NewMockHTTPClient(setupMockController(t))
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger()).WithCache(cache.NewMemoryStore(100))
+	client.HttpClient = mockHTTPClient
+
+	ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+		AccessToken: "valid_access_token",
+		UserID:      "user123",
+		SpotifyID:   "spotify123",
+	})
+
+	return client, mockHTTPClient, ctx
+}
+
+func jsonResponse(statusCode int, value any) *http.Response {
+	body, _ := json.Marshal(value)
+	return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(string(body)))}
+}
+
+func TestSpotifyClient_GetPlaylist_Cached(t *testing.T) {
+	assert := require.New(t)
+	client, mockHTTPClient, ctx := newCachedTestClient(t)
+
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		Return(jsonResponse(http.StatusOK, SpotifyPlaylist{ID: "playlist123", Name: "Old name"}), nil)
+
+	for range 2 {
+		playlist, err := client.GetPlaylist(ctx, "playlist123")
+		assert.NoError(err)
+		assert.Equal("Old name", playlist.Name)
+	}
+
+	// Updating the playlist drops it from the cache
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		Return(jsonResponse(http.StatusOK, SpotifyPlaylist{ID: "playlist123", Name: "New name"}), nil)
+
+	assert.NoError(client.UpdatePlaylist(ctx, "playlist123", "New name", ""))
+
+	playlist, err := client.GetPlaylist(ctx, "playlist123")
+	assert.NoError(err)
+	assert.Equal("New name", playlist.Name)
+}
+
+func TestSpotifyClient_GetPlaylistTracks_Cached(t *testing.T) {
+	assert := require.New(t)
+	client, mockHTTPClient, ctx := newCachedTestClient(t)
+
+	snapshotID := "snapshot1"
+	requests := make([]string, 0)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("fields") == "snapshot_id" {
+				requests = append(requests, "snapshot")
+				return jsonResponse(http.StatusOK, SpotifySnapshotResponse{SnapshotID: snapshotID}), nil
+			}
+
+			requests = append(requests, "tracks:"+req.URL.Query().Get("offset"))
+			return jsonResponse(http.StatusOK, SpotifyPlaylistTracksResponse{Total: 60}), nil
+		}).
+		AnyTimes()
+
+	_, err := client.GetPlaylistTracks(ctx, "playlist123", 50, 0)
+	assert.NoError(err)
+	assert.Equal([]string{"snapshot", "tracks:0"}, requests)
+
+	// The first page always checks the snapshot, the following ones reuse it
+	requests = requests[:0]
+	_, err = client.GetPlaylistTracks(ctx, "playlist123", 50, 0)
+	assert.NoError(err)
+	_, err = client.GetPlaylistTracks(ctx, "playlist123", 50, 50)
+	assert.NoError(err)
+	_, err = client.GetPlaylistTracks(ctx, "playlist123", 50, 50)
+	assert.NoError(err)
+	assert.Equal([]string{"snapshot", "tracks:50"}, requests)
+
+	// The playlist was edited outside of the router
+	requests = requests[:0]
+	snapshotID = "snapshot2"
+	_, err = client.GetPlaylistTracks(ctx, "playlist123", 50, 0)
+	assert.NoError(err)
+	assert.Equal([]string{"snapshot", "tracks:0"}, requests)
+}
+
+func TestSpotifyClient_GetAudioFeatures_Cached(t *testing.T) {
+	assert := require.New(t)
+	client, mockHTTPClient, ctx := newCachedTestClient(t)
+
+	requestedIDs := make([]string, 0)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			ids := strings.Split(req.URL.Query().Get("ids"), ",")
+			requestedIDs = append(requestedIDs, ids...)
+
+			// track2 has no audio features
+			audioFeatures := make([]*SpotifyAudioFeatures, len(ids))
+			for i, id := range ids {
+				if id != "track2" {
+					audioFeatures[i] = &SpotifyAudioFeatures{ID: id, Tempo: 120}
+				}
+			}
+
+			return jsonResponse(http.StatusOK, struct {
+				AudioFeatures []*SpotifyAudioFeatures `json:"audio_features"`
+			}{AudioFeatures: audioFeatures}), nil
+		}).
+		Times(2)
+
+	audioFeatures, err := client.GetAudioFeatures(ctx, []string{"track1", "track2"})
+	assert.NoError(err)
+	assert.Len(audioFeatures, 2)
+	assert.Nil(audioFeatures[1])
+
+	audioFeatures, err = client.GetAudioFeatures(ctx, []string{"track3", "track2", "track1"})
+	assert.NoError(err)
+	assert.Equal([]string{"track1", "track2", "track3"}, requestedIDs)
+	assert.Len(audioFeatures, 3)
+	assert.Equal("track3", audioFeatures[0].ID)
+	assert.Nil(audioFeatures[1])
+	assert.Equal("track1", audioFeatures[2].ID)
+}
+
+func TestSpotifyClient_GetArtists_Cached(t *testing.T) {
+	assert := require.New(t)
+	store := cache.NewMemoryStore(100)
+	mockHTTPClient := mocks.NewMockHTTPClient(setupMockController(t))
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger()).WithCache(store)
+	client.HttpClient = mockHTTPClient
+
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		Return(jsonResponse(http.StatusOK, struct{ Artists []*SpotifyArtist }{
+			Artists: []*SpotifyArtist{{ID: "artist1", Genres: []string{"rock"}}},
+		}), nil)
+
+	ctx := contextWithToken("valid_access_token")
+	for range 2 {
+		artists, err := client.GetArtists(ctx, []string{"artist1"})
+		assert.NoError(err)
+		assert.Len(artists, 1)
+		assert.Equal([]string{"rock"}, artists[0].Genres)
+	}
+
+	assert.Equal(1, store.Len())
+}
+
+func TestSpotifyClient_GetPlaylistSnapshotID_Cached(t *testing.T) {
+	assert := require.New(t)
+	client, mockHTTPClient, ctx := newCachedTestClient(t)
+
+	// The snapshot is always read from spotify
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		Return(jsonResponse(http.StatusOK, SpotifySnapshotResponse{SnapshotID: "snapshot1"}), nil)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		Return(jsonResponse(http.StatusOK, SpotifySnapshotResponse{SnapshotID: "snapshot2"}), nil)
+
+	snapshotID, err := client.GetPlaylistSnapshotID(ctx, "playlist123")
+	assert.NoError(err)
+	assert.Equal("snapshot1", snapshotID)
+
+	snapshotID, err = client.GetPlaylistSnapshotID(ctx, "playlist123")
+	assert.NoError(err)
+	assert.Equal("snapshot2", snapshotID)
+
+	// Following pages of tracks are read at the refreshed snapshot
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal("50", req.URL.Query().Get("offset"))
+			return jsonResponse(http.StatusOK, SpotifyPlaylistTracksResponse{Total: 60}), nil
+		})
+
+	_, err = client.GetPlaylistTracks(ctx, "playlist123", 50, 50)
+	assert.NoError(err)
+
+	var cachedSnapshotID string
+	assert.True(client.cache.get(ctx, playlistSnapshotCacheKey("user123:spotify123", "playlist123"), &cachedSnapshotID))
+	assert.Equal("snapshot2", cachedSnapshotID)
+}
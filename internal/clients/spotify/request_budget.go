@@ -0,0 +1,76 @@
+package spotifyclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// SPOTIFY_RATE_LIMIT_WINDOW is the rolling window Spotify computes its rate limit over
+const SPOTIFY_RATE_LIMIT_WINDOW = 30 * time.Second
+
+// QuotaWaiter is notified when a queued request waits for the request budget, so background
+// jobs can report they are waiting instead of looking stuck
+type QuotaWaiter interface {
+	WaitingForQuota(ctx context.Context, wait time.Duration)
+	ResumedAfterQuota(ctx context.Context)
+}
+
+type quotaQueueContextKey struct{}
+
+type quotaQueue struct {
+	waiter QuotaWaiter
+}
+
+// ContextWithQuotaQueue makes the requests done with ctx wait for the request budget to refill
+// instead of failing with ErrRateLimited. Meant for background jobs, where a delay is better than
+// a failure; waiter may be nil
+func ContextWithQuotaQueue(ctx context.Context, waiter QuotaWaiter) context.Context {
+	return context.WithValue(ctx, quotaQueueContextKey{}, &quotaQueue{waiter: waiter})
+}
+
+func getQuotaQueueFromContext(ctx context.Context) (*quotaQueue, bool) {
+	queue, ok := ctx.Value(quotaQueueContextKey{}).(*quotaQueue)
+	return queue, ok
+}
+
+// requestBudget is a token bucket shared by every request of the app, keeping the client under
+// the Spotify rate limit instead of discovering it through 429 responses
+type requestBudget struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	refill   float64 // Tokens per second
+	last     time.Time
+	now      func() time.Time
+}
+
+func newRequestBudget(requests int, window time.Duration) *requestBudget {
+	return &requestBudget{
+		capacity: float64(requests),
+		tokens:   float64(requests),
+		refill:   float64(requests) / window.Seconds(),
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+// take spends a request of the budget. When it is exhausted nothing is spent and the time until
+// a request is available again is returned
+func (b *requestBudget) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.refill)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	missing := 1 - b.tokens
+	return time.Duration(math.Ceil(missing / b.refill * float64(time.Second)))
+}
@@ -0,0 +1,125 @@
+package spotifyclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingQuotaWaiter struct {
+	waits   []time.Duration
+	resumed int
+}
+
+func (w *recordingQuotaWaiter) WaitingForQuota(ctx context.Context, wait time.Duration) {
+	w.waits = append(w.waits, wait)
+}
+
+func (w *recordingQuotaWaiter) ResumedAfterQuota(ctx context.Context) {
+	w.resumed++
+}
+
+func TestRequestBudget_Take(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	budget := newRequestBudget(3, 30*time.Second)
+	budget.now = func() time.Time { return now }
+	budget.last = now
+
+	for range 3 {
+		assert.Zero(budget.take())
+	}
+
+	// One request refills every 10 seconds
+	assert.Equal(10*time.Second, budget.take())
+
+	now = now.Add(4 * time.Second)
+	assert.Equal(6*time.Second, budget.take())
+
+	now = now.Add(6 * time.Second)
+	assert.Zero(budget.take())
+
+	// The budget never refills above its capacity
+	now = now.Add(time.Hour)
+	for range 3 {
+		assert.Zero(budget.take())
+	}
+	assert.NotZero(budget.take())
+}
+
+func newBudgetedTestClient(t *testing.T, budget *requestBudget) (*SpotifyClient, *mocks.MockHTTPClient) {
+	mockHTTPClient := mocks.NewMockHTTPClient(setupMockController(t))
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+	client.HttpClient = mockHTTPClient
+	client.requestBudget = budget
+
+	return client, mockHTTPClient
+}
+
+func playlistResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":"playlist123"}`))),
+	}
+}
+
+func TestSpotifyClient_RequestBudget_FailsInteractiveRequests(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newBudgetedTestClient(t, newRequestBudget(1, time.Hour))
+	mockHTTPClient.EXPECT().Do(gomock.Any()).Return(playlistResponse(), nil).Times(1)
+
+	ctx := contextWithToken("valid_token")
+	_, err := client.GetPlaylist(ctx, "playlist123")
+	assert.NoError(err)
+
+	_, err = client.GetPlaylist(ctx, "playlist123")
+	assert.ErrorIs(err, ErrRateLimited)
+}
+
+func TestSpotifyClient_RequestBudget_QueuesBackgroundRequests(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newBudgetedTestClient(t, newRequestBudget(1, 20*time.Millisecond))
+	mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(*http.Request) (*http.Response, error) {
+		return playlistResponse(), nil
+	}).Times(2)
+
+	waiter := &recordingQuotaWaiter{}
+	ctx := ContextWithQuotaQueue(contextWithToken("valid_token"), waiter)
+
+	_, err := client.GetPlaylist(ctx, "playlist123")
+	assert.NoError(err)
+	assert.Empty(waiter.waits)
+
+	_, err = client.GetPlaylist(ctx, "playlist123")
+	assert.NoError(err)
+	assert.Len(waiter.waits, 1)
+	assert.Equal(1, waiter.resumed)
+}
+
+func TestSpotifyClient_RequestBudget_QueuedRequestCancelled(t *testing.T) {
+	assert := require.New(t)
+
+	client, mockHTTPClient := newBudgetedTestClient(t, newRequestBudget(1, time.Hour))
+	mockHTTPClient.EXPECT().Do(gomock.Any()).Return(playlistResponse(), nil).Times(1)
+
+	ctx, cancel := context.WithTimeout(ContextWithQuotaQueue(contextWithToken("valid_token"), nil), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetPlaylist(ctx, "playlist123")
+	assert.NoError(err)
+
+	_, err = client.GetPlaylist(ctx, "playlist123")
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
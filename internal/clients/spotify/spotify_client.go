@@ -9,46 +9,57 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ngomez18/playlist-router/internal/cache"
 	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
 	"github.com/ngomez18/playlist-router/internal/config"
-	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/profiling"
+	"github.com/ngomez18/playlist-router/internal/tracing"
 )
 
 const (
-	MAX_PLAYLISTS = 50
-)
+	MAX_PLAYLISTS      = 50
+	MAX_ARTISTS        = 50
+	MAX_TRACKS         = 50
+	MAX_PLAYLIST_ITEMS = 100 // Tracks added to or removed from a playlist per request
 
-//go:generate mockgen -source=spotify_client.go -destination=mocks/mock_spotify_client.go -package=mocks
+	// MAX_CONCURRENT_PAGES bounds the pages of a listing fetched at the same time once the first
+	// page tells how many there are
+	MAX_CONCURRENT_PAGES = 4
 
-type SpotifyAPI interface {
-	// Auth
-	GenerateAuthURL(state string) string
-	ExchangeCodeForTokens(ctx context.Context, code string) (*SpotifyTokenResponse, error)
-	RefreshTokens(ctx context.Context, refreshToken string) (*SpotifyTokenResponse, error)
-	GetUserProfile(ctx context.Context, accessToken string) (*SpotifyUserProfile, error)
+	DEFAULT_AUTH_BASE_URL = "https://accounts.spotify.com/"
+	DEFAULT_API_BASE_URL  = "https://api.spotify.com/v1/"
 
-	// Playlists
-	GetPlaylist(ctx context.Context, playlistId string) (*SpotifyPlaylist, error)
-	GetAllUserPlaylists(ctx context.Context) ([]*SpotifyPlaylist, error)
-	CreatePlaylist(ctx context.Context, name, description string, public bool) (*SpotifyPlaylist, error)
-	DeletePlaylist(ctx context.Context, playlistId string) error
-	UpdatePlaylist(ctx context.Context, playlistId, name, description string) error
+	// MAX_COVER_IMAGE_SIZE is the largest base64 encoded JPEG Spotify accepts as a playlist cover
+	MAX_COVER_IMAGE_SIZE = 256 * 1024
+)
 
-	// Tracks
-	GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*SpotifyPlaylistTracksResponse, error)
-	AddTracksToPlaylist(ctx context.Context, playlistID string, trackURIs []string) error
+//go:generate mockgen -source=spotify_client.go -destination=mocks/mock_spotify_client.go -package=mocks
 
-	// Artists
-	GetSeveralArtists(ctx context.Context, artistIDs []string) ([]*SpotifyArtist, error)
+// SpotifyAPI is the spotify music provider. Services that only make sense with spotify, as signing
+// in with it, depend on it, the others on musicprovider.MusicProvider
+type SpotifyAPI interface {
+	musicprovider.MusicProvider
 }
 
 type SpotifyClient struct {
-	HttpClient clients.HTTPClient
-	config     *config.AuthConfig
-	logger     *slog.Logger
+	HttpClient  clients.HTTPClient
+	config      *config.AuthConfig
+	logger      *slog.Logger
+	credentials CredentialsProvider
+
+	// The client secret of the config, replaced when it is rotated in the secrets manager
+	clientSecretMu sync.RWMutex
+	clientSecret   string
+
+	artistCache      *artistCache
+	cache            *readCache     // nil when only artists are cached, in memory
+	requestBudget    *requestBudget // nil when unlimited
+	rateLimitBackoff time.Duration
 
 	// urls
 	authBaseUrl string
@@ -56,31 +67,79 @@ type SpotifyClient struct {
 }
 
 func NewSpotifyClient(config *config.AuthConfig, logger *slog.Logger) *SpotifyClient {
+	var budget *requestBudget
+	if config.SpotifyRequestBudget > 0 {
+		budget = newRequestBudget(config.SpotifyRequestBudget, SPOTIFY_RATE_LIMIT_WINDOW)
+	}
+
 	return &SpotifyClient{
 		HttpClient: &http.Client{
 			Timeout: 15 * time.Second,
-			Transport: &http.Transport{
+			Transport: tracing.Transport(&http.Transport{
 				MaxIdleConns:       10,
 				IdleConnTimeout:    30 * time.Second,
 				DisableCompression: false,
-			},
+			}),
 		},
-		config:      config,
-		logger:      logger.With("component", "SpotifyClient"),
-		authBaseUrl: "https://accounts.spotify.com/",
-		apiBaseUrl:  "https://api.spotify.com/v1/",
+		config:           config,
+		logger:           logger.With("component", "SpotifyClient"),
+		credentials:      ContextCredentialsProvider{},
+		clientSecret:     config.SpotifyClientSecret,
+		artistCache:      newArtistCache(ARTIST_CACHE_TTL),
+		requestBudget:    budget,
+		rateLimitBackoff: RATE_LIMIT_BASE_BACKOFF,
+		authBaseUrl:      baseURL(config.SpotifyAuthBaseURL, DEFAULT_AUTH_BASE_URL),
+		apiBaseUrl:       baseURL(config.SpotifyAPIBaseURL, DEFAULT_API_BASE_URL),
 	}
 }
 
-func (c *SpotifyClient) GenerateAuthURL(state string) string {
+// SetClientSecret replaces the client secret the token requests authenticate with
+func (c *SpotifyClient) SetClientSecret(clientSecret string) {
+	c.clientSecretMu.Lock()
+	defer c.clientSecretMu.Unlock()
+
+	c.clientSecret = clientSecret
+}
+
+func (c *SpotifyClient) getClientSecret() string {
+	c.clientSecretMu.RLock()
+	defer c.clientSecretMu.RUnlock()
+
+	return c.clientSecret
+}
+
+// baseURL returns the configured base URL ending in a slash, as the paths are appended to it, or
+// the default one when none is configured
+func baseURL(configured, defaultURL string) string {
+	if configured == "" {
+		return defaultURL
+	}
+
+	return strings.TrimSuffix(configured, "/") + "/"
+}
+
+// WithCache caches the playlists, playlist tracks, user playlists, audio features and artists read
+// from spotify in store, which replaces the in-memory artist cache
+func (c *SpotifyClient) WithCache(store cache.Store) *SpotifyClient {
+	c.cache = &readCache{store: store, logger: c.logger}
+	return c
+}
+
+// GenerateAuthURL returns the spotify authorization URL. A non empty code challenge starts the
+// Authorization Code with PKCE flow, the code is then exchanged with the matching verifier
+func (c *SpotifyClient) GenerateAuthURL(state, codeChallenge string) string {
 	path := "authorize"
 	params := url.Values{
 		"client_id":     {c.config.SpotifyClientID},
 		"response_type": {"code"},
 		"redirect_uri":  {c.config.SpotifyRedirectURI},
-		"scope":         {"user-read-email playlist-read-private playlist-modify-public playlist-modify-private"},
+		"scope":         {"user-read-email playlist-read-private playlist-modify-public playlist-modify-private ugc-image-upload"},
 		"state":         {state},
 	}
+	if codeChallenge != "" {
+		params.Set("code_challenge_method", PKCE_CHALLENGE_METHOD)
+		params.Set("code_challenge", codeChallenge)
+	}
 
 	url := fmt.Sprintf("%s%s", c.authBaseUrl, path)
 	authURL := fmt.Sprintf("%s?%s", url, params.Encode())
@@ -88,27 +147,27 @@ func (c *SpotifyClient) GenerateAuthURL(state string) string {
 	return authURL
 }
 
-func (c *SpotifyClient) ExchangeCodeForTokens(ctx context.Context, code string) (*SpotifyTokenResponse, error) {
-	c.logger.InfoContext(ctx, "exchanging authorization code for tokens")
-	path := "api/token"
+// ExchangeCodeForTokens exchanges the authorization code for tokens. The code verifier is required
+// when the authorization URL carried a code challenge, and must be empty otherwise
+func (c *SpotifyClient) ExchangeCodeForTokens(ctx context.Context, code, codeVerifier string) (*SpotifyTokenResponse, error) {
+	c.logger.InfoContext(ctx, "exchanging authorization code for tokens", "pkce", codeVerifier != "")
 
 	data := url.Values{
 		"grant_type":   {"authorization_code"},
 		"code":         {code},
 		"redirect_uri": {c.config.SpotifyRedirectURI},
 	}
+	if codeVerifier != "" {
+		data.Set("code_verifier", codeVerifier)
+	}
 
-	url := fmt.Sprintf("%s%s", c.authBaseUrl, path)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(data.Encode()))
+	req, err := c.newTokenRequest(ctx, data)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to create token request", "error", err)
 		return nil, fmt.Errorf("failed to create token request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.SetBasicAuth(c.config.SpotifyClientID, c.config.SpotifyClientSecret)
-
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to exchange code", "error", err)
 		return nil, fmt.Errorf("failed to exchange code: %w", err)
@@ -133,24 +192,19 @@ func (c *SpotifyClient) ExchangeCodeForTokens(ctx context.Context, code string)
 
 func (c *SpotifyClient) RefreshTokens(ctx context.Context, refreshToken string) (*SpotifyTokenResponse, error) {
 	c.logger.InfoContext(ctx, "refreshing spotify access tokens")
-	path := "api/token"
 
 	data := url.Values{
 		"grant_type":    {"refresh_token"},
 		"refresh_token": {refreshToken},
 	}
 
-	url := fmt.Sprintf("%s%s", c.authBaseUrl, path)
-	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(data.Encode()))
+	req, err := c.newTokenRequest(ctx, data)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to create token refresh request", "error", err)
 		return nil, fmt.Errorf("failed to create token refresh request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.SetBasicAuth(c.config.SpotifyClientID, c.config.SpotifyClientSecret)
-
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to refresh tokens", "error", err)
 		return nil, fmt.Errorf("failed to refresh tokens: %w", err)
@@ -173,7 +227,58 @@ func (c *SpotifyClient) RefreshTokens(ctx context.Context, refreshToken string)
 	return &tokens, nil
 }
 
-func (c *SpotifyClient) GetUserProfile(ctx context.Context, accessToken string) (*SpotifyUserProfile, error) {
+// newTokenRequest builds a request to the token endpoint. With a client secret the app authenticates
+// as a confidential client, without one it is a public client and only sends its client id, which
+// spotify accepts for PKCE authorizations and the tokens they issue
+func (c *SpotifyClient) newTokenRequest(ctx context.Context, data url.Values) (*http.Request, error) {
+	clientSecret := c.getClientSecret()
+	if clientSecret == "" {
+		data.Set("client_id", c.config.SpotifyClientID)
+	}
+
+	url := fmt.Sprintf("%s%s", c.authBaseUrl, "api/token")
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if clientSecret != "" {
+		req.SetBasicAuth(c.config.SpotifyClientID, clientSecret)
+	}
+
+	return req, nil
+}
+
+// Ping checks that spotify can be reached with a HEAD request to the token endpoint, which needs no
+// credentials and doesn't count towards the request budget. Any answer but a server error means
+// spotify is up, the endpoint itself only accepts POST
+func (c *SpotifyClient) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s%s", c.authBaseUrl, "api/token")
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create ping request: %w", err)
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("spotify unreachable: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("spotify unavailable (status %d)", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *SpotifyClient) GetUserProfile(ctx context.Context) (*SpotifyUserProfile, error) {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	c.logger.InfoContext(ctx, "fetching user profile from spotify")
 	path := "me"
 	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
@@ -186,7 +291,7 @@ func (c *SpotifyClient) GetUserProfile(ctx context.Context, accessToken string)
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to get user profile", "error", err)
 		return nil, fmt.Errorf("failed to get user profile: %w", err)
@@ -209,6 +314,101 @@ func (c *SpotifyClient) GetUserProfile(ctx context.Context, accessToken string)
 	return &profile, nil
 }
 
+// do sends the request once the request budget allows it. Requests of contexts with a quota
+// queue wait for the budget to refill, the others fail right away with ErrRateLimited.
+// Requests answered with 429 are retried honoring Retry-After, up to MAX_RATE_LIMIT_RETRIES
+// times; the last 429 response is returned when they run out
+func (c *SpotifyClient) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForBudget(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.HttpClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		recordRateLimit(ctx, func(stats *models.SyncRateLimitStats) {
+			stats.RateLimitedResponses++
+		})
+
+		wait, retry := c.retryDelay(resp, attempt)
+		if !retry {
+			c.logger.WarnContext(ctx, "spotify rate limit hit, giving up",
+				"url", req.URL.Path,
+				"attempts", attempt+1,
+				"retry_after", resp.Header.Get("Retry-After"),
+			)
+			return resp, nil
+		}
+
+		c.responseBodyCloser(ctx, resp)
+		c.logger.WarnContext(ctx, "spotify rate limit hit, retrying",
+			"url", req.URL.Path,
+			"attempt", attempt+1,
+			"wait", wait,
+		)
+
+		req, err = c.waitToRetry(req, wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *SpotifyClient) waitForBudget(ctx context.Context) error {
+	if c.requestBudget == nil {
+		return nil
+	}
+
+	wait := c.requestBudget.take()
+	if wait == 0 {
+		return nil
+	}
+
+	queue, ok := getQuotaQueueFromContext(ctx)
+	if !ok {
+		c.logger.WarnContext(ctx, "spotify request budget exhausted", "retry_after", wait)
+		return ErrRateLimited
+	}
+
+	if queue.waiter != nil {
+		queue.waiter.WaitingForQuota(ctx, wait)
+	}
+
+	_, endSpan := profiling.StartSpan(ctx, "rate_limit_wait")
+	defer endSpan()
+
+	waitStart := time.Now()
+	defer func() {
+		recordRateLimit(ctx, func(stats *models.SyncRateLimitStats) {
+			stats.BudgetWaits++
+			stats.WaitMs += time.Since(waitStart).Milliseconds()
+		})
+	}()
+
+	for wait > 0 {
+		c.logger.InfoContext(ctx, "waiting for spotify request budget", "wait", wait)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		// Other queued requests may have taken the refilled budget first
+		wait = c.requestBudget.take()
+	}
+
+	if queue.waiter != nil {
+		queue.waiter.ResumedAfterQuota(ctx)
+	}
+
+	return nil
+}
+
 func (c *SpotifyClient) responseBodyCloser(ctx context.Context, resp *http.Response) {
 	if closeErr := resp.Body.Close(); closeErr != nil {
 		c.logger.WarnContext(ctx, "failed to close response body", "error", closeErr)
@@ -216,20 +416,19 @@ func (c *SpotifyClient) responseBodyCloser(ctx context.Context, resp *http.Respo
 }
 
 func (c *SpotifyClient) getAccessToken(ctx context.Context) (string, error) {
-	integration, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
-	if !ok {
-		c.logger.ErrorContext(ctx, "failed to get spotify integration")
-		return "", ErrSpotifyCredentialsNotFound
+	integration, err := c.getIntegrationInfo(ctx)
+	if err != nil {
+		return "", err
 	}
 
 	return integration.AccessToken, nil
 }
 
 func (c *SpotifyClient) getIntegrationInfo(ctx context.Context) (*models.SpotifyIntegration, error) {
-	integration, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
-	if !ok {
-		c.logger.ErrorContext(ctx, "failed to get spotify integration")
-		return nil, ErrSpotifyCredentialsNotFound
+	integration, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to get spotify integration", "error", err)
+		return nil, err
 	}
 
 	return integration, nil
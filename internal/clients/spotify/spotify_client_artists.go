@@ -39,7 +39,7 @@ func (c *SpotifyClient) GetSeveralArtists(ctx context.Context, artistIDs []strin
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to get artists", "error", err)
 		return nil, fmt.Errorf("failed to get artists: %w", err)
@@ -63,3 +63,74 @@ func (c *SpotifyClient) GetSeveralArtists(ctx context.Context, artistIDs []strin
 	c.logger.InfoContext(ctx, "successfully fetched artists", "artists_count", len(artistsResponse.Artists))
 	return artistsResponse.Artists, nil
 }
+
+// GetArtists looks up any number of artists, serving them from the cache when possible and
+// fetching the rest in batches of MAX_ARTISTS. Unknown artists are left out of the result
+func (c *SpotifyClient) GetArtists(ctx context.Context, artistIDs []string) ([]*SpotifyArtist, error) {
+	uniqueIDs := make([]string, 0, len(artistIDs))
+	seen := make(map[string]bool, len(artistIDs))
+	for _, artistID := range artistIDs {
+		if artistID == "" || seen[artistID] {
+			continue
+		}
+		seen[artistID] = true
+		uniqueIDs = append(uniqueIDs, artistID)
+	}
+
+	artists, missingIDs := c.cachedArtists(ctx, uniqueIDs)
+	c.logger.InfoContext(ctx, "resolving artists", "artist_count", len(uniqueIDs), "cached", len(artists))
+
+	for offset := 0; offset < len(missingIDs); offset += MAX_ARTISTS {
+		endIndex := min(offset+MAX_ARTISTS, len(missingIDs))
+		fetched, err := c.GetSeveralArtists(ctx, missingIDs[offset:endIndex])
+		if err != nil {
+			return nil, err
+		}
+
+		// Spotify returns null for IDs that don't match an artist
+		found := make([]*SpotifyArtist, 0, len(fetched))
+		for _, artist := range fetched {
+			if artist != nil {
+				found = append(found, artist)
+			}
+		}
+
+		c.cacheArtists(ctx, found)
+		artists = append(artists, found...)
+	}
+
+	return artists, nil
+}
+
+// cachedArtists returns the cached artists and the IDs that have to be fetched from Spotify, from
+// the read cache when there is one and the in-memory artist cache otherwise
+func (c *SpotifyClient) cachedArtists(ctx context.Context, artistIDs []string) ([]*SpotifyArtist, []string) {
+	if c.cache == nil {
+		return c.artistCache.get(artistIDs)
+	}
+
+	found := make([]*SpotifyArtist, 0, len(artistIDs))
+	missing := make([]string, 0, len(artistIDs))
+	for _, artistID := range artistIDs {
+		var artist *SpotifyArtist
+		if c.cache.get(ctx, artistCacheKey(artistID), &artist) && artist != nil {
+			found = append(found, artist)
+			continue
+		}
+
+		missing = append(missing, artistID)
+	}
+
+	return found, missing
+}
+
+func (c *SpotifyClient) cacheArtists(ctx context.Context, artists []*SpotifyArtist) {
+	if c.cache == nil {
+		c.artistCache.set(artists)
+		return
+	}
+
+	for _, artist := range artists {
+		c.cache.set(ctx, artistCacheKey(artist.ID), artist, ARTIST_CACHE_TTL)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/ngomez18/playlist-router/internal/clients/mocks"
@@ -242,3 +243,109 @@ func TestSpotifyClient_GetSeveralArtists_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestSpotifyClient_GetArtists(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+	client.HttpClient = mockHTTPClient
+
+	ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+		AccessToken: "valid_access_token",
+		UserID:      "test_user",
+	})
+
+	// 51 unique artists plus a duplicate and an empty ID need two requests
+	artistIDs := make([]string, 0, MAX_ARTISTS+3)
+	for i := range MAX_ARTISTS + 1 {
+		artistIDs = append(artistIDs, fmt.Sprintf("artist%d", i))
+	}
+	artistIDs = append(artistIDs, "artist0", "")
+
+	requestedBatches := make([][]string, 0)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			ids := strings.Split(req.URL.Query().Get("ids"), ",")
+			requestedBatches = append(requestedBatches, ids)
+
+			// The last artist of the first batch is unknown to Spotify
+			artists := make([]*SpotifyArtist, len(ids))
+			for i, id := range ids {
+				if id != "artist49" {
+					artists[i] = &SpotifyArtist{ID: id, Genres: []string{"rock"}}
+				}
+			}
+
+			body, _ := json.Marshal(struct{ Artists []*SpotifyArtist }{Artists: artists})
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+		}).
+		Times(2)
+
+	artists, err := client.GetArtists(ctx, artistIDs)
+
+	assert.NoError(err)
+	assert.Len(artists, MAX_ARTISTS)
+	assert.Len(requestedBatches, 2)
+	assert.Len(requestedBatches[0], MAX_ARTISTS)
+	assert.Equal([]string{"artist50"}, requestedBatches[1])
+
+	// Known artists are served from the cache, the unknown one is looked up again
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal("artist49", req.URL.Query().Get("ids"))
+			body, _ := json.Marshal(struct{ Artists []*SpotifyArtist }{Artists: []*SpotifyArtist{nil}})
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+		}).
+		Times(1)
+
+	cachedArtists, err := client.GetArtists(ctx, []string{"artist0", "artist49", "artist50"})
+
+	assert.NoError(err)
+	assert.Len(cachedArtists, 2)
+	assert.Equal([]string{"rock"}, cachedArtists[0].Genres)
+}
+
+func TestSpotifyClient_GetArtists_Error(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+	client.HttpClient = mockHTTPClient
+
+	ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{AccessToken: "valid_access_token"})
+
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		Return(&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader("boom"))}, nil)
+
+	artists, err := client.GetArtists(ctx, []string{"artist1"})
+
+	assert.Error(err)
+	assert.Nil(artists)
+}
+
+func TestArtistCache_Expiry(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cache := newArtistCache(time.Hour)
+	cache.now = func() time.Time { return now }
+
+	cache.set([]*SpotifyArtist{{ID: "artist1"}})
+
+	found, missing := cache.get([]string{"artist1", "artist2"})
+	assert.Len(found, 1)
+	assert.Equal([]string{"artist2"}, missing)
+
+	now = now.Add(time.Hour + time.Second)
+	found, missing = cache.get([]string{"artist1"})
+	assert.Empty(found)
+	assert.Equal([]string{"artist1"}, missing)
+}
@@ -2,15 +2,30 @@ package spotifyclient
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"golang.org/x/sync/errgroup"
 )
 
+// GetPlaylist looks up a playlist, served from the read cache for PLAYLIST_CACHE_TTL
 func (c *SpotifyClient) GetPlaylist(ctx context.Context, playlistId string) (*SpotifyPlaylist, error) {
+	account, ok := c.cacheAccount(ctx)
+	if !ok {
+		return c.fetchPlaylist(ctx, playlistId)
+	}
+
+	return cachedRead(ctx, c, playlistCacheKey(account, playlistId), PLAYLIST_CACHE_TTL, func() (*SpotifyPlaylist, error) {
+		return c.fetchPlaylist(ctx, playlistId)
+	})
+}
+
+func (c *SpotifyClient) fetchPlaylist(ctx context.Context, playlistId string) (*SpotifyPlaylist, error) {
 	c.logger.InfoContext(ctx, "fetching playlist from spotify")
 
 	accessToken, err := c.getAccessToken(ctx)
@@ -29,13 +44,18 @@ func (c *SpotifyClient) GetPlaylist(ctx context.Context, playlistId string) (*Sp
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to get playlist", "error", err)
 		return nil, fmt.Errorf("failed to get playlist: %w", err)
 	}
 	defer c.responseBodyCloser(ctx, resp)
 
+	// Spotify answers 400 for malformed IDs and 404 for unknown ones
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistId)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		c.logger.ErrorContext(ctx, "spotify playlist fetch failed", "status_code", resp.StatusCode, "response_body", string(body))
@@ -76,7 +96,7 @@ func (c *SpotifyClient) GetUserPlaylists(ctx context.Context, limit, offset int)
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to get user playlists", "error", err)
 		return nil, fmt.Errorf("failed to get user playlists: %w", err)
@@ -99,34 +119,86 @@ func (c *SpotifyClient) GetUserPlaylists(ctx context.Context, limit, offset int)
 	return &playlists, nil
 }
 
+// GetAllUserPlaylists lists the library of the user, served from the read cache for
+// PLAYLIST_CACHE_TTL
 func (c *SpotifyClient) GetAllUserPlaylists(ctx context.Context) ([]*SpotifyPlaylist, error) {
+	account, ok := c.cacheAccount(ctx)
+	if !ok {
+		return c.fetchAllUserPlaylists(ctx)
+	}
+
+	return cachedRead(ctx, c, userPlaylistsCacheKey(account), PLAYLIST_CACHE_TTL, func() ([]*SpotifyPlaylist, error) {
+		return c.fetchAllUserPlaylists(ctx)
+	})
+}
+
+func (c *SpotifyClient) fetchAllUserPlaylists(ctx context.Context) ([]*SpotifyPlaylist, error) {
 	c.logger.InfoContext(ctx, "fetching all user playlists from spotify")
 
-	allPlaylists := make([]*SpotifyPlaylist, 0)
 	limit := MAX_PLAYLISTS
-	offset := 0
+	response, err := c.fetchUserPlaylistsPage(ctx, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	allPlaylists := append(make([]*SpotifyPlaylist, 0, response.Total), response.Items...)
+	total := response.Total
 
-	for {
-		response, err := c.GetUserPlaylists(ctx, limit, offset)
-		if err != nil {
-			c.logger.ErrorContext(ctx, "failed to fetch playlists batch", "offset", offset, "error", err)
-			return nil, fmt.Errorf("failed to fetch playlists batch at offset %d: %w", offset, err)
-		}
+	// The total tells which pages are left, they are fetched concurrently and merged in order
+	pages := make([][]*SpotifyPlaylist, 0)
+	for offset := limit; offset < total; offset += limit {
+		pages = append(pages, nil)
+	}
 
-		allPlaylists = append(allPlaylists, response.Items...)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(MAX_CONCURRENT_PAGES)
+	for i := range pages {
+		group.Go(func() error {
+			page, err := c.fetchUserPlaylistsPage(groupCtx, limit, (i+1)*limit)
+			if err != nil {
+				return err
+			}
+
+			pages[i] = page.Items
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	lastPage := response.Items
+	for _, page := range pages {
+		allPlaylists = append(allPlaylists, page...)
+		lastPage = page
+	}
 
-		// Break if we have all the items according to the total
-		if len(allPlaylists) >= response.Total || len(response.Items) == 0 {
-			break
+	// Playlists added while listing, or pages shorter than the limit, leave items past the
+	// expected pages
+	for offset := (len(pages) + 1) * limit; len(allPlaylists) < total && len(lastPage) > 0; offset += limit {
+		response, err := c.fetchUserPlaylistsPage(ctx, limit, offset)
+		if err != nil {
+			return nil, err
 		}
 
-		offset += limit
+		allPlaylists = append(allPlaylists, response.Items...)
+		lastPage = response.Items
+		total = response.Total
 	}
 
 	c.logger.InfoContext(ctx, "successfully fetched all user playlists", "total_count", len(allPlaylists))
 	return allPlaylists, nil
 }
 
+func (c *SpotifyClient) fetchUserPlaylistsPage(ctx context.Context, limit, offset int) (*SpotifyPlaylistResponse, error) {
+	response, err := c.GetUserPlaylists(ctx, limit, offset)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to fetch playlists batch", "offset", offset, "error", err)
+		return nil, fmt.Errorf("failed to fetch playlists batch at offset %d: %w", offset, err)
+	}
+
+	return response, nil
+}
+
 func (c *SpotifyClient) CreatePlaylist(ctx context.Context, name, description string, public bool) (*SpotifyPlaylist, error) {
 	integration, err := c.getIntegrationInfo(ctx)
 	if err != nil {
@@ -163,7 +235,7 @@ func (c *SpotifyClient) CreatePlaylist(ctx context.Context, name, description st
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to create playlist", "error", err, "body", string(jsonData))
 		return nil, fmt.Errorf("failed to create playlist: %w", err)
@@ -182,46 +254,99 @@ func (c *SpotifyClient) CreatePlaylist(ctx context.Context, name, description st
 		return nil, fmt.Errorf("failed to decode playlist response: %w", err)
 	}
 
+	c.invalidatePlaylist(ctx, playlist.ID)
 	c.logger.InfoContext(ctx, "successfully created playlist", "playlist_id", playlist.ID, "name", playlist.Name)
 	return &playlist, nil
 }
 
+// DeletePlaylist removes a playlist owned by the user. Spotify has no playlist deletion, the
+// owner unfollowing a playlist is what removes it from their library
 func (c *SpotifyClient) DeletePlaylist(ctx context.Context, playlistId string) error {
+	c.logger.InfoContext(ctx, "deleting playlist from spotify", "playlist_id", playlistId)
+	return c.UnfollowPlaylist(ctx, playlistId)
+}
+
+// FollowPlaylist adds a playlist to the library of the user, public controls whether it shows
+// on their profile
+func (c *SpotifyClient) FollowPlaylist(ctx context.Context, playlistID string, public bool) error {
 	integration, err := c.getIntegrationInfo(ctx)
 	if err != nil {
 		return err
 	}
 
-	accessToken := integration.AccessToken
-	userId := integration.UserID
+	c.logger.InfoContext(ctx, "following playlist in spotify", "user_id", integration.UserID, "playlist_id", playlistID, "public", public)
+	defer c.invalidatePlaylist(ctx, playlistID)
+
+	path := fmt.Sprintf("playlists/%s/followers", playlistID)
+	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
+
+	jsonData, err := json.Marshal(SpotifyPlaylistRequest{Public: &public})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to marshal follow playlist request", "error", err)
+		return fmt.Errorf("failed to marshal follow playlist request: %w", err)
+	}
 
-	c.logger.InfoContext(ctx, "deleting playlist from spotify", "user_id", userId, "playlist_id", playlistId)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, strings.NewReader(string(jsonData)))
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create follow playlist request", "error", err)
+		return fmt.Errorf("failed to create follow playlist request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+integration.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
 
-	path := fmt.Sprintf("playlists/%s/followers", playlistId)
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to follow playlist", "error", err)
+		return fmt.Errorf("failed to follow playlist: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify playlist follow failed", "status_code", resp.StatusCode, "response_body", string(body))
+		return fmt.Errorf("spotify playlist follow failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	c.logger.InfoContext(ctx, "successfully followed playlist", "playlist_id", playlistID)
+	return nil
+}
+
+// UnfollowPlaylist removes a playlist from the library of the user
+func (c *SpotifyClient) UnfollowPlaylist(ctx context.Context, playlistID string) error {
+	integration, err := c.getIntegrationInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.logger.InfoContext(ctx, "unfollowing playlist in spotify", "user_id", integration.UserID, "playlist_id", playlistID)
+	defer c.invalidatePlaylist(ctx, playlistID)
+
+	path := fmt.Sprintf("playlists/%s/followers", playlistID)
 	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
 
 	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
-		c.logger.ErrorContext(ctx, "failed to create delete playlist request", "error", err)
-		return fmt.Errorf("failed to create delete playlist request: %w", err)
+		c.logger.ErrorContext(ctx, "failed to create unfollow playlist request", "error", err)
+		return fmt.Errorf("failed to create unfollow playlist request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Authorization", "Bearer "+integration.AccessToken)
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		c.logger.ErrorContext(ctx, "failed to delete playlist", "error", err)
-		return fmt.Errorf("failed to delete playlist: %w", err)
+		c.logger.ErrorContext(ctx, "failed to unfollow playlist", "error", err)
+		return fmt.Errorf("failed to unfollow playlist: %w", err)
 	}
 	defer c.responseBodyCloser(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		c.logger.ErrorContext(ctx, "spotify playlist deletion failed", "status_code", resp.StatusCode, "response_body", string(body))
-		return fmt.Errorf("spotify playlist deletion failed (status %d): %s", resp.StatusCode, string(body))
+		c.logger.ErrorContext(ctx, "spotify playlist unfollow failed", "status_code", resp.StatusCode, "response_body", string(body))
+		return fmt.Errorf("spotify playlist unfollow failed (status %d): %s", resp.StatusCode, string(body))
 	}
 
-	c.logger.InfoContext(ctx, "successfully deleted playlist", "playlist_id", playlistId)
+	c.logger.InfoContext(ctx, "successfully unfollowed playlist", "playlist_id", playlistID)
 	return nil
 }
 
@@ -235,6 +360,7 @@ func (c *SpotifyClient) UpdatePlaylist(ctx context.Context, playlistId, name, de
 	userId := integration.UserID
 
 	c.logger.InfoContext(ctx, "updating playlist in spotify", "user_id", userId, "playlist_id", playlistId, "name", name)
+	defer c.invalidatePlaylist(ctx, playlistId)
 
 	path := fmt.Sprintf("playlists/%s", playlistId)
 	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
@@ -264,7 +390,7 @@ func (c *SpotifyClient) UpdatePlaylist(ctx context.Context, playlistId, name, de
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to update playlist", "error", err, "body", string(jsonData))
 		return fmt.Errorf("failed to update playlist: %w", err)
@@ -280,3 +406,52 @@ func (c *SpotifyClient) UpdatePlaylist(ctx context.Context, playlistId, name, de
 	c.logger.InfoContext(ctx, "successfully updated playlist", "playlist_id", playlistId)
 	return nil
 }
+
+// UploadPlaylistCover replaces the cover image of a playlist. Spotify processes the image
+// asynchronously, the new cover may take a few seconds to show up
+func (c *SpotifyClient) UploadPlaylistCover(ctx context.Context, playlistID string, jpegBytes []byte) error {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(jpegBytes)
+	if len(encoded) > MAX_COVER_IMAGE_SIZE {
+		return fmt.Errorf("%w: %d bytes encoded, at most %d allowed", ErrCoverImageTooLarge, len(encoded), MAX_COVER_IMAGE_SIZE)
+	}
+
+	c.logger.InfoContext(ctx, "uploading playlist cover", "playlist_id", playlistID, "size", len(encoded))
+	defer c.invalidatePlaylist(ctx, playlistID)
+
+	path := fmt.Sprintf("playlists/%s/images", playlistID)
+	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, strings.NewReader(encoded))
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create upload cover request", "error", err)
+		return fmt.Errorf("failed to create upload cover request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "image/jpeg")
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to upload playlist cover", "error", err)
+		return fmt.Errorf("failed to upload playlist cover: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify cover upload failed",
+			"status_code", resp.StatusCode,
+			"response_body", string(body),
+			"playlist_id", playlistID,
+		)
+		return fmt.Errorf("spotify cover upload failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	c.logger.InfoContext(ctx, "successfully uploaded playlist cover", "playlist_id", playlistID)
+	return nil
+}
@@ -3,11 +3,13 @@ package spotifyclient
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -42,6 +44,7 @@ func TestSpotifyClient_GetPlaylist_Success(t *testing.T) {
 				Images: []*SpotifyPlaylistImage{
 					{URL: "https://image.jpg", Height: 640, Width: 640},
 				},
+				Owner: &SpotifyPlaylistOwner{ID: "spotify_user_123", DisplayName: "Test User"},
 			},
 			expectedPlaylist: &SpotifyPlaylist{
 				ID:            "playlist123",
@@ -55,6 +58,7 @@ func TestSpotifyClient_GetPlaylist_Success(t *testing.T) {
 				Images: []*SpotifyPlaylistImage{
 					{URL: "https://image.jpg", Height: 640, Width: 640},
 				},
+				Owner: &SpotifyPlaylistOwner{ID: "spotify_user_123", DisplayName: "Test User"},
 			},
 			accessToken: "valid_token",
 		},
@@ -137,12 +141,27 @@ func TestSpotifyClient_GetPlaylist_Errors(t *testing.T) {
 		responseStatus int
 		responseError  error
 		accessToken    string
+		expectedErr    error
 	}{
 		{
 			name:           "playlist not found",
 			playlistId:     "nonexistent",
 			responseStatus: http.StatusNotFound,
 			accessToken:    "valid_token",
+			expectedErr:    ErrPlaylistNotFound,
+		},
+		{
+			name:           "malformed playlist ID",
+			playlistId:     "not a playlist",
+			responseStatus: http.StatusBadRequest,
+			accessToken:    "valid_token",
+			expectedErr:    ErrPlaylistNotFound,
+		},
+		{
+			name:           "spotify error",
+			playlistId:     "playlist123",
+			responseStatus: http.StatusInternalServerError,
+			accessToken:    "valid_token",
 		},
 		{
 			name:          "http client error",
@@ -194,6 +213,9 @@ func TestSpotifyClient_GetPlaylist_Errors(t *testing.T) {
 
 			assert.Error(err)
 			assert.Nil(result)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(err, tt.expectedErr)
+			}
 		})
 	}
 }
@@ -506,6 +528,40 @@ func TestSpotifyClient_GetAllUserPlaylists_Success(t *testing.T) {
 	}
 }
 
+func TestSpotifyClient_GetAllUserPlaylists_ConcurrentPages(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+	client.HttpClient = mockHTTPClient
+
+	total := 4*MAX_PLAYLISTS + 5
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+
+			response := SpotifyPlaylistResponse{Total: total}
+			for i := offset; i < min(offset+MAX_PLAYLISTS, total); i++ {
+				response.Items = append(response.Items, &SpotifyPlaylist{ID: fmt.Sprintf("playlist%d", i)})
+			}
+
+			responseJSON, _ := json.Marshal(response)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(responseJSON))}, nil
+		}).
+		Times(5)
+
+	result, err := client.GetAllUserPlaylists(contextWithToken("valid_token"))
+
+	assert.NoError(err)
+	assert.Len(result, total)
+	for i, playlist := range result {
+		assert.Equal(fmt.Sprintf("playlist%d", i), playlist.ID)
+	}
+}
+
 func TestSpotifyClient_GetAllUserPlaylists_Error(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -893,6 +949,179 @@ func TestSpotifyClient_DeletePlaylist_Errors(t *testing.T) {
 	}
 }
 
+func TestSpotifyClient_FollowPlaylist(t *testing.T) {
+	tests := []struct {
+		name           string
+		public         bool
+		responseStatus int
+		responseError  error
+		accessToken    string
+		expectedBody   string
+		expectError    bool
+	}{
+		{
+			name:           "follows publicly",
+			public:         true,
+			responseStatus: http.StatusOK,
+			accessToken:    "valid_token",
+			expectedBody:   `{"public":true}`,
+		},
+		{
+			name:           "follows privately",
+			public:         false,
+			responseStatus: http.StatusOK,
+			accessToken:    "valid_token",
+			expectedBody:   `{"public":false}`,
+		},
+		{
+			name:           "playlist not found",
+			public:         true,
+			responseStatus: http.StatusNotFound,
+			accessToken:    "valid_token",
+			expectedBody:   `{"public":true}`,
+			expectError:    true,
+		},
+		{
+			name:          "http client error",
+			public:        true,
+			responseError: errors.New("connection timeout"),
+			accessToken:   "valid_token",
+			expectError:   true,
+		},
+		{
+			name:        "missing access token",
+			accessToken: "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			if tt.responseError != nil {
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					Return(nil, tt.responseError).
+					Times(1)
+			}
+
+			if tt.responseStatus > 0 {
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal("PUT", req.Method)
+						assert.Equal("https://api.spotify.com/v1/playlists/playlist456/followers", req.URL.String())
+						assert.Equal("Bearer "+tt.accessToken, req.Header.Get("Authorization"))
+						assert.Equal("application/json", req.Header.Get("Content-Type"))
+
+						body, err := io.ReadAll(req.Body)
+						assert.NoError(err)
+						assert.JSONEq(tt.expectedBody, string(body))
+
+						return &http.Response{
+							StatusCode: tt.responseStatus,
+							Body:       io.NopCloser(strings.NewReader("")),
+						}, nil
+					}).
+					Times(1)
+			}
+
+			ctx := contextWithTokenAndID(tt.accessToken, "user123")
+			err := client.FollowPlaylist(ctx, "playlist456", tt.public)
+
+			if tt.expectError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSpotifyClient_UnfollowPlaylist(t *testing.T) {
+	tests := []struct {
+		name           string
+		responseStatus int
+		responseError  error
+		accessToken    string
+		expectError    bool
+	}{
+		{
+			name:           "unfollows playlist",
+			responseStatus: http.StatusOK,
+			accessToken:    "valid_token",
+		},
+		{
+			name:           "playlist not found",
+			responseStatus: http.StatusNotFound,
+			accessToken:    "valid_token",
+			expectError:    true,
+		},
+		{
+			name:          "http client error",
+			responseError: errors.New("connection timeout"),
+			accessToken:   "valid_token",
+			expectError:   true,
+		},
+		{
+			name:        "missing access token",
+			accessToken: "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			if tt.responseError != nil {
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					Return(nil, tt.responseError).
+					Times(1)
+			}
+
+			if tt.responseStatus > 0 {
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal("DELETE", req.Method)
+						assert.Equal("https://api.spotify.com/v1/playlists/playlist456/followers", req.URL.String())
+						assert.Equal("Bearer "+tt.accessToken, req.Header.Get("Authorization"))
+
+						return &http.Response{
+							StatusCode: tt.responseStatus,
+							Body:       io.NopCloser(strings.NewReader("")),
+						}, nil
+					}).
+					Times(1)
+			}
+
+			ctx := contextWithTokenAndID(tt.accessToken, "user123")
+			err := client.UnfollowPlaylist(ctx, "playlist456")
+
+			if tt.expectError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
 func TestSpotifyClient_UpdatePlaylist_Success(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -1117,3 +1346,75 @@ func contextWithTokenAndID(token, userID string) context.Context {
 		SpotifyID:   userID,
 	})
 }
+
+func TestSpotifyClient_UploadPlaylistCover(t *testing.T) {
+	jpegBytes := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10}
+
+	tests := []struct {
+		name           string
+		image          []byte
+		responseStatus int
+		expectRequest  bool
+		expectedError  string
+		expectedErrIs  error
+	}{
+		{
+			name:           "uploads the cover",
+			image:          jpegBytes,
+			responseStatus: http.StatusAccepted,
+			expectRequest:  true,
+		},
+		{
+			name:           "spotify error",
+			image:          jpegBytes,
+			responseStatus: http.StatusForbidden,
+			expectRequest:  true,
+			expectedError:  "spotify cover upload failed (status 403)",
+		},
+		{
+			name:          "image too large",
+			image:         bytes.Repeat([]byte{0xFF}, MAX_COVER_IMAGE_SIZE),
+			expectedErrIs: ErrCoverImageTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(setupMockController(t))
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			if tt.expectRequest {
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal("PUT", req.Method)
+						assert.Equal("https://api.spotify.com/v1/playlists/playlist123/images", req.URL.String())
+						assert.Equal("image/jpeg", req.Header.Get("Content-Type"))
+						assert.Equal("Bearer valid_token", req.Header.Get("Authorization"))
+
+						body, _ := io.ReadAll(req.Body)
+						assert.Equal(base64.StdEncoding.EncodeToString(tt.image), string(body))
+
+						return &http.Response{
+							StatusCode: tt.responseStatus,
+							Body:       io.NopCloser(strings.NewReader("")),
+						}, nil
+					})
+			}
+
+			err := client.UploadPlaylistCover(contextWithToken("valid_token"), "playlist123", tt.image)
+
+			switch {
+			case tt.expectedErrIs != nil:
+				assert.ErrorIs(err, tt.expectedErrIs)
+			case tt.expectedError != "":
+				assert.ErrorContains(err, tt.expectedError)
+			default:
+				assert.NoError(err)
+			}
+		})
+	}
+}
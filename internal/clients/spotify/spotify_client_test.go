@@ -33,6 +33,21 @@ func TestNewSpotifyClient(t *testing.T) {
 	assert.NotNil(client.HttpClient)
 	assert.Equal(cfg, client.config)
 	assert.NotNil(client.logger)
+	assert.Equal(DEFAULT_AUTH_BASE_URL, client.authBaseUrl)
+	assert.Equal(DEFAULT_API_BASE_URL, client.apiBaseUrl)
+}
+
+func TestNewSpotifyClient_BaseURLs(t *testing.T) {
+	assert := require.New(t)
+
+	client := NewSpotifyClient(&config.AuthConfig{
+		SpotifyAuthBaseURL: "http://127.0.0.1:8091",
+		SpotifyAPIBaseURL:  "http://127.0.0.1:8091/v1/",
+	}, createTestLogger())
+
+	assert.Equal("http://127.0.0.1:8091/", client.authBaseUrl)
+	assert.Equal("http://127.0.0.1:8091/v1/", client.apiBaseUrl)
+	assert.True(strings.HasPrefix(client.GenerateAuthURL("state", ""), "http://127.0.0.1:8091/authorize?"))
 }
 
 func TestSpotifyClient_GenerateAuthURL(t *testing.T) {
@@ -48,7 +63,7 @@ func TestSpotifyClient_GenerateAuthURL(t *testing.T) {
 	client := NewSpotifyClient(cfg, logger)
 
 	state := "test_state"
-	authURL := client.GenerateAuthURL(state)
+	authURL := client.GenerateAuthURL(state, "")
 
 	// Parse the URL to validate components
 	parsedURL, err := url.Parse(authURL)
@@ -63,7 +78,29 @@ func TestSpotifyClient_GenerateAuthURL(t *testing.T) {
 	assert.Equal(clientID, params.Get("client_id"))
 	assert.Equal(redirectURI, params.Get("redirect_uri"))
 	assert.Equal("code", params.Get("response_type"))
-	assert.Equal("user-read-email playlist-read-private playlist-modify-public playlist-modify-private", params.Get("scope"))
+	assert.Equal("user-read-email playlist-read-private playlist-modify-public playlist-modify-private ugc-image-upload", params.Get("scope"))
+	assert.False(params.Has("code_challenge"))
+	assert.False(params.Has("code_challenge_method"))
+}
+
+func TestSpotifyClient_GenerateAuthURL_PKCE(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := &config.AuthConfig{
+		SpotifyClientID:    "test_client_id",
+		SpotifyRedirectURI: "http://localhost:8080/callback",
+	}
+	client := NewSpotifyClient(cfg, createTestLogger())
+
+	authURL := client.GenerateAuthURL("test_state", "test_challenge")
+
+	parsedURL, err := url.Parse(authURL)
+	assert.NoError(err)
+
+	params := parsedURL.Query()
+	assert.Equal("test_state", params.Get("state"))
+	assert.Equal("test_challenge", params.Get("code_challenge"))
+	assert.Equal("S256", params.Get("code_challenge_method"))
 }
 
 func TestSpotifyClient_ExchangeCodeForTokens(t *testing.T) {
@@ -166,6 +203,8 @@ func TestSpotifyClient_ExchangeCodeForTokens(t *testing.T) {
 						assert.Equal("authorization_code", form.Get("grant_type"))
 						assert.Equal("test_code", form.Get("code"))
 						assert.Equal("http://localhost:8080/callback", form.Get("redirect_uri"))
+						assert.False(form.Has("code_verifier"))
+						assert.False(form.Has("client_id"))
 
 						return resp, nil
 					}).
@@ -173,7 +212,7 @@ func TestSpotifyClient_ExchangeCodeForTokens(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			tokens, err := client.ExchangeCodeForTokens(ctx, "test_code")
+			tokens, err := client.ExchangeCodeForTokens(ctx, "test_code", "")
 
 			if tt.expectError {
 				assert.Error(err)
@@ -186,6 +225,126 @@ func TestSpotifyClient_ExchangeCodeForTokens(t *testing.T) {
 	}
 }
 
+func TestSpotifyClient_ExchangeCodeForTokens_PKCEPublicClient(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	cfg := &config.AuthConfig{
+		SpotifyClientID:    "test_client_id",
+		SpotifyRedirectURI: "http://localhost:8080/callback",
+	}
+	client := NewSpotifyClient(cfg, createTestLogger())
+	client.HttpClient = mockHTTPClient
+
+	expectedTokens := &SpotifyTokenResponse{
+		AccessToken:  "access_token_123",
+		TokenType:    "Bearer",
+		ExpiresIn:    3600,
+		RefreshToken: "refresh_token_123",
+	}
+	bodyBytes, _ := json.Marshal(expectedTokens)
+
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			// Public clients send no credentials, only their client id
+			_, _, ok := req.BasicAuth()
+			assert.False(ok)
+
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			assert.Equal("authorization_code", form.Get("grant_type"))
+			assert.Equal("test_code", form.Get("code"))
+			assert.Equal("test_verifier", form.Get("code_verifier"))
+			assert.Equal("test_client_id", form.Get("client_id"))
+
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(bodyBytes))}, nil
+		}).
+		Times(1)
+
+	tokens, err := client.ExchangeCodeForTokens(context.Background(), "test_code", "test_verifier")
+
+	assert.NoError(err)
+	assert.Equal(expectedTokens, tokens)
+}
+
+func TestSpotifyClient_RefreshTokens_PublicClient(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	cfg := &config.AuthConfig{
+		SpotifyClientID:    "test_client_id",
+		SpotifyRedirectURI: "http://localhost:8080/callback",
+	}
+	client := NewSpotifyClient(cfg, createTestLogger())
+	client.HttpClient = mockHTTPClient
+
+	expectedTokens := &SpotifyTokenResponse{
+		AccessToken:  "new_access_token_456",
+		TokenType:    "Bearer",
+		ExpiresIn:    3600,
+		RefreshToken: "new_refresh_token_789",
+	}
+	bodyBytes, _ := json.Marshal(expectedTokens)
+
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			_, _, ok := req.BasicAuth()
+			assert.False(ok)
+
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			assert.Equal("refresh_token", form.Get("grant_type"))
+			assert.Equal("refresh_token_123", form.Get("refresh_token"))
+			assert.Equal("test_client_id", form.Get("client_id"))
+
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(bodyBytes))}, nil
+		}).
+		Times(1)
+
+	tokens, err := client.RefreshTokens(context.Background(), "refresh_token_123")
+
+	assert.NoError(err)
+	assert.Equal(expectedTokens, tokens)
+}
+
+func TestSpotifyClient_SetClientSecret(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	cfg := &config.AuthConfig{
+		SpotifyClientID:     "test_client_id",
+		SpotifyClientSecret: "test_client_secret",
+		SpotifyRedirectURI:  "http://localhost:8080/callback",
+	}
+	client := NewSpotifyClient(cfg, createTestLogger())
+	client.HttpClient = mockHTTPClient
+
+	// A rotated secret is used by the next token requests
+	client.SetClientSecret("rotated_client_secret")
+
+	bodyBytes, _ := json.Marshal(&SpotifyTokenResponse{AccessToken: "new_access_token_456"})
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			clientID, clientSecret, ok := req.BasicAuth()
+			assert.True(ok)
+			assert.Equal("test_client_id", clientID)
+			assert.Equal("rotated_client_secret", clientSecret)
+
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(bodyBytes))}, nil
+		}).
+		Times(1)
+
+	_, err := client.RefreshTokens(context.Background(), "refresh_token_123")
+
+	assert.NoError(err)
+}
+
 func TestSpotifyClient_RefreshTokens_Success(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -395,6 +554,11 @@ func TestSpotifyClient_GetUserProfile(t *testing.T) {
 			expectError:   true,
 			accessToken:   "valid_token",
 		},
+		{
+			name:        "missing credentials",
+			expectError: true,
+			accessToken: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -415,13 +579,13 @@ func TestSpotifyClient_GetUserProfile(t *testing.T) {
 			client := NewSpotifyClient(cfg, logger)
 			client.HttpClient = mockHTTPClient
 
-			// Setup mock expectations
+			// Setup mock expectations, no request is sent without credentials
 			if tt.responseError != nil {
 				mockHTTPClient.EXPECT().
 					Do(gomock.Any()).
 					Return(nil, tt.responseError).
 					Times(1)
-			} else {
+			} else if tt.accessToken != "" {
 				// Create response body
 				var responseBody io.ReadCloser
 				if tt.responseBody != nil {
@@ -449,8 +613,8 @@ func TestSpotifyClient_GetUserProfile(t *testing.T) {
 					Times(1)
 			}
 
-			ctx := context.Background()
-			profile, err := client.GetUserProfile(ctx, tt.accessToken)
+			ctx := contextWithTokenAndID(tt.accessToken, "user123")
+			profile, err := client.GetUserProfile(ctx)
 
 			if tt.expectError {
 				assert.Error(err)
@@ -462,3 +626,49 @@ func TestSpotifyClient_GetUserProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestSpotifyClient_Ping(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		doErr       error
+		expectedErr string
+	}{
+		{name: "method not allowed means reachable", statusCode: http.StatusMethodNotAllowed},
+		{name: "ok", statusCode: http.StatusOK},
+		{name: "server error", statusCode: http.StatusServiceUnavailable, expectedErr: "spotify unavailable (status 503)"},
+		{name: "network error", doErr: errors.New("connection refused"), expectedErr: "spotify unreachable: connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			mockHTTPClient.EXPECT().
+				Do(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal(http.MethodHead, req.Method)
+					assert.Equal("https://accounts.spotify.com/api/token", req.URL.String())
+					assert.Empty(req.Header.Get("Authorization"))
+
+					if tt.doErr != nil {
+						return nil, tt.doErr
+					}
+					return &http.Response{StatusCode: tt.statusCode, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+				})
+
+			err := client.Ping(context.Background())
+
+			if tt.expectedErr != "" {
+				assert.EqualError(err, tt.expectedErr)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
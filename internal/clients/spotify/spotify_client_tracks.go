@@ -10,7 +10,97 @@ import (
 	"strings"
 )
 
+// GetPlaylistTracks reads a page of the tracks of a playlist. With a read cache pages are keyed by
+// the snapshot of the playlist, looked up from spotify for the first page so edits made outside of
+// the router are always seen, and reused for the following pages
 func (c *SpotifyClient) GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*SpotifyPlaylistTracksResponse, error) {
+	account, ok := c.cacheAccount(ctx)
+	if !ok {
+		return c.fetchPlaylistTracks(ctx, playlistID, limit, offset)
+	}
+
+	snapshotID, err := c.playlistSnapshot(ctx, account, playlistID, offset == 0)
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to look up playlist snapshot, skipping cache", "playlist_id", playlistID, "error", err)
+		return c.fetchPlaylistTracks(ctx, playlistID, limit, offset)
+	}
+
+	key := playlistTracksCacheKey(account, playlistID, snapshotID, limit, offset)
+	return cachedRead(ctx, c, key, PLAYLIST_TRACKS_CACHE_TTL, func() (*SpotifyPlaylistTracksResponse, error) {
+		return c.fetchPlaylistTracks(ctx, playlistID, limit, offset)
+	})
+}
+
+// GetPlaylistSnapshotID returns the current snapshot ID of a playlist, which changes with every edit
+// of its tracks. It is always read from spotify, refreshing the cached one
+func (c *SpotifyClient) GetPlaylistSnapshotID(ctx context.Context, playlistID string) (string, error) {
+	account, ok := c.cacheAccount(ctx)
+	if !ok {
+		return c.fetchPlaylistSnapshot(ctx, playlistID)
+	}
+
+	return c.playlistSnapshot(ctx, account, playlistID, true)
+}
+
+// playlistSnapshot returns the current snapshot ID of a playlist, the one cached for
+// PLAYLIST_CACHE_TTL unless refresh is set
+func (c *SpotifyClient) playlistSnapshot(ctx context.Context, account, playlistID string, refresh bool) (string, error) {
+	key := playlistSnapshotCacheKey(account, playlistID)
+
+	var snapshotID string
+	if !refresh && c.cache.get(ctx, key, &snapshotID) {
+		return snapshotID, nil
+	}
+
+	snapshotID, err := c.fetchPlaylistSnapshot(ctx, playlistID)
+	if err != nil {
+		return "", err
+	}
+
+	c.cache.set(ctx, key, snapshotID, PLAYLIST_CACHE_TTL)
+	return snapshotID, nil
+}
+
+func (c *SpotifyClient) fetchPlaylistSnapshot(ctx context.Context, playlistID string) (string, error) {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{
+		"fields": {"snapshot_id"},
+	}
+
+	path := fmt.Sprintf("playlists/%s", playlistID)
+	url := fmt.Sprintf("%s%s?%s", c.apiBaseUrl, path, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create playlist snapshot request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get playlist snapshot: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("spotify playlist snapshot fetch failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var snapshot SpotifySnapshotResponse
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return "", fmt.Errorf("failed to decode playlist snapshot response: %w", err)
+	}
+
+	return snapshot.SnapshotID, nil
+}
+
+func (c *SpotifyClient) fetchPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*SpotifyPlaylistTracksResponse, error) {
 	c.logger.InfoContext(ctx, "fetching playlist tracks from spotify", "playlist_id", playlistID, "limit", limit, "offset", offset)
 
 	accessToken, err := c.getAccessToken(ctx)
@@ -35,7 +125,7 @@ func (c *SpotifyClient) GetPlaylistTracks(ctx context.Context, playlistID string
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to get playlist tracks", "error", err)
 		return nil, fmt.Errorf("failed to get playlist tracks: %w", err)
@@ -68,6 +158,7 @@ func (c *SpotifyClient) AddTracksToPlaylist(ctx context.Context, playlistID stri
 		"playlist_id", playlistID,
 		"track_count", len(trackURIs),
 	)
+	defer c.invalidatePlaylist(ctx, playlistID)
 
 	path := fmt.Sprintf("playlists/%s/tracks", playlistID)
 	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
@@ -91,7 +182,7 @@ func (c *SpotifyClient) AddTracksToPlaylist(ctx context.Context, playlistID stri
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HttpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		c.logger.ErrorContext(ctx, "failed to add tracks to playlist", "error", err)
 		return fmt.Errorf("failed to add tracks to playlist: %w", err)
@@ -114,3 +205,462 @@ func (c *SpotifyClient) AddTracksToPlaylist(ctx context.Context, playlistID stri
 	)
 	return nil
 }
+
+// ReplacePlaylistTracks overwrites the contents of a playlist with up to 100 tracks, an empty
+// list clears it. The playlist keeps its ID, followers and cover.
+func (c *SpotifyClient) ReplacePlaylistTracks(ctx context.Context, playlistID string, trackURIs []string) error {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.logger.InfoContext(ctx, "replacing playlist tracks",
+		"playlist_id", playlistID,
+		"track_count", len(trackURIs),
+	)
+	defer c.invalidatePlaylist(ctx, playlistID)
+
+	path := fmt.Sprintf("playlists/%s/tracks", playlistID)
+	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
+
+	if trackURIs == nil {
+		trackURIs = []string{}
+	}
+	requestBody := map[string][]string{
+		"uris": trackURIs,
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to marshal replace tracks request", "error", err)
+		return fmt.Errorf("failed to marshal replace tracks request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, strings.NewReader(string(jsonData)))
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create replace tracks request", "error", err)
+		return fmt.Errorf("failed to create replace tracks request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to replace playlist tracks", "error", err)
+		return fmt.Errorf("failed to replace playlist tracks: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify replace tracks failed",
+			"status_code", resp.StatusCode,
+			"response_body", string(body),
+			"playlist_id", playlistID,
+		)
+		return fmt.Errorf("spotify replace tracks failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	c.logger.InfoContext(ctx, "successfully replaced playlist tracks",
+		"playlist_id", playlistID,
+		"track_count", len(trackURIs),
+	)
+	return nil
+}
+
+// ReorderPlaylistTracks moves a range of tracks within a playlist without removing and adding them
+// again, returning the snapshot ID of the reordered playlist
+func (c *SpotifyClient) ReorderPlaylistTracks(ctx context.Context, playlistID string, reorder SpotifyReorderRequest) (string, error) {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.InfoContext(ctx, "reordering playlist tracks",
+		"playlist_id", playlistID,
+		"range_start", reorder.RangeStart,
+		"range_length", reorder.RangeLength,
+		"insert_before", reorder.InsertBefore,
+	)
+	defer c.invalidatePlaylist(ctx, playlistID)
+
+	path := fmt.Sprintf("playlists/%s/tracks", playlistID)
+	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
+
+	jsonData, err := json.Marshal(reorder)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to marshal reorder tracks request", "error", err)
+		return "", fmt.Errorf("failed to marshal reorder tracks request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, strings.NewReader(string(jsonData)))
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create reorder tracks request", "error", err)
+		return "", fmt.Errorf("failed to create reorder tracks request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to reorder playlist tracks", "error", err)
+		return "", fmt.Errorf("failed to reorder playlist tracks: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify reorder tracks failed",
+			"status_code", resp.StatusCode,
+			"response_body", string(body),
+			"playlist_id", playlistID,
+		)
+		return "", fmt.Errorf("spotify reorder tracks failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var snapshot SpotifySnapshotResponse
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		c.logger.ErrorContext(ctx, "failed to decode reorder tracks response", "error", err)
+		return "", fmt.Errorf("failed to decode reorder tracks response: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "successfully reordered playlist tracks",
+		"playlist_id", playlistID,
+		"snapshot_id", snapshot.SnapshotID,
+	)
+	return snapshot.SnapshotID, nil
+}
+
+// RemoveTracksFromPlaylist removes every occurrence of the tracks from a playlist, sending
+// MAX_PLAYLIST_ITEMS tracks per request. The playlist keeps its ID, followers and cover.
+func (c *SpotifyClient) RemoveTracksFromPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	if len(trackURIs) == 0 {
+		return nil
+	}
+
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.logger.InfoContext(ctx, "removing tracks from playlist",
+		"playlist_id", playlistID,
+		"track_count", len(trackURIs),
+	)
+	defer c.invalidatePlaylist(ctx, playlistID)
+
+	for offset := 0; offset < len(trackURIs); offset += MAX_PLAYLIST_ITEMS {
+		endIndex := min(offset+MAX_PLAYLIST_ITEMS, len(trackURIs))
+		if err := c.removeTracksBatch(ctx, accessToken, playlistID, trackURIs[offset:endIndex]); err != nil {
+			return err
+		}
+	}
+
+	c.logger.InfoContext(ctx, "successfully removed tracks from playlist",
+		"playlist_id", playlistID,
+		"tracks_removed", len(trackURIs),
+	)
+	return nil
+}
+
+func (c *SpotifyClient) removeTracksBatch(ctx context.Context, accessToken, playlistID string, trackURIs []string) error {
+	path := fmt.Sprintf("playlists/%s/tracks", playlistID)
+	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
+
+	tracks := make([]SpotifyTrackReference, len(trackURIs))
+	for i, uri := range trackURIs {
+		tracks[i] = SpotifyTrackReference{URI: uri}
+	}
+	requestBody := map[string][]SpotifyTrackReference{
+		"tracks": tracks,
+	}
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to marshal remove tracks request", "error", err)
+		return fmt.Errorf("failed to marshal remove tracks request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, strings.NewReader(string(jsonData)))
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create remove tracks request", "error", err)
+		return fmt.Errorf("failed to create remove tracks request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to remove tracks from playlist", "error", err)
+		return fmt.Errorf("failed to remove tracks from playlist: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify remove tracks failed",
+			"status_code", resp.StatusCode,
+			"response_body", string(body),
+			"playlist_id", playlistID,
+		)
+		return fmt.Errorf("spotify remove tracks failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// GetAudioFeatures fetches the audio features of up to 100 tracks. Tracks Spotify has no
+// audio features for are returned as nil entries, in the same position as their ID. With a read
+// cache only the tracks not cached yet are fetched
+func (c *SpotifyClient) GetAudioFeatures(ctx context.Context, trackIDs []string) ([]*SpotifyAudioFeatures, error) {
+	if c.cache == nil || len(trackIDs) == 0 {
+		return c.fetchAudioFeatures(ctx, trackIDs)
+	}
+
+	audioFeatures := make([]*SpotifyAudioFeatures, len(trackIDs))
+	missing := make([]int, 0, len(trackIDs))
+	for i, trackID := range trackIDs {
+		if !c.cache.get(ctx, audioFeaturesCacheKey(trackID), &audioFeatures[i]) {
+			missing = append(missing, i)
+		}
+	}
+
+	if len(missing) == 0 {
+		return audioFeatures, nil
+	}
+
+	missingIDs := make([]string, len(missing))
+	for i, index := range missing {
+		missingIDs[i] = trackIDs[index]
+	}
+
+	fetched, err := c.fetchAudioFeatures(ctx, missingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tracks without audio features are cached too, Spotify won't analyze them later
+	for i, index := range missing {
+		if i < len(fetched) {
+			audioFeatures[index] = fetched[i]
+			c.cache.set(ctx, audioFeaturesCacheKey(trackIDs[index]), fetched[i], AUDIO_FEATURES_CACHE_TTL)
+		}
+	}
+
+	return audioFeatures, nil
+}
+
+func (c *SpotifyClient) fetchAudioFeatures(ctx context.Context, trackIDs []string) ([]*SpotifyAudioFeatures, error) {
+	if len(trackIDs) == 0 {
+		return []*SpotifyAudioFeatures{}, nil
+	}
+
+	c.logger.InfoContext(ctx, "fetching audio features from spotify", "track_count", len(trackIDs))
+
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{
+		"ids": {strings.Join(trackIDs, ",")},
+	}
+
+	path := "audio-features"
+	url := fmt.Sprintf("%s%s?%s", c.apiBaseUrl, path, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create audio features request", "error", err)
+		return nil, fmt.Errorf("failed to create audio features request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to get audio features", "error", err)
+		return nil, fmt.Errorf("failed to get audio features: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify audio features fetch failed", "status_code", resp.StatusCode, "response_body", string(body))
+		return nil, fmt.Errorf("spotify audio features fetch failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var audioFeaturesResponse struct {
+		AudioFeatures []*SpotifyAudioFeatures `json:"audio_features"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&audioFeaturesResponse); err != nil {
+		c.logger.ErrorContext(ctx, "failed to decode audio features response", "error", err)
+		return nil, fmt.Errorf("failed to decode audio features response: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "successfully fetched audio features", "audio_features_count", len(audioFeaturesResponse.AudioFeatures))
+	return audioFeaturesResponse.AudioFeatures, nil
+}
+
+// GetTrack looks up a single track. Unknown and malformed track IDs return ErrTrackNotFound
+func (c *SpotifyClient) GetTrack(ctx context.Context, trackID string) (*SpotifyTrack, error) {
+	c.logger.InfoContext(ctx, "fetching track from spotify", "track_id", trackID)
+
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("tracks/%s", url.PathEscape(trackID))
+	url := fmt.Sprintf("%s%s", c.apiBaseUrl, path)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create track request", "error", err)
+		return nil, fmt.Errorf("failed to create track request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to get track", "error", err)
+		return nil, fmt.Errorf("failed to get track: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	// Spotify answers 400 for malformed IDs and 404 for unknown ones
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("%w: %s", ErrTrackNotFound, trackID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify track fetch failed", "status_code", resp.StatusCode, "response_body", string(body))
+		return nil, fmt.Errorf("spotify track fetch failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var track SpotifyTrack
+	if err := json.NewDecoder(resp.Body).Decode(&track); err != nil {
+		c.logger.ErrorContext(ctx, "failed to decode track response", "error", err)
+		return nil, fmt.Errorf("failed to decode track response: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "successfully fetched track", "track_id", trackID)
+	return &track, nil
+}
+
+// GetSeveralTracks looks up to MAX_TRACKS tracks in a single request. Unknown tracks are returned
+// as nil entries, in the same position as their ID.
+func (c *SpotifyClient) GetSeveralTracks(ctx context.Context, trackIDs []string) ([]*SpotifyTrack, error) {
+	if len(trackIDs) == 0 {
+		return []*SpotifyTrack{}, nil
+	}
+
+	c.logger.InfoContext(ctx, "fetching tracks from spotify", "track_count", len(trackIDs))
+
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{
+		"ids": {strings.Join(trackIDs, ",")},
+	}
+
+	path := "tracks"
+	url := fmt.Sprintf("%s%s?%s", c.apiBaseUrl, path, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create tracks request", "error", err)
+		return nil, fmt.Errorf("failed to create tracks request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to get tracks", "error", err)
+		return nil, fmt.Errorf("failed to get tracks: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify tracks fetch failed", "status_code", resp.StatusCode, "response_body", string(body))
+		return nil, fmt.Errorf("spotify tracks fetch failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var tracksResponse struct {
+		Tracks []*SpotifyTrack `json:"tracks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tracksResponse); err != nil {
+		c.logger.ErrorContext(ctx, "failed to decode tracks response", "error", err)
+		return nil, fmt.Errorf("failed to decode tracks response: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "successfully fetched tracks", "tracks_count", len(tracksResponse.Tracks))
+	return tracksResponse.Tracks, nil
+}
+
+// SearchTracks returns up to limit catalog tracks matching the query, best match first. Queries
+// take spotify's field filters, as isrc:, track: and artist:
+func (c *SpotifyClient) SearchTracks(ctx context.Context, query string, limit int) ([]*SpotifyTrack, error) {
+	if limit <= 0 || limit > MAX_TRACKS {
+		limit = MAX_TRACKS
+	}
+
+	c.logger.InfoContext(ctx, "searching tracks on spotify", "query", query)
+
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{
+		"q":     {query},
+		"type":  {"track"},
+		"limit": {fmt.Sprint(limit)},
+	}
+
+	path := "search"
+	url := fmt.Sprintf("%s%s?%s", c.apiBaseUrl, path, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to create search request", "error", err)
+		return nil, fmt.Errorf("failed to create search request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to search tracks", "error", err)
+		return nil, fmt.Errorf("failed to search tracks: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "spotify track search failed", "status_code", resp.StatusCode, "response_body", string(body))
+		return nil, fmt.Errorf("spotify track search failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var searchResponse struct {
+		Tracks struct {
+			Items []*SpotifyTrack `json:"items"`
+		} `json:"tracks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		c.logger.ErrorContext(ctx, "failed to decode search response", "error", err)
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "successfully searched tracks", "tracks_count", len(searchResponse.Tracks.Items))
+	return searchResponse.Tracks.Items, nil
+}
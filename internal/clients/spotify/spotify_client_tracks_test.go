@@ -542,7 +542,678 @@ func TestSpotifyClient_AddTracksToPlaylist_Errors(t *testing.T) {
 	}
 }
 
+func TestSpotifyClient_ReplacePlaylistTracks(t *testing.T) {
+	tests := []struct {
+		name           string
+		trackURIs      []string
+		responseStatus int
+		expectedURIs   []string
+		expectedError  string
+	}{
+		{
+			name:           "replaces the tracks",
+			trackURIs:      []string{"spotify:track:track1", "spotify:track:track2"},
+			responseStatus: http.StatusOK,
+			expectedURIs:   []string{"spotify:track:track1", "spotify:track:track2"},
+		},
+		{
+			name:           "clears the playlist",
+			trackURIs:      nil,
+			responseStatus: http.StatusOK,
+			expectedURIs:   []string{},
+		},
+		{
+			name:           "spotify error",
+			trackURIs:      []string{"spotify:track:track1"},
+			responseStatus: http.StatusForbidden,
+			expectedURIs:   []string{"spotify:track:track1"},
+			expectedError:  "spotify replace tracks failed (status 403)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+				AccessToken: "valid_access_token",
+				UserID:      "test_user",
+			})
+
+			mockHTTPClient.EXPECT().
+				Do(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal("PUT", req.Method)
+					assert.Equal("https://api.spotify.com/v1/playlists/playlist123/tracks", req.URL.String())
+
+					var requestBody map[string][]string
+					bodyBytes, _ := io.ReadAll(req.Body)
+					assert.NoError(json.Unmarshal(bodyBytes, &requestBody))
+					assert.Equal(tt.expectedURIs, requestBody["uris"])
+
+					return &http.Response{
+						StatusCode: tt.responseStatus,
+						Body:       io.NopCloser(strings.NewReader(`{"snapshot_id": "new_snapshot"}`)),
+					}, nil
+				})
+
+			err := client.ReplacePlaylistTracks(ctx, "playlist123", tt.trackURIs)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(err, tt.expectedError)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
 // Helper function to create string pointer
 func stringPointer(s string) *string {
 	return &s
 }
+
+func TestSpotifyClient_GetAudioFeatures_Success(t *testing.T) {
+	tests := []struct {
+		name           string
+		trackIDs       []string
+		responseBody   string
+		expectedResult []*SpotifyAudioFeatures
+	}{
+		{
+			name:         "audio features for every track",
+			trackIDs:     []string{"track1", "track2"},
+			responseBody: `{"audio_features":[{"id":"track1","tempo":120.5,"energy":0.8,"key":5,"mode":1},{"id":"track2","tempo":90,"energy":0.3,"key":0,"mode":0}]}`,
+			expectedResult: []*SpotifyAudioFeatures{
+				{ID: "track1", Tempo: 120.5, Energy: 0.8, Key: 5, Mode: 1},
+				{ID: "track2", Tempo: 90, Energy: 0.3, Key: 0, Mode: 0},
+			},
+		},
+		{
+			name:         "missing audio features are returned as nil",
+			trackIDs:     []string{"track1", "unknown"},
+			responseBody: `{"audio_features":[{"id":"track1","tempo":120.5},null]}`,
+			expectedResult: []*SpotifyAudioFeatures{
+				{ID: "track1", Tempo: 120.5},
+				nil,
+			},
+		},
+		{
+			name:           "empty track IDs returns empty slice",
+			trackIDs:       []string{},
+			expectedResult: []*SpotifyAudioFeatures{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+				AccessToken: "valid_access_token",
+				UserID:      "test_user",
+			})
+
+			if len(tt.trackIDs) > 0 {
+				expectedURL := fmt.Sprintf("https://api.spotify.com/v1/audio-features?ids=%s", strings.Join(tt.trackIDs, "%2C"))
+
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal("GET", req.Method)
+						assert.Equal(expectedURL, req.URL.String())
+						assert.Equal("Bearer valid_access_token", req.Header.Get("Authorization"))
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+						}, nil
+					}).
+					Times(1)
+			}
+
+			result, err := client.GetAudioFeatures(ctx, tt.trackIDs)
+
+			assert.NoError(err)
+			assert.Equal(tt.expectedResult, result)
+		})
+	}
+}
+
+func TestSpotifyClient_GetAudioFeatures_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		accessToken    string
+		responseStatus int
+		responseBody   string
+		httpError      error
+		expectedError  string
+	}{
+		{
+			name:           "spotify error response",
+			accessToken:    "valid_access_token",
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"error":{"status":403,"message":"Forbidden"}}`,
+			expectedError:  "spotify audio features fetch failed (status 403)",
+		},
+		{
+			name:          "http error",
+			accessToken:   "valid_access_token",
+			httpError:     errors.New("connection refused"),
+			expectedError: "failed to get audio features",
+		},
+		{
+			name:           "invalid response body",
+			accessToken:    "valid_access_token",
+			responseStatus: http.StatusOK,
+			responseBody:   `not json`,
+			expectedError:  "failed to decode audio features response",
+		},
+		{
+			name:          "missing access token",
+			expectedError: "spotify credentials not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := context.Background()
+			if tt.accessToken != "" {
+				ctx = requestcontext.ContextWithSpotifyAuth(ctx, &models.SpotifyIntegration{
+					AccessToken: tt.accessToken,
+					UserID:      "test_user",
+				})
+			}
+
+			if tt.httpError != nil {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(nil, tt.httpError).Times(1)
+			} else if tt.responseStatus > 0 {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+					StatusCode: tt.responseStatus,
+					Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+				}, nil).Times(1)
+			}
+
+			result, err := client.GetAudioFeatures(ctx, []string{"track1"})
+
+			assert.Error(err)
+			assert.Nil(result)
+			assert.Contains(err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestSpotifyClient_RemoveTracksFromPlaylist(t *testing.T) {
+	manyURIs := make([]string, MAX_PLAYLIST_ITEMS+1)
+	for i := range manyURIs {
+		manyURIs[i] = fmt.Sprintf("spotify:track:track%d", i)
+	}
+
+	tests := []struct {
+		name            string
+		trackURIs       []string
+		responseStatus  int
+		expectedBatches []int
+		expectedError   string
+	}{
+		{
+			name:            "removes the tracks",
+			trackURIs:       []string{"spotify:track:track1", "spotify:track:track2"},
+			responseStatus:  http.StatusOK,
+			expectedBatches: []int{2},
+		},
+		{
+			name:            "batches the tracks",
+			trackURIs:       manyURIs,
+			responseStatus:  http.StatusOK,
+			expectedBatches: []int{MAX_PLAYLIST_ITEMS, 1},
+		},
+		{
+			name:      "nothing to remove",
+			trackURIs: nil,
+		},
+		{
+			name:            "spotify error",
+			trackURIs:       manyURIs,
+			responseStatus:  http.StatusForbidden,
+			expectedBatches: []int{MAX_PLAYLIST_ITEMS},
+			expectedError:   "spotify remove tracks failed (status 403)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+				AccessToken: "valid_access_token",
+				UserID:      "test_user",
+			})
+
+			var batches []int
+			var removed []string
+			mockHTTPClient.EXPECT().
+				Do(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal("DELETE", req.Method)
+					assert.Equal("https://api.spotify.com/v1/playlists/playlist123/tracks", req.URL.String())
+					assert.Equal("Bearer valid_access_token", req.Header.Get("Authorization"))
+
+					var requestBody map[string][]SpotifyTrackReference
+					bodyBytes, _ := io.ReadAll(req.Body)
+					assert.NoError(json.Unmarshal(bodyBytes, &requestBody))
+					batches = append(batches, len(requestBody["tracks"]))
+					for _, track := range requestBody["tracks"] {
+						removed = append(removed, track.URI)
+					}
+
+					return &http.Response{
+						StatusCode: tt.responseStatus,
+						Body:       io.NopCloser(strings.NewReader(`{"snapshot_id": "new_snapshot"}`)),
+					}, nil
+				}).
+				Times(len(tt.expectedBatches))
+
+			err := client.RemoveTracksFromPlaylist(ctx, "playlist123", tt.trackURIs)
+
+			assert.Equal(tt.expectedBatches, batches)
+			if tt.expectedError != "" {
+				assert.ErrorContains(err, tt.expectedError)
+				return
+			}
+			assert.NoError(err)
+			if len(tt.trackURIs) > 0 {
+				assert.Equal(tt.trackURIs, removed)
+			}
+		})
+	}
+}
+
+func TestSpotifyClient_ReorderPlaylistTracks(t *testing.T) {
+	tests := []struct {
+		name           string
+		reorder        SpotifyReorderRequest
+		responseStatus int
+		responseBody   string
+		expectedBody   string
+		expectedID     string
+		expectedError  string
+	}{
+		{
+			name:           "moves a range of tracks",
+			reorder:        SpotifyReorderRequest{RangeStart: 5, InsertBefore: 0, RangeLength: 2, SnapshotID: "snapshot1"},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"snapshot_id": "snapshot2"}`,
+			expectedBody:   `{"range_start":5,"insert_before":0,"range_length":2,"snapshot_id":"snapshot1"}`,
+			expectedID:     "snapshot2",
+		},
+		{
+			name:           "moves a single track of the latest version",
+			reorder:        SpotifyReorderRequest{RangeStart: 0, InsertBefore: 10},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"snapshot_id": "snapshot2"}`,
+			expectedBody:   `{"range_start":0,"insert_before":10}`,
+			expectedID:     "snapshot2",
+		},
+		{
+			name:           "spotify error",
+			reorder:        SpotifyReorderRequest{RangeStart: 50, InsertBefore: 0},
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"error": {"status": 400, "message": "Range out of bounds"}}`,
+			expectedBody:   `{"range_start":50,"insert_before":0}`,
+			expectedError:  "spotify reorder tracks failed (status 400)",
+		},
+		{
+			name:           "invalid response",
+			reorder:        SpotifyReorderRequest{RangeStart: 0, InsertBefore: 10},
+			responseStatus: http.StatusOK,
+			responseBody:   `not json`,
+			expectedBody:   `{"range_start":0,"insert_before":10}`,
+			expectedError:  "failed to decode reorder tracks response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+				AccessToken: "valid_access_token",
+				UserID:      "test_user",
+			})
+
+			mockHTTPClient.EXPECT().
+				Do(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal("PUT", req.Method)
+					assert.Equal("https://api.spotify.com/v1/playlists/playlist123/tracks", req.URL.String())
+
+					bodyBytes, _ := io.ReadAll(req.Body)
+					assert.JSONEq(tt.expectedBody, string(bodyBytes))
+
+					return &http.Response{
+						StatusCode: tt.responseStatus,
+						Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+					}, nil
+				})
+
+			snapshotID, err := client.ReorderPlaylistTracks(ctx, "playlist123", tt.reorder)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(err, tt.expectedError)
+				assert.Empty(snapshotID)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expectedID, snapshotID)
+		})
+	}
+}
+
+func TestSpotifyClient_ReorderPlaylistTracks_NoCredentials(t *testing.T) {
+	assert := require.New(t)
+
+	client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+
+	_, err := client.ReorderPlaylistTracks(context.Background(), "playlist123", SpotifyReorderRequest{InsertBefore: 1})
+
+	assert.ErrorIs(err, ErrSpotifyCredentialsNotFound)
+}
+
+func TestSpotifyClient_GetSeveralTracks(t *testing.T) {
+	tests := []struct {
+		name           string
+		trackIDs       []string
+		responseBody   string
+		expectedResult []*SpotifyTrack
+	}{
+		{
+			name:         "tracks in request order",
+			trackIDs:     []string{"track1", "track2"},
+			responseBody: `{"tracks":[{"id":"track1","name":"Track 1","uri":"spotify:track:track1","duration_ms":180000},{"id":"track2","name":"Track 2","uri":"spotify:track:track2","explicit":true}]}`,
+			expectedResult: []*SpotifyTrack{
+				{ID: "track1", Name: "Track 1", URI: "spotify:track:track1", DurationMs: 180000},
+				{ID: "track2", Name: "Track 2", URI: "spotify:track:track2", Explicit: true},
+			},
+		},
+		{
+			name:         "unknown tracks are returned as nil",
+			trackIDs:     []string{"track1", "unknown"},
+			responseBody: `{"tracks":[{"id":"track1","uri":"spotify:track:track1"},null]}`,
+			expectedResult: []*SpotifyTrack{
+				{ID: "track1", URI: "spotify:track:track1"},
+				nil,
+			},
+		},
+		{
+			name:           "empty track IDs returns empty slice",
+			trackIDs:       []string{},
+			expectedResult: []*SpotifyTrack{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+				AccessToken: "valid_access_token",
+				UserID:      "test_user",
+			})
+
+			if len(tt.trackIDs) > 0 {
+				expectedURL := fmt.Sprintf("https://api.spotify.com/v1/tracks?ids=%s", strings.Join(tt.trackIDs, "%2C"))
+
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal("GET", req.Method)
+						assert.Equal(expectedURL, req.URL.String())
+						assert.Equal("Bearer valid_access_token", req.Header.Get("Authorization"))
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+						}, nil
+					}).
+					Times(1)
+			}
+
+			result, err := client.GetSeveralTracks(ctx, tt.trackIDs)
+
+			assert.NoError(err)
+			assert.Equal(tt.expectedResult, result)
+		})
+	}
+}
+
+func TestSpotifyClient_GetTrack(t *testing.T) {
+	tests := []struct {
+		name           string
+		accessToken    string
+		responseStatus int
+		responseBody   string
+		httpError      error
+		expectedResult *SpotifyTrack
+		expectedErr    error
+		expectedError  string
+	}{
+		{
+			name:           "track found",
+			accessToken:    "valid_access_token",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"id":"track1","name":"Track 1","uri":"spotify:track:track1","popularity":70}`,
+			expectedResult: &SpotifyTrack{ID: "track1", Name: "Track 1", URI: "spotify:track:track1", Popularity: 70},
+		},
+		{
+			name:           "unknown track",
+			accessToken:    "valid_access_token",
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"error":{"status":404,"message":"Not found."}}`,
+			expectedErr:    ErrTrackNotFound,
+		},
+		{
+			name:           "malformed track ID",
+			accessToken:    "valid_access_token",
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"error":{"status":400,"message":"invalid id"}}`,
+			expectedErr:    ErrTrackNotFound,
+		},
+		{
+			name:           "spotify error response",
+			accessToken:    "valid_access_token",
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"error":{"status":403,"message":"Forbidden"}}`,
+			expectedError:  "spotify track fetch failed (status 403)",
+		},
+		{
+			name:          "http error",
+			accessToken:   "valid_access_token",
+			httpError:     errors.New("connection refused"),
+			expectedError: "failed to get track",
+		},
+		{
+			name:          "missing access token",
+			expectedError: "spotify credentials not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := context.Background()
+			if tt.accessToken != "" {
+				ctx = requestcontext.ContextWithSpotifyAuth(ctx, &models.SpotifyIntegration{
+					AccessToken: tt.accessToken,
+					UserID:      "test_user",
+				})
+			}
+
+			if tt.httpError != nil {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(nil, tt.httpError).Times(1)
+			} else if tt.responseStatus > 0 {
+				mockHTTPClient.EXPECT().
+					Do(gomock.Any()).
+					DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal("GET", req.Method)
+						assert.Equal("https://api.spotify.com/v1/tracks/track1", req.URL.String())
+						return &http.Response{
+							StatusCode: tt.responseStatus,
+							Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+						}, nil
+					}).
+					Times(1)
+			}
+
+			result, err := client.GetTrack(ctx, "track1")
+
+			if tt.expectedResult != nil {
+				assert.NoError(err)
+				assert.Equal(tt.expectedResult, result)
+				return
+			}
+
+			assert.Error(err)
+			assert.Nil(result)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(err, tt.expectedErr)
+			} else {
+				assert.Contains(err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestSpotifyClient_SearchTracks(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          int
+		expectedLimit  string
+		responseStatus int
+		responseBody   string
+		expectedResult []*SpotifyTrack
+		expectedError  string
+	}{
+		{
+			name:           "matching tracks",
+			limit:          1,
+			expectedLimit:  "1",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"tracks":{"items":[{"id":"track1","name":"Track 1","uri":"spotify:track:track1","external_ids":{"isrc":"USUM71703861"}}]}}`,
+			expectedResult: []*SpotifyTrack{
+				{ID: "track1", Name: "Track 1", URI: "spotify:track:track1", ExternalIDs: &SpotifyExternalIDs{ISRC: "USUM71703861"}},
+			},
+		},
+		{
+			name:           "no match",
+			limit:          1,
+			expectedLimit:  "1",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"tracks":{"items":[]}}`,
+			expectedResult: []*SpotifyTrack{},
+		},
+		{
+			name:           "limit capped",
+			limit:          500,
+			expectedLimit:  "50",
+			responseStatus: http.StatusOK,
+			responseBody:   `{"tracks":{"items":[]}}`,
+			expectedResult: []*SpotifyTrack{},
+		},
+		{
+			name:           "spotify error response",
+			limit:          1,
+			expectedLimit:  "1",
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"error":{"status":400,"message":"No search query"}}`,
+			expectedError:  "spotify track search failed (status 400)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := setupMockController(t)
+
+			mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+			client := NewSpotifyClient(&config.AuthConfig{}, createTestLogger())
+			client.HttpClient = mockHTTPClient
+
+			ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+				AccessToken: "valid_access_token",
+				UserID:      "test_user",
+			})
+
+			mockHTTPClient.EXPECT().
+				Do(gomock.Any()).
+				DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal("GET", req.Method)
+					assert.Equal("/v1/search", req.URL.Path)
+					assert.Equal("isrc:USUM71703861", req.URL.Query().Get("q"))
+					assert.Equal("track", req.URL.Query().Get("type"))
+					assert.Equal(tt.expectedLimit, req.URL.Query().Get("limit"))
+					assert.Equal("Bearer valid_access_token", req.Header.Get("Authorization"))
+					return &http.Response{
+						StatusCode: tt.responseStatus,
+						Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+					}, nil
+				}).
+				Times(1)
+
+			result, err := client.SearchTracks(ctx, "isrc:USUM71703861", tt.limit)
+
+			if tt.expectedError != "" {
+				assert.Error(err)
+				assert.Contains(err.Error(), tt.expectedError)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.expectedResult, result)
+		})
+	}
+}
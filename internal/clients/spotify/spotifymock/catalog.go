@@ -0,0 +1,191 @@
+package spotifymock
+
+import (
+	"fmt"
+	"time"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+)
+
+// AddArtist adds an artist to the catalog
+func (s *Server) AddArtist(artist spotifyclient.SpotifyArtist) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if artist.URI == "" {
+		artist.URI = "spotify:artist:" + artist.ID
+	}
+	s.artists[artist.ID] = &artist
+}
+
+// AddTrack adds a track to the catalog, with its audio features unless nil. Returns its uri
+func (s *Server) AddTrack(track spotifyclient.SpotifyTrack, audioFeatures *spotifyclient.SpotifyAudioFeatures) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if track.URI == "" {
+		track.URI = trackURI(track.ID)
+	}
+	s.tracks[track.URI] = &track
+
+	if audioFeatures != nil {
+		audioFeatures.ID = track.ID
+		audioFeatures.URI = track.URI
+		s.audioFeatures[track.ID] = audioFeatures
+	}
+
+	return track.URI
+}
+
+// AddPlaylist adds a playlist of the user to their library with the catalog tracks given, added
+// at the times given. Returns the id of the playlist
+func (s *Server) AddPlaylist(name string, trackURIs []string, addedAt []time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.createPlaylist(spotifyclient.SpotifyPlaylist{Name: name, Public: true}, trackURIs)
+	for i := range addedAt {
+		s.playlists[id].items[i].addedAt = addedAt[i].UTC()
+	}
+
+	return id
+}
+
+// createPlaylist stores a new playlist owned and followed by the user
+func (s *Server) createPlaylist(details spotifyclient.SpotifyPlaylist, trackURIs []string) string {
+	s.nextID++
+	details.ID = fmt.Sprintf("mockplaylist%06d", s.nextID)
+	details.URI = "spotify:playlist:" + details.ID
+	details.Href = fmt.Sprintf("%splaylists/%s", API_PATH, details.ID)
+	details.Owner = &spotifyclient.SpotifyPlaylistOwner{ID: s.user.ID, DisplayName: s.user.Name}
+	details.Images = []*spotifyclient.SpotifyPlaylistImage{}
+
+	s.playlists[details.ID] = &playlist{details: details, items: s.newItems(trackURIs)}
+	s.library = append([]string{details.ID}, s.library...)
+
+	return details.ID
+}
+
+// PlaylistTrackURIs returns the uris of the tracks of a playlist in order, and whether it exists
+func (s *Server) PlaylistTrackURIs(playlistID string) ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.playlists[playlistID]
+	if !ok {
+		return nil, false
+	}
+
+	uris := make([]string, len(p.items))
+	for i, item := range p.items {
+		uris[i] = item.uri
+	}
+	return uris, true
+}
+
+// RestorePlaylist adds an empty playlist of the user with the id given unless it exists, so the
+// playlists the app created in a previous run can still be synced. Returns whether it was added
+func (s *Server) RestorePlaylist(id, name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.playlists[id]; ok {
+		return false
+	}
+
+	s.playlists[id] = &playlist{
+		details: spotifyclient.SpotifyPlaylist{
+			ID:     id,
+			Name:   name,
+			URI:    "spotify:playlist:" + id,
+			Href:   fmt.Sprintf("%splaylists/%s", API_PATH, id),
+			Owner:  &spotifyclient.SpotifyPlaylistOwner{ID: s.user.ID, DisplayName: s.user.Name},
+			Images: []*spotifyclient.SpotifyPlaylistImage{},
+		},
+		items: []playlistItem{},
+	}
+	s.library = append([]string{id}, s.library...)
+
+	// Playlists created from now on must not reuse the id
+	var n int
+	if _, err := fmt.Sscanf(id, "mockplaylist%d", &n); err == nil && n > s.nextID {
+		s.nextID = n
+	}
+
+	return true
+}
+
+var seedArtists = []struct {
+	name   string
+	genres []string
+}{
+	{name: "The Mock Strokes", genres: []string{"indie rock", "garage rock"}},
+	{name: "Fake Punk", genres: []string{"electronic", "house"}},
+	{name: "Stubby Wonder", genres: []string{"soul", "funk"}},
+	{name: "Miles Dummy", genres: []string{"jazz", "bebop"}},
+	{name: "Sandbox Sisters", genres: []string{"pop", "dance pop"}},
+	{name: "Local Host", genres: []string{"hip hop"}},
+}
+
+const SEED_TRACKS = 120
+
+// Seed fills the catalog with artists of a few genres and SEED_TRACKS tracks of varied audio
+// features, release dates and popularity, and the library with playlists of them to route
+func (s *Server) Seed() {
+	artists := make([]spotifyclient.SpotifyArtist, len(seedArtists))
+	for i, seedArtist := range seedArtists {
+		id := fmt.Sprintf("mockartist%03d", i+1)
+		artists[i] = spotifyclient.SpotifyArtist{ID: id, Name: seedArtist.name, URI: "spotify:artist:" + id}
+		s.AddArtist(spotifyclient.SpotifyArtist{
+			ID:         id,
+			Name:       seedArtist.name,
+			Genres:     seedArtist.genres,
+			Popularity: 40 + i*10,
+		})
+	}
+
+	trackURIs := make([]string, SEED_TRACKS)
+	addedAt := make([]time.Time, SEED_TRACKS)
+	now := s.now()
+	for i := range SEED_TRACKS {
+		artist := artists[i%len(artists)]
+		trackID := fmt.Sprintf("mocktrack%05d", i+1)
+		trackName := fmt.Sprintf("%s Song %d", artist.Name, i/len(artists)+1)
+		albumID := fmt.Sprintf("mockalbum%05d", i+1)
+
+		trackURIs[i] = s.AddTrack(spotifyclient.SpotifyTrack{
+			ID:         trackID,
+			Name:       trackName,
+			DurationMs: 150_000 + (i*7919)%150_000,
+			Popularity: (i * 37) % 101,
+			Explicit:   i%5 == 0,
+			Artists:    []spotifyclient.SpotifyArtist{artist},
+			Album: spotifyclient.SpotifyAlbum{ // Every track is a single
+				ID:          albumID,
+				Name:        trackName,
+				ReleaseDate: fmt.Sprintf("%d-%02d-15", 1970+i%55, i%12+1),
+				URI:         "spotify:album:" + albumID,
+			},
+			ExternalIDs: &spotifyclient.SpotifyExternalIDs{ISRC: fmt.Sprintf("QZMCK24%05d", i+1)},
+		}, &spotifyclient.SpotifyAudioFeatures{
+			Tempo:            70 + float64((i*13)%110),
+			Energy:           float64((i*17)%100) / 100,
+			Danceability:     float64((i*23)%100) / 100,
+			Valence:          float64((i*29)%100) / 100,
+			Acousticness:     float64((i*31)%100) / 100,
+			Instrumentalness: float64((i*41)%100) / 100,
+			Liveness:         float64((i*43)%100) / 100,
+			Speechiness:      float64((i*47)%100) / 100,
+			Loudness:         -float64((i*53)%30) - 3,
+			Key:              i % 12,
+			Mode:             i % 2,
+			TimeSignature:    4,
+		})
+
+		// Added every 3 days over the last year, in playlist order
+		addedAt[i] = now.AddDate(0, 0, -3*(SEED_TRACKS-1-i))
+	}
+
+	s.AddPlaylist("Mock Library", trackURIs, addedAt)
+	s.AddPlaylist("Mock Favourites", trackURIs[:SEED_TRACKS/4], addedAt[:SEED_TRACKS/4])
+}
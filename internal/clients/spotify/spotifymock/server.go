@@ -0,0 +1,754 @@
+// Package spotifymock is an in-memory fake of the spotify accounts and web API endpoints used by
+// the spotify client, so the app can run locally and in e2e tests without credentials or network.
+// Every token is accepted and acts as a single spotify user
+package spotifymock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+)
+
+const (
+	// API_PATH is where the web API endpoints are served, the accounts ones are served at the root
+	API_PATH = "/v1/"
+
+	MAX_PAGE_LIMIT     = 50
+	MAX_TRACKS_LIMIT   = 100
+	MAX_PLAYLIST_ITEMS = 100
+	MAX_AUDIO_FEATURES = 100
+
+	// TOKEN_EXPIRES_IN is the lifetime in seconds of the access tokens issued
+	TOKEN_EXPIRES_IN = 3600
+)
+
+type playlistItem struct {
+	uri     string
+	addedAt time.Time
+}
+
+type playlist struct {
+	details spotifyclient.SpotifyPlaylist
+	items   []playlistItem
+	version int
+}
+
+// Server fakes spotify for a single user owning an in-memory library of playlists, over a
+// catalog of tracks, artists and audio features
+type Server struct {
+	mu sync.Mutex
+
+	user          spotifyclient.SpotifyUserProfile
+	playlists     map[string]*playlist
+	library       []string // Followed playlist ids, most recent first
+	tracks        map[string]*spotifyclient.SpotifyTrack
+	artists       map[string]*spotifyclient.SpotifyArtist
+	audioFeatures map[string]*spotifyclient.SpotifyAudioFeatures
+	nextID        int
+
+	mux    *http.ServeMux
+	server *http.Server
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewServer returns a fake with an empty library and catalog for the user
+func NewServer(user spotifyclient.SpotifyUserProfile, logger *slog.Logger) *Server {
+	s := &Server{
+		user:          user,
+		playlists:     make(map[string]*playlist),
+		tracks:        make(map[string]*spotifyclient.SpotifyTrack),
+		artists:       make(map[string]*spotifyclient.SpotifyArtist),
+		audioFeatures: make(map[string]*spotifyclient.SpotifyAudioFeatures),
+		logger:        logger.With("component", "SpotifyMock"),
+		now:           time.Now,
+	}
+	s.routes()
+
+	return s
+}
+
+func (s *Server) routes() {
+	s.mux = http.NewServeMux()
+
+	// Accounts
+	s.mux.HandleFunc("GET /authorize", s.handleAuthorize)
+	s.mux.HandleFunc("POST /api/token", s.handleToken)
+
+	// Web API
+	api := func(pattern string, handler http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		s.mux.HandleFunc(method+" "+API_PATH+path, s.authorized(handler))
+	}
+	api("GET me", s.handleGetProfile)
+	api("GET me/playlists", s.handleGetUserPlaylists)
+	api("POST users/{userID}/playlists", s.handleCreatePlaylist)
+	api("GET playlists/{id}", s.handleGetPlaylist)
+	api("PUT playlists/{id}", s.handleUpdatePlaylist)
+	api("PUT playlists/{id}/followers", s.handleFollowPlaylist)
+	api("DELETE playlists/{id}/followers", s.handleUnfollowPlaylist)
+	api("PUT playlists/{id}/images", s.handleUploadCover)
+	api("GET playlists/{id}/tracks", s.handleGetPlaylistTracks)
+	api("POST playlists/{id}/tracks", s.handleAddTracks)
+	api("PUT playlists/{id}/tracks", s.handleReplaceOrReorderTracks)
+	api("DELETE playlists/{id}/tracks", s.handleRemoveTracks)
+	api("GET tracks", s.handleGetSeveralTracks)
+	api("GET tracks/{id}", s.handleGetTrack)
+	api("GET audio-features", s.handleGetAudioFeatures)
+	api("GET artists", s.handleGetSeveralArtists)
+	api("GET search", s.handleSearch)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Start serves the fake on addr in the background, returning its base URL
+func (s *Server) Start(addr string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s.server = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("spotify mock stopped", "error", err)
+		}
+	}()
+
+	baseURL := "http://" + listener.Addr().String()
+	s.logger.Info("spotify mock listening", "url", baseURL)
+	return baseURL, nil
+}
+
+// Shutdown stops the server started by Start
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Shutdown(ctx)
+}
+
+// AuthBaseURL and APIBaseURL are the spotify client base URLs of a fake served at baseURL
+func AuthBaseURL(baseURL string) string {
+	return baseURL + "/"
+}
+
+func APIBaseURL(baseURL string) string {
+	return baseURL + API_PATH
+}
+
+// authorized rejects requests without a bearer token, any other token is valid
+func (s *Server) authorized(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "No token provided")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		handler(w, r)
+	}
+}
+
+// handleAuthorize grants every authorization right away, redirecting back with a code
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		writeError(w, http.StatusBadRequest, "Invalid redirect URI")
+		return
+	}
+
+	params := redirectURI.Query()
+	params.Set("code", "mock-code-"+strconv.FormatInt(s.now().UnixNano(), 36))
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid form")
+		return
+	}
+
+	tokens := spotifyclient.SpotifyTokenResponse{
+		AccessToken: "mock-access-token-" + strconv.FormatInt(s.now().UnixNano(), 36),
+		TokenType:   "Bearer",
+		Scope:       "playlist-read-private playlist-modify-public playlist-modify-private user-read-email",
+		ExpiresIn:   TOKEN_EXPIRES_IN,
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		if r.PostForm.Get("code") == "" {
+			writeError(w, http.StatusBadRequest, "Missing authorization code")
+			return
+		}
+		tokens.RefreshToken = "mock-refresh-token"
+	case "refresh_token":
+		if r.PostForm.Get("refresh_token") == "" {
+			writeError(w, http.StatusBadRequest, "Missing refresh token")
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "Unsupported grant type")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokens)
+}
+
+func (s *Server) handleGetProfile(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.user)
+}
+
+func (s *Server) handleGetUserPlaylists(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := pagination(w, r, 20, MAX_PAGE_LIMIT)
+	if !ok {
+		return
+	}
+
+	items := make([]*spotifyclient.SpotifyPlaylist, 0, limit)
+	for i := offset; i < len(s.library) && i < offset+limit; i++ {
+		items = append(items, s.renderPlaylist(s.playlists[s.library[i]]))
+	}
+
+	writeJSON(w, http.StatusOK, spotifyclient.SpotifyPlaylistResponse{Total: len(s.library), Items: items})
+}
+
+func (s *Server) handleCreatePlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("userID") != s.user.ID {
+		writeError(w, http.StatusForbidden, "You cannot create a playlist for another user")
+		return
+	}
+
+	var request spotifyclient.SpotifyPlaylistRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Name == nil {
+		writeError(w, http.StatusBadRequest, "Missing required field: name")
+		return
+	}
+
+	details := spotifyclient.SpotifyPlaylist{Name: *request.Name, Public: true}
+	if request.Description != nil {
+		details.Description = *request.Description
+	}
+	if request.Public != nil {
+		details.Public = *request.Public
+	}
+
+	id := s.createPlaylist(details, nil)
+	writeJSON(w, http.StatusCreated, s.renderPlaylist(s.playlists[id]))
+}
+
+func (s *Server) handleGetPlaylist(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.playlist(w, r)
+	if !ok {
+		return
+	}
+
+	if r.URL.Query().Get("fields") == "snapshot_id" {
+		writeJSON(w, http.StatusOK, spotifyclient.SpotifySnapshotResponse{SnapshotID: snapshotID(p)})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.renderPlaylist(p))
+}
+
+func (s *Server) handleUpdatePlaylist(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.ownedPlaylist(w, r)
+	if !ok {
+		return
+	}
+
+	var request spotifyclient.SpotifyPlaylistRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if request.Name != nil {
+		p.details.Name = *request.Name
+	}
+	if request.Description != nil {
+		p.details.Description = *request.Description
+	}
+	if request.Public != nil {
+		p.details.Public = *request.Public
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleFollowPlaylist(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.playlist(w, r)
+	if !ok {
+		return
+	}
+
+	if !slices.Contains(s.library, p.details.ID) {
+		s.library = append([]string{p.details.ID}, s.library...)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleUnfollowPlaylist(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.playlist(w, r)
+	if !ok {
+		return
+	}
+
+	s.library = slices.DeleteFunc(s.library, func(id string) bool { return id == p.details.ID })
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleUploadCover(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.ownedPlaylist(w, r)
+	if !ok {
+		return
+	}
+
+	// The cover itself isn't kept, the playlist just gets an image like spotify would show
+	p.details.Images = []*spotifyclient.SpotifyPlaylistImage{
+		{URL: fmt.Sprintf("https://mosaic.scdn.co/640/%s", p.details.ID), Height: 640, Width: 640},
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) handleGetPlaylistTracks(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.playlist(w, r)
+	if !ok {
+		return
+	}
+
+	limit, offset, ok := pagination(w, r, MAX_TRACKS_LIMIT, MAX_TRACKS_LIMIT)
+	if !ok {
+		return
+	}
+
+	items := make([]spotifyclient.SpotifyPlaylistTrack, 0, limit)
+	for i := offset; i < len(p.items) && i < offset+limit; i++ {
+		items = append(items, spotifyclient.SpotifyPlaylistTrack{
+			AddedAt: p.items[i].addedAt,
+			Track:   s.tracks[p.items[i].uri],
+		})
+	}
+
+	response := spotifyclient.SpotifyPlaylistTracksResponse{
+		Items:  items,
+		Total:  len(p.items),
+		Limit:  limit,
+		Offset: offset,
+	}
+	if offset+limit < len(p.items) {
+		next := fmt.Sprintf("http://%s%s?offset=%d&limit=%d", r.Host, r.URL.Path, offset+limit, limit)
+		response.Next = &next
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleAddTracks(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.ownedPlaylist(w, r)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		URIs []string `json:"uris"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !s.validTrackURIs(w, request.URIs) {
+		return
+	}
+
+	p.items = append(p.items, s.newItems(request.URIs)...)
+	p.version++
+
+	writeJSON(w, http.StatusCreated, spotifyclient.SpotifySnapshotResponse{SnapshotID: snapshotID(p)})
+}
+
+// handleReplaceOrReorderTracks replaces the tracks of the playlist when the body has uris and
+// moves a range of them otherwise, as spotify does on the same endpoint
+func (s *Server) handleReplaceOrReorderTracks(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.ownedPlaylist(w, r)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		URIs         *[]string `json:"uris"`
+		RangeStart   *int      `json:"range_start"`
+		InsertBefore *int      `json:"insert_before"`
+		RangeLength  int       `json:"range_length"`
+		SnapshotID   string    `json:"snapshot_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if request.URIs != nil {
+		if !s.validTrackURIs(w, *request.URIs) {
+			return
+		}
+		p.items = s.newItems(*request.URIs)
+		p.version++
+
+		writeJSON(w, http.StatusCreated, spotifyclient.SpotifySnapshotResponse{SnapshotID: snapshotID(p)})
+		return
+	}
+
+	if request.RangeStart == nil || request.InsertBefore == nil {
+		writeError(w, http.StatusBadRequest, "Missing required field: range_start or insert_before")
+		return
+	}
+	if request.SnapshotID != "" && request.SnapshotID != snapshotID(p) {
+		writeError(w, http.StatusBadRequest, "Invalid snapshot id")
+		return
+	}
+
+	rangeLength := max(request.RangeLength, 1)
+	start, insertBefore := *request.RangeStart, *request.InsertBefore
+	if start < 0 || start+rangeLength > len(p.items) || insertBefore < 0 || insertBefore > len(p.items) {
+		writeError(w, http.StatusBadRequest, "Index out of bounds")
+		return
+	}
+
+	moved := append([]playlistItem(nil), p.items[start:start+rangeLength]...)
+	remaining := append(append([]playlistItem(nil), p.items[:start]...), p.items[start+rangeLength:]...)
+	if insertBefore > start {
+		insertBefore -= min(rangeLength, insertBefore-start)
+	}
+	p.items = append(append(remaining[:insertBefore:insertBefore], moved...), remaining[insertBefore:]...)
+	p.version++
+
+	writeJSON(w, http.StatusOK, spotifyclient.SpotifySnapshotResponse{SnapshotID: snapshotID(p)})
+}
+
+func (s *Server) handleRemoveTracks(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.ownedPlaylist(w, r)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Tracks []spotifyclient.SpotifyTrackReference `json:"tracks"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(request.Tracks) > MAX_PLAYLIST_ITEMS {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many tracks, at most %d allowed", MAX_PLAYLIST_ITEMS))
+		return
+	}
+
+	removed := make(map[string]bool, len(request.Tracks))
+	for _, track := range request.Tracks {
+		removed[track.URI] = true
+	}
+
+	kept := p.items[:0]
+	for _, item := range p.items {
+		if !removed[item.uri] {
+			kept = append(kept, item)
+		}
+	}
+	p.items = kept
+	p.version++
+
+	writeJSON(w, http.StatusOK, spotifyclient.SpotifySnapshotResponse{SnapshotID: snapshotID(p)})
+}
+
+func (s *Server) handleGetTrack(w http.ResponseWriter, r *http.Request) {
+	track, ok := s.tracks[trackURI(r.PathValue("id"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, track)
+}
+
+func (s *Server) handleGetSeveralTracks(w http.ResponseWriter, r *http.Request) {
+	ids, ok := idsParam(w, r, MAX_PAGE_LIMIT)
+	if !ok {
+		return
+	}
+
+	tracks := make([]*spotifyclient.SpotifyTrack, len(ids))
+	for i, id := range ids {
+		tracks[i] = s.tracks[trackURI(id)]
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Tracks []*spotifyclient.SpotifyTrack `json:"tracks"`
+	}{Tracks: tracks})
+}
+
+func (s *Server) handleGetAudioFeatures(w http.ResponseWriter, r *http.Request) {
+	ids, ok := idsParam(w, r, MAX_AUDIO_FEATURES)
+	if !ok {
+		return
+	}
+
+	audioFeatures := make([]*spotifyclient.SpotifyAudioFeatures, len(ids))
+	for i, id := range ids {
+		audioFeatures[i] = s.audioFeatures[id]
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		AudioFeatures []*spotifyclient.SpotifyAudioFeatures `json:"audio_features"`
+	}{AudioFeatures: audioFeatures})
+}
+
+func (s *Server) handleGetSeveralArtists(w http.ResponseWriter, r *http.Request) {
+	ids, ok := idsParam(w, r, MAX_PAGE_LIMIT)
+	if !ok {
+		return
+	}
+
+	artists := make([]*spotifyclient.SpotifyArtist, len(ids))
+	for i, id := range ids {
+		artists[i] = s.artists[id]
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Artists []*spotifyclient.SpotifyArtist `json:"artists"`
+	}{Artists: artists})
+}
+
+// handleSearch looks tracks up in the catalog, the only type searched. The isrc:, track: and
+// artist: filters of the query are matched, any other text is matched against the track names
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("type") != "track" {
+		writeError(w, http.StatusBadRequest, "Only track searches are supported")
+		return
+	}
+	if query.Get("q") == "" {
+		writeError(w, http.StatusBadRequest, "No search query")
+		return
+	}
+
+	limit, offset, ok := pagination(w, r, 20, MAX_PAGE_LIMIT)
+	if !ok {
+		return
+	}
+
+	filters := parseSearchQuery(query.Get("q"))
+	uris := make([]string, 0, len(s.tracks))
+	for uri := range s.tracks {
+		uris = append(uris, uri)
+	}
+	slices.Sort(uris)
+
+	matches := make([]*spotifyclient.SpotifyTrack, 0)
+	for _, uri := range uris {
+		if filters.match(s.tracks[uri]) {
+			matches = append(matches, s.tracks[uri])
+		}
+	}
+	total := len(matches)
+	matches = matches[min(offset, total):min(offset+limit, total)]
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"tracks": map[string]any{"items": matches, "total": total, "limit": limit, "offset": offset},
+	})
+}
+
+// playlist looks up the playlist of the request path, answering 404 when there is none
+func (s *Server) playlist(w http.ResponseWriter, r *http.Request) (*playlist, bool) {
+	p, ok := s.playlists[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return nil, false
+	}
+
+	return p, true
+}
+
+// ownedPlaylist looks up the playlist of the request path, answering 403 when the user can't
+// edit it
+func (s *Server) ownedPlaylist(w http.ResponseWriter, r *http.Request) (*playlist, bool) {
+	p, ok := s.playlist(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if p.details.Owner.ID != s.user.ID && !p.details.Collaborative {
+		writeError(w, http.StatusForbidden, "You cannot edit a playlist you don't own")
+		return nil, false
+	}
+
+	return p, true
+}
+
+// validTrackURIs answers 400 unless every uri is a track of the catalog and there are at most
+// MAX_PLAYLIST_ITEMS of them
+func (s *Server) validTrackURIs(w http.ResponseWriter, uris []string) bool {
+	if len(uris) > MAX_PLAYLIST_ITEMS {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many tracks, at most %d allowed", MAX_PLAYLIST_ITEMS))
+		return false
+	}
+
+	for _, uri := range uris {
+		if _, ok := s.tracks[uri]; !ok {
+			writeError(w, http.StatusBadRequest, "Invalid track uri: "+uri)
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Server) newItems(uris []string) []playlistItem {
+	now := s.now().UTC()
+	items := make([]playlistItem, len(uris))
+	for i, uri := range uris {
+		items[i] = playlistItem{uri: uri, addedAt: now}
+	}
+	return items
+}
+
+func (s *Server) renderPlaylist(p *playlist) *spotifyclient.SpotifyPlaylist {
+	rendered := p.details
+	rendered.SnapshotID = snapshotID(p)
+	rendered.Tracks = &spotifyclient.SpotifyPlaylistTracks{
+		Href:  fmt.Sprintf("%splaylists/%s/tracks", API_PATH, p.details.ID),
+		Total: len(p.items),
+	}
+	return &rendered
+}
+
+// searchFilterPattern matches the field filters of search queries, with quoted or bare values
+var searchFilterPattern = regexp.MustCompile(`(\w+):(?:"([^"]*)"|(\S+))`)
+
+// searchFilters are the lowercased terms of a search query
+type searchFilters struct {
+	isrc   string
+	track  string
+	artist string
+	text   string
+}
+
+func parseSearchQuery(q string) searchFilters {
+	var filters searchFilters
+	text := searchFilterPattern.ReplaceAllStringFunc(q, func(filter string) string {
+		groups := searchFilterPattern.FindStringSubmatch(filter)
+		value := strings.ToLower(groups[2] + groups[3])
+		switch strings.ToLower(groups[1]) {
+		case "isrc":
+			filters.isrc = value
+		case "track":
+			filters.track = value
+		case "artist":
+			filters.artist = value
+		default:
+			return filter
+		}
+		return ""
+	})
+	filters.text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	return filters
+}
+
+func (f searchFilters) match(track *spotifyclient.SpotifyTrack) bool {
+	name := strings.ToLower(track.Name)
+	if f.isrc != "" && (track.ExternalIDs == nil || strings.ToLower(track.ExternalIDs.ISRC) != f.isrc) {
+		return false
+	}
+	if !strings.Contains(name, f.track) || !strings.Contains(name, f.text) {
+		return false
+	}
+	if f.artist == "" {
+		return true
+	}
+
+	return slices.ContainsFunc(track.Artists, func(artist spotifyclient.SpotifyArtist) bool {
+		return strings.Contains(strings.ToLower(artist.Name), f.artist)
+	})
+}
+
+func snapshotID(p *playlist) string {
+	return fmt.Sprintf("%s-%d", p.details.ID, p.version)
+}
+
+func trackURI(trackID string) string {
+	return "spotify:track:" + trackID
+}
+
+// pagination reads the limit and offset params, answering 400 when they are out of range
+func pagination(w http.ResponseWriter, r *http.Request, defaultLimit, maxLimit int) (int, int, bool) {
+	limit, offset := defaultLimit, 0
+
+	var err error
+	query := r.URL.Query()
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxLimit {
+			writeError(w, http.StatusBadRequest, "Invalid limit")
+			return 0, 0, false
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid offset")
+			return 0, 0, false
+		}
+	}
+
+	return limit, offset, true
+}
+
+// idsParam reads the comma separated ids param, answering 400 when there are none or too many
+func idsParam(w http.ResponseWriter, r *http.Request, maxIDs int) ([]string, bool) {
+	value := r.URL.Query().Get("ids")
+	if value == "" {
+		writeError(w, http.StatusBadRequest, "Missing required param: ids")
+		return nil, false
+	}
+
+	ids := strings.Split(value, ",")
+	if len(ids) > maxIDs {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many ids, at most %d allowed", maxIDs))
+		return nil, false
+	}
+
+	return ids, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError answers with the error object of the spotify web API
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]any{"status": status, "message": message},
+	})
+}
@@ -0,0 +1,236 @@
+package spotifymock
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// newTestServer serves a seeded fake and returns a spotify client pointed at it, with a context
+// carrying the credentials of its user
+func newTestServer(t *testing.T) (*Server, *spotifyclient.SpotifyClient, context.Context) {
+	server := NewServer(spotifyclient.SpotifyUserProfile{ID: "mockuser", Email: "mock@example.com", Name: "Mock User"}, createTestLogger())
+	server.Seed()
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client := spotifyclient.NewSpotifyClient(&config.AuthConfig{
+		SpotifyClientID:    "mock-client",
+		SpotifyRedirectURI: "http://127.0.0.1:8090/auth/spotify/callback",
+		SpotifyAuthBaseURL: AuthBaseURL(httpServer.URL),
+		SpotifyAPIBaseURL:  APIBaseURL(httpServer.URL),
+	}, createTestLogger())
+
+	ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{
+		AccessToken: "mock-access-token",
+		UserID:      "user123",
+		SpotifyID:   "mockuser",
+	})
+
+	return server, client, ctx
+}
+
+func TestServer_AuthFlow(t *testing.T) {
+	assert := require.New(t)
+	_, client, _ := newTestServer(t)
+
+	httpClient := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := httpClient.Get(client.GenerateAuthURL("state123", "challenge"))
+	assert.NoError(err)
+	defer resp.Body.Close()
+
+	assert.Equal(http.StatusFound, resp.StatusCode)
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	assert.NoError(err)
+	assert.Equal("/auth/spotify/callback", callback.Path)
+	assert.Equal("state123", callback.Query().Get("state"))
+
+	tokens, err := client.ExchangeCodeForTokens(context.Background(), callback.Query().Get("code"), "verifier")
+	assert.NoError(err)
+	assert.NotEmpty(tokens.AccessToken)
+	assert.NotEmpty(tokens.RefreshToken)
+
+	refreshed, err := client.RefreshTokens(context.Background(), tokens.RefreshToken)
+	assert.NoError(err)
+	assert.NotEmpty(refreshed.AccessToken)
+
+	ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{AccessToken: tokens.AccessToken})
+	profile, err := client.GetUserProfile(ctx)
+	assert.NoError(err)
+	assert.Equal("mockuser", profile.ID)
+	assert.Equal("mock@example.com", profile.Email)
+
+	assert.NoError(client.Ping(context.Background()))
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	assert := require.New(t)
+	_, client, _ := newTestServer(t)
+
+	ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{})
+	_, err := client.GetUserProfile(ctx)
+	assert.ErrorContains(err, "status 401")
+}
+
+func TestServer_ReadPlaylists(t *testing.T) {
+	assert := require.New(t)
+	_, client, ctx := newTestServer(t)
+
+	playlists, err := client.GetAllUserPlaylists(ctx)
+	assert.NoError(err)
+	assert.Len(playlists, 2)
+	assert.Equal("Mock Favourites", playlists[0].Name)
+	assert.Equal("Mock Library", playlists[1].Name)
+	assert.Equal(SEED_TRACKS, playlists[1].Tracks.Total)
+
+	library := playlists[1]
+	page, err := client.GetPlaylistTracks(ctx, library.ID, 100, 0)
+	assert.NoError(err)
+	assert.Len(page.Items, 100)
+	assert.Equal(SEED_TRACKS, page.Total)
+	assert.NotNil(page.Next)
+	assert.True(page.Items[0].AddedAt.Before(page.Items[1].AddedAt))
+
+	page, err = client.GetPlaylistTracks(ctx, library.ID, 100, 100)
+	assert.NoError(err)
+	assert.Len(page.Items, SEED_TRACKS-100)
+	assert.Nil(page.Next)
+
+	track := page.Items[0].Track
+	artists, err := client.GetSeveralArtists(ctx, []string{track.Artists[0].ID})
+	assert.NoError(err)
+	assert.NotEmpty(artists[0].Genres)
+
+	audioFeatures, err := client.GetAudioFeatures(ctx, []string{track.ID, "unknown"})
+	assert.NoError(err)
+	assert.Equal(track.ID, audioFeatures[0].ID)
+	assert.Nil(audioFeatures[1])
+
+	tracks, err := client.GetSeveralTracks(ctx, []string{track.ID})
+	assert.NoError(err)
+	assert.Equal(track.Name, tracks[0].Name)
+
+	_, err = client.GetPlaylist(ctx, "unknown")
+	assert.ErrorIs(err, spotifyclient.ErrPlaylistNotFound)
+}
+
+func TestServer_SearchTracks(t *testing.T) {
+	assert := require.New(t)
+	_, client, ctx := newTestServer(t)
+
+	tracks, err := client.SearchTracks(ctx, "isrc:QZMCK2400001", 1)
+	assert.NoError(err)
+	assert.Len(tracks, 1)
+	assert.Equal("spotify:track:mocktrack00001", tracks[0].URI)
+
+	tracks, err = client.SearchTracks(ctx, `track:"mock strokes song 1" artist:"The Mock Strokes"`, 50)
+	assert.NoError(err)
+	assert.NotEmpty(tracks)
+	assert.Equal("The Mock Strokes Song 1", tracks[0].Name)
+
+	tracks, err = client.SearchTracks(ctx, `track:"Song 1" artist:"Unknown Artist"`, 1)
+	assert.NoError(err)
+	assert.Empty(tracks)
+}
+
+func TestServer_EditPlaylist(t *testing.T) {
+	assert := require.New(t)
+	server, client, ctx := newTestServer(t)
+
+	created, err := client.CreatePlaylist(ctx, "Child", "Routed tracks", false)
+	assert.NoError(err)
+	assert.Equal("mockuser", created.Owner.ID)
+
+	uris := []string{trackURI("mocktrack00001"), trackURI("mocktrack00002"), trackURI("mocktrack00003"), trackURI("mocktrack00004")}
+	snapshotID, err := client.GetPlaylistSnapshotID(ctx, created.ID)
+	assert.NoError(err)
+
+	assert.NoError(client.AddTracksToPlaylist(ctx, created.ID, uris[:2]))
+	assert.NoError(client.AddTracksToPlaylist(ctx, created.ID, uris[2:]))
+	newSnapshotID, err := client.GetPlaylistSnapshotID(ctx, created.ID)
+	assert.NoError(err)
+	assert.NotEqual(snapshotID, newSnapshotID)
+
+	// Moves the first track to the end
+	_, err = client.ReorderPlaylistTracks(ctx, created.ID, spotifyclient.SpotifyReorderRequest{RangeStart: 0, InsertBefore: 4, SnapshotID: newSnapshotID})
+	assert.NoError(err)
+	trackURIs, _ := server.PlaylistTrackURIs(created.ID)
+	assert.Equal([]string{uris[1], uris[2], uris[3], uris[0]}, trackURIs)
+
+	// Moves the last two tracks to the start
+	_, err = client.ReorderPlaylistTracks(ctx, created.ID, spotifyclient.SpotifyReorderRequest{RangeStart: 2, InsertBefore: 0, RangeLength: 2})
+	assert.NoError(err)
+	trackURIs, _ = server.PlaylistTrackURIs(created.ID)
+	assert.Equal([]string{uris[3], uris[0], uris[1], uris[2]}, trackURIs)
+
+	assert.NoError(client.RemoveTracksFromPlaylist(ctx, created.ID, []string{uris[0], uris[2]}))
+	trackURIs, _ = server.PlaylistTrackURIs(created.ID)
+	assert.Equal([]string{uris[3], uris[1]}, trackURIs)
+
+	assert.NoError(client.ReplacePlaylistTracks(ctx, created.ID, nil))
+	trackURIs, _ = server.PlaylistTrackURIs(created.ID)
+	assert.Empty(trackURIs)
+
+	err = client.AddTracksToPlaylist(ctx, created.ID, []string{"spotify:track:unknown"})
+	assert.ErrorContains(err, "status 400")
+
+	assert.NoError(client.UpdatePlaylist(ctx, created.ID, "Renamed", ""))
+	assert.NoError(client.UploadPlaylistCover(ctx, created.ID, []byte{0xff, 0xd8}))
+	playlist, err := client.GetPlaylist(ctx, created.ID)
+	assert.NoError(err)
+	assert.Equal("Renamed", playlist.Name)
+	assert.Equal("Routed tracks", playlist.Description)
+	assert.Len(playlist.Images, 1)
+
+	// Unfollowed playlists leave the library but can still be read
+	assert.NoError(client.DeletePlaylist(ctx, created.ID))
+	playlists, err := client.GetAllUserPlaylists(ctx)
+	assert.NoError(err)
+	assert.Len(playlists, 2)
+	_, err = client.GetPlaylist(ctx, created.ID)
+	assert.NoError(err)
+
+	assert.NoError(client.FollowPlaylist(ctx, created.ID, false))
+	playlists, err = client.GetAllUserPlaylists(ctx)
+	assert.NoError(err)
+	assert.Equal(created.ID, playlists[0].ID)
+}
+
+func TestServer_RestorePlaylist(t *testing.T) {
+	assert := require.New(t)
+	server, client, ctx := newTestServer(t)
+
+	assert.True(server.RestorePlaylist("mockplaylist000010", "[Mock Library] > Dance"))
+	assert.False(server.RestorePlaylist("mockplaylist000010", "[Mock Library] > Dance"))
+
+	playlist, err := client.GetPlaylist(ctx, "mockplaylist000010")
+	assert.NoError(err)
+	assert.Equal("[Mock Library] > Dance", playlist.Name)
+	assert.Equal("mockuser", playlist.Owner.ID)
+
+	assert.NoError(client.AddTracksToPlaylist(ctx, "mockplaylist000010", []string{trackURI("mocktrack00001")}))
+	trackURIs, _ := server.PlaylistTrackURIs("mockplaylist000010")
+	assert.Equal([]string{trackURI("mocktrack00001")}, trackURIs)
+
+	// New playlists don't reuse the restored id
+	created, err := client.CreatePlaylist(ctx, "Child", "", false)
+	assert.NoError(err)
+	assert.Equal("mockplaylist000011", created.ID)
+}
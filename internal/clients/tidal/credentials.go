@@ -0,0 +1,25 @@
+package tidalclient
+
+import (
+	"context"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// CredentialsProvider resolves the Tidal credentials a client request is sent with
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (*models.TidalIntegration, error)
+}
+
+// ContextCredentialsProvider reads the Tidal integration stored in the request context
+type ContextCredentialsProvider struct{}
+
+func (ContextCredentialsProvider) Credentials(ctx context.Context) (*models.TidalIntegration, error) {
+	integration, ok := requestcontext.GetTidalAuthFromContext(ctx)
+	if !ok {
+		return nil, ErrTidalCredentialsNotFound
+	}
+
+	return integration, nil
+}
@@ -0,0 +1,18 @@
+package tidalclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+)
+
+var (
+	ErrTidalCredentialsNotFound = errors.New("tidal credentials not found in context")
+	ErrRateLimited              = fmt.Errorf("tidal %w", musicprovider.ErrRateLimited)
+	ErrTrackNotFound            = fmt.Errorf("tidal %w", musicprovider.ErrTrackNotFound)
+	ErrPlaylistNotFound         = fmt.Errorf("tidal %w", musicprovider.ErrPlaylistNotFound)
+	ErrNotSupported             = fmt.Errorf("tidal: %w", musicprovider.ErrNotSupported)
+	ErrAuthorizationPending     = errors.New("tidal device authorization is pending")
+	ErrDeviceCodeExpired        = errors.New("tidal device code expired")
+)
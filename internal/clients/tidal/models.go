@@ -0,0 +1,90 @@
+package tidalclient
+
+// DeviceAuthorization starts the device flow. The user signs in at VerificationURI with UserCode
+// while DeviceCode is polled for tokens
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"deviceCode"`
+	UserCode                string `json:"userCode"`
+	VerificationURI         string `json:"verificationUri"`
+	VerificationURIComplete string `json:"verificationUriComplete"`
+	ExpiresIn               int    `json:"expiresIn"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceToken holds the tokens of a device authorization along with the account that granted it
+type DeviceToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int    `json:"expires_in"`
+	User         struct {
+		UserID      int64  `json:"userId"`
+		CountryCode string `json:"countryCode"`
+		Username    string `json:"username"`
+	} `json:"user"`
+}
+
+// Resources of the Tidal v1 API, only the fields the client reads
+
+type tidalOAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type tidalUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type tidalPlaylist struct {
+	UUID           string `json:"uuid"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	NumberOfTracks int    `json:"numberOfTracks"`
+	PublicPlaylist bool   `json:"publicPlaylist"`
+	SquareImage    string `json:"squareImage"`
+	Creator        struct {
+		ID int64 `json:"id"`
+	} `json:"creator"`
+}
+
+type tidalPlaylistPage struct {
+	Items              []tidalPlaylist `json:"items"`
+	Limit              int             `json:"limit"`
+	Offset             int             `json:"offset"`
+	TotalNumberOfItems int             `json:"totalNumberOfItems"`
+}
+
+type tidalTrack struct {
+	ID         int64  `json:"id"`
+	Title      string `json:"title"`
+	Duration   int    `json:"duration"` // Seconds
+	Explicit   bool   `json:"explicit"`
+	Popularity int    `json:"popularity"`
+	Artists    []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"artists"`
+	Album struct {
+		ID          int64  `json:"id"`
+		Title       string `json:"title"`
+		ReleaseDate string `json:"releaseDate"`
+	} `json:"album"`
+}
+
+type tidalPlaylistItem struct {
+	Type string `json:"type"` // track or video
+	Item struct {
+		tidalTrack
+		// DateAdded is sent as 2006-01-02T15:04:05.000-0700 rather than RFC 3339
+		DateAdded string `json:"dateAdded"`
+	} `json:"item"`
+}
+
+type tidalPlaylistItemPage struct {
+	Items              []tidalPlaylistItem `json:"items"`
+	Limit              int                 `json:"limit"`
+	Offset             int                 `json:"offset"`
+	TotalNumberOfItems int                 `json:"totalNumberOfItems"`
+}
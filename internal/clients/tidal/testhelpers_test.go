@@ -0,0 +1,75 @@
+package tidalclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testConfig() *config.TidalConfig {
+	return &config.TidalConfig{
+		ClientID:     "client_id",
+		ClientSecret: "client_secret",
+		AuthBaseURL:  "https://auth.tidal.com/v1/oauth2/",
+		APIBaseURL:   "https://api.tidal.com/v1/",
+	}
+}
+
+// setupClient returns a client whose requests are answered by handler
+func setupClient(t *testing.T, handler func(req *http.Request) *http.Response) *TidalClient {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return handler(req), nil
+		}).
+		AnyTimes()
+
+	client := NewTidalClient(testConfig(), createTestLogger())
+	client.HttpClient = mockHTTPClient
+	return client
+}
+
+func jsonResponse(status int, body any) *http.Response {
+	payload, _ := json.Marshal(body)
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(payload))}
+}
+
+// etagResponse answers a playlist lookup with the ETag given
+func etagResponse(etag string) *http.Response {
+	resp := jsonResponse(http.StatusOK, map[string]any{"uuid": "pl-1"})
+	resp.Header.Set("ETag", etag)
+	return resp
+}
+
+func contextWithToken(token string) context.Context {
+	return requestcontext.ContextWithTidalAuth(context.Background(), &models.TidalIntegration{
+		AccessToken: token,
+		TidalUserID: "1234",
+		CountryCode: "NL",
+	})
+}
+
+func readForm(req *http.Request) url.Values {
+	body, _ := io.ReadAll(req.Body)
+	form, _ := url.ParseQuery(string(body))
+	return form
+}
@@ -0,0 +1,313 @@
+package tidalclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/tracing"
+)
+
+const (
+	// MAX_ITEMS is the largest page of playlists or playlist items the API returns, and the most
+	// tracks added to or removed from a playlist per request
+	MAX_ITEMS = 100
+
+	// OAUTH_SCOPE reads and writes the collection of the account
+	OAUTH_SCOPE = "r_usr w_usr"
+
+	// DEVICE_CODE_GRANT_TYPE polls the tokens of a device authorization
+	DEVICE_CODE_GRANT_TYPE = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// Track and playlist URIs, in the shape of the spotify ones the routing passes around
+	TRACK_URI_PREFIX    = "tidal:track:"
+	PLAYLIST_URI_PREFIX = "tidal:playlist:"
+)
+
+//go:generate mockgen -source=tidal_client.go -destination=mocks/mock_tidal_client.go -package=mocks
+
+// TidalAPI is the Tidal music provider along with the device flow its accounts are linked through
+type TidalAPI interface {
+	musicprovider.MusicProvider
+	StartDeviceAuthorization(ctx context.Context) (*DeviceAuthorization, error)
+	PollDeviceAuthorization(ctx context.Context, deviceCode string) (*DeviceToken, error)
+}
+
+// TidalClient is the Tidal provider, through the v1 API of the Tidal apps. Tidal has no audio
+// features nor artist genres, filter rules on them never match
+type TidalClient struct {
+	HttpClient  clients.HTTPClient
+	config      *config.TidalConfig
+	logger      *slog.Logger
+	credentials CredentialsProvider
+
+	authBaseUrl string
+	apiBaseUrl  string
+}
+
+var _ TidalAPI = (*TidalClient)(nil)
+
+func NewTidalClient(config *config.TidalConfig, logger *slog.Logger) *TidalClient {
+	return &TidalClient{
+		HttpClient: &http.Client{
+			Timeout: 15 * time.Second,
+			Transport: tracing.Transport(&http.Transport{
+				MaxIdleConns:    10,
+				IdleConnTimeout: 30 * time.Second,
+			}),
+		},
+		config:      config,
+		logger:      logger.With("component", "TidalClient"),
+		credentials: ContextCredentialsProvider{},
+		authBaseUrl: strings.TrimSuffix(config.AuthBaseURL, "/") + "/",
+		apiBaseUrl:  strings.TrimSuffix(config.APIBaseURL, "/") + "/",
+	}
+}
+
+// WithCredentials resolves the credentials of the requests through provider instead of the context
+func (c *TidalClient) WithCredentials(provider CredentialsProvider) *TidalClient {
+	c.credentials = provider
+	return c
+}
+
+// GenerateAuthURL is not supported, Tidal accounts are linked through the device flow. It returns
+// an empty URL
+func (c *TidalClient) GenerateAuthURL(state, codeChallenge string) string {
+	return ""
+}
+
+// ExchangeCodeForTokens is not supported, see PollDeviceAuthorization
+func (c *TidalClient) ExchangeCodeForTokens(ctx context.Context, code, codeVerifier string) (*musicprovider.TokenResponse, error) {
+	return nil, ErrNotSupported
+}
+
+// StartDeviceAuthorization asks Tidal for a user code to show the user, and the device code to
+// poll the tokens with once they entered it
+func (c *TidalClient) StartDeviceAuthorization(ctx context.Context) (*DeviceAuthorization, error) {
+	c.logger.InfoContext(ctx, "starting tidal device authorization")
+
+	var authorization DeviceAuthorization
+	data := url.Values{"scope": {OAUTH_SCOPE}}
+	if err := c.postForm(ctx, "device_authorization", data, &authorization); err != nil {
+		return nil, err
+	}
+
+	// Tidal sends the verification URIs without a scheme
+	authorization.VerificationURI = withScheme(authorization.VerificationURI)
+	authorization.VerificationURIComplete = withScheme(authorization.VerificationURIComplete)
+
+	return &authorization, nil
+}
+
+// PollDeviceAuthorization returns the tokens of the device code once the user entered its user
+// code, ErrAuthorizationPending until then and ErrDeviceCodeExpired when they never did
+func (c *TidalClient) PollDeviceAuthorization(ctx context.Context, deviceCode string) (*DeviceToken, error) {
+	var token DeviceToken
+	err := c.postForm(ctx, "token", url.Values{
+		"grant_type":  {DEVICE_CODE_GRANT_TYPE},
+		"device_code": {deviceCode},
+		"scope":       {OAUTH_SCOPE},
+	}, &token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (c *TidalClient) RefreshTokens(ctx context.Context, refreshToken string) (*musicprovider.TokenResponse, error) {
+	c.logger.InfoContext(ctx, "refreshing tidal access tokens")
+
+	var tokens musicprovider.TokenResponse
+	err := c.postForm(ctx, "token", url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"scope":         {OAUTH_SCOPE},
+	}, &tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokens, nil
+}
+
+// postForm sends a request to the OAuth endpoints, authenticated with the client credentials
+func (c *TidalClient) postForm(ctx context.Context, path string, data url.Values, out any) error {
+	data.Set("client_id", c.config.ClientID)
+	if path == "token" {
+		data.Set("client_secret", c.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.authBaseUrl+path, strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", path, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to reach tidal auth", "path", path, "error", err)
+		return fmt.Errorf("failed to request %s: %w", path, err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+
+		var oauthErr tidalOAuthError
+		if json.Unmarshal(body, &oauthErr) == nil {
+			switch oauthErr.Error {
+			case "authorization_pending", "slow_down":
+				return ErrAuthorizationPending
+			case "expired_token":
+				return ErrDeviceCodeExpired
+			}
+		}
+
+		c.logger.ErrorContext(ctx, "tidal auth request failed", "path", path, "status_code", resp.StatusCode, "response_body", string(body))
+		return fmt.Errorf("tidal %s request failed (status %d): %s", path, resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+
+	return nil
+}
+
+// Ping checks that Tidal can be reached with a HEAD request to the token endpoint. Any answer but a
+// server error means it is up, the endpoint itself only accepts POST
+func (c *TidalClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.authBaseUrl+"token", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create ping request: %w", err)
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("tidal unreachable: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("tidal unavailable (status %d)", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *TidalClient) GetUserProfile(ctx context.Context) (*musicprovider.UserProfile, error) {
+	c.logger.InfoContext(ctx, "fetching user profile from tidal")
+
+	integration, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var user tidalUser
+	if _, _, err := c.call(ctx, http.MethodGet, "users/"+integration.TidalUserID, nil, nil, "", &user); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &musicprovider.UserProfile{ID: strconv.FormatInt(user.ID, 10), Name: user.Username}, nil
+}
+
+// call sends a request to the API on behalf of the user of the context and decodes the response
+// into out, when given. form is sent as the body, and etag as If-None-Match, which Tidal requires
+// to change the items of a playlist. It returns the status and the ETag of the response. Not found
+// responses are returned as their status with no error, so callers can report them as the resource
+// they looked up
+func (c *TidalClient) call(ctx context.Context, method, path string, params, form url.Values, etag string, out any) (int, string, error) {
+	integration, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to get tidal integration", "error", err)
+		return 0, "", err
+	}
+
+	query := url.Values{"countryCode": {countryCode(integration)}}
+	for key, values := range params {
+		query[key] = values
+	}
+
+	var reqBody io.Reader
+	if form != nil {
+		reqBody = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiBaseUrl+path+"?"+query.Encode(), reqBody)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+integration.AccessToken)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, "", nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, "", c.apiError(ctx, path, resp)
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, "", fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, resp.Header.Get("ETag"), nil
+}
+
+func (c *TidalClient) apiError(ctx context.Context, path string, resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	c.logger.ErrorContext(ctx, "tidal request failed", "path", path, "status_code", resp.StatusCode, "response_body", string(body))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return ErrRateLimited
+	}
+
+	return fmt.Errorf("tidal request failed (status %d): %s", resp.StatusCode, string(body))
+}
+
+func (c *TidalClient) responseBodyCloser(ctx context.Context, resp *http.Response) {
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		c.logger.WarnContext(ctx, "failed to close response body", "error", closeErr)
+	}
+}
+
+// countryCode is the country the catalog of the account is read in, Tidal requires it on every
+// request
+func countryCode(integration *models.TidalIntegration) string {
+	if integration.CountryCode == "" {
+		return "US"
+	}
+	return integration.CountryCode
+}
+
+func withScheme(uri string) string {
+	if uri == "" || strings.Contains(uri, "://") {
+		return uri
+	}
+	return "https://" + uri
+}
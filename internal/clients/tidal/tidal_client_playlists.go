@@ -0,0 +1,183 @@
+package tidalclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+)
+
+func (c *TidalClient) GetPlaylist(ctx context.Context, playlistId string) (*musicprovider.Playlist, error) {
+	c.logger.InfoContext(ctx, "fetching playlist from tidal")
+
+	var playlist tidalPlaylist
+	status, etag, err := c.call(ctx, http.MethodGet, "playlists/"+playlistId, nil, nil, "", &playlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlist: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistId)
+	}
+
+	parsed := parsePlaylist(&playlist)
+	parsed.SnapshotID = etag
+	return parsed, nil
+}
+
+// GetAllUserPlaylists returns the playlists the account created, not the ones it added to its
+// favorites
+func (c *TidalClient) GetAllUserPlaylists(ctx context.Context) ([]*musicprovider.Playlist, error) {
+	c.logger.InfoContext(ctx, "fetching all user playlists from tidal")
+
+	path, err := c.userPath(ctx, "playlists")
+	if err != nil {
+		return nil, err
+	}
+
+	var allPlaylists []*musicprovider.Playlist
+	for offset := 0; ; offset += MAX_ITEMS {
+		var page tidalPlaylistPage
+		params := url.Values{"limit": {strconv.Itoa(MAX_ITEMS)}, "offset": {strconv.Itoa(offset)}}
+		if _, _, err := c.call(ctx, http.MethodGet, path, params, nil, "", &page); err != nil {
+			return nil, fmt.Errorf("failed to get user playlists: %w", err)
+		}
+
+		for i := range page.Items {
+			allPlaylists = append(allPlaylists, parsePlaylist(&page.Items[i]))
+		}
+
+		if len(page.Items) == 0 || offset+len(page.Items) >= page.TotalNumberOfItems {
+			break
+		}
+	}
+
+	c.logger.InfoContext(ctx, "successfully fetched all user playlists", "total_playlists", len(allPlaylists))
+	return allPlaylists, nil
+}
+
+// CreatePlaylist creates a playlist in the account. Tidal creates them private, public is ignored
+func (c *TidalClient) CreatePlaylist(ctx context.Context, name, description string, public bool) (*musicprovider.Playlist, error) {
+	c.logger.InfoContext(ctx, "creating tidal playlist", "name", name)
+
+	path, err := c.userPath(ctx, "playlists")
+	if err != nil {
+		return nil, err
+	}
+
+	var created tidalPlaylist
+	form := url.Values{"title": {name}, "description": {description}}
+	if _, _, err := c.call(ctx, http.MethodPost, path, nil, form, "", &created); err != nil {
+		return nil, fmt.Errorf("failed to create playlist: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "successfully created tidal playlist", "playlist_id", created.UUID)
+	return parsePlaylist(&created), nil
+}
+
+func (c *TidalClient) DeletePlaylist(ctx context.Context, playlistId string) error {
+	c.logger.InfoContext(ctx, "deleting tidal playlist", "playlist_id", playlistId)
+
+	status, _, err := c.call(ctx, http.MethodDelete, "playlists/"+playlistId, nil, nil, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete playlist: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistId)
+	}
+
+	return nil
+}
+
+func (c *TidalClient) UpdatePlaylist(ctx context.Context, playlistId, name, description string) error {
+	c.logger.InfoContext(ctx, "updating tidal playlist", "playlist_id", playlistId)
+
+	form := url.Values{"title": {name}, "description": {description}}
+	status, _, err := c.call(ctx, http.MethodPost, "playlists/"+playlistId, nil, form, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistId)
+	}
+
+	return nil
+}
+
+// FollowPlaylist adds the playlist to the favorites of the account, public is ignored
+func (c *TidalClient) FollowPlaylist(ctx context.Context, playlistID string, public bool) error {
+	c.logger.InfoContext(ctx, "following tidal playlist", "playlist_id", playlistID)
+
+	path, err := c.userPath(ctx, "favorites/playlists")
+	if err != nil {
+		return err
+	}
+
+	status, _, err := c.call(ctx, http.MethodPost, path, nil, url.Values{"uuids": {playlistID}}, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to follow playlist: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistID)
+	}
+
+	return nil
+}
+
+func (c *TidalClient) UnfollowPlaylist(ctx context.Context, playlistID string) error {
+	c.logger.InfoContext(ctx, "unfollowing tidal playlist", "playlist_id", playlistID)
+
+	path, err := c.userPath(ctx, "favorites/playlists/"+playlistID)
+	if err != nil {
+		return err
+	}
+
+	// A playlist that isn't among the favorites is already unfollowed
+	if _, _, err := c.call(ctx, http.MethodDelete, path, nil, nil, "", nil); err != nil {
+		return fmt.Errorf("failed to unfollow playlist: %w", err)
+	}
+
+	return nil
+}
+
+// UploadPlaylistCover is not supported, Tidal playlists show a collage of their first albums
+func (c *TidalClient) UploadPlaylistCover(ctx context.Context, playlistID string, jpegBytes []byte) error {
+	return ErrNotSupported
+}
+
+// userPath returns the path of a resource of the account the context's credentials belong to
+func (c *TidalClient) userPath(ctx context.Context, resource string) (string, error) {
+	integration, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "users/" + integration.TidalUserID + "/" + resource, nil
+}
+
+func parsePlaylist(p *tidalPlaylist) *musicprovider.Playlist {
+	creatorID := strconv.FormatInt(p.Creator.ID, 10)
+	playlist := &musicprovider.Playlist{
+		ID:          p.UUID,
+		Name:        p.Title,
+		URI:         PLAYLIST_URI_PREFIX + p.UUID,
+		Public:      p.PublicPlaylist,
+		Description: p.Description,
+		Href:        "https://tidal.com/browse/playlist/" + p.UUID,
+		Images:      []*musicprovider.PlaylistImage{},
+		Tracks:      &musicprovider.PlaylistTracks{Total: p.NumberOfTracks},
+		Owner:       &musicprovider.PlaylistOwner{ID: creatorID},
+	}
+	if p.SquareImage != "" {
+		playlist.Images = append(playlist.Images, &musicprovider.PlaylistImage{
+			URL:    "https://resources.tidal.com/images/" + strings.ReplaceAll(p.SquareImage, "-", "/") + "/480x480.jpg",
+			Height: 480,
+			Width:  480,
+		})
+	}
+
+	return playlist
+}
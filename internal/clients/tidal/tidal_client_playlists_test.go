@@ -0,0 +1,127 @@
+package tidalclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTidalClient_GetPlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodGet, req.Method)
+		assert.Equal("/v1/playlists/pl-1", req.URL.Path)
+
+		resp := jsonResponse(http.StatusOK, map[string]any{
+			"uuid": "pl-1", "title": "Road Trip", "description": "Songs for the road", "numberOfTracks": 12,
+			"publicPlaylist": true, "squareImage": "ab-cd-ef", "creator": map[string]any{"id": 1234},
+		})
+		resp.Header.Set("ETag", `"42"`)
+		return resp
+	})
+
+	playlist, err := client.GetPlaylist(contextWithToken("valid_token"), "pl-1")
+
+	assert.NoError(err)
+	assert.Equal(&musicprovider.Playlist{
+		ID:          "pl-1",
+		Name:        "Road Trip",
+		URI:         "tidal:playlist:pl-1",
+		Public:      true,
+		Description: "Songs for the road",
+		Href:        "https://tidal.com/browse/playlist/pl-1",
+		Images:      []*musicprovider.PlaylistImage{{URL: "https://resources.tidal.com/images/ab/cd/ef/480x480.jpg", Height: 480, Width: 480}},
+		Tracks:      &musicprovider.PlaylistTracks{Total: 12},
+		SnapshotID:  `"42"`,
+		Owner:       &musicprovider.PlaylistOwner{ID: "1234"},
+	}, playlist)
+}
+
+func TestTidalClient_GetPlaylist_NotFound(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		return jsonResponse(http.StatusNotFound, map[string]any{"status": 404})
+	})
+
+	playlist, err := client.GetPlaylist(contextWithToken("valid_token"), "missing")
+
+	assert.ErrorIs(err, musicprovider.ErrPlaylistNotFound)
+	assert.Nil(playlist)
+}
+
+func TestTidalClient_GetAllUserPlaylists_Paginates(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal("/v1/users/1234/playlists", req.URL.Path)
+
+		if req.URL.Query().Get("offset") == "0" {
+			items := make([]map[string]any, MAX_ITEMS)
+			for i := range items {
+				items[i] = map[string]any{"uuid": "first"}
+			}
+			return jsonResponse(http.StatusOK, map[string]any{"items": items, "totalNumberOfItems": MAX_ITEMS + 1})
+		}
+
+		assert.Equal("100", req.URL.Query().Get("offset"))
+		return jsonResponse(http.StatusOK, map[string]any{
+			"items": []map[string]any{{"uuid": "last"}}, "totalNumberOfItems": MAX_ITEMS + 1,
+		})
+	})
+
+	playlists, err := client.GetAllUserPlaylists(contextWithToken("valid_token"))
+
+	assert.NoError(err)
+	assert.Len(playlists, MAX_ITEMS+1)
+	assert.Equal("last", playlists[MAX_ITEMS].ID)
+}
+
+func TestTidalClient_CreatePlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodPost, req.Method)
+		assert.Equal("/v1/users/1234/playlists", req.URL.Path)
+
+		form := readForm(req)
+		assert.Equal("Chill", form.Get("title"))
+		assert.Equal("Routed tracks", form.Get("description"))
+
+		return jsonResponse(http.StatusCreated, map[string]any{"uuid": "pl-new", "title": "Chill"})
+	})
+
+	playlist, err := client.CreatePlaylist(contextWithToken("valid_token"), "Chill", "Routed tracks", false)
+
+	assert.NoError(err)
+	assert.Equal("pl-new", playlist.ID)
+	assert.Equal("tidal:playlist:pl-new", playlist.URI)
+}
+
+func TestTidalClient_FollowPlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodPost, req.Method)
+		assert.Equal("/v1/users/1234/favorites/playlists", req.URL.Path)
+		assert.Equal("pl-1", readForm(req).Get("uuids"))
+
+		return jsonResponse(http.StatusOK, map[string]any{})
+	})
+
+	err := client.FollowPlaylist(contextWithToken("valid_token"), "pl-1", true)
+
+	assert.NoError(err)
+}
+
+func TestTidalClient_UploadPlaylistCover_NotSupported(t *testing.T) {
+	assert := require.New(t)
+	client := NewTidalClient(testConfig(), createTestLogger())
+
+	err := client.UploadPlaylistCover(contextWithToken("valid_token"), "pl-1", []byte("jpeg"))
+
+	assert.ErrorIs(err, musicprovider.ErrNotSupported)
+}
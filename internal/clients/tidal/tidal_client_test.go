@@ -0,0 +1,146 @@
+package tidalclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTidalClient_StartDeviceAuthorization(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodPost, req.Method)
+		assert.Equal("https://auth.tidal.com/v1/oauth2/device_authorization", req.URL.String())
+
+		form := readForm(req)
+		assert.Equal("client_id", form.Get("client_id"))
+		assert.Equal(OAUTH_SCOPE, form.Get("scope"))
+		assert.Empty(form.Get("client_secret"))
+
+		return jsonResponse(http.StatusOK, map[string]any{
+			"deviceCode": "device123", "userCode": "ABCDE", "verificationUri": "link.tidal.com",
+			"verificationUriComplete": "link.tidal.com/ABCDE", "expiresIn": 300, "interval": 2,
+		})
+	})
+
+	authorization, err := client.StartDeviceAuthorization(context.Background())
+
+	assert.NoError(err)
+	assert.Equal(&DeviceAuthorization{
+		DeviceCode:              "device123",
+		UserCode:                "ABCDE",
+		VerificationURI:         "https://link.tidal.com",
+		VerificationURIComplete: "https://link.tidal.com/ABCDE",
+		ExpiresIn:               300,
+		Interval:                2,
+	}, authorization)
+}
+
+func TestTidalClient_PollDeviceAuthorization(t *testing.T) {
+	t.Run("authorized", func(t *testing.T) {
+		assert := require.New(t)
+
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			assert.Equal("https://auth.tidal.com/v1/oauth2/token", req.URL.String())
+
+			form := readForm(req)
+			assert.Equal(DEVICE_CODE_GRANT_TYPE, form.Get("grant_type"))
+			assert.Equal("device123", form.Get("device_code"))
+			assert.Equal("client_secret", form.Get("client_secret"))
+
+			return jsonResponse(http.StatusOK, map[string]any{
+				"access_token": "access", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 86400,
+				"user": map[string]any{"userId": 1234, "countryCode": "NL", "username": "listener"},
+			})
+		})
+
+		token, err := client.PollDeviceAuthorization(context.Background(), "device123")
+
+		assert.NoError(err)
+		assert.Equal("access", token.AccessToken)
+		assert.Equal("refresh", token.RefreshToken)
+		assert.Equal(int64(1234), token.User.UserID)
+		assert.Equal("NL", token.User.CountryCode)
+		assert.Equal("listener", token.User.Username)
+	})
+
+	tests := []struct {
+		name     string
+		oauthErr string
+		want     error
+	}{
+		{name: "pending", oauthErr: "authorization_pending", want: ErrAuthorizationPending},
+		{name: "slow down", oauthErr: "slow_down", want: ErrAuthorizationPending},
+		{name: "expired", oauthErr: "expired_token", want: ErrDeviceCodeExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			client := setupClient(t, func(req *http.Request) *http.Response {
+				return jsonResponse(http.StatusBadRequest, map[string]any{"error": tt.oauthErr})
+			})
+
+			token, err := client.PollDeviceAuthorization(context.Background(), "device123")
+
+			assert.ErrorIs(err, tt.want)
+			assert.Nil(token)
+		})
+	}
+}
+
+func TestTidalClient_GetUserProfile(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal("/v1/users/1234", req.URL.Path)
+		assert.Equal("NL", req.URL.Query().Get("countryCode"))
+		assert.Equal("Bearer valid_token", req.Header.Get("Authorization"))
+
+		return jsonResponse(http.StatusOK, map[string]any{"id": 1234, "username": "listener"})
+	})
+
+	profile, err := client.GetUserProfile(contextWithToken("valid_token"))
+
+	assert.NoError(err)
+	assert.Equal(&musicprovider.UserProfile{ID: "1234", Name: "listener"}, profile)
+}
+
+func TestTidalClient_Errors(t *testing.T) {
+	t.Run("rate limited", func(t *testing.T) {
+		assert := require.New(t)
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			return jsonResponse(http.StatusTooManyRequests, map[string]any{"status": 429})
+		})
+
+		_, err := client.GetUserProfile(contextWithToken("valid_token"))
+
+		assert.ErrorIs(err, musicprovider.ErrRateLimited)
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		assert := require.New(t)
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			t.Fatal("no request expected without credentials")
+			return nil
+		})
+
+		_, err := client.GetAllUserPlaylists(context.Background())
+
+		assert.ErrorIs(err, ErrTidalCredentialsNotFound)
+	})
+
+	t.Run("authorization code flow", func(t *testing.T) {
+		assert := require.New(t)
+		client := NewTidalClient(testConfig(), createTestLogger())
+
+		_, err := client.ExchangeCodeForTokens(context.Background(), "code", "verifier")
+
+		assert.ErrorIs(err, musicprovider.ErrNotSupported)
+		assert.Empty(client.GenerateAuthURL("state", "challenge"))
+	})
+}
@@ -0,0 +1,267 @@
+package tidalclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+)
+
+// DATE_ADDED_LAYOUT is how Tidal sends the time a track was added to a playlist
+const DATE_ADDED_LAYOUT = "2006-01-02T15:04:05.000-0700"
+
+// GetPlaylistTracks reads a page of the playlist. Videos in the playlist are left out
+func (c *TidalClient) GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*musicprovider.PlaylistTracksResponse, error) {
+	if limit <= 0 || limit > MAX_ITEMS {
+		limit = MAX_ITEMS
+	}
+
+	c.logger.InfoContext(ctx, "fetching playlist tracks from tidal", "offset", offset)
+
+	page, err := c.listPlaylistItems(ctx, playlistID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &musicprovider.PlaylistTracksResponse{
+		Items:  make([]musicprovider.PlaylistTrack, 0, len(page.Items)),
+		Total:  page.TotalNumberOfItems,
+		Limit:  limit,
+		Offset: offset,
+	}
+	if offset+len(page.Items) < page.TotalNumberOfItems {
+		next := strconv.Itoa(offset + limit)
+		response.Next = &next
+	}
+
+	for i := range page.Items {
+		item := &page.Items[i]
+		if item.Type != "track" {
+			continue
+		}
+
+		// Playlists saved before Tidal recorded it have no date added
+		addedAt, _ := time.Parse(DATE_ADDED_LAYOUT, item.Item.DateAdded)
+		response.Items = append(response.Items, musicprovider.PlaylistTrack{AddedAt: addedAt, Track: parseTrack(&item.Item.tidalTrack)})
+	}
+
+	return response, nil
+}
+
+// GetPlaylistSnapshotID returns the ETag of the playlist, which changes with every change of it
+func (c *TidalClient) GetPlaylistSnapshotID(ctx context.Context, playlistID string) (string, error) {
+	status, etag, err := c.call(ctx, http.MethodGet, "playlists/"+playlistID, nil, nil, "", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get playlist: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistID)
+	}
+
+	return etag, nil
+}
+
+// AddTracksToPlaylist appends the tracks to the playlist, MAX_ITEMS at a time. Tracks missing from
+// the catalog of the account's country are skipped
+func (c *TidalClient) AddTracksToPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	c.logger.InfoContext(ctx, "adding tracks to tidal playlist", "playlist_id", playlistID, "track_count", len(trackURIs))
+
+	for batch := range slices.Chunk(trackURIs, MAX_ITEMS) {
+		etag, err := c.GetPlaylistSnapshotID(ctx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		trackIDs := make([]string, 0, len(batch))
+		for _, trackURI := range batch {
+			trackIDs = append(trackIDs, strings.TrimPrefix(trackURI, TRACK_URI_PREFIX))
+		}
+
+		form := url.Values{"trackIds": {strings.Join(trackIDs, ",")}, "onDupes": {"ADD"}, "onArtifactNotFound": {"SKIP"}}
+		status, _, err := c.call(ctx, http.MethodPost, "playlists/"+playlistID+"/items", nil, form, etag, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add tracks to playlist: %w", err)
+		}
+		if status == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistID)
+		}
+	}
+
+	return nil
+}
+
+// ReplacePlaylistTracks removes every item of the playlist and adds the tracks given
+func (c *TidalClient) ReplacePlaylistTracks(ctx context.Context, playlistID string, trackURIs []string) error {
+	c.logger.InfoContext(ctx, "replacing tidal playlist tracks", "playlist_id", playlistID, "track_count", len(trackURIs))
+
+	page, err := c.listPlaylistItems(ctx, playlistID, 1, 0)
+	if err != nil {
+		return err
+	}
+
+	indices := make([]int, page.TotalNumberOfItems)
+	for i := range indices {
+		indices[i] = i
+	}
+	if err := c.deletePlaylistItems(ctx, playlistID, indices); err != nil {
+		return err
+	}
+
+	return c.AddTracksToPlaylist(ctx, playlistID, trackURIs)
+}
+
+// RemoveTracksFromPlaylist removes every item of the playlist holding one of the tracks given
+func (c *TidalClient) RemoveTracksFromPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	c.logger.InfoContext(ctx, "removing tracks from tidal playlist", "playlist_id", playlistID, "track_count", len(trackURIs))
+
+	var indices []int
+	for offset := 0; ; offset += MAX_ITEMS {
+		page, err := c.listPlaylistItems(ctx, playlistID, MAX_ITEMS, offset)
+		if err != nil {
+			return err
+		}
+
+		for i, item := range page.Items {
+			if item.Type == "track" && slices.Contains(trackURIs, trackURI(item.Item.ID)) {
+				indices = append(indices, offset+i)
+			}
+		}
+
+		if len(page.Items) == 0 || offset+len(page.Items) >= page.TotalNumberOfItems {
+			break
+		}
+	}
+
+	return c.deletePlaylistItems(ctx, playlistID, indices)
+}
+
+// ReorderPlaylistTracks is not supported, in-place syncs of Tidal playlists replace the items
+func (c *TidalClient) ReorderPlaylistTracks(ctx context.Context, playlistID string, reorder musicprovider.ReorderRequest) (string, error) {
+	return "", ErrNotSupported
+}
+
+func (c *TidalClient) GetTrack(ctx context.Context, trackID string) (*musicprovider.Track, error) {
+	var track tidalTrack
+	status, _, err := c.call(ctx, http.MethodGet, "tracks/"+trackID, nil, nil, "", &track)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get track: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrTrackNotFound, trackID)
+	}
+
+	return parseTrack(&track), nil
+}
+
+// GetSeveralTracks looks up the tracks one at a time, the API has no batch lookup. Tracks missing
+// from the catalog are left out
+func (c *TidalClient) GetSeveralTracks(ctx context.Context, trackIDs []string) ([]*musicprovider.Track, error) {
+	tracks := make([]*musicprovider.Track, 0, len(trackIDs))
+	for _, trackID := range trackIDs {
+		track, err := c.GetTrack(ctx, trackID)
+		if errors.Is(err, ErrTrackNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// SearchTracks is not supported, tracks are only imported into Spotify playlists
+func (c *TidalClient) SearchTracks(ctx context.Context, query string, limit int) ([]*musicprovider.Track, error) {
+	return nil, ErrNotSupported
+}
+
+// GetAudioFeatures returns none, Tidal has no audio analysis of its tracks
+func (c *TidalClient) GetAudioFeatures(ctx context.Context, trackIDs []string) ([]*musicprovider.AudioFeatures, error) {
+	return []*musicprovider.AudioFeatures{}, nil
+}
+
+// GetSeveralArtists returns none, Tidal artists have no genres
+func (c *TidalClient) GetSeveralArtists(ctx context.Context, artistIDs []string) ([]*musicprovider.Artist, error) {
+	return []*musicprovider.Artist{}, nil
+}
+
+func (c *TidalClient) GetArtists(ctx context.Context, artistIDs []string) ([]*musicprovider.Artist, error) {
+	return c.GetSeveralArtists(ctx, artistIDs)
+}
+
+func (c *TidalClient) listPlaylistItems(ctx context.Context, playlistID string, limit, offset int) (*tidalPlaylistItemPage, error) {
+	var page tidalPlaylistItemPage
+	params := url.Values{"limit": {strconv.Itoa(limit)}, "offset": {strconv.Itoa(offset)}}
+	status, _, err := c.call(ctx, http.MethodGet, "playlists/"+playlistID+"/items", params, nil, "", &page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlist items: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistID)
+	}
+
+	return &page, nil
+}
+
+// deletePlaylistItems removes the items at the indices given, MAX_ITEMS at a time. The highest
+// indices go first so the ones left to remove keep their position
+func (c *TidalClient) deletePlaylistItems(ctx context.Context, playlistID string, indices []int) error {
+	indices = slices.Clone(indices)
+	slices.SortFunc(indices, func(a, b int) int { return b - a })
+
+	for batch := range slices.Chunk(indices, MAX_ITEMS) {
+		etag, err := c.GetPlaylistSnapshotID(ctx, playlistID)
+		if err != nil {
+			return err
+		}
+
+		positions := make([]string, 0, len(batch))
+		for _, index := range batch {
+			positions = append(positions, strconv.Itoa(index))
+		}
+
+		path := "playlists/" + playlistID + "/items/" + strings.Join(positions, ",")
+		params := url.Values{"order": {"INDEX"}, "orderDirection": {"ASC"}}
+		if _, _, err := c.call(ctx, http.MethodDelete, path, params, nil, etag, nil); err != nil {
+			return fmt.Errorf("failed to remove playlist items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func trackURI(trackID int64) string {
+	return TRACK_URI_PREFIX + strconv.FormatInt(trackID, 10)
+}
+
+func parseTrack(t *tidalTrack) *musicprovider.Track {
+	track := &musicprovider.Track{
+		ID:         strconv.FormatInt(t.ID, 10),
+		Name:       t.Title,
+		DurationMs: t.Duration * 1000,
+		Popularity: t.Popularity,
+		Explicit:   t.Explicit,
+		URI:        trackURI(t.ID),
+		Artists:    make([]musicprovider.Artist, 0, len(t.Artists)),
+		Album: musicprovider.Album{
+			ID:          strconv.FormatInt(t.Album.ID, 10),
+			Name:        t.Album.Title,
+			ReleaseDate: t.Album.ReleaseDate,
+			URI:         "tidal:album:" + strconv.FormatInt(t.Album.ID, 10),
+		},
+	}
+	for _, artist := range t.Artists {
+		artistID := strconv.FormatInt(artist.ID, 10)
+		track.Artists = append(track.Artists, musicprovider.Artist{ID: artistID, Name: artist.Name, URI: "tidal:artist:" + artistID})
+	}
+
+	return track
+}
@@ -0,0 +1,124 @@
+package tidalclient
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/stretchr/testify/require"
+)
+
+func playlistItem(itemType string, trackID int) map[string]any {
+	return map[string]any{
+		"type": itemType,
+		"item": map[string]any{
+			"id": trackID, "title": "Track", "duration": 215, "explicit": true, "popularity": 40,
+			"artists":   []map[string]any{{"id": 7, "name": "Artist"}},
+			"album":     map[string]any{"id": 9, "title": "Album", "releaseDate": "2019-05-17"},
+			"dateAdded": "2024-03-01T10:00:00.000+0000",
+		},
+	}
+}
+
+func TestTidalClient_GetPlaylistTracks(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal("/v1/playlists/pl-1/items", req.URL.Path)
+		assert.Equal("2", req.URL.Query().Get("limit"))
+		assert.Equal("0", req.URL.Query().Get("offset"))
+
+		return jsonResponse(http.StatusOK, map[string]any{
+			"items":              []map[string]any{playlistItem("track", 1), playlistItem("video", 2)},
+			"totalNumberOfItems": 5,
+		})
+	})
+
+	response, err := client.GetPlaylistTracks(contextWithToken("valid_token"), "pl-1", 2, 0)
+
+	assert.NoError(err)
+	assert.Equal(5, response.Total)
+	assert.NotNil(response.Next)
+	assert.Len(response.Items, 1)
+	assert.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), response.Items[0].AddedAt.UTC())
+	assert.Equal(&musicprovider.Track{
+		ID:         "1",
+		Name:       "Track",
+		DurationMs: 215000,
+		Popularity: 40,
+		Explicit:   true,
+		URI:        "tidal:track:1",
+		Artists:    []musicprovider.Artist{{ID: "7", Name: "Artist", URI: "tidal:artist:7"}},
+		Album:      musicprovider.Album{ID: "9", Name: "Album", ReleaseDate: "2019-05-17", URI: "tidal:album:9"},
+	}, response.Items[0].Track)
+}
+
+func TestTidalClient_AddTracksToPlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	var added []string
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		if req.Method == http.MethodGet {
+			return etagResponse(`"7"`)
+		}
+
+		assert.Equal(http.MethodPost, req.Method)
+		assert.Equal("/v1/playlists/pl-1/items", req.URL.Path)
+		assert.Equal(`"7"`, req.Header.Get("If-None-Match"))
+		added = append(added, readForm(req).Get("trackIds"))
+
+		return jsonResponse(http.StatusOK, map[string]any{})
+	})
+
+	err := client.AddTracksToPlaylist(contextWithToken("valid_token"), "pl-1", []string{"tidal:track:1", "tidal:track:2"})
+
+	assert.NoError(err)
+	assert.Equal([]string{"1,2"}, added)
+}
+
+func TestTidalClient_RemoveTracksFromPlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	var deleted []string
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		switch {
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/items"):
+			return jsonResponse(http.StatusOK, map[string]any{
+				"items":              []map[string]any{playlistItem("track", 1), playlistItem("track", 2), playlistItem("track", 1)},
+				"totalNumberOfItems": 3,
+			})
+		case req.Method == http.MethodGet:
+			return etagResponse(`"3"`)
+		}
+
+		assert.Equal(http.MethodDelete, req.Method)
+		assert.Equal(`"3"`, req.Header.Get("If-None-Match"))
+		deleted = append(deleted, req.URL.Path)
+
+		return jsonResponse(http.StatusOK, map[string]any{})
+	})
+
+	err := client.RemoveTracksFromPlaylist(contextWithToken("valid_token"), "pl-1", []string{"tidal:track:1"})
+
+	assert.NoError(err)
+	assert.Equal([]string{"/v1/playlists/pl-1/items/2,0"}, deleted)
+}
+
+func TestTidalClient_GetSeveralTracks_SkipsMissing(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		if req.URL.Path == "/v1/tracks/404" {
+			return jsonResponse(http.StatusNotFound, map[string]any{"status": 404})
+		}
+		return jsonResponse(http.StatusOK, playlistItem("track", 1)["item"])
+	})
+
+	tracks, err := client.GetSeveralTracks(contextWithToken("valid_token"), []string{"1", "404"})
+
+	assert.NoError(err)
+	assert.Len(tracks, 1)
+	assert.Equal("tidal:track:1", tracks[0].URI)
+}
@@ -0,0 +1,25 @@
+package youtubemusicclient
+
+import (
+	"context"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// CredentialsProvider resolves the YouTube Music credentials a client request is sent with
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (*models.YouTubeMusicIntegration, error)
+}
+
+// ContextCredentialsProvider reads the YouTube Music integration stored in the request context
+type ContextCredentialsProvider struct{}
+
+func (ContextCredentialsProvider) Credentials(ctx context.Context) (*models.YouTubeMusicIntegration, error) {
+	integration, ok := requestcontext.GetYouTubeMusicAuthFromContext(ctx)
+	if !ok {
+		return nil, ErrYouTubeMusicCredentialsNotFound
+	}
+
+	return integration, nil
+}
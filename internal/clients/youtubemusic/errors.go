@@ -0,0 +1,17 @@
+package youtubemusicclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+)
+
+var (
+	ErrYouTubeMusicCredentialsNotFound = errors.New("youtube music credentials not found in context")
+	ErrQuotaExceeded                   = fmt.Errorf("youtube music %w", musicprovider.ErrRateLimited)
+	ErrTrackNotFound                   = fmt.Errorf("youtube music %w", musicprovider.ErrTrackNotFound)
+	ErrPlaylistNotFound                = fmt.Errorf("youtube music %w", musicprovider.ErrPlaylistNotFound)
+	ErrNotSupported                    = fmt.Errorf("youtube music: %w", musicprovider.ErrNotSupported)
+	ErrUnalignedOffset                 = errors.New("youtube music playlist items are paged, the offset must be a multiple of the limit")
+)
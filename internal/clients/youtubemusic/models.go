@@ -0,0 +1,99 @@
+package youtubemusicclient
+
+import "time"
+
+// Resources of the YouTube Data API v3, only the parts the client requests
+
+type youTubeErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Errors  []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+type youTubePageInfo struct {
+	TotalResults int `json:"totalResults"`
+}
+
+type youTubeThumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type youTubeChannelListResponse struct {
+	Items []struct {
+		ID      string `json:"id"`
+		Snippet struct {
+			Title string `json:"title"`
+		} `json:"snippet"`
+	} `json:"items"`
+}
+
+type youTubePlaylist struct {
+	ID      string `json:"id,omitempty"`
+	Etag    string `json:"etag,omitempty"`
+	Snippet struct {
+		Title        string                      `json:"title"`
+		Description  string                      `json:"description"`
+		ChannelID    string                      `json:"channelId,omitempty"`
+		ChannelTitle string                      `json:"channelTitle,omitempty"`
+		Thumbnails   map[string]youTubeThumbnail `json:"thumbnails,omitempty"`
+	} `json:"snippet"`
+	Status         *youTubePlaylistStatus         `json:"status,omitempty"`
+	ContentDetails *youTubePlaylistContentDetails `json:"contentDetails,omitempty"`
+}
+
+type youTubePlaylistStatus struct {
+	PrivacyStatus string `json:"privacyStatus"`
+}
+
+type youTubePlaylistContentDetails struct {
+	ItemCount int `json:"itemCount"`
+}
+
+type youTubePlaylistListResponse struct {
+	Items         []youTubePlaylist `json:"items"`
+	NextPageToken string            `json:"nextPageToken"`
+}
+
+type youTubePlaylistItem struct {
+	ID      string `json:"id,omitempty"`
+	Snippet struct {
+		PlaylistID  string    `json:"playlistId"`
+		PublishedAt time.Time `json:"publishedAt,omitempty"`
+		ResourceID  struct {
+			Kind    string `json:"kind"`
+			VideoID string `json:"videoId"`
+		} `json:"resourceId"`
+	} `json:"snippet"`
+}
+
+type youTubePlaylistItemListResponse struct {
+	Items         []youTubePlaylistItem `json:"items"`
+	NextPageToken string                `json:"nextPageToken"`
+	PageInfo      youTubePageInfo       `json:"pageInfo"`
+}
+
+type youTubeVideo struct {
+	ID      string `json:"id"`
+	Snippet struct {
+		Title        string `json:"title"`
+		ChannelID    string `json:"channelId"`
+		ChannelTitle string `json:"channelTitle"`
+		PublishedAt  string `json:"publishedAt"`
+	} `json:"snippet"`
+	ContentDetails struct {
+		Duration      string `json:"duration"` // ISO 8601, e.g. PT3M25S
+		ContentRating struct {
+			YtRating string `json:"ytRating"`
+		} `json:"contentRating"`
+	} `json:"contentDetails"`
+}
+
+type youTubeVideoListResponse struct {
+	Items []youTubeVideo `json:"items"`
+}
@@ -0,0 +1,65 @@
+package youtubemusicclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/mocks"
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testConfig() *config.YouTubeMusicConfig {
+	return &config.YouTubeMusicConfig{
+		ClientID:     "client_id",
+		ClientSecret: "client_secret",
+		RedirectURI:  "http://localhost:8090/auth/youtube_music/callback",
+		AuthURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:     "https://oauth2.googleapis.com/token",
+		APIBaseURL:   "https://www.googleapis.com/youtube/v3/",
+	}
+}
+
+// setupClient returns a client whose requests are answered by handler
+func setupClient(t *testing.T, handler func(req *http.Request) *http.Response) *YouTubeMusicClient {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return handler(req), nil
+		}).
+		AnyTimes()
+
+	client := NewYouTubeMusicClient(testConfig(), createTestLogger())
+	client.HttpClient = mockHTTPClient
+	return client
+}
+
+func jsonResponse(status int, body any) *http.Response {
+	payload, _ := json.Marshal(body)
+	return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(payload))}
+}
+
+func contextWithToken(token string) context.Context {
+	return requestcontext.ContextWithYouTubeMusicAuth(context.Background(), &models.YouTubeMusicIntegration{
+		AccessToken: token,
+	})
+}
+
+func jsonDecode(req *http.Request, out any) error {
+	return json.NewDecoder(req.Body).Decode(out)
+}
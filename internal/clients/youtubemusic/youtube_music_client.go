@@ -0,0 +1,275 @@
+package youtubemusicclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/tracing"
+)
+
+const (
+	// MAX_RESULTS is the largest page of playlists, playlist items or videos the API returns
+	MAX_RESULTS = 50
+
+	// OAUTH_SCOPE manages the playlists of the account, YouTube Music shares them with YouTube
+	OAUTH_SCOPE = "https://www.googleapis.com/auth/youtube"
+
+	// Track and playlist URIs, in the shape of the spotify ones the routing passes around
+	VIDEO_URI_PREFIX    = "youtubemusic:video:"
+	PLAYLIST_URI_PREFIX = "youtubemusic:playlist:"
+)
+
+// YouTubeMusicClient is the YouTube Music provider, through the playlists and videos of the YouTube
+// Data API. YouTube has no audio features nor artist genres, filter rules on them never match
+type YouTubeMusicClient struct {
+	HttpClient  clients.HTTPClient
+	config      *config.YouTubeMusicConfig
+	logger      *slog.Logger
+	credentials CredentialsProvider
+
+	// Playlist items are paged with tokens rather than offsets. The tokens of the pages read so far
+	// are kept to reach the next ones without reading the playlist from the start
+	pageTokensMu sync.Mutex
+	pageTokens   map[string]*playlistPageTokens
+
+	apiBaseUrl string
+}
+
+type playlistPageTokens struct {
+	mu       sync.Mutex
+	tokens   map[int]string // Page token by offset
+	lastUsed time.Time      // Guarded by pageTokensMu
+}
+
+var _ musicprovider.MusicProvider = (*YouTubeMusicClient)(nil)
+
+func NewYouTubeMusicClient(config *config.YouTubeMusicConfig, logger *slog.Logger) *YouTubeMusicClient {
+	return &YouTubeMusicClient{
+		HttpClient: &http.Client{
+			Timeout: 15 * time.Second,
+			Transport: tracing.Transport(&http.Transport{
+				MaxIdleConns:    10,
+				IdleConnTimeout: 30 * time.Second,
+			}),
+		},
+		config:      config,
+		logger:      logger.With("component", "YouTubeMusicClient"),
+		credentials: ContextCredentialsProvider{},
+		pageTokens:  make(map[string]*playlistPageTokens),
+		apiBaseUrl:  strings.TrimSuffix(config.APIBaseURL, "/") + "/",
+	}
+}
+
+// WithCredentials resolves the credentials of the requests through provider instead of the context
+func (c *YouTubeMusicClient) WithCredentials(provider CredentialsProvider) *YouTubeMusicClient {
+	c.credentials = provider
+	return c
+}
+
+// GenerateAuthURL returns the Google authorization URL. Offline access with a consent prompt makes
+// Google issue a refresh token every time, also to accounts that authorized the app before
+func (c *YouTubeMusicClient) GenerateAuthURL(state, codeChallenge string) string {
+	params := url.Values{
+		"client_id":     {c.config.ClientID},
+		"response_type": {"code"},
+		"redirect_uri":  {c.config.RedirectURI},
+		"scope":         {OAUTH_SCOPE},
+		"access_type":   {"offline"},
+		"prompt":        {"consent"},
+		"state":         {state},
+	}
+	if codeChallenge != "" {
+		params.Set("code_challenge_method", "S256")
+		params.Set("code_challenge", codeChallenge)
+	}
+
+	c.logger.Info("generated youtube music auth URL", "state", state)
+	return fmt.Sprintf("%s?%s", c.config.AuthURL, params.Encode())
+}
+
+func (c *YouTubeMusicClient) ExchangeCodeForTokens(ctx context.Context, code, codeVerifier string) (*musicprovider.TokenResponse, error) {
+	c.logger.InfoContext(ctx, "exchanging youtube music authorization code for tokens", "pkce", codeVerifier != "")
+
+	data := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {c.config.RedirectURI},
+	}
+	if codeVerifier != "" {
+		data.Set("code_verifier", codeVerifier)
+	}
+
+	return c.requestTokens(ctx, data)
+}
+
+func (c *YouTubeMusicClient) RefreshTokens(ctx context.Context, refreshToken string) (*musicprovider.TokenResponse, error) {
+	c.logger.InfoContext(ctx, "refreshing youtube music access tokens")
+
+	return c.requestTokens(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+}
+
+func (c *YouTubeMusicClient) requestTokens(ctx context.Context, data url.Values) (*musicprovider.TokenResponse, error) {
+	data.Set("client_id", c.config.ClientID)
+	data.Set("client_secret", c.config.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.TokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to request youtube music tokens", "error", err)
+		return nil, fmt.Errorf("failed to request tokens: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		c.logger.ErrorContext(ctx, "youtube music token request failed", "status_code", resp.StatusCode, "response_body", string(body))
+		return nil, fmt.Errorf("youtube music token request failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var tokens musicprovider.TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	return &tokens, nil
+}
+
+// Ping checks that Google can be reached with a HEAD request to the token endpoint. Any answer but
+// a server error means it is up, the endpoint itself only accepts POST
+func (c *YouTubeMusicClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.config.TokenURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create ping request: %w", err)
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("youtube music unreachable: %w", err)
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("youtube music unavailable (status %d)", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// GetUserProfile returns the channel of the account, which owns its playlists
+func (c *YouTubeMusicClient) GetUserProfile(ctx context.Context) (*musicprovider.UserProfile, error) {
+	c.logger.InfoContext(ctx, "fetching channel from youtube music")
+
+	var channels youTubeChannelListResponse
+	params := url.Values{"part": {"snippet"}, "mine": {"true"}}
+	if _, err := c.call(ctx, http.MethodGet, "channels", params, nil, &channels); err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+	if len(channels.Items) == 0 {
+		return nil, fmt.Errorf("youtube music account has no channel")
+	}
+
+	channel := channels.Items[0]
+	return &musicprovider.UserProfile{ID: channel.ID, Name: channel.Snippet.Title}, nil
+}
+
+// call sends a request to the API on behalf of the user of the context and decodes the response
+// into out, when given. Not found responses are returned as their status with no error, so callers
+// can report them as the resource they looked up
+func (c *YouTubeMusicClient) call(ctx context.Context, method, path string, params url.Values, body, out any) (int, error) {
+	integration, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "failed to get youtube music integration", "error", err)
+		return 0, err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	requestURL := c.apiBaseUrl + path
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+integration.AccessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer c.responseBodyCloser(ctx, resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, c.apiError(ctx, path, resp)
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// apiError reports an error response. YouTube answers 403 with the quotaExceeded reason once the
+// daily quota of the app is used up, that is reported as rate limited
+func (c *YouTubeMusicClient) apiError(ctx context.Context, path string, resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	c.logger.ErrorContext(ctx, "youtube music request failed", "path", path, "status_code", resp.StatusCode, "response_body", string(body))
+
+	var apiErr youTubeErrorResponse
+	if json.Unmarshal(body, &apiErr) == nil {
+		for _, detail := range apiErr.Error.Errors {
+			if detail.Reason == "quotaExceeded" || detail.Reason == "rateLimitExceeded" {
+				return ErrQuotaExceeded
+			}
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return ErrQuotaExceeded
+	}
+
+	return fmt.Errorf("youtube music request failed (status %d): %s", resp.StatusCode, string(body))
+}
+
+func (c *YouTubeMusicClient) responseBodyCloser(ctx context.Context, resp *http.Response) {
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		c.logger.WarnContext(ctx, "failed to close response body", "error", closeErr)
+	}
+}
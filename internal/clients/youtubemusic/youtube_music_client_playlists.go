@@ -0,0 +1,155 @@
+package youtubemusicclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+)
+
+const playlistParts = "snippet,status,contentDetails"
+
+func (c *YouTubeMusicClient) GetPlaylist(ctx context.Context, playlistId string) (*musicprovider.Playlist, error) {
+	c.logger.InfoContext(ctx, "fetching playlist from youtube music")
+
+	var playlists youTubePlaylistListResponse
+	params := url.Values{"part": {playlistParts}, "id": {playlistId}}
+	status, err := c.call(ctx, http.MethodGet, "playlists", params, nil, &playlists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlist: %w", err)
+	}
+	if status == http.StatusNotFound || len(playlists.Items) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistId)
+	}
+
+	return parsePlaylist(&playlists.Items[0]), nil
+}
+
+func (c *YouTubeMusicClient) GetAllUserPlaylists(ctx context.Context) ([]*musicprovider.Playlist, error) {
+	c.logger.InfoContext(ctx, "fetching all user playlists from youtube music")
+
+	var allPlaylists []*musicprovider.Playlist
+	pageToken := ""
+	for {
+		params := url.Values{"part": {playlistParts}, "mine": {"true"}, "maxResults": {fmt.Sprint(MAX_RESULTS)}}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+
+		var page youTubePlaylistListResponse
+		if _, err := c.call(ctx, http.MethodGet, "playlists", params, nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to get user playlists: %w", err)
+		}
+
+		for i := range page.Items {
+			allPlaylists = append(allPlaylists, parsePlaylist(&page.Items[i]))
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	c.logger.InfoContext(ctx, "successfully fetched all user playlists", "total_playlists", len(allPlaylists))
+	return allPlaylists, nil
+}
+
+func (c *YouTubeMusicClient) CreatePlaylist(ctx context.Context, name, description string, public bool) (*musicprovider.Playlist, error) {
+	c.logger.InfoContext(ctx, "creating youtube music playlist", "name", name)
+
+	playlist := youTubePlaylist{}
+	playlist.Snippet.Title = name
+	playlist.Snippet.Description = description
+	playlist.Status = &youTubePlaylistStatus{PrivacyStatus: privacyStatus(public)}
+
+	var created youTubePlaylist
+	if _, err := c.call(ctx, http.MethodPost, "playlists", url.Values{"part": {"snippet,status"}}, playlist, &created); err != nil {
+		return nil, fmt.Errorf("failed to create playlist: %w", err)
+	}
+
+	c.logger.InfoContext(ctx, "successfully created youtube music playlist", "playlist_id", created.ID)
+	return parsePlaylist(&created), nil
+}
+
+func (c *YouTubeMusicClient) DeletePlaylist(ctx context.Context, playlistId string) error {
+	c.logger.InfoContext(ctx, "deleting youtube music playlist", "playlist_id", playlistId)
+
+	status, err := c.call(ctx, http.MethodDelete, "playlists", url.Values{"id": {playlistId}}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete playlist: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistId)
+	}
+
+	return nil
+}
+
+// UpdatePlaylist replaces the title and description, YouTube requires the title on every update
+func (c *YouTubeMusicClient) UpdatePlaylist(ctx context.Context, playlistId, name, description string) error {
+	c.logger.InfoContext(ctx, "updating youtube music playlist", "playlist_id", playlistId)
+
+	playlist := youTubePlaylist{ID: playlistId}
+	playlist.Snippet.Title = name
+	playlist.Snippet.Description = description
+
+	status, err := c.call(ctx, http.MethodPut, "playlists", url.Values{"part": {"snippet"}}, playlist, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistId)
+	}
+
+	return nil
+}
+
+// FollowPlaylist is not supported, YouTube playlists stay on the channel that created them
+func (c *YouTubeMusicClient) FollowPlaylist(ctx context.Context, playlistID string, public bool) error {
+	return ErrNotSupported
+}
+
+func (c *YouTubeMusicClient) UnfollowPlaylist(ctx context.Context, playlistID string) error {
+	return ErrNotSupported
+}
+
+// UploadPlaylistCover is not supported, YouTube playlists show the thumbnail of their first video
+func (c *YouTubeMusicClient) UploadPlaylistCover(ctx context.Context, playlistID string, jpegBytes []byte) error {
+	return ErrNotSupported
+}
+
+func privacyStatus(public bool) string {
+	if public {
+		return "public"
+	}
+	return "private"
+}
+
+func parsePlaylist(p *youTubePlaylist) *musicprovider.Playlist {
+	playlist := &musicprovider.Playlist{
+		ID:          p.ID,
+		Name:        p.Snippet.Title,
+		URI:         PLAYLIST_URI_PREFIX + p.ID,
+		Description: p.Snippet.Description,
+		Href:        "https://music.youtube.com/playlist?list=" + p.ID,
+		Images:      []*musicprovider.PlaylistImage{},
+		Tracks:      &musicprovider.PlaylistTracks{},
+		Owner:       &musicprovider.PlaylistOwner{ID: p.Snippet.ChannelID, DisplayName: p.Snippet.ChannelTitle},
+	}
+	if p.Status != nil {
+		playlist.Public = p.Status.PrivacyStatus == "public"
+	}
+	if p.ContentDetails != nil {
+		playlist.Tracks.Total = p.ContentDetails.ItemCount
+	}
+	for _, size := range []string{"maxres", "high", "medium", "default"} {
+		if thumbnail, ok := p.Snippet.Thumbnails[size]; ok {
+			playlist.Images = append(playlist.Images, &musicprovider.PlaylistImage{URL: thumbnail.URL, Height: thumbnail.Height, Width: thumbnail.Width})
+		}
+	}
+
+	return playlist
+}
@@ -0,0 +1,123 @@
+package youtubemusicclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYouTubeMusicClient_GetPlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodGet, req.Method)
+		assert.Equal("/youtube/v3/playlists", req.URL.Path)
+		assert.Equal("PL123", req.URL.Query().Get("id"))
+
+		return jsonResponse(http.StatusOK, map[string]any{
+			"items": []map[string]any{{
+				"id": "PL123",
+				"snippet": map[string]any{
+					"title": "Road Trip", "description": "Songs for the road", "channelId": "UC123", "channelTitle": "My Channel",
+					"thumbnails": map[string]any{"high": map[string]any{"url": "https://i.ytimg.com/high.jpg", "width": 480, "height": 360}},
+				},
+				"status":         map[string]any{"privacyStatus": "public"},
+				"contentDetails": map[string]any{"itemCount": 12},
+			}},
+		})
+	})
+
+	playlist, err := client.GetPlaylist(contextWithToken("valid_token"), "PL123")
+
+	assert.NoError(err)
+	assert.Equal(&musicprovider.Playlist{
+		ID:          "PL123",
+		Name:        "Road Trip",
+		URI:         "youtubemusic:playlist:PL123",
+		Public:      true,
+		Description: "Songs for the road",
+		Href:        "https://music.youtube.com/playlist?list=PL123",
+		Images:      []*musicprovider.PlaylistImage{{URL: "https://i.ytimg.com/high.jpg", Height: 360, Width: 480}},
+		Tracks:      &musicprovider.PlaylistTracks{Total: 12},
+		Owner:       &musicprovider.PlaylistOwner{ID: "UC123", DisplayName: "My Channel"},
+	}, playlist)
+}
+
+func TestYouTubeMusicClient_GetPlaylist_NotFound(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		return jsonResponse(http.StatusOK, map[string]any{"items": []any{}})
+	})
+
+	playlist, err := client.GetPlaylist(contextWithToken("valid_token"), "PLmissing")
+
+	assert.ErrorIs(err, musicprovider.ErrPlaylistNotFound)
+	assert.Nil(playlist)
+}
+
+func TestYouTubeMusicClient_GetAllUserPlaylists_Paginates(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal("true", req.URL.Query().Get("mine"))
+
+		if req.URL.Query().Get("pageToken") == "" {
+			return jsonResponse(http.StatusOK, map[string]any{
+				"items":         []map[string]any{{"id": "PL1", "snippet": map[string]any{"title": "First"}}},
+				"nextPageToken": "page2",
+			})
+		}
+
+		assert.Equal("page2", req.URL.Query().Get("pageToken"))
+		return jsonResponse(http.StatusOK, map[string]any{
+			"items": []map[string]any{{"id": "PL2", "snippet": map[string]any{"title": "Second"}}},
+		})
+	})
+
+	playlists, err := client.GetAllUserPlaylists(contextWithToken("valid_token"))
+
+	assert.NoError(err)
+	assert.Len(playlists, 2)
+	assert.Equal("PL1", playlists[0].ID)
+	assert.Equal("PL2", playlists[1].ID)
+}
+
+func TestYouTubeMusicClient_CreatePlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodPost, req.Method)
+		assert.Equal("snippet,status", req.URL.Query().Get("part"))
+
+		var body youTubePlaylist
+		assert.NoError(json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal("[Base] > Chill", body.Snippet.Title)
+		assert.Equal("private", body.Status.PrivacyStatus)
+
+		body.ID = "PLnew"
+		return jsonResponse(http.StatusOK, body)
+	})
+
+	playlist, err := client.CreatePlaylist(contextWithToken("valid_token"), "[Base] > Chill", "", false)
+
+	assert.NoError(err)
+	assert.Equal("PLnew", playlist.ID)
+	assert.False(playlist.Public)
+}
+
+func TestYouTubeMusicClient_UnsupportedPlaylistCalls(t *testing.T) {
+	assert := require.New(t)
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		t.Fatal("unsupported calls send no request")
+		return nil
+	})
+	ctx := contextWithToken("valid_token")
+
+	assert.ErrorIs(client.FollowPlaylist(ctx, "PL123", true), musicprovider.ErrNotSupported)
+	assert.ErrorIs(client.UnfollowPlaylist(ctx, "PL123"), musicprovider.ErrNotSupported)
+	assert.ErrorIs(client.UploadPlaylistCover(ctx, "PL123", []byte{0xFF}), musicprovider.ErrNotSupported)
+}
@@ -0,0 +1,102 @@
+package youtubemusicclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYouTubeMusicClient_GenerateAuthURL(t *testing.T) {
+	assert := require.New(t)
+	client := NewYouTubeMusicClient(testConfig(), createTestLogger())
+
+	authURL, err := url.Parse(client.GenerateAuthURL("state123", "challenge123"))
+
+	assert.NoError(err)
+	assert.Equal("accounts.google.com", authURL.Host)
+	query := authURL.Query()
+	assert.Equal("client_id", query.Get("client_id"))
+	assert.Equal(OAUTH_SCOPE, query.Get("scope"))
+	assert.Equal("offline", query.Get("access_type"))
+	assert.Equal("state123", query.Get("state"))
+	assert.Equal("S256", query.Get("code_challenge_method"))
+	assert.Equal("challenge123", query.Get("code_challenge"))
+}
+
+func TestYouTubeMusicClient_ExchangeCodeForTokens(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodPost, req.Method)
+		assert.Equal("https://oauth2.googleapis.com/token", req.URL.String())
+
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		assert.Equal("authorization_code", form.Get("grant_type"))
+		assert.Equal("code123", form.Get("code"))
+		assert.Equal("verifier123", form.Get("code_verifier"))
+		assert.Equal("client_secret", form.Get("client_secret"))
+
+		return jsonResponse(http.StatusOK, map[string]any{
+			"access_token": "access", "refresh_token": "refresh", "expires_in": 3599, "token_type": "Bearer", "scope": OAUTH_SCOPE,
+		})
+	})
+
+	tokens, err := client.ExchangeCodeForTokens(context.Background(), "code123", "verifier123")
+
+	assert.NoError(err)
+	assert.Equal(&musicprovider.TokenResponse{
+		AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 3599, TokenType: "Bearer", Scope: OAUTH_SCOPE,
+	}, tokens)
+}
+
+func TestYouTubeMusicClient_GetUserProfile(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal("/youtube/v3/channels", req.URL.Path)
+		assert.Equal("true", req.URL.Query().Get("mine"))
+		assert.Equal("Bearer valid_token", req.Header.Get("Authorization"))
+
+		return jsonResponse(http.StatusOK, map[string]any{
+			"items": []map[string]any{{"id": "UC123", "snippet": map[string]any{"title": "My Channel"}}},
+		})
+	})
+
+	profile, err := client.GetUserProfile(contextWithToken("valid_token"))
+
+	assert.NoError(err)
+	assert.Equal(&musicprovider.UserProfile{ID: "UC123", Name: "My Channel"}, profile)
+}
+
+func TestYouTubeMusicClient_Errors(t *testing.T) {
+	t.Run("quota exceeded", func(t *testing.T) {
+		assert := require.New(t)
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			return jsonResponse(http.StatusForbidden, map[string]any{
+				"error": map[string]any{"code": 403, "errors": []map[string]any{{"reason": "quotaExceeded"}}},
+			})
+		})
+
+		_, err := client.GetUserProfile(contextWithToken("valid_token"))
+
+		assert.ErrorIs(err, musicprovider.ErrRateLimited)
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		assert := require.New(t)
+		client := setupClient(t, func(req *http.Request) *http.Response {
+			t.Fatal("no request expected without credentials")
+			return nil
+		})
+
+		_, err := client.GetAllUserPlaylists(context.Background())
+
+		assert.ErrorIs(err, ErrYouTubeMusicCredentialsNotFound)
+	})
+}
@@ -0,0 +1,355 @@
+package youtubemusicclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+)
+
+// PAGE_TOKEN_TTL is how long the page tokens of a playlist are kept after its last page read
+const PAGE_TOKEN_TTL = 10 * time.Minute
+
+// GetPlaylistTracks reads a page of the playlist. Pages are reached through page tokens, so the
+// offset must be a multiple of the limit. Videos that were deleted or made private are left out
+func (c *YouTubeMusicClient) GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (*musicprovider.PlaylistTracksResponse, error) {
+	if limit <= 0 || limit > MAX_RESULTS {
+		limit = MAX_RESULTS
+	}
+	if offset%limit != 0 {
+		return nil, ErrUnalignedOffset
+	}
+
+	c.logger.InfoContext(ctx, "fetching playlist tracks from youtube music", "offset", offset)
+
+	pageToken, found, err := c.pageToken(ctx, playlistID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &musicprovider.PlaylistTracksResponse{Items: []musicprovider.PlaylistTrack{}, Limit: limit, Offset: offset}
+	if !found {
+		return response, nil
+	}
+
+	page, err := c.listPlaylistItems(ctx, playlistID, limit, pageToken)
+	if err != nil {
+		return nil, err
+	}
+	response.Total = page.PageInfo.TotalResults
+	if page.NextPageToken != "" {
+		c.storePageToken(playlistID, limit, offset+limit, page.NextPageToken)
+		next := page.NextPageToken
+		response.Next = &next
+	}
+
+	videoIDs := make([]string, 0, len(page.Items))
+	for _, item := range page.Items {
+		videoIDs = append(videoIDs, item.Snippet.ResourceID.VideoID)
+	}
+
+	tracks, err := c.GetSeveralTracks(ctx, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	tracksByID := make(map[string]*musicprovider.Track, len(tracks))
+	for _, track := range tracks {
+		tracksByID[track.ID] = track
+	}
+
+	for _, item := range page.Items {
+		track, ok := tracksByID[item.Snippet.ResourceID.VideoID]
+		if !ok {
+			continue
+		}
+		response.Items = append(response.Items, musicprovider.PlaylistTrack{AddedAt: item.Snippet.PublishedAt, Track: track})
+	}
+
+	return response, nil
+}
+
+// GetPlaylistSnapshotID is not supported, YouTube has no version of the playlist items. Syncs of
+// YouTube Music playlists skip the routing cache
+func (c *YouTubeMusicClient) GetPlaylistSnapshotID(ctx context.Context, playlistID string) (string, error) {
+	return "", ErrNotSupported
+}
+
+// AddTracksToPlaylist appends the videos to the playlist, YouTube takes them one at a time
+func (c *YouTubeMusicClient) AddTracksToPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	c.logger.InfoContext(ctx, "adding tracks to youtube music playlist", "playlist_id", playlistID, "track_count", len(trackURIs))
+
+	for _, trackURI := range trackURIs {
+		item := youTubePlaylistItem{}
+		item.Snippet.PlaylistID = playlistID
+		item.Snippet.ResourceID.Kind = "youtube#video"
+		item.Snippet.ResourceID.VideoID = strings.TrimPrefix(trackURI, VIDEO_URI_PREFIX)
+
+		status, err := c.call(ctx, http.MethodPost, "playlistItems", url.Values{"part": {"snippet"}}, item, nil)
+		if err != nil {
+			return fmt.Errorf("failed to add track to playlist: %w", err)
+		}
+		if status == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistID)
+		}
+	}
+
+	return nil
+}
+
+// ReplacePlaylistTracks removes every item of the playlist and adds the videos given
+func (c *YouTubeMusicClient) ReplacePlaylistTracks(ctx context.Context, playlistID string, trackURIs []string) error {
+	c.logger.InfoContext(ctx, "replacing youtube music playlist tracks", "playlist_id", playlistID, "track_count", len(trackURIs))
+
+	items, err := c.listAllPlaylistItems(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+	if err := c.deletePlaylistItems(ctx, items); err != nil {
+		return err
+	}
+
+	return c.AddTracksToPlaylist(ctx, playlistID, trackURIs)
+}
+
+// RemoveTracksFromPlaylist removes every item of the playlist holding one of the videos given
+func (c *YouTubeMusicClient) RemoveTracksFromPlaylist(ctx context.Context, playlistID string, trackURIs []string) error {
+	c.logger.InfoContext(ctx, "removing tracks from youtube music playlist", "playlist_id", playlistID, "track_count", len(trackURIs))
+
+	items, err := c.listAllPlaylistItems(ctx, playlistID)
+	if err != nil {
+		return err
+	}
+
+	removed := make([]youTubePlaylistItem, 0, len(trackURIs))
+	for _, item := range items {
+		if slices.Contains(trackURIs, VIDEO_URI_PREFIX+item.Snippet.ResourceID.VideoID) {
+			removed = append(removed, item)
+		}
+	}
+
+	return c.deletePlaylistItems(ctx, removed)
+}
+
+// ReorderPlaylistTracks is not supported, in-place syncs of YouTube Music playlists replace the items
+func (c *YouTubeMusicClient) ReorderPlaylistTracks(ctx context.Context, playlistID string, reorder musicprovider.ReorderRequest) (string, error) {
+	return "", ErrNotSupported
+}
+
+func (c *YouTubeMusicClient) GetTrack(ctx context.Context, trackID string) (*musicprovider.Track, error) {
+	tracks, err := c.GetSeveralTracks(ctx, []string{trackID})
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTrackNotFound, trackID)
+	}
+
+	return tracks[0], nil
+}
+
+// GetSeveralTracks looks up the videos, leaving out the ones that don't exist or aren't public
+func (c *YouTubeMusicClient) GetSeveralTracks(ctx context.Context, trackIDs []string) ([]*musicprovider.Track, error) {
+	tracks := make([]*musicprovider.Track, 0, len(trackIDs))
+	for start := 0; start < len(trackIDs); start += MAX_RESULTS {
+		batch := trackIDs[start:min(start+MAX_RESULTS, len(trackIDs))]
+
+		var videos youTubeVideoListResponse
+		params := url.Values{"part": {"snippet,contentDetails"}, "id": {strings.Join(batch, ",")}, "maxResults": {fmt.Sprint(MAX_RESULTS)}}
+		if _, err := c.call(ctx, http.MethodGet, "videos", params, nil, &videos); err != nil {
+			return nil, fmt.Errorf("failed to get videos: %w", err)
+		}
+
+		for i := range videos.Items {
+			tracks = append(tracks, parseTrack(&videos.Items[i]))
+		}
+	}
+
+	return tracks, nil
+}
+
+// SearchTracks is not supported, tracks are only imported into Spotify playlists
+func (c *YouTubeMusicClient) SearchTracks(ctx context.Context, query string, limit int) ([]*musicprovider.Track, error) {
+	return nil, ErrNotSupported
+}
+
+// GetAudioFeatures returns none, YouTube has no audio analysis of its videos
+func (c *YouTubeMusicClient) GetAudioFeatures(ctx context.Context, trackIDs []string) ([]*musicprovider.AudioFeatures, error) {
+	return []*musicprovider.AudioFeatures{}, nil
+}
+
+// GetSeveralArtists returns none, the artists of YouTube Music are channels, which have no genres
+func (c *YouTubeMusicClient) GetSeveralArtists(ctx context.Context, artistIDs []string) ([]*musicprovider.Artist, error) {
+	return []*musicprovider.Artist{}, nil
+}
+
+func (c *YouTubeMusicClient) GetArtists(ctx context.Context, artistIDs []string) ([]*musicprovider.Artist, error) {
+	return c.GetSeveralArtists(ctx, artistIDs)
+}
+
+func (c *YouTubeMusicClient) listPlaylistItems(ctx context.Context, playlistID string, limit int, pageToken string) (*youTubePlaylistItemListResponse, error) {
+	params := url.Values{"part": {"snippet"}, "playlistId": {playlistID}, "maxResults": {fmt.Sprint(limit)}}
+	if pageToken != "" {
+		params.Set("pageToken", pageToken)
+	}
+
+	var page youTubePlaylistItemListResponse
+	status, err := c.call(ctx, http.MethodGet, "playlistItems", params, nil, &page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlist items: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPlaylistNotFound, playlistID)
+	}
+
+	return &page, nil
+}
+
+func (c *YouTubeMusicClient) listAllPlaylistItems(ctx context.Context, playlistID string) ([]youTubePlaylistItem, error) {
+	var items []youTubePlaylistItem
+	pageToken := ""
+	for {
+		page, err := c.listPlaylistItems(ctx, playlistID, MAX_RESULTS, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+
+		if page.NextPageToken == "" {
+			return items, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (c *YouTubeMusicClient) deletePlaylistItems(ctx context.Context, items []youTubePlaylistItem) error {
+	for _, item := range items {
+		// Items removed meanwhile are already gone
+		if _, err := c.call(ctx, http.MethodDelete, "playlistItems", url.Values{"id": {item.ID}}, nil, nil); err != nil {
+			return fmt.Errorf("failed to remove playlist item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// pageToken returns the token of the page at offset, reading the pages before it that weren't read
+// yet. found is false when the playlist ends before offset
+func (c *YouTubeMusicClient) pageToken(ctx context.Context, playlistID string, limit, offset int) (token string, found bool, err error) {
+	if offset == 0 {
+		return "", true, nil
+	}
+
+	pages := c.playlistPages(playlistID, limit)
+	pages.mu.Lock()
+	defer pages.mu.Unlock()
+
+	if token, ok := pages.tokens[offset]; ok {
+		return token, true, nil
+	}
+
+	// Walk from the closest page before offset whose token is known
+	start, token := 0, ""
+	for known, knownToken := range pages.tokens {
+		if known < offset && known > start {
+			start, token = known, knownToken
+		}
+	}
+
+	for start < offset {
+		page, err := c.listPlaylistItems(ctx, playlistID, limit, token)
+		if err != nil {
+			return "", false, err
+		}
+		if page.NextPageToken == "" {
+			return "", false, nil
+		}
+
+		start, token = start+limit, page.NextPageToken
+		pages.tokens[start] = token
+	}
+
+	return token, true, nil
+}
+
+func (c *YouTubeMusicClient) storePageToken(playlistID string, limit, offset int, token string) {
+	pages := c.playlistPages(playlistID, limit)
+	pages.mu.Lock()
+	defer pages.mu.Unlock()
+
+	pages.tokens[offset] = token
+}
+
+// playlistPages returns the page tokens known for the playlist, dropping those of playlists not read
+// for PAGE_TOKEN_TTL
+func (c *YouTubeMusicClient) playlistPages(playlistID string, limit int) *playlistPageTokens {
+	key := fmt.Sprintf("%s:%d", playlistID, limit)
+	now := time.Now()
+
+	c.pageTokensMu.Lock()
+	defer c.pageTokensMu.Unlock()
+
+	for pagesKey, pages := range c.pageTokens {
+		if now.Sub(pages.lastUsed) > PAGE_TOKEN_TTL {
+			delete(c.pageTokens, pagesKey)
+		}
+	}
+
+	pages, ok := c.pageTokens[key]
+	if !ok {
+		pages = &playlistPageTokens{tokens: map[int]string{}}
+		c.pageTokens[key] = pages
+	}
+	pages.lastUsed = now
+
+	return pages
+}
+
+func parseTrack(v *youTubeVideo) *musicprovider.Track {
+	track := &musicprovider.Track{
+		ID:         v.ID,
+		Name:       v.Snippet.Title,
+		DurationMs: parseDurationMs(v.ContentDetails.Duration),
+		Explicit:   v.ContentDetails.ContentRating.YtRating == "ytAgeRestricted",
+		URI:        VIDEO_URI_PREFIX + v.ID,
+		Artists: []musicprovider.Artist{{
+			ID:   v.Snippet.ChannelID,
+			Name: strings.TrimSuffix(v.Snippet.ChannelTitle, " - Topic"),
+		}},
+	}
+
+	// The publish date of the video stands in for the release date of its album
+	if len(v.Snippet.PublishedAt) >= len("2006-01-02") {
+		track.Album.ReleaseDate = v.Snippet.PublishedAt[:len("2006-01-02")]
+	}
+
+	return track
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?T?(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+
+// parseDurationMs reads an ISO 8601 video duration such as PT3M25S, 0 when it can't be read
+func parseDurationMs(duration string) int {
+	match := isoDurationPattern.FindStringSubmatch(duration)
+	if match == nil {
+		return 0
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		value, _ := strconv.Atoi(match[i+1])
+		total += time.Duration(value) * unit
+	}
+
+	return int(total.Milliseconds())
+}
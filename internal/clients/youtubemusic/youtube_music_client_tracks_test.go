@@ -0,0 +1,185 @@
+package youtubemusicclient
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/stretchr/testify/require"
+)
+
+func playlistItemsPage(nextPageToken string, videoIDs ...string) map[string]any {
+	items := make([]map[string]any, 0, len(videoIDs))
+	for _, videoID := range videoIDs {
+		items = append(items, map[string]any{
+			"id": "item_" + videoID,
+			"snippet": map[string]any{
+				"playlistId":  "PL123",
+				"publishedAt": "2025-01-02T03:04:05Z",
+				"resourceId":  map[string]any{"kind": "youtube#video", "videoId": videoID},
+			},
+		})
+	}
+
+	page := map[string]any{"items": items, "pageInfo": map[string]any{"totalResults": 4}}
+	if nextPageToken != "" {
+		page["nextPageToken"] = nextPageToken
+	}
+	return page
+}
+
+func videosResponse(videoIDs ...string) map[string]any {
+	items := make([]map[string]any, 0, len(videoIDs))
+	for _, videoID := range videoIDs {
+		items = append(items, map[string]any{
+			"id":             videoID,
+			"snippet":        map[string]any{"title": "Song " + videoID, "channelId": "UCartist", "channelTitle": "Artist - Topic", "publishedAt": "2019-06-07T00:00:00Z"},
+			"contentDetails": map[string]any{"duration": "PT3M25S"},
+		})
+	}
+	return map[string]any{"items": items}
+}
+
+func TestYouTubeMusicClient_GetPlaylistTracks(t *testing.T) {
+	assert := require.New(t)
+
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		switch req.URL.Path {
+		case "/youtube/v3/playlistItems":
+			assert.Equal("PL123", req.URL.Query().Get("playlistId"))
+			assert.Equal("2", req.URL.Query().Get("maxResults"))
+			return jsonResponse(http.StatusOK, playlistItemsPage("page2", "video1", "deleted"))
+		case "/youtube/v3/videos":
+			assert.Equal("video1,deleted", req.URL.Query().Get("id"))
+			return jsonResponse(http.StatusOK, videosResponse("video1"))
+		}
+		t.Fatalf("unexpected request to %s", req.URL.Path)
+		return nil
+	})
+
+	page, err := client.GetPlaylistTracks(contextWithToken("valid_token"), "PL123", 2, 0)
+
+	assert.NoError(err)
+	assert.Equal(4, page.Total)
+	assert.NotNil(page.Next)
+	assert.Equal([]musicprovider.PlaylistTrack{{
+		AddedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Track: &musicprovider.Track{
+			ID:         "video1",
+			Name:       "Song video1",
+			DurationMs: 205000,
+			URI:        "youtubemusic:video:video1",
+			Artists:    []musicprovider.Artist{{ID: "UCartist", Name: "Artist"}},
+			Album:      musicprovider.Album{ReleaseDate: "2019-06-07"},
+		},
+	}}, page.Items)
+}
+
+func TestYouTubeMusicClient_GetPlaylistTracks_WalksPageTokens(t *testing.T) {
+	assert := require.New(t)
+
+	var mu sync.Mutex
+	var pageTokens []string
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		if req.URL.Path == "/youtube/v3/videos" {
+			return jsonResponse(http.StatusOK, videosResponse(strings.Split(req.URL.Query().Get("id"), ",")...))
+		}
+
+		mu.Lock()
+		pageTokens = append(pageTokens, req.URL.Query().Get("pageToken"))
+		mu.Unlock()
+
+		switch req.URL.Query().Get("pageToken") {
+		case "":
+			return jsonResponse(http.StatusOK, playlistItemsPage("page2", "video1", "video2"))
+		case "page2":
+			return jsonResponse(http.StatusOK, playlistItemsPage("", "video3", "video4"))
+		}
+		t.Fatalf("unexpected page token %s", req.URL.Query().Get("pageToken"))
+		return nil
+	})
+	ctx := contextWithToken("valid_token")
+
+	// The second page is requested first, the first one is read to find its token
+	page, err := client.GetPlaylistTracks(ctx, "PL123", 2, 2)
+	assert.NoError(err)
+	assert.Len(page.Items, 2)
+	assert.Equal("video3", page.Items[0].Track.ID)
+	assert.Nil(page.Next)
+
+	// Its token is kept, so reading it again doesn't walk the playlist
+	_, err = client.GetPlaylistTracks(ctx, "PL123", 2, 2)
+	assert.NoError(err)
+	assert.Equal([]string{"", "page2", "page2"}, pageTokens)
+
+	// Pages past the end are empty
+	page, err = client.GetPlaylistTracks(ctx, "PL123", 2, 4)
+	assert.NoError(err)
+	assert.Empty(page.Items)
+
+	_, err = client.GetPlaylistTracks(ctx, "PL123", 2, 3)
+	assert.ErrorIs(err, ErrUnalignedOffset)
+}
+
+func TestYouTubeMusicClient_RemoveTracksFromPlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	var deleted []string
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		if req.Method == http.MethodDelete {
+			deleted = append(deleted, req.URL.Query().Get("id"))
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}
+		}
+		return jsonResponse(http.StatusOK, playlistItemsPage("", "video1", "video2", "video3"))
+	})
+
+	err := client.RemoveTracksFromPlaylist(contextWithToken("valid_token"), "PL123", []string{"youtubemusic:video:video1", "youtubemusic:video:video3"})
+
+	assert.NoError(err)
+	assert.Equal([]string{"item_video1", "item_video3"}, deleted)
+}
+
+func TestYouTubeMusicClient_AddTracksToPlaylist(t *testing.T) {
+	assert := require.New(t)
+
+	var added []string
+	client := setupClient(t, func(req *http.Request) *http.Response {
+		assert.Equal(http.MethodPost, req.Method)
+		assert.Equal("/youtube/v3/playlistItems", req.URL.Path)
+
+		var item youTubePlaylistItem
+		assert.NoError(jsonDecode(req, &item))
+		assert.Equal("PL123", item.Snippet.PlaylistID)
+		added = append(added, item.Snippet.ResourceID.VideoID)
+
+		return jsonResponse(http.StatusOK, item)
+	})
+
+	err := client.AddTracksToPlaylist(contextWithToken("valid_token"), "PL123", []string{"youtubemusic:video:video1", "youtubemusic:video:video2"})
+
+	assert.NoError(err)
+	assert.Equal([]string{"video1", "video2"}, added)
+}
+
+func TestParseDurationMs(t *testing.T) {
+	tests := []struct {
+		duration string
+		expected int
+	}{
+		{duration: "PT3M25S", expected: 205000},
+		{duration: "PT1H2M3S", expected: 3723000},
+		{duration: "PT45S", expected: 45000},
+		{duration: "P1DT1S", expected: 86401000},
+		{duration: "P0D", expected: 0},
+		{duration: "invalid", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			require.Equal(t, tt.expected, parseDurationMs(tt.duration))
+		})
+	}
+}
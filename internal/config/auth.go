@@ -1,25 +1,84 @@
 package config
 
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
 type AuthConfig struct {
 	SpotifyClientID     string `env:"SPOTIFY_CLIENT_ID"`
-	SpotifyClientSecret string `env:"SPOTIFY_CLIENT_SECRET"`
+	SpotifyClientSecret string `env:"SPOTIFY_CLIENT_SECRET"` // Optional, PKCE-only public client when empty
 	SpotifyRedirectURI  string `env:"SPOTIFY_REDIRECT_URI"`
 	EncryptionKey       string `env:"ENCRYPTION_KEY"`
 	FrontendURL         string `env:"FRONTEND_URL" envDefault:"http://localhost:5173"`
+
+	// Spotify API requests the app allows itself every 30 seconds, 0 disables the budget
+	SpotifyRequestBudget int `env:"SPOTIFY_REQUEST_BUDGET" envDefault:"150"`
+
+	// Spotify endpoints, overridden to point the client at a proxy or a fake of the API
+	SpotifyAuthBaseURL string `env:"SPOTIFY_AUTH_BASE_URL" envDefault:"https://accounts.spotify.com/"`
+	SpotifyAPIBaseURL  string `env:"SPOTIFY_API_BASE_URL" envDefault:"https://api.spotify.com/v1/"`
+
+	// Serves an in-memory fake of spotify on SPOTIFY_MOCK_ADDR and points the client at it, so the
+	// app runs without spotify credentials or network. The base URLs are ignored
+	SpotifyMock     bool   `env:"SPOTIFY_MOCK"`
+	SpotifyMockAddr string `env:"SPOTIFY_MOCK_ADDR" envDefault:"127.0.0.1:8091"`
+
+	// Spotify tokens expiring within the window are refreshed before being used
+	SpotifyTokenRefreshWindow time.Duration `env:"SPOTIFY_TOKEN_REFRESH_WINDOW" envDefault:"15m"`
+
+	// Master key rotation: ENCRYPTION_KEY is the master key for ENCRYPTION_KEY_VERSION,
+	// retired master keys stay in PREVIOUS_ENCRYPTION_KEYS ("1:key,2:key") until rotated out
+	EncryptionKeyVersion   int            `env:"ENCRYPTION_KEY_VERSION" envDefault:"1"`
+	PreviousEncryptionKeys map[int]string `env:"PREVIOUS_ENCRYPTION_KEYS"`
 }
 
+// Validate reports every missing required setting at once, then the first invalid one
 func (c *AuthConfig) Validate() error {
-	if c.SpotifyClientID == "" {
-		return ErrMissingSpotifyClientID
-	}
-	if c.SpotifyClientSecret == "" {
-		return ErrMissingSpotifyClientSecret
+	var missing []error
+	if c.SpotifyClientID == "" && !c.SpotifyMock {
+		missing = append(missing, ErrMissingSpotifyClientID)
 	}
 	if c.SpotifyRedirectURI == "" {
-		return ErrMissingSpotifyRedirectURI
+		missing = append(missing, ErrMissingSpotifyRedirectURI)
 	}
 	if c.EncryptionKey == "" {
-		return ErrMissingEncryptionKey
+		missing = append(missing, ErrMissingEncryptionKey)
+	}
+	if len(missing) > 0 {
+		return errors.Join(missing...)
+	}
+
+	if c.EncryptionKeyVersion < 1 {
+		return ErrInvalidEncryptionKeyVersion
+	}
+	if _, ok := c.PreviousEncryptionKeys[c.EncryptionKeyVersion]; ok {
+		return ErrInvalidEncryptionKeyVersion
+	}
+	if !validBaseURL(c.SpotifyAuthBaseURL) || !validBaseURL(c.SpotifyAPIBaseURL) {
+		return ErrInvalidSpotifyBaseURL
 	}
 	return nil
 }
+
+// MasterKeys returns every configured master key indexed by version
+func (c *AuthConfig) MasterKeys() map[int]string {
+	masterKeys := make(map[int]string, len(c.PreviousEncryptionKeys)+1)
+	for version, key := range c.PreviousEncryptionKeys {
+		masterKeys[version] = key
+	}
+	masterKeys[c.EncryptionKeyVersion] = c.EncryptionKey
+
+	return masterKeys
+}
+
+// validBaseURL reports whether rawURL is an absolute http(s) URL
+func validBaseURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,67 @@
+package config
+
+import "slices"
+
+const (
+	BackupStorageLocal = "local"
+	BackupStorageS3    = "s3"
+	BackupStorageGCS   = "gcs"
+)
+
+var backupStorages = []string{BackupStorageLocal, BackupStorageS3, BackupStorageGCS}
+
+// GCS buckets are reached through their S3 compatible XML API, authenticated with HMAC keys
+const GCS_ENDPOINT = "https://storage.googleapis.com"
+
+type BackupConfig struct {
+	// 32 characters key encrypting the backups. Keep a copy outside the server, backups can't be
+	// restored without it
+	EncryptionKey string `env:"BACKUP_ENCRYPTION_KEY"`
+
+	// Local directory the backups are written to, and looked up in when restoring
+	Dir string `env:"BACKUP_DIR" envDefault:"pb_backups"`
+
+	// Where backups are also uploaded: local keeps them in BACKUP_DIR only, s3 and gcs upload them
+	// to BACKUP_BUCKET under BACKUP_PREFIX
+	Storage string `env:"BACKUP_STORAGE" envDefault:"local"`
+	Bucket  string `env:"BACKUP_BUCKET"`
+	Prefix  string `env:"BACKUP_PREFIX"`
+
+	// S3 compatible endpoint and credentials, HMAC keys with gcs. The endpoint defaults to AWS with s3
+	// and to GCS_ENDPOINT with gcs
+	Endpoint       string `env:"BACKUP_ENDPOINT"`
+	Region         string `env:"BACKUP_REGION" envDefault:"us-east-1"`
+	AccessKey      string `env:"BACKUP_ACCESS_KEY"`
+	SecretKey      string `env:"BACKUP_SECRET_KEY"`
+	ForcePathStyle bool   `env:"BACKUP_FORCE_PATH_STYLE"`
+}
+
+func (c *BackupConfig) Validate() error {
+	if c.EncryptionKey != "" && len(c.EncryptionKey) != 32 {
+		return ErrInvalidBackupEncryptionKey
+	}
+
+	if !slices.Contains(backupStorages, c.Storage) {
+		return ErrInvalidBackupStorage
+	}
+
+	if c.UploadsBackups() && (c.Bucket == "" || c.AccessKey == "" || c.SecretKey == "") {
+		return ErrMissingBackupBucket
+	}
+
+	return nil
+}
+
+// UploadsBackups reports whether backups are uploaded to a bucket besides being written locally
+func (c *BackupConfig) UploadsBackups() bool {
+	return c.Storage != BackupStorageLocal
+}
+
+// StorageEndpoint is the S3 compatible endpoint of the backup bucket, empty for AWS
+func (c *BackupConfig) StorageEndpoint() string {
+	if c.Endpoint == "" && c.Storage == BackupStorageGCS {
+		return GCS_ENDPOINT
+	}
+
+	return c.Endpoint
+}
@@ -0,0 +1,41 @@
+package config
+
+import "slices"
+
+const (
+	CacheBackendMemory = "memory"
+	CacheBackendRedis  = "redis"
+	CacheBackendNone   = "none"
+)
+
+var cacheBackends = []string{CacheBackendMemory, CacheBackendRedis, CacheBackendNone}
+
+type CacheConfig struct {
+	// Where spotify reads are cached: memory for a single instance, redis to share the entries
+	// between instances, none to read playlists, tracks and audio features from spotify every time
+	Backend string `env:"CACHE_BACKEND" envDefault:"memory"`
+
+	// Entries kept by the memory backend before the least recently used are evicted
+	MaxEntries int `env:"CACHE_MAX_ENTRIES" envDefault:"50000"`
+
+	// Redis server of the redis backend, as host:port
+	RedisAddr     string `env:"CACHE_REDIS_ADDR"`
+	RedisPassword string `env:"CACHE_REDIS_PASSWORD"`
+	RedisDB       int    `env:"CACHE_REDIS_DB"`
+}
+
+func (c *CacheConfig) Validate() error {
+	if !slices.Contains(cacheBackends, c.Backend) {
+		return ErrInvalidCacheBackend
+	}
+
+	if c.Backend == CacheBackendMemory && c.MaxEntries <= 0 {
+		return ErrInvalidCacheMaxEntries
+	}
+
+	if c.Backend == CacheBackendRedis && c.RedisAddr == "" {
+		return ErrMissingCacheRedisAddr
+	}
+
+	return nil
+}
@@ -1,13 +1,25 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"reflect"
+	"slices"
 
 	env "github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 )
 
+// hotReloadSettings are applied to the running app when the config is reloaded, along with the
+// secret settings. Changes to the others only take effect on restart
+var hotReloadSettings = []string{"LOG_LEVEL", "RATE_LIMIT_REQUESTS_PER_MINUTE", "RATE_LIMIT_BURST"}
+
 type Config struct {
+	// YAML or TOML file the settings are read from, under the environment variables
+	ConfigFile string `env:"CONFIG_FILE"`
+
 	// Application
 	Port          string `env:"PORT" envDefault:"8090"`
 	AppEnv        string `env:"APP_ENV" envDefault:"dev"`
@@ -15,36 +27,168 @@ type Config struct {
 	AdminEmail    string `env:"ADMIN_EMAIL"`
 	AdminPassword string `env:"ADMIN_PASSWORD"`
 
+	// Database
+	Database DatabaseConfig
+
 	// Authentication
 	Auth AuthConfig
+
+	// YouTube Music accounts, linked next to the spotify one
+	YouTubeMusic YouTubeMusicConfig
+
+	// Tidal accounts, linked through the device flow
+	Tidal TidalConfig
+
+	// Last.fm accounts, whose scrobbles enrich the tracks filtered by child playlists
+	LastFm LastFmConfig
+
+	// Internal service-to-service API
+	InternalAPI InternalAPIConfig
+
+	// Public gRPC API, served next to the HTTP one for typed integrations
+	GRPCAPI GRPCAPIConfig
+
+	// Per user rate limiting of the /api routes
+	RateLimit RateLimitConfig
+
+	// OpenTelemetry traces of requests, syncs and spotify calls
+	Tracing TracingConfig
+
+	// Cache of the playlists, tracks, artists and audio features read from spotify
+	Cache CacheConfig
+
+	// Playlist syncs
+	Sync SyncConfig
+
+	// Encrypted backups of the PocketBase data directory
+	Backup BackupConfig
+
+	// Secrets manager the spotify client secret and encryption keys are fetched from
+	SecretsManager SecretsConfig
 }
 
-// Load loads configuration from .env file and environment variables
+// Load loads configuration from the .env file, the environment variables, CONFIG_FILE and the
+// secrets provider
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
+	environment, err := readEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := readSecrets(environment); err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{}
-	if err := env.Parse(cfg); err != nil {
+	if err := parseEnvironment(cfg, environment); err != nil {
 		return nil, err
 	}
 
 	return cfg, nil
 }
 
-// MustLoad loads configuration and panics on error
+func parseEnvironment(v any, environment map[string]string) error {
+	return env.ParseWithOptions(v, env.Options{Environment: environment})
+}
+
+// MustLoad loads configuration and exits listing every invalid setting on error
 func MustLoad() *Config {
 	cfg, err := Load()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	if err := cfg.Auth.Validate(); err != nil {
-		log.Fatalf("invalid auth configuration: %v", err)
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
 	}
 
 	return cfg
 }
 
+// Validate checks every group of settings, reporting all the problems found rather than the first
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(group string, err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				errs = append(errs, fmt.Errorf("invalid %s configuration: %w", group, err))
+			}
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s configuration: %w", group, err))
+		}
+	}
+
+	if _, err := c.SlogLevel(); err != nil {
+		add("log", err)
+	}
+	add("auth", c.Auth.Validate())
+	if c.Auth.SpotifyMock && c.IsProduction() {
+		add("auth", ErrSpotifyMockInProduction)
+	}
+	add("youtube music", c.YouTubeMusic.Validate())
+	add("tidal", c.Tidal.Validate())
+	add("lastfm", c.LastFm.Validate())
+	add("database", c.Database.Validate())
+	add("internal api", c.InternalAPI.Validate())
+	add("rate limit", c.RateLimit.Validate())
+	add("tracing", c.Tracing.Validate())
+	add("cache", c.Cache.Validate())
+	add("sync", c.Sync.Validate())
+	add("backup", c.Backup.Validate())
+	add("secrets", c.SecretsManager.Validate())
+
+	return errors.Join(errs...)
+}
+
+// SlogLevel parses LOG_LEVEL
+func (c *Config) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return 0, ErrInvalidLogLevel
+	}
+	return level, nil
+}
+
+// ApplyHotReload copies the log level, rate limits and secrets of next into the config. It returns
+// the settings that changed, split into the applied ones and the ones waiting for a restart
+func (c *Config) ApplyHotReload(next *Config) (applied, pending []string) {
+	for _, setting := range changedSettings(c, next) {
+		if slices.Contains(hotReloadSettings, setting) || slices.Contains(secretSettings, setting) {
+			applied = append(applied, setting)
+		} else {
+			pending = append(pending, setting)
+		}
+	}
+
+	c.LogLevel = next.LogLevel
+	c.RateLimit = next.RateLimit
+	c.Auth.SpotifyClientSecret = next.Auth.SpotifyClientSecret
+	c.Auth.EncryptionKey = next.Auth.EncryptionKey
+	c.Auth.EncryptionKeyVersion = next.Auth.EncryptionKeyVersion
+	c.Auth.PreviousEncryptionKeys = next.Auth.PreviousEncryptionKeys
+
+	return applied, pending
+}
+
+// changedSettings returns the environment variables of the settings that differ between the configs
+func changedSettings(current, next *Config) []string {
+	nextValues := map[string]any{}
+	walkEnvFields(reflect.ValueOf(*next), func(name string, value reflect.Value) {
+		nextValues[name] = value.Interface()
+	})
+
+	var changed []string
+	walkEnvFields(reflect.ValueOf(*current), func(name string, value reflect.Value) {
+		if !reflect.DeepEqual(value.Interface(), nextValues[name]) {
+			changed = append(changed, name)
+		}
+	})
+
+	return changed
+}
+
 func (c *Config) IsDevelopment() bool {
 	return c.AppEnv == "dev"
 }
@@ -0,0 +1,85 @@
+package config
+
+import "slices"
+
+const (
+	DatabaseBackendPocketbase = "pocketbase"
+	DatabaseBackendPostgres   = "postgres"
+)
+
+var databaseBackends = []string{DatabaseBackendPocketbase, DatabaseBackendPostgres}
+
+// Auth tokens of users stored in postgres are signed with a secret of at least this length
+const MIN_POSTGRES_AUTH_SECRET_LENGTH = 32
+
+var synchronousModes = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
+
+type DatabaseConfig struct {
+	// Where the app data is stored: pocketbase keeps it in the PocketBase SQLite database, postgres
+	// moves it to DB_POSTGRES_URL. PocketBase still serves the app and keeps its logs and superusers
+	Backend string `env:"DB_BACKEND" envDefault:"pocketbase"`
+
+	// Empty data dir falls back to PocketBase's default (./pb_data next to the executable)
+	DataDir string `env:"DB_DATA_DIR"`
+
+	// Connection pool sizes, 0 keeps the PocketBase defaults
+	MaxOpenConns int `env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns int `env:"DB_MAX_IDLE_CONNS"`
+
+	// SQLite pragmas applied to every connection
+	BusyTimeoutMs     int    `env:"DB_BUSY_TIMEOUT_MS" envDefault:"10000"`
+	JournalSizeLimit  int    `env:"DB_JOURNAL_SIZE_LIMIT" envDefault:"200000000"`
+	WALAutocheckpoint int    `env:"DB_WAL_AUTOCHECKPOINT" envDefault:"1000"`
+	CacheSizeKB       int    `env:"DB_CACHE_SIZE_KB" envDefault:"16000"`
+	Synchronous       string `env:"DB_SYNCHRONOUS" envDefault:"NORMAL"`
+
+	// Read-only pool used by heavy list queries (history, overview). An empty replica
+	// path opens the primary data.db; otherwise it points to a replicated SQLite file.
+	ReadReplicaPath  string `env:"DB_READ_REPLICA_PATH"`
+	ReadMaxOpenConns int    `env:"DB_READ_MAX_OPEN_CONNS" envDefault:"10"`
+
+	// Postgres backend: connection string, pool size and the secret signing the auth tokens of users
+	PostgresURL        string `env:"DB_POSTGRES_URL"`
+	PostgresMaxConns   int    `env:"DB_POSTGRES_MAX_CONNS" envDefault:"10"`
+	PostgresAuthSecret string `env:"DB_POSTGRES_AUTH_SECRET"`
+	// Read replica serving the heavy list queries, with a pool of DB_READ_MAX_OPEN_CONNS. An empty
+	// url sends them to DB_POSTGRES_URL
+	PostgresReadURL string `env:"DB_POSTGRES_READ_URL"`
+}
+
+func (c *DatabaseConfig) Validate() error {
+	if !slices.Contains(databaseBackends, c.Backend) {
+		return ErrInvalidDatabaseBackend
+	}
+
+	if c.Backend == DatabaseBackendPostgres {
+		if c.PostgresURL == "" {
+			return ErrMissingPostgresURL
+		}
+		if c.PostgresMaxConns <= 0 {
+			return ErrInvalidPostgresMaxConns
+		}
+		if len(c.PostgresAuthSecret) < MIN_POSTGRES_AUTH_SECRET_LENGTH {
+			return ErrInvalidPostgresAuthSecret
+		}
+	}
+
+	if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 || c.ReadMaxOpenConns < 0 {
+		return ErrInvalidDatabaseConns
+	}
+
+	if c.BusyTimeoutMs < 0 || c.JournalSizeLimit < 0 || c.WALAutocheckpoint < 0 || c.CacheSizeKB < 0 {
+		return ErrInvalidDatabasePragma
+	}
+
+	if !slices.Contains(synchronousModes, c.Synchronous) {
+		return ErrInvalidDatabaseSynchronous
+	}
+
+	return nil
+}
+
+// UsesPostgres reports whether the app data is stored in postgres instead of PocketBase
+func (c *DatabaseConfig) UsesPostgres() bool {
+	return c.Backend == DatabaseBackendPostgres
+}
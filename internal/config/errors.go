@@ -3,8 +3,47 @@ package config
 import "errors"
 
 var (
-	ErrMissingSpotifyClientID     = errors.New("SPOTIFY_CLIENT_ID environment variable is required")
-	ErrMissingSpotifyClientSecret = errors.New("SPOTIFY_CLIENT_SECRET environment variable is required")
-	ErrMissingSpotifyRedirectURI  = errors.New("SPOTIFY_REDIRECT_URI environment variable is required")
-	ErrMissingEncryptionKey       = errors.New("ENCRYPTION_KEY environment variable is required")
+	ErrMissingSpotifyClientID         = errors.New("SPOTIFY_CLIENT_ID environment variable is required")
+	ErrMissingSpotifyRedirectURI      = errors.New("SPOTIFY_REDIRECT_URI environment variable is required")
+	ErrMissingEncryptionKey           = errors.New("ENCRYPTION_KEY environment variable is required")
+	ErrInvalidEncryptionKeyVersion    = errors.New("ENCRYPTION_KEY_VERSION must be positive and must not appear in PREVIOUS_ENCRYPTION_KEYS")
+	ErrInvalidSpotifyBaseURL          = errors.New("SPOTIFY_AUTH_BASE_URL and SPOTIFY_API_BASE_URL must be absolute http or https URLs")
+	ErrSpotifyMockInProduction        = errors.New("SPOTIFY_MOCK must not be enabled with APP_ENV=prod")
+	ErrMissingYouTubeMusicCredentials = errors.New("YOUTUBE_MUSIC_CLIENT_SECRET and YOUTUBE_MUSIC_REDIRECT_URI are required when YOUTUBE_MUSIC_CLIENT_ID is set")
+	ErrInvalidYouTubeMusicURL         = errors.New("YOUTUBE_MUSIC_AUTH_URL, YOUTUBE_MUSIC_TOKEN_URL and YOUTUBE_MUSIC_API_BASE_URL must be absolute http or https URLs")
+	ErrMissingTidalClientSecret       = errors.New("TIDAL_CLIENT_SECRET is required when TIDAL_CLIENT_ID is set")
+	ErrInvalidTidalURL                = errors.New("TIDAL_AUTH_BASE_URL and TIDAL_API_BASE_URL must be absolute http or https URLs")
+	ErrMissingLastFmCredentials       = errors.New("LASTFM_SHARED_SECRET and LASTFM_CALLBACK_URL are required when LASTFM_API_KEY is set")
+	ErrInvalidLastFmURL               = errors.New("LASTFM_AUTH_URL and LASTFM_API_BASE_URL must be absolute http or https URLs")
+	ErrInvalidLastFmCacheTTL          = errors.New("LASTFM_CACHE_TTL must be positive")
+	ErrInvalidDatabaseBackend         = errors.New("DB_BACKEND must be one of pocketbase or postgres")
+	ErrMissingPostgresURL             = errors.New("DB_POSTGRES_URL environment variable is required with the postgres backend")
+	ErrInvalidPostgresMaxConns        = errors.New("DB_POSTGRES_MAX_CONNS must be positive with the postgres backend")
+	ErrInvalidPostgresAuthSecret      = errors.New("DB_POSTGRES_AUTH_SECRET must be at least 32 characters with the postgres backend")
+	ErrInvalidDatabaseConns           = errors.New("DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_READ_MAX_OPEN_CONNS must not be negative")
+	ErrInvalidDatabasePragma          = errors.New("DB_BUSY_TIMEOUT_MS, DB_JOURNAL_SIZE_LIMIT, DB_WAL_AUTOCHECKPOINT and DB_CACHE_SIZE_KB must not be negative")
+	ErrInvalidDatabaseSynchronous     = errors.New("DB_SYNCHRONOUS must be one of OFF, NORMAL, FULL or EXTRA")
+	ErrMissingInternalAPIToken        = errors.New("INTERNAL_API_TOKEN environment variable is required when INTERNAL_API_PORT is set")
+	ErrInvalidRateLimit               = errors.New("RATE_LIMIT_REQUESTS_PER_MINUTE must not be negative")
+	ErrInvalidRateLimitBurst          = errors.New("RATE_LIMIT_BURST must be positive when rate limiting is enabled")
+	ErrInvalidTracingSampleRatio      = errors.New("TRACING_SAMPLE_RATIO must be between 0 and 1")
+	ErrInvalidCacheBackend            = errors.New("CACHE_BACKEND must be one of memory, redis or none")
+	ErrInvalidCacheMaxEntries         = errors.New("CACHE_MAX_ENTRIES must be positive with the memory cache backend")
+	ErrMissingCacheRedisAddr          = errors.New("CACHE_REDIS_ADDR environment variable is required with the redis cache backend")
+	ErrInvalidSyncChildConcurrency    = errors.New("SYNC_CHILD_CONCURRENCY must be positive")
+	ErrInvalidStreamingMinTracks      = errors.New("SYNC_STREAMING_MIN_TRACKS must not be negative")
+	ErrInvalidSyncEventRetention      = errors.New("SYNC_EVENT_RETENTION_DAYS must not be negative")
+	ErrInvalidBackupEncryptionKey     = errors.New("BACKUP_ENCRYPTION_KEY must be 32 characters")
+	ErrInvalidBackupStorage           = errors.New("BACKUP_STORAGE must be one of local, s3 or gcs")
+	ErrMissingBackupBucket            = errors.New("BACKUP_BUCKET, BACKUP_ACCESS_KEY and BACKUP_SECRET_KEY are required with the s3 and gcs backup storages")
+	ErrInvalidLogLevel                = errors.New("LOG_LEVEL must be one of debug, info, warn or error")
+	ErrUnsupportedConfigFile          = errors.New("CONFIG_FILE must be a .yaml, .yml or .toml file")
+	ErrInvalidConfigFile              = errors.New("failed to read CONFIG_FILE")
+	ErrUnknownConfigSettings          = errors.New("unknown settings")
+	ErrInvalidSecretsProvider         = errors.New("SECRETS_PROVIDER must be one of env, file, aws or vault")
+	ErrMissingSecretsDir              = errors.New("SECRETS_DIR environment variable is required with the file secrets provider")
+	ErrMissingSecretsAWSSecretID      = errors.New("SECRETS_AWS_SECRET_ID environment variable is required with the aws secrets provider")
+	ErrMissingSecretsVault            = errors.New("VAULT_ADDR, VAULT_TOKEN and SECRETS_VAULT_PATH are required with the vault secrets provider")
+	ErrInvalidSecretsRefreshInterval  = errors.New("SECRETS_REFRESH_INTERVAL must not be negative")
+	ErrFetchSecrets                   = errors.New("failed to fetch secrets")
 )
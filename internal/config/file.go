@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	env "github.com/caarlos0/env/v11"
+	"gopkg.in/yaml.v3"
+)
+
+// CONFIG_FILE_ENV points at a YAML or TOML file with settings. Its keys are the environment variable
+// names, in any case and optionally grouped by their prefix, so these are equivalent:
+//
+//	rate_limit_burst: 10
+//	rate_limit:
+//	  burst: 10
+//
+// Environment variables, the .env file included, take precedence over the file
+const CONFIG_FILE_ENV = "CONFIG_FILE"
+
+// readEnvironment returns the environment the settings are parsed from: the variables of the
+// process over the settings of the config file, when one is set
+func readEnvironment() (map[string]string, error) {
+	environment := map[string]string{}
+	for _, variable := range os.Environ() {
+		if key, value, ok := strings.Cut(variable, "="); ok {
+			environment[key] = value
+		}
+	}
+
+	path := environment[CONFIG_FILE_ENV]
+	if path == "" {
+		return environment, nil
+	}
+
+	settings, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range settings {
+		if _, ok := environment[key]; !ok {
+			environment[key] = value
+		}
+	}
+
+	return environment, nil
+}
+
+// readConfigFile parses the config file into environment variables, failing on settings the app
+// doesn't know so typos don't go unnoticed
+func readConfigFile(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfigFile, err)
+	}
+
+	document := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &document)
+	case ".toml":
+		err = toml.Unmarshal(raw, &document)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedConfigFile, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidConfigFile, path, err)
+	}
+
+	settings := map[string]string{}
+	if err := flattenSettings("", document, settings); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidConfigFile, path, err)
+	}
+
+	known, err := settingNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for key := range settings {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, fmt.Errorf("%w in %s: %s", ErrUnknownConfigSettings, path, strings.Join(unknown, ", "))
+	}
+
+	return settings, nil
+}
+
+// flattenSettings turns the groups of the document into prefixes of the setting names. Lists are
+// joined with commas, as the environment variables expect them
+func flattenSettings(prefix string, document map[string]any, settings map[string]string) error {
+	for key, value := range document {
+		name := strings.ToUpper(key)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flattenSettings(name, value, settings); err != nil {
+				return err
+			}
+		case []any:
+			items := make([]string, len(value))
+			for i, item := range value {
+				formatted, err := formatSetting(name, item)
+				if err != nil {
+					return err
+				}
+				items[i] = formatted
+			}
+			settings[name] = strings.Join(items, ",")
+		default:
+			formatted, err := formatSetting(name, value)
+			if err != nil {
+				return err
+			}
+			settings[name] = formatted
+		}
+	}
+
+	return nil
+}
+
+func formatSetting(name string, value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int:
+		return strconv.Itoa(value), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%s must be a string, number, boolean or list of them", name)
+	}
+}
+
+// settingNames returns the environment variable of every setting
+func settingNames() ([]string, error) {
+	params, err := env.GetFieldParams(&Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Key
+	}
+	return names, nil
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadConfigFile_YAML(t *testing.T) {
+	assert := require.New(t)
+
+	path := writeConfigFile(t, "config.yaml", `
+log_level: warn
+rate_limit:
+  requests_per_minute: 60
+  burst: 10
+spotify_client_id: client123
+tracing:
+  sample_ratio: 0.5
+`)
+
+	settings, err := readConfigFile(path)
+
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"LOG_LEVEL":                      "warn",
+		"RATE_LIMIT_REQUESTS_PER_MINUTE": "60",
+		"RATE_LIMIT_BURST":               "10",
+		"SPOTIFY_CLIENT_ID":              "client123",
+		"TRACING_SAMPLE_RATIO":           "0.5",
+	}, settings)
+}
+
+func TestReadConfigFile_TOML(t *testing.T) {
+	assert := require.New(t)
+
+	path := writeConfigFile(t, "config.toml", `
+LOG_LEVEL = "debug"
+SPOTIFY_MOCK = true
+
+[rate_limit]
+burst = 5
+`)
+
+	settings, err := readConfigFile(path)
+
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"LOG_LEVEL":        "debug",
+		"SPOTIFY_MOCK":     "true",
+		"RATE_LIMIT_BURST": "5",
+	}, settings)
+}
+
+func TestReadConfigFile_UnknownSettings(t *testing.T) {
+	assert := require.New(t)
+
+	path := writeConfigFile(t, "config.yml", `
+log_levl: debug
+rate_limit:
+  brust: 5
+`)
+
+	settings, err := readConfigFile(path)
+
+	assert.ErrorIs(err, ErrUnknownConfigSettings)
+	assert.ErrorContains(err, "LOG_LEVL, RATE_LIMIT_BRUST")
+	assert.Nil(settings)
+}
+
+func TestReadConfigFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected error
+	}{
+		{name: "unsupported extension", file: "config.json", content: `{}`, expected: ErrUnsupportedConfigFile},
+		{name: "malformed yaml", file: "config.yaml", content: "log_level: [debug", expected: ErrInvalidConfigFile},
+		{name: "malformed toml", file: "config.toml", content: "log_level = ", expected: ErrInvalidConfigFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := readConfigFile(writeConfigFile(t, tt.file, tt.content))
+
+			require.ErrorIs(t, err, tt.expected)
+			require.Nil(t, settings)
+		})
+	}
+}
+
+func TestLoad_EnvironmentOverridesConfigFile(t *testing.T) {
+	assert := require.New(t)
+
+	t.Setenv(CONFIG_FILE_ENV, writeConfigFile(t, "config.yaml", `
+log_level: warn
+rate_limit:
+  burst: 10
+`))
+	t.Setenv("LOG_LEVEL", "error")
+
+	cfg, err := Load()
+
+	assert.NoError(err)
+	assert.Equal("error", cfg.LogLevel)
+	assert.Equal(10, cfg.RateLimit.Burst)
+}
+
+func TestConfig_Validate_ListsEveryProblem(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := &Config{
+		LogLevel:  "loud",
+		Auth:      AuthConfig{EncryptionKeyVersion: 1},
+		Database:  DatabaseConfig{Backend: "pocketbase"},
+		RateLimit: RateLimitConfig{RequestsPerMinute: -1},
+		Cache:     CacheConfig{Backend: "none"},
+		Sync:      SyncConfig{ChildConcurrency: 1},
+		Backup:    BackupConfig{Storage: "local"},
+	}
+
+	err := cfg.Validate()
+
+	assert.ErrorIs(err, ErrInvalidLogLevel)
+	assert.ErrorIs(err, ErrMissingSpotifyClientID)
+	assert.ErrorIs(err, ErrMissingSpotifyRedirectURI)
+	assert.ErrorIs(err, ErrMissingEncryptionKey)
+	assert.ErrorIs(err, ErrInvalidRateLimit)
+	assert.Contains(err.Error(), "invalid auth configuration: SPOTIFY_REDIRECT_URI environment variable is required")
+}
+
+func TestConfig_ApplyHotReload(t *testing.T) {
+	assert := require.New(t)
+
+	cfg := &Config{LogLevel: "info", Port: "8090", Auth: AuthConfig{SpotifyClientSecret: "secret"}, RateLimit: RateLimitConfig{RequestsPerMinute: 120, Burst: 30}}
+	next := &Config{LogLevel: "debug", Port: "9000", Auth: AuthConfig{SpotifyClientSecret: "rotated"}, RateLimit: RateLimitConfig{RequestsPerMinute: 120, Burst: 5}}
+
+	applied, pending := cfg.ApplyHotReload(next)
+
+	assert.Equal([]string{"LOG_LEVEL", "SPOTIFY_CLIENT_SECRET", "RATE_LIMIT_BURST"}, applied)
+	assert.Equal([]string{"PORT"}, pending)
+	assert.Equal("debug", cfg.LogLevel)
+	assert.Equal(5, cfg.RateLimit.Burst)
+	assert.Equal("rotated", cfg.Auth.SpotifyClientSecret)
+	assert.Equal("8090", cfg.Port)
+}
@@ -0,0 +1,9 @@
+package config
+
+type GRPCAPIConfig struct {
+	Port string `env:"GRPC_API_PORT"`
+}
+
+func (c *GRPCAPIConfig) Enabled() bool {
+	return c.Port != ""
+}
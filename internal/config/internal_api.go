@@ -0,0 +1,18 @@
+package config
+
+type InternalAPIConfig struct {
+	Port  string `env:"INTERNAL_API_PORT"`
+	Token string `env:"INTERNAL_API_TOKEN"`
+}
+
+func (c *InternalAPIConfig) Enabled() bool {
+	return c.Port != ""
+}
+
+func (c *InternalAPIConfig) Validate() error {
+	if c.Enabled() && c.Token == "" {
+		return ErrMissingInternalAPIToken
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// LastFmConfig lets users link a Last.fm account, whose scrobbles enrich the tracks of their syncs
+// with play counts and tags. The integration is off without an API key
+type LastFmConfig struct {
+	APIKey       string `env:"LASTFM_API_KEY"`
+	SharedSecret string `env:"LASTFM_SHARED_SECRET"`
+	CallbackURL  string `env:"LASTFM_CALLBACK_URL"`
+
+	// Last.fm web auth and API endpoints, overridden to point the client at a proxy or a fake of
+	// the API
+	AuthURL    string `env:"LASTFM_AUTH_URL" envDefault:"https://www.last.fm/api/auth/"`
+	APIBaseURL string `env:"LASTFM_API_BASE_URL" envDefault:"https://ws.audioscrobbler.com/2.0/"`
+
+	// How long the play counts and tags of a track are reused before being looked up again
+	CacheTTL time.Duration `env:"LASTFM_CACHE_TTL" envDefault:"6h"`
+}
+
+func (c *LastFmConfig) Enabled() bool {
+	return c.APIKey != ""
+}
+
+func (c *LastFmConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	var missing []error
+	if c.SharedSecret == "" || c.CallbackURL == "" {
+		missing = append(missing, ErrMissingLastFmCredentials)
+	}
+	if !validBaseURL(c.AuthURL) || !validBaseURL(c.APIBaseURL) {
+		missing = append(missing, ErrInvalidLastFmURL)
+	}
+	if c.CacheTTL <= 0 {
+		missing = append(missing, ErrInvalidLastFmCacheTTL)
+	}
+
+	return errors.Join(missing...)
+}
@@ -0,0 +1,26 @@
+package config
+
+type RateLimitConfig struct {
+	// Requests a user, or an IP for anonymous requests, can make to the /api routes per minute on
+	// average. 0 disables rate limiting
+	RequestsPerMinute int `env:"RATE_LIMIT_REQUESTS_PER_MINUTE" envDefault:"120"`
+
+	// Requests that can be made in a row before the per minute rate applies
+	Burst int `env:"RATE_LIMIT_BURST" envDefault:"30"`
+}
+
+func (c *RateLimitConfig) Enabled() bool {
+	return c.RequestsPerMinute > 0
+}
+
+func (c *RateLimitConfig) Validate() error {
+	if c.RequestsPerMinute < 0 {
+		return ErrInvalidRateLimit
+	}
+
+	if c.Enabled() && c.Burst < 1 {
+		return ErrInvalidRateLimitBurst
+	}
+
+	return nil
+}
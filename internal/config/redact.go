@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces secrets in redacted output
+const RedactedValue = "[REDACTED]"
+
+// secretEnvMarkers flag the environment variables holding credentials
+var secretEnvMarkers = []string{"SECRET", "PASSWORD", "TOKEN", "KEY"}
+
+// Redacted returns the configuration keyed by environment variable, with the values of
+// secret variables replaced. Unset secrets are left empty so their absence stays visible.
+func (c *Config) Redacted() map[string]any {
+	redacted := map[string]any{}
+	walkEnvFields(reflect.ValueOf(*c), func(name string, value reflect.Value) {
+		if !isSecretEnv(name) {
+			redacted[name] = value.Interface()
+			return
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			if value.String() != "" {
+				redacted[name] = RedactedValue
+			} else {
+				redacted[name] = ""
+			}
+		case reflect.Map:
+			masked := make(map[string]string, value.Len())
+			for _, key := range value.MapKeys() {
+				masked[fmt.Sprint(key.Interface())] = RedactedValue
+			}
+			redacted[name] = masked
+		default:
+			// Non text values such as key versions are not credentials
+			redacted[name] = value.Interface()
+		}
+	})
+
+	return redacted
+}
+
+// Secrets returns every configured secret value, so it can be scrubbed from free text
+func (c *Config) Secrets() []string {
+	secrets := []string{}
+	walkEnvFields(reflect.ValueOf(*c), func(name string, value reflect.Value) {
+		if !isSecretEnv(name) {
+			return
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			if value.String() != "" {
+				secrets = append(secrets, value.String())
+			}
+		case reflect.Map:
+			for _, key := range value.MapKeys() {
+				if secret := value.MapIndex(key); secret.Kind() == reflect.String && secret.String() != "" {
+					secrets = append(secrets, secret.String())
+				}
+			}
+		}
+	})
+
+	return secrets
+}
+
+func walkEnvFields(value reflect.Value, visit func(name string, value reflect.Value)) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		if name == "" {
+			if value.Field(i).Kind() == reflect.Struct {
+				walkEnvFields(value.Field(i), visit)
+			}
+			continue
+		}
+
+		visit(name, value.Field(i))
+	}
+}
+
+func isSecretEnv(name string) bool {
+	for _, marker := range secretEnvMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,284 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+const (
+	SecretsProviderEnv   = "env"
+	SecretsProviderFile  = "file"
+	SecretsProviderAWS   = "aws"
+	SecretsProviderVault = "vault"
+)
+
+var secretsProviders = []string{SecretsProviderEnv, SecretsProviderFile, SecretsProviderAWS, SecretsProviderVault}
+
+// secretSettings are the settings a secret provider can hold. Any other key it returns is ignored
+var secretSettings = []string{"SPOTIFY_CLIENT_SECRET", "ENCRYPTION_KEY", "ENCRYPTION_KEY_VERSION", "PREVIOUS_ENCRYPTION_KEYS"}
+
+// SECRETS_FETCH_TIMEOUT bounds fetching the secrets, so an unreachable secrets manager fails the
+// startup instead of hanging it
+const SECRETS_FETCH_TIMEOUT = 30 * time.Second
+
+type SecretsConfig struct {
+	// Where the spotify client secret and the encryption keys are read from: env (default) keeps them
+	// in the environment, file, aws and vault fetch them on start and every SECRETS_REFRESH_INTERVAL
+	Provider string `env:"SECRETS_PROVIDER" envDefault:"env"`
+
+	// file: directory with a file per secret named after its variable, as Docker and Kubernetes
+	// mount secrets
+	Dir string `env:"SECRETS_DIR" envDefault:"/run/secrets"`
+
+	// aws: name or ARN of the Secrets Manager secret, a JSON object keyed by variable. Credentials and
+	// region come from the default AWS chain, the endpoint is overridden for LocalStack and the like
+	AWSSecretID string `env:"SECRETS_AWS_SECRET_ID"`
+	AWSRegion   string `env:"SECRETS_AWS_REGION"`
+	AWSEndpoint string `env:"SECRETS_AWS_ENDPOINT"`
+
+	// vault: KV v2 secret keyed by variable, read from VAULT_ADDR/v1/SECRETS_VAULT_PATH
+	// (e.g. secret/data/playlist-router)
+	VaultAddr  string `env:"VAULT_ADDR"`
+	VaultToken string `env:"VAULT_TOKEN"`
+	VaultPath  string `env:"SECRETS_VAULT_PATH"`
+
+	// How often the secrets are fetched again to pick up rotations, 0 only fetches them on start
+	RefreshInterval time.Duration `env:"SECRETS_REFRESH_INTERVAL" envDefault:"1h"`
+}
+
+func (c *SecretsConfig) Validate() error {
+	if !slices.Contains(secretsProviders, c.Provider) {
+		return ErrInvalidSecretsProvider
+	}
+
+	var missing []error
+	switch c.Provider {
+	case SecretsProviderFile:
+		if c.Dir == "" {
+			missing = append(missing, ErrMissingSecretsDir)
+		}
+	case SecretsProviderAWS:
+		if c.AWSSecretID == "" {
+			missing = append(missing, ErrMissingSecretsAWSSecretID)
+		}
+	case SecretsProviderVault:
+		if c.VaultAddr == "" || c.VaultToken == "" || c.VaultPath == "" {
+			missing = append(missing, ErrMissingSecretsVault)
+		}
+	}
+	if c.RefreshInterval < 0 {
+		missing = append(missing, ErrInvalidSecretsRefreshInterval)
+	}
+
+	return errors.Join(missing...)
+}
+
+// FetchesSecrets reports whether the secrets come from a provider other than the environment
+func (c *SecretsConfig) FetchesSecrets() bool {
+	return c.Provider != SecretsProviderEnv
+}
+
+// SecretProvider fetches the secret settings, keyed by their environment variable. Settings the
+// provider doesn't hold are left out and keep their environment value
+type SecretProvider interface {
+	FetchSecrets(ctx context.Context) (map[string]string, error)
+}
+
+func NewSecretProvider(cfg SecretsConfig) (SecretProvider, error) {
+	switch cfg.Provider {
+	case SecretsProviderEnv:
+		return envSecretProvider{}, nil
+	case SecretsProviderFile:
+		return &fileSecretProvider{dir: cfg.Dir}, nil
+	case SecretsProviderAWS:
+		return &awsSecretProvider{secretID: cfg.AWSSecretID, region: cfg.AWSRegion, endpoint: cfg.AWSEndpoint}, nil
+	case SecretsProviderVault:
+		return &vaultSecretProvider{
+			httpClient: &http.Client{Timeout: 15 * time.Second},
+			addr:       strings.TrimSuffix(cfg.VaultAddr, "/"),
+			token:      cfg.VaultToken,
+			path:       strings.Trim(cfg.VaultPath, "/"),
+		}, nil
+	default:
+		return nil, ErrInvalidSecretsProvider
+	}
+}
+
+// readSecrets overlays the secrets of the provider configured in environment onto it. Fetched
+// secrets take precedence, the secrets manager is where they are rotated
+func readSecrets(environment map[string]string) error {
+	cfg := SecretsConfig{}
+	if err := parseEnvironment(&cfg, environment); err != nil {
+		return err
+	}
+	if !cfg.FetchesSecrets() {
+		return nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid secrets configuration: %w", err)
+	}
+
+	provider, err := NewSecretProvider(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SECRETS_FETCH_TIMEOUT)
+	defer cancel()
+
+	secrets, err := provider.FetchSecrets(ctx)
+	if err != nil {
+		return fmt.Errorf("%w from %s: %w", ErrFetchSecrets, cfg.Provider, err)
+	}
+
+	for key, value := range secrets {
+		environment[key] = value
+	}
+
+	return nil
+}
+
+// envSecretProvider leaves the secrets in the environment
+type envSecretProvider struct{}
+
+func (envSecretProvider) FetchSecrets(ctx context.Context) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// fileSecretProvider reads each secret from the file named after its variable
+type fileSecretProvider struct {
+	dir string
+}
+
+func (p *fileSecretProvider) FetchSecrets(ctx context.Context) (map[string]string, error) {
+	secrets := map[string]string{}
+	for _, name := range secretSettings {
+		raw, err := os.ReadFile(filepath.Join(p.dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		secrets[name] = strings.TrimRight(string(raw), "\r\n")
+	}
+
+	return secrets, nil
+}
+
+// awsSecretProvider reads the secrets from a JSON secret of AWS Secrets Manager
+type awsSecretProvider struct {
+	secretID string
+	region   string
+	endpoint string
+}
+
+func (p *awsSecretProvider) FetchSecrets(ctx context.Context) (map[string]string, error) {
+	var options []func(*awsconfig.LoadOptions) error
+	if p.region != "" {
+		options = append(options, awsconfig.WithRegion(p.region))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+
+	client := secretsmanager.NewFromConfig(awsCfg, func(o *secretsmanager.Options) {
+		if p.endpoint != "" {
+			o.BaseEndpoint = aws.String(p.endpoint)
+		}
+	})
+
+	output, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(p.secretID)})
+	if err != nil {
+		return nil, err
+	}
+	if output.SecretString == nil {
+		return nil, fmt.Errorf("secret %s is binary, a JSON object is expected", p.secretID)
+	}
+
+	return parseSecrets([]byte(*output.SecretString))
+}
+
+// vaultSecretProvider reads the secrets from a KV v2 secret of HashiCorp Vault
+type vaultSecretProvider struct {
+	httpClient *http.Client
+	addr       string
+	token      string
+	path       string
+}
+
+func (p *vaultSecretProvider) FetchSecrets(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", p.addr, p.path), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	if secret.Data.Data == nil {
+		return nil, fmt.Errorf("vault secret %s has no data, a KV v2 secret is expected", p.path)
+	}
+
+	return parseSecrets(secret.Data.Data)
+}
+
+// parseSecrets reads the secret settings out of a JSON object keyed by variable
+func parseSecrets(raw []byte) (map[string]string, error) {
+	document := map[string]any{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode secret: %w", err)
+	}
+
+	secrets := map[string]string{}
+	for key, value := range document {
+		name := strings.ToUpper(key)
+		if !slices.Contains(secretSettings, name) {
+			continue
+		}
+
+		formatted, err := formatSetting(name, value)
+		if err != nil {
+			return nil, err
+		}
+		secrets[name] = formatted
+	}
+
+	return secrets, nil
+}
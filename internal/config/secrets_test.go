@@ -0,0 +1,184 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      SecretsConfig
+		expected error
+	}{
+		{name: "env", cfg: SecretsConfig{Provider: SecretsProviderEnv}},
+		{name: "file", cfg: SecretsConfig{Provider: SecretsProviderFile, Dir: "/run/secrets"}},
+		{name: "aws", cfg: SecretsConfig{Provider: SecretsProviderAWS, AWSSecretID: "playlist-router"}},
+		{name: "vault", cfg: SecretsConfig{Provider: SecretsProviderVault, VaultAddr: "http://vault:8200", VaultToken: "token", VaultPath: "secret/data/playlist-router"}},
+		{name: "unknown provider", cfg: SecretsConfig{Provider: "gcp"}, expected: ErrInvalidSecretsProvider},
+		{name: "file without dir", cfg: SecretsConfig{Provider: SecretsProviderFile}, expected: ErrMissingSecretsDir},
+		{name: "aws without secret id", cfg: SecretsConfig{Provider: SecretsProviderAWS}, expected: ErrMissingSecretsAWSSecretID},
+		{name: "vault without token", cfg: SecretsConfig{Provider: SecretsProviderVault, VaultAddr: "http://vault:8200", VaultPath: "secret/data/playlist-router"}, expected: ErrMissingSecretsVault},
+		{name: "negative refresh interval", cfg: SecretsConfig{Provider: SecretsProviderEnv, RefreshInterval: -1}, expected: ErrInvalidSecretsRefreshInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+
+			if tt.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFileSecretProvider_FetchSecrets(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "SPOTIFY_CLIENT_SECRET"), []byte("client-secret\n"), 0o600))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "ENCRYPTION_KEY"), []byte("0123456789abcdef0123456789abcdef"), 0o600))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "DB_POSTGRES_URL"), []byte("postgres://ignored"), 0o600))
+
+	provider, err := NewSecretProvider(SecretsConfig{Provider: SecretsProviderFile, Dir: dir})
+	assert.NoError(err)
+
+	secrets, err := provider.FetchSecrets(context.Background())
+
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"SPOTIFY_CLIENT_SECRET": "client-secret",
+		"ENCRYPTION_KEY":        "0123456789abcdef0123456789abcdef",
+	}, secrets)
+}
+
+func TestVaultSecretProvider_FetchSecrets(t *testing.T) {
+	assert := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/v1/secret/data/playlist-router", r.URL.Path)
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"data": map[string]any{
+					"spotify_client_secret":    "client-secret",
+					"ENCRYPTION_KEY_VERSION":   2,
+					"PREVIOUS_ENCRYPTION_KEYS": "1:0123456789abcdef0123456789abcdef",
+					"unrelated":                "ignored",
+				},
+				"metadata": map[string]any{"version": 3},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewSecretProvider(SecretsConfig{Provider: SecretsProviderVault, VaultAddr: server.URL + "/", VaultToken: "vault-token", VaultPath: "/secret/data/playlist-router"})
+	assert.NoError(err)
+
+	secrets, err := provider.FetchSecrets(context.Background())
+
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"SPOTIFY_CLIENT_SECRET":    "client-secret",
+		"ENCRYPTION_KEY_VERSION":   "2",
+		"PREVIOUS_ENCRYPTION_KEYS": "1:0123456789abcdef0123456789abcdef",
+	}, secrets)
+
+	// A rejected token fails with the status vault answered
+	provider, err = NewSecretProvider(SecretsConfig{Provider: SecretsProviderVault, VaultAddr: server.URL, VaultToken: "wrong", VaultPath: "secret/data/playlist-router"})
+	assert.NoError(err)
+
+	secrets, err = provider.FetchSecrets(context.Background())
+
+	assert.ErrorContains(err, "vault returned status 403")
+	assert.Nil(secrets)
+}
+
+func TestAWSSecretProvider_FetchSecrets(t *testing.T) {
+	assert := require.New(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.Contains(r.Header.Get("Authorization"), "Credential=access-key/")
+
+		var input struct {
+			SecretId string
+		}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal("playlist-router", input.SecretId)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Name":         "playlist-router",
+			"SecretString": `{"SPOTIFY_CLIENT_SECRET": "client-secret", "ENCRYPTION_KEY": "0123456789abcdef0123456789abcdef"}`,
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewSecretProvider(SecretsConfig{Provider: SecretsProviderAWS, AWSSecretID: "playlist-router", AWSRegion: "eu-west-1", AWSEndpoint: server.URL})
+	assert.NoError(err)
+
+	secrets, err := provider.FetchSecrets(context.Background())
+
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"SPOTIFY_CLIENT_SECRET": "client-secret",
+		"ENCRYPTION_KEY":        "0123456789abcdef0123456789abcdef",
+	}, secrets)
+}
+
+func TestLoad_SecretsOverrideEnvironment(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "ENCRYPTION_KEY"), []byte("fedcba9876543210fedcba9876543210"), 0o600))
+
+	t.Setenv("SECRETS_PROVIDER", SecretsProviderFile)
+	t.Setenv("SECRETS_DIR", dir)
+	t.Setenv("ENCRYPTION_KEY", "0123456789abcdef0123456789abcdef")
+	t.Setenv("SPOTIFY_CLIENT_SECRET", "env-client-secret")
+
+	cfg, err := Load()
+
+	assert.NoError(err)
+	assert.Equal("fedcba9876543210fedcba9876543210", cfg.Auth.EncryptionKey)
+	assert.Equal("env-client-secret", cfg.Auth.SpotifyClientSecret)
+}
+
+func TestLoad_SecretsUnavailable(t *testing.T) {
+	assert := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	t.Setenv("SECRETS_PROVIDER", SecretsProviderVault)
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "vault-token")
+	t.Setenv("SECRETS_VAULT_PATH", "secret/data/playlist-router")
+
+	cfg, err := Load()
+
+	assert.ErrorIs(err, ErrFetchSecrets)
+	assert.Nil(cfg)
+}
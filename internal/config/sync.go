@@ -0,0 +1,42 @@
+package config
+
+import "time"
+
+type SyncConfig struct {
+	// Child playlists of a base playlist written to spotify at the same time during a sync. Their
+	// requests still draw from the shared SPOTIFY_REQUEST_BUDGET, 1 writes them one at a time
+	ChildConcurrency int `env:"SYNC_CHILD_CONCURRENCY" envDefault:"4"`
+
+	// Base playlists with at least this many tracks are synced as a stream of pages, holding little
+	// track data in memory and writing the first tracks early. 0 syncs every playlist in one batch
+	StreamingMinTracks int `env:"SYNC_STREAMING_MIN_TRACKS" envDefault:"0"`
+
+	// Keeps the tracks matching each child playlist per snapshot of its base playlist, so re-syncs of
+	// unchanged playlists with unchanged filter rules skip evaluating them. Costs a request per sync
+	RoutingCache bool `env:"SYNC_ROUTING_CACHE" envDefault:"true"`
+
+	// Finished sync events older than this are deleted once a day, keeping the latest and the latest
+	// completed sync of every base playlist. 0 keeps them forever
+	EventRetentionDays int `env:"SYNC_EVENT_RETENTION_DAYS" envDefault:"90"`
+}
+
+func (c *SyncConfig) Validate() error {
+	if c.ChildConcurrency < 1 {
+		return ErrInvalidSyncChildConcurrency
+	}
+
+	if c.StreamingMinTracks < 0 {
+		return ErrInvalidStreamingMinTracks
+	}
+
+	if c.EventRetentionDays < 0 {
+		return ErrInvalidSyncEventRetention
+	}
+
+	return nil
+}
+
+// EventRetention is how long finished sync events are kept, 0 when they are kept forever
+func (c *SyncConfig) EventRetention() time.Duration {
+	return time.Duration(c.EventRetentionDays) * 24 * time.Hour
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// TidalConfig lets users link a Tidal account and keep base and child playlists there. Accounts are
+// linked through the OAuth device flow, so there is no redirect URI. The integration is off without
+// a client ID
+type TidalConfig struct {
+	ClientID     string `env:"TIDAL_CLIENT_ID"`
+	ClientSecret string `env:"TIDAL_CLIENT_SECRET"`
+
+	// Tidal OAuth and API endpoints, overridden to point the client at a proxy or a fake of the API
+	AuthBaseURL string `env:"TIDAL_AUTH_BASE_URL" envDefault:"https://auth.tidal.com/v1/oauth2/"`
+	APIBaseURL  string `env:"TIDAL_API_BASE_URL" envDefault:"https://api.tidal.com/v1/"`
+
+	// Tidal tokens expiring within the window are refreshed before being used
+	TokenRefreshWindow time.Duration `env:"TIDAL_TOKEN_REFRESH_WINDOW" envDefault:"5m"`
+}
+
+func (c *TidalConfig) Enabled() bool {
+	return c.ClientID != ""
+}
+
+func (c *TidalConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	var missing []error
+	if c.ClientSecret == "" {
+		missing = append(missing, ErrMissingTidalClientSecret)
+	}
+	if !validBaseURL(c.AuthBaseURL) || !validBaseURL(c.APIBaseURL) {
+		missing = append(missing, ErrInvalidTidalURL)
+	}
+
+	return errors.Join(missing...)
+}
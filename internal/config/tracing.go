@@ -0,0 +1,25 @@
+package config
+
+type TracingConfig struct {
+	// OTLP/HTTP collector the traces are exported to, e.g. http://localhost:4318. Empty disables
+	// tracing. Collector headers are read by the exporter from OTEL_EXPORTER_OTLP_HEADERS
+	Endpoint string `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+
+	ServiceName string `env:"OTEL_SERVICE_NAME" envDefault:"playlist-router"`
+
+	// Share of the traces started here that are exported, from 0 to 1. Traces continued from an
+	// incoming request follow the sampling decision of the caller
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+}
+
+func (c *TracingConfig) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+func (c *TracingConfig) Validate() error {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return ErrInvalidTracingSampleRatio
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// YouTubeMusicConfig lets users link a YouTube Music account and keep base and child playlists
+// there. The integration is off without a client ID
+type YouTubeMusicConfig struct {
+	ClientID     string `env:"YOUTUBE_MUSIC_CLIENT_ID"`
+	ClientSecret string `env:"YOUTUBE_MUSIC_CLIENT_SECRET"`
+	RedirectURI  string `env:"YOUTUBE_MUSIC_REDIRECT_URI"`
+
+	// Google OAuth and YouTube Data API endpoints, overridden to point the client at a proxy or a
+	// fake of the API
+	AuthURL    string `env:"YOUTUBE_MUSIC_AUTH_URL" envDefault:"https://accounts.google.com/o/oauth2/v2/auth"`
+	TokenURL   string `env:"YOUTUBE_MUSIC_TOKEN_URL" envDefault:"https://oauth2.googleapis.com/token"`
+	APIBaseURL string `env:"YOUTUBE_MUSIC_API_BASE_URL" envDefault:"https://www.googleapis.com/youtube/v3/"`
+
+	// Google tokens expiring within the window are refreshed before being used
+	TokenRefreshWindow time.Duration `env:"YOUTUBE_MUSIC_TOKEN_REFRESH_WINDOW" envDefault:"5m"`
+}
+
+func (c *YouTubeMusicConfig) Enabled() bool {
+	return c.ClientID != ""
+}
+
+func (c *YouTubeMusicConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	var missing []error
+	if c.ClientSecret == "" || c.RedirectURI == "" {
+		missing = append(missing, ErrMissingYouTubeMusicCredentials)
+	}
+	if !validBaseURL(c.AuthURL) || !validBaseURL(c.TokenURL) || !validBaseURL(c.APIBaseURL) {
+		missing = append(missing, ErrInvalidYouTubeMusicURL)
+	}
+
+	return errors.Join(missing...)
+}
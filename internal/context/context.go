@@ -11,6 +11,13 @@ type contextKey string
 const (
 	UserContextKey        contextKey = "user"
 	SpotifyAuthContextKey contextKey = "spotify_integration"
+	YouTubeMusicAuthKey   contextKey = "youtube_music_integration"
+	TidalAuthKey          contextKey = "tidal_integration"
+	MusicProviderKey      contextKey = "music_provider"
+	APIKeyContextKey      contextKey = "api_key"
+	BackgroundJobKey      contextKey = "background_job"
+	SyncProfilingKey      contextKey = "sync_profiling"
+	RequestIDKey          contextKey = "request_id"
 )
 
 func ContextWithUser(ctx context.Context, user *models.User) context.Context {
@@ -31,6 +38,80 @@ func GetSpotifyAuthFromContext(ctx context.Context) (*models.SpotifyIntegration,
 	return s, ok
 }
 
+func ContextWithYouTubeMusicAuth(ctx context.Context, youTubeMusicAuth *models.YouTubeMusicIntegration) context.Context {
+	return context.WithValue(ctx, YouTubeMusicAuthKey, youTubeMusicAuth)
+}
+
+func GetYouTubeMusicAuthFromContext(ctx context.Context) (*models.YouTubeMusicIntegration, bool) {
+	integration, ok := ctx.Value(YouTubeMusicAuthKey).(*models.YouTubeMusicIntegration)
+	return integration, ok
+}
+
+func ContextWithTidalAuth(ctx context.Context, tidalAuth *models.TidalIntegration) context.Context {
+	return context.WithValue(ctx, TidalAuthKey, tidalAuth)
+}
+
+func GetTidalAuthFromContext(ctx context.Context) (*models.TidalIntegration, bool) {
+	integration, ok := ctx.Value(TidalAuthKey).(*models.TidalIntegration)
+	return integration, ok
+}
+
+// ContextWithMusicProvider picks the streaming service the music provider calls made with ctx go to.
+// An unset provider leaves ctx as is, playlists stored before providers existed stay on spotify
+func ContextWithMusicProvider(ctx context.Context, provider models.MusicProvider) context.Context {
+	if provider == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, MusicProviderKey, provider)
+}
+
+// GetMusicProviderFromContext returns the streaming service picked for ctx, spotify when none is
+func GetMusicProviderFromContext(ctx context.Context) models.MusicProvider {
+	provider, _ := ctx.Value(MusicProviderKey).(models.MusicProvider)
+	return provider.OrDefault()
+}
+
+func ContextWithAPIKey(ctx context.Context, apiKey *models.APIKey) context.Context {
+	return context.WithValue(ctx, APIKeyContextKey, apiKey)
+}
+
+func GetAPIKeyFromContext(ctx context.Context) (*models.APIKey, bool) {
+	apiKey, ok := ctx.Value(APIKeyContextKey).(*models.APIKey)
+	return apiKey, ok
+}
+
+// ContextWithBackgroundJob marks work no user is waiting on, such as syncs requested by internal
+// services, which can be delayed instead of failing when the Spotify request budget is exhausted
+func ContextWithBackgroundJob(ctx context.Context) context.Context {
+	return context.WithValue(ctx, BackgroundJobKey, true)
+}
+
+func IsBackgroundJob(ctx context.Context) bool {
+	background, _ := ctx.Value(BackgroundJobKey).(bool)
+	return background
+}
+
+// ContextWithSyncProfiling asks the syncs run with ctx to record a timing profile of their steps
+func ContextWithSyncProfiling(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SyncProfilingKey, true)
+}
+
+func IsSyncProfiling(ctx context.Context) bool {
+	profiling, _ := ctx.Value(SyncProfilingKey).(bool)
+	return profiling
+}
+
+// ContextWithRequestID tags the work done for a request with its ID, so its logs and the sync events
+// it starts can be correlated
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+func GetRequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(RequestIDKey).(string)
+	return requestID, ok
+}
+
 func GetUserAndSpotifyAuthFromContext(ctx context.Context) (*models.User, *models.SpotifyIntegration, bool) {
 	user, userOk := ctx.Value(UserContextKey).(*models.User)
 	spotifyIntegration, spotifyIntegrationOk := ctx.Value(SpotifyAuthContextKey).(*models.SpotifyIntegration)
@@ -145,3 +145,28 @@ func TestGetUserAndSpotifyAuthFromContext(t *testing.T) {
 		})
 	}
 }
+
+func TestContextWithBackgroundJob(t *testing.T) {
+	assert := require.New(t)
+
+	assert.False(IsBackgroundJob(context.Background()))
+	assert.True(IsBackgroundJob(ContextWithBackgroundJob(context.Background())))
+}
+
+func TestContextWithSyncProfiling(t *testing.T) {
+	assert := require.New(t)
+
+	assert.False(IsSyncProfiling(context.Background()))
+	assert.True(IsSyncProfiling(ContextWithSyncProfiling(context.Background())))
+}
+
+func TestContextWithRequestID(t *testing.T) {
+	assert := require.New(t)
+
+	_, ok := GetRequestIDFromContext(context.Background())
+	assert.False(ok)
+
+	requestID, ok := GetRequestIDFromContext(ContextWithRequestID(context.Background(), "req123"))
+	assert.True(ok)
+	assert.Equal("req123", requestID)
+}
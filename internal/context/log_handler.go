@@ -0,0 +1,47 @@
+package requestcontext
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogHandler adds the request ID of the context to the records logged with the *Context methods of
+// slog, so every log line of a request can be found from the X-Request-ID of its response
+type LogHandler struct {
+	slog.Handler
+	level slog.Leveler // nil when every level the wrapped handler accepts is logged
+}
+
+func NewLogHandler(handler slog.Handler) *LogHandler {
+	return &LogHandler{Handler: handler}
+}
+
+// WithLevel drops the records below level, which can be changed while logging through a slog.LevelVar
+func (h *LogHandler) WithLevel(level slog.Leveler) *LogHandler {
+	h.level = level
+	return h
+}
+
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.level != nil && level < h.level.Level() {
+		return false
+	}
+
+	return h.Handler.Enabled(ctx, level)
+}
+
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID, ok := GetRequestIDFromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
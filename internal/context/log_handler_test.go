@@ -0,0 +1,43 @@
+package requestcontext
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogHandler(t *testing.T) {
+	assert := require.New(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewTextHandler(&buf, nil))).With("component", "Test")
+	ctx := ContextWithRequestID(context.Background(), "req123")
+
+	logger.InfoContext(ctx, "with request")
+	assert.Contains(buf.String(), "component=Test")
+	assert.Contains(buf.String(), "request_id=req123")
+
+	buf.Reset()
+	logger.InfoContext(context.Background(), "without request")
+	assert.NotContains(buf.String(), "request_id")
+}
+
+func TestLogHandler_WithLevel(t *testing.T) {
+	assert := require.New(t)
+
+	var buf bytes.Buffer
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelWarn)
+	logger := slog.New(NewLogHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})).WithLevel(level)).With("component", "Test")
+
+	logger.Info("dropped")
+	assert.Empty(buf.String())
+
+	// Loggers derived before the change follow it
+	level.Set(slog.LevelDebug)
+	logger.Debug("logged")
+	assert.Contains(buf.String(), "logged")
+}
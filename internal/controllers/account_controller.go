@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// AccountController lets users export or erase all of their data
+type AccountController struct {
+	accountService services.AccountServicer
+}
+
+func NewAccountController(accountService services.AccountServicer) *AccountController {
+	return &AccountController{
+		accountService: accountService,
+	}
+}
+
+// Delete erases the account of the user. The spotify playlists generated for its child playlists
+// are only deleted when the delete_spotify_playlists query parameter is set
+func (c *AccountController) Delete(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	deleteSpotifyPlaylists := false
+	if raw := r.URL.Query().Get("delete_spotify_playlists"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid delete_spotify_playlists")
+			return
+		}
+		deleteSpotifyPlaylists = value
+	}
+
+	report, err := c.accountService.DeleteAccount(r.Context(), user.ID, deleteSpotifyPlaylists)
+	if err != nil {
+		writeError(w, err, "unable to delete account")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// Export responds with all the data stored about the user as a json attachment
+func (c *AccountController) Export(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	export, err := c.accountService.ExportAccount(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to export account")
+		return
+	}
+
+	filename := fmt.Sprintf("playlist-router-export-%s.json", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAccountController(t *testing.T) (*AccountController, *servicemocks.MockAccountServicer) {
+	mockService := servicemocks.NewMockAccountServicer(gomock.NewController(t))
+	return NewAccountController(mockService), mockService
+}
+
+func TestAccountController_Delete(t *testing.T) {
+	tests := []struct {
+		name                   string
+		path                   string
+		expectCall             bool
+		deleteSpotifyPlaylists bool
+		serviceErr             error
+		expectedStatus         int
+	}{
+		{
+			name:           "keeps spotify playlists by default",
+			path:           "/api/account",
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:                   "deletes spotify playlists",
+			path:                   "/api/account?delete_spotify_playlists=true",
+			expectCall:             true,
+			deleteSpotifyPlaylists: true,
+			expectedStatus:         http.StatusOK,
+		},
+		{
+			name:           "invalid flag",
+			path:           "/api/account?delete_spotify_playlists=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "user not found",
+			path:           "/api/account",
+			expectCall:     true,
+			serviceErr:     repositories.ErrUseNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			path:           "/api/account",
+			expectCall:     true,
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mockService := setupAccountController(t)
+
+			report := &models.AccountDeletionReport{UserID: "user123", SpotifyPlaylistsDeleted: 2}
+			if tt.expectCall {
+				if tt.serviceErr != nil {
+					report = nil
+				}
+				mockService.EXPECT().DeleteAccount(gomock.Any(), "user123", tt.deleteSpotifyPlaylists).Return(report, tt.serviceErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.Delete(w, newAutomationRequest(http.MethodDelete, tt.path, ""))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.AccountDeletionReport
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(*report, body)
+			}
+		})
+	}
+}
+
+func TestAccountController_Delete_Unauthorized(t *testing.T) {
+	assert := require.New(t)
+	controller, _ := setupAccountController(t)
+
+	w := httptest.NewRecorder()
+	controller.Delete(w, httptest.NewRequest(http.MethodDelete, "/api/account", nil))
+
+	assert.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func TestAccountController_Export(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "user not found", serviceErr: repositories.ErrUseNotFound, expectedStatus: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mockService := setupAccountController(t)
+
+			var export *models.AccountExport
+			if tt.serviceErr == nil {
+				export = &models.AccountExport{
+					User:          &models.User{ID: "user123"},
+					BasePlaylists: []*models.BasePlaylistExport{{BasePlaylist: &models.BasePlaylist{ID: "bp1"}}},
+				}
+			}
+			mockService.EXPECT().ExportAccount(gomock.Any(), "user123").Return(export, tt.serviceErr)
+
+			w := httptest.NewRecorder()
+			controller.Export(w, newAutomationRequest(http.MethodGet, "/api/account/export", ""))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.serviceErr == nil {
+				assert.Contains(w.Header().Get("Content-Disposition"), `attachment; filename="playlist-router-export-`)
+
+				var body map[string]any
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal("user123", body["user"].(map[string]any)["id"])
+				assert.Equal("bp1", body["base_playlists"].([]any)[0].(map[string]any)["id"])
+			}
+		})
+	}
+}
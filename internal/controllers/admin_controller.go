@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// AdminController serves the admin API operators use to triage abusive accounts and stuck syncs
+// across every user
+type AdminController struct {
+	adminService     services.AdminServicer
+	syncOrchestrator orchestrators.SyncOrchestrator
+}
+
+func NewAdminController(adminService services.AdminServicer, syncOrchestrator orchestrators.SyncOrchestrator) *AdminController {
+	return &AdminController{
+		adminService:     adminService,
+		syncOrchestrator: syncOrchestrator,
+	}
+}
+
+// ListUsers lists the users matching the q query parameter, paginated with limit and offset
+func (c *AdminController) ListUsers(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	users, err := c.adminService.ListUsers(r.Context(), r.URL.Query().Get("q"), limit, offset)
+	if err != nil {
+		writeError(w, err, "unable to retrieve users")
+		return
+	}
+
+	writeAdminJSON(w, users)
+}
+
+func (c *AdminController) DisableUser(w http.ResponseWriter, r *http.Request) {
+	c.setUserDisabled(w, r, true)
+}
+
+func (c *AdminController) EnableUser(w http.ResponseWriter, r *http.Request) {
+	c.setUserDisabled(w, r, false)
+}
+
+func (c *AdminController) setUserDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	admin, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	userID := r.PathValue("id")
+	if userID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "user ID is required")
+		return
+	}
+
+	// Admins can't lock themselves out
+	if disabled && userID == admin.ID {
+		problem.Write(w, http.StatusBadRequest, problem.CodeBadRequest, "admins can not disable their own account")
+		return
+	}
+
+	user, err := c.adminService.SetUserDisabled(r.Context(), userID, disabled)
+	if err != nil {
+		writeError(w, err, "unable to update user")
+		return
+	}
+
+	writeAdminJSON(w, user)
+}
+
+// ListSyncEvents lists the sync events of every user, optionally filtered by the status, user_id and
+// base_playlist_id query parameters and paginated with limit and offset
+func (c *AdminController) ListSyncEvents(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	syncEvents, err := c.adminService.ListSyncEvents(r.Context(), repositories.SyncEventFilter{
+		Status:         models.SyncStatus(query.Get("status")),
+		UserID:         query.Get("user_id"),
+		BasePlaylistID: query.Get("base_playlist_id"),
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		writeError(w, err, "unable to retrieve sync events")
+		return
+	}
+
+	writeAdminJSON(w, syncEvents)
+}
+
+// RetrySync runs again a failed or stuck sync on behalf of its owner
+func (c *AdminController) RetrySync(w http.ResponseWriter, r *http.Request) {
+	syncEventID := r.PathValue("id")
+	if syncEventID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "sync event ID is required")
+		return
+	}
+
+	syncEvent, err := c.syncOrchestrator.RetrySync(r.Context(), syncEventID)
+	if err != nil && syncEvent == nil {
+		writeError(w, err, "failed to retry sync: "+err.Error())
+		return
+	}
+
+	// A retry that ran but failed again is reported through its sync event
+	writeAdminJSON(w, syncEvent)
+}
+
+func writeAdminJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
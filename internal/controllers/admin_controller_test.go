@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	orchestratormocks "github.com/ngomez18/playlist-router/internal/orchestrators/mocks"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAdminController(t *testing.T) (*AdminController, *servicemocks.MockAdminServicer, *orchestratormocks.MockSyncOrchestrator) {
+	ctrl := gomock.NewController(t)
+	mockService := servicemocks.NewMockAdminServicer(ctrl)
+	mockOrchestrator := orchestratormocks.NewMockSyncOrchestrator(ctrl)
+
+	return NewAdminController(mockService, mockOrchestrator), mockService, mockOrchestrator
+}
+
+func TestAdminController_ListUsers(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectCall     bool
+		expectedQuery  string
+		expectedLimit  int
+		expectedOffset int
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "search",
+			path:           "/api/admin/users?q=alice&limit=10&offset=20",
+			expectCall:     true,
+			expectedQuery:  "alice",
+			expectedLimit:  10,
+			expectedOffset: 20,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "defaults",
+			path:           "/api/admin/users",
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid limit",
+			path:           "/api/admin/users?limit=ten",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative offset",
+			path:           "/api/admin/users?offset=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			path:           "/api/admin/users",
+			expectCall:     true,
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mockService, _ := setupAdminController(t)
+
+			if tt.expectCall {
+				users := []*models.User{{ID: "user123", Email: "alice@example.com"}}
+				if tt.serviceErr != nil {
+					users = nil
+				}
+				mockService.EXPECT().ListUsers(gomock.Any(), tt.expectedQuery, tt.expectedLimit, tt.expectedOffset).Return(users, tt.serviceErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.ListUsers(w, newAutomationRequest(http.MethodGet, tt.path, ""))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body []models.User
+				assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+				assert.Len(body, 1)
+			}
+		})
+	}
+}
+
+func TestAdminController_DisableUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		expectCall     bool
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			userID:         "user456",
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "own account",
+			userID:         "user123",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "user not found",
+			userID:         "user456",
+			expectCall:     true,
+			serviceErr:     fmt.Errorf("failed to update user: %w", repositories.ErrUseNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			userID:         "user456",
+			expectCall:     true,
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mockService, _ := setupAdminController(t)
+
+			if tt.expectCall {
+				var user *models.User
+				if tt.serviceErr == nil {
+					user = &models.User{ID: tt.userID, Disabled: true}
+				}
+				mockService.EXPECT().SetUserDisabled(gomock.Any(), tt.userID, true).Return(user, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/admin/users/"+tt.userID+"/disable", "")
+			req.SetPathValue("id", tt.userID)
+			w := httptest.NewRecorder()
+			controller.DisableUser(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.User
+				assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+				assert.True(body.Disabled)
+			}
+		})
+	}
+}
+
+func TestAdminController_EnableUser(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService, _ := setupAdminController(t)
+
+	// Admins can enable their own account, only disabling it is refused
+	mockService.EXPECT().SetUserDisabled(gomock.Any(), "user123", false).Return(&models.User{ID: "user123"}, nil)
+
+	req := newAutomationRequest(http.MethodPost, "/api/admin/users/user123/enable", "")
+	req.SetPathValue("id", "user123")
+	w := httptest.NewRecorder()
+	controller.EnableUser(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+}
+
+func TestAdminController_ListSyncEvents(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService, _ := setupAdminController(t)
+
+	mockService.EXPECT().ListSyncEvents(gomock.Any(), repositories.SyncEventFilter{
+		Status:         models.SyncStatusFailed,
+		UserID:         "user456",
+		BasePlaylistID: "base789",
+		Limit:          25,
+	}).Return([]*models.SyncEvent{{ID: "sync123", Status: models.SyncStatusFailed}}, nil)
+
+	w := httptest.NewRecorder()
+	controller.ListSyncEvents(w, newAutomationRequest(http.MethodGet, "/api/admin/sync_events?status=failed&user_id=user456&base_playlist_id=base789&limit=25", ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var body []models.SyncEvent
+	assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+	assert.Len(body, 1)
+	assert.Equal("sync123", body[0].ID)
+}
+
+func TestAdminController_ListSyncEvents_Error(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService, _ := setupAdminController(t)
+
+	mockService.EXPECT().ListSyncEvents(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	w := httptest.NewRecorder()
+	controller.ListSyncEvents(w, newAutomationRequest(http.MethodGet, "/api/admin/sync_events", ""))
+
+	assert.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func TestAdminController_RetrySync(t *testing.T) {
+	failedRetry := &models.SyncEvent{ID: "sync_retry", Status: models.SyncStatusFailed}
+
+	tests := []struct {
+		name           string
+		syncEvent      *models.SyncEvent
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			syncEvent:      &models.SyncEvent{ID: "sync_retry", Status: models.SyncStatusCompleted},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "retry failed again",
+			syncEvent:      failedRetry,
+			err:            errors.New("spotify unavailable"),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "sync event not found",
+			err:            fmt.Errorf("%w: sync123", orchestrators.ErrSyncEventNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "sync still running",
+			err:            fmt.Errorf("%w for base playlist base456", orchestrators.ErrSyncInProgress),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "completed sync",
+			err:            fmt.Errorf("%w: sync123 is completed", orchestrators.ErrSyncNotRetryable),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "retry never started",
+			err:            errors.New("failed to load spotify credentials"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, _, mockOrchestrator := setupAdminController(t)
+
+			mockOrchestrator.EXPECT().RetrySync(gomock.Any(), "sync123").Return(tt.syncEvent, tt.err)
+
+			req := newAutomationRequest(http.MethodPost, "/api/admin/sync/sync123/retry", "")
+			req.SetPathValue("id", "sync123")
+			w := httptest.NewRecorder()
+			controller.RetrySync(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body models.SyncEvent
+				assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal(tt.syncEvent.Status, body.Status)
+			}
+		})
+	}
+}
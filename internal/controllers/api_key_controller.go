@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// APIKeyController manages the API keys users hand to automation platforms
+type APIKeyController struct {
+	apiKeyService services.APIKeyServicer
+	validator     *validator.Validate
+}
+
+func NewAPIKeyController(apiKeyService services.APIKeyServicer) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: apiKeyService,
+		validator:     newValidator(),
+	}
+}
+
+// Create issues a new API key. The raw key is only included in this response
+func (c *APIKeyController) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	apiKey, err := c.apiKeyService.CreateAPIKey(r.Context(), user.ID, &req)
+	if err != nil {
+		writeError(w, err, "unable to create api key")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(apiKey); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *APIKeyController) List(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	apiKeys, err := c.apiKeyService.ListAPIKeys(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve api keys")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(apiKeys); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *APIKeyController) Delete(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	apiKeyID := r.PathValue("id")
+	if apiKeyID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "api key ID is required")
+		return
+	}
+
+	err := c.apiKeyService.DeleteAPIKey(r.Context(), apiKeyID, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAPIKeyNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeAPIKeyNotFound, "api key not found")
+			return
+		}
+
+		writeError(w, err, "unable to delete api key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
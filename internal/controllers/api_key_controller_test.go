@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyController_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"name":"Zapier","scopes":["sync:read","sync:write"]}`,
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "unknown scope",
+			body:           `{"name":"Zapier","scopes":["admin"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "no scopes",
+			body:           `{"name":"Zapier","scopes":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "expiry in the past",
+			body:           `{"name":"CI","scopes":["sync:write"],"expires_at":"2020-01-01T00:00:00Z"}`,
+			serviceErr:     services.ErrInvalidAPIKeyExpiry,
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			body:           `{"name":"Zapier","scopes":["sync:read"]}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockAPIKeyServicer(gomock.NewController(t))
+			controller := NewAPIKeyController(mockService)
+
+			if tt.expectCall {
+				var created *models.CreatedAPIKey
+				if tt.serviceErr == nil {
+					created = &models.CreatedAPIKey{APIKey: &models.APIKey{ID: "key123", Name: "Zapier"}, Key: "prk_secret"}
+				}
+				mockService.EXPECT().CreateAPIKey(gomock.Any(), "user123", gomock.Any()).Return(created, tt.serviceErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.Create(w, newAutomationRequest(http.MethodPost, "/api/keys", tt.body))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var body map[string]any
+				assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal("key123", body["id"])
+				assert.Equal("prk_secret", body["key"])
+			}
+		})
+	}
+}
+
+func TestAPIKeyController_List(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockAPIKeyServicer(gomock.NewController(t))
+	controller := NewAPIKeyController(mockService)
+
+	apiKeys := []*models.APIKey{{ID: "key123", Name: "Zapier", KeyHash: "hash123"}}
+	mockService.EXPECT().ListAPIKeys(gomock.Any(), "user123").Return(apiKeys, nil)
+
+	w := httptest.NewRecorder()
+	controller.List(w, newAutomationRequest(http.MethodGet, "/api/keys", ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+	// The key hash is never exposed
+	assert.NotContains(w.Body.String(), "hash123")
+}
+
+func TestAPIKeyController_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusNoContent},
+		{name: "not found", serviceErr: fmt.Errorf("failed to delete api key: %w", repositories.ErrAPIKeyNotFound), expectedStatus: http.StatusNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to delete api key: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockAPIKeyServicer(gomock.NewController(t))
+			controller := NewAPIKeyController(mockService)
+
+			mockService.EXPECT().DeleteAPIKey(gomock.Any(), "key123", "user123").Return(tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodDelete, "/api/keys/key123", "")
+			req.SetPathValue("id", "key123")
+			w := httptest.NewRecorder()
+			controller.Delete(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/ngomez18/playlist-router/internal/config"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/services"
 )
 
@@ -28,18 +31,44 @@ func (c *AuthController) SpotifyLogin(w http.ResponseWriter, r *http.Request) {
 	// Generate random state for CSRF protection
 	state := generateState()
 
-	// Store state in session/cookie for validation (TODO: implement proper state storage)
+	// The auth service keeps the state with the PKCE code verifier of the authorization
+	authURL, err := c.authService.GenerateSpotifyAuthURL(state)
+	if err != nil {
+		writeError(w, err, "unable to start spotify login")
+		return
+	}
 
-	authURL := c.authService.GenerateSpotifyAuthURL(state)
 	http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
 }
 
+// SpotifyLink starts linking another spotify account to the authenticated user. The authorization
+// URL is returned instead of redirecting, since the browser can't send the auth token on a redirect
+func (c *AuthController) SpotifyLink(w http.ResponseWriter, r *http.Request) {
+	user, found := requestcontext.GetUserFromContext(r.Context())
+	if !found {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	authURL, err := c.authService.GenerateSpotifyLinkURL(user.ID)
+	if err != nil {
+		writeError(w, err, "unable to start spotify account link")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]string{"auth_url": authURL}); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+	}
+}
+
 func (c *AuthController) SpotifyCallback(w http.ResponseWriter, r *http.Request) {
 	code := r.URL.Query().Get("code")
 	state := r.URL.Query().Get("state")
 
 	if code == "" {
-		http.Error(w, "authorization code is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "authorization code is required")
 		return
 	}
 
@@ -47,8 +76,16 @@ func (c *AuthController) SpotifyCallback(w http.ResponseWriter, r *http.Request)
 
 	// Handle OAuth callback
 	result, err := c.authService.HandleSpotifyCallback(r.Context(), code, state)
+	if errors.Is(err, services.ErrSpotifyAccountLinked) {
+		problem.Write(w, http.StatusConflict, problem.CodeSpotifyAccountLinked, "spotify account is linked to another user")
+		return
+	}
+	if errors.Is(err, repositories.ErrUserDisabled) {
+		problem.Write(w, http.StatusForbidden, problem.CodeAccountDisabled, "account is disabled")
+		return
+	}
 	if err != nil {
-		http.Error(w, "authentication failed", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "authentication failed")
 		return
 	}
 
@@ -57,19 +94,37 @@ func (c *AuthController) SpotifyCallback(w http.ResponseWriter, r *http.Request)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 }
 
+// Logout revokes every auth token of the user server side, the one used by this request included,
+// and asks the browser to drop the cookies of the site
+func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
+	user, found := requestcontext.GetUserFromContext(r.Context())
+	if !found {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	if err := c.authService.Logout(r.Context(), user.ID); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to log out")
+		return
+	}
+
+	w.Header().Set("Clear-Site-Data", `"cookies"`)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (c *AuthController) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	// This endpoint is protected by auth middleware, so user is already validated
 	// and available in context. Just return the user.
 	user, found := requestcontext.GetUserFromContext(r.Context())
 	if !found {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(user); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
 	}
 }
 
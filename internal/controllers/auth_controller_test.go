@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -13,8 +14,10 @@ import (
 	"github.com/ngomez18/playlist-router/internal/config"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/services"
 	"github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,7 +57,7 @@ func TestAuthController_SpotifyLogin(t *testing.T) {
 			// Setup mock expectations - we can't predict the exact state, so use Any()
 			mockAuthService.EXPECT().
 				GenerateSpotifyAuthURL(gomock.Any()).
-				Return(tt.expectedAuthURL).
+				Return(tt.expectedAuthURL, nil).
 				Times(1)
 
 			// Create request
@@ -74,6 +77,26 @@ func TestAuthController_SpotifyLogin(t *testing.T) {
 	}
 }
 
+func TestAuthController_SpotifyLogin_Error(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	mockAuthService := mocks.NewMockAuthServicer(ctrl)
+	controller := NewAuthController(mockAuthService, createTestConfig())
+
+	mockAuthService.EXPECT().
+		GenerateSpotifyAuthURL(gomock.Any()).
+		Return("", errors.New("failed to generate pkce code verifier"))
+
+	req := httptest.NewRequest("GET", "/auth/spotify/login", nil)
+	w := httptest.NewRecorder()
+
+	controller.SpotifyLogin(w, req)
+
+	assert.Equal(http.StatusInternalServerError, w.Code)
+	assert.Empty(w.Header().Get("Location"))
+}
+
 func TestAuthController_SpotifyCallback(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -126,6 +149,26 @@ func TestAuthController_SpotifyCallback(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectRedirect:     false,
 		},
+		{
+			name: "account linked to another user",
+			queryParams: map[string]string{
+				"code":  "auth_code_123",
+				"state": "link_state_123",
+			},
+			mockError:          services.ErrSpotifyAccountLinked,
+			expectedStatusCode: http.StatusConflict,
+			expectRedirect:     false,
+		},
+		{
+			name: "disabled user",
+			queryParams: map[string]string{
+				"code":  "auth_code_123",
+				"state": "state_123",
+			},
+			mockError:          fmt.Errorf("failed to generate auth token: %w", repositories.ErrUserDisabled),
+			expectedStatusCode: http.StatusForbidden,
+			expectRedirect:     false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -305,6 +348,112 @@ func TestAuthController_SpotifyCallback_ContextPropagation(t *testing.T) {
 	assert.Equal(expectedURL, w.Header().Get("Location"))
 }
 
+func TestAuthController_SpotifyLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *models.User
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "returns the authorization url",
+			user:           testfixtures.NewUser().Build(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"auth_url":"https://accounts.spotify.com/authorize?state=link_state"}`,
+		},
+		{
+			name:           "user not found in context",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:           "service error",
+			user:           testfixtures.NewUser().Build(),
+			serviceErr:     errors.New("no randomness"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "unable to start spotify account link",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthService := mocks.NewMockAuthServicer(ctrl)
+			controller := NewAuthController(mockAuthService, createTestConfig())
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/spotify/link", nil)
+			if tt.user != nil {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), tt.user))
+				mockAuthService.EXPECT().
+					GenerateSpotifyLinkURL(tt.user.ID).
+					Return("https://accounts.spotify.com/authorize?state=link_state", tt.serviceErr)
+			}
+			w := httptest.NewRecorder()
+
+			controller.SpotifyLink(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthController_Logout(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *models.User
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "revokes the session",
+			user:           testfixtures.NewUser().Build(),
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "user not found in context",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "service error",
+			user:           testfixtures.NewUser().Build(),
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuthService := mocks.NewMockAuthServicer(ctrl)
+			controller := NewAuthController(mockAuthService, createTestConfig())
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+			if tt.user != nil {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), tt.user))
+				mockAuthService.EXPECT().Logout(gomock.Any(), tt.user.ID).Return(tt.serviceErr)
+			}
+			w := httptest.NewRecorder()
+
+			controller.Logout(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Equal(`"cookies"`, w.Header().Get("Clear-Site-Data"))
+			}
+		})
+	}
+}
+
 func TestAuthController_ValidateToken_Success(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -312,21 +461,13 @@ func TestAuthController_ValidateToken_Success(t *testing.T) {
 		expectedStatus int
 	}{
 		{
-			name: "successful token validation with complete user",
-			user: &models.User{
-				ID:    "user123",
-				Email: "test@example.com",
-				Name:  "Test User",
-			},
+			name:           "successful token validation with complete user",
+			user:           testfixtures.NewUser().WithID("user123").WithEmail("test@example.com").WithName("Test User").Build(),
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "successful token validation with minimal user",
-			user: &models.User{
-				ID:    "user456",
-				Email: "minimal@example.com",
-				Name:  "Minimal User",
-			},
+			name:           "successful token validation with minimal user",
+			user:           testfixtures.NewUser().WithID("user456").WithEmail("minimal@example.com").WithName("Minimal User").Build(),
 			expectedStatus: http.StatusOK,
 		},
 	}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// AutomationController serves the trigger and action endpoints of automation platforms such as
+// Zapier or IFTTT. Requests are authorized by an API key, whose owner is the user in context
+type AutomationController struct {
+	automationService    services.AutomationServicer
+	basePlaylistService  services.BasePlaylistServicer
+	childPlaylistService services.ChildPlaylistServicer
+	syncOrchestrator     orchestrators.SyncOrchestrator
+	validator            *validator.Validate
+}
+
+func NewAutomationController(
+	automationService services.AutomationServicer,
+	basePlaylistService services.BasePlaylistServicer,
+	childPlaylistService services.ChildPlaylistServicer,
+	syncOrchestrator orchestrators.SyncOrchestrator,
+) *AutomationController {
+	return &AutomationController{
+		automationService:    automationService,
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		syncOrchestrator:     syncOrchestrator,
+		validator:            newValidator(),
+	}
+}
+
+// Me identifies the owner of the API key, used by automation platforms to test the connection
+func (c *AutomationController) Me(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	writeAutomationResponse(w, http.StatusOK, user)
+}
+
+func (c *AutomationController) SyncCompleted(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	triggers, err := c.automationService.GetSyncCompletedTriggers(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve completed syncs")
+		return
+	}
+
+	writeAutomationResponse(w, http.StatusOK, triggers)
+}
+
+func (c *AutomationController) TracksRouted(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	triggers, err := c.automationService.GetTracksRoutedTriggers(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve routed tracks")
+		return
+	}
+
+	writeAutomationResponse(w, http.StatusOK, triggers)
+}
+
+// TriggerSync syncs a base playlist of the API key owner
+func (c *AutomationController) TriggerSync(w http.ResponseWriter, r *http.Request) {
+	var req models.TriggerSyncActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	if _, err := c.basePlaylistService.GetBasePlaylist(r.Context(), req.BasePlaylistID, user.ID); err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to retrieve base playlist")
+		return
+	}
+
+	syncEvent, err := c.syncOrchestrator.SyncBasePlaylist(r.Context(), user.ID, req.BasePlaylistID)
+	switch {
+	case errors.Is(err, orchestrators.ErrSyncAnomalyDetected) && syncEvent != nil:
+		// The held back sync event lists the anomalies the user has to confirm in the app
+		writeAutomationResponse(w, http.StatusConflict, syncEvent)
+		return
+	case err != nil:
+		writeError(w, err, "failed to sync base playlist")
+		return
+	}
+
+	writeAutomationResponse(w, http.StatusOK, syncEvent)
+}
+
+// AddExclusion excludes a genre or keyword from the filter rules of a child playlist
+func (c *AutomationController) AddExclusion(w http.ResponseWriter, r *http.Request) {
+	var req models.AddExclusionActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	childPlaylist, err := c.childPlaylistService.AddExclusion(r.Context(), req.ChildPlaylistID, user.ID, req.Field, req.Value)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeChildPlaylistNotFound, "child playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to add exclusion")
+		return
+	}
+
+	writeAutomationResponse(w, http.StatusOK, childPlaylist)
+}
+
+func writeAutomationResponse(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+	}
+}
@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	orchestratormocks "github.com/ngomez18/playlist-router/internal/orchestrators/mocks"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+type automationControllerMocks struct {
+	automationService    *servicemocks.MockAutomationServicer
+	basePlaylistService  *servicemocks.MockBasePlaylistServicer
+	childPlaylistService *servicemocks.MockChildPlaylistServicer
+	syncOrchestrator     *orchestratormocks.MockSyncOrchestrator
+}
+
+func setupAutomationController(t *testing.T) (*AutomationController, automationControllerMocks) {
+	ctrl := gomock.NewController(t)
+
+	mocks := automationControllerMocks{
+		automationService:    servicemocks.NewMockAutomationServicer(ctrl),
+		basePlaylistService:  servicemocks.NewMockBasePlaylistServicer(ctrl),
+		childPlaylistService: servicemocks.NewMockChildPlaylistServicer(ctrl),
+		syncOrchestrator:     orchestratormocks.NewMockSyncOrchestrator(ctrl),
+	}
+
+	controller := NewAutomationController(mocks.automationService, mocks.basePlaylistService, mocks.childPlaylistService, mocks.syncOrchestrator)
+	return controller, mocks
+}
+
+func newAutomationRequest(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	user := testfixtures.NewUser().WithID("user123").WithEmail("test@example.com").Build()
+	return req.WithContext(requestcontext.ContextWithUser(req.Context(), user))
+}
+
+func TestAutomationController_Me(t *testing.T) {
+	assert := require.New(t)
+	controller, _ := setupAutomationController(t)
+
+	w := httptest.NewRecorder()
+	controller.Me(w, newAutomationRequest(http.MethodGet, "/zapier/me", ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var user models.User
+	assert.NoError(json.NewDecoder(w.Body).Decode(&user))
+	assert.Equal("user123", user.ID)
+}
+
+func TestAutomationController_SyncCompleted(t *testing.T) {
+	tests := []struct {
+		name           string
+		triggers       []*models.SyncCompletedTrigger
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			triggers:       []*models.SyncCompletedTrigger{{ID: "sync2"}, {ID: "sync1"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mocks := setupAutomationController(t)
+
+			mocks.automationService.EXPECT().GetSyncCompletedTriggers(gomock.Any(), "user123").Return(tt.triggers, tt.serviceErr)
+
+			w := httptest.NewRecorder()
+			controller.SyncCompleted(w, newAutomationRequest(http.MethodGet, "/zapier/triggers/sync_completed", ""))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.serviceErr == nil {
+				var triggers []*models.SyncCompletedTrigger
+				assert.NoError(json.NewDecoder(w.Body).Decode(&triggers))
+				assert.Equal(tt.triggers, triggers)
+			}
+		})
+	}
+}
+
+func TestAutomationController_TracksRouted(t *testing.T) {
+	assert := require.New(t)
+	controller, mocks := setupAutomationController(t)
+
+	triggers := []*models.TracksRoutedTrigger{{ID: "sync1:child1", TracksAdded: 3}}
+	mocks.automationService.EXPECT().GetTracksRoutedTriggers(gomock.Any(), "user123").Return(triggers, nil)
+
+	w := httptest.NewRecorder()
+	controller.TracksRouted(w, newAutomationRequest(http.MethodGet, "/zapier/triggers/tracks_routed", ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var result []*models.TracksRoutedTrigger
+	assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal(triggers, result)
+}
+
+func TestAutomationController_TriggerSync(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		lookupErr      error
+		syncEvent      *models.SyncEvent
+		syncErr        error
+		expectLookup   bool
+		expectSync     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"base_playlist_id":"base123"}`,
+			syncEvent:      testfixtures.NewSyncEvent().WithID("sync123").WithStatus(models.SyncStatusCompleted).Build(),
+			expectLookup:   true,
+			expectSync:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing base playlist id",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "base playlist of another user",
+			body:           `{"base_playlist_id":"base123"}`,
+			lookupErr:      fmt.Errorf("failed to retrieve playlist: %w", repositories.ErrUnauthorized),
+			expectLookup:   true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "anomaly detected",
+			body:           `{"base_playlist_id":"base123"}`,
+			syncEvent:      testfixtures.NewSyncEvent().WithID("sync123").WithStatus(models.SyncStatusNeedsConfirmation).Build(),
+			syncErr:        orchestrators.ErrSyncAnomalyDetected,
+			expectLookup:   true,
+			expectSync:     true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "sync in progress",
+			body:           `{"base_playlist_id":"base123"}`,
+			syncErr:        fmt.Errorf("%w for base playlist base123", orchestrators.ErrSyncInProgress),
+			expectLookup:   true,
+			expectSync:     true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "sync failure",
+			body:           `{"base_playlist_id":"base123"}`,
+			syncErr:        errors.New("spotify down"),
+			expectLookup:   true,
+			expectSync:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mocks := setupAutomationController(t)
+
+			if tt.expectLookup {
+				mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base123", "user123").
+					Return(testfixtures.NewBasePlaylist().WithID("base123").Build(), tt.lookupErr)
+			}
+			if tt.expectSync {
+				mocks.syncOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), "user123", "base123").Return(tt.syncEvent, tt.syncErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.TriggerSync(w, newAutomationRequest(http.MethodPost, "/zapier/actions/sync", tt.body))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAutomationController_AddExclusion(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"child_playlist_id":"child123","field":"genres","value":"metal"}`,
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unsupported field",
+			body:           `{"child_playlist_id":"child123","field":"popularity","value":"10"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing value",
+			body:           `{"child_playlist_id":"child123","field":"genres"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "child playlist not found",
+			body:           `{"child_playlist_id":"child123","field":"genres","value":"metal"}`,
+			serviceErr:     fmt.Errorf("failed to get child playlist: %w", repositories.ErrChildPlaylistNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			body:           `{"child_playlist_id":"child123","field":"genres","value":"metal"}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mocks := setupAutomationController(t)
+
+			if tt.expectCall {
+				var childPlaylist *models.ChildPlaylist
+				if tt.serviceErr == nil {
+					childPlaylist = testfixtures.NewChildPlaylist().WithID("child123").Build()
+				}
+				mocks.childPlaylistService.EXPECT().
+					AddExclusion(gomock.Any(), "child123", "user123", models.ExclusionFieldGenres, "metal").
+					Return(childPlaylist, tt.serviceErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.AddExclusion(w, newAutomationRequest(http.MethodPost, "/zapier/actions/exclusion", tt.body))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
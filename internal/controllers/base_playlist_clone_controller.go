@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// BasePlaylistCloneController copies base playlists along with their child playlists
+type BasePlaylistCloneController struct {
+	cloneService services.BasePlaylistCloneServicer
+	validator    *validator.Validate
+}
+
+func NewBasePlaylistCloneController(cloneService services.BasePlaylistCloneServicer) *BasePlaylistCloneController {
+	return &BasePlaylistCloneController{
+		cloneService: cloneService,
+		validator:    newValidator(),
+	}
+}
+
+// Clone copies the base playlist of the path and its child playlists onto the spotify playlist in the request
+func (c *BasePlaylistCloneController) Clone(w http.ResponseWriter, r *http.Request) {
+	var req models.CloneBasePlaylistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylistID := r.PathValue("id")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
+		return
+	}
+
+	clone, err := c.cloneService.CloneBasePlaylist(r.Context(), user.ID, basePlaylistID, &req)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to clone base playlist")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(clone); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasePlaylistCloneController_Clone(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{
+			name:           "success",
+			body:           `{"spotify_playlist_id":"spotify456","name":"Liked Songs (copy)"}`,
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing spotify playlist",
+			body:           `{"name":"Liked Songs (copy)"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "invalid payload",
+			body:           `{`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidPayload,
+		},
+		{
+			name:           "same spotify playlist",
+			body:           `{"spotify_playlist_id":"spotify123"}`,
+			serviceErr:     fmt.Errorf("%w: spotify123", services.ErrCloneSameSpotifyPlaylist),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeSameSpotifyPlaylist,
+		},
+		{
+			name:           "not found",
+			body:           `{"spotify_playlist_id":"spotify456"}`,
+			serviceErr:     fmt.Errorf("failed to retrieve base playlist: %w", repositories.ErrBasePlaylistNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   problem.CodeBasePlaylistNotFound,
+		},
+		{
+			name:           "other user",
+			body:           `{"spotify_playlist_id":"spotify456"}`,
+			serviceErr:     fmt.Errorf("failed to retrieve base playlist: %w", repositories.ErrUnauthorized),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   problem.CodeBasePlaylistNotFound,
+		},
+		{
+			name:           "service error",
+			body:           `{"spotify_playlist_id":"spotify456"}`,
+			serviceErr:     errors.New("spotify error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   problem.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockBasePlaylistCloneServicer(gomock.NewController(t))
+			controller := NewBasePlaylistCloneController(mockService)
+
+			if tt.expectCall {
+				var clone *models.BasePlaylistWithChilds
+				if tt.serviceErr == nil {
+					clone = &models.BasePlaylistWithChilds{
+						BasePlaylist: testfixtures.NewBasePlaylist().WithID("clone123").WithName("Liked Songs (copy)").Build(),
+						Childs:       []*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithID("child1").Build()},
+					}
+				}
+				mockService.EXPECT().CloneBasePlaylist(gomock.Any(), "user123", "base123", gomock.Any()).Return(clone, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/base_playlist/base123/clone", tt.body)
+			req.SetPathValue("id", "base123")
+			w := httptest.NewRecorder()
+			controller.Clone(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var clone models.BasePlaylistWithChilds
+				assert.NoError(json.NewDecoder(w.Body).Decode(&clone))
+				assert.Equal("clone123", clone.ID)
+				assert.Len(clone.Childs, 1)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/services"
 )
 
@@ -18,32 +22,32 @@ type BasePlaylistController struct {
 func NewBasePlaylistController(bpService services.BasePlaylistServicer) *BasePlaylistController {
 	return &BasePlaylistController{
 		basePlaylistService: bpService,
-		validator:           validator.New(),
+		validator:           newValidator(),
 	}
 }
 
 func (c *BasePlaylistController) Create(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateBasePlaylistRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid payload", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
 		return
 	}
 
 	if err := c.validator.Struct(&req); err != nil {
-		http.Error(w, "validation failed: "+err.Error(), http.StatusBadRequest)
+		writeValidationError(w, err)
 		return
 	}
 
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	newBasePlaylist, err := c.basePlaylistService.CreateBasePlaylist(r.Context(), user.ID, &req)
 	if err != nil {
-		http.Error(w, "unable to create base playlist", http.StatusInternalServerError)
+		writeError(w, err, "unable to create base playlist")
 		return
 	}
 
@@ -51,7 +55,7 @@ func (c *BasePlaylistController) Create(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusCreated)
 	err = json.NewEncoder(w).Encode(newBasePlaylist)
 	if err != nil {
-		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
 	}
 }
 
@@ -60,45 +64,72 @@ func (c *BasePlaylistController) Delete(w http.ResponseWriter, r *http.Request)
 	basePlaylistId := r.PathValue("id")
 
 	if basePlaylistId == "" {
-		http.Error(w, "playlist id is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
 		return
 	}
 
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	err := c.basePlaylistService.DeleteBasePlaylist(r.Context(), basePlaylistId, user.ID)
 	if err != nil {
-		http.Error(w, "unable to delete base playlist", http.StatusInternalServerError)
+		writeError(w, err, "unable to delete base playlist")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
+// Restore brings back a deleted base playlist along with the child playlists deleted with it
+func (c *BasePlaylistController) Restore(w http.ResponseWriter, r *http.Request) {
+	basePlaylistId := r.PathValue("id")
+	if basePlaylistId == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylist, err := c.basePlaylistService.RestoreBasePlaylist(r.Context(), basePlaylistId, user.ID)
+	if err != nil {
+		writeError(w, err, "unable to restore base playlist")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(basePlaylist); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
 func (c *BasePlaylistController) GetByID(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from URL path
 	basePlaylistId := r.PathValue("id")
 
 	if basePlaylistId == "" {
-		http.Error(w, "playlist id is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
 		return
 	}
 
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	basePlaylist, err := c.basePlaylistService.GetBasePlaylist(r.Context(), basePlaylistId, user.ID)
 	if err != nil {
-		http.Error(w, "unable to retrieve base playlist", http.StatusInternalServerError)
+		writeError(w, err, "unable to retrieve base playlist")
 		return
 	}
 
@@ -106,7 +137,45 @@ func (c *BasePlaylistController) GetByID(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(basePlaylist)
 	if err != nil {
-		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+func (c *BasePlaylistController) Update(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateBasePlaylistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	// Extract ID from URL path
+	basePlaylistId := r.PathValue("id")
+	if basePlaylistId == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
+		return
+	}
+
+	updatedBasePlaylist, err := c.basePlaylistService.UpdateBasePlaylist(r.Context(), basePlaylistId, user.ID, &req)
+	if err != nil {
+		writeError(w, err, "unable to update base playlist")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(updatedBasePlaylist); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
 	}
 }
 
@@ -114,13 +183,13 @@ func (c *BasePlaylistController) GetByUserID(w http.ResponseWriter, r *http.Requ
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	basePlaylists, err := c.basePlaylistService.GetBasePlaylistsByUserID(r.Context(), user.ID)
 	if err != nil {
-		http.Error(w, "unable to retrieve base playlists", http.StatusInternalServerError)
+		writeError(w, err, "unable to retrieve base playlists")
 		return
 	}
 
@@ -128,27 +197,110 @@ func (c *BasePlaylistController) GetByUserID(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(basePlaylists)
 	if err != nil {
-		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
 	}
 }
 
+// GetByUserIDWithChilds lists a page of the base playlists of the user with their child playlists,
+// optionally filtered by the is_active and archived query parameters and sorted by created or name
 func (c *BasePlaylistController) GetByUserIDWithChilds(w http.ResponseWriter, r *http.Request) {
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+	sort, ok := parseSort(w, r, models.ListSortCreatedAsc, models.ListSortCreatedDesc, models.ListSortNameAsc, models.ListSortNameDesc)
+	if !ok {
+		return
+	}
+	isActive, ok := parseBoolParam(w, r, "is_active")
+	if !ok {
+		return
+	}
+	archived, ok := parseBoolParam(w, r, "archived")
+	if !ok {
 		return
 	}
 
-	basePlaylistsWithChilds, err := c.basePlaylistService.GetBasePlaylistsByUserIDWithChilds(r.Context(), user.ID)
+	basePlaylistsWithChilds, err := c.basePlaylistService.ListBasePlaylistsWithChilds(r.Context(), user.ID, repositories.BasePlaylistFilter{
+		IsActive: isActive,
+		Archived: archived,
+		Sort:     sort,
+		Limit:    limit,
+		Offset:   offset,
+	})
 	if err != nil {
-		http.Error(w, "unable to retrieve base playlists with childs", http.StatusInternalServerError)
+		writeError(w, err, "unable to retrieve base playlists with childs")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(basePlaylistsWithChilds); err != nil {
-		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+// EnableHooks issues a hook token for the automation hooks of the base playlist, rotating any previous one
+func (c *BasePlaylistController) EnableHooks(w http.ResponseWriter, r *http.Request) {
+	c.updateWith(w, r, c.basePlaylistService.EnableHooks, "unable to update base playlist hooks")
+}
+
+// DisableHooks revokes the hook token of the base playlist
+func (c *BasePlaylistController) DisableHooks(w http.ResponseWriter, r *http.Request) {
+	c.updateWith(w, r, c.basePlaylistService.DisableHooks, "unable to update base playlist hooks")
+}
+
+// Archive leaves the base playlist out of bulk and automated syncs until it is unarchived
+func (c *BasePlaylistController) Archive(w http.ResponseWriter, r *http.Request) {
+	c.updateWith(w, r, c.basePlaylistService.ArchiveBasePlaylist, "unable to archive base playlist")
+}
+
+// Unarchive lets the base playlist sync again
+func (c *BasePlaylistController) Unarchive(w http.ResponseWriter, r *http.Request) {
+	c.updateWith(w, r, c.basePlaylistService.UnarchiveBasePlaylist, "unable to unarchive base playlist")
+}
+
+// updateWith applies update to the base playlist of the path and responds with the result
+func (c *BasePlaylistController) updateWith(
+	w http.ResponseWriter,
+	r *http.Request,
+	update func(ctx context.Context, id, userId string) (*models.BasePlaylist, error),
+	errorDetail string,
+) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	// Extract ID from URL path
+	basePlaylistId := r.PathValue("id")
+	if basePlaylistId == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
+		return
+	}
+
+	basePlaylist, err := update(r.Context(), basePlaylistId, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, errorDetail)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(basePlaylist); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
 	}
 }
@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
 	"github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,13 +47,12 @@ func TestBasePlaylistController_Create_Success(t *testing.T) {
 				Name:              "My Test Playlist",
 				SpotifyPlaylistID: "spotify123",
 			},
-			serviceResult: &models.BasePlaylist{
-				ID:                "playlist123",
-				UserID:            "user123",
-				Name:              "My Test Playlist",
-				SpotifyPlaylistID: "spotify123",
-				IsActive:          true,
-			},
+			serviceResult: testfixtures.NewBasePlaylist().
+				WithID("playlist123").
+				WithUserID("user123").
+				WithName("My Test Playlist").
+				WithSpotifyPlaylistID("spotify123").
+				Build(),
 			expectedStatus: http.StatusCreated,
 		},
 		{
@@ -57,13 +61,12 @@ func TestBasePlaylistController_Create_Success(t *testing.T) {
 				Name:              "A",
 				SpotifyPlaylistID: "spotify456",
 			},
-			serviceResult: &models.BasePlaylist{
-				ID:                "playlist456",
-				UserID:            "user456",
-				Name:              "A",
-				SpotifyPlaylistID: "spotify456",
-				IsActive:          true,
-			},
+			serviceResult: testfixtures.NewBasePlaylist().
+				WithID("playlist456").
+				WithUserID("user456").
+				WithName("A").
+				WithSpotifyPlaylistID("spotify456").
+				Build(),
 			expectedStatus: http.StatusCreated,
 		},
 	}
@@ -150,6 +153,13 @@ func TestBasePlaylistController_Create_Errors(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedError:      "unable to create base playlist",
 		},
+		{
+			name:               "spotify account not linked",
+			requestBody:        models.CreateBasePlaylistRequest{Name: "Test", SpotifyIntegrationID: "integration_other"},
+			serviceError:       fmt.Errorf("failed to load spotify account: %w", services.ErrSpotifyIntegrationUnavailable),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "spotify account not linked",
+		},
 	}
 
 	for _, tt := range tests {
@@ -307,6 +317,67 @@ func TestBasePlaylistController_Delete_Errors(t *testing.T) {
 	}
 }
 
+func TestBasePlaylistController_Restore(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "restores the playlist",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id":"playlist123"`,
+		},
+		{
+			name:           "another playlist links the same spotify playlist",
+			serviceErr:     fmt.Errorf("failed to restore playlist: %w", repositories.ErrRestoreConflict),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"code":"restore_conflict"`,
+		},
+		{
+			name:           "playlist not found",
+			serviceErr:     repositories.ErrBasePlaylistNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "base playlist not found",
+		},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "unable to restore base playlist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockBasePlaylistServicer(ctrl)
+			controller := NewBasePlaylistController(mockService)
+
+			var result *models.BasePlaylist
+			if tt.serviceErr == nil {
+				result = testfixtures.NewBasePlaylist().WithID("playlist123").Build()
+			}
+			mockService.EXPECT().RestoreBasePlaylist(gomock.Any(), "playlist123", "test_user_123").Return(result, tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/base_playlist/playlist123/restore", nil)
+			req.SetPathValue("id", "playlist123")
+			req = addUserToContext(req)
+
+			w := httptest.NewRecorder()
+			controller.Restore(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
 func TestBasePlaylistController_GetByID_Success(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -319,26 +390,25 @@ func TestBasePlaylistController_GetByID_Success(t *testing.T) {
 			name:       "successful retrieval with valid id",
 			playlistID: "playlist123",
 			urlPath:    "/api/base_playlist/playlist123",
-			serviceResult: &models.BasePlaylist{
-				ID:                "playlist123",
-				UserID:            "user123",
-				Name:              "My Test Playlist",
-				SpotifyPlaylistID: "spotify123",
-				IsActive:          true,
-			},
+			serviceResult: testfixtures.NewBasePlaylist().
+				WithID("playlist123").
+				WithUserID("user123").
+				WithName("My Test Playlist").
+				WithSpotifyPlaylistID("spotify123").
+				Build(),
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:       "successful retrieval with complex id",
 			playlistID: "pl_abc123def456",
 			urlPath:    "/api/base_playlist/pl_abc123def456",
-			serviceResult: &models.BasePlaylist{
-				ID:                "pl_abc123def456",
-				UserID:            "user456",
-				Name:              "Another Playlist",
-				SpotifyPlaylistID: "spotify456",
-				IsActive:          false,
-			},
+			serviceResult: testfixtures.NewBasePlaylist().
+				WithID("pl_abc123def456").
+				WithUserID("user456").
+				WithName("Another Playlist").
+				WithSpotifyPlaylistID("spotify456").
+				Inactive().
+				Build(),
 			expectedStatus: http.StatusOK,
 		},
 	}
@@ -458,33 +528,31 @@ func TestBasePlaylistController_GetByUserID_Success(t *testing.T) {
 		{
 			name: "successful retrieval with multiple playlists",
 			serviceResult: []*models.BasePlaylist{
-				{
-					ID:                "playlist123",
-					UserID:            "user123",
-					Name:              "My Test Playlist",
-					SpotifyPlaylistID: "spotify123",
-					IsActive:          true,
-				},
-				{
-					ID:                "playlist456",
-					UserID:            "user123",
-					Name:              "Another Playlist",
-					SpotifyPlaylistID: "spotify456",
-					IsActive:          false,
-				},
+				testfixtures.NewBasePlaylist().
+					WithID("playlist123").
+					WithUserID("user123").
+					WithName("My Test Playlist").
+					WithSpotifyPlaylistID("spotify123").
+					Build(),
+				testfixtures.NewBasePlaylist().
+					WithID("playlist456").
+					WithUserID("user123").
+					WithName("Another Playlist").
+					WithSpotifyPlaylistID("spotify456").
+					Inactive().
+					Build(),
 			},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name: "successful retrieval with single playlist",
 			serviceResult: []*models.BasePlaylist{
-				{
-					ID:                "playlist123",
-					UserID:            "user123",
-					Name:              "My Only Playlist",
-					SpotifyPlaylistID: "spotify123",
-					IsActive:          true,
-				},
+				testfixtures.NewBasePlaylist().
+					WithID("playlist123").
+					WithUserID("user123").
+					WithName("My Only Playlist").
+					WithSpotifyPlaylistID("spotify123").
+					Build(),
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -605,13 +673,12 @@ func TestBasePlaylistController_GetByUserID_ResponseEncodingError(t *testing.T)
 	controller := NewBasePlaylistController(mockService)
 
 	serviceResult := []*models.BasePlaylist{
-		{
-			ID:                "playlist123",
-			UserID:            "user123",
-			Name:              "Test Playlist",
-			SpotifyPlaylistID: "spotify123",
-			IsActive:          true,
-		},
+		testfixtures.NewBasePlaylist().
+			WithID("playlist123").
+			WithUserID("user123").
+			WithName("Test Playlist").
+			WithSpotifyPlaylistID("spotify123").
+			Build(),
 	}
 
 	// Prepare request
@@ -643,16 +710,16 @@ func TestBasePlaylistController_GetByUserIDWithChilds_Success(t *testing.T) {
 			name: "successful retrieval with multiple playlists and childs",
 			serviceResult: []*models.BasePlaylistWithChilds{
 				{
-					BasePlaylist: &models.BasePlaylist{ID: "playlist123"},
+					BasePlaylist: testfixtures.NewBasePlaylist().WithID("playlist123").Build(),
 					Childs: []*models.ChildPlaylist{
-						{ ID: "child123" },
-						{ ID: "child456" },
+						testfixtures.NewChildPlaylist().WithID("child123").Build(),
+						testfixtures.NewChildPlaylist().WithID("child456").Build(),
 					},
 				},
 				{
-					BasePlaylist: &models.BasePlaylist{ID: "playlist456"},
+					BasePlaylist: testfixtures.NewBasePlaylist().WithID("playlist456").Build(),
 					Childs: []*models.ChildPlaylist{
-						{ ID: "child789" },
+						testfixtures.NewChildPlaylist().WithID("child789").Build(),
 					},
 				},
 			},
@@ -662,8 +729,8 @@ func TestBasePlaylistController_GetByUserIDWithChilds_Success(t *testing.T) {
 			name: "successful retrieval with single playlist and childs",
 			serviceResult: []*models.BasePlaylistWithChilds{
 				{
-					BasePlaylist: &models.BasePlaylist{ID: "playlist123"},
-					Childs: []*models.ChildPlaylist{{ ID: "child123" }},
+					BasePlaylist: testfixtures.NewBasePlaylist().WithID("playlist123").Build(),
+					Childs:       []*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithID("child123").Build()},
 				},
 			},
 			expectedStatus: http.StatusOK,
@@ -687,14 +754,17 @@ func TestBasePlaylistController_GetByUserIDWithChilds_Success(t *testing.T) {
 			controller := NewBasePlaylistController(mockService)
 
 			// Prepare request
-			req := httptest.NewRequest(http.MethodGet, "/api/base_playlist/with_childs", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/base_playlist", nil)
 			req = addUserToContext(req)
 			w := httptest.NewRecorder()
 
 			// Set expectations
 			mockService.EXPECT().
-				GetBasePlaylistsByUserIDWithChilds(gomock.Any(), "test_user_123").
-				Return(tt.serviceResult, nil).
+				ListBasePlaylistsWithChilds(gomock.Any(), "test_user_123", repositories.BasePlaylistFilter{}).
+				Return(&models.BasePlaylistPage{
+					Items:    tt.serviceResult,
+					PageInfo: models.PageInfo{Total: len(tt.serviceResult), Limit: 50},
+				}, nil).
 				Times(1)
 
 			// Execute
@@ -705,30 +775,63 @@ func TestBasePlaylistController_GetByUserIDWithChilds_Success(t *testing.T) {
 			assert.Equal("application/json", w.Header().Get("Content-Type"))
 
 			// Verify response body
-			var responseBody []*models.BasePlaylistWithChilds
+			var responseBody models.BasePlaylistPage
 			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
 			assert.NoError(err)
-			assert.Equal(len(tt.serviceResult), len(responseBody))
+			assert.Equal(len(tt.serviceResult), responseBody.Total)
+			assert.Equal(50, responseBody.Limit)
+			assert.Equal(len(tt.serviceResult), len(responseBody.Items))
 
 			for i, expectedPlaylist := range tt.serviceResult {
-				assert.Equal(expectedPlaylist.ID, responseBody[i].ID)
-				assert.Equal(len(expectedPlaylist.Childs), len(responseBody[i].Childs))
+				assert.Equal(expectedPlaylist.ID, responseBody.Items[i].ID)
+				assert.Equal(len(expectedPlaylist.Childs), len(responseBody.Items[i].Childs))
 
 				for j, expectedChild := range expectedPlaylist.Childs {
-					assert.Equal(expectedChild.ID, responseBody[i].Childs[j].ID)
+					assert.Equal(expectedChild.ID, responseBody.Items[i].Childs[j].ID)
 				}
 			}
 		})
 	}
 }
 
+func TestBasePlaylistController_GetByUserIDWithChilds_QueryParameters(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockBasePlaylistServicer(ctrl)
+	controller := NewBasePlaylistController(mockService)
+
+	isActive := false
+	archived := true
+	mockService.EXPECT().
+		ListBasePlaylistsWithChilds(gomock.Any(), "test_user_123", repositories.BasePlaylistFilter{
+			IsActive: &isActive,
+			Archived: &archived,
+			Sort:     models.ListSortNameAsc,
+			Limit:    10,
+			Offset:   20,
+		}).
+		Return(&models.BasePlaylistPage{Items: []*models.BasePlaylistWithChilds{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/base_playlist?limit=10&offset=20&sort=name&is_active=false&archived=true", nil)
+	req = addUserToContext(req)
+	w := httptest.NewRecorder()
+
+	controller.GetByUserIDWithChilds(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+}
+
 func TestBasePlaylistController_GetByUserIDWithChilds_Errors(t *testing.T) {
 	tests := []struct {
 		name               string
-		serviceError        error
-		noUserInContext     bool
-		expectedStatusCode  int
-		expectedError       string
+		query              string
+		serviceError       error
+		noUserInContext    bool
+		expectedStatusCode int
+		expectedError      string
 	}{
 		{
 			name:               "service error",
@@ -742,6 +845,24 @@ func TestBasePlaylistController_GetByUserIDWithChilds_Errors(t *testing.T) {
 			expectedStatusCode: http.StatusUnauthorized,
 			expectedError:      "user not found in context",
 		},
+		{
+			name:               "invalid limit",
+			query:              "?limit=ten",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid limit",
+		},
+		{
+			name:               "unsupported sort",
+			query:              "?sort=spotify_playlist_id",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid sort",
+		},
+		{
+			name:               "invalid is_active",
+			query:              "?is_active=maybe",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid is_active",
+		},
 	}
 
 	for _, tt := range tests {
@@ -756,12 +877,12 @@ func TestBasePlaylistController_GetByUserIDWithChilds_Errors(t *testing.T) {
 
 			if tt.serviceError != nil {
 				mockService.EXPECT().
-					GetBasePlaylistsByUserIDWithChilds(gomock.Any(), "test_user_123").
+					ListBasePlaylistsWithChilds(gomock.Any(), "test_user_123", gomock.Any()).
 					Return(nil, tt.serviceError).
 					Times(1)
 			}
 
-			req := httptest.NewRequest(http.MethodGet, "/api/base_playlist/with_childs", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/base_playlist"+tt.query, nil)
 			if !tt.noUserInContext {
 				req = addUserToContext(req)
 			}
@@ -777,7 +898,306 @@ func TestBasePlaylistController_GetByUserIDWithChilds_Errors(t *testing.T) {
 
 // Helper function to add user to request context
 func addUserToContext(req *http.Request) *http.Request {
-	user := &models.User{ID: "test_user_123", Email: "test@example.com", Name: "Test User"}
+	user := testfixtures.NewUser().WithID("test_user_123").WithEmail("test@example.com").WithName("Test User").Build()
 	ctx := requestcontext.ContextWithUser(req.Context(), user)
 	return req.WithContext(ctx)
 }
+
+func TestBasePlaylistController_Update(t *testing.T) {
+	firstMatch := models.DedupeStrategyFirstMatch
+	name := "Renamed"
+	inactive := false
+	spotifyPlaylistID := "spotify_playlist_456"
+
+	tests := []struct {
+		name               string
+		playlistID         string
+		requestBody        string
+		mockSetup          func(*mocks.MockBasePlaylistServicer)
+		noUserInContext    bool
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:        "successful update",
+			playlistID:  "playlist123",
+			requestBody: `{"dedupe_strategy":"first_match"}`,
+			mockSetup: func(mockService *mocks.MockBasePlaylistServicer) {
+				mockService.EXPECT().
+					UpdateBasePlaylist(gomock.Any(), "playlist123", "test_user_123", &models.UpdateBasePlaylistRequest{DedupeStrategy: &firstMatch}).
+					Return(testfixtures.NewBasePlaylist().WithID("playlist123").WithDedupeStrategy(firstMatch).Build(), nil).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `"dedupe_strategy":"first_match"`,
+		},
+		{
+			name:        "rename, deactivate and relink",
+			playlistID:  "playlist123",
+			requestBody: `{"name":"Renamed","is_active":false,"spotify_playlist_id":"spotify_playlist_456"}`,
+			mockSetup: func(mockService *mocks.MockBasePlaylistServicer) {
+				mockService.EXPECT().
+					UpdateBasePlaylist(gomock.Any(), "playlist123", "test_user_123", &models.UpdateBasePlaylistRequest{
+						Name:              &name,
+						IsActive:          &inactive,
+						SpotifyPlaylistID: &spotifyPlaylistID,
+					}).
+					Return(testfixtures.NewBasePlaylist().WithID("playlist123").WithName(name).Inactive().WithSpotifyPlaylistID(spotifyPlaylistID).Build(), nil).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `"spotify_playlist_id":"spotify_playlist_456"`,
+		},
+		{
+			name:               "empty name",
+			playlistID:         "playlist123",
+			requestBody:        `{"name":""}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "validation failed",
+		},
+		{
+			name:        "spotify playlist owned by another account",
+			playlistID:  "playlist123",
+			requestBody: `{"spotify_playlist_id":"spotify_playlist_456"}`,
+			mockSetup: func(mockService *mocks.MockBasePlaylistServicer) {
+				mockService.EXPECT().
+					UpdateBasePlaylist(gomock.Any(), "playlist123", "test_user_123", gomock.Any()).
+					Return(nil, fmt.Errorf("failed to update playlist: %w", services.ErrSpotifyPlaylistNotOwned)).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "spotify_playlist_not_owned",
+		},
+		{
+			name:        "spotify playlist not found",
+			playlistID:  "playlist123",
+			requestBody: `{"spotify_playlist_id":"spotify_playlist_456"}`,
+			mockSetup: func(mockService *mocks.MockBasePlaylistServicer) {
+				mockService.EXPECT().
+					UpdateBasePlaylist(gomock.Any(), "playlist123", "test_user_123", gomock.Any()).
+					Return(nil, fmt.Errorf("failed to update playlist: %w", spotifyclient.ErrPlaylistNotFound)).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "playlist_not_found",
+		},
+		{
+			name:               "invalid dedupe strategy",
+			playlistID:         "playlist123",
+			requestBody:        `{"dedupe_strategy":"random"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "validation failed",
+		},
+		{
+			name:               "invalid payload",
+			playlistID:         "playlist123",
+			requestBody:        `{`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "invalid payload",
+		},
+		{
+			name:               "empty id in path",
+			playlistID:         "",
+			requestBody:        `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "playlist id is required",
+		},
+		{
+			name:               "no user in context",
+			playlistID:         "playlist123",
+			requestBody:        `{}`,
+			noUserInContext:    true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       "user not found in context",
+		},
+		{
+			name:        "service error",
+			playlistID:  "playlist123",
+			requestBody: `{}`,
+			mockSetup: func(mockService *mocks.MockBasePlaylistServicer) {
+				mockService.EXPECT().
+					UpdateBasePlaylist(gomock.Any(), "playlist123", "test_user_123", gomock.Any()).
+					Return(nil, errors.New("some service error")).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "unable to update base playlist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockBasePlaylistServicer(ctrl)
+			controller := NewBasePlaylistController(mockService)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockService)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/base_playlist/"+tt.playlistID, bytes.NewBufferString(tt.requestBody))
+			req.SetPathValue("id", tt.playlistID)
+			if !tt.noUserInContext {
+				req = addUserToContext(req)
+			}
+
+			w := httptest.NewRecorder()
+			controller.Update(w, req)
+
+			assert.Equal(tt.expectedStatusCode, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestBasePlaylistController_Hooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		enable         bool
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "enable hooks",
+			enable:         true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"hook_token":"hook123"`,
+		},
+		{
+			name:           "disable hooks",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id":"playlist123"`,
+		},
+		{
+			name:           "not owned by user",
+			enable:         true,
+			serviceErr:     repositories.ErrUnauthorized,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "base playlist not found",
+		},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "unable to update base playlist hooks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockBasePlaylistServicer(ctrl)
+			controller := NewBasePlaylistController(mockService)
+
+			var result *models.BasePlaylist
+			if tt.serviceErr == nil {
+				result = testfixtures.NewBasePlaylist().WithID("playlist123").Build()
+				if tt.enable {
+					result.HookToken = "hook123"
+				}
+			}
+
+			method := http.MethodDelete
+			handler := controller.DisableHooks
+			if tt.enable {
+				method = http.MethodPost
+				handler = controller.EnableHooks
+				mockService.EXPECT().EnableHooks(gomock.Any(), "playlist123", "user123").Return(result, tt.serviceErr)
+			} else {
+				mockService.EXPECT().DisableHooks(gomock.Any(), "playlist123", "user123").Return(result, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(method, "/api/base_playlist/playlist123/hooks", nil)
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+			req.SetPathValue("id", "playlist123")
+
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestBasePlaylistController_Archive(t *testing.T) {
+	tests := []struct {
+		name           string
+		archive        bool
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "archive",
+			archive:        true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"archived":true`,
+		},
+		{
+			name:           "unarchive",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"archived":false`,
+		},
+		{
+			name:           "not owned by user",
+			archive:        true,
+			serviceErr:     repositories.ErrUnauthorized,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "base playlist not found",
+		},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "unable to unarchive base playlist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockBasePlaylistServicer(ctrl)
+			controller := NewBasePlaylistController(mockService)
+
+			var result *models.BasePlaylist
+			if tt.serviceErr == nil {
+				result = testfixtures.NewBasePlaylist().WithID("playlist123").Build()
+				result.Archived = tt.archive
+			}
+
+			method := http.MethodDelete
+			handler := controller.Unarchive
+			if tt.archive {
+				method = http.MethodPost
+				handler = controller.Archive
+				mockService.EXPECT().ArchiveBasePlaylist(gomock.Any(), "playlist123", "user123").Return(result, tt.serviceErr)
+			} else {
+				mockService.EXPECT().UnarchiveBasePlaylist(gomock.Any(), "playlist123", "user123").Return(result, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(method, "/api/base_playlist/playlist123/archive", nil)
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+			req.SetPathValue("id", "playlist123")
+
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
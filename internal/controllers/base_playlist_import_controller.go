@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/playlistfile"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// MAX_IMPORTED_FILE_SIZE bounds the body of a track import
+const MAX_IMPORTED_FILE_SIZE = 2 << 20
+
+// BasePlaylistImportController creates base playlists from CSV or JSON files of tracks
+type BasePlaylistImportController struct {
+	importService services.BasePlaylistImportServicer
+	validator     *validator.Validate
+}
+
+func NewBasePlaylistImportController(importService services.BasePlaylistImportServicer) *BasePlaylistImportController {
+	return &BasePlaylistImportController{
+		importService: importService,
+		validator:     newValidator(),
+	}
+}
+
+// ImportTracks reads the tracks of the body, as CSV for text/csv and as JSON otherwise, and creates
+// a new spotify playlist with those found on spotify, registered as a base playlist. The name,
+// dedupe_strategy and spotify_integration_id query parameters set up the base playlist
+func (c *BasePlaylistImportController) ImportTracks(w http.ResponseWriter, r *http.Request) {
+	format, ok := importedFileFormat(r.Header.Get("Content-Type"))
+	if !ok {
+		problem.Write(w, http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, "tracks must be CSV or JSON")
+		return
+	}
+
+	query := r.URL.Query()
+	req := models.ImportTracksRequest{
+		Name:                 query.Get("name"),
+		DedupeStrategy:       models.DedupeStrategy(query.Get("dedupe_strategy")),
+		SpotifyIntegrationID: query.Get("spotify_integration_id"),
+	}
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MAX_IMPORTED_FILE_SIZE))
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	tracks, err := playlistfile.ReadTracks(bytes.NewReader(body), format)
+	if errors.Is(err, playlistfile.ErrTooManyTracks) {
+		problem.Write(w, http.StatusBadRequest, problem.CodeTooManyImportedTracks, err.Error())
+		return
+	}
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPlaylistFile, err.Error())
+		return
+	}
+	if len(tracks) == 0 {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPlaylistFile, "the file has no tracks")
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	result, err := c.importService.ImportTracks(r.Context(), user.ID, &req, tracks)
+	if err != nil {
+		writeError(w, err, "unable to import tracks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// importedFileFormat maps the Content-Type of a track import to the format of its body, a missing
+// Content-Type being JSON
+func importedFileFormat(contentType string) (models.ExportFormat, bool) {
+	if contentType == "" {
+		return models.ExportFormatJSON, true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "application/json":
+		return models.ExportFormatJSON, true
+	case "text/csv", "application/csv":
+		return models.ExportFormatCSV, true
+	default:
+		return "", false
+	}
+}
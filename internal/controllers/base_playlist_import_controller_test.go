@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasePlaylistImportController_ImportTracks(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockBasePlaylistImportServicer(gomock.NewController(t))
+	controller := NewBasePlaylistImportController(mockService)
+
+	expectedRequest := &models.ImportTracksRequest{Name: "Imported", DedupeStrategy: models.DedupeStrategyFirstMatch}
+	expectedTracks := []models.ImportedTrack{
+		{URI: "spotify:track:track1"},
+		{Title: "Mr. Brightside", Artist: "The Killers"},
+	}
+	mockService.EXPECT().ImportTracks(gomock.Any(), "user123", expectedRequest, expectedTracks).Return(&models.ImportTracksResult{
+		BasePlaylist:    testfixtures.NewBasePlaylist().WithID("base123").WithName("Imported").Build(),
+		MatchedTracks:   1,
+		UnmatchedTracks: []models.ImportedTrack{{Title: "Mr. Brightside", Artist: "The Killers"}},
+	}, nil)
+
+	body := "uri,title,artist\nspotify:track:track1,,\n,Mr. Brightside,The Killers\n"
+	req := newAutomationRequest(http.MethodPost, "/api/base_playlist/import_tracks?name=Imported&dedupe_strategy=first_match", body)
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	controller.ImportTracks(w, req)
+
+	assert.Equal(http.StatusCreated, w.Code)
+
+	var result models.ImportTracksResult
+	assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal("base123", result.BasePlaylist.ID)
+	assert.Equal(1, result.MatchedTracks)
+	assert.Len(result.UnmatchedTracks, 1)
+}
+
+func TestBasePlaylistImportController_ImportTracks_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{name: "unsupported content type", query: "?name=Imported", contentType: "audio/x-mpegurl", body: "#EXTM3U", expectedStatus: http.StatusUnsupportedMediaType, expectedCode: problem.CodeUnsupportedMediaType},
+		{name: "missing name", contentType: "application/json", body: `[{"uri":"spotify:track:1"}]`, expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeValidationFailed},
+		{name: "invalid dedupe strategy", query: "?name=Imported&dedupe_strategy=none", contentType: "application/json", body: `[{"uri":"spotify:track:1"}]`, expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeValidationFailed},
+		{name: "invalid file", query: "?name=Imported", contentType: "application/json", body: `{"uri":"spotify:track:1"}`, expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeInvalidPlaylistFile},
+		{name: "row without track", query: "?name=Imported", contentType: "text/csv", body: "title,artist\nOnly a title,\n", expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeInvalidPlaylistFile},
+		{name: "empty file", query: "?name=Imported", contentType: "application/json", body: `[]`, expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeInvalidPlaylistFile},
+		{name: "nothing matched", query: "?name=Imported", body: `[{"isrc":"XX0000000000"}]`, serviceErr: services.ErrNoTracksMatched, expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeNoTracksMatched},
+		{name: "service error", query: "?name=Imported", body: `[{"isrc":"XX0000000000"}]`, serviceErr: errors.New("spotify error"), expectedStatus: http.StatusInternalServerError, expectedCode: problem.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockBasePlaylistImportServicer(gomock.NewController(t))
+			controller := NewBasePlaylistImportController(mockService)
+
+			if tt.serviceErr != nil {
+				mockService.EXPECT().ImportTracks(gomock.Any(), "user123", gomock.Any(), gomock.Any()).Return(nil, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/base_playlist/import_tracks"+tt.query, tt.body)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			controller.ImportTracks(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
+
+func TestBasePlaylistImportController_ImportTracks_TooManyTracks(t *testing.T) {
+	assert := require.New(t)
+	controller := NewBasePlaylistImportController(servicemocks.NewMockBasePlaylistImportServicer(gomock.NewController(t)))
+
+	body := "uri\n" + strings.Repeat("spotify:track:track1\n", 1001)
+	req := newAutomationRequest(http.MethodPost, "/api/base_playlist/import_tracks?name=Imported", body)
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	w := httptest.NewRecorder()
+	controller.ImportTracks(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+
+	var problemBody problem.Problem
+	assert.NoError(json.NewDecoder(w.Body).Decode(&problemBody))
+	assert.Equal(problem.CodeTooManyImportedTracks, problemBody.Code)
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+type BasePlaylistOverviewController struct {
+	overviewService services.BasePlaylistOverviewServicer
+}
+
+func NewBasePlaylistOverviewController(overviewService services.BasePlaylistOverviewServicer) *BasePlaylistOverviewController {
+	return &BasePlaylistOverviewController{
+		overviewService: overviewService,
+	}
+}
+
+// GetOverview responds with the track, child playlist and unmatched track counts of a base playlist
+// along with its last sync, in one call for the dashboard
+func (c *BasePlaylistOverviewController) GetOverview(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylistID := r.PathValue("id")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
+		return
+	}
+
+	overview, err := c.overviewService.GetBasePlaylistOverview(r.Context(), user.ID, basePlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to load base playlist overview")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(overview); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasePlaylistOverviewController_GetOverview(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "not found", serviceErr: repositories.ErrBasePlaylistNotFound, expectedStatus: http.StatusNotFound, expectedCode: problem.CodeBasePlaylistNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to retrieve base playlist: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound, expectedCode: problem.CodeBasePlaylistNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError, expectedCode: problem.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockBasePlaylistOverviewServicer(gomock.NewController(t))
+			controller := NewBasePlaylistOverviewController(mockService)
+
+			var overview *models.BasePlaylistOverview
+			if tt.serviceErr == nil {
+				overview = &models.BasePlaylistOverview{
+					BasePlaylistID:  "base123",
+					TrackCount:      120,
+					ChildPlaylists:  models.ChildPlaylistCounts{Total: 3, Active: 2, Inactive: 1},
+					UnmatchedTracks: 8,
+					LastSync:        &models.SyncSummary{ID: "sync123", Status: models.SyncStatusFailed},
+				}
+			}
+			mockService.EXPECT().GetBasePlaylistOverview(gomock.Any(), "user123", "base123").Return(overview, tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodGet, "/api/base_playlist/base123/overview", "")
+			req.SetPathValue("id", "base123")
+			w := httptest.NewRecorder()
+			controller.GetOverview(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.BasePlaylistOverview
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.Equal(*overview, result)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// BlocklistController manages the tracks and artists kept out of every child playlist of a base playlist
+type BlocklistController struct {
+	blocklistService services.BlocklistServicer
+	validator        *validator.Validate
+}
+
+func NewBlocklistController(blocklistService services.BlocklistServicer) *BlocklistController {
+	return &BlocklistController{
+		blocklistService: blocklistService,
+		validator:        newValidator(),
+	}
+}
+
+func (c *BlocklistController) Create(w http.ResponseWriter, r *http.Request) {
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	var req models.CreateBlocklistEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	entry, err := c.blocklistService.AddEntry(r.Context(), user.ID, basePlaylistID, &req)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to create blocklist entry")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *BlocklistController) List(w http.ResponseWriter, r *http.Request) {
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	entries, err := c.blocklistService.ListEntries(r.Context(), user.ID, basePlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to retrieve blocklist")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *BlocklistController) Delete(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	entryID := r.PathValue("id")
+	if entryID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "blocklist entry ID is required")
+		return
+	}
+
+	err := c.blocklistService.DeleteEntry(r.Context(), user.ID, entryID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBlocklistEntryNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBlocklistEntryNotFound, "blocklist entry not found")
+			return
+		}
+
+		writeError(w, err, "unable to delete blocklist entry")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocklistController_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"type":"track","value":"spotify:track:1"}`,
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "unknown type",
+			body:           `{"type":"album","value":"album1"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid value",
+			body:           `{"type":"track","value":"spotify:track:1"}`,
+			serviceErr:     fmt.Errorf("%w: tracks are blocked by spotify track uri", services.ErrInvalidBlocklistEntry),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "already blocked",
+			body:           `{"type":"track","value":"spotify:track:1"}`,
+			serviceErr:     services.ErrBlocklistEntryExists,
+			expectCall:     true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "base playlist not found",
+			body:           `{"type":"track","value":"spotify:track:1"}`,
+			serviceErr:     fmt.Errorf("failed to get base playlist: %w", repositories.ErrBasePlaylistNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			body:           `{"type":"track","value":"spotify:track:1"}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockBlocklistServicer(gomock.NewController(t))
+			controller := NewBlocklistController(mockService)
+
+			if tt.expectCall {
+				var entry *models.BlocklistEntry
+				if tt.serviceErr == nil {
+					entry = &models.BlocklistEntry{ID: "entry1", Type: models.BlocklistEntryTypeTrack, Value: "spotify:track:1"}
+				}
+				mockService.EXPECT().
+					AddEntry(gomock.Any(), "user123", "bp1", &models.CreateBlocklistEntryRequest{Type: models.BlocklistEntryTypeTrack, Value: "spotify:track:1"}).
+					Return(entry, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/base_playlist/bp1/blocklist", tt.body)
+			req.SetPathValue("basePlaylistID", "bp1")
+			w := httptest.NewRecorder()
+			controller.Create(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var body models.BlocklistEntry
+				assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal("entry1", body.ID)
+			}
+		})
+	}
+}
+
+func TestBlocklistController_List(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockBlocklistServicer(gomock.NewController(t))
+	controller := NewBlocklistController(mockService)
+
+	entries := []*models.BlocklistEntry{{ID: "entry1", Type: models.BlocklistEntryTypeArtist, Value: "artist1"}}
+	mockService.EXPECT().ListEntries(gomock.Any(), "user123", "bp1").Return(entries, nil)
+
+	req := newAutomationRequest(http.MethodGet, "/api/base_playlist/bp1/blocklist", "")
+	req.SetPathValue("basePlaylistID", "bp1")
+	w := httptest.NewRecorder()
+	controller.List(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var body []models.BlocklistEntry
+	assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+	assert.Len(body, 1)
+	assert.Equal("artist1", body[0].Value)
+}
+
+func TestBlocklistController_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusNoContent},
+		{name: "not found", serviceErr: fmt.Errorf("failed to delete blocklist entry: %w", repositories.ErrBlocklistEntryNotFound), expectedStatus: http.StatusNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to delete blocklist entry: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockBlocklistServicer(gomock.NewController(t))
+			controller := NewBlocklistController(mockService)
+
+			mockService.EXPECT().DeleteEntry(gomock.Any(), "user123", "entry1").Return(tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodDelete, "/api/blocklist/entry1", "")
+			req.SetPathValue("id", "entry1")
+			w := httptest.NewRecorder()
+			controller.Delete(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -1,12 +1,21 @@
 package controllers
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/services"
 )
 
@@ -18,46 +27,53 @@ type ChildPlaylistController struct {
 func NewChildPlaylistController(cpService services.ChildPlaylistServicer) *ChildPlaylistController {
 	return &ChildPlaylistController{
 		childPlaylistService: cpService,
-		validator:            validator.New(),
+		validator:            newValidator(),
 	}
 }
 
 func (c *ChildPlaylistController) Create(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateChildPlaylistRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid payload", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
 		return
 	}
 
 	if err := c.validator.Struct(&req); err != nil {
-		http.Error(w, "validation failed: "+err.Error(), http.StatusBadRequest)
+		writeValidationError(w, err)
 		return
 	}
 
+	if req.FilterRules != nil {
+		if err := req.FilterRules.Validate(); err != nil {
+			writeFilterRulesError(w, err)
+			return
+		}
+	}
+
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	// Extract base playlist ID from URL path
 	basePlaylistID := r.PathValue("basePlaylistID")
 	if basePlaylistID == "" {
-		http.Error(w, "base playlist ID is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
 		return
 	}
 
 	newChildPlaylist, err := c.childPlaylistService.CreateChildPlaylist(r.Context(), user.ID, basePlaylistID, &req)
 	if err != nil {
-		http.Error(w, "unable to create child playlist", http.StatusInternalServerError)
+		writeError(w, err, "unable to create child playlist")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(newChildPlaylist); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
 		return
 	}
 }
@@ -66,26 +82,27 @@ func (c *ChildPlaylistController) GetByID(w http.ResponseWriter, r *http.Request
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	// Extract child playlist ID from URL path
 	childPlaylistID := r.PathValue("id")
 	if childPlaylistID == "" {
-		http.Error(w, "child playlist ID is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
 		return
 	}
 
 	childPlaylist, err := c.childPlaylistService.GetChildPlaylist(r.Context(), childPlaylistID, user.ID)
 	if err != nil {
-		http.Error(w, "child playlist not found", http.StatusNotFound)
+		problem.Write(w, http.StatusNotFound, problem.CodeChildPlaylistNotFound, "child playlist not found")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", childPlaylistETag(childPlaylist))
 	if err := json.NewEncoder(w).Encode(childPlaylist); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
 		return
 	}
 }
@@ -94,26 +111,26 @@ func (c *ChildPlaylistController) GetByBasePlaylistID(w http.ResponseWriter, r *
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	// Extract base playlist ID from URL path
 	basePlaylistID := r.PathValue("basePlaylistID")
 	if basePlaylistID == "" {
-		http.Error(w, "base playlist ID is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
 		return
 	}
 
 	childPlaylists, err := c.childPlaylistService.GetChildPlaylistsByBasePlaylistID(r.Context(), basePlaylistID, user.ID)
 	if err != nil {
-		http.Error(w, "unable to retrieve child playlists", http.StatusInternalServerError)
+		writeError(w, err, "unable to retrieve child playlists")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(childPlaylists); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
 		return
 	}
 }
@@ -121,38 +138,56 @@ func (c *ChildPlaylistController) GetByBasePlaylistID(w http.ResponseWriter, r *
 func (c *ChildPlaylistController) Update(w http.ResponseWriter, r *http.Request) {
 	var req models.UpdateChildPlaylistRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid payload", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
 		return
 	}
 
 	if err := c.validator.Struct(&req); err != nil {
-		http.Error(w, "validation failed: "+err.Error(), http.StatusBadRequest)
+		writeValidationError(w, err)
 		return
 	}
 
+	if req.FilterRules != nil {
+		if err := req.FilterRules.Validate(); err != nil {
+			writeFilterRulesError(w, err)
+			return
+		}
+	}
+
+	// If-Match * matches any version, so it leaves the update unconditional
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" {
+		expectedUpdated, err := parseChildPlaylistETag(ifMatch)
+		if err != nil {
+			problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid If-Match header")
+			return
+		}
+		req.ExpectedUpdated = &expectedUpdated
+	}
+
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	// Extract child playlist ID from URL path
 	childPlaylistID := r.PathValue("id")
 	if childPlaylistID == "" {
-		http.Error(w, "child playlist ID is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
 		return
 	}
 
 	updatedChildPlaylist, err := c.childPlaylistService.UpdateChildPlaylist(r.Context(), childPlaylistID, user.ID, &req)
 	if err != nil {
-		http.Error(w, "unable to update child playlist", http.StatusInternalServerError)
+		writeError(w, err, "unable to update child playlist")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", childPlaylistETag(updatedChildPlaylist))
 	if err := json.NewEncoder(w).Encode(updatedChildPlaylist); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
 		return
 	}
 }
@@ -161,22 +196,266 @@ func (c *ChildPlaylistController) Delete(w http.ResponseWriter, r *http.Request)
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	// Extract child playlist ID from URL path
 	childPlaylistID := r.PathValue("id")
 	if childPlaylistID == "" {
-		http.Error(w, "child playlist ID is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
 		return
 	}
 
 	err := c.childPlaylistService.DeleteChildPlaylist(r.Context(), childPlaylistID, user.ID)
 	if err != nil {
-		http.Error(w, "unable to delete child playlist", http.StatusInternalServerError)
+		writeError(w, err, "unable to delete child playlist")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Restore brings back a deleted child playlist and follows its spotify playlist again
+func (c *ChildPlaylistController) Restore(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	childPlaylistID := r.PathValue("id")
+	if childPlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
+		return
+	}
+
+	childPlaylist, err := c.childPlaylistService.RestoreChildPlaylist(r.Context(), childPlaylistID, user.ID)
+	if err != nil {
+		writeError(w, err, "unable to restore child playlist")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(childPlaylist); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+	}
+}
+
+func (c *ChildPlaylistController) Reorder(w http.ResponseWriter, r *http.Request) {
+	var req models.ReorderChildPlaylistsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	// Extract base playlist ID from URL path
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	childPlaylists, err := c.childPlaylistService.ReorderChildPlaylists(r.Context(), basePlaylistID, user.ID, req.ChildPlaylistIDs)
+	if err != nil {
+		writeError(w, err, "unable to reorder child playlists")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(childPlaylists); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// Share enables the public embeddable widget of the child playlist, rotating any previous share token
+func (c *ChildPlaylistController) Share(w http.ResponseWriter, r *http.Request) {
+	c.updateSharing(w, r, c.childPlaylistService.EnableSharing)
+}
+
+// Unshare revokes the share token of the child playlist
+func (c *ChildPlaylistController) Unshare(w http.ResponseWriter, r *http.Request) {
+	c.updateSharing(w, r, c.childPlaylistService.DisableSharing)
+}
+
+func (c *ChildPlaylistController) updateSharing(
+	w http.ResponseWriter,
+	r *http.Request,
+	update func(ctx context.Context, id, userID string) (*models.ChildPlaylist, error),
+) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	// Extract child playlist ID from URL path
+	childPlaylistID := r.PathValue("id")
+	if childPlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
+		return
+	}
+
+	childPlaylist, err := update(r.Context(), childPlaylistID, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeChildPlaylistNotFound, "child playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to update child playlist sharing")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(childPlaylist); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// PinTracks pins tracks to the child playlist, they are synced whatever its filter rules
+func (c *ChildPlaylistController) PinTracks(w http.ResponseWriter, r *http.Request) {
+	var req models.PinTracksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	c.updatePinnedTracks(w, r, func(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+		return c.childPlaylistService.PinTracks(ctx, id, userID, req.TrackURIs)
+	})
+}
+
+// UnpinTrack removes a track from the pinned tracks of the child playlist
+func (c *ChildPlaylistController) UnpinTrack(w http.ResponseWriter, r *http.Request) {
+	trackURI := r.PathValue("trackURI")
+	if trackURI == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "track URI is required")
+		return
+	}
+
+	c.updatePinnedTracks(w, r, func(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+		return c.childPlaylistService.UnpinTrack(ctx, id, userID, trackURI)
+	})
+}
+
+func (c *ChildPlaylistController) updatePinnedTracks(
+	w http.ResponseWriter,
+	r *http.Request,
+	update func(ctx context.Context, id, userID string) (*models.ChildPlaylist, error),
+) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	// Extract child playlist ID from URL path
+	childPlaylistID := r.PathValue("id")
+	if childPlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
+		return
+	}
+
+	childPlaylist, err := update(r.Context(), childPlaylistID, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeChildPlaylistNotFound, "child playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to update pinned tracks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(childPlaylist); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// UploadCover sets the cover of the child playlist to the JPEG image in the request body. An empty
+// body generates a cover colored after the filter rules of the child
+func (c *ChildPlaylistController) UploadCover(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	// Extract child playlist ID from URL path
+	childPlaylistID := r.PathValue("id")
+	if childPlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
+		return
+	}
+
+	// Spotify limits the base64 encoded image, cap the raw image to what still fits once encoded
+	maxImageSize := int64(base64.StdEncoding.DecodedLen(spotifyclient.MAX_COVER_IMAGE_SIZE))
+	jpegBytes, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImageSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			problem.Write(w, http.StatusRequestEntityTooLarge, problem.CodeCoverImageTooLarge, spotifyclient.ErrCoverImageTooLarge.Error())
+			return
+		}
+
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if len(jpegBytes) > 0 && r.Header.Get("Content-Type") != "image/jpeg" {
+		problem.Write(w, http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, "cover must be a JPEG image")
+		return
+	}
+
+	err = c.childPlaylistService.UpdateChildPlaylistCover(r.Context(), childPlaylistID, user.ID, jpegBytes)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeChildPlaylistNotFound, "child playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to update child playlist cover")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// childPlaylistETag identifies the version of a child playlist by its updated time, which changes
+// with every write
+func childPlaylistETag(childPlaylist *models.ChildPlaylist) string {
+	return `"` + childPlaylist.Updated.UTC().Format(time.RFC3339Nano) + `"`
+}
+
+// parseChildPlaylistETag reads the updated time back from an ETag of childPlaylistETag. Weak ETags are
+// compared as strong ones
+func parseChildPlaylistETag(etag string) (time.Time, error) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	return time.Parse(time.RFC3339Nano, strings.Trim(etag, `"`))
+}
@@ -2,18 +2,26 @@ package controllers
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
 	"github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 )
 
 func TestNewChildPlaylistController(t *testing.T) {
@@ -48,15 +56,14 @@ func TestChildPlaylistController_Create_Success(t *testing.T) {
 					Popularity: &models.RangeFilter{Min: ptrFloat64(50), Max: ptrFloat64(100)},
 				},
 			},
-			serviceResult: &models.ChildPlaylist{
-				ID:                "child123",
-				UserID:            "user123",
-				BasePlaylistID:    "base123",
-				Name:              "Test Child Playlist",
-				Description:       "Test description",
-				SpotifyPlaylistID: "spotify123",
-				IsActive:          true,
-			},
+			serviceResult: testfixtures.NewChildPlaylist().
+				WithID("child123").
+				WithUserID("user123").
+				WithBasePlaylistID("base123").
+				WithName("Test Child Playlist").
+				WithDescription("Test description").
+				WithSpotifyPlaylistID("spotify123").
+				Build(),
 			expectedStatusCode: http.StatusCreated,
 		},
 		{
@@ -65,14 +72,13 @@ func TestChildPlaylistController_Create_Success(t *testing.T) {
 			request: models.CreateChildPlaylistRequest{
 				Name: "Minimal Child",
 			},
-			serviceResult: &models.ChildPlaylist{
-				ID:                "child456",
-				UserID:            "user123",
-				BasePlaylistID:    "base456",
-				Name:              "Minimal Child",
-				SpotifyPlaylistID: "spotify456",
-				IsActive:          true,
-			},
+			serviceResult: testfixtures.NewChildPlaylist().
+				WithID("child456").
+				WithUserID("user123").
+				WithBasePlaylistID("base456").
+				WithName("Minimal Child").
+				WithSpotifyPlaylistID("spotify456").
+				Build(),
 			expectedStatusCode: http.StatusCreated,
 		},
 	}
@@ -96,7 +102,7 @@ func TestChildPlaylistController_Create_Success(t *testing.T) {
 			// Create request body
 			requestBody, _ := json.Marshal(tt.request)
 			req := httptest.NewRequest("POST", "/api/base_playlist/"+tt.basePlaylistID+"/child_playlist", bytes.NewReader(requestBody))
-			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 			req.SetPathValue("basePlaylistID", tt.basePlaylistID)
 
 			w := httptest.NewRecorder()
@@ -138,6 +144,20 @@ func TestChildPlaylistController_Create_Errors(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedError:      "validation failed",
 		},
+		{
+			name:               "malformed duration",
+			basePlaylistID:     "base123",
+			requestBody:        `{"name": "Test", "filter_rules": {"duration": {"max": "4 minutes"}}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid payload",
+		},
+		{
+			name:               "unknown duration preset",
+			basePlaylistID:     "base123",
+			requestBody:        `{"name": "Test", "filter_rules": {"duration": {"preset": "epic"}}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "filter_rules.duration: preset must be one of short, standard or long",
+		},
 		{
 			name:               "no user in context",
 			basePlaylistID:     "base123",
@@ -154,6 +174,14 @@ func TestChildPlaylistController_Create_Errors(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedError:      "unable to create child playlist",
 		},
+		{
+			name:               "spotify account not linked",
+			basePlaylistID:     "base123",
+			requestBody:        models.CreateChildPlaylistRequest{Name: "Test", SpotifyIntegrationID: "integration_other"},
+			serviceError:       fmt.Errorf("failed to load spotify account: %w", services.ErrSpotifyIntegrationUnavailable),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "spotify account not linked",
+		},
 		{
 			name:               "empty base playlist ID",
 			basePlaylistID:     "",
@@ -189,7 +217,7 @@ func TestChildPlaylistController_Create_Errors(t *testing.T) {
 
 			req := httptest.NewRequest("POST", "/api/base_playlist/"+tt.basePlaylistID+"/child_playlist", bytes.NewReader(reqBody))
 			if !tt.noUserInContext {
-				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 			}
 			req.SetPathValue("basePlaylistID", tt.basePlaylistID)
 
@@ -212,14 +240,13 @@ func TestChildPlaylistController_GetByID_Success(t *testing.T) {
 		{
 			name:            "successful retrieval",
 			childPlaylistID: "child123",
-			serviceResult: &models.ChildPlaylist{
-				ID:                "child123",
-				UserID:            "user123",
-				BasePlaylistID:    "base123",
-				Name:              "Test Child Playlist",
-				SpotifyPlaylistID: "spotify123",
-				IsActive:          true,
-			},
+			serviceResult: testfixtures.NewChildPlaylist().
+				WithID("child123").
+				WithUserID("user123").
+				WithBasePlaylistID("base123").
+				WithName("Test Child Playlist").
+				WithSpotifyPlaylistID("spotify123").
+				Build(),
 			expectedStatusCode: http.StatusOK,
 		},
 	}
@@ -240,7 +267,7 @@ func TestChildPlaylistController_GetByID_Success(t *testing.T) {
 				Times(1)
 
 			req := httptest.NewRequest("GET", "/api/child_playlist/"+tt.childPlaylistID, nil)
-			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 			req.SetPathValue("id", tt.childPlaylistID)
 
 			w := httptest.NewRecorder()
@@ -248,6 +275,7 @@ func TestChildPlaylistController_GetByID_Success(t *testing.T) {
 
 			assert.Equal(tt.expectedStatusCode, w.Code)
 			assert.Equal("application/json", w.Header().Get("Content-Type"))
+			assert.Equal(`"`+tt.serviceResult.Updated.UTC().Format(time.RFC3339Nano)+`"`, w.Header().Get("ETag"))
 
 			var response models.ChildPlaylist
 			err := json.NewDecoder(w.Body).Decode(&response)
@@ -307,7 +335,7 @@ func TestChildPlaylistController_GetByID_Errors(t *testing.T) {
 
 			req := httptest.NewRequest("GET", "/api/child_playlist/"+tt.childPlaylistID, nil)
 			if !tt.noUserInContext {
-				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 			}
 			req.SetPathValue("id", tt.childPlaylistID)
 
@@ -330,20 +358,20 @@ func TestChildPlaylistController_GetByBasePlaylistID_Success(t *testing.T) {
 	controller := NewChildPlaylistController(mockService)
 
 	expectedPlaylists := []*models.ChildPlaylist{
-		{
-			ID:                "child1",
-			UserID:            "user123",
-			BasePlaylistID:    "base123",
-			Name:              "Child 1",
-			SpotifyPlaylistID: "spotify1",
-		},
-		{
-			ID:                "child2",
-			UserID:            "user123",
-			BasePlaylistID:    "base123",
-			Name:              "Child 2",
-			SpotifyPlaylistID: "spotify2",
-		},
+		testfixtures.NewChildPlaylist().
+			WithID("child1").
+			WithUserID("user123").
+			WithBasePlaylistID("base123").
+			WithName("Child 1").
+			WithSpotifyPlaylistID("spotify1").
+			Build(),
+		testfixtures.NewChildPlaylist().
+			WithID("child2").
+			WithUserID("user123").
+			WithBasePlaylistID("base123").
+			WithName("Child 2").
+			WithSpotifyPlaylistID("spotify2").
+			Build(),
 	}
 
 	mockService.EXPECT().
@@ -352,7 +380,7 @@ func TestChildPlaylistController_GetByBasePlaylistID_Success(t *testing.T) {
 		Times(1)
 
 	req := httptest.NewRequest("GET", "/api/base_playlist/base123/child_playlist", nil)
-	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 	req.SetPathValue("basePlaylistID", "base123")
 
 	w := httptest.NewRecorder()
@@ -419,7 +447,7 @@ func TestChildPlaylistController_GetByBasePlaylistID_Errors(t *testing.T) {
 
 			req := httptest.NewRequest("GET", "/api/base_playlist/"+tt.basePlaylistID+"/child_playlist", nil)
 			if !tt.noUserInContext {
-				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 			}
 			req.SetPathValue("basePlaylistID", tt.basePlaylistID)
 
@@ -448,14 +476,14 @@ func TestChildPlaylistController_Update_Success(t *testing.T) {
 		Description: &newDescription,
 	}
 
-	expectedResult := &models.ChildPlaylist{
-		ID:                "child123",
-		UserID:            "user123",
-		BasePlaylistID:    "base123",
-		Name:              "Updated Name",
-		Description:       "Updated Description",
-		SpotifyPlaylistID: "spotify123",
-	}
+	expectedResult := testfixtures.NewChildPlaylist().
+		WithID("child123").
+		WithUserID("user123").
+		WithBasePlaylistID("base123").
+		WithName("Updated Name").
+		WithDescription("Updated Description").
+		WithSpotifyPlaylistID("spotify123").
+		Build()
 
 	mockService.EXPECT().
 		UpdateChildPlaylist(gomock.Any(), "child123", "user123", &request).
@@ -464,7 +492,7 @@ func TestChildPlaylistController_Update_Success(t *testing.T) {
 
 	requestBody, _ := json.Marshal(request)
 	req := httptest.NewRequest("PUT", "/api/child_playlist/child123", bytes.NewReader(requestBody))
-	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 	req.SetPathValue("id", "child123")
 
 	w := httptest.NewRecorder()
@@ -487,6 +515,7 @@ func TestChildPlaylistController_Update_Errors(t *testing.T) {
 		name               string
 		childPlaylistID    string
 		requestBody        interface{}
+		ifMatch            string
 		serviceError       error
 		noUserInContext    bool
 		expectedStatusCode int
@@ -499,6 +528,23 @@ func TestChildPlaylistController_Update_Errors(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedError:      "invalid payload",
 		},
+		{
+			name:               "invalid If-Match header",
+			childPlaylistID:    "child123",
+			requestBody:        models.UpdateChildPlaylistRequest{Name: &newName},
+			ifMatch:            `"v2"`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid If-Match header",
+		},
+		{
+			name:               "modified since read",
+			childPlaylistID:    "child123",
+			requestBody:        models.UpdateChildPlaylistRequest{Name: &newName},
+			ifMatch:            `"2026-10-16T10:00:00.123Z"`,
+			serviceError:       repositories.ErrChildPlaylistModified,
+			expectedStatusCode: http.StatusConflict,
+			expectedError:      "child_playlist_modified",
+		},
 		{
 			name:               "validation error",
 			childPlaylistID:    "child123",
@@ -506,6 +552,15 @@ func TestChildPlaylistController_Update_Errors(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedError:      "validation failed",
 		},
+		{
+			name:            "invalid filter rule expression",
+			childPlaylistID: "child123",
+			requestBody: models.UpdateChildPlaylistRequest{FilterRules: &models.MetadataFilters{
+				Or: []*models.MetadataFilters{{}},
+			}},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "filter_rules.or[0]: group must contain at least one condition",
+		},
 		{
 			name:               "no user in context",
 			childPlaylistID:    "child123",
@@ -556,8 +611,11 @@ func TestChildPlaylistController_Update_Errors(t *testing.T) {
 			}
 
 			req := httptest.NewRequest("PUT", "/api/child_playlist/"+tt.childPlaylistID, bytes.NewReader(reqBody))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			if !tt.noUserInContext {
-				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 			}
 			req.SetPathValue("id", tt.childPlaylistID)
 
@@ -570,6 +628,66 @@ func TestChildPlaylistController_Update_Errors(t *testing.T) {
 	}
 }
 
+func TestChildPlaylistController_Update_IfMatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		ifMatch         string
+		expectedUpdated *time.Time
+	}{
+		{
+			name:            "strong etag",
+			ifMatch:         `"2026-10-16T10:00:00.123Z"`,
+			expectedUpdated: timeToPointer(time.Date(2026, 10, 16, 10, 0, 0, 123000000, time.UTC)),
+		},
+		{
+			name:            "weak etag",
+			ifMatch:         `W/"2026-10-16T10:00:00.123Z"`,
+			expectedUpdated: timeToPointer(time.Date(2026, 10, 16, 10, 0, 0, 123000000, time.UTC)),
+		},
+		{
+			name:    "any version",
+			ifMatch: "*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+			controller := NewChildPlaylistController(mockService)
+
+			updated := testfixtures.NewChildPlaylist().WithID("child123").Build()
+			updated.Updated = time.Date(2026, 10, 16, 10, 5, 0, 456000000, time.UTC)
+
+			mockService.EXPECT().
+				UpdateChildPlaylist(gomock.Any(), "child123", "user123", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, id, userID string, input *models.UpdateChildPlaylistRequest) (*models.ChildPlaylist, error) {
+					assert.Equal(tt.expectedUpdated, input.ExpectedUpdated)
+					return updated, nil
+				})
+
+			req := httptest.NewRequest("PUT", "/api/child_playlist/child123", bytes.NewReader([]byte(`{"name":"Updated Name"}`)))
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+			req.SetPathValue("id", "child123")
+			req.Header.Set("If-Match", tt.ifMatch)
+
+			w := httptest.NewRecorder()
+			controller.Update(w, req)
+
+			assert.Equal(http.StatusOK, w.Code)
+			assert.Equal(`"2026-10-16T10:05:00.456Z"`, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func timeToPointer(t time.Time) *time.Time {
+	return &t
+}
+
 func stringToPointer(s string) *string {
 	return &s
 }
@@ -589,7 +707,7 @@ func TestChildPlaylistController_Delete_Success(t *testing.T) {
 		Times(1)
 
 	req := httptest.NewRequest("DELETE", "/api/child_playlist/child123", nil)
-	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 	req.SetPathValue("id", "child123")
 
 	w := httptest.NewRecorder()
@@ -649,7 +767,7 @@ func TestChildPlaylistController_Delete_Errors(t *testing.T) {
 
 			req := httptest.NewRequest("DELETE", "/api/child_playlist/"+tt.childPlaylistID, nil)
 			if !tt.noUserInContext {
-				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
 			}
 			req.SetPathValue("id", tt.childPlaylistID)
 
@@ -666,3 +784,472 @@ func TestChildPlaylistController_Delete_Errors(t *testing.T) {
 func ptrFloat64(f float64) *float64 {
 	return &f
 }
+
+func TestChildPlaylistController_Restore(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "restores the child playlist",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"id":"child123"`,
+		},
+		{
+			name:           "another child playlist links the same spotify playlist",
+			serviceErr:     fmt.Errorf("failed to restore child playlist: %w", repositories.ErrRestoreConflict),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"code":"restore_conflict"`,
+		},
+		{
+			name:           "base playlist deleted",
+			serviceErr:     repositories.ErrBasePlaylistNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "base playlist not found",
+		},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("spotify api error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "unable to restore child playlist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+			controller := NewChildPlaylistController(mockService)
+
+			var result *models.ChildPlaylist
+			if tt.serviceErr == nil {
+				result = testfixtures.NewChildPlaylist().WithID("child123").Build()
+			}
+			mockService.EXPECT().RestoreChildPlaylist(gomock.Any(), "child123", "user123").Return(result, tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/child_playlist/child123/restore", nil)
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+			req.SetPathValue("id", "child123")
+
+			w := httptest.NewRecorder()
+			controller.Restore(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestChildPlaylistController_Reorder_Success(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+	controller := NewChildPlaylistController(mockService)
+
+	request := models.ReorderChildPlaylistsRequest{ChildPlaylistIDs: []string{"child2", "child1"}}
+	expectedResult := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child2").WithPriority(0).Build(),
+		testfixtures.NewChildPlaylist().WithID("child1").WithPriority(1).Build(),
+	}
+
+	mockService.EXPECT().
+		ReorderChildPlaylists(gomock.Any(), "base123", "user123", request.ChildPlaylistIDs).
+		Return(expectedResult, nil).
+		Times(1)
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("PATCH", "/api/base_playlist/base123/child_playlist/order", bytes.NewReader(requestBody))
+	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+	req.SetPathValue("basePlaylistID", "base123")
+
+	w := httptest.NewRecorder()
+	controller.Reorder(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+
+	var response []*models.ChildPlaylist
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(err)
+	assert.Equal(expectedResult, response)
+}
+
+func TestChildPlaylistController_Reorder_Errors(t *testing.T) {
+	validRequest := models.ReorderChildPlaylistsRequest{ChildPlaylistIDs: []string{"child1"}}
+
+	tests := []struct {
+		name               string
+		basePlaylistID     string
+		requestBody        interface{}
+		serviceError       error
+		noUserInContext    bool
+		expectedStatusCode int
+		expectedError      string
+	}{
+		{
+			name:               "invalid request body",
+			basePlaylistID:     "base123",
+			requestBody:        "invalid json",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid payload",
+		},
+		{
+			name:               "validation error",
+			basePlaylistID:     "base123",
+			requestBody:        models.ReorderChildPlaylistsRequest{},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "validation failed",
+		},
+		{
+			name:               "no user in context",
+			basePlaylistID:     "base123",
+			requestBody:        validRequest,
+			noUserInContext:    true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "user not found in context",
+		},
+		{
+			name:               "empty base playlist ID",
+			basePlaylistID:     "",
+			requestBody:        validRequest,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "base playlist ID is required",
+		},
+		{
+			name:               "invalid order",
+			basePlaylistID:     "base123",
+			requestBody:        validRequest,
+			serviceError:       services.ErrInvalidChildPlaylistOrder,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "must list every child playlist",
+		},
+		{
+			name:               "service error",
+			basePlaylistID:     "base123",
+			requestBody:        validRequest,
+			serviceError:       errors.New("some service error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedError:      "unable to reorder child playlists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+			controller := NewChildPlaylistController(mockService)
+
+			if tt.serviceError != nil {
+				mockService.EXPECT().
+					ReorderChildPlaylists(gomock.Any(), tt.basePlaylistID, "user123", gomock.Any()).
+					Return(nil, tt.serviceError).
+					Times(1)
+			}
+
+			var reqBody []byte
+			if body, ok := tt.requestBody.(string); ok {
+				reqBody = []byte(body)
+			} else {
+				reqBody, _ = json.Marshal(tt.requestBody)
+			}
+
+			req := httptest.NewRequest("PATCH", "/api/base_playlist/"+tt.basePlaylistID+"/child_playlist/order", bytes.NewReader(reqBody))
+			if !tt.noUserInContext {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+			}
+			req.SetPathValue("basePlaylistID", tt.basePlaylistID)
+
+			w := httptest.NewRecorder()
+			controller.Reorder(w, req)
+
+			assert.Equal(tt.expectedStatusCode, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedError)
+		})
+	}
+}
+
+func TestChildPlaylistController_Share_Success(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+	controller := NewChildPlaylistController(mockService)
+
+	mockService.EXPECT().
+		EnableSharing(gomock.Any(), "child123", "user123").
+		Return(testfixtures.NewChildPlaylist().WithID("child123").WithShareToken("token123").Build(), nil).
+		Times(1)
+
+	req := httptest.NewRequest("POST", "/api/child_playlist/child123/share", nil)
+	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+	req.SetPathValue("id", "child123")
+
+	w := httptest.NewRecorder()
+	controller.Share(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), `"share_token":"token123"`)
+}
+
+func TestChildPlaylistController_Unshare(t *testing.T) {
+	tests := []struct {
+		name               string
+		serviceError       error
+		noUserInContext    bool
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "success",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `"id":"child123"`,
+		},
+		{
+			name:               "not owned by user",
+			serviceError:       repositories.ErrUnauthorized,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       "child playlist not found",
+		},
+		{
+			name:               "service error",
+			serviceError:       errors.New("db error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       "unable to update child playlist sharing",
+		},
+		{
+			name:               "no user in context",
+			noUserInContext:    true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       "user not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+			controller := NewChildPlaylistController(mockService)
+
+			if !tt.noUserInContext {
+				if tt.serviceError != nil {
+					mockService.EXPECT().DisableSharing(gomock.Any(), "child123", "user123").Return(nil, tt.serviceError)
+				} else {
+					mockService.EXPECT().DisableSharing(gomock.Any(), "child123", "user123").Return(testfixtures.NewChildPlaylist().WithID("child123").Build(), nil)
+				}
+			}
+
+			req := httptest.NewRequest("DELETE", "/api/child_playlist/child123/share", nil)
+			if !tt.noUserInContext {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+			}
+			req.SetPathValue("id", "child123")
+
+			w := httptest.NewRecorder()
+			controller.Unshare(w, req)
+
+			assert.Equal(tt.expectedStatusCode, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestChildPlaylistController_PinTracks(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		expectCall         bool
+		serviceError       error
+		expectedStatusCode int
+	}{
+		{
+			name:               "success",
+			body:               `{"track_uris":["spotify:track:1"]}`,
+			expectCall:         true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "not a track uri",
+			body:               `{"track_uris":["spotify:album:1"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "no tracks",
+			body:               `{"track_uris":[]}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "too many pinned tracks",
+			body:               `{"track_uris":["spotify:track:1"]}`,
+			expectCall:         true,
+			serviceError:       services.ErrTooManyPinnedTracks,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "not owned by user",
+			body:               `{"track_uris":["spotify:track:1"]}`,
+			expectCall:         true,
+			serviceError:       repositories.ErrUnauthorized,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+			controller := NewChildPlaylistController(mockService)
+
+			if tt.expectCall {
+				var childPlaylist *models.ChildPlaylist
+				if tt.serviceError == nil {
+					childPlaylist = testfixtures.NewChildPlaylist().WithID("child123").WithPinnedTracks("spotify:track:1").Build()
+				}
+				mockService.EXPECT().
+					PinTracks(gomock.Any(), "child123", "user123", []string{"spotify:track:1"}).
+					Return(childPlaylist, tt.serviceError)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/child_playlist/child123/pinned_tracks", tt.body)
+			req.SetPathValue("id", "child123")
+
+			w := httptest.NewRecorder()
+			controller.PinTracks(w, req)
+
+			assert.Equal(tt.expectedStatusCode, w.Code)
+			if tt.expectedStatusCode == http.StatusOK {
+				assert.Contains(w.Body.String(), `"pinned_tracks":["spotify:track:1"]`)
+			}
+		})
+	}
+}
+
+func TestChildPlaylistController_UnpinTrack(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+	controller := NewChildPlaylistController(mockService)
+
+	mockService.EXPECT().
+		UnpinTrack(gomock.Any(), "child123", "user123", "spotify:track:1").
+		Return(testfixtures.NewChildPlaylist().WithID("child123").Build(), nil)
+
+	req := newAutomationRequest(http.MethodDelete, "/api/child_playlist/child123/pinned_tracks/spotify:track:1", "")
+	req.SetPathValue("id", "child123")
+	req.SetPathValue("trackURI", "spotify:track:1")
+
+	w := httptest.NewRecorder()
+	controller.UnpinTrack(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.NotContains(w.Body.String(), "pinned_tracks")
+}
+
+func TestChildPlaylistController_UploadCover(t *testing.T) {
+	jpegBytes := []byte{0xFF, 0xD8, 0xFF, 0xE0}
+
+	tests := []struct {
+		name               string
+		body               []byte
+		contentType        string
+		expectCall         bool
+		serviceError       error
+		expectedStatusCode int
+	}{
+		{
+			name:               "uploads the image",
+			body:               jpegBytes,
+			contentType:        "image/jpeg",
+			expectCall:         true,
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name:               "empty body generates the cover",
+			body:               []byte{},
+			expectCall:         true,
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name:               "not a jpeg",
+			body:               jpegBytes,
+			contentType:        "image/png",
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:               "image too large once encoded",
+			body:               bytes.Repeat([]byte{0xFF}, base64.StdEncoding.DecodedLen(spotifyclient.MAX_COVER_IMAGE_SIZE)+1),
+			contentType:        "image/jpeg",
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:               "not owned by user",
+			body:               jpegBytes,
+			contentType:        "image/jpeg",
+			expectCall:         true,
+			serviceError:       repositories.ErrUnauthorized,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "spotify error",
+			body:               jpegBytes,
+			contentType:        "image/jpeg",
+			expectCall:         true,
+			serviceError:       errors.New("spotify down"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockChildPlaylistServicer(ctrl)
+			controller := NewChildPlaylistController(mockService)
+
+			if tt.expectCall {
+				mockService.EXPECT().
+					UpdateChildPlaylistCover(gomock.Any(), "child123", "user123", tt.body).
+					Return(tt.serviceError)
+			}
+
+			req := newAutomationRequest(http.MethodPut, "/api/child_playlist/child123/cover", string(tt.body))
+			req.SetPathValue("id", "child123")
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			w := httptest.NewRecorder()
+			controller.UploadCover(w, req)
+
+			assert.Equal(tt.expectedStatusCode, w.Code)
+		})
+	}
+}
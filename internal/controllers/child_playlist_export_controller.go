@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/playlistfile"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// ChildPlaylistExportController serves the routed tracks of child playlists as M3U, CSV or JSON
+// files
+type ChildPlaylistExportController struct {
+	exportService services.ChildPlaylistExportServicer
+}
+
+func NewChildPlaylistExportController(exportService services.ChildPlaylistExportServicer) *ChildPlaylistExportController {
+	return &ChildPlaylistExportController{
+		exportService: exportService,
+	}
+}
+
+// Export streams the tracks of the child playlist as an attachment, in the format of the format
+// query parameter: m3u, csv or json, the default
+func (c *ChildPlaylistExportController) Export(w http.ResponseWriter, r *http.Request) {
+	format := models.ExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = models.ExportFormatJSON
+	}
+	if !format.Valid() {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "format must be m3u, csv or json")
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	childPlaylistID := r.PathValue("id")
+	if childPlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist id is required")
+		return
+	}
+
+	attachment := &attachmentWriter{
+		w:           w,
+		contentType: playlistfile.ContentType(format),
+		filename:    fmt.Sprintf("child-playlist-%s.%s", childPlaylistID, format),
+	}
+
+	err := c.exportService.ExportChildPlaylist(r.Context(), user.ID, childPlaylistID, format, attachment)
+	if err == nil || attachment.started {
+		// Once the file started streaming its status is sent, a failure can only cut it short
+		return
+	}
+
+	if errors.Is(err, repositories.ErrUnauthorized) {
+		problem.Write(w, http.StatusNotFound, problem.CodeChildPlaylistNotFound, "child playlist not found")
+		return
+	}
+
+	writeError(w, err, "unable to export child playlist")
+}
+
+// attachmentWriter sends the headers of the attachment along with its first bytes, so errors
+// happening before anything is written can still be responded with a problem
+type attachmentWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (a *attachmentWriter) Write(p []byte) (int, error) {
+	if !a.started {
+		a.started = true
+		a.w.Header().Set("Content-Type", a.contentType)
+		a.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, a.filename))
+		a.w.WriteHeader(http.StatusOK)
+	}
+
+	return a.w.Write(p)
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildPlaylistExportController_Export(t *testing.T) {
+	tests := []struct {
+		name                string
+		format              string
+		expectedFormat      models.ExportFormat
+		serviceErr          error
+		expectedStatus      int
+		expectedCode        problem.Code
+		expectedContentType string
+	}{
+		{name: "default format", expectedFormat: models.ExportFormatJSON, expectedStatus: http.StatusOK, expectedContentType: "application/json"},
+		{name: "m3u", format: "m3u", expectedFormat: models.ExportFormatM3U, expectedStatus: http.StatusOK, expectedContentType: "audio/x-mpegurl"},
+		{name: "csv", format: "csv", expectedFormat: models.ExportFormatCSV, expectedStatus: http.StatusOK, expectedContentType: "text/csv; charset=utf-8"},
+		{name: "unknown format", format: "xspf", expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeInvalidParameter},
+		{name: "not found", format: "csv", expectedFormat: models.ExportFormatCSV, serviceErr: repositories.ErrChildPlaylistNotFound, expectedStatus: http.StatusNotFound, expectedCode: problem.CodeChildPlaylistNotFound},
+		{name: "other user", format: "csv", expectedFormat: models.ExportFormatCSV, serviceErr: fmt.Errorf("failed to retrieve child playlist: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound, expectedCode: problem.CodeChildPlaylistNotFound},
+		{name: "service error", format: "csv", expectedFormat: models.ExportFormatCSV, serviceErr: errors.New("spotify error"), expectedStatus: http.StatusInternalServerError, expectedCode: problem.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockChildPlaylistExportServicer(gomock.NewController(t))
+			controller := NewChildPlaylistExportController(mockService)
+
+			if tt.expectedFormat != "" {
+				mockService.EXPECT().ExportChildPlaylist(gomock.Any(), "user123", "child123", tt.expectedFormat, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _ string, _ models.ExportFormat, w io.Writer) error {
+						if tt.serviceErr != nil {
+							return tt.serviceErr
+						}
+						_, err := w.Write([]byte("exported"))
+						return err
+					})
+			}
+
+			req := newAutomationRequest(http.MethodGet, "/api/child_playlist/child123/export?format="+tt.format, "")
+			req.SetPathValue("id", "child123")
+			w := httptest.NewRecorder()
+			controller.Export(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(tt.expectedContentType, w.Header().Get("Content-Type"))
+				assert.Equal(fmt.Sprintf(`attachment; filename="child-playlist-child123.%s"`, tt.expectedFormat), w.Header().Get("Content-Disposition"))
+				assert.Equal("exported", w.Body.String())
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
+
+func TestChildPlaylistExportController_Export_FailsMidStream(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockChildPlaylistExportServicer(gomock.NewController(t))
+	controller := NewChildPlaylistExportController(mockService)
+
+	mockService.EXPECT().ExportChildPlaylist(gomock.Any(), "user123", "child123", models.ExportFormatM3U, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ string, _ models.ExportFormat, w io.Writer) error {
+			_, _ = w.Write([]byte("#EXTM3U\n"))
+			return errors.New("spotify error")
+		})
+
+	req := newAutomationRequest(http.MethodGet, "/api/child_playlist/child123/export?format=m3u", "")
+	req.SetPathValue("id", "child123")
+	w := httptest.NewRecorder()
+	controller.Export(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("#EXTM3U\n", w.Body.String())
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+type ChildPlaylistStatsController struct {
+	statsService services.ChildPlaylistStatsServicer
+}
+
+func NewChildPlaylistStatsController(statsService services.ChildPlaylistStatsServicer) *ChildPlaylistStatsController {
+	return &ChildPlaylistStatsController{
+		statsService: statsService,
+	}
+}
+
+// GetStats responds with the track count, last sync and current match rate of a child playlist,
+// along with the top genres and artists it gets from its base playlist
+func (c *ChildPlaylistStatsController) GetStats(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	childPlaylistID := r.PathValue("id")
+	if childPlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist id is required")
+		return
+	}
+
+	stats, err := c.statsService.GetChildPlaylistStats(r.Context(), user.ID, childPlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeChildPlaylistNotFound, "child playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to compute child playlist stats")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildPlaylistStatsController_GetStats(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "not found", serviceErr: repositories.ErrChildPlaylistNotFound, expectedStatus: http.StatusNotFound, expectedCode: problem.CodeChildPlaylistNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to retrieve child playlist: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound, expectedCode: problem.CodeChildPlaylistNotFound},
+		{name: "service error", serviceErr: errors.New("spotify error"), expectedStatus: http.StatusInternalServerError, expectedCode: problem.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockChildPlaylistStatsServicer(gomock.NewController(t))
+			controller := NewChildPlaylistStatsController(mockService)
+
+			var stats *models.ChildPlaylistStats
+			if tt.serviceErr == nil {
+				stats = &models.ChildPlaylistStats{
+					ChildPlaylistID: "child123",
+					TrackCount:      12,
+					BaseTrackCount:  40,
+					MatchedTracks:   10,
+					MatchRate:       0.25,
+					TopGenres:       []models.StatCount{{Name: "rock", Count: 6}},
+					TopArtists:      []models.StatCount{{Name: "Artist", Count: 3}},
+				}
+			}
+			mockService.EXPECT().GetChildPlaylistStats(gomock.Any(), "user123", "child123").Return(stats, tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodGet, "/api/child_playlist/child123/stats", "")
+			req.SetPathValue("id", "child123")
+			w := httptest.NewRecorder()
+			controller.GetStats(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.ChildPlaylistStats
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.Equal(*stats, result)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
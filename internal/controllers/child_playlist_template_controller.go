@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// ChildPlaylistTemplateController manages the child playlist templates and creates their child playlists on base playlists
+type ChildPlaylistTemplateController struct {
+	templateService services.ChildPlaylistTemplateServicer
+	validator       *validator.Validate
+}
+
+func NewChildPlaylistTemplateController(templateService services.ChildPlaylistTemplateServicer) *ChildPlaylistTemplateController {
+	return &ChildPlaylistTemplateController{
+		templateService: templateService,
+		validator:       newValidator(),
+	}
+}
+
+// List returns the built-in templates and the ones created by the user
+func (c *ChildPlaylistTemplateController) List(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	templates, err := c.templateService.ListTemplates(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve templates")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(templates); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *ChildPlaylistTemplateController) GetByID(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	templateID := r.PathValue("id")
+	if templateID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "template ID is required")
+		return
+	}
+
+	template, err := c.templateService.GetTemplate(r.Context(), user.ID, templateID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistTemplateNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeTemplateNotFound, "template not found")
+			return
+		}
+
+		writeError(w, err, "unable to retrieve template")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(template); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *ChildPlaylistTemplateController) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateChildPlaylistTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	template, err := c.templateService.CreateTemplate(r.Context(), user.ID, &req)
+	if err != nil {
+		writeError(w, err, "unable to create template")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(template); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *ChildPlaylistTemplateController) Delete(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	templateID := r.PathValue("id")
+	if templateID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "template ID is required")
+		return
+	}
+
+	err := c.templateService.DeleteTemplate(r.Context(), user.ID, templateID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistTemplateNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeTemplateNotFound, "template not found")
+			return
+		}
+
+		writeError(w, err, "unable to delete template")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Instantiate creates every child playlist of the template on the base playlist in the request
+func (c *ChildPlaylistTemplateController) Instantiate(w http.ResponseWriter, r *http.Request) {
+	var req models.InstantiateChildPlaylistTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	templateID := r.PathValue("id")
+	if templateID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "template ID is required")
+		return
+	}
+
+	childPlaylists, err := c.templateService.InstantiateTemplate(r.Context(), user.ID, templateID, req.BasePlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistTemplateNotFound) {
+			problem.Write(w, http.StatusNotFound, problem.CodeTemplateNotFound, "template not found")
+			return
+		}
+
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to instantiate template: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(childPlaylists); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
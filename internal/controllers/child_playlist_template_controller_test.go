@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildPlaylistTemplateController_List(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockChildPlaylistTemplateServicer(gomock.NewController(t))
+	controller := NewChildPlaylistTemplateController(mockService)
+
+	mockService.EXPECT().ListTemplates(gomock.Any(), "user123").Return(models.BuiltInChildPlaylistTemplates(), nil)
+
+	w := httptest.NewRecorder()
+	controller.List(w, newAutomationRequest(http.MethodGet, "/api/templates", ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+	var templates []models.ChildPlaylistTemplate
+	assert.NoError(json.NewDecoder(w.Body).Decode(&templates))
+	assert.Len(templates, 3)
+	assert.Equal("Workout (high energy)", templates[0].Name)
+}
+
+func TestChildPlaylistTemplateController_GetByID(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "not found", serviceErr: fmt.Errorf("failed to retrieve child playlist template: %w", repositories.ErrChildPlaylistTemplateNotFound), expectedStatus: http.StatusNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to retrieve child playlist template: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockChildPlaylistTemplateServicer(gomock.NewController(t))
+			controller := NewChildPlaylistTemplateController(mockService)
+
+			var template *models.ChildPlaylistTemplate
+			if tt.serviceErr == nil {
+				template = &models.ChildPlaylistTemplate{ID: "template1", Name: "Party"}
+			}
+			mockService.EXPECT().GetTemplate(gomock.Any(), "user123", "template1").Return(template, tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodGet, "/api/templates/template1", "")
+			req.SetPathValue("id", "template1")
+			w := httptest.NewRecorder()
+			controller.GetByID(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestChildPlaylistTemplateController_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"name":"Party","children":[{"name":"Bangers","filter_rules":{"energy":{"min":0.8}}}]}`,
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "no children",
+			body:           `{"name":"Party","children":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "child without name",
+			body:           `{"name":"Party","children":[{"description":"nameless"}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid template",
+			body:           `{"name":"Party","children":[{"name":"A","is_fallback":true},{"name":"B","is_fallback":true}]}`,
+			serviceErr:     fmt.Errorf("%w: only one child can be the fallback", services.ErrInvalidChildPlaylistTemplate),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "service error",
+			body:           `{"name":"Party","children":[{"name":"Bangers"}]}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockChildPlaylistTemplateServicer(gomock.NewController(t))
+			controller := NewChildPlaylistTemplateController(mockService)
+
+			if tt.expectCall {
+				var created *models.ChildPlaylistTemplate
+				if tt.serviceErr == nil {
+					created = &models.ChildPlaylistTemplate{ID: "template1", Name: "Party"}
+				}
+				mockService.EXPECT().CreateTemplate(gomock.Any(), "user123", gomock.Any()).Return(created, tt.serviceErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.Create(w, newAutomationRequest(http.MethodPost, "/api/templates", tt.body))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestChildPlaylistTemplateController_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusNoContent},
+		{name: "built-in", serviceErr: services.ErrBuiltInTemplateReadOnly, expectedStatus: http.StatusForbidden},
+		{name: "not found", serviceErr: fmt.Errorf("failed to delete child playlist template: %w", repositories.ErrChildPlaylistTemplateNotFound), expectedStatus: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockChildPlaylistTemplateServicer(gomock.NewController(t))
+			controller := NewChildPlaylistTemplateController(mockService)
+
+			mockService.EXPECT().DeleteTemplate(gomock.Any(), "user123", "template1").Return(tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodDelete, "/api/templates/template1", "")
+			req.SetPathValue("id", "template1")
+			w := httptest.NewRecorder()
+			controller.Delete(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestChildPlaylistTemplateController_Instantiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"base_playlist_id":"bp1"}`,
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing base playlist",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "template not found",
+			body:           `{"base_playlist_id":"bp1"}`,
+			serviceErr:     fmt.Errorf("failed to retrieve child playlist template: %w", repositories.ErrChildPlaylistTemplateNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "base playlist not found",
+			body:           `{"base_playlist_id":"bp1"}`,
+			serviceErr:     fmt.Errorf("failed to create child playlist \"Workout\": %w", repositories.ErrBasePlaylistNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			body:           `{"base_playlist_id":"bp1"}`,
+			serviceErr:     errors.New("spotify error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockChildPlaylistTemplateServicer(gomock.NewController(t))
+			controller := NewChildPlaylistTemplateController(mockService)
+
+			if tt.expectCall {
+				var childPlaylists []*models.ChildPlaylist
+				if tt.serviceErr == nil {
+					childPlaylists = []*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithID("child1").WithName("Workout").Build(), testfixtures.NewChildPlaylist().WithID("child2").WithName("Cool Down").Build()}
+				}
+				mockService.EXPECT().InstantiateTemplate(gomock.Any(), "user123", models.TemplateIDWorkout, "bp1").Return(childPlaylists, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/templates/"+models.TemplateIDWorkout+"/instantiate", tt.body)
+			req.SetPathValue("id", models.TemplateIDWorkout)
+			w := httptest.NewRecorder()
+			controller.Instantiate(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var body []models.ChildPlaylist
+				assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+				assert.Len(body, 2)
+			}
+		})
+	}
+}
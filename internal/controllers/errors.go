@@ -1 +1,115 @@
 package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// errorResponse is how a sentinel error of the lower layers is reported to clients
+type errorResponse struct {
+	err    error
+	status int
+	code   problem.Code
+	detail string // Replaces the error message when it would leak details, empty to keep it
+}
+
+// errorResponses maps the sentinel errors to their status and code, so every controller reports
+// them the same way. The first match wins
+var errorResponses = []errorResponse{
+	// Sync orchestration
+	{err: orchestrators.ErrSyncInProgress, status: http.StatusConflict, code: problem.CodeSyncInProgress},
+	{err: orchestrators.ErrSyncAnomalyDetected, status: http.StatusConflict, code: problem.CodeSyncNeedsConfirmation},
+	{err: orchestrators.ErrSyncNotAwaitingConfirmation, status: http.StatusConflict, code: problem.CodeSyncNotAwaitingConfirmation},
+	{err: orchestrators.ErrSyncNotRetryable, status: http.StatusConflict, code: problem.CodeSyncNotRetryable},
+	{err: orchestrators.ErrNothingToRollback, status: http.StatusConflict, code: problem.CodeNothingToRollback},
+	{err: orchestrators.ErrBasePlaylistArchived, status: http.StatusConflict, code: problem.CodeBasePlaylistArchived},
+	{err: orchestrators.ErrMaintenanceMode, status: http.StatusServiceUnavailable, code: problem.CodeMaintenance},
+	{err: orchestrators.ErrSyncEventNotFound, status: http.StatusNotFound, code: problem.CodeSyncEventNotFound},
+	{err: orchestrators.ErrRoutingReportNotFound, status: http.StatusNotFound, code: problem.CodeRoutingReportNotFound},
+
+	// Music provider
+	{err: musicprovider.ErrRateLimited, status: http.StatusTooManyRequests, code: problem.CodeSpotifyRateLimited},
+	{err: musicprovider.ErrCoverImageTooLarge, status: http.StatusRequestEntityTooLarge, code: problem.CodeCoverImageTooLarge},
+	{err: musicprovider.ErrPlaylistNotFound, status: http.StatusNotFound, code: problem.CodePlaylistNotFound},
+	{err: services.ErrSpotifyPlaylistNotOwned, status: http.StatusForbidden, code: problem.CodeSpotifyPlaylistNotOwned},
+	{err: services.ErrSpotifyIntegrationUnavailable, status: http.StatusBadRequest, code: problem.CodeSpotifyIntegrationRequired, detail: "spotify account not linked"},
+	{err: services.ErrSpotifyTokenRefresh, status: http.StatusUnauthorized, code: problem.CodeSpotifyTokenRefreshFailed},
+	{err: services.ErrSpotifyAccountLinked, status: http.StatusConflict, code: problem.CodeSpotifyAccountLinked},
+	{err: services.ErrDefaultSpotifyAccount, status: http.StatusConflict, code: problem.CodeDefaultSpotifyAccount},
+	{err: services.ErrSpotifyAccountInUse, status: http.StatusConflict, code: problem.CodeSpotifyAccountInUse},
+	{err: musicprovider.ErrNotSupported, status: http.StatusBadRequest, code: problem.CodeNotSupportedByProvider},
+	{err: musicprovider.ErrProviderUnavailable, status: http.StatusServiceUnavailable, code: problem.CodeProviderUnavailable},
+	{err: services.ErrYouTubeMusicIntegrationUnavailable, status: http.StatusBadRequest, code: problem.CodeYouTubeMusicIntegrationRequired, detail: "youtube music account not linked"},
+	{err: services.ErrYouTubeMusicTokenRefresh, status: http.StatusUnauthorized, code: problem.CodeYouTubeMusicTokenRefreshFailed},
+	{err: services.ErrYouTubeMusicAccountInUse, status: http.StatusConflict, code: problem.CodeYouTubeMusicAccountInUse},
+	{err: services.ErrYouTubeMusicAuthStateInvalid, status: http.StatusBadRequest, code: problem.CodeYouTubeMusicAuthInvalid},
+	{err: services.ErrTidalIntegrationUnavailable, status: http.StatusBadRequest, code: problem.CodeTidalIntegrationRequired, detail: "tidal account not linked"},
+	{err: services.ErrTidalTokenRefresh, status: http.StatusUnauthorized, code: problem.CodeTidalTokenRefreshFailed},
+	{err: services.ErrTidalAccountInUse, status: http.StatusConflict, code: problem.CodeTidalAccountInUse},
+	{err: services.ErrTidalLinkInvalid, status: http.StatusBadRequest, code: problem.CodeTidalLinkInvalid},
+	{err: services.ErrLastFmIntegrationUnavailable, status: http.StatusBadRequest, code: problem.CodeLastFmIntegrationRequired, detail: "lastfm account not linked"},
+	{err: services.ErrLastFmAuthStateInvalid, status: http.StatusBadRequest, code: problem.CodeLastFmAuthInvalid},
+
+	// Invalid input caught by the services
+	{err: services.ErrInvalidChildPlaylistOrder, status: http.StatusBadRequest, code: problem.CodeInvalidChildPlaylistOrder},
+	{err: services.ErrTooManyPinnedTracks, status: http.StatusBadRequest, code: problem.CodeTooManyPinnedTracks},
+	{err: services.ErrCloneSameSpotifyPlaylist, status: http.StatusBadRequest, code: problem.CodeSameSpotifyPlaylist},
+	{err: services.ErrInvalidChildPlaylistTemplate, status: http.StatusBadRequest, code: problem.CodeInvalidTemplate},
+	{err: services.ErrBuiltInTemplateReadOnly, status: http.StatusForbidden, code: problem.CodeBuiltInTemplateReadOnly},
+	{err: services.ErrInvalidRoutingConfig, status: http.StatusBadRequest, code: problem.CodeInvalidRoutingConfig},
+	{err: services.ErrNoTracksMatched, status: http.StatusBadRequest, code: problem.CodeNoTracksMatched},
+	{err: services.ErrInvalidWebhookURL, status: http.StatusBadRequest, code: problem.CodeInvalidWebhookURL},
+	{err: services.ErrInvalidBlocklistEntry, status: http.StatusBadRequest, code: problem.CodeInvalidBlocklistEntry},
+	{err: services.ErrBlocklistEntryExists, status: http.StatusConflict, code: problem.CodeBlocklistEntryExists},
+	{err: services.ErrInvalidNotificationPreferences, status: http.StatusBadRequest, code: problem.CodeInvalidNotificationSettings},
+	{err: services.ErrInvalidFeatureFlag, status: http.StatusBadRequest, code: problem.CodeInvalidFeatureFlag},
+	{err: services.ErrInvalidAPIKeyExpiry, status: http.StatusBadRequest, code: problem.CodeInvalidAPIKeyExpiry},
+	{err: services.ErrInvalidAPIKey, status: http.StatusUnauthorized, code: problem.CodeInvalidAPIKey},
+
+	// Missing records
+	{err: repositories.ErrRestoreConflict, status: http.StatusConflict, code: problem.CodeRestoreConflict},
+	{err: repositories.ErrChildPlaylistModified, status: http.StatusConflict, code: problem.CodeChildPlaylistModified},
+	{err: repositories.ErrUserDisabled, status: http.StatusForbidden, code: problem.CodeAccountDisabled, detail: "account is disabled"},
+	{err: repositories.ErrUseNotFound, status: http.StatusNotFound, code: problem.CodeUserNotFound},
+	{err: repositories.ErrBasePlaylistNotFound, status: http.StatusNotFound, code: problem.CodeBasePlaylistNotFound},
+	{err: repositories.ErrChildPlaylistNotFound, status: http.StatusNotFound, code: problem.CodeChildPlaylistNotFound},
+	{err: repositories.ErrSpotifyIntegrationNotFound, status: http.StatusNotFound, code: problem.CodeSpotifyIntegrationNotFound},
+	{err: repositories.ErrYouTubeMusicIntegrationNotFound, status: http.StatusNotFound, code: problem.CodeYouTubeMusicIntegrationNotFound},
+	{err: repositories.ErrTidalIntegrationNotFound, status: http.StatusNotFound, code: problem.CodeTidalIntegrationNotFound},
+	{err: repositories.ErrLastFmIntegrationNotFound, status: http.StatusNotFound, code: problem.CodeLastFmIntegrationNotFound},
+	{err: repositories.ErrSyncEventNotFound, status: http.StatusNotFound, code: problem.CodeSyncEventNotFound},
+	{err: repositories.ErrRoutingReportNotFound, status: http.StatusNotFound, code: problem.CodeRoutingReportNotFound},
+	{err: repositories.ErrFilterRuleChangeNotFound, status: http.StatusNotFound, code: problem.CodeFilterRuleChangeNotFound},
+	{err: repositories.ErrPlaylistWebhookNotFound, status: http.StatusNotFound, code: problem.CodeWebhookNotFound},
+	{err: repositories.ErrChildPlaylistTemplateNotFound, status: http.StatusNotFound, code: problem.CodeTemplateNotFound},
+	{err: repositories.ErrBlocklistEntryNotFound, status: http.StatusNotFound, code: problem.CodeBlocklistEntryNotFound},
+	{err: repositories.ErrAPIKeyNotFound, status: http.StatusNotFound, code: problem.CodeAPIKeyNotFound},
+	{err: repositories.ErrFeatureFlagNotFound, status: http.StatusNotFound, code: problem.CodeFeatureFlagNotFound},
+	// Records of other users are reported as missing, without confirming they exist
+	{err: repositories.ErrUnauthorized, status: http.StatusNotFound, code: problem.CodeNotFound, detail: "resource not found"},
+}
+
+// writeError responds with the problem matching err. Unexpected errors are internal errors
+// described by detail, so their message doesn't leak
+func writeError(w http.ResponseWriter, err error, detail string) {
+	for _, response := range errorResponses {
+		if !errors.Is(err, response.err) {
+			continue
+		}
+
+		message := response.detail
+		if message == "" {
+			message = err.Error()
+		}
+		problem.Write(w, response.status, response.code, message)
+		return
+	}
+
+	problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, detail)
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	youtubemusicclient "github.com/ngomez18/playlist-router/internal/clients/youtubemusic"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   problem.Code
+		expectedDetail string
+	}{
+		{
+			name:           "wrapped sentinel keeps its message",
+			err:            fmt.Errorf("%w for base playlist base123", orchestrators.ErrSyncInProgress),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   problem.CodeSyncInProgress,
+			expectedDetail: "sync already in progress for base playlist base123",
+		},
+		{
+			name:           "sentinel with its own detail",
+			err:            services.ErrSpotifyIntegrationUnavailable,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeSpotifyIntegrationRequired,
+			expectedDetail: "spotify account not linked",
+		},
+		{
+			name:           "provider error wrapping a music provider sentinel",
+			err:            fmt.Errorf("failed to follow playlist: %w", youtubemusicclient.ErrNotSupported),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeNotSupportedByProvider,
+			expectedDetail: "failed to follow playlist: youtube music: not supported by the music provider",
+		},
+		{
+			name:           "records of other users are not found",
+			err:            repositories.ErrUnauthorized,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   problem.CodeNotFound,
+			expectedDetail: "resource not found",
+		},
+		{
+			name:           "unexpected error",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   problem.CodeInternalError,
+			expectedDetail: "unable to do the thing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			w := httptest.NewRecorder()
+			writeError(w, tt.err, "unable to do the thing")
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Equal(problem.CONTENT_TYPE, w.Header().Get("Content-Type"))
+
+			var body problem.Problem
+			assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(tt.expectedCode, body.Code)
+			assert.Equal(tt.expectedDetail, body.Detail)
+		})
+	}
+}
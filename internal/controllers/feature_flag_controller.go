@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// FeatureFlagController lets admins roll the sync features out gradually and turn them off at runtime
+type FeatureFlagController struct {
+	featureFlagService services.FeatureFlagServicer
+	validator          *validator.Validate
+}
+
+func NewFeatureFlagController(featureFlagService services.FeatureFlagServicer) *FeatureFlagController {
+	return &FeatureFlagController{
+		featureFlagService: featureFlagService,
+		validator:          newValidator(),
+	}
+}
+
+func (c *FeatureFlagController) List(w http.ResponseWriter, r *http.Request) {
+	flags, err := c.featureFlagService.ListFeatureFlags(r.Context())
+	if err != nil {
+		writeError(w, err, "unable to retrieve feature flags")
+		return
+	}
+
+	writeAdminJSON(w, flags)
+}
+
+// Update creates or changes the flag of the key path parameter
+func (c *FeatureFlagController) Update(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "feature flag key is required")
+		return
+	}
+
+	var req models.UpdateFeatureFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	flag, err := c.featureFlagService.UpdateFeatureFlag(r.Context(), key, &req)
+	if err != nil {
+		writeError(w, err, "unable to update feature flag")
+		return
+	}
+
+	writeAdminJSON(w, flag)
+}
+
+// Delete drops the flag of the key path parameter, its feature follows the config again
+func (c *FeatureFlagController) Delete(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	if key == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "feature flag key is required")
+		return
+	}
+
+	if err := c.featureFlagService.DeleteFeatureFlag(r.Context(), key); err != nil {
+		writeError(w, err, "unable to delete feature flag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagController_List(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockFeatureFlagServicer(gomock.NewController(t))
+	controller := NewFeatureFlagController(mockService)
+
+	mockService.EXPECT().ListFeatureFlags(gomock.Any()).Return([]*models.FeatureFlag{
+		{Key: models.FeatureConcurrentSync, Enabled: true, RolloutPercentage: 10, UserIDs: []string{}},
+	}, nil)
+
+	req := newAutomationRequest(http.MethodGet, "/api/admin/feature_flags", "")
+	w := httptest.NewRecorder()
+	controller.List(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	var flags []models.FeatureFlag
+	assert.NoError(json.NewDecoder(w.Body).Decode(&flags))
+	assert.Len(flags, 1)
+	assert.Equal(10, flags[0].RolloutPercentage)
+}
+
+func TestFeatureFlagController_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{
+			name:           "success",
+			key:            models.FeatureConcurrentSync,
+			body:           `{"enabled":true,"rollout_percentage":25}`,
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid payload",
+			key:            models.FeatureConcurrentSync,
+			body:           `{"enabled":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidPayload,
+		},
+		{
+			name:           "rollout out of range",
+			key:            models.FeatureConcurrentSync,
+			body:           `{"rollout_percentage":101}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "empty user id",
+			key:            models.FeatureConcurrentSync,
+			body:           `{"user_ids":["user123",""]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "invalid key",
+			key:            "Concurrent-Sync",
+			body:           `{"enabled":true}`,
+			serviceErr:     fmt.Errorf("%w: key must be 1 to 100 lowercase letters, digits or underscores", services.ErrInvalidFeatureFlag),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidFeatureFlag,
+		},
+		{
+			name:           "service error",
+			key:            models.FeatureConcurrentSync,
+			body:           `{"enabled":true}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   problem.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockFeatureFlagServicer(gomock.NewController(t))
+			controller := NewFeatureFlagController(mockService)
+
+			if tt.expectCall {
+				var flag *models.FeatureFlag
+				if tt.serviceErr == nil {
+					flag = &models.FeatureFlag{Key: tt.key, Enabled: true, RolloutPercentage: 25, UserIDs: []string{}}
+				}
+				mockService.EXPECT().UpdateFeatureFlag(gomock.Any(), tt.key, gomock.Any()).Return(flag, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPut, "/api/admin/feature_flags/"+tt.key, tt.body)
+			req.SetPathValue("key", tt.key)
+			w := httptest.NewRecorder()
+			controller.Update(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.FeatureFlag
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.True(result.Enabled)
+				assert.Equal(25, result.RolloutPercentage)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
+
+func TestFeatureFlagController_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusNoContent},
+		{name: "not found", serviceErr: repositories.ErrFeatureFlagNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockFeatureFlagServicer(gomock.NewController(t))
+			controller := NewFeatureFlagController(mockService)
+
+			mockService.EXPECT().DeleteFeatureFlag(gomock.Any(), models.FeatureStreamingSync).Return(tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodDelete, "/api/admin/feature_flags/"+models.FeatureStreamingSync, "")
+			req.SetPathValue("key", models.FeatureStreamingSync)
+			w := httptest.NewRecorder()
+			controller.Delete(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
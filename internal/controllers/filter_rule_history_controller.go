@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+type FilterRuleHistoryController struct {
+	ruleHistoryService services.FilterRuleHistoryServicer
+	syncOrchestrator   orchestrators.SyncOrchestrator
+}
+
+func NewFilterRuleHistoryController(ruleHistoryService services.FilterRuleHistoryServicer, syncOrchestrator orchestrators.SyncOrchestrator) *FilterRuleHistoryController {
+	return &FilterRuleHistoryController{
+		ruleHistoryService: ruleHistoryService,
+		syncOrchestrator:   syncOrchestrator,
+	}
+}
+
+func (c *FilterRuleHistoryController) GetHistory(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	childPlaylistID := r.PathValue("id")
+	if childPlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID is required")
+		return
+	}
+
+	changes, err := c.ruleHistoryService.GetHistory(r.Context(), childPlaylistID, user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve filter rule history")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(changes); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// ComputeDiff computes on demand the routing diff of a filter rule change, replacing any stored one
+func (c *FilterRuleHistoryController) ComputeDiff(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	childPlaylistID := r.PathValue("id")
+	changeID := r.PathValue("changeID")
+	if childPlaylistID == "" || changeID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "child playlist ID and change ID are required")
+		return
+	}
+
+	change, err := c.ruleHistoryService.GetChange(r.Context(), changeID, user.ID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrFilterRuleChangeNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeFilterRuleChangeNotFound, "filter rule change not found")
+			return
+		}
+
+		writeError(w, err, "unable to retrieve filter rule change")
+		return
+	}
+	if change.ChildPlaylistID != childPlaylistID {
+		problem.Write(w, http.StatusNotFound, problem.CodeFilterRuleChangeNotFound, "filter rule change not found")
+		return
+	}
+
+	updatedChange, err := c.syncOrchestrator.ComputeRoutingDiff(r.Context(), user.ID, change.ID)
+	if err != nil {
+		writeError(w, err, "failed to compute routing diff: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(updatedChange); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
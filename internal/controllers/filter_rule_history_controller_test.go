@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	orchestratormocks "github.com/ngomez18/playlist-router/internal/orchestrators/mocks"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRuleHistoryController_GetHistory(t *testing.T) {
+	tests := []struct {
+		name           string
+		hasUser        bool
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "success",
+			hasUser:        true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "change123",
+		},
+		{
+			name:           "no user in context",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:           "service error",
+			hasUser:        true,
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "unable to retrieve filter rule history",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+			mockService := servicemocks.NewMockFilterRuleHistoryServicer(ctrl)
+			controller := NewFilterRuleHistoryController(mockService, orchestratormocks.NewMockSyncOrchestrator(ctrl))
+
+			if tt.hasUser {
+				if tt.serviceErr != nil {
+					mockService.EXPECT().GetHistory(gomock.Any(), "child123", user.ID).Return(nil, tt.serviceErr)
+				} else {
+					mockService.EXPECT().GetHistory(gomock.Any(), "child123", user.ID).
+						Return([]*models.FilterRuleChange{{ID: "change123", ChildPlaylistID: "child123"}}, nil)
+				}
+			}
+
+			req := httptest.NewRequest("GET", "/api/child_playlist/child123/rule_history", nil)
+			req.SetPathValue("id", "child123")
+			if tt.hasUser {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), user))
+			}
+
+			w := httptest.NewRecorder()
+			controller.GetHistory(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestFilterRuleHistoryController_ComputeDiff(t *testing.T) {
+	tests := []struct {
+		name            string
+		change          *models.FilterRuleChange
+		getChangeErr    error
+		orchestratorErr error
+		expectCompute   bool
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:           "success",
+			change:         &models.FilterRuleChange{ID: "change123", ChildPlaylistID: "child123"},
+			expectCompute:  true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "routing_diff",
+		},
+		{
+			name:           "change not found",
+			getChangeErr:   repositories.ErrFilterRuleChangeNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "filter rule change not found",
+		},
+		{
+			name:           "change of another user",
+			getChangeErr:   repositories.ErrUnauthorized,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "filter rule change not found",
+		},
+		{
+			name:           "change of another child playlist",
+			change:         &models.FilterRuleChange{ID: "change123", ChildPlaylistID: "other_child"},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "filter rule change not found",
+		},
+		{
+			name:            "orchestrator error",
+			change:          &models.FilterRuleChange{ID: "change123", ChildPlaylistID: "child123"},
+			orchestratorErr: errors.New("spotify down"),
+			expectCompute:   true,
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "failed to compute routing diff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+			mockService := servicemocks.NewMockFilterRuleHistoryServicer(ctrl)
+			mockOrchestrator := orchestratormocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewFilterRuleHistoryController(mockService, mockOrchestrator)
+
+			mockService.EXPECT().GetChange(gomock.Any(), "change123", user.ID).Return(tt.change, tt.getChangeErr)
+			if tt.expectCompute {
+				if tt.orchestratorErr != nil {
+					mockOrchestrator.EXPECT().ComputeRoutingDiff(gomock.Any(), user.ID, "change123").Return(nil, tt.orchestratorErr)
+				} else {
+					computed := *tt.change
+					computed.RoutingDiff = &models.RoutingDiff{AddedTrackURIs: []string{"spotify:track:1"}}
+					mockOrchestrator.EXPECT().ComputeRoutingDiff(gomock.Any(), user.ID, "change123").Return(&computed, nil)
+				}
+			}
+
+			req := httptest.NewRequest("POST", "/api/child_playlist/child123/rule_history/change123/diff", nil)
+			req.SetPathValue("id", "child123")
+			req.SetPathValue("changeID", "change123")
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), user))
+
+			w := httptest.NewRecorder()
+			controller.ComputeDiff(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
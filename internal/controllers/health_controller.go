@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// HealthController serves the liveness and readiness probes of the container orchestrator. Probes
+// only look at the status code: 200 while the app is up, even degraded, and 503 once it is down
+type HealthController struct {
+	healthService services.HealthServicer
+}
+
+func NewHealthController(healthService services.HealthServicer) *HealthController {
+	return &HealthController{
+		healthService: healthService,
+	}
+}
+
+// Liveness tells whether the app must be restarted
+func (c *HealthController) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, c.healthService.Liveness(r.Context()))
+}
+
+// Readiness tells whether the app can be sent requests
+func (c *HealthController) Readiness(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, c.healthService.Readiness(r.Context()))
+}
+
+func writeHealthReport(w http.ResponseWriter, report *models.HealthReport) {
+	statusCode := http.StatusOK
+	if report.Status == models.HealthStatusDown {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthController_Readiness(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         models.HealthStatus
+		expectedStatus int
+	}{
+		{name: "ok", status: models.HealthStatusOK, expectedStatus: http.StatusOK},
+		{name: "degraded", status: models.HealthStatusDegraded, expectedStatus: http.StatusOK},
+		{name: "down", status: models.HealthStatusDown, expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockHealthServicer(gomock.NewController(t))
+			controller := NewHealthController(mockService)
+
+			report := &models.HealthReport{
+				Status: tt.status,
+				Components: map[string]models.ComponentHealth{
+					models.HealthComponentDatabase: {Status: tt.status, Critical: true, LatencyMs: 2},
+				},
+			}
+			mockService.EXPECT().Readiness(gomock.Any()).Return(report)
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			w := httptest.NewRecorder()
+			controller.Readiness(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Equal("application/json", w.Header().Get("Content-Type"))
+			assert.Equal("no-store", w.Header().Get("Cache-Control"))
+
+			var body models.HealthReport
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.status, body.Status)
+			assert.Equal(report.Components, body.Components)
+		})
+	}
+}
+
+func TestHealthController_Liveness(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockHealthServicer(gomock.NewController(t))
+	controller := NewHealthController(mockService)
+
+	mockService.EXPECT().Liveness(gomock.Any()).Return(&models.HealthReport{Status: models.HealthStatusDown})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+	controller.Liveness(w, req)
+
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+}
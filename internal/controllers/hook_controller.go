@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// HookController serves the automation hooks of base playlists. They are authorized only by the
+// hook token in the path, so automations can call them without going through the OAuth flow. The
+// tokens of disabled users are rejected like their API keys
+type HookController struct {
+	basePlaylistService services.BasePlaylistServicer
+	syncEventService    services.SyncEventServicer
+	syncOrchestrator    orchestrators.SyncOrchestrator
+	spotifyAuth         services.SpotifyAuthProvider
+	userService         services.UserServicer
+}
+
+func NewHookController(
+	basePlaylistService services.BasePlaylistServicer,
+	syncEventService services.SyncEventServicer,
+	syncOrchestrator orchestrators.SyncOrchestrator,
+	spotifyAuth services.SpotifyAuthProvider,
+	userService services.UserServicer,
+) *HookController {
+	return &HookController{
+		basePlaylistService: basePlaylistService,
+		syncEventService:    syncEventService,
+		syncOrchestrator:    syncOrchestrator,
+		spotifyAuth:         spotifyAuth,
+		userService:         userService,
+	}
+}
+
+// TriggerSync syncs the base playlist the hook token belongs to
+func (c *HookController) TriggerSync(w http.ResponseWriter, r *http.Request) {
+	basePlaylist, ok := c.resolveHookToken(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, err := c.spotifyAuth.ContextWithSpotifyAuth(r.Context(), basePlaylist.UserID)
+	if err != nil {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeSpotifyIntegrationRequired, "spotify account is not connected")
+		return
+	}
+
+	syncEvent, err := c.syncOrchestrator.SyncBasePlaylist(ctx, basePlaylist.UserID, basePlaylist.ID)
+	switch {
+	case errors.Is(err, orchestrators.ErrSyncAnomalyDetected) && syncEvent != nil:
+		writeHookStatus(w, http.StatusConflict, &models.HookSyncStatus{
+			Playlist:    basePlaylist.Name,
+			Status:      string(syncEvent.Status),
+			Message:     fmt.Sprintf("Sync of %s is waiting for confirmation in the app", basePlaylist.Name),
+			SyncEventID: syncEvent.ID,
+		})
+		return
+	case errors.Is(err, orchestrators.ErrSyncInProgress):
+		writeHookStatus(w, http.StatusConflict, &models.HookSyncStatus{
+			Playlist: basePlaylist.Name,
+			Status:   string(models.SyncStatusInProgress),
+			Message:  fmt.Sprintf("%s is already syncing", basePlaylist.Name),
+		})
+		return
+	case errors.Is(err, orchestrators.ErrMaintenanceMode):
+		writeHookStatus(w, http.StatusServiceUnavailable, &models.HookSyncStatus{
+			Playlist: basePlaylist.Name,
+			Status:   string(models.SyncStatusFailed),
+			Message:  fmt.Sprintf("%s can't sync right now, syncs are paused for maintenance", basePlaylist.Name),
+		})
+		return
+	case err != nil:
+		writeHookStatus(w, http.StatusInternalServerError, &models.HookSyncStatus{
+			Playlist: basePlaylist.Name,
+			Status:   string(models.SyncStatusFailed),
+			Message:  fmt.Sprintf("Sync of %s failed", basePlaylist.Name),
+		})
+		return
+	}
+
+	writeHookStatus(w, http.StatusOK, &models.HookSyncStatus{
+		Playlist:        basePlaylist.Name,
+		Status:          string(syncEvent.Status),
+		Message:         fmt.Sprintf("Synced %s: %d tracks processed", basePlaylist.Name, syncEvent.TracksProcessed),
+		SyncEventID:     syncEvent.ID,
+		TracksProcessed: syncEvent.TracksProcessed,
+		LastSyncedAt:    syncEvent.CompletedAt,
+	})
+}
+
+// GetStatus reports whether the base playlist is syncing and when it was last synced
+func (c *HookController) GetStatus(w http.ResponseWriter, r *http.Request) {
+	basePlaylist, ok := c.resolveHookToken(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := c.getSyncStatus(r.Context(), basePlaylist)
+	if err != nil {
+		writeError(w, err, "unable to retrieve sync status")
+		return
+	}
+
+	writeHookStatus(w, http.StatusOK, status)
+}
+
+func (c *HookController) getSyncStatus(ctx context.Context, basePlaylist *models.BasePlaylist) (*models.HookSyncStatus, error) {
+	isSyncing, err := c.syncEventService.HasActiveSyncForBasePlaylist(ctx, basePlaylist.UserID, basePlaylist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSync, err := c.syncEventService.GetLastCompletedSyncEvent(ctx, basePlaylist.UserID, basePlaylist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.HookSyncStatus{Playlist: basePlaylist.Name}
+	if lastSync != nil {
+		status.SyncEventID = lastSync.ID
+		status.TracksProcessed = lastSync.TracksProcessed
+		status.LastSyncedAt = lastSync.CompletedAt
+	}
+
+	switch {
+	case isSyncing:
+		status.Status = string(models.SyncStatusInProgress)
+		status.Message = fmt.Sprintf("%s is syncing", basePlaylist.Name)
+	case lastSync != nil:
+		status.Status = string(models.SyncStatusCompleted)
+		status.Message = fmt.Sprintf("%s was last synced with %d tracks processed", basePlaylist.Name, lastSync.TracksProcessed)
+	default:
+		status.Status = models.HookStatusNeverSynced
+		status.Message = fmt.Sprintf("%s has not been synced yet", basePlaylist.Name)
+	}
+
+	return status, nil
+}
+
+func (c *HookController) resolveHookToken(w http.ResponseWriter, r *http.Request) (*models.BasePlaylist, bool) {
+	hookToken := r.PathValue("playlistToken")
+	if hookToken == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist token is required")
+		return nil, false
+	}
+
+	basePlaylist, err := c.basePlaylistService.GetBasePlaylistByHookToken(r.Context(), hookToken)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) {
+			problem.Write(w, http.StatusNotFound, problem.CodePlaylistNotFound, "playlist not found")
+			return nil, false
+		}
+
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to retrieve playlist")
+		return nil, false
+	}
+
+	owner, err := c.userService.GetUserByID(r.Context(), basePlaylist.UserID)
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to retrieve playlist")
+		return nil, false
+	}
+	if owner.Disabled {
+		problem.Write(w, http.StatusForbidden, problem.CodeAccountDisabled, "account is disabled")
+		return nil, false
+	}
+
+	return basePlaylist, true
+}
+
+func writeHookStatus(w http.ResponseWriter, statusCode int, status *models.HookSyncStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+	}
+}
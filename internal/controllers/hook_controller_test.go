@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	orchestratormocks "github.com/ngomez18/playlist-router/internal/orchestrators/mocks"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+type hookControllerMocks struct {
+	basePlaylistService *servicemocks.MockBasePlaylistServicer
+	syncEventService    *servicemocks.MockSyncEventServicer
+	syncOrchestrator    *orchestratormocks.MockSyncOrchestrator
+	spotifyAuth         *servicemocks.MockSpotifyAuthProvider
+	userService         *servicemocks.MockUserServicer
+}
+
+func setupHookController(t *testing.T) (*HookController, hookControllerMocks) {
+	ctrl := gomock.NewController(t)
+
+	mocks := hookControllerMocks{
+		basePlaylistService: servicemocks.NewMockBasePlaylistServicer(ctrl),
+		syncEventService:    servicemocks.NewMockSyncEventServicer(ctrl),
+		syncOrchestrator:    orchestratormocks.NewMockSyncOrchestrator(ctrl),
+		spotifyAuth:         servicemocks.NewMockSpotifyAuthProvider(ctrl),
+		userService:         servicemocks.NewMockUserServicer(ctrl),
+	}
+
+	controller := NewHookController(mocks.basePlaylistService, mocks.syncEventService, mocks.syncOrchestrator, mocks.spotifyAuth, mocks.userService)
+	return controller, mocks
+}
+
+func newHookRequest(method, token string) *http.Request {
+	req := httptest.NewRequest(method, "/hooks/sync/"+token, nil)
+	req.SetPathValue("playlistToken", token)
+	return req
+}
+
+func TestHookController_TriggerSync(t *testing.T) {
+	basePlaylist := testfixtures.NewBasePlaylist().
+		WithID("base123").
+		WithUserID("user123").
+		WithName("Liked Songs").
+		WithHookToken("hook123").
+		Build()
+	completedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		lookupErr      error
+		ownerDisabled  bool
+		authErr        error
+		syncEvent      *models.SyncEvent
+		syncErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			syncEvent: testfixtures.NewSyncEvent().
+				WithID("sync123").
+				WithStatus(models.SyncStatusCompleted).
+				WithStats(42, 0, 0).
+				WithCompletedAt(completedAt).
+				Build(),
+			expectedStatus: http.StatusOK,
+			expectedBody:   "Synced Liked Songs: 42 tracks processed",
+		},
+		{
+			name:           "unknown token",
+			lookupErr:      fmt.Errorf("failed to retrieve playlist: %w", repositories.ErrBasePlaylistNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "playlist not found",
+		},
+		{
+			name:           "disabled account",
+			ownerDisabled:  true,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "account is disabled",
+		},
+		{
+			name:           "spotify not connected",
+			authErr:        errors.New("no spotify integration available for user"),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "spotify account is not connected",
+		},
+		{
+			name:           "anomaly detected",
+			syncEvent:      testfixtures.NewSyncEvent().WithID("sync123").WithStatus(models.SyncStatusNeedsConfirmation).Build(),
+			syncErr:        orchestrators.ErrSyncAnomalyDetected,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "waiting for confirmation",
+		},
+		{
+			name:           "sync in progress",
+			syncErr:        fmt.Errorf("%w for base playlist base123", orchestrators.ErrSyncInProgress),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "is already syncing",
+		},
+		{
+			name:           "maintenance mode",
+			syncErr:        fmt.Errorf("%w: Upgrading", orchestrators.ErrMaintenanceMode),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "syncs are paused for maintenance",
+		},
+		{
+			name:           "sync failure",
+			syncErr:        errors.New("spotify down"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Sync of Liked Songs failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mocks := setupHookController(t)
+
+			if tt.lookupErr != nil {
+				mocks.basePlaylistService.EXPECT().GetBasePlaylistByHookToken(gomock.Any(), "hook123").Return(nil, tt.lookupErr)
+			} else {
+				mocks.basePlaylistService.EXPECT().GetBasePlaylistByHookToken(gomock.Any(), "hook123").Return(basePlaylist, nil)
+				mocks.userService.EXPECT().GetUserByID(gomock.Any(), "user123").Return(&models.User{ID: "user123", Disabled: tt.ownerDisabled}, nil)
+
+				switch {
+				case tt.ownerDisabled:
+					// Rejected before anything is synced
+				case tt.authErr != nil:
+					mocks.spotifyAuth.EXPECT().ContextWithSpotifyAuth(gomock.Any(), "user123").Return(nil, tt.authErr)
+				default:
+					mocks.spotifyAuth.EXPECT().ContextWithSpotifyAuth(gomock.Any(), "user123").
+						DoAndReturn(func(ctx context.Context, userID string) (context.Context, error) { return ctx, nil })
+					mocks.syncOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), "user123", "base123").Return(tt.syncEvent, tt.syncErr)
+				}
+			}
+
+			w := httptest.NewRecorder()
+			controller.TriggerSync(w, newHookRequest(http.MethodPost, "hook123"))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestHookController_GetStatus(t *testing.T) {
+	basePlaylist := testfixtures.NewBasePlaylist().
+		WithID("base123").
+		WithUserID("user123").
+		WithName("Liked Songs").
+		WithHookToken("hook123").
+		Build()
+	completedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		isSyncing      bool
+		lastSync       *models.SyncEvent
+		serviceErr     error
+		expectedStatus int
+		expectedState  string
+	}{
+		{
+			name: "last completed sync",
+			lastSync: testfixtures.NewSyncEvent().
+				WithID("sync123").
+				WithStatus(models.SyncStatusCompleted).
+				WithStats(42, 0, 0).
+				WithCompletedAt(completedAt).
+				Build(),
+			expectedStatus: http.StatusOK,
+			expectedState:  string(models.SyncStatusCompleted),
+		},
+		{
+			name:           "syncing",
+			isSyncing:      true,
+			expectedStatus: http.StatusOK,
+			expectedState:  string(models.SyncStatusInProgress),
+		},
+		{
+			name:           "never synced",
+			expectedStatus: http.StatusOK,
+			expectedState:  models.HookStatusNeverSynced,
+		},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mocks := setupHookController(t)
+
+			mocks.basePlaylistService.EXPECT().GetBasePlaylistByHookToken(gomock.Any(), "hook123").Return(basePlaylist, nil)
+			mocks.userService.EXPECT().GetUserByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+			mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), "user123", "base123").Return(tt.isSyncing, tt.serviceErr)
+			if tt.serviceErr == nil {
+				mocks.syncEventService.EXPECT().GetLastCompletedSyncEvent(gomock.Any(), "user123", "base123").Return(tt.lastSync, nil)
+			}
+
+			w := httptest.NewRecorder()
+			controller.GetStatus(w, newHookRequest(http.MethodGet, "hook123"))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.serviceErr != nil {
+				return
+			}
+
+			var status models.HookSyncStatus
+			assert.NoError(json.Unmarshal(w.Body.Bytes(), &status))
+			assert.Equal("Liked Songs", status.Playlist)
+			assert.Equal(tt.expectedState, status.Status)
+			if tt.lastSync != nil {
+				assert.Equal(42, status.TracksProcessed)
+				assert.Equal(&completedAt, status.LastSyncedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// LastFmController links the last.fm account of the user, whose scrobbles child playlists can
+// filter on
+type LastFmController struct {
+	lastFmAccountService services.LastFmAccountServicer
+	config               *config.Config
+}
+
+func NewLastFmController(lastFmAccountService services.LastFmAccountServicer, config *config.Config) *LastFmController {
+	return &LastFmController{
+		lastFmAccountService: lastFmAccountService,
+		config:               config,
+	}
+}
+
+// Link starts linking a last.fm account to the authenticated user. The authorization URL is
+// returned instead of redirecting, since the browser can't send the auth token on a redirect
+func (c *LastFmController) Link(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	authURL, err := c.lastFmAccountService.GenerateLinkURL(user.ID)
+	if err != nil {
+		writeError(w, err, "unable to start lastfm account link")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]string{"auth_url": authURL}); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+	}
+}
+
+// Callback completes the link Last.fm redirects back to, then sends the user to the frontend
+func (c *LastFmController) Callback(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	state := r.URL.Query().Get("state")
+
+	if token == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "authorization token is required")
+		return
+	}
+
+	if _, err := c.lastFmAccountService.HandleCallback(r.Context(), token, state); err != nil {
+		writeError(w, err, "unable to link lastfm account")
+		return
+	}
+
+	redirectURL := fmt.Sprintf("%s/?lastfm=linked", c.config.Auth.FrontendURL)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
+// GetAccount returns the last.fm account linked by the user
+func (c *LastFmController) GetAccount(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	account, err := c.lastFmAccountService.GetAccount(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve lastfm account")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(account); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+// Unlink removes the last.fm account of the user
+func (c *LastFmController) Unlink(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	if err := c.lastFmAccountService.Unlink(r.Context(), user.ID); err != nil {
+		writeError(w, err, "unable to unlink lastfm account")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLastFmController(t *testing.T) (*LastFmController, *mocks.MockLastFmAccountServicer) {
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockLastFmAccountServicer(ctrl)
+	cfg := &config.Config{Auth: config.AuthConfig{FrontendURL: "http://localhost:5173"}}
+
+	return NewLastFmController(service, cfg), service
+}
+
+func TestLastFmController_Link(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupLastFmController(t)
+
+	service.EXPECT().GenerateLinkURL("test_user_123").Return("https://www.last.fm/api/auth/?api_key=key", nil)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodPost, "/auth/lastfm/link", nil))
+	w := httptest.NewRecorder()
+
+	controller.Link(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	var body map[string]string
+	assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal("https://www.last.fm/api/auth/?api_key=key", body["auth_url"])
+}
+
+func TestLastFmController_Callback(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		serviceErr       error
+		expectCall       bool
+		expectedStatus   int
+		expectedLocation string
+		expectedCode     problem.Code
+	}{
+		{
+			name:             "linked account redirects to the frontend",
+			query:            "?token=lastfm_token&state=state123",
+			expectCall:       true,
+			expectedStatus:   http.StatusTemporaryRedirect,
+			expectedLocation: "http://localhost:5173/?lastfm=linked",
+		},
+		{
+			name:           "missing token",
+			query:          "?state=state123",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeMissingParameter,
+		},
+		{
+			name:           "unknown state",
+			query:          "?token=lastfm_token&state=state123",
+			serviceErr:     services.ErrLastFmAuthStateInvalid,
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeLastFmAuthInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, service := setupLastFmController(t)
+
+			if tt.expectCall {
+				service.EXPECT().HandleCallback(gomock.Any(), "lastfm_token", "state123").
+					Return(&models.LastFmIntegration{ID: "lastfm_integration123"}, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/lastfm/callback"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			controller.Callback(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedLocation != "" {
+				assert.Equal(tt.expectedLocation, w.Header().Get("Location"))
+			}
+			if tt.expectedCode != "" {
+				assert.Equal(tt.expectedCode, decodeProblem(t, w).Code)
+			}
+		})
+	}
+}
+
+func TestLastFmController_GetAccount(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupLastFmController(t)
+
+	service.EXPECT().GetAccount(gomock.Any(), "test_user_123").
+		Return(&models.LastFmIntegration{ID: "lastfm_integration123", Username: "listener", SessionKey: "secret"}, nil)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodGet, "/api/lastfm/account", nil))
+	w := httptest.NewRecorder()
+
+	controller.GetAccount(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), `"username":"listener"`)
+	assert.NotContains(w.Body.String(), "secret")
+}
+
+func TestLastFmController_Unlink(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{name: "unlinked", expectedStatus: http.StatusNoContent},
+		{
+			name:           "no linked account",
+			serviceErr:     services.ErrLastFmIntegrationUnavailable,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeLastFmIntegrationRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, service := setupLastFmController(t)
+
+			service.EXPECT().Unlink(gomock.Any(), "test_user_123").Return(tt.serviceErr)
+
+			req := addUserToContext(httptest.NewRequest(http.MethodDelete, "/api/lastfm/account", nil))
+			w := httptest.NewRecorder()
+
+			controller.Unlink(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				assert.Equal(tt.expectedCode, decodeProblem(t, w).Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// MaintenanceController lets admins pause changes and syncs during upgrades or Spotify outages
+type MaintenanceController struct {
+	maintenanceService services.MaintenanceServicer
+	validator          *validator.Validate
+}
+
+func NewMaintenanceController(maintenanceService services.MaintenanceServicer) *MaintenanceController {
+	return &MaintenanceController{
+		maintenanceService: maintenanceService,
+		validator:          newValidator(),
+	}
+}
+
+func (c *MaintenanceController) Get(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, c.maintenanceService.Status(r.Context()))
+}
+
+// Update turns maintenance mode on or off, the message is shown to users whose changes are rejected
+func (c *MaintenanceController) Update(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateMaintenanceModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	status, err := c.maintenanceService.SetMaintenanceMode(r.Context(), &req)
+	if err != nil {
+		writeError(w, err, "unable to update maintenance mode")
+		return
+	}
+
+	writeAdminJSON(w, status)
+}
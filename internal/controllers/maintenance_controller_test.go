@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceController_Get(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockMaintenanceServicer(gomock.NewController(t))
+	controller := NewMaintenanceController(mockService)
+
+	mockService.EXPECT().Status(gomock.Any()).Return(&models.MaintenanceMode{Message: models.DEFAULT_MAINTENANCE_MESSAGE})
+
+	req := newAutomationRequest(http.MethodGet, "/api/admin/maintenance", "")
+	w := httptest.NewRecorder()
+	controller.Get(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	var status models.MaintenanceMode
+	assert.NoError(json.NewDecoder(w.Body).Decode(&status))
+	assert.False(status.Enabled)
+	assert.Equal(models.DEFAULT_MAINTENANCE_MESSAGE, status.Message)
+}
+
+func TestMaintenanceController_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{
+			name:           "success",
+			body:           `{"enabled":true,"message":"Spotify is down, changes are paused"}`,
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid payload",
+			body:           `{"enabled":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidPayload,
+		},
+		{
+			name:           "missing enabled",
+			body:           `{"message":"Upgrading"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "message too long",
+			body:           `{"enabled":true,"message":"` + strings.Repeat("a", 501) + `"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "service error",
+			body:           `{"enabled":false}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   problem.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockMaintenanceServicer(gomock.NewController(t))
+			controller := NewMaintenanceController(mockService)
+
+			if tt.expectCall {
+				var status *models.MaintenanceMode
+				if tt.serviceErr == nil {
+					status = &models.MaintenanceMode{Enabled: true, Message: "Spotify is down, changes are paused"}
+				}
+				mockService.EXPECT().SetMaintenanceMode(gomock.Any(), gomock.Any()).Return(status, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPut, "/api/admin/maintenance", tt.body)
+			w := httptest.NewRecorder()
+			controller.Update(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.MaintenanceMode
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.True(result.Enabled)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
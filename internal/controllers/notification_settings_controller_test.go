@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSettingsController_Get(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockNotificationServicer(gomock.NewController(t))
+			controller := NewNotificationSettingsController(mockService)
+
+			var preferences *models.NotificationPreferences
+			if tt.serviceErr == nil {
+				preferences = models.DefaultNotificationPreferences("user123")
+			}
+			mockService.EXPECT().GetPreferences(gomock.Any(), "user123").Return(preferences, tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodGet, "/api/settings/notifications", "")
+			w := httptest.NewRecorder()
+			controller.Get(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.NotificationPreferences
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.Equal(models.DEFAULT_SYNC_FAILURE_THRESHOLD, result.SyncFailureThreshold)
+			}
+		})
+	}
+}
+
+func TestNotificationSettingsController_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{
+			name:           "success",
+			body:           `{"email_on_sync_failure":false,"sync_failure_threshold":5}`,
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid payload",
+			body:           `{"sync_failure_threshold":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidPayload,
+		},
+		{
+			name:           "threshold out of range",
+			body:           `{"sync_failure_threshold":0}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "unknown channel",
+			body:           `{"sync_summary_channels":["teams"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "invalid webhook url",
+			body:           `{"sync_summary_channels":["slack"],"slack_webhook_url":"https://example.com/hook"}`,
+			serviceErr:     fmt.Errorf("%w: slack_webhook_url is not a slack webhook url", services.ErrInvalidNotificationPreferences),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidNotificationSettings,
+		},
+		{
+			name:           "service error",
+			body:           `{"email_on_sync_failure":true}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   problem.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockNotificationServicer(gomock.NewController(t))
+			controller := NewNotificationSettingsController(mockService)
+
+			if tt.expectCall {
+				var preferences *models.NotificationPreferences
+				if tt.serviceErr == nil {
+					preferences = &models.NotificationPreferences{UserID: "user123", SyncFailureThreshold: 5}
+				}
+				mockService.EXPECT().UpdatePreferences(gomock.Any(), "user123", gomock.Any()).Return(preferences, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPut, "/api/settings/notifications", tt.body)
+			w := httptest.NewRecorder()
+			controller.Update(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.NotificationPreferences
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.False(result.EmailOnSyncFailure)
+				assert.Equal(5, result.SyncFailureThreshold)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
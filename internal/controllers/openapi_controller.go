@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/ngomez18/playlist-router/internal/problem"
+)
+
+// swaggerUIVersion pins the Swagger UI release loaded from the CDN
+const swaggerUIVersion = "5.17.14"
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>PlaylistRouter API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js" crossorigin></script>
+<script>
+window.ui = SwaggerUIBundle({url: {{.SpecURL}}, dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`))
+
+type OpenAPIController struct {
+	spec    []byte
+	specURL string
+}
+
+// NewOpenAPIController serves spec, the OpenAPI document of the API, which the Swagger UI page
+// loads from specURL
+func NewOpenAPIController(spec []byte, specURL string) *OpenAPIController {
+	return &OpenAPIController{
+		spec:    spec,
+		specURL: specURL,
+	}
+}
+
+// GetSpec responds with the OpenAPI document, readable from any origin so clients can be
+// generated from the deployed API
+func (c *OpenAPIController) GetSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(c.spec)
+}
+
+// GetDocs serves the Swagger UI page browsing the OpenAPI document
+func (c *OpenAPIController) GetDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := swaggerUITemplate.Execute(w, map[string]string{
+		"Version": swaggerUIVersion,
+		"SpecURL": c.specURL,
+	})
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to render docs")
+	}
+}
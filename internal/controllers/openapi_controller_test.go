@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIController_GetSpec(t *testing.T) {
+	assert := require.New(t)
+
+	spec := []byte(`{"openapi":"3.0.3"}`)
+	controller := NewOpenAPIController(spec, "/api/openapi.json")
+
+	w := httptest.NewRecorder()
+	controller.GetSpec(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+	assert.Equal("*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(spec, w.Body.Bytes())
+}
+
+func TestOpenAPIController_GetDocs(t *testing.T) {
+	assert := require.New(t)
+
+	controller := NewOpenAPIController([]byte(`{}`), "/api/openapi.json")
+
+	w := httptest.NewRecorder()
+	controller.GetDocs(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(w.Body.String(), "swagger-ui-dist@"+swaggerUIVersion+"/swagger-ui-bundle.js")
+	assert.Contains(w.Body.String(), `url: "/api/openapi.json"`)
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+)
+
+// parsePagination reads the limit and offset query parameters, responding with a bad request when
+// they are not numbers. Missing ones are left to the service defaults
+func parsePagination(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	values := [2]int{}
+	for i, name := range []string{"limit", "offset"} {
+		raw := r.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid "+name)
+			return 0, 0, false
+		}
+		values[i] = value
+	}
+
+	return values[0], values[1], true
+}
+
+// parseSort reads the sort query parameter, which has to be one of allowed. Missing ones are left
+// to the repository default
+func parseSort(w http.ResponseWriter, r *http.Request, allowed ...models.ListSort) (models.ListSort, bool) {
+	sort := models.ListSort(r.URL.Query().Get("sort"))
+	if sort != "" && !slices.Contains(allowed, sort) {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid sort")
+		return "", false
+	}
+
+	return sort, true
+}
+
+// parseBoolParam reads an optional boolean query parameter, nil when it is missing
+func parseBoolParam(w http.ResponseWriter, r *http.Request, name string) (*bool, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, true
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid "+name)
+		return nil, false
+	}
+
+	return &value, true
+}
+
+// parseTimeParam reads an optional RFC 3339 query parameter, the zero time when it is missing
+func parseTimeParam(w http.ResponseWriter, r *http.Request, name string) (time.Time, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return time.Time{}, true
+	}
+
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid "+name)
+		return time.Time{}, false
+	}
+
+	return value, true
+}
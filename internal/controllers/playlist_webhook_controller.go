@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// PlaylistWebhookController manages the webhooks notified when a base playlist changes outside of the router
+type PlaylistWebhookController struct {
+	webhookService services.PlaylistWebhookServicer
+	validator      *validator.Validate
+}
+
+func NewPlaylistWebhookController(webhookService services.PlaylistWebhookServicer) *PlaylistWebhookController {
+	return &PlaylistWebhookController{
+		webhookService: webhookService,
+		validator:      newValidator(),
+	}
+}
+
+// Create registers a webhook on the base playlist. The signing secret is only included in this response
+func (c *PlaylistWebhookController) Create(w http.ResponseWriter, r *http.Request) {
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	var req models.CreatePlaylistWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	webhook, err := c.webhookService.CreateWebhook(r.Context(), user.ID, basePlaylistID, req.URL)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to create webhook")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(webhook); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *PlaylistWebhookController) List(w http.ResponseWriter, r *http.Request) {
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	webhooks, err := c.webhookService.ListWebhooks(r.Context(), user.ID, basePlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to retrieve webhooks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(webhooks); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *PlaylistWebhookController) Delete(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	webhookID := r.PathValue("id")
+	if webhookID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "webhook ID is required")
+		return
+	}
+
+	err := c.webhookService.DeleteWebhook(r.Context(), user.ID, webhookID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrPlaylistWebhookNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeWebhookNotFound, "webhook not found")
+			return
+		}
+
+		writeError(w, err, "unable to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
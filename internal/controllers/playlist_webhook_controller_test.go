@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistWebhookController_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"url":"https://example.com/hook"}`,
+			expectCall:     true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid url",
+			body:           `{"url":"ftp://example.com/hook"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "base playlist not found",
+			body:           `{"url":"https://example.com/hook"}`,
+			serviceErr:     fmt.Errorf("failed to get base playlist: %w", repositories.ErrBasePlaylistNotFound),
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "service error",
+			body:           `{"url":"https://example.com/hook"}`,
+			serviceErr:     errors.New("db error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockPlaylistWebhookServicer(gomock.NewController(t))
+			controller := NewPlaylistWebhookController(mockService)
+
+			if tt.expectCall {
+				var created *models.CreatedPlaylistWebhook
+				if tt.serviceErr == nil {
+					created = &models.CreatedPlaylistWebhook{
+						PlaylistWebhook: &models.PlaylistWebhook{ID: "wh1", Secret: "secret"},
+						Secret:          "secret",
+					}
+				}
+				mockService.EXPECT().CreateWebhook(gomock.Any(), "user123", "bp1", "https://example.com/hook").Return(created, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/base_playlist/bp1/webhooks", tt.body)
+			req.SetPathValue("basePlaylistID", "bp1")
+			w := httptest.NewRecorder()
+			controller.Create(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var body map[string]any
+				assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal("wh1", body["id"])
+				assert.Equal("secret", body["secret"])
+			}
+		})
+	}
+}
+
+func TestPlaylistWebhookController_List(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockPlaylistWebhookServicer(gomock.NewController(t))
+	controller := NewPlaylistWebhookController(mockService)
+
+	webhooks := []*models.PlaylistWebhook{{ID: "wh1", URL: "https://example.com/hook", Secret: "secret123"}}
+	mockService.EXPECT().ListWebhooks(gomock.Any(), "user123", "bp1").Return(webhooks, nil)
+
+	req := newAutomationRequest(http.MethodGet, "/api/base_playlist/bp1/webhooks", "")
+	req.SetPathValue("basePlaylistID", "bp1")
+	w := httptest.NewRecorder()
+	controller.List(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	// The signing secret is never listed
+	assert.NotContains(w.Body.String(), "secret123")
+}
+
+func TestPlaylistWebhookController_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusNoContent},
+		{name: "not found", serviceErr: fmt.Errorf("failed to delete playlist webhook: %w", repositories.ErrPlaylistWebhookNotFound), expectedStatus: http.StatusNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to delete playlist webhook: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockPlaylistWebhookServicer(gomock.NewController(t))
+			controller := NewPlaylistWebhookController(mockService)
+
+			mockService.EXPECT().DeleteWebhook(gomock.Any(), "user123", "wh1").Return(tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodDelete, "/api/webhooks/wh1", "")
+			req.SetPathValue("id", "wh1")
+			w := httptest.NewRecorder()
+			controller.Delete(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
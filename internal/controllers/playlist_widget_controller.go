@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// widgetCacheControl lets browsers and CDNs reuse the widget for as long as the service caches it
+const widgetCacheControl = "public, max-age=300"
+
+// widgetProviderNames are the names of the providers shown on the follow link of the widget
+var widgetProviderNames = map[models.MusicProvider]string{
+	models.MusicProviderSpotify:      "Spotify",
+	models.MusicProviderYouTubeMusic: "YouTube Music",
+	models.MusicProviderTidal:        "Tidal",
+}
+
+var widgetTemplate = template.Must(template.New("widget").Funcs(template.FuncMap{
+	"providerName": func(provider models.MusicProvider) string { return widgetProviderNames[provider] },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}}</title>
+<style>
+body{margin:0;font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,sans-serif;background:#121212;color:#fff}
+.widget{display:flex;gap:16px;align-items:center;padding:16px}
+.cover{width:96px;height:96px;border-radius:4px;object-fit:cover;background:#282828;flex-shrink:0}
+.name{margin:0 0 4px;font-size:18px}
+.meta{margin:0 0 12px;font-size:13px;color:#b3b3b3}
+.follow{display:inline-block;padding:8px 16px;border-radius:500px;background:#1db954;color:#000;font-weight:700;font-size:13px;text-decoration:none}
+</style>
+</head>
+<body>
+<div class="widget">
+{{if .CoverImageURL}}<img class="cover" src="{{.CoverImageURL}}" alt="">{{else}}<div class="cover"></div>{{end}}
+<div>
+<h1 class="name">{{.Name}}</h1>
+<p class="meta">{{.TrackCount}} tracks{{if .LastSyncedAt}} · Updated {{.LastSyncedAt.Format "Jan 2, 2006"}}{{end}}</p>
+{{if .PlaylistURL}}<a class="follow" href="{{.PlaylistURL}}" target="_blank" rel="noopener">Follow on {{providerName .Provider}}</a>{{end}}
+</div>
+</div>
+</body>
+</html>
+`))
+
+type PlaylistWidgetController struct {
+	widgetService services.PlaylistWidgetServicer
+}
+
+func NewPlaylistWidgetController(widgetService services.PlaylistWidgetServicer) *PlaylistWidgetController {
+	return &PlaylistWidgetController{
+		widgetService: widgetService,
+	}
+}
+
+// GetWidget serves the public widget of a shared child playlist, as HTML meant to be iframed
+// or as JSON when requested with ?format=json. The share token is the only credential
+func (c *PlaylistWidgetController) GetWidget(w http.ResponseWriter, r *http.Request) {
+	shareToken := r.PathValue("shareToken")
+	if shareToken == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "share token is required")
+		return
+	}
+
+	widget, err := c.widgetService.GetWidget(r.Context(), shareToken)
+	if err != nil {
+		if errors.Is(err, repositories.ErrChildPlaylistNotFound) {
+			problem.Write(w, http.StatusNotFound, problem.CodePlaylistNotFound, "shared playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to load shared playlist")
+		return
+	}
+
+	w.Header().Set("Cache-Control", widgetCacheControl)
+
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if err := json.NewEncoder(w).Encode(widget); err != nil {
+			problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		}
+		return
+	}
+
+	// Allow any site to frame the widget
+	w.Header().Set("Content-Security-Policy", "frame-ancestors *")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := widgetTemplate.Execute(w, widget); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to render widget")
+	}
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistWidgetController_GetWidget(t *testing.T) {
+	lastSyncedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	widget := &models.PlaylistWidget{
+		Name:          "Workout <mix>",
+		CoverImageURL: "https://i.scdn.co/cover.jpg",
+		TrackCount:    12,
+		LastSyncedAt:  &lastSyncedAt,
+		Provider:      models.MusicProviderSpotify,
+		PlaylistURL:   "https://open.spotify.com/playlist/spotify1",
+	}
+	withoutLink := &models.PlaylistWidget{Name: "Workout", Provider: "unknown"}
+
+	tests := []struct {
+		name                string
+		query               string
+		widget              *models.PlaylistWidget
+		serviceErr          error
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        []string
+		unexpectedBody      []string
+	}{
+		{
+			name:                "html widget",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/html; charset=utf-8",
+			expectedBody:        []string{"Workout &lt;mix&gt;", "12 tracks", "May 1, 2024", `href="https://open.spotify.com/playlist/spotify1"`, "Follow on Spotify"},
+		},
+		{
+			name:                "json widget",
+			query:               "?format=json",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        []string{`"track_count":12`, `"provider":"spotify"`, `"playlist_url":"https://open.spotify.com/playlist/spotify1"`},
+		},
+		{
+			name:                "html widget without a playlist link",
+			widget:              withoutLink,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/html; charset=utf-8",
+			expectedBody:        []string{"Workout"},
+			unexpectedBody:      []string{"Follow on"},
+		},
+		{
+			name:           "unknown share token",
+			serviceErr:     repositories.ErrChildPlaylistNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   []string{"shared playlist not found"},
+		},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   []string{"unable to load shared playlist"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := servicemocks.NewMockPlaylistWidgetServicer(ctrl)
+			controller := NewPlaylistWidgetController(mockService)
+
+			if tt.serviceErr != nil {
+				mockService.EXPECT().GetWidget(gomock.Any(), "token123").Return(nil, tt.serviceErr)
+			} else if tt.widget != nil {
+				mockService.EXPECT().GetWidget(gomock.Any(), "token123").Return(tt.widget, nil)
+			} else {
+				mockService.EXPECT().GetWidget(gomock.Any(), "token123").Return(widget, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/embed/child_playlist/token123"+tt.query, nil)
+			req.SetPathValue("shareToken", "token123")
+			w := httptest.NewRecorder()
+
+			controller.GetWidget(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			for _, expected := range tt.expectedBody {
+				assert.Contains(w.Body.String(), expected)
+			}
+			for _, unexpected := range tt.unexpectedBody {
+				assert.NotContains(w.Body.String(), unexpected)
+			}
+			if tt.expectedContentType != "" {
+				assert.Equal(tt.expectedContentType, w.Header().Get("Content-Type"))
+				assert.Equal(widgetCacheControl, w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestPlaylistWidgetController_GetWidget_MissingToken(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	controller := NewPlaylistWidgetController(servicemocks.NewMockPlaylistWidgetServicer(ctrl))
+
+	req := httptest.NewRequest(http.MethodGet, "/embed/child_playlist/", nil)
+	w := httptest.NewRecorder()
+
+	controller.GetWidget(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/ngomez18/playlist-router/internal/realtime"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+const (
+	// WS_AUTH_TIMEOUT is how long a new connection has to send its auth message
+	WS_AUTH_TIMEOUT = 10 * time.Second
+	// WS_PING_INTERVAL must stay under WS_PONG_WAIT so a live connection always answers in time
+	WS_PING_INTERVAL = 30 * time.Second
+	WS_PONG_WAIT     = 60 * time.Second
+	WS_WRITE_TIMEOUT = 10 * time.Second
+	// WS_MAX_MESSAGE_SIZE bounds the messages of the app, which only sends its auth message
+	WS_MAX_MESSAGE_SIZE = 4096
+)
+
+const (
+	wsMessageAuth       = "auth"
+	wsMessageAuthOK     = "auth.ok"
+	wsMessageAuthFailed = "auth.failed"
+)
+
+// wsMessage is the handshake of a connection: the app sends its auth token, and is told whether it
+// was accepted before any event is pushed
+type wsMessage struct {
+	Type   string `json:"type"`
+	Token  string `json:"token,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// RealtimeController pushes the events of the user to the app over a WebSocket
+type RealtimeController struct {
+	userService services.UserServicer
+	hub         *realtime.Hub
+	upgrader    websocket.Upgrader
+}
+
+// NewRealtimeController accepts connections from allowedOrigins, where "*" allows every origin.
+// Connections from the origin of the API, or without one, are always accepted
+func NewRealtimeController(userService services.UserServicer, hub *realtime.Hub, allowedOrigins []string) *RealtimeController {
+	return &RealtimeController{
+		userService: userService,
+		hub:         hub,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return checkOrigin(r, allowedOrigins)
+			},
+		},
+	}
+}
+
+func checkOrigin(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || slices.Contains(allowedOrigins, "*") || slices.Contains(allowedOrigins, origin) {
+		return true
+	}
+
+	originURL, err := url.Parse(origin)
+	return err == nil && originURL.Host == r.Host
+}
+
+// Connect upgrades the request to a WebSocket. Browsers can't send headers on it, so the first
+// message must be {"type":"auth","token":"..."} with the auth token of the user. Once it is
+// accepted the events of the user are pushed as they happen until either side closes
+func (c *RealtimeController) Connect(w http.ResponseWriter, r *http.Request) {
+	conn, err := c.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already responded with the error
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(WS_MAX_MESSAGE_SIZE)
+
+	userID, ok := c.authenticate(conn, r)
+	if !ok {
+		return
+	}
+
+	subscription := c.hub.Subscribe(userID)
+	defer subscription.Close()
+
+	// The app sends nothing after its auth message, reading only handles pongs and the close
+	closed := make(chan struct{})
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(WS_PONG_WAIT))
+	})
+	_ = conn.SetReadDeadline(time.Now().Add(WS_PONG_WAIT))
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(WS_PING_INTERVAL)
+	defer ping.Stop()
+
+	for {
+		select {
+		case payload := <-subscription.Events():
+			_ = conn.SetWriteDeadline(time.Now().Add(WS_WRITE_TIMEOUT))
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(WS_WRITE_TIMEOUT)); err != nil {
+				return
+			}
+		case <-subscription.Done():
+			// Dropped by the hub for falling behind, the app reconnects and refetches
+			closeConn(conn, websocket.CloseTryAgainLater, "too slow")
+			return
+		case <-closed:
+			return
+		}
+	}
+}
+
+// authenticate reads the auth message of the connection, closing it when the token is missing or
+// invalid
+func (c *RealtimeController) authenticate(conn *websocket.Conn, r *http.Request) (string, bool) {
+	_ = conn.SetReadDeadline(time.Now().Add(WS_AUTH_TIMEOUT))
+
+	var message wsMessage
+	if err := conn.ReadJSON(&message); err != nil || message.Type != wsMessageAuth || message.Token == "" {
+		rejectConn(conn, "expected an auth message with the auth token")
+		return "", false
+	}
+
+	user, err := c.userService.ValidateAuthToken(r.Context(), message.Token)
+	if err != nil {
+		rejectConn(conn, "invalid or expired token")
+		return "", false
+	}
+
+	_ = conn.SetWriteDeadline(time.Now().Add(WS_WRITE_TIMEOUT))
+	if err := conn.WriteJSON(wsMessage{Type: wsMessageAuthOK}); err != nil {
+		return "", false
+	}
+
+	return user.ID, true
+}
+
+func rejectConn(conn *websocket.Conn, detail string) {
+	_ = conn.SetWriteDeadline(time.Now().Add(WS_WRITE_TIMEOUT))
+	_ = conn.WriteJSON(wsMessage{Type: wsMessageAuthFailed, Detail: detail})
+	closeConn(conn, websocket.ClosePolicyViolation, detail)
+}
+
+func closeConn(conn *websocket.Conn, code int, reason string) {
+	message := websocket.FormatCloseMessage(code, reason)
+	_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(WS_WRITE_TIMEOUT))
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/realtime"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRealtimeServer(t *testing.T, userService *servicemocks.MockUserServicer) (*realtime.Hub, string) {
+	hub := realtime.NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	controller := NewRealtimeController(userService, hub, []string{"https://app.example.com"})
+
+	server := httptest.NewServer(http.HandlerFunc(controller.Connect))
+	t.Cleanup(server.Close)
+
+	return hub, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dialRealtime(t *testing.T, url string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	return conn
+}
+
+func TestRealtimeController_Connect_PushesEventsOfTheUser(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	userService := servicemocks.NewMockUserServicer(ctrl)
+	hub, url := setupRealtimeServer(t, userService)
+
+	userService.EXPECT().ValidateAuthToken(gomock.Any(), "token123").Return(&models.User{ID: "user123"}, nil)
+
+	conn := dialRealtime(t, url)
+	assert.NoError(conn.WriteJSON(wsMessage{Type: wsMessageAuth, Token: "token123"}))
+
+	var reply wsMessage
+	assert.NoError(conn.ReadJSON(&reply))
+	assert.Equal(wsMessageAuthOK, reply.Type)
+	assert.Equal(1, hub.SubscriberCount("user123"))
+
+	hub.Publish("user456", models.NewPlaylistDeletedEvent(models.PlaylistKindBase, "base456", ""))
+	hub.Publish("user123", models.NewPlaylistDeletedEvent(models.PlaylistKindChild, "child123", "base123"))
+
+	var event models.AppEvent
+	assert.NoError(conn.ReadJSON(&event))
+	assert.Equal(models.AppEventPlaylistDeleted, event.Type)
+	assert.Equal(&models.PlaylistChange{Kind: models.PlaylistKindChild, ID: "child123", BasePlaylistID: "base123"}, event.Playlist)
+
+	conn.Close()
+	assert.Eventually(func() bool { return hub.SubscriberCount("user123") == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestRealtimeController_Connect_RejectsUnauthenticated(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   any
+		tokenErr  error
+		expectErr string
+	}{
+		{
+			name:      "invalid token",
+			message:   wsMessage{Type: wsMessageAuth, Token: "expired"},
+			tokenErr:  errors.New("invalid token"),
+			expectErr: "invalid or expired token",
+		},
+		{
+			name:      "missing token",
+			message:   wsMessage{Type: wsMessageAuth},
+			expectErr: "expected an auth message with the auth token",
+		},
+		{
+			name:      "other message",
+			message:   map[string]string{"type": "subscribe"},
+			expectErr: "expected an auth message with the auth token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			userService := servicemocks.NewMockUserServicer(ctrl)
+			_, url := setupRealtimeServer(t, userService)
+
+			if tt.tokenErr != nil {
+				userService.EXPECT().ValidateAuthToken(gomock.Any(), gomock.Any()).Return(nil, tt.tokenErr)
+			}
+
+			conn := dialRealtime(t, url)
+			assert.NoError(conn.WriteJSON(tt.message))
+
+			var reply wsMessage
+			assert.NoError(conn.ReadJSON(&reply))
+			assert.Equal(wsMessage{Type: wsMessageAuthFailed, Detail: tt.expectErr}, reply)
+
+			_, _, err := conn.ReadMessage()
+			var closeErr *websocket.CloseError
+			assert.True(errors.As(err, &closeErr))
+			assert.Equal(websocket.ClosePolicyViolation, closeErr.Code)
+		})
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	tests := []struct {
+		name           string
+		origin         string
+		allowedOrigins []string
+		expected       bool
+	}{
+		{name: "no origin", allowedOrigins: []string{"https://app.example.com"}, expected: true},
+		{name: "allowed origin", origin: "https://app.example.com", allowedOrigins: []string{"https://app.example.com"}, expected: true},
+		{name: "same host", origin: "https://api.example.com", allowedOrigins: []string{"https://app.example.com"}, expected: true},
+		{name: "other origin", origin: "https://evil.example.com", allowedOrigins: []string{"https://app.example.com"}},
+		{name: "every origin", origin: "https://evil.example.com", allowedOrigins: []string{"*"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://api.example.com/api/ws", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			require.Equal(t, tt.expected, checkOrigin(req, tt.allowedOrigins))
+		})
+	}
+}
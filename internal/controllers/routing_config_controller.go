@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"gopkg.in/yaml.v3"
+)
+
+// MAX_ROUTING_CONFIG_SIZE bounds the body of a routing config import
+const MAX_ROUTING_CONFIG_SIZE = 1 << 20
+
+const (
+	routingConfigFormatJSON = "json"
+	routingConfigFormatYAML = "yaml"
+)
+
+// RoutingConfigController exports the routing setup of base playlists and imports it back, as
+// JSON or YAML
+type RoutingConfigController struct {
+	routingConfigService services.RoutingConfigServicer
+	validator            *validator.Validate
+}
+
+func NewRoutingConfigController(routingConfigService services.RoutingConfigServicer) *RoutingConfigController {
+	return &RoutingConfigController{
+		routingConfigService: routingConfigService,
+		validator:            newValidator(),
+	}
+}
+
+// Export responds with the routing config of the base playlist as an attachment, in the format of
+// the format query parameter: json, the default, or yaml
+func (c *RoutingConfigController) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = routingConfigFormatJSON
+	}
+	if format != routingConfigFormatJSON && format != routingConfigFormatYAML {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "format must be json or yaml")
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylistID := r.PathValue("id")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
+		return
+	}
+
+	config, err := c.routingConfigService.ExportRoutingConfig(r.Context(), user.ID, basePlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to export routing config")
+		return
+	}
+
+	body, contentType, err := encodeRoutingConfig(config, format)
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+
+	filename := fmt.Sprintf("routing-config-%s.%s", basePlaylistID, format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// Import applies a routing config to the base playlist of the user reading its spotify playlist,
+// creating it when needed. The config is read as YAML when the Content-Type says so, JSON otherwise
+func (c *RoutingConfigController) Import(w http.ResponseWriter, r *http.Request) {
+	format, ok := routingConfigFormat(r.Header.Get("Content-Type"))
+	if !ok {
+		problem.Write(w, http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, "routing config must be JSON or YAML")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MAX_ROUTING_CONFIG_SIZE))
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	config, err := decodeRoutingConfig(body, format)
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload: "+err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(config); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylist, err := c.routingConfigService.ImportRoutingConfig(r.Context(), user.ID, config)
+	if err != nil {
+		writeError(w, err, "unable to import routing config")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(basePlaylist); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// routingConfigFormat maps the Content-Type of an import to the format of its body, a missing
+// Content-Type being JSON
+func routingConfigFormat(contentType string) (string, bool) {
+	if contentType == "" {
+		return routingConfigFormatJSON, true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "application/json":
+		return routingConfigFormatJSON, true
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return routingConfigFormatYAML, true
+	default:
+		return "", false
+	}
+}
+
+// encodeRoutingConfig goes through the JSON encoding for YAML too, so both formats use the same
+// field names. The YAML is written in block style, keeping the field order
+func encodeRoutingConfig(config *models.RoutingConfig, format string) ([]byte, string, error) {
+	body, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == routingConfigFormatJSON {
+		return body, "application/json", nil
+	}
+
+	// JSON is valid YAML, decoding it into a node keeps the field order
+	var node yaml.Node
+	if err := yaml.Unmarshal(body, &node); err != nil {
+		return nil, "", err
+	}
+	if err := toYAMLBlockStyle(&node); err != nil {
+		return nil, "", err
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buffer.Bytes(), "application/yaml", nil
+}
+
+// decodeRoutingConfig rejects unknown fields, so a misspelled filter rule fails the import instead
+// of being dropped
+func decodeRoutingConfig(body []byte, format string) (*models.RoutingConfig, error) {
+	if format == routingConfigFormatYAML {
+		var value any
+		if err := yaml.Unmarshal(body, &value); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if body, err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	var config models.RoutingConfig
+	if err := decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// toYAMLBlockStyle drops the JSON styling of a node decoded from JSON. Strings are encoded again,
+// which only quotes the ones that would read back as something else, like "true"
+func toYAMLBlockStyle(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		return node.Encode(node.Value)
+	}
+
+	node.Style &^= yaml.FlowStyle
+	for _, child := range node.Content {
+		if err := toYAMLBlockStyle(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func testRoutingConfig() *models.RoutingConfig {
+	minEnergy := 0.7
+	return &models.RoutingConfig{
+		Version:           models.ROUTING_CONFIG_VERSION,
+		Name:              "true",
+		SpotifyPlaylistID: "spotify123",
+		DedupeStrategy:    models.DedupeStrategyFirstMatch,
+		Children: []models.TemplateChild{
+			{Name: "Workout", FilterRules: &models.MetadataFilters{Energy: &models.RangeFilter{Min: &minEnergy}}},
+			{Name: "Everything Else", IsFallback: true},
+		},
+	}
+}
+
+func TestRoutingConfigController_Export(t *testing.T) {
+	tests := []struct {
+		name                string
+		format              string
+		serviceErr          error
+		expectCall          bool
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{name: "json by default", expectCall: true, expectedStatus: http.StatusOK, expectedContentType: "application/json"},
+		{name: "yaml", format: "yaml", expectCall: true, expectedStatus: http.StatusOK, expectedContentType: "application/yaml"},
+		{name: "unknown format", format: "xml", expectedStatus: http.StatusBadRequest},
+		{name: "not found", serviceErr: fmt.Errorf("failed to retrieve base playlist: %w", repositories.ErrBasePlaylistNotFound), expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to retrieve base playlist: %w", repositories.ErrUnauthorized), expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectCall: true, expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockRoutingConfigServicer(gomock.NewController(t))
+			controller := NewRoutingConfigController(mockService)
+
+			if tt.expectCall {
+				var config *models.RoutingConfig
+				if tt.serviceErr == nil {
+					config = testRoutingConfig()
+				}
+				mockService.EXPECT().ExportRoutingConfig(gomock.Any(), "user123", "base123").Return(config, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodGet, "/api/base_playlist/base123/config/export?format="+tt.format, "")
+			req.SetPathValue("id", "base123")
+			w := httptest.NewRecorder()
+			controller.Export(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedContentType != "" {
+				assert.Equal(tt.expectedContentType, w.Header().Get("Content-Type"))
+				assert.Contains(w.Header().Get("Content-Disposition"), "routing-config-base123.")
+			}
+		})
+	}
+}
+
+func TestRoutingConfigController_ExportYAML_ImportsBack(t *testing.T) {
+	assert := require.New(t)
+	mockService := servicemocks.NewMockRoutingConfigServicer(gomock.NewController(t))
+	controller := NewRoutingConfigController(mockService)
+
+	mockService.EXPECT().ExportRoutingConfig(gomock.Any(), "user123", "base123").Return(testRoutingConfig(), nil)
+
+	req := newAutomationRequest(http.MethodGet, "/api/base_playlist/base123/config/export?format=yaml", "")
+	req.SetPathValue("id", "base123")
+	w := httptest.NewRecorder()
+	controller.Export(w, req)
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), "children:\n")
+
+	mockService.EXPECT().ImportRoutingConfig(gomock.Any(), "user123", testRoutingConfig()).
+		Return(&models.BasePlaylistWithChilds{BasePlaylist: testfixtures.NewBasePlaylist().WithID("base123").Build()}, nil)
+
+	req = newAutomationRequest(http.MethodPost, "/api/base_playlist/import", w.Body.String())
+	req.Header.Set("Content-Type", "application/yaml")
+	w = httptest.NewRecorder()
+	controller.Import(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+}
+
+func TestRoutingConfigController_Import(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		serviceErr     error
+		expectCall     bool
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{
+			name:           "json",
+			contentType:    "application/json",
+			body:           `{"version":1,"name":"Liked Songs","spotify_playlist_id":"spotify123","children":[{"name":"Workout","filter_rules":{"energy":{"min":0.7}}}]}`,
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "yaml",
+			contentType:    "text/yaml; charset=utf-8",
+			body:           "version: 1\nname: Liked Songs\nspotify_playlist_id: spotify123\nchildren:\n  - name: Workout\n    filter_rules:\n      duration:\n        preset: short\n",
+			expectCall:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unsupported media type",
+			contentType:    "text/csv",
+			body:           "name,spotify_playlist_id",
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   problem.CodeUnsupportedMediaType,
+		},
+		{
+			name:           "misspelled filter rule",
+			contentType:    "application/json",
+			body:           `{"version":1,"name":"Liked Songs","spotify_playlist_id":"spotify123","children":[{"name":"Workout","filter_rules":{"enrgy":{"min":0.7}}}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidPayload,
+		},
+		{
+			name:           "invalid yaml",
+			contentType:    "application/yaml",
+			body:           "version: [1\n",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidPayload,
+		},
+		{
+			name:           "unknown version",
+			contentType:    "application/json",
+			body:           `{"version":2,"name":"Liked Songs","spotify_playlist_id":"spotify123","children":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "child without name",
+			contentType:    "application/json",
+			body:           `{"version":1,"name":"Liked Songs","spotify_playlist_id":"spotify123","children":[{"description":"nameless"}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeValidationFailed,
+		},
+		{
+			name:           "invalid filter rules",
+			contentType:    "application/json",
+			body:           `{"version":1,"name":"Liked Songs","spotify_playlist_id":"spotify123","children":[{"name":"Workout","filter_rules":{"energy":{"min":0.9,"max":0.1}}}]}`,
+			serviceErr:     fmt.Errorf("%w: child %q: filter_rules.energy: min can not be greater than max", services.ErrInvalidRoutingConfig, "Workout"),
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeInvalidRoutingConfig,
+		},
+		{
+			name:           "service error",
+			contentType:    "application/json",
+			body:           `{"version":1,"name":"Liked Songs","spotify_playlist_id":"spotify123","children":[]}`,
+			serviceErr:     errors.New("spotify error"),
+			expectCall:     true,
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   problem.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockRoutingConfigServicer(gomock.NewController(t))
+			controller := NewRoutingConfigController(mockService)
+
+			if tt.expectCall {
+				var result *models.BasePlaylistWithChilds
+				if tt.serviceErr == nil {
+					result = &models.BasePlaylistWithChilds{
+						BasePlaylist: testfixtures.NewBasePlaylist().WithID("base123").Build(),
+						Childs:       []*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithID("child1").Build()},
+					}
+				}
+				mockService.EXPECT().ImportRoutingConfig(gomock.Any(), "user123", gomock.Any()).Return(result, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodPost, "/api/base_playlist/import", tt.body)
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			controller.Import(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.BasePlaylistWithChilds
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.Equal("base123", result.ID)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
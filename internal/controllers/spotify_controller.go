@@ -2,32 +2,36 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
 	"github.com/ngomez18/playlist-router/internal/services"
 )
 
 type SpotifyController struct {
-	spotifyApiService services.SpotifyAPIServicer
+	spotifyApiService     services.SpotifyAPIServicer
+	spotifyAccountService services.SpotifyAccountServicer
 }
 
-func NewSpotifyController(spotifyApiService services.SpotifyAPIServicer) *SpotifyController {
+func NewSpotifyController(spotifyApiService services.SpotifyAPIServicer, spotifyAccountService services.SpotifyAccountServicer) *SpotifyController {
 	return &SpotifyController{
-		spotifyApiService: spotifyApiService,
+		spotifyApiService:     spotifyApiService,
+		spotifyAccountService: spotifyAccountService,
 	}
 }
 
 func (c *SpotifyController) GetUserPlaylists(w http.ResponseWriter, r *http.Request) {
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	playlists, err := c.spotifyApiService.GetFilteredUserPlaylists(r.Context(), user.ID)
 	if err != nil {
-		http.Error(w, "unable to retrieve spotify playlists", http.StatusInternalServerError)
+		writeError(w, err, "unable to retrieve spotify playlists")
 		return
 	}
 
@@ -35,6 +39,75 @@ func (c *SpotifyController) GetUserPlaylists(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(playlists)
 	if err != nil {
-		http.Error(w, "unable to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+// DisconnectIntegration deletes the user's spotify integration and suspends their playlists until
+// the same spotify account logs in again
+func (c *SpotifyController) DisconnectIntegration(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	err := c.spotifyAccountService.Disconnect(r.Context(), user.ID)
+	if err != nil {
+		if errors.Is(err, services.ErrSpotifyIntegrationUnavailable) {
+			problem.Write(w, http.StatusNotFound, problem.CodeSpotifyIntegrationNotFound, "spotify integration not found")
+			return
+		}
+
+		writeError(w, err, "unable to disconnect spotify integration")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAccounts returns the spotify accounts linked by the user, the default one first
+func (c *SpotifyController) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	accounts, err := c.spotifyAccountService.ListAccounts(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve spotify accounts")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(accounts); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+// UnlinkAccount removes one of the additional spotify accounts of the user
+func (c *SpotifyController) UnlinkAccount(w http.ResponseWriter, r *http.Request) {
+	integrationID := r.PathValue("id")
+	if integrationID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "spotify account id is required")
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	err := c.spotifyAccountService.UnlinkAccount(r.Context(), user.ID, integrationID)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, services.ErrSpotifyIntegrationUnavailable):
+		problem.Write(w, http.StatusNotFound, problem.CodeSpotifyIntegrationNotFound, "spotify account not found")
+	default:
+		writeError(w, err, "unable to unlink spotify account")
 	}
 }
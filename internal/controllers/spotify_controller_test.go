@@ -10,7 +10,9 @@ import (
 	"github.com/golang/mock/gomock"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/services"
 	"github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,10 +22,12 @@ func TestNewSpotifyController(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockSpotifyApiService := mocks.NewMockSpotifyAPIServicer(ctrl)
-	controller := NewSpotifyController(mockSpotifyApiService)
+	mockSpotifyAccountService := mocks.NewMockSpotifyAccountServicer(ctrl)
+	controller := NewSpotifyController(mockSpotifyApiService, mockSpotifyAccountService)
 
 	assert.NotNil(controller)
 	assert.Equal(mockSpotifyApiService, controller.spotifyApiService)
+	assert.Equal(mockSpotifyAccountService, controller.spotifyAccountService)
 }
 
 func TestSpotifyController_GetUserPlaylists_Success(t *testing.T) {
@@ -54,7 +58,7 @@ func TestSpotifyController_GetUserPlaylists_Success(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockSpotifyApiService := mocks.NewMockSpotifyAPIServicer(ctrl)
-			controller := NewSpotifyController(mockSpotifyApiService)
+			controller := NewSpotifyController(mockSpotifyApiService, nil)
 
 			// Mock the service call
 			mockSpotifyApiService.EXPECT().
@@ -119,7 +123,7 @@ func TestSpotifyController_GetUserPlaylists_Errors(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockSpotifyApiService := mocks.NewMockSpotifyAPIServicer(ctrl)
-			controller := NewSpotifyController(mockSpotifyApiService)
+			controller := NewSpotifyController(mockSpotifyApiService, nil)
 
 			if tt.serviceError != nil {
 				mockSpotifyApiService.EXPECT().
@@ -143,8 +147,167 @@ func TestSpotifyController_GetUserPlaylists_Errors(t *testing.T) {
 }
 
 // Helper function to add user to request context for Spotify controller tests
+func TestSpotifyController_DisconnectIntegration(t *testing.T) {
+	tests := []struct {
+		name               string
+		serviceError       error
+		noUserInContext    bool
+		expectedStatusCode int
+		expectedError      string
+	}{
+		{
+			name:               "success",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "integration not found",
+			serviceError:       services.ErrSpotifyIntegrationUnavailable,
+			expectedStatusCode: http.StatusNotFound,
+			expectedError:      "spotify integration not found",
+		},
+		{
+			name:               "service error",
+			serviceError:       errors.New("some service error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedError:      "unable to disconnect spotify integration",
+		},
+		{
+			name:               "no user in context",
+			noUserInContext:    true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "user not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSpotifyAccountService := mocks.NewMockSpotifyAccountServicer(ctrl)
+			controller := NewSpotifyController(nil, mockSpotifyAccountService)
+
+			if !tt.noUserInContext {
+				mockSpotifyAccountService.EXPECT().
+					Disconnect(gomock.Any(), "test_user_123").
+					Return(tt.serviceError).
+					Times(1)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/spotify/integration", nil)
+			if !tt.noUserInContext {
+				req = addUserToSpotifyContext(req)
+			}
+
+			w := httptest.NewRecorder()
+			controller.DisconnectIntegration(w, req)
+
+			assert.Equal(tt.expectedStatusCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(w.Body.String(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestSpotifyController_ListAccounts(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpotifyAccountService := mocks.NewMockSpotifyAccountServicer(ctrl)
+	controller := NewSpotifyController(nil, mockSpotifyAccountService)
+
+	accounts := []*models.SpotifyIntegration{
+		testfixtures.NewSpotifyIntegration().Build(),
+		testfixtures.NewSpotifyIntegration().WithID("integration_dj").WithSpotifyID("spotify_dj").Build(),
+	}
+	mockSpotifyAccountService.EXPECT().ListAccounts(gomock.Any(), "test_user_123").Return(accounts, nil)
+
+	req := addUserToSpotifyContext(httptest.NewRequest(http.MethodGet, "/api/spotify/accounts", nil))
+	w := httptest.NewRecorder()
+	controller.ListAccounts(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var response []models.SpotifyIntegration
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(response, 2)
+	assert.Equal("integration_dj", response[1].ID)
+	assert.NotContains(w.Body.String(), "access_token")
+}
+
+func TestSpotifyController_UnlinkAccount(t *testing.T) {
+	tests := []struct {
+		name               string
+		serviceError       error
+		expectedStatusCode int
+		expectedError      string
+	}{
+		{
+			name:               "success",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "account not found",
+			serviceError:       services.ErrSpotifyIntegrationUnavailable,
+			expectedStatusCode: http.StatusNotFound,
+			expectedError:      "spotify account not found",
+		},
+		{
+			name:               "default account",
+			serviceError:       services.ErrDefaultSpotifyAccount,
+			expectedStatusCode: http.StatusConflict,
+			expectedError:      "the default spotify account can not be unlinked",
+		},
+		{
+			name:               "account in use",
+			serviceError:       services.ErrSpotifyAccountInUse,
+			expectedStatusCode: http.StatusConflict,
+			expectedError:      "spotify account is used by playlists",
+		},
+		{
+			name:               "service error",
+			serviceError:       errors.New("some service error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedError:      "unable to unlink spotify account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSpotifyAccountService := mocks.NewMockSpotifyAccountServicer(ctrl)
+			controller := NewSpotifyController(nil, mockSpotifyAccountService)
+
+			mockSpotifyAccountService.EXPECT().
+				UnlinkAccount(gomock.Any(), "test_user_123", "integration_dj").
+				Return(tt.serviceError)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/spotify/accounts/integration_dj", nil)
+			req.SetPathValue("id", "integration_dj")
+			req = addUserToSpotifyContext(req)
+
+			w := httptest.NewRecorder()
+			controller.UnlinkAccount(w, req)
+
+			assert.Equal(tt.expectedStatusCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(w.Body.String(), tt.expectedError)
+			}
+		})
+	}
+}
+
 func addUserToSpotifyContext(req *http.Request) *http.Request {
-	user := &models.User{ID: "test_user_123", Email: "test@example.com", Name: "Test User"}
+	user := testfixtures.NewUser().WithID("test_user_123").WithEmail("test@example.com").WithName("Test User").Build()
 	ctx := requestcontext.ContextWithUser(req.Context(), user)
 	return req.WithContext(ctx)
 }
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// SupportController serves the diagnostics operators attach to bug reports
+type SupportController struct {
+	supportBundleService services.SupportBundleServicer
+}
+
+func NewSupportController(supportBundleService services.SupportBundleServicer) *SupportController {
+	return &SupportController{
+		supportBundleService: supportBundleService,
+	}
+}
+
+// DownloadBundle responds with the support bundle archive as an attachment
+func (c *SupportController) DownloadBundle(w http.ResponseWriter, r *http.Request) {
+	// Buffered so a failure can still be reported with a proper status
+	var bundle bytes.Buffer
+	if err := c.supportBundleService.WriteBundle(r.Context(), &bundle); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to generate support bundle")
+		return
+	}
+
+	filename := fmt.Sprintf("support-bundle-%s.zip", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(bundle.Bytes())
+}
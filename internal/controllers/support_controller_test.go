@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportController_DownloadBundle(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "service error", serviceErr: errors.New("zip error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockSupportBundleServicer(gomock.NewController(t))
+			controller := NewSupportController(mockService)
+
+			mockService.EXPECT().WriteBundle(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, w io.Writer) error {
+					_, _ = w.Write([]byte("PK"))
+					return tt.serviceErr
+				})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/support_bundle", nil)
+			w := httptest.NewRecorder()
+			controller.DownloadBundle(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.serviceErr == nil {
+				assert.Equal("application/zip", w.Header().Get("Content-Type"))
+				assert.Contains(w.Header().Get("Content-Disposition"), `attachment; filename="support-bundle-`)
+				assert.Equal("PK", w.Body.String())
+			}
+		})
+	}
+}
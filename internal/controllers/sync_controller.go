@@ -1,20 +1,43 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
 )
 
+var syncStatuses = []models.SyncStatus{
+	models.SyncStatusInProgress,
+	models.SyncStatusCompleted,
+	models.SyncStatusFailed,
+	models.SyncStatusNeedsConfirmation,
+	models.SyncStatusConfirmed,
+}
+
 type SyncController struct {
 	syncOrchestrator orchestrators.SyncOrchestrator
+	syncEventService services.SyncEventServicer
+	validator        *validator.Validate
 }
 
-func NewSyncController(syncOrchestrator orchestrators.SyncOrchestrator) *SyncController {
+func NewSyncController(syncOrchestrator orchestrators.SyncOrchestrator, syncEventService services.SyncEventServicer) *SyncController {
 	return &SyncController{
 		syncOrchestrator: syncOrchestrator,
+		syncEventService: syncEventService,
+		validator:        newValidator(),
 	}
 }
 
@@ -22,32 +45,434 @@ func (c *SyncController) SyncBasePlaylist(w http.ResponseWriter, r *http.Request
 	// Extract user ID from auth context
 	user, ok := requestcontext.GetUserFromContext(r.Context())
 	if !ok {
-		http.Error(w, "user not found in context", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	syncEvent, err := c.syncOrchestrator.SyncBasePlaylist(syncContext(r), user.ID, basePlaylistID)
+	if errors.Is(err, orchestrators.ErrSyncAnomalyDetected) && syncEvent != nil {
+		// The held back sync event lists the anomalies the user has to confirm
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		if err := json.NewEncoder(w).Encode(syncEvent); err != nil {
+			problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		}
+		return
+	}
+	if err != nil {
+		writeError(w, err, "failed to sync base playlist: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(syncEvent); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *SyncController) SyncAllBasePlaylists(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	report, err := c.syncOrchestrator.SyncAllBasePlaylists(syncContext(r), user.ID)
+	if err != nil {
+		writeError(w, err, "failed to sync base playlists: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// MigrateToInPlace moves the child playlists of the user still recreated on every sync over to
+// in place syncing, reporting the outcome per base playlist
+func (c *SyncController) MigrateToInPlace(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	report, err := c.syncOrchestrator.MigrateToInPlace(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "failed to migrate child playlists: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *SyncController) RollbackSync(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	syncEventID := r.PathValue("syncEventID")
+	if syncEventID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "sync event ID is required")
+		return
+	}
+
+	syncEvent, err := c.syncOrchestrator.RollbackSync(r.Context(), user.ID, syncEventID)
+	if err != nil {
+		writeError(w, err, "failed to rollback sync: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(syncEvent); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *SyncController) ConfirmSync(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	syncEventID := r.PathValue("syncEventID")
+	if syncEventID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "sync event ID is required")
+		return
+	}
+
+	syncEvent, err := c.syncOrchestrator.ConfirmSync(r.Context(), user.ID, syncEventID)
+	if err != nil {
+		writeError(w, err, "failed to confirm sync: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(syncEvent); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// GetRoutingReport explains, per track, which child playlists matched and which ones received it
+// during the sync. Only the latest sync of each base playlist keeps its report
+func (c *SyncController) GetRoutingReport(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	syncEventID := r.PathValue("syncEventID")
+	if syncEventID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "sync event ID is required")
+		return
+	}
+
+	report, err := c.syncOrchestrator.GetRoutingReport(r.Context(), user.ID, syncEventID)
+	if err != nil {
+		writeError(w, err, "failed to get routing report: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// AuditBasePlaylist reports how the child playlists of a base playlist drifted from Spotify, without syncing
+func (c *SyncController) AuditBasePlaylist(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	audit, err := c.syncOrchestrator.AuditBasePlaylist(r.Context(), user.ID, basePlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "failed to audit base playlist: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(audit); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// PreviewFilters reports which tracks of the base playlist the filter rules in the payload would
+// match, without creating or updating any child playlist
+func (c *SyncController) PreviewFilters(w http.ResponseWriter, r *http.Request) {
+	var req models.FilterPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidPayload, "invalid payload")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	if err := req.FilterRules.Validate(); err != nil {
+		writeFilterRulesError(w, err)
+		return
+	}
+
+	if err := req.FilterRules.ResolveDurations(); err != nil {
+		writeFilterRulesError(w, err)
+		return
+	}
+
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
 		return
 	}
 
 	basePlaylistID := r.PathValue("basePlaylistID")
 	if basePlaylistID == "" {
-		http.Error(w, "base playlist ID is required", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
 		return
 	}
 
-	syncEvent, err := c.syncOrchestrator.SyncBasePlaylist(r.Context(), user.ID, basePlaylistID)
+	preview, err := c.syncOrchestrator.PreviewFilters(r.Context(), user.ID, basePlaylistID, &req)
 	if err != nil {
-		// Check if it's a sync already in progress error
-		if err.Error() == "sync already in progress for base playlist "+basePlaylistID {
-			http.Error(w, err.Error(), http.StatusConflict)
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) || errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
 			return
 		}
 
-		http.Error(w, "failed to sync base playlist: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, err, "failed to preview filter rules: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(preview); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+func (c *SyncController) AuditAllBasePlaylists(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from auth context
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	report, err := c.syncOrchestrator.AuditAllBasePlaylists(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "failed to audit base playlists: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// syncContext enables the per-step timing profile when the request asks for it with ?profile=true
+func syncContext(r *http.Request) context.Context {
+	if r.URL.Query().Get("profile") == "true" {
+		return requestcontext.ContextWithSyncProfiling(r.Context())
+	}
+
+	return r.Context()
+}
+
+// ListSyncEvents lists a page of the sync history of the user, optionally filtered by the status,
+// base_playlist_id, created_after and created_before query parameters
+func (c *SyncController) ListSyncEvents(w http.ResponseWriter, r *http.Request) {
+	c.listSyncEvents(w, r, r.URL.Query().Get("base_playlist_id"))
+}
+
+// ListBasePlaylistSyncEvents lists a page of the sync history of a base playlist, taking the same
+// query parameters as ListSyncEvents
+func (c *SyncController) ListBasePlaylistSyncEvents(w http.ResponseWriter, r *http.Request) {
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	c.listSyncEvents(w, r, basePlaylistID)
+}
+
+func (c *SyncController) listSyncEvents(w http.ResponseWriter, r *http.Request, basePlaylistID string) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	limit, offset, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+	sort, ok := parseSort(w, r, models.ListSortCreatedAsc, models.ListSortCreatedDesc)
+	if !ok {
+		return
+	}
+	createdAfter, ok := parseTimeParam(w, r, "created_after")
+	if !ok {
+		return
+	}
+	createdBefore, ok := parseTimeParam(w, r, "created_before")
+	if !ok {
+		return
+	}
+
+	status := models.SyncStatus(r.URL.Query().Get("status"))
+	if status != "" && !slices.Contains(syncStatuses, status) {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid status")
+		return
+	}
+
+	syncEvents, err := c.syncEventService.ListSyncEvents(r.Context(), user.ID, repositories.SyncEventFilter{
+		Status:         status,
+		BasePlaylistID: basePlaylistID,
+		CreatedAfter:   createdAfter,
+		CreatedBefore:  createdBefore,
+		Sort:           sort,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		writeError(w, err, "unable to retrieve sync events")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(syncEvents); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// PruneSyncEvents deletes the finished sync events of the user older than the older_than_days query
+// parameter, keeping the latest and the latest completed sync of every base playlist
+func (c *SyncController) PruneSyncEvents(w http.ResponseWriter, r *http.Request) {
+	c.pruneSyncEvents(w, r, "")
+}
+
+// PruneBasePlaylistSyncEvents deletes the finished sync events of a base playlist, taking the same
+// query parameter as PruneSyncEvents
+func (c *SyncController) PruneBasePlaylistSyncEvents(w http.ResponseWriter, r *http.Request) {
+	basePlaylistID := r.PathValue("basePlaylistID")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "base playlist ID is required")
+		return
+	}
+
+	c.pruneSyncEvents(w, r, basePlaylistID)
+}
+
+func (c *SyncController) pruneSyncEvents(w http.ResponseWriter, r *http.Request, basePlaylistID string) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	raw := r.URL.Query().Get("older_than_days")
+	if raw == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "older_than_days is required")
+		return
+	}
+	olderThanDays, err := strconv.Atoi(raw)
+	if err != nil || olderThanDays < 0 {
+		problem.Write(w, http.StatusBadRequest, problem.CodeInvalidParameter, "invalid older_than_days")
+		return
+	}
+
+	pruned, err := c.syncEventService.PruneSyncEvents(r.Context(), user.ID, basePlaylistID, time.Duration(olderThanDays)*24*time.Hour)
+	if err != nil {
+		writeError(w, err, "unable to prune sync events")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(models.SyncEventPruneResult{Deleted: pruned}); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
+
+// GetSyncEvent returns a single sync event of the user
+func (c *SyncController) GetSyncEvent(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	syncEventID := r.PathValue("syncEventID")
+	if syncEventID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "sync event ID is required")
+		return
+	}
+
+	syncEvent, err := c.syncEventService.GetUserSyncEvent(r.Context(), user.ID, syncEventID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve sync event")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(syncEvent); err != nil {
-		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
 		return
 	}
 }
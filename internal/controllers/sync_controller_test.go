@@ -1,15 +1,25 @@
 package controllers
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
 	"github.com/ngomez18/playlist-router/internal/orchestrators/mocks"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,7 +30,7 @@ func TestNewSyncController(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
-	controller := NewSyncController(mockOrchestrator)
+	controller := NewSyncController(mockOrchestrator, nil)
 
 	assert.NotNil(controller)
 	assert.Equal(mockOrchestrator, controller.syncOrchestrator)
@@ -33,18 +43,18 @@ func TestSyncController_SyncBasePlaylist_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	// Setup test data
-	user := &models.User{ID: "user123"}
+	user := testfixtures.NewUser().WithID("user123").Build()
 	basePlaylistID := "base456"
-	expectedSyncEvent := &models.SyncEvent{
-		ID:             "sync123",
-		UserID:         user.ID,
-		BasePlaylistID: basePlaylistID,
-		Status:         models.SyncStatusInProgress,
-	}
+	expectedSyncEvent := testfixtures.NewSyncEvent().
+		WithID("sync123").
+		WithUserID(user.ID).
+		WithBasePlaylistID(basePlaylistID).
+		WithStatus(models.SyncStatusInProgress).
+		Build()
 
 	// Setup mocks
 	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
-	controller := NewSyncController(mockOrchestrator)
+	controller := NewSyncController(mockOrchestrator, nil)
 
 	mockOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), user.ID, basePlaylistID).Return(expectedSyncEvent, nil)
 
@@ -68,6 +78,47 @@ func TestSyncController_SyncBasePlaylist_Success(t *testing.T) {
 	assert.Contains(w.Body.String(), "base456")
 }
 
+func TestSyncController_SyncBasePlaylist_Profile(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantProfiling bool
+	}{
+		{name: "profile requested", query: "?profile=true", wantProfiling: true},
+		{name: "profile not requested", query: "", wantProfiling: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+			basePlaylistID := "base456"
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			mockOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), user.ID, basePlaylistID).
+				DoAndReturn(func(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
+					assert.Equal(tt.wantProfiling, requestcontext.IsSyncProfiling(ctx))
+					return testfixtures.NewSyncEvent().WithID("sync123").Build(), nil
+				})
+
+			req := httptest.NewRequest("POST", "/api/sync/"+basePlaylistID+tt.query, nil)
+			req.SetPathValue("basePlaylistID", basePlaylistID)
+			req = req.WithContext(requestcontext.ContextWithUser(req.Context(), user))
+
+			w := httptest.NewRecorder()
+			controller.SyncBasePlaylist(w, req)
+
+			assert.Equal(http.StatusOK, w.Code)
+		})
+	}
+}
+
 func TestSyncController_SyncBasePlaylist_NoUserInContext(t *testing.T) {
 	assert := require.New(t)
 
@@ -75,7 +126,7 @@ func TestSyncController_SyncBasePlaylist_NoUserInContext(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
-	controller := NewSyncController(mockOrchestrator)
+	controller := NewSyncController(mockOrchestrator, nil)
 
 	// Create request without user in context
 	req := httptest.NewRequest("POST", "/api/base_playlist/base456/sync", nil)
@@ -95,9 +146,9 @@ func TestSyncController_SyncBasePlaylist_MissingBasePlaylistID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
-	controller := NewSyncController(mockOrchestrator)
+	controller := NewSyncController(mockOrchestrator, nil)
 
-	user := &models.User{ID: "user123"}
+	user := testfixtures.NewUser().WithID("user123").Build()
 	req := httptest.NewRequest("POST", "/api/base_playlist//sync", nil)
 	// Don't set basePlaylistID path value to simulate missing ID
 
@@ -117,13 +168,13 @@ func TestSyncController_SyncBasePlaylist_SyncInProgress(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	user := &models.User{ID: "user123"}
+	user := testfixtures.NewUser().WithID("user123").Build()
 	basePlaylistID := "base456"
 
 	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
-	controller := NewSyncController(mockOrchestrator)
+	controller := NewSyncController(mockOrchestrator, nil)
 
-	mockOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), user.ID, basePlaylistID).Return(nil, errors.New("sync already in progress for base playlist "+basePlaylistID))
+	mockOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), user.ID, basePlaylistID).Return(nil, fmt.Errorf("%w for base playlist %s", orchestrators.ErrSyncInProgress, basePlaylistID))
 
 	req := httptest.NewRequest("POST", "/api/base_playlist/"+basePlaylistID+"/sync", nil)
 	req.SetPathValue("basePlaylistID", basePlaylistID)
@@ -138,17 +189,42 @@ func TestSyncController_SyncBasePlaylist_SyncInProgress(t *testing.T) {
 	assert.Contains(w.Body.String(), "sync already in progress")
 }
 
+func TestSyncController_SyncBasePlaylist_RateLimited(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testfixtures.NewUser().WithID("user123").Build()
+	basePlaylistID := "base456"
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	syncErr := fmt.Errorf("failed to aggregate track data: %w", spotifyclient.ErrRateLimited)
+	mockOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), user.ID, basePlaylistID).Return(testfixtures.NewSyncEvent().WithID("sync123").Build(), syncErr)
+
+	req := httptest.NewRequest("POST", "/api/base_playlist/"+basePlaylistID+"/sync", nil)
+	req.SetPathValue("basePlaylistID", basePlaylistID)
+	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), user))
+
+	w := httptest.NewRecorder()
+	controller.SyncBasePlaylist(w, req)
+
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+}
+
 func TestSyncController_SyncBasePlaylist_OrchestratorError(t *testing.T) {
 	assert := require.New(t)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	user := &models.User{ID: "user123"}
+	user := testfixtures.NewUser().WithID("user123").Build()
 	basePlaylistID := "base456"
 
 	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
-	controller := NewSyncController(mockOrchestrator)
+	controller := NewSyncController(mockOrchestrator, nil)
 
 	mockOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), user.ID, basePlaylistID).Return(nil, errors.New("failed to aggregate track data"))
 
@@ -164,3 +240,1078 @@ func TestSyncController_SyncBasePlaylist_OrchestratorError(t *testing.T) {
 	assert.Equal(http.StatusInternalServerError, w.Code)
 	assert.Contains(w.Body.String(), "failed to sync base playlist")
 }
+
+func TestSyncController_SyncBasePlaylist_AnomalyDetected(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testfixtures.NewUser().WithID("user123").Build()
+	basePlaylistID := "base456"
+	heldSyncEvent := testfixtures.NewSyncEvent().
+		WithID("sync123").
+		WithUserID(user.ID).
+		WithBasePlaylistID(basePlaylistID).
+		WithStatus(models.SyncStatusNeedsConfirmation).
+		WithAnomalies(models.SyncAnomaly{Type: models.SyncAnomalyUnmatchedSpike, PreviousCount: 5, NewCount: 450}).
+		Build()
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	mockOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), user.ID, basePlaylistID).
+		Return(heldSyncEvent, fmt.Errorf("%w: 1 anomalies detected", orchestrators.ErrSyncAnomalyDetected))
+
+	req := httptest.NewRequest("POST", "/api/base_playlist/"+basePlaylistID+"/sync", nil)
+	req.SetPathValue("basePlaylistID", basePlaylistID)
+
+	ctx := requestcontext.ContextWithUser(req.Context(), user)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	controller.SyncBasePlaylist(w, req)
+
+	assert.Equal(http.StatusConflict, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+	assert.Contains(w.Body.String(), "needs_confirmation")
+	assert.Contains(w.Body.String(), "unmatched_spike")
+}
+
+func TestSyncController_SyncAllBasePlaylists_Success(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testfixtures.NewUser().WithID("user123").Build()
+	expectedReport := &models.MultiSyncReport{
+		UserID: user.ID,
+		Results: []models.BaseSyncResult{
+			{BasePlaylistID: "base1", SyncEventID: "sync1", Status: models.SyncStatusCompleted},
+		},
+		Total:     1,
+		Succeeded: 1,
+	}
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	mockOrchestrator.EXPECT().SyncAllBasePlaylists(gomock.Any(), user.ID).Return(expectedReport, nil)
+
+	req := httptest.NewRequest("POST", "/api/sync/all", nil)
+	ctx := requestcontext.ContextWithUser(req.Context(), user)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	controller.SyncAllBasePlaylists(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+	assert.Contains(w.Body.String(), "base1")
+	assert.Contains(w.Body.String(), "sync1")
+	assert.Contains(w.Body.String(), `"succeeded":1`)
+}
+
+func TestSyncController_SyncAllBasePlaylists_NoUserInContext(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	req := httptest.NewRequest("POST", "/api/sync/all", nil)
+
+	w := httptest.NewRecorder()
+	controller.SyncAllBasePlaylists(w, req)
+
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	assert.Contains(w.Body.String(), "user not found in context")
+}
+
+func TestSyncController_SyncAllBasePlaylists_OrchestratorError(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testfixtures.NewUser().WithID("user123").Build()
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	mockOrchestrator.EXPECT().SyncAllBasePlaylists(gomock.Any(), user.ID).Return(nil, errors.New("failed to get base playlists"))
+
+	req := httptest.NewRequest("POST", "/api/sync/all", nil)
+	ctx := requestcontext.ContextWithUser(req.Context(), user)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	controller.SyncAllBasePlaylists(w, req)
+
+	assert.Equal(http.StatusInternalServerError, w.Code)
+	assert.Contains(w.Body.String(), "failed to sync base playlists")
+}
+
+func TestSyncController_MigrateToInPlace(t *testing.T) {
+	tests := []struct {
+		name           string
+		withUser       bool
+		report         *models.MultiSyncReport
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:     "success",
+			withUser: true,
+			report: &models.MultiSyncReport{
+				UserID:    "user123",
+				Results:   []models.BaseSyncResult{{BasePlaylistID: "base1", SyncEventID: "sync1", Status: models.SyncStatusCompleted}},
+				Total:     1,
+				Succeeded: 1,
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"sync_event_id":"sync1"`,
+		},
+		{
+			name:           "no user in context",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:           "orchestrator error",
+			withUser:       true,
+			err:            errors.New("failed to get base playlists"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to migrate child playlists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			req := httptest.NewRequest("POST", "/api/sync/migrate-in-place", nil)
+			if tt.withUser {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), testfixtures.NewUser().WithID("user123").Build()))
+				mockOrchestrator.EXPECT().MigrateToInPlace(gomock.Any(), "user123").Return(tt.report, tt.err)
+			}
+
+			w := httptest.NewRecorder()
+			controller.MigrateToInPlace(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestSyncController_RollbackSync_Success(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testfixtures.NewUser().WithID("user123").Build()
+	rollbackSyncEvent := testfixtures.NewSyncEvent().
+		WithID("sync_rollback").
+		WithUserID(user.ID).
+		WithBasePlaylistID("base456").
+		WithStatus(models.SyncStatusCompleted).
+		Build()
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	mockOrchestrator.EXPECT().RollbackSync(gomock.Any(), user.ID, "sync123").Return(rollbackSyncEvent, nil)
+
+	req := httptest.NewRequest("POST", "/api/sync/sync123/rollback", nil)
+	req.SetPathValue("syncEventID", "sync123")
+	ctx := requestcontext.ContextWithUser(req.Context(), user)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	controller.RollbackSync(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+	assert.Contains(w.Body.String(), "sync_rollback")
+}
+
+func TestSyncController_RollbackSync_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasUser         bool
+		syncEventID     string
+		orchestratorErr error
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:           "no user in context",
+			syncEventID:    "sync123",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:           "missing sync event ID",
+			hasUser:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "sync event ID is required",
+		},
+		{
+			name:            "sync event not found",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w: sync123", orchestrators.ErrSyncEventNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    "sync event not found",
+		},
+		{
+			name:            "nothing to rollback",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w sync123", orchestrators.ErrNothingToRollback),
+			expectedStatus:  http.StatusConflict,
+			expectedBody:    "no playlist snapshots recorded",
+		},
+		{
+			name:            "sync in progress",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w for base playlist base456", orchestrators.ErrSyncInProgress),
+			expectedStatus:  http.StatusConflict,
+			expectedBody:    "sync already in progress",
+		},
+		{
+			name:            "orchestrator error",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: errors.New("failed to get base playlist"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "failed to rollback sync",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			if tt.orchestratorErr != nil {
+				mockOrchestrator.EXPECT().RollbackSync(gomock.Any(), user.ID, tt.syncEventID).Return(nil, tt.orchestratorErr)
+			}
+
+			req := httptest.NewRequest("POST", "/api/sync/"+tt.syncEventID+"/rollback", nil)
+			req.SetPathValue("syncEventID", tt.syncEventID)
+			if tt.hasUser {
+				ctx := requestcontext.ContextWithUser(req.Context(), user)
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			controller.RollbackSync(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestSyncController_ConfirmSync_Success(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testfixtures.NewUser().WithID("user123").Build()
+	confirmedSyncEvent := testfixtures.NewSyncEvent().
+		WithID("sync_confirmed").
+		WithUserID(user.ID).
+		WithBasePlaylistID("base456").
+		WithStatus(models.SyncStatusCompleted).
+		Build()
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	mockOrchestrator.EXPECT().ConfirmSync(gomock.Any(), user.ID, "sync123").Return(confirmedSyncEvent, nil)
+
+	req := httptest.NewRequest("POST", "/api/sync/sync123/confirm", nil)
+	req.SetPathValue("syncEventID", "sync123")
+	ctx := requestcontext.ContextWithUser(req.Context(), user)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	controller.ConfirmSync(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+	assert.Contains(w.Body.String(), "sync_confirmed")
+}
+
+func TestSyncController_ConfirmSync_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasUser         bool
+		syncEventID     string
+		orchestratorErr error
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:           "no user in context",
+			syncEventID:    "sync123",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:           "missing sync event ID",
+			hasUser:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "sync event ID is required",
+		},
+		{
+			name:            "sync event not found",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w: sync123", orchestrators.ErrSyncEventNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    "sync event not found",
+		},
+		{
+			name:            "sync not awaiting confirmation",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w: sync123", orchestrators.ErrSyncNotAwaitingConfirmation),
+			expectedStatus:  http.StatusConflict,
+			expectedBody:    "not awaiting confirmation",
+		},
+		{
+			name:            "sync in progress",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w for base playlist base456", orchestrators.ErrSyncInProgress),
+			expectedStatus:  http.StatusConflict,
+			expectedBody:    "sync already in progress",
+		},
+		{
+			name:            "orchestrator error",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: errors.New("failed to get base playlist"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "failed to confirm sync",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			if tt.orchestratorErr != nil {
+				mockOrchestrator.EXPECT().ConfirmSync(gomock.Any(), user.ID, tt.syncEventID).Return(nil, tt.orchestratorErr)
+			}
+
+			req := httptest.NewRequest("POST", "/api/sync/"+tt.syncEventID+"/confirm", nil)
+			req.SetPathValue("syncEventID", tt.syncEventID)
+			if tt.hasUser {
+				ctx := requestcontext.ContextWithUser(req.Context(), user)
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			controller.ConfirmSync(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestSyncController_AuditBasePlaylist(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasUser         bool
+		basePlaylistID  string
+		audit           *models.BasePlaylistAudit
+		orchestratorErr error
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:           "success",
+			hasUser:        true,
+			basePlaylistID: "base123",
+			audit: &models.BasePlaylistAudit{
+				BasePlaylistID: "base123",
+				Children: []models.ChildPlaylistAudit{
+					{ChildPlaylistID: "child1", Drift: []models.PlaylistDriftType{models.PlaylistDriftMissing}},
+				},
+				Drifted: 1,
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"drift":["playlist_missing"]`,
+		},
+		{
+			name:           "no user in context",
+			basePlaylistID: "base123",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:           "missing base playlist ID",
+			hasUser:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "base playlist ID is required",
+		},
+		{
+			name:            "base playlist not found",
+			hasUser:         true,
+			basePlaylistID:  "base123",
+			orchestratorErr: fmt.Errorf("failed to get base playlist: %w", repositories.ErrBasePlaylistNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    "base playlist not found",
+		},
+		{
+			name:            "orchestrator error",
+			hasUser:         true,
+			basePlaylistID:  "base123",
+			orchestratorErr: errors.New("failed to get user playlists"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "failed to audit base playlist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			if tt.audit != nil || tt.orchestratorErr != nil {
+				mockOrchestrator.EXPECT().AuditBasePlaylist(gomock.Any(), user.ID, tt.basePlaylistID).Return(tt.audit, tt.orchestratorErr)
+			}
+
+			req := httptest.NewRequest("GET", "/api/base_playlist/"+tt.basePlaylistID+"/audit", nil)
+			req.SetPathValue("basePlaylistID", tt.basePlaylistID)
+			if tt.hasUser {
+				ctx := requestcontext.ContextWithUser(req.Context(), user)
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			controller.AuditBasePlaylist(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestSyncController_PreviewFilters(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasUser         bool
+		basePlaylistID  string
+		body            string
+		preview         *models.FilterPreview
+		orchestratorErr error
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:           "success",
+			hasUser:        true,
+			basePlaylistID: "base123",
+			body:           `{"filter_rules":{"popularity":{"min":50}},"sample_size":5}`,
+			preview: &models.FilterPreview{
+				BasePlaylistID:  "base123",
+				TotalTracks:     10,
+				MatchedTracks:   1,
+				UnmatchedTracks: 9,
+				Sample:          []models.FilterPreviewTrack{{ID: "track1", Name: "Song"}},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"matched_tracks":1`,
+		},
+		{
+			name:           "invalid payload",
+			hasUser:        true,
+			basePlaylistID: "base123",
+			body:           `{"filter_rules":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid payload",
+		},
+		{
+			name:           "missing filter rules",
+			hasUser:        true,
+			basePlaylistID: "base123",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "validation failed",
+		},
+		{
+			name:           "invalid filter rules",
+			hasUser:        true,
+			basePlaylistID: "base123",
+			body:           `{"filter_rules":{"popularity":{"min":80,"max":20}}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "min can not be greater than max",
+		},
+		{
+			name:           "invalid duration",
+			hasUser:        true,
+			basePlaylistID: "base123",
+			body:           `{"filter_rules":{"duration":{"preset":"endless"}}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "validation failed",
+		},
+		{
+			name:           "no user in context",
+			basePlaylistID: "base123",
+			body:           `{"filter_rules":{}}`,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:            "base playlist not found",
+			hasUser:         true,
+			basePlaylistID:  "base123",
+			body:            `{"filter_rules":{}}`,
+			orchestratorErr: fmt.Errorf("failed to get base playlist: %w", repositories.ErrBasePlaylistNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    "base playlist not found",
+		},
+		{
+			name:            "orchestrator error",
+			hasUser:         true,
+			basePlaylistID:  "base123",
+			body:            `{"filter_rules":{}}`,
+			orchestratorErr: errors.New("failed to aggregate track data"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "failed to preview filter rules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			if tt.preview != nil || tt.orchestratorErr != nil {
+				mockOrchestrator.EXPECT().PreviewFilters(gomock.Any(), user.ID, tt.basePlaylistID, gomock.Any()).Return(tt.preview, tt.orchestratorErr)
+			}
+
+			req := httptest.NewRequest("POST", "/api/base_playlist/"+tt.basePlaylistID+"/filter_preview", strings.NewReader(tt.body))
+			req.SetPathValue("basePlaylistID", tt.basePlaylistID)
+			if tt.hasUser {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), user))
+			}
+
+			w := httptest.NewRecorder()
+			controller.PreviewFilters(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestSyncController_AuditAllBasePlaylists(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasUser         bool
+		report          *models.AuditReport
+		orchestratorErr error
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:           "success",
+			hasUser:        true,
+			report:         &models.AuditReport{UserID: "user123", TotalChildren: 3, DriftedChildren: 1},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"drifted_children":1`,
+		},
+		{
+			name:           "no user in context",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:            "orchestrator error",
+			hasUser:         true,
+			orchestratorErr: errors.New("failed to get base playlists"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "failed to audit base playlists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			if tt.hasUser {
+				mockOrchestrator.EXPECT().AuditAllBasePlaylists(gomock.Any(), user.ID).Return(tt.report, tt.orchestratorErr)
+			}
+
+			req := httptest.NewRequest("GET", "/api/sync/audit", nil)
+			if tt.hasUser {
+				ctx := requestcontext.ContextWithUser(req.Context(), user)
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			controller.AuditAllBasePlaylists(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestSyncController_GetRoutingReport_Success(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testfixtures.NewUser().WithID("user123").Build()
+	report := &models.RoutingReport{
+		ID:             "report123",
+		UserID:         user.ID,
+		BasePlaylistID: "base456",
+		SyncEventID:    "sync123",
+		Tracks: []models.TrackRoutingDecision{
+			{
+				TrackURI:        "spotify:track:1",
+				MatchedChildIDs: []string{"child1", "child2"},
+				RoutedChildIDs:  []string{"child1"},
+				Outcome:         models.RoutingOutcomeRouted,
+			},
+		},
+	}
+
+	mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+	controller := NewSyncController(mockOrchestrator, nil)
+
+	mockOrchestrator.EXPECT().GetRoutingReport(gomock.Any(), user.ID, "sync123").Return(report, nil)
+
+	req := httptest.NewRequest("GET", "/api/sync/sync123/report", nil)
+	req.SetPathValue("syncEventID", "sync123")
+	ctx := requestcontext.ContextWithUser(req.Context(), user)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	controller.GetRoutingReport(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("application/json", w.Header().Get("Content-Type"))
+
+	var response models.RoutingReport
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal("report123", response.ID)
+	assert.Equal([]string{"child1", "child2"}, response.Tracks[0].MatchedChildIDs)
+}
+
+func TestSyncController_GetRoutingReport_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasUser         bool
+		syncEventID     string
+		orchestratorErr error
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name:           "no user in context",
+			syncEventID:    "sync123",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "user not found in context",
+		},
+		{
+			name:           "missing sync event ID",
+			hasUser:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "sync event ID is required",
+		},
+		{
+			name:            "sync event not found",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w: sync123", orchestrators.ErrSyncEventNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    "sync event not found",
+		},
+		{
+			name:            "report not found",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: fmt.Errorf("%w: sync123", orchestrators.ErrRoutingReportNotFound),
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    "no routing report recorded",
+		},
+		{
+			name:            "orchestrator error",
+			hasUser:         true,
+			syncEventID:     "sync123",
+			orchestratorErr: errors.New("database error"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedBody:    "failed to get routing report",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testfixtures.NewUser().WithID("user123").Build()
+
+			mockOrchestrator := mocks.NewMockSyncOrchestrator(ctrl)
+			controller := NewSyncController(mockOrchestrator, nil)
+
+			if tt.orchestratorErr != nil {
+				mockOrchestrator.EXPECT().GetRoutingReport(gomock.Any(), user.ID, tt.syncEventID).Return(nil, tt.orchestratorErr)
+			}
+
+			req := httptest.NewRequest("GET", "/api/sync/"+tt.syncEventID+"/report", nil)
+			req.SetPathValue("syncEventID", tt.syncEventID)
+			if tt.hasUser {
+				ctx := requestcontext.ContextWithUser(req.Context(), user)
+				req = req.WithContext(ctx)
+			}
+
+			w := httptest.NewRecorder()
+			controller.GetRoutingReport(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func setupSyncHistoryController(t *testing.T) (*SyncController, *servicemocks.MockSyncEventServicer) {
+	ctrl := gomock.NewController(t)
+	mockService := servicemocks.NewMockSyncEventServicer(ctrl)
+	return NewSyncController(mocks.NewMockSyncOrchestrator(ctrl), mockService), mockService
+}
+
+func TestSyncController_ListSyncEvents(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService := setupSyncHistoryController(t)
+
+	page := &models.SyncEventPage{
+		Items:    []*models.SyncEvent{{ID: "sync123", Status: models.SyncStatusFailed}},
+		PageInfo: models.PageInfo{Total: 21, Limit: 10, Offset: 20},
+	}
+	mockService.EXPECT().
+		ListSyncEvents(gomock.Any(), "user123", repositories.SyncEventFilter{
+			Status:         models.SyncStatusFailed,
+			BasePlaylistID: "base123",
+			CreatedAfter:   time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+			CreatedBefore:  time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+			Sort:           models.ListSortCreatedAsc,
+			Limit:          10,
+			Offset:         20,
+		}).
+		Return(page, nil)
+
+	path := "/api/sync_events?status=failed&base_playlist_id=base123&created_after=2024-06-01T00:00:00Z" +
+		"&created_before=2024-07-01T00:00:00Z&sort=created&limit=10&offset=20"
+	w := httptest.NewRecorder()
+	controller.ListSyncEvents(w, newAutomationRequest(http.MethodGet, path, ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var body models.SyncEventPage
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(page.PageInfo, body.PageInfo)
+	assert.Len(body.Items, 1)
+	assert.Equal("sync123", body.Items[0].ID)
+}
+
+func TestSyncController_ListSyncEvents_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "invalid offset", query: "?offset=-1", expectedStatus: http.StatusBadRequest, expectedError: "invalid offset"},
+		{name: "name sort is not supported", query: "?sort=name", expectedStatus: http.StatusBadRequest, expectedError: "invalid sort"},
+		{name: "invalid date", query: "?created_after=yesterday", expectedStatus: http.StatusBadRequest, expectedError: "invalid created_after"},
+		{name: "unknown status", query: "?status=exploded", expectedStatus: http.StatusBadRequest, expectedError: "invalid status"},
+		{
+			name:           "service error",
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "unable to retrieve sync events",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mockService := setupSyncHistoryController(t)
+
+			if tt.serviceErr != nil {
+				mockService.EXPECT().ListSyncEvents(gomock.Any(), "user123", gomock.Any()).Return(nil, tt.serviceErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.ListSyncEvents(w, newAutomationRequest(http.MethodGet, "/api/sync_events"+tt.query, ""))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedError)
+		})
+	}
+}
+
+func TestSyncController_ListSyncEvents_Unauthorized(t *testing.T) {
+	assert := require.New(t)
+	controller, _ := setupSyncHistoryController(t)
+
+	w := httptest.NewRecorder()
+	controller.ListSyncEvents(w, httptest.NewRequest(http.MethodGet, "/api/sync_events", nil))
+
+	assert.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func TestSyncController_ListBasePlaylistSyncEvents(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService := setupSyncHistoryController(t)
+
+	completedAt := time.Date(2024, time.June, 1, 12, 0, 5, 0, time.UTC)
+	durationMs := int64(5000)
+	page := &models.SyncEventPage{
+		Items: []*models.SyncEvent{{
+			ID:              "sync123",
+			BasePlaylistID:  "base123",
+			Status:          models.SyncStatusCompleted,
+			StartedAt:       completedAt.Add(-5 * time.Second),
+			CompletedAt:     &completedAt,
+			DurationMs:      &durationMs,
+			TracksProcessed: 42,
+		}},
+		PageInfo: models.PageInfo{Total: 1, Limit: 20},
+	}
+
+	// The base playlist of the path wins over the query parameter
+	mockService.EXPECT().
+		ListSyncEvents(gomock.Any(), "user123", repositories.SyncEventFilter{
+			Status:         models.SyncStatusCompleted,
+			BasePlaylistID: "base123",
+			Limit:          20,
+		}).
+		Return(page, nil)
+
+	req := newAutomationRequest(http.MethodGet, "/api/base_playlist/base123/sync_events?status=completed&base_playlist_id=other&limit=20", "")
+	req.SetPathValue("basePlaylistID", "base123")
+	w := httptest.NewRecorder()
+	controller.ListBasePlaylistSyncEvents(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var body models.SyncEventPage
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(body.Items, 1)
+	assert.Equal(int64(5000), *body.Items[0].DurationMs)
+	assert.Equal(42, body.Items[0].TracksProcessed)
+}
+
+func TestSyncController_ListBasePlaylistSyncEvents_MissingID(t *testing.T) {
+	assert := require.New(t)
+	controller, _ := setupSyncHistoryController(t)
+
+	w := httptest.NewRecorder()
+	controller.ListBasePlaylistSyncEvents(w, newAutomationRequest(http.MethodGet, "/api/base_playlist//sync_events", ""))
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Contains(w.Body.String(), "base playlist ID is required")
+}
+
+func TestSyncController_PruneSyncEvents(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService := setupSyncHistoryController(t)
+
+	mockService.EXPECT().PruneSyncEvents(gomock.Any(), "user123", "", 30*24*time.Hour).Return(7, nil)
+
+	w := httptest.NewRecorder()
+	controller.PruneSyncEvents(w, newAutomationRequest(http.MethodDelete, "/api/sync_events?older_than_days=30", ""))
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var body models.SyncEventPruneResult
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(7, body.Deleted)
+}
+
+func TestSyncController_PruneBasePlaylistSyncEvents(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService := setupSyncHistoryController(t)
+
+	mockService.EXPECT().PruneSyncEvents(gomock.Any(), "user123", "base123", time.Duration(0)).Return(3, nil)
+
+	req := newAutomationRequest(http.MethodDelete, "/api/base_playlist/base123/sync_events?older_than_days=0", "")
+	req.SetPathValue("basePlaylistID", "base123")
+	w := httptest.NewRecorder()
+	controller.PruneBasePlaylistSyncEvents(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.JSONEq(`{"deleted": 3}`, w.Body.String())
+}
+
+func TestSyncController_PruneSyncEvents_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "missing days", expectedStatus: http.StatusBadRequest, expectedError: "older_than_days is required"},
+		{name: "negative days", query: "?older_than_days=-1", expectedStatus: http.StatusBadRequest, expectedError: "invalid older_than_days"},
+		{name: "invalid days", query: "?older_than_days=month", expectedStatus: http.StatusBadRequest, expectedError: "invalid older_than_days"},
+		{
+			name:           "service error",
+			query:          "?older_than_days=30",
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "unable to prune sync events",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mockService := setupSyncHistoryController(t)
+
+			if tt.serviceErr != nil {
+				mockService.EXPECT().PruneSyncEvents(gomock.Any(), "user123", "", gomock.Any()).Return(0, tt.serviceErr)
+			}
+
+			w := httptest.NewRecorder()
+			controller.PruneSyncEvents(w, newAutomationRequest(http.MethodDelete, "/api/sync_events"+tt.query, ""))
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedError)
+		})
+	}
+}
+
+func TestSyncController_GetSyncEvent(t *testing.T) {
+	assert := require.New(t)
+	controller, mockService := setupSyncHistoryController(t)
+
+	errorMessage := "spotify unavailable"
+	mockService.EXPECT().
+		GetUserSyncEvent(gomock.Any(), "user123", "sync123").
+		Return(&models.SyncEvent{ID: "sync123", Status: models.SyncStatusFailed, ErrorMessage: &errorMessage}, nil)
+
+	req := newAutomationRequest(http.MethodGet, "/api/sync/sync123", "")
+	req.SetPathValue("syncEventID", "sync123")
+	w := httptest.NewRecorder()
+	controller.GetSyncEvent(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+
+	var body models.SyncEvent
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal("sync123", body.ID)
+	assert.Equal(errorMessage, *body.ErrorMessage)
+}
+
+func TestSyncController_GetSyncEvent_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		syncEventID    string
+		serviceErr     error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "missing sync event ID", expectedStatus: http.StatusBadRequest, expectedError: "sync event ID is required"},
+		{
+			name:           "sync event not found",
+			syncEventID:    "sync123",
+			serviceErr:     repositories.ErrSyncEventNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "sync_event_not_found",
+		},
+		{
+			name:           "service error",
+			syncEventID:    "sync123",
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "unable to retrieve sync event",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, mockService := setupSyncHistoryController(t)
+
+			if tt.serviceErr != nil {
+				mockService.EXPECT().GetUserSyncEvent(gomock.Any(), "user123", tt.syncEventID).Return(nil, tt.serviceErr)
+			}
+
+			req := newAutomationRequest(http.MethodGet, "/api/sync/"+tt.syncEventID, "")
+			req.SetPathValue("syncEventID", tt.syncEventID)
+			w := httptest.NewRecorder()
+			controller.GetSyncEvent(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			assert.Contains(w.Body.String(), tt.expectedError)
+		})
+	}
+}
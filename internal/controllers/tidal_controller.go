@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// TidalController links the tidal account of the user through the device flow and lists its
+// playlists, to be picked as base or child playlists
+type TidalController struct {
+	tidalAccountService services.TidalAccountServicer
+}
+
+func NewTidalController(tidalAccountService services.TidalAccountServicer) *TidalController {
+	return &TidalController{
+		tidalAccountService: tidalAccountService,
+	}
+}
+
+// StartLink starts linking a tidal account to the authenticated user, returning the code the user
+// enters on the tidal site
+func (c *TidalController) StartLink(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	link, err := c.tidalAccountService.StartLink(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to start tidal account link")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(link); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+	}
+}
+
+// PollLink completes the link of the user once they authorized it on tidal, answering 202 while
+// the authorization is pending
+func (c *TidalController) PollLink(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	account, err := c.tidalAccountService.PollLink(r.Context(), user.ID)
+	if errors.Is(err, services.ErrTidalLinkPending) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(map[string]string{"status": "pending"}); err != nil {
+			problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		}
+		return
+	}
+	if err != nil {
+		writeError(w, err, "unable to link tidal account")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(account); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+// GetAccount returns the tidal account linked by the user
+func (c *TidalController) GetAccount(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	account, err := c.tidalAccountService.GetAccount(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve tidal account")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(account); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+// Unlink removes the tidal account of the user, once no playlist lives on it anymore
+func (c *TidalController) Unlink(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	if err := c.tidalAccountService.Unlink(r.Context(), user.ID); err != nil {
+		writeError(w, err, "unable to unlink tidal account")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUserPlaylists returns the tidal playlists of the user not used by any base or child
+// playlist yet
+func (c *TidalController) GetUserPlaylists(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	playlists, err := c.tidalAccountService.ListPlaylists(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve tidal playlists")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(playlists); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTidalController(t *testing.T) (*TidalController, *mocks.MockTidalAccountServicer) {
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockTidalAccountServicer(ctrl)
+
+	return NewTidalController(service), service
+}
+
+func TestTidalController_StartLink(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupTidalController(t)
+
+	service.EXPECT().StartLink(gomock.Any(), "test_user_123").Return(&models.TidalDeviceLink{
+		UserCode:        "ABCDE",
+		VerificationURI: "https://link.tidal.com",
+		ExpiresIn:       300,
+		Interval:        2,
+	}, nil)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodPost, "/auth/tidal/link", nil))
+	w := httptest.NewRecorder()
+
+	controller.StartLink(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	var link models.TidalDeviceLink
+	assert.NoError(json.NewDecoder(w.Body).Decode(&link))
+	assert.Equal("ABCDE", link.UserCode)
+	assert.Equal(2, link.Interval)
+}
+
+func TestTidalController_PollLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		account        *models.TidalIntegration
+		serviceErr     error
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{
+			name:           "linked account",
+			account:        &models.TidalIntegration{ID: "tidal_integration123", DisplayName: "listener"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "authorization pending",
+			serviceErr:     services.ErrTidalLinkPending,
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "link not started",
+			serviceErr:     services.ErrTidalLinkInvalid,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeTidalLinkInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, service := setupTidalController(t)
+
+			service.EXPECT().PollLink(gomock.Any(), "test_user_123").Return(tt.account, tt.serviceErr)
+
+			req := addUserToContext(httptest.NewRequest(http.MethodPost, "/auth/tidal/link/poll", nil))
+			w := httptest.NewRecorder()
+
+			controller.PollLink(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				assert.Equal(tt.expectedCode, decodeProblem(t, w).Code)
+			}
+			if tt.account != nil {
+				var account models.TidalIntegration
+				assert.NoError(json.NewDecoder(w.Body).Decode(&account))
+				assert.Equal("listener", account.DisplayName)
+			}
+		})
+	}
+}
+
+func TestTidalController_GetAccount_NotLinked(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupTidalController(t)
+
+	service.EXPECT().GetAccount(gomock.Any(), "test_user_123").Return(nil, services.ErrTidalIntegrationUnavailable)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodGet, "/api/tidal/account", nil))
+	w := httptest.NewRecorder()
+
+	controller.GetAccount(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(problem.CodeTidalIntegrationRequired, decodeProblem(t, w).Code)
+}
+
+func TestTidalController_Unlink(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "unlinked", expectedStatus: http.StatusNoContent},
+		{name: "account still used by playlists", serviceErr: services.ErrTidalAccountInUse, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, service := setupTidalController(t)
+
+			service.EXPECT().Unlink(gomock.Any(), "test_user_123").Return(tt.serviceErr)
+
+			req := addUserToContext(httptest.NewRequest(http.MethodDelete, "/api/tidal/account", nil))
+			w := httptest.NewRecorder()
+
+			controller.Unlink(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestTidalController_GetUserPlaylists(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupTidalController(t)
+
+	service.EXPECT().ListPlaylists(gomock.Any(), "test_user_123").Return([]*models.SpotifyPlaylist{{ID: "pl-1", Name: "Mix"}}, nil)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodGet, "/api/tidal/playlists", nil))
+	w := httptest.NewRecorder()
+
+	controller.GetUserPlaylists(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	var playlists []*models.SpotifyPlaylist
+	assert.NoError(json.NewDecoder(w.Body).Decode(&playlists))
+	assert.Len(playlists, 1)
+	assert.Equal("pl-1", playlists[0].ID)
+}
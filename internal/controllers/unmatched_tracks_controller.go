@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+type UnmatchedTracksController struct {
+	unmatchedService services.UnmatchedTracksServicer
+}
+
+func NewUnmatchedTracksController(unmatchedService services.UnmatchedTracksServicer) *UnmatchedTracksController {
+	return &UnmatchedTracksController{
+		unmatchedService: unmatchedService,
+	}
+}
+
+// List responds with the tracks of a base playlist no child playlist matches, and the filter rules
+// of each child playlist they fail
+func (c *UnmatchedTracksController) List(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	basePlaylistID := r.PathValue("id")
+	if basePlaylistID == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "playlist id is required")
+		return
+	}
+
+	unmatched, err := c.unmatchedService.GetUnmatchedTracks(r.Context(), user.ID, basePlaylistID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUnauthorized) {
+			problem.Write(w, http.StatusNotFound, problem.CodeBasePlaylistNotFound, "base playlist not found")
+			return
+		}
+
+		writeError(w, err, "unable to list unmatched tracks")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(unmatched); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+		return
+	}
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmatchedTracksController_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   problem.Code
+	}{
+		{name: "success", expectedStatus: http.StatusOK},
+		{name: "not found", serviceErr: repositories.ErrBasePlaylistNotFound, expectedStatus: http.StatusNotFound, expectedCode: problem.CodeBasePlaylistNotFound},
+		{name: "other user", serviceErr: fmt.Errorf("failed to retrieve base playlist: %w", repositories.ErrUnauthorized), expectedStatus: http.StatusNotFound, expectedCode: problem.CodeBasePlaylistNotFound},
+		{name: "service error", serviceErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError, expectedCode: problem.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mockService := servicemocks.NewMockUnmatchedTracksServicer(gomock.NewController(t))
+			controller := NewUnmatchedTracksController(mockService)
+
+			var unmatched *models.UnmatchedTracks
+			if tt.serviceErr == nil {
+				unmatched = &models.UnmatchedTracks{
+					BasePlaylistID: "base123",
+					TotalTracks:    120,
+					Tracks: []models.UnmatchedTrack{{
+						TrackURI:  "spotify:track:1",
+						TrackName: "Song",
+						Artists:   []string{"Artist"},
+						Outcome:   models.RoutingOutcomeUnmatched,
+						ChildEvaluations: []models.ChildFilterEvaluation{
+							{ChildPlaylistID: "child1", ChildPlaylistName: "Workout", FailedRules: []string{"energy"}},
+						},
+					}},
+				}
+			}
+			mockService.EXPECT().GetUnmatchedTracks(gomock.Any(), "user123", "base123").Return(unmatched, tt.serviceErr)
+
+			req := newAutomationRequest(http.MethodGet, "/api/base_playlist/base123/unmatched", "")
+			req.SetPathValue("id", "base123")
+			w := httptest.NewRecorder()
+			controller.List(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.UnmatchedTracks
+				assert.NoError(json.NewDecoder(w.Body).Decode(&result))
+				assert.Equal(*unmatched, result)
+				return
+			}
+
+			var body problem.Problem
+			assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+			assert.Equal(tt.expectedCode, body.Code)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/ngomez18/playlist-router/internal/problem"
+)
+
+// FILTER_RULES_RULE is the rule reported for the invalid filter rules of a request
+const FILTER_RULES_RULE = "filter_rules"
+
+// newValidator validates the request payloads, naming the fields after their json key so the
+// errors match what the client sent
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		default:
+			return name
+		}
+	})
+
+	return validate
+}
+
+// writeValidationError responds 400 with a field error for every rule the payload broke
+func writeValidationError(w http.ResponseWriter, err error) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		problem.Write(w, http.StatusBadRequest, problem.CodeValidationFailed, "validation failed: "+err.Error())
+		return
+	}
+
+	fieldErrors := make([]problem.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fieldErrors = append(fieldErrors, problem.FieldError{
+			Field:   fieldPath(fieldError),
+			Rule:    fieldError.Tag(),
+			Message: fieldErrorMessage(fieldError),
+		})
+	}
+
+	writeFieldErrors(w, fieldErrors)
+}
+
+// writeFilterRulesError responds 400 with an error of MetadataFilters.Validate or ResolveDurations,
+// which are prefixed by the path of the invalid rule
+func writeFilterRulesError(w http.ResponseWriter, err error) {
+	field, message, found := strings.Cut(err.Error(), ": ")
+	if !found {
+		field, message = FILTER_RULES_RULE, err.Error()
+	}
+
+	writeFieldErrors(w, []problem.FieldError{{Field: field, Rule: FILTER_RULES_RULE, Message: message}})
+}
+
+func writeFieldErrors(w http.ResponseWriter, fieldErrors []problem.FieldError) {
+	details := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		details = append(details, fieldError.Field+": "+fieldError.Message)
+	}
+
+	p := problem.New(http.StatusBadRequest, problem.CodeValidationFailed, "validation failed: "+strings.Join(details, "; "))
+	p.Errors = fieldErrors
+	problem.WriteProblem(w, p)
+}
+
+// fieldPath drops the request struct from the namespace, e.g. CreateChildPlaylistRequest.name
+// becomes name
+func fieldPath(fieldError validator.FieldError) string {
+	_, path, found := strings.Cut(fieldError.Namespace(), ".")
+	if !found {
+		return fieldError.Field()
+	}
+	return path
+}
+
+func fieldErrorMessage(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "min", "max":
+		bound := "at least"
+		if fieldError.Tag() == "max" {
+			bound = "at most"
+		}
+
+		switch fieldError.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be %s %s characters long", bound, param)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must have %s %s items", bound, param)
+		default:
+			return fmt.Sprintf("must be %s %s", bound, param)
+		}
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "url":
+		return "must be a valid URL"
+	case "startswith":
+		return fmt.Sprintf("must start with %q", param)
+	default:
+		return fmt.Sprintf("failed the %s rule", fieldError.Tag())
+	}
+}
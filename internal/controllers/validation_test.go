@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) problem.Problem {
+	t.Helper()
+
+	var body problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func TestWriteValidationError(t *testing.T) {
+	assert := require.New(t)
+
+	req := models.CreateChildPlaylistRequest{
+		MaxTracks:             20000,
+		SelectionStrategy:     "loudest",
+		SourceBasePlaylistIDs: []string{"base123", ""},
+	}
+	err := newValidator().Struct(&req)
+	assert.Error(err)
+
+	w := httptest.NewRecorder()
+	writeValidationError(w, err)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	body := decodeProblem(t, w)
+	assert.Equal(problem.CodeValidationFailed, body.Code)
+	assert.Contains(body.Detail, "validation failed")
+	assert.Equal([]problem.FieldError{
+		{Field: "name", Rule: "required", Message: "is required"},
+		{Field: "max_tracks", Rule: "max", Message: "must be at most 10000"},
+		{Field: "selection_strategy", Rule: "oneof", Message: "must be one of: most_popular, newest, random, most_played"},
+		{Field: "source_base_playlist_ids[1]", Rule: "required", Message: "is required"},
+	}, body.Errors)
+}
+
+func TestWriteValidationError_NotValidationErrors(t *testing.T) {
+	assert := require.New(t)
+
+	w := httptest.NewRecorder()
+	writeValidationError(w, errors.New("validator: (nil *models.CreateChildPlaylistRequest)"))
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	body := decodeProblem(t, w)
+	assert.Equal(problem.CodeValidationFailed, body.Code)
+	assert.Empty(body.Errors)
+}
+
+func TestWriteFilterRulesError(t *testing.T) {
+	assert := require.New(t)
+
+	w := httptest.NewRecorder()
+	writeFilterRulesError(w, errors.New("filter_rules.and[1].energy: min can not be greater than max"))
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	body := decodeProblem(t, w)
+	assert.Equal("validation failed: filter_rules.and[1].energy: min can not be greater than max", body.Detail)
+	assert.Equal([]problem.FieldError{
+		{Field: "filter_rules.and[1].energy", Rule: FILTER_RULES_RULE, Message: "min can not be greater than max"},
+	}, body.Errors)
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// YouTubeMusicController links the youtube music account of the user and lists its playlists, to be
+// picked as base or child playlists
+type YouTubeMusicController struct {
+	youTubeMusicAccountService services.YouTubeMusicAccountServicer
+	config                     *config.Config
+}
+
+func NewYouTubeMusicController(youTubeMusicAccountService services.YouTubeMusicAccountServicer, config *config.Config) *YouTubeMusicController {
+	return &YouTubeMusicController{
+		youTubeMusicAccountService: youTubeMusicAccountService,
+		config:                     config,
+	}
+}
+
+// Link starts linking a youtube music account to the authenticated user. The authorization URL is
+// returned instead of redirecting, since the browser can't send the auth token on a redirect
+func (c *YouTubeMusicController) Link(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	authURL, err := c.youTubeMusicAccountService.GenerateLinkURL(user.ID)
+	if err != nil {
+		writeError(w, err, "unable to start youtube music account link")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]string{"auth_url": authURL}); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "failed to encode response")
+	}
+}
+
+// Callback completes the link Google redirects back to, then sends the user to the frontend
+func (c *YouTubeMusicController) Callback(w http.ResponseWriter, r *http.Request) {
+	code := r.URL.Query().Get("code")
+	state := r.URL.Query().Get("state")
+
+	if code == "" {
+		problem.Write(w, http.StatusBadRequest, problem.CodeMissingParameter, "authorization code is required")
+		return
+	}
+
+	if _, err := c.youTubeMusicAccountService.HandleCallback(r.Context(), code, state); err != nil {
+		writeError(w, err, "unable to link youtube music account")
+		return
+	}
+
+	redirectURL := fmt.Sprintf("%s/?youtube_music=linked", c.config.Auth.FrontendURL)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
+// GetAccount returns the youtube music account linked by the user
+func (c *YouTubeMusicController) GetAccount(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	account, err := c.youTubeMusicAccountService.GetAccount(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve youtube music account")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(account); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
+
+// Unlink removes the youtube music account of the user, once no playlist lives on it anymore
+func (c *YouTubeMusicController) Unlink(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	if err := c.youTubeMusicAccountService.Unlink(r.Context(), user.ID); err != nil {
+		writeError(w, err, "unable to unlink youtube music account")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUserPlaylists returns the youtube music playlists of the user not used by any base or child
+// playlist yet
+func (c *YouTubeMusicController) GetUserPlaylists(w http.ResponseWriter, r *http.Request) {
+	user, ok := requestcontext.GetUserFromContext(r.Context())
+	if !ok {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+		return
+	}
+
+	playlists, err := c.youTubeMusicAccountService.ListPlaylists(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, err, "unable to retrieve youtube music playlists")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(playlists); err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to encode response")
+	}
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupYouTubeMusicController(t *testing.T) (*YouTubeMusicController, *mocks.MockYouTubeMusicAccountServicer) {
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockYouTubeMusicAccountServicer(ctrl)
+	cfg := &config.Config{Auth: config.AuthConfig{FrontendURL: "http://localhost:5173"}}
+
+	return NewYouTubeMusicController(service, cfg), service
+}
+
+func TestYouTubeMusicController_Link(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupYouTubeMusicController(t)
+
+	service.EXPECT().GenerateLinkURL("test_user_123").Return("https://accounts.google.com/o/oauth2/v2/auth?state=abc", nil)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodPost, "/api/youtube_music/link", nil))
+	w := httptest.NewRecorder()
+
+	controller.Link(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	var body map[string]string
+	assert.NoError(json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal("https://accounts.google.com/o/oauth2/v2/auth?state=abc", body["auth_url"])
+}
+
+func TestYouTubeMusicController_Callback(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		serviceErr       error
+		expectCall       bool
+		expectedStatus   int
+		expectedLocation string
+		expectedCode     problem.Code
+	}{
+		{
+			name:             "linked account redirects to the frontend",
+			query:            "?code=auth_code&state=state123",
+			expectCall:       true,
+			expectedStatus:   http.StatusTemporaryRedirect,
+			expectedLocation: "http://localhost:5173/?youtube_music=linked",
+		},
+		{
+			name:           "missing code",
+			query:          "?state=state123",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeMissingParameter,
+		},
+		{
+			name:           "unknown state",
+			query:          "?code=auth_code&state=state123",
+			serviceErr:     services.ErrYouTubeMusicAuthStateInvalid,
+			expectCall:     true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   problem.CodeYouTubeMusicAuthInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, service := setupYouTubeMusicController(t)
+
+			if tt.expectCall {
+				service.EXPECT().HandleCallback(gomock.Any(), "auth_code", "state123").
+					Return(&models.YouTubeMusicIntegration{ID: "yt_integration123"}, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/youtube_music/callback"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			controller.Callback(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedLocation != "" {
+				assert.Equal(tt.expectedLocation, w.Header().Get("Location"))
+			}
+			if tt.expectedCode != "" {
+				assert.Equal(tt.expectedCode, decodeProblem(t, w).Code)
+			}
+		})
+	}
+}
+
+func TestYouTubeMusicController_GetAccount_NotLinked(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupYouTubeMusicController(t)
+
+	service.EXPECT().GetAccount(gomock.Any(), "test_user_123").Return(nil, services.ErrYouTubeMusicIntegrationUnavailable)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodGet, "/api/youtube_music/account", nil))
+	w := httptest.NewRecorder()
+
+	controller.GetAccount(w, req)
+
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(problem.CodeYouTubeMusicIntegrationRequired, decodeProblem(t, w).Code)
+}
+
+func TestYouTubeMusicController_Unlink(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "unlinked", expectedStatus: http.StatusNoContent},
+		{name: "account still used by playlists", serviceErr: services.ErrYouTubeMusicAccountInUse, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			controller, service := setupYouTubeMusicController(t)
+
+			service.EXPECT().Unlink(gomock.Any(), "test_user_123").Return(tt.serviceErr)
+
+			req := addUserToContext(httptest.NewRequest(http.MethodDelete, "/api/youtube_music/account", nil))
+			w := httptest.NewRecorder()
+
+			controller.Unlink(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestYouTubeMusicController_GetUserPlaylists(t *testing.T) {
+	assert := require.New(t)
+	controller, service := setupYouTubeMusicController(t)
+
+	service.EXPECT().ListPlaylists(gomock.Any(), "test_user_123").Return([]*models.SpotifyPlaylist{{ID: "PLabc", Name: "Mix"}}, nil)
+
+	req := addUserToContext(httptest.NewRequest(http.MethodGet, "/api/youtube_music/playlists", nil))
+	w := httptest.NewRecorder()
+
+	controller.GetUserPlaylists(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	var playlists []*models.SpotifyPlaylist
+	assert.NoError(json.NewDecoder(w.Body).Decode(&playlists))
+	assert.Len(playlists, 1)
+	assert.Equal("PLabc", playlists[0].ID)
+}
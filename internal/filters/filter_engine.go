@@ -1,36 +1,114 @@
 package filters
 
-import "github.com/ngomez18/playlist-router/internal/models"
+import (
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
 
 type FilterEngine struct {
-	filters []Filter
+	filters []ruleFilter
+}
+
+// ruleFilter is the filter of a top level filter rule, named after its field in the filter rules
+type ruleFilter struct {
+	rule   string
+	filter Filter
 }
 
 func NewFilterEngine(playlist *models.ChildPlaylist) *FilterEngine {
 	if playlist.FilterRules == nil {
-		return &FilterEngine{filters: []Filter{}}
+		return &FilterEngine{filters: []ruleFilter{}}
+	}
+
+	return &FilterEngine{filters: buildRuleFilters(playlist.FilterRules, time.Now())}
+}
+
+// buildFilters turns a node of the filter rule expression into the filters a track must all match,
+// nesting its and/or/not groups as group filters. Relative date filters are resolved against now.
+func buildFilters(rules *models.MetadataFilters, now time.Time) []Filter {
+	ruleFilters := buildRuleFilters(rules, now)
+
+	filters := make([]Filter, len(ruleFilters))
+	for i, ruleFilter := range ruleFilters {
+		filters[i] = ruleFilter.filter
+	}
+	return filters
+}
+
+func buildRuleFilters(rules *models.MetadataFilters, now time.Time) []ruleFilter {
+	filters := []ruleFilter{
+		{"duration_ms", &DurationFilter{rules.Duration}},
+		{"popularity", &PopularityFilter{rules.Popularity}},
+		{"explicit", &ExplicitFilter{rules.Explicit}},
+		{"added_date", &AddedDateFilter{rules.AddedDate, now}},
+		{"genres", &GenresFilter{rules.Genres}},
+		{"artists", &ArtistsFilter{rules.Artists}},
+		{"release_year", &ReleaseYearFilter{rules.ReleaseYear}},
+		{"release_date", &ReleaseDateFilter{rules.ReleaseDate, now}},
+		{"artist_popularity", &ArtistPopularityFilter{rules.ArtistPopularity}},
+		{"track_keywords", &TrackKeywordsFilter{rules.TrackKeywords}},
+		{"artist_keywords", &ArtistKeywordsFilter{rules.ArtistKeywords}},
+		{"track_name", &NameFilter{rules.TrackName, func(t models.TrackInfo) []string { return []string{t.Name} }}},
+		{"artist_name", &NameFilter{rules.ArtistName, func(t models.TrackInfo) []string { return t.ArtistNames }}},
+		{"album_name", &NameFilter{rules.AlbumName, func(t models.TrackInfo) []string { return []string{t.Album.Name} }}},
+		{"tempo", &AudioFeatureFilter{rules.Tempo, func(a *models.AudioFeatures) float64 { return a.Tempo }}},
+		{"energy", &AudioFeatureFilter{rules.Energy, func(a *models.AudioFeatures) float64 { return a.Energy }}},
+		{"danceability", &AudioFeatureFilter{rules.Danceability, func(a *models.AudioFeatures) float64 { return a.Danceability }}},
+		{"valence", &AudioFeatureFilter{rules.Valence, func(a *models.AudioFeatures) float64 { return a.Valence }}},
+		{"acousticness", &AudioFeatureFilter{rules.Acousticness, func(a *models.AudioFeatures) float64 { return a.Acousticness }}},
+		{"instrumentalness", &AudioFeatureFilter{rules.Instrumentalness, func(a *models.AudioFeatures) float64 { return a.Instrumentalness }}},
+		{"liveness", &AudioFeatureFilter{rules.Liveness, func(a *models.AudioFeatures) float64 { return a.Liveness }}},
+		{"speechiness", &AudioFeatureFilter{rules.Speechiness, func(a *models.AudioFeatures) float64 { return a.Speechiness }}},
+		{"loudness", &AudioFeatureFilter{rules.Loudness, func(a *models.AudioFeatures) float64 { return a.Loudness }}},
+		{"key", &AudioFeatureFilter{rules.Key, func(a *models.AudioFeatures) float64 { return float64(a.Key) }}},
+		{"mode", &AudioFeatureFilter{rules.Mode, func(a *models.AudioFeatures) float64 { return float64(a.Mode) }}},
+		{"play_count", &PlayCountFilter{rules.PlayCount}},
+		{"lastfm_tags", &LastFmTagsFilter{rules.LastFmTags}},
 	}
 
-	filters := []Filter{
-		&DurationFilter{playlist.FilterRules.Duration},
-		&PopularityFilter{playlist.FilterRules.Popularity},
-		&ExplicitFilter{playlist.FilterRules.Explicit},
-		&GenresFilter{playlist.FilterRules.Genres},
-		&ReleaseYearFilter{playlist.FilterRules.ReleaseYear},
-		&ArtistPopularityFilter{playlist.FilterRules.ArtistPopularity},
-		&TrackKeywordsFilter{playlist.FilterRules.TrackKeywords},
-		&ArtistKeywordsFilter{playlist.FilterRules.ArtistKeywords},
+	for _, group := range rules.And {
+		if group != nil {
+			filters = append(filters, ruleFilter{"and", &AndFilter{buildFilters(group, now)}})
+		}
 	}
 
-	return &FilterEngine{filters: filters}
+	if len(rules.Or) > 0 {
+		orFilter := &OrFilter{}
+		for _, group := range rules.Or {
+			if group != nil {
+				orFilter.groups = append(orFilter.groups, &AndFilter{buildFilters(group, now)})
+			}
+		}
+		filters = append(filters, ruleFilter{"or", orFilter})
+	}
+
+	if rules.Not != nil {
+		filters = append(filters, ruleFilter{"not", &NotFilter{&AndFilter{buildFilters(rules.Not, now)}}})
+	}
+
+	return filters
 }
 
 func (eng *FilterEngine) MatchTrack(track models.TrackInfo) bool {
 	for _, filter := range eng.filters {
-		if ok := filter.Matches(track); !ok {
+		if ok := filter.filter.Matches(track); !ok {
 			return false
 		}
 	}
 
 	return true
 }
+
+// FailedRules returns the top level filter rules the track doesn't match, in filter evaluation
+// order, an and, or or not group failing as a whole. Empty when the track matches
+func (eng *FilterEngine) FailedRules(track models.TrackInfo) []string {
+	failed := []string{}
+	for _, filter := range eng.filters {
+		if !filter.filter.Matches(track) {
+			failed = append(failed, filter.rule)
+		}
+	}
+
+	return failed
+}
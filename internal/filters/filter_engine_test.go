@@ -26,13 +26,13 @@ func TestNewFilterEngine(t *testing.T) {
 		engine := NewFilterEngine(playlist)
 
 		assert.NotNil(t, engine)
-		assert.Len(t, engine.filters, 8) // All filter types are created
+		assert.Len(t, engine.filters, 27) // All filter types are created
 	})
 }
 
 func TestFilterEngine_MatchTrack(t *testing.T) {
 	t.Run("empty filter engine matches all", func(t *testing.T) {
-		engine := &FilterEngine{filters: []Filter{}}
+		engine := &FilterEngine{filters: []ruleFilter{}}
 		track := models.TrackInfo{DurationMs: 180000}
 
 		assert.True(t, engine.MatchTrack(track))
@@ -57,6 +57,25 @@ func TestFilterEngine_MatchTrack(t *testing.T) {
 		assert.True(t, engine.MatchTrack(track))
 	})
 
+	t.Run("audio feature filters", func(t *testing.T) {
+		playlist := &models.ChildPlaylist{
+			FilterRules: &models.MetadataFilters{
+				Tempo:  &models.RangeFilter{Min: float64Ptr(120), Max: float64Ptr(140)},
+				Energy: &models.RangeFilter{Min: float64Ptr(0.7)},
+				Mode:   &models.RangeFilter{Min: float64Ptr(1)},
+			},
+		}
+		engine := NewFilterEngine(playlist)
+
+		assert.True(t, engine.MatchTrack(models.TrackInfo{
+			AudioFeatures: &models.AudioFeatures{Tempo: 128, Energy: 0.9, Mode: 1},
+		}))
+		assert.False(t, engine.MatchTrack(models.TrackInfo{
+			AudioFeatures: &models.AudioFeatures{Tempo: 128, Energy: 0.9, Mode: 0},
+		}))
+		assert.False(t, engine.MatchTrack(models.TrackInfo{}))
+	})
+
 	t.Run("one filter fails", func(t *testing.T) {
 		playlist := &models.ChildPlaylist{
 			FilterRules: &models.MetadataFilters{
@@ -103,3 +122,83 @@ func TestFilterEngine_MatchTrack(t *testing.T) {
 		assert.False(t, engine.MatchTrack(failingTrack))
 	})
 }
+
+func TestFilterEngine_BooleanComposition(t *testing.T) {
+	rock := models.TrackInfo{AllGenres: []string{"rock"}, Popularity: 80, Explicit: true}
+	jazz := models.TrackInfo{AllGenres: []string{"jazz"}, Popularity: 40}
+	pop := models.TrackInfo{AllGenres: []string{"pop"}, Popularity: 90}
+
+	t.Run("or of groups", func(t *testing.T) {
+		engine := NewFilterEngine(&models.ChildPlaylist{
+			FilterRules: &models.MetadataFilters{
+				Or: []*models.MetadataFilters{
+					{Genres: &models.SetFilter{Include: []string{"rock"}}},
+					{Genres: &models.SetFilter{Include: []string{"jazz"}}},
+				},
+			},
+		})
+
+		assert.True(t, engine.MatchTrack(rock))
+		assert.True(t, engine.MatchTrack(jazz))
+		assert.False(t, engine.MatchTrack(pop))
+	})
+
+	t.Run("conditions are combined with groups", func(t *testing.T) {
+		// popularity >= 50 AND (rock OR pop) AND NOT explicit
+		engine := NewFilterEngine(&models.ChildPlaylist{
+			FilterRules: &models.MetadataFilters{
+				Popularity: &models.RangeFilter{Min: float64Ptr(50)},
+				Or: []*models.MetadataFilters{
+					{Genres: &models.SetFilter{Include: []string{"rock"}}},
+					{Genres: &models.SetFilter{Include: []string{"pop"}}},
+				},
+				Not: &models.MetadataFilters{Explicit: boolPtr(true)},
+			},
+		})
+
+		assert.False(t, engine.MatchTrack(rock))
+		assert.False(t, engine.MatchTrack(jazz))
+		assert.True(t, engine.MatchTrack(pop))
+	})
+
+	t.Run("nested groups", func(t *testing.T) {
+		// (rock AND popularity >= 50) OR NOT (popularity >= 50)
+		engine := NewFilterEngine(&models.ChildPlaylist{
+			FilterRules: &models.MetadataFilters{
+				Or: []*models.MetadataFilters{
+					{And: []*models.MetadataFilters{
+						{Genres: &models.SetFilter{Include: []string{"rock"}}},
+						{Popularity: &models.RangeFilter{Min: float64Ptr(50)}},
+					}},
+					{Not: &models.MetadataFilters{Popularity: &models.RangeFilter{Min: float64Ptr(50)}}},
+				},
+			},
+		})
+
+		assert.True(t, engine.MatchTrack(rock))
+		assert.True(t, engine.MatchTrack(jazz))
+		assert.False(t, engine.MatchTrack(pop))
+	})
+}
+
+func TestFilterEngine_FailedRules(t *testing.T) {
+	engine := NewFilterEngine(&models.ChildPlaylist{
+		FilterRules: &models.MetadataFilters{
+			Popularity: &models.RangeFilter{Min: float64Ptr(50)},
+			Genres:     &models.SetFilter{Include: []string{"rock"}},
+			Not:        &models.MetadataFilters{Explicit: boolPtr(true)},
+		},
+	})
+
+	t.Run("matching track", func(t *testing.T) {
+		track := models.TrackInfo{Popularity: 60, AllGenres: []string{"rock"}}
+
+		assert.Empty(t, engine.FailedRules(track))
+	})
+
+	t.Run("failing rules", func(t *testing.T) {
+		track := models.TrackInfo{Popularity: 20, AllGenres: []string{"rock"}, Explicit: true}
+
+		assert.Equal(t, []string{"popularity", "not"}, engine.FailedRules(track))
+	})
+}
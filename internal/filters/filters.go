@@ -3,10 +3,13 @@ package filters
 import (
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 )
 
+const SPOTIFY_ARTIST_URI_PREFIX = "spotify:artist:"
+
 type Filter interface {
 	Matches(track models.TrackInfo) bool
 }
@@ -43,6 +46,25 @@ func (f *GenresFilter) Matches(track models.TrackInfo) bool {
 	return matchesSetFilterValues(f.SetFilter, track.AllGenres)
 }
 
+// ArtistsFilter matches the artists of a track, identified by Spotify ID, URI or name
+type ArtistsFilter struct {
+	*models.SetFilter
+}
+
+func (f *ArtistsFilter) Matches(track models.TrackInfo) bool {
+	if f.SetFilter == nil {
+		return true
+	}
+
+	identifiers := make([]string, 0, 2*len(track.Artists)+len(track.ArtistNames))
+	for _, artistID := range track.Artists {
+		identifiers = append(identifiers, artistID, SPOTIFY_ARTIST_URI_PREFIX+artistID)
+	}
+	identifiers = append(identifiers, track.ArtistNames...)
+
+	return matchesSetFilterValues(f.SetFilter, identifiers)
+}
+
 type ReleaseYearFilter struct {
 	*models.RangeFilter
 }
@@ -51,6 +73,48 @@ func (f *ReleaseYearFilter) Matches(track models.TrackInfo) bool {
 	return matchesRangeFilter(f.RangeFilter, float64(track.ReleaseYear))
 }
 
+// ReleaseDateFilter matches the album release date of a track against a date filter resolved on
+// the day of now. Tracks without a release date never match an active release date filter.
+type ReleaseDateFilter struct {
+	*models.DateFilter
+	now time.Time
+}
+
+func (f *ReleaseDateFilter) Matches(track models.TrackInfo) bool {
+	return matchesDateFilter(f.DateFilter, f.now, track.ReleaseDate)
+}
+
+// AddedDateFilter matches the date a track was added to the base playlist, so rolling "recently
+// added" child playlists can be built. Tracks without an added date never match an active filter.
+type AddedDateFilter struct {
+	*models.DateFilter
+	now time.Time
+}
+
+func (f *AddedDateFilter) Matches(track models.TrackInfo) bool {
+	return matchesDateFilter(f.DateFilter, f.now, track.AddedAt)
+}
+
+// matchesDateFilter checks the day of date falls within the bounds of the filter on the day of now
+func matchesDateFilter(filter *models.DateFilter, now, date time.Time) bool {
+	if filter == nil {
+		return true
+	}
+
+	if date.IsZero() {
+		return false
+	}
+
+	date = date.UTC()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	from, to := filter.Bounds(now)
+	afterFrom := from.IsZero() || !day.Before(from)
+	beforeTo := to.IsZero() || !day.After(to)
+
+	return afterFrom && beforeTo
+}
+
 type ArtistPopularityFilter struct {
 	*models.RangeFilter
 }
@@ -76,6 +140,130 @@ func (f *ArtistKeywordsFilter) Matches(track models.TrackInfo) bool {
 	return matchesSetFilterText(f.SetFilter, artistNamesText)
 }
 
+// NameFilter matches a text filter against names of a track. A track is excluded when any of its
+// names matches an exclude pattern, and included when any of them matches an include pattern.
+type NameFilter struct {
+	*models.TextFilter
+	names func(track models.TrackInfo) []string
+}
+
+func (f *NameFilter) Matches(track models.TrackInfo) bool {
+	if f.TextFilter == nil {
+		return true
+	}
+
+	names := f.names(track)
+	matchesAnyName := func(pattern models.TextPattern) bool {
+		return slices.ContainsFunc(names, func(name string) bool {
+			return matchesTextPattern(pattern, name)
+		})
+	}
+
+	if slices.ContainsFunc(f.Exclude, matchesAnyName) {
+		return false
+	}
+
+	if len(f.Include) > 0 {
+		return slices.ContainsFunc(f.Include, matchesAnyName)
+	}
+
+	return true
+}
+
+// AudioFeatureFilter matches a range over one of the audio features of a track.
+// Tracks without audio features never match an active audio feature filter.
+type AudioFeatureFilter struct {
+	*models.RangeFilter
+	feature func(features *models.AudioFeatures) float64
+}
+
+func (f *AudioFeatureFilter) Matches(track models.TrackInfo) bool {
+	if f.RangeFilter == nil {
+		return true
+	}
+
+	if track.AudioFeatures == nil {
+		return false
+	}
+
+	return matchesRangeFilter(f.RangeFilter, f.feature(track.AudioFeatures))
+}
+
+// PlayCountFilter matches how many times the user played a track according to their Last.fm
+// scrobbles. Tracks without Last.fm data never match an active play count filter.
+type PlayCountFilter struct {
+	*models.RangeFilter
+}
+
+func (f *PlayCountFilter) Matches(track models.TrackInfo) bool {
+	if f.RangeFilter == nil {
+		return true
+	}
+
+	if track.PlayCount == nil {
+		return false
+	}
+
+	return matchesRangeFilter(f.RangeFilter, float64(*track.PlayCount))
+}
+
+// LastFmTagsFilter matches the Last.fm top tags of a track. Tracks without Last.fm data never match
+// an active tags filter, not even one only excluding tags.
+type LastFmTagsFilter struct {
+	*models.SetFilter
+}
+
+func (f *LastFmTagsFilter) Matches(track models.TrackInfo) bool {
+	if f.SetFilter == nil {
+		return true
+	}
+
+	if track.PlayCount == nil {
+		return false
+	}
+
+	return matchesSetFilterValues(f.SetFilter, track.LastFmTags)
+}
+
+// AndFilter matches tracks matching every one of its filters
+type AndFilter struct {
+	filters []Filter
+}
+
+func (f *AndFilter) Matches(track models.TrackInfo) bool {
+	for _, filter := range f.filters {
+		if !filter.Matches(track) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OrFilter matches tracks matching at least one of its groups. Without groups it matches every track
+type OrFilter struct {
+	groups []Filter
+}
+
+func (f *OrFilter) Matches(track models.TrackInfo) bool {
+	if len(f.groups) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(f.groups, func(group Filter) bool {
+		return group.Matches(track)
+	})
+}
+
+// NotFilter matches tracks not matching its group
+type NotFilter struct {
+	Filter
+}
+
+func (f *NotFilter) Matches(track models.TrackInfo) bool {
+	return !f.Filter.Matches(track)
+}
+
 // filter matcher functions
 
 func matchesRangeFilter(filter *models.RangeFilter, value float64) bool {
@@ -141,3 +329,17 @@ func matchesSetFilterText(filter *models.SetFilter, text string) bool {
 
 	return true
 }
+
+func matchesTextPattern(pattern models.TextPattern, text string) bool {
+	switch pattern.Type {
+	case models.TextMatchContains:
+		return strings.Contains(strings.ToLower(text), strings.ToLower(pattern.Value))
+	case models.TextMatchPrefix:
+		return strings.HasPrefix(strings.ToLower(text), strings.ToLower(pattern.Value))
+	case models.TextMatchRegex:
+		regex := compileCachedRegex(pattern.Value)
+		return regex != nil && regex.MatchString(text)
+	default:
+		return false
+	}
+}
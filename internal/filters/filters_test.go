@@ -2,6 +2,7 @@ package filters
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -87,6 +88,36 @@ func TestGenresFilter(t *testing.T) {
 	}
 }
 
+func TestArtistsFilter(t *testing.T) {
+	track := models.TrackInfo{
+		Artists:     []string{"3WrFJ7ztbogyGnTHbHJFl2", "4x1nvY2FN8jxqAFA0DA02H"},
+		ArtistNames: []string{"The Beatles", "John Lennon"},
+	}
+
+	tests := []struct {
+		name     string
+		filter   *models.SetFilter
+		expected bool
+	}{
+		{"nil filter", nil, true},
+		{"include by id", &models.SetFilter{Include: []string{"3WrFJ7ztbogyGnTHbHJFl2"}}, true},
+		{"include by uri", &models.SetFilter{Include: []string{"spotify:artist:4x1nvY2FN8jxqAFA0DA02H"}}, true},
+		{"include by name", &models.SetFilter{Include: []string{"the beatles"}}, true},
+		{"include any of several artists", &models.SetFilter{Include: []string{"Queen", "John Lennon"}}, true},
+		{"include no match", &models.SetFilter{Include: []string{"Queen", "0LcJLqbBmaGUft1e9Mm8HV"}}, false},
+		{"name must match exactly", &models.SetFilter{Include: []string{"Beatles"}}, false},
+		{"exclude any featured artist", &models.SetFilter{Exclude: []string{"4x1nvY2FN8jxqAFA0DA02H"}}, false},
+		{"exclude no match", &models.SetFilter{Exclude: []string{"Queen"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &ArtistsFilter{tt.filter}
+			assert.Equal(t, tt.expected, filter.Matches(track))
+		})
+	}
+}
+
 func TestReleaseYearFilter(t *testing.T) {
 	filter := &ReleaseYearFilter{&models.RangeFilter{Min: float64Ptr(2000), Max: float64Ptr(2020)}}
 
@@ -95,6 +126,68 @@ func TestReleaseYearFilter(t *testing.T) {
 	assert.False(t, filter.Matches(models.TrackInfo{ReleaseYear: 2025}))
 }
 
+func TestReleaseDateFilter(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 18, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name        string
+		filter      *models.DateFilter
+		releaseDate time.Time
+		expected    bool
+	}{
+		{name: "nil filter", filter: nil, releaseDate: date(1990, time.May, 1), expected: true},
+		{name: "unknown release date", filter: &models.DateFilter{Decade: intPtr(2020)}, expected: false},
+		{name: "in decade", filter: &models.DateFilter{Decade: intPtr(2020)}, releaseDate: date(2029, time.December, 31), expected: true},
+		{name: "before decade", filter: &models.DateFilter{Decade: intPtr(2020)}, releaseDate: date(2019, time.December, 31), expected: false},
+		{name: "after decade", filter: &models.DateFilter{Decade: intPtr(2010)}, releaseDate: date(2020, time.January, 1), expected: false},
+		{name: "on absolute bounds", filter: &models.DateFilter{After: stringPtr("2024-01-01"), Before: stringPtr("2024-03-31")}, releaseDate: date(2024, time.March, 31), expected: true},
+		{name: "outside absolute bounds", filter: &models.DateFilter{After: stringPtr("2024-01-01"), Before: stringPtr("2024-03-31")}, releaseDate: date(2024, time.April, 1), expected: false},
+		{name: "within last days", filter: &models.DateFilter{WithinDays: intPtr(30)}, releaseDate: date(2024, time.May, 16), expected: true},
+		{name: "older than last days", filter: &models.DateFilter{WithinDays: intPtr(30)}, releaseDate: date(2024, time.May, 15), expected: false},
+		{name: "within last years", filter: &models.DateFilter{WithinYears: intPtr(2)}, releaseDate: date(2022, time.June, 15), expected: true},
+		{name: "older than last years", filter: &models.DateFilter{WithinYears: intPtr(2)}, releaseDate: date(2022, time.June, 14), expected: false},
+		{name: "combined bounds", filter: &models.DateFilter{Decade: intPtr(2020), WithinYears: intPtr(1)}, releaseDate: date(2021, time.January, 1), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &ReleaseDateFilter{tt.filter, now}
+			track := models.TrackInfo{ReleaseDate: tt.releaseDate}
+			assert.Equal(t, tt.expected, filter.Matches(track))
+		})
+	}
+}
+
+func TestAddedDateFilter(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   *models.DateFilter
+		addedAt  time.Time
+		expected bool
+	}{
+		{name: "nil filter", filter: nil, addedAt: time.Date(2010, time.May, 1, 0, 0, 0, 0, time.UTC), expected: true},
+		{name: "unknown added date", filter: &models.DateFilter{WithinDays: intPtr(7)}, expected: false},
+		{name: "added today", filter: &models.DateFilter{WithinDays: intPtr(7)}, addedAt: time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC), expected: true},
+		{name: "added within last days", filter: &models.DateFilter{WithinDays: intPtr(7)}, addedAt: time.Date(2024, time.June, 8, 23, 59, 0, 0, time.UTC), expected: true},
+		{name: "added before last days", filter: &models.DateFilter{WithinDays: intPtr(7)}, addedAt: time.Date(2024, time.June, 7, 23, 59, 0, 0, time.UTC), expected: false},
+		{name: "on absolute before bound", filter: &models.DateFilter{Before: stringPtr("2024-01-31")}, addedAt: time.Date(2024, time.January, 31, 22, 0, 0, 0, time.UTC), expected: true},
+		{name: "after absolute before bound", filter: &models.DateFilter{Before: stringPtr("2024-01-31")}, addedAt: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &AddedDateFilter{tt.filter, now}
+			track := models.TrackInfo{AddedAt: tt.addedAt}
+			assert.Equal(t, tt.expected, filter.Matches(track))
+		})
+	}
+}
+
 func TestArtistPopularityFilter(t *testing.T) {
 	filter := &ArtistPopularityFilter{&models.RangeFilter{Min: float64Ptr(70), Max: nil}}
 
@@ -136,6 +229,132 @@ func TestArtistKeywordsFilter(t *testing.T) {
 	assert.False(t, filter.Matches(track2))
 }
 
+func TestNameFilter(t *testing.T) {
+	trackName := func(t models.TrackInfo) []string { return []string{t.Name} }
+	artistNames := func(t models.TrackInfo) []string { return t.ArtistNames }
+
+	contains := func(value string) models.TextPattern {
+		return models.TextPattern{Type: models.TextMatchContains, Value: value}
+	}
+	prefix := func(value string) models.TextPattern {
+		return models.TextPattern{Type: models.TextMatchPrefix, Value: value}
+	}
+	regex := func(value string) models.TextPattern {
+		return models.TextPattern{Type: models.TextMatchRegex, Value: value}
+	}
+
+	tests := []struct {
+		name     string
+		filter   *models.TextFilter
+		names    func(t models.TrackInfo) []string
+		track    models.TrackInfo
+		expected bool
+	}{
+		{"nil filter", nil, trackName, models.TrackInfo{Name: "Song"}, true},
+		{"exclude remixes", &models.TextFilter{Exclude: []models.TextPattern{contains("remix")}}, trackName, models.TrackInfo{Name: "Song - Club REMIX"}, false},
+		{"exclude remixes keeps originals", &models.TextFilter{Exclude: []models.TextPattern{contains("remix")}}, trackName, models.TrackInfo{Name: "Song"}, true},
+		{"prefix match", &models.TextFilter{Include: []models.TextPattern{prefix("the ")}}, trackName, models.TrackInfo{Name: "The Song"}, true},
+		{"prefix only at start", &models.TextFilter{Include: []models.TextPattern{prefix("song")}}, trackName, models.TrackInfo{Name: "The Song"}, false},
+		{"only live versions", &models.TextFilter{Include: []models.TextPattern{regex(`\blive\b|\(live`)}}, trackName, models.TrackInfo{Name: "Song (Live at Wembley)"}, true},
+		{"regex is case insensitive word match", &models.TextFilter{Include: []models.TextPattern{regex(`\blive\b`)}}, trackName, models.TrackInfo{Name: "Alive"}, false},
+		{"any include pattern", &models.TextFilter{Include: []models.TextPattern{contains("acoustic"), contains("unplugged")}}, trackName, models.TrackInfo{Name: "Song (Unplugged)"}, true},
+		{"exclude wins over include", &models.TextFilter{Include: []models.TextPattern{contains("song")}, Exclude: []models.TextPattern{contains("edit")}}, trackName, models.TrackInfo{Name: "Song (Radio Edit)"}, false},
+		{"invalid regex never matches", &models.TextFilter{Include: []models.TextPattern{regex("(")}}, trackName, models.TrackInfo{Name: "("}, false},
+		{"any artist included", &models.TextFilter{Include: []models.TextPattern{prefix("john")}}, artistNames, models.TrackInfo{ArtistNames: []string{"The Beatles", "John Lennon"}}, true},
+		{"any artist excluded", &models.TextFilter{Exclude: []models.TextPattern{contains("lennon")}}, artistNames, models.TrackInfo{ArtistNames: []string{"The Beatles", "John Lennon"}}, false},
+		{"no names with include", &models.TextFilter{Include: []models.TextPattern{contains("a")}}, artistNames, models.TrackInfo{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &NameFilter{tt.filter, tt.names}
+			assert.Equal(t, tt.expected, filter.Matches(tt.track))
+		})
+	}
+}
+
+func TestCompileCachedRegex(t *testing.T) {
+	first := compileCachedRegex(`^remix`)
+	assert.NotNil(t, first)
+	assert.Same(t, first, compileCachedRegex(`^remix`))
+	assert.True(t, first.MatchString("REMIX"))
+
+	assert.Nil(t, compileCachedRegex(`[`))
+}
+
+func TestAudioFeatureFilter(t *testing.T) {
+	energy := func(a *models.AudioFeatures) float64 { return a.Energy }
+
+	tests := []struct {
+		name     string
+		filter   *models.RangeFilter
+		features *models.AudioFeatures
+		expected bool
+	}{
+		{"nil filter", nil, &models.AudioFeatures{Energy: 0.5}, true},
+		{"nil filter without audio features", nil, nil, true},
+		{"within range", &models.RangeFilter{Min: float64Ptr(0.4), Max: float64Ptr(0.8)}, &models.AudioFeatures{Energy: 0.5}, true},
+		{"below min", &models.RangeFilter{Min: float64Ptr(0.6)}, &models.AudioFeatures{Energy: 0.5}, false},
+		{"above max", &models.RangeFilter{Max: float64Ptr(0.4)}, &models.AudioFeatures{Energy: 0.5}, false},
+		{"without audio features", &models.RangeFilter{Min: float64Ptr(0.4)}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &AudioFeatureFilter{tt.filter, energy}
+			track := models.TrackInfo{AudioFeatures: tt.features}
+			assert.Equal(t, tt.expected, filter.Matches(track))
+		})
+	}
+}
+
+func TestPlayCountFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    *models.RangeFilter
+		playCount *int
+		expected  bool
+	}{
+		{"nil filter", nil, intPtr(3), true},
+		{"nil filter without play count", nil, nil, true},
+		{"within range", &models.RangeFilter{Min: float64Ptr(1), Max: float64Ptr(5)}, intPtr(3), true},
+		{"never played", &models.RangeFilter{Max: float64Ptr(0)}, intPtr(0), true},
+		{"played", &models.RangeFilter{Max: float64Ptr(0)}, intPtr(3), false},
+		{"without play count", &models.RangeFilter{Max: float64Ptr(0)}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &PlayCountFilter{tt.filter}
+			track := models.TrackInfo{PlayCount: tt.playCount}
+			assert.Equal(t, tt.expected, filter.Matches(track))
+		})
+	}
+}
+
+func TestLastFmTagsFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *models.SetFilter
+		track    models.TrackInfo
+		expected bool
+	}{
+		{"nil filter", nil, models.TrackInfo{}, true},
+		{"include match", &models.SetFilter{Include: []string{"Shoegaze"}}, models.TrackInfo{PlayCount: intPtr(1), LastFmTags: []string{"shoegaze", "rock"}}, true},
+		{"include no match", &models.SetFilter{Include: []string{"jazz"}}, models.TrackInfo{PlayCount: intPtr(1), LastFmTags: []string{"shoegaze"}}, false},
+		{"exclude match", &models.SetFilter{Exclude: []string{"rock"}}, models.TrackInfo{PlayCount: intPtr(1), LastFmTags: []string{"shoegaze", "rock"}}, false},
+		{"exclude without tags", &models.SetFilter{Exclude: []string{"rock"}}, models.TrackInfo{PlayCount: intPtr(0)}, true},
+		{"without lastfm data", &models.SetFilter{Exclude: []string{"rock"}}, models.TrackInfo{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &LastFmTagsFilter{tt.filter}
+			assert.Equal(t, tt.expected, filter.Matches(tt.track))
+		})
+	}
+}
+
 // Helper functions
 func float64Ptr(f float64) *float64 {
 	return &f
@@ -144,3 +363,11 @@ func float64Ptr(f float64) *float64 {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
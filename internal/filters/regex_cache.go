@@ -0,0 +1,40 @@
+package filters
+
+import (
+	"regexp"
+	"sync"
+)
+
+// MAX_CACHED_REGEXES bounds the compiled patterns kept between syncs
+const MAX_CACHED_REGEXES = 1000
+
+var regexCache = struct {
+	sync.RWMutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+// compileCachedRegex compiles the pattern case insensitively, reusing the compilation across
+// filter engines. Invalid patterns return nil, rules are validated before being stored.
+func compileCachedRegex(pattern string) *regexp.Regexp {
+	regexCache.RLock()
+	compiled, ok := regexCache.patterns[pattern]
+	regexCache.RUnlock()
+	if ok {
+		return compiled
+	}
+
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		compiled = nil
+	}
+
+	regexCache.Lock()
+	defer regexCache.Unlock()
+	// Patterns come from user rules, so the cache is dropped instead of growing unbounded
+	if len(regexCache.patterns) >= MAX_CACHED_REGEXES {
+		regexCache.patterns = make(map[string]*regexp.Regexp)
+	}
+	regexCache.patterns[pattern] = compiled
+
+	return compiled
+}
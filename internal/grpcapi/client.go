@@ -0,0 +1,132 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/ngomez18/playlist-router/internal/internalapi"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client calls the gRPC API on behalf of a user, authenticated with their JWT or one of their API
+// keys. Messages use the JSON codec of the internal API
+type Client struct {
+	conn  grpc.ClientConnInterface
+	token string
+}
+
+func NewClient(conn grpc.ClientConnInterface, token string) *Client {
+	return &Client{
+		conn:  conn,
+		token: token,
+	}
+}
+
+func (c *Client) ListBasePlaylists(ctx context.Context) ([]*models.BasePlaylistWithChilds, error) {
+	var resp ListBasePlaylistsResponse
+	if err := c.invoke(ctx, "ListBasePlaylists", &ListBasePlaylistsRequest{}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.BasePlaylists, nil
+}
+
+func (c *Client) GetBasePlaylist(ctx context.Context, id string) (*models.BasePlaylistWithChilds, error) {
+	var basePlaylist models.BasePlaylistWithChilds
+	if err := c.invoke(ctx, "GetBasePlaylist", &GetBasePlaylistRequest{ID: id}, &basePlaylist); err != nil {
+		return nil, err
+	}
+
+	return &basePlaylist, nil
+}
+
+func (c *Client) ListChildPlaylists(ctx context.Context, basePlaylistID string) ([]*models.ChildPlaylist, error) {
+	var resp ListChildPlaylistsResponse
+	req := &ListChildPlaylistsRequest{BasePlaylistID: basePlaylistID}
+	if err := c.invoke(ctx, "ListChildPlaylists", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.ChildPlaylists, nil
+}
+
+func (c *Client) GetFilterRules(ctx context.Context, childPlaylistID string) (*models.AudioFeatureFilters, error) {
+	var resp GetFilterRulesResponse
+	req := &GetFilterRulesRequest{ChildPlaylistID: childPlaylistID}
+	if err := c.invoke(ctx, "GetFilterRules", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.FilterRules, nil
+}
+
+func (c *Client) UpdateFilterRules(ctx context.Context, childPlaylistID string, filterRules *models.AudioFeatureFilters) (*models.ChildPlaylist, error) {
+	var childPlaylist models.ChildPlaylist
+	req := &UpdateFilterRulesRequest{ChildPlaylistID: childPlaylistID, FilterRules: filterRules}
+	if err := c.invoke(ctx, "UpdateFilterRules", req, &childPlaylist); err != nil {
+		return nil, err
+	}
+
+	return &childPlaylist, nil
+}
+
+func (c *Client) SyncBasePlaylist(ctx context.Context, basePlaylistID string) (*models.SyncEvent, error) {
+	var syncEvent models.SyncEvent
+	req := &SyncBasePlaylistRequest{BasePlaylistID: basePlaylistID}
+	if err := c.invoke(ctx, "SyncBasePlaylist", req, &syncEvent); err != nil {
+		return nil, err
+	}
+
+	return &syncEvent, nil
+}
+
+// StreamSyncBasePlaylist syncs the base playlist, calling onProgress with each sync event received.
+// The last one is the finished sync
+func (c *Client) StreamSyncBasePlaylist(ctx context.Context, basePlaylistID string, onProgress func(*models.SyncEvent)) error {
+	req := &SyncBasePlaylistRequest{BasePlaylistID: basePlaylistID}
+	return c.stream(ctx, "StreamSyncBasePlaylist", req, onProgress)
+}
+
+// WatchSyncs calls onProgress with the sync events of the syncs of the user, or only those of a base
+// playlist when basePlaylistID is set, until ctx is canceled
+func (c *Client) WatchSyncs(ctx context.Context, basePlaylistID string, onProgress func(*models.SyncEvent)) error {
+	req := &WatchSyncsRequest{BasePlaylistID: basePlaylistID}
+	return c.stream(ctx, "WatchSyncs", req, onProgress)
+}
+
+func (c *Client) invoke(ctx context.Context, method string, req, resp any) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	return c.conn.Invoke(ctx, fullMethodName(method), req, resp, grpc.CallContentSubtype(internalapi.CodecName))
+}
+
+// stream opens a server streaming call and hands every sync event received to onEvent, until the
+// server ends the call
+func (c *Client) stream(ctx context.Context, method string, req any, onEvent func(*models.SyncEvent)) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	desc := &grpc.StreamDesc{StreamName: method, ServerStreams: true}
+
+	stream, err := c.conn.NewStream(ctx, desc, fullMethodName(method), grpc.CallContentSubtype(internalapi.CodecName))
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		var syncEvent models.SyncEvent
+		if err := stream.RecvMsg(&syncEvent); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		onEvent(&syncEvent)
+	}
+}
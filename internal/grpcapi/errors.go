@@ -0,0 +1,59 @@
+package grpcapi
+
+import (
+	"errors"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusResponse is how a sentinel error of the service layer is reported to gRPC clients
+type statusResponse struct {
+	err     error
+	code    codes.Code
+	message string // Replaces the error message when it would leak details, empty to keep it
+}
+
+// statusResponses maps the sentinel errors to their status code, the gRPC counterpart of the
+// problems of the HTTP API. The first match wins
+var statusResponses = []statusResponse{
+	// Sync orchestration
+	{err: orchestrators.ErrSyncInProgress, code: codes.Aborted},
+	{err: orchestrators.ErrSyncAnomalyDetected, code: codes.FailedPrecondition},
+	{err: orchestrators.ErrBasePlaylistArchived, code: codes.FailedPrecondition},
+	{err: orchestrators.ErrMaintenanceMode, code: codes.Unavailable},
+
+	// Music provider
+	{err: musicprovider.ErrRateLimited, code: codes.ResourceExhausted},
+	{err: musicprovider.ErrProviderUnavailable, code: codes.Unavailable},
+	{err: services.ErrSpotifyIntegrationUnavailable, code: codes.FailedPrecondition, message: "spotify account not linked"},
+	{err: services.ErrSpotifyTokenRefresh, code: codes.FailedPrecondition},
+
+	// Missing records
+	{err: repositories.ErrChildPlaylistModified, code: codes.Aborted},
+	{err: repositories.ErrBasePlaylistNotFound, code: codes.NotFound},
+	{err: repositories.ErrChildPlaylistNotFound, code: codes.NotFound},
+	// Records of other users are reported as missing, without confirming they exist
+	{err: repositories.ErrUnauthorized, code: codes.NotFound, message: "resource not found"},
+}
+
+// statusError converts err into the status matching it. Unexpected errors are internal errors
+// described by message, so their details don't leak
+func statusError(err error, message string) error {
+	for _, response := range statusResponses {
+		if !errors.Is(err, response.err) {
+			continue
+		}
+
+		if response.message != "" {
+			return status.Error(response.code, response.message)
+		}
+		return status.Error(response.code, err.Error())
+	}
+
+	return status.Error(codes.Internal, message)
+}
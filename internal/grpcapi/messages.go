@@ -0,0 +1,44 @@
+package grpcapi
+
+import "github.com/ngomez18/playlist-router/internal/models"
+
+type ListBasePlaylistsRequest struct{}
+
+type ListBasePlaylistsResponse struct {
+	BasePlaylists []*models.BasePlaylistWithChilds `json:"base_playlists"`
+}
+
+type GetBasePlaylistRequest struct {
+	ID string `json:"id"`
+}
+
+type ListChildPlaylistsRequest struct {
+	BasePlaylistID string `json:"base_playlist_id"`
+}
+
+type ListChildPlaylistsResponse struct {
+	ChildPlaylists []*models.ChildPlaylist `json:"child_playlists"`
+}
+
+type GetFilterRulesRequest struct {
+	ChildPlaylistID string `json:"child_playlist_id"`
+}
+
+type GetFilterRulesResponse struct {
+	// FilterRules is empty for child playlists routing every track, such as fallbacks
+	FilterRules *models.AudioFeatureFilters `json:"filter_rules,omitempty"`
+}
+
+type UpdateFilterRulesRequest struct {
+	ChildPlaylistID string                      `json:"child_playlist_id"`
+	FilterRules     *models.AudioFeatureFilters `json:"filter_rules"`
+}
+
+type SyncBasePlaylistRequest struct {
+	BasePlaylistID string `json:"base_playlist_id"`
+}
+
+type WatchSyncsRequest struct {
+	// BasePlaylistID only watches the syncs of a base playlist, every sync of the user when empty
+	BasePlaylistID string `json:"base_playlist_id,omitempty"`
+}
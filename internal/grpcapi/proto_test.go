@@ -0,0 +1,203 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+// protoMessageTypes are the Go types the messages of playlist_router.proto are encoded from. No
+// code is generated from the proto file, so this is what keeps the two in sync
+var protoMessageTypes = map[string]reflect.Type{
+	"ListBasePlaylistsRequest":   reflect.TypeFor[ListBasePlaylistsRequest](),
+	"ListBasePlaylistsResponse":  reflect.TypeFor[ListBasePlaylistsResponse](),
+	"GetBasePlaylistRequest":     reflect.TypeFor[GetBasePlaylistRequest](),
+	"ListChildPlaylistsRequest":  reflect.TypeFor[ListChildPlaylistsRequest](),
+	"ListChildPlaylistsResponse": reflect.TypeFor[ListChildPlaylistsResponse](),
+	"GetFilterRulesRequest":      reflect.TypeFor[GetFilterRulesRequest](),
+	"GetFilterRulesResponse":     reflect.TypeFor[GetFilterRulesResponse](),
+	"UpdateFilterRulesRequest":   reflect.TypeFor[UpdateFilterRulesRequest](),
+	"SyncBasePlaylistRequest":    reflect.TypeFor[SyncBasePlaylistRequest](),
+	"WatchSyncsRequest":          reflect.TypeFor[WatchSyncsRequest](),
+	"BasePlaylistWithChilds":     reflect.TypeFor[models.BasePlaylistWithChilds](),
+	"ChildPlaylist":              reflect.TypeFor[models.ChildPlaylist](),
+	"FilterRules":                reflect.TypeFor[models.AudioFeatureFilters](),
+	"RangeFilter":                reflect.TypeFor[models.RangeFilter](),
+	"SetFilter":                  reflect.TypeFor[models.SetFilter](),
+	"TextFilter":                 reflect.TypeFor[models.TextFilter](),
+	"TextPattern":                reflect.TypeFor[models.TextPattern](),
+	"DateFilter":                 reflect.TypeFor[models.DateFilter](),
+	"DurationFilter":             reflect.TypeFor[models.DurationFilter](),
+	"SyncEvent":                  reflect.TypeFor[models.SyncEvent](),
+	"ChildSyncResult":            reflect.TypeFor[models.ChildSyncResult](),
+	"SyncAnomaly":                reflect.TypeFor[models.SyncAnomaly](),
+	"SyncRateLimitStats":         reflect.TypeFor[models.SyncRateLimitStats](),
+	"SyncProfileSpan":            reflect.TypeFor[models.SyncProfileSpan](),
+}
+
+var (
+	protoMessagePattern = regexp.MustCompile(`(?s)\nmessage (\w+) \{(.*?)\n?\}`)
+	protoFieldPattern   = regexp.MustCompile(`^(repeated |optional )?([\w.]+) (\w+) = \d+;`)
+	protoMethodPattern  = regexp.MustCompile(`rpc (\w+)\((\w+)\) returns \((stream )?(\w+)\)`)
+)
+
+type protoField struct {
+	repeated bool
+	typeName string
+}
+
+// parseProtoMessages returns the fields of each message of the proto file, by name
+func parseProtoMessages(t *testing.T, proto string) map[string]map[string]protoField {
+	t.Helper()
+
+	messages := map[string]map[string]protoField{}
+	for _, match := range protoMessagePattern.FindAllStringSubmatch(proto, -1) {
+		fields := map[string]protoField{}
+		for _, line := range strings.Split(match[2], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "//") {
+				continue
+			}
+
+			field := protoFieldPattern.FindStringSubmatch(line)
+			require.NotNil(t, field, "unexpected line in message %s: %q", match[1], line)
+			fields[field[3]] = protoField{repeated: field[1] == "repeated ", typeName: field[2]}
+		}
+		messages[match[1]] = fields
+	}
+
+	return messages
+}
+
+// jsonFields returns the fields of a struct by their JSON name, including those of embedded structs
+func jsonFields(goType reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := range goType.NumField() {
+		field := goType.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			for embeddedName, embeddedType := range jsonFields(embedded) {
+				fields[embeddedName] = embeddedType
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// matchesProtoType reports whether values of goType are encoded as the proto type
+func matchesProtoType(goType reflect.Type, typeName string) bool {
+	if goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	if messageType, ok := protoMessageTypes[typeName]; ok {
+		return goType == messageType
+	}
+
+	if typeName == "google.protobuf.Timestamp" {
+		return goType == reflect.TypeFor[time.Time]()
+	}
+	if goType.Implements(reflect.TypeFor[json.Marshaler]()) {
+		return typeName == "string"
+	}
+
+	switch typeName {
+	case "string":
+		return goType.Kind() == reflect.String
+	case "bool":
+		return goType.Kind() == reflect.Bool
+	case "int32", "int64":
+		return goType.Kind() >= reflect.Int && goType.Kind() <= reflect.Int64
+	case "double":
+		return goType.Kind() == reflect.Float64
+	}
+
+	return false
+}
+
+func TestProto_MatchesGoTypes(t *testing.T) {
+	assert := require.New(t)
+
+	content, err := os.ReadFile("playlist_router.proto")
+	assert.NoError(err)
+	messages := parseProtoMessages(t, string(content))
+
+	for name, goType := range protoMessageTypes {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			protoFields, ok := messages[name]
+			assert.True(ok, "message %s is missing from the proto file", name)
+
+			goFields := jsonFields(goType)
+			for fieldName, goFieldType := range goFields {
+				protoField, ok := protoFields[fieldName]
+				assert.True(ok, "field %s of %s is missing from the proto file", fieldName, goType)
+
+				repeated := goFieldType.Kind() == reflect.Slice
+				if repeated {
+					goFieldType = goFieldType.Elem()
+				}
+				assert.Equal(repeated, protoField.repeated, "field %s of %s", fieldName, name)
+				assert.True(matchesProtoType(goFieldType, protoField.typeName), "field %s of %s is a %s, not a %s", fieldName, name, goFieldType, protoField.typeName)
+			}
+
+			for fieldName := range protoFields {
+				_, ok := goFields[fieldName]
+				assert.True(ok, "field %s of message %s is missing from %s", fieldName, name, goType)
+			}
+		})
+	}
+
+	for name := range messages {
+		_, ok := protoMessageTypes[name]
+		assert.True(ok, "message %s has no Go type", name)
+	}
+}
+
+func TestProto_MatchesServiceDesc(t *testing.T) {
+	assert := require.New(t)
+
+	content, err := os.ReadFile("playlist_router.proto")
+	assert.NoError(err)
+
+	served := map[string]bool{}
+	for _, method := range ServiceDesc.Methods {
+		served[method.MethodName] = false
+	}
+	for _, stream := range ServiceDesc.Streams {
+		served[stream.StreamName] = true
+	}
+
+	methods := protoMethodPattern.FindAllStringSubmatch(string(content), -1)
+	assert.Len(methods, len(served))
+	for _, method := range methods {
+		streaming, ok := served[method[1]]
+		assert.True(ok, "rpc %s is not served", method[1])
+		assert.Equal(streaming, method[3] != "", "rpc %s", method[1])
+		_, ok = methodScopes[fullMethodName(method[1])]
+		assert.True(ok, "rpc %s has no api key scope", method[1])
+	}
+}
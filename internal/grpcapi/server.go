@@ -0,0 +1,466 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/realtime"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SpotifyAuthProvider builds a context carrying the spotify credentials of a user, the same way
+// the HTTP spotify auth middleware does for user-facing requests.
+type SpotifyAuthProvider interface {
+	ContextWithSpotifyAuth(ctx context.Context, userID string) (context.Context, error)
+}
+
+// MaintenanceStatus reports whether maintenance mode is on, which refuses the changes of non admins
+type MaintenanceStatus interface {
+	Status(ctx context.Context) *models.MaintenanceMode
+}
+
+// RateLimiter spends a request of the user. It is shared with the HTTP API, so calls over both
+// count against the same limit
+type RateLimiter interface {
+	AllowUser(userID string) (bool, time.Duration)
+}
+
+// methodScopes is the scope an API key needs to call each method. Calls authenticated with a JWT
+// can call all of them
+var methodScopes = map[string]models.APIKeyScope{
+	fullMethodName("ListBasePlaylists"):      models.APIKeyScopeSyncRead,
+	fullMethodName("GetBasePlaylist"):        models.APIKeyScopeSyncRead,
+	fullMethodName("ListChildPlaylists"):     models.APIKeyScopeSyncRead,
+	fullMethodName("GetFilterRules"):         models.APIKeyScopeSyncRead,
+	fullMethodName("UpdateFilterRules"):      models.APIKeyScopeRulesWrite,
+	fullMethodName("SyncBasePlaylist"):       models.APIKeyScopeSyncWrite,
+	fullMethodName("StreamSyncBasePlaylist"): models.APIKeyScopeSyncWrite,
+	fullMethodName("WatchSyncs"):             models.APIKeyScopeSyncRead,
+}
+
+// writeMethods are the methods refused while maintenance mode is on, the counterpart of the HTTP
+// requests that aren't reads
+var writeMethods = map[string]bool{
+	fullMethodName("UpdateFilterRules"):      true,
+	fullMethodName("SyncBasePlaylist"):       true,
+	fullMethodName("StreamSyncBasePlaylist"): true,
+}
+
+// Server serves the public gRPC API on top of the same services as the HTTP API, to the users
+// authenticated with their JWT or one of their API keys
+type Server struct {
+	basePlaylistService  services.BasePlaylistServicer
+	childPlaylistService services.ChildPlaylistServicer
+	syncOrchestrator     orchestrators.SyncOrchestrator
+	hub                  *realtime.Hub
+	spotifyAuth          SpotifyAuthProvider
+	userService          services.UserServicer
+	apiKeyService        services.APIKeyServicer
+	maintenance          MaintenanceStatus
+	rateLimiter          RateLimiter
+	logger               *slog.Logger
+}
+
+func NewServer(
+	basePlaylistService services.BasePlaylistServicer,
+	childPlaylistService services.ChildPlaylistServicer,
+	syncOrchestrator orchestrators.SyncOrchestrator,
+	hub *realtime.Hub,
+	spotifyAuth SpotifyAuthProvider,
+	userService services.UserServicer,
+	apiKeyService services.APIKeyServicer,
+	maintenance MaintenanceStatus,
+	rateLimiter RateLimiter,
+	logger *slog.Logger,
+) *Server {
+	return &Server{
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		syncOrchestrator:     syncOrchestrator,
+		hub:                  hub,
+		spotifyAuth:          spotifyAuth,
+		userService:          userService,
+		apiKeyService:        apiKeyService,
+		maintenance:          maintenance,
+		rateLimiter:          rateLimiter,
+		logger:               logger.With("component", "GRPCAPIServer"),
+	}
+}
+
+// NewGRPCServer creates a gRPC server with the API registered. Every call goes through user auth,
+// then the rate limit and maintenance mode, in the same order as the HTTP API
+func (s *Server) NewGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(s.unaryAuthInterceptor, s.unaryLimitInterceptor),
+		grpc.ChainStreamInterceptor(s.streamAuthInterceptor, s.streamLimitInterceptor),
+	)
+	grpcServer := grpc.NewServer(opts...)
+	grpcServer.RegisterService(&ServiceDesc, s)
+
+	return grpcServer
+}
+
+func (s *Server) ListBasePlaylists(ctx context.Context, req *ListBasePlaylistsRequest) (*ListBasePlaylistsResponse, error) {
+	user := userFromContext(ctx)
+
+	basePlaylists, err := s.basePlaylistService.GetBasePlaylistsByUserIDWithChilds(ctx, user.ID)
+	if err != nil {
+		return nil, statusError(err, "unable to retrieve base playlists")
+	}
+
+	return &ListBasePlaylistsResponse{BasePlaylists: basePlaylists}, nil
+}
+
+func (s *Server) GetBasePlaylist(ctx context.Context, req *GetBasePlaylistRequest) (*models.BasePlaylistWithChilds, error) {
+	if req.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	user := userFromContext(ctx)
+
+	basePlaylist, err := s.basePlaylistService.GetBasePlaylist(ctx, req.ID, user.ID)
+	if err != nil {
+		return nil, statusError(err, "unable to retrieve base playlist")
+	}
+
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, req.ID, user.ID)
+	if err != nil {
+		return nil, statusError(err, "unable to retrieve child playlists")
+	}
+
+	return &models.BasePlaylistWithChilds{BasePlaylist: basePlaylist, Childs: childPlaylists}, nil
+}
+
+func (s *Server) ListChildPlaylists(ctx context.Context, req *ListChildPlaylistsRequest) (*ListChildPlaylistsResponse, error) {
+	if req.BasePlaylistID == "" {
+		return nil, status.Error(codes.InvalidArgument, "base_playlist_id is required")
+	}
+
+	user := userFromContext(ctx)
+
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, req.BasePlaylistID, user.ID)
+	if err != nil {
+		return nil, statusError(err, "unable to retrieve child playlists")
+	}
+
+	return &ListChildPlaylistsResponse{ChildPlaylists: childPlaylists}, nil
+}
+
+func (s *Server) GetFilterRules(ctx context.Context, req *GetFilterRulesRequest) (*GetFilterRulesResponse, error) {
+	if req.ChildPlaylistID == "" {
+		return nil, status.Error(codes.InvalidArgument, "child_playlist_id is required")
+	}
+
+	user := userFromContext(ctx)
+
+	childPlaylist, err := s.childPlaylistService.GetChildPlaylist(ctx, req.ChildPlaylistID, user.ID)
+	if err != nil {
+		return nil, statusError(err, "unable to retrieve child playlist")
+	}
+
+	return &GetFilterRulesResponse{FilterRules: childPlaylist.FilterRules}, nil
+}
+
+// UpdateFilterRules replaces the filter rules of a child playlist, the rest of it is left as is
+func (s *Server) UpdateFilterRules(ctx context.Context, req *UpdateFilterRulesRequest) (*models.ChildPlaylist, error) {
+	if req.ChildPlaylistID == "" || req.FilterRules == nil {
+		return nil, status.Error(codes.InvalidArgument, "child_playlist_id and filter_rules are required")
+	}
+
+	if err := req.FilterRules.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid filter_rules: "+err.Error())
+	}
+
+	user := userFromContext(ctx)
+
+	ctx, err := s.spotifyAuth.ContextWithSpotifyAuth(ctx, user.ID)
+	if err != nil {
+		return nil, statusError(err, "unable to authenticate with spotify")
+	}
+
+	input := &models.UpdateChildPlaylistRequest{FilterRules: req.FilterRules}
+	childPlaylist, err := s.childPlaylistService.UpdateChildPlaylist(ctx, req.ChildPlaylistID, user.ID, input)
+	if err != nil {
+		return nil, statusError(err, "unable to update filter rules")
+	}
+
+	return childPlaylist, nil
+}
+
+func (s *Server) SyncBasePlaylist(ctx context.Context, req *SyncBasePlaylistRequest) (*models.SyncEvent, error) {
+	if req.BasePlaylistID == "" {
+		return nil, status.Error(codes.InvalidArgument, "base_playlist_id is required")
+	}
+
+	user := userFromContext(ctx)
+
+	ctx, err := s.spotifyAuth.ContextWithSpotifyAuth(ctx, user.ID)
+	if err != nil {
+		return nil, statusError(err, "unable to authenticate with spotify")
+	}
+
+	syncEvent, err := s.syncOrchestrator.SyncBasePlaylist(ctx, user.ID, req.BasePlaylistID)
+	if err != nil {
+		return nil, statusError(err, "failed to sync base playlist")
+	}
+
+	return syncEvent, nil
+}
+
+// syncResult is the outcome of a sync run in the background of a stream
+type syncResult struct {
+	syncEvent *models.SyncEvent
+	err       error
+}
+
+// StreamSyncBasePlaylist syncs the base playlist, sending its sync event every time it moves to
+// another phase and the finished sync event last. A sync held back by anomalies sends the held back
+// sync event before failing with FailedPrecondition
+func (s *Server) StreamSyncBasePlaylist(req *SyncBasePlaylistRequest, stream SyncEventStream) error {
+	if req.BasePlaylistID == "" {
+		return status.Error(codes.InvalidArgument, "base_playlist_id is required")
+	}
+
+	user := userFromContext(stream.Context())
+
+	ctx, err := s.spotifyAuth.ContextWithSpotifyAuth(stream.Context(), user.ID)
+	if err != nil {
+		return statusError(err, "unable to authenticate with spotify")
+	}
+
+	// Subscribed before the sync starts so none of its progress is missed
+	subscription := s.hub.Subscribe(user.ID)
+	defer subscription.Close()
+
+	results := make(chan syncResult, 1)
+	go func() {
+		syncEvent, err := s.syncOrchestrator.SyncBasePlaylist(ctx, user.ID, req.BasePlaylistID)
+		results <- syncResult{syncEvent: syncEvent, err: err}
+	}()
+
+	// sendProgress forwards the progress of this sync, the finished sync event is sent from its result
+	sendProgress := func(payload []byte) error {
+		syncEvent, ok := s.decodeSyncProgress(payload)
+		if !ok || syncEvent.BasePlaylistID != req.BasePlaylistID || syncEvent.Status != models.SyncStatusInProgress {
+			return nil
+		}
+		return stream.Send(syncEvent)
+	}
+
+	// A subscription dropped for falling behind stops the progress, the result is still sent
+	events, dropped := subscription.Events(), subscription.Done()
+	for {
+		select {
+		case payload := <-events:
+			if err := sendProgress(payload); err != nil {
+				return err
+			}
+		case <-dropped:
+			events, dropped = nil, nil
+		case result := <-results:
+			// Progress published right before the sync returned still goes ahead of its result
+			for pending := len(events); pending > 0; pending-- {
+				if err := sendProgress(<-events); err != nil {
+					return err
+				}
+			}
+
+			if result.err != nil {
+				if errors.Is(result.err, orchestrators.ErrSyncAnomalyDetected) && result.syncEvent != nil {
+					// The held back sync event lists the anomalies the user has to confirm
+					if err := stream.Send(result.syncEvent); err != nil {
+						return err
+					}
+				}
+				return statusError(result.err, "failed to sync base playlist")
+			}
+
+			return stream.Send(result.syncEvent)
+		}
+	}
+}
+
+// WatchSyncs sends the sync events of the syncs of the user as they progress, whoever started
+// them, until the call is canceled. A watcher falling behind is dropped with Unavailable
+func (s *Server) WatchSyncs(req *WatchSyncsRequest, stream SyncEventStream) error {
+	user := userFromContext(stream.Context())
+
+	subscription := s.hub.Subscribe(user.ID)
+	defer subscription.Close()
+
+	for {
+		select {
+		case payload := <-subscription.Events():
+			syncEvent, ok := s.decodeSyncProgress(payload)
+			if !ok || (req.BasePlaylistID != "" && syncEvent.BasePlaylistID != req.BasePlaylistID) {
+				continue
+			}
+			if err := stream.Send(syncEvent); err != nil {
+				return err
+			}
+		case <-subscription.Done():
+			return status.Error(codes.Unavailable, "watch fell behind, reconnect to keep watching")
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// decodeSyncProgress returns the sync event of an encoded app event, if it reports sync progress
+func (s *Server) decodeSyncProgress(payload []byte) (*models.SyncEvent, bool) {
+	var event models.AppEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		s.logger.Error("failed to decode app event", "error", err.Error())
+		return nil, false
+	}
+
+	if event.Type != models.AppEventSyncProgress || event.SyncEvent == nil {
+		return nil, false
+	}
+
+	return event.SyncEvent, true
+}
+
+func (s *Server) unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuthInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate adds the user of the authorization metadata to the context, which carries either a
+// JWT or an API key granted the scope of the method, the same credentials the HTTP API accepts
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+
+	if strings.HasPrefix(token, services.API_KEY_PREFIX) {
+		return s.authenticateAPIKey(ctx, method, token)
+	}
+
+	user, err := s.userService.ValidateAuthToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	s.logger.InfoContext(ctx, "handling grpc api call", "method", method, "user_id", user.ID)
+	return requestcontext.ContextWithUser(ctx, user), nil
+}
+
+func (s *Server) authenticateAPIKey(ctx context.Context, method, rawKey string) (context.Context, error) {
+	apiKey, err := s.apiKeyService.Authenticate(ctx, rawKey)
+	if errors.Is(err, services.ErrInvalidAPIKey) {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired api key")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "unable to validate api key")
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok || !apiKey.HasScope(scope) {
+		s.logger.WarnContext(ctx, "rejected grpc api call with insufficient scope", "method", method, "api_key_id", apiKey.ID)
+		return nil, status.Error(codes.PermissionDenied, "api key is missing the "+string(scope)+" scope")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired api key")
+	}
+	if user.Disabled {
+		return nil, status.Error(codes.PermissionDenied, "account is disabled")
+	}
+
+	s.logger.InfoContext(ctx, "handling grpc api call", "method", method, "user_id", user.ID, "api_key_id", apiKey.ID)
+	ctx = requestcontext.ContextWithAPIKey(ctx, apiKey)
+	return requestcontext.ContextWithUser(ctx, user), nil
+}
+
+func (s *Server) unaryLimitInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.admit(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *Server) streamLimitInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.admit(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+// admit spends a request of the user's rate limit and refuses write methods while maintenance mode
+// is on. Admins are let through maintenance mode, so they can check the app and turn it off
+func (s *Server) admit(ctx context.Context, method string) error {
+	user := userFromContext(ctx)
+
+	if allowed, retryAfter := s.rateLimiter.AllowUser(user.ID); !allowed {
+		// The counterpart of the Retry-After header of the HTTP API
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	if !writeMethods[method] || user.HasRole(models.RoleAdmin) {
+		return nil
+	}
+
+	if maintenance := s.maintenance.Status(ctx); maintenance.Enabled {
+		return status.Error(codes.Unavailable, maintenance.Message)
+	}
+
+	return nil
+}
+
+// authenticatedStream is a server stream whose context carries the authenticated user
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// userFromContext returns the user added by the auth interceptors, which run before every method
+func userFromContext(ctx context.Context) *models.User {
+	user, _ := requestcontext.GetUserFromContext(ctx)
+	return user
+}
@@ -0,0 +1,517 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/internalapi"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	orchestratormocks "github.com/ngomez18/playlist-router/internal/orchestrators/mocks"
+	"github.com/ngomez18/playlist-router/internal/realtime"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/services"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testJWT    = "user-jwt"
+	testAPIKey = "prk_secret"
+)
+
+type fakeSpotifyAuth struct {
+	err error
+}
+
+func (f *fakeSpotifyAuth) ContextWithSpotifyAuth(ctx context.Context, userID string) (context.Context, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return requestcontext.ContextWithSpotifyAuth(ctx, &models.SpotifyIntegration{UserID: userID}), nil
+}
+
+type fakeMaintenance struct {
+	mode models.MaintenanceMode
+}
+
+func (f *fakeMaintenance) Status(ctx context.Context) *models.MaintenanceMode {
+	return &f.mode
+}
+
+// fakeRateLimiter allows a number of calls, every call when nil
+type fakeRateLimiter struct {
+	remaining *int
+	users     []string
+}
+
+func (f *fakeRateLimiter) AllowUser(userID string) (bool, time.Duration) {
+	f.users = append(f.users, userID)
+	if f.remaining == nil {
+		return true, 0
+	}
+	if *f.remaining == 0 {
+		return false, 1500 * time.Millisecond
+	}
+	*f.remaining--
+	return true, 0
+}
+
+type testDeps struct {
+	basePlaylistService  *servicemocks.MockBasePlaylistServicer
+	childPlaylistService *servicemocks.MockChildPlaylistServicer
+	syncOrchestrator     *orchestratormocks.MockSyncOrchestrator
+	userService          *servicemocks.MockUserServicer
+	apiKeyService        *servicemocks.MockAPIKeyServicer
+	hub                  *realtime.Hub
+	spotifyAuth          *fakeSpotifyAuth
+	maintenance          *fakeMaintenance
+	rateLimiter          *fakeRateLimiter
+}
+
+// expectJWT accepts testJWT as the token of user123 for a single call
+func (d testDeps) expectJWT() {
+	d.userService.EXPECT().ValidateAuthToken(gomock.Any(), testJWT).Return(&models.User{ID: "user123"}, nil)
+}
+
+func startTestServer(t *testing.T, ctrl *gomock.Controller) (testDeps, *grpc.ClientConn) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	deps := testDeps{
+		basePlaylistService:  servicemocks.NewMockBasePlaylistServicer(ctrl),
+		childPlaylistService: servicemocks.NewMockChildPlaylistServicer(ctrl),
+		syncOrchestrator:     orchestratormocks.NewMockSyncOrchestrator(ctrl),
+		userService:          servicemocks.NewMockUserServicer(ctrl),
+		apiKeyService:        servicemocks.NewMockAPIKeyServicer(ctrl),
+		hub:                  realtime.NewHub(logger),
+		spotifyAuth:          &fakeSpotifyAuth{},
+		maintenance:          &fakeMaintenance{},
+		rateLimiter:          &fakeRateLimiter{},
+	}
+
+	server := NewServer(
+		deps.basePlaylistService,
+		deps.childPlaylistService,
+		deps.syncOrchestrator,
+		deps.hub,
+		deps.spotifyAuth,
+		deps.userService,
+		deps.apiKeyService,
+		deps.maintenance,
+		deps.rateLimiter,
+		logger,
+	)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := server.NewGRPCServer()
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return deps, conn
+}
+
+func TestServer_Auth(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        string
+		setupMocks   func(deps testDeps)
+		expectedCode codes.Code
+	}{
+		{
+			name:         "missing token",
+			setupMocks:   func(deps testDeps) {},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:  "invalid jwt",
+			token: "expired-jwt",
+			setupMocks: func(deps testDeps) {
+				deps.userService.EXPECT().ValidateAuthToken(gomock.Any(), "expired-jwt").Return(nil, errors.New("token expired"))
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:  "jwt",
+			token: testJWT,
+			setupMocks: func(deps testDeps) {
+				deps.expectJWT()
+				deps.basePlaylistService.EXPECT().GetBasePlaylistsByUserIDWithChilds(gomock.Any(), "user123").Return(nil, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:  "api key with scope",
+			token: testAPIKey,
+			setupMocks: func(deps testDeps) {
+				deps.apiKeyService.EXPECT().Authenticate(gomock.Any(), testAPIKey).
+					Return(&models.APIKey{ID: "key1", UserID: "user123", Scopes: []models.APIKeyScope{models.APIKeyScopeSyncRead}}, nil)
+				deps.userService.EXPECT().GetUserByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+				deps.basePlaylistService.EXPECT().GetBasePlaylistsByUserIDWithChilds(gomock.Any(), "user123").Return(nil, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:  "api key missing scope",
+			token: testAPIKey,
+			setupMocks: func(deps testDeps) {
+				deps.apiKeyService.EXPECT().Authenticate(gomock.Any(), testAPIKey).
+					Return(&models.APIKey{ID: "key1", UserID: "user123", Scopes: []models.APIKeyScope{models.APIKeyScopeRulesWrite}}, nil)
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:  "invalid api key",
+			token: testAPIKey,
+			setupMocks: func(deps testDeps) {
+				deps.apiKeyService.EXPECT().Authenticate(gomock.Any(), testAPIKey).Return(nil, services.ErrInvalidAPIKey)
+			},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:  "disabled account",
+			token: testAPIKey,
+			setupMocks: func(deps testDeps) {
+				deps.apiKeyService.EXPECT().Authenticate(gomock.Any(), testAPIKey).
+					Return(&models.APIKey{ID: "key1", UserID: "user123", Scopes: []models.APIKeyScope{models.APIKeyScopeSyncRead}}, nil)
+				deps.userService.EXPECT().GetUserByID(gomock.Any(), "user123").Return(&models.User{ID: "user123", Disabled: true}, nil)
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			deps, conn := startTestServer(t, ctrl)
+			tt.setupMocks(deps)
+
+			client := NewClient(conn, tt.token)
+			_, err := client.ListBasePlaylists(context.Background())
+
+			assert.Equal(tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	remaining := 1
+	deps.rateLimiter.remaining = &remaining
+	deps.userService.EXPECT().ValidateAuthToken(gomock.Any(), testJWT).Return(&models.User{ID: "user123"}, nil).Times(3)
+	deps.basePlaylistService.EXPECT().GetBasePlaylistsByUserIDWithChilds(gomock.Any(), "user123").Return(nil, nil)
+
+	client := NewClient(conn, testJWT)
+	_, err := client.ListBasePlaylists(context.Background())
+	assert.NoError(err)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testJWT)
+	err = conn.Invoke(ctx, fullMethodName("ListBasePlaylists"), &ListBasePlaylistsRequest{}, &ListBasePlaylistsResponse{},
+		grpc.CallContentSubtype(internalapi.CodecName), grpc.Header(&header))
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+	assert.Equal([]string{"2"}, header.Get("retry-after"))
+
+	// Streams spend requests too
+	err = client.WatchSyncs(context.Background(), "", func(*models.SyncEvent) {})
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+	assert.Equal([]string{"user123", "user123", "user123"}, deps.rateLimiter.users)
+}
+
+func TestServer_MaintenanceMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         *models.User
+		call         func(client *Client) error
+		setupMocks   func(deps testDeps)
+		expectedCode codes.Code
+	}{
+		{
+			name: "write refused",
+			user: &models.User{ID: "user123"},
+			call: func(client *Client) error {
+				_, err := client.SyncBasePlaylist(context.Background(), "base456")
+				return err
+			},
+			setupMocks:   func(deps testDeps) {},
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name: "streamed write refused",
+			user: &models.User{ID: "user123"},
+			call: func(client *Client) error {
+				return client.StreamSyncBasePlaylist(context.Background(), "base456", func(*models.SyncEvent) {})
+			},
+			setupMocks:   func(deps testDeps) {},
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name: "read allowed",
+			user: &models.User{ID: "user123"},
+			call: func(client *Client) error {
+				_, err := client.ListBasePlaylists(context.Background())
+				return err
+			},
+			setupMocks: func(deps testDeps) {
+				deps.basePlaylistService.EXPECT().GetBasePlaylistsByUserIDWithChilds(gomock.Any(), "user123").Return(nil, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "admin write allowed",
+			user: &models.User{ID: "user123", Roles: []models.UserRole{models.RoleAdmin}},
+			call: func(client *Client) error {
+				_, err := client.SyncBasePlaylist(context.Background(), "base456")
+				return err
+			},
+			setupMocks: func(deps testDeps) {
+				deps.syncOrchestrator.EXPECT().SyncBasePlaylist(gomock.Any(), "user123", "base456").Return(&models.SyncEvent{ID: "sync123"}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			deps, conn := startTestServer(t, ctrl)
+			deps.maintenance.mode = models.MaintenanceMode{Enabled: true, Message: "upgrading the database"}
+			deps.userService.EXPECT().ValidateAuthToken(gomock.Any(), testJWT).Return(tt.user, nil)
+			tt.setupMocks(deps)
+
+			err := tt.call(NewClient(conn, testJWT))
+
+			assert.Equal(tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.Unavailable {
+				assert.Equal("upgrading the database", status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestServer_GetBasePlaylist(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	deps.expectJWT()
+	deps.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base1", "user123").
+		Return(nil, fmt.Errorf("failed to retrieve base playlist: %w", repositories.ErrUnauthorized))
+
+	client := NewClient(conn, testJWT)
+	basePlaylist, err := client.GetBasePlaylist(context.Background(), "base1")
+
+	// Base playlists of other users are reported as missing
+	assert.Equal(codes.NotFound, status.Code(err))
+	assert.Nil(basePlaylist)
+}
+
+func TestServer_UpdateFilterRules(t *testing.T) {
+	minEnergy := 0.7
+	tests := []struct {
+		name         string
+		filterRules  *models.AudioFeatureFilters
+		setupMocks   func(deps testDeps)
+		expectedCode codes.Code
+	}{
+		{
+			name:        "success",
+			filterRules: &models.AudioFeatureFilters{Energy: &models.RangeFilter{Min: &minEnergy}},
+			setupMocks: func(deps testDeps) {
+				deps.childPlaylistService.EXPECT().UpdateChildPlaylist(gomock.Any(), "child1", "user123", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id, userID string, input *models.UpdateChildPlaylistRequest) (*models.ChildPlaylist, error) {
+						_, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
+						require.True(t, ok)
+						// Only the filter rules change
+						require.Equal(t, &models.UpdateChildPlaylistRequest{FilterRules: input.FilterRules}, input)
+						return &models.ChildPlaylist{ID: id, FilterRules: input.FilterRules}, nil
+					})
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "missing filter rules",
+			setupMocks:   func(deps testDeps) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid filter rules",
+			filterRules: &models.AudioFeatureFilters{TrackName: &models.TextFilter{
+				Include: []models.TextPattern{{Type: models.TextMatchRegex, Value: "("}},
+			}},
+			setupMocks:   func(deps testDeps) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:        "child playlist not found",
+			filterRules: &models.AudioFeatureFilters{Energy: &models.RangeFilter{Min: &minEnergy}},
+			setupMocks: func(deps testDeps) {
+				deps.childPlaylistService.EXPECT().UpdateChildPlaylist(gomock.Any(), "child1", "user123", gomock.Any()).
+					Return(nil, repositories.ErrChildPlaylistNotFound)
+			},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			deps, conn := startTestServer(t, ctrl)
+			deps.expectJWT()
+			tt.setupMocks(deps)
+
+			client := NewClient(conn, testJWT)
+			childPlaylist, err := client.UpdateFilterRules(context.Background(), "child1", tt.filterRules)
+
+			assert.Equal(tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(minEnergy, *childPlaylist.FilterRules.Energy.Min)
+			}
+		})
+	}
+}
+
+func TestServer_StreamSyncBasePlaylist(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	deps.expectJWT()
+	deps.syncOrchestrator.EXPECT().
+		SyncBasePlaylist(gomock.Any(), "user123", "base456").
+		DoAndReturn(func(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
+			_, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
+			require.True(t, ok)
+
+			deps.hub.Publish(userID, models.NewSyncProgressEvent(&models.SyncEvent{
+				ID: "sync123", BasePlaylistID: basePlaylistID, Status: models.SyncStatusInProgress, Phase: models.SyncPhaseFetchingTracks,
+			}))
+			// Syncs of other base playlists are left out
+			deps.hub.Publish(userID, models.NewSyncProgressEvent(&models.SyncEvent{
+				ID: "sync999", BasePlaylistID: "base999", Status: models.SyncStatusInProgress, Phase: models.SyncPhaseFetchingTracks,
+			}))
+			deps.hub.Publish(userID, models.NewSyncProgressEvent(&models.SyncEvent{
+				ID: "sync123", BasePlaylistID: basePlaylistID, Status: models.SyncStatusInProgress, Phase: models.SyncPhaseRoutingTracks,
+			}))
+			completed := &models.SyncEvent{ID: "sync123", BasePlaylistID: basePlaylistID, Status: models.SyncStatusCompleted}
+			deps.hub.Publish(userID, models.NewSyncProgressEvent(completed))
+
+			return completed, nil
+		})
+
+	var received []*models.SyncEvent
+	client := NewClient(conn, testJWT)
+	err := client.StreamSyncBasePlaylist(context.Background(), "base456", func(syncEvent *models.SyncEvent) {
+		received = append(received, syncEvent)
+	})
+
+	assert.NoError(err)
+	assert.Len(received, 3)
+	assert.Equal(models.SyncPhaseFetchingTracks, received[0].Phase)
+	assert.Equal(models.SyncPhaseRoutingTracks, received[1].Phase)
+	assert.Equal(models.SyncStatusCompleted, received[2].Status)
+	assert.Zero(deps.hub.SubscriberCount("user123"))
+}
+
+func TestServer_StreamSyncBasePlaylist_AnomalyDetected(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	deps.expectJWT()
+	heldBack := &models.SyncEvent{
+		ID:        "sync123",
+		Status:    models.SyncStatusNeedsConfirmation,
+		Anomalies: []models.SyncAnomaly{{ChildPlaylistID: "child1", PreviousCount: 100, NewCount: 2}},
+	}
+	deps.syncOrchestrator.EXPECT().
+		SyncBasePlaylist(gomock.Any(), "user123", "base456").
+		Return(heldBack, orchestrators.ErrSyncAnomalyDetected)
+
+	var received []*models.SyncEvent
+	client := NewClient(conn, testJWT)
+	err := client.StreamSyncBasePlaylist(context.Background(), "base456", func(syncEvent *models.SyncEvent) {
+		received = append(received, syncEvent)
+	})
+
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+	assert.Len(received, 1)
+	assert.Equal(models.SyncStatusNeedsConfirmation, received[0].Status)
+	assert.Len(received[0].Anomalies, 1)
+}
+
+func TestServer_WatchSyncs(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	deps.expectJWT()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan *models.SyncEvent, 1)
+	done := make(chan error, 1)
+	client := NewClient(conn, testJWT)
+	go func() {
+		done <- client.WatchSyncs(ctx, "base456", func(syncEvent *models.SyncEvent) {
+			received <- syncEvent
+		})
+	}()
+
+	assert.Eventually(func() bool { return deps.hub.SubscriberCount("user123") == 1 }, time.Second, 10*time.Millisecond)
+	deps.hub.Publish("user123", models.NewBasePlaylistEvent(models.AppEventPlaylistUpdated, &models.BasePlaylist{ID: "base456"}))
+	deps.hub.Publish("user123", models.NewSyncProgressEvent(&models.SyncEvent{ID: "sync999", BasePlaylistID: "base999"}))
+	deps.hub.Publish("user123", models.NewSyncProgressEvent(&models.SyncEvent{ID: "sync123", BasePlaylistID: "base456"}))
+
+	syncEvent := <-received
+	assert.Equal("sync123", syncEvent.ID)
+
+	cancel()
+	assert.Equal(codes.Canceled, status.Code(<-done))
+	assert.Eventually(func() bool { return deps.hub.SubscriberCount("user123") == 0 }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,136 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "playlistrouter.v1.PlaylistRouter"
+
+// PlaylistRouterServer is the public gRPC API, the playlists, filter rules and syncs of the
+// authenticated user. Its messages are described in playlist_router.proto
+type PlaylistRouterServer interface {
+	ListBasePlaylists(ctx context.Context, req *ListBasePlaylistsRequest) (*ListBasePlaylistsResponse, error)
+	GetBasePlaylist(ctx context.Context, req *GetBasePlaylistRequest) (*models.BasePlaylistWithChilds, error)
+	ListChildPlaylists(ctx context.Context, req *ListChildPlaylistsRequest) (*ListChildPlaylistsResponse, error)
+	GetFilterRules(ctx context.Context, req *GetFilterRulesRequest) (*GetFilterRulesResponse, error)
+	UpdateFilterRules(ctx context.Context, req *UpdateFilterRulesRequest) (*models.ChildPlaylist, error)
+	SyncBasePlaylist(ctx context.Context, req *SyncBasePlaylistRequest) (*models.SyncEvent, error)
+	StreamSyncBasePlaylist(req *SyncBasePlaylistRequest, stream SyncEventStream) error
+	WatchSyncs(req *WatchSyncsRequest, stream SyncEventStream) error
+}
+
+// SyncEventStream sends the sync events of a server streaming call
+type SyncEventStream interface {
+	Context() context.Context
+	Send(syncEvent *models.SyncEvent) error
+}
+
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*PlaylistRouterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBasePlaylists",
+			Handler: unaryHandler("ListBasePlaylists", func(srv PlaylistRouterServer, ctx context.Context, req *ListBasePlaylistsRequest) (any, error) {
+				return srv.ListBasePlaylists(ctx, req)
+			}),
+		},
+		{
+			MethodName: "GetBasePlaylist",
+			Handler: unaryHandler("GetBasePlaylist", func(srv PlaylistRouterServer, ctx context.Context, req *GetBasePlaylistRequest) (any, error) {
+				return srv.GetBasePlaylist(ctx, req)
+			}),
+		},
+		{
+			MethodName: "ListChildPlaylists",
+			Handler: unaryHandler("ListChildPlaylists", func(srv PlaylistRouterServer, ctx context.Context, req *ListChildPlaylistsRequest) (any, error) {
+				return srv.ListChildPlaylists(ctx, req)
+			}),
+		},
+		{
+			MethodName: "GetFilterRules",
+			Handler: unaryHandler("GetFilterRules", func(srv PlaylistRouterServer, ctx context.Context, req *GetFilterRulesRequest) (any, error) {
+				return srv.GetFilterRules(ctx, req)
+			}),
+		},
+		{
+			MethodName: "UpdateFilterRules",
+			Handler: unaryHandler("UpdateFilterRules", func(srv PlaylistRouterServer, ctx context.Context, req *UpdateFilterRulesRequest) (any, error) {
+				return srv.UpdateFilterRules(ctx, req)
+			}),
+		},
+		{
+			MethodName: "SyncBasePlaylist",
+			Handler: unaryHandler("SyncBasePlaylist", func(srv PlaylistRouterServer, ctx context.Context, req *SyncBasePlaylistRequest) (any, error) {
+				return srv.SyncBasePlaylist(ctx, req)
+			}),
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSyncBasePlaylist",
+			ServerStreams: true,
+			Handler: streamHandler(func(srv PlaylistRouterServer, req *SyncBasePlaylistRequest, stream SyncEventStream) error {
+				return srv.StreamSyncBasePlaylist(req, stream)
+			}),
+		},
+		{
+			StreamName:    "WatchSyncs",
+			ServerStreams: true,
+			Handler: streamHandler(func(srv PlaylistRouterServer, req *WatchSyncsRequest, stream SyncEventStream) error {
+				return srv.WatchSyncs(req, stream)
+			}),
+		},
+	},
+	Metadata: "playlist_router.proto",
+}
+
+func fullMethodName(method string) string {
+	return "/" + serviceName + "/" + method
+}
+
+// unaryHandler adapts a typed method into the generic handler signature gRPC expects,
+// taking care of request decoding and interceptor chaining.
+func unaryHandler[Req any](method string, call func(srv PlaylistRouterServer, ctx context.Context, req *Req) (any, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+
+		if interceptor == nil {
+			return call(srv.(PlaylistRouterServer), ctx, req)
+		}
+
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethodName(method)}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return call(srv.(PlaylistRouterServer), ctx, req.(*Req))
+		}
+
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+// streamHandler adapts a typed server streaming method, reading its single request. Stream
+// interceptors already run around the handler
+func streamHandler[Req any](call func(srv PlaylistRouterServer, req *Req, stream SyncEventStream) error) grpc.StreamHandler {
+	return func(srv any, stream grpc.ServerStream) error {
+		req := new(Req)
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+
+		return call(srv.(PlaylistRouterServer), req, &syncEventStream{stream})
+	}
+}
+
+type syncEventStream struct {
+	grpc.ServerStream
+}
+
+func (s *syncEventStream) Send(syncEvent *models.SyncEvent) error {
+	return s.SendMsg(syncEvent)
+}
@@ -0,0 +1,67 @@
+package internalapi
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client calls the internal API on behalf of workers and other internal services
+type Client struct {
+	conn  grpc.ClientConnInterface
+	token string
+}
+
+func NewClient(conn grpc.ClientConnInterface, token string) *Client {
+	return &Client{
+		conn:  conn,
+		token: token,
+	}
+}
+
+func (c *Client) SyncBasePlaylist(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
+	var syncEvent models.SyncEvent
+	req := &SyncBasePlaylistRequest{UserID: userID, BasePlaylistID: basePlaylistID}
+	if err := c.invoke(ctx, "SyncBasePlaylist", req, &syncEvent); err != nil {
+		return nil, err
+	}
+
+	return &syncEvent, nil
+}
+
+func (c *Client) SyncAllBasePlaylists(ctx context.Context, userID string) (*models.MultiSyncReport, error) {
+	var report models.MultiSyncReport
+	req := &SyncAllBasePlaylistsRequest{UserID: userID}
+	if err := c.invoke(ctx, "SyncAllBasePlaylists", req, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (c *Client) GetBasePlaylists(ctx context.Context, userID string) ([]*models.BasePlaylistWithChilds, error) {
+	var resp GetBasePlaylistsResponse
+	req := &GetBasePlaylistsRequest{UserID: userID}
+	if err := c.invoke(ctx, "GetBasePlaylists", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.BasePlaylists, nil
+}
+
+func (c *Client) GetChildPlaylists(ctx context.Context, userID, basePlaylistID string) ([]*models.ChildPlaylist, error) {
+	var resp GetChildPlaylistsResponse
+	req := &GetChildPlaylistsRequest{UserID: userID, BasePlaylistID: basePlaylistID}
+	if err := c.invoke(ctx, "GetChildPlaylists", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.ChildPlaylists, nil
+}
+
+func (c *Client) invoke(ctx context.Context, method string, req, resp any) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	return c.conn.Invoke(ctx, fullMethodName(method), req, resp, grpc.CallContentSubtype(CodecName))
+}
@@ -0,0 +1,29 @@
+package internalapi
+
+import "github.com/ngomez18/playlist-router/internal/models"
+
+type SyncBasePlaylistRequest struct {
+	UserID         string `json:"user_id"`
+	BasePlaylistID string `json:"base_playlist_id"`
+}
+
+type SyncAllBasePlaylistsRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type GetBasePlaylistsRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type GetBasePlaylistsResponse struct {
+	BasePlaylists []*models.BasePlaylistWithChilds `json:"base_playlists"`
+}
+
+type GetChildPlaylistsRequest struct {
+	UserID         string `json:"user_id"`
+	BasePlaylistID string `json:"base_playlist_id"`
+}
+
+type GetChildPlaylistsResponse struct {
+	ChildPlaylists []*models.ChildPlaylist `json:"child_playlists"`
+}
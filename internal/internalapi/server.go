@@ -0,0 +1,151 @@
+package internalapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"strings"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/orchestrators"
+	"github.com/ngomez18/playlist-router/internal/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SpotifyAuthProvider builds a context carrying the spotify credentials of a user, the same way
+// the HTTP spotify auth middleware does for user-facing requests.
+type SpotifyAuthProvider interface {
+	ContextWithSpotifyAuth(ctx context.Context, userID string) (context.Context, error)
+}
+
+type Server struct {
+	syncOrchestrator     orchestrators.SyncOrchestrator
+	basePlaylistService  services.BasePlaylistServicer
+	childPlaylistService services.ChildPlaylistServicer
+	spotifyAuth          SpotifyAuthProvider
+	token                string
+	logger               *slog.Logger
+}
+
+func NewServer(
+	syncOrchestrator orchestrators.SyncOrchestrator,
+	basePlaylistService services.BasePlaylistServicer,
+	childPlaylistService services.ChildPlaylistServicer,
+	spotifyAuth SpotifyAuthProvider,
+	token string,
+	logger *slog.Logger,
+) *Server {
+	return &Server{
+		syncOrchestrator:     syncOrchestrator,
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		spotifyAuth:          spotifyAuth,
+		token:                token,
+		logger:               logger.With("component", "InternalAPIServer"),
+	}
+}
+
+// NewGRPCServer creates a gRPC server with the internal API registered and token auth enforced
+func (s *Server) NewGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.UnaryInterceptor(s.authInterceptor))
+	grpcServer := grpc.NewServer(opts...)
+	grpcServer.RegisterService(&ServiceDesc, s)
+
+	return grpcServer
+}
+
+func (s *Server) SyncBasePlaylist(ctx context.Context, req *SyncBasePlaylistRequest) (*models.SyncEvent, error) {
+	if req.UserID == "" || req.BasePlaylistID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and base_playlist_id are required")
+	}
+
+	ctx, err := s.spotifyAuth.ContextWithSpotifyAuth(ctx, req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// Internal services sync on schedules no user is waiting on
+	ctx = requestcontext.ContextWithBackgroundJob(ctx)
+
+	syncEvent, err := s.syncOrchestrator.SyncBasePlaylist(ctx, req.UserID, req.BasePlaylistID)
+	if errors.Is(err, orchestrators.ErrSyncAnomalyDetected) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to sync base playlist: "+err.Error())
+	}
+
+	return syncEvent, nil
+}
+
+func (s *Server) SyncAllBasePlaylists(ctx context.Context, req *SyncAllBasePlaylistsRequest) (*models.MultiSyncReport, error) {
+	if req.UserID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	ctx, err := s.spotifyAuth.ContextWithSpotifyAuth(ctx, req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	ctx = requestcontext.ContextWithBackgroundJob(ctx)
+
+	report, err := s.syncOrchestrator.SyncAllBasePlaylists(ctx, req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to sync base playlists: "+err.Error())
+	}
+
+	return report, nil
+}
+
+func (s *Server) GetBasePlaylists(ctx context.Context, req *GetBasePlaylistsRequest) (*GetBasePlaylistsResponse, error) {
+	if req.UserID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	basePlaylists, err := s.basePlaylistService.GetBasePlaylistsByUserIDWithChilds(ctx, req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "unable to retrieve base playlists: "+err.Error())
+	}
+
+	return &GetBasePlaylistsResponse{BasePlaylists: basePlaylists}, nil
+}
+
+func (s *Server) GetChildPlaylists(ctx context.Context, req *GetChildPlaylistsRequest) (*GetChildPlaylistsResponse, error) {
+	if req.UserID == "" || req.BasePlaylistID == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and base_playlist_id are required")
+	}
+
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, req.BasePlaylistID, req.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "unable to retrieve child playlists: "+err.Error())
+	}
+
+	return &GetChildPlaylistsResponse{ChildPlaylists: childPlaylists}, nil
+}
+
+func (s *Server) authInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		s.logger.WarnContext(ctx, "rejected internal api call with invalid token", "method", info.FullMethod)
+		return nil, status.Error(codes.Unauthenticated, "invalid internal api token")
+	}
+
+	s.logger.InfoContext(ctx, "handling internal api call", "method", info.FullMethod)
+	return handler(ctx, req)
+}
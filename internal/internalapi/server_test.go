@@ -0,0 +1,227 @@
+package internalapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	orchestratormocks "github.com/ngomez18/playlist-router/internal/orchestrators/mocks"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testToken = "internal-secret"
+
+type fakeSpotifyAuth struct {
+	err error
+}
+
+func (f *fakeSpotifyAuth) ContextWithSpotifyAuth(ctx context.Context, userID string) (context.Context, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return requestcontext.ContextWithSpotifyAuth(ctx, &models.SpotifyIntegration{UserID: userID}), nil
+}
+
+type testDeps struct {
+	syncOrchestrator     *orchestratormocks.MockSyncOrchestrator
+	basePlaylistService  *servicemocks.MockBasePlaylistServicer
+	childPlaylistService *servicemocks.MockChildPlaylistServicer
+	spotifyAuth          *fakeSpotifyAuth
+}
+
+func startTestServer(t *testing.T, ctrl *gomock.Controller) (testDeps, *grpc.ClientConn) {
+	t.Helper()
+
+	deps := testDeps{
+		syncOrchestrator:     orchestratormocks.NewMockSyncOrchestrator(ctrl),
+		basePlaylistService:  servicemocks.NewMockBasePlaylistServicer(ctrl),
+		childPlaylistService: servicemocks.NewMockChildPlaylistServicer(ctrl),
+		spotifyAuth:          &fakeSpotifyAuth{},
+	}
+
+	server := NewServer(
+		deps.syncOrchestrator,
+		deps.basePlaylistService,
+		deps.childPlaylistService,
+		deps.spotifyAuth,
+		testToken,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := server.NewGRPCServer()
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return deps, conn
+}
+
+func TestServer_SyncBasePlaylist(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        string
+		spotifyErr   error
+		setupMocks   func(deps testDeps)
+		expectedCode codes.Code
+	}{
+		{
+			name:  "success",
+			token: testToken,
+			setupMocks: func(deps testDeps) {
+				deps.syncOrchestrator.EXPECT().
+					SyncBasePlaylist(gomock.Any(), "user123", "base456").
+					DoAndReturn(func(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
+						_, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
+						require.True(t, ok)
+						// Syncs requested by internal services wait for quota instead of failing
+						require.True(t, requestcontext.IsBackgroundJob(ctx))
+						return &models.SyncEvent{ID: "sync123", BasePlaylistID: basePlaylistID, Status: models.SyncStatusCompleted}, nil
+					})
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "invalid token",
+			token:        "wrong-token",
+			setupMocks:   func(deps testDeps) {},
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "missing spotify integration",
+			token:        testToken,
+			spotifyErr:   errors.New("no spotify integration available for user"),
+			setupMocks:   func(deps testDeps) {},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:  "orchestrator error",
+			token: testToken,
+			setupMocks: func(deps testDeps) {
+				deps.syncOrchestrator.EXPECT().
+					SyncBasePlaylist(gomock.Any(), "user123", "base456").
+					Return(nil, errors.New("sync already in progress for base playlist base456"))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			deps, conn := startTestServer(t, ctrl)
+			deps.spotifyAuth.err = tt.spotifyErr
+			tt.setupMocks(deps)
+
+			client := NewClient(conn, tt.token)
+			syncEvent, err := client.SyncBasePlaylist(context.Background(), "user123", "base456")
+
+			assert.Equal(tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal("sync123", syncEvent.ID)
+				assert.Equal(models.SyncStatusCompleted, syncEvent.Status)
+			} else {
+				assert.Nil(syncEvent)
+			}
+		})
+	}
+}
+
+func TestServer_SyncBasePlaylist_InvalidArgument(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, conn := startTestServer(t, ctrl)
+	client := NewClient(conn, testToken)
+
+	_, err := client.SyncBasePlaylist(context.Background(), "user123", "")
+
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_SyncAllBasePlaylists(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	deps.syncOrchestrator.EXPECT().
+		SyncAllBasePlaylists(gomock.Any(), "user123").
+		Return(&models.MultiSyncReport{UserID: "user123", Total: 2, Succeeded: 2}, nil)
+
+	client := NewClient(conn, testToken)
+	report, err := client.SyncAllBasePlaylists(context.Background(), "user123")
+
+	assert.NoError(err)
+	assert.Equal(2, report.Total)
+	assert.Equal(2, report.Succeeded)
+}
+
+func TestServer_GetBasePlaylists(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	deps.basePlaylistService.EXPECT().
+		GetBasePlaylistsByUserIDWithChilds(gomock.Any(), "user123").
+		Return([]*models.BasePlaylistWithChilds{
+			{
+				BasePlaylist: &models.BasePlaylist{ID: "base1", UserID: "user123"},
+				Childs:       []*models.ChildPlaylist{{ID: "child1"}},
+			},
+		}, nil)
+
+	client := NewClient(conn, testToken)
+	basePlaylists, err := client.GetBasePlaylists(context.Background(), "user123")
+
+	assert.NoError(err)
+	assert.Len(basePlaylists, 1)
+	assert.Equal("base1", basePlaylists[0].ID)
+	assert.Len(basePlaylists[0].Childs, 1)
+}
+
+func TestServer_GetChildPlaylists(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deps, conn := startTestServer(t, ctrl)
+	deps.childPlaylistService.EXPECT().
+		GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base1", "user123").
+		Return(nil, errors.New("database error"))
+
+	client := NewClient(conn, testToken)
+	childPlaylists, err := client.GetChildPlaylists(context.Background(), "user123", "base1")
+
+	assert.Equal(codes.Internal, status.Code(err))
+	assert.Nil(childPlaylists)
+}
@@ -0,0 +1,76 @@
+package internalapi
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "playlistrouter.internal.v1.InternalAPI"
+
+// InternalAPIServer is the set of operations exposed to workers and other internal services
+type InternalAPIServer interface {
+	SyncBasePlaylist(ctx context.Context, req *SyncBasePlaylistRequest) (*models.SyncEvent, error)
+	SyncAllBasePlaylists(ctx context.Context, req *SyncAllBasePlaylistsRequest) (*models.MultiSyncReport, error)
+	GetBasePlaylists(ctx context.Context, req *GetBasePlaylistsRequest) (*GetBasePlaylistsResponse, error)
+	GetChildPlaylists(ctx context.Context, req *GetChildPlaylistsRequest) (*GetChildPlaylistsResponse, error)
+}
+
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*InternalAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SyncBasePlaylist",
+			Handler: unaryHandler("SyncBasePlaylist", func(srv InternalAPIServer, ctx context.Context, req *SyncBasePlaylistRequest) (any, error) {
+				return srv.SyncBasePlaylist(ctx, req)
+			}),
+		},
+		{
+			MethodName: "SyncAllBasePlaylists",
+			Handler: unaryHandler("SyncAllBasePlaylists", func(srv InternalAPIServer, ctx context.Context, req *SyncAllBasePlaylistsRequest) (any, error) {
+				return srv.SyncAllBasePlaylists(ctx, req)
+			}),
+		},
+		{
+			MethodName: "GetBasePlaylists",
+			Handler: unaryHandler("GetBasePlaylists", func(srv InternalAPIServer, ctx context.Context, req *GetBasePlaylistsRequest) (any, error) {
+				return srv.GetBasePlaylists(ctx, req)
+			}),
+		},
+		{
+			MethodName: "GetChildPlaylists",
+			Handler: unaryHandler("GetChildPlaylists", func(srv InternalAPIServer, ctx context.Context, req *GetChildPlaylistsRequest) (any, error) {
+				return srv.GetChildPlaylists(ctx, req)
+			}),
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func fullMethodName(method string) string {
+	return "/" + serviceName + "/" + method
+}
+
+// unaryHandler adapts a typed method into the generic handler signature gRPC expects,
+// taking care of request decoding and interceptor chaining.
+func unaryHandler[Req any](method string, call func(srv InternalAPIServer, ctx context.Context, req *Req) (any, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+
+		if interceptor == nil {
+			return call(srv.(InternalAPIServer), ctx, req)
+		}
+
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethodName(method)}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return call(srv.(InternalAPIServer), ctx, req.(*Req))
+		}
+
+		return interceptor(ctx, req, info, handler)
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+// APIKeyHeader carries the API key of automation platforms such as Zapier or IFTTT
+const APIKeyHeader = "X-API-Key"
+
+type APIKeyMiddleware struct {
+	apiKeyService services.APIKeyServicer
+	userService   services.UserServicer
+}
+
+func NewAPIKeyMiddleware(apiKeyService services.APIKeyServicer, userService services.UserServicer) *APIKeyMiddleware {
+	return &APIKeyMiddleware{
+		apiKeyService: apiKeyService,
+		userService:   userService,
+	}
+}
+
+// RequireAPIKey authenticates the request with the API key header and adds the key and its
+// owner to the request context, so user scoped handlers work the same as with a JWT
+func (m *APIKeyMiddleware) RequireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawKey := r.Header.Get(APIKeyHeader)
+		if rawKey == "" {
+			problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidAPIKey, "api key header is required")
+			return
+		}
+
+		apiKey, err := m.apiKeyService.Authenticate(r.Context(), rawKey)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidAPIKey) {
+				problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidAPIKey, "invalid api key")
+				return
+			}
+
+			problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to validate api key")
+			return
+		}
+
+		user, err := m.userService.GetUserByID(r.Context(), apiKey.UserID)
+		if err != nil {
+			problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidAPIKey, "invalid api key")
+			return
+		}
+		if user.Disabled {
+			problem.Write(w, http.StatusForbidden, problem.CodeAccountDisabled, "account is disabled")
+			return
+		}
+
+		ctx := requestcontext.ContextWithUser(r.Context(), user)
+		ctx = requestcontext.ContextWithAPIKey(ctx, apiKey)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireScope rejects requests whose API key was not granted the scope. It must run after RequireAPIKey
+func (m *APIKeyMiddleware) RequireScope(scope models.APIKeyScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey, ok := requestcontext.GetAPIKeyFromContext(r.Context())
+			if !ok {
+				problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidAPIKey, "api key not available in context")
+				return
+			}
+
+			if !apiKey.HasScope(scope) {
+				problem.Write(w, http.StatusForbidden, problem.CodeInsufficientScope, "api key is missing the "+string(scope)+" scope")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/services"
+	serviceMocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyMiddleware_RequireAPIKey_Success(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyService := serviceMocks.NewMockAPIKeyServicer(ctrl)
+	mockUserService := serviceMocks.NewMockUserServicer(ctrl)
+
+	apiKey := &models.APIKey{ID: "key123", UserID: "user123", Scopes: []models.APIKeyScope{models.APIKeyScopeSyncRead}}
+	user := &models.User{ID: "user123", Email: "test@example.com"}
+
+	mockAPIKeyService.EXPECT().Authenticate(gomock.Any(), "prk_valid").Return(apiKey, nil)
+	mockUserService.EXPECT().GetUserByID(gomock.Any(), "user123").Return(user, nil)
+
+	middleware := NewAPIKeyMiddleware(mockAPIKeyService, mockUserService)
+	handlerCalled := false
+
+	handler := middleware.RequireAPIKey(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+
+		contextUser, ok := requestcontext.GetUserFromContext(r.Context())
+		assert.True(ok)
+		assert.Equal(user, contextUser)
+
+		contextKey, ok := requestcontext.GetAPIKeyFromContext(r.Context())
+		assert.True(ok)
+		assert.Equal(apiKey, contextKey)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(APIKeyHeader, "prk_valid")
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	assert.True(handlerCalled)
+	assert.Equal(http.StatusOK, recorder.Code)
+}
+
+func TestAPIKeyMiddleware_RequireAPIKey_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		apiKeyHeader   string
+		setupMocks     func(*serviceMocks.MockAPIKeyServicer, *serviceMocks.MockUserServicer)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "missing_api_key_header",
+			setupMocks:     func(*serviceMocks.MockAPIKeyServicer, *serviceMocks.MockUserServicer) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "api key header is required",
+		},
+		{
+			name:         "invalid_api_key",
+			apiKeyHeader: "prk_invalid",
+			setupMocks: func(apiKeyService *serviceMocks.MockAPIKeyServicer, _ *serviceMocks.MockUserServicer) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "prk_invalid").Return(nil, services.ErrInvalidAPIKey)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid api key",
+		},
+		{
+			name:         "api_key_service_error",
+			apiKeyHeader: "prk_valid",
+			setupMocks: func(apiKeyService *serviceMocks.MockAPIKeyServicer, _ *serviceMocks.MockUserServicer) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "prk_valid").Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "unable to validate api key",
+		},
+		{
+			name:         "owner_not_found",
+			apiKeyHeader: "prk_valid",
+			setupMocks: func(apiKeyService *serviceMocks.MockAPIKeyServicer, userService *serviceMocks.MockUserServicer) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "prk_valid").Return(&models.APIKey{ID: "key123", UserID: "user123"}, nil)
+				userService.EXPECT().GetUserByID(gomock.Any(), "user123").Return(nil, errors.New("user not found"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid api key",
+		},
+		{
+			name:         "owner_disabled",
+			apiKeyHeader: "prk_valid",
+			setupMocks: func(apiKeyService *serviceMocks.MockAPIKeyServicer, userService *serviceMocks.MockUserServicer) {
+				apiKeyService.EXPECT().Authenticate(gomock.Any(), "prk_valid").Return(&models.APIKey{ID: "key123", UserID: "user123"}, nil)
+				userService.EXPECT().GetUserByID(gomock.Any(), "user123").Return(&models.User{ID: "user123", Disabled: true}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "account is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPIKeyService := serviceMocks.NewMockAPIKeyServicer(ctrl)
+			mockUserService := serviceMocks.NewMockUserServicer(ctrl)
+			tt.setupMocks(mockAPIKeyService, mockUserService)
+			middleware := NewAPIKeyMiddleware(mockAPIKeyService, mockUserService)
+
+			handler := middleware.RequireAPIKey(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("handler should not be called")
+			}))
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.apiKeyHeader != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKeyHeader)
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(tt.expectedStatus, recorder.Code)
+			assert.Contains(recorder.Body.String(), tt.expectedError)
+		})
+	}
+}
+
+func TestAPIKeyMiddleware_RequireScope(t *testing.T) {
+	tests := []struct {
+		name           string
+		apiKey         *models.APIKey
+		expectedStatus int
+	}{
+		{
+			name:           "scope_granted",
+			apiKey:         &models.APIKey{Scopes: []models.APIKeyScope{models.APIKeyScopeSyncRead, models.APIKeyScopeSyncWrite}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "scope_missing",
+			apiKey:         &models.APIKey{Scopes: []models.APIKeyScope{models.APIKeyScopeSyncRead}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "no_api_key_in_context",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			middleware := NewAPIKeyMiddleware(nil, nil)
+			handler := middleware.RequireScope(models.APIKeyScopeSyncWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest("POST", "/test", nil)
+			if tt.apiKey != nil {
+				req = req.WithContext(requestcontext.ContextWithAPIKey(req.Context(), tt.apiKey))
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(tt.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -1,15 +1,19 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
 	"github.com/ngomez18/playlist-router/internal/services"
 )
 
 type AuthMiddleware struct {
-	userService services.UserServicer
+	userService   services.UserServicer
+	apiKeyService services.APIKeyServicer
 }
 
 func NewAuthMiddleware(userService services.UserServicer) *AuthMiddleware {
@@ -18,30 +22,42 @@ func NewAuthMiddleware(userService services.UserServicer) *AuthMiddleware {
 	}
 }
 
+// WithAPIKeys lets RequireAuth accept API keys as bearer tokens, so scripts and CI jobs can call the
+// routes opted in with AllowAPIKey without going through the browser OAuth flow
+func (m *AuthMiddleware) WithAPIKeys(apiKeyService services.APIKeyServicer) *AuthMiddleware {
+	m.apiKeyService = apiKeyService
+	return m
+}
+
 func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "authorization header is required", http.StatusUnauthorized)
+			problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "authorization header is required")
 			return
 		}
 
 		// Extract Bearer token
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			http.Error(w, "invalid authorization header format", http.StatusUnauthorized)
+			problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidToken, "invalid authorization header format")
 			return
 		}
 
 		token := strings.TrimPrefix(authHeader, "Bearer ")
 		if token == "" {
-			http.Error(w, "token is required", http.StatusUnauthorized)
+			problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidToken, "token is required")
+			return
+		}
+
+		if m.apiKeyService != nil && strings.HasPrefix(token, services.API_KEY_PREFIX) {
+			m.authenticateAPIKey(w, r, token, next)
 			return
 		}
 
 		// Validate token using user service
 		user, err := m.userService.ValidateAuthToken(r.Context(), token)
 		if err != nil {
-			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidToken, "invalid or expired token")
 			return
 		}
 
@@ -51,6 +67,75 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	})
 }
 
+// authenticateAPIKey only adds the key to the request context. The user is added by AllowAPIKey once
+// the route accepted the key, handlers of other routes find no user and reject the request
+func (m *AuthMiddleware) authenticateAPIKey(w http.ResponseWriter, r *http.Request, rawKey string, next http.Handler) {
+	apiKey, err := m.apiKeyService.Authenticate(r.Context(), rawKey)
+	if errors.Is(err, services.ErrInvalidAPIKey) {
+		problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidAPIKey, "invalid or expired api key")
+		return
+	}
+	if err != nil {
+		problem.Write(w, http.StatusInternalServerError, problem.CodeInternalError, "unable to validate api key")
+		return
+	}
+
+	ctx := requestcontext.ContextWithAPIKey(r.Context(), apiKey)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AllowAPIKey opens a route to the API keys granted the scope. Requests authenticated with a JWT pass
+// through unchanged
+func (m *AuthMiddleware) AllowAPIKey(scope models.APIKeyScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey, ok := requestcontext.GetAPIKeyFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !apiKey.HasScope(scope) {
+				problem.Write(w, http.StatusForbidden, problem.CodeInsufficientScope, "api key is missing the "+string(scope)+" scope")
+				return
+			}
+
+			user, err := m.userService.GetUserByID(r.Context(), apiKey.UserID)
+			if err != nil {
+				problem.Write(w, http.StatusUnauthorized, problem.CodeInvalidAPIKey, "invalid or expired api key")
+				return
+			}
+			if user.Disabled {
+				problem.Write(w, http.StatusForbidden, problem.CodeAccountDisabled, "account is disabled")
+				return
+			}
+
+			ctx := requestcontext.ContextWithUser(r.Context(), user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRole rejects requests whose user was not granted the role. It must run after RequireAuth
+func (m *AuthMiddleware) RequireRole(role models.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := requestcontext.GetUserFromContext(r.Context())
+			if !ok {
+				problem.Write(w, http.StatusUnauthorized, problem.CodeUnauthorized, "user not found in context")
+				return
+			}
+
+			if !user.HasRole(role) {
+				problem.Write(w, http.StatusForbidden, problem.CodeForbidden, "the "+string(role)+" role is required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -11,6 +11,7 @@ import (
 	"github.com/golang/mock/gomock"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/services"
 	serviceMocks "github.com/ngomez18/playlist-router/internal/services/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -275,6 +276,176 @@ func TestAuthMiddleware_RequireAuth_ValidToken(t *testing.T) {
 	assert.Equal("success", recorder.Body.String())
 }
 
+func TestAuthMiddleware_RequireAuth_APIKey(t *testing.T) {
+	apiKey := &models.APIKey{ID: "key123", UserID: "user123", Scopes: []models.APIKeyScope{models.APIKeyScopeSyncWrite}}
+	user := &models.User{ID: "user123"}
+
+	tests := []struct {
+		name           string
+		allowedScope   models.APIKeyScope
+		optedIn        bool
+		setupMocks     func(*serviceMocks.MockAPIKeyServicer, *serviceMocks.MockUserServicer)
+		expectedStatus int
+		expectUser     bool
+	}{
+		{
+			name:         "route opted in with a granted scope",
+			allowedScope: models.APIKeyScopeSyncWrite,
+			optedIn:      true,
+			setupMocks: func(apiKeys *serviceMocks.MockAPIKeyServicer, users *serviceMocks.MockUserServicer) {
+				apiKeys.EXPECT().Authenticate(gomock.Any(), "prk_secret").Return(apiKey, nil)
+				users.EXPECT().GetUserByID(gomock.Any(), "user123").Return(user, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectUser:     true,
+		},
+		{
+			name:         "owner disabled",
+			allowedScope: models.APIKeyScopeSyncWrite,
+			optedIn:      true,
+			setupMocks: func(apiKeys *serviceMocks.MockAPIKeyServicer, users *serviceMocks.MockUserServicer) {
+				apiKeys.EXPECT().Authenticate(gomock.Any(), "prk_secret").Return(apiKey, nil)
+				users.EXPECT().GetUserByID(gomock.Any(), "user123").Return(&models.User{ID: "user123", Disabled: true}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:         "route opted in with a scope not granted",
+			allowedScope: models.APIKeyScopeSyncRead,
+			optedIn:      true,
+			setupMocks: func(apiKeys *serviceMocks.MockAPIKeyServicer, users *serviceMocks.MockUserServicer) {
+				apiKeys.EXPECT().Authenticate(gomock.Any(), "prk_secret").Return(apiKey, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "route not opted in gets no user",
+			setupMocks: func(apiKeys *serviceMocks.MockAPIKeyServicer, users *serviceMocks.MockUserServicer) {
+				apiKeys.EXPECT().Authenticate(gomock.Any(), "prk_secret").Return(apiKey, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:         "invalid or expired key",
+			allowedScope: models.APIKeyScopeSyncWrite,
+			optedIn:      true,
+			setupMocks: func(apiKeys *serviceMocks.MockAPIKeyServicer, users *serviceMocks.MockUserServicer) {
+				apiKeys.EXPECT().Authenticate(gomock.Any(), "prk_secret").Return(nil, services.ErrInvalidAPIKey)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "key lookup error",
+			allowedScope: models.APIKeyScopeSyncWrite,
+			optedIn:      true,
+			setupMocks: func(apiKeys *serviceMocks.MockAPIKeyServicer, users *serviceMocks.MockUserServicer) {
+				apiKeys.EXPECT().Authenticate(gomock.Any(), "prk_secret").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctrl := gomock.NewController(t)
+
+			mockAPIKeyService := serviceMocks.NewMockAPIKeyServicer(ctrl)
+			mockUserService := serviceMocks.NewMockUserServicer(ctrl)
+			tt.setupMocks(mockAPIKeyService, mockUserService)
+			middleware := NewAuthMiddleware(mockUserService).WithAPIKeys(mockAPIKeyService)
+
+			var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, found := requestcontext.GetUserFromContext(r.Context())
+				assert.Equal(tt.expectUser, found)
+				w.WriteHeader(http.StatusOK)
+			})
+			if tt.optedIn {
+				handler = middleware.AllowAPIKey(tt.allowedScope)(handler)
+			}
+			handler = middleware.RequireAuth(handler)
+
+			req := httptest.NewRequest("POST", "/api/sync/all", nil)
+			req.Header.Set("Authorization", "Bearer prk_secret")
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_AllowAPIKey_JWT(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+
+	mockUserService := serviceMocks.NewMockUserServicer(ctrl)
+	middleware := NewAuthMiddleware(mockUserService).WithAPIKeys(serviceMocks.NewMockAPIKeyServicer(ctrl))
+
+	user := &models.User{ID: "user123"}
+	mockUserService.EXPECT().ValidateAuthToken(gomock.Any(), "valid_token").Return(user, nil)
+
+	handlerCalled := false
+	handler := middleware.RequireAuth(middleware.AllowAPIKey(models.APIKeyScopeSyncWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+		contextUser, found := requestcontext.GetUserFromContext(r.Context())
+		assert.True(found)
+		assert.Equal(user.ID, contextUser.ID)
+	})))
+
+	req := httptest.NewRequest("POST", "/api/sync/all", nil)
+	req.Header.Set("Authorization", "Bearer valid_token")
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(handlerCalled)
+}
+
+func TestAuthMiddleware_RequireRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *models.User
+		expectedStatus int
+	}{
+		{
+			name:           "user granted the role",
+			user:           &models.User{ID: "user123", Roles: []models.UserRole{models.RoleUser, models.RoleAdmin}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "user without the role",
+			user:           &models.User{ID: "user123", Roles: []models.UserRole{models.RoleUser}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "user not found in context",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			middleware := NewAuthMiddleware(serviceMocks.NewMockUserServicer(gomock.NewController(t)))
+
+			handler := middleware.RequireRole(models.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest("GET", "/api/admin/users", nil)
+			if tt.user != nil {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), tt.user))
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
 func TestAuthMiddleware_OptionalAuth_InvalidToken(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := gomock.NewController(t)
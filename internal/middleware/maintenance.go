@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/problem"
+	"github.com/ngomez18/playlist-router/internal/services"
+)
+
+type MaintenanceMiddleware struct {
+	maintenanceService services.MaintenanceServicer
+}
+
+func NewMaintenanceMiddleware(maintenanceService services.MaintenanceServicer) *MaintenanceMiddleware {
+	return &MaintenanceMiddleware{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// RejectWrites answers 503 Service Unavailable to every request that isn't a read while maintenance
+// mode is on. Admins are let through, so they can check the app and turn maintenance mode off
+func (m *MaintenanceMiddleware) RejectWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if user, ok := requestcontext.GetUserFromContext(r.Context()); ok && user.HasRole(models.RoleAdmin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		status := m.maintenanceService.Status(r.Context())
+		if status.Enabled {
+			problem.Write(w, http.StatusServiceUnavailable, problem.CodeMaintenance, status.Message)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
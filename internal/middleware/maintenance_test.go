@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	serviceMocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceMiddleware_RejectWrites(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		user           *models.User
+		enabled        bool
+		checksStatus   bool
+		expectedStatus int
+	}{
+		{name: "read during maintenance", method: http.MethodGet, enabled: true, expectedStatus: http.StatusOK},
+		{name: "write during maintenance", method: http.MethodPost, user: &models.User{ID: "user123"}, enabled: true, checksStatus: true, expectedStatus: http.StatusServiceUnavailable},
+		{name: "write without user during maintenance", method: http.MethodDelete, enabled: true, checksStatus: true, expectedStatus: http.StatusServiceUnavailable},
+		{name: "admin write during maintenance", method: http.MethodPut, user: &models.User{ID: "admin123", Roles: []models.UserRole{models.RoleAdmin}}, enabled: true, expectedStatus: http.StatusOK},
+		{name: "write without maintenance", method: http.MethodPost, user: &models.User{ID: "user123"}, checksStatus: true, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			mockMaintenanceService := serviceMocks.NewMockMaintenanceServicer(ctrl)
+			if tt.checksStatus {
+				mockMaintenanceService.EXPECT().Status(gomock.Any()).Return(&models.MaintenanceMode{Enabled: tt.enabled, Message: "Upgrading, back in a few minutes"})
+			}
+
+			handler := NewMaintenanceMiddleware(mockMaintenanceService).RejectWrites(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/api/base_playlist", nil)
+			if tt.user != nil {
+				req = req.WithContext(requestcontext.ContextWithUser(req.Context(), tt.user))
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.Contains(w.Body.String(), `"code":"maintenance"`)
+				assert.Contains(w.Body.String(), "Upgrading, back in a few minutes")
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/problem"
+)
+
+// RATE_LIMIT_SWEEP_INTERVAL is how often the buckets refilled to their capacity are dropped, so
+// clients that stopped calling the API don't keep using memory
+const RATE_LIMIT_SWEEP_INTERVAL = 5 * time.Minute
+
+// RateLimitMiddleware limits how often each user can call the API, protecting the Spotify quota and
+// the database from clients stuck in a request loop. Requests without a user are limited per IP
+type RateLimitMiddleware struct {
+	mu        sync.Mutex
+	buckets   map[string]*rateLimitBucket
+	capacity  float64
+	refill    float64 // Tokens per second
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// rateLimitBucket is the token bucket of a single client
+type rateLimitBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimitMiddleware(cfg config.RateLimitConfig) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		buckets:   map[string]*rateLimitBucket{},
+		capacity:  float64(cfg.Burst),
+		refill:    float64(cfg.RequestsPerMinute) / time.Minute.Seconds(),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Limit answers 429 Too Many Requests once the client spent its requests. Every response reports
+// the remaining requests through the X-RateLimit-* headers
+func (m *RateLimitMiddleware) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, remaining, reset, retryAfter := m.take(rateLimitKey(r))
+		if limit == 0 { // Disabled
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			problem.Write(w, http.StatusTooManyRequests, problem.CodeRateLimited, "rate limit exceeded")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AllowUser spends a request of the user for calls that don't go through Limit, like those of the
+// gRPC API, so they count against the same limit. When the user has no request left it returns
+// false and the time until a request is available
+func (m *RateLimitMiddleware) AllowUser(userID string) (bool, time.Duration) {
+	_, _, _, retryAfter := m.take("user:" + userID)
+	return retryAfter == 0, retryAfter
+}
+
+// Update applies new limits, as when the config is reloaded. The buckets are dropped so every
+// client starts with the new burst, and a limit of 0 requests per minute lets every request through
+func (m *RateLimitMiddleware) Update(cfg config.RateLimitConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.buckets = map[string]*rateLimitBucket{}
+	m.capacity = float64(cfg.Burst)
+	m.refill = float64(cfg.RequestsPerMinute) / time.Minute.Seconds()
+}
+
+// take spends a request of the client. It returns the requests a full bucket holds, 0 when rate
+// limiting is disabled, the requests left, when the bucket is full again and, when nothing could be
+// spent, the time until a request is available
+func (m *RateLimitMiddleware) take(key string) (int, int, time.Time, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.refill <= 0 {
+		return 0, 0, time.Time{}, 0
+	}
+
+	now := m.now()
+	m.sweep(now)
+
+	bucket, ok := m.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{tokens: m.capacity, last: now}
+		m.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(m.capacity, bucket.tokens+now.Sub(bucket.last).Seconds()*m.refill)
+	bucket.last = now
+
+	var retryAfter time.Duration
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+	} else {
+		retryAfter = m.refillDuration(1 - bucket.tokens)
+	}
+
+	reset := now.Add(m.refillDuration(m.capacity - bucket.tokens))
+	return int(m.capacity), int(bucket.tokens), reset, retryAfter
+}
+
+// sweep drops the buckets that refilled since their last request, they are created full again
+func (m *RateLimitMiddleware) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < RATE_LIMIT_SWEEP_INTERVAL {
+		return
+	}
+	m.lastSweep = now
+
+	for key, bucket := range m.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*m.refill >= m.capacity {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+func (m *RateLimitMiddleware) refillDuration(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / m.refill * float64(time.Second)))
+}
+
+// rateLimitKey identifies the client of the request: its user, the owner of its API key or, for
+// anonymous requests, its IP
+func rateLimitKey(r *http.Request) string {
+	if user, ok := requestcontext.GetUserFromContext(r.Context()); ok {
+		return "user:" + user.ID
+	}
+	if apiKey, ok := requestcontext.GetAPIKeyFromContext(r.Context()); ok {
+		return "user:" + apiKey.UserID
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRateLimitMiddleware(now *time.Time) *RateLimitMiddleware {
+	middleware := NewRateLimitMiddleware(config.RateLimitConfig{RequestsPerMinute: 60, Burst: 2})
+	middleware.now = func() time.Time { return *now }
+	middleware.lastSweep = *now
+	return middleware
+}
+
+func rateLimitedRequest(middleware *RateLimitMiddleware, r *http.Request) *httptest.ResponseRecorder {
+	handler := middleware.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func userRequest(userID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/base_playlist", nil)
+	return req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: userID}))
+}
+
+func TestRateLimitMiddleware_Limit(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	middleware := newTestRateLimitMiddleware(&now)
+
+	w := rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal("1", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(strconv.FormatInt(now.Add(time.Second).Unix(), 10), w.Header().Get("X-RateLimit-Reset"))
+
+	w = rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+
+	w = rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal("1", w.Header().Get("Retry-After"))
+
+	// Other users have their own bucket
+	w = rateLimitedRequest(middleware, userRequest("user456"))
+	assert.Equal(http.StatusOK, w.Code)
+
+	// One request refills every second
+	now = now.Add(time.Second)
+	w = rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusOK, w.Code)
+}
+
+func TestRateLimitMiddleware_Limit_APIKey(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	middleware := newTestRateLimitMiddleware(&now)
+
+	// API key requests share the bucket of the key owner
+	for range 2 {
+		assert.Equal(http.StatusOK, rateLimitedRequest(middleware, userRequest("user123")).Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/sync/all", nil)
+	req = req.WithContext(requestcontext.ContextWithAPIKey(req.Context(), &models.APIKey{ID: "key123", UserID: "user123"}))
+	assert.Equal(http.StatusTooManyRequests, rateLimitedRequest(middleware, req).Code)
+}
+
+func TestRateLimitMiddleware_Limit_AnonymousByIP(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	middleware := newTestRateLimitMiddleware(&now)
+
+	anonymousRequest := func(remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/base_playlist", nil)
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	assert.Equal(http.StatusOK, rateLimitedRequest(middleware, anonymousRequest("10.0.0.1:1234")).Code)
+	assert.Equal(http.StatusOK, rateLimitedRequest(middleware, anonymousRequest("10.0.0.1:5678")).Code)
+	assert.Equal(http.StatusTooManyRequests, rateLimitedRequest(middleware, anonymousRequest("10.0.0.1:9999")).Code)
+	assert.Equal(http.StatusOK, rateLimitedRequest(middleware, anonymousRequest("10.0.0.2:1234")).Code)
+}
+
+func TestRateLimitMiddleware_AllowUser(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	middleware := newTestRateLimitMiddleware(&now)
+
+	// Shares the bucket of the user's HTTP requests
+	w := rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusOK, w.Code)
+
+	allowed, retryAfter := middleware.AllowUser("user123")
+	assert.True(allowed)
+	assert.Zero(retryAfter)
+
+	allowed, retryAfter = middleware.AllowUser("user123")
+	assert.False(allowed)
+	assert.Equal(time.Second, retryAfter)
+
+	w = rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+}
+
+func TestRateLimitMiddleware_Sweep(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	middleware := newTestRateLimitMiddleware(&now)
+
+	rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Len(middleware.buckets, 1)
+
+	now = now.Add(RATE_LIMIT_SWEEP_INTERVAL)
+	rateLimitedRequest(middleware, userRequest("user456"))
+
+	// The idle bucket refilled and was dropped
+	assert.Len(middleware.buckets, 1)
+	assert.Contains(middleware.buckets, "user:user456")
+}
+
+func TestRateLimitMiddleware_Update(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	middleware := newTestRateLimitMiddleware(&now)
+
+	rateLimitedRequest(middleware, userRequest("user123"))
+	rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusTooManyRequests, rateLimitedRequest(middleware, userRequest("user123")).Code)
+
+	// A larger burst applies right away
+	middleware.Update(config.RateLimitConfig{RequestsPerMinute: 60, Burst: 5})
+	w := rateLimitedRequest(middleware, userRequest("user123"))
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal("5", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal("4", w.Header().Get("X-RateLimit-Remaining"))
+
+	// Disabled, requests go through without the headers
+	middleware.Update(config.RateLimitConfig{RequestsPerMinute: 0})
+	for range 10 {
+		w = rateLimitedRequest(middleware, userRequest("user123"))
+		assert.Equal(http.StatusOK, w.Code)
+		assert.Empty(w.Header().Get("X-RateLimit-Limit"))
+	}
+}
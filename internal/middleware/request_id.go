@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+)
+
+const REQUEST_ID_HEADER = "X-Request-ID"
+
+// validRequestID bounds the request IDs accepted from clients and proxies, which end up in logs and
+// sync events
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestID tags each request with an ID, the one sent in its X-Request-ID header when there is a
+// valid one, or a new one. The ID is returned in the X-Request-ID header of the response and put
+// in the request context, where the logs and the sync events started by the request pick it up
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(REQUEST_ID_HEADER)
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		w.Header().Set(REQUEST_ID_HEADER, requestID)
+		next.ServeHTTP(w, r.WithContext(requestcontext.ContextWithRequestID(r.Context(), requestID)))
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name            string
+		header          string
+		expectGenerated bool
+	}{
+		{name: "no request id", expectGenerated: true},
+		{name: "incoming request id", header: "abc-123_def.456"},
+		{name: "too long request id", header: strings.Repeat("a", 129), expectGenerated: true},
+		{name: "invalid request id", header: "abc 123\n", expectGenerated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var contextRequestID string
+			handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contextRequestID, _ = requestcontext.GetRequestIDFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/base_playlist", nil)
+			if tt.header != "" {
+				req.Header.Set(REQUEST_ID_HEADER, tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(REQUEST_ID_HEADER)
+			assert.Equal(requestID, contextRequestID)
+			if tt.expectGenerated {
+				assert.Len(requestID, 32)
+				assert.NotEqual(tt.header, requestID)
+			} else {
+				assert.Equal(tt.header, requestID)
+			}
+		})
+	}
+}
+
+func TestRequestID_GeneratesUniqueIDs(t *testing.T) {
+	assert := require.New(t)
+
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ids := map[string]bool{}
+	for range 10 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		ids[w.Header().Get(REQUEST_ID_HEADER)] = true
+	}
+
+	assert.Len(ids, 10)
+}
@@ -17,24 +17,32 @@ import (
 	spotifymocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
 	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/services"
 	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
 )
 
+// newTestSpotifyAuthMiddleware builds the middleware on a real token manager, so requests go
+// through the same refresh logic as in production
+func newTestSpotifyAuthMiddleware(spotifyIntegrationService *servicemocks.MockSpotifyIntegrationServicer, spotifyClient *spotifymocks.MockSpotifyAPI) *SpotifyAuthMiddleware {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tokenManager := services.NewSpotifyTokenManager(spotifyIntegrationService, spotifyClient, 15*time.Minute, logger)
+
+	return NewSpotifyAuthMiddleware(tokenManager, logger)
+}
+
 func TestNewSpotifyAuthMiddleware(t *testing.T) {
 	assert := require.New(t)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockSpotifyService := servicemocks.NewMockSpotifyIntegrationServicer(ctrl)
-	mockSpotifyClient := spotifymocks.NewMockSpotifyAPI(ctrl)
+	mockSpotifyAuth := servicemocks.NewMockSpotifyAuthProvider(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
-	middleware := NewSpotifyAuthMiddleware(mockSpotifyService, mockSpotifyClient, logger)
+	middleware := NewSpotifyAuthMiddleware(mockSpotifyAuth, logger)
 
 	assert.NotNil(middleware)
-	assert.Equal(mockSpotifyService, middleware.spotifyIntegrationService)
-	assert.Equal(mockSpotifyClient, middleware.spotifyClient)
+	assert.Equal(mockSpotifyAuth, middleware.spotifyAuth)
 	assert.NotNil(middleware.logger)
 }
 
@@ -68,9 +76,8 @@ func TestSpotifyAuthMiddleware_RequireSpotifyAuth_Success(t *testing.T) {
 
 			mockSpotifyService := servicemocks.NewMockSpotifyIntegrationServicer(ctrl)
 			mockSpotifyClient := spotifymocks.NewMockSpotifyAPI(ctrl)
-			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
-			middleware := NewSpotifyAuthMiddleware(mockSpotifyService, mockSpotifyClient, logger)
+			middleware := newTestSpotifyAuthMiddleware(mockSpotifyService, mockSpotifyClient)
 
 			// Create test user and integration
 			user := &models.User{ID: "user123"}
@@ -88,6 +95,10 @@ func TestSpotifyAuthMiddleware_RequireSpotifyAuth_Success(t *testing.T) {
 				GetIntegrationByUserID(gomock.Any(), "user123").
 				Return(integration, nil).
 				Times(1)
+			mockSpotifyService.EXPECT().
+				GetIntegrationByID(gomock.Any(), "integration123", "user123").
+				Return(integration, nil).
+				Times(1)
 
 			if tt.shouldRefresh {
 				// Mock token refresh
@@ -159,9 +170,8 @@ func TestSpotifyAuthMiddleware_RequireSpotifyAuth_TokenRefreshNoNewRefreshToken(
 
 	mockSpotifyIntegrationService := servicemocks.NewMockSpotifyIntegrationServicer(ctrl)
 	mockSpotifyClient := spotifymocks.NewMockSpotifyAPI(ctrl)
-	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
-	middleware := NewSpotifyAuthMiddleware(mockSpotifyIntegrationService, mockSpotifyClient, logger)
+	middleware := newTestSpotifyAuthMiddleware(mockSpotifyIntegrationService, mockSpotifyClient)
 
 	// Create test user and integration
 	user := &models.User{ID: "user123"}
@@ -179,6 +189,10 @@ func TestSpotifyAuthMiddleware_RequireSpotifyAuth_TokenRefreshNoNewRefreshToken(
 		GetIntegrationByUserID(gomock.Any(), "user123").
 		Return(integration, nil).
 		Times(1)
+	mockSpotifyIntegrationService.EXPECT().
+		GetIntegrationByID(gomock.Any(), "integration123", "user123").
+		Return(integration, nil).
+		Times(1)
 
 	// Mock token refresh without new refresh token
 	refreshResponse := &spotifyclient.SpotifyTokenResponse{
@@ -264,9 +278,8 @@ func TestSpotifyAuthMiddleware_RequireSpotifyAuth_Errors(t *testing.T) {
 
 			mockSpotifyIntegrationService := servicemocks.NewMockSpotifyIntegrationServicer(ctrl)
 			mockSpotifyClient := spotifymocks.NewMockSpotifyAPI(ctrl)
-			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
-			middleware := NewSpotifyAuthMiddleware(mockSpotifyIntegrationService, mockSpotifyClient, logger)
+			middleware := newTestSpotifyAuthMiddleware(mockSpotifyIntegrationService, mockSpotifyClient)
 
 			// Create request
 			req := httptest.NewRequest("GET", "/test", nil)
@@ -295,6 +308,10 @@ func TestSpotifyAuthMiddleware_RequireSpotifyAuth_Errors(t *testing.T) {
 						GetIntegrationByUserID(gomock.Any(), "user123").
 						Return(integration, nil).
 						Times(1)
+					mockSpotifyIntegrationService.EXPECT().
+						GetIntegrationByID(gomock.Any(), "integration123", "user123").
+						Return(integration, nil).
+						Times(1)
 
 					if tt.tokenRefreshError != nil {
 						mockSpotifyClient.EXPECT().
@@ -337,3 +354,49 @@ func TestSpotifyAuthMiddleware_RequireSpotifyAuth_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestSpotifyAuthMiddleware_RequireSpotifyAuth_SelectedAccount(t *testing.T) {
+	assert := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpotifyIntegrationService := servicemocks.NewMockSpotifyIntegrationServicer(ctrl)
+	mockSpotifyClient := spotifymocks.NewMockSpotifyAPI(ctrl)
+
+	middleware := newTestSpotifyAuthMiddleware(mockSpotifyIntegrationService, mockSpotifyClient)
+
+	integration := &models.SpotifyIntegration{
+		ID:           "integration456",
+		UserID:       "user123",
+		SpotifyID:    "spotify_dj",
+		AccessToken:  "access_token_456",
+		RefreshToken: "refresh_token_456",
+		ExpiresAt:    time.Now().Add(1 * time.Hour),
+	}
+
+	// The account picked by the header is read directly, the default one is never looked up
+	mockSpotifyIntegrationService.EXPECT().
+		GetIntegrationByID(gomock.Any(), "integration456", "user123").
+		Return(integration, nil).
+		Times(1)
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(SPOTIFY_ACCOUNT_HEADER, "integration456")
+	req = req.WithContext(requestcontext.ContextWithUser(req.Context(), &models.User{ID: "user123"}))
+
+	handlerCalled := false
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+		spotifyIntegration, ok := requestcontext.GetSpotifyAuthFromContext(r.Context())
+		assert.True(ok)
+		assert.Equal("spotify_dj", spotifyIntegration.SpotifyID)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	middleware.RequireSpotifyAuth(testHandler).ServeHTTP(w, req)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.True(handlerCalled)
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// AccountExport is every piece of data stored about a user, returned by the account data export.
+// Credentials such as spotify tokens, API key hashes and webhook secrets are left out
+type AccountExport struct {
+	ExportedAt             time.Time                `json:"exported_at"`
+	User                   *User                    `json:"user"`
+	SpotifyAccounts        []*SpotifyIntegration    `json:"spotify_accounts"`
+	BasePlaylists          []*BasePlaylistExport    `json:"base_playlists"`
+	SyncEvents             []*SyncEvent             `json:"sync_events"`
+	APIKeys                []*APIKey                `json:"api_keys"`
+	ChildPlaylistTemplates []*ChildPlaylistTemplate `json:"child_playlist_templates"`
+}
+
+// BasePlaylistExport is a base playlist along with the data configured for it
+type BasePlaylistExport struct {
+	*BasePlaylist
+	ChildPlaylists   []*ChildPlaylist   `json:"child_playlists"`
+	BlocklistEntries []*BlocklistEntry  `json:"blocklist_entries"`
+	Webhooks         []*PlaylistWebhook `json:"webhooks"`
+}
+
+// AccountDeletionReport summarizes an account deletion. Spotify playlists are only counted when
+// their deletion was requested
+type AccountDeletionReport struct {
+	UserID                  string `json:"user_id"`
+	SpotifyPlaylistsDeleted int    `json:"spotify_playlists_deleted"`
+	SpotifyPlaylistsFailed  int    `json:"spotify_playlists_failed"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// APIKeyScope limits which automation endpoints an API key can call
+type APIKeyScope string
+
+const (
+	// APIKeyScopeSyncRead allows polling the sync triggers, reading sync events and exporting routing configs
+	APIKeyScopeSyncRead APIKeyScope = "sync:read"
+	// APIKeyScopeSyncWrite allows triggering syncs
+	APIKeyScopeSyncWrite APIKeyScope = "sync:write"
+	// APIKeyScopeRulesWrite allows editing the filter rules of child playlists
+	APIKeyScopeRulesWrite APIKeyScope = "rules:write"
+)
+
+// APIKeyScopes lists every scope an API key can be granted
+var APIKeyScopes = []APIKeyScope{APIKeyScopeSyncRead, APIKeyScopeSyncWrite, APIKeyScopeRulesWrite}
+
+// APIKey authorizes automation platforms such as Zapier or IFTTT, and scripts or CI jobs calling the
+// API, to act on behalf of a user. Only a hash of the key is stored, the raw key is returned once
+// when the key is created
+type APIKey struct {
+	ID         string        `json:"id"`
+	UserID     string        `json:"user_id" validate:"required"`
+	Name       string        `json:"name" validate:"required,min=1,max=100"`
+	KeyHash    string        `json:"-"`
+	KeyPrefix  string        `json:"key_prefix"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"` // nil when the key never expires
+	Created    time.Time     `json:"created"`
+	Updated    time.Time     `json:"updated"`
+}
+
+// HasScope reports whether the key was granted the scope
+func (key *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, granted := range key.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsExpired reports whether the key expired by now
+func (key *APIKey) IsExpired(now time.Time) bool {
+	return key.ExpiresAt != nil && !now.Before(*key.ExpiresAt)
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string        `json:"name" validate:"required,min=1,max=100"`
+	Scopes    []APIKeyScope `json:"scopes" validate:"required,min=1,dive,oneof=sync:read sync:write rules:write"`
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+}
+
+// CreatedAPIKey is returned when a key is created, the only time the raw key is available
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// AppEventType identifies what changed in an event pushed to the open app connections of a user
+type AppEventType string
+
+const (
+	// AppEventSyncProgress carries the sync event of a sync that started, moved to another phase or
+	// finished
+	AppEventSyncProgress    AppEventType = "sync.progress"
+	AppEventPlaylistCreated AppEventType = "playlist.created"
+	AppEventPlaylistUpdated AppEventType = "playlist.updated"
+	AppEventPlaylistDeleted AppEventType = "playlist.deleted"
+)
+
+// PlaylistKind tells base playlists apart from child playlists in playlist events
+type PlaylistKind string
+
+const (
+	PlaylistKindBase  PlaylistKind = "base"
+	PlaylistKindChild PlaylistKind = "child"
+)
+
+// AppEvent is pushed to the app so it can update without polling. Only the field of its type is set
+type AppEvent struct {
+	Type      AppEventType    `json:"type"`
+	SyncEvent *SyncEvent      `json:"sync_event,omitempty"`
+	Playlist  *PlaylistChange `json:"playlist,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// PlaylistChange identifies the playlist of a playlist event, along with its state after the change
+// unless it was deleted
+type PlaylistChange struct {
+	Kind           PlaylistKind   `json:"kind"`
+	ID             string         `json:"id"`
+	BasePlaylistID string         `json:"base_playlist_id,omitempty"` // Set for child playlists
+	BasePlaylist   *BasePlaylist  `json:"base_playlist,omitempty"`
+	ChildPlaylist  *ChildPlaylist `json:"child_playlist,omitempty"`
+}
+
+func NewSyncProgressEvent(syncEvent *SyncEvent) *AppEvent {
+	return &AppEvent{Type: AppEventSyncProgress, SyncEvent: syncEvent}
+}
+
+func NewBasePlaylistEvent(eventType AppEventType, basePlaylist *BasePlaylist) *AppEvent {
+	return &AppEvent{
+		Type: eventType,
+		Playlist: &PlaylistChange{
+			Kind:         PlaylistKindBase,
+			ID:           basePlaylist.ID,
+			BasePlaylist: basePlaylist,
+		},
+	}
+}
+
+func NewChildPlaylistEvent(eventType AppEventType, childPlaylist *ChildPlaylist) *AppEvent {
+	return &AppEvent{
+		Type: eventType,
+		Playlist: &PlaylistChange{
+			Kind:           PlaylistKindChild,
+			ID:             childPlaylist.ID,
+			BasePlaylistID: childPlaylist.BasePlaylistID,
+			ChildPlaylist:  childPlaylist,
+		},
+	}
+}
+
+// NewPlaylistDeletedEvent reports a deleted playlist. Deleting a base playlist deletes its child
+// playlists with it, without an event of their own
+func NewPlaylistDeletedEvent(kind PlaylistKind, id, basePlaylistID string) *AppEvent {
+	return &AppEvent{
+		Type: AppEventPlaylistDeleted,
+		Playlist: &PlaylistChange{
+			Kind:           kind,
+			ID:             id,
+			BasePlaylistID: basePlaylistID,
+		},
+	}
+}
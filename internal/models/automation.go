@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MAX_AUTOMATION_TRIGGER_ITEMS bounds how many items a polling trigger returns, newest first
+const MAX_AUTOMATION_TRIGGER_ITEMS = 50
+
+// SyncCompletedTrigger is an item of the sync completed polling trigger. Automation platforms
+// deduplicate items by ID, so it never changes for the same sync
+type SyncCompletedTrigger struct {
+	ID               string    `json:"id"`
+	SyncEventID      string    `json:"sync_event_id"`
+	BasePlaylistID   string    `json:"base_playlist_id"`
+	BasePlaylistName string    `json:"base_playlist_name"`
+	TracksProcessed  int       `json:"tracks_processed"`
+	TracksUnmatched  int       `json:"tracks_unmatched"`
+	CompletedAt      time.Time `json:"completed_at"`
+}
+
+// TracksRoutedTrigger is an item of the tracks routed polling trigger, one per child playlist
+// that received new tracks in a sync
+type TracksRoutedTrigger struct {
+	ID                string    `json:"id"`
+	SyncEventID       string    `json:"sync_event_id"`
+	BasePlaylistID    string    `json:"base_playlist_id"`
+	ChildPlaylistID   string    `json:"child_playlist_id"`
+	ChildPlaylistName string    `json:"child_playlist_name"`
+	TracksAdded       int       `json:"tracks_added"`
+	TracksRemoved     int       `json:"tracks_removed"`
+	RoutedAt          time.Time `json:"routed_at"`
+}
+
+// ExclusionField is a set filter of the child playlist rules an automation can add exclusions to
+type ExclusionField string
+
+const (
+	ExclusionFieldGenres         ExclusionField = "genres"
+	ExclusionFieldTrackKeywords  ExclusionField = "track_keywords"
+	ExclusionFieldArtistKeywords ExclusionField = "artist_keywords"
+)
+
+type TriggerSyncActionRequest struct {
+	BasePlaylistID string `json:"base_playlist_id" validate:"required"`
+}
+
+type AddExclusionActionRequest struct {
+	ChildPlaylistID string         `json:"child_playlist_id" validate:"required"`
+	Field           ExclusionField `json:"field" validate:"required,oneof=genres track_keywords artist_keywords"`
+	Value           string         `json:"value" validate:"required,min=1,max=100"`
+}
+
+// WithExclusion returns a copy of the rules that also excludes the value in the given set filter.
+// The rules are returned unchanged when the value is already excluded
+func (rules *MetadataFilters) WithExclusion(field ExclusionField, value string) (*MetadataFilters, error) {
+	updated := &MetadataFilters{}
+	if rules != nil {
+		*updated = *rules
+	}
+
+	var setFilter **SetFilter
+	switch field {
+	case ExclusionFieldGenres:
+		setFilter = &updated.Genres
+	case ExclusionFieldTrackKeywords:
+		setFilter = &updated.TrackKeywords
+	case ExclusionFieldArtistKeywords:
+		setFilter = &updated.ArtistKeywords
+	default:
+		return nil, fmt.Errorf("unsupported exclusion field %q", field)
+	}
+
+	current := &SetFilter{}
+	if *setFilter != nil {
+		current = *setFilter
+	}
+
+	if slices.ContainsFunc(current.Exclude, func(excluded string) bool { return strings.EqualFold(excluded, value) }) {
+		return updated, nil
+	}
+
+	*setFilter = &SetFilter{
+		Include: current.Include,
+		Exclude: append(slices.Clone(current.Exclude), value),
+	}
+
+	return updated, nil
+}
@@ -2,14 +2,38 @@ package models
 
 import "time"
 
+// DedupeStrategy controls how a track matching several child playlists is routed
+type DedupeStrategy string
+
+const (
+	// DedupeStrategyAllMatches routes a track to every child playlist it matches
+	DedupeStrategyAllMatches DedupeStrategy = "all_matches"
+	// DedupeStrategyFirstMatch routes a track only to the highest-priority child playlist it matches
+	DedupeStrategyFirstMatch DedupeStrategy = "first_match"
+)
+
 type BasePlaylist struct {
-	ID                string    `json:"id"`
-	UserID            string    `json:"user_id" validate:"required"`
-	Name              string    `json:"name" validate:"required,min=1,max=100"`
-	SpotifyPlaylistID string    `json:"spotify_playlist_id" validate:"required"`
-	IsActive          bool      `json:"is_active"`
-	Created           time.Time `json:"created"`
-	Updated           time.Time `json:"updated"`
+	ID                string         `json:"id"`
+	UserID            string         `json:"user_id" validate:"required"`
+	Name              string         `json:"name" validate:"required,min=1,max=100"`
+	SpotifyPlaylistID string         `json:"spotify_playlist_id" validate:"required"`
+	IsActive          bool           `json:"is_active"`
+	DedupeStrategy    DedupeStrategy `json:"dedupe_strategy"`
+	HookToken         string         `json:"hook_token,omitempty"` // Authorizes the automation hooks, empty when disabled
+	Suspended         bool           `json:"suspended"`            // Deactivated by a spotify disconnect, reactivated when the account is re-linked
+	Archived          bool           `json:"archived"`             // Left out of bulk and automated syncs, keeping its children and history
+	// SpotifyIntegrationID is the linked spotify account the playlist lives in, empty for the user's default account
+	SpotifyIntegrationID string        `json:"spotify_integration_id,omitempty"`
+	Provider             MusicProvider `json:"provider"` // Streaming service the playlist lives in
+	Created              time.Time     `json:"created"`
+	Updated              time.Time     `json:"updated"`
+}
+
+// CloneBasePlaylistRequest copies a base playlist and its child playlists onto another spotify playlist
+type CloneBasePlaylistRequest struct {
+	SpotifyPlaylistID string `json:"spotify_playlist_id" validate:"required"`
+	// Name of the clone, the name of the base playlist cloned when empty
+	Name string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
 }
 
 type BasePlaylistWithChilds struct {
@@ -18,6 +42,21 @@ type BasePlaylistWithChilds struct {
 }
 
 type CreateBasePlaylistRequest struct {
-	Name              string `json:"name" validate:"required,min=1,max=100"`
-	SpotifyPlaylistID string `json:"spotify_playlist_id"`
+	Name              string         `json:"name" validate:"required,min=1,max=100"`
+	SpotifyPlaylistID string         `json:"spotify_playlist_id"`
+	DedupeStrategy    DedupeStrategy `json:"dedupe_strategy,omitempty" validate:"omitempty,oneof=all_matches first_match"`
+	// SpotifyIntegrationID picks the linked spotify account of the playlist, the default account when empty
+	SpotifyIntegrationID string `json:"spotify_integration_id,omitempty"`
+	// Provider is the streaming service of the playlist, spotify when empty. youtube_music and tidal
+	// playlists live in the linked account of that service, SpotifyPlaylistID then being its playlist ID
+	Provider MusicProvider `json:"provider,omitempty" validate:"omitempty,oneof=spotify youtube_music tidal"`
+}
+
+// UpdateBasePlaylistRequest changes the fields that are set, leaving the others as they are
+type UpdateBasePlaylistRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	IsActive *bool   `json:"is_active,omitempty"`
+	// SpotifyPlaylistID links another playlist of the spotify account of the base playlist
+	SpotifyPlaylistID *string         `json:"spotify_playlist_id,omitempty" validate:"omitempty,min=1"`
+	DedupeStrategy    *DedupeStrategy `json:"dedupe_strategy,omitempty" validate:"omitempty,oneof=all_matches first_match"`
 }
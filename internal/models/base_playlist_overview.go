@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// BasePlaylistOverview gathers what the dashboard shows about a base playlist
+type BasePlaylistOverview struct {
+	BasePlaylistID  string              `json:"base_playlist_id"`
+	TrackCount      int                 `json:"track_count"` // Tracks in the spotify playlist, or processed by the last sync when Spotify can't be read
+	ChildPlaylists  ChildPlaylistCounts `json:"child_playlists"`
+	UnmatchedTracks int                 `json:"unmatched_tracks"` // Tracks the last completed sync routed to no child playlist
+	LastSync        *SyncSummary        `json:"last_sync,omitempty"`
+	LastCompletedAt *time.Time          `json:"last_completed_at,omitempty"`
+}
+
+type ChildPlaylistCounts struct {
+	Total    int `json:"total"`
+	Active   int `json:"active"`
+	Inactive int `json:"inactive"` // Deactivated by the user or suspended by a spotify disconnect
+}
+
+// SyncSummary is the status of a sync without its statistics
+type SyncSummary struct {
+	ID           string     `json:"id"`
+	Status       SyncStatus `json:"status"`
+	StartedAt    time.Time  `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+type BlocklistEntryType string
+
+const (
+	// BlocklistEntryTypeTrack blocks a single track, the value is its Spotify track URI
+	BlocklistEntryTypeTrack BlocklistEntryType = "track"
+	// BlocklistEntryTypeArtist blocks every track of an artist, the value is its Spotify artist ID
+	BlocklistEntryTypeArtist BlocklistEntryType = "artist"
+)
+
+// BlocklistEntry keeps a track or artist of a base playlist out of all its child playlists,
+// whatever their filter rules
+type BlocklistEntry struct {
+	ID             string             `json:"id"`
+	UserID         string             `json:"user_id" validate:"required"`
+	BasePlaylistID string             `json:"base_playlist_id" validate:"required"`
+	Type           BlocklistEntryType `json:"type" validate:"required,oneof=track artist"`
+	Value          string             `json:"value" validate:"required"`
+	Created        time.Time          `json:"created"`
+	Updated        time.Time          `json:"updated"`
+}
+
+type CreateBlocklistEntryRequest struct {
+	Type  BlocklistEntryType `json:"type" validate:"required,oneof=track artist"`
+	Value string             `json:"value" validate:"required,max=255"`
+}
+
+// Blocklist answers whether a track is blocked from the child playlists of a base playlist
+type Blocklist struct {
+	trackURIs map[string]struct{}
+	artistIDs map[string]struct{}
+}
+
+func NewBlocklist(entries []*BlocklistEntry) *Blocklist {
+	blocklist := &Blocklist{
+		trackURIs: make(map[string]struct{}),
+		artistIDs: make(map[string]struct{}),
+	}
+
+	for _, entry := range entries {
+		switch entry.Type {
+		case BlocklistEntryTypeTrack:
+			blocklist.trackURIs[entry.Value] = struct{}{}
+		case BlocklistEntryTypeArtist:
+			blocklist.artistIDs[entry.Value] = struct{}{}
+		}
+	}
+
+	return blocklist
+}
+
+func (b *Blocklist) IsEmpty() bool {
+	return len(b.trackURIs) == 0 && len(b.artistIDs) == 0
+}
+
+// Blocks reports whether the track or any of its artists is blocked
+func (b *Blocklist) Blocks(track TrackInfo) bool {
+	if _, ok := b.trackURIs[track.URI]; ok {
+		return true
+	}
+
+	return slices.ContainsFunc(track.Artists, func(artistID string) bool {
+		_, ok := b.artistIDs[artistID]
+		return ok
+	})
+}
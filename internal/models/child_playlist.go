@@ -5,30 +5,126 @@ import (
 	"time"
 )
 
+// SelectionStrategy picks which matching tracks a size capped child playlist keeps
+type SelectionStrategy string
+
+const (
+	// SelectionMostPopular keeps the tracks with the highest Spotify popularity
+	SelectionMostPopular SelectionStrategy = "most_popular"
+	// SelectionNewest keeps the most recently released tracks
+	SelectionNewest SelectionStrategy = "newest"
+	// SelectionRandom keeps a random sample, drawn again on every sync
+	SelectionRandom SelectionStrategy = "random"
+	// SelectionMostPlayed keeps the tracks the user played the most according to their Last.fm
+	// scrobbles, tracks without a play count rank last
+	SelectionMostPlayed SelectionStrategy = "most_played"
+)
+
+// SyncStrategy is how a sync writes the routed tracks to the Spotify playlist of a child
+type SyncStrategy string
+
+const (
+	// SyncStrategyRecreate deletes the Spotify playlist and creates a new one on every sync, the
+	// playlist ID changes each time. Children created before in place syncing have no strategy
+	// stored and sync this way.
+	SyncStrategyRecreate SyncStrategy = "recreate"
+	// SyncStrategyInPlace replaces the tracks of the same Spotify playlist, keeping its ID,
+	// followers and cover
+	SyncStrategyInPlace SyncStrategy = "in_place"
+)
+
+// MAX_SOURCE_BASE_PLAYLISTS bounds the other base playlists merged into a child playlist
+const MAX_SOURCE_BASE_PLAYLISTS = 10
+
+// MAX_PINNED_TRACKS bounds the tracks pinned to a child playlist
+const MAX_PINNED_TRACKS = 100
+
 type ChildPlaylist struct {
-	ID                string               `json:"id"`
-	UserID            string               `json:"user_id" validate:"required"`
-	BasePlaylistID    string               `json:"base_playlist_id" validate:"required"`
-	Name              string               `json:"name" validate:"required,min=1,max=100"`
-	Description       string               `json:"description,omitempty"`
-	SpotifyPlaylistID string               `json:"spotify_playlist_id" validate:"required"`
-	FilterRules       *AudioFeatureFilters `json:"filter_rules,omitempty"`
-	IsActive          bool                 `json:"is_active"`
-	Created           time.Time            `json:"created"`
-	Updated           time.Time            `json:"updated"`
+	ID                    string               `json:"id"`
+	UserID                string               `json:"user_id" validate:"required"`
+	BasePlaylistID        string               `json:"base_playlist_id" validate:"required"`
+	Name                  string               `json:"name" validate:"required,min=1,max=100"`
+	Description           string               `json:"description,omitempty"`
+	SpotifyPlaylistID     string               `json:"spotify_playlist_id" validate:"required"`
+	FilterRules           *AudioFeatureFilters `json:"filter_rules,omitempty"`
+	IsActive              bool                 `json:"is_active"`
+	IsFallback            bool                 `json:"is_fallback"`
+	Priority              int                  `json:"priority"`
+	ShareToken            string               `json:"share_token,omitempty"` // Grants public access to the playlist widget, empty when not shared
+	MaxTracks             int                  `json:"max_tracks,omitempty"`  // Caps the tracks routed to the playlist, 0 when unlimited
+	SelectionStrategy     SelectionStrategy    `json:"selection_strategy,omitempty"`
+	SyncStrategy          SyncStrategy         `json:"sync_strategy,omitempty"`
+	SourceBasePlaylistIDs []string             `json:"source_base_playlist_ids,omitempty"` // Other base playlists merged into the child, empty for regular child playlists
+	PinnedTracks          []string             `json:"pinned_tracks,omitempty"`            // Track URIs always synced to the child, whatever its filter rules
+	RefollowRecreated     bool                 `json:"refollow_recreated"`                 // Follows the new Spotify playlist publicly each time a recreate sync replaces it
+	Suspended             bool                 `json:"suspended"`                          // Deactivated by a spotify disconnect, reactivated when the account is re-linked
+	SpotifyIntegrationID  string               `json:"spotify_integration_id,omitempty"`   // Linked spotify account the playlist lives in, empty for the account of its base playlist
+	Provider              MusicProvider        `json:"provider"`                           // Streaming service the playlist lives in, the one of its base playlist
+	Created               time.Time            `json:"created"`
+	Updated               time.Time            `json:"updated"`
 }
 
 type CreateChildPlaylistRequest struct {
 	Name        string               `json:"name" validate:"required,min=1,max=100"`
 	Description string               `json:"description,omitempty"`
 	FilterRules *AudioFeatureFilters `json:"filter_rules,omitempty"`
+	IsFallback  bool                 `json:"is_fallback,omitempty"`
+	MaxTracks   int                  `json:"max_tracks,omitempty" validate:"omitempty,min=1,max=10000"`
+	// SelectionStrategy defaults to most_popular when max_tracks is set
+	SelectionStrategy SelectionStrategy `json:"selection_strategy,omitempty" validate:"omitempty,oneof=most_popular newest random most_played"`
+	// SourceBasePlaylistIDs turns the child into a merge playlist, fed by these base playlists too
+	SourceBasePlaylistIDs []string `json:"source_base_playlist_ids,omitempty" validate:"omitempty,max=10,dive,required"`
+	// RefollowRecreated follows the playlist again each time a recreate sync replaces it
+	RefollowRecreated bool `json:"refollow_recreated,omitempty"`
+	// SpotifyIntegrationID picks the linked spotify account of the playlist, the account of the base playlist when empty
+	SpotifyIntegrationID string `json:"spotify_integration_id,omitempty"`
 }
 
 type UpdateChildPlaylistRequest struct {
-	Name        *string              `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
-	Description *string              `json:"description,omitempty"`
-	FilterRules *AudioFeatureFilters `json:"filter_rules,omitempty"`
-	IsActive    *bool                `json:"is_active,omitempty"`
+	Name              *string              `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description       *string              `json:"description,omitempty"`
+	FilterRules       *AudioFeatureFilters `json:"filter_rules,omitempty"`
+	IsActive          *bool                `json:"is_active,omitempty"`
+	IsFallback        *bool                `json:"is_fallback,omitempty"`
+	MaxTracks         *int                 `json:"max_tracks,omitempty" validate:"omitempty,min=0,max=10000"` // 0 removes the cap
+	SelectionStrategy *SelectionStrategy   `json:"selection_strategy,omitempty" validate:"omitempty,oneof=most_popular newest random most_played"`
+	// An empty list stops merging other base playlists into the child
+	SourceBasePlaylistIDs *[]string `json:"source_base_playlist_ids,omitempty" validate:"omitempty,max=10,dive,required"`
+	RefollowRecreated     *bool     `json:"refollow_recreated,omitempty"`
+	// Updated of the child playlist the edit started from, the update is refused with a conflict when it
+	// was modified since. The If-Match header takes precedence
+	ExpectedUpdated *time.Time `json:"expected_updated,omitempty"`
+}
+
+// ReorderChildPlaylistsRequest lists every child playlist of a base playlist from highest to lowest routing priority
+type ReorderChildPlaylistsRequest struct {
+	ChildPlaylistIDs []string `json:"child_playlist_ids" validate:"required,min=1,dive,required"`
+}
+
+// PinTracksRequest pins tracks to a child playlist, by Spotify track URI
+type PinTracksRequest struct {
+	TrackURIs []string `json:"track_uris" validate:"required,min=1,max=100,dive,required,startswith=spotify:track:"`
+}
+
+// ToCreateRequest builds the request creating a copy of the child playlist definition, with its own
+// spotify playlist. Pinned tracks, sharing and the sync strategy aren't copied
+func (c *ChildPlaylist) ToCreateRequest() *CreateChildPlaylistRequest {
+	return &CreateChildPlaylistRequest{
+		Name:                  c.Name,
+		Description:           c.Description,
+		FilterRules:           c.FilterRules,
+		IsFallback:            c.IsFallback,
+		MaxTracks:             c.MaxTracks,
+		SelectionStrategy:     c.SelectionStrategy,
+		SourceBasePlaylistIDs: c.SourceBasePlaylistIDs,
+		RefollowRecreated:     c.RefollowRecreated,
+		SpotifyIntegrationID:  c.SpotifyIntegrationID,
+	}
+}
+
+// IsMerge reports whether the child playlist is fed by other base playlists besides its own
+func (c *ChildPlaylist) IsMerge() bool {
+	return len(c.SourceBasePlaylistIDs) > 0
 }
 
 func BuildChildPlaylistName(basePlaylistName, childPlaylistName string) string {
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// CHILD_PLAYLIST_STATS_TOP_SIZE bounds the genres and artists listed in child playlist stats
+const CHILD_PLAYLIST_STATS_TOP_SIZE = 10
+
+// ChildPlaylistStats summarizes a child playlist and how it routes the current tracks of its base
+// playlist, merged sources included
+type ChildPlaylistStats struct {
+	ChildPlaylistID string      `json:"child_playlist_id"`
+	TrackCount      int         `json:"track_count"` // Tracks in the spotify playlist, or routed by the last sync when Spotify can't be read
+	LastSyncedAt    *time.Time  `json:"last_synced_at,omitempty"`
+	BaseTrackCount  int         `json:"base_track_count"`
+	MatchedTracks   int         `json:"matched_tracks"` // Base playlist tracks a sync would route to the child now
+	MatchRate       float64     `json:"match_rate"`     // MatchedTracks over BaseTrackCount, 0 for an empty base playlist
+	TopGenres       []StatCount `json:"top_genres"`     // Most frequent genres of the matched tracks
+	TopArtists      []StatCount `json:"top_artists"`    // Most frequent artists of the matched tracks
+	ComputedAt      time.Time   `json:"computed_at"`
+}
+
+// StatCount is a value along with the number of tracks having it
+type StatCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Built-in child playlist templates, available to every user and not stored in the database
+const (
+	TemplateIDWorkout  = "builtin_workout"
+	TemplateIDChill    = "builtin_chill"
+	TemplateIDByDecade = "builtin_by_decade"
+)
+
+// ChildPlaylistTemplate is a reusable set of child playlists that can be instantiated against any
+// base playlist. Built-in templates have no user
+type ChildPlaylistTemplate struct {
+	ID          string          `json:"id"`
+	UserID      string          `json:"user_id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Children    []TemplateChild `json:"children"`
+	BuiltIn     bool            `json:"built_in"`
+	Created     time.Time       `json:"created"`
+	Updated     time.Time       `json:"updated"`
+}
+
+// TemplateChild is a child playlist created when a template is instantiated
+type TemplateChild struct {
+	Name              string            `json:"name" validate:"required,min=1,max=100"`
+	Description       string            `json:"description,omitempty"`
+	FilterRules       *MetadataFilters  `json:"filter_rules,omitempty"`
+	IsFallback        bool              `json:"is_fallback,omitempty"`
+	MaxTracks         int               `json:"max_tracks,omitempty" validate:"omitempty,min=1,max=10000"`
+	SelectionStrategy SelectionStrategy `json:"selection_strategy,omitempty" validate:"omitempty,oneof=most_popular newest random most_played"`
+}
+
+// ToCreateRequest builds the request creating the child playlist of the template
+func (c TemplateChild) ToCreateRequest() *CreateChildPlaylistRequest {
+	return &CreateChildPlaylistRequest{
+		Name:              c.Name,
+		Description:       c.Description,
+		FilterRules:       c.FilterRules,
+		IsFallback:        c.IsFallback,
+		MaxTracks:         c.MaxTracks,
+		SelectionStrategy: c.SelectionStrategy,
+	}
+}
+
+type CreateChildPlaylistTemplateRequest struct {
+	Name        string          `json:"name" validate:"required,min=1,max=100"`
+	Description string          `json:"description,omitempty"`
+	Children    []TemplateChild `json:"children" validate:"required,min=1,max=20,dive"`
+}
+
+type InstantiateChildPlaylistTemplateRequest struct {
+	BasePlaylistID string `json:"base_playlist_id" validate:"required"`
+}
+
+// BuiltInChildPlaylistTemplates returns the templates shipped with the router. A fresh copy is built
+// on every call, so callers are free to modify the filter rules
+func BuiltInChildPlaylistTemplates() []*ChildPlaylistTemplate {
+	return []*ChildPlaylistTemplate{
+		{
+			ID:          TemplateIDWorkout,
+			Name:        "Workout (high energy)",
+			Description: "Splits high energy, fast tracks from the ones to cool down with",
+			BuiltIn:     true,
+			Children: []TemplateChild{
+				{
+					Name:        "Workout",
+					Description: "High energy tracks at a running tempo",
+					FilterRules: &MetadataFilters{
+						Energy: &RangeFilter{Min: floatPtr(0.7)},
+						Tempo:  &RangeFilter{Min: floatPtr(120)},
+					},
+				},
+				{
+					Name:        "Cool Down",
+					Description: "Calm tracks to end the session",
+					FilterRules: &MetadataFilters{
+						Energy: &RangeFilter{Max: floatPtr(0.4)},
+					},
+				},
+			},
+		},
+		{
+			ID:          TemplateIDChill,
+			Name:        "Chill (low energy)",
+			Description: "Collects the relaxed, low energy tracks",
+			BuiltIn:     true,
+			Children: []TemplateChild{
+				{
+					Name:        "Chill",
+					Description: "Low energy tracks",
+					FilterRules: &MetadataFilters{
+						Energy: &RangeFilter{Max: floatPtr(0.4)},
+					},
+				},
+				{
+					Name:        "Acoustic",
+					Description: "Mostly acoustic, mellow tracks",
+					FilterRules: &MetadataFilters{
+						Acousticness: &RangeFilter{Min: floatPtr(0.6)},
+						Energy:       &RangeFilter{Max: floatPtr(0.5)},
+					},
+				},
+			},
+		},
+		{
+			ID:          TemplateIDByDecade,
+			Name:        "By decade",
+			Description: "One child playlist per release decade, from the 70s on",
+			BuiltIn:     true,
+			Children:    decadeTemplateChildren(1970, 2020),
+		},
+	}
+}
+
+// decadeTemplateChildren builds a child playlist for each decade from the first to the last, inclusive
+func decadeTemplateChildren(first, last int) []TemplateChild {
+	children := make([]TemplateChild, 0, (last-first)/10+1)
+	for decade := first; decade <= last; decade += 10 {
+		children = append(children, TemplateChild{
+			Name:        decadeName(decade),
+			Description: fmt.Sprintf("Tracks released between %d and %d", decade, decade+9),
+			FilterRules: &MetadataFilters{
+				ReleaseYear: &RangeFilter{Min: floatPtr(float64(decade)), Max: floatPtr(float64(decade + 9))},
+			},
+		})
+	}
+
+	return children
+}
+
+func decadeName(decade int) string {
+	if decade < 2000 {
+		return fmt.Sprintf("%ds", decade%100)
+	}
+
+	return fmt.Sprintf("%ds", decade)
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
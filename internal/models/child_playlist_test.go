@@ -1,8 +1,10 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -112,3 +114,332 @@ func TestBuildChildPlaylistDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataFilters_Validate(t *testing.T) {
+	low, high := 5.0, 10.0
+	rock := &MetadataFilters{Genres: &SetFilter{Include: []string{"rock"}}}
+	afterDate, beforeDate, malformedDate := "2020-01-01", "2024-12-31", "2020/01/01"
+	decade, notDecade, zero := 2020, 2025, 0
+	longPreset, unknownPreset := DurationPresetLong, DurationPreset("epic")
+	twoMinutes, fourMinutes := FilterDuration(2*time.Minute), FilterDuration(4*time.Minute)
+
+	nested := rock
+	for range MAX_FILTER_RULE_DEPTH {
+		nested = &MetadataFilters{Not: nested}
+	}
+
+	tests := []struct {
+		name          string
+		filters       *MetadataFilters
+		expectedError string
+	}{
+		{
+			name:    "flat rules",
+			filters: &MetadataFilters{Popularity: &RangeFilter{Min: &low, Max: &high}},
+		},
+		{
+			name:    "composed rules",
+			filters: &MetadataFilters{Or: []*MetadataFilters{rock, {Not: rock}}},
+		},
+		{
+			name:          "inverted range",
+			filters:       &MetadataFilters{Popularity: &RangeFilter{Min: &high, Max: &low}},
+			expectedError: "filter_rules.popularity: min can not be greater than max",
+		},
+		{
+			name:          "inverted range in a group",
+			filters:       &MetadataFilters{And: []*MetadataFilters{{Tempo: &RangeFilter{Min: &high, Max: &low}}}},
+			expectedError: "filter_rules.and[0].tempo: min can not be greater than max",
+		},
+		{
+			name:          "empty or group",
+			filters:       &MetadataFilters{Or: []*MetadataFilters{rock, {}}},
+			expectedError: "filter_rules.or[1]: group must contain at least one condition",
+		},
+		{
+			name:          "nil and group",
+			filters:       &MetadataFilters{And: []*MetadataFilters{nil}},
+			expectedError: "filter_rules.and[0]: group must contain at least one condition",
+		},
+		{
+			name:          "empty not group",
+			filters:       &MetadataFilters{Not: &MetadataFilters{}},
+			expectedError: "filter_rules.not: group must contain at least one condition",
+		},
+		{
+			name:    "release date rules",
+			filters: &MetadataFilters{ReleaseDate: &DateFilter{After: &afterDate, Before: &beforeDate, Decade: &decade}},
+		},
+		{
+			name:          "malformed release date bound",
+			filters:       &MetadataFilters{ReleaseDate: &DateFilter{After: &malformedDate}},
+			expectedError: "filter_rules.release_date: after must be a date formatted as YYYY-MM-DD",
+		},
+		{
+			name:          "inverted release date bounds",
+			filters:       &MetadataFilters{Or: []*MetadataFilters{{ReleaseDate: &DateFilter{After: &beforeDate, Before: &afterDate}}}},
+			expectedError: "filter_rules.or[0].release_date: after can not be later than before",
+		},
+		{
+			name:          "non positive relative window",
+			filters:       &MetadataFilters{ReleaseDate: &DateFilter{WithinDays: &zero}},
+			expectedError: "filter_rules.release_date: within_days must be positive",
+		},
+		{
+			name:          "decade not starting a decade",
+			filters:       &MetadataFilters{ReleaseDate: &DateFilter{Decade: &notDecade}},
+			expectedError: "filter_rules.release_date: decade must be the first year of a decade",
+		},
+		{
+			name:          "added date within non positive days",
+			filters:       &MetadataFilters{AddedDate: &DateFilter{WithinDays: &zero}},
+			expectedError: "filter_rules.added_date: within_days must be positive",
+		},
+		{
+			name: "name text rules",
+			filters: &MetadataFilters{
+				TrackName:  &TextFilter{Exclude: []TextPattern{{Type: TextMatchContains, Value: "remix"}}},
+				ArtistName: &TextFilter{Include: []TextPattern{{Type: TextMatchPrefix, Value: "the "}}},
+				AlbumName:  &TextFilter{Include: []TextPattern{{Type: TextMatchRegex, Value: `\blive\b`}}},
+			},
+		},
+		{
+			name:          "empty text filter",
+			filters:       &MetadataFilters{TrackName: &TextFilter{}},
+			expectedError: "filter_rules.track_name: include or exclude must contain at least one pattern",
+		},
+		{
+			name:          "unknown text match type",
+			filters:       &MetadataFilters{AlbumName: &TextFilter{Include: []TextPattern{{Type: "suffix", Value: "live"}}}},
+			expectedError: "filter_rules.album_name: include[0]: type must be one of contains, prefix or regex",
+		},
+		{
+			name:          "invalid regex",
+			filters:       &MetadataFilters{And: []*MetadataFilters{{TrackName: &TextFilter{Exclude: []TextPattern{{Type: TextMatchRegex, Value: "(remix"}}}}}},
+			expectedError: "filter_rules.and[0].track_name: exclude[0]: invalid regex",
+		},
+		{
+			name:          "empty pattern value",
+			filters:       &MetadataFilters{ArtistName: &TextFilter{Include: []TextPattern{{Type: TextMatchContains}}}},
+			expectedError: "filter_rules.artist_name: include[0]: value is required",
+		},
+		{
+			name:    "duration preset",
+			filters: &MetadataFilters{DurationRange: &DurationFilter{Preset: &longPreset}},
+		},
+		{
+			name:          "unknown duration preset",
+			filters:       &MetadataFilters{DurationRange: &DurationFilter{Preset: &unknownPreset}},
+			expectedError: "filter_rules.duration: preset must be one of short, standard or long",
+		},
+		{
+			name:          "duration preset with bounds",
+			filters:       &MetadataFilters{DurationRange: &DurationFilter{Preset: &longPreset, Max: &fourMinutes}},
+			expectedError: "filter_rules.duration: preset can not be combined with min or max",
+		},
+		{
+			name:          "inverted duration bounds",
+			filters:       &MetadataFilters{Or: []*MetadataFilters{{DurationRange: &DurationFilter{Min: &fourMinutes, Max: &twoMinutes}}}},
+			expectedError: "filter_rules.or[0].duration: min can not be greater than max",
+		},
+		{
+			name:          "duration with duration_ms",
+			filters:       &MetadataFilters{Duration: &RangeFilter{Min: &low}, DurationRange: &DurationFilter{Min: &twoMinutes}},
+			expectedError: "filter_rules: duration and duration_ms can not be combined",
+		},
+		{
+			name:          "too deeply nested",
+			filters:       nested,
+			expectedError: fmt.Sprintf("groups can not be nested more than %d levels deep", MAX_FILTER_RULE_DEPTH),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			err := tt.filters.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(err)
+				return
+			}
+			assert.ErrorContains(err, tt.expectedError)
+		})
+	}
+}
+
+func TestFilterDuration_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name             string
+		payload          string
+		expectedDuration time.Duration
+		expectedError    bool
+	}{
+		{name: "duration string", payload: `"3m30s"`, expectedDuration: 3*time.Minute + 30*time.Second},
+		{name: "seconds", payload: `210`, expectedDuration: 210 * time.Second},
+		{name: "fractional seconds", payload: `90.5`, expectedDuration: 90*time.Second + 500*time.Millisecond},
+		{name: "malformed string", payload: `"3 minutes"`, expectedError: true},
+		{name: "unsupported type", payload: `true`, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var duration FilterDuration
+			err := json.Unmarshal([]byte(tt.payload), &duration)
+
+			if tt.expectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expectedDuration, time.Duration(duration))
+		})
+	}
+}
+
+func TestMetadataFilters_ResolveDurations(t *testing.T) {
+	assert := require.New(t)
+
+	var filters MetadataFilters
+	err := json.Unmarshal([]byte(`{
+		"duration": {"min": "2m", "max": 300},
+		"or": [{"duration": {"preset": "short"}}, {"not": {"duration": {"preset": "long"}}}]
+	}`), &filters)
+	assert.NoError(err)
+
+	assert.NoError(filters.ResolveDurations())
+
+	assert.Nil(filters.DurationRange)
+	assert.Equal(120000.0, *filters.Duration.Min)
+	assert.Equal(300000.0, *filters.Duration.Max)
+	assert.Nil(filters.Or[0].Duration.Min)
+	assert.Equal(180000.0, *filters.Or[0].Duration.Max)
+	assert.Equal(300000.0, *filters.Or[1].Not.Duration.Min)
+	assert.Nil(filters.Or[1].Not.Duration.Max)
+
+	// Resolved rules only carry the millisecond range
+	resolved, err := json.Marshal(filters.Or[0])
+	assert.NoError(err)
+	assert.JSONEq(`{"duration_ms": {"max": 180000}}`, string(resolved))
+
+	invalid := &MetadataFilters{And: []*MetadataFilters{{DurationRange: &DurationFilter{}}}}
+	assert.ErrorContains(invalid.ResolveDurations(), "filter_rules.and[0].duration: preset, min or max is required")
+
+	var nilFilters *MetadataFilters
+	assert.NoError(nilFilters.ResolveDurations())
+}
+
+func TestDateFilter_Bounds(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 18, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	after, before := "2021-05-01", "2025-01-01"
+	days, years, decade := 10, 3, 2020
+
+	tests := []struct {
+		name         string
+		filter       *DateFilter
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{name: "open", filter: &DateFilter{}},
+		{name: "absolute", filter: &DateFilter{After: &after, Before: &before}, expectedFrom: date(2021, time.May, 1), expectedTo: date(2025, time.January, 1)},
+		{name: "last days", filter: &DateFilter{WithinDays: &days}, expectedFrom: date(2024, time.June, 5)},
+		{name: "last years", filter: &DateFilter{WithinYears: &years}, expectedFrom: date(2021, time.June, 15)},
+		{name: "decade", filter: &DateFilter{Decade: &decade}, expectedFrom: date(2020, time.January, 1), expectedTo: date(2029, time.December, 31)},
+		{
+			name:         "narrowest bounds win",
+			filter:       &DateFilter{After: &after, Before: &before, Decade: &decade, WithinYears: &years},
+			expectedFrom: date(2021, time.June, 15),
+			expectedTo:   date(2025, time.January, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			from, to := tt.filter.Bounds(now)
+
+			assert.Equal(tt.expectedFrom, from)
+			assert.Equal(tt.expectedTo, to)
+		})
+	}
+}
+
+func TestMetadataFilters_IsRelative(t *testing.T) {
+	after, days, decade := "2021-05-01", 10, 2020
+	relative := &MetadataFilters{ReleaseDate: &DateFilter{WithinDays: &days}}
+
+	tests := []struct {
+		name     string
+		filters  *MetadataFilters
+		expected bool
+	}{
+		{name: "no rules", filters: nil, expected: false},
+		{name: "absolute dates", filters: &MetadataFilters{AddedDate: &DateFilter{After: &after}, ReleaseDate: &DateFilter{Decade: &decade}}, expected: false},
+		{name: "date window", filters: relative, expected: true},
+		{name: "date window in or group", filters: &MetadataFilters{Or: []*MetadataFilters{{Explicit: new(bool)}, relative}}, expected: true},
+		{name: "date window in not group", filters: &MetadataFilters{Not: relative}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.filters.IsRelative())
+		})
+	}
+}
+
+func TestMetadataFilters_WithExclusion(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    *MetadataFilters
+		field    ExclusionField
+		value    string
+		expected *MetadataFilters
+	}{
+		{
+			name:     "no rules yet",
+			rules:    nil,
+			field:    ExclusionFieldGenres,
+			value:    "metal",
+			expected: &MetadataFilters{Genres: &SetFilter{Exclude: []string{"metal"}}},
+		},
+		{
+			name:     "keeps includes and other conditions",
+			rules:    &MetadataFilters{Popularity: &RangeFilter{}, TrackKeywords: &SetFilter{Include: []string{"live"}}},
+			field:    ExclusionFieldTrackKeywords,
+			value:    "remix",
+			expected: &MetadataFilters{Popularity: &RangeFilter{}, TrackKeywords: &SetFilter{Include: []string{"live"}, Exclude: []string{"remix"}}},
+		},
+		{
+			name:     "already excluded",
+			rules:    &MetadataFilters{ArtistKeywords: &SetFilter{Exclude: []string{"Nickelback"}}},
+			field:    ExclusionFieldArtistKeywords,
+			value:    "nickelback",
+			expected: &MetadataFilters{ArtistKeywords: &SetFilter{Exclude: []string{"Nickelback"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			result, err := tt.rules.WithExclusion(tt.field, tt.value)
+
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+
+	t.Run("unsupported field", func(t *testing.T) {
+		result, err := (&MetadataFilters{}).WithExclusion("popularity", "10")
+
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
@@ -0,0 +1,79 @@
+package models
+
+// DemoData is what the seed command provisions for local development: a user linked to the spotify
+// mock, with playlists to route and a history of syncs
+type DemoData struct {
+	User           *User            `json:"user"`
+	BasePlaylists  []*BasePlaylist  `json:"base_playlists"`
+	ChildPlaylists []*ChildPlaylist `json:"child_playlists"`
+	SyncEvents     []*SyncEvent     `json:"sync_events"`
+}
+
+// DemoChildPlaylists returns the child playlists seeded for each demo base playlist, the largest
+// base playlist first. Between them they cover the main kinds of filter rules
+func DemoChildPlaylists() [][]TemplateChild {
+	clean := false
+
+	return [][]TemplateChild{
+		{
+			{
+				Name:        "Dance Floor",
+				Description: "Danceable, high energy tracks",
+				FilterRules: &MetadataFilters{
+					Danceability: &RangeFilter{Min: floatPtr(0.6)},
+					Energy:       &RangeFilter{Min: floatPtr(0.6)},
+				},
+			},
+			{
+				Name:        "Late Night Jazz & Soul",
+				Description: "Jazz and soul artists",
+				FilterRules: &MetadataFilters{
+					Genres: &SetFilter{Include: []string{"jazz", "soul"}},
+				},
+			},
+			{
+				Name:        "Throwbacks",
+				Description: "Released before 2000",
+				FilterRules: &MetadataFilters{
+					ReleaseYear: &RangeFilter{Max: floatPtr(1999)},
+				},
+			},
+			{
+				Name:        "Chill",
+				Description: "The 25 most popular acoustic or slow tracks",
+				FilterRules: &MetadataFilters{
+					Or: []*MetadataFilters{
+						{Acousticness: &RangeFilter{Min: floatPtr(0.6)}},
+						{Tempo: &RangeFilter{Max: floatPtr(95)}},
+					},
+					Not: &MetadataFilters{Genres: &SetFilter{Include: []string{"hip hop"}}},
+				},
+				MaxTracks:         25,
+				SelectionStrategy: SelectionMostPopular,
+			},
+			{
+				Name:        "Everything Else",
+				Description: "Tracks no other child playlist took",
+				IsFallback:  true,
+			},
+		},
+		{
+			{
+				Name:        "Clean Favourites",
+				Description: "Favourites without explicit lyrics",
+				FilterRules: &MetadataFilters{
+					Explicit: &clean,
+				},
+			},
+			{
+				Name:        "Popular Favourites",
+				Description: "The 10 most recently added popular favourites",
+				FilterRules: &MetadataFilters{
+					Popularity: &RangeFilter{Min: floatPtr(50)},
+				},
+				MaxTracks:         10,
+				SelectionStrategy: SelectionNewest,
+			},
+		},
+	}
+}
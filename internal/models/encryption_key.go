@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// UserEncryptionKey is a per-user data key, stored wrapped by a versioned master key
+type UserEncryptionKey struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	WrappedKey string    `json:"-"`
+	KeyVersion int       `json:"key_version"`
+	Created    time.Time `json:"created"`
+	Updated    time.Time `json:"updated"`
+}
+
+// EncryptionKeyRotation is the audit record of a master key rotation run
+type EncryptionKeyRotation struct {
+	ID          string    `json:"id"`
+	ToVersion   int       `json:"to_version"`
+	KeysRotated int       `json:"keys_rotated"`
+	KeysFailed  int       `json:"keys_failed"`
+	Created     time.Time `json:"created"`
+}
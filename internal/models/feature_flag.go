@@ -0,0 +1,62 @@
+package models
+
+import (
+	"hash/fnv"
+	"slices"
+	"time"
+)
+
+// Features of the sync that can be rolled out gradually. Without a stored flag a feature is on for
+// everyone whenever the config enables it, a flag narrows it down to the users it is enabled for
+const (
+	// Re-syncs of unchanged base playlists reuse the cached routing, see SYNC_ROUTING_CACHE
+	FeatureIncrementalSync = "incremental_sync"
+	// Child playlists are written to spotify concurrently, see SYNC_CHILD_CONCURRENCY
+	FeatureConcurrentSync = "concurrent_sync"
+	// Large base playlists are synced as a stream of pages, see SYNC_STREAMING_MIN_TRACKS
+	FeatureStreamingSync = "streaming_sync"
+)
+
+// FeatureFlag turns a feature on for a share of the users, plus the users listed
+type FeatureFlag struct {
+	ID          string `json:"id"`
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	// A disabled flag is off for every user, the listed ones included
+	Enabled bool `json:"enabled"`
+	// RolloutPercentage is the share of users the flag is on for. Users are placed by a hash of their
+	// id, so raising it keeps the feature on for those who already had it
+	RolloutPercentage int       `json:"rollout_percentage"`
+	UserIDs           []string  `json:"user_ids"`
+	Created           time.Time `json:"created"`
+	Updated           time.Time `json:"updated"`
+}
+
+// EnabledFor reports whether the flag is on for the user
+func (f *FeatureFlag) EnabledFor(userID string) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	if slices.Contains(f.UserIDs, userID) {
+		return true
+	}
+
+	return rolloutBucket(f.Key, userID) < f.RolloutPercentage
+}
+
+// rolloutBucket places the user in one of 100 buckets. The flag key is part of the hash so the same
+// users aren't the first to get every feature
+func rolloutBucket(key, userID string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key + ":" + userID))
+	return int(hash.Sum32() % 100)
+}
+
+// UpdateFeatureFlagRequest creates the flag when it doesn't exist yet, disabled unless enabled is set
+type UpdateFeatureFlagRequest struct {
+	Description       *string   `json:"description,omitempty" validate:"omitempty,max=500"`
+	Enabled           *bool     `json:"enabled,omitempty"`
+	RolloutPercentage *int      `json:"rollout_percentage,omitempty" validate:"omitempty,min=0,max=100"`
+	UserIDs           *[]string `json:"user_ids,omitempty" validate:"omitempty,max=1000,dive,required,max=50"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlag_EnabledFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     FeatureFlag
+		userID   string
+		expected bool
+	}{
+		{name: "disabled", flag: FeatureFlag{Key: FeatureConcurrentSync, RolloutPercentage: 100, UserIDs: []string{"user123"}}, userID: "user123", expected: false},
+		{name: "listed user", flag: FeatureFlag{Key: FeatureConcurrentSync, Enabled: true, UserIDs: []string{"user123"}}, userID: "user123", expected: true},
+		{name: "no rollout", flag: FeatureFlag{Key: FeatureConcurrentSync, Enabled: true, UserIDs: []string{"user123"}}, userID: "user456", expected: false},
+		{name: "full rollout", flag: FeatureFlag{Key: FeatureConcurrentSync, Enabled: true, RolloutPercentage: 100}, userID: "user456", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.flag.EnabledFor(tt.userID))
+		})
+	}
+}
+
+func TestFeatureFlag_EnabledFor_Rollout(t *testing.T) {
+	assert := require.New(t)
+
+	flag := FeatureFlag{Key: FeatureIncrementalSync, Enabled: true, RolloutPercentage: 20}
+	enabledAt20 := map[string]bool{}
+	for i := range 1000 {
+		userID := fmt.Sprintf("user%d", i)
+		enabledAt20[userID] = flag.EnabledFor(userID)
+	}
+
+	count := 0
+	for _, enabled := range enabledAt20 {
+		if enabled {
+			count++
+		}
+	}
+	assert.InDelta(200, count, 50)
+
+	// Raising the rollout keeps the feature on for the users who had it
+	flag.RolloutPercentage = 50
+	for userID, enabled := range enabledAt20 {
+		if enabled {
+			assert.True(flag.EnabledFor(userID), userID)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+const DEFAULT_FILTER_PREVIEW_SAMPLE_SIZE = 20
+
+// FilterPreviewRequest holds filter rules to try against the tracks of a base playlist
+type FilterPreviewRequest struct {
+	FilterRules *MetadataFilters `json:"filter_rules" validate:"required"`
+	SampleSize  int              `json:"sample_size,omitempty" validate:"omitempty,min=1,max=100"` // Defaults to DEFAULT_FILTER_PREVIEW_SAMPLE_SIZE
+}
+
+// FilterPreview reports which tracks of a base playlist a set of filter rules would route to a
+// child playlist, before any child playlist is created or updated with them
+type FilterPreview struct {
+	BasePlaylistID  string               `json:"base_playlist_id"`
+	TotalTracks     int                  `json:"total_tracks"`
+	MatchedTracks   int                  `json:"matched_tracks"`
+	UnmatchedTracks int                  `json:"unmatched_tracks"`
+	Sample          []FilterPreviewTrack `json:"sample"` // First matching tracks, in base playlist order
+}
+
+type FilterPreviewTrack struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	URI     string   `json:"uri"`
+	Artists []string `json:"artists"`
+	Album   string   `json:"album"`
+}
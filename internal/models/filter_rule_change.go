@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// FilterRuleChange records an edit of the filter rules of a child playlist, together with the
+// routing diff it caused once it is computed on the next sync or on demand
+type FilterRuleChange struct {
+	ID                  string               `json:"id"`
+	UserID              string               `json:"user_id" validate:"required"`
+	BasePlaylistID      string               `json:"base_playlist_id" validate:"required"`
+	ChildPlaylistID     string               `json:"child_playlist_id" validate:"required"`
+	PreviousFilterRules *AudioFeatureFilters `json:"previous_filter_rules,omitempty"`
+	NewFilterRules      *AudioFeatureFilters `json:"new_filter_rules,omitempty"`
+	RoutingDiff         *RoutingDiff         `json:"routing_diff,omitempty"`
+	Created             time.Time            `json:"created"`
+	Updated             time.Time            `json:"updated"`
+}
+
+// RoutingDiff compares the tracks a child playlist receives with its previous filter rules
+// against the tracks it receives with its new ones, over the same base playlist tracks
+type RoutingDiff struct {
+	SyncEventID      string    `json:"sync_event_id,omitempty"`
+	TracksBefore     int       `json:"tracks_before"`
+	TracksAfter      int       `json:"tracks_after"`
+	AddedTrackURIs   []string  `json:"added_track_uris"`
+	RemovedTrackURIs []string  `json:"removed_track_uris"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
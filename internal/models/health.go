@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// HealthStatus is the state of the app or of one of its components
+type HealthStatus string
+
+const (
+	HealthStatusOK HealthStatus = "ok"
+	// HealthStatusDegraded marks an app still serving requests with a non critical component down
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusDown     HealthStatus = "down"
+)
+
+const (
+	HealthComponentDatabase = "database"
+	HealthComponentSpotify  = "spotify"
+	// HealthComponentWorkers is the scheduler running the background jobs
+	HealthComponentWorkers = "workers"
+)
+
+// HealthReport is the answer of the liveness and readiness probes
+type HealthReport struct {
+	Status     HealthStatus               `json:"status"`
+	Components map[string]ComponentHealth `json:"components"`
+	CheckedAt  time.Time                  `json:"checked_at"`
+}
+
+// ComponentHealth is the outcome of the check of a single component
+type ComponentHealth struct {
+	Status        HealthStatus `json:"status"`
+	Critical      bool         `json:"critical"` // A critical component down takes the app down
+	LatencyMs     int64        `json:"latency_ms"`
+	Error         string       `json:"error,omitempty"`
+	LastHeartbeat *time.Time   `json:"last_heartbeat,omitempty"` // Only reported for the workers
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// HookSyncStatus is the minimal payload returned by the automation hooks, small enough to be
+// read directly by Apple Shortcuts or IFTTT-style automations
+type HookSyncStatus struct {
+	Playlist        string     `json:"playlist"`
+	Status          string     `json:"status"`
+	Message         string     `json:"message"`
+	SyncEventID     string     `json:"sync_event_id,omitempty"`
+	TracksProcessed int        `json:"tracks_processed"`
+	LastSyncedAt    *time.Time `json:"last_synced_at,omitempty"`
+}
+
+// HookStatusNeverSynced is reported by the status hook for base playlists without a completed sync
+const HookStatusNeverSynced = "never_synced"
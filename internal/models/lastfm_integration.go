@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// LastFmIntegration is the Last.fm account a user linked, one per user. Its scrobbles give the play
+// counts and tags child playlist filters can match on
+type LastFmIntegration struct {
+	ID      string    `json:"id" db:"id"`
+	Created time.Time `json:"created" db:"created"`
+	Updated time.Time `json:"updated" db:"updated"`
+
+	// Foreign key to users collection
+	UserID string `json:"user_id" db:"user"`
+
+	// Last.fm user whose play counts are read
+	Username string `json:"username" db:"username"`
+
+	// Session key of the web auth (hidden from JSON responses). Last.fm sessions don't expire
+	SessionKey string `json:"-" db:"session_key"`
+}
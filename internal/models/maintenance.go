@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// MaintenanceModeFlag is the feature flag maintenance mode is stored as, so every instance sees it
+const MaintenanceModeFlag = "maintenance_mode"
+
+const DEFAULT_MAINTENANCE_MESSAGE = "PlaylistRouter is down for maintenance. Your playlists can still be viewed, changes and syncs will be back in a few minutes."
+
+// MaintenanceMode pauses the changes and syncs of every user, reads keep working
+type MaintenanceMode struct {
+	Enabled bool `json:"enabled"`
+	// Message is shown to the users whose changes are refused
+	Message string     `json:"message"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+type UpdateMaintenanceModeRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+	// Message defaults to DEFAULT_MAINTENANCE_MESSAGE
+	Message string `json:"message,omitempty" validate:"max=500"`
+}
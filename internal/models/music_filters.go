@@ -1,19 +1,82 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MAX_FILTER_RULE_DEPTH bounds how deeply and/or/not groups can be nested
+	MAX_FILTER_RULE_DEPTH = 5
+
+	// DATE_FILTER_LAYOUT is the format of the absolute bounds of a date filter
+	DATE_FILTER_LAYOUT = "2006-01-02"
+
+	// MAX_TEXT_PATTERN_LENGTH bounds the size of text filter patterns
+	MAX_TEXT_PATTERN_LENGTH = 200
+)
+
+// TextMatchType is how a text filter pattern is compared to a name
+type TextMatchType string
+
+const (
+	TextMatchContains TextMatchType = "contains"
+	TextMatchPrefix   TextMatchType = "prefix"
+	TextMatchRegex    TextMatchType = "regex"
+)
+
+// MetadataFilters is a node of a filter rule expression. A track matches the node when it matches
+// every condition set on it, every group in And, at least one group in Or, and not the Not group.
+// Rules without groups keep working as a flat AND of conditions.
 type MetadataFilters struct {
 	// Track Information
-	Duration   *RangeFilter `json:"duration_ms,omitempty"`
-	Popularity *RangeFilter `json:"popularity,omitempty"`
-	Explicit   *bool        `json:"explicit,omitempty"` // true = explicit only, false = clean only, nil = both
+	Duration *RangeFilter `json:"duration_ms,omitempty"`
+	// Request only: human friendly duration range, converted into Duration by ResolveDurations before saving
+	DurationRange *DurationFilter `json:"duration,omitempty"`
+	Popularity    *RangeFilter    `json:"popularity,omitempty"`
+	Explicit      *bool           `json:"explicit,omitempty"`   // true = explicit only, false = clean only, nil = both
+	AddedDate     *DateFilter     `json:"added_date,omitempty"` // Date the track was added to the base playlist, tracks without one never match
 
 	// Artist & Album Information
 	Genres           *SetFilter   `json:"genres,omitempty"`
+	Artists          *SetFilter   `json:"artists,omitempty"` // Spotify artist IDs, URIs or names, matching any of the track artists
 	ReleaseYear      *RangeFilter `json:"release_year,omitempty"`
+	ReleaseDate      *DateFilter  `json:"release_date,omitempty"` // Album release date, tracks without one never match
 	ArtistPopularity *RangeFilter `json:"artist_popularity,omitempty"`
 
 	// Search-based Filters
-	TrackKeywords  *SetFilter `json:"track_keywords,omitempty"`  // Keywords to search for in track names
-	ArtistKeywords *SetFilter `json:"artist_keywords,omitempty"` // Keywords to search for in artist names
+	TrackKeywords  *SetFilter  `json:"track_keywords,omitempty"`  // Keywords to search for in track names
+	ArtistKeywords *SetFilter  `json:"artist_keywords,omitempty"` // Keywords to search for in artist names
+	TrackName      *TextFilter `json:"track_name,omitempty"`
+	ArtistName     *TextFilter `json:"artist_name,omitempty"` // Matches when any of the track artists matches
+	AlbumName      *TextFilter `json:"album_name,omitempty"`
+
+	// Audio Features (tracks without audio features never match these)
+	Tempo            *RangeFilter `json:"tempo,omitempty"`
+	Energy           *RangeFilter `json:"energy,omitempty"`
+	Danceability     *RangeFilter `json:"danceability,omitempty"`
+	Valence          *RangeFilter `json:"valence,omitempty"`
+	Acousticness     *RangeFilter `json:"acousticness,omitempty"`
+	Instrumentalness *RangeFilter `json:"instrumentalness,omitempty"`
+	Liveness         *RangeFilter `json:"liveness,omitempty"`
+	Speechiness      *RangeFilter `json:"speechiness,omitempty"`
+	Loudness         *RangeFilter `json:"loudness,omitempty"`
+	Key              *RangeFilter `json:"key,omitempty"`
+	Mode             *RangeFilter `json:"mode,omitempty"`
+
+	// Last.fm scrobbles (tracks without Last.fm data never match these)
+	PlayCount  *RangeFilter `json:"play_count,omitempty"` // Times the user played the track, max 0 for never played tracks
+	LastFmTags *SetFilter   `json:"lastfm_tags,omitempty"`
+
+	// Boolean Composition
+	And []*MetadataFilters `json:"and,omitempty"` // Every group must match
+	Or  []*MetadataFilters `json:"or,omitempty"`  // At least one group must match
+	Not *MetadataFilters   `json:"not,omitempty"` // The group must not match
 }
 
 // Legacy type alias for backward compatibility during transition
@@ -28,3 +91,416 @@ type SetFilter struct {
 	Include []string `json:"include,omitempty"`
 	Exclude []string `json:"exclude,omitempty"`
 }
+
+// TextFilter matches names against patterns, ignoring case: a name must match at least one include
+// pattern, when any, and none of the exclude patterns
+type TextFilter struct {
+	Include []TextPattern `json:"include,omitempty"`
+	Exclude []TextPattern `json:"exclude,omitempty"`
+}
+
+type TextPattern struct {
+	Type  TextMatchType `json:"type"`
+	Value string        `json:"value"`
+}
+
+// Validate checks every pattern has a known type and a value, and that regex patterns compile
+func (f *TextFilter) Validate() error {
+	if len(f.Include) == 0 && len(f.Exclude) == 0 {
+		return fmt.Errorf("include or exclude must contain at least one pattern")
+	}
+
+	groups := []struct {
+		name     string
+		patterns []TextPattern
+	}{{"include", f.Include}, {"exclude", f.Exclude}}
+	for _, group := range groups {
+		for i, pattern := range group.patterns {
+			if err := pattern.validate(); err != nil {
+				return fmt.Errorf("%s[%d]: %w", group.name, i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p TextPattern) validate() error {
+	if p.Value == "" {
+		return fmt.Errorf("value is required")
+	}
+	if len(p.Value) > MAX_TEXT_PATTERN_LENGTH {
+		return fmt.Errorf("value can not be longer than %d characters", MAX_TEXT_PATTERN_LENGTH)
+	}
+
+	switch p.Type {
+	case TextMatchContains, TextMatchPrefix:
+		return nil
+	case TextMatchRegex:
+		// Go regular expressions run in linear time, so any pattern that compiles is safe to run
+		if _, err := regexp.Compile(p.Value); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("type must be one of contains, prefix or regex")
+	}
+}
+
+// DateFilter matches dates within every bound set on it: an absolute range, a window ending on the
+// current day, and a decade. Absolute bounds are formatted as YYYY-MM-DD and are inclusive.
+type DateFilter struct {
+	After       *string `json:"after,omitempty"`
+	Before      *string `json:"before,omitempty"`
+	WithinDays  *int    `json:"within_days,omitempty"`  // Dates in the last N days
+	WithinYears *int    `json:"within_years,omitempty"` // Dates in the last N years
+	Decade      *int    `json:"decade,omitempty"`       // First year of the decade, e.g. 2020 for the 2020s
+}
+
+// Validate checks the bounds of the filter are well formed and can match some date
+func (f *DateFilter) Validate() error {
+	after, err := parseDateBound("after", f.After)
+	if err != nil {
+		return err
+	}
+
+	before, err := parseDateBound("before", f.Before)
+	if err != nil {
+		return err
+	}
+
+	if !after.IsZero() && !before.IsZero() && after.After(before) {
+		return fmt.Errorf("after can not be later than before")
+	}
+	if f.WithinDays != nil && *f.WithinDays <= 0 {
+		return fmt.Errorf("within_days must be positive")
+	}
+	if f.WithinYears != nil && *f.WithinYears <= 0 {
+		return fmt.Errorf("within_years must be positive")
+	}
+	if f.Decade != nil && *f.Decade%10 != 0 {
+		return fmt.Errorf("decade must be the first year of a decade, e.g. 2020")
+	}
+
+	return nil
+}
+
+// Bounds returns the inclusive range of days the filter matches on the day of now.
+// A zero bound means the range is open on that side.
+func (f *DateFilter) Bounds(now time.Time) (from, to time.Time) {
+	// Validated filters always parse
+	from, _ = parseDateBound("after", f.After)
+	to, _ = parseDateBound("before", f.Before)
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if f.WithinDays != nil {
+		from = laterDate(from, today.AddDate(0, 0, -*f.WithinDays))
+	}
+	if f.WithinYears != nil {
+		from = laterDate(from, today.AddDate(-*f.WithinYears, 0, 0))
+	}
+	if f.Decade != nil {
+		from = laterDate(from, time.Date(*f.Decade, time.January, 1, 0, 0, 0, 0, time.UTC))
+		decadeEnd := time.Date(*f.Decade+9, time.December, 31, 0, 0, 0, 0, time.UTC)
+		if to.IsZero() || decadeEnd.Before(to) {
+			to = decadeEnd
+		}
+	}
+
+	return from, to
+}
+
+func parseDateBound(name string, value *string) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(DATE_FILTER_LAYOUT, *value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date formatted as YYYY-MM-DD", name)
+	}
+
+	return date, nil
+}
+
+func laterDate(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// DurationPreset is a named track duration range
+type DurationPreset string
+
+const (
+	DurationPresetShort    DurationPreset = "short"    // Under 3 minutes
+	DurationPresetStandard DurationPreset = "standard" // From 3 to 5 minutes
+	DurationPresetLong     DurationPreset = "long"     // Over 5 minutes
+)
+
+// durationPresetRanges holds the bounds of every preset, a zero bound leaves that side open
+var durationPresetRanges = map[DurationPreset][2]time.Duration{
+	DurationPresetShort:    {0, 3 * time.Minute},
+	DurationPresetStandard: {3 * time.Minute, 5 * time.Minute},
+	DurationPresetLong:     {5 * time.Minute, 0},
+}
+
+// FilterDuration is a track duration given either as a duration string ("3m30s") or a number of seconds
+type FilterDuration time.Duration
+
+func (d *FilterDuration) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case float64:
+		*d = FilterDuration(time.Duration(value * float64(time.Second)))
+	case string:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q, use a format like 3m30s or a number of seconds", value)
+		}
+		*d = FilterDuration(duration)
+	default:
+		return fmt.Errorf("duration must be a string like 3m30s or a number of seconds")
+	}
+
+	return nil
+}
+
+func (d FilterDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// DurationFilter matches track durations either within a preset or between human friendly bounds
+type DurationFilter struct {
+	Preset *DurationPreset `json:"preset,omitempty"`
+	Min    *FilterDuration `json:"min,omitempty"`
+	Max    *FilterDuration `json:"max,omitempty"`
+}
+
+// Validate checks the filter sets either a known preset or non negative, ordered bounds
+func (f *DurationFilter) Validate() error {
+	if f.Preset != nil {
+		if f.Min != nil || f.Max != nil {
+			return fmt.Errorf("preset can not be combined with min or max")
+		}
+		if _, ok := durationPresetRanges[*f.Preset]; !ok {
+			return fmt.Errorf("preset must be one of short, standard or long")
+		}
+		return nil
+	}
+
+	if f.Min == nil && f.Max == nil {
+		return fmt.Errorf("preset, min or max is required")
+	}
+	if (f.Min != nil && *f.Min < 0) || (f.Max != nil && *f.Max < 0) {
+		return fmt.Errorf("min and max can not be negative")
+	}
+	if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+		return fmt.Errorf("min can not be greater than max")
+	}
+
+	return nil
+}
+
+// ToRangeFilter converts the filter into the millisecond range stored in filter rules
+func (f *DurationFilter) ToRangeFilter() *RangeFilter {
+	toMs := func(duration time.Duration) *float64 {
+		ms := float64(duration.Milliseconds())
+		return &ms
+	}
+
+	rangeFilter := &RangeFilter{}
+	if f.Preset != nil {
+		bounds := durationPresetRanges[*f.Preset]
+		if bounds[0] > 0 {
+			rangeFilter.Min = toMs(bounds[0])
+		}
+		if bounds[1] > 0 {
+			rangeFilter.Max = toMs(bounds[1])
+		}
+		return rangeFilter
+	}
+
+	if f.Min != nil {
+		rangeFilter.Min = toMs(time.Duration(*f.Min))
+	}
+	if f.Max != nil {
+		rangeFilter.Max = toMs(time.Duration(*f.Max))
+	}
+	return rangeFilter
+}
+
+// ResolveDurations validates and converts every human friendly duration of the expression, groups
+// included, into the millisecond ranges the filter engine matches on
+func (f *MetadataFilters) ResolveDurations() error {
+	return f.resolveDurations("filter_rules")
+}
+
+func (f *MetadataFilters) resolveDurations(path string) error {
+	if f == nil {
+		return nil
+	}
+
+	if f.DurationRange != nil {
+		if f.Duration != nil {
+			return fmt.Errorf("%s: duration and duration_ms can not be combined", path)
+		}
+		if err := f.DurationRange.Validate(); err != nil {
+			return fmt.Errorf("%s.duration: %w", path, err)
+		}
+		f.Duration = f.DurationRange.ToRangeFilter()
+		f.DurationRange = nil
+	}
+
+	for i, node := range f.And {
+		if err := node.resolveDurations(fmt.Sprintf("%s.and[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	for i, node := range f.Or {
+		if err := node.resolveDurations(fmt.Sprintf("%s.or[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return f.Not.resolveDurations(path + ".not")
+}
+
+// IsEmpty reports whether the node has no condition nor group, and so matches every track
+func (f *MetadataFilters) IsEmpty() bool {
+	if f == nil {
+		return true
+	}
+
+	return len(f.And) == 0 && len(f.Or) == 0 && f.Not == nil && f.conditionCount() == 0
+}
+
+// IsRelative reports whether the node or any of its groups has a date window ending on the current
+// day, so the tracks it matches change from one day to the next
+func (f *MetadataFilters) IsRelative() bool {
+	if f == nil {
+		return false
+	}
+
+	for _, dateFilter := range []*DateFilter{f.AddedDate, f.ReleaseDate} {
+		if dateFilter != nil && (dateFilter.WithinDays != nil || dateFilter.WithinYears != nil) {
+			return true
+		}
+	}
+
+	for _, group := range append(slices.Clone(f.And), f.Or...) {
+		if group.IsRelative() {
+			return true
+		}
+	}
+
+	return f.Not.IsRelative()
+}
+
+// Validate checks the structure of the rule expression: groups must hold at least one condition,
+// nesting is bounded by MAX_FILTER_RULE_DEPTH and ranges must not be inverted
+func (f *MetadataFilters) Validate() error {
+	return f.validate("filter_rules", 1)
+}
+
+func (f *MetadataFilters) validate(path string, depth int) error {
+	if depth > MAX_FILTER_RULE_DEPTH {
+		return fmt.Errorf("%s: groups can not be nested more than %d levels deep", path, MAX_FILTER_RULE_DEPTH)
+	}
+
+	value := reflect.ValueOf(*f)
+	for i := range value.NumField() {
+		rangeFilter, ok := value.Field(i).Interface().(*RangeFilter)
+		if !ok || rangeFilter == nil || rangeFilter.Min == nil || rangeFilter.Max == nil {
+			continue
+		}
+		if *rangeFilter.Min > *rangeFilter.Max {
+			return fmt.Errorf("%s.%s: min can not be greater than max", path, jsonFieldName(value.Type().Field(i)))
+		}
+	}
+
+	if f.ReleaseDate != nil {
+		if err := f.ReleaseDate.Validate(); err != nil {
+			return fmt.Errorf("%s.release_date: %w", path, err)
+		}
+	}
+
+	if f.AddedDate != nil {
+		if err := f.AddedDate.Validate(); err != nil {
+			return fmt.Errorf("%s.added_date: %w", path, err)
+		}
+	}
+
+	if f.DurationRange != nil {
+		if f.Duration != nil {
+			return fmt.Errorf("%s: duration and duration_ms can not be combined", path)
+		}
+		if err := f.DurationRange.Validate(); err != nil {
+			return fmt.Errorf("%s.duration: %w", path, err)
+		}
+	}
+
+	textFilters := []struct {
+		name   string
+		filter *TextFilter
+	}{{"track_name", f.TrackName}, {"artist_name", f.ArtistName}, {"album_name", f.AlbumName}}
+	for _, text := range textFilters {
+		if text.filter == nil {
+			continue
+		}
+		if err := text.filter.Validate(); err != nil {
+			return fmt.Errorf("%s.%s: %w", path, text.name, err)
+		}
+	}
+
+	groups := []struct {
+		name  string
+		nodes []*MetadataFilters
+	}{{"and", f.And}, {"or", f.Or}}
+	for _, group := range groups {
+		for i, node := range group.nodes {
+			nodePath := fmt.Sprintf("%s.%s[%d]", path, group.name, i)
+			if node.IsEmpty() {
+				return fmt.Errorf("%s: group must contain at least one condition", nodePath)
+			}
+			if err := node.validate(nodePath, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	if f.Not != nil {
+		nodePath := path + ".not"
+		if f.Not.IsEmpty() {
+			return fmt.Errorf("%s: group must contain at least one condition", nodePath)
+		}
+		if err := f.Not.validate(nodePath, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// conditionCount counts the conditions set directly on the node, ignoring its groups
+func (f *MetadataFilters) conditionCount() int {
+	count := 0
+	value := reflect.ValueOf(*f)
+	for i := range value.NumField() {
+		field := value.Field(i)
+		if field.Kind() == reflect.Pointer && !field.IsNil() && field.Type() != reflect.TypeOf(f) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
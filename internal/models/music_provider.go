@@ -0,0 +1,26 @@
+package models
+
+// MusicProvider is the streaming service an account or a playlist belongs to
+type MusicProvider string
+
+const (
+	MusicProviderSpotify      MusicProvider = "spotify"
+	MusicProviderYouTubeMusic MusicProvider = "youtube_music"
+	MusicProviderTidal        MusicProvider = "tidal"
+)
+
+// OrDefault returns the provider, spotify for records stored before providers were tracked
+func (p MusicProvider) OrDefault() MusicProvider {
+	if p == "" {
+		return MusicProviderSpotify
+	}
+	return p
+}
+
+// OAuthTokenRefresh holds the tokens of a music provider integration refreshed through OAuth. The
+// refresh token is empty when the provider keeps the current one
+type OAuthTokenRefresh struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in"`
+}
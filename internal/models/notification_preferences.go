@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// DEFAULT_SYNC_FAILURE_THRESHOLD is the number of scheduled syncs of a base playlist failing in a
+// row before the user is notified, when the user never changed it
+const DEFAULT_SYNC_FAILURE_THRESHOLD = 3
+
+// NotificationChannel is a chat service sync summaries can be posted to
+type NotificationChannel string
+
+const (
+	NotificationChannelSlack   NotificationChannel = "slack"
+	NotificationChannelDiscord NotificationChannel = "discord"
+)
+
+// NotificationPreferences is how a user wants to hear about their syncs. Users without stored
+// preferences get DefaultNotificationPreferences
+type NotificationPreferences struct {
+	ID                 string `json:"id,omitempty"`
+	UserID             string `json:"user_id"`
+	EmailOnSyncFailure bool   `json:"email_on_sync_failure"`
+	// SyncFailureThreshold is the number of scheduled syncs of a base playlist failing in a row that
+	// triggers the email. It is sent once per streak of failures
+	SyncFailureThreshold int `json:"sync_failure_threshold"`
+	// SyncSummaryChannels are the chat services a summary of every sync is posted to, through the
+	// incoming webhook url of the channel
+	SyncSummaryChannels []NotificationChannel `json:"sync_summary_channels"`
+	SlackWebhookURL     string                `json:"slack_webhook_url"`
+	DiscordWebhookURL   string                `json:"discord_webhook_url"`
+	Created             time.Time             `json:"created"`
+	Updated             time.Time             `json:"updated"`
+}
+
+// WebhookURL returns the incoming webhook url of the channel, empty when not set
+func (p *NotificationPreferences) WebhookURL(channel NotificationChannel) string {
+	switch channel {
+	case NotificationChannelSlack:
+		return p.SlackWebhookURL
+	case NotificationChannelDiscord:
+		return p.DiscordWebhookURL
+	default:
+		return ""
+	}
+}
+
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:               userID,
+		EmailOnSyncFailure:   true,
+		SyncFailureThreshold: DEFAULT_SYNC_FAILURE_THRESHOLD,
+		SyncSummaryChannels:  []NotificationChannel{},
+	}
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	EmailOnSyncFailure   *bool `json:"email_on_sync_failure,omitempty"`
+	SyncFailureThreshold *int  `json:"sync_failure_threshold,omitempty" validate:"omitempty,min=1,max=50"`
+	// An empty list stops posting sync summaries
+	SyncSummaryChannels *[]NotificationChannel `json:"sync_summary_channels,omitempty" validate:"omitempty,dive,oneof=slack discord"`
+	// An empty url removes the webhook
+	SlackWebhookURL   *string `json:"slack_webhook_url,omitempty" validate:"omitempty,max=500"`
+	DiscordWebhookURL *string `json:"discord_webhook_url,omitempty" validate:"omitempty,max=500"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "strings"
+
+// ListSort orders the items of a list endpoint by a field, a leading - sorts them descending
+type ListSort string
+
+const (
+	ListSortCreatedAsc  ListSort = "created"
+	ListSortCreatedDesc ListSort = "-created"
+	ListSortNameAsc     ListSort = "name"
+	ListSortNameDesc    ListSort = "-name"
+)
+
+// Field returns the sorted field and whether the items are sorted descending
+func (s ListSort) Field() (string, bool) {
+	field, descending := strings.CutPrefix(string(s), "-")
+	return field, descending
+}
+
+// PageInfo locates a page of a list endpoint in the whole list
+type PageInfo struct {
+	Total  int `json:"total"` // Items matching the filters across every page
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+type BasePlaylistPage struct {
+	Items []*BasePlaylistWithChilds `json:"items"`
+	PageInfo
+}
+
+type SyncEventPage struct {
+	Items []*SyncEvent `json:"items"`
+	PageInfo
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type PlaylistDriftType string
+
+const (
+	PlaylistDriftMissing             PlaylistDriftType = "playlist_missing"
+	PlaylistDriftNeverSynced         PlaylistDriftType = "never_synced"
+	PlaylistDriftContentMismatch     PlaylistDriftType = "content_mismatch"
+	PlaylistDriftNameMismatch        PlaylistDriftType = "name_mismatch"
+	PlaylistDriftDescriptionMismatch PlaylistDriftType = "description_mismatch"
+)
+
+// ChildPlaylistAudit compares a child playlist against the spotify playlist it manages
+type ChildPlaylistAudit struct {
+	ChildPlaylistID     string              `json:"child_playlist_id"`
+	ChildPlaylistName   string              `json:"child_playlist_name"`
+	SpotifyPlaylistID   string              `json:"spotify_playlist_id"`
+	InSync              bool                `json:"in_sync"`
+	Drift               []PlaylistDriftType `json:"drift"`
+	ExpectedTrackCount  int                 `json:"expected_track_count"`
+	ActualTrackCount    int                 `json:"actual_track_count"`
+	MissingTrackURIs    []string            `json:"missing_track_uris,omitempty"`
+	UnexpectedTrackURIs []string            `json:"unexpected_track_uris,omitempty"`
+	ExpectedName        string              `json:"expected_name"`
+	ActualName          string              `json:"actual_name,omitempty"`
+	ExpectedDescription string              `json:"expected_description"`
+	ActualDescription   string              `json:"actual_description,omitempty"`
+	ErrorMessage        *string             `json:"error_message,omitempty"`
+}
+
+// BasePlaylistAudit groups the audits of every child playlist of a base playlist
+type BasePlaylistAudit struct {
+	BasePlaylistID   string               `json:"base_playlist_id"`
+	BasePlaylistName string               `json:"base_playlist_name"`
+	Children         []ChildPlaylistAudit `json:"children"`
+	Drifted          int                  `json:"drifted"`
+	ErrorMessage     *string              `json:"error_message,omitempty"`
+}
+
+// AuditReport is the drift report of every base playlist of a user
+type AuditReport struct {
+	UserID          string              `json:"user_id"`
+	AuditedAt       time.Time           `json:"audited_at"`
+	BasePlaylists   []BasePlaylistAudit `json:"base_playlists"`
+	TotalChildren   int                 `json:"total_children"`
+	DriftedChildren int                 `json:"drifted_children"`
+}
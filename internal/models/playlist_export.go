@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// ExportFormat is the file format child playlists are exported to
+type ExportFormat string
+
+const (
+	// ExportFormatM3U is an extended M3U playlist, one entry per track URI
+	ExportFormatM3U ExportFormat = "m3u"
+	// ExportFormatCSV is a spreadsheet of the tracks with a header row
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSON is an array of ExportedTrack
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// Valid reports whether the format is one of the supported export formats
+func (f ExportFormat) Valid() bool {
+	switch f {
+	case ExportFormatM3U, ExportFormatCSV, ExportFormatJSON:
+		return true
+	}
+	return false
+}
+
+// ExportedTrack is a track of an exported playlist, along with the metadata other tools match it on
+type ExportedTrack struct {
+	URI         string     `json:"uri"`
+	Name        string     `json:"name"`
+	Artists     []string   `json:"artists"`
+	Album       string     `json:"album"`
+	ReleaseDate string     `json:"release_date,omitempty"` // As sent by the provider, a year, month or day
+	DurationMs  int        `json:"duration_ms"`
+	Explicit    bool       `json:"explicit"`
+	Popularity  int        `json:"popularity"`
+	AddedAt     *time.Time `json:"added_at,omitempty"` // When the track was added to the playlist, nil when unknown
+}
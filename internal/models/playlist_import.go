@@ -0,0 +1,26 @@
+package models
+
+// ImportedTrack is a row of a file of tracks imported into a new base playlist. Rows are matched to
+// a catalog track by their URI, then their ISRC, then searching their title and artist
+type ImportedTrack struct {
+	URI    string `json:"uri,omitempty"`
+	ISRC   string `json:"isrc,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Artist string `json:"artist,omitempty"`
+}
+
+// ImportTracksRequest is the base playlist created from an imported file
+type ImportTracksRequest struct {
+	Name           string         `json:"name" validate:"required,min=1,max=100"`
+	DedupeStrategy DedupeStrategy `json:"dedupe_strategy,omitempty" validate:"omitempty,oneof=all_matches first_match"`
+	// SpotifyIntegrationID picks the linked spotify account of the playlist, the default account when empty
+	SpotifyIntegrationID string `json:"spotify_integration_id,omitempty"`
+}
+
+// ImportTracksResult is the base playlist created by an import, along with the rows no track was
+// found for
+type ImportTracksResult struct {
+	BasePlaylist    *BasePlaylist   `json:"base_playlist"`
+	MatchedTracks   int             `json:"matched_tracks"`
+	UnmatchedTracks []ImportedTrack `json:"unmatched_tracks"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// PlaylistMembership stores the track URIs the last successful sync wrote to a child playlist,
+// the state audits compare the Spotify playlist against
+type PlaylistMembership struct {
+	ID                string    `json:"id"`
+	UserID            string    `json:"user_id" validate:"required"`
+	ChildPlaylistID   string    `json:"child_playlist_id" validate:"required"`
+	SyncEventID       string    `json:"sync_event_id"`
+	SpotifyPlaylistID string    `json:"spotify_playlist_id"`
+	TrackURIs         []string  `json:"track_uris"`
+	Created           time.Time `json:"created"`
+	Updated           time.Time `json:"updated"`
+}
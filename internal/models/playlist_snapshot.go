@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// PlaylistSnapshot stores the track URIs a child playlist held right before a sync replaced them,
+// so the sync can be rolled back
+type PlaylistSnapshot struct {
+	ID                string    `json:"id"`
+	UserID            string    `json:"user_id" validate:"required"`
+	SyncEventID       string    `json:"sync_event_id" validate:"required"`
+	ChildPlaylistID   string    `json:"child_playlist_id" validate:"required"`
+	SpotifyPlaylistID string    `json:"spotify_playlist_id"`
+	TrackURIs         []string  `json:"track_uris"`
+	Created           time.Time `json:"created"`
+	Updated           time.Time `json:"updated"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// PlaylistWebhookEventBaseChanged is sent when the tracks of a base playlist changed outside of the router
+const PlaylistWebhookEventBaseChanged = "base_playlist.changed"
+
+// PlaylistWebhook is an url notified when the watched base playlist changes. Deliveries are signed
+// with the secret, which is only returned when the webhook is created
+type PlaylistWebhook struct {
+	ID                 string     `json:"id"`
+	UserID             string     `json:"user_id" validate:"required"`
+	BasePlaylistID     string     `json:"base_playlist_id" validate:"required"`
+	URL                string     `json:"url" validate:"required,url"`
+	Secret             string     `json:"-"`
+	IsActive           bool       `json:"is_active"`
+	LastDeliveredAt    *time.Time `json:"last_delivered_at,omitempty"`
+	LastDeliveryStatus int        `json:"last_delivery_status,omitempty"` // HTTP status of the last delivery, 0 when unreachable
+	Created            time.Time  `json:"created"`
+	Updated            time.Time  `json:"updated"`
+}
+
+type CreatePlaylistWebhookRequest struct {
+	URL string `json:"url" validate:"required,url,startswith=https://"` // Must resolve to a public address
+}
+
+// CreatedPlaylistWebhook is returned when a webhook is created, the only time the secret is available
+type CreatedPlaylistWebhook struct {
+	*PlaylistWebhook
+	Secret string `json:"secret"`
+}
+
+// BasePlaylistWatch is the last state of a base playlist seen by the change poller
+type BasePlaylistWatch struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	BasePlaylistID string    `json:"base_playlist_id"`
+	SnapshotID     string    `json:"snapshot_id"`
+	TrackURIs      []string  `json:"track_uris"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+}
+
+// BasePlaylistChange is the delta delivered to webhooks when a base playlist changed
+type BasePlaylistChange struct {
+	Event              string    `json:"event"`
+	BasePlaylistID     string    `json:"base_playlist_id"`
+	BasePlaylistName   string    `json:"base_playlist_name"`
+	SpotifyPlaylistID  string    `json:"spotify_playlist_id"`
+	PreviousSnapshotID string    `json:"previous_snapshot_id"`
+	SnapshotID         string    `json:"snapshot_id"`
+	AddedTrackURIs     []string  `json:"added_track_uris"`
+	RemovedTrackURIs   []string  `json:"removed_track_uris"`
+	TrackCount         int       `json:"track_count"`
+	DetectedAt         time.Time `json:"detected_at"`
+}
+
+// PlaylistChangePollReport summarizes a run of the base playlist change poller
+type PlaylistChangePollReport struct {
+	BasePlaylistsChecked int `json:"base_playlists_checked"`
+	BasePlaylistsChanged int `json:"base_playlists_changed"`
+	Deliveries           int `json:"deliveries"`
+	FailedDeliveries     int `json:"failed_deliveries"`
+	Failed               int `json:"failed"` // Base playlists that could not be checked
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PlaylistWidget is the public view of a shared child playlist rendered by the embeddable widget
+type PlaylistWidget struct {
+	Name          string        `json:"name"`
+	Description   string        `json:"description,omitempty"`
+	CoverImageURL string        `json:"cover_image_url,omitempty"`
+	TrackCount    int           `json:"track_count"`
+	LastSyncedAt  *time.Time    `json:"last_synced_at,omitempty"`
+	Provider      MusicProvider `json:"provider"`
+	PlaylistURL   string        `json:"playlist_url,omitempty"` // Link to the playlist in the app of its provider
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// RoutingCacheEntry holds the tracks of a base playlist snapshot matching the filter rules of a
+// child playlist, so re-syncs of an unchanged playlist with unchanged rules skip evaluating them.
+// Only the entry of the latest sync of each child playlist is kept
+type RoutingCacheEntry struct {
+	ID              string    `json:"id"`
+	UserID          string    `json:"user_id"`
+	ChildPlaylistID string    `json:"child_playlist_id"`
+	SnapshotID      string    `json:"snapshot_id"`
+	FilterHash      string    `json:"filter_hash"`
+	TrackURIs       []string  `json:"track_uris"`
+	Created         time.Time `json:"created"`
+	Updated         time.Time `json:"updated"`
+}
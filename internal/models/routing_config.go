@@ -0,0 +1,56 @@
+package models
+
+// ROUTING_CONFIG_VERSION is the version of the routing config format written by exports
+const ROUTING_CONFIG_VERSION = 1
+
+// RoutingConfig is the portable routing setup of a base playlist: the spotify playlist it reads and
+// its child playlists, from highest to lowest routing priority. Everything tied to the account of
+// the user (IDs, merge sources, pinned tracks, sharing) is left out so configs can be shared
+type RoutingConfig struct {
+	Version           int             `json:"version" validate:"required,eq=1"`
+	Name              string          `json:"name" validate:"required,min=1,max=100"`
+	SpotifyPlaylistID string          `json:"spotify_playlist_id" validate:"required"`
+	DedupeStrategy    DedupeStrategy  `json:"dedupe_strategy,omitempty" validate:"omitempty,oneof=all_matches first_match"`
+	Children          []TemplateChild `json:"children" validate:"max=50,dive"`
+}
+
+// ToTemplateChild builds the routing config entry of the child playlist. Filter rules matching every
+// track are left out, like in a config that sets none, and so is the selection strategy of an
+// uncapped child
+func (c *ChildPlaylist) ToTemplateChild() TemplateChild {
+	child := TemplateChild{
+		Name:        c.Name,
+		Description: c.Description,
+		IsFallback:  c.IsFallback,
+		MaxTracks:   c.MaxTracks,
+	}
+	if !c.FilterRules.IsEmpty() {
+		child.FilterRules = c.FilterRules
+	}
+	if c.MaxTracks > 0 {
+		child.SelectionStrategy = c.SelectionStrategy
+	}
+
+	return child
+}
+
+// ToUpdateRequest builds the request applying the entry to an existing child playlist, clearing the
+// filter rules and the track cap the entry doesn't set
+func (c TemplateChild) ToUpdateRequest() *UpdateChildPlaylistRequest {
+	filterRules := c.FilterRules
+	if filterRules == nil {
+		filterRules = &MetadataFilters{}
+	}
+
+	request := &UpdateChildPlaylistRequest{
+		Description: &c.Description,
+		FilterRules: filterRules,
+		IsFallback:  &c.IsFallback,
+		MaxTracks:   &c.MaxTracks,
+	}
+	if c.SelectionStrategy != "" {
+		request.SelectionStrategy = &c.SelectionStrategy
+	}
+
+	return request
+}
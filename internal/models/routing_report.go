@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// RoutingOutcome is where a track of the base playlist ended up after routing
+type RoutingOutcome string
+
+const (
+	// RoutingOutcomeRouted tracks matched the rules of at least one child playlist that received them
+	RoutingOutcomeRouted RoutingOutcome = "routed"
+	// RoutingOutcomeFallback tracks matched no child playlist and landed in the fallback child
+	RoutingOutcomeFallback RoutingOutcome = "fallback"
+	// RoutingOutcomeUnmatched tracks matched no child playlist and there is no fallback child
+	RoutingOutcomeUnmatched RoutingOutcome = "unmatched"
+	// RoutingOutcomeCapped tracks matched child playlists but were left out by their max_tracks
+	RoutingOutcomeCapped RoutingOutcome = "capped"
+	// RoutingOutcomeBlocked tracks are in the blocklist of the base playlist
+	RoutingOutcomeBlocked RoutingOutcome = "blocked"
+)
+
+// RoutingReport explains the routing of every track of a base playlist during a sync. Only the
+// report of the latest sync of each base playlist is kept
+type RoutingReport struct {
+	ID             string                 `json:"id"`
+	UserID         string                 `json:"user_id"`
+	BasePlaylistID string                 `json:"base_playlist_id"`
+	SyncEventID    string                 `json:"sync_event_id"`
+	DedupeStrategy DedupeStrategy         `json:"dedupe_strategy"`
+	Tracks         []TrackRoutingDecision `json:"tracks"`
+	Created        time.Time              `json:"created"`
+	Updated        time.Time              `json:"updated"`
+}
+
+// TrackRoutingDecision records which child playlists matched a track and which ones received it.
+// With the first_match dedupe strategy the highest priority match wins, so a track can match
+// several child playlists but only be routed to one
+type TrackRoutingDecision struct {
+	TrackURI        string         `json:"track_uri"`
+	TrackName       string         `json:"track_name"`
+	MatchedChildIDs []string       `json:"matched_child_ids"` // From highest to lowest priority
+	RoutedChildIDs  []string       `json:"routed_child_ids"`
+	Outcome         RoutingOutcome `json:"outcome"`
+}
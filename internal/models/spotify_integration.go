@@ -11,6 +11,9 @@ type SpotifyIntegration struct {
 	// Foreign key to users collection
 	UserID string `json:"user_id" db:"user"`
 
+	// Provider the account belongs to
+	Provider MusicProvider `json:"provider" db:"provider"`
+
 	// Spotify account details
 	SpotifyID string `json:"spotify_id" db:"spotify_id"`
 
@@ -30,3 +33,10 @@ type SpotifyIntegrationTokenRefresh struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
 	ExpiresIn    int    `json:"expires_in"`
 }
+
+// TokenRefreshReport summarizes a run of the proactive token refresh job
+type TokenRefreshReport struct {
+	Checked   int `json:"checked"`
+	Refreshed int `json:"refreshed"`
+	Failed    int `json:"failed"`
+}
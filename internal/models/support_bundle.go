@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// LogEntry is a persisted application log line
+type LogEntry struct {
+	ID      string         `json:"id"`
+	Level   int            `json:"level"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data"`
+	Created time.Time      `json:"created"`
+}
+
+// SchemaVersions describes the database schema the app is running on
+type SchemaVersions struct {
+	Migrations  []AppliedMigration  `json:"migrations"`
+	Collections []CollectionVersion `json:"collections"`
+}
+
+// AppliedMigration is a migration file recorded as applied
+type AppliedMigration struct {
+	File      string    `json:"file"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// CollectionVersion identifies a collection schema by its field count and last change
+type CollectionVersion struct {
+	Name    string    `json:"name"`
+	Fields  int       `json:"fields"`
+	Updated time.Time `json:"updated"`
+}
+
+// EnvironmentInfo is the runtime information included in support bundles
+type EnvironmentInfo struct {
+	AppEnv            string    `json:"app_env"`
+	GoVersion         string    `json:"go_version"`
+	OS                string    `json:"os"`
+	Arch              string    `json:"arch"`
+	NumCPU            int       `json:"num_cpu"`
+	PocketBaseVersion string    `json:"pocketbase_version"`
+	GeneratedAt       time.Time `json:"generated_at"`
+}
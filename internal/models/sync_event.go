@@ -9,6 +9,31 @@ const (
 	SyncStatusInProgress SyncStatus = "in_progress"
 	SyncStatusCompleted  SyncStatus = "completed"
 	SyncStatusFailed     SyncStatus = "failed"
+	// SyncStatusNeedsConfirmation marks a sync held back by anomaly detection until the user confirms it
+	SyncStatusNeedsConfirmation SyncStatus = "needs_confirmation"
+	// SyncStatusConfirmed marks a held back sync the user confirmed, its confirmed run is recorded as a new sync event
+	SyncStatusConfirmed SyncStatus = "confirmed"
+)
+
+// SyncPhase is the step an in progress sync is running
+type SyncPhase string
+
+const (
+	SyncPhaseFetchingTracks    SyncPhase = "fetching_tracks"
+	SyncPhaseRoutingTracks     SyncPhase = "routing_tracks"
+	SyncPhaseUpdatingPlaylists SyncPhase = "updating_playlists"
+	// SyncPhaseWaitingForQuota marks a background sync queued until the Spotify request budget refills
+	SyncPhaseWaitingForQuota SyncPhase = "waiting_for_quota"
+)
+
+// SyncAnomalyType identifies the heuristic that flagged a sync as suspicious
+type SyncAnomalyType string
+
+const (
+	// SyncAnomalyChildTrackDrop flags a child playlist losing most of its tracks
+	SyncAnomalyChildTrackDrop SyncAnomalyType = "child_track_drop"
+	// SyncAnomalyUnmatchedSpike flags most base playlist tracks suddenly matching no child playlist
+	SyncAnomalyUnmatchedSpike SyncAnomalyType = "unmatched_spike"
 )
 
 // SyncEvent tracks sync operations
@@ -18,13 +43,82 @@ type SyncEvent struct {
 	BasePlaylistID   string     `json:"base_playlist_id" validate:"required"`
 	ChildPlaylistIDs []string   `json:"child_playlist_ids"`
 	Status           SyncStatus `json:"status"`
+	Phase            SyncPhase  `json:"phase,omitempty"`
+	HeartbeatAt      *time.Time `json:"heartbeat_at,omitempty"` // Last sign of life of a sync waiting for quota
 	StartedAt        time.Time  `json:"started_at"`
 	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	DurationMs       *int64     `json:"duration_ms,omitempty"` // Read only, from started_at to completed_at of finished syncs
 	ErrorMessage     *string    `json:"error_message,omitempty"`
+	RequestID        string     `json:"request_id,omitempty"` // X-Request-ID of the request that started the sync, none for scheduled syncs
 	Created          time.Time  `json:"created"`
 	Updated          time.Time  `json:"updated"`
 
 	// Sync statistics
 	TracksProcessed  int `json:"tracks_processed"`
+	TracksUnmatched  int `json:"tracks_unmatched"`
 	TotalAPIRequests int `json:"total_api_requests"`
+
+	ChildSyncResults []ChildSyncResult   `json:"child_sync_results"`
+	Anomalies        []SyncAnomaly       `json:"anomalies,omitempty"`
+	Profile          *SyncProfileSpan    `json:"profile,omitempty"`    // Only recorded for syncs run with profiling
+	RateLimit        *SyncRateLimitStats `json:"rate_limit,omitempty"` // Only recorded when Spotify rate limiting slowed the sync down
+}
+
+// SyncRateLimitStats reports how much Spotify rate limiting slowed a sync down
+type SyncRateLimitStats struct {
+	RateLimitedResponses int   `json:"rate_limited_responses"` // 429 responses received from Spotify
+	Retries              int   `json:"retries"`                // Requests sent again after a 429 response
+	BudgetWaits          int   `json:"budget_waits"`           // Requests queued until the request budget refilled
+	WaitMs               int64 `json:"wait_ms"`                // Time spent waiting on Retry-After and the request budget
+}
+
+// SyncProfileSpan is a timed step of a profiled sync. The tree follows the d3-flame-graph format:
+// value is the duration of the step in milliseconds and children are its nested steps
+type SyncProfileSpan struct {
+	Name     string             `json:"name"`
+	Value    int64              `json:"value"`
+	StartMs  int64              `json:"start_ms"` // Offset from the start of the sync
+	Children []*SyncProfileSpan `json:"children,omitempty"`
+}
+
+// SyncAnomaly describes a suspicious change between the previous completed sync and a new one
+type SyncAnomaly struct {
+	Type            SyncAnomalyType `json:"type"`
+	ChildPlaylistID string          `json:"child_playlist_id,omitempty"`
+	PreviousCount   int             `json:"previous_count"`
+	NewCount        int             `json:"new_count"`
+	Message         string          `json:"message"`
+}
+
+// ChildSyncResult captures the outcome of syncing a single child playlist within a sync event
+type ChildSyncResult struct {
+	ChildPlaylistID   string     `json:"child_playlist_id"`
+	SpotifyPlaylistID string     `json:"spotify_playlist_id"`
+	Status            SyncStatus `json:"status"`
+	TracksAdded       int        `json:"tracks_added"`
+	TracksRemoved     int        `json:"tracks_removed"`
+	APIRequests       int        `json:"api_requests"`
+	ErrorMessage      *string    `json:"error_message,omitempty"`
+}
+
+// BaseSyncResult summarizes the outcome of syncing a single base playlist in a multi-base sync
+type BaseSyncResult struct {
+	BasePlaylistID string     `json:"base_playlist_id"`
+	SyncEventID    string     `json:"sync_event_id,omitempty"`
+	Status         SyncStatus `json:"status"`
+	ErrorMessage   *string    `json:"error_message,omitempty"`
+}
+
+// MultiSyncReport aggregates the results of syncing every active base playlist of a user
+type MultiSyncReport struct {
+	UserID    string           `json:"user_id"`
+	Results   []BaseSyncResult `json:"results"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+}
+
+// SyncEventPruneResult reports how many sync events a manual prune deleted
+type SyncEventPruneResult struct {
+	Deleted int `json:"deleted"`
 }
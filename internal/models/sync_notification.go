@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// SyncNotification summarizes a finished sync for the chat services of the user
+type SyncNotification struct {
+	SyncEventID      string                  `json:"sync_event_id"`
+	BasePlaylistID   string                  `json:"base_playlist_id"`
+	BasePlaylistName string                  `json:"base_playlist_name"`
+	Status           SyncStatus              `json:"status"`
+	Duration         time.Duration           `json:"duration"`
+	TracksProcessed  int                     `json:"tracks_processed"`
+	TracksUnmatched  int                     `json:"tracks_unmatched"`
+	Children         []ChildSyncNotification `json:"children"`
+	ErrorMessage     string                  `json:"error_message,omitempty"`
+}
+
+// ChildSyncNotification is the outcome of a sync for one of the child playlists
+type ChildSyncNotification struct {
+	Name          string     `json:"name"`
+	Status        SyncStatus `json:"status"`
+	TracksAdded   int        `json:"tracks_added"`
+	TracksRemoved int        `json:"tracks_removed"`
+	ErrorMessage  string     `json:"error_message,omitempty"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// TidalIntegration is the Tidal account a user linked, one per user. It owns the playlists of the
+// base and child playlists kept on Tidal
+type TidalIntegration struct {
+	ID      string    `json:"id" db:"id"`
+	Created time.Time `json:"created" db:"created"`
+	Updated time.Time `json:"updated" db:"updated"`
+
+	// Foreign key to users collection
+	UserID string `json:"user_id" db:"user"`
+
+	// Tidal user of the account, and the country its catalog is read in
+	TidalUserID string `json:"tidal_user_id" db:"tidal_user_id"`
+	CountryCode string `json:"country_code" db:"country_code"`
+
+	// Authentication tokens (hidden from JSON responses)
+	AccessToken  string    `json:"-" db:"access_token"`
+	RefreshToken string    `json:"-" db:"refresh_token"`
+	TokenType    string    `json:"-" db:"token_type"`
+	ExpiresAt    time.Time `json:"-" db:"expires_at"`
+	Scope        string    `json:"-" db:"scope"`
+
+	// Username of the account
+	DisplayName string `json:"display_name" db:"display_name"`
+}
+
+type TidalIntegrationTokenRefresh = OAuthTokenRefresh
+
+// TidalDeviceLink is a pending link of a Tidal account. The user enters UserCode at VerificationURI,
+// or opens VerificationURIComplete, then the link is polled every Interval seconds until ExpiresIn
+type TidalDeviceLink struct {
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
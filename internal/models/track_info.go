@@ -1,12 +1,24 @@
 package models
 
+import (
+	"slices"
+	"time"
+)
+
 // PlaylistTracksInfo contains all aggregated data for a playlist
 type PlaylistTracksInfo struct {
-	PlaylistID   string
-	UserID       string
-	Tracks       []TrackInfo
-	Artists      map[string]ArtistInfo
-	APICallCount int
+	PlaylistID        string
+	SourcePlaylistIDs []string // Other base playlists merged into the tracks, empty unless merged
+	SnapshotID        string   // Spotify snapshot of the base playlist the tracks were read at, empty when unknown
+	UserID            string
+	Tracks            []TrackInfo
+	Artists           map[string]ArtistInfo
+	APICallCount      int
+}
+
+// BasePlaylistIDs returns the base playlist of the tracks followed by the ones merged into them
+func (p *PlaylistTracksInfo) BasePlaylistIDs() []string {
+	return append([]string{p.PlaylistID}, p.SourcePlaylistIDs...)
 }
 
 // TrackInfo contains all track data needed for routing decisions
@@ -17,14 +29,39 @@ type TrackInfo struct {
 	DurationMs int
 	Popularity int
 	Explicit   bool
+	AddedAt    time.Time // When the track was added to the playlist, zero when unknown
 	Artists    []string
 	Album      AlbumInfo
 
+	// Audio features, nil when Spotify has none for the track
+	AudioFeatures *AudioFeatures `json:"audio_features,omitempty"`
+
+	// Last.fm scrobble data of the user, nil and empty when they linked no Last.fm account or the
+	// track couldn't be looked up
+	PlayCount  *int     `json:"play_count,omitempty"`
+	LastFmTags []string `json:"lastfm_tags,omitempty"` // Normalized top tags of the track
+
 	// Pre-processed data for efficient filtering
-	ReleaseYear  int      `json:"release_year"`
-	AllGenres    []string `json:"all_genres"` // Normalized genres from all track artists
-	MaxArtistPop int      `json:"max_artist_popularity"`
-	ArtistNames  []string `json:"artist_names"` // Artist names for keyword matching
+	ReleaseYear  int       `json:"release_year"`
+	ReleaseDate  time.Time `json:"release_date"` // Zero when unknown, first day of the year or month for less precise dates
+	AllGenres    []string  `json:"all_genres"`   // Normalized genres from all track artists
+	MaxArtistPop int       `json:"max_artist_popularity"`
+	ArtistNames  []string  `json:"artist_names"` // Artist names for keyword matching
+}
+
+// AudioFeatures contains the Spotify audio analysis of a track
+type AudioFeatures struct {
+	Tempo            float64 // Beats per minute
+	Energy           float64 // 0.0 - 1.0
+	Danceability     float64 // 0.0 - 1.0
+	Valence          float64 // 0.0 - 1.0, musical positiveness
+	Acousticness     float64 // 0.0 - 1.0
+	Instrumentalness float64 // 0.0 - 1.0
+	Liveness         float64 // 0.0 - 1.0
+	Speechiness      float64 // 0.0 - 1.0
+	Loudness         float64 // Decibels, typically -60 - 0
+	Key              int     // Pitch class, 0 = C ... 11 = B, -1 when undetected
+	Mode             int     // 1 = major, 0 = minor
 }
 
 type ArtistInfo struct {
@@ -58,3 +95,73 @@ func (p *PlaylistTracksInfo) GetAllArtists() []string {
 
 	return uniqueArtistIDs
 }
+
+// GetAllTrackIDs returns the unique Spotify IDs of the playlist tracks, skipping local tracks without one
+func (p *PlaylistTracksInfo) GetAllTrackIDs() []string {
+	seen := make(map[string]bool, len(p.Tracks))
+	trackIDs := make([]string, 0, len(p.Tracks))
+
+	for _, track := range p.Tracks {
+		if track.ID == "" || seen[track.ID] {
+			continue
+		}
+
+		seen[track.ID] = true
+		trackIDs = append(trackIDs, track.ID)
+	}
+
+	return trackIDs
+}
+
+// MergePlaylistTracks combines the tracks of several playlists in order, keeping the first occurrence
+// of tracks found in more than one. The result takes the playlist and user of the first one, the
+// other playlists are listed as its sources
+func MergePlaylistTracks(playlists ...*PlaylistTracksInfo) *PlaylistTracksInfo {
+	merged := &PlaylistTracksInfo{
+		Tracks:  make([]TrackInfo, 0),
+		Artists: make(map[string]ArtistInfo),
+	}
+	if len(playlists) > 0 {
+		merged.PlaylistID = playlists[0].PlaylistID
+		merged.UserID = playlists[0].UserID
+	}
+
+	seen := make(map[string]bool)
+	for _, playlist := range playlists {
+		for _, playlistID := range playlist.BasePlaylistIDs() {
+			if playlistID != merged.PlaylistID && !slices.Contains(merged.SourcePlaylistIDs, playlistID) {
+				merged.SourcePlaylistIDs = append(merged.SourcePlaylistIDs, playlistID)
+			}
+		}
+
+		for _, track := range playlist.Tracks {
+			if seen[track.URI] {
+				continue
+			}
+
+			seen[track.URI] = true
+			merged.Tracks = append(merged.Tracks, track)
+		}
+
+		for id, artist := range playlist.Artists {
+			merged.Artists[id] = artist
+		}
+		merged.APICallCount += playlist.APICallCount
+	}
+
+	return merged
+}
+
+// RoutedTracks are the tracks of a page of a streamed base playlist routed to a child playlist, in
+// base playlist order
+type RoutedTracks struct {
+	SpotifyPlaylistID string
+	TrackURIs         []string
+}
+
+// StreamRoutingSummary counts the tracks of a streamed base playlist, which are never all held at once
+type StreamRoutingSummary struct {
+	TracksProcessed int
+	TracksUnmatched int // Tracks matching no child playlist filter, as counted for batch syncs
+	APICallCount    int
+}
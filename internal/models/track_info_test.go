@@ -133,3 +133,65 @@ func TestPlaylistTracksInfo_GetAllArtists(t *testing.T) {
 		})
 	}
 }
+
+func TestPlaylistTracksInfo_GetAllTrackIDs(t *testing.T) {
+	assert := require.New(t)
+
+	playlistTracks := PlaylistTracksInfo{
+		Tracks: []TrackInfo{
+			{ID: "track1"},
+			{ID: "track2"},
+			{ID: "track1"}, // Duplicate
+			{ID: ""},       // Local track
+		},
+	}
+
+	assert.Equal([]string{"track1", "track2"}, playlistTracks.GetAllTrackIDs())
+	assert.Empty((&PlaylistTracksInfo{}).GetAllTrackIDs())
+}
+
+func TestMergePlaylistTracks(t *testing.T) {
+	assert := require.New(t)
+
+	base := &PlaylistTracksInfo{
+		PlaylistID: "base1",
+		UserID:     "user123",
+		Tracks: []TrackInfo{
+			{ID: "track1", URI: "spotify:track:1", Artists: []string{"artist1"}},
+			{ID: "track2", URI: "spotify:track:2", Artists: []string{"artist2"}},
+		},
+		Artists:      map[string]ArtistInfo{"artist1": {ID: "artist1"}, "artist2": {ID: "artist2"}},
+		APICallCount: 3,
+	}
+	source := &PlaylistTracksInfo{
+		PlaylistID: "base2",
+		UserID:     "user123",
+		Tracks: []TrackInfo{
+			{ID: "track2", URI: "spotify:track:2", Artists: []string{"artist2"}},
+			{ID: "track3", URI: "spotify:track:3", Artists: []string{"artist3"}},
+		},
+		Artists:      map[string]ArtistInfo{"artist2": {ID: "artist2"}, "artist3": {ID: "artist3"}},
+		APICallCount: 2,
+	}
+
+	merged := MergePlaylistTracks(base, source)
+
+	assert.Equal("base1", merged.PlaylistID)
+	assert.Equal([]string{"base2"}, merged.SourcePlaylistIDs)
+	assert.Equal([]string{"base1", "base2"}, merged.BasePlaylistIDs())
+	assert.Equal("user123", merged.UserID)
+	assert.Len(merged.Tracks, 3)
+	assert.Equal("spotify:track:1", merged.Tracks[0].URI)
+	assert.Equal("spotify:track:2", merged.Tracks[1].URI)
+	assert.Equal("spotify:track:3", merged.Tracks[2].URI)
+	assert.Len(merged.Artists, 3)
+	assert.Equal(5, merged.APICallCount)
+
+	// Inputs are left untouched
+	assert.Len(base.Tracks, 2)
+	assert.Len(base.Artists, 2)
+
+	// Sources of already merged playlists are kept, without repeating the base playlist
+	third := &PlaylistTracksInfo{PlaylistID: "base3", SourcePlaylistIDs: []string{"base1", "base4"}}
+	assert.Equal([]string{"base2", "base3", "base4"}, MergePlaylistTracks(merged, third).SourcePlaylistIDs)
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// UnmatchedTracks lists the tracks of a base playlist that match the filter rules of no child
+// playlist, with the rules that kept each child playlist from matching them
+type UnmatchedTracks struct {
+	BasePlaylistID string           `json:"base_playlist_id"`
+	TotalTracks    int              `json:"total_tracks"`
+	Tracks         []UnmatchedTrack `json:"tracks"`
+	ComputedAt     time.Time        `json:"computed_at"`
+}
+
+type UnmatchedTrack struct {
+	TrackURI  string         `json:"track_uri"`
+	TrackName string         `json:"track_name"`
+	Artists   []string       `json:"artists"`
+	Outcome   RoutingOutcome `json:"outcome"` // fallback when a fallback child playlist receives the track, unmatched otherwise
+	// Active non fallback child playlists from highest to lowest priority
+	ChildEvaluations []ChildFilterEvaluation `json:"child_evaluations"`
+}
+
+// ChildFilterEvaluation names the top level filter rules of a child playlist a track doesn't match
+type ChildFilterEvaluation struct {
+	ChildPlaylistID   string   `json:"child_playlist_id"`
+	ChildPlaylistName string   `json:"child_playlist_name"`
+	FailedRules       []string `json:"failed_rules"`
+}
@@ -1,15 +1,36 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
+
+// UserRole grants access to groups of routes, every user has the user role
+type UserRole string
+
+const (
+	RoleUser  UserRole = "user"
+	RoleAdmin UserRole = "admin"
+)
 
 // User represents a user in the PocketBase users collection
 type User struct {
-	ID       string    `json:"id" db:"id"`
-	Username string    `json:"username" db:"username"`
-	Email    string    `json:"email" db:"email"`
-	Name     string    `json:"name" db:"name"`
-	Created  time.Time `json:"created" db:"created"`
-	Updated  time.Time `json:"updated" db:"updated"`
+	ID       string `json:"id" db:"id"`
+	Username string `json:"username" db:"username"`
+	Email    string `json:"email" db:"email"`
+	Name     string `json:"name" db:"name"`
+	// DisconnectedSpotifyID is the spotify account the user disconnected, so reconnecting it re-links
+	// the user instead of creating a new one
+	DisconnectedSpotifyID string     `json:"disconnected_spotify_id,omitempty" db:"disconnected_spotify_id"`
+	Roles                 []UserRole `json:"roles" db:"roles"`
+	Disabled              bool       `json:"disabled" db:"disabled"` // Disabled users can't log in nor use their API keys
+	Created               time.Time  `json:"created" db:"created"`
+	Updated               time.Time  `json:"updated" db:"updated"`
+}
+
+// HasRole reports whether the user was granted the role
+func (u *User) HasRole(role UserRole) bool {
+	return slices.Contains(u.Roles, role)
 }
 
 // ToAuthUser converts a User to an AuthUser for API responses
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// YouTubeMusicIntegration is the YouTube Music account a user linked, one per user. Its channel owns
+// the playlists of the base and child playlists kept on YouTube Music
+type YouTubeMusicIntegration struct {
+	ID      string    `json:"id" db:"id"`
+	Created time.Time `json:"created" db:"created"`
+	Updated time.Time `json:"updated" db:"updated"`
+
+	// Foreign key to users collection
+	UserID string `json:"user_id" db:"user"`
+
+	// YouTube channel of the account
+	ChannelID string `json:"channel_id" db:"channel_id"`
+
+	// Authentication tokens (hidden from JSON responses)
+	AccessToken  string    `json:"-" db:"access_token"`
+	RefreshToken string    `json:"-" db:"refresh_token"`
+	TokenType    string    `json:"-" db:"token_type"`
+	ExpiresAt    time.Time `json:"-" db:"expires_at"`
+	Scope        string    `json:"-" db:"scope"`
+
+	// Title of the channel
+	DisplayName string `json:"display_name" db:"display_name"`
+}
+
+type YouTubeMusicIntegrationTokenRefresh = OAuthTokenRefresh
@@ -0,0 +1,15 @@
+// Package openapi describes the routes of the API as an OpenAPI 3 document. The document is
+// generated from Routes into openapi.json, which is embedded and served by the app
+package openapi
+
+import _ "embed"
+
+//go:generate go run ../../cmd/openapi -o openapi.json
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns the generated OpenAPI document as JSON
+func Spec() []byte {
+	return spec
+}
@@ -0,0 +1,210 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpec_UpToDate(t *testing.T) {
+	assert := require.New(t)
+
+	generated, err := Generate()
+	assert.NoError(err)
+	assert.Equal(string(generated), string(Spec()), "openapi.json is stale, run go generate ./internal/openapi")
+}
+
+var (
+	groupPattern = regexp.MustCompile(`(\w+) := (\w+(?:\.Router)?)\.Group\("([^"]*)"\)`)
+	// Groups registered under several paths in a loop
+	groupLoopPattern = regexp.MustCompile(`range \[\]string\{([^}]*)\} \{\s*(\w+) := (\w+)\.Group\(\w+\)`)
+	routePattern     = regexp.MustCompile(`(\w+(?:\.Router)?)\.(GET|POST|PUT|PATCH|DELETE)\("([^"]*)"`)
+)
+
+// The routes registered in main.go are read from its source, so a new route can't be left out
+func TestRoutes_DescribeEveryRegisteredRoute(t *testing.T) {
+	assert := require.New(t)
+
+	source, err := os.ReadFile("../../cmd/pb/main.go")
+	assert.NoError(err)
+
+	prefixes := map[string][]string{"e.Router": {""}}
+	addGroup := func(name, parent string, paths ...string) {
+		parentPrefixes, ok := prefixes[parent]
+		assert.True(ok, "group %s of unknown parent %s", name, parent)
+		for _, parentPrefix := range parentPrefixes {
+			for _, path := range paths {
+				prefixes[name] = append(prefixes[name], parentPrefix+path)
+			}
+		}
+	}
+	for _, match := range groupPattern.FindAllStringSubmatch(string(source), -1) {
+		addGroup(match[1], match[2], match[3])
+	}
+	for _, match := range groupLoopPattern.FindAllStringSubmatch(string(source), -1) {
+		var paths []string
+		for _, path := range strings.Split(match[1], ",") {
+			paths = append(paths, strings.Trim(strings.TrimSpace(path), `"`))
+		}
+		addGroup(match[2], match[3], paths...)
+	}
+
+	described := make(map[string]bool, len(Routes))
+	for _, route := range Routes {
+		described[route.Method+" "+route.Path] = true
+	}
+
+	registered := 0
+	for _, match := range routePattern.FindAllStringSubmatch(string(source), -1) {
+		groupPrefixes, ok := prefixes[match[1]]
+		assert.True(ok, "route %s of unknown group %s", match[3], match[1])
+
+		for _, prefix := range groupPrefixes {
+			route := match[2] + " " + prefix + match[3]
+			if route == "GET /{path...}" {
+				// Frontend files
+				continue
+			}
+
+			assert.True(described[route], "%s is not described in Routes", route)
+			registered++
+		}
+	}
+	assert.Len(Routes, registered)
+}
+
+func TestBuild(t *testing.T) {
+	assert := require.New(t)
+
+	type item struct {
+		Name string `json:"name" validate:"required,max=10"`
+	}
+	type page struct {
+		Count int `json:"count"`
+	}
+	type request struct {
+		Name     string   `json:"name" validate:"required,min=1,max=100"`
+		Tags     []string `json:"tags,omitempty" validate:"omitempty,max=3,dive,oneof=a b"`
+		Limit    *int     `json:"limit,omitempty" validate:"omitempty,min=1"`
+		Version  int      `json:"version" validate:"required,eq=1"`
+		Internal string   `json:"-"`
+		hidden   string
+	}
+	type response struct {
+		page
+		Items     []item `json:"items"`
+		Parent    *item  `json:"parent"`
+		Labels    map[string]int
+		Created   time.Time  `json:"created"`
+		Heartbeat *time.Time `json:"heartbeat"`
+	}
+
+	doc, err := Build([]Route{
+		{
+			Method: http.MethodPost, Path: "/api/things/{thingID}/items", OperationID: "createItem", Tag: "things",
+			Auth:      AuthUser,
+			Query:     []Param{{Name: "dry_run", Type: "boolean"}},
+			Request:   jsonBody(request{}),
+			Responses: created(response{}),
+		},
+		{
+			Method: http.MethodGet, Path: "/public", OperationID: "public", Tag: "things",
+			Responses: []RouteResponse{{Status: http.StatusOK, ContentType: "text/plain"}},
+		},
+	})
+	assert.NoError(err)
+
+	var spec map[string]any
+	raw, err := json.Marshal(doc)
+	assert.NoError(err)
+	assert.NoError(json.Unmarshal(raw, &spec))
+
+	operation := spec["paths"].(map[string]any)["/api/things/{thingID}/items"].(map[string]any)["post"].(map[string]any)
+	assert.Equal([]any{map[string]any{"bearerAuth": []any{}}}, operation["security"])
+	assert.Equal([]any{
+		map[string]any{"name": "thingID", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+		map[string]any{"name": "dry_run", "in": "query", "schema": map[string]any{"type": "boolean"}},
+	}, operation["parameters"])
+	assert.Equal(
+		map[string]any{"$ref": "#/components/schemas/response"},
+		operation["responses"].(map[string]any)["201"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"],
+	)
+	assert.Equal(
+		map[string]any{"$ref": "#/components/schemas/Problem"},
+		operation["responses"].(map[string]any)["default"].(map[string]any)["content"].(map[string]any)["application/problem+json"].(map[string]any)["schema"],
+	)
+
+	public := spec["paths"].(map[string]any)["/public"].(map[string]any)["get"].(map[string]any)
+	assert.Equal([]any{}, public["security"])
+	assert.Equal(
+		map[string]any{"type": "string", "format": "binary"},
+		public["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["text/plain"].(map[string]any)["schema"],
+	)
+
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	assert.Equal(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":    map[string]any{"type": "string", "minLength": float64(1), "maxLength": float64(100)},
+			"tags":    map[string]any{"type": "array", "maxItems": float64(3), "items": map[string]any{"type": "string", "enum": []any{"a", "b"}}},
+			"limit":   map[string]any{"type": "integer", "format": "int32", "minimum": float64(1)},
+			"version": map[string]any{"type": "integer", "format": "int32", "enum": []any{float64(1)}},
+		},
+		"required": []any{"name", "version"},
+	}, schemas["request"])
+	assert.Equal(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"count":     map[string]any{"type": "integer", "format": "int32"},
+			"items":     map[string]any{"type": "array", "items": map[string]any{"$ref": "#/components/schemas/item"}},
+			"parent":    map[string]any{"allOf": []any{map[string]any{"$ref": "#/components/schemas/item"}}, "nullable": true},
+			"Labels":    map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "integer", "format": "int32"}},
+			"created":   map[string]any{"type": "string", "format": "date-time"},
+			"heartbeat": map[string]any{"type": "string", "format": "date-time", "nullable": true},
+		},
+	}, schemas["response"])
+	assert.Contains(schemas, "item")
+}
+
+func TestBuild_InvalidRoutes(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes []Route
+		err    string
+	}{
+		{
+			name: "duplicated operation id",
+			routes: []Route{
+				{Method: http.MethodGet, Path: "/a", OperationID: "get", Responses: noContent()},
+				{Method: http.MethodGet, Path: "/b", OperationID: "get", Responses: noContent()},
+			},
+			err: "duplicated operation id get",
+		},
+		{
+			name: "route described twice",
+			routes: []Route{
+				{Method: http.MethodGet, Path: "/a", OperationID: "getA", Responses: noContent()},
+				{Method: http.MethodGet, Path: "/a", OperationID: "getAgain", Responses: noContent()},
+			},
+			err: "GET /a is described twice",
+		},
+		{
+			name:   "no responses",
+			routes: []Route{{Method: http.MethodGet, Path: "/a", OperationID: "getA"}},
+			err:    "GET /a: no responses",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Build(tt.routes)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
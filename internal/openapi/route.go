@@ -0,0 +1,97 @@
+package openapi
+
+// Auth is how a route authenticates its caller
+type Auth int
+
+const (
+	// AuthNone routes are public or authorized by a token in the path
+	AuthNone Auth = iota
+	// AuthUser routes take the auth token of the user
+	AuthUser
+	// AuthUserOrAPIKey routes also take an API key with the right scope as bearer token
+	AuthUserOrAPIKey
+	// AuthAPIKey routes take an API key in the X-API-Key header
+	AuthAPIKey
+	// AuthSuperuser routes are restricted to PocketBase superusers
+	AuthSuperuser
+)
+
+func (a Auth) requirements() []map[string][]string {
+	switch a {
+	case AuthUser, AuthUserOrAPIKey:
+		return []map[string][]string{{"bearerAuth": {}}}
+	case AuthAPIKey:
+		return []map[string][]string{{"apiKeyHeader": {}}}
+	case AuthSuperuser:
+		return []map[string][]string{{"superuserAuth": {}}}
+	default:
+		// An empty list, unlike a missing one, says the route needs no credentials
+		return []map[string][]string{}
+	}
+}
+
+// Route describes a route of the API. Path parameters are read from the path
+type Route struct {
+	Method      string
+	Path        string
+	OperationID string
+	Tag         string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Auth        Auth
+	Query       []Param
+	Headers     []Param
+	Request     *Body
+	Responses   []RouteResponse
+}
+
+// Param is a query or header parameter, a string unless Type says otherwise
+type Param struct {
+	Name        string
+	Type        string
+	Format      string
+	Description string
+	Required    bool
+	Enum        []any
+}
+
+func (p Param) schema() *Schema {
+	paramType := p.Type
+	if paramType == "" {
+		paramType = "string"
+	}
+
+	return &Schema{Type: paramType, Format: p.Format, Enum: p.Enum}
+}
+
+// Body is the request body of a route. Body holds a value of the decoded type, nil for raw bodies
+type Body struct {
+	Body         any
+	ContentTypes []string
+}
+
+func (b *Body) contentTypes() []string {
+	if len(b.ContentTypes) == 0 {
+		return []string{"application/json"}
+	}
+
+	return b.ContentTypes
+}
+
+// RouteResponse is a successful response of a route. Body holds a value of the encoded type, nil
+// for empty responses, or raw ones when ContentType is set
+type RouteResponse struct {
+	Status      int
+	Description string
+	Body        any
+	ContentType string
+}
+
+func (r RouteResponse) contentType() string {
+	if r.ContentType == "" {
+		return "application/json"
+	}
+
+	return r.ContentType
+}
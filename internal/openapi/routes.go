@@ -0,0 +1,802 @@
+package openapi
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/ngomez18/playlist-router/internal/middleware"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// API_VERSION is the version of the API in the document, bumped on breaking changes
+const API_VERSION = "1.0.0"
+
+var tags = []Tag{
+	{Name: "auth", Description: "Spotify login and auth tokens"},
+	{Name: "account", Description: "Data and settings of the user account"},
+	{Name: "base_playlists", Description: "Spotify playlists the tracks are routed from"},
+	{Name: "child_playlists", Description: "Playlists the tracks of a base playlist are routed to by filter rules"},
+	{Name: "templates", Description: "Reusable sets of child playlists"},
+	{Name: "sync", Description: "Routing the tracks of base playlists into their child playlists"},
+	{Name: "api_keys", Description: "API keys for scripts and automations"},
+	{Name: "webhooks", Description: "Webhooks notified when a base playlist changes"},
+	{Name: "blocklist", Description: "Tracks and artists never routed from a base playlist"},
+	{Name: "spotify", Description: "Linked spotify accounts and their playlists"},
+	{Name: "youtube_music", Description: "Linked youtube music account and its playlists"},
+	{Name: "tidal", Description: "Linked tidal account and its playlists"},
+	{Name: "lastfm", Description: "Linked last.fm account, whose scrobbles child playlists filter on"},
+	{Name: "public", Description: "Routes authorized by a token in the path"},
+	{Name: "automation", Description: "Zapier/IFTTT style triggers and actions"},
+	{Name: "admin", Description: "Routes restricted to admins and superusers"},
+	{Name: "events", Description: "Live updates pushed to the app"},
+	{Name: "docs", Description: "API documentation"},
+}
+
+var (
+	paginationParams = []Param{
+		{Name: "limit", Type: "integer", Description: "Maximum number of items in the page"},
+		{Name: "offset", Type: "integer", Description: "Number of items skipped"},
+	}
+	syncEventFilterParams = slices.Concat(paginationParams, []Param{
+		{Name: "sort", Enum: []any{models.ListSortCreatedAsc, models.ListSortCreatedDesc}},
+		{Name: "status", Enum: []any{
+			models.SyncStatusInProgress,
+			models.SyncStatusCompleted,
+			models.SyncStatusFailed,
+			models.SyncStatusNeedsConfirmation,
+			models.SyncStatusConfirmed,
+		}},
+		{Name: "created_after", Format: "date-time"},
+		{Name: "created_before", Format: "date-time"},
+	})
+	syncEventPruneParams = []Param{
+		{Name: "older_than_days", Type: "integer", Required: true, Description: "Only finished syncs created more than this many days ago are deleted"},
+	}
+	profileParam = Param{Name: "profile", Type: "boolean", Description: "Adds the per-step timing profile to the sync event"}
+
+	// Routes that call spotify can act as another of the linked spotify accounts of the user
+	spotifyAccountHeaders = []Param{
+		{Name: middleware.SPOTIFY_ACCOUNT_HEADER, Description: "Integration ID of the linked spotify account to act as, the default one when missing"},
+	}
+)
+
+func ok(body any) []RouteResponse {
+	return []RouteResponse{{Status: http.StatusOK, Body: body}}
+}
+
+func created(body any) []RouteResponse {
+	return []RouteResponse{{Status: http.StatusCreated, Body: body}}
+}
+
+func noContent() []RouteResponse {
+	return []RouteResponse{{Status: http.StatusNoContent}}
+}
+
+func jsonBody(body any) *Body {
+	return &Body{Body: body}
+}
+
+// Routes describes every route registered by the app. New routes are added here too, then
+// openapi.json is regenerated with go generate
+var Routes = []Route{
+	// Auth
+	{
+		Method: http.MethodGet, Path: "/auth/spotify/login", OperationID: "spotifyLogin", Tag: "auth",
+		Summary:   "Start the spotify login",
+		Responses: []RouteResponse{{Status: http.StatusTemporaryRedirect, Description: "Redirect to the spotify authorization page"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/auth/spotify/callback", OperationID: "spotifyCallback", Tag: "auth",
+		Summary: "Complete the spotify login",
+		Query: []Param{
+			{Name: "code", Required: true, Description: "Authorization code issued by spotify"},
+			{Name: "state"},
+		},
+		Responses: []RouteResponse{{Status: http.StatusTemporaryRedirect, Description: "Redirect to the frontend with the auth token in the token parameter"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/auth/validate", OperationID: "validateToken", Tag: "auth",
+		Summary: "Return the user of the auth token", Auth: AuthUser,
+		Responses: ok(models.User{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/spotify/link", OperationID: "linkSpotifyAccount", Tag: "auth",
+		Summary: "Start linking another spotify account", Auth: AuthUser,
+		Responses: ok(map[string]string{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/youtube_music/link", OperationID: "linkYouTubeMusicAccount", Tag: "auth",
+		Summary: "Start linking a youtube music account", Auth: AuthUser,
+		Responses: ok(map[string]string{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/auth/youtube_music/callback", OperationID: "youTubeMusicCallback", Tag: "auth",
+		Summary: "Complete linking a youtube music account",
+		Query: []Param{
+			{Name: "code", Required: true, Description: "Authorization code issued by Google"},
+			{Name: "state", Required: true},
+		},
+		Responses: []RouteResponse{{Status: http.StatusTemporaryRedirect, Description: "Redirect to the frontend"}},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/tidal/link", OperationID: "linkTidalAccount", Tag: "auth",
+		Summary: "Start linking a tidal account", Auth: AuthUser,
+		Description: "Starts the device flow, the user enters the returned code at the verification URI",
+		Responses:   ok(models.TidalDeviceLink{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/tidal/link/poll", OperationID: "pollTidalAccountLink", Tag: "auth",
+		Summary: "Complete linking a tidal account", Auth: AuthUser,
+		Description: "Polled every interval seconds until the user entered the code, answering 202 until then",
+		Responses: []RouteResponse{
+			{Status: http.StatusOK, Body: models.TidalIntegration{}},
+			{Status: http.StatusAccepted, Description: "The user hasn't entered the code yet", Body: map[string]string{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/lastfm/link", OperationID: "linkLastFmAccount", Tag: "auth",
+		Summary: "Start linking a last.fm account", Auth: AuthUser,
+		Responses: ok(map[string]string{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/auth/lastfm/callback", OperationID: "lastFmCallback", Tag: "auth",
+		Summary: "Complete linking a last.fm account",
+		Query: []Param{
+			{Name: "token", Required: true, Description: "Token of the web auth the user approved on Last.fm"},
+			{Name: "state", Required: true},
+		},
+		Responses: []RouteResponse{{Status: http.StatusTemporaryRedirect, Description: "Redirect to the frontend"}},
+	},
+	{
+		Method: http.MethodPost, Path: "/auth/logout", OperationID: "logout", Tag: "auth",
+		Summary: "Revoke every auth token of the user", Auth: AuthUser,
+		Responses: noContent(),
+	},
+
+	// Base playlists
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist", OperationID: "createBasePlaylist", Tag: "base_playlists",
+		Summary: "Create a base playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Request:   jsonBody(models.CreateBasePlaylistRequest{}),
+		Responses: created(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist", OperationID: "listBasePlaylists", Tag: "base_playlists",
+		Summary: "List the base playlists of the user with their child playlists", Auth: AuthUserOrAPIKey,
+		Description: "Also accepts API keys with the sync:read scope",
+		Query: slices.Concat(paginationParams, []Param{
+			{Name: "sort", Enum: []any{models.ListSortCreatedAsc, models.ListSortCreatedDesc, models.ListSortNameAsc, models.ListSortNameDesc}},
+			{Name: "is_active", Type: "boolean"},
+			{Name: "archived", Type: "boolean"},
+		}),
+		Responses: ok(models.BasePlaylistPage{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{id}", OperationID: "getBasePlaylist", Tag: "base_playlists",
+		Summary: "Get a base playlist", Auth: AuthUser,
+		Responses: ok(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodPut, Path: "/api/base_playlist/{id}", OperationID: "updateBasePlaylist", Tag: "base_playlists",
+		Summary: "Update a base playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Request:   jsonBody(models.UpdateBasePlaylistRequest{}),
+		Responses: ok(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/base_playlist/{id}", OperationID: "deleteBasePlaylist", Tag: "base_playlists",
+		Summary: "Delete a base playlist with its child playlists", Auth: AuthUser,
+		Responses: []RouteResponse{{Status: http.StatusOK}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{id}/restore", OperationID: "restoreBasePlaylist", Tag: "base_playlists",
+		Summary: "Restore a deleted base playlist", Auth: AuthUser,
+		Responses: ok(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{id}/overview", OperationID: "getBasePlaylistOverview", Tag: "base_playlists",
+		Summary: "Get the dashboard overview of a base playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.BasePlaylistOverview{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{id}/unmatched", OperationID: "listUnmatchedTracks", Tag: "base_playlists",
+		Summary: "List the tracks no child playlist matches", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.UnmatchedTracks{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{id}/clone", OperationID: "cloneBasePlaylist", Tag: "base_playlists",
+		Summary: "Clone the child playlists of a base playlist onto another spotify playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Request:   jsonBody(models.CloneBasePlaylistRequest{}),
+		Responses: created(models.BasePlaylistWithChilds{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/import", OperationID: "importRoutingConfig", Tag: "base_playlists",
+		Summary: "Import a routing config", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Request: &Body{
+			Body:         models.RoutingConfig{},
+			ContentTypes: []string{"application/json", "application/yaml"},
+		},
+		Responses: ok(models.BasePlaylistWithChilds{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/import_tracks", OperationID: "importTracks", Tag: "base_playlists",
+		Summary: "Create a base playlist from a CSV or JSON file of tracks", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Query: []Param{
+			{Name: "name", Required: true, Description: "Name of the new base playlist and its spotify playlist"},
+			{Name: "dedupe_strategy", Enum: []any{"all_matches", "first_match"}},
+			{Name: "spotify_integration_id", Description: "Linked spotify account of the playlist, the default one when missing"},
+		},
+		Request: &Body{
+			Body:         []models.ImportedTrack{},
+			ContentTypes: []string{"application/json", "text/csv"},
+		},
+		Responses: created(models.ImportTracksResult{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{id}/config/export", OperationID: "exportRoutingConfig", Tag: "base_playlists",
+		Summary: "Export the routing config of a base playlist as an attachment", Auth: AuthUserOrAPIKey,
+		Description: "Also accepts API keys with the sync:read scope",
+		Query:       []Param{{Name: "format", Enum: []any{"json", "yaml"}}},
+		Responses: []RouteResponse{{
+			Status: http.StatusOK, Body: models.RoutingConfig{}, ContentType: "application/json",
+		}},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{id}/hooks", OperationID: "enableBasePlaylistHooks", Tag: "base_playlists",
+		Summary: "Enable the automation hooks of a base playlist", Auth: AuthUser,
+		Responses: ok(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/base_playlist/{id}/hooks", OperationID: "disableBasePlaylistHooks", Tag: "base_playlists",
+		Summary: "Disable the automation hooks of a base playlist", Auth: AuthUser,
+		Responses: ok(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{id}/archive", OperationID: "archiveBasePlaylist", Tag: "base_playlists",
+		Summary: "Archive a base playlist", Auth: AuthUser,
+		Responses: ok(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/base_playlist/{id}/archive", OperationID: "unarchiveBasePlaylist", Tag: "base_playlists",
+		Summary: "Unarchive a base playlist", Auth: AuthUser,
+		Responses: ok(models.BasePlaylist{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{basePlaylistID}/sync", OperationID: "syncBasePlaylist", Tag: "sync",
+		Summary: "Sync a base playlist", Auth: AuthUserOrAPIKey, Headers: spotifyAccountHeaders,
+		Description: "Also accepts API keys with the sync:write scope",
+		Query:       []Param{profileParam},
+		Responses: []RouteResponse{
+			{Status: http.StatusOK, Body: models.SyncEvent{}},
+			{Status: http.StatusConflict, Description: "Sync held back until the user confirms its anomalies", Body: models.SyncEvent{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{basePlaylistID}/sync_events", OperationID: "listBasePlaylistSyncEvents", Tag: "sync",
+		Summary: "List the sync history of a base playlist", Auth: AuthUserOrAPIKey,
+		Description: "Also accepts API keys with the sync:read scope",
+		Query:       syncEventFilterParams,
+		Responses:   ok(models.SyncEventPage{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/base_playlist/{basePlaylistID}/sync_events", OperationID: "pruneBasePlaylistSyncEvents", Tag: "sync",
+		Summary: "Delete the old sync history of a base playlist", Auth: AuthUserOrAPIKey,
+		Description: "Keeps the latest sync and the latest completed sync. Also accepts API keys with the sync:write scope",
+		Query:       syncEventPruneParams,
+		Responses:   ok(models.SyncEventPruneResult{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{basePlaylistID}/webhooks", OperationID: "createWebhook", Tag: "webhooks",
+		Summary: "Create a webhook for a base playlist", Auth: AuthUser,
+		Request:   jsonBody(models.CreatePlaylistWebhookRequest{}),
+		Responses: created(models.CreatedPlaylistWebhook{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{basePlaylistID}/webhooks", OperationID: "listWebhooks", Tag: "webhooks",
+		Summary: "List the webhooks of a base playlist", Auth: AuthUser,
+		Responses: ok([]models.PlaylistWebhook{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{basePlaylistID}/blocklist", OperationID: "createBlocklistEntry", Tag: "blocklist",
+		Summary: "Block a track or artist from a base playlist", Auth: AuthUser,
+		Request:   jsonBody(models.CreateBlocklistEntryRequest{}),
+		Responses: created(models.BlocklistEntry{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{basePlaylistID}/blocklist", OperationID: "listBlocklistEntries", Tag: "blocklist",
+		Summary: "List the blocklist of a base playlist", Auth: AuthUser,
+		Responses: ok([]models.BlocklistEntry{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{basePlaylistID}/audit", OperationID: "auditBasePlaylist", Tag: "sync",
+		Summary: "Audit the child playlists of a base playlist against their filter rules", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.BasePlaylistAudit{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{basePlaylistID}/filter_preview", OperationID: "previewFilters", Tag: "child_playlists",
+		Summary: "Preview the tracks filter rules would route", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Request:   jsonBody(models.FilterPreviewRequest{}),
+		Responses: ok(models.FilterPreview{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/base_playlist/{basePlaylistID}/child_playlist", OperationID: "createChildPlaylist", Tag: "child_playlists",
+		Summary: "Create a child playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Request:   jsonBody(models.CreateChildPlaylistRequest{}),
+		Responses: created(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/base_playlist/{basePlaylistID}/child_playlist", OperationID: "listChildPlaylists", Tag: "child_playlists",
+		Summary: "List the child playlists of a base playlist", Auth: AuthUser,
+		Responses: ok([]models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodPatch, Path: "/api/base_playlist/{basePlaylistID}/child_playlist/order", OperationID: "reorderChildPlaylists", Tag: "child_playlists",
+		Summary: "Reorder the child playlists of a base playlist", Auth: AuthUser,
+		Request:   jsonBody(models.ReorderChildPlaylistsRequest{}),
+		Responses: ok([]models.ChildPlaylist{}),
+	},
+
+	// Child playlists
+	{
+		Method: http.MethodGet, Path: "/api/child_playlist/{id}", OperationID: "getChildPlaylist", Tag: "child_playlists",
+		Summary: "Get a child playlist", Auth: AuthUser,
+		Responses: ok(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodPut, Path: "/api/child_playlist/{id}", OperationID: "updateChildPlaylist", Tag: "child_playlists",
+		Summary: "Update a child playlist", Auth: AuthUser,
+		Description: "Refused with 409 when the child playlist was modified since the version in If-Match or expected_updated",
+		Headers: append([]Param{
+			{Name: "If-Match", Description: "ETag of the child playlist the edit started from, as returned when reading it"},
+		}, spotifyAccountHeaders...),
+		Request:   jsonBody(models.UpdateChildPlaylistRequest{}),
+		Responses: ok(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/child_playlist/{id}", OperationID: "deleteChildPlaylist", Tag: "child_playlists",
+		Summary: "Delete a child playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: noContent(),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/child_playlist/{id}/restore", OperationID: "restoreChildPlaylist", Tag: "child_playlists",
+		Summary: "Restore a deleted child playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/child_playlist/{id}/stats", OperationID: "getChildPlaylistStats", Tag: "child_playlists",
+		Summary: "Get the stats of a child playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.ChildPlaylistStats{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/child_playlist/{id}/export", OperationID: "exportChildPlaylist", Tag: "child_playlists",
+		Summary: "Export the routed tracks of a child playlist as an attachment", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Query: []Param{{Name: "format", Enum: []any{"m3u", "csv", "json"}}},
+		Responses: []RouteResponse{{
+			Status: http.StatusOK, Body: []models.ExportedTrack{}, ContentType: "application/json",
+		}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/child_playlist/{id}/rule_history", OperationID: "getFilterRuleHistory", Tag: "child_playlists",
+		Summary: "List the changes to the filter rules of a child playlist", Auth: AuthUser,
+		Responses: ok([]models.FilterRuleChange{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/child_playlist/{id}/rule_history/{changeID}/diff", OperationID: "computeRoutingDiff", Tag: "child_playlists",
+		Summary: "Compute how a filter rule change changed the routed tracks", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.FilterRuleChange{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/child_playlist/{id}/share", OperationID: "shareChildPlaylist", Tag: "child_playlists",
+		Summary: "Share a child playlist through its embeddable widget", Auth: AuthUser,
+		Responses: ok(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/child_playlist/{id}/share", OperationID: "unshareChildPlaylist", Tag: "child_playlists",
+		Summary: "Stop sharing a child playlist", Auth: AuthUser,
+		Responses: ok(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/child_playlist/{id}/pinned_tracks", OperationID: "pinTracks", Tag: "child_playlists",
+		Summary: "Pin tracks to a child playlist", Auth: AuthUser,
+		Request:   jsonBody(models.PinTracksRequest{}),
+		Responses: ok(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/child_playlist/{id}/pinned_tracks/{trackURI}", OperationID: "unpinTrack", Tag: "child_playlists",
+		Summary: "Unpin a track from a child playlist", Auth: AuthUser,
+		Responses: ok(models.ChildPlaylist{}),
+	},
+	{
+		Method: http.MethodPut, Path: "/api/child_playlist/{id}/cover", OperationID: "uploadChildPlaylistCover", Tag: "child_playlists",
+		Summary: "Set the cover of a child playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Description: "An empty body generates a cover colored after the filter rules of the child playlist",
+		Request:     &Body{ContentTypes: []string{"image/jpeg"}},
+		Responses:   []RouteResponse{{Status: http.StatusAccepted}},
+	},
+
+	// Sync
+	{
+		Method: http.MethodPost, Path: "/api/sync/all", OperationID: "syncAllBasePlaylists", Tag: "sync",
+		Summary: "Sync every base playlist of the user", Auth: AuthUserOrAPIKey, Headers: spotifyAccountHeaders,
+		Description: "Also accepts API keys with the sync:write scope",
+		Query:       []Param{profileParam},
+		Responses:   ok(models.MultiSyncReport{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/sync/audit", OperationID: "auditAllBasePlaylists", Tag: "sync",
+		Summary: "Audit the child playlists of every base playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.AuditReport{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/sync/migrate-in-place", OperationID: "migrateToInPlace", Tag: "sync",
+		Summary: "Move the child playlists recreated on every sync to in place syncing", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.MultiSyncReport{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/sync/{syncEventID}/rollback", OperationID: "rollbackSync", Tag: "sync",
+		Summary: "Restore the child playlists to their state before a sync", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.SyncEvent{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/sync/{syncEventID}/confirm", OperationID: "confirmSync", Tag: "sync",
+		Summary: "Run a sync held back by anomaly detection", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok(models.SyncEvent{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/sync/{syncEventID}", OperationID: "getSyncEvent", Tag: "sync",
+		Summary: "Get a sync event", Auth: AuthUserOrAPIKey,
+		Description: "Also accepts API keys with the sync:read scope",
+		Responses:   ok(models.SyncEvent{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/sync/{syncEventID}/report", OperationID: "getRoutingReport", Tag: "sync",
+		Summary: "Get why each track was routed where it was in a sync", Auth: AuthUserOrAPIKey,
+		Description: "Also accepts API keys with the sync:read scope",
+		Responses:   ok(models.RoutingReport{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/sync_events", OperationID: "listSyncEvents", Tag: "sync",
+		Summary: "List the sync history of the user", Auth: AuthUserOrAPIKey,
+		Description: "Also accepts API keys with the sync:read scope",
+		Query:       slices.Concat(syncEventFilterParams, []Param{{Name: "base_playlist_id"}}),
+		Responses:   ok(models.SyncEventPage{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/sync_events", OperationID: "pruneSyncEvents", Tag: "sync",
+		Summary: "Delete the old sync history of the user", Auth: AuthUserOrAPIKey,
+		Description: "Keeps the latest sync and the latest completed sync of every base playlist. Also accepts API keys with the sync:write scope",
+		Query:       syncEventPruneParams,
+		Responses:   ok(models.SyncEventPruneResult{}),
+	},
+
+	// API keys
+	{
+		Method: http.MethodPost, Path: "/api/keys", OperationID: "createAPIKey", Tag: "api_keys",
+		Summary: "Create an API key", Auth: AuthUser,
+		Description: "The key is only returned in this response",
+		Request:     jsonBody(models.CreateAPIKeyRequest{}),
+		Responses:   created(models.CreatedAPIKey{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/keys", OperationID: "listAPIKeys", Tag: "api_keys",
+		Summary: "List the API keys of the user", Auth: AuthUser,
+		Responses: ok([]models.APIKey{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/keys/{id}", OperationID: "deleteAPIKey", Tag: "api_keys",
+		Summary: "Revoke an API key", Auth: AuthUser,
+		Responses: noContent(),
+	},
+
+	// Webhooks and blocklist entries by ID
+	{
+		Method: http.MethodDelete, Path: "/api/webhooks/{id}", OperationID: "deleteWebhook", Tag: "webhooks",
+		Summary: "Delete a webhook", Auth: AuthUser,
+		Responses: noContent(),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/blocklist/{id}", OperationID: "deleteBlocklistEntry", Tag: "blocklist",
+		Summary: "Delete a blocklist entry", Auth: AuthUser,
+		Responses: noContent(),
+	},
+
+	// Templates
+	{
+		Method: http.MethodGet, Path: "/api/templates", OperationID: "listTemplates", Tag: "templates",
+		Summary: "List the built in templates and the ones of the user", Auth: AuthUser,
+		Responses: ok([]models.ChildPlaylistTemplate{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/templates", OperationID: "createTemplate", Tag: "templates",
+		Summary: "Create a template", Auth: AuthUser,
+		Request:   jsonBody(models.CreateChildPlaylistTemplateRequest{}),
+		Responses: created(models.ChildPlaylistTemplate{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/templates/{id}", OperationID: "getTemplate", Tag: "templates",
+		Summary: "Get a template", Auth: AuthUser,
+		Responses: ok(models.ChildPlaylistTemplate{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/templates/{id}", OperationID: "deleteTemplate", Tag: "templates",
+		Summary: "Delete a template of the user", Auth: AuthUser,
+		Responses: noContent(),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/templates/{id}/instantiate", OperationID: "instantiateTemplate", Tag: "templates",
+		Summary: "Create the child playlists of a template under a base playlist", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Request:   jsonBody(models.InstantiateChildPlaylistTemplateRequest{}),
+		Responses: created([]models.ChildPlaylist{}),
+	},
+
+	// Account
+	{
+		Method: http.MethodDelete, Path: "/api/account", OperationID: "deleteAccount", Tag: "account",
+		Summary: "Delete the account and every record of the user", Auth: AuthUser,
+		Query: []Param{
+			{Name: "delete_spotify_playlists", Type: "boolean", Description: "Also deletes the child playlists from spotify"},
+		},
+		Responses: ok(models.AccountDeletionReport{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/account/export", OperationID: "exportAccount", Tag: "account",
+		Summary: "Export the data of the user", Auth: AuthUser,
+		Responses: ok(models.AccountExport{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/settings/notifications", OperationID: "getNotificationSettings", Tag: "account",
+		Summary: "Get the notification settings of the user", Auth: AuthUser,
+		Responses: ok(models.NotificationPreferences{}),
+	},
+	{
+		Method: http.MethodPut, Path: "/api/settings/notifications", OperationID: "updateNotificationSettings", Tag: "account",
+		Summary: "Update the notification settings of the user", Auth: AuthUser,
+		Request:   jsonBody(models.UpdateNotificationPreferencesRequest{}),
+		Responses: ok(models.NotificationPreferences{}),
+	},
+
+	// Spotify
+	{
+		Method: http.MethodDelete, Path: "/api/spotify/integration", OperationID: "disconnectSpotify", Tag: "spotify",
+		Summary: "Disconnect every spotify account of the user", Auth: AuthUser,
+		Responses: noContent(),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/spotify/accounts", OperationID: "listSpotifyAccounts", Tag: "spotify",
+		Summary: "List the linked spotify accounts", Auth: AuthUser,
+		Responses: ok([]models.SpotifyIntegration{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/spotify/accounts/{id}", OperationID: "unlinkSpotifyAccount", Tag: "spotify",
+		Summary: "Unlink a spotify account", Auth: AuthUser,
+		Responses: noContent(),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/spotify/playlists", OperationID: "listSpotifyPlaylists", Tag: "spotify",
+		Summary: "List the spotify playlists of the user that can be base playlists", Auth: AuthUser, Headers: spotifyAccountHeaders,
+		Responses: ok([]models.SpotifyPlaylist{}),
+	},
+
+	// YouTube Music, only served when the integration is configured
+	{
+		Method: http.MethodGet, Path: "/api/youtube_music/account", OperationID: "getYouTubeMusicAccount", Tag: "youtube_music",
+		Summary: "Get the linked youtube music account", Auth: AuthUser,
+		Responses: ok(models.YouTubeMusicIntegration{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/youtube_music/account", OperationID: "unlinkYouTubeMusicAccount", Tag: "youtube_music",
+		Summary: "Unlink the youtube music account", Auth: AuthUser,
+		Responses: noContent(),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/youtube_music/playlists", OperationID: "listYouTubeMusicPlaylists", Tag: "youtube_music",
+		Summary: "List the youtube music playlists of the user that can be base playlists", Auth: AuthUser,
+		Responses: ok([]models.SpotifyPlaylist{}),
+	},
+
+	// Tidal, only served when the integration is configured
+	{
+		Method: http.MethodGet, Path: "/api/tidal/account", OperationID: "getTidalAccount", Tag: "tidal",
+		Summary: "Get the linked tidal account", Auth: AuthUser,
+		Responses: ok(models.TidalIntegration{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/tidal/account", OperationID: "unlinkTidalAccount", Tag: "tidal",
+		Summary: "Unlink the tidal account", Auth: AuthUser,
+		Responses: noContent(),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/tidal/playlists", OperationID: "listTidalPlaylists", Tag: "tidal",
+		Summary: "List the tidal playlists of the user that can be base playlists", Auth: AuthUser,
+		Responses: ok([]models.SpotifyPlaylist{}),
+	},
+
+	// Last.fm, only served when the integration is configured
+	{
+		Method: http.MethodGet, Path: "/api/lastfm/account", OperationID: "getLastFmAccount", Tag: "lastfm",
+		Summary: "Get the linked last.fm account", Auth: AuthUser,
+		Responses: ok(models.LastFmIntegration{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/lastfm/account", OperationID: "unlinkLastFmAccount", Tag: "lastfm",
+		Summary: "Unlink the last.fm account", Auth: AuthUser,
+		Responses: noContent(),
+	},
+
+	// Public routes
+	{
+		Method: http.MethodGet, Path: "/embed/child_playlist/{shareToken}", OperationID: "getWidget", Tag: "public",
+		Summary: "Get the embeddable widget of a shared child playlist",
+		Query:   []Param{{Name: "format", Enum: []any{"json"}, Description: "Responds with JSON instead of HTML"}},
+		Responses: []RouteResponse{{
+			Status: http.StatusOK, Description: "HTML widget, or its data as JSON with format=json", Body: models.PlaylistWidget{},
+		}},
+	},
+	{
+		Method: http.MethodPost, Path: "/hooks/sync/{playlistToken}", OperationID: "triggerHookSync", Tag: "public",
+		Summary: "Sync the base playlist of the hook token",
+		Responses: []RouteResponse{
+			{Status: http.StatusOK, Body: models.HookSyncStatus{}},
+			{Status: http.StatusConflict, Description: "Already syncing or waiting for confirmation", Body: models.HookSyncStatus{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/hooks/sync/{playlistToken}", OperationID: "getHookSyncStatus", Tag: "public",
+		Summary:   "Get the sync status of the base playlist of the hook token",
+		Responses: ok(models.HookSyncStatus{}),
+	},
+
+	// Automation
+	{
+		Method: http.MethodGet, Path: "/zapier/me", OperationID: "automationMe", Tag: "automation",
+		Summary: "Identify the owner of the API key", Auth: AuthAPIKey,
+		Responses: ok(models.User{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/zapier/triggers/sync_completed", OperationID: "syncCompletedTrigger", Tag: "automation",
+		Summary: "Poll the latest completed syncs", Auth: AuthAPIKey,
+		Description: "Requires the sync:read scope",
+		Responses:   ok([]models.SyncCompletedTrigger{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/zapier/triggers/tracks_routed", OperationID: "tracksRoutedTrigger", Tag: "automation",
+		Summary: "Poll the latest routed tracks", Auth: AuthAPIKey,
+		Description: "Requires the sync:read scope",
+		Responses:   ok([]models.TracksRoutedTrigger{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/zapier/actions/sync", OperationID: "syncAction", Tag: "automation",
+		Summary: "Sync a base playlist", Auth: AuthAPIKey,
+		Description: "Requires the sync:write scope",
+		Request:     jsonBody(models.TriggerSyncActionRequest{}),
+		Responses: []RouteResponse{
+			{Status: http.StatusOK, Body: models.SyncEvent{}},
+			{Status: http.StatusConflict, Description: "Sync held back until the user confirms its anomalies", Body: models.SyncEvent{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/zapier/actions/exclusion", OperationID: "exclusionAction", Tag: "automation",
+		Summary: "Exclude a genre or keyword from a child playlist", Auth: AuthAPIKey,
+		Description: "Requires the rules:write scope",
+		Request:     jsonBody(models.AddExclusionActionRequest{}),
+		Responses:   ok(models.ChildPlaylist{}),
+	},
+
+	// Admin
+	{
+		Method: http.MethodGet, Path: "/api/admin/users", OperationID: "adminListUsers", Tag: "admin",
+		Summary: "List users", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Query:       slices.Concat([]Param{{Name: "q", Description: "Searches the users by id, email or name"}}, paginationParams),
+		Responses:   ok([]models.User{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/admin/users/{id}/disable", OperationID: "adminDisableUser", Tag: "admin",
+		Summary: "Disable a user", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Responses:   ok(models.User{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/admin/users/{id}/enable", OperationID: "adminEnableUser", Tag: "admin",
+		Summary: "Enable a disabled user", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Responses:   ok(models.User{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/admin/sync_events", OperationID: "adminListSyncEvents", Tag: "admin",
+		Summary: "List the sync events of every user", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Query: slices.Concat([]Param{
+			{Name: "status"},
+			{Name: "user_id"},
+			{Name: "base_playlist_id"},
+		}, paginationParams),
+		Responses: ok([]models.SyncEvent{}),
+	},
+	{
+		Method: http.MethodPost, Path: "/api/admin/sync/{id}/retry", OperationID: "adminRetrySync", Tag: "admin",
+		Summary: "Retry a failed or stuck sync on behalf of its owner", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Responses:   ok(models.SyncEvent{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/admin/feature_flags", OperationID: "adminListFeatureFlags", Tag: "admin",
+		Summary: "List the feature flags", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Responses:   ok([]models.FeatureFlag{}),
+	},
+	{
+		Method: http.MethodPut, Path: "/api/admin/feature_flags/{key}", OperationID: "adminUpdateFeatureFlag", Tag: "admin",
+		Summary: "Create or update a feature flag", Auth: AuthUser,
+		Description: "Requires the admin role. Fields left out keep their value, a new flag starts disabled",
+		Request:     jsonBody(models.UpdateFeatureFlagRequest{}),
+		Responses:   ok(models.FeatureFlag{}),
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/admin/feature_flags/{key}", OperationID: "adminDeleteFeatureFlag", Tag: "admin",
+		Summary: "Delete a feature flag, its feature follows the config again", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Responses:   noContent(),
+	},
+	{
+		Method: http.MethodGet, Path: "/api/admin/maintenance", OperationID: "adminGetMaintenanceMode", Tag: "admin",
+		Summary: "Get the maintenance mode", Auth: AuthUser,
+		Description: "Requires the admin role",
+		Responses:   ok(models.MaintenanceMode{}),
+	},
+	{
+		Method: http.MethodPut, Path: "/api/admin/maintenance", OperationID: "adminUpdateMaintenanceMode", Tag: "admin",
+		Summary: "Turn the maintenance mode on or off", Auth: AuthUser,
+		Description: "Requires the admin role. While it is on, every request other than a read answers 503 with the maintenance message and syncs don't start. Admins can still make changes",
+		Request:     jsonBody(models.UpdateMaintenanceModeRequest{}),
+		Responses:   ok(models.MaintenanceMode{}),
+	},
+	{
+		Method: http.MethodGet, Path: "/admin/support_bundle", OperationID: "downloadSupportBundle", Tag: "admin",
+		Summary: "Download the support bundle", Auth: AuthSuperuser,
+		Responses: []RouteResponse{{Status: http.StatusOK, ContentType: "application/zip"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/health", OperationID: "health", Tag: "admin",
+		Summary:   "Health check",
+		Responses: []RouteResponse{{Status: http.StatusOK, ContentType: "text/plain"}},
+	},
+	{
+		Method: http.MethodGet, Path: "/healthz", OperationID: "liveness", Tag: "admin",
+		Summary:     "Liveness probe",
+		Description: "Fails once the background workers stop running, the app must then be restarted",
+		Responses: []RouteResponse{
+			{Status: http.StatusOK, Body: models.HealthReport{}},
+			{Status: http.StatusServiceUnavailable, Description: "The app must be restarted", Body: models.HealthReport{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/readyz", OperationID: "readiness", Tag: "admin",
+		Summary: "Readiness probe",
+		Description: "Checks the database, spotify and the background workers. Spotify being unreachable only " +
+			"degrades the app, the probe still succeeds",
+		Responses: []RouteResponse{
+			{Status: http.StatusOK, Description: "The app is up, possibly degraded", Body: models.HealthReport{}},
+			{Status: http.StatusServiceUnavailable, Description: "A critical component is down", Body: models.HealthReport{}},
+		},
+	},
+
+	// Docs
+	{
+		Method: http.MethodGet, Path: "/api/ws", OperationID: "connectEvents", Tag: "events",
+		Summary: "Open a WebSocket receiving the events of the user",
+		Description: "The first message must be {\"type\":\"auth\",\"token\":\"...\"} with the auth token of the user, " +
+			"answered with {\"type\":\"auth.ok\"} or {\"type\":\"auth.failed\"} before the connection is closed. " +
+			"Sync progress and playlist changes are then pushed as AppEvent messages",
+		Responses: []RouteResponse{{Status: http.StatusSwitchingProtocols, Description: "Upgraded to a WebSocket of AppEvent messages", Body: models.AppEvent{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/openapi.json", OperationID: "getOpenAPISpec", Tag: "docs",
+		Summary:   "Get this OpenAPI document",
+		Responses: []RouteResponse{{Status: http.StatusOK, Body: map[string]any{}}},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/docs", OperationID: "getAPIDocs", Tag: "docs",
+		Summary:   "Browse this document with Swagger UI",
+		Responses: []RouteResponse{{Status: http.StatusOK, ContentType: "text/html"}},
+	},
+}
@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// Schema is the subset of the OpenAPI 3.0 schema object the API models need
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// typeSchemas describes the types that marshal to something other than their Go shape
+var typeSchemas = map[reflect.Type]func() *Schema{
+	reflect.TypeOf(time.Time{}): func() *Schema {
+		return &Schema{Type: "string", Format: "date-time"}
+	},
+	reflect.TypeOf(time.Duration(0)): func() *Schema {
+		return &Schema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}
+	},
+	reflect.TypeOf(models.FilterDuration(0)): func() *Schema {
+		return &Schema{
+			OneOf: []*Schema{
+				{Type: "number", Description: "Seconds"},
+				{Type: "string", Description: "Go duration, e.g. 3m30s"},
+			},
+		}
+	},
+}
+
+// schemaRegistry turns Go types into schemas, collecting every named struct as a component so
+// they are described once and referenced everywhere else
+type schemaRegistry struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: make(map[string]*Schema),
+		types:      make(map[string]reflect.Type),
+	}
+}
+
+// schemaOf returns the schema of the type of value, nil when value is nil
+func (reg *schemaRegistry) schemaOf(value any) *Schema {
+	if value == nil {
+		return nil
+	}
+
+	return reg.schema(reflect.TypeOf(value))
+}
+
+func (reg *schemaRegistry) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if build, ok := typeSchemas[t]; ok {
+		return build()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: reg.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: reg.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return reg.structSchema(t)
+		}
+		return reg.component(t)
+	default:
+		// Interfaces can hold anything
+		return &Schema{}
+	}
+}
+
+// component registers the named struct once and returns a reference to it
+func (reg *schemaRegistry) component(t reflect.Type) *Schema {
+	name := t.Name()
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+
+	if registered, ok := reg.types[name]; ok {
+		if registered != t {
+			panic(fmt.Sprintf("openapi: %s and %s share the component name %s", registered, t, name))
+		}
+		return ref
+	}
+
+	// Registered before its fields are walked, so recursive types end in a reference
+	reg.types[name] = t
+	reg.components[name] = &Schema{}
+	*reg.components[name] = *reg.structSchema(t)
+
+	return ref
+}
+
+func (reg *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	reg.addFields(schema, t)
+
+	return schema
+}
+
+func (reg *schemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, omitempty, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		// Embedded structs without a json name are flattened, as encoding/json does
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			reg.addFields(schema, fieldType)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := reg.schema(field.Type)
+		required := applyValidation(fieldSchema, field.Type, field.Tag.Get("validate"))
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+
+		// Pointers without omitempty are sent as null when unset
+		if field.Type.Kind() == reflect.Pointer && !omitempty {
+			fieldSchema = nullable(fieldSchema)
+		}
+
+		schema.Properties[name] = fieldSchema
+	}
+}
+
+// jsonName reads the json tag of the field, ok is false for fields encoding/json skips
+func jsonName(field reflect.StructField) (name string, omitempty bool, ok bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false, false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	omitempty = strings.Contains(options, "omitempty")
+
+	return name, omitempty, true
+}
+
+func nullable(schema *Schema) *Schema {
+	// $ref siblings are ignored in OpenAPI 3.0, so references are wrapped
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+
+	schema.Nullable = true
+	return schema
+}
+
+// applyValidation translates the validator tag of a field into schema constraints, reporting
+// whether the field is required. Rules after dive apply to the items of the field
+func applyValidation(schema *Schema, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	required := false
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			if schema.Items != nil {
+				applyValidation(schema.Items, t.Elem(), strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "min", "max":
+			applyBound(schema, t, name, param)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, enumValue(t, value))
+			}
+		case "eq":
+			schema.Enum = []any{enumValue(t, param)}
+		case "url":
+			schema.Format = "uri"
+		case "startswith":
+			schema.Description = "Starts with " + param
+		}
+	}
+
+	return required
+}
+
+func applyBound(schema *Schema, t reflect.Type, rule, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	size := int(value)
+
+	switch t.Kind() {
+	case reflect.String:
+		if rule == "min" {
+			schema.MinLength = &size
+		} else {
+			schema.MaxLength = &size
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if rule == "min" {
+			schema.MinItems = &size
+		} else {
+			schema.MaxItems = &size
+		}
+	default:
+		if rule == "min" {
+			schema.Minimum = &value
+		} else {
+			schema.Maximum = &value
+		}
+	}
+}
+
+func enumValue(t reflect.Type, value string) any {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if number, err := strconv.Atoi(value); err == nil {
+			return number
+		}
+	case reflect.Float32, reflect.Float64:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ngomez18/playlist-router/internal/problem"
+)
+
+const OPENAPI_VERSION = "3.0.3"
+
+// Document is the OpenAPI 3.0 document describing the API
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers"`
+	Tags       []Tag               `json:"tags"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// PathItem maps the lowercase http methods of a path to their operation
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Tags        []string              `json:"tags"`
+	Security    []map[string][]string `json:"security"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+var securitySchemes = map[string]*SecurityScheme{
+	"bearerAuth": {
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "Auth token returned by the spotify login. Routes that say so also accept an API key",
+	},
+	"apiKeyHeader": {
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "X-API-Key",
+		Description: "API key of the user, used by the Zapier/IFTTT routes",
+	},
+	"superuserAuth": {
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "Authorization",
+		Description: "PocketBase superuser token",
+	},
+}
+
+// Build describes the routes as an OpenAPI document
+func Build(routes []Route) (*Document, error) {
+	schemas := newSchemaRegistry()
+	problemSchema := schemas.schemaOf(problem.Problem{})
+
+	doc := &Document{
+		OpenAPI: OPENAPI_VERSION,
+		Info: Info{
+			Title:       "PlaylistRouter API",
+			Description: "Routes the tracks of a base spotify playlist into child playlists by filter rules",
+			Version:     API_VERSION,
+		},
+		Servers: []Server{{URL: "/"}},
+		Tags:    tags,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: securitySchemes,
+		},
+	}
+
+	operationIDs := make(map[string]bool)
+	for _, route := range routes {
+		if operationIDs[route.OperationID] {
+			return nil, fmt.Errorf("duplicated operation id %s", route.OperationID)
+		}
+		operationIDs[route.OperationID] = true
+
+		method := strings.ToLower(route.Method)
+		item, ok := doc.Paths[route.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[route.Path] = item
+		}
+		if _, ok := item[method]; ok {
+			return nil, fmt.Errorf("%s %s is described twice", route.Method, route.Path)
+		}
+
+		operation, err := buildOperation(schemas, route, problemSchema)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+		item[method] = operation
+	}
+
+	doc.Components.Schemas = schemas.components
+
+	return doc, nil
+}
+
+func buildOperation(schemas *schemaRegistry, route Route, problemSchema *Schema) (*Operation, error) {
+	if len(route.Responses) == 0 {
+		return nil, fmt.Errorf("no responses")
+	}
+
+	operation := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Description: route.Description,
+		Deprecated:  route.Deprecated,
+		Tags:        []string{route.Tag},
+		Security:    route.Auth.requirements(),
+		Responses:   make(map[string]*Response),
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	for _, param := range route.Query {
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      param.schema(),
+		})
+	}
+	for _, header := range route.Headers {
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:        header.Name,
+			In:          "header",
+			Description: header.Description,
+			Required:    header.Required,
+			Schema:      header.schema(),
+		})
+	}
+
+	if route.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  content(route.Request.contentTypes(), rawOr(schemas.schemaOf(route.Request.Body))),
+		}
+	}
+
+	for _, response := range route.Responses {
+		if response.Status == 0 {
+			return nil, fmt.Errorf("response without a status")
+		}
+
+		description := response.Description
+		if description == "" {
+			description = http.StatusText(response.Status)
+		}
+
+		built := &Response{Description: description}
+		if response.Body != nil || response.ContentType != "" {
+			built.Content = content([]string{response.contentType()}, rawOr(schemas.schemaOf(response.Body)))
+		}
+		operation.Responses[strconv.Itoa(response.Status)] = built
+	}
+
+	operation.Responses["default"] = &Response{
+		Description: "Error described as RFC 7807 problem details",
+		Content:     content([]string{problem.CONTENT_TYPE}, problemSchema),
+	}
+
+	return operation, nil
+}
+
+// rawOr describes bodies without a Go type as raw bytes
+func rawOr(schema *Schema) *Schema {
+	if schema == nil {
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	return schema
+}
+
+func content(contentTypes []string, schema *Schema) map[string]*MediaType {
+	mediaTypes := make(map[string]*MediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		mediaTypes[contentType] = &MediaType{Schema: schema}
+	}
+
+	return mediaTypes
+}
+
+// Generate builds the document of the API routes as indented JSON, the content of openapi.json
+func Generate() ([]byte, error) {
+	doc, err := Build(Routes)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(spec, '\n'), nil
+}
@@ -0,0 +1,19 @@
+package orchestrators
+
+import "errors"
+
+var (
+	ErrSyncInProgress    = errors.New("sync already in progress")
+	ErrSyncEventNotFound = errors.New("sync event not found")
+	ErrNothingToRollback = errors.New("no playlist snapshots recorded for sync event")
+
+	ErrRoutingReportNotFound = errors.New("no routing report recorded for sync event")
+
+	ErrSyncAnomalyDetected         = errors.New("sync held back for confirmation")
+	ErrSyncNotAwaitingConfirmation = errors.New("sync event is not awaiting confirmation")
+	ErrSyncNotRetryable            = errors.New("only failed or stuck syncs can be retried")
+	ErrBasePlaylistArchived        = errors.New("base playlist is archived")
+	ErrMaintenanceMode             = errors.New("syncs are paused for maintenance")
+
+	ErrMigrationVerificationFailed = errors.New("migrated playlist does not match the original")
+)
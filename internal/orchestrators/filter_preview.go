@@ -0,0 +1,61 @@
+package orchestrators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ngomez18/playlist-router/internal/filters"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// PreviewFilters matches the filter rules against the current tracks of the base playlist without
+// touching any child playlist, so they can be tuned before being saved. The rules must already be
+// validated and have their durations resolved.
+func (s *DefaultSyncOrchestrator) PreviewFilters(ctx context.Context, userID, basePlaylistID string, request *models.FilterPreviewRequest) (*models.FilterPreview, error) {
+	s.logger.InfoContext(ctx, "previewing filter rules",
+		"user_id", userID,
+		"base_playlist_id", basePlaylistID,
+	)
+
+	// Makes sure the base playlist belongs to the user before reading its tracks
+	if _, err := s.basePlaylistService.GetBasePlaylist(ctx, basePlaylistID, userID); err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	trackData, err := s.trackAggregator.AggregatePlaylistData(ctx, userID, basePlaylistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate track data: %w", err)
+	}
+
+	sampleSize := request.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = models.DEFAULT_FILTER_PREVIEW_SAMPLE_SIZE
+	}
+
+	engine := filters.NewFilterEngine(&models.ChildPlaylist{FilterRules: request.FilterRules})
+	preview := &models.FilterPreview{
+		BasePlaylistID: basePlaylistID,
+		TotalTracks:    len(trackData.Tracks),
+		Sample:         make([]models.FilterPreviewTrack, 0, min(sampleSize, len(trackData.Tracks))),
+	}
+
+	for _, track := range trackData.Tracks {
+		if !engine.MatchTrack(track) {
+			continue
+		}
+
+		preview.MatchedTracks++
+		if len(preview.Sample) < sampleSize {
+			preview.Sample = append(preview.Sample, models.FilterPreviewTrack{
+				ID:      track.ID,
+				Name:    track.Name,
+				URI:     track.URI,
+				Artists: track.ArtistNames,
+				Album:   track.Album.Name,
+			})
+		}
+	}
+	preview.UnmatchedTracks = preview.TotalTracks - preview.MatchedTracks
+
+	return preview, nil
+}
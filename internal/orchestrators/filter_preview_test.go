@@ -0,0 +1,122 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSyncOrchestrator_PreviewFilters(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := testfixtures.DEFAULT_USER_ID
+	basePlaylistID := testfixtures.DEFAULT_BASE_PLAYLIST_ID
+
+	tracks := make([]models.TrackInfo, 0, 5)
+	for i := range 5 {
+		tracks = append(tracks, models.TrackInfo{
+			ID:          fmt.Sprintf("track%d", i),
+			Name:        fmt.Sprintf("Track %d", i),
+			URI:         fmt.Sprintf("spotify:track:track%d", i),
+			Popularity:  i * 20,
+			ArtistNames: []string{"Artist"},
+			Album:       models.AlbumInfo{Name: "Album"},
+		})
+	}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().Build(), nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(&models.PlaylistTracksInfo{Tracks: tracks}, nil)
+
+	// Popularity 40, 60 and 80 match, only the first two are sampled
+	request := &models.FilterPreviewRequest{
+		FilterRules: testfixtures.NewFilters().WithPopularity(40, 100).Build(),
+		SampleSize:  2,
+	}
+	preview, err := orchestrator.PreviewFilters(context.Background(), userID, basePlaylistID, request)
+
+	assert.NoError(err)
+	assert.Equal(basePlaylistID, preview.BasePlaylistID)
+	assert.Equal(5, preview.TotalTracks)
+	assert.Equal(3, preview.MatchedTracks)
+	assert.Equal(2, preview.UnmatchedTracks)
+	assert.Equal([]models.FilterPreviewTrack{
+		{ID: "track2", Name: "Track 2", URI: "spotify:track:track2", Artists: []string{"Artist"}, Album: "Album"},
+		{ID: "track3", Name: "Track 3", URI: "spotify:track:track3", Artists: []string{"Artist"}, Album: "Album"},
+	}, preview.Sample)
+}
+
+func TestDefaultSyncOrchestrator_PreviewFilters_DefaultSampleSize(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tracks := make([]models.TrackInfo, 30)
+	for i := range tracks {
+		tracks[i] = models.TrackInfo{ID: fmt.Sprintf("track%d", i)}
+	}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewBasePlaylist().Build(), nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.PlaylistTracksInfo{Tracks: tracks}, nil)
+
+	// Empty rules match every track
+	preview, err := orchestrator.PreviewFilters(context.Background(), "user123", "base123", &models.FilterPreviewRequest{FilterRules: &models.MetadataFilters{}})
+
+	assert.NoError(err)
+	assert.Equal(30, preview.MatchedTracks)
+	assert.Len(preview.Sample, models.DEFAULT_FILTER_PREVIEW_SAMPLE_SIZE)
+}
+
+func TestDefaultSyncOrchestrator_PreviewFilters_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(mocks mockServices)
+		expectedError string
+	}{
+		{
+			name: "base playlist not found",
+			setupMocks: func(mocks mockServices) {
+				mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("not found"))
+			},
+			expectedError: "failed to get base playlist",
+		},
+		{
+			name: "aggregation fails",
+			setupMocks: func(mocks mockServices) {
+				mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewBasePlaylist().Build(), nil)
+				mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("spotify error"))
+			},
+			expectedError: "failed to aggregate track data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+			tt.setupMocks(mocks)
+
+			preview, err := orchestrator.PreviewFilters(context.Background(), "user123", "base123", &models.FilterPreviewRequest{FilterRules: &models.MetadataFilters{}})
+
+			assert.Nil(preview)
+			assert.ErrorContains(err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package orchestrators
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// MigrateToInPlace moves every child playlist of the user that is still synced by recreating its
+// spotify playlist over to in place syncing. Each base playlist with children left to migrate is
+// migrated as its own sync event, so it cannot overlap a sync, snapshots the old contents and can be
+// rolled back. Migrated children are skipped, running it again only retries what failed.
+func (s *DefaultSyncOrchestrator) MigrateToInPlace(ctx context.Context, userID string) (*models.MultiSyncReport, error) {
+	s.logger.InfoContext(ctx, "starting in place sync migration", "user_id", userID)
+
+	basePlaylists, err := s.basePlaylistService.GetBasePlaylistsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlists: %w", err)
+	}
+
+	report := &models.MultiSyncReport{
+		UserID:  userID,
+		Results: make([]models.BaseSyncResult, 0, len(basePlaylists)),
+	}
+
+	for _, basePlaylist := range basePlaylists {
+		childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			errorMessage := fmt.Sprintf("failed to get child playlists: %s", err.Error())
+			report.Results = append(report.Results, models.BaseSyncResult{
+				BasePlaylistID: basePlaylist.ID,
+				Status:         models.SyncStatusFailed,
+				ErrorMessage:   &errorMessage,
+			})
+			continue
+		}
+
+		if !slices.ContainsFunc(childPlaylists, needsInPlaceMigration) {
+			continue
+		}
+
+		report.Results = append(report.Results, s.migrateBasePlaylistForReport(ctx, userID, basePlaylist))
+	}
+
+	report.Total = len(report.Results)
+	for _, result := range report.Results {
+		if result.Status == models.SyncStatusCompleted {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+
+	s.logger.InfoContext(ctx, "in place sync migration completed",
+		"user_id", userID,
+		"total", report.Total,
+		"succeeded", report.Succeeded,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
+
+func (s *DefaultSyncOrchestrator) migrateBasePlaylistForReport(ctx context.Context, userID string, basePlaylist *models.BasePlaylist) models.BaseSyncResult {
+	result := models.BaseSyncResult{BasePlaylistID: basePlaylist.ID}
+	ctx = requestcontext.ContextWithMusicProvider(ctx, basePlaylist.Provider)
+
+	syncEvent, err := s.runSyncEvent(ctx, userID, basePlaylist.ID, func(ctx context.Context, syncEvent *models.SyncEvent) error {
+		return s.executeMigrationFlow(ctx, syncEvent, basePlaylist)
+	})
+	if syncEvent != nil {
+		result.SyncEventID = syncEvent.ID
+		result.Status = syncEvent.Status
+	}
+
+	if err != nil {
+		errorMessage := err.Error()
+		result.ErrorMessage = &errorMessage
+		result.Status = models.SyncStatusFailed
+	}
+
+	return result
+}
+
+// executeMigrationFlow migrates the children of the base playlist one by one. A failing child is
+// left on its old playlist and strategy without stopping the others.
+func (s *DefaultSyncOrchestrator) executeMigrationFlow(ctx context.Context, syncEvent *models.SyncEvent, basePlaylist *models.BasePlaylist) error {
+	// Reloaded under the sync guard, a sync may have recreated playlists since the caller looked
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get child playlists: %w", err)
+	}
+
+	syncEvent.Phase = models.SyncPhaseUpdatingPlaylists
+	syncEvent.ChildSyncResults = make([]models.ChildSyncResult, 0, len(childPlaylists))
+	failedChildren := 0
+
+	for _, childPlaylist := range childPlaylists {
+		if !needsInPlaceMigration(childPlaylist) {
+			continue
+		}
+
+		syncEvent.ChildPlaylistIDs = append(syncEvent.ChildPlaylistIDs, childPlaylist.ID)
+		result := models.ChildSyncResult{
+			ChildPlaylistID:   childPlaylist.ID,
+			SpotifyPlaylistID: childPlaylist.SpotifyPlaylistID,
+			Status:            models.SyncStatusCompleted,
+		}
+
+		apiRequestCount, err := s.migrateChildPlaylist(ctx, basePlaylist, *childPlaylist, syncEvent, &result)
+		result.APIRequests = apiRequestCount
+		syncEvent.TotalAPIRequests += apiRequestCount
+
+		if err != nil {
+			errorMessage := err.Error()
+			result.Status = models.SyncStatusFailed
+			result.ErrorMessage = &errorMessage
+			failedChildren++
+
+			s.logger.ErrorContext(ctx, "failed to migrate child playlist",
+				"sync_event_id", syncEvent.ID,
+				"child_playlist_id", childPlaylist.ID,
+				"error", errorMessage,
+			)
+		}
+
+		syncEvent.ChildSyncResults = append(syncEvent.ChildSyncResults, result)
+	}
+
+	if failedChildren > 0 {
+		return fmt.Errorf("failed to migrate %d of %d child playlists", failedChildren, len(syncEvent.ChildSyncResults))
+	}
+
+	return nil
+}
+
+// migrateChildPlaylist copies the current tracks of the child into a fresh spotify playlist, checks
+// the copy and only then switches the child over and removes the old playlist. Returns the number of
+// spotify requests made.
+func (s *DefaultSyncOrchestrator) migrateChildPlaylist(
+	ctx context.Context,
+	basePlaylist *models.BasePlaylist,
+	childPlaylist models.ChildPlaylist,
+	syncEvent *models.SyncEvent,
+	result *models.ChildSyncResult,
+) (int, error) {
+	oldPlaylistID := childPlaylist.SpotifyPlaylistID
+
+	trackURIs, apiRequestCount, err := s.fetchPlaylistTrackURIs(ctx, oldPlaylistID)
+	if err != nil {
+		return apiRequestCount, fmt.Errorf("failed to read playlist %s: %w", oldPlaylistID, err)
+	}
+
+	_, err = s.snapshotService.CreateSnapshot(ctx, &models.PlaylistSnapshot{
+		UserID:            syncEvent.UserID,
+		SyncEventID:       syncEvent.ID,
+		ChildPlaylistID:   childPlaylist.ID,
+		SpotifyPlaylistID: oldPlaylistID,
+		TrackURIs:         trackURIs,
+	})
+	if err != nil {
+		return apiRequestCount, fmt.Errorf("failed to snapshot playlist %s: %w", oldPlaylistID, err)
+	}
+	syncEvent.TracksProcessed += len(trackURIs)
+
+	formattedName := models.BuildChildPlaylistName(basePlaylist.Name, childPlaylist.Name)
+	formattedDescription := models.BuildChildPlaylistDescription(childPlaylist.Description)
+
+	newPlaylist, err := s.musicProvider.CreatePlaylist(ctx, formattedName, formattedDescription, false)
+	if err != nil {
+		return apiRequestCount, fmt.Errorf("failed to create new playlist for %s: %w", formattedName, err)
+	}
+	apiRequestCount++
+
+	requests, err := s.copyTracksToPlaylist(ctx, syncEvent.ID, newPlaylist.ID, trackURIs)
+	apiRequestCount += requests
+	if err == nil {
+		_, err = s.childPlaylistService.MigrateChildPlaylistToInPlace(ctx, childPlaylist.ID, childPlaylist.UserID, newPlaylist.ID)
+	}
+	if err != nil {
+		// The child still points at its old playlist, the half made copy is of no use
+		apiRequestCount++
+		if deleteErr := s.musicProvider.DeletePlaylist(ctx, newPlaylist.ID); deleteErr != nil {
+			s.logger.WarnContext(ctx, "failed to delete unused migration playlist",
+				"sync_event_id", syncEvent.ID,
+				"spotify_playlist_id", newPlaylist.ID,
+				"error", deleteErr.Error(),
+			)
+		}
+		return apiRequestCount, fmt.Errorf("failed to migrate playlist %s: %w", oldPlaylistID, err)
+	}
+
+	result.SpotifyPlaylistID = newPlaylist.ID
+	result.TracksAdded = len(trackURIs)
+
+	s.recordMembership(ctx, childPlaylist, syncEvent.ID, newPlaylist.ID, trackURIs)
+
+	// The child is already migrated, a leftover old playlist only needs a manual cleanup
+	if err := s.musicProvider.DeletePlaylist(ctx, oldPlaylistID); err != nil {
+		s.logger.WarnContext(ctx, "failed to delete playlist replaced by migration",
+			"sync_event_id", syncEvent.ID,
+			"spotify_playlist_id", oldPlaylistID,
+			"error", err.Error(),
+		)
+	}
+	apiRequestCount++
+
+	s.logger.InfoContext(ctx, "migrated child playlist to in place sync",
+		"sync_event_id", syncEvent.ID,
+		"child_playlist_id", childPlaylist.ID,
+		"old_spotify_playlist_id", oldPlaylistID,
+		"new_spotify_playlist_id", newPlaylist.ID,
+		"track_count", len(trackURIs),
+	)
+
+	return apiRequestCount, nil
+}
+
+// copyTracksToPlaylist adds the tracks to the playlist and reads them back to make sure it holds
+// exactly them, in order. Returns the number of spotify requests made.
+func (s *DefaultSyncOrchestrator) copyTracksToPlaylist(ctx context.Context, syncEventID, playlistID string, trackURIs []string) (int, error) {
+	apiRequestCount, err := s.addTracksInBatches(ctx, syncEventID, playlistID, trackURIs)
+	if err != nil {
+		return apiRequestCount, fmt.Errorf("failed to add tracks to playlist %s: %w", playlistID, err)
+	}
+
+	copiedTrackURIs, requests, err := s.fetchPlaylistTrackURIs(ctx, playlistID)
+	apiRequestCount += requests
+	if err != nil {
+		return apiRequestCount, fmt.Errorf("failed to verify playlist %s: %w", playlistID, err)
+	}
+
+	if !slices.Equal(copiedTrackURIs, trackURIs) {
+		return apiRequestCount, fmt.Errorf("%w: playlist %s holds %d tracks, expected %d",
+			ErrMigrationVerificationFailed, playlistID, len(copiedTrackURIs), len(trackURIs))
+	}
+
+	return apiRequestCount, nil
+}
+
+func needsInPlaceMigration(childPlaylist *models.ChildPlaylist) bool {
+	return childPlaylist.SyncStrategy != models.SyncStrategyInPlace
+}
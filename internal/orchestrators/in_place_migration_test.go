@@ -0,0 +1,182 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSyncOrchestrator_MigrateToInPlace(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := testfixtures.DEFAULT_USER_ID
+	basePlaylist := testfixtures.NewBasePlaylist().WithID("base1").Build()
+	migratedBasePlaylist := testfixtures.NewBasePlaylist().WithID("base2").Build()
+
+	recreateChild := testfixtures.NewChildPlaylist().WithID("child1").WithBasePlaylistID("base1").WithSpotifyPlaylistID("old1").Build()
+	inPlaceChild := testfixtures.NewChildPlaylist().WithID("child2").WithBasePlaylistID("base1").WithSpotifyPlaylistID("spotify2").
+		WithSyncStrategy(models.SyncStrategyInPlace).Build()
+	migratedChild := testfixtures.NewChildPlaylist().WithID("child3").WithBasePlaylistID("base2").WithSyncStrategy(models.SyncStrategyInPlace).Build()
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithBasePlaylistID("base1").Build()
+	expectedName := models.BuildChildPlaylistName(basePlaylist.Name, recreateChild.Name)
+	expectedDescription := models.BuildChildPlaylistDescription(recreateChild.Description)
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), userID).Return([]*models.BasePlaylist{basePlaylist, migratedBasePlaylist}, nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base1", userID).
+		Return([]*models.ChildPlaylist{recreateChild, inPlaceChild}, nil).Times(2)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base2", userID).
+		Return([]*models.ChildPlaylist{migratedChild}, nil)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, "base1").Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+
+	// Only the recreated child is copied into a fresh playlist, verified and switched over
+	expectSnapshot(mocks, "old1", "spotify:track:1", "spotify:track:2")
+	mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), expectedName, expectedDescription, false).
+		Return(&spotifyclient.SpotifyPlaylist{ID: "new1", Name: expectedName}, nil)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new1", []string{"spotify:track:1", "spotify:track:2"}).Return(nil)
+	expectPlaylistTracks(mocks, "new1", "spotify:track:1", "spotify:track:2")
+	mocks.childPlaylistService.EXPECT().MigrateChildPlaylistToInPlace(gomock.Any(), "child1", userID, "new1").Return(recreateChild, nil)
+	expectMembership(mocks, "new1", "spotify:track:1", "spotify:track:2")
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "old1").Return(nil)
+
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	report, err := orchestrator.MigrateToInPlace(context.Background(), userID)
+
+	assert.NoError(err)
+	assert.Equal(1, report.Total)
+	assert.Equal(1, report.Succeeded)
+	assert.Equal("base1", report.Results[0].BasePlaylistID)
+	assert.Equal(models.SyncStatusCompleted, report.Results[0].Status)
+
+	assert.Equal([]string{"child1"}, createdSyncEvent.ChildPlaylistIDs)
+	assert.Equal(2, createdSyncEvent.TracksProcessed)
+	assert.Len(createdSyncEvent.ChildSyncResults, 1)
+	assert.Equal("new1", createdSyncEvent.ChildSyncResults[0].SpotifyPlaylistID)
+	assert.Equal(5, createdSyncEvent.ChildSyncResults[0].APIRequests) // read + create + add + verify + delete
+}
+
+func TestDefaultSyncOrchestrator_MigrateToInPlace_ChildErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(mocks mockServices)
+		expectedError string
+	}{
+		{
+			name: "copy does not match",
+			setupMocks: func(mocks mockServices) {
+				mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new1", []string{"spotify:track:1", "spotify:track:2"}).Return(nil)
+				expectPlaylistTracks(mocks, "new1", "spotify:track:1")
+			},
+			expectedError: "migrated playlist does not match the original",
+		},
+		{
+			name: "adding tracks fails",
+			setupMocks: func(mocks mockServices) {
+				mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new1", gomock.Any()).Return(errors.New("spotify error"))
+			},
+			expectedError: "failed to add tracks to playlist new1",
+		},
+		{
+			name: "storing the new playlist fails",
+			setupMocks: func(mocks mockServices) {
+				mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new1", gomock.Any()).Return(nil)
+				expectPlaylistTracks(mocks, "new1", "spotify:track:1", "spotify:track:2")
+				mocks.childPlaylistService.EXPECT().MigrateChildPlaylistToInPlace(gomock.Any(), "child1", gomock.Any(), "new1").Return(nil, errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID := testfixtures.DEFAULT_USER_ID
+			basePlaylist := testfixtures.NewBasePlaylist().WithID("base1").Build()
+			child := testfixtures.NewChildPlaylist().WithID("child1").WithBasePlaylistID("base1").WithSpotifyPlaylistID("old1").Build()
+			createdSyncEvent := testfixtures.NewSyncEvent().WithBasePlaylistID("base1").Build()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+
+			mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), userID).Return([]*models.BasePlaylist{basePlaylist}, nil)
+			mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base1", userID).
+				Return([]*models.ChildPlaylist{child}, nil).Times(2)
+			mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, "base1").Return(false, nil)
+			mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+
+			expectSnapshot(mocks, "old1", "spotify:track:1", "spotify:track:2")
+			mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).
+				Return(&spotifyclient.SpotifyPlaylist{ID: "new1"}, nil)
+			tt.setupMocks(mocks)
+
+			// The copy is thrown away and the old playlist is left alone
+			mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "new1").Return(nil)
+			mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+			report, err := orchestrator.MigrateToInPlace(context.Background(), userID)
+
+			assert.NoError(err)
+			assert.Equal(1, report.Failed)
+			assert.Equal(models.SyncStatusFailed, report.Results[0].Status)
+			assert.Len(createdSyncEvent.ChildSyncResults, 1)
+			assert.Equal("old1", createdSyncEvent.ChildSyncResults[0].SpotifyPlaylistID)
+			assert.Contains(*createdSyncEvent.ChildSyncResults[0].ErrorMessage, tt.expectedError)
+		})
+	}
+}
+
+func TestDefaultSyncOrchestrator_MigrateToInPlace_SyncInProgress(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := testfixtures.DEFAULT_USER_ID
+	basePlaylist := testfixtures.NewBasePlaylist().WithID("base1").Build()
+	child := testfixtures.NewChildPlaylist().WithBasePlaylistID("base1").Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), userID).Return([]*models.BasePlaylist{basePlaylist}, nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base1", userID).Return([]*models.ChildPlaylist{child}, nil)
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, "base1").Return(true, nil)
+
+	report, err := orchestrator.MigrateToInPlace(context.Background(), userID)
+
+	assert.NoError(err)
+	assert.Equal(1, report.Failed)
+	assert.Contains(*report.Results[0].ErrorMessage, ErrSyncInProgress.Error())
+}
+
+func TestDefaultSyncOrchestrator_MigrateToInPlace_GetBasePlaylistsError(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), "user123").Return(nil, errors.New("db error"))
+
+	report, err := orchestrator.MigrateToInPlace(context.Background(), "user123")
+
+	assert.Nil(report)
+	assert.ErrorContains(err, "failed to get base playlists")
+}
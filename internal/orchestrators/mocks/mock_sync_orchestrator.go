@@ -35,6 +35,156 @@ func (m *MockSyncOrchestrator) EXPECT() *MockSyncOrchestratorMockRecorder {
 	return m.recorder
 }
 
+// AuditAllBasePlaylists mocks base method.
+func (m *MockSyncOrchestrator) AuditAllBasePlaylists(ctx context.Context, userID string) (*models.AuditReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditAllBasePlaylists", ctx, userID)
+	ret0, _ := ret[0].(*models.AuditReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditAllBasePlaylists indicates an expected call of AuditAllBasePlaylists.
+func (mr *MockSyncOrchestratorMockRecorder) AuditAllBasePlaylists(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditAllBasePlaylists", reflect.TypeOf((*MockSyncOrchestrator)(nil).AuditAllBasePlaylists), ctx, userID)
+}
+
+// AuditBasePlaylist mocks base method.
+func (m *MockSyncOrchestrator) AuditBasePlaylist(ctx context.Context, userID, basePlaylistID string) (*models.BasePlaylistAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditBasePlaylist", ctx, userID, basePlaylistID)
+	ret0, _ := ret[0].(*models.BasePlaylistAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditBasePlaylist indicates an expected call of AuditBasePlaylist.
+func (mr *MockSyncOrchestratorMockRecorder) AuditBasePlaylist(ctx, userID, basePlaylistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditBasePlaylist", reflect.TypeOf((*MockSyncOrchestrator)(nil).AuditBasePlaylist), ctx, userID, basePlaylistID)
+}
+
+// ComputeRoutingDiff mocks base method.
+func (m *MockSyncOrchestrator) ComputeRoutingDiff(ctx context.Context, userID, ruleChangeID string) (*models.FilterRuleChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComputeRoutingDiff", ctx, userID, ruleChangeID)
+	ret0, _ := ret[0].(*models.FilterRuleChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ComputeRoutingDiff indicates an expected call of ComputeRoutingDiff.
+func (mr *MockSyncOrchestratorMockRecorder) ComputeRoutingDiff(ctx, userID, ruleChangeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeRoutingDiff", reflect.TypeOf((*MockSyncOrchestrator)(nil).ComputeRoutingDiff), ctx, userID, ruleChangeID)
+}
+
+// ConfirmSync mocks base method.
+func (m *MockSyncOrchestrator) ConfirmSync(ctx context.Context, userID, syncEventID string) (*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmSync", ctx, userID, syncEventID)
+	ret0, _ := ret[0].(*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmSync indicates an expected call of ConfirmSync.
+func (mr *MockSyncOrchestratorMockRecorder) ConfirmSync(ctx, userID, syncEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmSync", reflect.TypeOf((*MockSyncOrchestrator)(nil).ConfirmSync), ctx, userID, syncEventID)
+}
+
+// GetRoutingReport mocks base method.
+func (m *MockSyncOrchestrator) GetRoutingReport(ctx context.Context, userID, syncEventID string) (*models.RoutingReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoutingReport", ctx, userID, syncEventID)
+	ret0, _ := ret[0].(*models.RoutingReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoutingReport indicates an expected call of GetRoutingReport.
+func (mr *MockSyncOrchestratorMockRecorder) GetRoutingReport(ctx, userID, syncEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutingReport", reflect.TypeOf((*MockSyncOrchestrator)(nil).GetRoutingReport), ctx, userID, syncEventID)
+}
+
+// MigrateToInPlace mocks base method.
+func (m *MockSyncOrchestrator) MigrateToInPlace(ctx context.Context, userID string) (*models.MultiSyncReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateToInPlace", ctx, userID)
+	ret0, _ := ret[0].(*models.MultiSyncReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateToInPlace indicates an expected call of MigrateToInPlace.
+func (mr *MockSyncOrchestratorMockRecorder) MigrateToInPlace(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateToInPlace", reflect.TypeOf((*MockSyncOrchestrator)(nil).MigrateToInPlace), ctx, userID)
+}
+
+// PreviewFilters mocks base method.
+func (m *MockSyncOrchestrator) PreviewFilters(ctx context.Context, userID, basePlaylistID string, request *models.FilterPreviewRequest) (*models.FilterPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewFilters", ctx, userID, basePlaylistID, request)
+	ret0, _ := ret[0].(*models.FilterPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewFilters indicates an expected call of PreviewFilters.
+func (mr *MockSyncOrchestratorMockRecorder) PreviewFilters(ctx, userID, basePlaylistID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewFilters", reflect.TypeOf((*MockSyncOrchestrator)(nil).PreviewFilters), ctx, userID, basePlaylistID, request)
+}
+
+// RetrySync mocks base method.
+func (m *MockSyncOrchestrator) RetrySync(ctx context.Context, syncEventID string) (*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrySync", ctx, syncEventID)
+	ret0, _ := ret[0].(*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrySync indicates an expected call of RetrySync.
+func (mr *MockSyncOrchestratorMockRecorder) RetrySync(ctx, syncEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrySync", reflect.TypeOf((*MockSyncOrchestrator)(nil).RetrySync), ctx, syncEventID)
+}
+
+// RollbackSync mocks base method.
+func (m *MockSyncOrchestrator) RollbackSync(ctx context.Context, userID, syncEventID string) (*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackSync", ctx, userID, syncEventID)
+	ret0, _ := ret[0].(*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackSync indicates an expected call of RollbackSync.
+func (mr *MockSyncOrchestratorMockRecorder) RollbackSync(ctx, userID, syncEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackSync", reflect.TypeOf((*MockSyncOrchestrator)(nil).RollbackSync), ctx, userID, syncEventID)
+}
+
+// SyncAllBasePlaylists mocks base method.
+func (m *MockSyncOrchestrator) SyncAllBasePlaylists(ctx context.Context, userID string) (*models.MultiSyncReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncAllBasePlaylists", ctx, userID)
+	ret0, _ := ret[0].(*models.MultiSyncReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncAllBasePlaylists indicates an expected call of SyncAllBasePlaylists.
+func (mr *MockSyncOrchestratorMockRecorder) SyncAllBasePlaylists(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncAllBasePlaylists", reflect.TypeOf((*MockSyncOrchestrator)(nil).SyncAllBasePlaylists), ctx, userID)
+}
+
 // SyncBasePlaylist mocks base method.
 func (m *MockSyncOrchestrator) SyncBasePlaylist(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
 	m.ctrl.T.Helper()
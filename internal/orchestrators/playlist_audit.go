@@ -0,0 +1,175 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+// AuditBasePlaylist verifies, without mutating anything, that every child playlist of the base playlist
+// still matches the spotify playlist it manages
+func (s *DefaultSyncOrchestrator) AuditBasePlaylist(ctx context.Context, userID, basePlaylistID string) (*models.BasePlaylistAudit, error) {
+	s.logger.InfoContext(ctx, "auditing base playlist",
+		"user_id", userID,
+		"base_playlist_id", basePlaylistID,
+	)
+
+	basePlaylist, err := s.basePlaylistService.GetBasePlaylist(ctx, basePlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	userPlaylists, err := s.getUserPlaylistsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.auditBasePlaylist(ctx, basePlaylist, userPlaylists), nil
+}
+
+// AuditAllBasePlaylists produces the drift report of every base playlist of the user
+func (s *DefaultSyncOrchestrator) AuditAllBasePlaylists(ctx context.Context, userID string) (*models.AuditReport, error) {
+	s.logger.InfoContext(ctx, "auditing all base playlists", "user_id", userID)
+
+	basePlaylists, err := s.basePlaylistService.GetBasePlaylistsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlists: %w", err)
+	}
+
+	userPlaylists, err := s.getUserPlaylistsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.AuditReport{
+		UserID:        userID,
+		AuditedAt:     time.Now(),
+		BasePlaylists: make([]models.BasePlaylistAudit, 0, len(basePlaylists)),
+	}
+	for _, basePlaylist := range basePlaylists {
+		audit := s.auditBasePlaylist(ctx, basePlaylist, userPlaylists)
+		report.BasePlaylists = append(report.BasePlaylists, *audit)
+		report.TotalChildren += len(audit.Children)
+		report.DriftedChildren += audit.Drifted
+	}
+
+	s.logger.InfoContext(ctx, "audit completed",
+		"user_id", userID,
+		"total_children", report.TotalChildren,
+		"drifted_children", report.DriftedChildren,
+	)
+
+	return report, nil
+}
+
+// getUserPlaylistsByID indexes the playlists the user follows. Deleting a spotify playlist only
+// unfollows it, so a managed playlist missing from this list no longer exists for the user.
+func (s *DefaultSyncOrchestrator) getUserPlaylistsByID(ctx context.Context) (map[string]*musicprovider.Playlist, error) {
+	playlists, err := s.musicProvider.GetAllUserPlaylists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user playlists: %w", err)
+	}
+
+	playlistsByID := make(map[string]*musicprovider.Playlist, len(playlists))
+	for _, playlist := range playlists {
+		playlistsByID[playlist.ID] = playlist
+	}
+
+	return playlistsByID, nil
+}
+
+func (s *DefaultSyncOrchestrator) auditBasePlaylist(
+	ctx context.Context,
+	basePlaylist *models.BasePlaylist,
+	userPlaylists map[string]*musicprovider.Playlist,
+) *models.BasePlaylistAudit {
+	audit := &models.BasePlaylistAudit{
+		BasePlaylistID:   basePlaylist.ID,
+		BasePlaylistName: basePlaylist.Name,
+		Children:         make([]models.ChildPlaylistAudit, 0),
+	}
+
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, basePlaylist.ID, basePlaylist.UserID)
+	if err != nil {
+		errorMessage := fmt.Sprintf("failed to get child playlists: %s", err.Error())
+		audit.ErrorMessage = &errorMessage
+		return audit
+	}
+
+	for _, childPlaylist := range childPlaylists {
+		childAudit := s.auditChildPlaylist(ctx, basePlaylist, childPlaylist, userPlaylists[childPlaylist.SpotifyPlaylistID])
+		if !childAudit.InSync {
+			audit.Drifted++
+		}
+		audit.Children = append(audit.Children, childAudit)
+	}
+
+	return audit
+}
+
+// auditChildPlaylist compares a child playlist against its spotify playlist, nil when the user no
+// longer has it, and the membership recorded by its last sync
+func (s *DefaultSyncOrchestrator) auditChildPlaylist(
+	ctx context.Context,
+	basePlaylist *models.BasePlaylist,
+	childPlaylist *models.ChildPlaylist,
+	spotifyPlaylist *musicprovider.Playlist,
+) models.ChildPlaylistAudit {
+	audit := models.ChildPlaylistAudit{
+		ChildPlaylistID:     childPlaylist.ID,
+		ChildPlaylistName:   childPlaylist.Name,
+		SpotifyPlaylistID:   childPlaylist.SpotifyPlaylistID,
+		Drift:               make([]models.PlaylistDriftType, 0),
+		ExpectedName:        models.BuildChildPlaylistName(basePlaylist.Name, childPlaylist.Name),
+		ExpectedDescription: models.BuildChildPlaylistDescription(childPlaylist.Description),
+	}
+
+	if spotifyPlaylist == nil {
+		audit.Drift = append(audit.Drift, models.PlaylistDriftMissing)
+		return audit
+	}
+
+	audit.ActualName = spotifyPlaylist.Name
+	// Spotify returns descriptions HTML escaped
+	audit.ActualDescription = html.UnescapeString(spotifyPlaylist.Description)
+	if audit.ActualName != audit.ExpectedName {
+		audit.Drift = append(audit.Drift, models.PlaylistDriftNameMismatch)
+	}
+	if audit.ActualDescription != audit.ExpectedDescription {
+		audit.Drift = append(audit.Drift, models.PlaylistDriftDescriptionMismatch)
+	}
+
+	actualTrackURIs, _, err := s.fetchPlaylistTrackURIs(ctx, childPlaylist.SpotifyPlaylistID)
+	if err != nil {
+		errorMessage := err.Error()
+		audit.ErrorMessage = &errorMessage
+		return audit
+	}
+	audit.ActualTrackCount = len(actualTrackURIs)
+
+	membership, err := s.snapshotService.GetMembership(ctx, childPlaylist.ID, childPlaylist.UserID)
+	switch {
+	case errors.Is(err, repositories.ErrPlaylistMembershipNotFound):
+		audit.Drift = append(audit.Drift, models.PlaylistDriftNeverSynced)
+	case err != nil:
+		errorMessage := err.Error()
+		audit.ErrorMessage = &errorMessage
+		return audit
+	default:
+		audit.ExpectedTrackCount = len(membership.TrackURIs)
+		audit.UnexpectedTrackURIs, audit.MissingTrackURIs = diffTrackURIs(membership.TrackURIs, actualTrackURIs)
+
+		if len(audit.MissingTrackURIs) > 0 || len(audit.UnexpectedTrackURIs) > 0 || audit.ExpectedTrackCount != audit.ActualTrackCount {
+			audit.Drift = append(audit.Drift, models.PlaylistDriftContentMismatch)
+		}
+	}
+
+	audit.InSync = len(audit.Drift) == 0
+	return audit
+}
@@ -0,0 +1,206 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSyncOrchestrator_AuditBasePlaylist(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	basePlaylist := &models.BasePlaylist{ID: "base123", UserID: "user123", Name: "Liked"}
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: "user123", Name: "Rock", Description: "Loud & proud", SpotifyPlaylistID: "spotify1"},
+		{ID: "child2", UserID: "user123", Name: "Jazz", SpotifyPlaylistID: "spotify2"},
+		{ID: "child3", UserID: "user123", Name: "Pop", SpotifyPlaylistID: "spotify3"},
+		{ID: "child4", UserID: "user123", Name: "Folk", SpotifyPlaylistID: "spotify4"},
+	}
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base123", "user123").Return(basePlaylist, nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base123", "user123").Return(childPlaylists, nil)
+	mocks.spotifyClient.EXPECT().GetAllUserPlaylists(gomock.Any()).Return([]*spotifyclient.SpotifyPlaylist{
+		// Spotify returns descriptions HTML escaped
+		{ID: "spotify1", Name: "[Liked] > Rock", Description: "[PLAYLIST GENERATED AND MANAGED BY PlaylistRouter] Loud &amp; proud"},
+		{ID: "spotify2", Name: "Renamed", Description: models.BuildChildPlaylistDescription("")},
+		{ID: "spotify3", Name: "[Liked] > Pop", Description: models.BuildChildPlaylistDescription("")},
+		{ID: "unrelated", Name: "Other"},
+	}, nil)
+
+	// child1 matches its membership
+	expectPlaylistTracks(mocks, "spotify1", "spotify:track:1", "spotify:track:2")
+	mocks.snapshotService.EXPECT().GetMembership(gomock.Any(), "child1", "user123").
+		Return(&models.PlaylistMembership{TrackURIs: []string{"spotify:track:1", "spotify:track:2"}}, nil)
+
+	// child2 was renamed and edited by hand
+	expectPlaylistTracks(mocks, "spotify2", "spotify:track:1", "spotify:track:9")
+	mocks.snapshotService.EXPECT().GetMembership(gomock.Any(), "child2", "user123").
+		Return(&models.PlaylistMembership{TrackURIs: []string{"spotify:track:1", "spotify:track:2"}}, nil)
+
+	// child3 never completed a sync
+	expectPlaylistTracks(mocks, "spotify3")
+	mocks.snapshotService.EXPECT().GetMembership(gomock.Any(), "child3", "user123").
+		Return(nil, fmt.Errorf("failed to retrieve playlist membership: %w", repositories.ErrPlaylistMembershipNotFound))
+
+	// child4 was deleted in spotify, nothing else is fetched for it
+
+	audit, err := orchestrator.AuditBasePlaylist(context.Background(), "user123", "base123")
+	assert.NoError(err)
+	assert.Equal("base123", audit.BasePlaylistID)
+	assert.Len(audit.Children, 4)
+	assert.Equal(3, audit.Drifted)
+
+	inSync := audit.Children[0]
+	assert.True(inSync.InSync)
+	assert.Empty(inSync.Drift)
+	assert.Equal("[PLAYLIST GENERATED AND MANAGED BY PlaylistRouter] Loud & proud", inSync.ActualDescription)
+	assert.Equal(2, inSync.ExpectedTrackCount)
+	assert.Equal(2, inSync.ActualTrackCount)
+
+	edited := audit.Children[1]
+	assert.False(edited.InSync)
+	assert.Equal([]models.PlaylistDriftType{models.PlaylistDriftNameMismatch, models.PlaylistDriftContentMismatch}, edited.Drift)
+	assert.Equal("[Liked] > Jazz", edited.ExpectedName)
+	assert.Equal("Renamed", edited.ActualName)
+	assert.Equal([]string{"spotify:track:2"}, edited.MissingTrackURIs)
+	assert.Equal([]string{"spotify:track:9"}, edited.UnexpectedTrackURIs)
+
+	neverSynced := audit.Children[2]
+	assert.False(neverSynced.InSync)
+	assert.Equal([]models.PlaylistDriftType{models.PlaylistDriftNeverSynced}, neverSynced.Drift)
+
+	missing := audit.Children[3]
+	assert.False(missing.InSync)
+	assert.Equal([]models.PlaylistDriftType{models.PlaylistDriftMissing}, missing.Drift)
+	assert.Empty(missing.ActualName)
+}
+
+func TestDefaultSyncOrchestrator_AuditBasePlaylist_ChildErrors(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	basePlaylist := &models.BasePlaylist{ID: "base123", UserID: "user123", Name: "Liked"}
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: "user123", Name: "Rock", SpotifyPlaylistID: "spotify1"},
+		{ID: "child2", UserID: "user123", Name: "Jazz", SpotifyPlaylistID: "spotify2"},
+	}
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base123", "user123").Return(basePlaylist, nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base123", "user123").Return(childPlaylists, nil)
+	mocks.spotifyClient.EXPECT().GetAllUserPlaylists(gomock.Any()).Return([]*spotifyclient.SpotifyPlaylist{
+		{ID: "spotify1", Name: "[Liked] > Rock", Description: models.BuildChildPlaylistDescription("")},
+		{ID: "spotify2", Name: "[Liked] > Jazz", Description: models.BuildChildPlaylistDescription("")},
+	}, nil)
+
+	mocks.spotifyClient.EXPECT().GetPlaylistTracks(gomock.Any(), "spotify1", MAX_PLAYLIST_TRACKS, 0).Return(nil, errors.New("spotify down"))
+	expectPlaylistTracks(mocks, "spotify2")
+	mocks.snapshotService.EXPECT().GetMembership(gomock.Any(), "child2", "user123").Return(nil, errors.New("db error"))
+
+	audit, err := orchestrator.AuditBasePlaylist(context.Background(), "user123", "base123")
+	assert.NoError(err)
+	assert.Equal(2, audit.Drifted)
+	for _, child := range audit.Children {
+		// A child that could not be verified is never reported in sync
+		assert.False(child.InSync)
+		assert.NotNil(child.ErrorMessage)
+	}
+}
+
+func TestDefaultSyncOrchestrator_AuditBasePlaylist_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseErr       error
+		playlistsErr  error
+		expectedError string
+	}{
+		{name: "base playlist not found", baseErr: repositories.ErrBasePlaylistNotFound, expectedError: "failed to get base playlist"},
+		{name: "user playlists error", playlistsErr: errors.New("spotify down"), expectedError: "failed to get user playlists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+
+			if tt.baseErr != nil {
+				mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base123", "user123").Return(nil, tt.baseErr)
+			} else {
+				mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base123", "user123").
+					Return(&models.BasePlaylist{ID: "base123", UserID: "user123"}, nil)
+				mocks.spotifyClient.EXPECT().GetAllUserPlaylists(gomock.Any()).Return(nil, tt.playlistsErr)
+			}
+
+			audit, err := orchestrator.AuditBasePlaylist(context.Background(), "user123", "base123")
+			assert.Error(err)
+			assert.Contains(err.Error(), tt.expectedError)
+			assert.Nil(audit)
+		})
+	}
+}
+
+func TestDefaultSyncOrchestrator_AuditAllBasePlaylists(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	basePlaylists := []*models.BasePlaylist{
+		{ID: "base1", UserID: "user123", Name: "Liked"},
+		{ID: "base2", UserID: "user123", Name: "Archive", IsActive: false},
+	}
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), "user123").Return(basePlaylists, nil)
+	// User playlists are fetched once for the whole report
+	mocks.spotifyClient.EXPECT().GetAllUserPlaylists(gomock.Any()).Return([]*spotifyclient.SpotifyPlaylist{}, nil).Times(1)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base1", "user123").
+		Return([]*models.ChildPlaylist{{ID: "child1", UserID: "user123", SpotifyPlaylistID: "spotify1"}}, nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base2", "user123").
+		Return(nil, errors.New("db error"))
+
+	report, err := orchestrator.AuditAllBasePlaylists(context.Background(), "user123")
+	assert.NoError(err)
+	assert.Equal("user123", report.UserID)
+	assert.False(report.AuditedAt.IsZero())
+	assert.Len(report.BasePlaylists, 2)
+	assert.Equal(1, report.TotalChildren)
+	assert.Equal(1, report.DriftedChildren)
+	assert.Equal([]models.PlaylistDriftType{models.PlaylistDriftMissing}, report.BasePlaylists[0].Children[0].Drift)
+	assert.NotNil(report.BasePlaylists[1].ErrorMessage)
+}
+
+func TestDefaultSyncOrchestrator_AuditAllBasePlaylists_Error(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), "user123").Return(nil, errors.New("db error"))
+
+	report, err := orchestrator.AuditAllBasePlaylists(context.Background(), "user123")
+	assert.Error(err)
+	assert.Nil(report)
+}
@@ -0,0 +1,182 @@
+package orchestrators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// ComputeRoutingDiff computes on demand the before/after routing diff of a filter rule change
+// against the current tracks of the base playlist, without touching any Spotify playlist
+func (s *DefaultSyncOrchestrator) ComputeRoutingDiff(ctx context.Context, userID, ruleChangeID string) (*models.FilterRuleChange, error) {
+	s.logger.InfoContext(ctx, "computing routing diff on demand",
+		"user_id", userID,
+		"filter_rule_change_id", ruleChangeID,
+	)
+
+	change, err := s.ruleHistoryService.GetChange(ctx, ruleChangeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	basePlaylist, err := s.basePlaylistService.GetBasePlaylist(ctx, change.BasePlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, change.BasePlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child playlists: %w", err)
+	}
+
+	trackData, err := s.trackAggregator.AggregatePlaylistData(ctx, userID, change.BasePlaylistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate track data: %w", err)
+	}
+
+	diff, err := s.computeRoutingDiff(ctx, change, basePlaylist, childPlaylists, trackData)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ruleHistoryService.SaveRoutingDiff(ctx, change.ID, diff)
+}
+
+// recordPendingRoutingDiffs stores the routing diff of every filter rule change made since the
+// previous sync of the base playlist. Failures are only logged so they never fail the sync.
+func (s *DefaultSyncOrchestrator) recordPendingRoutingDiffs(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	basePlaylist *models.BasePlaylist,
+	childPlaylists []*models.ChildPlaylist,
+	trackData *models.PlaylistTracksInfo,
+) {
+	changes, err := s.ruleHistoryService.GetPendingChanges(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "unable to get pending filter rule changes, skipping routing diffs",
+			"sync_event_id", syncEvent.ID,
+			"error", err.Error(),
+		)
+		return
+	}
+
+	for _, change := range changes {
+		diff, err := s.computeRoutingDiff(ctx, change, basePlaylist, childPlaylists, trackData)
+		if err != nil {
+			s.logger.WarnContext(ctx, "unable to compute routing diff",
+				"sync_event_id", syncEvent.ID,
+				"filter_rule_change_id", change.ID,
+				"error", err.Error(),
+			)
+			continue
+		}
+
+		diff.SyncEventID = syncEvent.ID
+		if _, err := s.ruleHistoryService.SaveRoutingDiff(ctx, change.ID, diff); err != nil {
+			s.logger.WarnContext(ctx, "unable to save routing diff",
+				"sync_event_id", syncEvent.ID,
+				"filter_rule_change_id", change.ID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+// computeRoutingDiff routes the tracks once with the previous and once with the new filter rules
+// of the changed child playlist, keeping every other child playlist as it is today, and compares
+// the tracks the changed child playlist receives in both cases
+func (s *DefaultSyncOrchestrator) computeRoutingDiff(
+	ctx context.Context,
+	change *models.FilterRuleChange,
+	basePlaylist *models.BasePlaylist,
+	childPlaylists []*models.ChildPlaylist,
+	trackData *models.PlaylistTracksInfo,
+) (*models.RoutingDiff, error) {
+	var changedChild *models.ChildPlaylist
+	for _, child := range childPlaylists {
+		if child.ID == change.ChildPlaylistID {
+			changedChild = child
+			break
+		}
+	}
+	if changedChild == nil {
+		return nil, fmt.Errorf("child playlist %s not found in base playlist %s", change.ChildPlaylistID, change.BasePlaylistID)
+	}
+
+	before, err := s.routeChildWithRules(ctx, changedChild, change.PreviousFilterRules, basePlaylist, childPlaylists, trackData)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.routeChildWithRules(ctx, changedChild, change.NewFilterRules, basePlaylist, childPlaylists, trackData)
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed := diffTrackURIs(before, after)
+
+	return &models.RoutingDiff{
+		TracksBefore:     len(before),
+		TracksAfter:      len(after),
+		AddedTrackURIs:   added,
+		RemovedTrackURIs: removed,
+		ComputedAt:       time.Now(),
+	}, nil
+}
+
+// routeChildWithRules returns the tracks routed to a child playlist if it used the given filter rules
+func (s *DefaultSyncOrchestrator) routeChildWithRules(
+	ctx context.Context,
+	changedChild *models.ChildPlaylist,
+	filterRules *models.AudioFeatureFilters,
+	basePlaylist *models.BasePlaylist,
+	childPlaylists []*models.ChildPlaylist,
+	trackData *models.PlaylistTracksInfo,
+) ([]string, error) {
+	children := make([]*models.ChildPlaylist, len(childPlaylists))
+	for i, child := range childPlaylists {
+		if child.ID != changedChild.ID {
+			children[i] = child
+			continue
+		}
+
+		withRules := *child
+		withRules.FilterRules = filterRules
+		children[i] = &withRules
+	}
+
+	routing, _, err := s.trackRouter.RouteTracksToChildren(ctx, trackData, children, basePlaylist.DedupeStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to route tracks: %w", err)
+	}
+
+	return routing[changedChild.SpotifyPlaylistID], nil
+}
+
+// diffTrackURIs returns the tracks only present in after and the ones only present in before, keeping their order
+func diffTrackURIs(before, after []string) (added, removed []string) {
+	beforeSet := make(map[string]bool, len(before))
+	for _, uri := range before {
+		beforeSet[uri] = true
+	}
+
+	added, removed = []string{}, []string{}
+
+	afterSet := make(map[string]bool, len(after))
+	for _, uri := range after {
+		afterSet[uri] = true
+		if !beforeSet[uri] {
+			added = append(added, uri)
+		}
+	}
+
+	for _, uri := range before {
+		if !afterSet[uri] {
+			removed = append(removed, uri)
+		}
+	}
+
+	return added, removed
+}
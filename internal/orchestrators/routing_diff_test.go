@@ -0,0 +1,204 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTrackURIs(t *testing.T) {
+	tests := []struct {
+		name            string
+		before          []string
+		after           []string
+		expectedAdded   []string
+		expectedRemoved []string
+	}{
+		{
+			name:            "no changes",
+			before:          []string{"spotify:track:1", "spotify:track:2"},
+			after:           []string{"spotify:track:1", "spotify:track:2"},
+			expectedAdded:   []string{},
+			expectedRemoved: []string{},
+		},
+		{
+			name:            "tracks added and removed",
+			before:          []string{"spotify:track:1", "spotify:track:2"},
+			after:           []string{"spotify:track:2", "spotify:track:3"},
+			expectedAdded:   []string{"spotify:track:3"},
+			expectedRemoved: []string{"spotify:track:1"},
+		},
+		{
+			name:            "empty before",
+			before:          nil,
+			after:           []string{"spotify:track:1"},
+			expectedAdded:   []string{"spotify:track:1"},
+			expectedRemoved: []string{},
+		},
+		{
+			name:            "empty after",
+			before:          []string{"spotify:track:1"},
+			after:           nil,
+			expectedAdded:   []string{},
+			expectedRemoved: []string{"spotify:track:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			added, removed := diffTrackURIs(tt.before, tt.after)
+
+			assert.Equal(tt.expectedAdded, added)
+			assert.Equal(tt.expectedRemoved, removed)
+		})
+	}
+}
+
+// expectRoutingByRules mocks the router so the changed child playlist receives a different set
+// of tracks depending on whether it is routed with the previous or the new filter rules
+func expectRoutingByRules(mocks mockServices, change *models.FilterRuleChange, before, after []string) {
+	mocks.trackRouter.EXPECT().RouteTracksToChildren(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, dedupeStrategy models.DedupeStrategy) (map[string][]string, *models.RoutingReport, error) {
+			for _, child := range childPlaylists {
+				if child.ID != change.ChildPlaylistID {
+					continue
+				}
+				if child.FilterRules == change.PreviousFilterRules {
+					return map[string][]string{child.SpotifyPlaylistID: before}, nil, nil
+				}
+				return map[string][]string{child.SpotifyPlaylistID: after}, nil, nil
+			}
+			return map[string][]string{}, nil, nil
+		}).Times(2)
+}
+
+func TestDefaultSyncOrchestrator_RecordPendingRoutingDiffs(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	previousRules := &models.AudioFeatureFilters{Genres: &models.SetFilter{Include: []string{"rock"}}}
+	newRules := &models.AudioFeatureFilters{Genres: &models.SetFilter{Include: []string{"rock", "metal"}}}
+
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123", BasePlaylistID: "base456"}
+	basePlaylist := &models.BasePlaylist{ID: "base456", DedupeStrategy: models.DedupeStrategyAllMatches}
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", SpotifyPlaylistID: "spotify1", FilterRules: newRules},
+		{ID: "child2", SpotifyPlaylistID: "spotify2"},
+	}
+	trackData := &models.PlaylistTracksInfo{Tracks: []models.TrackInfo{{URI: "spotify:track:1"}, {URI: "spotify:track:2"}}}
+
+	change := &models.FilterRuleChange{
+		ID:                  "change1",
+		BasePlaylistID:      "base456",
+		ChildPlaylistID:     "child1",
+		PreviousFilterRules: previousRules,
+		NewFilterRules:      newRules,
+	}
+	orphanChange := &models.FilterRuleChange{ID: "change2", BasePlaylistID: "base456", ChildPlaylistID: "deleted_child"}
+
+	mocks.ruleHistoryService.EXPECT().GetPendingChanges(gomock.Any(), "base456", "user123").
+		Return([]*models.FilterRuleChange{change, orphanChange}, nil)
+	expectRoutingByRules(mocks, change, []string{"spotify:track:1"}, []string{"spotify:track:1", "spotify:track:2"})
+	mocks.ruleHistoryService.EXPECT().SaveRoutingDiff(gomock.Any(), "change1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, diff *models.RoutingDiff) (*models.FilterRuleChange, error) {
+			assert.Equal("sync123", diff.SyncEventID)
+			assert.Equal(1, diff.TracksBefore)
+			assert.Equal(2, diff.TracksAfter)
+			assert.Equal([]string{"spotify:track:2"}, diff.AddedTrackURIs)
+			assert.Empty(diff.RemovedTrackURIs)
+			assert.False(diff.ComputedAt.IsZero())
+			return &models.FilterRuleChange{ID: id, RoutingDiff: diff}, nil
+		})
+
+	// The orphan change is skipped without failing the others
+	orchestrator.recordPendingRoutingDiffs(context.Background(), syncEvent, basePlaylist, childPlaylists, trackData)
+}
+
+func TestDefaultSyncOrchestrator_RecordPendingRoutingDiffs_GetPendingError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123", BasePlaylistID: "base456"}
+
+	mocks.ruleHistoryService.EXPECT().GetPendingChanges(gomock.Any(), "base456", "user123").
+		Return(nil, errors.New("db error"))
+
+	// No routing or saving happens, and the sync is not failed
+	orchestrator.recordPendingRoutingDiffs(context.Background(), syncEvent, &models.BasePlaylist{}, nil, &models.PlaylistTracksInfo{})
+}
+
+func TestDefaultSyncOrchestrator_ComputeRoutingDiff_Success(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	explicitOnly, cleanOnly := true, false
+	previousRules := &models.AudioFeatureFilters{Explicit: &explicitOnly}
+	newRules := &models.AudioFeatureFilters{Explicit: &cleanOnly}
+
+	change := &models.FilterRuleChange{
+		ID:                  "change1",
+		UserID:              "user123",
+		BasePlaylistID:      "base456",
+		ChildPlaylistID:     "child1",
+		PreviousFilterRules: previousRules,
+		NewFilterRules:      newRules,
+	}
+	basePlaylist := &models.BasePlaylist{ID: "base456", DedupeStrategy: models.DedupeStrategyAllMatches}
+	childPlaylists := []*models.ChildPlaylist{{ID: "child1", SpotifyPlaylistID: "spotify1", FilterRules: newRules}}
+	trackData := &models.PlaylistTracksInfo{Tracks: []models.TrackInfo{{URI: "spotify:track:1"}, {URI: "spotify:track:2"}}}
+
+	mocks.ruleHistoryService.EXPECT().GetChange(gomock.Any(), "change1", "user123").Return(change, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base456", "user123").Return(basePlaylist, nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base456", "user123").Return(childPlaylists, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), "user123", "base456").Return(trackData, nil)
+	expectRoutingByRules(mocks, change, []string{"spotify:track:1"}, []string{"spotify:track:2"})
+	mocks.ruleHistoryService.EXPECT().SaveRoutingDiff(gomock.Any(), "change1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, diff *models.RoutingDiff) (*models.FilterRuleChange, error) {
+			updated := *change
+			updated.RoutingDiff = diff
+			return &updated, nil
+		})
+
+	result, err := orchestrator.ComputeRoutingDiff(context.Background(), "user123", "change1")
+
+	assert.NoError(err)
+	assert.NotNil(result.RoutingDiff)
+	assert.Empty(result.RoutingDiff.SyncEventID)
+	assert.Equal([]string{"spotify:track:2"}, result.RoutingDiff.AddedTrackURIs)
+	assert.Equal([]string{"spotify:track:1"}, result.RoutingDiff.RemovedTrackURIs)
+}
+
+func TestDefaultSyncOrchestrator_ComputeRoutingDiff_ChangeNotFound(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.ruleHistoryService.EXPECT().GetChange(gomock.Any(), "missing", "user123").
+		Return(nil, repositories.ErrFilterRuleChangeNotFound)
+
+	result, err := orchestrator.ComputeRoutingDiff(context.Background(), "user123", "missing")
+
+	assert.ErrorIs(err, repositories.ErrFilterRuleChangeNotFound)
+	assert.Nil(result)
+}
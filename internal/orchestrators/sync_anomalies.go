@@ -0,0 +1,104 @@
+package orchestrators
+
+import (
+	"fmt"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+const (
+	// ANOMALY_MIN_TRACKS keeps small playlists out of the checks, where large relative swings are normal
+	ANOMALY_MIN_TRACKS = 20
+	// ANOMALY_MAX_TRACK_DROP_RATIO is the share of its previous tracks a child playlist may lose in one sync
+	ANOMALY_MAX_TRACK_DROP_RATIO = 0.8
+	// ANOMALY_MAX_UNMATCHED_RATIO is the share of base playlist tracks that may match no child playlist
+	ANOMALY_MAX_UNMATCHED_RATIO = 0.9
+)
+
+// countUnmatchedTracks returns how many tracks of the base playlist matched no child playlist filter.
+// Tracks only routed to a fallback child playlist count as unmatched.
+func countUnmatchedTracks(trackData *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, routing map[string][]string) int {
+	fallbackPlaylistIDs := make(map[string]bool)
+	for _, childPlaylist := range childPlaylists {
+		if childPlaylist.IsFallback {
+			fallbackPlaylistIDs[childPlaylist.SpotifyPlaylistID] = true
+		}
+	}
+
+	matched := make(map[string]bool)
+	for spotifyPlaylistID, trackURIs := range routing {
+		if fallbackPlaylistIDs[spotifyPlaylistID] {
+			continue
+		}
+		for _, trackURI := range trackURIs {
+			matched[trackURI] = true
+		}
+	}
+
+	unmatched := 0
+	for _, track := range trackData.Tracks {
+		if !matched[track.URI] {
+			unmatched++
+		}
+	}
+
+	return unmatched
+}
+
+// detectSyncAnomalies compares the routing of a sync against the last completed sync of the
+// same base playlist and flags changes that usually come from a bad filter edit or an upstream
+// data glitch rather than from a real change in the base playlist
+func detectSyncAnomalies(
+	previousSync *models.SyncEvent,
+	syncEvent *models.SyncEvent,
+	childPlaylists []*models.ChildPlaylist,
+	routing map[string][]string,
+) []models.SyncAnomaly {
+	if previousSync == nil {
+		return nil
+	}
+
+	var anomalies []models.SyncAnomaly
+
+	previousTrackCounts := make(map[string]int, len(previousSync.ChildSyncResults))
+	for _, result := range previousSync.ChildSyncResults {
+		if result.Status == models.SyncStatusCompleted {
+			previousTrackCounts[result.ChildPlaylistID] = result.TracksAdded
+		}
+	}
+
+	for _, childPlaylist := range childPlaylists {
+		previousCount, ok := previousTrackCounts[childPlaylist.ID]
+		if !ok || previousCount < ANOMALY_MIN_TRACKS {
+			continue
+		}
+
+		newCount := len(routing[childPlaylist.SpotifyPlaylistID])
+		if float64(previousCount-newCount) > float64(previousCount)*ANOMALY_MAX_TRACK_DROP_RATIO {
+			anomalies = append(anomalies, models.SyncAnomaly{
+				Type:            models.SyncAnomalyChildTrackDrop,
+				ChildPlaylistID: childPlaylist.ID,
+				PreviousCount:   previousCount,
+				NewCount:        newCount,
+				Message:         fmt.Sprintf("child playlist %q would drop from %d to %d tracks", childPlaylist.Name, previousCount, newCount),
+			})
+		}
+	}
+
+	if syncEvent.TracksProcessed >= ANOMALY_MIN_TRACKS &&
+		isMostlyUnmatched(syncEvent.TracksUnmatched, syncEvent.TracksProcessed) &&
+		!isMostlyUnmatched(previousSync.TracksUnmatched, previousSync.TracksProcessed) {
+		anomalies = append(anomalies, models.SyncAnomaly{
+			Type:          models.SyncAnomalyUnmatchedSpike,
+			PreviousCount: previousSync.TracksUnmatched,
+			NewCount:      syncEvent.TracksUnmatched,
+			Message:       fmt.Sprintf("%d of %d tracks would match no child playlist", syncEvent.TracksUnmatched, syncEvent.TracksProcessed),
+		})
+	}
+
+	return anomalies
+}
+
+func isMostlyUnmatched(unmatched, total int) bool {
+	return total > 0 && float64(unmatched) >= float64(total)*ANOMALY_MAX_UNMATCHED_RATIO
+}
@@ -0,0 +1,130 @@
+package orchestrators
+
+import (
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountUnmatchedTracks(t *testing.T) {
+	assert := require.New(t)
+
+	trackData := &models.PlaylistTracksInfo{
+		Tracks: []models.TrackInfo{
+			{URI: "spotify:track:1"},
+			{URI: "spotify:track:2"},
+			{URI: "spotify:track:3"},
+		},
+	}
+	routing := map[string][]string{
+		"spotify1": {"spotify:track:1"},
+		"spotify2": {"spotify:track:1", "spotify:track:3"},
+	}
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", SpotifyPlaylistID: "spotify1"},
+		{ID: "child2", SpotifyPlaylistID: "spotify2"},
+		{ID: "fallback", SpotifyPlaylistID: "spotify_fallback", IsFallback: true},
+	}
+
+	assert.Equal(1, countUnmatchedTracks(trackData, childPlaylists, routing))
+	assert.Equal(3, countUnmatchedTracks(trackData, childPlaylists, map[string][]string{}))
+
+	// Tracks routed to the fallback child are still unmatched
+	routing["spotify_fallback"] = []string{"spotify:track:2"}
+	assert.Equal(1, countUnmatchedTracks(trackData, childPlaylists, routing))
+}
+
+func TestDetectSyncAnomalies(t *testing.T) {
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", SpotifyPlaylistID: "spotify1", Name: "Child 1"},
+		{ID: "child2", SpotifyPlaylistID: "spotify2", Name: "Child 2"},
+	}
+
+	routingWithCounts := func(child1, child2 int) map[string][]string {
+		return map[string][]string{
+			"spotify1": make([]string, child1),
+			"spotify2": make([]string, child2),
+		}
+	}
+
+	previousSync := &models.SyncEvent{
+		TracksProcessed: 600,
+		TracksUnmatched: 10,
+		ChildSyncResults: []models.ChildSyncResult{
+			{ChildPlaylistID: "child1", Status: models.SyncStatusCompleted, TracksAdded: 500},
+			{ChildPlaylistID: "child2", Status: models.SyncStatusCompleted, TracksAdded: 10},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		previousSync  *models.SyncEvent
+		syncEvent     *models.SyncEvent
+		routing       map[string][]string
+		expectedTypes []models.SyncAnomalyType
+	}{
+		{
+			name:         "no previous sync",
+			previousSync: nil,
+			syncEvent:    &models.SyncEvent{TracksProcessed: 600, TracksUnmatched: 600},
+			routing:      routingWithCounts(0, 0),
+		},
+		{
+			name:         "stable sync",
+			previousSync: previousSync,
+			syncEvent:    &models.SyncEvent{TracksProcessed: 600, TracksUnmatched: 20},
+			routing:      routingWithCounts(480, 12),
+		},
+		{
+			name:          "child playlist drops most of its tracks",
+			previousSync:  previousSync,
+			syncEvent:     &models.SyncEvent{TracksProcessed: 600, TracksUnmatched: 20},
+			routing:       routingWithCounts(3, 10),
+			expectedTypes: []models.SyncAnomalyType{models.SyncAnomalyChildTrackDrop},
+		},
+		{
+			name:         "small child playlist dropping is ignored",
+			previousSync: previousSync,
+			syncEvent:    &models.SyncEvent{TracksProcessed: 600, TracksUnmatched: 20},
+			routing:      routingWithCounts(500, 0),
+		},
+		{
+			name:          "tracks suddenly unmatched",
+			previousSync:  previousSync,
+			syncEvent:     &models.SyncEvent{TracksProcessed: 600, TracksUnmatched: 560},
+			routing:       routingWithCounts(40, 10),
+			expectedTypes: []models.SyncAnomalyType{models.SyncAnomalyChildTrackDrop, models.SyncAnomalyUnmatchedSpike},
+		},
+		{
+			name: "tracks already mostly unmatched",
+			previousSync: &models.SyncEvent{
+				TracksProcessed: 600,
+				TracksUnmatched: 590,
+			},
+			syncEvent: &models.SyncEvent{TracksProcessed: 600, TracksUnmatched: 595},
+			routing:   routingWithCounts(5, 0),
+		},
+		{
+			name:         "small base playlist is ignored",
+			previousSync: &models.SyncEvent{TracksProcessed: 10},
+			syncEvent:    &models.SyncEvent{TracksProcessed: 10, TracksUnmatched: 10},
+			routing:      routingWithCounts(0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			anomalies := detectSyncAnomalies(tt.previousSync, tt.syncEvent, childPlaylists, tt.routing)
+
+			types := make([]models.SyncAnomalyType, len(anomalies))
+			for i, anomaly := range anomalies {
+				types[i] = anomaly.Type
+			}
+			assert.ElementsMatch(tt.expectedTypes, types)
+		})
+	}
+}
@@ -2,23 +2,46 @@ package orchestrators
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/profiling"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/services"
+	"github.com/ngomez18/playlist-router/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
-	MAX_PLAYLIST_TRACKS = 100
+	MAX_PLAYLIST_TRACKS       = 100
+	MAX_CONCURRENT_BASE_SYNCS = 3
 )
 
 //go:generate mockgen -source=sync_orchestrator.go -destination=mocks/mock_sync_orchestrator.go -package=mocks
 
 type SyncOrchestrator interface {
 	SyncBasePlaylist(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error)
+	SyncAllBasePlaylists(ctx context.Context, userID string) (*models.MultiSyncReport, error)
+	RollbackSync(ctx context.Context, userID, syncEventID string) (*models.SyncEvent, error)
+	ConfirmSync(ctx context.Context, userID, syncEventID string) (*models.SyncEvent, error)
+	ComputeRoutingDiff(ctx context.Context, userID, ruleChangeID string) (*models.FilterRuleChange, error)
+	AuditBasePlaylist(ctx context.Context, userID, basePlaylistID string) (*models.BasePlaylistAudit, error)
+	AuditAllBasePlaylists(ctx context.Context, userID string) (*models.AuditReport, error)
+	MigrateToInPlace(ctx context.Context, userID string) (*models.MultiSyncReport, error)
+	PreviewFilters(ctx context.Context, userID, basePlaylistID string, request *models.FilterPreviewRequest) (*models.FilterPreview, error)
+	GetRoutingReport(ctx context.Context, userID, syncEventID string) (*models.RoutingReport, error)
+	RetrySync(ctx context.Context, syncEventID string) (*models.SyncEvent, error)
 }
 
 type DefaultSyncOrchestrator struct {
@@ -27,7 +50,17 @@ type DefaultSyncOrchestrator struct {
 	childPlaylistService services.ChildPlaylistServicer
 	basePlaylistService  services.BasePlaylistServicer
 	syncEventService     services.SyncEventServicer
-	spotifyClient        spotifyclient.SpotifyAPI
+	snapshotService      services.PlaylistSnapshotServicer
+	ruleHistoryService   services.FilterRuleHistoryServicer
+	musicProvider        musicprovider.MusicProvider
+	spotifyAuth          services.SpotifyAuthProvider  // nil when syncs only start from authenticated requests
+	notificationService  services.NotificationServicer // nil when users aren't notified about their syncs
+	events               services.EventPublisher       // nil when sync progress isn't pushed to the app
+	featureFlags         services.FeatureFlagServicer  // nil when features follow their config for every user
+	maintenance          services.MaintenanceServicer  // nil when syncs can't be paused by maintenance mode
+	childConcurrency     int
+	streamingMinTracks   int  // 0 when every base playlist is synced in one batch
+	routingCache         bool // false when the track router isn't given base playlist snapshots
 
 	logger *slog.Logger
 }
@@ -38,7 +71,9 @@ func NewDefaultSyncOrchestrator(
 	childPlaylistService services.ChildPlaylistServicer,
 	basePlaylistService services.BasePlaylistServicer,
 	syncEventService services.SyncEventServicer,
-	spotifyClient spotifyclient.SpotifyAPI,
+	snapshotService services.PlaylistSnapshotServicer,
+	ruleHistoryService services.FilterRuleHistoryServicer,
+	musicProvider musicprovider.MusicProvider,
 	logger *slog.Logger,
 ) *DefaultSyncOrchestrator {
 	return &DefaultSyncOrchestrator{
@@ -47,40 +82,248 @@ func NewDefaultSyncOrchestrator(
 		childPlaylistService: childPlaylistService,
 		basePlaylistService:  basePlaylistService,
 		syncEventService:     syncEventService,
-		spotifyClient:        spotifyClient,
+		snapshotService:      snapshotService,
+		ruleHistoryService:   ruleHistoryService,
+		musicProvider:        musicProvider,
+		childConcurrency:     1,
 		logger:               logger.With("component", "DefaultSyncOrchestrator"),
 	}
 }
 
+// WithSpotifyAuth lets syncs run headlessly: a sync started without spotify credentials in its
+// context, by a scheduled or queued job, loads the credentials of the user through spotifyAuth
+func (s *DefaultSyncOrchestrator) WithSpotifyAuth(spotifyAuth services.SpotifyAuthProvider) *DefaultSyncOrchestrator {
+	s.spotifyAuth = spotifyAuth
+	return s
+}
+
+// WithNotifications notifies users about their finished syncs through notificationService
+func (s *DefaultSyncOrchestrator) WithNotifications(notificationService services.NotificationServicer) *DefaultSyncOrchestrator {
+	s.notificationService = notificationService
+	return s
+}
+
+// WithChildConcurrency writes up to limit child playlists of a sync to spotify at the same time
+// instead of one after the other. Their requests share the request budget of the spotify client,
+// so syncs with many children finish sooner without going over the spotify rate limit
+func (s *DefaultSyncOrchestrator) WithChildConcurrency(limit int) *DefaultSyncOrchestrator {
+	s.childConcurrency = max(limit, 1)
+	return s
+}
+
+// WithStreaming syncs base playlists of at least minTracks tracks as a stream of pages, holding
+// little track data in memory and writing the first tracks early, when their child playlists allow it
+func (s *DefaultSyncOrchestrator) WithStreaming(minTracks int) *DefaultSyncOrchestrator {
+	s.streamingMinTracks = minTracks
+	return s
+}
+
+// WithRoutingCache looks up the snapshot of each base playlist before reading its tracks, letting the
+// track router reuse the routing cached for that snapshot
+func (s *DefaultSyncOrchestrator) WithRoutingCache(enabled bool) *DefaultSyncOrchestrator {
+	s.routingCache = enabled
+	return s
+}
+
+// WithFeatureFlags rolls the sync features out gradually: a feature enabled in the config is only
+// used for the users its flag is on for, when it has one
+func (s *DefaultSyncOrchestrator) WithFeatureFlags(featureFlags services.FeatureFlagServicer) *DefaultSyncOrchestrator {
+	s.featureFlags = featureFlags
+	return s
+}
+
+// WithMaintenance refuses to start syncs while maintenance mode is on, whether started by a user,
+// an automation or a scheduled job. Syncs already running finish
+func (s *DefaultSyncOrchestrator) WithMaintenance(maintenance services.MaintenanceServicer) *DefaultSyncOrchestrator {
+	s.maintenance = maintenance
+	return s
+}
+
+// WithEvents pushes the progress of every sync to the app through events: its start, each phase
+// it moves to and its outcome
+func (s *DefaultSyncOrchestrator) WithEvents(events services.EventPublisher) *DefaultSyncOrchestrator {
+	s.events = events
+	return s
+}
+
 func (s *DefaultSyncOrchestrator) SyncBasePlaylist(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
 	s.logger.InfoContext(ctx, "starting playlist sync orchestration",
 		"user_id", userID,
 		"base_playlist_id", basePlaylistID,
 	)
 
+	return s.runSyncEvent(ctx, userID, basePlaylistID, func(ctx context.Context, syncEvent *models.SyncEvent) error {
+		return s.executeSyncFlow(ctx, syncEvent, true)
+	})
+}
+
+// ConfirmSync runs again a sync that was held back by anomaly detection, this time
+// committing its outcome without checking for anomalies. The held sync is marked confirmed
+// before the run starts, so it can only be confirmed once
+func (s *DefaultSyncOrchestrator) ConfirmSync(ctx context.Context, userID, syncEventID string) (*models.SyncEvent, error) {
+	s.logger.InfoContext(ctx, "confirming held back sync",
+		"user_id", userID,
+		"sync_event_id", syncEventID,
+	)
+
+	heldSyncEvent, err := s.syncEventService.GetSyncEvent(ctx, syncEventID)
+	if err != nil || heldSyncEvent.UserID != userID {
+		return nil, fmt.Errorf("%w: %s", ErrSyncEventNotFound, syncEventID)
+	}
+	if heldSyncEvent.Status != models.SyncStatusNeedsConfirmation {
+		return nil, fmt.Errorf("%w: %s", ErrSyncNotAwaitingConfirmation, syncEventID)
+	}
+
+	// A concurrent confirmation of the same sync may have won since it was read
+	err = s.syncEventService.TransitionSyncEventStatus(ctx, syncEventID, models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed)
+	if errors.Is(err, repositories.ErrSyncEventStatusChanged) {
+		return nil, fmt.Errorf("%w: %s", ErrSyncNotAwaitingConfirmation, syncEventID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm sync event: %w", err)
+	}
+
+	confirmedSyncEvent, err := s.runSyncEvent(ctx, userID, heldSyncEvent.BasePlaylistID, func(ctx context.Context, syncEvent *models.SyncEvent) error {
+		return s.executeSyncFlow(ctx, syncEvent, false)
+	})
+	if confirmedSyncEvent == nil {
+		// The confirmed run never started, keep the held sync confirmable
+		s.restoreHeldSync(ctx, syncEventID)
+	}
+
+	return confirmedSyncEvent, err
+}
+
+// restoreHeldSync puts a confirmed sync back to needs_confirmation. Failures are only logged, the
+// error that kept the confirmed run from starting is the one reported
+func (s *DefaultSyncOrchestrator) restoreHeldSync(ctx context.Context, syncEventID string) {
+	err := s.syncEventService.TransitionSyncEventStatus(ctx, syncEventID, models.SyncStatusConfirmed, models.SyncStatusNeedsConfirmation)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to restore held back sync", "sync_event_id", syncEventID, "error", err.Error())
+	}
+}
+
+// RollbackSync restores the child playlists touched by a previous sync to the tracks they
+// held before it ran. The rollback is recorded as a new sync event and snapshots the
+// current contents too, so it can be rolled back as well.
+func (s *DefaultSyncOrchestrator) RollbackSync(ctx context.Context, userID, syncEventID string) (*models.SyncEvent, error) {
+	s.logger.InfoContext(ctx, "starting sync rollback orchestration",
+		"user_id", userID,
+		"sync_event_id", syncEventID,
+	)
+
+	targetSyncEvent, err := s.syncEventService.GetSyncEvent(ctx, syncEventID)
+	if err != nil || targetSyncEvent.UserID != userID {
+		return nil, fmt.Errorf("%w: %s", ErrSyncEventNotFound, syncEventID)
+	}
+
+	snapshots, err := s.snapshotService.GetSnapshotsBySyncEventID(ctx, syncEventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlist snapshots: %w", err)
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("%w %s", ErrNothingToRollback, syncEventID)
+	}
+
+	return s.runSyncEvent(ctx, userID, targetSyncEvent.BasePlaylistID, func(ctx context.Context, syncEvent *models.SyncEvent) error {
+		return s.executeRollbackFlow(ctx, syncEvent, snapshots)
+	})
+}
+
+// GetRoutingReport explains how the sync routed each track of the base playlist. Only the latest
+// sync of each base playlist keeps its report
+func (s *DefaultSyncOrchestrator) GetRoutingReport(ctx context.Context, userID, syncEventID string) (*models.RoutingReport, error) {
+	syncEvent, err := s.syncEventService.GetSyncEvent(ctx, syncEventID)
+	if err != nil || syncEvent.UserID != userID {
+		return nil, fmt.Errorf("%w: %s", ErrSyncEventNotFound, syncEventID)
+	}
+
+	report, err := s.snapshotService.GetRoutingReport(ctx, syncEventID, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRoutingReportNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrRoutingReportNotFound, syncEventID)
+		}
+		return nil, fmt.Errorf("failed to get routing report: %w", err)
+	}
+
+	return report, nil
+}
+
+// runSyncEvent guards against concurrent syncs of the same base playlist, records a new
+// sync event and completes it according to the outcome of flow. Background syncs wait for
+// the Spotify request budget instead of failing when it is exhausted
+func (s *DefaultSyncOrchestrator) runSyncEvent(
+	ctx context.Context,
+	userID, basePlaylistID string,
+	flow func(ctx context.Context, syncEvent *models.SyncEvent) error,
+) (*models.SyncEvent, error) {
+	// The steps of the sync are traced under this span, see profiling.StartSpan
+	ctx, span := tracing.Tracer().Start(ctx, "sync", trace.WithAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("base_playlist_id", basePlaylistID),
+	))
+	defer span.End()
+
+	if s.maintenance != nil {
+		if status := s.maintenance.Status(ctx); status.Enabled {
+			return nil, fmt.Errorf("%w: %s", ErrMaintenanceMode, status.Message)
+		}
+	}
+
+	ctx, err := s.ensureSpotifyAuth(ctx, userID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
 	// Check for existing active sync
 	hasActiveSync, err := s.syncEventService.HasActiveSyncForBasePlaylist(ctx, userID, basePlaylistID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check for active sync: %w", err)
 	}
 	if hasActiveSync {
-		return nil, fmt.Errorf("sync already in progress for base playlist %s", basePlaylistID)
+		return nil, fmt.Errorf("%w for base playlist %s", ErrSyncInProgress, basePlaylistID)
 	}
 
+	// Syncs started by a request keep its ID, so a failed sync can be traced back to the logs
+	requestID, _ := requestcontext.GetRequestIDFromContext(ctx)
 	syncEvent := &models.SyncEvent{
 		UserID:         userID,
 		BasePlaylistID: basePlaylistID,
 		Status:         models.SyncStatusInProgress,
 		StartedAt:      time.Now(),
+		RequestID:      requestID,
 	}
 
 	syncEvent, err = s.syncEventService.CreateSyncEvent(ctx, syncEvent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sync event: %w", err)
 	}
+	span.SetAttributes(attribute.String("sync_event_id", syncEvent.ID))
+	s.publishSyncProgress(syncEvent)
+
+	if requestcontext.IsBackgroundJob(ctx) {
+		ctx = spotifyclient.ContextWithQuotaQueue(ctx, &syncQuotaWaiter{orchestrator: s, syncEvent: syncEvent})
+	}
+
+	rateLimitStats := &spotifyclient.RateLimitStats{}
+	ctx = spotifyclient.ContextWithRateLimitStats(ctx, rateLimitStats)
+
+	var profiler *profiling.Profiler
+	if requestcontext.IsSyncProfiling(ctx) {
+		profiler = profiling.NewProfiler("sync")
+		ctx = profiling.ContextWithProfiler(ctx, profiler)
+	}
 
 	// Execute sync and handle completion/failure
-	if syncErr := s.executeSyncFlow(ctx, syncEvent); syncErr != nil {
+	syncErr := flow(ctx, syncEvent)
+	syncEvent.RateLimit = rateLimitStats.Summary()
+	if profiler != nil {
+		syncEvent.Profile = profiler.Finish()
+	}
+	if syncErr != nil {
+		span.RecordError(syncErr)
+		span.SetStatus(codes.Error, syncErr.Error())
 		s.completeSyncWithError(ctx, syncEvent, syncErr)
 		return syncEvent, syncErr
 	}
@@ -89,26 +332,166 @@ func (s *DefaultSyncOrchestrator) SyncBasePlaylist(ctx context.Context, userID,
 	return syncEvent, nil
 }
 
-func (s *DefaultSyncOrchestrator) executeSyncFlow(ctx context.Context, syncEvent *models.SyncEvent) error {
+// SyncAllBasePlaylists syncs every active, unarchived base playlist of the user, running at most
+// MAX_CONCURRENT_BASE_SYNCS syncs at a time. Individual sync failures are reported
+// in the returned report instead of aborting the remaining syncs.
+func (s *DefaultSyncOrchestrator) SyncAllBasePlaylists(ctx context.Context, userID string) (*models.MultiSyncReport, error) {
+	s.logger.InfoContext(ctx, "starting multi-base playlist sync orchestration", "user_id", userID)
+
+	// Loaded once for every base playlist synced
+	ctx, err := s.ensureSpotifyAuth(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	basePlaylists, err := s.basePlaylistService.GetBasePlaylistsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlists: %w", err)
+	}
+
+	activeBasePlaylists := make([]*models.BasePlaylist, 0, len(basePlaylists))
+	for _, basePlaylist := range basePlaylists {
+		if basePlaylist.IsActive && !basePlaylist.Archived {
+			activeBasePlaylists = append(activeBasePlaylists, basePlaylist)
+		}
+	}
+
+	results := make([]models.BaseSyncResult, len(activeBasePlaylists))
+	semaphore := make(chan struct{}, MAX_CONCURRENT_BASE_SYNCS)
+	var wg sync.WaitGroup
+
+	for i, basePlaylist := range activeBasePlaylists {
+		wg.Add(1)
+		go func(i int, basePlaylistID string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = s.syncBasePlaylistForReport(ctx, userID, basePlaylistID)
+		}(i, basePlaylist.ID)
+	}
+
+	wg.Wait()
+
+	report := &models.MultiSyncReport{
+		UserID:  userID,
+		Results: results,
+		Total:   len(results),
+	}
+	for _, result := range results {
+		if result.Status == models.SyncStatusCompleted {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+
+	s.logger.InfoContext(ctx, "multi-base playlist sync completed",
+		"user_id", userID,
+		"total", report.Total,
+		"succeeded", report.Succeeded,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
+
+// ensureSpotifyAuth returns a context carrying the spotify credentials of the user. Syncs started
+// from an API request already have them; headless syncs get them loaded, and refreshed when
+// about to expire, through the spotify auth provider
+func (s *DefaultSyncOrchestrator) ensureSpotifyAuth(ctx context.Context, userID string) (context.Context, error) {
+	if integration, ok := requestcontext.GetSpotifyAuthFromContext(ctx); ok && integration.UserID == userID {
+		return ctx, nil
+	}
+	if s.spotifyAuth == nil {
+		return ctx, nil
+	}
+
+	spotifyCtx, err := s.spotifyAuth.ContextWithSpotifyAuth(ctx, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to load spotify credentials", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to load spotify credentials: %w", err)
+	}
+
+	return spotifyCtx, nil
+}
+
+// withPlaylistAccount returns a context routed to the music provider of a playlist, carrying the
+// credentials of the spotify account it is linked to when that is not the account the context
+// already carries. Playlists without an account, or syncs without a spotify auth provider, keep the
+// account of the context. Youtube music playlists use the single account of the user
+func (s *DefaultSyncOrchestrator) withPlaylistAccount(ctx context.Context, userID string, provider models.MusicProvider, integrationID string) (context.Context, error) {
+	ctx = requestcontext.ContextWithMusicProvider(ctx, provider)
+	if provider.OrDefault() != models.MusicProviderSpotify || integrationID == "" || s.spotifyAuth == nil {
+		return ctx, nil
+	}
+	if integration, ok := requestcontext.GetSpotifyAuthFromContext(ctx); ok && integration.ID == integrationID {
+		return ctx, nil
+	}
+
+	spotifyCtx, err := s.spotifyAuth.ContextWithSpotifyAccount(ctx, userID, integrationID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to load spotify account credentials", "user_id", userID, "integration_id", integrationID, "error", err.Error())
+		return nil, fmt.Errorf("failed to load spotify account credentials: %w", err)
+	}
+
+	return spotifyCtx, nil
+}
+
+func (s *DefaultSyncOrchestrator) syncBasePlaylistForReport(ctx context.Context, userID, basePlaylistID string) models.BaseSyncResult {
+	result := models.BaseSyncResult{BasePlaylistID: basePlaylistID}
+
+	syncEvent, err := s.SyncBasePlaylist(ctx, userID, basePlaylistID)
+	if syncEvent != nil {
+		result.SyncEventID = syncEvent.ID
+		result.Status = syncEvent.Status
+	}
+
+	if err != nil {
+		errorMessage := err.Error()
+		result.ErrorMessage = &errorMessage
+		if !errors.Is(err, ErrSyncAnomalyDetected) {
+			result.Status = models.SyncStatusFailed
+		}
+	}
+
+	return result
+}
+
+func (s *DefaultSyncOrchestrator) executeSyncFlow(ctx context.Context, syncEvent *models.SyncEvent, checkAnomalies bool) error {
 	// Get base playlist
 	s.logger.InfoContext(ctx, "step 1: fetching base playlist", "sync_event_id", syncEvent.ID)
+	loadCtx, endLoad := profiling.StartSpan(ctx, "load_playlists")
 
-	basePlaylist, err := s.basePlaylistService.GetBasePlaylist(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	basePlaylist, err := s.basePlaylistService.GetBasePlaylist(loadCtx, syncEvent.BasePlaylistID, syncEvent.UserID)
 	if err != nil {
+		endLoad()
 		return fmt.Errorf("failed to get base playlist: %w", err)
 	}
+	if basePlaylist.Archived {
+		endLoad()
+		return fmt.Errorf("%w: %s", ErrBasePlaylistArchived, basePlaylist.ID)
+	}
 
 	// Get child playlists
 	s.logger.InfoContext(ctx, "step 2: fetching child playlists", "sync_event_id", syncEvent.ID)
 
-	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(loadCtx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	endLoad()
 	if err != nil {
 		return fmt.Errorf("failed to get child playlists: %w", err)
 	}
 
+	// The base playlist is read through the account it was created with
+	ctx, err = s.withPlaylistAccount(ctx, syncEvent.UserID, basePlaylist.Provider, basePlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return err
+	}
+
 	if len(childPlaylists) == 0 {
 		s.logger.InfoContext(ctx, "no child playlists found, skipping sync", "sync_event_id", syncEvent.ID)
-		return nil
+		return s.syncSourcedMergeChildPlaylists(ctx, syncEvent)
 	}
 
 	childPlaylistIDs := make([]string, len(childPlaylists))
@@ -122,13 +505,22 @@ func (s *DefaultSyncOrchestrator) executeSyncFlow(ctx context.Context, syncEvent
 		"child_playlist_count", len(childPlaylists),
 	)
 
+	if s.shouldStream(ctx, syncEvent, basePlaylist, childPlaylists) {
+		return s.executeStreamingSyncFlow(ctx, syncEvent, basePlaylist, childPlaylists)
+	}
+
 	// Aggregate track data
 	s.logger.InfoContext(ctx, "step 3: aggregating track data", "sync_event_id", syncEvent.ID)
+	s.setSyncPhase(syncEvent, models.SyncPhaseFetchingTracks)
 
-	trackData, err := s.trackAggregator.AggregatePlaylistData(ctx, syncEvent.UserID, syncEvent.BasePlaylistID)
+	aggregationCtx, endAggregation := profiling.StartSpan(ctx, "aggregation")
+	snapshotID := s.baseSnapshotID(aggregationCtx, syncEvent, basePlaylist)
+	trackData, err := s.trackAggregator.AggregatePlaylistData(aggregationCtx, syncEvent.UserID, syncEvent.BasePlaylistID)
+	endAggregation()
 	if err != nil {
 		return fmt.Errorf("failed to aggregate track data: %w", err)
 	}
+	trackData.SnapshotID = snapshotID
 
 	syncEvent.TracksProcessed = len(trackData.Tracks)
 	syncEvent.TotalAPIRequests += trackData.APICallCount
@@ -141,67 +533,458 @@ func (s *DefaultSyncOrchestrator) executeSyncFlow(ctx context.Context, syncEvent
 
 	// Route tracks to child playlists
 	s.logger.InfoContext(ctx, "step 4: routing tracks", "sync_event_id", syncEvent.ID)
+	s.setSyncPhase(syncEvent, models.SyncPhaseRoutingTracks)
 
-	routing, err := s.trackRouter.RouteTracksToChildren(ctx, trackData, childPlaylists)
+	routingCtx, endRouting := profiling.StartSpan(ctx, "routing")
+	routing, report, err := s.trackRouter.RouteTracksToChildren(routingCtx, trackData, childPlaylists, basePlaylist.DedupeStrategy)
+	if err == nil {
+		err = s.routeMergeChildPlaylists(routingCtx, syncEvent, trackData, childPlaylists, childPlaylists, basePlaylist.DedupeStrategy, routing)
+	}
+	endRouting()
 	if err != nil {
 		return fmt.Errorf("failed to route tracks: %w", err)
 	}
 
+	applyPinnedTracks(childPlaylists, routing)
+	s.recordRoutingReport(ctx, syncEvent, report)
+
 	totalRoutedTracks := 0
 	for _, trackURIs := range routing {
 		totalRoutedTracks += len(trackURIs)
 	}
 
+	syncEvent.TracksUnmatched = countUnmatchedTracks(trackData, childPlaylists, routing)
+
 	s.logger.InfoContext(ctx, "track routing completed",
 		"sync_event_id", syncEvent.ID,
 		"child_playlists_with_tracks", len(routing),
 		"total_routed_tracks", totalRoutedTracks,
+		"tracks_unmatched", syncEvent.TracksUnmatched,
 	)
 
+	s.recordPendingRoutingDiffs(ctx, syncEvent, basePlaylist, childPlaylists, trackData)
+
+	if checkAnomalies {
+		anomaliesCtx, endAnomalies := profiling.StartSpan(ctx, "anomaly_check")
+		err := s.checkSyncAnomalies(anomaliesCtx, syncEvent, childPlaylists, routing)
+		endAnomalies()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Update Spotify playlists (delete/recreate)
 	s.logger.InfoContext(ctx, "step 5: updating spotify playlists", "sync_event_id", syncEvent.ID)
+	s.setSyncPhase(syncEvent, models.SyncPhaseUpdatingPlaylists)
 
-	if err := s.updateSpotifyPlaylists(ctx, syncEvent, basePlaylist, childPlaylists, routing); err != nil {
-		return fmt.Errorf("failed to update spotify playlists: %w", err)
+	writesCtx, endWrites := profiling.StartSpan(ctx, "playlist_writes")
+	err = s.updateSpotifyPlaylists(writesCtx, syncEvent, basePlaylist, childPlaylists, routing)
+	endWrites()
+	if err != nil {
+		err = fmt.Errorf("failed to update spotify playlists: %w", err)
+	}
+
+	// Merge children of other base playlists are attempted even when a child of this one failed
+	if mergeErr := s.syncSourcedMergeChildPlaylists(ctx, syncEvent); mergeErr != nil {
+		err = errors.Join(err, mergeErr)
+	}
+	if err != nil {
+		return err
 	}
 
 	s.logger.InfoContext(ctx, "spotify playlist updates completed", "sync_event_id", syncEvent.ID)
 	return nil
 }
 
-func (s *DefaultSyncOrchestrator) updateSpotifyPlaylists(
+// baseSnapshotID returns the current snapshot of the base playlist when the routing cache is enabled
+// for the user, empty otherwise or when it can't be read. It is read before the tracks, so tracks
+// edited in between are cached under the older snapshot, which is never read again
+func (s *DefaultSyncOrchestrator) baseSnapshotID(ctx context.Context, syncEvent *models.SyncEvent, basePlaylist *models.BasePlaylist) string {
+	if !s.routingCache || !s.featureEnabled(ctx, models.FeatureIncrementalSync, syncEvent.UserID) {
+		return ""
+	}
+
+	snapshotID, err := s.musicProvider.GetPlaylistSnapshotID(ctx, basePlaylist.SpotifyPlaylistID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to look up base playlist snapshot, routing without cache",
+			"sync_event_id", syncEvent.ID,
+			"error", err.Error(),
+		)
+		return ""
+	}
+	syncEvent.TotalAPIRequests++
+
+	return snapshotID
+}
+
+// syncSourcedMergeChildPlaylists syncs the active merge children of other base playlists that merge
+// this one, so changes to any source base playlist reach them and not only syncs of their own base
+// playlist. Each is routed against the siblings of its own base playlist, as when that one syncs
+func (s *DefaultSyncOrchestrator) syncSourcedMergeChildPlaylists(ctx context.Context, syncEvent *models.SyncEvent) error {
+	mergeChildPlaylists, err := s.childPlaylistService.GetMergeChildPlaylistsBySourceBasePlaylistID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get merge child playlists: %w", err)
+	}
+
+	mergeChildPlaylistsByBase := make(map[string][]*models.ChildPlaylist)
+	homeBasePlaylistIDs := make([]string, 0)
+	for _, childPlaylist := range mergeChildPlaylists {
+		if !childPlaylist.IsActive || childPlaylist.BasePlaylistID == syncEvent.BasePlaylistID {
+			continue
+		}
+		if _, exists := mergeChildPlaylistsByBase[childPlaylist.BasePlaylistID]; !exists {
+			homeBasePlaylistIDs = append(homeBasePlaylistIDs, childPlaylist.BasePlaylistID)
+		}
+		mergeChildPlaylistsByBase[childPlaylist.BasePlaylistID] = append(mergeChildPlaylistsByBase[childPlaylist.BasePlaylistID], childPlaylist)
+	}
+
+	if len(homeBasePlaylistIDs) == 0 {
+		return nil
+	}
+
+	s.logger.InfoContext(ctx, "syncing merge child playlists of other base playlists",
+		"sync_event_id", syncEvent.ID,
+		"base_playlist_count", len(homeBasePlaylistIDs),
+	)
+
+	var errs []error
+	for _, homeBasePlaylistID := range homeBasePlaylistIDs {
+		err := s.syncMergeChildPlaylistsOfBase(ctx, syncEvent, homeBasePlaylistID, mergeChildPlaylistsByBase[homeBasePlaylistID])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to sync merge child playlists of base playlist %s: %w", homeBasePlaylistID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *DefaultSyncOrchestrator) syncMergeChildPlaylistsOfBase(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	homeBasePlaylistID string,
+	mergeChildPlaylists []*models.ChildPlaylist,
+) error {
+	homeBasePlaylist, err := s.basePlaylistService.GetBasePlaylist(ctx, homeBasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get base playlist: %w", err)
+	}
+	// Archived base playlists keep their merge children as they are, like their other children
+	if homeBasePlaylist.Archived {
+		s.logger.InfoContext(ctx, "skipping merge child playlists of archived base playlist", "base_playlist_id", homeBasePlaylistID)
+		return nil
+	}
+
+	ctx, err = s.withPlaylistAccount(ctx, syncEvent.UserID, homeBasePlaylist.Provider, homeBasePlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return err
+	}
+
+	siblingPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, homeBasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get child playlists: %w", err)
+	}
+
+	trackData, err := s.trackAggregator.AggregatePlaylistData(ctx, syncEvent.UserID, homeBasePlaylistID)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate track data: %w", err)
+	}
+	syncEvent.TotalAPIRequests += trackData.APICallCount
+
+	routing := make(map[string][]string, len(mergeChildPlaylists))
+	err = s.routeMergeChildPlaylists(ctx, syncEvent, trackData, siblingPlaylists, mergeChildPlaylists, homeBasePlaylist.DedupeStrategy, routing)
+	if err != nil {
+		return fmt.Errorf("failed to route tracks: %w", err)
+	}
+	applyPinnedTracks(mergeChildPlaylists, routing)
+
+	for _, childPlaylist := range mergeChildPlaylists {
+		syncEvent.ChildPlaylistIDs = append(syncEvent.ChildPlaylistIDs, childPlaylist.ID)
+	}
+
+	return s.updateSpotifyPlaylists(ctx, syncEvent, homeBasePlaylist, mergeChildPlaylists, routing)
+}
+
+// routeMergeChildPlaylists replaces the routing of the merge children among mergeChildPlaylists.
+// Their sources are aggregated after the base playlist tracks and the siblings routed again against
+// the merged tracks, so priorities and dedupe still apply, keeping only the merge child result
+func (s *DefaultSyncOrchestrator) routeMergeChildPlaylists(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	trackData *models.PlaylistTracksInfo,
+	siblingPlaylists []*models.ChildPlaylist,
+	mergeChildPlaylists []*models.ChildPlaylist,
+	dedupeStrategy models.DedupeStrategy,
+	routing map[string][]string,
+) error {
+	for _, childPlaylist := range mergeChildPlaylists {
+		if !childPlaylist.IsMerge() {
+			continue
+		}
+
+		sourceData, err := s.trackAggregator.AggregateMergedPlaylistData(ctx, syncEvent.UserID, childPlaylist.SourceBasePlaylistIDs)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate sources of child playlist %s: %w", childPlaylist.ID, err)
+		}
+		syncEvent.TotalAPIRequests += sourceData.APICallCount
+
+		mergedData := models.MergePlaylistTracks(trackData, sourceData)
+		mergedRouting, _, err := s.trackRouter.RouteTracksToChildren(ctx, mergedData, siblingPlaylists, dedupeStrategy)
+		if err != nil {
+			return err
+		}
+
+		if trackURIs := mergedRouting[childPlaylist.SpotifyPlaylistID]; len(trackURIs) > 0 {
+			routing[childPlaylist.SpotifyPlaylistID] = trackURIs
+		} else {
+			delete(routing, childPlaylist.SpotifyPlaylistID)
+		}
+
+		s.logger.InfoContext(ctx, "routed merge child playlist",
+			"sync_event_id", syncEvent.ID,
+			"child_playlist_id", childPlaylist.ID,
+			"source_base_playlists", len(childPlaylist.SourceBasePlaylistIDs),
+			"tracks", len(routing[childPlaylist.SpotifyPlaylistID]),
+		)
+	}
+
+	return nil
+}
+
+// applyPinnedTracks puts the pinned tracks of each active child playlist first, followed by its
+// routed tracks that aren't pinned
+func applyPinnedTracks(childPlaylists []*models.ChildPlaylist, routing map[string][]string) {
+	for _, childPlaylist := range childPlaylists {
+		if !childPlaylist.IsActive || len(childPlaylist.PinnedTracks) == 0 {
+			continue
+		}
+
+		trackURIs := slices.Clone(childPlaylist.PinnedTracks)
+		for _, trackURI := range routing[childPlaylist.SpotifyPlaylistID] {
+			if !slices.Contains(childPlaylist.PinnedTracks, trackURI) {
+				trackURIs = append(trackURIs, trackURI)
+			}
+		}
+
+		routing[childPlaylist.SpotifyPlaylistID] = trackURIs
+	}
+}
+
+// checkSyncAnomalies holds the sync back before any child playlist is touched when its
+// routing looks suspicious compared to the last completed sync
+func (s *DefaultSyncOrchestrator) checkSyncAnomalies(
 	ctx context.Context,
 	syncEvent *models.SyncEvent,
-	basePlaylist *models.BasePlaylist,
 	childPlaylists []*models.ChildPlaylist,
 	routing map[string][]string,
 ) error {
-	playlistLookup := make(map[string]*models.ChildPlaylist)
-	for _, child := range childPlaylists {
-		playlistLookup[child.SpotifyPlaylistID] = child
+	previousSync, err := s.syncEventService.GetLastCompletedSyncEvent(ctx, syncEvent.UserID, syncEvent.BasePlaylistID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "unable to get previous sync, skipping anomaly detection",
+			"sync_event_id", syncEvent.ID,
+			"error", err.Error(),
+		)
+		return nil
+	}
+
+	anomalies := detectSyncAnomalies(previousSync, syncEvent, childPlaylists, routing)
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	syncEvent.Anomalies = anomalies
+	s.logger.WarnContext(ctx, "sync anomalies detected, waiting for confirmation",
+		"sync_event_id", syncEvent.ID,
+		"anomalies", len(anomalies),
+	)
+
+	return fmt.Errorf("%w: %d anomalies detected", ErrSyncAnomalyDetected, len(anomalies))
+}
+
+// executeRollbackFlow rewrites every snapshotted child playlist with the tracks it held
+// before the rolled back sync, reusing the regular child playlist update path. Merge children
+// of other base playlists synced along are restored too
+func (s *DefaultSyncOrchestrator) executeRollbackFlow(ctx context.Context, syncEvent *models.SyncEvent, snapshots []*models.PlaylistSnapshot) error {
+	basePlaylist, err := s.basePlaylistService.GetBasePlaylist(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get base playlist: %w", err)
 	}
 
-	for spotifyPlaylistID, trackURIs := range routing {
-		childPlaylist, exists := playlistLookup[spotifyPlaylistID]
+	childPlaylists, err := s.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get child playlists: %w", err)
+	}
+
+	mergeChildPlaylists, err := s.childPlaylistService.GetMergeChildPlaylistsBySourceBasePlaylistID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get merge child playlists: %w", err)
+	}
+
+	// Children are written under their own base playlist, which names recreated playlists
+	childPlaylistsByID := make(map[string]*models.ChildPlaylist, len(childPlaylists)+len(mergeChildPlaylists))
+	homeBasePlaylistIDs := make(map[string]string, len(childPlaylists)+len(mergeChildPlaylists))
+	for _, childPlaylist := range mergeChildPlaylists {
+		childPlaylistsByID[childPlaylist.ID] = childPlaylist
+		homeBasePlaylistIDs[childPlaylist.ID] = childPlaylist.BasePlaylistID
+	}
+	for _, childPlaylist := range childPlaylists {
+		childPlaylistsByID[childPlaylist.ID] = childPlaylist
+		homeBasePlaylistIDs[childPlaylist.ID] = syncEvent.BasePlaylistID
+	}
+
+	// Route each snapshot back to the current spotify playlist of its child
+	restoredChildPlaylists := make([]*models.ChildPlaylist, 0, len(snapshots))
+	routing := make(map[string][]string, len(snapshots))
+	for _, snapshot := range snapshots {
+		childPlaylist, exists := childPlaylistsByID[snapshot.ChildPlaylistID]
 		if !exists {
-			s.logger.WarnContext(ctx, "child playlist not found for spotify playlist",
-				"spotify_playlist_id", spotifyPlaylistID,
+			s.logger.WarnContext(ctx, "skipping snapshot of deleted child playlist",
 				"sync_event_id", syncEvent.ID,
+				"child_playlist_id", snapshot.ChildPlaylistID,
 			)
 			continue
 		}
 
-		apiRequestCount, err := s.syncChildPlaylist(ctx, basePlaylist, *childPlaylist, spotifyPlaylistID, trackURIs, syncEvent)
+		restoredChildPlaylists = append(restoredChildPlaylists, childPlaylist)
+		routing[childPlaylist.SpotifyPlaylistID] = snapshot.TrackURIs
+		syncEvent.ChildPlaylistIDs = append(syncEvent.ChildPlaylistIDs, childPlaylist.ID)
+		syncEvent.TracksProcessed += len(snapshot.TrackURIs)
+	}
+
+	s.logger.InfoContext(ctx, "restoring child playlists from snapshots",
+		"sync_event_id", syncEvent.ID,
+		"child_playlist_count", len(restoredChildPlaylists),
+	)
+
+	basePlaylistsByID := map[string]*models.BasePlaylist{syncEvent.BasePlaylistID: basePlaylist}
+	restoredByBase := make(map[string][]*models.ChildPlaylist)
+	basePlaylistIDs := make([]string, 0, 1)
+	for _, childPlaylist := range restoredChildPlaylists {
+		homeBasePlaylistID := homeBasePlaylistIDs[childPlaylist.ID]
+		if _, exists := restoredByBase[homeBasePlaylistID]; !exists {
+			basePlaylistIDs = append(basePlaylistIDs, homeBasePlaylistID)
+		}
+		restoredByBase[homeBasePlaylistID] = append(restoredByBase[homeBasePlaylistID], childPlaylist)
+	}
+
+	s.setSyncPhase(syncEvent, models.SyncPhaseUpdatingPlaylists)
+	writesCtx, endWrites := profiling.StartSpan(ctx, "playlist_writes")
+	defer endWrites()
+
+	var errs []error
+	for _, basePlaylistID := range basePlaylistIDs {
+		homeBasePlaylist, exists := basePlaylistsByID[basePlaylistID]
+		if !exists {
+			homeBasePlaylist, err = s.basePlaylistService.GetBasePlaylist(writesCtx, basePlaylistID, syncEvent.UserID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get base playlist %s: %w", basePlaylistID, err))
+				continue
+			}
+		}
+
+		err = s.updateSpotifyPlaylists(writesCtx, syncEvent, homeBasePlaylist, restoredByBase[basePlaylistID], routing)
 		if err != nil {
-			return err
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to restore spotify playlists: %w", err)
+	}
+
+	return nil
+}
+
+func (s *DefaultSyncOrchestrator) updateSpotifyPlaylists(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	basePlaylist *models.BasePlaylist,
+	childPlaylists []*models.ChildPlaylist,
+	routing map[string][]string,
+) error {
+	routedPlaylists := make([]*models.ChildPlaylist, 0, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		if _, routed := routing[childPlaylist.SpotifyPlaylistID]; routed {
+			routedPlaylists = append(routedPlaylists, childPlaylist)
 		}
+	}
+
+	// Up to childConcurrency children are written at a time. Workers only fill in the result of their
+	// child, the sync event is updated once all of them are done
+	limit := s.childConcurrency
+	if limit > 1 && !s.featureEnabled(ctx, models.FeatureConcurrentSync, syncEvent.UserID) {
+		limit = 1
+	}
+
+	results := make([]models.ChildSyncResult, len(routedPlaylists))
+	var group errgroup.Group
+	group.SetLimit(limit)
+
+	for i, childPlaylist := range routedPlaylists {
+		group.Go(func() error {
+			results[i] = s.updateSpotifyPlaylist(ctx, syncEvent, basePlaylist, childPlaylist, routing[childPlaylist.SpotifyPlaylistID])
+			return nil
+		})
+	}
+
+	// Every child is attempted so one failing child does not hide the outcome of the others
+	_ = group.Wait()
+
+	failedChildren := 0
+	for _, result := range results {
+		syncEvent.TotalAPIRequests += result.APIRequests
+		syncEvent.ChildSyncResults = append(syncEvent.ChildSyncResults, result)
+		if result.Status == models.SyncStatusFailed {
+			failedChildren++
+		}
+	}
 
-		syncEvent.TotalAPIRequests += apiRequestCount
+	if failedChildren > 0 {
+		return fmt.Errorf("failed to sync %d of %d child playlists", failedChildren, len(results))
 	}
 
 	return nil
 }
 
+// updateSpotifyPlaylist writes the routed tracks of a child playlist to spotify, reporting the
+// outcome in its result. Safe to run for several children of the sync at the same time
+func (s *DefaultSyncOrchestrator) updateSpotifyPlaylist(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	basePlaylist *models.BasePlaylist,
+	childPlaylist *models.ChildPlaylist,
+	trackURIs []string,
+) models.ChildSyncResult {
+	result := models.ChildSyncResult{
+		ChildPlaylistID:   childPlaylist.ID,
+		SpotifyPlaylistID: childPlaylist.SpotifyPlaylistID,
+		Status:            models.SyncStatusCompleted,
+	}
+
+	// Each child is written through its own spotify account, which may differ from the base one
+	childCtx, endChild := profiling.StartSpan(ctx, "child:"+childPlaylist.Name)
+	apiRequestCount := 0
+	accountCtx, err := s.withPlaylistAccount(childCtx, syncEvent.UserID, childPlaylist.Provider, childPlaylist.SpotifyIntegrationID)
+	if err == nil {
+		apiRequestCount, err = s.syncChildPlaylist(accountCtx, basePlaylist, *childPlaylist, childPlaylist.SpotifyPlaylistID, trackURIs, syncEvent, &result)
+	}
+	endChild()
+	result.APIRequests = apiRequestCount
+
+	if err != nil {
+		errorMessage := err.Error()
+		result.Status = models.SyncStatusFailed
+		result.ErrorMessage = &errorMessage
+
+		s.logger.ErrorContext(ctx, "failed to sync child playlist",
+			"sync_event_id", syncEvent.ID,
+			"child_playlist_id", childPlaylist.ID,
+			"error", errorMessage,
+		)
+	}
+
+	return result
+}
+
 func (s *DefaultSyncOrchestrator) syncChildPlaylist(
 	ctx context.Context,
 	basePlaylist *models.BasePlaylist,
@@ -209,18 +992,87 @@ func (s *DefaultSyncOrchestrator) syncChildPlaylist(
 	spotifyPlaylistID string,
 	trackURIs []string,
 	syncEvent *models.SyncEvent,
+	result *models.ChildSyncResult,
 ) (int, error) {
 	apiRequestCount := 0
 
-	s.logger.InfoContext(ctx, "recreating spotify playlist",
+	s.logger.InfoContext(ctx, "syncing spotify playlist",
 		"sync_event_id", syncEvent.ID,
 		"child_playlist_id", childPlaylist.ID,
 		"spotify_playlist_id", spotifyPlaylistID,
+		"sync_strategy", childPlaylist.SyncStrategy,
 		"track_count", len(trackURIs),
 	)
 
-	if err := s.spotifyClient.DeletePlaylist(ctx, spotifyPlaylistID); err != nil {
-		return apiRequestCount, fmt.Errorf("failed to delete playlist %s: %w", spotifyPlaylistID, err)
+	snapshotCtx, endSnapshot := profiling.StartSpan(ctx, "snapshot")
+	snapshotRequests, err := s.snapshotChildPlaylist(snapshotCtx, childPlaylist, spotifyPlaylistID, syncEvent)
+	endSnapshot()
+	apiRequestCount += snapshotRequests
+	if err != nil {
+		return apiRequestCount, fmt.Errorf("failed to snapshot playlist %s: %w", spotifyPlaylistID, err)
+	}
+
+	playlistID := spotifyPlaylistID
+	tracksToAdd := trackURIs
+
+	if childPlaylist.SyncStrategy == models.SyncStrategyInPlace {
+		// The first batch replaces the current tracks, the rest is appended below
+		firstBatch := min(MAX_PLAYLIST_TRACKS, len(trackURIs))
+
+		replaceCtx, endReplace := profiling.StartSpan(ctx, "replace_tracks")
+		err := s.musicProvider.ReplacePlaylistTracks(replaceCtx, spotifyPlaylistID, trackURIs[:firstBatch])
+		endReplace()
+		if err != nil {
+			return apiRequestCount, fmt.Errorf("failed to replace tracks of playlist %s: %w", spotifyPlaylistID, err)
+		}
+		apiRequestCount++
+		tracksToAdd = trackURIs[firstBatch:]
+	} else {
+		recreateCtx, endRecreate := profiling.StartSpan(ctx, "recreate_playlist")
+		newPlaylistID, recreateRequests, err := s.recreateChildPlaylist(recreateCtx, basePlaylist, childPlaylist, spotifyPlaylistID, syncEvent, result)
+		endRecreate()
+		apiRequestCount += recreateRequests
+		if err != nil {
+			return apiRequestCount, err
+		}
+		playlistID = newPlaylistID
+	}
+
+	addCtx, endAdd := profiling.StartSpan(ctx, "add_tracks")
+	batchCount, err := s.addTracksInBatches(addCtx, syncEvent.ID, playlistID, tracksToAdd)
+	endAdd()
+	if err != nil {
+		return apiRequestCount, fmt.Errorf("failed to add tracks to playlist %s: %w", playlistID, err)
+	}
+	apiRequestCount += batchCount
+	result.TracksAdded = len(trackURIs)
+
+	s.logger.InfoContext(ctx, "added tracks to playlist",
+		"sync_event_id", syncEvent.ID,
+		"spotify_playlist_id", playlistID,
+		"tracks_added", len(trackURIs),
+		"batch_count", batchCount,
+	)
+
+	s.recordMembership(ctx, childPlaylist, syncEvent.ID, playlistID, trackURIs)
+
+	return apiRequestCount, nil
+}
+
+// recreateChildPlaylist deletes the spotify playlist of the child and creates an empty one in its
+// place, returning the new playlist ID and the number of spotify requests made
+func (s *DefaultSyncOrchestrator) recreateChildPlaylist(
+	ctx context.Context,
+	basePlaylist *models.BasePlaylist,
+	childPlaylist models.ChildPlaylist,
+	spotifyPlaylistID string,
+	syncEvent *models.SyncEvent,
+	result *models.ChildSyncResult,
+) (string, int, error) {
+	apiRequestCount := 0
+
+	if err := s.musicProvider.DeletePlaylist(ctx, spotifyPlaylistID); err != nil {
+		return "", apiRequestCount, fmt.Errorf("failed to delete playlist %s: %w", spotifyPlaylistID, err)
 	}
 	apiRequestCount++
 
@@ -228,9 +1080,9 @@ func (s *DefaultSyncOrchestrator) syncChildPlaylist(
 	formattedName := models.BuildChildPlaylistName(basePlaylist.Name, childPlaylist.Name)
 	formattedDescription := models.BuildChildPlaylistDescription(childPlaylist.Description)
 
-	newPlaylist, err := s.spotifyClient.CreatePlaylist(ctx, formattedName, formattedDescription, false)
+	newPlaylist, err := s.musicProvider.CreatePlaylist(ctx, formattedName, formattedDescription, false)
 	if err != nil {
-		return apiRequestCount, fmt.Errorf("failed to create new playlist for %s: %w", formattedName, err)
+		return "", apiRequestCount, fmt.Errorf("failed to create new playlist for %s: %w", formattedName, err)
 	}
 	apiRequestCount++
 
@@ -241,27 +1093,121 @@ func (s *DefaultSyncOrchestrator) syncChildPlaylist(
 		"playlist_name", newPlaylist.Name,
 	)
 
+	result.SpotifyPlaylistID = newPlaylist.ID
+
 	_, err = s.childPlaylistService.UpdateChildPlaylistSpotifyID(ctx, childPlaylist.ID, childPlaylist.UserID, newPlaylist.ID)
 	if err != nil {
-		return apiRequestCount, fmt.Errorf("failed to update child playlist %s: %w", childPlaylist.Name, err)
+		return "", apiRequestCount, fmt.Errorf("failed to update child playlist %s: %w", childPlaylist.Name, err)
+	}
+
+	// The new playlist has no followers, following it again keeps it on the profile of the user.
+	// The tracks are written either way, failing to follow must not fail the sync
+	if childPlaylist.RefollowRecreated {
+		if err := s.musicProvider.FollowPlaylist(ctx, newPlaylist.ID, true); err != nil {
+			s.logger.WarnContext(ctx, "failed to follow recreated playlist",
+				"sync_event_id", syncEvent.ID,
+				"child_playlist_id", childPlaylist.ID,
+				"spotify_playlist_id", newPlaylist.ID,
+				"error", err.Error(),
+			)
+		}
+		apiRequestCount++
 	}
 
-	batchCount, err := s.addTracksInBatches(ctx, syncEvent.ID, newPlaylist.ID, trackURIs)
+	return newPlaylist.ID, apiRequestCount, nil
+}
+
+// recordMembership stores the tracks written to the child playlist. The membership only feeds
+// audits, failing to record it must not fail an otherwise complete sync
+func (s *DefaultSyncOrchestrator) recordMembership(ctx context.Context, childPlaylist models.ChildPlaylist, syncEventID, spotifyPlaylistID string, trackURIs []string) {
+	_, err := s.snapshotService.RecordMembership(ctx, &models.PlaylistMembership{
+		UserID:            childPlaylist.UserID,
+		ChildPlaylistID:   childPlaylist.ID,
+		SyncEventID:       syncEventID,
+		SpotifyPlaylistID: spotifyPlaylistID,
+		TrackURIs:         trackURIs,
+	})
 	if err != nil {
-		return apiRequestCount, fmt.Errorf("failed to add tracks to playlist %s: %w", newPlaylist.ID, err)
+		s.logger.WarnContext(ctx, "failed to record playlist membership",
+			"sync_event_id", syncEventID,
+			"child_playlist_id", childPlaylist.ID,
+			"error", err.Error(),
+		)
 	}
-	apiRequestCount += batchCount
+}
 
-	s.logger.InfoContext(ctx, "added tracks to new playlist",
-		"sync_event_id", syncEvent.ID,
-		"spotify_playlist_id", newPlaylist.ID,
-		"tracks_added", len(trackURIs),
-		"batch_count", batchCount,
-	)
+// recordRoutingReport stores how the base playlist tracks were routed. The report only explains the
+// routing, failing to record it must not fail the sync
+func (s *DefaultSyncOrchestrator) recordRoutingReport(ctx context.Context, syncEvent *models.SyncEvent, report *models.RoutingReport) {
+	if report == nil {
+		return
+	}
+
+	report.SyncEventID = syncEvent.ID
+	_, err := s.snapshotService.RecordRoutingReport(ctx, report)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to record routing report",
+			"sync_event_id", syncEvent.ID,
+			"base_playlist_id", syncEvent.BasePlaylistID,
+			"error", err.Error(),
+		)
+	}
+}
+
+// snapshotChildPlaylist stores the current tracks of the child playlist before they are replaced,
+// returning the number of spotify requests made
+func (s *DefaultSyncOrchestrator) snapshotChildPlaylist(
+	ctx context.Context,
+	childPlaylist models.ChildPlaylist,
+	spotifyPlaylistID string,
+	syncEvent *models.SyncEvent,
+) (int, error) {
+	trackURIs, apiRequestCount, err := s.fetchPlaylistTrackURIs(ctx, spotifyPlaylistID)
+	if err != nil {
+		return apiRequestCount, err
+	}
+
+	_, err = s.snapshotService.CreateSnapshot(ctx, &models.PlaylistSnapshot{
+		UserID:            syncEvent.UserID,
+		SyncEventID:       syncEvent.ID,
+		ChildPlaylistID:   childPlaylist.ID,
+		SpotifyPlaylistID: spotifyPlaylistID,
+		TrackURIs:         trackURIs,
+	})
+	if err != nil {
+		return apiRequestCount, err
+	}
 
 	return apiRequestCount, nil
 }
 
+// fetchPlaylistTrackURIs reads every track of a spotify playlist, returning the number of spotify requests made
+func (s *DefaultSyncOrchestrator) fetchPlaylistTrackURIs(ctx context.Context, spotifyPlaylistID string) ([]string, int, error) {
+	apiRequestCount := 0
+	trackURIs := make([]string, 0)
+
+	for offset := 0; ; offset += MAX_PLAYLIST_TRACKS {
+		tracksResp, err := s.musicProvider.GetPlaylistTracks(ctx, spotifyPlaylistID, MAX_PLAYLIST_TRACKS, offset)
+		if err != nil {
+			return nil, apiRequestCount, fmt.Errorf("failed to fetch playlist tracks: %w", err)
+		}
+		apiRequestCount++
+
+		for _, item := range tracksResp.Items {
+			// Removed or unavailable tracks come back without track data
+			if item.Track != nil && item.Track.URI != "" {
+				trackURIs = append(trackURIs, item.Track.URI)
+			}
+		}
+
+		if tracksResp.Next == nil {
+			break
+		}
+	}
+
+	return trackURIs, apiRequestCount, nil
+}
+
 func (s *DefaultSyncOrchestrator) addTracksInBatches(ctx context.Context, syncEventID, playlistID string, trackURIs []string) (int, error) {
 	batchCount := 0
 
@@ -269,7 +1215,7 @@ func (s *DefaultSyncOrchestrator) addTracksInBatches(ctx context.Context, syncEv
 		end := min(i+MAX_PLAYLIST_TRACKS, len(trackURIs))
 
 		batch := trackURIs[i:end]
-		if err := s.spotifyClient.AddTracksToPlaylist(ctx, playlistID, batch); err != nil {
+		if err := s.musicProvider.AddTracksToPlaylist(ctx, playlistID, batch); err != nil {
 			return batchCount, fmt.Errorf("failed to add tracks batch %d-%d: %w", i, end, err)
 		}
 
@@ -290,6 +1236,7 @@ func (s *DefaultSyncOrchestrator) addTracksInBatches(ctx context.Context, syncEv
 func (s *DefaultSyncOrchestrator) completeSyncWithSuccess(ctx context.Context, syncEvent *models.SyncEvent) {
 	now := time.Now()
 	syncEvent.Status = models.SyncStatusCompleted
+	syncEvent.Phase = ""
 	syncEvent.CompletedAt = &now
 
 	if _, err := s.syncEventService.UpdateSyncEvent(ctx, syncEvent.ID, syncEvent); err != nil {
@@ -298,18 +1245,24 @@ func (s *DefaultSyncOrchestrator) completeSyncWithSuccess(ctx context.Context, s
 			"error", err.Error(),
 		)
 	}
+	s.publishSyncProgress(syncEvent)
 
 	s.logger.InfoContext(ctx, "playlist sync completed successfully",
 		"sync_event_id", syncEvent.ID,
 		"tracks_processed", syncEvent.TracksProcessed,
 		"total_api_requests", syncEvent.TotalAPIRequests,
 	)
+
+	s.notifySyncCompleted(ctx, syncEvent)
 }
 
 func (s *DefaultSyncOrchestrator) completeSyncWithError(ctx context.Context, syncEvent *models.SyncEvent, syncErr error) {
 	now := time.Now()
 	errorMessage := syncErr.Error()
 	syncEvent.Status = models.SyncStatusFailed
+	if errors.Is(syncErr, ErrSyncAnomalyDetected) {
+		syncEvent.Status = models.SyncStatusNeedsConfirmation
+	}
 	syncEvent.CompletedAt = &now
 	syncEvent.ErrorMessage = &errorMessage
 
@@ -319,6 +1272,7 @@ func (s *DefaultSyncOrchestrator) completeSyncWithError(ctx context.Context, syn
 			"error", err.Error(),
 		)
 	}
+	s.publishSyncProgress(syncEvent)
 
 	s.logger.ErrorContext(ctx, "playlist sync failed",
 		"sync_event_id", syncEvent.ID,
@@ -326,4 +1280,45 @@ func (s *DefaultSyncOrchestrator) completeSyncWithError(ctx context.Context, syn
 		"tracks_processed", syncEvent.TracksProcessed,
 		"total_api_requests", syncEvent.TotalAPIRequests,
 	)
+
+	s.notifySyncCompleted(ctx, syncEvent)
+}
+
+func (s *DefaultSyncOrchestrator) setSyncPhase(syncEvent *models.SyncEvent, phase models.SyncPhase) {
+	syncEvent.Phase = phase
+	s.publishSyncProgress(syncEvent)
+}
+
+// publishSyncProgress sends the sync event as it is now, the app reads its status and phase
+func (s *DefaultSyncOrchestrator) publishSyncProgress(syncEvent *models.SyncEvent) {
+	if s.events == nil {
+		return
+	}
+
+	event := models.NewSyncProgressEvent(syncEvent)
+	event.Timestamp = time.Now()
+	s.events.Publish(syncEvent.UserID, event)
+}
+
+// notifySyncCompleted is best-effort, failed notifications don't change the outcome of the sync
+func (s *DefaultSyncOrchestrator) notifySyncCompleted(ctx context.Context, syncEvent *models.SyncEvent) {
+	if s.notificationService == nil {
+		return
+	}
+
+	if err := s.notificationService.NotifySyncCompleted(ctx, syncEvent); err != nil {
+		s.logger.WarnContext(ctx, "failed to notify sync completion",
+			"sync_event_id", syncEvent.ID,
+			"error", err.Error(),
+		)
+	}
+}
+
+// featureEnabled reports whether the flag of a feature enabled in the config is on for the user
+func (s *DefaultSyncOrchestrator) featureEnabled(ctx context.Context, feature, userID string) bool {
+	if s.featureFlags == nil {
+		return true
+	}
+
+	return s.featureFlags.IsEnabled(ctx, feature, userID, true)
 }
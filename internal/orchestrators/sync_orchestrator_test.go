@@ -3,15 +3,22 @@ package orchestrators
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	clientmocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,6 +33,8 @@ func TestNewDefaultSyncOrchestrator(t *testing.T) {
 	mockChildPlaylistService := servicemocks.NewMockChildPlaylistServicer(ctrl)
 	mockBasePlaylistService := servicemocks.NewMockBasePlaylistServicer(ctrl)
 	mockSyncEventService := servicemocks.NewMockSyncEventServicer(ctrl)
+	mockSnapshotService := servicemocks.NewMockPlaylistSnapshotServicer(ctrl)
+	mockRuleHistoryService := servicemocks.NewMockFilterRuleHistoryServicer(ctrl)
 	mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
 	logger := createTestLogger()
 
@@ -35,6 +44,8 @@ func TestNewDefaultSyncOrchestrator(t *testing.T) {
 		mockChildPlaylistService,
 		mockBasePlaylistService,
 		mockSyncEventService,
+		mockSnapshotService,
+		mockRuleHistoryService,
 		mockSpotifyClient,
 		logger,
 	)
@@ -44,7 +55,9 @@ func TestNewDefaultSyncOrchestrator(t *testing.T) {
 	assert.Equal(mockTrackRouter, orchestrator.trackRouter)
 	assert.Equal(mockChildPlaylistService, orchestrator.childPlaylistService)
 	assert.Equal(mockSyncEventService, orchestrator.syncEventService)
-	assert.Equal(mockSpotifyClient, orchestrator.spotifyClient)
+	assert.Equal(mockSnapshotService, orchestrator.snapshotService)
+	assert.Equal(mockRuleHistoryService, orchestrator.ruleHistoryService)
+	assert.Equal(mockSpotifyClient, orchestrator.musicProvider)
 	assert.NotNil(orchestrator.logger)
 }
 
@@ -90,13 +103,17 @@ func TestDefaultSyncOrchestrator_SyncBasePlaylist_Success(t *testing.T) {
 		"spotify2": {"spotify:track:2"},
 	}
 
-	createdSyncEvent := &models.SyncEvent{
-		ID:             "sync123",
+	report := &models.RoutingReport{
 		UserID:         userID,
 		BasePlaylistID: basePlaylistID,
-		Status:         models.SyncStatusInProgress,
+		Tracks: []models.TrackRoutingDecision{
+			{TrackURI: "spotify:track:1", MatchedChildIDs: []string{"child1"}, RoutedChildIDs: []string{"child1"}, Outcome: models.RoutingOutcomeRouted},
+			{TrackURI: "spotify:track:2", MatchedChildIDs: []string{"child2"}, RoutedChildIDs: []string{"child2"}, Outcome: models.RoutingOutcomeRouted},
+		},
 	}
 
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
 	// Setup mocks
 	mocks := createMockServices(ctrl)
 	orchestrator := createTestOrchestrator(mocks)
@@ -104,14 +121,22 @@ func TestDefaultSyncOrchestrator_SyncBasePlaylist_Success(t *testing.T) {
 	// Mock expectations
 	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
 	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
-	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(&models.BasePlaylist{
-		ID:     basePlaylistID,
-		UserID: userID,
-		Name:   "Test Base Playlist",
-	}, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
 	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
 	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(trackData, nil)
-	mocks.trackRouter.EXPECT().RouteTracksToChildren(gomock.Any(), trackData, childPlaylists).Return(routing, nil)
+	mocks.trackRouter.EXPECT().RouteTracksToChildren(gomock.Any(), trackData, childPlaylists, models.DedupeStrategyAllMatches).Return(routing, report, nil)
+	mocks.snapshotService.EXPECT().RecordRoutingReport(gomock.Any(), report).DoAndReturn(
+		func(ctx context.Context, report *models.RoutingReport) (*models.RoutingReport, error) {
+			assert.Equal(createdSyncEvent.ID, report.SyncEventID)
+			return report, nil
+		})
+	mocks.ruleHistoryService.EXPECT().GetPendingChanges(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.syncEventService.EXPECT().GetLastCompletedSyncEvent(gomock.Any(), userID, basePlaylistID).Return(nil, nil)
+
+	// Mock snapshots of the current child playlist contents
+	expectSnapshot(mocks, "spotify1", "spotify:track:old1")
+	expectSnapshot(mocks, "spotify2")
 
 	// Mock Spotify operations - use MinTimes/MaxTimes to handle non-deterministic map iteration order
 	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), gomock.Any()).Return(nil).Times(2)
@@ -134,7 +159,9 @@ func TestDefaultSyncOrchestrator_SyncBasePlaylist_Success(t *testing.T) {
 
 	// Mock track addition - expect each exactly once but in any order
 	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new_spotify1", []string{"spotify:track:1"}).Return(nil).Times(1)
+	expectMembership(mocks, "new_spotify1", "spotify:track:1")
 	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new_spotify2", []string{"spotify:track:2"}).Return(nil).Times(1)
+	expectMembership(mocks, "new_spotify2", "spotify:track:2")
 
 	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
 
@@ -167,6 +194,138 @@ func TestDefaultSyncOrchestrator_SyncBasePlaylist_ActiveSyncInProgress(t *testin
 	assert.Contains(err.Error(), "sync already in progress")
 }
 
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_HeadlessSpotifyAuth(t *testing.T) {
+	userID, basePlaylistID := "user123", "base456"
+	userAuth := &models.SpotifyIntegration{UserID: userID, AccessToken: "access_token"}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		expectLoad  bool
+		loadErr     error
+		expectedErr string
+	}{
+		{
+			name:        "credentials are loaded when the context has none",
+			ctx:         context.Background(),
+			expectLoad:  true,
+			expectedErr: "sync already in progress",
+		},
+		{
+			name:        "credentials of another user are replaced",
+			ctx:         requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{UserID: "other_user"}),
+			expectLoad:  true,
+			expectedErr: "sync already in progress",
+		},
+		{
+			name:        "credentials of the user in the context are kept",
+			ctx:         requestcontext.ContextWithSpotifyAuth(context.Background(), userAuth),
+			expectedErr: "sync already in progress",
+		},
+		{
+			name:        "load failure stops the sync before it starts",
+			ctx:         context.Background(),
+			expectLoad:  true,
+			loadErr:     errors.New("no spotify integration available for user"),
+			expectedErr: "failed to load spotify credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			spotifyAuth := servicemocks.NewMockSpotifyAuthProvider(ctrl)
+			orchestrator := createTestOrchestrator(mocks).WithSpotifyAuth(spotifyAuth)
+
+			if tt.expectLoad {
+				spotifyAuth.EXPECT().
+					ContextWithSpotifyAuth(gomock.Any(), userID).
+					DoAndReturn(func(ctx context.Context, userID string) (context.Context, error) {
+						if tt.loadErr != nil {
+							return nil, tt.loadErr
+						}
+						return requestcontext.ContextWithSpotifyAuth(ctx, userAuth), nil
+					})
+			}
+
+			if tt.loadErr == nil {
+				mocks.syncEventService.EXPECT().
+					HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).
+					DoAndReturn(func(ctx context.Context, userID, basePlaylistID string) (bool, error) {
+						spotifyAuth, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
+						assert.True(ok)
+						assert.Equal(userAuth, spotifyAuth)
+						return true, nil
+					})
+			}
+
+			result, err := orchestrator.SyncBasePlaylist(tt.ctx, userID, basePlaylistID)
+
+			assert.Nil(result)
+			assert.ErrorContains(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_ChildOnOtherSpotifyAccount(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID, basePlaylistID := "user123", "base456"
+	personalAuth := &models.SpotifyIntegration{ID: "integration_personal", UserID: userID}
+	djAuth := &models.SpotifyIntegration{ID: "integration_dj", UserID: userID}
+
+	basePlaylist := testfixtures.NewBasePlaylist().WithID(basePlaylistID).WithSpotifyIntegrationID(personalAuth.ID).Build()
+	childPlaylist := testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify1").WithSpotifyIntegrationID(djAuth.ID).Build()
+	childPlaylists := []*models.ChildPlaylist{childPlaylist}
+	trackData := &models.PlaylistTracksInfo{PlaylistID: basePlaylistID, Tracks: []models.TrackInfo{{URI: "spotify:track:1"}}}
+	routing := map[string][]string{"spotify1": {"spotify:track:1"}}
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	spotifyAuth := servicemocks.NewMockSpotifyAuthProvider(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithSpotifyAuth(spotifyAuth)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(basePlaylist, nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.ruleHistoryService.EXPECT().GetPendingChanges(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.syncEventService.EXPECT().GetLastCompletedSyncEvent(gomock.Any(), userID, basePlaylistID).Return(nil, nil)
+	mocks.trackRouter.EXPECT().RouteTracksToChildren(gomock.Any(), trackData, childPlaylists, models.DedupeStrategyAllMatches).Return(routing, nil, nil)
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	// The base playlist is read as the account the request came with, the child is written as its own
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).
+		DoAndReturn(func(ctx context.Context, _, _ string) (*models.PlaylistTracksInfo, error) {
+			integration, _ := requestcontext.GetSpotifyAuthFromContext(ctx)
+			assert.Equal(personalAuth, integration)
+			return trackData, nil
+		})
+	spotifyAuth.EXPECT().
+		ContextWithSpotifyAccount(gomock.Any(), userID, djAuth.ID).
+		DoAndReturn(func(ctx context.Context, _, _ string) (context.Context, error) {
+			return requestcontext.ContextWithSpotifyAuth(ctx, djAuth), nil
+		})
+	expectSnapshot(mocks, "spotify1")
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify1").
+		DoAndReturn(func(ctx context.Context, _ string) error {
+			integration, _ := requestcontext.GetSpotifyAuthFromContext(ctx)
+			assert.Equal(djAuth, integration)
+			return errors.New("delete failed")
+		})
+
+	_, err := orchestrator.SyncBasePlaylist(requestcontext.ContextWithSpotifyAuth(context.Background(), personalAuth), userID, basePlaylistID)
+
+	assert.ErrorContains(err, "failed to sync 1 of 1 child playlists")
+}
+
 func TestDefaultSyncOrchestrator_SyncBasePlaylist_NoChildPlaylists(t *testing.T) {
 	assert := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -175,72 +334,798 @@ func TestDefaultSyncOrchestrator_SyncBasePlaylist_NoChildPlaylists(t *testing.T)
 	userID := "user123"
 	basePlaylistID := "base456"
 
-	createdSyncEvent := &models.SyncEvent{
-		ID:             "sync123",
-		UserID:         userID,
-		BasePlaylistID: basePlaylistID,
-		Status:         models.SyncStatusInProgress,
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return([]*models.ChildPlaylist{}, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.NoError(err)
+	assert.NotNil(result)
+	assert.Equal(models.SyncStatusCompleted, result.Status)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_Archived(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	// Nothing past the base playlist is loaded, let alone written
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Archived().Build(), nil)
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.ErrorIs(err, ErrBasePlaylistArchived)
+	assert.Equal(models.SyncStatusFailed, result.Status)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_MaintenanceMode(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	maintenance := servicemocks.NewMockMaintenanceServicer(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithMaintenance(maintenance)
+
+	// No sync event is recorded while syncs are paused
+	maintenance.EXPECT().Status(gomock.Any()).Return(&models.MaintenanceMode{Enabled: true, Message: "Spotify is down"})
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), "user123", "base456")
+
+	assert.ErrorIs(err, ErrMaintenanceMode)
+	assert.ErrorContains(err, "Spotify is down")
+	assert.Nil(result)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_SourcedMergeChildOfArchivedBase(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	sourceBasePlaylistID := "base2"
+	homeBasePlaylistID := "base1"
+
+	mergeChild := testfixtures.NewChildPlaylist().WithID("child2").WithBasePlaylistID(homeBasePlaylistID).WithSourceBasePlaylistIDs(sourceBasePlaylistID).Build()
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(sourceBasePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, sourceBasePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), sourceBasePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(sourceBasePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), sourceBasePlaylistID, userID).Return([]*models.ChildPlaylist{}, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), sourceBasePlaylistID, userID).Return([]*models.ChildPlaylist{mergeChild}, nil)
+
+	// The merge child is left as it is, like the other children of its archived base playlist
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), homeBasePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(homeBasePlaylistID).Archived().Build(), nil)
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, sourceBasePlaylistID)
+
+	assert.NoError(err)
+	assert.Equal(models.SyncStatusCompleted, result.Status)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_SourcedMergeChildPlaylists(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	sourceBasePlaylistID := "base2"
+	homeBasePlaylistID := "base1"
+
+	sibling := testfixtures.NewChildPlaylist().WithID("child1").WithBasePlaylistID(homeBasePlaylistID).WithSpotifyPlaylistID("spotify1").Build()
+	mergeChild := testfixtures.NewChildPlaylist().
+		WithID("child2").
+		WithBasePlaylistID(homeBasePlaylistID).
+		WithSpotifyPlaylistID("spotify2").
+		WithSourceBasePlaylistIDs(sourceBasePlaylistID).
+		WithSyncStrategy(models.SyncStrategyInPlace).
+		Build()
+	inactiveMergeChild := testfixtures.NewChildPlaylist().WithID("child3").WithBasePlaylistID(homeBasePlaylistID).WithSourceBasePlaylistIDs(sourceBasePlaylistID).Inactive().Build()
+	siblingPlaylists := []*models.ChildPlaylist{sibling, mergeChild}
+
+	homeTrackData := &models.PlaylistTracksInfo{PlaylistID: homeBasePlaylistID, APICallCount: 1, Tracks: []models.TrackInfo{{URI: "spotify:track:1"}}}
+	sourceTrackData := &models.PlaylistTracksInfo{PlaylistID: sourceBasePlaylistID, APICallCount: 2, Tracks: []models.TrackInfo{{URI: "spotify:track:2"}}}
+	mergedData := models.MergePlaylistTracks(homeTrackData, sourceTrackData)
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(sourceBasePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	// The source base playlist has no children of its own, its merge children are synced still
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, sourceBasePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), sourceBasePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(sourceBasePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), sourceBasePlaylistID, userID).Return([]*models.ChildPlaylist{}, nil)
+	mocks.childPlaylistService.EXPECT().
+		GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), sourceBasePlaylistID, userID).
+		Return([]*models.ChildPlaylist{mergeChild, inactiveMergeChild}, nil)
+
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), homeBasePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(homeBasePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), homeBasePlaylistID, userID).Return(siblingPlaylists, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, homeBasePlaylistID).Return(homeTrackData, nil)
+	mocks.trackAggregator.EXPECT().AggregateMergedPlaylistData(gomock.Any(), userID, []string{sourceBasePlaylistID}).Return(sourceTrackData, nil)
+	mocks.trackRouter.EXPECT().
+		RouteTracksToChildren(gomock.Any(), mergedData, siblingPlaylists, models.DedupeStrategyAllMatches).
+		Return(map[string][]string{
+			"spotify1": {"spotify:track:1"},
+			"spotify2": {"spotify:track:1", "spotify:track:2"},
+		}, nil, nil)
+
+	// Only the merge child is written, its sibling is left to syncs of its own base playlist
+	expectSnapshot(mocks, "spotify2")
+	mocks.spotifyClient.EXPECT().ReplacePlaylistTracks(gomock.Any(), "spotify2", []string{"spotify:track:1", "spotify:track:2"}).Return(nil)
+	expectMembership(mocks, "spotify2", "spotify:track:1", "spotify:track:2")
+
+	var updatedSyncEvent *models.SyncEvent
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+			updatedSyncEvent = syncEvent
+			return syncEvent, nil
+		})
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, sourceBasePlaylistID)
+
+	assert.NoError(err)
+	assert.Equal(models.SyncStatusCompleted, result.Status)
+	assert.Equal([]string{"child2"}, updatedSyncEvent.ChildPlaylistIDs)
+	assert.Len(updatedSyncEvent.ChildSyncResults, 1)
+	assert.Equal("child2", updatedSyncEvent.ChildSyncResults[0].ChildPlaylistID)
+	assert.Equal(2, updatedSyncEvent.ChildSyncResults[0].TracksAdded)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_TrackAggregationError(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: userID, IsActive: true},
+	}
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(nil, errors.New("aggregation failed"))
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.Error(err)
+	assert.NotNil(result)
+	assert.Equal(models.SyncStatusFailed, result.Status)
+	assert.Contains(err.Error(), "failed to aggregate track data")
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_NotifiesFailure(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	mockNotificationService := servicemocks.NewMockNotificationServicer(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithNotifications(mockNotificationService)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return([]*models.ChildPlaylist{{ID: "child1", UserID: userID, IsActive: true}}, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(nil, errors.New("aggregation failed"))
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	// A failing notification doesn't change the outcome of the sync
+	mockNotificationService.EXPECT().NotifySyncCompleted(gomock.Any(), createdSyncEvent).Return(errors.New("smtp error"))
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.ErrorContains(err, "failed to aggregate track data")
+	assert.Equal(models.SyncStatusFailed, result.Status)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_RecordsRequestID(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+		assert.Equal("req123", syncEvent.RequestID)
+		return createdSyncEvent, nil
+	})
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return([]*models.ChildPlaylist{{ID: "child1", UserID: userID, IsActive: true}}, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(nil, errors.New("aggregation failed"))
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	ctx := requestcontext.ContextWithRequestID(context.Background(), "req123")
+	_, err := orchestrator.SyncBasePlaylist(ctx, userID, basePlaylistID)
+
+	assert.ErrorContains(err, "failed to aggregate track data")
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_PublishesProgress(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	mockEvents := servicemocks.NewMockEventPublisher(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithEvents(mockEvents)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return([]*models.ChildPlaylist{{ID: "child1", UserID: userID, IsActive: true}}, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(nil, errors.New("aggregation failed"))
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	// The sync event keeps changing, so its state is captured as each event is published
+	type progress struct {
+		status models.SyncStatus
+		phase  models.SyncPhase
+	}
+	var published []progress
+	mockEvents.EXPECT().Publish(userID, gomock.Any()).Do(func(_ string, event *models.AppEvent) {
+		assert.Equal(models.AppEventSyncProgress, event.Type)
+		assert.Equal(createdSyncEvent.ID, event.SyncEvent.ID)
+		published = append(published, progress{event.SyncEvent.Status, event.SyncEvent.Phase})
+	}).Times(3)
+
+	_, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.Error(err)
+	assert.Equal([]progress{
+		{models.SyncStatusInProgress, ""},
+		{models.SyncStatusInProgress, models.SyncPhaseFetchingTracks},
+		{models.SyncStatusFailed, models.SyncPhaseFetchingTracks},
+	}, published)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_Profiling(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: userID, IsActive: true},
+	}
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(nil, errors.New("aggregation failed"))
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+			assert.NotNil(syncEvent.Profile)
+			return syncEvent, nil
+		})
+
+	ctx := requestcontext.ContextWithSyncProfiling(context.Background())
+	result, err := orchestrator.SyncBasePlaylist(ctx, userID, basePlaylistID)
+
+	assert.Error(err)
+	assert.NotNil(result.Profile)
+	assert.Equal("sync", result.Profile.Name)
+
+	var spanNames []string
+	for _, span := range result.Profile.Children {
+		spanNames = append(spanNames, span.Name)
+	}
+	assert.Equal([]string{"load_playlists", "aggregation"}, spanNames)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_NoProfilingByDefault(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return([]*models.ChildPlaylist{}, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.NoError(err)
+	assert.Nil(result.Profile)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_PartialChildFailure(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: userID, SpotifyPlaylistID: "spotify1", Name: "Child 1", IsActive: true},
+		{ID: "child2", UserID: userID, SpotifyPlaylistID: "spotify2", Name: "Child 2", IsActive: true},
+	}
+
+	trackData := &models.PlaylistTracksInfo{
+		PlaylistID: basePlaylistID,
+		Tracks: []models.TrackInfo{
+			{URI: "spotify:track:1", Name: "Track 1"},
+			{URI: "spotify:track:2", Name: "Track 2"},
+		},
+	}
+
+	routing := map[string][]string{
+		"spotify1": {"spotify:track:1", "spotify:track:2"},
+		"spotify2": {"spotify:track:2"},
+	}
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(trackData, nil)
+	mocks.trackRouter.EXPECT().RouteTracksToChildren(gomock.Any(), trackData, childPlaylists, models.DedupeStrategyAllMatches).Return(routing, nil, nil)
+	mocks.ruleHistoryService.EXPECT().GetPendingChanges(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.syncEventService.EXPECT().GetLastCompletedSyncEvent(gomock.Any(), userID, basePlaylistID).Return(nil, nil)
+
+	// First child syncs, second child fails to be deleted
+	expectSnapshot(mocks, "spotify1")
+	expectSnapshot(mocks, "spotify2")
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify1").Return(nil)
+	mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), "[Test Base Playlist] > Child 1", gomock.Any(), false).
+		Return(&spotifyclient.SpotifyPlaylist{ID: "new_spotify1"}, nil)
+	mocks.childPlaylistService.EXPECT().UpdateChildPlaylistSpotifyID(gomock.Any(), "child1", userID, "new_spotify1").Return(childPlaylists[0], nil)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new_spotify1", routing["spotify1"]).Return(nil)
+	expectMembership(mocks, "new_spotify1", routing["spotify1"]...)
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify2").Return(errors.New("delete failed"))
+
+	var updatedSyncEvent *models.SyncEvent
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+			updatedSyncEvent = syncEvent
+			return syncEvent, nil
+		})
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.Error(err)
+	assert.NotNil(result)
+	assert.Equal(models.SyncStatusFailed, result.Status)
+	assert.Contains(err.Error(), "failed to sync 1 of 2 child playlists")
+
+	assert.NotNil(updatedSyncEvent)
+	assert.Len(updatedSyncEvent.ChildSyncResults, 2)
+
+	succeeded := updatedSyncEvent.ChildSyncResults[0]
+	assert.Equal("child1", succeeded.ChildPlaylistID)
+	assert.Equal("new_spotify1", succeeded.SpotifyPlaylistID)
+	assert.Equal(models.SyncStatusCompleted, succeeded.Status)
+	assert.Equal(2, succeeded.TracksAdded)
+	assert.Equal(4, succeeded.APIRequests)
+	assert.Nil(succeeded.ErrorMessage)
+
+	failed := updatedSyncEvent.ChildSyncResults[1]
+	assert.Equal("child2", failed.ChildPlaylistID)
+	assert.Equal("spotify2", failed.SpotifyPlaylistID)
+	assert.Equal(models.SyncStatusFailed, failed.Status)
+	assert.Equal(0, failed.TracksAdded)
+	assert.NotNil(failed.ErrorMessage)
+	assert.Contains(*failed.ErrorMessage, "failed to delete playlist")
+}
+
+func TestDefaultSyncOrchestrator_UpdateSpotifyPlaylists_Concurrent(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	basePlaylist := testfixtures.NewBasePlaylist().Build()
+	childPlaylists := make([]*models.ChildPlaylist, 3)
+	routing := make(map[string][]string, 3)
+	for i := range childPlaylists {
+		spotifyPlaylistID := fmt.Sprintf("spotify%d", i+1)
+		childPlaylists[i] = testfixtures.NewChildPlaylist().
+			WithID(fmt.Sprintf("child%d", i+1)).
+			WithSpotifyPlaylistID(spotifyPlaylistID).
+			WithSyncStrategy(models.SyncStrategyInPlace).
+			Build()
+		routing[spotifyPlaylistID] = []string{"spotify:track:" + spotifyPlaylistID}
+	}
+	syncEvent := &models.SyncEvent{ID: "sync123"}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithChildConcurrency(3)
+
+	for _, childPlaylist := range childPlaylists {
+		expectPlaylistTracks(mocks, childPlaylist.SpotifyPlaylistID)
+	}
+	mocks.snapshotService.EXPECT().CreateSnapshot(gomock.Any(), gomock.Any()).Return(&models.PlaylistSnapshot{}, nil).Times(3)
+
+	// Every child waits for the others to start writing, which only happens when they run at once
+	var started sync.WaitGroup
+	started.Add(len(childPlaylists))
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	mocks.spotifyClient.EXPECT().
+		ReplacePlaylistTracks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, spotifyPlaylistID string, trackURIs []string) error {
+			started.Done()
+			select {
+			case <-allStarted:
+			case <-time.After(5 * time.Second):
+				return errors.New("children were written one at a time")
+			}
+
+			if spotifyPlaylistID == "spotify2" {
+				return errors.New("replace failed")
+			}
+			return nil
+		}).
+		Times(3)
+	expectMembership(mocks, "spotify1", routing["spotify1"]...)
+	expectMembership(mocks, "spotify3", routing["spotify3"]...)
+
+	err := orchestrator.updateSpotifyPlaylists(context.Background(), syncEvent, basePlaylist, childPlaylists, routing)
+
+	assert.ErrorContains(err, "failed to sync 1 of 3 child playlists")
+	assert.Equal(5, syncEvent.TotalAPIRequests) // snapshot + replace for the children that succeeded, snapshot for the failed one
+
+	// Results keep the order of the children, whichever finished first
+	assert.Len(syncEvent.ChildSyncResults, 3)
+	for i, result := range syncEvent.ChildSyncResults {
+		assert.Equal(childPlaylists[i].ID, result.ChildPlaylistID)
+	}
+	assert.Equal(models.SyncStatusCompleted, syncEvent.ChildSyncResults[0].Status)
+	assert.Equal(models.SyncStatusFailed, syncEvent.ChildSyncResults[1].Status)
+	assert.Contains(*syncEvent.ChildSyncResults[1].ErrorMessage, "replace failed")
+	assert.Equal(models.SyncStatusCompleted, syncEvent.ChildSyncResults[2].Status)
+}
+
+func TestDefaultSyncOrchestrator_UpdateSpotifyPlaylists_ConcurrentSyncFlagOff(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	basePlaylist := testfixtures.NewBasePlaylist().Build()
+	childPlaylists := make([]*models.ChildPlaylist, 3)
+	routing := make(map[string][]string, 3)
+	for i := range childPlaylists {
+		spotifyPlaylistID := fmt.Sprintf("spotify%d", i+1)
+		childPlaylists[i] = testfixtures.NewChildPlaylist().
+			WithID(fmt.Sprintf("child%d", i+1)).
+			WithSpotifyPlaylistID(spotifyPlaylistID).
+			WithSyncStrategy(models.SyncStrategyInPlace).
+			Build()
+		routing[spotifyPlaylistID] = []string{"spotify:track:" + spotifyPlaylistID}
+	}
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123"}
+
+	mocks := createMockServices(ctrl)
+	featureFlags := servicemocks.NewMockFeatureFlagServicer(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithChildConcurrency(3).WithFeatureFlags(featureFlags)
+
+	featureFlags.EXPECT().IsEnabled(gomock.Any(), models.FeatureConcurrentSync, "user123", true).Return(false)
+	for _, childPlaylist := range childPlaylists {
+		expectPlaylistTracks(mocks, childPlaylist.SpotifyPlaylistID)
+		expectMembership(mocks, childPlaylist.SpotifyPlaylistID, routing[childPlaylist.SpotifyPlaylistID]...)
+	}
+	mocks.snapshotService.EXPECT().CreateSnapshot(gomock.Any(), gomock.Any()).Return(&models.PlaylistSnapshot{}, nil).Times(3)
+
+	// The children are written one at a time while the flag is off for the user
+	var writing, maxWriting int
+	var mu sync.Mutex
+	mocks.spotifyClient.EXPECT().
+		ReplacePlaylistTracks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, spotifyPlaylistID string, trackURIs []string) error {
+			mu.Lock()
+			writing++
+			maxWriting = max(maxWriting, writing)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			writing--
+			mu.Unlock()
+			return nil
+		}).
+		Times(3)
+
+	err := orchestrator.updateSpotifyPlaylists(context.Background(), syncEvent, basePlaylist, childPlaylists, routing)
+
+	assert.NoError(err)
+	assert.Equal(1, maxWriting)
+	assert.Len(syncEvent.ChildSyncResults, 3)
+}
+
+func TestDefaultSyncOrchestrator_RouteMergeChildPlaylists(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", SpotifyPlaylistID: "spotify1"},
+		{ID: "child2", SpotifyPlaylistID: "spotify2", SourceBasePlaylistIDs: []string{"base2", "base3"}},
+		{ID: "child3", SpotifyPlaylistID: "spotify3", SourceBasePlaylistIDs: []string{"base2"}},
+	}
+	trackData := &models.PlaylistTracksInfo{
+		PlaylistID: "base1",
+		Tracks:     []models.TrackInfo{{URI: "spotify:track:1"}},
+	}
+	sourceData := &models.PlaylistTracksInfo{
+		PlaylistID:   "base2",
+		APICallCount: 4,
+		Tracks:       []models.TrackInfo{{URI: "spotify:track:1"}, {URI: "spotify:track:2"}},
+	}
+	mergedData := models.MergePlaylistTracks(trackData, sourceData)
+	routing := map[string][]string{
+		"spotify1": {"spotify:track:1"},
+		"spotify3": {"spotify:track:1"},
+	}
+	syncEvent := testfixtures.NewSyncEvent().WithUserID("user123").Build()
+
+	mocks.trackAggregator.EXPECT().
+		AggregateMergedPlaylistData(gomock.Any(), "user123", []string{"base2", "base3"}).
+		Return(sourceData, nil)
+	mocks.trackAggregator.EXPECT().
+		AggregateMergedPlaylistData(gomock.Any(), "user123", []string{"base2"}).
+		Return(sourceData, nil)
+	mocks.trackRouter.EXPECT().
+		RouteTracksToChildren(gomock.Any(), mergedData, childPlaylists, models.DedupeStrategyFirstMatch).
+		Return(map[string][]string{
+			"spotify1": {"spotify:track:1"},
+			"spotify2": {"spotify:track:2"},
+		}, nil, nil).
+		Times(2)
+
+	err := orchestrator.routeMergeChildPlaylists(context.Background(), syncEvent, trackData, childPlaylists, childPlaylists, models.DedupeStrategyFirstMatch, routing)
+
+	assert.NoError(err)
+	assert.Equal(map[string][]string{
+		"spotify1": {"spotify:track:1"},
+		"spotify2": {"spotify:track:2"},
+	}, routing)
+	assert.Equal(8, syncEvent.TotalAPIRequests)
+}
+
+func TestDefaultSyncOrchestrator_RouteMergeChildPlaylists_AggregationError(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", SpotifyPlaylistID: "spotify1", SourceBasePlaylistIDs: []string{"base2"}},
+	}
+	syncEvent := testfixtures.NewSyncEvent().WithUserID("user123").Build()
+
+	mocks.trackAggregator.EXPECT().
+		AggregateMergedPlaylistData(gomock.Any(), "user123", []string{"base2"}).
+		Return(nil, errors.New("spotify api error"))
+
+	err := orchestrator.routeMergeChildPlaylists(context.Background(), syncEvent, &models.PlaylistTracksInfo{}, childPlaylists, childPlaylists, models.DedupeStrategyAllMatches, map[string][]string{})
+
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed to aggregate sources of child playlist child1")
+}
+
+func TestDefaultSyncOrchestrator_BaseSnapshotID(t *testing.T) {
+	tests := []struct {
+		name                string
+		routingCache        bool
+		flagOff             bool
+		snapshotErr         error
+		expectedSnapshotID  string
+		expectedAPIRequests int
+	}{
+		{name: "routing cache disabled", routingCache: false, expectedSnapshotID: "", expectedAPIRequests: 0},
+		{name: "routing cache enabled", routingCache: true, expectedSnapshotID: "snap1", expectedAPIRequests: 1},
+		{name: "incremental sync flag off", routingCache: true, flagOff: true, expectedSnapshotID: "", expectedAPIRequests: 0},
+		{name: "snapshot lookup fails", routingCache: true, snapshotErr: errors.New("spotify unavailable"), expectedSnapshotID: "", expectedAPIRequests: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			featureFlags := servicemocks.NewMockFeatureFlagServicer(ctrl)
+			orchestrator := createTestOrchestrator(mocks).WithRoutingCache(tt.routingCache).WithFeatureFlags(featureFlags)
+
+			if tt.routingCache {
+				featureFlags.EXPECT().IsEnabled(gomock.Any(), models.FeatureIncrementalSync, "user123", true).Return(!tt.flagOff)
+			}
+			if tt.routingCache && !tt.flagOff {
+				mocks.spotifyClient.EXPECT().GetPlaylistSnapshotID(gomock.Any(), "spotify456").Return(tt.expectedSnapshotID, tt.snapshotErr)
+			}
+
+			syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123"}
+			basePlaylist := testfixtures.NewBasePlaylist().WithSpotifyPlaylistID("spotify456").Build()
+
+			assert.Equal(tt.expectedSnapshotID, orchestrator.baseSnapshotID(context.Background(), syncEvent, basePlaylist))
+			assert.Equal(tt.expectedAPIRequests, syncEvent.TotalAPIRequests)
+		})
+	}
+}
+
+func TestApplyPinnedTracks(t *testing.T) {
+	assert := require.New(t)
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", SpotifyPlaylistID: "spotify1", IsActive: true, PinnedTracks: []string{"spotify:track:3", "spotify:track:1"}},
+		{ID: "child2", SpotifyPlaylistID: "spotify2", IsActive: true, PinnedTracks: []string{"spotify:track:4"}},
+		{ID: "child3", SpotifyPlaylistID: "spotify3", IsActive: false, PinnedTracks: []string{"spotify:track:5"}},
+		{ID: "child4", SpotifyPlaylistID: "spotify4", IsActive: true},
+	}
+	routing := map[string][]string{
+		"spotify1": {"spotify:track:1", "spotify:track:2"},
+		"spotify4": {"spotify:track:2"},
+	}
+
+	applyPinnedTracks(childPlaylists, routing)
+
+	assert.Equal(map[string][]string{
+		"spotify1": {"spotify:track:3", "spotify:track:1", "spotify:track:2"},
+		"spotify2": {"spotify:track:4"},
+		"spotify4": {"spotify:track:2"},
+	}, routing)
+}
+
+func TestDefaultSyncOrchestrator_SyncAllBasePlaylists(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+
+	basePlaylists := []*models.BasePlaylist{
+		{ID: "base1", UserID: userID, Name: "Base 1", IsActive: true},
+		{ID: "base2", UserID: userID, Name: "Base 2", IsActive: true},
+		{ID: "base3", UserID: userID, Name: "Base 3", IsActive: false},
+		{ID: "base4", UserID: userID, Name: "Base 4", IsActive: true, Archived: true},
 	}
 
+	createdSyncEvent := testfixtures.NewSyncEvent().WithID("sync1").WithUserID(userID).WithBasePlaylistID("base1").Build()
+
 	mocks := createMockServices(ctrl)
 	orchestrator := createTestOrchestrator(mocks)
 
-	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), userID).Return(basePlaylists, nil)
+
+	// base1 syncs successfully without child playlists
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, "base1").Return(false, nil)
 	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
-	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(&models.BasePlaylist{
-		ID:     basePlaylistID,
-		UserID: userID,
-		Name:   "Test Base Playlist",
-	}, nil)
-	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return([]*models.ChildPlaylist{}, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base1", userID).Return(basePlaylists[0], nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base1", userID).Return([]*models.ChildPlaylist{}, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), "base1", userID).Return(nil, nil)
 	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
 
-	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+	// base2 already has a sync in progress
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, "base2").Return(true, nil)
+
+	report, err := orchestrator.SyncAllBasePlaylists(context.Background(), userID)
 
 	assert.NoError(err)
-	assert.NotNil(result)
-	assert.Equal(models.SyncStatusCompleted, result.Status)
+	assert.NotNil(report)
+	assert.Equal(userID, report.UserID)
+	assert.Equal(2, report.Total)
+	assert.Equal(1, report.Succeeded)
+	assert.Equal(1, report.Failed)
+	assert.Len(report.Results, 2)
+
+	assert.Equal("base1", report.Results[0].BasePlaylistID)
+	assert.Equal("sync1", report.Results[0].SyncEventID)
+	assert.Equal(models.SyncStatusCompleted, report.Results[0].Status)
+	assert.Nil(report.Results[0].ErrorMessage)
+
+	assert.Equal("base2", report.Results[1].BasePlaylistID)
+	assert.Empty(report.Results[1].SyncEventID)
+	assert.Equal(models.SyncStatusFailed, report.Results[1].Status)
+	assert.NotNil(report.Results[1].ErrorMessage)
+	assert.Contains(*report.Results[1].ErrorMessage, "sync already in progress")
 }
 
-func TestDefaultSyncOrchestrator_SyncBasePlaylist_TrackAggregationError(t *testing.T) {
+func TestDefaultSyncOrchestrator_SyncAllBasePlaylists_GetBasePlaylistsError(t *testing.T) {
 	assert := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	userID := "user123"
-	basePlaylistID := "base456"
-
-	childPlaylists := []*models.ChildPlaylist{
-		{ID: "child1", UserID: userID, IsActive: true},
-	}
-
-	createdSyncEvent := &models.SyncEvent{
-		ID:             "sync123",
-		UserID:         userID,
-		BasePlaylistID: basePlaylistID,
-		Status:         models.SyncStatusInProgress,
-	}
 
 	mocks := createMockServices(ctrl)
 	orchestrator := createTestOrchestrator(mocks)
 
-	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
-	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
-	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(&models.BasePlaylist{
-		ID:     basePlaylistID,
-		UserID: userID,
-		Name:   "Test Base Playlist",
-	}, nil)
-	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
-	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(nil, errors.New("aggregation failed"))
-	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylistsByUserID(gomock.Any(), userID).Return(nil, errors.New("database error"))
 
-	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+	report, err := orchestrator.SyncAllBasePlaylists(context.Background(), userID)
 
 	assert.Error(err)
-	assert.NotNil(result)
-	assert.Equal(models.SyncStatusFailed, result.Status)
-	assert.Contains(err.Error(), "failed to aggregate track data")
+	assert.Nil(report)
+	assert.Contains(err.Error(), "failed to get base playlists")
 }
 
 func TestDefaultSyncOrchestrator_SyncChildPlaylist_Success(t *testing.T) {
@@ -278,17 +1163,116 @@ func TestDefaultSyncOrchestrator_SyncChildPlaylist_Success(t *testing.T) {
 	orchestrator := createTestOrchestrator(mocks)
 
 	// Mock expectations
+	expectSnapshot(mocks, "old_spotify1", "spotify:track:old")
 	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "old_spotify1").Return(nil)
 	mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), expectedName, expectedDescription, false).Return(newPlaylist, nil)
 	mocks.childPlaylistService.EXPECT().UpdateChildPlaylistSpotifyID(gomock.Any(), childPlaylist.ID, childPlaylist.UserID, newPlaylist.ID).Return(&childPlaylist, nil)
 	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), newPlaylist.ID, trackURIs).Return(nil)
+	expectMembership(mocks, newPlaylist.ID, trackURIs...)
 
 	// Execute
-	apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "old_spotify1", trackURIs, syncEvent)
+	result := &models.ChildSyncResult{ChildPlaylistID: childPlaylist.ID}
+	apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "old_spotify1", trackURIs, syncEvent, result)
 
 	// Assert
 	assert.NoError(err)
-	assert.Equal(3, apiRequestCount) // delete + create + add tracks
+	assert.Equal(4, apiRequestCount) // snapshot + delete + create + add tracks
+	assert.Equal(newPlaylist.ID, result.SpotifyPlaylistID)
+	assert.Equal(len(trackURIs), result.TracksAdded)
+}
+
+func TestDefaultSyncOrchestrator_SyncChildPlaylist_RefollowRecreated(t *testing.T) {
+	tests := []struct {
+		name      string
+		followErr error
+	}{
+		{name: "follows the new playlist"},
+		{name: "follow failure does not fail the sync", followErr: errors.New("follow failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			basePlaylist := testfixtures.NewBasePlaylist().Build()
+			childPlaylist := *testfixtures.NewChildPlaylist().WithSpotifyPlaylistID("old_spotify1").WithRefollowRecreated(true).Build()
+			trackURIs := []string{"spotify:track:1"}
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+
+			expectSnapshot(mocks, "old_spotify1")
+			mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "old_spotify1").Return(nil)
+			mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&spotifyclient.SpotifyPlaylist{ID: "new_spotify1"}, nil)
+			mocks.childPlaylistService.EXPECT().UpdateChildPlaylistSpotifyID(gomock.Any(), childPlaylist.ID, childPlaylist.UserID, "new_spotify1").Return(&childPlaylist, nil)
+			mocks.spotifyClient.EXPECT().FollowPlaylist(gomock.Any(), "new_spotify1", true).Return(tt.followErr)
+			mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new_spotify1", trackURIs).Return(nil)
+			expectMembership(mocks, "new_spotify1", trackURIs...)
+
+			result := &models.ChildSyncResult{ChildPlaylistID: childPlaylist.ID}
+			apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "old_spotify1", trackURIs, &models.SyncEvent{ID: "sync123"}, result)
+
+			assert.NoError(err)
+			assert.Equal(5, apiRequestCount) // snapshot + delete + create + follow + add tracks
+			assert.Equal("new_spotify1", result.SpotifyPlaylistID)
+		})
+	}
+}
+
+func TestDefaultSyncOrchestrator_SyncChildPlaylist_InPlace(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	basePlaylist := testfixtures.NewBasePlaylist().Build()
+	childPlaylist := *testfixtures.NewChildPlaylist().WithSpotifyPlaylistID("spotify1").WithSyncStrategy(models.SyncStrategyInPlace).Build()
+
+	trackURIs := make([]string, 150)
+	for i := range trackURIs {
+		trackURIs[i] = fmt.Sprintf("spotify:track:%d", i)
+	}
+	syncEvent := &models.SyncEvent{ID: "sync123"}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	// The playlist keeps its ID: the first batch replaces its tracks and the rest is appended
+	expectSnapshot(mocks, "spotify1", "spotify:track:old")
+	mocks.spotifyClient.EXPECT().ReplacePlaylistTracks(gomock.Any(), "spotify1", trackURIs[:100]).Return(nil)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "spotify1", trackURIs[100:]).Return(nil)
+	expectMembership(mocks, "spotify1", trackURIs...)
+
+	result := &models.ChildSyncResult{ChildPlaylistID: childPlaylist.ID, SpotifyPlaylistID: "spotify1"}
+	apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "spotify1", trackURIs, syncEvent, result)
+
+	assert.NoError(err)
+	assert.Equal(3, apiRequestCount) // snapshot + replace + add tracks
+	assert.Equal("spotify1", result.SpotifyPlaylistID)
+	assert.Equal(len(trackURIs), result.TracksAdded)
+}
+
+func TestDefaultSyncOrchestrator_SyncChildPlaylist_InPlaceEmpty(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	basePlaylist := testfixtures.NewBasePlaylist().Build()
+	childPlaylist := *testfixtures.NewChildPlaylist().WithSpotifyPlaylistID("spotify1").WithSyncStrategy(models.SyncStrategyInPlace).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	expectSnapshot(mocks, "spotify1", "spotify:track:old")
+	mocks.spotifyClient.EXPECT().ReplacePlaylistTracks(gomock.Any(), "spotify1", []string{}).Return(nil)
+	expectMembership(mocks, "spotify1")
+
+	result := &models.ChildSyncResult{ChildPlaylistID: childPlaylist.ID}
+	apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "spotify1", []string{}, &models.SyncEvent{ID: "sync123"}, result)
+
+	assert.NoError(err)
+	assert.Equal(2, apiRequestCount) // snapshot + replace
 }
 
 func TestDefaultSyncOrchestrator_SyncChildPlaylist_DeletePlaylistError(t *testing.T) {
@@ -313,15 +1297,407 @@ func TestDefaultSyncOrchestrator_SyncChildPlaylist_DeletePlaylistError(t *testin
 	mocks := createMockServices(ctrl)
 	orchestrator := createTestOrchestrator(mocks)
 
+	expectSnapshot(mocks, "old_spotify1")
 	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "old_spotify1").Return(errors.New("delete failed"))
 
-	apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "old_spotify1", trackURIs, syncEvent)
+	apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "old_spotify1", trackURIs, syncEvent, &models.ChildSyncResult{})
 
 	assert.Error(err)
-	assert.Equal(0, apiRequestCount)
+	assert.Equal(1, apiRequestCount) // snapshot only
 	assert.Contains(err.Error(), "failed to delete playlist")
 }
 
+func TestDefaultSyncOrchestrator_SnapshotChildPlaylist_Paginates(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	childPlaylist := models.ChildPlaylist{ID: "child1", UserID: "user123"}
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123"}
+	next := "next_page"
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	gomock.InOrder(
+		mocks.spotifyClient.EXPECT().GetPlaylistTracks(gomock.Any(), "spotify1", MAX_PLAYLIST_TRACKS, 0).Return(&spotifyclient.SpotifyPlaylistTracksResponse{
+			Items: []spotifyclient.SpotifyPlaylistTrack{
+				{Track: &spotifyclient.SpotifyTrack{URI: "spotify:track:1"}},
+				{Track: nil}, // unavailable track
+			},
+			Next: &next,
+		}, nil),
+		mocks.spotifyClient.EXPECT().GetPlaylistTracks(gomock.Any(), "spotify1", MAX_PLAYLIST_TRACKS, MAX_PLAYLIST_TRACKS).Return(&spotifyclient.SpotifyPlaylistTracksResponse{
+			Items: []spotifyclient.SpotifyPlaylistTrack{
+				{Track: &spotifyclient.SpotifyTrack{URI: "spotify:track:2"}},
+			},
+		}, nil),
+	)
+
+	mocks.snapshotService.EXPECT().CreateSnapshot(gomock.Any(), &models.PlaylistSnapshot{
+		UserID:            "user123",
+		SyncEventID:       "sync123",
+		ChildPlaylistID:   "child1",
+		SpotifyPlaylistID: "spotify1",
+		TrackURIs:         []string{"spotify:track:1", "spotify:track:2"},
+	}).Return(&models.PlaylistSnapshot{ID: "snapshot1"}, nil)
+
+	apiRequestCount, err := orchestrator.snapshotChildPlaylist(context.Background(), childPlaylist, "spotify1", syncEvent)
+
+	assert.NoError(err)
+	assert.Equal(2, apiRequestCount)
+}
+
+func TestDefaultSyncOrchestrator_SyncChildPlaylist_SnapshotError(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	basePlaylist := &models.BasePlaylist{ID: "base1", UserID: "user123", Name: "Base Playlist"}
+	childPlaylist := models.ChildPlaylist{ID: "child1", SpotifyPlaylistID: "old_spotify1"}
+	syncEvent := &models.SyncEvent{ID: "sync123"}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.spotifyClient.EXPECT().GetPlaylistTracks(gomock.Any(), "old_spotify1", MAX_PLAYLIST_TRACKS, 0).
+		Return(&spotifyclient.SpotifyPlaylistTracksResponse{}, nil)
+	mocks.snapshotService.EXPECT().CreateSnapshot(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	// The playlist must not be deleted when its contents could not be snapshotted
+	apiRequestCount, err := orchestrator.syncChildPlaylist(context.Background(), basePlaylist, childPlaylist, "old_spotify1", []string{"spotify:track:1"}, syncEvent, &models.ChildSyncResult{})
+
+	assert.Error(err)
+	assert.Equal(1, apiRequestCount)
+	assert.Contains(err.Error(), "failed to snapshot playlist")
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_AnomalyDetected(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: userID, SpotifyPlaylistID: "spotify1", Name: "Child 1", IsActive: true},
+	}
+
+	trackData := &models.PlaylistTracksInfo{
+		PlaylistID: basePlaylistID,
+		Tracks: []models.TrackInfo{
+			{URI: "spotify:track:1"},
+			{URI: "spotify:track:2"},
+		},
+	}
+
+	previousSync := &models.SyncEvent{
+		ID:              "sync_previous",
+		Status:          models.SyncStatusCompleted,
+		TracksProcessed: 500,
+		ChildSyncResults: []models.ChildSyncResult{
+			{ChildPlaylistID: "child1", Status: models.SyncStatusCompleted, TracksAdded: 500},
+		},
+	}
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID(userID).WithBasePlaylistID(basePlaylistID).Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(trackData, nil)
+	mocks.trackRouter.EXPECT().RouteTracksToChildren(gomock.Any(), trackData, childPlaylists, models.DedupeStrategyAllMatches).
+		Return(map[string][]string{"spotify1": {"spotify:track:1"}}, nil, nil)
+	mocks.ruleHistoryService.EXPECT().GetPendingChanges(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.syncEventService.EXPECT().GetLastCompletedSyncEvent(gomock.Any(), userID, basePlaylistID).Return(previousSync, nil)
+
+	// No spotify playlist is touched while the sync waits for confirmation
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).Return(createdSyncEvent, nil)
+
+	result, err := orchestrator.SyncBasePlaylist(context.Background(), userID, basePlaylistID)
+
+	assert.ErrorIs(err, ErrSyncAnomalyDetected)
+	assert.NotNil(result)
+	assert.Equal(models.SyncStatusNeedsConfirmation, result.Status)
+	assert.Equal(1, result.TracksUnmatched)
+	assert.Len(result.Anomalies, 1)
+	assert.Equal(models.SyncAnomalyChildTrackDrop, result.Anomalies[0].Type)
+	assert.Equal("child1", result.Anomalies[0].ChildPlaylistID)
+	assert.Equal(500, result.Anomalies[0].PreviousCount)
+	assert.Equal(1, result.Anomalies[0].NewCount)
+}
+
+func TestDefaultSyncOrchestrator_ConfirmSync_Success(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	heldSyncEvent := &models.SyncEvent{
+		ID:             "sync_held",
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		Status:         models.SyncStatusNeedsConfirmation,
+	}
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: userID, SpotifyPlaylistID: "spotify1", Name: "Child 1", IsActive: true},
+	}
+
+	trackData := &models.PlaylistTracksInfo{
+		PlaylistID: basePlaylistID,
+		Tracks:     []models.TrackInfo{{URI: "spotify:track:1"}},
+	}
+
+	confirmedSyncEvent := &models.SyncEvent{
+		ID:             "sync_confirmed",
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		Status:         models.SyncStatusInProgress,
+	}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync_held").Return(heldSyncEvent, nil)
+	mocks.syncEventService.EXPECT().
+		TransitionSyncEventStatus(gomock.Any(), "sync_held", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed).
+		Return(nil)
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(confirmedSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+	mocks.trackAggregator.EXPECT().AggregatePlaylistData(gomock.Any(), userID, basePlaylistID).Return(trackData, nil)
+	mocks.trackRouter.EXPECT().RouteTracksToChildren(gomock.Any(), trackData, childPlaylists, models.DedupeStrategyAllMatches).
+		Return(map[string][]string{"spotify1": {"spotify:track:1"}}, nil, nil)
+	mocks.ruleHistoryService.EXPECT().GetPendingChanges(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+
+	// Anomaly detection is skipped, so the previous sync is never looked up
+	expectSnapshot(mocks, "spotify1")
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify1").Return(nil)
+	mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), "[Test Base Playlist] > Child 1", gomock.Any(), false).
+		Return(&spotifyclient.SpotifyPlaylist{ID: "new_spotify1"}, nil)
+	mocks.childPlaylistService.EXPECT().UpdateChildPlaylistSpotifyID(gomock.Any(), "child1", userID, "new_spotify1").Return(childPlaylists[0], nil)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "new_spotify1", []string{"spotify:track:1"}).Return(nil)
+	expectMembership(mocks, "new_spotify1", "spotify:track:1")
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), "sync_confirmed", gomock.Any()).Return(confirmedSyncEvent, nil)
+
+	result, err := orchestrator.ConfirmSync(context.Background(), userID, "sync_held")
+
+	assert.NoError(err)
+	assert.Equal("sync_confirmed", result.ID)
+	assert.Equal(models.SyncStatusCompleted, result.Status)
+}
+
+func TestDefaultSyncOrchestrator_ConfirmSync_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(mocks mockServices)
+		expectedError error
+	}{
+		{
+			name: "sync event not found",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(nil, errors.New("not found"))
+			},
+			expectedError: ErrSyncEventNotFound,
+		},
+		{
+			name: "sync event owned by another user",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "other_user", Status: models.SyncStatusNeedsConfirmation}, nil)
+			},
+			expectedError: ErrSyncEventNotFound,
+		},
+		{
+			name: "sync event not awaiting confirmation",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "user123", Status: models.SyncStatusCompleted}, nil)
+			},
+			expectedError: ErrSyncNotAwaitingConfirmation,
+		},
+		{
+			name: "sync confirmed concurrently",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "user123", BasePlaylistID: "base456", Status: models.SyncStatusNeedsConfirmation}, nil)
+				mocks.syncEventService.EXPECT().
+					TransitionSyncEventStatus(gomock.Any(), "sync123", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed).
+					Return(fmt.Errorf("failed to transition sync event status: %w", repositories.ErrSyncEventStatusChanged))
+			},
+			expectedError: ErrSyncNotAwaitingConfirmation,
+		},
+		{
+			name: "sync already in progress",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "user123", BasePlaylistID: "base456", Status: models.SyncStatusNeedsConfirmation}, nil)
+				gomock.InOrder(
+					mocks.syncEventService.EXPECT().
+						TransitionSyncEventStatus(gomock.Any(), "sync123", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed).
+						Return(nil),
+					mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), "user123", "base456").Return(true, nil),
+					// The held sync stays confirmable since the confirmed run never started
+					mocks.syncEventService.EXPECT().
+						TransitionSyncEventStatus(gomock.Any(), "sync123", models.SyncStatusConfirmed, models.SyncStatusNeedsConfirmation).
+						Return(nil),
+				)
+			},
+			expectedError: ErrSyncInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+			tt.setupMocks(mocks)
+
+			result, err := orchestrator.ConfirmSync(context.Background(), "user123", "sync123")
+
+			assert.Nil(result)
+			assert.ErrorIs(err, tt.expectedError)
+		})
+	}
+}
+
+func TestDefaultSyncOrchestrator_RollbackSync_Success(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := "user123"
+	basePlaylistID := "base456"
+
+	targetSyncEvent := &models.SyncEvent{
+		ID:             "sync_target",
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		Status:         models.SyncStatusCompleted,
+	}
+
+	snapshots := []*models.PlaylistSnapshot{
+		{ID: "snapshot1", SyncEventID: "sync_target", ChildPlaylistID: "child1", SpotifyPlaylistID: "deleted_spotify1", TrackURIs: []string{"spotify:track:1", "spotify:track:2"}},
+		{ID: "snapshot2", SyncEventID: "sync_target", ChildPlaylistID: "child_gone", TrackURIs: []string{"spotify:track:3"}},
+	}
+
+	childPlaylists := []*models.ChildPlaylist{
+		{ID: "child1", UserID: userID, SpotifyPlaylistID: "current_spotify1", Name: "Child 1"},
+		{ID: "child2", UserID: userID, SpotifyPlaylistID: "current_spotify2", Name: "Child 2"},
+	}
+
+	rollbackSyncEvent := &models.SyncEvent{
+		ID:             "sync_rollback",
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		Status:         models.SyncStatusInProgress,
+	}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks)
+
+	mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync_target").Return(targetSyncEvent, nil)
+	mocks.snapshotService.EXPECT().GetSnapshotsBySyncEventID(gomock.Any(), "sync_target", userID).Return(snapshots, nil)
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), userID, basePlaylistID).Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(rollbackSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), basePlaylistID, userID).Return(testfixtures.NewBasePlaylist().WithID(basePlaylistID).Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(childPlaylists, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return(nil, nil)
+
+	// Only child1 is restored, into its current spotify playlist
+	expectSnapshot(mocks, "current_spotify1", "spotify:track:9")
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "current_spotify1").Return(nil)
+	mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), "[Test Base Playlist] > Child 1", gomock.Any(), false).
+		Return(&spotifyclient.SpotifyPlaylist{ID: "restored_spotify1"}, nil)
+	mocks.childPlaylistService.EXPECT().UpdateChildPlaylistSpotifyID(gomock.Any(), "child1", userID, "restored_spotify1").Return(childPlaylists[0], nil)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "restored_spotify1", []string{"spotify:track:1", "spotify:track:2"}).Return(nil)
+	expectMembership(mocks, "restored_spotify1", "spotify:track:1", "spotify:track:2")
+
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), "sync_rollback", gomock.Any()).Return(rollbackSyncEvent, nil)
+
+	result, err := orchestrator.RollbackSync(context.Background(), userID, "sync_target")
+
+	assert.NoError(err)
+	assert.Equal("sync_rollback", result.ID)
+	assert.Equal(models.SyncStatusCompleted, result.Status)
+	assert.Equal([]string{"child1"}, result.ChildPlaylistIDs)
+	assert.Equal(2, result.TracksProcessed)
+	assert.Len(result.ChildSyncResults, 1)
+	assert.Equal("restored_spotify1", result.ChildSyncResults[0].SpotifyPlaylistID)
+	assert.Equal(2, result.ChildSyncResults[0].TracksAdded)
+}
+
+func TestDefaultSyncOrchestrator_RollbackSync_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(mocks mockServices)
+		expectedError error
+	}{
+		{
+			name: "sync event not found",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(nil, errors.New("not found"))
+			},
+			expectedError: ErrSyncEventNotFound,
+		},
+		{
+			name: "sync event owned by another user",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(&models.SyncEvent{ID: "sync123", UserID: "other_user"}, nil)
+			},
+			expectedError: ErrSyncEventNotFound,
+		},
+		{
+			name: "no snapshots recorded",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(&models.SyncEvent{ID: "sync123", UserID: "user123"}, nil)
+				mocks.snapshotService.EXPECT().GetSnapshotsBySyncEventID(gomock.Any(), "sync123", "user123").Return([]*models.PlaylistSnapshot{}, nil)
+			},
+			expectedError: ErrNothingToRollback,
+		},
+		{
+			name: "sync in progress",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(&models.SyncEvent{ID: "sync123", UserID: "user123", BasePlaylistID: "base456"}, nil)
+				mocks.snapshotService.EXPECT().GetSnapshotsBySyncEventID(gomock.Any(), "sync123", "user123").Return([]*models.PlaylistSnapshot{{ID: "snapshot1"}}, nil)
+				mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), "user123", "base456").Return(true, nil)
+			},
+			expectedError: ErrSyncInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+			tt.setupMocks(mocks)
+
+			result, err := orchestrator.RollbackSync(context.Background(), "user123", "sync123")
+
+			assert.ErrorIs(err, tt.expectedError)
+			assert.Nil(result)
+		})
+	}
+}
+
 func TestDefaultSyncOrchestrator_AddTracksInBatches_Success(t *testing.T) {
 	assert := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -389,6 +1765,8 @@ type mockServices struct {
 	childPlaylistService *servicemocks.MockChildPlaylistServicer
 	basePlaylistService  *servicemocks.MockBasePlaylistServicer
 	syncEventService     *servicemocks.MockSyncEventServicer
+	snapshotService      *servicemocks.MockPlaylistSnapshotServicer
+	ruleHistoryService   *servicemocks.MockFilterRuleHistoryServicer
 	spotifyClient        *clientmocks.MockSpotifyAPI
 }
 
@@ -399,6 +1777,8 @@ func createMockServices(ctrl *gomock.Controller) mockServices {
 		childPlaylistService: servicemocks.NewMockChildPlaylistServicer(ctrl),
 		basePlaylistService:  servicemocks.NewMockBasePlaylistServicer(ctrl),
 		syncEventService:     servicemocks.NewMockSyncEventServicer(ctrl),
+		snapshotService:      servicemocks.NewMockPlaylistSnapshotServicer(ctrl),
+		ruleHistoryService:   servicemocks.NewMockFilterRuleHistoryServicer(ctrl),
 		spotifyClient:        clientmocks.NewMockSpotifyAPI(ctrl),
 	}
 }
@@ -410,11 +1790,108 @@ func createTestOrchestrator(mocks mockServices) *DefaultSyncOrchestrator {
 		mocks.childPlaylistService,
 		mocks.basePlaylistService,
 		mocks.syncEventService,
+		mocks.snapshotService,
+		mocks.ruleHistoryService,
 		mocks.spotifyClient,
 		createTestLogger(),
 	)
 }
 
+// expectSnapshot mocks fetching the current tracks of a spotify playlist and storing them as a snapshot
+func expectPlaylistTracks(mocks mockServices, spotifyPlaylistID string, trackURIs ...string) {
+	items := make([]spotifyclient.SpotifyPlaylistTrack, len(trackURIs))
+	for i, uri := range trackURIs {
+		items[i] = spotifyclient.SpotifyPlaylistTrack{Track: &spotifyclient.SpotifyTrack{URI: uri}}
+	}
+
+	mocks.spotifyClient.EXPECT().
+		GetPlaylistTracks(gomock.Any(), spotifyPlaylistID, MAX_PLAYLIST_TRACKS, 0).
+		Return(&spotifyclient.SpotifyPlaylistTracksResponse{Items: items}, nil)
+}
+
+func expectSnapshot(mocks mockServices, spotifyPlaylistID string, trackURIs ...string) {
+	expectPlaylistTracks(mocks, spotifyPlaylistID, trackURIs...)
+	mocks.snapshotService.EXPECT().
+		CreateSnapshot(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, snapshot *models.PlaylistSnapshot) (*models.PlaylistSnapshot, error) {
+			if snapshot.SpotifyPlaylistID != spotifyPlaylistID {
+				return nil, errors.New("unexpected snapshot for " + snapshot.SpotifyPlaylistID)
+			}
+			return snapshot, nil
+		})
+}
+
+// expectMembership expects the tracks written to a recreated spotify playlist to be recorded
+func expectMembership(mocks mockServices, spotifyPlaylistID string, trackURIs ...string) {
+	mocks.snapshotService.EXPECT().
+		RecordMembership(gomock.Any(), membershipMatcher{spotifyPlaylistID: spotifyPlaylistID, trackURIs: trackURIs}).
+		DoAndReturn(func(ctx context.Context, membership *models.PlaylistMembership) (*models.PlaylistMembership, error) {
+			return membership, nil
+		})
+}
+
+type membershipMatcher struct {
+	spotifyPlaylistID string
+	trackURIs         []string
+}
+
+func (m membershipMatcher) Matches(x any) bool {
+	membership, ok := x.(*models.PlaylistMembership)
+	return ok && membership.SpotifyPlaylistID == m.spotifyPlaylistID && slices.Equal(membership.TrackURIs, m.trackURIs)
+}
+
+func (m membershipMatcher) String() string {
+	return fmt.Sprintf("membership of %s with tracks %v", m.spotifyPlaylistID, m.trackURIs)
+}
+
 func createTestLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
 }
+
+func TestDefaultSyncOrchestrator_GetRoutingReport(t *testing.T) {
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123", BasePlaylistID: "base456"}
+	report := &models.RoutingReport{ID: "report123", UserID: "user123", BasePlaylistID: "base456", SyncEventID: "sync123"}
+
+	tests := []struct {
+		name          string
+		userID        string
+		syncEvent     *models.SyncEvent
+		syncEventErr  error
+		report        *models.RoutingReport
+		reportErr     error
+		expectedError error
+	}{
+		{name: "success", userID: "user123", syncEvent: syncEvent, report: report},
+		{name: "sync event not found", userID: "user123", syncEventErr: repositories.ErrSyncEventNotFound, expectedError: ErrSyncEventNotFound},
+		{name: "sync event of another user", userID: "other_user", syncEvent: syncEvent, expectedError: ErrSyncEventNotFound},
+		{name: "report replaced by a newer sync", userID: "user123", syncEvent: syncEvent, reportErr: repositories.ErrRoutingReportNotFound, expectedError: ErrRoutingReportNotFound},
+		{name: "report lookup fails", userID: "user123", syncEvent: syncEvent, reportErr: repositories.ErrDatabaseOperation, expectedError: repositories.ErrDatabaseOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+
+			mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(tt.syncEvent, tt.syncEventErr)
+			if tt.syncEvent != nil && tt.syncEvent.UserID == tt.userID {
+				mocks.snapshotService.EXPECT().GetRoutingReport(gomock.Any(), "sync123", tt.userID).Return(tt.report, tt.reportErr)
+			}
+
+			result, err := orchestrator.GetRoutingReport(context.Background(), tt.userID, "sync123")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(err, tt.expectedError)
+				assert.Nil(result)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(report, result)
+		})
+	}
+}
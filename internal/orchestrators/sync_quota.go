@@ -0,0 +1,61 @@
+package orchestrators
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// syncQuotaWaiter records on the sync event when a background sync is queued until the Spotify
+// request budget refills, so users see it waiting for quota instead of stuck or failed
+type syncQuotaWaiter struct {
+	orchestrator *DefaultSyncOrchestrator
+	syncEvent    *models.SyncEvent
+
+	mu          sync.Mutex
+	resumePhase models.SyncPhase
+}
+
+func (w *syncQuotaWaiter) WaitingForQuota(ctx context.Context, wait time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.syncEvent.Phase != models.SyncPhaseWaitingForQuota {
+		w.resumePhase = w.syncEvent.Phase
+	}
+	w.syncEvent.Phase = models.SyncPhaseWaitingForQuota
+	w.heartbeat(ctx)
+
+	w.orchestrator.logger.InfoContext(ctx, "sync waiting for spotify quota",
+		"sync_event_id", w.syncEvent.ID,
+		"wait", wait,
+	)
+}
+
+func (w *syncQuotaWaiter) ResumedAfterQuota(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.syncEvent.Phase != models.SyncPhaseWaitingForQuota {
+		return
+	}
+	w.syncEvent.Phase = w.resumePhase
+	w.heartbeat(ctx)
+
+	w.orchestrator.logger.InfoContext(ctx, "sync resumed after spotify quota wait", "sync_event_id", w.syncEvent.ID)
+}
+
+func (w *syncQuotaWaiter) heartbeat(ctx context.Context) {
+	now := time.Now()
+	w.syncEvent.HeartbeatAt = &now
+
+	if _, err := w.orchestrator.syncEventService.UpdateSyncEvent(ctx, w.syncEvent.ID, w.syncEvent); err != nil {
+		w.orchestrator.logger.WarnContext(ctx, "failed to record sync quota wait",
+			"sync_event_id", w.syncEvent.ID,
+			"error", err.Error(),
+		)
+	}
+	w.orchestrator.publishSyncProgress(w.syncEvent)
+}
@@ -0,0 +1,67 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncQuotaWaiter_RecordsWaitAndResume(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSyncEventService := servicemocks.NewMockSyncEventServicer(ctrl)
+	orchestrator := &DefaultSyncOrchestrator{syncEventService: mockSyncEventService, logger: createTestLogger()}
+
+	syncEvent := &models.SyncEvent{ID: "sync123", Status: models.SyncStatusInProgress, Phase: models.SyncPhaseFetchingTracks}
+	waiter := &syncQuotaWaiter{orchestrator: orchestrator, syncEvent: syncEvent}
+	ctx := context.Background()
+
+	var phases []models.SyncPhase
+	mockSyncEventService.EXPECT().UpdateSyncEvent(ctx, "sync123", syncEvent).
+		DoAndReturn(func(_ context.Context, _ string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+			assert.NotNil(syncEvent.HeartbeatAt)
+			phases = append(phases, syncEvent.Phase)
+			return syncEvent, nil
+		}).
+		Times(3)
+
+	waiter.WaitingForQuota(ctx, time.Second)
+	// Waiting again keeps the phase to resume to
+	waiter.WaitingForQuota(ctx, time.Second)
+	waiter.ResumedAfterQuota(ctx)
+
+	assert.Equal([]models.SyncPhase{
+		models.SyncPhaseWaitingForQuota,
+		models.SyncPhaseWaitingForQuota,
+		models.SyncPhaseFetchingTracks,
+	}, phases)
+
+	// Resuming without waiting changes nothing
+	waiter.ResumedAfterQuota(ctx)
+	assert.Equal(models.SyncPhaseFetchingTracks, syncEvent.Phase)
+}
+
+func TestSyncQuotaWaiter_UpdateFailureDoesNotStopSync(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSyncEventService := servicemocks.NewMockSyncEventServicer(ctrl)
+	orchestrator := &DefaultSyncOrchestrator{syncEventService: mockSyncEventService, logger: createTestLogger()}
+
+	syncEvent := &models.SyncEvent{ID: "sync123", Phase: models.SyncPhaseUpdatingPlaylists}
+	waiter := &syncQuotaWaiter{orchestrator: orchestrator, syncEvent: syncEvent}
+
+	mockSyncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), "sync123", syncEvent).Return(nil, errors.New("db error"))
+
+	waiter.WaitingForQuota(context.Background(), time.Second)
+	assert.Equal(models.SyncPhaseWaitingForQuota, syncEvent.Phase)
+}
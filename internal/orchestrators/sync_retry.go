@@ -0,0 +1,78 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+// STUCK_SYNC_THRESHOLD is how long an in progress sync can go without updates before it is
+// considered stuck, syncs waiting for quota keep updating their heartbeat meanwhile
+const STUCK_SYNC_THRESHOLD = 30 * time.Minute
+
+// RetrySync runs again the base playlist sync of a failed or stuck sync event, on behalf of the user
+// that owns it. Stuck syncs are marked failed first, so they no longer block new syncs of the base
+// playlist. The retry is recorded as a new sync event
+func (s *DefaultSyncOrchestrator) RetrySync(ctx context.Context, syncEventID string) (*models.SyncEvent, error) {
+	s.logger.InfoContext(ctx, "retrying sync", "sync_event_id", syncEventID)
+
+	syncEvent, err := s.syncEventService.GetSyncEvent(ctx, syncEventID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSyncEventNotFound, syncEventID)
+	}
+
+	switch syncEvent.Status {
+	case models.SyncStatusFailed:
+	case models.SyncStatusInProgress:
+		if !isSyncStuck(syncEvent, time.Now()) {
+			return nil, fmt.Errorf("%w for base playlist %s", ErrSyncInProgress, syncEvent.BasePlaylistID)
+		}
+		if err := s.failStuckSync(ctx, syncEvent); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s is %s", ErrSyncNotRetryable, syncEventID, syncEvent.Status)
+	}
+
+	return s.SyncBasePlaylist(ctx, syncEvent.UserID, syncEvent.BasePlaylistID)
+}
+
+// failStuckSync marks a stuck sync failed, unless it finished since it was read
+func (s *DefaultSyncOrchestrator) failStuckSync(ctx context.Context, syncEvent *models.SyncEvent) error {
+	err := s.syncEventService.TransitionSyncEventStatus(ctx, syncEvent.ID, models.SyncStatusInProgress, models.SyncStatusFailed)
+	if errors.Is(err, repositories.ErrSyncEventStatusChanged) {
+		return fmt.Errorf("%w: %s finished while retrying", ErrSyncNotRetryable, syncEvent.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to mark stuck sync event as failed: %w", err)
+	}
+
+	now := time.Now()
+	errorMessage := "sync stuck, retried by an admin"
+	syncEvent.Status = models.SyncStatusFailed
+	syncEvent.CompletedAt = &now
+	syncEvent.ErrorMessage = &errorMessage
+	if _, err := s.syncEventService.UpdateSyncEvent(ctx, syncEvent.ID, syncEvent); err != nil {
+		// The status already changed, the missing details don't keep the retry from running
+		s.logger.ErrorContext(ctx, "failed to record stuck sync details", "sync_event_id", syncEvent.ID, "error", err.Error())
+	}
+
+	return nil
+}
+
+// isSyncStuck reports whether the sync stopped updating its sync event for STUCK_SYNC_THRESHOLD
+func isSyncStuck(syncEvent *models.SyncEvent, now time.Time) bool {
+	lastActivity := syncEvent.StartedAt
+	if syncEvent.Updated.After(lastActivity) {
+		lastActivity = syncEvent.Updated
+	}
+	if syncEvent.HeartbeatAt != nil && syncEvent.HeartbeatAt.After(lastActivity) {
+		lastActivity = *syncEvent.HeartbeatAt
+	}
+
+	return now.Sub(lastActivity) > STUCK_SYNC_THRESHOLD
+}
@@ -0,0 +1,181 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSyncOrchestrator_RetrySync_Success(t *testing.T) {
+	stuckSince := time.Now().Add(-2 * STUCK_SYNC_THRESHOLD)
+
+	tests := []struct {
+		name       string
+		syncEvent  *models.SyncEvent
+		setupMocks func(mocks mockServices)
+	}{
+		{
+			name: "failed sync",
+			syncEvent: &models.SyncEvent{
+				ID:             "sync123",
+				UserID:         "user123",
+				BasePlaylistID: "base456",
+				Status:         models.SyncStatusFailed,
+			},
+			setupMocks: func(mocks mockServices) {},
+		},
+		{
+			name: "stuck sync",
+			syncEvent: &models.SyncEvent{
+				ID:             "sync123",
+				UserID:         "user123",
+				BasePlaylistID: "base456",
+				Status:         models.SyncStatusInProgress,
+				StartedAt:      stuckSince,
+				Updated:        stuckSince,
+			},
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().
+					TransitionSyncEventStatus(gomock.Any(), "sync123", models.SyncStatusInProgress, models.SyncStatusFailed).
+					Return(nil)
+				mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), "sync123", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+						require.Equal(t, models.SyncStatusFailed, syncEvent.Status)
+						require.NotNil(t, syncEvent.ErrorMessage)
+						return syncEvent, nil
+					})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			retrySyncEvent := testfixtures.NewSyncEvent().WithID("sync_retry").WithUserID("user123").WithBasePlaylistID("base456").Build()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+
+			mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(tt.syncEvent, nil)
+			tt.setupMocks(mocks)
+
+			// The retry runs on behalf of the owner of the sync event
+			mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), "user123", "base456").Return(false, nil)
+			mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(retrySyncEvent, nil)
+			mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base456", "user123").Return(testfixtures.NewBasePlaylist().WithID("base456").Build(), nil)
+			mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base456", "user123").Return([]*models.ChildPlaylist{}, nil)
+			mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), "base456", "user123").Return(nil, nil)
+			mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), "sync_retry", gomock.Any()).Return(retrySyncEvent, nil)
+
+			result, err := orchestrator.RetrySync(context.Background(), "sync123")
+
+			assert.NoError(err)
+			assert.Equal("sync_retry", result.ID)
+			assert.Equal(models.SyncStatusCompleted, result.Status)
+		})
+	}
+}
+
+func TestDefaultSyncOrchestrator_RetrySync_Errors(t *testing.T) {
+	stuckSince := time.Now().Add(-2 * STUCK_SYNC_THRESHOLD)
+
+	tests := []struct {
+		name          string
+		setupMocks    func(mocks mockServices)
+		expectedError error
+	}{
+		{
+			name: "sync event not found",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").Return(nil, errors.New("not found"))
+			},
+			expectedError: ErrSyncEventNotFound,
+		},
+		{
+			name: "completed sync",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "user123", Status: models.SyncStatusCompleted}, nil)
+			},
+			expectedError: ErrSyncNotRetryable,
+		},
+		{
+			name: "sync awaiting confirmation",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "user123", Status: models.SyncStatusNeedsConfirmation}, nil)
+			},
+			expectedError: ErrSyncNotRetryable,
+		},
+		{
+			name: "sync still running",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "user123", Status: models.SyncStatusInProgress, StartedAt: time.Now()}, nil)
+			},
+			expectedError: ErrSyncInProgress,
+		},
+		{
+			name: "stuck sync finished while retrying",
+			setupMocks: func(mocks mockServices) {
+				mocks.syncEventService.EXPECT().GetSyncEvent(gomock.Any(), "sync123").
+					Return(&models.SyncEvent{ID: "sync123", UserID: "user123", Status: models.SyncStatusInProgress, StartedAt: stuckSince}, nil)
+				mocks.syncEventService.EXPECT().
+					TransitionSyncEventStatus(gomock.Any(), "sync123", models.SyncStatusInProgress, models.SyncStatusFailed).
+					Return(fmt.Errorf("failed to transition sync event status: %w", repositories.ErrSyncEventStatusChanged))
+			},
+			expectedError: ErrSyncNotRetryable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := createMockServices(ctrl)
+			orchestrator := createTestOrchestrator(mocks)
+			tt.setupMocks(mocks)
+
+			result, err := orchestrator.RetrySync(context.Background(), "sync123")
+
+			assert.Nil(result)
+			assert.ErrorIs(err, tt.expectedError)
+		})
+	}
+}
+
+func TestIsSyncStuck(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	longAgo := now.Add(-2 * STUCK_SYNC_THRESHOLD)
+	recently := now.Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		syncEvent *models.SyncEvent
+		expected  bool
+	}{
+		{name: "no activity since it started", syncEvent: &models.SyncEvent{StartedAt: longAgo, Updated: longAgo}, expected: true},
+		{name: "recently started", syncEvent: &models.SyncEvent{StartedAt: recently}, expected: false},
+		{name: "recently updated", syncEvent: &models.SyncEvent{StartedAt: longAgo, Updated: recently}, expected: false},
+		{name: "waiting for quota", syncEvent: &models.SyncEvent{StartedAt: longAgo, Updated: longAgo, HeartbeatAt: &recently}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isSyncStuck(tt.syncEvent, now))
+		})
+	}
+}
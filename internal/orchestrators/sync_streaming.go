@@ -0,0 +1,310 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/profiling"
+	"golang.org/x/sync/errgroup"
+)
+
+// STREAMING_PAGE_BUFFER is how many pages of tracks, and of routed tracks for each child playlist,
+// can wait between the steps of a streamed sync. It bounds the track data held in memory
+const STREAMING_PAGE_BUFFER = 2
+
+// streamable reports whether the child playlists can be synced from a stream of tracks. Caps pick
+// among every track, merge children need their sources aggregated first and pinned tracks go first
+func streamable(childPlaylists []*models.ChildPlaylist) bool {
+	for _, childPlaylist := range childPlaylists {
+		if !childPlaylist.IsActive {
+			continue
+		}
+		if childPlaylist.MaxTracks > 0 || childPlaylist.IsMerge() || len(childPlaylist.PinnedTracks) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// shouldStream reports whether the base playlist is large enough to be synced as a stream, when
+// streaming is enabled and its child playlists allow it
+func (s *DefaultSyncOrchestrator) shouldStream(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	basePlaylist *models.BasePlaylist,
+	childPlaylists []*models.ChildPlaylist,
+) bool {
+	if s.streamingMinTracks <= 0 || !streamable(childPlaylists) || !s.featureEnabled(ctx, models.FeatureStreamingSync, syncEvent.UserID) {
+		return false
+	}
+
+	playlist, err := s.musicProvider.GetPlaylist(ctx, basePlaylist.SpotifyPlaylistID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to look up base playlist size, syncing in one batch",
+			"sync_event_id", syncEvent.ID,
+			"error", err.Error(),
+		)
+		return false
+	}
+	syncEvent.TotalAPIRequests++
+
+	return playlist.Tracks != nil && playlist.Tracks.Total >= s.streamingMinTracks
+}
+
+// executeStreamingSyncFlow syncs a large base playlist page by page: the aggregator sends each page
+// to the router once enriched, and the router sends the tracks each child playlist gets to a writer
+// of its own, which writes them in batches. Only a few pages of track data are held at once and the
+// first tracks are written before the last ones are fetched.
+//
+// Tracks are written as they are routed, so streamed syncs can't be held back by anomaly detection
+// and record no routing report; the snapshots taken before the first write of each child still
+// let them be rolled back
+func (s *DefaultSyncOrchestrator) executeStreamingSyncFlow(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	basePlaylist *models.BasePlaylist,
+	childPlaylists []*models.ChildPlaylist,
+) error {
+	s.logger.InfoContext(ctx, "streaming tracks to child playlists", "sync_event_id", syncEvent.ID)
+	s.setSyncPhase(syncEvent, models.SyncPhaseUpdatingPlaylists)
+
+	streamCtx, endStream := profiling.StartSpan(ctx, "streaming")
+	pages := make(chan *models.PlaylistTracksInfo, STREAMING_PAGE_BUFFER)
+	routed := make(chan models.RoutedTracks, STREAMING_PAGE_BUFFER)
+
+	// A failing step cancels the others; the steps only close their output once done
+	group, groupCtx := errgroup.WithContext(streamCtx)
+	group.Go(func() error {
+		return s.trackAggregator.StreamPlaylistData(groupCtx, syncEvent.UserID, syncEvent.BasePlaylistID, pages)
+	})
+
+	var summary *models.StreamRoutingSummary
+	group.Go(func() error {
+		var err error
+		summary, err = s.trackRouter.RouteTrackPages(groupCtx, syncEvent.UserID, syncEvent.BasePlaylistID, pages, childPlaylists, basePlaylist.DedupeStrategy, routed)
+		return err
+	})
+
+	results := s.writeRoutedTracks(groupCtx, syncEvent, basePlaylist, childPlaylists, routed)
+	err := group.Wait()
+	endStream()
+
+	failedChildren := 0
+	for _, result := range results {
+		syncEvent.TotalAPIRequests += result.APIRequests
+		syncEvent.ChildSyncResults = append(syncEvent.ChildSyncResults, result)
+		if result.Status == models.SyncStatusFailed {
+			failedChildren++
+		}
+	}
+
+	if err != nil {
+		err = fmt.Errorf("failed to stream tracks: %w", err)
+	} else {
+		syncEvent.TracksProcessed = summary.TracksProcessed
+		syncEvent.TracksUnmatched = summary.TracksUnmatched
+		syncEvent.TotalAPIRequests += summary.APICallCount
+
+		s.logger.InfoContext(ctx, "streamed tracks to child playlists",
+			"sync_event_id", syncEvent.ID,
+			"tracks_processed", syncEvent.TracksProcessed,
+			"tracks_unmatched", syncEvent.TracksUnmatched,
+		)
+
+		if failedChildren > 0 {
+			err = fmt.Errorf("failed to update spotify playlists: failed to sync %d of %d child playlists", failedChildren, len(results))
+		}
+	}
+
+	// Merge children of other base playlists are attempted even when a child of this one failed
+	if mergeErr := s.syncSourcedMergeChildPlaylists(ctx, syncEvent); mergeErr != nil {
+		err = errors.Join(err, mergeErr)
+	}
+
+	return err
+}
+
+// writeRoutedTracks hands the tracks routed to each child playlist to a writer of its own, started
+// with the first tracks the child gets. As in batch syncs, children getting no tracks are left as
+// they are. Returns the results of the children written, in child order
+func (s *DefaultSyncOrchestrator) writeRoutedTracks(
+	ctx context.Context,
+	syncEvent *models.SyncEvent,
+	basePlaylist *models.BasePlaylist,
+	childPlaylists []*models.ChildPlaylist,
+	routed <-chan models.RoutedTracks,
+) []models.ChildSyncResult {
+	childIndexes := make(map[string]int, len(childPlaylists))
+	for i, childPlaylist := range childPlaylists {
+		childIndexes[childPlaylist.SpotifyPlaylistID] = i
+	}
+
+	batches := make([]chan []string, len(childPlaylists))
+	writers := make([]*childStreamWriter, len(childPlaylists))
+	var wg sync.WaitGroup
+
+dispatch:
+	for {
+		var routedTracks models.RoutedTracks
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case received, ok := <-routed:
+			if !ok {
+				break dispatch
+			}
+			routedTracks = received
+		}
+
+		i, found := childIndexes[routedTracks.SpotifyPlaylistID]
+		if !found {
+			continue
+		}
+
+		if writers[i] == nil {
+			batches[i] = make(chan []string, STREAMING_PAGE_BUFFER)
+			writers[i] = &childStreamWriter{
+				orchestrator:  s,
+				syncEvent:     syncEvent,
+				basePlaylist:  basePlaylist,
+				childPlaylist: childPlaylists[i],
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				writers[i].run(ctx, batches[i])
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case batches[i] <- routedTracks.TrackURIs:
+		}
+	}
+
+	for _, batch := range batches {
+		if batch != nil {
+			close(batch)
+		}
+	}
+	wg.Wait()
+
+	results := make([]models.ChildSyncResult, 0, len(childPlaylists))
+	for _, writer := range writers {
+		if writer != nil {
+			results = append(results, writer.result)
+		}
+	}
+
+	return results
+}
+
+// childStreamWriter writes the tracks streamed to a child playlist in batches of MAX_PLAYLIST_TRACKS.
+// The playlist is snapshotted, then recreated or replaced in place, with the first batch
+type childStreamWriter struct {
+	orchestrator  *DefaultSyncOrchestrator
+	syncEvent     *models.SyncEvent
+	basePlaylist  *models.BasePlaylist
+	childPlaylist *models.ChildPlaylist
+
+	result     models.ChildSyncResult
+	playlistID string
+	written    []string
+	started    bool
+}
+
+func (w *childStreamWriter) run(ctx context.Context, batches <-chan []string) {
+	s := w.orchestrator
+	w.result = models.ChildSyncResult{
+		ChildPlaylistID:   w.childPlaylist.ID,
+		SpotifyPlaylistID: w.childPlaylist.SpotifyPlaylistID,
+		Status:            models.SyncStatusCompleted,
+	}
+	w.playlistID = w.childPlaylist.SpotifyPlaylistID
+
+	// Each child is written through its own spotify account, which may differ from the base one
+	childCtx, endChild := profiling.StartSpan(ctx, "child:"+w.childPlaylist.Name)
+	defer endChild()
+	accountCtx, err := s.withPlaylistAccount(childCtx, w.syncEvent.UserID, w.childPlaylist.Provider, w.childPlaylist.SpotifyIntegrationID)
+
+	// Batches keep being received after a failure, so the router is never blocked by this child
+	pending := make([]string, 0, MAX_PLAYLIST_TRACKS)
+	for trackURIs := range batches {
+		if err != nil {
+			continue
+		}
+
+		pending = append(pending, trackURIs...)
+		for len(pending) >= MAX_PLAYLIST_TRACKS && err == nil {
+			err = w.write(accountCtx, pending[:MAX_PLAYLIST_TRACKS])
+			pending = append(pending[:0], pending[MAX_PLAYLIST_TRACKS:]...)
+		}
+	}
+
+	if err == nil && ctx.Err() != nil {
+		err = fmt.Errorf("sync stopped before every track was written: %w", ctx.Err())
+	}
+	if err == nil && len(pending) > 0 {
+		err = w.write(accountCtx, pending)
+	}
+
+	if err != nil {
+		errorMessage := err.Error()
+		w.result.Status = models.SyncStatusFailed
+		w.result.ErrorMessage = &errorMessage
+
+		s.logger.ErrorContext(ctx, "failed to sync child playlist",
+			"sync_event_id", w.syncEvent.ID,
+			"child_playlist_id", w.childPlaylist.ID,
+			"error", errorMessage,
+		)
+		return
+	}
+
+	w.result.TracksAdded = len(w.written)
+	s.recordMembership(ctx, *w.childPlaylist, w.syncEvent.ID, w.playlistID, w.written)
+}
+
+func (w *childStreamWriter) write(ctx context.Context, trackURIs []string) error {
+	s := w.orchestrator
+
+	if !w.started {
+		w.started = true
+
+		snapshotRequests, err := s.snapshotChildPlaylist(ctx, *w.childPlaylist, w.childPlaylist.SpotifyPlaylistID, w.syncEvent)
+		w.result.APIRequests += snapshotRequests
+		if err != nil {
+			return fmt.Errorf("failed to snapshot playlist %s: %w", w.childPlaylist.SpotifyPlaylistID, err)
+		}
+
+		if w.childPlaylist.SyncStrategy == models.SyncStrategyInPlace {
+			if err := s.musicProvider.ReplacePlaylistTracks(ctx, w.playlistID, trackURIs); err != nil {
+				return fmt.Errorf("failed to replace tracks of playlist %s: %w", w.playlistID, err)
+			}
+			w.result.APIRequests++
+			w.written = append(w.written, trackURIs...)
+			return nil
+		}
+
+		newPlaylistID, recreateRequests, err := s.recreateChildPlaylist(ctx, w.basePlaylist, *w.childPlaylist, w.playlistID, w.syncEvent, &w.result)
+		w.result.APIRequests += recreateRequests
+		if err != nil {
+			return err
+		}
+		w.playlistID = newPlaylistID
+	}
+
+	if err := s.musicProvider.AddTracksToPlaylist(ctx, w.playlistID, trackURIs); err != nil {
+		return fmt.Errorf("failed to add tracks to playlist %s: %w", w.playlistID, err)
+	}
+	w.result.APIRequests++
+	w.written = append(w.written, trackURIs...)
+
+	return nil
+}
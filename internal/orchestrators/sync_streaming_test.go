@@ -0,0 +1,197 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/models"
+	servicemocks "github.com/ngomez18/playlist-router/internal/services/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamable(t *testing.T) {
+	tests := []struct {
+		name           string
+		childPlaylist  *models.ChildPlaylist
+		expectedResult bool
+	}{
+		{name: "filtered child", childPlaylist: testfixtures.NewChildPlaylist().Build(), expectedResult: true},
+		{name: "capped child", childPlaylist: testfixtures.NewChildPlaylist().WithMaxTracks(50, models.SelectionNewest).Build(), expectedResult: false},
+		{name: "inactive capped child", childPlaylist: testfixtures.NewChildPlaylist().WithMaxTracks(50, models.SelectionNewest).Inactive().Build(), expectedResult: true},
+		{name: "merge child", childPlaylist: testfixtures.NewChildPlaylist().WithSourceBasePlaylistIDs("base1", "base2").Build(), expectedResult: false},
+		{name: "child with pinned tracks", childPlaylist: testfixtures.NewChildPlaylist().WithPinnedTracks("spotify:track:1").Build(), expectedResult: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			childPlaylists := []*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithID("other").Build(), tt.childPlaylist}
+			assert.Equal(tt.expectedResult, streamable(childPlaylists))
+		})
+	}
+}
+
+// expectStreamedTracks mocks the aggregator streaming a single page and the router sending routing
+// to the children, closing their channels as they do on success
+func expectStreamedTracks(mocks mockServices, routing []models.RoutedTracks, summary *models.StreamRoutingSummary) {
+	mocks.trackAggregator.EXPECT().
+		StreamPlaylistData(gomock.Any(), "user123", "base456", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID, basePlaylistID string, pages chan<- *models.PlaylistTracksInfo) error {
+			pages <- &models.PlaylistTracksInfo{PlaylistID: basePlaylistID}
+			close(pages)
+			return nil
+		})
+	mocks.trackRouter.EXPECT().
+		RouteTrackPages(gomock.Any(), "user123", "base456", gomock.Any(), gomock.Any(), models.DedupeStrategyAllMatches, gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			userID, basePlaylistID string,
+			pages <-chan *models.PlaylistTracksInfo,
+			childPlaylists []*models.ChildPlaylist,
+			dedupeStrategy models.DedupeStrategy,
+			routed chan<- models.RoutedTracks,
+		) (*models.StreamRoutingSummary, error) {
+			for range pages {
+			}
+			for _, routedTracks := range routing {
+				routed <- routedTracks
+			}
+			close(routed)
+			return summary, nil
+		})
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_Streaming(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify1").WithSyncStrategy(models.SyncStrategyInPlace).Build(),
+		testfixtures.NewChildPlaylist().WithID("child2").WithSpotifyPlaylistID("spotify2").WithSyncStrategy(models.SyncStrategyInPlace).Build(),
+	}
+
+	trackURIs := make([]string, 150)
+	for i := range trackURIs {
+		trackURIs[i] = fmt.Sprintf("spotify:track:%d", i)
+	}
+
+	createdSyncEvent := testfixtures.NewSyncEvent().WithUserID("user123").WithBasePlaylistID("base456").Build()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithStreaming(100)
+
+	mocks.syncEventService.EXPECT().HasActiveSyncForBasePlaylist(gomock.Any(), "user123", "base456").Return(false, nil)
+	mocks.syncEventService.EXPECT().CreateSyncEvent(gomock.Any(), gomock.Any()).Return(createdSyncEvent, nil)
+	mocks.basePlaylistService.EXPECT().GetBasePlaylist(gomock.Any(), "base456", "user123").Return(testfixtures.NewBasePlaylist().WithID("base456").WithSpotifyPlaylistID("spotify456").Build(), nil)
+	mocks.childPlaylistService.EXPECT().GetChildPlaylistsByBasePlaylistID(gomock.Any(), "base456", "user123").Return(childPlaylists, nil)
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), "base456", "user123").Return(nil, nil)
+	mocks.spotifyClient.EXPECT().
+		GetPlaylist(gomock.Any(), "spotify456").
+		Return(&spotifyclient.SpotifyPlaylist{ID: "spotify456", Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 150}}, nil)
+
+	// child1 gets two pages of tracks, child2 gets none and is left as it is
+	expectStreamedTracks(mocks, []models.RoutedTracks{
+		{SpotifyPlaylistID: "spotify1", TrackURIs: trackURIs[:80]},
+		{SpotifyPlaylistID: "spotify1", TrackURIs: trackURIs[80:]},
+	}, &models.StreamRoutingSummary{TracksProcessed: 150, TracksUnmatched: 0, APICallCount: 6})
+
+	expectSnapshot(mocks, "spotify1", "spotify:track:old")
+	mocks.spotifyClient.EXPECT().ReplacePlaylistTracks(gomock.Any(), "spotify1", trackURIs[:100]).Return(nil)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "spotify1", trackURIs[100:]).Return(nil)
+	expectMembership(mocks, "spotify1", trackURIs...)
+
+	var updatedSyncEvent *models.SyncEvent
+	mocks.syncEventService.EXPECT().UpdateSyncEvent(gomock.Any(), createdSyncEvent.ID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+			updatedSyncEvent = syncEvent
+			return syncEvent, nil
+		})
+
+	_, err := orchestrator.SyncBasePlaylist(context.Background(), "user123", "base456")
+
+	assert.NoError(err)
+	assert.Equal(models.SyncStatusCompleted, updatedSyncEvent.Status)
+	assert.Equal(150, updatedSyncEvent.TracksProcessed)
+	assert.Equal(10, updatedSyncEvent.TotalAPIRequests) // size lookup + 6 streamed + snapshot + replace + add tracks
+	assert.Len(updatedSyncEvent.ChildSyncResults, 1)
+	assert.Equal(150, updatedSyncEvent.ChildSyncResults[0].TracksAdded)
+}
+
+func TestDefaultSyncOrchestrator_SyncBasePlaylist_StreamingSmallPlaylist(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithStreaming(100)
+
+	mocks.spotifyClient.EXPECT().
+		GetPlaylist(gomock.Any(), "spotify456").
+		Return(&spotifyclient.SpotifyPlaylist{ID: "spotify456", Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 99}}, nil)
+
+	basePlaylist := testfixtures.NewBasePlaylist().WithSpotifyPlaylistID("spotify456").Build()
+	syncEvent := &models.SyncEvent{ID: "sync123"}
+
+	assert.False(orchestrator.shouldStream(context.Background(), syncEvent, basePlaylist, []*models.ChildPlaylist{testfixtures.NewChildPlaylist().Build()}))
+	assert.Equal(1, syncEvent.TotalAPIRequests)
+}
+
+func TestDefaultSyncOrchestrator_ShouldStream_FlagOff(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocks := createMockServices(ctrl)
+	featureFlags := servicemocks.NewMockFeatureFlagServicer(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithStreaming(100).WithFeatureFlags(featureFlags)
+
+	featureFlags.EXPECT().IsEnabled(gomock.Any(), models.FeatureStreamingSync, "user123", true).Return(false)
+
+	basePlaylist := testfixtures.NewBasePlaylist().WithSpotifyPlaylistID("spotify456").Build()
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123"}
+
+	// The size isn't looked up for users the flag is off for
+	assert.False(orchestrator.shouldStream(context.Background(), syncEvent, basePlaylist, []*models.ChildPlaylist{testfixtures.NewChildPlaylist().Build()}))
+	assert.Equal(0, syncEvent.TotalAPIRequests)
+}
+
+func TestDefaultSyncOrchestrator_ExecuteStreamingSyncFlow_RoutingError(t *testing.T) {
+	assert := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	childPlaylists := []*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify1").Build()}
+
+	mocks := createMockServices(ctrl)
+	orchestrator := createTestOrchestrator(mocks).WithStreaming(100)
+
+	// The aggregator is blocked on a full channel until the routing error cancels it
+	mocks.trackAggregator.EXPECT().
+		StreamPlaylistData(gomock.Any(), "user123", "base456", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID, basePlaylistID string, pages chan<- *models.PlaylistTracksInfo) error {
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case pages <- &models.PlaylistTracksInfo{}:
+				}
+			}
+		})
+	mocks.trackRouter.EXPECT().
+		RouteTrackPages(gomock.Any(), "user123", "base456", gomock.Any(), childPlaylists, gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("failed to get blocklist"))
+	mocks.childPlaylistService.EXPECT().GetMergeChildPlaylistsBySourceBasePlaylistID(gomock.Any(), "base456", "user123").Return(nil, nil)
+
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123", BasePlaylistID: "base456"}
+	err := orchestrator.executeStreamingSyncFlow(context.Background(), syncEvent, testfixtures.NewBasePlaylist().Build(), childPlaylists)
+
+	assert.ErrorContains(err, "failed to stream tracks: failed to get blocklist")
+	assert.Empty(syncEvent.ChildSyncResults)
+}
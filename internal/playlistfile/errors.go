@@ -0,0 +1,9 @@
+package playlistfile
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported playlist file format")
+	ErrInvalidFile       = errors.New("invalid playlist file")
+	ErrTooManyTracks     = errors.New("too many tracks in playlist file")
+)
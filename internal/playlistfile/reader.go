@@ -0,0 +1,145 @@
+package playlistfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// MAX_IMPORTED_TRACKS bounds the rows of an imported file, since each can take a search to match
+const MAX_IMPORTED_TRACKS = 1000
+
+// jsonTrackRow is a row of an imported JSON file. Files exported as JSON name the title and artists
+// of their tracks name and artists, so they can be imported back
+type jsonTrackRow struct {
+	URI     string   `json:"uri"`
+	ISRC    string   `json:"isrc"`
+	Title   string   `json:"title"`
+	Name    string   `json:"name"`
+	Artist  string   `json:"artist"`
+	Artists []string `json:"artists"`
+}
+
+// ReadTracks reads the rows of a CSV or JSON file of tracks. CSV files start with a header naming
+// their columns, JSON files are an array of objects; both use the uri, isrc, title and artist
+// fields, reading name and artists as exported when title and artist are missing. Every row needs a
+// uri, an isrc, or a title and artist
+func ReadTracks(r io.Reader, format models.ExportFormat) ([]models.ImportedTrack, error) {
+	switch format {
+	case models.ExportFormatCSV:
+		return readCSV(r)
+	case models.ExportFormatJSON:
+		return readJSON(r)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+func readCSV(r io.Reader) ([]models.ImportedTrack, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheets may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, exists := columns[name]; !exists {
+			columns[name] = i
+		}
+	}
+	field := func(record []string, names ...string) string {
+		for _, name := range names {
+			if i, ok := columns[name]; ok && strings.TrimSpace(record[i]) != "" {
+				return record[i]
+			}
+		}
+		return ""
+	}
+
+	var tracks []models.ImportedTrack
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		}
+		if len(tracks) == MAX_IMPORTED_TRACKS {
+			return nil, fmt.Errorf("%w: at most %d allowed", ErrTooManyTracks, MAX_IMPORTED_TRACKS)
+		}
+
+		track, err := importedTrack(row,
+			field(record, "uri"),
+			field(record, "isrc"),
+			field(record, "title", "name"),
+			field(record, "artist", "artists"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+func readJSON(r io.Reader) ([]models.ImportedTrack, error) {
+	var rows []jsonTrackRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+	if len(rows) > MAX_IMPORTED_TRACKS {
+		return nil, fmt.Errorf("%w: at most %d allowed", ErrTooManyTracks, MAX_IMPORTED_TRACKS)
+	}
+
+	tracks := make([]models.ImportedTrack, 0, len(rows))
+	for i, row := range rows {
+		title, artist := row.Title, row.Artist
+		if title == "" {
+			title = row.Name
+		}
+		if artist == "" && len(row.Artists) > 0 {
+			artist = row.Artists[0]
+		}
+
+		track, err := importedTrack(i+1, row.URI, row.ISRC, title, artist)
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// importedTrack checks the row can be matched. Only the first of the artists joined as exported is
+// searched for
+func importedTrack(row int, uri, isrc, title, artist string) (models.ImportedTrack, error) {
+	artist, _, _ = strings.Cut(artist, ARTIST_SEPARATOR)
+	track := models.ImportedTrack{
+		URI:    strings.TrimSpace(uri),
+		ISRC:   strings.ToUpper(strings.TrimSpace(isrc)),
+		Title:  strings.TrimSpace(title),
+		Artist: strings.TrimSpace(artist),
+	}
+
+	if track.URI == "" && track.ISRC == "" && (track.Title == "" || track.Artist == "") {
+		return models.ImportedTrack{}, fmt.Errorf("%w: row %d needs a uri, an isrc or a title and artist", ErrInvalidFile, row)
+	}
+
+	return track, nil
+}
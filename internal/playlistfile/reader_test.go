@@ -0,0 +1,100 @@
+package playlistfile
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTracks_CSV(t *testing.T) {
+	assert := require.New(t)
+
+	file := "\ufeffURI,ISRC,Title,Artist\n" +
+		"spotify:track:track1,,,\n" +
+		",usum71703861,,\n" +
+		",, Mr. Brightside , The Killers\n"
+
+	tracks, err := ReadTracks(strings.NewReader(file), models.ExportFormatCSV)
+	assert.NoError(err)
+	assert.Equal([]models.ImportedTrack{
+		{URI: "spotify:track:track1"},
+		{ISRC: "USUM71703861"},
+		{Title: "Mr. Brightside", Artist: "The Killers"},
+	}, tracks)
+}
+
+func TestReadTracks_Exported(t *testing.T) {
+	for _, format := range []models.ExportFormat{models.ExportFormatCSV, models.ExportFormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			assert := require.New(t)
+
+			file := writeFile(t, format, testTracks())
+			tracks, err := ReadTracks(strings.NewReader(file), format)
+			assert.NoError(err)
+			assert.Equal([]models.ImportedTrack{
+				{URI: "spotify:track:track1", Title: "Song, Part 1", Artist: "Artist"},
+				{URI: "spotify:track:track2", Title: "Other\nSong"},
+			}, tracks)
+		})
+	}
+}
+
+func TestReadTracks_JSON(t *testing.T) {
+	assert := require.New(t)
+
+	file := `[{"isrc":"USUM71703861"},{"title":"Mr. Brightside","artist":"The Killers"}]`
+
+	tracks, err := ReadTracks(strings.NewReader(file), models.ExportFormatJSON)
+	assert.NoError(err)
+	assert.Equal([]models.ImportedTrack{
+		{ISRC: "USUM71703861"},
+		{Title: "Mr. Brightside", Artist: "The Killers"},
+	}, tracks)
+}
+
+func TestReadTracks_Errors(t *testing.T) {
+	tooMany := "uri\n" + strings.Repeat("spotify:track:track1\n", MAX_IMPORTED_TRACKS+1)
+
+	tests := []struct {
+		name        string
+		format      models.ExportFormat
+		file        string
+		expectedErr error
+		expectedMsg string
+	}{
+		{name: "empty csv", format: models.ExportFormatCSV, file: "", expectedErr: ErrInvalidFile, expectedMsg: "missing header"},
+		{name: "malformed csv", format: models.ExportFormatCSV, file: "uri,title\nspotify:track:track1\n", expectedErr: ErrInvalidFile},
+		{name: "unmatchable csv row", format: models.ExportFormatCSV, file: "uri,title\n,Only A Title\n", expectedErr: ErrInvalidFile, expectedMsg: "row 1"},
+		{name: "too many csv rows", format: models.ExportFormatCSV, file: tooMany, expectedErr: ErrTooManyTracks},
+		{name: "malformed json", format: models.ExportFormatJSON, file: `{"uri":"spotify:track:track1"}`, expectedErr: ErrInvalidFile},
+		{name: "unmatchable json row", format: models.ExportFormatJSON, file: `[{"uri":"spotify:track:track1"},{"artist":"The Killers"}]`, expectedErr: ErrInvalidFile, expectedMsg: "row 2"},
+		{name: "m3u", format: models.ExportFormatM3U, file: "#EXTM3U\n", expectedErr: ErrUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			tracks, err := ReadTracks(strings.NewReader(tt.file), tt.format)
+			assert.ErrorIs(err, tt.expectedErr)
+			assert.ErrorContains(err, tt.expectedMsg)
+			assert.Nil(tracks)
+		})
+	}
+}
+
+func TestReadTracks_MaxTracks(t *testing.T) {
+	assert := require.New(t)
+
+	rows := make([]string, MAX_IMPORTED_TRACKS)
+	for i := range rows {
+		rows[i] = fmt.Sprintf(`{"uri":"spotify:track:track%d"}`, i)
+	}
+
+	tracks, err := ReadTracks(strings.NewReader("["+strings.Join(rows, ",")+"]"), models.ExportFormatJSON)
+	assert.NoError(err)
+	assert.Len(tracks, MAX_IMPORTED_TRACKS)
+}
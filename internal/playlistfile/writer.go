@@ -0,0 +1,184 @@
+package playlistfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// ARTIST_SEPARATOR joins the artists of a track in the single column or title they are written to
+const ARTIST_SEPARATOR = "; "
+
+// CSV_HEADER names the columns of exported CSV files, in order
+var CSV_HEADER = []string{"uri", "name", "artists", "album", "release_date", "duration_ms", "explicit", "popularity", "added_at"}
+
+// Writer writes the tracks of a playlist to a file a page at a time, so whole playlists never have
+// to be held in memory. Close ends the file, it doesn't close the underlying writer
+type Writer interface {
+	WriteTracks(tracks []models.ExportedTrack) error
+	Close() error
+}
+
+// NewWriter starts a file of the format on w, writing its header right away. The playlist name
+// titles M3U files and is ignored by the other formats
+func NewWriter(w io.Writer, format models.ExportFormat, playlistName string) (Writer, error) {
+	switch format {
+	case models.ExportFormatM3U:
+		return newM3UWriter(w, playlistName)
+	case models.ExportFormatCSV:
+		return newCSVWriter(w)
+	case models.ExportFormatJSON:
+		return newJSONWriter(w)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// ContentType is the media type files of the format are served with
+func ContentType(format models.ExportFormat) string {
+	switch format {
+	case models.ExportFormatM3U:
+		return "audio/x-mpegurl"
+	case models.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+type m3uWriter struct {
+	w io.Writer
+}
+
+func newM3UWriter(w io.Writer, playlistName string) (*m3uWriter, error) {
+	header := "#EXTM3U\n"
+	if playlistName != "" {
+		header += "#PLAYLIST:" + singleLine(playlistName) + "\n"
+	}
+	if _, err := io.WriteString(w, header); err != nil {
+		return nil, err
+	}
+
+	return &m3uWriter{w: w}, nil
+}
+
+// WriteTracks writes an #EXTINF entry per track, its duration in seconds and "artists - name" as
+// the title, followed by the track URI
+func (m *m3uWriter) WriteTracks(tracks []models.ExportedTrack) error {
+	var b strings.Builder
+	for _, track := range tracks {
+		title := singleLine(track.Name)
+		if len(track.Artists) > 0 {
+			title = singleLine(strings.Join(track.Artists, ARTIST_SEPARATOR)) + " - " + title
+		}
+		fmt.Fprintf(&b, "#EXTINF:%d,%s\n%s\n", track.DurationMs/1000, title, track.URI)
+	}
+
+	_, err := io.WriteString(m.w, b.String())
+	return err
+}
+
+func (m *m3uWriter) Close() error {
+	return nil
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w)}
+	if err := c.w.Write(CSV_HEADER); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *csvWriter) WriteTracks(tracks []models.ExportedTrack) error {
+	for _, track := range tracks {
+		addedAt := ""
+		if track.AddedAt != nil {
+			addedAt = track.AddedAt.UTC().Format(time.RFC3339)
+		}
+
+		err := c.w.Write([]string{
+			track.URI,
+			track.Name,
+			strings.Join(track.Artists, ARTIST_SEPARATOR),
+			track.Album,
+			track.ReleaseDate,
+			strconv.Itoa(track.DurationMs),
+			strconv.FormatBool(track.Explicit),
+			strconv.Itoa(track.Popularity),
+			addedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Flushed a page at a time, so the rows reach the client as they are read
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonWriter streams a JSON array, the elements of each page written as they come
+type jsonWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func newJSONWriter(w io.Writer) (*jsonWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+
+	return &jsonWriter{w: w}, nil
+}
+
+func (j *jsonWriter) WriteTracks(tracks []models.ExportedTrack) error {
+	for _, track := range tracks {
+		encoded, err := json.Marshal(track)
+		if err != nil {
+			return fmt.Errorf("failed to encode track %s: %w", track.URI, err)
+		}
+
+		separator := "\n  "
+		if j.written {
+			separator = ",\n  "
+		}
+		if _, err := io.WriteString(j.w, separator+string(encoded)); err != nil {
+			return err
+		}
+		j.written = true
+	}
+
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	closing := "]\n"
+	if j.written {
+		closing = "\n]\n"
+	}
+
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
+
+// singleLine keeps names from breaking the line based M3U format
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,104 @@
+package playlistfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func testTracks() []models.ExportedTrack {
+	addedAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	return []models.ExportedTrack{
+		{
+			URI:         "spotify:track:track1",
+			Name:        "Song, Part 1",
+			Artists:     []string{"Artist", "Featured"},
+			Album:       "Album",
+			ReleaseDate: "2020-05-01",
+			DurationMs:  215000,
+			Explicit:    true,
+			Popularity:  70,
+			AddedAt:     &addedAt,
+		},
+		{URI: "spotify:track:track2", Name: "Other\nSong", Artists: []string{}, DurationMs: 60500},
+	}
+}
+
+func writeFile(t *testing.T, format models.ExportFormat, pages ...[]models.ExportedTrack) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, format, "Road Trip")
+	require.NoError(t, err)
+	for _, page := range pages {
+		require.NoError(t, writer.WriteTracks(page))
+	}
+	require.NoError(t, writer.Close())
+
+	return buf.String()
+}
+
+func TestWriter_M3U(t *testing.T) {
+	assert := require.New(t)
+
+	file := writeFile(t, models.ExportFormatM3U, testTracks()[:1], testTracks()[1:])
+
+	assert.Equal("#EXTM3U\n"+
+		"#PLAYLIST:Road Trip\n"+
+		"#EXTINF:215,Artist; Featured - Song, Part 1\n"+
+		"spotify:track:track1\n"+
+		"#EXTINF:60,Other Song\n"+
+		"spotify:track:track2\n", file)
+}
+
+func TestWriter_CSV(t *testing.T) {
+	assert := require.New(t)
+
+	file := writeFile(t, models.ExportFormatCSV, testTracks())
+
+	assert.Equal("uri,name,artists,album,release_date,duration_ms,explicit,popularity,added_at\n"+
+		"spotify:track:track1,\"Song, Part 1\",Artist; Featured,Album,2020-05-01,215000,true,70,2024-03-01T12:00:00Z\n"+
+		"spotify:track:track2,\"Other\nSong\",,,,60500,false,0,\n", file)
+}
+
+func TestWriter_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages [][]models.ExportedTrack
+		count int
+	}{
+		{name: "tracks over several pages", pages: [][]models.ExportedTrack{testTracks()[:1], {}, testTracks()[1:]}, count: 2},
+		{name: "empty playlist", count: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			file := writeFile(t, models.ExportFormatJSON, tt.pages...)
+
+			var tracks []models.ExportedTrack
+			assert.NoError(json.Unmarshal([]byte(file), &tracks))
+			assert.Len(tracks, tt.count)
+			if tt.count > 0 {
+				assert.Equal(testTracks()[0], tracks[0])
+				assert.Nil(tracks[1].AddedAt)
+			}
+		})
+	}
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	assert := require.New(t)
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, models.ExportFormat("xspf"), "Road Trip")
+
+	assert.Nil(writer)
+	assert.ErrorIs(err, ErrUnsupportedFormat)
+	assert.Empty(buf.String())
+}
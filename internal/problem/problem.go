@@ -0,0 +1,148 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// CONTENT_TYPE is the media type of RFC 7807 problem details
+const CONTENT_TYPE = "application/problem+json"
+
+// Code identifies the kind of error, so clients can react to it without parsing the detail
+type Code string
+
+const (
+	// Request errors
+	CodeBadRequest                  Code = "bad_request"
+	CodeInvalidPayload              Code = "invalid_payload"
+	CodeValidationFailed            Code = "validation_failed"
+	CodeMissingParameter            Code = "missing_parameter"
+	CodeInvalidParameter            Code = "invalid_parameter"
+	CodeUnsupportedMediaType        Code = "unsupported_media_type"
+	CodeCoverImageTooLarge          Code = "cover_image_too_large"
+	CodeInvalidChildPlaylistOrder   Code = "invalid_child_playlist_order"
+	CodeTooManyPinnedTracks         Code = "too_many_pinned_tracks"
+	CodeInvalidTemplate             Code = "invalid_template"
+	CodeInvalidRoutingConfig        Code = "invalid_routing_config"
+	CodeInvalidPlaylistFile         Code = "invalid_playlist_file"
+	CodeTooManyImportedTracks       Code = "too_many_imported_tracks"
+	CodeNoTracksMatched             Code = "no_tracks_matched"
+	CodeInvalidBlocklistEntry       Code = "invalid_blocklist_entry"
+	CodeInvalidWebhookURL           Code = "invalid_webhook_url"
+	CodeInvalidAPIKeyExpiry         Code = "invalid_api_key_expiry"
+	CodeSameSpotifyPlaylist         Code = "same_spotify_playlist"
+	CodeInvalidNotificationSettings Code = "invalid_notification_settings"
+	CodeInvalidFeatureFlag          Code = "invalid_feature_flag"
+	CodeNotSupportedByProvider      Code = "not_supported_by_provider"
+	CodeYouTubeMusicAuthInvalid     Code = "youtube_music_auth_invalid"
+	CodeTidalLinkInvalid            Code = "tidal_link_invalid"
+	CodeLastFmAuthInvalid           Code = "lastfm_auth_invalid"
+
+	// Authentication and authorization errors
+	CodeUnauthorized                   Code = "unauthorized"
+	CodeInvalidToken                   Code = "invalid_token"
+	CodeInvalidAPIKey                  Code = "invalid_api_key"
+	CodeInsufficientScope              Code = "insufficient_scope"
+	CodeForbidden                      Code = "forbidden"
+	CodeAccountDisabled                Code = "account_disabled"
+	CodeBuiltInTemplateReadOnly        Code = "built_in_template_read_only"
+	CodeSpotifyPlaylistNotOwned        Code = "spotify_playlist_not_owned"
+	CodeSpotifyTokenRefreshFailed      Code = "spotify_token_refresh_failed"
+	CodeYouTubeMusicTokenRefreshFailed Code = "youtube_music_token_refresh_failed"
+	CodeTidalTokenRefreshFailed        Code = "tidal_token_refresh_failed"
+
+	// Missing resources
+	CodeNotFound                        Code = "not_found"
+	CodeUserNotFound                    Code = "user_not_found"
+	CodePlaylistNotFound                Code = "playlist_not_found"
+	CodeBasePlaylistNotFound            Code = "base_playlist_not_found"
+	CodeChildPlaylistNotFound           Code = "child_playlist_not_found"
+	CodeSyncEventNotFound               Code = "sync_event_not_found"
+	CodeRoutingReportNotFound           Code = "routing_report_not_found"
+	CodeFilterRuleChangeNotFound        Code = "filter_rule_change_not_found"
+	CodeWebhookNotFound                 Code = "webhook_not_found"
+	CodeTemplateNotFound                Code = "template_not_found"
+	CodeBlocklistEntryNotFound          Code = "blocklist_entry_not_found"
+	CodeAPIKeyNotFound                  Code = "api_key_not_found"
+	CodeFeatureFlagNotFound             Code = "feature_flag_not_found"
+	CodeSpotifyIntegrationNotFound      Code = "spotify_integration_not_found"
+	CodeSpotifyIntegrationRequired      Code = "spotify_integration_required"
+	CodeYouTubeMusicIntegrationNotFound Code = "youtube_music_integration_not_found"
+	CodeYouTubeMusicIntegrationRequired Code = "youtube_music_integration_required"
+	CodeTidalIntegrationNotFound        Code = "tidal_integration_not_found"
+	CodeTidalIntegrationRequired        Code = "tidal_integration_required"
+	CodeLastFmIntegrationNotFound       Code = "lastfm_integration_not_found"
+	CodeLastFmIntegrationRequired       Code = "lastfm_integration_required"
+
+	// Conflicts with the current state
+	CodeSpotifyAccountLinked        Code = "spotify_account_linked"
+	CodeSpotifyAccountInUse         Code = "spotify_account_in_use"
+	CodeYouTubeMusicAccountInUse    Code = "youtube_music_account_in_use"
+	CodeTidalAccountInUse           Code = "tidal_account_in_use"
+	CodeDefaultSpotifyAccount       Code = "default_spotify_account"
+	CodeBlocklistEntryExists        Code = "blocklist_entry_exists"
+	CodeSyncInProgress              Code = "sync_in_progress"
+	CodeSyncNeedsConfirmation       Code = "sync_needs_confirmation"
+	CodeSyncNotAwaitingConfirmation Code = "sync_not_awaiting_confirmation"
+	CodeSyncNotRetryable            Code = "sync_not_retryable"
+	CodeNothingToRollback           Code = "nothing_to_rollback"
+	CodeRestoreConflict             Code = "restore_conflict"
+	CodeChildPlaylistModified       Code = "child_playlist_modified"
+	CodeBasePlaylistArchived        Code = "base_playlist_archived"
+
+	// Throttling and server errors
+	CodeRateLimited         Code = "rate_limited"
+	CodeSpotifyRateLimited  Code = "spotify_rate_limited"
+	CodeProviderUnavailable Code = "provider_unavailable"
+	CodeMaintenance         Code = "maintenance"
+	CodeInternalError       Code = "internal_error"
+)
+
+// Problem is the RFC 7807 body of every error response, extended with the machine readable code
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Code   Code   `json:"code"`
+	Detail string `json:"detail,omitempty"`
+	// Errors lists the invalid fields of a request that failed validation
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a single field of the request is invalid
+type FieldError struct {
+	Field   string `json:"field"`   // Path of the field in the json payload, e.g. filter_rules.energy
+	Rule    string `json:"rule"`    // Validation rule that failed, e.g. required or max
+	Message string `json:"message"` // Human readable explanation
+}
+
+// New describes an error with the given status. Problems have no type of their own, so the type is
+// about:blank and the title the standard text of the status
+func New(status int, code Code, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// Write responds with the problem, replacing http.Error for every error response of the API
+func Write(w http.ResponseWriter, status int, code Code, detail string) {
+	WriteProblem(w, New(status, code, detail))
+}
+
+// WriteProblem responds with an already built problem
+func WriteProblem(w http.ResponseWriter, p *Problem) {
+	h := w.Header()
+	// Mirrors http.Error, headers set for the success response don't apply to the error
+	h.Del("Content-Length")
+	h.Set("Content-Type", CONTENT_TYPE)
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(p)
+}
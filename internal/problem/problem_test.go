@@ -0,0 +1,48 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	assert := require.New(t)
+
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Length", "42")
+
+	Write(w, http.StatusNotFound, CodeBasePlaylistNotFound, "base playlist <id> not found")
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(CONTENT_TYPE, w.Header().Get("Content-Type"))
+	assert.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Empty(w.Header().Get("Content-Length"))
+	assert.Contains(w.Body.String(), "<id>")
+
+	var body Problem
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(Problem{
+		Type:   "about:blank",
+		Title:  "Not Found",
+		Status: http.StatusNotFound,
+		Code:   CodeBasePlaylistNotFound,
+		Detail: "base playlist <id> not found",
+	}, body)
+}
+
+func TestWrite_NoDetail(t *testing.T) {
+	assert := require.New(t)
+
+	w := httptest.NewRecorder()
+	Write(w, http.StatusTooManyRequests, CodeRateLimited, "")
+
+	var body map[string]any
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal("Too Many Requests", body["title"])
+	assert.Equal("rate_limited", body["code"])
+	assert.NotContains(body, "detail")
+}
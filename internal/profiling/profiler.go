@@ -0,0 +1,109 @@
+package profiling
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Profiler records the timing of the steps of a single sync as a tree of spans. Steps open spans
+// with StartSpan on the context they run with, so code that may or may not be profiled needs no
+// profiler of its own: without one in the context StartSpan does nothing.
+type Profiler struct {
+	mu    sync.Mutex
+	start time.Time
+	root  *models.SyncProfileSpan
+	now   func() time.Time
+}
+
+type profilerContextKey struct{}
+type spanContextKey struct{}
+
+func NewProfiler(name string) *Profiler {
+	return newProfiler(name, time.Now)
+}
+
+func newProfiler(name string, now func() time.Time) *Profiler {
+	return &Profiler{
+		start: now(),
+		root:  &models.SyncProfileSpan{Name: name},
+		now:   now,
+	}
+}
+
+// ContextWithProfiler nests the spans started with the returned context under the root span of profiler
+func ContextWithProfiler(ctx context.Context, profiler *Profiler) context.Context {
+	ctx = context.WithValue(ctx, profilerContextKey{}, profiler)
+	return context.WithValue(ctx, spanContextKey{}, profiler.root)
+}
+
+// StartSpan opens a span named name under the current span of ctx. The returned context nests
+// further spans under the new one, and end records its duration; only the first call to end
+// counts, so it can be both deferred and called early. When ctx carries no profiler nothing is
+// profiled.
+//
+// The step is traced as well, whether profiled or not. Names of the form kind:detail, such as the
+// writes of each child playlist, are traced as kind with the detail as an attribute, keeping the
+// span names of the traces few
+func StartSpan(ctx context.Context, name string) (context.Context, func()) {
+	ctx, traceSpan := startTraceSpan(ctx, name)
+
+	profiler, ok := ctx.Value(profilerContextKey{}).(*Profiler)
+	if !ok {
+		return ctx, func() { traceSpan.End() }
+	}
+	parent, _ := ctx.Value(spanContextKey{}).(*models.SyncProfileSpan)
+
+	started := profiler.now()
+	span := &models.SyncProfileSpan{Name: name, StartMs: started.Sub(profiler.start).Milliseconds()}
+
+	// Concurrent steps of the same sync may open spans under the same parent
+	profiler.mu.Lock()
+	parent.Children = append(parent.Children, span)
+	profiler.mu.Unlock()
+
+	var once sync.Once
+	end := func() {
+		once.Do(func() {
+			traceSpan.End()
+
+			profiler.mu.Lock()
+			defer profiler.mu.Unlock()
+			span.Value = profiler.now().Sub(started).Milliseconds()
+		})
+	}
+
+	return context.WithValue(ctx, spanContextKey{}, span), end
+}
+
+func startTraceSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	var options []trace.SpanStartOption
+	if kind, detail, hasDetail := strings.Cut(name, ":"); hasDetail {
+		name = kind
+		options = append(options, trace.WithAttributes(attribute.String("step.detail", detail)))
+	}
+
+	spanCtx, span := tracing.Tracer().Start(ctx, name, options...)
+
+	// Nothing nests under spans that aren't recorded, such as every span when tracing is off
+	if !span.IsRecording() {
+		return ctx, span
+	}
+
+	return spanCtx, span
+}
+
+// Finish closes the root span and returns the recorded tree
+func (p *Profiler) Finish() *models.SyncProfileSpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.root.Value = p.now().Sub(p.start).Milliseconds()
+	return p.root
+}
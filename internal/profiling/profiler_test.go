@@ -0,0 +1,89 @@
+package profiling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestProfiler_RecordsNestedSpans(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	advance := func(d time.Duration) { now = now.Add(d) }
+
+	profiler := newProfiler("sync", clock)
+	ctx := ContextWithProfiler(context.Background(), profiler)
+
+	aggregationCtx, endAggregation := StartSpan(ctx, "aggregation")
+	advance(100 * time.Millisecond)
+	_, endWait := StartSpan(aggregationCtx, "rate_limit_wait")
+	advance(2 * time.Second)
+	endWait()
+	endAggregation()
+
+	_, endRouting := StartSpan(ctx, "routing")
+	advance(50 * time.Millisecond)
+	endRouting()
+	advance(time.Second)
+	endRouting() // Only the first call counts
+
+	assert.Equal(&models.SyncProfileSpan{
+		Name:  "sync",
+		Value: 3150,
+		Children: []*models.SyncProfileSpan{
+			{
+				Name:     "aggregation",
+				Value:    2100,
+				Children: []*models.SyncProfileSpan{{Name: "rate_limit_wait", StartMs: 100, Value: 2000}},
+			},
+			{Name: "routing", StartMs: 2100, Value: 50},
+		},
+	}, profiler.Finish())
+}
+
+func TestStartSpan_WithoutProfiler(t *testing.T) {
+	assert := require.New(t)
+
+	ctx := context.Background()
+	spanCtx, end := StartSpan(ctx, "aggregation")
+	end()
+
+	assert.Equal(ctx, spanCtx)
+}
+
+func TestStartSpan_Traces(t *testing.T) {
+	assert := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	// Traced with or without a profiler
+	profiler := NewProfiler("sync")
+	writesCtx, endWrites := StartSpan(ContextWithProfiler(context.Background(), profiler), "playlist_writes")
+	_, endChild := StartSpan(writesCtx, "child:Workout")
+	endChild()
+	endWrites()
+	_, endRouting := StartSpan(context.Background(), "routing")
+	endRouting()
+	endRouting()
+
+	spans := recorder.Ended()
+	assert.Len(spans, 3)
+	assert.Equal("child", spans[0].Name())
+	assert.Equal([]attribute.KeyValue{attribute.String("step.detail", "Workout")}, spans[0].Attributes())
+	assert.Equal(spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal("playlist_writes", spans[1].Name())
+	assert.Equal("routing", spans[2].Name())
+	assert.Equal("child:Workout", profiler.Finish().Children[0].Children[0].Name)
+}
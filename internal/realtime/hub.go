@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// SUBSCRIPTION_BUFFER is how many events a subscription holds before it is considered too slow
+// and dropped, so a stalled connection never blocks the syncs publishing to it
+const SUBSCRIPTION_BUFFER = 64
+
+// Hub keeps the subscriptions of the open app connections by user and fans the events of each user
+// out to all of them
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[string]map[*Subscription]struct{}
+
+	logger *slog.Logger
+}
+
+// Subscription receives the events of a user, already encoded as JSON
+type Subscription struct {
+	hub    *Hub
+	userID string
+	events chan []byte
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewHub(logger *slog.Logger) *Hub {
+	return &Hub{
+		subscriptions: make(map[string]map[*Subscription]struct{}),
+		logger:        logger.With("component", "RealtimeHub"),
+	}
+}
+
+// Subscribe registers a new subscription to the events of the user, which lasts until it is closed
+func (h *Hub) Subscribe(userID string) *Subscription {
+	subscription := &Subscription{
+		hub:    h,
+		userID: userID,
+		events: make(chan []byte, SUBSCRIPTION_BUFFER),
+		done:   make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscriptions[userID] == nil {
+		h.subscriptions[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscriptions[userID][subscription] = struct{}{}
+
+	return subscription
+}
+
+// Publish sends the event to every subscription of the user without waiting on them. The event is
+// encoded before Publish returns, so the caller can keep changing what it points to
+func (h *Hub) Publish(userID string, event *models.AppEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscriptions := h.subscriptions[userID]
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		h.logger.Error("failed to encode app event", "user_id", userID, "type", event.Type, "error", err.Error())
+		return
+	}
+
+	for subscription := range subscriptions {
+		select {
+		case subscription.events <- payload:
+		default:
+			h.logger.Warn("dropping slow subscription", "user_id", userID)
+			h.remove(subscription)
+		}
+	}
+}
+
+// SubscriberCount returns how many subscriptions the user has open
+func (h *Hub) SubscriberCount(userID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscriptions[userID])
+}
+
+// remove unregisters the subscription and closes it, with h.mu held
+func (h *Hub) remove(subscription *Subscription) {
+	subscriptions := h.subscriptions[subscription.userID]
+	if _, ok := subscriptions[subscription]; !ok {
+		return
+	}
+
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(h.subscriptions, subscription.userID)
+	}
+	subscription.once.Do(func() { close(subscription.done) })
+}
+
+// Events delivers the encoded events of the user
+func (s *Subscription) Events() <-chan []byte {
+	return s.events
+}
+
+// Done is closed once the subscription is closed, either by its owner or by the hub because it fell
+// behind
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close unsubscribes from the hub. It can be called more than once
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
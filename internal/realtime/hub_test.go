@@ -0,0 +1,92 @@
+package realtime
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub() *Hub {
+	return NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func receive(t *testing.T, subscription *Subscription) *models.AppEvent {
+	t.Helper()
+
+	select {
+	case payload := <-subscription.Events():
+		var event models.AppEvent
+		require.NoError(t, json.Unmarshal(payload, &event))
+		return &event
+	default:
+		t.Fatal("no event received")
+		return nil
+	}
+}
+
+func TestHub_PublishReachesEverySubscriptionOfTheUser(t *testing.T) {
+	assert := require.New(t)
+	hub := newTestHub()
+
+	first := hub.Subscribe("user123")
+	second := hub.Subscribe("user123")
+	other := hub.Subscribe("user456")
+
+	hub.Publish("user123", models.NewPlaylistDeletedEvent(models.PlaylistKindBase, "base123", ""))
+
+	for _, subscription := range []*Subscription{first, second} {
+		event := receive(t, subscription)
+		assert.Equal(models.AppEventPlaylistDeleted, event.Type)
+		assert.Equal("base123", event.Playlist.ID)
+	}
+	assert.Empty(other.Events())
+}
+
+func TestHub_PublishEncodesTheEventRightAway(t *testing.T) {
+	assert := require.New(t)
+	hub := newTestHub()
+	subscription := hub.Subscribe("user123")
+
+	syncEvent := &models.SyncEvent{ID: "sync123", UserID: "user123", Phase: models.SyncPhaseFetchingTracks}
+	hub.Publish("user123", models.NewSyncProgressEvent(syncEvent))
+	syncEvent.Phase = models.SyncPhaseRoutingTracks
+
+	event := receive(t, subscription)
+	assert.Equal(models.SyncPhaseFetchingTracks, event.SyncEvent.Phase)
+}
+
+func TestHub_Close(t *testing.T) {
+	assert := require.New(t)
+	hub := newTestHub()
+
+	subscription := hub.Subscribe("user123")
+	assert.Equal(1, hub.SubscriberCount("user123"))
+
+	subscription.Close()
+	subscription.Close()
+
+	assert.Equal(0, hub.SubscriberCount("user123"))
+	assert.NotContains(hub.subscriptions, "user123")
+	<-subscription.Done()
+
+	hub.Publish("user123", models.NewPlaylistDeletedEvent(models.PlaylistKindBase, "base123", ""))
+	assert.Empty(subscription.Events())
+}
+
+func TestHub_DropsSlowSubscriptions(t *testing.T) {
+	assert := require.New(t)
+	hub := newTestHub()
+
+	slow := hub.Subscribe("user123")
+	for range SUBSCRIPTION_BUFFER + 1 {
+		hub.Publish("user123", models.NewPlaylistDeletedEvent(models.PlaylistKindBase, "base123", ""))
+	}
+
+	<-slow.Done()
+	assert.Len(slow.Events(), SUBSCRIPTION_BUFFER)
+	assert.Equal(0, hub.SubscriberCount("user123"))
+}
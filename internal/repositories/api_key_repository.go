@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=api_key_repository.go -destination=mocks/mock_api_key_repository.go -package=mocks
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *models.APIKey) (*models.APIKey, error)
+	GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	GetByUserID(ctx context.Context, userID string) ([]*models.APIKey, error)
+	Delete(ctx context.Context, id, userID string) error
+	UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error
+}
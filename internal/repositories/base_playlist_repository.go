@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 )
@@ -9,8 +10,39 @@ import (
 //go:generate mockgen -source=base_playlist_repository.go -destination=mocks/mock_base_playlist_repository.go -package=mocks
 
 type BasePlaylistRepository interface {
-	Create(ctx context.Context, userId, name, spotifyPlaylistId string) (*models.BasePlaylist, error)
+	Create(ctx context.Context, userId, name, spotifyPlaylistId string, dedupeStrategy models.DedupeStrategy, spotifyIntegrationId string, provider models.MusicProvider) (*models.BasePlaylist, error)
+	// Delete soft deletes the base playlist together with its child playlists
 	Delete(ctx context.Context, id, userId string) error
+	// Restore undoes Delete, bringing back the child playlists deleted with the base playlist
+	Restore(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
+	// Purge permanently deletes the base playlists soft deleted before the cutoff
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 	GetByID(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
 	GetByUserID(ctx context.Context, userId string) ([]*models.BasePlaylist, error)
+	// List returns a page of the base playlists of the user matching the filter
+	List(ctx context.Context, filter BasePlaylistFilter) ([]*models.BasePlaylist, error)
+	// Count returns how many base playlists match the filter, ignoring its pagination
+	Count(ctx context.Context, filter BasePlaylistFilter) (int, error)
+	Update(ctx context.Context, id, userId string, fields UpdateBasePlaylistFields) (*models.BasePlaylist, error)
+	GetByHookToken(ctx context.Context, hookToken string) (*models.BasePlaylist, error)
+}
+
+// BasePlaylistFilter narrows down List, nil or empty fields match every base playlist of the user
+type BasePlaylistFilter struct {
+	UserID   string
+	IsActive *bool
+	Archived *bool
+	Sort     models.ListSort // Newest first when empty
+	Limit    int
+	Offset   int
+}
+
+type UpdateBasePlaylistFields struct {
+	Name              *string                `json:"name,omitempty"`
+	SpotifyPlaylistID *string                `json:"spotify_playlist_id,omitempty"`
+	DedupeStrategy    *models.DedupeStrategy `json:"dedupe_strategy,omitempty"`
+	HookToken         *string                `json:"hook_token,omitempty"` // Empty string disables the hooks
+	IsActive          *bool                  `json:"is_active,omitempty"`
+	Suspended         *bool                  `json:"suspended,omitempty"`
+	Archived          *bool                  `json:"archived,omitempty"`
 }
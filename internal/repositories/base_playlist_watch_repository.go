@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=base_playlist_watch_repository.go -destination=mocks/mock_base_playlist_watch_repository.go -package=mocks
+
+type BasePlaylistWatchRepository interface {
+	// Upsert stores the state of the base playlist, replacing the previous one
+	Upsert(ctx context.Context, watch *models.BasePlaylistWatch) (*models.BasePlaylistWatch, error)
+	GetByBasePlaylistID(ctx context.Context, basePlaylistID string) (*models.BasePlaylistWatch, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=blocklist_entry_repository.go -destination=mocks/mock_blocklist_entry_repository.go -package=mocks
+
+type BlocklistEntryRepository interface {
+	Create(ctx context.Context, entry *models.BlocklistEntry) (*models.BlocklistEntry, error)
+	GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.BlocklistEntry, error)
+	Delete(ctx context.Context, id, userID string) error
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 )
@@ -10,26 +11,54 @@ import (
 
 type ChildPlaylistRepository interface {
 	Create(ctx context.Context, fields CreateChildPlaylistFields) (*models.ChildPlaylist, error)
+	// Delete soft deletes the child playlist
 	Delete(ctx context.Context, id, userID string) error
+	// Restore undoes Delete, as long as the base playlist of the child was not deleted
+	Restore(ctx context.Context, id, userID string) (*models.ChildPlaylist, error)
+	// Purge permanently deletes the child playlists soft deleted before the cutoff
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 	GetByID(ctx context.Context, id, userID string) (*models.ChildPlaylist, error)
 	GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.ChildPlaylist, error)
+	// GetBySourceBasePlaylistID returns the merge child playlists of other base playlists merging this one
+	GetBySourceBasePlaylistID(ctx context.Context, sourceBasePlaylistID, userID string) ([]*models.ChildPlaylist, error)
+	GetByShareToken(ctx context.Context, shareToken string) (*models.ChildPlaylist, error)
 	Update(ctx context.Context, id, userID string, fields UpdateChildPlaylistFields) (*models.ChildPlaylist, error)
 }
 
 type CreateChildPlaylistFields struct {
-	UserID            string                      `json:"user_id" validate:"required"`
-	BasePlaylistID    string                      `json:"base_playlist_id" validate:"required"`
-	Name              string                      `json:"name" validate:"required,min=1,max=100"`
-	Description       string                      `json:"description,omitempty"`
-	SpotifyPlaylistID string                      `json:"spotify_playlist_id" validate:"required"`
-	FilterRules       *models.AudioFeatureFilters `json:"filter_rules,omitempty"`
-	IsActive          bool                        `json:"is_active"`
+	UserID                string                      `json:"user_id" validate:"required"`
+	BasePlaylistID        string                      `json:"base_playlist_id" validate:"required"`
+	Name                  string                      `json:"name" validate:"required,min=1,max=100"`
+	Description           string                      `json:"description,omitempty"`
+	SpotifyPlaylistID     string                      `json:"spotify_playlist_id" validate:"required"`
+	FilterRules           *models.AudioFeatureFilters `json:"filter_rules,omitempty"`
+	IsActive              bool                        `json:"is_active"`
+	IsFallback            bool                        `json:"is_fallback"`
+	Priority              int                         `json:"priority"`
+	MaxTracks             int                         `json:"max_tracks"`
+	SelectionStrategy     models.SelectionStrategy    `json:"selection_strategy,omitempty"`
+	SourceBasePlaylistIDs []string                    `json:"source_base_playlist_ids,omitempty"`
+	RefollowRecreated     bool                        `json:"refollow_recreated"`
+	SpotifyIntegrationID  string                      `json:"spotify_integration_id,omitempty"`
+	Provider              models.MusicProvider        `json:"provider,omitempty"` // Defaults to spotify
 }
 
 type UpdateChildPlaylistFields struct {
-	Name              *string                     `json:"name,omitempty"`
-	Description       *string                     `json:"description,omitempty"`
-	FilterRules       *models.AudioFeatureFilters `json:"filter_rules,omitempty"`
-	IsActive          *bool                       `json:"is_active,omitempty"`
-	SpotifyPlaylistID *string                     `json:"spotify_playlist_id,omitempty"`
+	Name                  *string                     `json:"name,omitempty"`
+	Description           *string                     `json:"description,omitempty"`
+	FilterRules           *models.AudioFeatureFilters `json:"filter_rules,omitempty"`
+	IsActive              *bool                       `json:"is_active,omitempty"`
+	SpotifyPlaylistID     *string                     `json:"spotify_playlist_id,omitempty"`
+	IsFallback            *bool                       `json:"is_fallback,omitempty"`
+	Priority              *int                        `json:"priority,omitempty"`
+	ShareToken            *string                     `json:"share_token,omitempty"` // Empty string stops sharing
+	MaxTracks             *int                        `json:"max_tracks,omitempty"`
+	SelectionStrategy     *models.SelectionStrategy   `json:"selection_strategy,omitempty"`
+	SyncStrategy          *models.SyncStrategy        `json:"sync_strategy,omitempty"`
+	SourceBasePlaylistIDs *[]string                   `json:"source_base_playlist_ids,omitempty"` // Empty list stops merging
+	PinnedTracks          *[]string                   `json:"pinned_tracks,omitempty"`
+	RefollowRecreated     *bool                       `json:"refollow_recreated,omitempty"`
+	Suspended             *bool                       `json:"suspended,omitempty"`
+	// Precondition of the update, fails with ErrChildPlaylistModified when the record was updated since
+	ExpectedUpdated *time.Time `json:"expected_updated,omitempty"`
 }
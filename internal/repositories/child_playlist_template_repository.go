@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=child_playlist_template_repository.go -destination=mocks/mock_child_playlist_template_repository.go -package=mocks
+
+// ChildPlaylistTemplateRepository stores the templates created by users, built-in templates are not stored
+type ChildPlaylistTemplateRepository interface {
+	Create(ctx context.Context, template *models.ChildPlaylistTemplate) (*models.ChildPlaylistTemplate, error)
+	GetByID(ctx context.Context, id, userID string) (*models.ChildPlaylistTemplate, error)
+	GetByUserID(ctx context.Context, userID string) ([]*models.ChildPlaylistTemplate, error)
+	Delete(ctx context.Context, id, userID string) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=diagnostics_repository.go -destination=mocks/mock_diagnostics_repository.go -package=mocks
+
+// DiagnosticsRepository reads the operational data of the app itself, as opposed to user data
+type DiagnosticsRepository interface {
+	GetRecentLogs(ctx context.Context, limit int) ([]*models.LogEntry, error)
+	GetSchemaVersions(ctx context.Context) (*models.SchemaVersions, error)
+	Ping(ctx context.Context) error
+}
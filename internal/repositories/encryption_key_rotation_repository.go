@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=encryption_key_rotation_repository.go -destination=mocks/mock_encryption_key_rotation_repository.go -package=mocks
+
+type EncryptionKeyRotationRepository interface {
+	Create(ctx context.Context, rotation *models.EncryptionKeyRotation) (*models.EncryptionKeyRotation, error)
+}
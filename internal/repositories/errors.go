@@ -9,17 +9,65 @@ var (
 	ErrUnauthorized       = errors.New("user can not access this resource")
 
 	// User errors
-	ErrUseNotFound = errors.New("user not found")
+	ErrUseNotFound  = errors.New("user not found")
+	ErrUserDisabled = errors.New("user is disabled")
 
 	// Base playlist errors
 	ErrBasePlaylistNotFound = errors.New("base playlist not found")
 
+	// ErrRestoreConflict is returned when restoring a playlist linked to the same spotify playlist as a live one
+	ErrRestoreConflict = errors.New("another playlist is linked to the same spotify playlist")
+
 	// Child playlist errors
 	ErrChildPlaylistNotFound = errors.New("child playlist not found")
 
+	// ErrChildPlaylistModified is returned when an update expects a child playlist that was modified since
+	ErrChildPlaylistModified = errors.New("child playlist was modified since it was read")
+
 	// Spotify integration errors
 	ErrSpotifyIntegrationNotFound = errors.New("spotify integration not found")
 
+	// YouTube Music integration errors
+	ErrYouTubeMusicIntegrationNotFound = errors.New("youtube music integration not found")
+
+	// Tidal integration errors
+	ErrTidalIntegrationNotFound = errors.New("tidal integration not found")
+
+	// Last.fm integration errors
+	ErrLastFmIntegrationNotFound = errors.New("lastfm integration not found")
+
 	// Sync event errors
-	ErrSyncEventNotFound = errors.New("sync event not found")
+	ErrSyncEventNotFound      = errors.New("sync event not found")
+	ErrSyncEventStatusChanged = errors.New("sync event status changed")
+
+	// Playlist membership errors
+	ErrPlaylistMembershipNotFound = errors.New("playlist membership not found")
+
+	// Filter rule change errors
+	ErrFilterRuleChangeNotFound = errors.New("filter rule change not found")
+
+	// Playlist webhook errors
+	ErrPlaylistWebhookNotFound   = errors.New("playlist webhook not found")
+	ErrBasePlaylistWatchNotFound = errors.New("base playlist watch not found")
+
+	// Child playlist template errors
+	ErrChildPlaylistTemplateNotFound = errors.New("child playlist template not found")
+
+	// Blocklist errors
+	ErrBlocklistEntryNotFound = errors.New("blocklist entry not found")
+
+	// Routing report errors
+	ErrRoutingReportNotFound = errors.New("routing report not found")
+
+	// Notification preferences errors
+	ErrNotificationPreferencesNotFound = errors.New("notification preferences not found")
+
+	// Feature flag errors
+	ErrFeatureFlagNotFound = errors.New("feature flag not found")
+
+	// API key errors
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	// Encryption key errors
+	ErrUserEncryptionKeyNotFound = errors.New("user encryption key not found")
 )
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=feature_flag_repository.go -destination=mocks/mock_feature_flag_repository.go -package=mocks
+
+type FeatureFlagRepository interface {
+	List(ctx context.Context) ([]*models.FeatureFlag, error)
+	GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error)
+	// Upsert stores the flag under its key, replacing the previous one
+	Upsert(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error)
+	Delete(ctx context.Context, key string) error
+}
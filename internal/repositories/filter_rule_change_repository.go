@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=filter_rule_change_repository.go -destination=mocks/mock_filter_rule_change_repository.go -package=mocks
+
+type FilterRuleChangeRepository interface {
+	Create(ctx context.Context, change *models.FilterRuleChange) (*models.FilterRuleChange, error)
+	GetByID(ctx context.Context, id, userID string) (*models.FilterRuleChange, error)
+	GetByChildPlaylistID(ctx context.Context, childPlaylistID, userID string) ([]*models.FilterRuleChange, error)
+	GetPendingByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.FilterRuleChange, error)
+	UpdateRoutingDiff(ctx context.Context, id string, diff *models.RoutingDiff) (*models.FilterRuleChange, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=lastfm_integration_repository.go -destination=mocks/mock_lastfm_integration_repository.go -package=mocks
+
+type LastFmIntegrationRepository interface {
+	// CreateOrUpdate upserts the integration of the user, a user links a single last.fm account
+	CreateOrUpdate(ctx context.Context, userID string, integration *models.LastFmIntegration) (*models.LastFmIntegration, error)
+	GetByUserID(ctx context.Context, userID string) (*models.LastFmIntegration, error)
+	Delete(ctx context.Context, userID string) error
+}
@@ -7,9 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ngomez18/playlist-router/internal/models"
+	repositories "github.com/ngomez18/playlist-router/internal/repositories"
 )
 
 // MockBasePlaylistRepository is a mock of BasePlaylistRepository interface.
@@ -35,19 +37,34 @@ func (m *MockBasePlaylistRepository) EXPECT() *MockBasePlaylistRepositoryMockRec
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockBasePlaylistRepository) Count(ctx context.Context, filter repositories.BasePlaylistFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockBasePlaylistRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockBasePlaylistRepository)(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
-func (m *MockBasePlaylistRepository) Create(ctx context.Context, userId, name, spotifyPlaylistId string) (*models.BasePlaylist, error) {
+func (m *MockBasePlaylistRepository) Create(ctx context.Context, userId, name, spotifyPlaylistId string, dedupeStrategy models.DedupeStrategy, spotifyIntegrationId string, provider models.MusicProvider) (*models.BasePlaylist, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, userId, name, spotifyPlaylistId)
+	ret := m.ctrl.Call(m, "Create", ctx, userId, name, spotifyPlaylistId, dedupeStrategy, spotifyIntegrationId, provider)
 	ret0, _ := ret[0].(*models.BasePlaylist)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockBasePlaylistRepositoryMockRecorder) Create(ctx, userId, name, spotifyPlaylistId interface{}) *gomock.Call {
+func (mr *MockBasePlaylistRepositoryMockRecorder) Create(ctx, userId, name, spotifyPlaylistId, dedupeStrategy, spotifyIntegrationId, provider interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBasePlaylistRepository)(nil).Create), ctx, userId, name, spotifyPlaylistId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBasePlaylistRepository)(nil).Create), ctx, userId, name, spotifyPlaylistId, dedupeStrategy, spotifyIntegrationId, provider)
 }
 
 // Delete mocks base method.
@@ -64,6 +81,21 @@ func (mr *MockBasePlaylistRepositoryMockRecorder) Delete(ctx, id, userId interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBasePlaylistRepository)(nil).Delete), ctx, id, userId)
 }
 
+// GetByHookToken mocks base method.
+func (m *MockBasePlaylistRepository) GetByHookToken(ctx context.Context, hookToken string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHookToken", ctx, hookToken)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHookToken indicates an expected call of GetByHookToken.
+func (mr *MockBasePlaylistRepositoryMockRecorder) GetByHookToken(ctx, hookToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHookToken", reflect.TypeOf((*MockBasePlaylistRepository)(nil).GetByHookToken), ctx, hookToken)
+}
+
 // GetByID mocks base method.
 func (m *MockBasePlaylistRepository) GetByID(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
 	m.ctrl.T.Helper()
@@ -93,3 +125,63 @@ func (mr *MockBasePlaylistRepositoryMockRecorder) GetByUserID(ctx, userId interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockBasePlaylistRepository)(nil).GetByUserID), ctx, userId)
 }
+
+// List mocks base method.
+func (m *MockBasePlaylistRepository) List(ctx context.Context, filter repositories.BasePlaylistFilter) ([]*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBasePlaylistRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBasePlaylistRepository)(nil).List), ctx, filter)
+}
+
+// Purge mocks base method.
+func (m *MockBasePlaylistRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockBasePlaylistRepositoryMockRecorder) Purge(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockBasePlaylistRepository)(nil).Purge), ctx, deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockBasePlaylistRepository) Restore(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userId)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBasePlaylistRepositoryMockRecorder) Restore(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBasePlaylistRepository)(nil).Restore), ctx, id, userId)
+}
+
+// Update mocks base method.
+func (m *MockBasePlaylistRepository) Update(ctx context.Context, id, userId string, fields repositories.UpdateBasePlaylistFields) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, userId, fields)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBasePlaylistRepositoryMockRecorder) Update(ctx, id, userId, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBasePlaylistRepository)(nil).Update), ctx, id, userId, fields)
+}
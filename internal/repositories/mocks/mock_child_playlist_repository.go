@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ngomez18/playlist-router/internal/models"
@@ -95,6 +96,66 @@ func (mr *MockChildPlaylistRepositoryMockRecorder) GetByID(ctx, id, userID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChildPlaylistRepository)(nil).GetByID), ctx, id, userID)
 }
 
+// GetBySourceBasePlaylistID mocks base method.
+func (m *MockChildPlaylistRepository) GetBySourceBasePlaylistID(ctx context.Context, sourceBasePlaylistID, userID string) ([]*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySourceBasePlaylistID", ctx, sourceBasePlaylistID, userID)
+	ret0, _ := ret[0].([]*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySourceBasePlaylistID indicates an expected call of GetBySourceBasePlaylistID.
+func (mr *MockChildPlaylistRepositoryMockRecorder) GetBySourceBasePlaylistID(ctx, sourceBasePlaylistID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySourceBasePlaylistID", reflect.TypeOf((*MockChildPlaylistRepository)(nil).GetBySourceBasePlaylistID), ctx, sourceBasePlaylistID, userID)
+}
+
+// GetByShareToken mocks base method.
+func (m *MockChildPlaylistRepository) GetByShareToken(ctx context.Context, shareToken string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByShareToken", ctx, shareToken)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByShareToken indicates an expected call of GetByShareToken.
+func (mr *MockChildPlaylistRepositoryMockRecorder) GetByShareToken(ctx, shareToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShareToken", reflect.TypeOf((*MockChildPlaylistRepository)(nil).GetByShareToken), ctx, shareToken)
+}
+
+// Purge mocks base method.
+func (m *MockChildPlaylistRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockChildPlaylistRepositoryMockRecorder) Purge(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockChildPlaylistRepository)(nil).Purge), ctx, deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockChildPlaylistRepository) Restore(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, userID)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockChildPlaylistRepositoryMockRecorder) Restore(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockChildPlaylistRepository)(nil).Restore), ctx, id, userID)
+}
+
 // Update mocks base method.
 func (m *MockChildPlaylistRepository) Update(ctx context.Context, id, userID string, fields repositories.UpdateChildPlaylistFields) (*models.ChildPlaylist, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ngomez18/playlist-router/internal/models"
@@ -64,6 +65,35 @@ func (mr *MockSpotifyIntegrationRepositoryMockRecorder) Delete(ctx, userID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSpotifyIntegrationRepository)(nil).Delete), ctx, userID)
 }
 
+// DeleteByID mocks base method.
+func (m *MockSpotifyIntegrationRepository) DeleteByID(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSpotifyIntegrationRepositoryMockRecorder) DeleteByID(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSpotifyIntegrationRepository)(nil).DeleteByID), ctx, id, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockSpotifyIntegrationRepository) GetByID(ctx context.Context, id, userID string) (*models.SpotifyIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, userID)
+	ret0, _ := ret[0].(*models.SpotifyIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSpotifyIntegrationRepositoryMockRecorder) GetByID(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSpotifyIntegrationRepository)(nil).GetByID), ctx, id, userID)
+}
+
 // GetBySpotifyID mocks base method.
 func (m *MockSpotifyIntegrationRepository) GetBySpotifyID(ctx context.Context, spotifyID string) (*models.SpotifyIntegration, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +124,36 @@ func (mr *MockSpotifyIntegrationRepositoryMockRecorder) GetByUserID(ctx, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockSpotifyIntegrationRepository)(nil).GetByUserID), ctx, userID)
 }
 
+// GetExpiringBefore mocks base method.
+func (m *MockSpotifyIntegrationRepository) GetExpiringBefore(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringBefore", ctx, before)
+	ret0, _ := ret[0].([]*models.SpotifyIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringBefore indicates an expected call of GetExpiringBefore.
+func (mr *MockSpotifyIntegrationRepositoryMockRecorder) GetExpiringBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringBefore", reflect.TypeOf((*MockSpotifyIntegrationRepository)(nil).GetExpiringBefore), ctx, before)
+}
+
+// ListByUserID mocks base method.
+func (m *MockSpotifyIntegrationRepository) ListByUserID(ctx context.Context, userID string) ([]*models.SpotifyIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*models.SpotifyIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockSpotifyIntegrationRepositoryMockRecorder) ListByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockSpotifyIntegrationRepository)(nil).ListByUserID), ctx, userID)
+}
+
 // UpdateTokens mocks base method.
 func (m *MockSpotifyIntegrationRepository) UpdateTokens(ctx context.Context, integrationID string, tokens *models.SpotifyIntegrationTokenRefresh) error {
 	m.ctrl.T.Helper()
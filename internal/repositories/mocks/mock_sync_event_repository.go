@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ngomez18/playlist-router/internal/models"
+	repositories "github.com/ngomez18/playlist-router/internal/repositories"
 )
 
 // MockSyncEventRepository is a mock of SyncEventRepository interface.
@@ -35,6 +36,21 @@ func (m *MockSyncEventRepository) EXPECT() *MockSyncEventRepositoryMockRecorder
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockSyncEventRepository) Count(ctx context.Context, filter repositories.SyncEventFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSyncEventRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSyncEventRepository)(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
 func (m *MockSyncEventRepository) Create(ctx context.Context, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +111,65 @@ func (mr *MockSyncEventRepositoryMockRecorder) GetByUserID(ctx, userID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockSyncEventRepository)(nil).GetByUserID), ctx, userID)
 }
 
+// GetRecentFailed mocks base method.
+func (m *MockSyncEventRepository) GetRecentFailed(ctx context.Context, limit int) ([]*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentFailed", ctx, limit)
+	ret0, _ := ret[0].([]*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentFailed indicates an expected call of GetRecentFailed.
+func (mr *MockSyncEventRepositoryMockRecorder) GetRecentFailed(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentFailed", reflect.TypeOf((*MockSyncEventRepository)(nil).GetRecentFailed), ctx, limit)
+}
+
+// List mocks base method.
+func (m *MockSyncEventRepository) List(ctx context.Context, filter repositories.SyncEventFilter) ([]*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSyncEventRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSyncEventRepository)(nil).List), ctx, filter)
+}
+
+// Prune mocks base method.
+func (m *MockSyncEventRepository) Prune(ctx context.Context, filter repositories.SyncEventPruneFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockSyncEventRepositoryMockRecorder) Prune(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockSyncEventRepository)(nil).Prune), ctx, filter)
+}
+
+// TransitionStatus mocks base method.
+func (m *MockSyncEventRepository) TransitionStatus(ctx context.Context, id string, from, to models.SyncStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransitionStatus indicates an expected call of TransitionStatus.
+func (mr *MockSyncEventRepositoryMockRecorder) TransitionStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionStatus", reflect.TypeOf((*MockSyncEventRepository)(nil).TransitionStatus), ctx, id, from, to)
+}
+
 // Update mocks base method.
 func (m *MockSyncEventRepository) Update(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +79,21 @@ func (mr *MockUserRepositoryMockRecorder) GenerateAuthToken(ctx, userID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAuthToken", reflect.TypeOf((*MockUserRepository)(nil).GenerateAuthToken), ctx, userID)
 }
 
+// GetByDisconnectedSpotifyID mocks base method.
+func (m *MockUserRepository) GetByDisconnectedSpotifyID(ctx context.Context, spotifyID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDisconnectedSpotifyID", ctx, spotifyID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDisconnectedSpotifyID indicates an expected call of GetByDisconnectedSpotifyID.
+func (mr *MockUserRepositoryMockRecorder) GetByDisconnectedSpotifyID(ctx, spotifyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDisconnectedSpotifyID", reflect.TypeOf((*MockUserRepository)(nil).GetByDisconnectedSpotifyID), ctx, spotifyID)
+}
+
 // GetByID mocks base method.
 func (m *MockUserRepository) GetByID(ctx context.Context, userID string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +109,50 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, userID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, userID)
 }
 
+// RevokeAuthTokens mocks base method.
+func (m *MockUserRepository) RevokeAuthTokens(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAuthTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAuthTokens indicates an expected call of RevokeAuthTokens.
+func (mr *MockUserRepositoryMockRecorder) RevokeAuthTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAuthTokens", reflect.TypeOf((*MockUserRepository)(nil).RevokeAuthTokens), ctx, userID)
+}
+
+// Search mocks base method.
+func (m *MockUserRepository) Search(ctx context.Context, query string, limit, offset int) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, offset)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUserRepositoryMockRecorder) Search(ctx, query, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserRepository)(nil).Search), ctx, query, limit, offset)
+}
+
+// SetDisabled mocks base method.
+func (m *MockUserRepository) SetDisabled(ctx context.Context, userID string, disabled bool) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", ctx, userID, disabled)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockUserRepositoryMockRecorder) SetDisabled(ctx, userID, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockUserRepository)(nil).SetDisabled), ctx, userID, disabled)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
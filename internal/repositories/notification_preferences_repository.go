@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=notification_preferences_repository.go -destination=mocks/mock_notification_preferences_repository.go -package=mocks
+
+type NotificationPreferencesRepository interface {
+	// Upsert stores the preferences of the user, replacing the previous ones
+	Upsert(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+	GetByUserID(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+}
@@ -0,0 +1,194 @@
+package pb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type APIKeyRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewAPIKeyRepositoryPocketbase(pb *pocketbase.PocketBase) *APIKeyRepositoryPocketbase {
+	return &APIKeyRepositoryPocketbase{
+		collection: CollectionAPIKey,
+		app:        pb,
+		log:        pb.Logger().With("component", "APIKeyRepositoryPocketbase"),
+	}
+}
+
+func (akRepo *APIKeyRepositoryPocketbase) Create(ctx context.Context, apiKey *models.APIKey) (*models.APIKey, error) {
+	collection, err := akRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("user_id", apiKey.UserID)
+	record.Set("name", apiKey.Name)
+	record.Set("key_hash", apiKey.KeyHash)
+	record.Set("key_prefix", apiKey.KeyPrefix)
+	record.Set("scopes", apiKey.Scopes)
+	if apiKey.ExpiresAt != nil {
+		record.Set("expires_at", *apiKey.ExpiresAt)
+	}
+
+	err = appFromContext(ctx, akRepo.app).Save(record)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to store api_key record", "user_id", apiKey.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	akRepo.log.InfoContext(ctx, "api_key stored successfully", "id", record.Id, "user_id", apiKey.UserID)
+	return recordToAPIKey(record), nil
+}
+
+func (akRepo *APIKeyRepositoryPocketbase) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	if keyHash == "" {
+		return nil, repositories.ErrAPIKeyNotFound
+	}
+
+	collection, err := akRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, akRepo.app).FindFirstRecordByFilter(collection, "key_hash = {:keyHash}", dbx.Params{"keyHash": keyHash})
+	if err != nil {
+		return nil, repositories.ErrAPIKeyNotFound
+	}
+
+	return recordToAPIKey(record), nil
+}
+
+func (akRepo *APIKeyRepositoryPocketbase) GetByUserID(ctx context.Context, userID string) ([]*models.APIKey, error) {
+	collection, err := akRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, akRepo.app).FindRecordsByFilter(
+		collection,
+		"user_id = {:userID}",
+		"-created", // Newest first
+		0,          // limit (0 = no limit)
+		0,          // offset
+		dbx.Params{"userID": userID},
+	)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to find api_key records", "user_id", userID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	apiKeys := make([]*models.APIKey, len(records))
+	for i, record := range records {
+		apiKeys[i] = recordToAPIKey(record)
+	}
+
+	return apiKeys, nil
+}
+
+func (akRepo *APIKeyRepositoryPocketbase) Delete(ctx context.Context, id, userID string) error {
+	collection, err := akRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, akRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to find api_key record", "id", id, "error", err)
+		return repositories.ErrAPIKeyNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userID {
+		akRepo.log.ErrorContext(ctx, "unauthorized delete attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return repositories.ErrUnauthorized
+	}
+
+	err = appFromContext(ctx, akRepo.app).Delete(record)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to delete api_key record", "id", id, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	akRepo.log.InfoContext(ctx, "api_key deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func (akRepo *APIKeyRepositoryPocketbase) UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error {
+	collection, err := akRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, akRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to find api_key record", "id", id, "error", err)
+		return repositories.ErrAPIKeyNotFound
+	}
+
+	record.Set("last_used_at", lastUsedAt)
+
+	err = appFromContext(ctx, akRepo.app).Save(record)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to update api_key record", "id", id, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return nil
+}
+
+func (akRepo *APIKeyRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, akRepo.app).FindCollectionByNameOrId(string(akRepo.collection))
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to find collection", "collection", akRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func recordToAPIKey(record *core.Record) *models.APIKey {
+	apiKey := &models.APIKey{
+		ID:        record.Id,
+		UserID:    record.GetString("user_id"),
+		Name:      record.GetString("name"),
+		KeyHash:   record.GetString("key_hash"),
+		KeyPrefix: record.GetString("key_prefix"),
+		Scopes:    []models.APIKeyScope{},
+		Created:   record.GetDateTime("created").Time(),
+		Updated:   record.GetDateTime("updated").Time(),
+	}
+
+	if scopesJSON := record.GetString("scopes"); scopesJSON != "" && scopesJSON != "null" {
+		_ = json.Unmarshal([]byte(scopesJSON), &apiKey.Scopes)
+	}
+
+	if lastUsedAt := record.GetDateTime("last_used_at"); !lastUsedAt.IsZero() {
+		t := lastUsedAt.Time()
+		apiKey.LastUsedAt = &t
+	}
+
+	if expiresAt := record.GetDateTime("expires_at"); !expiresAt.IsZero() {
+		t := expiresAt.Time()
+		apiKey.ExpiresAt = &t
+	}
+
+	return apiKey
+}
@@ -0,0 +1,155 @@
+package pb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepositoryPocketbase_CreateAndGetByKeyHash(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupAPIKeyCollection(t, app)
+	repo := NewAPIKeyRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.APIKey{
+		UserID:    "user123",
+		Name:      "Zapier",
+		KeyHash:   "hash123",
+		KeyPrefix: "prk_abcd",
+		Scopes:    []models.APIKeyScope{models.APIKeyScopeSyncRead, models.APIKeyScopeSyncWrite},
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal("Zapier", created.Name)
+	assert.Equal([]models.APIKeyScope{models.APIKeyScopeSyncRead, models.APIKeyScopeSyncWrite}, created.Scopes)
+	assert.Nil(created.LastUsedAt)
+	assert.Nil(created.ExpiresAt)
+
+	result, err := repo.GetByKeyHash(ctx, "hash123")
+	assert.NoError(err)
+	assert.Equal(created.ID, result.ID)
+	assert.Equal("user123", result.UserID)
+	assert.Equal("prk_abcd", result.KeyPrefix)
+
+	result, err = repo.GetByKeyHash(ctx, "unknown")
+	assert.ErrorIs(err, repositories.ErrAPIKeyNotFound)
+	assert.Nil(result)
+
+	result, err = repo.GetByKeyHash(ctx, "")
+	assert.ErrorIs(err, repositories.ErrAPIKeyNotFound)
+	assert.Nil(result)
+}
+
+func TestAPIKeyRepositoryPocketbase_Create_WithExpiry(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupAPIKeyCollection(t, app)
+	repo := NewAPIKeyRepositoryPocketbase(app)
+
+	ctx := context.Background()
+	expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := repo.Create(ctx, &models.APIKey{
+		UserID:    "user123",
+		Name:      "CI",
+		KeyHash:   "hash123",
+		KeyPrefix: "prk_abcd",
+		Scopes:    []models.APIKeyScope{models.APIKeyScopeSyncWrite},
+		ExpiresAt: &expiresAt,
+	})
+	assert.NoError(err)
+
+	result, err := repo.GetByKeyHash(ctx, "hash123")
+	assert.NoError(err)
+	assert.NotNil(result.ExpiresAt)
+	assert.True(expiresAt.Equal(*result.ExpiresAt))
+}
+
+func TestAPIKeyRepositoryPocketbase_GetByUserID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupAPIKeyCollection(t, app)
+	repo := NewAPIKeyRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	for _, apiKey := range []*models.APIKey{
+		{UserID: "user123", Name: "Zapier", KeyHash: "hash1", KeyPrefix: "prk_1"},
+		{UserID: "user123", Name: "IFTTT", KeyHash: "hash2", KeyPrefix: "prk_2"},
+		{UserID: "other_user", Name: "Zapier", KeyHash: "hash3", KeyPrefix: "prk_3"},
+	} {
+		_, err := repo.Create(ctx, apiKey)
+		assert.NoError(err)
+	}
+
+	results, err := repo.GetByUserID(ctx, "user123")
+	assert.NoError(err)
+	assert.Len(results, 2)
+	for _, result := range results {
+		assert.Equal("user123", result.UserID)
+	}
+
+	results, err = repo.GetByUserID(ctx, "nonexistent")
+	assert.NoError(err)
+	assert.Empty(results)
+}
+
+func TestAPIKeyRepositoryPocketbase_Delete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupAPIKeyCollection(t, app)
+	repo := NewAPIKeyRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.APIKey{UserID: "user123", Name: "Zapier", KeyHash: "hash123", KeyPrefix: "prk_1"})
+	assert.NoError(err)
+
+	err = repo.Delete(ctx, created.ID, "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+
+	err = repo.Delete(ctx, created.ID, "user123")
+	assert.NoError(err)
+
+	_, err = repo.GetByKeyHash(ctx, "hash123")
+	assert.ErrorIs(err, repositories.ErrAPIKeyNotFound)
+
+	err = repo.Delete(ctx, created.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyRepositoryPocketbase_UpdateLastUsed(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupAPIKeyCollection(t, app)
+	repo := NewAPIKeyRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.APIKey{UserID: "user123", Name: "Zapier", KeyHash: "hash123", KeyPrefix: "prk_1"})
+	assert.NoError(err)
+
+	lastUsedAt := time.Now().UTC().Truncate(time.Millisecond)
+	err = repo.UpdateLastUsed(ctx, created.ID, lastUsedAt)
+	assert.NoError(err)
+
+	result, err := repo.GetByKeyHash(ctx, "hash123")
+	assert.NoError(err)
+	assert.NotNil(result.LastUsedAt)
+	assert.True(lastUsedAt.Equal(*result.LastUsedAt))
+
+	err = repo.UpdateLastUsed(ctx, "nonexistent123", lastUsedAt)
+	assert.ErrorIs(err, repositories.ErrAPIKeyNotFound)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
@@ -15,6 +16,7 @@ import (
 type BasePlaylistRepositoryPocketbase struct {
 	collection Collection
 	app        *pocketbase.PocketBase
+	readDB     dbx.Builder
 	log        *slog.Logger
 }
 
@@ -22,11 +24,18 @@ func NewBasePlaylistRepositoryPocketbase(pb *pocketbase.PocketBase) *BasePlaylis
 	return &BasePlaylistRepositoryPocketbase{
 		collection: CollectionBasePlaylist,
 		app:        pb,
+		readDB:     pb.ConcurrentDB(),
 		log:        pb.Logger().With("component", "BasePlaylistRepositoryPocketbase"),
 	}
 }
 
-func (bpRepo *BasePlaylistRepositoryPocketbase) Create(ctx context.Context, userId, name, spotifyPlaylistId string) (*models.BasePlaylist, error) {
+// WithReadDB routes the list queries of the repository through a dedicated read builder
+func (bpRepo *BasePlaylistRepositoryPocketbase) WithReadDB(db dbx.Builder) *BasePlaylistRepositoryPocketbase {
+	bpRepo.readDB = db
+	return bpRepo
+}
+
+func (bpRepo *BasePlaylistRepositoryPocketbase) Create(ctx context.Context, userId, name, spotifyPlaylistId string, dedupeStrategy models.DedupeStrategy, spotifyIntegrationId string, provider models.MusicProvider) (*models.BasePlaylist, error) {
 	collection, err := bpRepo.getCollection(ctx)
 	if err != nil {
 		return nil, err
@@ -37,8 +46,15 @@ func (bpRepo *BasePlaylistRepositoryPocketbase) Create(ctx context.Context, user
 	basePlaylist.Set("name", name)
 	basePlaylist.Set("spotify_playlist_id", spotifyPlaylistId)
 	basePlaylist.Set("is_active", true)
+	basePlaylist.Set("spotify_integration_id", spotifyIntegrationId)
+	basePlaylist.Set("provider", string(provider.OrDefault()))
 
-	err = bpRepo.app.Save(basePlaylist)
+	if dedupeStrategy == "" {
+		dedupeStrategy = models.DedupeStrategyAllMatches
+	}
+	basePlaylist.Set("dedupe_strategy", string(dedupeStrategy))
+
+	err = appFromContext(ctx, bpRepo.app).Save(basePlaylist)
 	if err != nil {
 		bpRepo.log.ErrorContext(ctx, "unable to store base_playlist record", "record", basePlaylist, "error", err)
 		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
@@ -49,35 +65,143 @@ func (bpRepo *BasePlaylistRepositoryPocketbase) Create(ctx context.Context, user
 	return recordToBasePlaylist(basePlaylist), nil
 }
 
+// Delete soft deletes the base playlist and its child playlists, stamping them with the same
+// deleted_at so Restore can tell them apart from children deleted on their own
 func (bpRepo *BasePlaylistRepositoryPocketbase) Delete(ctx context.Context, id, userId string) error {
 	collection, err := bpRepo.getCollection(ctx)
 	if err != nil {
 		return err
 	}
 
-	record, err := bpRepo.app.FindRecordById(collection, id)
+	err = appFromContext(ctx, bpRepo.app).RunInTransaction(func(txApp core.App) error {
+		record, err := txApp.FindRecordById(collection, id)
+		if err != nil || isSoftDeleted(record) {
+			return repositories.ErrBasePlaylistNotFound
+		}
+
+		// Check ownership
+		if record.GetString("user_id") != userId {
+			bpRepo.log.ErrorContext(ctx, "unauthorized delete attempt",
+				"id", id,
+				"requested_by", userId,
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		deletedAt, err := cascadeDeletedAt(txApp, dbx.HashExp{"base_playlist_id": id})
+		if err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+
+		record.Set("deleted_at", deletedAt)
+		if err := txApp.Save(record); err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+
+		children, err := txApp.FindAllRecords(string(CollectionChildPlaylist), dbx.HashExp{"base_playlist_id": id}, notDeleted())
+		if err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+
+		for _, child := range children {
+			child.Set("deleted_at", deletedAt)
+			if err := txApp.Save(child); err != nil {
+				return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		bpRepo.log.ErrorContext(ctx, "unable to find base_playlist record", "id", id, "error", err)
-		return fmt.Errorf(`%w: %s`, repositories.ErrBasePlaylistNotFound, err.Error())
+		bpRepo.log.ErrorContext(ctx, "unable to delete base_playlist record", "id", id, "error", err)
+		return err
 	}
 
-	// Check ownership
-	if record.GetString("user_id") != userId {
-		bpRepo.log.ErrorContext(ctx, "unauthorized delete attempt",
-			"id", id,
-			"requested_by", userId,
+	bpRepo.log.InfoContext(ctx, "base_playlist deleted successfully", "id", id, "user_id", userId)
+	return nil
+}
+
+// Restore clears the deleted_at of the base playlist and of the child playlists deleted with it.
+// Restoring a base playlist that is not deleted returns it as is
+func (bpRepo *BasePlaylistRepositoryPocketbase) Restore(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	collection, err := bpRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *core.Record
+	err = appFromContext(ctx, bpRepo.app).RunInTransaction(func(txApp core.App) error {
+		record, err := txApp.FindRecordById(collection, id)
+		if err != nil {
+			return repositories.ErrBasePlaylistNotFound
+		}
+
+		// Check ownership
+		if record.GetString("user_id") != userId {
+			bpRepo.log.ErrorContext(ctx, "unauthorized restore attempt",
+				"id", id,
+				"requested_by", userId,
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		restored = record
+		if !isSoftDeleted(record) {
+			return nil
+		}
+
+		// The spotify playlist may have been added again as a new base playlist meanwhile
+		_, err = txApp.FindFirstRecordByFilter(collection,
+			"user_id = {:userId} && spotify_playlist_id = {:spotifyPlaylistId} && deleted_at = ''",
+			dbx.Params{"userId": userId, "spotifyPlaylistId": record.GetString("spotify_playlist_id")},
 		)
-		return repositories.ErrUnauthorized
+		if err == nil {
+			return repositories.ErrRestoreConflict
+		}
+
+		deletedAt := record.GetString("deleted_at")
+		record.Set("deleted_at", "")
+		if err := txApp.Save(record); err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+
+		children, err := txApp.FindAllRecords(string(CollectionChildPlaylist), dbx.HashExp{"base_playlist_id": id, "deleted_at": deletedAt})
+		if err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+
+		for _, child := range children {
+			child.Set("deleted_at", "")
+			if err := txApp.Save(child); err != nil {
+				return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to restore base_playlist record", "id", id, "error", err)
+		return nil, err
 	}
 
-	err = bpRepo.app.Delete(record)
+	bpRepo.log.InfoContext(ctx, "base_playlist restored successfully", "id", id, "user_id", userId)
+	return recordToBasePlaylist(restored), nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPocketbase) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	collection, err := bpRepo.getCollection(ctx)
 	if err != nil {
-		bpRepo.log.ErrorContext(ctx, "unable to delete base_playlist record", "id", id, "error", err)
-		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		return 0, err
 	}
 
-	bpRepo.log.InfoContext(ctx, "base_playlist deleted successfully", "id", id, "user_id", userId)
-	return nil
+	purged, err := purgeRecords(appFromContext(ctx, bpRepo.app), collection, deletedBefore)
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to purge base_playlist records", "deleted_before", deletedBefore, "purged", purged, "error", err)
+		return purged, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	bpRepo.log.InfoContext(ctx, "deleted base_playlists purged", "deleted_before", deletedBefore, "purged", purged)
+	return purged, nil
 }
 
 func (bpRepo *BasePlaylistRepositoryPocketbase) GetByID(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
@@ -86,8 +210,8 @@ func (bpRepo *BasePlaylistRepositoryPocketbase) GetByID(ctx context.Context, id,
 		return nil, err
 	}
 
-	record, err := bpRepo.app.FindRecordById(collection, id)
-	if err != nil {
+	record, err := appFromContext(ctx, bpRepo.app).FindRecordById(collection, id)
+	if err != nil || isSoftDeleted(record) {
 		bpRepo.log.ErrorContext(ctx, "unable to find base_playlist record", "id", id, "error", err)
 		return nil, repositories.ErrBasePlaylistNotFound
 	}
@@ -111,15 +235,12 @@ func (bpRepo *BasePlaylistRepositoryPocketbase) GetByUserID(ctx context.Context,
 		return nil, err
 	}
 
-	records, err := bpRepo.app.FindRecordsByFilter(
+	records, err := findRecordsOnReadDB(
+		ctx,
+		bpRepo.readDB,
 		collection,
-		"user_id = {:userId}",
-		"-created", // Order by created date descending (newest first)
-		0,          // limit (0 = no limit)
-		0,          // offset
-		dbx.Params{
-			"userId": userId,
-		},
+		dbx.HashExp{"user_id": userId, "deleted_at": ""},
+		"created DESC", // Newest first
 	)
 	if err != nil {
 		bpRepo.log.ErrorContext(ctx, "unable to find base_playlist records for user", "user_id", userId, "error", err)
@@ -135,8 +256,132 @@ func (bpRepo *BasePlaylistRepositoryPocketbase) GetByUserID(ctx context.Context,
 	return basePlaylists, nil
 }
 
+func (bpRepo *BasePlaylistRepositoryPocketbase) List(ctx context.Context, filter repositories.BasePlaylistFilter) ([]*models.BasePlaylist, error) {
+	collection, err := bpRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := findRecordPageOnReadDB(
+		ctx,
+		bpRepo.readDB,
+		collection,
+		basePlaylistFilterExpression(filter),
+		filter.Limit,
+		filter.Offset,
+		listOrderBy(filter.Sort)...,
+	)
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to list base_playlist records", "filter", filter, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	basePlaylists := make([]*models.BasePlaylist, len(records))
+	for i, record := range records {
+		basePlaylists[i] = recordToBasePlaylist(record)
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlists listed successfully", "filter", filter, "count", len(basePlaylists))
+	return basePlaylists, nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPocketbase) Count(ctx context.Context, filter repositories.BasePlaylistFilter) (int, error) {
+	collection, err := bpRepo.getCollection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := countRecordsOnReadDB(ctx, bpRepo.readDB, collection, basePlaylistFilterExpression(filter))
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to count base_playlist records", "filter", filter, "error", err)
+		return 0, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return total, nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPocketbase) Update(ctx context.Context, id, userId string, fields repositories.UpdateBasePlaylistFields) (*models.BasePlaylist, error) {
+	collection, err := bpRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, bpRepo.app).FindRecordById(collection, id)
+	if err != nil || isSoftDeleted(record) {
+		bpRepo.log.ErrorContext(ctx, "unable to find base_playlist record", "id", id, "error", err)
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userId {
+		bpRepo.log.ErrorContext(ctx, "unauthorized update attempt",
+			"id", id,
+			"requested_by", userId,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	// Update fields if provided
+	if fields.Name != nil {
+		record.Set("name", *fields.Name)
+	}
+
+	if fields.SpotifyPlaylistID != nil {
+		record.Set("spotify_playlist_id", *fields.SpotifyPlaylistID)
+	}
+
+	if fields.DedupeStrategy != nil {
+		record.Set("dedupe_strategy", string(*fields.DedupeStrategy))
+	}
+
+	if fields.HookToken != nil {
+		record.Set("hook_token", *fields.HookToken)
+	}
+
+	if fields.IsActive != nil {
+		record.Set("is_active", *fields.IsActive)
+	}
+
+	if fields.Suspended != nil {
+		record.Set("suspended", *fields.Suspended)
+	}
+	if fields.Archived != nil {
+		record.Set("archived", *fields.Archived)
+	}
+
+	err = appFromContext(ctx, bpRepo.app).Save(record)
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to update base_playlist record", "id", id, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlist updated successfully", "id", id)
+	return recordToBasePlaylist(record), nil
+}
+
+// GetByHookToken finds the base playlist an automation hook token belongs to, without checking
+// ownership since the token is the credential
+func (bpRepo *BasePlaylistRepositoryPocketbase) GetByHookToken(ctx context.Context, hookToken string) (*models.BasePlaylist, error) {
+	if hookToken == "" {
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+
+	collection, err := bpRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, bpRepo.app).FindFirstRecordByFilter(collection, "hook_token = {:hookToken} && deleted_at = ''", dbx.Params{"hookToken": hookToken})
+	if err != nil {
+		bpRepo.log.WarnContext(ctx, "unable to find base_playlist record by hook token", "error", err)
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+
+	return recordToBasePlaylist(record), nil
+}
+
 func (bpRepo *BasePlaylistRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
-	collection, err := bpRepo.app.FindCollectionByNameOrId(string(bpRepo.collection))
+	collection, err := appFromContext(ctx, bpRepo.app).FindCollectionByNameOrId(string(bpRepo.collection))
 	if err != nil {
 		bpRepo.log.ErrorContext(ctx, "unable to find collection", "collection", bpRepo.collection, "error", err)
 		return nil, repositories.ErrCollectionNotFound
@@ -145,14 +390,38 @@ func (bpRepo *BasePlaylistRepositoryPocketbase) getCollection(ctx context.Contex
 	return collection, nil
 }
 
+func basePlaylistFilterExpression(filter repositories.BasePlaylistFilter) dbx.Expression {
+	conditions := dbx.HashExp{"user_id": filter.UserID, "deleted_at": ""}
+	if filter.IsActive != nil {
+		conditions["is_active"] = *filter.IsActive
+	}
+	if filter.Archived != nil {
+		conditions["archived"] = *filter.Archived
+	}
+
+	return conditions
+}
+
 func recordToBasePlaylist(record *core.Record) *models.BasePlaylist {
+	// Base playlists created before dedupe strategies existed route to all matches
+	dedupeStrategy := models.DedupeStrategy(record.GetString("dedupe_strategy"))
+	if dedupeStrategy == "" {
+		dedupeStrategy = models.DedupeStrategyAllMatches
+	}
+
 	return &models.BasePlaylist{
-		ID:                record.Id,
-		UserID:            record.GetString("user_id"),
-		Name:              record.GetString("name"),
-		SpotifyPlaylistID: record.GetString("spotify_playlist_id"),
-		IsActive:          record.GetBool("is_active"),
-		Created:           record.GetDateTime("created").Time(),
-		Updated:           record.GetDateTime("updated").Time(),
+		ID:                   record.Id,
+		UserID:               record.GetString("user_id"),
+		Name:                 record.GetString("name"),
+		SpotifyPlaylistID:    record.GetString("spotify_playlist_id"),
+		IsActive:             record.GetBool("is_active"),
+		DedupeStrategy:       dedupeStrategy,
+		HookToken:            record.GetString("hook_token"),
+		Suspended:            record.GetBool("suspended"),
+		Archived:             record.GetBool("archived"),
+		SpotifyIntegrationID: record.GetString("spotify_integration_id"),
+		Provider:             models.MusicProvider(record.GetString("provider")).OrDefault(),
+		Created:              record.GetDateTime("created").Time(),
+		Updated:              record.GetDateTime("updated").Time(),
 	}
 }
@@ -3,6 +3,7 @@ package pb
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
@@ -48,7 +49,7 @@ func TestBasePlaylistRepositoryPocketbase_Create_Success(t *testing.T) {
 
 			// Execute test
 			ctx := context.Background()
-			playlist, err := repo.Create(ctx, tt.userID, tt.playlistName, tt.spotifyPlaylistID)
+			playlist, err := repo.Create(ctx, tt.userID, tt.playlistName, tt.spotifyPlaylistID, "", "", "")
 
 			// Verify success
 			assert.NoError(err)
@@ -59,12 +60,14 @@ func TestBasePlaylistRepositoryPocketbase_Create_Success(t *testing.T) {
 			assert.Equal(tt.playlistName, playlist.Name)
 			assert.Equal(tt.spotifyPlaylistID, playlist.SpotifyPlaylistID)
 			assert.True(playlist.IsActive)
+			assert.Equal(models.MusicProviderSpotify, playlist.Provider)
 			assert.NotEmpty(playlist.ID)
 
 			// Verify the playlist was actually saved to the database
 			savedPlaylist, err := findBasePlaylistInDB(t, app, playlist.ID)
 			assert.NoError(err)
 			assert.Equal(tt.userID, savedPlaylist.UserID)
+			assert.Equal(models.MusicProviderSpotify, savedPlaylist.Provider)
 		})
 	}
 }
@@ -118,7 +121,7 @@ func TestBasePlaylistRepositoryPocketbase_Create_ValidationErrors(t *testing.T)
 
 			// Execute test
 			ctx := context.Background()
-			playlist, err := repo.Create(ctx, tt.userID, tt.playlistName, tt.spotifyPlaylistID)
+			playlist, err := repo.Create(ctx, tt.userID, tt.playlistName, tt.spotifyPlaylistID, "", "", "")
 
 			// Verify error occurred
 			assert.Error(err)
@@ -142,7 +145,7 @@ func TestBasePlaylistRepositoryPocketbase_Create_DatabaseErrors(t *testing.T) {
 
 		// Execute test
 		ctx := context.Background()
-		playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123")
+		playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
 
 		// Verify error occurred
 		assert.Error(err)
@@ -156,27 +159,166 @@ func TestBasePlaylistRepositoryPocketbase_Delete_Success(t *testing.T) {
 	// Setup test environment
 	app := NewTestApp(t)
 	SetupBasePlaylistCollection(t, app)
+	SetupChildPlaylistCollection(t, app)
 	repo := NewBasePlaylistRepositoryPocketbase(app)
+	childRepo := NewChildPlaylistRepositoryPocketbase(app)
 
 	ctx := context.Background()
 
 	// First create a playlist to delete
-	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123")
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
 	assert.NoError(err)
 	assert.NotNil(playlist)
 
-	// Verify playlist exists
-	foundPlaylist, err := findBasePlaylistInDB(t, app, playlist.ID)
+	child, err := childRepo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    playlist.ID,
+		Name:              "Child",
+		SpotifyPlaylistID: "spotify_child",
+		IsActive:          true,
+	})
 	assert.NoError(err)
-	assert.Equal(playlist.ID, foundPlaylist.ID)
 
 	// Execute delete with correct user ID
 	err = repo.Delete(ctx, playlist.ID, "user123")
 	assert.NoError(err)
 
-	// Verify playlist no longer exists
+	// The record is kept until purged, but hidden from every query
 	_, err = findBasePlaylistInDB(t, app, playlist.ID)
+	assert.NoError(err)
+
+	_, err = repo.GetByID(ctx, playlist.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+
+	playlists, err := repo.GetByUserID(ctx, "user123")
+	assert.NoError(err)
+	assert.Empty(playlists)
+
+	total, err := repo.Count(ctx, repositories.BasePlaylistFilter{UserID: "user123"})
+	assert.NoError(err)
+	assert.Zero(total)
+
+	// Its child playlists are deleted with it
+	_, err = childRepo.GetByID(ctx, child.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+
+	// Deleting it again reports it missing
+	err = repo.Delete(ctx, playlist.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
+
+func TestBasePlaylistRepositoryPocketbase_Restore(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+	childRepo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+
+	createChild := func(spotifyPlaylistID string) *models.ChildPlaylist {
+		child, err := childRepo.Create(ctx, repositories.CreateChildPlaylistFields{
+			UserID:            "user123",
+			BasePlaylistID:    playlist.ID,
+			Name:              "Child " + spotifyPlaylistID,
+			SpotifyPlaylistID: spotifyPlaylistID,
+			IsActive:          true,
+		})
+		assert.NoError(err)
+		return child
+	}
+	deletedWithBase := createChild("spotify_child_1")
+	deletedBefore := createChild("spotify_child_2")
+
+	assert.NoError(childRepo.Delete(ctx, deletedBefore.ID, "user123"))
+	assert.NoError(repo.Delete(ctx, playlist.ID, "user123"))
+
+	// Other users can't restore it
+	_, err = repo.Restore(ctx, playlist.ID, "user456")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+
+	restored, err := repo.Restore(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Equal(playlist.ID, restored.ID)
+
+	_, err = repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+
+	// Only the child playlists deleted with the base playlist come back
+	children, err := childRepo.GetByBasePlaylistID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Len(children, 1)
+	assert.Equal(deletedWithBase.ID, children[0].ID)
+
+	// Restoring a live playlist is a no-op
+	_, err = repo.Restore(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+
+	_, err = repo.Restore(ctx, "nonexistent123", "user123")
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
+
+func TestBasePlaylistRepositoryPocketbase_Restore_Conflict(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+	assert.NoError(repo.Delete(ctx, playlist.ID, "user123"))
+
+	// The spotify playlist was imported again after the delete
+	_, err = repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+
+	_, err = repo.Restore(ctx, playlist.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrRestoreConflict)
+}
+
+func TestBasePlaylistRepositoryPocketbase_Purge(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	expired, err := repo.Create(ctx, "user123", "Expired", "spotify1", "", "", "")
+	assert.NoError(err)
+	recent, err := repo.Create(ctx, "user123", "Recent", "spotify2", "", "", "")
+	assert.NoError(err)
+	live, err := repo.Create(ctx, "user123", "Live", "spotify3", "", "", "")
+	assert.NoError(err)
+
+	assert.NoError(repo.Delete(ctx, expired.ID, "user123"))
+	assert.NoError(repo.Delete(ctx, recent.ID, "user123"))
+	backdateDeletion(t, app, CollectionBasePlaylist, expired.ID, time.Now().Add(-48*time.Hour))
+
+	purged, err := repo.Purge(ctx, time.Now().Add(-24*time.Hour))
+	assert.NoError(err)
+	assert.Equal(1, purged)
+
+	_, err = findBasePlaylistInDB(t, app, expired.ID)
 	assert.Error(err)
+	_, err = findBasePlaylistInDB(t, app, recent.ID)
+	assert.NoError(err)
+	_, err = findBasePlaylistInDB(t, app, live.ID)
+	assert.NoError(err)
 }
 
 func TestBasePlaylistRepositoryPocketbase_Delete_UnauthorizedError(t *testing.T) {
@@ -190,7 +332,7 @@ func TestBasePlaylistRepositoryPocketbase_Delete_UnauthorizedError(t *testing.T)
 	ctx := context.Background()
 
 	// First create a playlist owned by user123
-	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123")
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
 	assert.NoError(err)
 	assert.NotNil(playlist)
 
@@ -276,7 +418,7 @@ func TestBasePlaylistRepositoryPocketbase_GetByID_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// First create a playlist to retrieve
-	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123")
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
 	assert.NoError(err)
 	assert.NotNil(playlist)
 
@@ -304,7 +446,7 @@ func TestBasePlaylistRepositoryPocketbase_GetByID_UnauthorizedError(t *testing.T
 	ctx := context.Background()
 
 	// First create a playlist owned by user123
-	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123")
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
 	assert.NoError(err)
 	assert.NotNil(playlist)
 
@@ -424,13 +566,13 @@ func TestBasePlaylistRepositoryPocketbase_GetByUserID_Success(t *testing.T) {
 			// Create playlists for this user
 			createdPlaylists := make([]*models.BasePlaylist, 0, len(tt.playlistsToCreate))
 			for _, playlist := range tt.playlistsToCreate {
-				created, err := repo.Create(ctx, tt.userID, playlist.name, playlist.spotifyID)
+				created, err := repo.Create(ctx, tt.userID, playlist.name, playlist.spotifyID, "", "", "")
 				assert.NoError(err)
 				createdPlaylists = append(createdPlaylists, created)
 			}
 
 			// Create some playlists for a different user to ensure isolation
-			_, err := repo.Create(ctx, "otheruser", "Other User Playlist", "spotify999")
+			_, err := repo.Create(ctx, "otheruser", "Other User Playlist", "spotify999", "", "", "")
 			assert.NoError(err)
 
 			// Execute GetByUserID
@@ -532,3 +674,245 @@ func findBasePlaylistInDB(t *testing.T, app *pocketbase.PocketBase, id string) (
 
 	return recordToBasePlaylist(record), nil
 }
+
+func TestBasePlaylistRepositoryPocketbase_DedupeStrategy(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	// Empty strategy defaults to all_matches
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+	assert.Equal(models.DedupeStrategyAllMatches, playlist.DedupeStrategy)
+
+	firstMatch := models.DedupeStrategyFirstMatch
+	updatedPlaylist, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateBasePlaylistFields{
+		DedupeStrategy: &firstMatch,
+	})
+	assert.NoError(err)
+	assert.Equal(models.DedupeStrategyFirstMatch, updatedPlaylist.DedupeStrategy)
+
+	retrievedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Equal(models.DedupeStrategyFirstMatch, retrievedPlaylist.DedupeStrategy)
+}
+
+func TestBasePlaylistRepositoryPocketbase_Update(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+
+	name := "Renamed Playlist"
+	spotifyPlaylistID := "spotify456"
+	inactive := false
+	updatedPlaylist, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateBasePlaylistFields{
+		Name:              &name,
+		SpotifyPlaylistID: &spotifyPlaylistID,
+		IsActive:          &inactive,
+	})
+	assert.NoError(err)
+	assert.Equal(name, updatedPlaylist.Name)
+	assert.Equal(spotifyPlaylistID, updatedPlaylist.SpotifyPlaylistID)
+	assert.False(updatedPlaylist.IsActive)
+
+	// Fields that are not set are kept
+	assert.Equal(playlist.DedupeStrategy, updatedPlaylist.DedupeStrategy)
+
+	// Other users can't update the playlist
+	_, err = repo.Update(ctx, playlist.ID, "user456", repositories.UpdateBasePlaylistFields{Name: &name})
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+}
+
+func TestBasePlaylistRepositoryPocketbase_GetByHookToken(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+	assert.Empty(playlist.HookToken)
+
+	// Hooks disabled
+	_, err = repo.GetByHookToken(ctx, "")
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+
+	hookToken := "hook123"
+	_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateBasePlaylistFields{HookToken: &hookToken})
+	assert.NoError(err)
+
+	retrievedPlaylist, err := repo.GetByHookToken(ctx, hookToken)
+	assert.NoError(err)
+	assert.Equal(playlist.ID, retrievedPlaylist.ID)
+	assert.Equal(hookToken, retrievedPlaylist.HookToken)
+
+	// Clearing the token disables the hooks
+	emptyToken := ""
+	_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateBasePlaylistFields{HookToken: &emptyToken})
+	assert.NoError(err)
+
+	_, err = repo.GetByHookToken(ctx, hookToken)
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
+
+func TestBasePlaylistRepositoryPocketbase_Update_Errors(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+
+	// Different user can not update the playlist
+	updatedPlaylist, err := repo.Update(ctx, playlist.ID, "user456", repositories.UpdateBasePlaylistFields{})
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+	assert.Nil(updatedPlaylist)
+
+	// Non-existent playlist
+	updatedPlaylist, err = repo.Update(ctx, "nonexistent123", "user123", repositories.UpdateBasePlaylistFields{})
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+	assert.Nil(updatedPlaylist)
+}
+
+func TestBasePlaylistRepositoryPocketbase_Update_Suspended(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, "user123", "Test Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+	assert.True(playlist.IsActive)
+	assert.False(playlist.Suspended)
+
+	isActive, suspended := false, true
+	updatedPlaylist, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateBasePlaylistFields{IsActive: &isActive, Suspended: &suspended})
+	assert.NoError(err)
+	assert.False(updatedPlaylist.IsActive)
+	assert.True(updatedPlaylist.Suspended)
+
+	storedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.False(storedPlaylist.IsActive)
+	assert.True(storedPlaylist.Suspended)
+}
+
+func TestBasePlaylistRepositoryPocketbase_ListAndCount(t *testing.T) {
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	inactive, archived, unarchived := false, true, false
+	for _, name := range []string{"Bravo", "Alpha", "Delta", "Charlie"} {
+		playlist, err := repo.Create(ctx, "user123", name, "spotify_"+name, "", "", "")
+		require.NoError(t, err)
+
+		switch name {
+		case "Delta":
+			_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateBasePlaylistFields{IsActive: &inactive})
+			require.NoError(t, err)
+		case "Bravo":
+			_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateBasePlaylistFields{Archived: &archived})
+			require.NoError(t, err)
+		}
+	}
+	_, err := repo.Create(ctx, "user456", "Echo", "spotify_echo", "", "", "")
+	require.NoError(t, err)
+
+	active := true
+	tests := []struct {
+		name          string
+		filter        repositories.BasePlaylistFilter
+		expectedNames []string
+		expectedTotal int
+	}{
+		{
+			name:          "sorted by name",
+			filter:        repositories.BasePlaylistFilter{UserID: "user123", Sort: models.ListSortNameAsc, Limit: 10},
+			expectedNames: []string{"Alpha", "Bravo", "Charlie", "Delta"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "sorted by name descending and paginated",
+			filter:        repositories.BasePlaylistFilter{UserID: "user123", Sort: models.ListSortNameDesc, Limit: 2, Offset: 1},
+			expectedNames: []string{"Charlie", "Bravo"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "only active ones",
+			filter:        repositories.BasePlaylistFilter{UserID: "user123", IsActive: &active, Sort: models.ListSortNameAsc, Limit: 10},
+			expectedNames: []string{"Alpha", "Bravo", "Charlie"},
+			expectedTotal: 3,
+		},
+		{
+			name:          "only inactive ones",
+			filter:        repositories.BasePlaylistFilter{UserID: "user123", IsActive: &inactive, Limit: 10},
+			expectedNames: []string{"Delta"},
+			expectedTotal: 1,
+		},
+		{
+			name:          "only archived ones",
+			filter:        repositories.BasePlaylistFilter{UserID: "user123", Archived: &archived, Limit: 10},
+			expectedNames: []string{"Bravo"},
+			expectedTotal: 1,
+		},
+		{
+			name:          "only unarchived ones",
+			filter:        repositories.BasePlaylistFilter{UserID: "user123", Archived: &unarchived, Sort: models.ListSortNameAsc, Limit: 10},
+			expectedNames: []string{"Alpha", "Charlie", "Delta"},
+			expectedTotal: 3,
+		},
+		{
+			name:          "other user",
+			filter:        repositories.BasePlaylistFilter{UserID: "user789", Limit: 10},
+			expectedNames: []string{},
+			expectedTotal: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			basePlaylists, err := repo.List(ctx, tt.filter)
+			assert.NoError(err)
+
+			names := make([]string, len(basePlaylists))
+			for i, basePlaylist := range basePlaylists {
+				names[i] = basePlaylist.Name
+			}
+			assert.Equal(tt.expectedNames, names)
+
+			total, err := repo.Count(ctx, tt.filter)
+			assert.NoError(err)
+			assert.Equal(tt.expectedTotal, total)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type BasePlaylistWatchRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewBasePlaylistWatchRepositoryPocketbase(pb *pocketbase.PocketBase) *BasePlaylistWatchRepositoryPocketbase {
+	return &BasePlaylistWatchRepositoryPocketbase{
+		collection: CollectionBasePlaylistWatch,
+		app:        pb,
+		log:        pb.Logger().With("component", "BasePlaylistWatchRepositoryPocketbase"),
+	}
+}
+
+func (bwRepo *BasePlaylistWatchRepositoryPocketbase) Upsert(ctx context.Context, watch *models.BasePlaylistWatch) (*models.BasePlaylistWatch, error) {
+	collection, err := GetCollection(ctx, bwRepo.app, bwRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := bwRepo.findRecordByBasePlaylistID(ctx, collection, watch.BasePlaylistID)
+	if err != nil {
+		record = core.NewRecord(collection)
+	} else if record.GetString("user_id") != watch.UserID {
+		bwRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"base_playlist_id", watch.BasePlaylistID,
+			"user_id", watch.UserID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	trackURIs := watch.TrackURIs
+	if trackURIs == nil {
+		trackURIs = []string{}
+	}
+
+	record.Set("user_id", watch.UserID)
+	record.Set("base_playlist_id", watch.BasePlaylistID)
+	record.Set("snapshot_id", watch.SnapshotID)
+	record.Set("track_uris", trackURIs)
+
+	err = appFromContext(ctx, bwRepo.app).Save(record)
+	if err != nil {
+		bwRepo.log.ErrorContext(ctx, "unable to store base_playlist_watch record", "base_playlist_id", watch.BasePlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return recordToBasePlaylistWatch(record), nil
+}
+
+func (bwRepo *BasePlaylistWatchRepositoryPocketbase) GetByBasePlaylistID(ctx context.Context, basePlaylistID string) (*models.BasePlaylistWatch, error) {
+	collection, err := GetCollection(ctx, bwRepo.app, bwRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := bwRepo.findRecordByBasePlaylistID(ctx, collection, basePlaylistID)
+	if err != nil {
+		return nil, repositories.ErrBasePlaylistWatchNotFound
+	}
+
+	return recordToBasePlaylistWatch(record), nil
+}
+
+func (bwRepo *BasePlaylistWatchRepositoryPocketbase) findRecordByBasePlaylistID(ctx context.Context, collection *core.Collection, basePlaylistID string) (*core.Record, error) {
+	return appFromContext(ctx, bwRepo.app).FindFirstRecordByFilter(collection, "base_playlist_id = {:basePlaylistID}", dbx.Params{"basePlaylistID": basePlaylistID})
+}
+
+func recordToBasePlaylistWatch(record *core.Record) *models.BasePlaylistWatch {
+	watch := &models.BasePlaylistWatch{
+		ID:             record.Id,
+		UserID:         record.GetString("user_id"),
+		BasePlaylistID: record.GetString("base_playlist_id"),
+		SnapshotID:     record.GetString("snapshot_id"),
+		Created:        record.GetDateTime("created").Time(),
+		Updated:        record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("track_uris", &watch.TrackURIs); err != nil || watch.TrackURIs == nil {
+		watch.TrackURIs = []string{}
+	}
+
+	return watch
+}
@@ -0,0 +1,70 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasePlaylistWatchRepositoryPocketbase_Upsert(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupBasePlaylistWatchCollection(t, app)
+	repo := NewBasePlaylistWatchRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Upsert(ctx, &models.BasePlaylistWatch{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		SnapshotID:     "snap1",
+		TrackURIs:      []string{"spotify:track:1"},
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+
+	// A new snapshot replaces the stored baseline for the same base playlist
+	updated, err := repo.Upsert(ctx, &models.BasePlaylistWatch{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		SnapshotID:     "snap2",
+	})
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.Equal("snap2", updated.SnapshotID)
+	assert.Empty(updated.TrackURIs)
+
+	_, err = repo.Upsert(ctx, &models.BasePlaylistWatch{UserID: "other_user", BasePlaylistID: "base123"})
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+}
+
+func TestBasePlaylistWatchRepositoryPocketbase_GetByBasePlaylistID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupBasePlaylistWatchCollection(t, app)
+	repo := NewBasePlaylistWatchRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	_, err := repo.Upsert(ctx, &models.BasePlaylistWatch{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		SnapshotID:     "snap1",
+		TrackURIs:      []string{"spotify:track:1", "spotify:track:2"},
+	})
+	assert.NoError(err)
+
+	result, err := repo.GetByBasePlaylistID(ctx, "base123")
+	assert.NoError(err)
+	assert.Equal("snap1", result.SnapshotID)
+	assert.Equal([]string{"spotify:track:1", "spotify:track:2"}, result.TrackURIs)
+
+	result, err = repo.GetByBasePlaylistID(ctx, "nonexistent")
+	assert.ErrorIs(err, repositories.ErrBasePlaylistWatchNotFound)
+	assert.Nil(result)
+}
@@ -0,0 +1,119 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type BlocklistEntryRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewBlocklistEntryRepositoryPocketbase(pb *pocketbase.PocketBase) *BlocklistEntryRepositoryPocketbase {
+	return &BlocklistEntryRepositoryPocketbase{
+		collection: CollectionBlocklistEntry,
+		app:        pb,
+		log:        pb.Logger().With("component", "BlocklistEntryRepositoryPocketbase"),
+	}
+}
+
+func (beRepo *BlocklistEntryRepositoryPocketbase) Create(ctx context.Context, entry *models.BlocklistEntry) (*models.BlocklistEntry, error) {
+	collection, err := GetCollection(ctx, beRepo.app, beRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("user_id", entry.UserID)
+	record.Set("base_playlist_id", entry.BasePlaylistID)
+	record.Set("type", string(entry.Type))
+	record.Set("value", entry.Value)
+
+	err = appFromContext(ctx, beRepo.app).Save(record)
+	if err != nil {
+		beRepo.log.ErrorContext(ctx, "unable to store blocklist_entry record", "base_playlist_id", entry.BasePlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	beRepo.log.InfoContext(ctx, "blocklist_entry stored successfully", "id", record.Id, "base_playlist_id", entry.BasePlaylistID)
+	return recordToBlocklistEntry(record), nil
+}
+
+func (beRepo *BlocklistEntryRepositoryPocketbase) GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.BlocklistEntry, error) {
+	collection, err := GetCollection(ctx, beRepo.app, beRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, beRepo.app).FindRecordsByFilter(
+		collection,
+		"base_playlist_id = {:basePlaylistID} && user_id = {:userID}",
+		"created",
+		0,
+		0,
+		dbx.Params{"basePlaylistID": basePlaylistID, "userID": userID},
+	)
+	if err != nil {
+		beRepo.log.ErrorContext(ctx, "unable to find blocklist_entry records", "base_playlist_id", basePlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	entries := make([]*models.BlocklistEntry, len(records))
+	for i, record := range records {
+		entries[i] = recordToBlocklistEntry(record)
+	}
+
+	return entries, nil
+}
+
+func (beRepo *BlocklistEntryRepositoryPocketbase) Delete(ctx context.Context, id, userID string) error {
+	collection, err := GetCollection(ctx, beRepo.app, beRepo.collection)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, beRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		return repositories.ErrBlocklistEntryNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userID {
+		beRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return repositories.ErrUnauthorized
+	}
+
+	err = appFromContext(ctx, beRepo.app).Delete(record)
+	if err != nil {
+		beRepo.log.ErrorContext(ctx, "unable to delete blocklist_entry record", "id", id, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	beRepo.log.InfoContext(ctx, "blocklist_entry deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func recordToBlocklistEntry(record *core.Record) *models.BlocklistEntry {
+	return &models.BlocklistEntry{
+		ID:             record.Id,
+		UserID:         record.GetString("user_id"),
+		BasePlaylistID: record.GetString("base_playlist_id"),
+		Type:           models.BlocklistEntryType(record.GetString("type")),
+		Value:          record.GetString("value"),
+		Created:        record.GetDateTime("created").Time(),
+		Updated:        record.GetDateTime("updated").Time(),
+	}
+}
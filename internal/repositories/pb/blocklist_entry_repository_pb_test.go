@@ -0,0 +1,75 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocklistEntryRepositoryPocketbase_CreateAndList(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupBlocklistEntryCollection(t, app)
+	repo := NewBlocklistEntryRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.BlocklistEntry{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Type:           models.BlocklistEntryTypeTrack,
+		Value:          "spotify:track:1",
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal(models.BlocklistEntryTypeTrack, created.Type)
+	assert.Equal("spotify:track:1", created.Value)
+
+	_, err = repo.Create(ctx, &models.BlocklistEntry{
+		UserID:         "user123",
+		BasePlaylistID: "base456",
+		Type:           models.BlocklistEntryTypeArtist,
+		Value:          "artist1",
+	})
+	assert.NoError(err)
+
+	entries, err := repo.GetByBasePlaylistID(ctx, "base123", "user123")
+	assert.NoError(err)
+	assert.Len(entries, 1)
+	assert.Equal(created.ID, entries[0].ID)
+
+	entries, err = repo.GetByBasePlaylistID(ctx, "base123", "other_user")
+	assert.NoError(err)
+	assert.Empty(entries)
+}
+
+func TestBlocklistEntryRepositoryPocketbase_Delete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupBlocklistEntryCollection(t, app)
+	repo := NewBlocklistEntryRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.BlocklistEntry{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Type:           models.BlocklistEntryTypeArtist,
+		Value:          "artist1",
+	})
+	assert.NoError(err)
+
+	err = repo.Delete(ctx, created.ID, "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+
+	err = repo.Delete(ctx, created.ID, "user123")
+	assert.NoError(err)
+
+	err = repo.Delete(ctx, created.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrBlocklistEntryNotFound)
+}
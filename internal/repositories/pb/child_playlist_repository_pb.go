@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 type ChildPlaylistRepositoryPocketbase struct {
@@ -43,6 +45,14 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) Create(
 	childPlaylist.Set("description", fields.Description)
 	childPlaylist.Set("spotify_playlist_id", fields.SpotifyPlaylistID)
 	childPlaylist.Set("is_active", fields.IsActive)
+	childPlaylist.Set("is_fallback", fields.IsFallback)
+	childPlaylist.Set("priority", fields.Priority)
+	childPlaylist.Set("max_tracks", fields.MaxTracks)
+	childPlaylist.Set("selection_strategy", string(fields.SelectionStrategy))
+	childPlaylist.Set("source_base_playlist_ids", fields.SourceBasePlaylistIDs)
+	childPlaylist.Set("refollow_recreated", fields.RefollowRecreated)
+	childPlaylist.Set("spotify_integration_id", fields.SpotifyIntegrationID)
+	childPlaylist.Set("provider", string(fields.Provider.OrDefault()))
 
 	// Serialize filter rules to JSON
 	if fields.FilterRules != nil {
@@ -55,7 +65,7 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) Create(
 		childPlaylist.Set("filter_rules", string(filterRulesJSON))
 	}
 
-	err = cpRepo.app.Save(childPlaylist)
+	err = appFromContext(ctx, cpRepo.app).Save(childPlaylist)
 	if err != nil {
 		cpRepo.log.ErrorContext(ctx, "unable to store child_playlist record", "record", childPlaylist, "error", err)
 		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
@@ -66,16 +76,17 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) Create(
 	return recordToChildPlaylist(childPlaylist), nil
 }
 
+// Delete soft deletes the child playlist, it is purged once its retention expires
 func (cpRepo *ChildPlaylistRepositoryPocketbase) Delete(ctx context.Context, id, userID string) error {
 	collection, err := cpRepo.getCollection(ctx)
 	if err != nil {
 		return err
 	}
 
-	record, err := cpRepo.app.FindRecordById(collection, id)
-	if err != nil {
+	record, err := appFromContext(ctx, cpRepo.app).FindRecordById(collection, id)
+	if err != nil || isSoftDeleted(record) {
 		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist record", "id", id, "error", err)
-		return fmt.Errorf(`%w: %s`, repositories.ErrChildPlaylistNotFound, err.Error())
+		return repositories.ErrChildPlaylistNotFound
 	}
 
 	// Check ownership (belongs to the specified user)
@@ -88,7 +99,8 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) Delete(ctx context.Context, id,
 		return repositories.ErrUnauthorized
 	}
 
-	err = cpRepo.app.Delete(record)
+	record.Set("deleted_at", types.NowDateTime())
+	err = appFromContext(ctx, cpRepo.app).Save(record)
 	if err != nil {
 		cpRepo.log.ErrorContext(ctx, "unable to delete child_playlist record", "id", id, "error", err)
 		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
@@ -98,14 +110,84 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) Delete(ctx context.Context, id,
 	return nil
 }
 
-func (cpRepo *ChildPlaylistRepositoryPocketbase) GetByID(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+// Restore clears the deleted_at of the child playlist. Children of a deleted base playlist come
+// back by restoring the base playlist instead. Restoring a child that is not deleted returns it as is
+func (cpRepo *ChildPlaylistRepositoryPocketbase) Restore(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
 	collection, err := cpRepo.getCollection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	record, err := cpRepo.app.FindRecordById(collection, id)
+	record, err := appFromContext(ctx, cpRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist record", "id", id, "error", err)
+		return nil, repositories.ErrChildPlaylistNotFound
+	}
+
+	// Check ownership (belongs to the specified user)
+	if record.GetString("user_id") != userID {
+		cpRepo.log.ErrorContext(ctx, "unauthorized restore attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	if !isSoftDeleted(record) {
+		return recordToChildPlaylist(record), nil
+	}
+
+	basePlaylist, err := appFromContext(ctx, cpRepo.app).FindRecordById(string(CollectionBasePlaylist), record.GetString("base_playlist_id"))
+	if err != nil || isSoftDeleted(basePlaylist) {
+		cpRepo.log.ErrorContext(ctx, "unable to restore child_playlist of deleted base playlist", "id", id, "base_playlist_id", record.GetString("base_playlist_id"))
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+
+	// The spotify playlist may have been added again as a new child playlist meanwhile
+	_, err = appFromContext(ctx, cpRepo.app).FindFirstRecordByFilter(collection,
+		"base_playlist_id = {:basePlaylistID} && spotify_playlist_id = {:spotifyPlaylistID} && deleted_at = ''",
+		dbx.Params{"basePlaylistID": record.GetString("base_playlist_id"), "spotifyPlaylistID": record.GetString("spotify_playlist_id")},
+	)
+	if err == nil {
+		return nil, repositories.ErrRestoreConflict
+	}
+
+	record.Set("deleted_at", "")
+	err = appFromContext(ctx, cpRepo.app).Save(record)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to restore child_playlist record", "id", id, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	cpRepo.log.InfoContext(ctx, "child_playlist restored successfully", "id", id, "user_id", userID)
+	return recordToChildPlaylist(record), nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPocketbase) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	collection, err := cpRepo.getCollection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	purged, err := purgeRecords(appFromContext(ctx, cpRepo.app), collection, deletedBefore)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to purge child_playlist records", "deleted_before", deletedBefore, "purged", purged, "error", err)
+		return purged, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	cpRepo.log.InfoContext(ctx, "deleted child_playlists purged", "deleted_before", deletedBefore, "purged", purged)
+	return purged, nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPocketbase) GetByID(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	collection, err := cpRepo.getCollection(ctx)
 	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, cpRepo.app).FindRecordById(collection, id)
+	if err != nil || isSoftDeleted(record) {
 		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist record", "id", id, "error", err)
 		return nil, repositories.ErrChildPlaylistNotFound
 	}
@@ -130,9 +212,9 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) GetByBasePlaylistID(ctx context
 		return nil, err
 	}
 
-	records, err := cpRepo.app.FindRecordsByFilter(
+	records, err := appFromContext(ctx, cpRepo.app).FindRecordsByFilter(
 		collection,
-		"base_playlist_id = {:basePlaylistID} && user_id = {:userID}",
+		"base_playlist_id = {:basePlaylistID} && user_id = {:userID} && deleted_at = ''",
 		"-created", // Order by created date descending (newest first)
 		0,          // limit (0 = no limit)
 		0,          // offset
@@ -155,58 +237,107 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) GetByBasePlaylistID(ctx context
 	return childPlaylists, nil
 }
 
-func (cpRepo *ChildPlaylistRepositoryPocketbase) Update(ctx context.Context, id, userID string, fields repositories.UpdateChildPlaylistFields) (*models.ChildPlaylist, error) {
+func (cpRepo *ChildPlaylistRepositoryPocketbase) GetBySourceBasePlaylistID(ctx context.Context, sourceBasePlaylistID, userID string) ([]*models.ChildPlaylist, error) {
 	collection, err := cpRepo.getCollection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	record, err := cpRepo.app.FindRecordById(collection, id)
+	// Sources are stored as a JSON array of base playlist IDs, older records may hold an empty value
+	var records []*core.Record
+	err = appFromContext(ctx, cpRepo.app).RecordQuery(collection).
+		AndWhere(dbx.HashExp{"user_id": userID}).
+		AndWhere(notDeleted()).
+		AndWhere(dbx.NewExp(
+			"EXISTS (SELECT 1 FROM json_each(CASE WHEN json_valid([[source_base_playlist_ids]]) THEN [[source_base_playlist_ids]] ELSE '[]' END) WHERE json_each.value = {:sourceBasePlaylistID})",
+			dbx.Params{"sourceBasePlaylistID": sourceBasePlaylistID},
+		)).
+		OrderBy("created DESC"). // Newest first
+		WithContext(ctx).
+		All(&records)
 	if err != nil {
-		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist record", "id", id, "error", err)
-		return nil, repositories.ErrChildPlaylistNotFound
+		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist records merging base playlist", "source_base_playlist_id", sourceBasePlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
 	}
 
-	// Check ownership (belongs to the specified user)
-	if record.GetString("user_id") != userID {
-		cpRepo.log.ErrorContext(ctx, "unauthorized update attempt",
-			"id", id,
-			"user_id", userID,
-			"actual_user_id", record.GetString("user_id"),
-		)
-		return nil, repositories.ErrUnauthorized
+	childPlaylists := make([]*models.ChildPlaylist, len(records))
+	for i, record := range records {
+		childPlaylists[i] = recordToChildPlaylist(record)
 	}
 
-	// Update fields if provided
-	if fields.Name != nil {
-		record.Set("name", *fields.Name)
+	cpRepo.log.InfoContext(ctx, "merge child_playlists retrieved successfully", "source_base_playlist_id", sourceBasePlaylistID, "user_id", userID, "count", len(childPlaylists))
+	return childPlaylists, nil
+}
+
+// GetByShareToken finds a shared child playlist without checking ownership, since the share token is the credential
+func (cpRepo *ChildPlaylistRepositoryPocketbase) GetByShareToken(ctx context.Context, shareToken string) (*models.ChildPlaylist, error) {
+	if shareToken == "" {
+		return nil, repositories.ErrChildPlaylistNotFound
 	}
 
-	if fields.Description != nil {
-		record.Set("description", *fields.Description)
+	collection, err := cpRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if fields.IsActive != nil {
-		record.Set("is_active", *fields.IsActive)
+	record, err := appFromContext(ctx, cpRepo.app).FindFirstRecordByFilter(collection, "share_token = {:shareToken} && deleted_at = ''", dbx.Params{"shareToken": shareToken})
+	if err != nil {
+		cpRepo.log.WarnContext(ctx, "unable to find shared child_playlist record", "error", err)
+		return nil, repositories.ErrChildPlaylistNotFound
 	}
 
-	if fields.SpotifyPlaylistID != nil {
-		record.Set("spotify_playlist_id", *fields.SpotifyPlaylistID)
+	return recordToChildPlaylist(record), nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPocketbase) Update(ctx context.Context, id, userID string, fields repositories.UpdateChildPlaylistFields) (*models.ChildPlaylist, error) {
+	collection, err := cpRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if fields.FilterRules != nil {
-		filterRulesJSON, err := json.Marshal(fields.FilterRules)
-		if err != nil {
-			cpRepo.log.ErrorContext(ctx, "unable to serialize filter rules", "filter_rules", fields.FilterRules, "error", err)
-			return nil, fmt.Errorf(`%w: failed to serialize filter rules: %s`, repositories.ErrDatabaseOperation, err.Error())
+	// The record is read and saved in a transaction, so no other write lands between the check of
+	// the expected updated and the save
+	var record *core.Record
+	err = appFromContext(ctx, cpRepo.app).RunInTransaction(func(txApp core.App) error {
+		record, err = txApp.FindRecordById(collection, id)
+		if err != nil || isSoftDeleted(record) {
+			cpRepo.log.ErrorContext(ctx, "unable to find child_playlist record", "id", id, "error", err)
+			return repositories.ErrChildPlaylistNotFound
+		}
+
+		// Check ownership (belongs to the specified user)
+		if record.GetString("user_id") != userID {
+			cpRepo.log.ErrorContext(ctx, "unauthorized update attempt",
+				"id", id,
+				"user_id", userID,
+				"actual_user_id", record.GetString("user_id"),
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		// updated is stored with millisecond precision, while the record returned by the save that set
+		// it keeps the full precision
+		if fields.ExpectedUpdated != nil && !record.GetDateTime("updated").Time().Equal(fields.ExpectedUpdated.Truncate(time.Millisecond)) {
+			cpRepo.log.WarnContext(ctx, "child_playlist modified since it was read",
+				"id", id,
+				"expected_updated", *fields.ExpectedUpdated,
+				"updated", record.GetDateTime("updated").Time(),
+			)
+			return repositories.ErrChildPlaylistModified
+		}
+
+		if err := setChildPlaylistFields(record, fields); err != nil {
+			return err
 		}
-		record.Set("filter_rules", string(filterRulesJSON))
-	}
 
-	err = cpRepo.app.Save(record)
+		if err := txApp.Save(record); err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+		return nil
+	})
 	if err != nil {
 		cpRepo.log.ErrorContext(ctx, "unable to update child_playlist record", "id", id, "error", err)
-		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		return nil, err
 	}
 
 	cpRepo.log.InfoContext(ctx, "child_playlist updated successfully", "id", id)
@@ -214,7 +345,7 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) Update(ctx context.Context, id,
 }
 
 func (cpRepo *ChildPlaylistRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
-	collection, err := cpRepo.app.FindCollectionByNameOrId(string(cpRepo.collection))
+	collection, err := appFromContext(ctx, cpRepo.app).FindCollectionByNameOrId(string(cpRepo.collection))
 	if err != nil {
 		cpRepo.log.ErrorContext(ctx, "unable to find collection", "collection", cpRepo.collection, "error", err)
 		return nil, repositories.ErrCollectionNotFound
@@ -223,17 +354,98 @@ func (cpRepo *ChildPlaylistRepositoryPocketbase) getCollection(ctx context.Conte
 	return collection, nil
 }
 
+// setChildPlaylistFields sets the fields of an update on the record, leaving the nil ones untouched
+func setChildPlaylistFields(record *core.Record, fields repositories.UpdateChildPlaylistFields) error {
+	if fields.Name != nil {
+		record.Set("name", *fields.Name)
+	}
+
+	if fields.Description != nil {
+		record.Set("description", *fields.Description)
+	}
+
+	if fields.IsActive != nil {
+		record.Set("is_active", *fields.IsActive)
+	}
+
+	if fields.SpotifyPlaylistID != nil {
+		record.Set("spotify_playlist_id", *fields.SpotifyPlaylistID)
+	}
+
+	if fields.IsFallback != nil {
+		record.Set("is_fallback", *fields.IsFallback)
+	}
+
+	if fields.Priority != nil {
+		record.Set("priority", *fields.Priority)
+	}
+
+	if fields.ShareToken != nil {
+		record.Set("share_token", *fields.ShareToken)
+	}
+
+	if fields.MaxTracks != nil {
+		record.Set("max_tracks", *fields.MaxTracks)
+	}
+
+	if fields.SelectionStrategy != nil {
+		record.Set("selection_strategy", string(*fields.SelectionStrategy))
+	}
+
+	if fields.SyncStrategy != nil {
+		record.Set("sync_strategy", string(*fields.SyncStrategy))
+	}
+
+	if fields.SourceBasePlaylistIDs != nil {
+		record.Set("source_base_playlist_ids", *fields.SourceBasePlaylistIDs)
+	}
+
+	if fields.PinnedTracks != nil {
+		record.Set("pinned_tracks", *fields.PinnedTracks)
+	}
+
+	if fields.RefollowRecreated != nil {
+		record.Set("refollow_recreated", *fields.RefollowRecreated)
+	}
+
+	if fields.Suspended != nil {
+		record.Set("suspended", *fields.Suspended)
+	}
+
+	if fields.FilterRules != nil {
+		filterRulesJSON, err := json.Marshal(fields.FilterRules)
+		if err != nil {
+			return fmt.Errorf(`%w: failed to serialize filter rules: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+		record.Set("filter_rules", string(filterRulesJSON))
+	}
+
+	return nil
+}
+
 func recordToChildPlaylist(record *core.Record) *models.ChildPlaylist {
 	childPlaylist := &models.ChildPlaylist{
-		ID:                record.Id,
-		UserID:            record.GetString("user_id"),
-		BasePlaylistID:    record.GetString("base_playlist_id"),
-		Name:              record.GetString("name"),
-		Description:       record.GetString("description"),
-		SpotifyPlaylistID: record.GetString("spotify_playlist_id"),
-		IsActive:          record.GetBool("is_active"),
-		Created:           record.GetDateTime("created").Time(),
-		Updated:           record.GetDateTime("updated").Time(),
+		ID:                    record.Id,
+		UserID:                record.GetString("user_id"),
+		BasePlaylistID:        record.GetString("base_playlist_id"),
+		Name:                  record.GetString("name"),
+		Description:           record.GetString("description"),
+		SpotifyPlaylistID:     record.GetString("spotify_playlist_id"),
+		IsActive:              record.GetBool("is_active"),
+		IsFallback:            record.GetBool("is_fallback"),
+		Priority:              record.GetInt("priority"),
+		ShareToken:            record.GetString("share_token"),
+		MaxTracks:             record.GetInt("max_tracks"),
+		SelectionStrategy:     models.SelectionStrategy(record.GetString("selection_strategy")),
+		SyncStrategy:          models.SyncStrategy(record.GetString("sync_strategy")),
+		SourceBasePlaylistIDs: record.GetStringSlice("source_base_playlist_ids"),
+		PinnedTracks:          record.GetStringSlice("pinned_tracks"),
+		RefollowRecreated:     record.GetBool("refollow_recreated"),
+		Suspended:             record.GetBool("suspended"),
+		SpotifyIntegrationID:  record.GetString("spotify_integration_id"),
+		Provider:              models.MusicProvider(record.GetString("provider")).OrDefault(),
+		Created:               record.GetDateTime("created").Time(),
+		Updated:               record.GetDateTime("updated").Time(),
 	}
 
 	// Deserialize filter rules from JSON
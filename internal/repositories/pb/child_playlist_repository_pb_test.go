@@ -3,6 +3,7 @@ package pb
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
@@ -87,11 +88,13 @@ func TestChildPlaylistRepositoryPocketbase_Create_Success(t *testing.T) {
 			assert.True(playlist.IsActive)
 			assert.NotEmpty(playlist.ID)
 			assert.Equal(tt.filterRules, playlist.FilterRules)
+			assert.Equal(models.MusicProviderSpotify, playlist.Provider)
 
 			// Verify the playlist was actually saved to the database
 			savedPlaylist, err := findChildPlaylistInDB(t, app, playlist.ID)
 			assert.NoError(err)
 			assert.Equal(tt.userID, savedPlaylist.UserID)
+			assert.Equal(models.MusicProviderSpotify, savedPlaylist.Provider)
 		})
 	}
 }
@@ -216,9 +219,131 @@ func TestChildPlaylistRepositoryPocketbase_Delete_Success(t *testing.T) {
 	err = repo.Delete(ctx, playlist.ID, "user123")
 	assert.NoError(err)
 
-	// Verify playlist no longer exists
+	// The record is kept until purged, but hidden from every query
 	_, err = findChildPlaylistInDB(t, app, playlist.ID)
+	assert.NoError(err)
+
+	_, err = repo.GetByID(ctx, playlist.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+
+	children, err := repo.GetByBasePlaylistID(ctx, "base123", "user123")
+	assert.NoError(err)
+	assert.Empty(children)
+}
+
+func TestChildPlaylistRepositoryPocketbase_Restore(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+	baseRepo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	basePlaylist, err := baseRepo.Create(ctx, "user123", "Base", "spotify_base", "", "", "")
+	assert.NoError(err)
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    basePlaylist.ID,
+		Name:              "Test Playlist",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+	})
+	assert.NoError(err)
+	assert.NoError(repo.Delete(ctx, playlist.ID, "user123"))
+
+	// Other users can't restore it
+	_, err = repo.Restore(ctx, playlist.ID, "user456")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+
+	restored, err := repo.Restore(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Equal(playlist.ID, restored.ID)
+
+	_, err = repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+
+	// Children of a deleted base playlist come back with the base playlist only
+	assert.NoError(repo.Delete(ctx, playlist.ID, "user123"))
+	assert.NoError(baseRepo.Delete(ctx, basePlaylist.ID, "user123"))
+
+	_, err = repo.Restore(ctx, playlist.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
+
+func TestChildPlaylistRepositoryPocketbase_Restore_Conflict(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+	baseRepo := NewBasePlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	basePlaylist, err := baseRepo.Create(ctx, "user123", "Base", "spotify_base", "", "", "")
+	assert.NoError(err)
+
+	fields := repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    basePlaylist.ID,
+		Name:              "Test Playlist",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+	}
+	playlist, err := repo.Create(ctx, fields)
+	assert.NoError(err)
+	assert.NoError(repo.Delete(ctx, playlist.ID, "user123"))
+
+	_, err = repo.Create(ctx, fields)
+	assert.NoError(err)
+
+	_, err = repo.Restore(ctx, playlist.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrRestoreConflict)
+}
+
+func TestChildPlaylistRepositoryPocketbase_Purge(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	createChild := func(spotifyPlaylistID string) *models.ChildPlaylist {
+		child, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+			UserID:            "user123",
+			BasePlaylistID:    "base123",
+			Name:              "Child " + spotifyPlaylistID,
+			SpotifyPlaylistID: spotifyPlaylistID,
+			IsActive:          true,
+		})
+		assert.NoError(err)
+		return child
+	}
+	expired := createChild("spotify1")
+	recent := createChild("spotify2")
+
+	assert.NoError(repo.Delete(ctx, expired.ID, "user123"))
+	assert.NoError(repo.Delete(ctx, recent.ID, "user123"))
+	backdateDeletion(t, app, CollectionChildPlaylist, expired.ID, time.Now().Add(-48*time.Hour))
+
+	purged, err := repo.Purge(ctx, time.Now().Add(-24*time.Hour))
+	assert.NoError(err)
+	assert.Equal(1, purged)
+
+	_, err = findChildPlaylistInDB(t, app, expired.ID)
 	assert.Error(err)
+	_, err = findChildPlaylistInDB(t, app, recent.ID)
+	assert.NoError(err)
 }
 
 func TestChildPlaylistRepositoryPocketbase_Delete_UnauthorizedError(t *testing.T) {
@@ -602,6 +727,304 @@ func TestChildPlaylistRepositoryPocketbase_Update_PartialUpdate(t *testing.T) {
 	assert.Equal(playlist.IsActive, updatedPlaylist.IsActive)       // Unchanged
 }
 
+func TestChildPlaylistRepositoryPocketbase_PriorityAndFallback(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Child",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+		IsFallback:        true,
+		Priority:          2,
+	})
+	assert.NoError(err)
+	assert.Equal(2, playlist.Priority)
+	assert.True(playlist.IsFallback)
+
+	priority := 0
+	isFallback := false
+	updatedPlaylist, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{Priority: &priority, IsFallback: &isFallback})
+	assert.NoError(err)
+	assert.Equal(0, updatedPlaylist.Priority)
+	assert.False(updatedPlaylist.IsFallback)
+
+	storedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Equal(0, storedPlaylist.Priority)
+	assert.Equal("Child", storedPlaylist.Name) // Unchanged
+}
+
+func TestChildPlaylistRepositoryPocketbase_GetByShareToken(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Shared Child",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+	})
+	assert.NoError(err)
+	assert.Empty(playlist.ShareToken)
+
+	// Not shared yet
+	_, err = repo.GetByShareToken(ctx, "")
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+
+	shareToken := "token123"
+	_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{ShareToken: &shareToken})
+	assert.NoError(err)
+
+	sharedPlaylist, err := repo.GetByShareToken(ctx, shareToken)
+	assert.NoError(err)
+	assert.Equal(playlist.ID, sharedPlaylist.ID)
+	assert.Equal(shareToken, sharedPlaylist.ShareToken)
+
+	// Clearing the token revokes access
+	emptyToken := ""
+	_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{ShareToken: &emptyToken})
+	assert.NoError(err)
+
+	_, err = repo.GetByShareToken(ctx, shareToken)
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+}
+
+func TestChildPlaylistRepositoryPocketbase_MaxTracks(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Top 50",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+		MaxTracks:         50,
+		SelectionStrategy: models.SelectionMostPopular,
+	})
+	assert.NoError(err)
+	assert.Equal(50, playlist.MaxTracks)
+	assert.Equal(models.SelectionMostPopular, playlist.SelectionStrategy)
+
+	maxTracks, strategy := 0, models.SelectionNewest
+	updated, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{MaxTracks: &maxTracks, SelectionStrategy: &strategy})
+	assert.NoError(err)
+	assert.Zero(updated.MaxTracks)
+	assert.Equal(models.SelectionNewest, updated.SelectionStrategy)
+}
+
+func TestChildPlaylistRepositoryPocketbase_SyncStrategy(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Child",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+	})
+	assert.NoError(err)
+	assert.Empty(playlist.SyncStrategy)
+
+	spotifyPlaylistID, strategy := "spotify456", models.SyncStrategyInPlace
+	_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{SpotifyPlaylistID: &spotifyPlaylistID, SyncStrategy: &strategy})
+	assert.NoError(err)
+
+	storedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Equal(models.SyncStrategyInPlace, storedPlaylist.SyncStrategy)
+	assert.Equal("spotify456", storedPlaylist.SpotifyPlaylistID)
+}
+
+func TestChildPlaylistRepositoryPocketbase_SourceBasePlaylists(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:                "user123",
+		BasePlaylistID:        "base123",
+		Name:                  "Merged",
+		SpotifyPlaylistID:     "spotify123",
+		IsActive:              true,
+		SourceBasePlaylistIDs: []string{"base456", "base789"},
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"base456", "base789"}, playlist.SourceBasePlaylistIDs)
+
+	storedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Equal([]string{"base456", "base789"}, storedPlaylist.SourceBasePlaylistIDs)
+	assert.True(storedPlaylist.IsMerge())
+
+	// An empty list turns it back into a regular child playlist
+	sources := []string{}
+	updated, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{SourceBasePlaylistIDs: &sources})
+	assert.NoError(err)
+	assert.Empty(updated.SourceBasePlaylistIDs)
+	assert.False(updated.IsMerge())
+}
+
+func TestChildPlaylistRepositoryPocketbase_GetBySourceBasePlaylistID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	create := func(userID, basePlaylistID, spotifyPlaylistID string, sources ...string) *models.ChildPlaylist {
+		playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+			UserID:                userID,
+			BasePlaylistID:        basePlaylistID,
+			Name:                  "Child " + spotifyPlaylistID,
+			SpotifyPlaylistID:     spotifyPlaylistID,
+			IsActive:              true,
+			SourceBasePlaylistIDs: sources,
+		})
+		assert.NoError(err)
+		return playlist
+	}
+
+	merging := create("user123", "base123", "spotify1", "base456", "base789")
+	create("user123", "base123", "spotify2")
+	create("user123", "base456", "spotify3", "base789")
+	create("other_user", "base999", "spotify4", "base456")
+
+	merges, err := repo.GetBySourceBasePlaylistID(ctx, "base456", "user123")
+	assert.NoError(err)
+	assert.Len(merges, 1)
+	assert.Equal(merging.ID, merges[0].ID)
+
+	merges, err = repo.GetBySourceBasePlaylistID(ctx, "base789", "user123")
+	assert.NoError(err)
+	assert.Len(merges, 2)
+
+	merges, err = repo.GetBySourceBasePlaylistID(ctx, "base123", "user123")
+	assert.NoError(err)
+	assert.Empty(merges)
+}
+
+func TestChildPlaylistRepositoryPocketbase_PinnedTracks(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Pinned",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+	})
+	assert.NoError(err)
+	assert.Empty(playlist.PinnedTracks)
+
+	pinned := []string{"spotify:track:1", "spotify:track:2"}
+	updated, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{PinnedTracks: &pinned})
+	assert.NoError(err)
+	assert.Equal(pinned, updated.PinnedTracks)
+
+	storedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.Equal(pinned, storedPlaylist.PinnedTracks)
+}
+
+func TestChildPlaylistRepositoryPocketbase_RefollowRecreated(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Refollow",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+		RefollowRecreated: true,
+	})
+	assert.NoError(err)
+	assert.True(playlist.RefollowRecreated)
+
+	refollow := false
+	updated, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{RefollowRecreated: &refollow})
+	assert.NoError(err)
+	assert.False(updated.RefollowRecreated)
+
+	storedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.False(storedPlaylist.RefollowRecreated)
+}
+
+func TestChildPlaylistRepositoryPocketbase_Suspended(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Suspended",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+	})
+	assert.NoError(err)
+	assert.False(playlist.Suspended)
+
+	isActive, suspended := false, true
+	_, err = repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{IsActive: &isActive, Suspended: &suspended})
+	assert.NoError(err)
+
+	storedPlaylist, err := repo.GetByID(ctx, playlist.ID, "user123")
+	assert.NoError(err)
+	assert.False(storedPlaylist.IsActive)
+	assert.True(storedPlaylist.Suspended)
+}
+
 func TestChildPlaylistRepositoryPocketbase_Update_UnauthorizedError(t *testing.T) {
 	assert := require.New(t)
 
@@ -664,6 +1087,49 @@ func TestChildPlaylistRepositoryPocketbase_Update_NotFoundError(t *testing.T) {
 	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
 }
 
+func TestChildPlaylistRepositoryPocketbase_Update_ExpectedUpdated(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistCollection(t, app)
+	repo := NewChildPlaylistRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	playlist, err := repo.Create(ctx, repositories.CreateChildPlaylistFields{
+		UserID:            "user123",
+		BasePlaylistID:    "base123",
+		Name:              "Test Playlist",
+		SpotifyPlaylistID: "spotify123",
+		IsActive:          true,
+	})
+	assert.NoError(err)
+
+	// The first tab saves the version it read. Updated is stored with millisecond precision, so the
+	// save has to land on a later millisecond to be told apart
+	time.Sleep(2 * time.Millisecond)
+	firstName := "First Tab"
+	updated, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{
+		Name:            &firstName,
+		ExpectedUpdated: &playlist.Updated,
+	})
+	assert.NoError(err)
+	assert.Equal("First Tab", updated.Name)
+
+	// The second tab read the same version, which was modified since
+	secondName := "Second Tab"
+	stale, err := repo.Update(ctx, playlist.ID, "user123", repositories.UpdateChildPlaylistFields{
+		Name:            &secondName,
+		ExpectedUpdated: &playlist.Updated,
+	})
+	assert.ErrorIs(err, repositories.ErrChildPlaylistModified)
+	assert.Nil(stale)
+
+	stored, err := findChildPlaylistInDB(t, app, playlist.ID)
+	assert.NoError(err)
+	assert.Equal("First Tab", stored.Name)
+}
+
 // ptrFloat64 returns a pointer to a float64 value
 func ptrFloat64(f float64) *float64 {
 	return &f
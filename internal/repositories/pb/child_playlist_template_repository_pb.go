@@ -0,0 +1,135 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type ChildPlaylistTemplateRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewChildPlaylistTemplateRepositoryPocketbase(pb *pocketbase.PocketBase) *ChildPlaylistTemplateRepositoryPocketbase {
+	return &ChildPlaylistTemplateRepositoryPocketbase{
+		collection: CollectionChildPlaylistTemplate,
+		app:        pb,
+		log:        pb.Logger().With("component", "ChildPlaylistTemplateRepositoryPocketbase"),
+	}
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPocketbase) Create(ctx context.Context, template *models.ChildPlaylistTemplate) (*models.ChildPlaylistTemplate, error) {
+	collection, err := GetCollection(ctx, ctRepo.app, ctRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("user_id", template.UserID)
+	record.Set("name", template.Name)
+	record.Set("description", template.Description)
+	record.Set("children", template.Children)
+
+	err = appFromContext(ctx, ctRepo.app).Save(record)
+	if err != nil {
+		ctRepo.log.ErrorContext(ctx, "unable to store child_playlist_template record", "user_id", template.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	ctRepo.log.InfoContext(ctx, "child_playlist_template stored successfully", "id", record.Id, "user_id", template.UserID)
+	return recordToChildPlaylistTemplate(record), nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPocketbase) GetByID(ctx context.Context, id, userID string) (*models.ChildPlaylistTemplate, error) {
+	record, err := ctRepo.findOwnedRecord(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return recordToChildPlaylistTemplate(record), nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPocketbase) GetByUserID(ctx context.Context, userID string) ([]*models.ChildPlaylistTemplate, error) {
+	collection, err := GetCollection(ctx, ctRepo.app, ctRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, ctRepo.app).FindRecordsByFilter(collection, "user_id = {:userID}", "created", 0, 0, dbx.Params{"userID": userID})
+	if err != nil {
+		ctRepo.log.ErrorContext(ctx, "unable to find child_playlist_template records", "user_id", userID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	templates := make([]*models.ChildPlaylistTemplate, len(records))
+	for i, record := range records {
+		templates[i] = recordToChildPlaylistTemplate(record)
+	}
+
+	return templates, nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPocketbase) Delete(ctx context.Context, id, userID string) error {
+	record, err := ctRepo.findOwnedRecord(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = appFromContext(ctx, ctRepo.app).Delete(record)
+	if err != nil {
+		ctRepo.log.ErrorContext(ctx, "unable to delete child_playlist_template record", "id", id, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	ctRepo.log.InfoContext(ctx, "child_playlist_template deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPocketbase) findOwnedRecord(ctx context.Context, id, userID string) (*core.Record, error) {
+	collection, err := GetCollection(ctx, ctRepo.app, ctRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, ctRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		return nil, repositories.ErrChildPlaylistTemplateNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userID {
+		ctRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return record, nil
+}
+
+func recordToChildPlaylistTemplate(record *core.Record) *models.ChildPlaylistTemplate {
+	template := &models.ChildPlaylistTemplate{
+		ID:          record.Id,
+		UserID:      record.GetString("user_id"),
+		Name:        record.GetString("name"),
+		Description: record.GetString("description"),
+		Created:     record.GetDateTime("created").Time(),
+		Updated:     record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("children", &template.Children); err != nil || template.Children == nil {
+		template.Children = []models.TemplateChild{}
+	}
+
+	return template
+}
@@ -0,0 +1,99 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChildPlaylistTemplateRepositoryPocketbase_CreateAndGet(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistTemplateCollection(t, app)
+	repo := NewChildPlaylistTemplateRepositoryPocketbase(app)
+
+	ctx := context.Background()
+	minEnergy := 0.8
+
+	created, err := repo.Create(ctx, &models.ChildPlaylistTemplate{
+		UserID:      "user123",
+		Name:        "Party",
+		Description: "Dance floor fillers",
+		Children: []models.TemplateChild{
+			{
+				Name:        "Bangers",
+				FilterRules: &models.MetadataFilters{Energy: &models.RangeFilter{Min: &minEnergy}},
+				MaxTracks:   50,
+			},
+			{Name: "Everything Else", IsFallback: true},
+		},
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.False(created.BuiltIn)
+
+	template, err := repo.GetByID(ctx, created.ID, "user123")
+	assert.NoError(err)
+	assert.Equal("Party", template.Name)
+	assert.Equal("Dance floor fillers", template.Description)
+	assert.Len(template.Children, 2)
+	assert.Equal("Bangers", template.Children[0].Name)
+	assert.Equal(0.8, *template.Children[0].FilterRules.Energy.Min)
+	assert.Equal(50, template.Children[0].MaxTracks)
+	assert.True(template.Children[1].IsFallback)
+
+	_, err = repo.GetByID(ctx, created.ID, "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+
+	_, err = repo.GetByID(ctx, "nonexistent", "user123")
+	assert.ErrorIs(err, repositories.ErrChildPlaylistTemplateNotFound)
+}
+
+func TestChildPlaylistTemplateRepositoryPocketbase_GetByUserID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistTemplateCollection(t, app)
+	repo := NewChildPlaylistTemplateRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	_, err := repo.Create(ctx, &models.ChildPlaylistTemplate{UserID: "user123", Name: "First", Children: []models.TemplateChild{{Name: "A"}}})
+	assert.NoError(err)
+	_, err = repo.Create(ctx, &models.ChildPlaylistTemplate{UserID: "other_user", Name: "Second", Children: []models.TemplateChild{{Name: "B"}}})
+	assert.NoError(err)
+
+	templates, err := repo.GetByUserID(ctx, "user123")
+	assert.NoError(err)
+	assert.Len(templates, 1)
+	assert.Equal("First", templates[0].Name)
+
+	templates, err = repo.GetByUserID(ctx, "nobody")
+	assert.NoError(err)
+	assert.Empty(templates)
+}
+
+func TestChildPlaylistTemplateRepositoryPocketbase_Delete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupChildPlaylistTemplateCollection(t, app)
+	repo := NewChildPlaylistTemplateRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.ChildPlaylistTemplate{UserID: "user123", Name: "Party", Children: []models.TemplateChild{{Name: "A"}}})
+	assert.NoError(err)
+
+	err = repo.Delete(ctx, created.ID, "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+
+	assert.NoError(repo.Delete(ctx, created.ID, "user123"))
+
+	err = repo.Delete(ctx, created.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrChildPlaylistTemplateNotFound)
+}
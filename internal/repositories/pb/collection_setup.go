@@ -2,8 +2,11 @@ package pb
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/core"
@@ -15,6 +18,10 @@ func InitCollections(app *pocketbase.PocketBase, cfg *config.Config) error {
 		return err
 	}
 
+	if err := ensureUserFields(app); err != nil {
+		return err
+	}
+
 	if err := createBasePlaylistCollection(app); err != nil {
 		return err
 	}
@@ -31,6 +38,74 @@ func InitCollections(app *pocketbase.PocketBase, cfg *config.Config) error {
 		return err
 	}
 
+	if err := createPlaylistSnapshotCollection(app); err != nil {
+		return err
+	}
+
+	if err := createUserEncryptionKeyCollection(app); err != nil {
+		return err
+	}
+
+	if err := createEncryptionKeyRotationCollection(app); err != nil {
+		return err
+	}
+
+	if err := createFilterRuleChangeCollection(app); err != nil {
+		return err
+	}
+
+	if err := createAPIKeyCollection(app); err != nil {
+		return err
+	}
+
+	if err := createPlaylistMembershipCollection(app); err != nil {
+		return err
+	}
+
+	if err := createPlaylistWebhookCollection(app); err != nil {
+		return err
+	}
+
+	if err := createBasePlaylistWatchCollection(app); err != nil {
+		return err
+	}
+
+	if err := createChildPlaylistTemplateCollection(app); err != nil {
+		return err
+	}
+
+	if err := createBlocklistEntryCollection(app); err != nil {
+		return err
+	}
+
+	if err := createRoutingReportCollection(app); err != nil {
+		return err
+	}
+
+	if err := createNotificationPreferencesCollection(app); err != nil {
+		return err
+	}
+
+	if err := createRoutingCacheCollection(app); err != nil {
+		return err
+	}
+
+	if err := createFeatureFlagCollection(app); err != nil {
+		return err
+	}
+
+	if err := createYouTubeMusicIntegrationCollection(app); err != nil {
+		return err
+	}
+
+	if err := createTidalIntegrationCollection(app); err != nil {
+		return err
+	}
+
+	if err := createLastFmIntegrationCollection(app); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -62,10 +137,22 @@ func createAdminUser(app *pocketbase.PocketBase, cfg *config.Config) error {
 // createBasePlaylistCollection creates the base_playlists collection
 func createBasePlaylistCollection(app *pocketbase.PocketBase) error {
 	// Check if base_playlists collection exists
-	_, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
+	existing, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
 	if err == nil {
-		// Collection already exists
-		return nil
+		err := ensureFields(app, existing,
+			&core.TextField{Name: "dedupe_strategy"},
+			&core.TextField{Name: "hook_token"},
+			&core.BoolField{Name: "suspended"},
+			&core.BoolField{Name: "archived"},
+			&core.TextField{Name: "spotify_integration_id"},
+			&core.DateField{Name: "deleted_at"},
+			&core.TextField{Name: "provider"},
+		)
+		if err != nil {
+			return err
+		}
+
+		return ensureIndexes(app, existing, basePlaylistIndexes())
 	}
 
 	// Create base_playlists collection
@@ -96,6 +183,44 @@ func createBasePlaylistCollection(app *pocketbase.PocketBase) error {
 		Required: false,
 	})
 
+	collection.Fields.Add(&core.TextField{
+		Name:     "dedupe_strategy",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "hook_token",
+		Required: false,
+	})
+
+	// Set on the playlists deactivated by a spotify disconnect
+	collection.Fields.Add(&core.BoolField{
+		Name:     "suspended",
+		Required: false,
+	})
+
+	// Set on the playlists left out of bulk and automated syncs
+	collection.Fields.Add(&core.BoolField{
+		Name:     "archived",
+		Required: false,
+	})
+
+	// Spotify account the playlist lives in, empty for the user's default account
+	collection.Fields.Add(&core.TextField{
+		Name:     "spotify_integration_id",
+		Required: false,
+	})
+
+	// Set on soft deleted playlists, purged once their retention expires
+	collection.Fields.Add(&core.DateField{
+		Name: "deleted_at",
+	})
+
+	// Streaming service the playlist lives in, spotify when empty
+	collection.Fields.Add(&core.TextField{
+		Name: "provider",
+	})
+
 	collection.Fields.Add(&core.AutodateField{
 		Name:     "created",
 		OnCreate: true,
@@ -106,21 +231,86 @@ func createBasePlaylistCollection(app *pocketbase.PocketBase) error {
 		OnUpdate: true,
 	})
 
-	// Create unique index on user_id + spotify_playlist_id to prevent duplicate imports
-	collection.Indexes = []string{
-		"CREATE UNIQUE INDEX idx_base_playlists_user_spotify ON base_playlists (user_id, spotify_playlist_id)",
-	}
+	collection.Indexes = basePlaylistIndexes()
 
 	return app.Save(collection)
 }
 
+// basePlaylistIndexes prevents importing a spotify playlist twice, soft deleted playlists aside so
+// a deleted playlist can be imported again
+func basePlaylistIndexes() []string {
+	return []string{
+		"CREATE UNIQUE INDEX idx_base_playlists_user_spotify ON base_playlists (user_id, spotify_playlist_id) WHERE deleted_at = ''",
+	}
+}
+
+// ensureUserFields adds the app specific fields to the built-in users collection
+func ensureUserFields(app *pocketbase.PocketBase) error {
+	users, err := app.FindCollectionByNameOrId(string(CollectionUsers))
+	if err != nil {
+		return err
+	}
+
+	if err := ensureFields(app, users,
+		&core.TextField{Name: "disconnected_spotify_id"},
+		&core.SelectField{
+			Name:      "roles",
+			Values:    []string{string(models.RoleUser), string(models.RoleAdmin)},
+			MaxSelect: 2,
+		},
+		// Disabled users can't log in nor use their API keys, set by admins through the admin API
+		&core.BoolField{Name: "disabled"},
+	); err != nil {
+		return err
+	}
+
+	return ensureAdminFieldsReadOnly(app, users)
+}
+
+// ensureAdminFieldsReadOnly keeps users from granting themselves roles, or enabling their disabled
+// account, through the PocketBase records API. Those fields are only set by admins and superusers
+func ensureAdminFieldsReadOnly(app *pocketbase.PocketBase, users *core.Collection) error {
+	changed := false
+	for _, field := range []string{"roles", "disabled"} {
+		condition := "@request.body." + field + ":isset = false"
+
+		var createChanged, updateChanged bool
+		users.CreateRule, createChanged = guardRule(users.CreateRule, condition)
+		users.UpdateRule, updateChanged = guardRule(users.UpdateRule, condition)
+		changed = changed || createChanged || updateChanged
+	}
+	if !changed {
+		return nil
+	}
+
+	return app.Save(users)
+}
+
+// guardRule adds the condition to an API rule, unless the rule already has it. nil rules are left as
+// they are, they already restrict the action to superusers
+func guardRule(rule *string, condition string) (*string, bool) {
+	if rule == nil || strings.Contains(*rule, condition) {
+		return rule, false
+	}
+
+	guarded := condition
+	if *rule != "" {
+		guarded = "(" + *rule + ") && " + condition
+	}
+
+	return &guarded, true
+}
+
 // createSpotifyIntegrationsCollection creates the spotify_integrations collection
 func createSpotifyIntegrationsCollection(app *pocketbase.PocketBase) error {
 	// Check if spotify_integrations collection exists
-	_, err := app.FindCollectionByNameOrId(string(CollectionSpotifyIntegration))
+	existing, err := app.FindCollectionByNameOrId(string(CollectionSpotifyIntegration))
 	if err == nil {
-		// Collection already exists
-		return nil
+		if err := ensureFields(app, existing, &core.TextField{Name: "provider"}); err != nil {
+			return err
+		}
+
+		return ensureSpotifyIntegrationIndexes(app, existing)
 	}
 
 	// Create spotify_integrations collection
@@ -135,6 +325,11 @@ func createSpotifyIntegrationsCollection(app *pocketbase.PocketBase) error {
 		CascadeDelete: true,
 	})
 
+	// Streaming service the account belongs to, spotify when empty
+	collection.Fields.Add(&core.TextField{
+		Name: "provider",
+	})
+
 	// Spotify user ID (unique identifier from Spotify)
 	collection.Fields.Add(&core.TextField{
 		Name:     "spotify_id",
@@ -189,22 +384,39 @@ func createSpotifyIntegrationsCollection(app *pocketbase.PocketBase) error {
 		OnUpdate: true,
 	})
 
-	// Create unique index on user_id to ensure one integration per user
-	collection.Indexes = []string{
-		"CREATE UNIQUE INDEX idx_spotify_integrations_user ON spotify_integrations (user)",
+	collection.Indexes = spotifyIntegrationIndexes()
+
+	return app.Save(collection)
+}
+
+// spotifyIntegrationIndexes lets a user link several spotify accounts, each of them once
+func spotifyIntegrationIndexes() []string {
+	return []string{
+		"CREATE INDEX idx_spotify_integrations_user ON spotify_integrations (user)",
+		"CREATE UNIQUE INDEX idx_spotify_integrations_user_spotify ON spotify_integrations (user, spotify_id)",
+	}
+}
+
+// ensureSpotifyIntegrationIndexes replaces the one integration per user index of collections
+// created by older versions
+func ensureSpotifyIntegrationIndexes(app *pocketbase.PocketBase, collection *core.Collection) error {
+	return ensureIndexes(app, collection, spotifyIntegrationIndexes())
+}
+
+// ensureIndexes replaces the indexes of collections created by older versions when they changed
+func ensureIndexes(app *pocketbase.PocketBase, collection *core.Collection, indexes []string) error {
+	if slices.Equal(collection.Indexes, indexes) {
+		return nil
 	}
 
+	collection.Indexes = indexes
 	return app.Save(collection)
 }
 
 // createChildPlaylistCollection creates the child_playlists collection
 func createChildPlaylistCollection(app *pocketbase.PocketBase) error {
 	// Check if child_playlists collection exists
-	_, err := app.FindCollectionByNameOrId(string(CollectionChildPlaylist))
-	if err == nil {
-		// Collection already exists
-		return nil
-	}
+	existing, existingErr := app.FindCollectionByNameOrId(string(CollectionChildPlaylist))
 
 	// Get the base_playlists collection to reference it properly
 	basePlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
@@ -212,6 +424,29 @@ func createChildPlaylistCollection(app *pocketbase.PocketBase) error {
 		return fmt.Errorf("base_playlists collection must exist before creating child_playlists: %w", err)
 	}
 
+	if existingErr == nil {
+		err := ensureFields(app, existing,
+			&core.BoolField{Name: "is_fallback"},
+			&core.NumberField{Name: "priority", OnlyInt: true},
+			&core.TextField{Name: "share_token"},
+			&core.NumberField{Name: "max_tracks", OnlyInt: true},
+			&core.TextField{Name: "selection_strategy"},
+			&core.TextField{Name: "sync_strategy"},
+			sourceBasePlaylistsField(basePlaylistCollection),
+			&core.JSONField{Name: "pinned_tracks"},
+			&core.BoolField{Name: "refollow_recreated"},
+			&core.BoolField{Name: "suspended"},
+			&core.TextField{Name: "spotify_integration_id"},
+			&core.DateField{Name: "deleted_at"},
+			&core.TextField{Name: "provider"},
+		)
+		if err != nil {
+			return err
+		}
+
+		return ensureIndexes(app, existing, childPlaylistIndexes())
+	}
+
 	// Create child_playlists collection
 	collection := core.NewBaseCollection(string(CollectionChildPlaylist))
 
@@ -259,6 +494,64 @@ func createChildPlaylistCollection(app *pocketbase.PocketBase) error {
 		Required: false,
 	})
 
+	collection.Fields.Add(&core.BoolField{
+		Name:     "is_fallback",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "priority",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "share_token",
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "max_tracks",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "selection_strategy",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "sync_strategy",
+	})
+
+	collection.Fields.Add(sourceBasePlaylistsField(basePlaylistCollection))
+
+	// Track URIs synced to the child whatever its filter rules
+	collection.Fields.Add(&core.JSONField{
+		Name: "pinned_tracks",
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name: "refollow_recreated",
+	})
+
+	// Set on the playlists deactivated by a spotify disconnect
+	collection.Fields.Add(&core.BoolField{
+		Name: "suspended",
+	})
+
+	// Spotify account the playlist lives in, empty for the account of its base playlist
+	collection.Fields.Add(&core.TextField{
+		Name: "spotify_integration_id",
+	})
+
+	// Set on soft deleted playlists, purged once their retention expires
+	collection.Fields.Add(&core.DateField{
+		Name: "deleted_at",
+	})
+
+	// Streaming service the playlist lives in, spotify when empty
+	collection.Fields.Add(&core.TextField{
+		Name: "provider",
+	})
+
 	collection.Fields.Add(&core.AutodateField{
 		Name:     "created",
 		OnCreate: true,
@@ -269,21 +562,33 @@ func createChildPlaylistCollection(app *pocketbase.PocketBase) error {
 		OnUpdate: true,
 	})
 
-	// Create unique index on base_playlist_id + spotify_playlist_id to prevent duplicate child playlists
-	collection.Indexes = []string{
-		"CREATE UNIQUE INDEX idx_child_playlists_base_spotify ON child_playlists (base_playlist_id, spotify_playlist_id)",
-	}
+	collection.Indexes = childPlaylistIndexes()
 
 	return app.Save(collection)
 }
 
+// childPlaylistIndexes prevents duplicate child playlists, soft deleted playlists aside
+func childPlaylistIndexes() []string {
+	return []string{
+		"CREATE UNIQUE INDEX idx_child_playlists_base_spotify ON child_playlists (base_playlist_id, spotify_playlist_id) WHERE deleted_at = ''",
+	}
+}
+
 // createSyncEventCollection creates the sync_events collection
 func createSyncEventCollection(app *pocketbase.PocketBase) error {
 	// Check if sync_events collection exists
-	_, err := app.FindCollectionByNameOrId(string(CollectionSyncEvent))
+	existing, err := app.FindCollectionByNameOrId(string(CollectionSyncEvent))
 	if err == nil {
-		// Collection already exists
-		return nil
+		return ensureFields(app, existing,
+			&core.JSONField{Name: "child_sync_results"},
+			&core.NumberField{Name: "tracks_unmatched"},
+			&core.JSONField{Name: "anomalies"},
+			&core.TextField{Name: "phase"},
+			&core.DateField{Name: "heartbeat_at"},
+			&core.JSONField{Name: "profile"},
+			&core.JSONField{Name: "rate_limit"},
+			&core.TextField{Name: "request_id"},
+		)
 	}
 
 	basePlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
@@ -337,10 +642,111 @@ func createSyncEventCollection(app *pocketbase.PocketBase) error {
 		Name: "tracks_processed",
 	})
 
+	collection.Fields.Add(&core.NumberField{
+		Name: "tracks_unmatched",
+	})
+
 	collection.Fields.Add(&core.NumberField{
 		Name: "total_api_requests",
 	})
 
+	collection.Fields.Add(&core.JSONField{
+		Name: "child_sync_results",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "anomalies",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "profile",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "rate_limit",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "phase",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name: "heartbeat_at",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "request_id",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	return app.Save(collection)
+}
+
+// createPlaylistSnapshotCollection creates the playlist_snapshots collection
+func createPlaylistSnapshotCollection(app *pocketbase.PocketBase) error {
+	// Check if playlist_snapshots collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionPlaylistSnapshot))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	syncEventCollection, err := app.FindCollectionByNameOrId(string(CollectionSyncEvent))
+	if err != nil {
+		return fmt.Errorf("sync_events collection must exist before creating playlist_snapshots: %w", err)
+	}
+
+	childPlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionChildPlaylist))
+	if err != nil {
+		return fmt.Errorf("child_playlists collection must exist before creating playlist_snapshots: %w", err)
+	}
+
+	// Create playlist_snapshots collection
+	collection := core.NewBaseCollection(string(CollectionPlaylistSnapshot))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "sync_event_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  syncEventCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "child_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  childPlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "spotify_playlist_id",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "track_uris",
+	})
+
 	collection.Fields.Add(&core.AutodateField{
 		Name:     "created",
 		OnCreate: true,
@@ -352,5 +758,1041 @@ func createSyncEventCollection(app *pocketbase.PocketBase) error {
 		OnUpdate: true,
 	})
 
+	// Create unique index so each child playlist has a single snapshot per sync event
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_playlist_snapshots_sync_child ON playlist_snapshots (sync_event_id, child_playlist_id)",
+	}
+
+	return app.Save(collection)
+}
+
+func createUserEncryptionKeyCollection(app *pocketbase.PocketBase) error {
+	// Check if user_encryption_keys collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionUserEncryptionKey))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	// Create user_encryption_keys collection
+	collection := core.NewBaseCollection(string(CollectionUserEncryptionKey))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	// Data key encrypted with the master key of key_version
+	collection.Fields.Add(&core.TextField{
+		Name:     "wrapped_key",
+		Required: true,
+		Hidden:   true,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "key_version",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	// Each user has a single data key
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_user_encryption_keys_user ON user_encryption_keys (user_id)",
+		"CREATE INDEX idx_user_encryption_keys_version ON user_encryption_keys (key_version)",
+	}
+
+	return app.Save(collection)
+}
+
+func createEncryptionKeyRotationCollection(app *pocketbase.PocketBase) error {
+	// Check if encryption_key_rotations collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionKeyRotation))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	// Create encryption_key_rotations collection
+	collection := core.NewBaseCollection(string(CollectionKeyRotation))
+
+	// Add fields
+	collection.Fields.Add(&core.NumberField{
+		Name:    "to_version",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "keys_rotated",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "keys_failed",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	return app.Save(collection)
+}
+
+// ensureFields adds the given fields to an existing collection when they are missing,
+// so collections created by older versions pick up newly introduced fields
+func ensureFields(app *pocketbase.PocketBase, collection *core.Collection, fields ...core.Field) error {
+	missing := false
+	for _, field := range fields {
+		if collection.Fields.GetByName(field.GetName()) == nil {
+			collection.Fields.Add(field)
+			missing = true
+		}
+	}
+
+	if !missing {
+		return nil
+	}
+
+	return app.Save(collection)
+}
+
+// sourceBasePlaylistsField holds the other base playlists merged into a child playlist. Deleting one
+// of them only drops it from the list, the child keeps being fed by the rest
+func sourceBasePlaylistsField(basePlaylistCollection *core.Collection) *core.RelationField {
+	return &core.RelationField{
+		Name:         "source_base_playlist_ids",
+		MaxSelect:    models.MAX_SOURCE_BASE_PLAYLISTS,
+		CollectionId: basePlaylistCollection.Id,
+	}
+}
+
+// createFilterRuleChangeCollection creates the filter_rule_changes collection
+func createFilterRuleChangeCollection(app *pocketbase.PocketBase) error {
+	// Check if filter_rule_changes collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionFilterRuleChange))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	basePlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
+	if err != nil {
+		return fmt.Errorf("base_playlists collection must exist before creating filter_rule_changes: %w", err)
+	}
+
+	childPlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionChildPlaylist))
+	if err != nil {
+		return fmt.Errorf("child_playlists collection must exist before creating filter_rule_changes: %w", err)
+	}
+
+	// Create filter_rule_changes collection
+	collection := core.NewBaseCollection(string(CollectionFilterRuleChange))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "base_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  basePlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "child_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  childPlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "previous_filter_rules",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "new_filter_rules",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "routing_diff",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name: "diff_computed_at",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE INDEX idx_filter_rule_changes_child ON filter_rule_changes (child_playlist_id)",
+		"CREATE INDEX idx_filter_rule_changes_base_pending ON filter_rule_changes (base_playlist_id, diff_computed_at)",
+	}
+
+	return app.Save(collection)
+}
+
+// createAPIKeyCollection creates the api_keys collection
+func createAPIKeyCollection(app *pocketbase.PocketBase) error {
+	// Check if api_keys collection exists
+	existing, err := app.FindCollectionByNameOrId(string(CollectionAPIKey))
+	if err == nil {
+		return ensureFields(app, existing,
+			&core.DateField{Name: "expires_at"},
+		)
+	}
+
+	// Create api_keys collection
+	collection := core.NewBaseCollection(string(CollectionAPIKey))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "name",
+		Required: true,
+		Max:      100,
+	})
+
+	// SHA-256 of the raw key, the raw key itself is never stored
+	collection.Fields.Add(&core.TextField{
+		Name:     "key_hash",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "key_prefix",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "scopes",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name: "last_used_at",
+	})
+
+	// Empty when the key never expires
+	collection.Fields.Add(&core.DateField{
+		Name: "expires_at",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_api_keys_key_hash ON api_keys (key_hash)",
+		"CREATE INDEX idx_api_keys_user ON api_keys (user_id)",
+	}
+
+	return app.Save(collection)
+}
+
+// createPlaylistMembershipCollection creates the playlist_memberships collection
+func createPlaylistMembershipCollection(app *pocketbase.PocketBase) error {
+	// Check if playlist_memberships collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionPlaylistMembership))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	childPlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionChildPlaylist))
+	if err != nil {
+		return fmt.Errorf("child_playlists collection must exist before creating playlist_memberships: %w", err)
+	}
+
+	// Create playlist_memberships collection
+	collection := core.NewBaseCollection(string(CollectionPlaylistMembership))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "child_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  childPlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	// Plain text so the membership survives the retention of its sync event
+	collection.Fields.Add(&core.TextField{
+		Name: "sync_event_id",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "spotify_playlist_id",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "track_uris",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_playlist_memberships_child ON playlist_memberships (child_playlist_id)",
+	}
+
+	return app.Save(collection)
+}
+
+func createPlaylistWebhookCollection(app *pocketbase.PocketBase) error {
+	// Check if playlist_webhooks collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionPlaylistWebhook))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	basePlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
+	if err != nil {
+		return fmt.Errorf("base_playlists collection must exist before creating playlist_webhooks: %w", err)
+	}
+
+	// Create playlist_webhooks collection
+	collection := core.NewBaseCollection(string(CollectionPlaylistWebhook))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "base_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  basePlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.URLField{
+		Name:     "url",
+		Required: true,
+	})
+
+	// Signs the deliveries, so receivers can verify they come from the router
+	collection.Fields.Add(&core.TextField{
+		Name:     "secret",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name: "is_active",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name: "last_delivered_at",
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "last_delivery_status",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE INDEX idx_playlist_webhooks_base ON playlist_webhooks (base_playlist_id)",
+		"CREATE INDEX idx_playlist_webhooks_active ON playlist_webhooks (is_active)",
+	}
+
+	return app.Save(collection)
+}
+
+func createBasePlaylistWatchCollection(app *pocketbase.PocketBase) error {
+	// Check if base_playlist_watches collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylistWatch))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	basePlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
+	if err != nil {
+		return fmt.Errorf("base_playlists collection must exist before creating base_playlist_watches: %w", err)
+	}
+
+	// Create base_playlist_watches collection
+	collection := core.NewBaseCollection(string(CollectionBasePlaylistWatch))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "base_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  basePlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "snapshot_id",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "track_uris",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_base_playlist_watches_base ON base_playlist_watches (base_playlist_id)",
+	}
+
+	return app.Save(collection)
+}
+
+func createChildPlaylistTemplateCollection(app *pocketbase.PocketBase) error {
+	// Check if child_playlist_templates collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionChildPlaylistTemplate))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	// Create child_playlist_templates collection
+	collection := core.NewBaseCollection(string(CollectionChildPlaylistTemplate))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "name",
+		Required: true,
+		Max:      100,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "description",
+	})
+
+	// The child playlists created on instantiation, with their filter rules
+	collection.Fields.Add(&core.JSONField{
+		Name: "children",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE INDEX idx_child_playlist_templates_user ON child_playlist_templates (user_id)",
+	}
+
+	return app.Save(collection)
+}
+
+func createBlocklistEntryCollection(app *pocketbase.PocketBase) error {
+	// Check if blocklist_entries collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionBlocklistEntry))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	basePlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
+	if err != nil {
+		return fmt.Errorf("base_playlists collection must exist before creating blocklist_entries: %w", err)
+	}
+
+	// Create blocklist_entries collection
+	collection := core.NewBaseCollection(string(CollectionBlocklistEntry))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "base_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  basePlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "type",
+		Required: true,
+	})
+
+	// Spotify track URI or artist ID, depending on the type
+	collection.Fields.Add(&core.TextField{
+		Name:     "value",
+		Required: true,
+		Max:      255,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_blocklist_entries_unique ON blocklist_entries (base_playlist_id, type, value)",
+	}
+
+	return app.Save(collection)
+}
+
+func createRoutingReportCollection(app *pocketbase.PocketBase) error {
+	// Check if routing_reports collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionRoutingReport))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	basePlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylist))
+	if err != nil {
+		return fmt.Errorf("base_playlists collection must exist before creating routing_reports: %w", err)
+	}
+
+	// Create routing_reports collection
+	collection := core.NewBaseCollection(string(CollectionRoutingReport))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "base_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  basePlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "sync_event_id",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "dedupe_strategy",
+	})
+
+	// One decision per track of the base playlist, large playlists exceed the default 1MB
+	collection.Fields.Add(&core.JSONField{
+		Name:    "tracks",
+		MaxSize: 20 << 20,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_routing_reports_base ON routing_reports (base_playlist_id)",
+		"CREATE INDEX idx_routing_reports_sync_event ON routing_reports (sync_event_id)",
+	}
+
+	return app.Save(collection)
+}
+
+func createNotificationPreferencesCollection(app *pocketbase.PocketBase) error {
+	// Check if notification_preferences collection exists
+	existing, err := app.FindCollectionByNameOrId(string(CollectionNotificationPreferences))
+	if err == nil {
+		return ensureFields(app, existing,
+			&core.JSONField{Name: "sync_summary_channels"},
+			&core.TextField{Name: "slack_webhook_url"},
+			&core.TextField{Name: "discord_webhook_url"},
+		)
+	}
+
+	// Create notification_preferences collection
+	collection := core.NewBaseCollection(string(CollectionNotificationPreferences))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name: "email_on_sync_failure",
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "sync_failure_threshold",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "sync_summary_channels",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "slack_webhook_url",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "discord_webhook_url",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_notification_preferences_user ON notification_preferences (user_id)",
+	}
+
+	return app.Save(collection)
+}
+
+func createRoutingCacheCollection(app *pocketbase.PocketBase) error {
+	// Check if routing_cache_entries collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionRoutingCache))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	childPlaylistCollection, err := app.FindCollectionByNameOrId(string(CollectionChildPlaylist))
+	if err != nil {
+		return fmt.Errorf("child_playlists collection must exist before creating routing_cache_entries: %w", err)
+	}
+
+	// Create routing_cache_entries collection
+	collection := core.NewBaseCollection(string(CollectionRoutingCache))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "child_playlist_id",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  childPlaylistCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "snapshot_id",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "filter_hash",
+	})
+
+	// Children of large base playlists can match more tracks than the default 1MB holds
+	collection.Fields.Add(&core.JSONField{
+		Name:    "track_uris",
+		MaxSize: 20 << 20,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_routing_cache_entries_child ON routing_cache_entries (child_playlist_id)",
+	}
+
+	return app.Save(collection)
+}
+
+func createFeatureFlagCollection(app *pocketbase.PocketBase) error {
+	// Check if feature_flags collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionFeatureFlag))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	// Create feature_flags collection
+	collection := core.NewBaseCollection(string(CollectionFeatureFlag))
+
+	// Add fields
+	collection.Fields.Add(&core.TextField{
+		Name:     "key",
+		Required: true,
+		Max:      100,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "description",
+		Max:  500,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name: "enabled",
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "rollout_percentage",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "user_ids",
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_feature_flags_key ON feature_flags (key)",
+	}
+
+	return app.Save(collection)
+}
+
+func createYouTubeMusicIntegrationCollection(app *pocketbase.PocketBase) error {
+	// Check if youtube_music_integrations collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionYouTubeMusicIntegration))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	// Create youtube_music_integrations collection
+	collection := core.NewBaseCollection(string(CollectionYouTubeMusicIntegration))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	// YouTube channel of the account, owner of its playlists
+	collection.Fields.Add(&core.TextField{
+		Name:     "channel_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "access_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "refresh_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "token_type",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "expires_at",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "scope",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "display_name",
+		Max:  200,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_youtube_music_integrations_user ON youtube_music_integrations (user)",
+	}
+
+	return app.Save(collection)
+}
+
+func createTidalIntegrationCollection(app *pocketbase.PocketBase) error {
+	// Check if tidal_integrations collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionTidalIntegration))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	// Create tidal_integrations collection
+	collection := core.NewBaseCollection(string(CollectionTidalIntegration))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	// Tidal user of the account, owner of its playlists
+	collection.Fields.Add(&core.TextField{
+		Name:     "tidal_user_id",
+		Required: true,
+	})
+
+	// Country the catalog of the account is read in
+	collection.Fields.Add(&core.TextField{
+		Name: "country_code",
+		Max:  2,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "access_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "refresh_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "token_type",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "expires_at",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "scope",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "display_name",
+		Max:  200,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_tidal_integrations_user ON tidal_integrations (user)",
+	}
+
+	return app.Save(collection)
+}
+
+func createLastFmIntegrationCollection(app *pocketbase.PocketBase) error {
+	// Check if lastfm_integrations collection exists
+	_, err := app.FindCollectionByNameOrId(string(CollectionLastFmIntegration))
+	if err == nil {
+		// Collection already exists
+		return nil
+	}
+
+	// Create lastfm_integrations collection
+	collection := core.NewBaseCollection(string(CollectionLastFmIntegration))
+
+	// Add fields
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  "_pb_users_auth_",
+		CascadeDelete: true,
+	})
+
+	// Last.fm user whose scrobbles are read
+	collection.Fields.Add(&core.TextField{
+		Name:     "username",
+		Required: true,
+		Max:      100,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "session_key",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_lastfm_integrations_user ON lastfm_integrations (user)",
+	}
+
 	return app.Save(collection)
 }
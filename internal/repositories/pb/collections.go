@@ -11,11 +11,28 @@ import (
 type Collection string
 
 var (
-	CollectionUsers              Collection = "users"
-	CollectionBasePlaylist       Collection = "base_playlists"
-	CollectionChildPlaylist      Collection = "child_playlists"
-	CollectionSpotifyIntegration Collection = "spotify_integrations"
-	CollectionSyncEvent          Collection = "sync_events"
+	CollectionUsers                   Collection = "users"
+	CollectionBasePlaylist            Collection = "base_playlists"
+	CollectionChildPlaylist           Collection = "child_playlists"
+	CollectionSpotifyIntegration      Collection = "spotify_integrations"
+	CollectionSyncEvent               Collection = "sync_events"
+	CollectionPlaylistSnapshot        Collection = "playlist_snapshots"
+	CollectionUserEncryptionKey       Collection = "user_encryption_keys"
+	CollectionKeyRotation             Collection = "encryption_key_rotations"
+	CollectionFilterRuleChange        Collection = "filter_rule_changes"
+	CollectionAPIKey                  Collection = "api_keys"
+	CollectionPlaylistMembership      Collection = "playlist_memberships"
+	CollectionPlaylistWebhook         Collection = "playlist_webhooks"
+	CollectionBasePlaylistWatch       Collection = "base_playlist_watches"
+	CollectionChildPlaylistTemplate   Collection = "child_playlist_templates"
+	CollectionBlocklistEntry          Collection = "blocklist_entries"
+	CollectionRoutingReport           Collection = "routing_reports"
+	CollectionNotificationPreferences Collection = "notification_preferences"
+	CollectionRoutingCache            Collection = "routing_cache_entries"
+	CollectionFeatureFlag             Collection = "feature_flags"
+	CollectionYouTubeMusicIntegration Collection = "youtube_music_integrations"
+	CollectionTidalIntegration        Collection = "tidal_integrations"
+	CollectionLastFmIntegration       Collection = "lastfm_integrations"
 )
 
 func GetCollection(ctx context.Context, app *pocketbase.PocketBase, collectionName Collection) (*core.Collection, error) {
@@ -0,0 +1,61 @@
+package pb
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// NewDBConnect returns a PocketBase DB connect function that applies the configured SQLite pragmas
+func NewDBConnect(cfg config.DatabaseConfig) core.DBConnectFunc {
+	dsnParams := BuildPragmaParams(cfg)
+
+	return func(dbPath string) (*dbx.DB, error) {
+		return dbx.Open("sqlite", dbPath+"?"+dsnParams)
+	}
+}
+
+// BuildPragmaParams builds the sqlite DSN query with the configured pragmas.
+// busy_timeout must come first so connections block on busy before WAL mode is enabled.
+func BuildPragmaParams(cfg config.DatabaseConfig) string {
+	pragmas := []string{
+		fmt.Sprintf("busy_timeout(%d)", cfg.BusyTimeoutMs),
+		"journal_mode(WAL)",
+		fmt.Sprintf("journal_size_limit(%d)", cfg.JournalSizeLimit),
+		fmt.Sprintf("wal_autocheckpoint(%d)", cfg.WALAutocheckpoint),
+		fmt.Sprintf("synchronous(%s)", cfg.Synchronous),
+		"foreign_keys(ON)",
+		"temp_store(MEMORY)",
+		fmt.Sprintf("cache_size(-%d)", cfg.CacheSizeKB),
+	}
+
+	return encodePragmas(pragmas)
+}
+
+// BuildReadPragmaParams builds the sqlite DSN query for the read-only pool.
+// query_only rejects any write issued through these connections.
+func BuildReadPragmaParams(cfg config.DatabaseConfig) string {
+	pragmas := []string{
+		fmt.Sprintf("busy_timeout(%d)", cfg.BusyTimeoutMs),
+		"query_only(1)",
+		"temp_store(MEMORY)",
+		fmt.Sprintf("cache_size(-%d)", cfg.CacheSizeKB),
+	}
+
+	return encodePragmas(pragmas)
+}
+
+func encodePragmas(pragmas []string) string {
+	params := ""
+	for i, pragma := range pragmas {
+		if i > 0 {
+			params += "&"
+		}
+		params += "_pragma=" + url.QueryEscape(pragma)
+	}
+
+	return params
+}
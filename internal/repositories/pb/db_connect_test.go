@@ -0,0 +1,68 @@
+package pb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func testDatabaseConfig() config.DatabaseConfig {
+	return config.DatabaseConfig{
+		BusyTimeoutMs:     5000,
+		JournalSizeLimit:  1000000,
+		WALAutocheckpoint: 500,
+		CacheSizeKB:       8000,
+		Synchronous:       "FULL",
+	}
+}
+
+func TestBuildPragmaParams(t *testing.T) {
+	assert := require.New(t)
+
+	params := BuildPragmaParams(testDatabaseConfig())
+
+	assert.Equal(
+		"_pragma=busy_timeout%285000%29"+
+			"&_pragma=journal_mode%28WAL%29"+
+			"&_pragma=journal_size_limit%281000000%29"+
+			"&_pragma=wal_autocheckpoint%28500%29"+
+			"&_pragma=synchronous%28FULL%29"+
+			"&_pragma=foreign_keys%28ON%29"+
+			"&_pragma=temp_store%28MEMORY%29"+
+			"&_pragma=cache_size%28-8000%29",
+		params,
+	)
+}
+
+func TestNewDBConnect_AppliesPragmas(t *testing.T) {
+	assert := require.New(t)
+
+	connect := NewDBConnect(testDatabaseConfig())
+	db, err := connect(filepath.Join(t.TempDir(), "data.db"))
+	assert.NoError(err)
+	defer func() { _ = db.Close() }()
+
+	// pin a single connection so every pragma query runs on the same one
+	db.DB().SetMaxOpenConns(1)
+
+	tests := []struct {
+		pragma   string
+		expected string
+	}{
+		{pragma: "busy_timeout", expected: "5000"},
+		{pragma: "journal_mode", expected: "wal"},
+		{pragma: "journal_size_limit", expected: "1000000"},
+		{pragma: "wal_autocheckpoint", expected: "500"},
+		{pragma: "synchronous", expected: "2"}, // FULL
+		{pragma: "cache_size", expected: "-8000"},
+	}
+
+	for _, tt := range tests {
+		var value string
+		err := db.NewQuery("PRAGMA " + tt.pragma).Row(&value)
+		assert.NoError(err, tt.pragma)
+		assert.Equal(tt.expected, value, tt.pragma)
+	}
+}
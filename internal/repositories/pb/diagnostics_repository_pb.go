@@ -0,0 +1,107 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type DiagnosticsRepositoryPocketbase struct {
+	app *pocketbase.PocketBase
+	log *slog.Logger
+}
+
+func NewDiagnosticsRepositoryPocketbase(pb *pocketbase.PocketBase) *DiagnosticsRepositoryPocketbase {
+	return &DiagnosticsRepositoryPocketbase{
+		app: pb,
+		log: pb.Logger().With("component", "DiagnosticsRepositoryPocketbase"),
+	}
+}
+
+func (dRepo *DiagnosticsRepositoryPocketbase) GetRecentLogs(ctx context.Context, limit int) ([]*models.LogEntry, error) {
+	logs := []*core.Log{}
+
+	err := appFromContext(ctx, dRepo.app).LogQuery().
+		OrderBy("created DESC").
+		Limit(int64(limit)).
+		WithContext(ctx).
+		All(&logs)
+	if err != nil {
+		dRepo.log.ErrorContext(ctx, "unable to find log records", "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	entries := make([]*models.LogEntry, len(logs))
+	for i, log := range logs {
+		entries[i] = &models.LogEntry{
+			ID:      log.Id,
+			Level:   log.Level,
+			Message: log.Message,
+			Data:    log.Data,
+			Created: log.Created.Time(),
+		}
+	}
+
+	return entries, nil
+}
+
+func (dRepo *DiagnosticsRepositoryPocketbase) GetSchemaVersions(ctx context.Context) (*models.SchemaVersions, error) {
+	migrations := []struct {
+		File    string `db:"file"`
+		Applied int64  `db:"applied"`
+	}{}
+
+	err := appFromContext(ctx, dRepo.app).DB().
+		Select("file", "applied").
+		From(core.DefaultMigrationsTable).
+		OrderBy("applied ASC", "file ASC").
+		WithContext(ctx).
+		All(&migrations)
+	if err != nil {
+		dRepo.log.ErrorContext(ctx, "unable to find applied migrations", "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	collections, err := appFromContext(ctx, dRepo.app).FindAllCollections()
+	if err != nil {
+		dRepo.log.ErrorContext(ctx, "unable to find collections", "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	versions := &models.SchemaVersions{
+		Migrations:  make([]models.AppliedMigration, len(migrations)),
+		Collections: make([]models.CollectionVersion, len(collections)),
+	}
+	for i, migration := range migrations {
+		versions.Migrations[i] = models.AppliedMigration{
+			File:      migration.File,
+			AppliedAt: time.UnixMicro(migration.Applied).UTC(),
+		}
+	}
+	for i, collection := range collections {
+		versions.Collections[i] = models.CollectionVersion{
+			Name:    collection.Name,
+			Fields:  len(collection.Fields),
+			Updated: collection.Updated.Time(),
+		}
+	}
+
+	return versions, nil
+}
+
+// Ping runs a trivial query, checking the database can still be read
+func (dRepo *DiagnosticsRepositoryPocketbase) Ping(ctx context.Context) error {
+	var result int
+	if err := appFromContext(ctx, dRepo.app).DB().NewQuery("SELECT 1").WithContext(ctx).Row(&result); err != nil {
+		dRepo.log.ErrorContext(ctx, "database ping failed", "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticsRepositoryPocketbase_GetRecentLogs(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	repo := NewDiagnosticsRepositoryPocketbase(app)
+
+	for _, message := range []string{"first", "second", "third"} {
+		log := &core.Log{
+			Message: message,
+			Level:   0,
+			Data:    types.JSONMap[any]{"user_id": "user123"},
+			Created: types.NowDateTime(),
+		}
+		log.Id = core.GenerateDefaultRandomId()
+		assert.NoError(app.AuxSave(log))
+	}
+
+	logs, err := repo.GetRecentLogs(context.Background(), 2)
+	assert.NoError(err)
+	assert.Len(logs, 2)
+	for _, log := range logs {
+		assert.NotEmpty(log.ID)
+		assert.Equal("user123", log.Data["user_id"])
+		assert.False(log.Created.IsZero())
+	}
+}
+
+func TestDiagnosticsRepositoryPocketbase_GetSchemaVersions(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewDiagnosticsRepositoryPocketbase(app)
+
+	versions, err := repo.GetSchemaVersions(context.Background())
+	assert.NoError(err)
+
+	// Bootstrapping applies the PocketBase system migrations
+	assert.NotEmpty(versions.Migrations)
+	for _, migration := range versions.Migrations {
+		assert.NotEmpty(migration.File)
+		assert.False(migration.AppliedAt.IsZero())
+	}
+
+	var syncEvents bool
+	for _, collection := range versions.Collections {
+		if collection.Name == string(CollectionSyncEvent) {
+			syncEvents = true
+			assert.Positive(collection.Fields)
+		}
+	}
+	assert.True(syncEvents)
+}
+
+func TestDiagnosticsRepositoryPocketbase_Ping(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	repo := NewDiagnosticsRepositoryPocketbase(app)
+
+	assert.NoError(repo.Ping(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(repo.Ping(ctx))
+}
@@ -0,0 +1,53 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type EncryptionKeyRotationRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewEncryptionKeyRotationRepositoryPocketbase(pb *pocketbase.PocketBase) *EncryptionKeyRotationRepositoryPocketbase {
+	return &EncryptionKeyRotationRepositoryPocketbase{
+		collection: CollectionKeyRotation,
+		app:        pb,
+		log:        pb.Logger().With("component", "EncryptionKeyRotationRepositoryPocketbase"),
+	}
+}
+
+func (krRepo *EncryptionKeyRotationRepositoryPocketbase) Create(ctx context.Context, rotation *models.EncryptionKeyRotation) (*models.EncryptionKeyRotation, error) {
+	collection, err := GetCollection(ctx, krRepo.app, krRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("to_version", rotation.ToVersion)
+	record.Set("keys_rotated", rotation.KeysRotated)
+	record.Set("keys_failed", rotation.KeysFailed)
+
+	err = appFromContext(ctx, krRepo.app).Save(record)
+	if err != nil {
+		krRepo.log.ErrorContext(ctx, "unable to store encryption_key_rotation record", "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	krRepo.log.InfoContext(ctx, "encryption_key_rotation stored successfully", "id", record.Id, "to_version", rotation.ToVersion)
+	return &models.EncryptionKeyRotation{
+		ID:          record.Id,
+		ToVersion:   record.GetInt("to_version"),
+		KeysRotated: record.GetInt("keys_rotated"),
+		KeysFailed:  record.GetInt("keys_failed"),
+		Created:     record.GetDateTime("created").Time(),
+	}, nil
+}
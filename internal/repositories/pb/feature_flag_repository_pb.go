@@ -0,0 +1,135 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type FeatureFlagRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewFeatureFlagRepositoryPocketbase(pb *pocketbase.PocketBase) *FeatureFlagRepositoryPocketbase {
+	return &FeatureFlagRepositoryPocketbase{
+		collection: CollectionFeatureFlag,
+		app:        pb,
+		log:        pb.Logger().With("component", "FeatureFlagRepositoryPocketbase"),
+	}
+}
+
+func (ffRepo *FeatureFlagRepositoryPocketbase) List(ctx context.Context) ([]*models.FeatureFlag, error) {
+	collection, err := GetCollection(ctx, ffRepo.app, ffRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, ffRepo.app).FindRecordsByFilter(collection, "", "key", 0, 0)
+	if err != nil {
+		ffRepo.log.ErrorContext(ctx, "unable to find feature_flag records", "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	flags := make([]*models.FeatureFlag, len(records))
+	for i, record := range records {
+		flags[i] = recordToFeatureFlag(record)
+	}
+
+	return flags, nil
+}
+
+func (ffRepo *FeatureFlagRepositoryPocketbase) GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	collection, err := GetCollection(ctx, ffRepo.app, ffRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := ffRepo.findRecordByKey(ctx, collection, key)
+	if err != nil {
+		return nil, repositories.ErrFeatureFlagNotFound
+	}
+
+	return recordToFeatureFlag(record), nil
+}
+
+func (ffRepo *FeatureFlagRepositoryPocketbase) Upsert(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+	collection, err := GetCollection(ctx, ffRepo.app, ffRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := ffRepo.findRecordByKey(ctx, collection, flag.Key)
+	if err != nil {
+		record = core.NewRecord(collection)
+	}
+
+	userIDs := flag.UserIDs
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+
+	record.Set("key", flag.Key)
+	record.Set("description", flag.Description)
+	record.Set("enabled", flag.Enabled)
+	record.Set("rollout_percentage", flag.RolloutPercentage)
+	record.Set("user_ids", userIDs)
+
+	err = appFromContext(ctx, ffRepo.app).Save(record)
+	if err != nil {
+		ffRepo.log.ErrorContext(ctx, "unable to store feature_flag record", "key", flag.Key, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return recordToFeatureFlag(record), nil
+}
+
+func (ffRepo *FeatureFlagRepositoryPocketbase) Delete(ctx context.Context, key string) error {
+	collection, err := GetCollection(ctx, ffRepo.app, ffRepo.collection)
+	if err != nil {
+		return err
+	}
+
+	record, err := ffRepo.findRecordByKey(ctx, collection, key)
+	if err != nil {
+		return repositories.ErrFeatureFlagNotFound
+	}
+
+	err = appFromContext(ctx, ffRepo.app).Delete(record)
+	if err != nil {
+		ffRepo.log.ErrorContext(ctx, "unable to delete feature_flag record", "key", key, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	ffRepo.log.InfoContext(ctx, "feature_flag deleted successfully", "key", key)
+	return nil
+}
+
+func (ffRepo *FeatureFlagRepositoryPocketbase) findRecordByKey(ctx context.Context, collection *core.Collection, key string) (*core.Record, error) {
+	return appFromContext(ctx, ffRepo.app).FindFirstRecordByFilter(collection, "key = {:key}", dbx.Params{"key": key})
+}
+
+func recordToFeatureFlag(record *core.Record) *models.FeatureFlag {
+	flag := &models.FeatureFlag{
+		ID:                record.Id,
+		Key:               record.GetString("key"),
+		Description:       record.GetString("description"),
+		Enabled:           record.GetBool("enabled"),
+		RolloutPercentage: record.GetInt("rollout_percentage"),
+		Created:           record.GetDateTime("created").Time(),
+		Updated:           record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("user_ids", &flag.UserIDs); err != nil || flag.UserIDs == nil {
+		flag.UserIDs = []string{}
+	}
+
+	return flag
+}
@@ -0,0 +1,97 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagRepositoryPocketbase_Upsert(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupFeatureFlagCollection(t, app)
+	repo := NewFeatureFlagRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Upsert(ctx, &models.FeatureFlag{
+		Key:               models.FeatureConcurrentSync,
+		Enabled:           true,
+		RolloutPercentage: 10,
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal([]string{}, created.UserIDs)
+
+	// Storing it again replaces the flag with the same key
+	updated, err := repo.Upsert(ctx, &models.FeatureFlag{
+		Key:               models.FeatureConcurrentSync,
+		Description:       "Write children concurrently",
+		Enabled:           true,
+		RolloutPercentage: 50,
+		UserIDs:           []string{"user123"},
+	})
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.Equal("Write children concurrently", updated.Description)
+	assert.Equal(50, updated.RolloutPercentage)
+	assert.Equal([]string{"user123"}, updated.UserIDs)
+}
+
+func TestFeatureFlagRepositoryPocketbase_GetByKey(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupFeatureFlagCollection(t, app)
+	repo := NewFeatureFlagRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	_, err := repo.GetByKey(ctx, models.FeatureStreamingSync)
+	assert.ErrorIs(err, repositories.ErrFeatureFlagNotFound)
+
+	_, err = repo.Upsert(ctx, &models.FeatureFlag{Key: models.FeatureStreamingSync, UserIDs: []string{"user123", "user456"}})
+	assert.NoError(err)
+
+	flag, err := repo.GetByKey(ctx, models.FeatureStreamingSync)
+	assert.NoError(err)
+	assert.Equal(models.FeatureStreamingSync, flag.Key)
+	assert.False(flag.Enabled)
+	assert.Equal([]string{"user123", "user456"}, flag.UserIDs)
+}
+
+func TestFeatureFlagRepositoryPocketbase_ListAndDelete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupFeatureFlagCollection(t, app)
+	repo := NewFeatureFlagRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	flags, err := repo.List(ctx)
+	assert.NoError(err)
+	assert.Empty(flags)
+
+	for _, key := range []string{models.FeatureStreamingSync, models.FeatureConcurrentSync, models.FeatureIncrementalSync} {
+		_, err := repo.Upsert(ctx, &models.FeatureFlag{Key: key, Enabled: true})
+		assert.NoError(err)
+	}
+
+	flags, err = repo.List(ctx)
+	assert.NoError(err)
+	assert.Len(flags, 3)
+	assert.Equal(models.FeatureConcurrentSync, flags[0].Key)
+	assert.Equal(models.FeatureStreamingSync, flags[2].Key)
+
+	assert.NoError(repo.Delete(ctx, models.FeatureConcurrentSync))
+	assert.ErrorIs(repo.Delete(ctx, models.FeatureConcurrentSync), repositories.ErrFeatureFlagNotFound)
+
+	flags, err = repo.List(ctx)
+	assert.NoError(err)
+	assert.Len(flags, 2)
+}
@@ -0,0 +1,200 @@
+package pb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type FilterRuleChangeRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewFilterRuleChangeRepositoryPocketbase(pb *pocketbase.PocketBase) *FilterRuleChangeRepositoryPocketbase {
+	return &FilterRuleChangeRepositoryPocketbase{
+		collection: CollectionFilterRuleChange,
+		app:        pb,
+		log:        pb.Logger().With("component", "FilterRuleChangeRepositoryPocketbase"),
+	}
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPocketbase) Create(ctx context.Context, change *models.FilterRuleChange) (*models.FilterRuleChange, error) {
+	collection, err := frcRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("user_id", change.UserID)
+	record.Set("base_playlist_id", change.BasePlaylistID)
+	record.Set("child_playlist_id", change.ChildPlaylistID)
+	if change.PreviousFilterRules != nil {
+		record.Set("previous_filter_rules", change.PreviousFilterRules)
+	}
+	if change.NewFilterRules != nil {
+		record.Set("new_filter_rules", change.NewFilterRules)
+	}
+
+	err = appFromContext(ctx, frcRepo.app).Save(record)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to store filter_rule_change record", "child_playlist_id", change.ChildPlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	frcRepo.log.InfoContext(ctx, "filter_rule_change stored successfully", "id", record.Id, "child_playlist_id", change.ChildPlaylistID)
+	return recordToFilterRuleChange(record), nil
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPocketbase) GetByID(ctx context.Context, id, userID string) (*models.FilterRuleChange, error) {
+	collection, err := frcRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, frcRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to find filter_rule_change record", "id", id, "error", err)
+		return nil, repositories.ErrFilterRuleChangeNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userID {
+		frcRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"requested_by", userID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return recordToFilterRuleChange(record), nil
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPocketbase) GetByChildPlaylistID(ctx context.Context, childPlaylistID, userID string) ([]*models.FilterRuleChange, error) {
+	return frcRepo.findByFilter(ctx,
+		"child_playlist_id = {:childPlaylistID} && user_id = {:userID}",
+		dbx.Params{
+			"childPlaylistID": childPlaylistID,
+			"userID":          userID,
+		},
+	)
+}
+
+// GetPendingByBasePlaylistID returns the changes of the base playlist whose routing diff was not computed yet
+func (frcRepo *FilterRuleChangeRepositoryPocketbase) GetPendingByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.FilterRuleChange, error) {
+	return frcRepo.findByFilter(ctx,
+		"base_playlist_id = {:basePlaylistID} && user_id = {:userID} && diff_computed_at = ''",
+		dbx.Params{
+			"basePlaylistID": basePlaylistID,
+			"userID":         userID,
+		},
+	)
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPocketbase) UpdateRoutingDiff(ctx context.Context, id string, diff *models.RoutingDiff) (*models.FilterRuleChange, error) {
+	collection, err := frcRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, frcRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to find filter_rule_change record", "id", id, "error", err)
+		return nil, repositories.ErrFilterRuleChangeNotFound
+	}
+
+	record.Set("routing_diff", diff)
+	record.Set("diff_computed_at", diff.ComputedAt)
+
+	err = appFromContext(ctx, frcRepo.app).Save(record)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to update filter_rule_change record", "id", id, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	frcRepo.log.InfoContext(ctx, "filter_rule_change routing diff stored successfully", "id", id)
+	return recordToFilterRuleChange(record), nil
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPocketbase) findByFilter(ctx context.Context, filter string, params dbx.Params) ([]*models.FilterRuleChange, error) {
+	collection, err := frcRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, frcRepo.app).FindRecordsByFilter(
+		collection,
+		filter,
+		"-created", // Newest first
+		0,          // limit (0 = no limit)
+		0,          // offset
+		params,
+	)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to find filter_rule_change records", "filter", filter, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	changes := make([]*models.FilterRuleChange, len(records))
+	for i, record := range records {
+		changes[i] = recordToFilterRuleChange(record)
+	}
+
+	return changes, nil
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, frcRepo.app).FindCollectionByNameOrId(string(frcRepo.collection))
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to find collection", "collection", frcRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func recordToFilterRuleChange(record *core.Record) *models.FilterRuleChange {
+	change := &models.FilterRuleChange{
+		ID:              record.Id,
+		UserID:          record.GetString("user_id"),
+		BasePlaylistID:  record.GetString("base_playlist_id"),
+		ChildPlaylistID: record.GetString("child_playlist_id"),
+		Created:         record.GetDateTime("created").Time(),
+		Updated:         record.GetDateTime("updated").Time(),
+	}
+
+	change.PreviousFilterRules = filterRulesFromRecord(record, "previous_filter_rules")
+	change.NewFilterRules = filterRulesFromRecord(record, "new_filter_rules")
+
+	if !record.GetDateTime("diff_computed_at").IsZero() {
+		var routingDiff models.RoutingDiff
+		if err := record.UnmarshalJSONField("routing_diff", &routingDiff); err == nil {
+			change.RoutingDiff = &routingDiff
+		}
+	}
+
+	return change
+}
+
+// filterRulesFromRecord deserializes a filter rules JSON field, returning nil when the field is empty
+func filterRulesFromRecord(record *core.Record, field string) *models.AudioFeatureFilters {
+	filterRulesJSON := record.GetString(field)
+	if filterRulesJSON == "" || filterRulesJSON == "null" {
+		return nil
+	}
+
+	var filterRules models.AudioFeatureFilters
+	if err := json.Unmarshal([]byte(filterRulesJSON), &filterRules); err != nil {
+		return nil
+	}
+
+	return &filterRules
+}
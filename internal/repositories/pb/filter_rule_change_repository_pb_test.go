@@ -0,0 +1,184 @@
+package pb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRuleChangeRepositoryPocketbase_Create_Success(t *testing.T) {
+	tests := []struct {
+		name                string
+		previousFilterRules *models.AudioFeatureFilters
+		newFilterRules      *models.AudioFeatureFilters
+	}{
+		{
+			name: "rules changed",
+			previousFilterRules: &models.AudioFeatureFilters{
+				Popularity: &models.RangeFilter{Min: ptrFloat64(20)},
+			},
+			newFilterRules: &models.AudioFeatureFilters{
+				Popularity: &models.RangeFilter{Min: ptrFloat64(60)},
+			},
+		},
+		{
+			name:                "rules added",
+			previousFilterRules: nil,
+			newFilterRules: &models.AudioFeatureFilters{
+				Genres: &models.SetFilter{Include: []string{"rock"}},
+			},
+		},
+		{
+			name: "rules removed",
+			previousFilterRules: &models.AudioFeatureFilters{
+				Genres: &models.SetFilter{Include: []string{"rock"}},
+			},
+			newFilterRules: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			app := NewTestApp(t)
+			SetupFilterRuleChangeCollection(t, app)
+			repo := NewFilterRuleChangeRepositoryPocketbase(app)
+
+			result, err := repo.Create(context.Background(), &models.FilterRuleChange{
+				UserID:              "user123",
+				BasePlaylistID:      "base123",
+				ChildPlaylistID:     "child123",
+				PreviousFilterRules: tt.previousFilterRules,
+				NewFilterRules:      tt.newFilterRules,
+			})
+
+			assert.NoError(err)
+			assert.NotEmpty(result.ID)
+			assert.Equal("user123", result.UserID)
+			assert.Equal("base123", result.BasePlaylistID)
+			assert.Equal("child123", result.ChildPlaylistID)
+			assert.Equal(tt.previousFilterRules, result.PreviousFilterRules)
+			assert.Equal(tt.newFilterRules, result.NewFilterRules)
+			assert.Nil(result.RoutingDiff)
+		})
+	}
+}
+
+func TestFilterRuleChangeRepositoryPocketbase_GetByID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupFilterRuleChangeCollection(t, app)
+	repo := NewFilterRuleChangeRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.FilterRuleChange{
+		UserID:          "user123",
+		BasePlaylistID:  "base123",
+		ChildPlaylistID: "child123",
+	})
+	assert.NoError(err)
+
+	result, err := repo.GetByID(ctx, created.ID, "user123")
+	assert.NoError(err)
+	assert.Equal(created.ID, result.ID)
+
+	result, err = repo.GetByID(ctx, created.ID, "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+	assert.Nil(result)
+
+	result, err = repo.GetByID(ctx, "nonexistent123", "user123")
+	assert.ErrorIs(err, repositories.ErrFilterRuleChangeNotFound)
+	assert.Nil(result)
+}
+
+func TestFilterRuleChangeRepositoryPocketbase_GetByChildPlaylistID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupFilterRuleChangeCollection(t, app)
+	repo := NewFilterRuleChangeRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	for _, change := range []*models.FilterRuleChange{
+		{UserID: "user123", BasePlaylistID: "base123", ChildPlaylistID: "child123"},
+		{UserID: "user123", BasePlaylistID: "base123", ChildPlaylistID: "child123"},
+		{UserID: "user123", BasePlaylistID: "base123", ChildPlaylistID: "child456"},
+		{UserID: "other_user", BasePlaylistID: "base789", ChildPlaylistID: "child123"},
+	} {
+		_, err := repo.Create(ctx, change)
+		assert.NoError(err)
+	}
+
+	results, err := repo.GetByChildPlaylistID(ctx, "child123", "user123")
+	assert.NoError(err)
+	assert.Len(results, 2)
+	for _, result := range results {
+		assert.Equal("child123", result.ChildPlaylistID)
+		assert.Equal("user123", result.UserID)
+	}
+
+	results, err = repo.GetByChildPlaylistID(ctx, "nonexistent", "user123")
+	assert.NoError(err)
+	assert.Empty(results)
+}
+
+func TestFilterRuleChangeRepositoryPocketbase_UpdateRoutingDiff(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupFilterRuleChangeCollection(t, app)
+	repo := NewFilterRuleChangeRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	pendingChange, err := repo.Create(ctx, &models.FilterRuleChange{
+		UserID:          "user123",
+		BasePlaylistID:  "base123",
+		ChildPlaylistID: "child123",
+	})
+	assert.NoError(err)
+
+	computedChange, err := repo.Create(ctx, &models.FilterRuleChange{
+		UserID:          "user123",
+		BasePlaylistID:  "base123",
+		ChildPlaylistID: "child456",
+	})
+	assert.NoError(err)
+
+	pending, err := repo.GetPendingByBasePlaylistID(ctx, "base123", "user123")
+	assert.NoError(err)
+	assert.Len(pending, 2)
+
+	diff := &models.RoutingDiff{
+		SyncEventID:      "sync123",
+		TracksBefore:     3,
+		TracksAfter:      2,
+		AddedTrackURIs:   []string{"spotify:track:4"},
+		RemovedTrackURIs: []string{"spotify:track:1", "spotify:track:2"},
+		ComputedAt:       time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	result, err := repo.UpdateRoutingDiff(ctx, computedChange.ID, diff)
+	assert.NoError(err)
+	assert.Equal(diff.SyncEventID, result.RoutingDiff.SyncEventID)
+	assert.Equal(diff.AddedTrackURIs, result.RoutingDiff.AddedTrackURIs)
+	assert.Equal(diff.RemovedTrackURIs, result.RoutingDiff.RemovedTrackURIs)
+	assert.True(diff.ComputedAt.Equal(result.RoutingDiff.ComputedAt))
+
+	// Only the change without a diff is still pending
+	pending, err = repo.GetPendingByBasePlaylistID(ctx, "base123", "user123")
+	assert.NoError(err)
+	assert.Len(pending, 1)
+	assert.Equal(pendingChange.ID, pending[0].ID)
+
+	_, err = repo.UpdateRoutingDiff(ctx, "nonexistent123", diff)
+	assert.ErrorIs(err, repositories.ErrFilterRuleChangeNotFound)
+}
@@ -0,0 +1,161 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type LastFmIntegrationRepositoryPocketbase struct {
+	collection  Collection
+	app         *pocketbase.PocketBase
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
+}
+
+func NewLastFmIntegrationRepositoryPocketbase(pb *pocketbase.PocketBase) *LastFmIntegrationRepositoryPocketbase {
+	return &LastFmIntegrationRepositoryPocketbase{
+		app:        pb,
+		collection: CollectionLastFmIntegration,
+		log:        pb.Logger().With("component", "LastFmIntegrationRepositoryPocketbase"),
+	}
+}
+
+// WithTokenCipher encrypts the stored Last.fm session keys with the data key of their user
+func (lRepo *LastFmIntegrationRepositoryPocketbase) WithTokenCipher(tokenCipher repositories.TokenCipher) *LastFmIntegrationRepositoryPocketbase {
+	lRepo.tokenCipher = tokenCipher
+	return lRepo
+}
+
+func (lRepo *LastFmIntegrationRepositoryPocketbase) CreateOrUpdate(
+	ctx context.Context,
+	userId string,
+	integration *models.LastFmIntegration,
+) (*models.LastFmIntegration, error) {
+	collection, err := lRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var record *core.Record
+	existing, err := appFromContext(ctx, lRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		lRepo.log.InfoContext(ctx, "lastfm_integration not found", "user", userId)
+		record = core.NewRecord(collection)
+		record.Set("user", userId)
+	} else {
+		lRepo.log.InfoContext(ctx, "lastfm_integration found", "user", userId, "record", existing.Id)
+		record = existing
+	}
+
+	sessionKey := integration.SessionKey
+	if lRepo.tokenCipher != nil {
+		sessionKey, err = lRepo.tokenCipher.EncryptForUser(ctx, userId, integration.SessionKey)
+		if err != nil {
+			lRepo.log.ErrorContext(ctx, "unable to encrypt lastfm session key", "user", userId, "error", err)
+			return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+	}
+
+	record.Set("username", integration.Username)
+	record.Set("session_key", sessionKey)
+
+	if err := appFromContext(ctx, lRepo.app).Save(record); err != nil {
+		lRepo.log.ErrorContext(ctx, "unable to store lastfm_integration record", "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	lRepo.log.InfoContext(ctx, "lastfm_integration stored successfully", "user", userId, "username", integration.Username)
+	return lRepo.toLastFmIntegration(ctx, record)
+}
+
+func (lRepo *LastFmIntegrationRepositoryPocketbase) GetByUserID(ctx context.Context, userId string) (*models.LastFmIntegration, error) {
+	collection, err := lRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, lRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		lRepo.log.InfoContext(ctx, "unable to fetch lastfm_integration", "user", userId, "error", err)
+		return nil, repositories.ErrLastFmIntegrationNotFound
+	}
+
+	return lRepo.toLastFmIntegration(ctx, record)
+}
+
+func (lRepo *LastFmIntegrationRepositoryPocketbase) Delete(ctx context.Context, userId string) error {
+	collection, err := lRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, lRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		lRepo.log.ErrorContext(ctx, "lastfm_integration not found", "user", userId, "error", err)
+		return repositories.ErrLastFmIntegrationNotFound
+	}
+
+	if err := appFromContext(ctx, lRepo.app).Delete(record); err != nil {
+		lRepo.log.ErrorContext(ctx, "unable to delete lastfm_integration", "user", userId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	lRepo.log.InfoContext(ctx, "lastfm_integration deleted", "user", userId)
+	return nil
+}
+
+func (lRepo *LastFmIntegrationRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, lRepo.app).FindCollectionByNameOrId(string(lRepo.collection))
+	if err != nil {
+		lRepo.log.ErrorContext(ctx, "unable to find collection", "collection", lRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func (lRepo *LastFmIntegrationRepositoryPocketbase) toLastFmIntegration(ctx context.Context, record *core.Record) (*models.LastFmIntegration, error) {
+	integration := recordToLastFmIntegration(record)
+	if lRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	sessionKey, err := lRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.SessionKey)
+	if err != nil {
+		lRepo.log.ErrorContext(ctx, "unable to decrypt lastfm session key", "user", integration.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	integration.SessionKey = sessionKey
+	return integration, nil
+}
+
+func recordToLastFmIntegration(record *core.Record) *models.LastFmIntegration {
+	return &models.LastFmIntegration{
+		ID:         record.Id,
+		UserID:     record.GetString("user"),
+		Username:   record.GetString("username"),
+		SessionKey: record.GetString("session_key"),
+		Created:    record.GetDateTime("created").Time(),
+		Updated:    record.GetDateTime("updated").Time(),
+	}
+}
@@ -0,0 +1,78 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastFmIntegrationRepositoryPocketbase_CreateOrUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupLastFmIntegrationCollection(t, app)
+	repo := NewLastFmIntegrationRepositoryPocketbase(app).WithTokenCipher(prefixTokenCipher{})
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("lastfm@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	created, err := repo.CreateOrUpdate(ctx, userID, &models.LastFmIntegration{Username: "listener", SessionKey: "session_key_123"})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal(userID, created.UserID)
+	assert.Equal("listener", created.Username)
+	assert.Equal("session_key_123", created.SessionKey)
+
+	// Stored session keys are encrypted
+	record, err := app.FindRecordById(string(CollectionLastFmIntegration), created.ID)
+	assert.NoError(err)
+	assert.Equal(userID+":session_key_123", record.GetString("session_key"))
+
+	// Linking another account replaces the one of the user
+	updated, err := repo.CreateOrUpdate(ctx, userID, &models.LastFmIntegration{Username: "other", SessionKey: "session_key_456"})
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+
+	retrieved, err := repo.GetByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Equal("other", retrieved.Username)
+	assert.Equal("session_key_456", retrieved.SessionKey)
+}
+
+func TestLastFmIntegrationRepositoryPocketbase_GetByUserID_NotFound(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupLastFmIntegrationCollection(t, app)
+	repo := NewLastFmIntegrationRepositoryPocketbase(app)
+
+	result, err := repo.GetByUserID(context.Background(), "nonexistent")
+
+	assert.ErrorIs(err, repositories.ErrLastFmIntegrationNotFound)
+	assert.Nil(result)
+}
+
+func TestLastFmIntegrationRepositoryPocketbase_Delete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupLastFmIntegrationCollection(t, app)
+	repo := NewLastFmIntegrationRepositoryPocketbase(app)
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("lastfm@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	_, err := repo.CreateOrUpdate(ctx, userID, &models.LastFmIntegration{Username: "listener", SessionKey: "session_key_123"})
+	assert.NoError(err)
+
+	assert.NoError(repo.Delete(ctx, userID))
+
+	_, err = repo.GetByUserID(ctx, userID)
+	assert.ErrorIs(err, repositories.ErrLastFmIntegrationNotFound)
+
+	assert.ErrorIs(repo.Delete(ctx, userID), repositories.ErrLastFmIntegrationNotFound)
+}
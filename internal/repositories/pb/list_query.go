@@ -0,0 +1,17 @@
+package pb
+
+import "github.com/ngomez18/playlist-router/internal/models"
+
+// listOrderBy sorts a list query by the requested field, newest first when empty. Ties are broken by
+// id so consecutive pages don't overlap
+func listOrderBy(sort models.ListSort) []string {
+	if sort == "" {
+		sort = models.ListSortCreatedDesc
+	}
+
+	field, descending := sort.Field()
+	if descending {
+		return []string{field + " DESC", "id DESC"}
+	}
+	return []string{field + " ASC", "id ASC"}
+}
@@ -0,0 +1,97 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type NotificationPreferencesRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewNotificationPreferencesRepositoryPocketbase(pb *pocketbase.PocketBase) *NotificationPreferencesRepositoryPocketbase {
+	return &NotificationPreferencesRepositoryPocketbase{
+		collection: CollectionNotificationPreferences,
+		app:        pb,
+		log:        pb.Logger().With("component", "NotificationPreferencesRepositoryPocketbase"),
+	}
+}
+
+func (npRepo *NotificationPreferencesRepositoryPocketbase) Upsert(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	collection, err := GetCollection(ctx, npRepo.app, npRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := npRepo.findRecordByUserID(ctx, collection, preferences.UserID)
+	if err != nil {
+		record = core.NewRecord(collection)
+	}
+
+	record.Set("user_id", preferences.UserID)
+	record.Set("email_on_sync_failure", preferences.EmailOnSyncFailure)
+	record.Set("sync_failure_threshold", preferences.SyncFailureThreshold)
+
+	channels := preferences.SyncSummaryChannels
+	if channels == nil {
+		channels = []models.NotificationChannel{}
+	}
+
+	record.Set("sync_summary_channels", channels)
+	record.Set("slack_webhook_url", preferences.SlackWebhookURL)
+	record.Set("discord_webhook_url", preferences.DiscordWebhookURL)
+
+	err = appFromContext(ctx, npRepo.app).Save(record)
+	if err != nil {
+		npRepo.log.ErrorContext(ctx, "unable to store notification_preferences record", "user_id", preferences.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return recordToNotificationPreferences(record), nil
+}
+
+func (npRepo *NotificationPreferencesRepositoryPocketbase) GetByUserID(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	collection, err := GetCollection(ctx, npRepo.app, npRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := npRepo.findRecordByUserID(ctx, collection, userID)
+	if err != nil {
+		return nil, repositories.ErrNotificationPreferencesNotFound
+	}
+
+	return recordToNotificationPreferences(record), nil
+}
+
+func (npRepo *NotificationPreferencesRepositoryPocketbase) findRecordByUserID(ctx context.Context, collection *core.Collection, userID string) (*core.Record, error) {
+	return appFromContext(ctx, npRepo.app).FindFirstRecordByFilter(collection, "user_id = {:userID}", dbx.Params{"userID": userID})
+}
+
+func recordToNotificationPreferences(record *core.Record) *models.NotificationPreferences {
+	preferences := &models.NotificationPreferences{
+		ID:                   record.Id,
+		UserID:               record.GetString("user_id"),
+		EmailOnSyncFailure:   record.GetBool("email_on_sync_failure"),
+		SyncFailureThreshold: record.GetInt("sync_failure_threshold"),
+		SlackWebhookURL:      record.GetString("slack_webhook_url"),
+		DiscordWebhookURL:    record.GetString("discord_webhook_url"),
+		Created:              record.GetDateTime("created").Time(),
+		Updated:              record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("sync_summary_channels", &preferences.SyncSummaryChannels); err != nil || preferences.SyncSummaryChannels == nil {
+		preferences.SyncSummaryChannels = []models.NotificationChannel{}
+	}
+
+	return preferences
+}
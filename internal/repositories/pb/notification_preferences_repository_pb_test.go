@@ -0,0 +1,74 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferencesRepositoryPocketbase_Upsert(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupNotificationPreferencesCollection(t, app)
+	repo := NewNotificationPreferencesRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Upsert(ctx, &models.NotificationPreferences{
+		UserID:               "user123",
+		EmailOnSyncFailure:   true,
+		SyncFailureThreshold: 3,
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+
+	// Storing them again replaces the preferences of the user
+	updated, err := repo.Upsert(ctx, &models.NotificationPreferences{
+		UserID:               "user123",
+		EmailOnSyncFailure:   false,
+		SyncFailureThreshold: 5,
+	})
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.False(updated.EmailOnSyncFailure)
+	assert.Equal(5, updated.SyncFailureThreshold)
+
+	other, err := repo.Upsert(ctx, &models.NotificationPreferences{UserID: "other_user", SyncFailureThreshold: 1})
+	assert.NoError(err)
+	assert.NotEqual(created.ID, other.ID)
+}
+
+func TestNotificationPreferencesRepositoryPocketbase_GetByUserID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupNotificationPreferencesCollection(t, app)
+	repo := NewNotificationPreferencesRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	_, err := repo.GetByUserID(ctx, "user123")
+	assert.ErrorIs(err, repositories.ErrNotificationPreferencesNotFound)
+
+	_, err = repo.Upsert(ctx, &models.NotificationPreferences{
+		UserID:               "user123",
+		EmailOnSyncFailure:   true,
+		SyncFailureThreshold: 4,
+		SyncSummaryChannels:  []models.NotificationChannel{models.NotificationChannelDiscord},
+		DiscordWebhookURL:    "https://discord.com/api/webhooks/123/token",
+	})
+	assert.NoError(err)
+
+	preferences, err := repo.GetByUserID(ctx, "user123")
+	assert.NoError(err)
+	assert.Equal("user123", preferences.UserID)
+	assert.True(preferences.EmailOnSyncFailure)
+	assert.Equal(4, preferences.SyncFailureThreshold)
+	assert.Equal([]models.NotificationChannel{models.NotificationChannelDiscord}, preferences.SyncSummaryChannels)
+	assert.Equal("https://discord.com/api/webhooks/123/token", preferences.DiscordWebhookURL)
+	assert.Empty(preferences.SlackWebhookURL)
+}
@@ -0,0 +1,121 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type PlaylistMembershipRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewPlaylistMembershipRepositoryPocketbase(pb *pocketbase.PocketBase) *PlaylistMembershipRepositoryPocketbase {
+	return &PlaylistMembershipRepositoryPocketbase{
+		collection: CollectionPlaylistMembership,
+		app:        pb,
+		log:        pb.Logger().With("component", "PlaylistMembershipRepositoryPocketbase"),
+	}
+}
+
+func (pmRepo *PlaylistMembershipRepositoryPocketbase) Upsert(ctx context.Context, membership *models.PlaylistMembership) (*models.PlaylistMembership, error) {
+	collection, err := pmRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := pmRepo.findRecordByChildPlaylistID(ctx, collection, membership.ChildPlaylistID)
+	if err != nil {
+		record = core.NewRecord(collection)
+	} else if record.GetString("user_id") != membership.UserID {
+		pmRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"child_playlist_id", membership.ChildPlaylistID,
+			"user_id", membership.UserID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	trackURIs := membership.TrackURIs
+	if trackURIs == nil {
+		trackURIs = []string{}
+	}
+
+	record.Set("user_id", membership.UserID)
+	record.Set("child_playlist_id", membership.ChildPlaylistID)
+	record.Set("sync_event_id", membership.SyncEventID)
+	record.Set("spotify_playlist_id", membership.SpotifyPlaylistID)
+	record.Set("track_uris", trackURIs)
+
+	err = appFromContext(ctx, pmRepo.app).Save(record)
+	if err != nil {
+		pmRepo.log.ErrorContext(ctx, "unable to store playlist_membership record", "child_playlist_id", membership.ChildPlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	pmRepo.log.InfoContext(ctx, "playlist_membership stored successfully", "id", record.Id, "track_count", len(trackURIs))
+	return recordToPlaylistMembership(record), nil
+}
+
+func (pmRepo *PlaylistMembershipRepositoryPocketbase) GetByChildPlaylistID(ctx context.Context, childPlaylistID, userID string) (*models.PlaylistMembership, error) {
+	collection, err := pmRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := pmRepo.findRecordByChildPlaylistID(ctx, collection, childPlaylistID)
+	if err != nil {
+		return nil, repositories.ErrPlaylistMembershipNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userID {
+		pmRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"child_playlist_id", childPlaylistID,
+			"requested_by", userID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return recordToPlaylistMembership(record), nil
+}
+
+func (pmRepo *PlaylistMembershipRepositoryPocketbase) findRecordByChildPlaylistID(ctx context.Context, collection *core.Collection, childPlaylistID string) (*core.Record, error) {
+	return appFromContext(ctx, pmRepo.app).FindFirstRecordByFilter(collection, "child_playlist_id = {:childPlaylistID}", dbx.Params{"childPlaylistID": childPlaylistID})
+}
+
+func (pmRepo *PlaylistMembershipRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, pmRepo.app).FindCollectionByNameOrId(string(pmRepo.collection))
+	if err != nil {
+		pmRepo.log.ErrorContext(ctx, "unable to find collection", "collection", pmRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func recordToPlaylistMembership(record *core.Record) *models.PlaylistMembership {
+	membership := &models.PlaylistMembership{
+		ID:                record.Id,
+		UserID:            record.GetString("user_id"),
+		ChildPlaylistID:   record.GetString("child_playlist_id"),
+		SyncEventID:       record.GetString("sync_event_id"),
+		SpotifyPlaylistID: record.GetString("spotify_playlist_id"),
+		Created:           record.GetDateTime("created").Time(),
+		Updated:           record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("track_uris", &membership.TrackURIs); err != nil || membership.TrackURIs == nil {
+		membership.TrackURIs = []string{}
+	}
+
+	return membership
+}
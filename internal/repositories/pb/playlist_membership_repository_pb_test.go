@@ -0,0 +1,76 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistMembershipRepositoryPocketbase_Upsert(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupPlaylistMembershipCollection(t, app)
+	repo := NewPlaylistMembershipRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Upsert(ctx, &models.PlaylistMembership{
+		UserID:            "user123",
+		ChildPlaylistID:   "child123",
+		SyncEventID:       "sync1",
+		SpotifyPlaylistID: "spotify1",
+		TrackURIs:         []string{"spotify:track:1", "spotify:track:2"},
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal([]string{"spotify:track:1", "spotify:track:2"}, created.TrackURIs)
+
+	// A later sync replaces the membership of the same child playlist
+	updated, err := repo.Upsert(ctx, &models.PlaylistMembership{
+		UserID:            "user123",
+		ChildPlaylistID:   "child123",
+		SyncEventID:       "sync2",
+		SpotifyPlaylistID: "spotify2",
+	})
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.Equal("sync2", updated.SyncEventID)
+	assert.Equal("spotify2", updated.SpotifyPlaylistID)
+	assert.Empty(updated.TrackURIs)
+
+	_, err = repo.Upsert(ctx, &models.PlaylistMembership{UserID: "other_user", ChildPlaylistID: "child123"})
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+}
+
+func TestPlaylistMembershipRepositoryPocketbase_GetByChildPlaylistID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupPlaylistMembershipCollection(t, app)
+	repo := NewPlaylistMembershipRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	_, err := repo.Upsert(ctx, &models.PlaylistMembership{
+		UserID:          "user123",
+		ChildPlaylistID: "child123",
+		TrackURIs:       []string{"spotify:track:1"},
+	})
+	assert.NoError(err)
+
+	result, err := repo.GetByChildPlaylistID(ctx, "child123", "user123")
+	assert.NoError(err)
+	assert.Equal([]string{"spotify:track:1"}, result.TrackURIs)
+
+	result, err = repo.GetByChildPlaylistID(ctx, "child123", "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+	assert.Nil(result)
+
+	result, err = repo.GetByChildPlaylistID(ctx, "nonexistent", "user123")
+	assert.ErrorIs(err, repositories.ErrPlaylistMembershipNotFound)
+	assert.Nil(result)
+}
@@ -0,0 +1,114 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type PlaylistSnapshotRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewPlaylistSnapshotRepositoryPocketbase(pb *pocketbase.PocketBase) *PlaylistSnapshotRepositoryPocketbase {
+	return &PlaylistSnapshotRepositoryPocketbase{
+		collection: CollectionPlaylistSnapshot,
+		app:        pb,
+		log:        pb.Logger().With("component", "PlaylistSnapshotRepositoryPocketbase"),
+	}
+}
+
+func (psRepo *PlaylistSnapshotRepositoryPocketbase) Create(ctx context.Context, snapshot *models.PlaylistSnapshot) (*models.PlaylistSnapshot, error) {
+	collection, err := psRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	trackURIs := snapshot.TrackURIs
+	if trackURIs == nil {
+		trackURIs = []string{}
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("user_id", snapshot.UserID)
+	record.Set("sync_event_id", snapshot.SyncEventID)
+	record.Set("child_playlist_id", snapshot.ChildPlaylistID)
+	record.Set("spotify_playlist_id", snapshot.SpotifyPlaylistID)
+	record.Set("track_uris", trackURIs)
+
+	err = appFromContext(ctx, psRepo.app).Save(record)
+	if err != nil {
+		psRepo.log.ErrorContext(ctx, "unable to store playlist_snapshot record", "sync_event_id", snapshot.SyncEventID, "child_playlist_id", snapshot.ChildPlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	psRepo.log.InfoContext(ctx, "playlist_snapshot stored successfully", "id", record.Id, "track_count", len(trackURIs))
+	return recordToPlaylistSnapshot(record), nil
+}
+
+func (psRepo *PlaylistSnapshotRepositoryPocketbase) GetBySyncEventID(ctx context.Context, syncEventID, userID string) ([]*models.PlaylistSnapshot, error) {
+	collection, err := psRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, psRepo.app).FindRecordsByFilter(
+		collection,
+		"sync_event_id = {:syncEventID} && user_id = {:userID}",
+		"created",
+		0, // limit (0 = no limit)
+		0, // offset
+		dbx.Params{
+			"syncEventID": syncEventID,
+			"userID":      userID,
+		},
+	)
+	if err != nil {
+		psRepo.log.ErrorContext(ctx, "unable to find playlist_snapshot records for sync event", "sync_event_id", syncEventID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	snapshots := make([]*models.PlaylistSnapshot, len(records))
+	for i, record := range records {
+		snapshots[i] = recordToPlaylistSnapshot(record)
+	}
+
+	psRepo.log.InfoContext(ctx, "playlist_snapshots retrieved successfully", "sync_event_id", syncEventID, "count", len(snapshots))
+	return snapshots, nil
+}
+
+func (psRepo *PlaylistSnapshotRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, psRepo.app).FindCollectionByNameOrId(string(psRepo.collection))
+	if err != nil {
+		psRepo.log.ErrorContext(ctx, "unable to find collection", "collection", psRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func recordToPlaylistSnapshot(record *core.Record) *models.PlaylistSnapshot {
+	snapshot := &models.PlaylistSnapshot{
+		ID:                record.Id,
+		UserID:            record.GetString("user_id"),
+		SyncEventID:       record.GetString("sync_event_id"),
+		ChildPlaylistID:   record.GetString("child_playlist_id"),
+		SpotifyPlaylistID: record.GetString("spotify_playlist_id"),
+		Created:           record.GetDateTime("created").Time(),
+		Updated:           record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("track_uris", &snapshot.TrackURIs); err != nil || snapshot.TrackURIs == nil {
+		snapshot.TrackURIs = []string{}
+	}
+
+	return snapshot
+}
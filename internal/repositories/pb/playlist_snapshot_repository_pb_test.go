@@ -0,0 +1,99 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistSnapshotRepositoryPocketbase_Create_Success(t *testing.T) {
+	tests := []struct {
+		name      string
+		trackURIs []string
+		expected  []string
+	}{
+		{
+			name:      "snapshot with tracks",
+			trackURIs: []string{"spotify:track:1", "spotify:track:2"},
+			expected:  []string{"spotify:track:1", "spotify:track:2"},
+		},
+		{
+			name:      "snapshot of empty playlist",
+			trackURIs: nil,
+			expected:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			app := NewTestApp(t)
+			SetupPlaylistSnapshotCollection(t, app)
+			repo := NewPlaylistSnapshotRepositoryPocketbase(app)
+
+			snapshot, err := repo.Create(context.Background(), &models.PlaylistSnapshot{
+				UserID:            "user123",
+				SyncEventID:       "sync123",
+				ChildPlaylistID:   "child123",
+				SpotifyPlaylistID: "spotify123",
+				TrackURIs:         tt.trackURIs,
+			})
+
+			assert.NoError(err)
+			assert.NotEmpty(snapshot.ID)
+			assert.Equal("user123", snapshot.UserID)
+			assert.Equal("sync123", snapshot.SyncEventID)
+			assert.Equal("child123", snapshot.ChildPlaylistID)
+			assert.Equal("spotify123", snapshot.SpotifyPlaylistID)
+			assert.Equal(tt.expected, snapshot.TrackURIs)
+			assert.False(snapshot.Created.IsZero())
+		})
+	}
+}
+
+func TestPlaylistSnapshotRepositoryPocketbase_GetBySyncEventID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupPlaylistSnapshotCollection(t, app)
+	repo := NewPlaylistSnapshotRepositoryPocketbase(app)
+
+	ctx := context.Background()
+	snapshots := []*models.PlaylistSnapshot{
+		{UserID: "user123", SyncEventID: "sync123", ChildPlaylistID: "child1", TrackURIs: []string{"spotify:track:1"}},
+		{UserID: "user123", SyncEventID: "sync123", ChildPlaylistID: "child2", TrackURIs: []string{"spotify:track:2"}},
+		{UserID: "user123", SyncEventID: "sync456", ChildPlaylistID: "child1", TrackURIs: []string{"spotify:track:3"}},
+		{UserID: "user456", SyncEventID: "sync123", ChildPlaylistID: "child3", TrackURIs: []string{"spotify:track:4"}},
+	}
+	for _, snapshot := range snapshots {
+		_, err := repo.Create(ctx, snapshot)
+		assert.NoError(err)
+	}
+
+	result, err := repo.GetBySyncEventID(ctx, "sync123", "user123")
+	assert.NoError(err)
+	assert.Len(result, 2)
+
+	childIDs := []string{result[0].ChildPlaylistID, result[1].ChildPlaylistID}
+	assert.ElementsMatch([]string{"child1", "child2"}, childIDs)
+
+	result, err = repo.GetBySyncEventID(ctx, "sync123", "other_user")
+	assert.NoError(err)
+	assert.Empty(result)
+}
+
+func TestPlaylistSnapshotRepositoryPocketbase_CollectionNotFound(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	repo := NewPlaylistSnapshotRepositoryPocketbase(app)
+
+	_, err := repo.Create(context.Background(), &models.PlaylistSnapshot{UserID: "user123"})
+	assert.Error(err)
+
+	_, err = repo.GetBySyncEventID(context.Background(), "sync123", "user123")
+	assert.Error(err)
+}
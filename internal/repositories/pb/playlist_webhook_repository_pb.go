@@ -0,0 +1,166 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type PlaylistWebhookRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewPlaylistWebhookRepositoryPocketbase(pb *pocketbase.PocketBase) *PlaylistWebhookRepositoryPocketbase {
+	return &PlaylistWebhookRepositoryPocketbase{
+		collection: CollectionPlaylistWebhook,
+		app:        pb,
+		log:        pb.Logger().With("component", "PlaylistWebhookRepositoryPocketbase"),
+	}
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPocketbase) Create(ctx context.Context, webhook *models.PlaylistWebhook) (*models.PlaylistWebhook, error) {
+	collection, err := GetCollection(ctx, pwRepo.app, pwRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("user_id", webhook.UserID)
+	record.Set("base_playlist_id", webhook.BasePlaylistID)
+	record.Set("url", webhook.URL)
+	record.Set("secret", webhook.Secret)
+	record.Set("is_active", webhook.IsActive)
+
+	err = appFromContext(ctx, pwRepo.app).Save(record)
+	if err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to store playlist_webhook record", "base_playlist_id", webhook.BasePlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	pwRepo.log.InfoContext(ctx, "playlist_webhook stored successfully", "id", record.Id, "base_playlist_id", webhook.BasePlaylistID)
+	return recordToPlaylistWebhook(record), nil
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPocketbase) GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.PlaylistWebhook, error) {
+	return pwRepo.findWebhooks(ctx,
+		"base_playlist_id = {:basePlaylistID} && user_id = {:userID}",
+		dbx.Params{"basePlaylistID": basePlaylistID, "userID": userID},
+	)
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPocketbase) GetActive(ctx context.Context) ([]*models.PlaylistWebhook, error) {
+	return pwRepo.findWebhooks(ctx, "is_active = true", nil)
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPocketbase) Delete(ctx context.Context, id, userID string) error {
+	record, err := pwRepo.findOwnedRecord(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = appFromContext(ctx, pwRepo.app).Delete(record)
+	if err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to delete playlist_webhook record", "id", id, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	pwRepo.log.InfoContext(ctx, "playlist_webhook deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPocketbase) RecordDelivery(ctx context.Context, id string, status int, deliveredAt time.Time) error {
+	collection, err := GetCollection(ctx, pwRepo.app, pwRepo.collection)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, pwRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		return repositories.ErrPlaylistWebhookNotFound
+	}
+
+	record.Set("last_delivery_status", status)
+	record.Set("last_delivered_at", deliveredAt)
+
+	err = appFromContext(ctx, pwRepo.app).Save(record)
+	if err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to update playlist_webhook record", "id", id, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return nil
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPocketbase) findWebhooks(ctx context.Context, filter string, params dbx.Params) ([]*models.PlaylistWebhook, error) {
+	collection, err := GetCollection(ctx, pwRepo.app, pwRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, pwRepo.app).FindRecordsByFilter(collection, filter, "created", 0, 0, params)
+	if err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to find playlist_webhook records", "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	webhooks := make([]*models.PlaylistWebhook, len(records))
+	for i, record := range records {
+		webhooks[i] = recordToPlaylistWebhook(record)
+	}
+
+	return webhooks, nil
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPocketbase) findOwnedRecord(ctx context.Context, id, userID string) (*core.Record, error) {
+	collection, err := GetCollection(ctx, pwRepo.app, pwRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, pwRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		return nil, repositories.ErrPlaylistWebhookNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userID {
+		pwRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return record, nil
+}
+
+func recordToPlaylistWebhook(record *core.Record) *models.PlaylistWebhook {
+	webhook := &models.PlaylistWebhook{
+		ID:                 record.Id,
+		UserID:             record.GetString("user_id"),
+		BasePlaylistID:     record.GetString("base_playlist_id"),
+		URL:                record.GetString("url"),
+		Secret:             record.GetString("secret"),
+		IsActive:           record.GetBool("is_active"),
+		LastDeliveryStatus: record.GetInt("last_delivery_status"),
+		Created:            record.GetDateTime("created").Time(),
+		Updated:            record.GetDateTime("updated").Time(),
+	}
+
+	if deliveredAt := record.GetDateTime("last_delivered_at"); !deliveredAt.IsZero() {
+		t := deliveredAt.Time()
+		webhook.LastDeliveredAt = &t
+	}
+
+	return webhook
+}
@@ -0,0 +1,114 @@
+package pb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistWebhookRepositoryPocketbase_CreateAndList(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupPlaylistWebhookCollection(t, app)
+	repo := NewPlaylistWebhookRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.PlaylistWebhook{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		URL:            "https://example.com/hook",
+		Secret:         "secret",
+		IsActive:       true,
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal("secret", created.Secret)
+	assert.Nil(created.LastDeliveredAt)
+
+	_, err = repo.Create(ctx, &models.PlaylistWebhook{
+		UserID:         "user123",
+		BasePlaylistID: "base456",
+		URL:            "https://example.com/other",
+		Secret:         "secret",
+		IsActive:       false,
+	})
+	assert.NoError(err)
+
+	webhooks, err := repo.GetByBasePlaylistID(ctx, "base123", "user123")
+	assert.NoError(err)
+	assert.Len(webhooks, 1)
+	assert.Equal(created.ID, webhooks[0].ID)
+
+	webhooks, err = repo.GetByBasePlaylistID(ctx, "base123", "other_user")
+	assert.NoError(err)
+	assert.Empty(webhooks)
+
+	active, err := repo.GetActive(ctx)
+	assert.NoError(err)
+	assert.Len(active, 1)
+	assert.Equal("base123", active[0].BasePlaylistID)
+}
+
+func TestPlaylistWebhookRepositoryPocketbase_RecordDelivery(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupPlaylistWebhookCollection(t, app)
+	repo := NewPlaylistWebhookRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.PlaylistWebhook{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		URL:            "https://example.com/hook",
+		Secret:         "secret",
+		IsActive:       true,
+	})
+	assert.NoError(err)
+
+	deliveredAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(repo.RecordDelivery(ctx, created.ID, 204, deliveredAt))
+
+	webhooks, err := repo.GetByBasePlaylistID(ctx, "base123", "user123")
+	assert.NoError(err)
+	assert.Equal(204, webhooks[0].LastDeliveryStatus)
+	assert.NotNil(webhooks[0].LastDeliveredAt)
+	assert.True(deliveredAt.Equal(*webhooks[0].LastDeliveredAt))
+
+	err = repo.RecordDelivery(ctx, "nonexistent", 200, deliveredAt)
+	assert.ErrorIs(err, repositories.ErrPlaylistWebhookNotFound)
+}
+
+func TestPlaylistWebhookRepositoryPocketbase_Delete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupPlaylistWebhookCollection(t, app)
+	repo := NewPlaylistWebhookRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &models.PlaylistWebhook{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		URL:            "https://example.com/hook",
+		Secret:         "secret",
+		IsActive:       true,
+	})
+	assert.NoError(err)
+
+	err = repo.Delete(ctx, created.ID, "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+
+	assert.NoError(repo.Delete(ctx, created.ID, "user123"))
+
+	err = repo.Delete(ctx, created.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrPlaylistWebhookNotFound)
+}
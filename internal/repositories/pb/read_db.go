@@ -0,0 +1,119 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+const primaryDBFile = "data.db"
+
+// OpenReadDB opens the read-only connection pool used by heavy list queries so they
+// don't contend with sync write bursts. It reads from the configured replica when set,
+// otherwise from the primary database inside dataDir.
+func OpenReadDB(dataDir string, cfg config.DatabaseConfig) (*dbx.DB, error) {
+	dbPath := cfg.ReadReplicaPath
+	if dbPath == "" {
+		dbPath = filepath.Join(dataDir, primaryDBFile)
+	}
+
+	db, err := dbx.Open("sqlite", dbPath+"?"+BuildReadPragmaParams(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open read database: %w", err)
+	}
+
+	if cfg.ReadMaxOpenConns > 0 {
+		db.DB().SetMaxOpenConns(cfg.ReadMaxOpenConns)
+		db.DB().SetMaxIdleConns(cfg.ReadMaxOpenConns)
+	}
+
+	return db, nil
+}
+
+// findRecordsOnReadDB runs a list query for the collection on the given read builder
+// and hydrates the rows into records, mirroring how PocketBase loads them.
+func findRecordsOnReadDB(
+	ctx context.Context,
+	db dbx.Builder,
+	collection *core.Collection,
+	where dbx.Expression,
+	orderBy ...string,
+) ([]*core.Record, error) {
+	query := db.Select(db.QuoteSimpleColumnName(collection.Name) + ".*").
+		From(collection.Name).
+		Where(where).
+		OrderBy(orderBy...)
+
+	return loadRecordsOnReadDB(ctx, query, collection)
+}
+
+// findRecordPageOnReadDB is findRecordsOnReadDB limited to a page of the matching records
+func findRecordPageOnReadDB(
+	ctx context.Context,
+	db dbx.Builder,
+	collection *core.Collection,
+	where dbx.Expression,
+	limit, offset int,
+	orderBy ...string,
+) ([]*core.Record, error) {
+	query := db.Select(db.QuoteSimpleColumnName(collection.Name) + ".*").
+		From(collection.Name).
+		Where(where).
+		OrderBy(orderBy...).
+		Limit(int64(limit)).
+		Offset(int64(offset))
+
+	return loadRecordsOnReadDB(ctx, query, collection)
+}
+
+// countRecordsOnReadDB counts the records of the collection matching where on the given read builder
+func countRecordsOnReadDB(ctx context.Context, db dbx.Builder, collection *core.Collection, where dbx.Expression) (int, error) {
+	var total int
+	err := db.Select("COUNT(*)").
+		From(collection.Name).
+		Where(where).
+		WithContext(ctx).
+		Row(&total)
+
+	return total, err
+}
+
+func loadRecordsOnReadDB(ctx context.Context, query *dbx.SelectQuery, collection *core.Collection) ([]*core.Record, error) {
+	rows := []dbx.NullStringMap{}
+	if err := query.WithContext(ctx).All(&rows); err != nil {
+		return nil, err
+	}
+
+	records := make([]*core.Record, len(rows))
+	for i, row := range rows {
+		record := core.NewRecord(collection)
+
+		for _, field := range collection.Fields {
+			name := field.GetName()
+
+			var raw any
+			if value, ok := row[name]; ok && value.Valid {
+				raw = value.String
+			}
+
+			value, err := field.PrepareValue(record, raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load field %s: %w", name, err)
+			}
+
+			record.SetRaw(name, value)
+		}
+
+		if err := record.PostScan(); err != nil {
+			return nil, err
+		}
+
+		records[i] = record
+	}
+
+	return records, nil
+}
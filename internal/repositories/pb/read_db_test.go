@@ -0,0 +1,53 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReadPragmaParams(t *testing.T) {
+	assert := require.New(t)
+
+	params := BuildReadPragmaParams(testDatabaseConfig())
+
+	assert.Equal(
+		"_pragma=busy_timeout%285000%29"+
+			"&_pragma=query_only%281%29"+
+			"&_pragma=temp_store%28MEMORY%29"+
+			"&_pragma=cache_size%28-8000%29",
+		params,
+	)
+}
+
+func TestOpenReadDB_ReadsPrimaryAndRejectsWrites(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+
+	ctx := context.Background()
+	_, err := NewBasePlaylistRepositoryPocketbase(app).Create(ctx, "user123", "Base Playlist", "spotify123", "", "", "")
+	assert.NoError(err)
+
+	cfg := testDatabaseConfig()
+	cfg.ReadMaxOpenConns = 2
+
+	readDB, err := OpenReadDB(app.DataDir(), cfg)
+	assert.NoError(err)
+	defer func() { _ = readDB.Close() }()
+
+	repo := NewBasePlaylistRepositoryPocketbase(app).WithReadDB(readDB)
+
+	basePlaylists, err := repo.GetByUserID(ctx, "user123")
+	assert.NoError(err)
+	assert.Len(basePlaylists, 1)
+	assert.Equal("Base Playlist", basePlaylists[0].Name)
+	assert.Equal("spotify123", basePlaylists[0].SpotifyPlaylistID)
+	assert.True(basePlaylists[0].IsActive)
+	assert.False(basePlaylists[0].Created.IsZero())
+
+	_, err = readDB.NewQuery("DELETE FROM " + string(CollectionBasePlaylist)).Execute()
+	assert.Error(err)
+}
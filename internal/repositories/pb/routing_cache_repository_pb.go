@@ -0,0 +1,112 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type RoutingCacheRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewRoutingCacheRepositoryPocketbase(pb *pocketbase.PocketBase) *RoutingCacheRepositoryPocketbase {
+	return &RoutingCacheRepositoryPocketbase{
+		collection: CollectionRoutingCache,
+		app:        pb,
+		log:        pb.Logger().With("component", "RoutingCacheRepositoryPocketbase"),
+	}
+}
+
+func (rcRepo *RoutingCacheRepositoryPocketbase) Upsert(ctx context.Context, entry *models.RoutingCacheEntry) (*models.RoutingCacheEntry, error) {
+	collection, err := GetCollection(ctx, rcRepo.app, rcRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, rcRepo.app).FindFirstRecordByFilter(collection, "child_playlist_id = {:childPlaylistID}", dbx.Params{"childPlaylistID": entry.ChildPlaylistID})
+	if err != nil {
+		record = core.NewRecord(collection)
+	} else if record.GetString("user_id") != entry.UserID {
+		rcRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"child_playlist_id", entry.ChildPlaylistID,
+			"user_id", entry.UserID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	trackURIs := entry.TrackURIs
+	if trackURIs == nil {
+		trackURIs = []string{}
+	}
+
+	record.Set("user_id", entry.UserID)
+	record.Set("child_playlist_id", entry.ChildPlaylistID)
+	record.Set("snapshot_id", entry.SnapshotID)
+	record.Set("filter_hash", entry.FilterHash)
+	record.Set("track_uris", trackURIs)
+
+	err = appFromContext(ctx, rcRepo.app).Save(record)
+	if err != nil {
+		rcRepo.log.ErrorContext(ctx, "unable to store routing_cache record", "child_playlist_id", entry.ChildPlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return recordToRoutingCacheEntry(record), nil
+}
+
+func (rcRepo *RoutingCacheRepositoryPocketbase) GetByChildPlaylistIDs(ctx context.Context, childPlaylistIDs []string, userID string) ([]*models.RoutingCacheEntry, error) {
+	collection, err := GetCollection(ctx, rcRepo.app, rcRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(childPlaylistIDs) == 0 {
+		return []*models.RoutingCacheEntry{}, nil
+	}
+
+	ids := make([]any, len(childPlaylistIDs))
+	for i, childPlaylistID := range childPlaylistIDs {
+		ids[i] = childPlaylistID
+	}
+
+	records, err := appFromContext(ctx, rcRepo.app).FindAllRecords(collection, dbx.HashExp{"child_playlist_id": ids, "user_id": userID})
+	if err != nil {
+		rcRepo.log.ErrorContext(ctx, "unable to fetch routing_cache records", "user_id", userID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	entries := make([]*models.RoutingCacheEntry, len(records))
+	for i, record := range records {
+		entries[i] = recordToRoutingCacheEntry(record)
+	}
+
+	return entries, nil
+}
+
+func recordToRoutingCacheEntry(record *core.Record) *models.RoutingCacheEntry {
+	entry := &models.RoutingCacheEntry{
+		ID:              record.Id,
+		UserID:          record.GetString("user_id"),
+		ChildPlaylistID: record.GetString("child_playlist_id"),
+		SnapshotID:      record.GetString("snapshot_id"),
+		FilterHash:      record.GetString("filter_hash"),
+		Created:         record.GetDateTime("created").Time(),
+		Updated:         record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("track_uris", &entry.TrackURIs); err != nil || entry.TrackURIs == nil {
+		entry.TrackURIs = []string{}
+	}
+
+	return entry
+}
@@ -0,0 +1,76 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingCacheRepositoryPocketbase_Upsert(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupRoutingCacheCollection(t, app)
+	repo := NewRoutingCacheRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Upsert(ctx, &models.RoutingCacheEntry{
+		UserID:          "user123",
+		ChildPlaylistID: "child123",
+		SnapshotID:      "snap1",
+		FilterHash:      "hash1",
+		TrackURIs:       []string{"spotify:track:1"},
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+
+	// A new snapshot replaces the stored entry of the child playlist
+	updated, err := repo.Upsert(ctx, &models.RoutingCacheEntry{
+		UserID:          "user123",
+		ChildPlaylistID: "child123",
+		SnapshotID:      "snap2",
+		FilterHash:      "hash1",
+	})
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.Equal("snap2", updated.SnapshotID)
+	assert.Empty(updated.TrackURIs)
+
+	_, err = repo.Upsert(ctx, &models.RoutingCacheEntry{UserID: "other_user", ChildPlaylistID: "child123"})
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+}
+
+func TestRoutingCacheRepositoryPocketbase_GetByChildPlaylistIDs(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupRoutingCacheCollection(t, app)
+	repo := NewRoutingCacheRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	entries := []*models.RoutingCacheEntry{
+		{UserID: "user123", ChildPlaylistID: "child1", SnapshotID: "snap1", FilterHash: "hash1", TrackURIs: []string{"spotify:track:1", "spotify:track:2"}},
+		{UserID: "user123", ChildPlaylistID: "child2", SnapshotID: "snap1", FilterHash: "hash2", TrackURIs: []string{"spotify:track:3"}},
+		{UserID: "other_user", ChildPlaylistID: "child3", SnapshotID: "snap1", FilterHash: "hash3"},
+	}
+	for _, entry := range entries {
+		_, err := repo.Upsert(ctx, entry)
+		assert.NoError(err)
+	}
+
+	result, err := repo.GetByChildPlaylistIDs(ctx, []string{"child1", "child3", "nonexistent"}, "user123")
+	assert.NoError(err)
+	assert.Len(result, 1)
+	assert.Equal("child1", result[0].ChildPlaylistID)
+	assert.Equal("hash1", result[0].FilterHash)
+	assert.Equal([]string{"spotify:track:1", "spotify:track:2"}, result[0].TrackURIs)
+
+	result, err = repo.GetByChildPlaylistIDs(ctx, []string{}, "user123")
+	assert.NoError(err)
+	assert.Empty(result)
+}
@@ -0,0 +1,107 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type RoutingReportRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewRoutingReportRepositoryPocketbase(pb *pocketbase.PocketBase) *RoutingReportRepositoryPocketbase {
+	return &RoutingReportRepositoryPocketbase{
+		collection: CollectionRoutingReport,
+		app:        pb,
+		log:        pb.Logger().With("component", "RoutingReportRepositoryPocketbase"),
+	}
+}
+
+func (rrRepo *RoutingReportRepositoryPocketbase) Upsert(ctx context.Context, report *models.RoutingReport) (*models.RoutingReport, error) {
+	collection, err := GetCollection(ctx, rrRepo.app, rrRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, rrRepo.app).FindFirstRecordByFilter(collection, "base_playlist_id = {:basePlaylistID}", dbx.Params{"basePlaylistID": report.BasePlaylistID})
+	if err != nil {
+		record = core.NewRecord(collection)
+	} else if record.GetString("user_id") != report.UserID {
+		rrRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"base_playlist_id", report.BasePlaylistID,
+			"user_id", report.UserID,
+			"actual_user_id", record.GetString("user_id"),
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	tracks := report.Tracks
+	if tracks == nil {
+		tracks = []models.TrackRoutingDecision{}
+	}
+
+	record.Set("user_id", report.UserID)
+	record.Set("base_playlist_id", report.BasePlaylistID)
+	record.Set("sync_event_id", report.SyncEventID)
+	record.Set("dedupe_strategy", string(report.DedupeStrategy))
+	record.Set("tracks", tracks)
+
+	err = appFromContext(ctx, rrRepo.app).Save(record)
+	if err != nil {
+		rrRepo.log.ErrorContext(ctx, "unable to store routing_report record", "base_playlist_id", report.BasePlaylistID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	rrRepo.log.InfoContext(ctx, "routing_report stored successfully", "id", record.Id, "track_count", len(tracks))
+	return recordToRoutingReport(record), nil
+}
+
+func (rrRepo *RoutingReportRepositoryPocketbase) GetBySyncEventID(ctx context.Context, syncEventID, userID string) (*models.RoutingReport, error) {
+	collection, err := GetCollection(ctx, rrRepo.app, rrRepo.collection)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, rrRepo.app).FindFirstRecordByFilter(collection, "sync_event_id = {:syncEventID}", dbx.Params{"syncEventID": syncEventID})
+	if err != nil {
+		return nil, repositories.ErrRoutingReportNotFound
+	}
+
+	// Check ownership
+	if record.GetString("user_id") != userID {
+		rrRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"sync_event_id", syncEventID,
+			"requested_by", userID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return recordToRoutingReport(record), nil
+}
+
+func recordToRoutingReport(record *core.Record) *models.RoutingReport {
+	report := &models.RoutingReport{
+		ID:             record.Id,
+		UserID:         record.GetString("user_id"),
+		BasePlaylistID: record.GetString("base_playlist_id"),
+		SyncEventID:    record.GetString("sync_event_id"),
+		DedupeStrategy: models.DedupeStrategy(record.GetString("dedupe_strategy")),
+		Created:        record.GetDateTime("created").Time(),
+		Updated:        record.GetDateTime("updated").Time(),
+	}
+
+	if err := record.UnmarshalJSONField("tracks", &report.Tracks); err != nil || report.Tracks == nil {
+		report.Tracks = []models.TrackRoutingDecision{}
+	}
+
+	return report
+}
@@ -0,0 +1,89 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingReportRepositoryPocketbase_Upsert(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupRoutingReportCollection(t, app)
+	repo := NewRoutingReportRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Upsert(ctx, &models.RoutingReport{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		SyncEventID:    "sync1",
+		DedupeStrategy: models.DedupeStrategyFirstMatch,
+		Tracks: []models.TrackRoutingDecision{
+			{
+				TrackURI:        "spotify:track:1",
+				TrackName:       "Track 1",
+				MatchedChildIDs: []string{"child1", "child2"},
+				RoutedChildIDs:  []string{"child1"},
+				Outcome:         models.RoutingOutcomeRouted,
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal(models.DedupeStrategyFirstMatch, created.DedupeStrategy)
+	assert.Len(created.Tracks, 1)
+	assert.Equal([]string{"child1", "child2"}, created.Tracks[0].MatchedChildIDs)
+
+	// The report of a newer sync replaces the stored one for the same base playlist
+	updated, err := repo.Upsert(ctx, &models.RoutingReport{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		SyncEventID:    "sync2",
+	})
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.Equal("sync2", updated.SyncEventID)
+	assert.Empty(updated.Tracks)
+
+	_, err = repo.Upsert(ctx, &models.RoutingReport{UserID: "other_user", BasePlaylistID: "base123"})
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+}
+
+func TestRoutingReportRepositoryPocketbase_GetBySyncEventID(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupRoutingReportCollection(t, app)
+	repo := NewRoutingReportRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	_, err := repo.Upsert(ctx, &models.RoutingReport{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		SyncEventID:    "sync1",
+		Tracks: []models.TrackRoutingDecision{
+			{TrackURI: "spotify:track:1", MatchedChildIDs: []string{}, RoutedChildIDs: []string{}, Outcome: models.RoutingOutcomeUnmatched},
+		},
+	})
+	assert.NoError(err)
+
+	result, err := repo.GetBySyncEventID(ctx, "sync1", "user123")
+	assert.NoError(err)
+	assert.Equal("base123", result.BasePlaylistID)
+	assert.Len(result.Tracks, 1)
+	assert.Equal(models.RoutingOutcomeUnmatched, result.Tracks[0].Outcome)
+
+	result, err = repo.GetBySyncEventID(ctx, "sync1", "other_user")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+	assert.Nil(result)
+
+	result, err = repo.GetBySyncEventID(ctx, "nonexistent", "user123")
+	assert.ErrorIs(err, repositories.ErrRoutingReportNotFound)
+	assert.Nil(result)
+}
@@ -0,0 +1,60 @@
+package pb
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// cascadeDeletedAt returns the deleted_at of a base playlist deleted with its child playlists. As
+// deleted_at only has millisecond precision, it is moved past the deleted_at of any child playlist
+// deleted on its own in the same millisecond, so Restore doesn't bring that child playlist back
+func cascadeDeletedAt(app core.App, children dbx.HashExp) (types.DateTime, error) {
+	deletedAt := types.NowDateTime()
+	for {
+		sameDeletedAt, err := app.FindAllRecords(string(CollectionChildPlaylist), children, dbx.HashExp{"deleted_at": deletedAt.String()})
+		if err != nil {
+			return types.DateTime{}, err
+		}
+		if len(sameDeletedAt) == 0 {
+			return deletedAt, nil
+		}
+
+		deletedAt = deletedAt.Add(time.Millisecond)
+	}
+}
+
+// notDeleted matches the records that were not soft deleted
+func notDeleted() dbx.Expression {
+	return dbx.HashExp{"deleted_at": ""}
+}
+
+// deletedBefore matches the records soft deleted before the cutoff
+func deletedBefore(cutoff time.Time) dbx.Expression {
+	return dbx.And(
+		dbx.Not(dbx.HashExp{"deleted_at": ""}),
+		dbx.NewExp("deleted_at < {:cutoff}", dbx.Params{"cutoff": cutoff.UTC().Format(types.DefaultDateLayout)}),
+	)
+}
+
+func isSoftDeleted(record *core.Record) bool {
+	return !record.GetDateTime("deleted_at").IsZero()
+}
+
+// purgeRecords permanently deletes the records of the collection soft deleted before the cutoff
+func purgeRecords(app core.App, collection *core.Collection, cutoff time.Time) (int, error) {
+	records, err := app.FindAllRecords(collection, deletedBefore(cutoff))
+	if err != nil {
+		return 0, err
+	}
+
+	for i, record := range records {
+		if err := app.Delete(record); err != nil {
+			return i, err
+		}
+	}
+
+	return len(records), nil
+}
@@ -2,6 +2,7 @@ package pb
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -10,12 +11,14 @@ import (
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 type SpotifyIntegrationRepositoryPocketbase struct {
-	collection Collection
-	app        *pocketbase.PocketBase
-	log        *slog.Logger
+	collection  Collection
+	app         *pocketbase.PocketBase
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
 }
 
 func NewSpotifyIntegrationRepositoryPocketbase(pb *pocketbase.PocketBase) *SpotifyIntegrationRepositoryPocketbase {
@@ -26,6 +29,12 @@ func NewSpotifyIntegrationRepositoryPocketbase(pb *pocketbase.PocketBase) *Spoti
 	}
 }
 
+// WithTokenCipher encrypts the stored Spotify tokens with the data key of their user
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) WithTokenCipher(tokenCipher repositories.TokenCipher) *SpotifyIntegrationRepositoryPocketbase {
+	siRepo.tokenCipher = tokenCipher
+	return siRepo
+}
+
 func (siRepo *SpotifyIntegrationRepositoryPocketbase) CreateOrUpdate(
 	ctx context.Context,
 	userId string,
@@ -37,35 +46,47 @@ func (siRepo *SpotifyIntegrationRepositoryPocketbase) CreateOrUpdate(
 	}
 
 	var record *core.Record
-	existing, err := siRepo.app.FindFirstRecordByFilter(
+	existing, err := appFromContext(ctx, siRepo.app).FindFirstRecordByFilter(
 		collection,
-		"user = {:user}",
-		dbx.Params{"user": userId},
+		"user = {:user} && spotify_id = {:spotify_id}",
+		dbx.Params{"user": userId, "spotify_id": integration.SpotifyID},
 	)
 	if err != nil {
-		siRepo.log.InfoContext(ctx, "spotify_integration not found", "user", userId)
+		siRepo.log.InfoContext(ctx, "spotify_integration not found", "user", userId, "spotify_id", integration.SpotifyID)
 		record = core.NewRecord(collection)
 		record.Set("user", userId)
 	} else {
-		siRepo.log.InfoContext(ctx, "spotify_integration found", "user", userId, "record", record)
+		siRepo.log.InfoContext(ctx, "spotify_integration found", "user", userId, "record", existing.Id)
 		record = existing
 	}
 
+	accessToken, err := siRepo.encryptToken(ctx, userId, integration.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := siRepo.encryptToken(ctx, userId, integration.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	integration.Provider = integration.Provider.OrDefault()
+	record.Set("provider", string(integration.Provider))
 	record.Set("spotify_id", integration.SpotifyID)
-	record.Set("access_token", integration.AccessToken)
-	record.Set("refresh_token", integration.RefreshToken)
+	record.Set("access_token", accessToken)
+	record.Set("refresh_token", refreshToken)
 	record.Set("token_type", integration.TokenType)
 	record.Set("expires_at", integration.ExpiresAt)
 	record.Set("scope", integration.Scope)
 	record.Set("display_name", integration.DisplayName)
 
-	if err := siRepo.app.Save(record); err != nil {
+	if err := appFromContext(ctx, siRepo.app).Save(record); err != nil {
 		siRepo.log.ErrorContext(ctx, "unable to store spotify_integration record", "error", err)
 		return nil, repositories.ErrDatabaseOperation
 	}
 
 	siRepo.log.InfoContext(ctx, "spotify_integration stored successfully", "user", userId, "spotify_id", integration.SpotifyID)
-	return recordToSpotifyIntegration(record), nil
+	return siRepo.toSpotifyIntegration(ctx, record)
 }
 
 func (siRepo *SpotifyIntegrationRepositoryPocketbase) GetByUserID(ctx context.Context, userId string) (*models.SpotifyIntegration, error) {
@@ -74,18 +95,73 @@ func (siRepo *SpotifyIntegrationRepositoryPocketbase) GetByUserID(ctx context.Co
 		return nil, err
 	}
 
-	record, err := siRepo.app.FindFirstRecordByFilter(
+	records, err := appFromContext(ctx, siRepo.app).FindRecordsByFilter(
 		collection,
 		"user = {:user}",
+		"created",
+		1,
+		0,
 		dbx.Params{"user": userId},
 	)
-	if err != nil {
+	if err != nil || len(records) == 0 {
 		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integration", "user", userId, "error", err)
 		return nil, repositories.ErrSpotifyIntegrationNotFound
 	}
 
-	siRepo.log.InfoContext(ctx, "spotify_integration found", "user", userId, "spotify_id", record.Id)
-	return recordToSpotifyIntegration(record), nil
+	siRepo.log.InfoContext(ctx, "spotify_integration found", "user", userId, "spotify_id", records[0].Id)
+	return siRepo.toSpotifyIntegration(ctx, records[0])
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) GetByID(ctx context.Context, id, userId string) (*models.SpotifyIntegration, error) {
+	collection, err := siRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, siRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integration", "integration_id", id, "error", err)
+		return nil, repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	// Integrations of other users are reported as not found
+	if record.GetString("user") != userId {
+		siRepo.log.ErrorContext(ctx, "unauthorized spotify_integration access attempt", "integration_id", id, "requested_by", userId)
+		return nil, repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	return siRepo.toSpotifyIntegration(ctx, record)
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) ListByUserID(ctx context.Context, userId string) ([]*models.SpotifyIntegration, error) {
+	collection, err := siRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, siRepo.app).FindRecordsByFilter(
+		collection,
+		"user = {:user}",
+		"created",
+		0,
+		0,
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integrations", "user", userId, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	integrations := make([]*models.SpotifyIntegration, 0, len(records))
+	for _, record := range records {
+		integration, err := siRepo.toSpotifyIntegration(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, integration)
+	}
+
+	return integrations, nil
 }
 
 func (siRepo *SpotifyIntegrationRepositoryPocketbase) GetBySpotifyID(ctx context.Context, spotifyId string) (*models.SpotifyIntegration, error) {
@@ -94,7 +170,7 @@ func (siRepo *SpotifyIntegrationRepositoryPocketbase) GetBySpotifyID(ctx context
 		return nil, err
 	}
 
-	record, err := siRepo.app.FindFirstRecordByFilter(
+	record, err := appFromContext(ctx, siRepo.app).FindFirstRecordByFilter(
 		collection,
 		"spotify_id = {:spotify_id}",
 		dbx.Params{"spotify_id": spotifyId},
@@ -105,7 +181,7 @@ func (siRepo *SpotifyIntegrationRepositoryPocketbase) GetBySpotifyID(ctx context
 	}
 
 	siRepo.log.InfoContext(ctx, "spotify_integration found", "spotify_id", record.Id)
-	return recordToSpotifyIntegration(record), nil
+	return siRepo.toSpotifyIntegration(ctx, record)
 }
 
 func (siRepo *SpotifyIntegrationRepositoryPocketbase) UpdateTokens(
@@ -118,22 +194,32 @@ func (siRepo *SpotifyIntegrationRepositoryPocketbase) UpdateTokens(
 		return err
 	}
 
-	record, err := siRepo.app.FindRecordById(collection, integrationId)
+	record, err := appFromContext(ctx, siRepo.app).FindRecordById(collection, integrationId)
 	if err != nil {
 		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integration", "integration_id", integrationId, "error", err)
 		return repositories.ErrSpotifyIntegrationNotFound
 	}
 
-	record.Set("access_token", tokens.AccessToken)
+	userId := record.GetString("user")
+
+	accessToken, err := siRepo.encryptToken(ctx, userId, tokens.AccessToken)
+	if err != nil {
+		return err
+	}
+	record.Set("access_token", accessToken)
 
 	if tokens.RefreshToken != "" {
-		record.Set("refresh_token", tokens.RefreshToken)
+		refreshToken, err := siRepo.encryptToken(ctx, userId, tokens.RefreshToken)
+		if err != nil {
+			return err
+		}
+		record.Set("refresh_token", refreshToken)
 	}
 
 	expiresAt := time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
 	record.Set("expires_at", expiresAt)
 
-	if err := siRepo.app.Save(record); err != nil {
+	if err := appFromContext(ctx, siRepo.app).Save(record); err != nil {
 		siRepo.log.ErrorContext(ctx, "unable to update spotify_integration", "integration_id", integrationId, "error", err)
 		return repositories.ErrDatabaseOperation
 	}
@@ -142,33 +228,83 @@ func (siRepo *SpotifyIntegrationRepositoryPocketbase) UpdateTokens(
 	return nil
 }
 
-func (siRepo *SpotifyIntegrationRepositoryPocketbase) Delete(ctx context.Context, userId string) error {
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) GetExpiringBefore(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error) {
 	collection, err := siRepo.getCollection(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	record, err := siRepo.app.FindFirstRecordByFilter(
+	records, err := appFromContext(ctx, siRepo.app).FindRecordsByFilter(
 		collection,
-		"user = {:user}",
-		map[string]any{"user": userId},
+		"expires_at < {:before}",
+		"expires_at",
+		0,
+		0,
+		dbx.Params{"before": before.UTC().Format(types.DefaultDateLayout)},
 	)
 	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch expiring spotify_integrations", "before", before, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	integrations := make([]*models.SpotifyIntegration, 0, len(records))
+	for _, record := range records {
+		integration, err := siRepo.toSpotifyIntegration(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, integration)
+	}
+
+	return integrations, nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) Delete(ctx context.Context, userId string) error {
+	collection, err := siRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	records, err := appFromContext(ctx, siRepo.app).FindAllRecords(collection, dbx.HashExp{"user": userId})
+	if err != nil || len(records) == 0 {
 		siRepo.log.ErrorContext(ctx, "spotify_integration not found", "user", userId, "error", err)
 		return repositories.ErrSpotifyIntegrationNotFound
 	}
 
-	if err := siRepo.app.Delete(record); err != nil {
-		siRepo.log.ErrorContext(ctx, "unable to delete spotify_integration", "user", userId, "integration_id", record.Id, "error", err)
+	for _, record := range records {
+		if err := appFromContext(ctx, siRepo.app).Delete(record); err != nil {
+			siRepo.log.ErrorContext(ctx, "unable to delete spotify_integration", "user", userId, "integration_id", record.Id, "error", err)
+			return repositories.ErrDatabaseOperation
+		}
+	}
+
+	siRepo.log.InfoContext(ctx, "spotify_integrations deleted", "user", userId, "count", len(records))
+	return nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) DeleteByID(ctx context.Context, id, userId string) error {
+	collection, err := siRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, siRepo.app).FindRecordById(collection, id)
+	if err != nil || record.GetString("user") != userId {
+		siRepo.log.ErrorContext(ctx, "spotify_integration not found", "integration_id", id, "user", userId, "error", err)
+		return repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	if err := appFromContext(ctx, siRepo.app).Delete(record); err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to delete spotify_integration", "user", userId, "integration_id", id, "error", err)
 		return repositories.ErrDatabaseOperation
 	}
 
-	siRepo.log.InfoContext(ctx, "spotify_integration deleted", "user", userId, "integration_id", record.Id)
+	siRepo.log.InfoContext(ctx, "spotify_integration deleted", "user", userId, "integration_id", id)
 	return nil
 }
 
 func (siRepo *SpotifyIntegrationRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
-	collection, err := siRepo.app.FindCollectionByNameOrId(string(siRepo.collection))
+	collection, err := appFromContext(ctx, siRepo.app).FindCollectionByNameOrId(string(siRepo.collection))
 	if err != nil {
 		siRepo.log.ErrorContext(ctx, "unable to find collection", "collection", siRepo.collection, "error", err)
 		return nil, repositories.ErrCollectionNotFound
@@ -177,10 +313,48 @@ func (siRepo *SpotifyIntegrationRepositoryPocketbase) getCollection(ctx context.
 	return collection, nil
 }
 
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) encryptToken(ctx context.Context, userId, token string) (string, error) {
+	if siRepo.tokenCipher == nil || token == "" {
+		return token, nil
+	}
+
+	encryptedToken, err := siRepo.tokenCipher.EncryptForUser(ctx, userId, token)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to encrypt spotify token", "user", userId, "error", err)
+		return "", fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return encryptedToken, nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPocketbase) toSpotifyIntegration(ctx context.Context, record *core.Record) (*models.SpotifyIntegration, error) {
+	integration := recordToSpotifyIntegration(record)
+	if siRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	accessToken, err := siRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.AccessToken)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to decrypt spotify access token", "user", integration.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	refreshToken, err := siRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.RefreshToken)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to decrypt spotify refresh token", "user", integration.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	integration.AccessToken = accessToken
+	integration.RefreshToken = refreshToken
+	return integration, nil
+}
+
 func recordToSpotifyIntegration(record *core.Record) *models.SpotifyIntegration {
 	return &models.SpotifyIntegration{
 		ID:           record.Id,
 		UserID:       record.GetString("user"),
+		Provider:     models.MusicProvider(record.GetString("provider")).OrDefault(),
 		SpotifyID:    record.GetString("spotify_id"),
 		AccessToken:  record.GetString("access_token"),
 		RefreshToken: record.GetString("refresh_token"),
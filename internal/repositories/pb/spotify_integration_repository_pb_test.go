@@ -2,13 +2,14 @@ package pb
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/pocketbase/pocketbase"
-	"github.com/pocketbase/pocketbase/core"
 	"github.com/stretchr/testify/require"
 )
 
@@ -58,22 +59,18 @@ func TestSpotifyIntegrationRepositoryPocketbase_CreateOrUpdate_Success(t *testin
 			repo := NewSpotifyIntegrationRepositoryPocketbase(app)
 
 			// Create test user
-			userID := CreateTestUser(t, app, tt.userEmail, "Test User")
+			userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail(tt.userEmail).WithName("Test User").Build()).ID
 
-			// If testing update, create an existing integration first
+			// If testing update, create an existing integration of the same spotify account first
+			var existing *models.SpotifyIntegration
 			if !tt.expectCreate {
-				existingIntegration := &models.SpotifyIntegration{
-					UserID:       userID,
-					SpotifyID:    "old_spotify_id",
-					AccessToken:  "old_access_token",
-					RefreshToken: "old_refresh_token",
-					TokenType:    "Bearer",
-					ExpiresAt:    time.Now().Add(30 * time.Minute),
-					Scope:        "user-read-email",
-					DisplayName:  "Old User",
-				}
-				_, err := createIntegrationInDB(t, app, existingIntegration)
-				assert.NoError(err)
+				existing = testfixtures.SeedSpotifyIntegration(t, app, testfixtures.NewSpotifyIntegration().
+					WithUserID(userID).
+					WithSpotifyID(tt.integration.SpotifyID).
+					WithTokens("old_access_token", "old_refresh_token").
+					WithExpiresAt(time.Now().Add(30*time.Minute)).
+					WithDisplayName("Old User").
+					Build())
 			}
 
 			// Execute test
@@ -84,6 +81,9 @@ func TestSpotifyIntegrationRepositoryPocketbase_CreateOrUpdate_Success(t *testin
 			assert.NoError(err)
 			assert.NotNil(result)
 			assert.NotEmpty(result.ID)
+			if existing != nil {
+				assert.Equal(existing.ID, result.ID)
+			}
 			assert.Equal(userID, result.UserID)
 			assert.Equal(tt.integration.SpotifyID, result.SpotifyID)
 			assert.Equal(tt.integration.AccessToken, result.AccessToken)
@@ -91,6 +91,7 @@ func TestSpotifyIntegrationRepositoryPocketbase_CreateOrUpdate_Success(t *testin
 			assert.Equal(tt.integration.TokenType, result.TokenType)
 			assert.Equal(tt.integration.Scope, result.Scope)
 			assert.Equal(tt.integration.DisplayName, result.DisplayName)
+			assert.Equal(models.MusicProviderSpotify, result.Provider)
 
 			// Verify timestamps
 			assert.WithinDuration(tt.integration.ExpiresAt, result.ExpiresAt, 1*time.Second)
@@ -101,6 +102,7 @@ func TestSpotifyIntegrationRepositoryPocketbase_CreateOrUpdate_Success(t *testin
 			savedIntegration, err := findIntegrationInDB(t, app, result.ID)
 			assert.NoError(err)
 			assert.Equal(result.ID, savedIntegration.ID)
+			assert.Equal(models.MusicProviderSpotify, savedIntegration.Provider)
 		})
 	}
 }
@@ -137,23 +139,16 @@ func TestSpotifyIntegrationRepositoryPocketbase_GetByUserID_Success(t *testing.T
 	SetupSpotifyIntegrationsCollection(t, app)
 	repo := NewSpotifyIntegrationRepositoryPocketbase(app)
 
-	userID := CreateTestUser(t, app, "get@test.com", "GetByUserID Test User")
-	integration := &models.SpotifyIntegration{
-		UserID:       userID,
-		SpotifyID:    "spotify_user_123",
-		AccessToken:  "access_token_123",
-		RefreshToken: "refresh_token_123",
-		TokenType:    "Bearer",
-		ExpiresAt:    time.Now().Add(1 * time.Hour),
-		Scope:        "user-read-email",
-		DisplayName:  "Test User",
-	}
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("get@test.com").WithName("GetByUserID Test User").Build()).ID
+	integration := testfixtures.NewSpotifyIntegration().
+		WithUserID(userID).
+		WithExpiresAt(time.Now().Add(1 * time.Hour)).
+		Build()
 
 	ctx := context.Background()
 
 	// Create integration first
-	createdIntegration, err := createIntegrationInDB(t, app, integration)
-	assert.NoError(err)
+	createdIntegration := testfixtures.SeedSpotifyIntegration(t, app, integration)
 
 	// Execute test
 	result, err := repo.GetByUserID(ctx, userID)
@@ -194,24 +189,18 @@ func TestSpotifyIntegrationRepositoryPocketbase_GetBySpotifyID_Success(t *testin
 	SetupSpotifyIntegrationsCollection(t, app)
 	repo := NewSpotifyIntegrationRepositoryPocketbase(app)
 
-	userID := CreateTestUser(t, app, "get@test.com", "Get By SpotifyID Test User")
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("get@test.com").WithName("Get By SpotifyID Test User").Build()).ID
 	spotifyID := "spotify_user_123"
-	integration := &models.SpotifyIntegration{
-		UserID:       userID,
-		SpotifyID:    spotifyID,
-		AccessToken:  "access_token_123",
-		RefreshToken: "refresh_token_123",
-		TokenType:    "Bearer",
-		ExpiresAt:    time.Now().Add(1 * time.Hour),
-		Scope:        "user-read-email",
-		DisplayName:  "Test User",
-	}
+	integration := testfixtures.NewSpotifyIntegration().
+		WithUserID(userID).
+		WithSpotifyID(spotifyID).
+		WithExpiresAt(time.Now().Add(1 * time.Hour)).
+		Build()
 
 	ctx := context.Background()
 
 	// Create integration first
-	createdIntegration, err := createIntegrationInDB(t, app, integration)
-	assert.NoError(err)
+	createdIntegration := testfixtures.SeedSpotifyIntegration(t, app, integration)
 
 	// Execute test
 	result, err := repo.GetBySpotifyID(ctx, spotifyID)
@@ -251,23 +240,17 @@ func TestSpotifyIntegrationRepositoryPocketbase_UpdateTokens_Success(t *testing.
 	SetupSpotifyIntegrationsCollection(t, app)
 	repo := NewSpotifyIntegrationRepositoryPocketbase(app)
 
-	userID := CreateTestUser(t, app, "updatetokens@test.com", "Update Tokens Test User")
-	integration := &models.SpotifyIntegration{
-		UserID:       userID,
-		SpotifyID:    "spotify_user_123",
-		AccessToken:  "old_access_token",
-		RefreshToken: "old_refresh_token",
-		TokenType:    "Bearer",
-		ExpiresAt:    time.Now().Add(30 * time.Minute),
-		Scope:        "user-read-email",
-		DisplayName:  "Test User",
-	}
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("updatetokens@test.com").WithName("Update Tokens Test User").Build()).ID
+	integration := testfixtures.NewSpotifyIntegration().
+		WithUserID(userID).
+		WithTokens("old_access_token", "old_refresh_token").
+		WithExpiresAt(time.Now().Add(30 * time.Minute)).
+		Build()
 
 	ctx := context.Background()
 
 	// Create integration first
-	createdIntegration, err := createIntegrationInDB(t, app, integration)
-	assert.NoError(err)
+	createdIntegration := testfixtures.SeedSpotifyIntegration(t, app, integration)
 
 	// Prepare new tokens
 	newTokens := &models.SpotifyIntegrationTokenRefresh{
@@ -277,7 +260,7 @@ func TestSpotifyIntegrationRepositoryPocketbase_UpdateTokens_Success(t *testing.
 	}
 
 	// Execute test
-	err = repo.UpdateTokens(ctx, createdIntegration.ID, newTokens)
+	err := repo.UpdateTokens(ctx, createdIntegration.ID, newTokens)
 
 	// Verify success
 	assert.NoError(err)
@@ -304,24 +287,18 @@ func TestSpotifyIntegrationRepositoryPocketbase_UpdateTokens_WithoutRefreshToken
 	SetupSpotifyIntegrationsCollection(t, app)
 	repo := NewSpotifyIntegrationRepositoryPocketbase(app)
 
-	userID := CreateTestUser(t, app, "updatetokens@test.com", "Update Tokens Test User")
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("updatetokens@test.com").WithName("Update Tokens Test User").Build()).ID
 	originalRefreshToken := "original_refresh_token"
-	integration := &models.SpotifyIntegration{
-		UserID:       userID,
-		SpotifyID:    "spotify_user_123",
-		AccessToken:  "old_access_token",
-		RefreshToken: originalRefreshToken,
-		TokenType:    "Bearer",
-		ExpiresAt:    time.Now().Add(30 * time.Minute),
-		Scope:        "user-read-email",
-		DisplayName:  "Test User",
-	}
+	integration := testfixtures.NewSpotifyIntegration().
+		WithUserID(userID).
+		WithTokens("old_access_token", originalRefreshToken).
+		WithExpiresAt(time.Now().Add(30 * time.Minute)).
+		Build()
 
 	ctx := context.Background()
 
 	// Create integration first
-	createdIntegration, err := createIntegrationInDB(t, app, integration)
-	assert.NoError(err)
+	createdIntegration := testfixtures.SeedSpotifyIntegration(t, app, integration)
 
 	// Prepare new tokens without refresh token
 	newTokens := &models.SpotifyIntegrationTokenRefresh{
@@ -331,7 +308,7 @@ func TestSpotifyIntegrationRepositoryPocketbase_UpdateTokens_WithoutRefreshToken
 	}
 
 	// Execute test
-	err = repo.UpdateTokens(ctx, createdIntegration.ID, newTokens)
+	err := repo.UpdateTokens(ctx, createdIntegration.ID, newTokens)
 
 	// Verify success
 	assert.NoError(err)
@@ -377,23 +354,16 @@ func TestSpotifyIntegrationRepositoryPocketbase_Delete_Success(t *testing.T) {
 	SetupSpotifyIntegrationsCollection(t, app)
 	repo := NewSpotifyIntegrationRepositoryPocketbase(app)
 
-	userID := CreateTestUser(t, app, "delete@test.com", "Delete Spotify Integration User")
-	integration := &models.SpotifyIntegration{
-		UserID:       userID,
-		SpotifyID:    "spotify_user_123",
-		AccessToken:  "access_token_123",
-		RefreshToken: "refresh_token_123",
-		TokenType:    "Bearer",
-		ExpiresAt:    time.Now().Add(1 * time.Hour),
-		Scope:        "user-read-email",
-		DisplayName:  "Test User",
-	}
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("delete@test.com").WithName("Delete Spotify Integration User").Build()).ID
+	integration := testfixtures.NewSpotifyIntegration().
+		WithUserID(userID).
+		WithExpiresAt(time.Now().Add(1 * time.Hour)).
+		Build()
 
 	ctx := context.Background()
 
 	// Create integration first
-	integration, err := createIntegrationInDB(t, app, integration)
-	assert.NoError(err)
+	integration = testfixtures.SeedSpotifyIntegration(t, app, integration)
 
 	// Verify integration exists
 	foundIntegration, err := findIntegrationInDB(t, app, integration.ID)
@@ -447,6 +417,109 @@ func TestSpotifyIntegrationRepositoryPocketbase_Delete_DatabaseErrors(t *testing
 	})
 }
 
+func TestSpotifyIntegrationRepositoryPocketbase_GetExpiringBefore(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSpotifyIntegrationsCollection(t, app)
+	repo := NewSpotifyIntegrationRepositoryPocketbase(app).WithTokenCipher(prefixTokenCipher{})
+
+	now := time.Now()
+	expiresIn := map[string]time.Duration{
+		"expired@test.com":  -10 * time.Minute,
+		"expiring@test.com": 30 * time.Minute,
+		"fresh@test.com":    2 * time.Hour,
+	}
+
+	userIDs := make(map[string]string, len(expiresIn))
+	for email, expiresIn := range expiresIn {
+		userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail(email).WithName(email).Build()).ID
+		userIDs[email] = userID
+
+		testfixtures.SeedSpotifyIntegration(t, app, testfixtures.NewSpotifyIntegration().
+			WithUserID(userID).
+			WithSpotifyID("spotify_"+email).
+			WithTokens(userID+":access", userID+":refresh").
+			WithExpiresAt(now.Add(expiresIn)).
+			Build())
+	}
+
+	integrations, err := repo.GetExpiringBefore(context.Background(), now.Add(time.Hour))
+	assert.NoError(err)
+	assert.Len(integrations, 2)
+
+	// Soonest expiry first, with decrypted tokens
+	assert.Equal(userIDs["expired@test.com"], integrations[0].UserID)
+	assert.Equal(userIDs["expiring@test.com"], integrations[1].UserID)
+	assert.Equal("refresh", integrations[1].RefreshToken)
+}
+
+func TestSpotifyIntegrationRepositoryPocketbase_MultipleAccounts(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSpotifyIntegrationsCollection(t, app)
+	repo := NewSpotifyIntegrationRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("multi@test.com").Build()).ID
+	otherUserID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("other@test.com").Build()).ID
+
+	personal, err := repo.CreateOrUpdate(ctx, userID, &models.SpotifyIntegration{SpotifyID: "spotify_personal", AccessToken: "personal", RefreshToken: "personal", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(err)
+
+	// Keeps the created timestamps apart, the default account is the oldest one
+	time.Sleep(10 * time.Millisecond)
+
+	dj, err := repo.CreateOrUpdate(ctx, userID, &models.SpotifyIntegration{SpotifyID: "spotify_dj", AccessToken: "dj", RefreshToken: "dj", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(err)
+	assert.NotEqual(personal.ID, dj.ID)
+
+	defaultIntegration, err := repo.GetByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Equal(personal.ID, defaultIntegration.ID)
+
+	integrations, err := repo.ListByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Len(integrations, 2)
+	assert.Equal(personal.ID, integrations[0].ID)
+	assert.Equal(dj.ID, integrations[1].ID)
+
+	found, err := repo.GetByID(ctx, dj.ID, userID)
+	assert.NoError(err)
+	assert.Equal("spotify_dj", found.SpotifyID)
+
+	// Accounts of other users are not found
+	_, err = repo.GetByID(ctx, dj.ID, otherUserID)
+	assert.ErrorIs(err, repositories.ErrSpotifyIntegrationNotFound)
+	assert.ErrorIs(repo.DeleteByID(ctx, dj.ID, otherUserID), repositories.ErrSpotifyIntegrationNotFound)
+
+	assert.NoError(repo.DeleteByID(ctx, dj.ID, userID))
+	integrations, err = repo.ListByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Len(integrations, 1)
+	assert.Equal(personal.ID, integrations[0].ID)
+}
+
+func TestSpotifyIntegrationRepositoryPocketbase_Delete_AllAccounts(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSpotifyIntegrationsCollection(t, app)
+	repo := NewSpotifyIntegrationRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("multi@test.com").Build()).ID
+	testfixtures.SeedSpotifyIntegration(t, app, testfixtures.NewSpotifyIntegration().WithUserID(userID).WithSpotifyID("spotify_personal").Build())
+	testfixtures.SeedSpotifyIntegration(t, app, testfixtures.NewSpotifyIntegration().WithUserID(userID).WithSpotifyID("spotify_dj").Build())
+
+	assert.NoError(repo.Delete(ctx, userID))
+
+	integrations, err := repo.ListByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Empty(integrations)
+}
+
 // findIntegrationInDB is a helper function to verify an integration exists in the database
 func findIntegrationInDB(t *testing.T, app *pocketbase.PocketBase, id string) (*models.SpotifyIntegration, error) {
 	t.Helper()
@@ -463,28 +536,57 @@ func findIntegrationInDB(t *testing.T, app *pocketbase.PocketBase, id string) (*
 	return recordToSpotifyIntegration(record), nil
 }
 
-// createIntegrationInDB is a helper function to insert an integration in the database
-func createIntegrationInDB(t *testing.T, app *pocketbase.PocketBase, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error) {
-	t.Helper()
+// prefixTokenCipher is a reversible TokenCipher used to verify tokens go through the cipher
+type prefixTokenCipher struct{}
+
+func (prefixTokenCipher) EncryptForUser(ctx context.Context, userID, plaintext string) (string, error) {
+	return userID + ":" + plaintext, nil
+}
+
+func (prefixTokenCipher) DecryptForUser(ctx context.Context, userID, ciphertext string) (string, error) {
+	return strings.TrimPrefix(ciphertext, userID+":"), nil
+}
+
+func TestSpotifyIntegrationRepositoryPocketbase_WithTokenCipher(t *testing.T) {
 	assert := require.New(t)
 
-	collection, err := app.FindCollectionByNameOrId(string(CollectionSpotifyIntegration))
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupSpotifyIntegrationsCollection(t, app)
+	repo := NewSpotifyIntegrationRepositoryPocketbase(app).WithTokenCipher(prefixTokenCipher{})
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("cipher@test.com").WithName("Cipher Test User").Build()).ID
+	ctx := context.Background()
+
+	created, err := repo.CreateOrUpdate(ctx, userID, &models.SpotifyIntegration{
+		SpotifyID:    "spotify_user_123",
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(1 * time.Hour),
+	})
 	assert.NoError(err)
+	assert.Equal("access_token_123", created.AccessToken)
+	assert.Equal("refresh_token_123", created.RefreshToken)
 
-	record := core.NewRecord(collection)
-	record.Set("user", integration.UserID)
-	record.Set("spotify_id", integration.SpotifyID)
-	record.Set("access_token", integration.AccessToken)
-	record.Set("refresh_token", integration.RefreshToken)
-	record.Set("token_type", integration.TokenType)
-	record.Set("expires_at", integration.ExpiresAt)
-	record.Set("scope", integration.Scope)
-	record.Set("display_name", integration.DisplayName)
-
-	err = app.Save(record)
-	if err != nil {
-		return nil, err
-	}
+	// Stored values are encrypted
+	stored, err := findIntegrationInDB(t, app, created.ID)
+	assert.NoError(err)
+	assert.Equal(userID+":access_token_123", stored.AccessToken)
+	assert.Equal(userID+":refresh_token_123", stored.RefreshToken)
 
-	return recordToSpotifyIntegration(record), nil
+	err = repo.UpdateTokens(ctx, created.ID, &models.SpotifyIntegrationTokenRefresh{
+		AccessToken: "access_token_456",
+		ExpiresIn:   3600,
+	})
+	assert.NoError(err)
+
+	stored, err = findIntegrationInDB(t, app, created.ID)
+	assert.NoError(err)
+	assert.Equal(userID+":access_token_456", stored.AccessToken)
+
+	retrieved, err := repo.GetBySpotifyID(ctx, "spotify_user_123")
+	assert.NoError(err)
+	assert.Equal("access_token_456", retrieved.AccessToken)
+	assert.Equal("refresh_token_123", retrieved.RefreshToken)
 }
@@ -11,11 +11,13 @@ import (
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 type SyncEventRepositoryPocketbase struct {
 	collection Collection
 	app        *pocketbase.PocketBase
+	readDB     dbx.Builder
 	log        *slog.Logger
 }
 
@@ -23,10 +25,17 @@ func NewSyncEventRepositoryPocketbase(pb *pocketbase.PocketBase) *SyncEventRepos
 	return &SyncEventRepositoryPocketbase{
 		collection: CollectionSyncEvent,
 		app:        pb,
+		readDB:     pb.ConcurrentDB(),
 		log:        pb.Logger().With("component", "SyncEventRepositoryPocketbase"),
 	}
 }
 
+// WithReadDB routes the list queries of the repository through a dedicated read builder
+func (seRepo *SyncEventRepositoryPocketbase) WithReadDB(db dbx.Builder) *SyncEventRepositoryPocketbase {
+	seRepo.readDB = db
+	return seRepo
+}
+
 func (seRepo *SyncEventRepositoryPocketbase) Create(ctx context.Context, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
 	collection, err := seRepo.getCollection(ctx)
 	if err != nil {
@@ -37,8 +46,11 @@ func (seRepo *SyncEventRepositoryPocketbase) Create(ctx context.Context, syncEve
 	record.Set("user_id", syncEvent.UserID)
 	record.Set("base_playlist_id", syncEvent.BasePlaylistID)
 	record.Set("status", string(syncEvent.Status))
+	record.Set("phase", string(syncEvent.Phase))
 	record.Set("started_at", syncEvent.StartedAt)
+	record.Set("request_id", syncEvent.RequestID)
 	record.Set("tracks_processed", syncEvent.TracksProcessed)
+	record.Set("tracks_unmatched", syncEvent.TracksUnmatched)
 	record.Set("total_api_requests", syncEvent.TotalAPIRequests)
 
 	// Serialize child playlist IDs to JSON
@@ -51,6 +63,22 @@ func (seRepo *SyncEventRepositoryPocketbase) Create(ctx context.Context, syncEve
 		record.Set("child_playlist_ids", string(childPlaylistIDsJSON))
 	}
 
+	if syncEvent.ChildSyncResults != nil {
+		record.Set("child_sync_results", syncEvent.ChildSyncResults)
+	}
+
+	if syncEvent.Anomalies != nil {
+		record.Set("anomalies", syncEvent.Anomalies)
+	}
+
+	if syncEvent.Profile != nil {
+		record.Set("profile", syncEvent.Profile)
+	}
+
+	if syncEvent.RateLimit != nil {
+		record.Set("rate_limit", syncEvent.RateLimit)
+	}
+
 	// Set optional fields
 	if syncEvent.CompletedAt != nil {
 		record.Set("completed_at", *syncEvent.CompletedAt)
@@ -58,8 +86,11 @@ func (seRepo *SyncEventRepositoryPocketbase) Create(ctx context.Context, syncEve
 	if syncEvent.ErrorMessage != nil {
 		record.Set("error_message", *syncEvent.ErrorMessage)
 	}
+	if syncEvent.HeartbeatAt != nil {
+		record.Set("heartbeat_at", *syncEvent.HeartbeatAt)
+	}
 
-	err = seRepo.app.Save(record)
+	err = appFromContext(ctx, seRepo.app).Save(record)
 	if err != nil {
 		seRepo.log.ErrorContext(ctx, "unable to store sync_event record", "record", record, "error", err)
 		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
@@ -76,7 +107,7 @@ func (seRepo *SyncEventRepositoryPocketbase) Update(ctx context.Context, id stri
 		return nil, err
 	}
 
-	record, err := seRepo.app.FindRecordById(collection, id)
+	record, err := appFromContext(ctx, seRepo.app).FindRecordById(collection, id)
 	if err != nil {
 		seRepo.log.ErrorContext(ctx, "unable to find sync_event record", "id", id, "error", err)
 		return nil, fmt.Errorf(`%w: %s`, repositories.ErrSyncEventNotFound, err.Error())
@@ -84,7 +115,9 @@ func (seRepo *SyncEventRepositoryPocketbase) Update(ctx context.Context, id stri
 
 	// Update fields
 	record.Set("status", string(syncEvent.Status))
+	record.Set("phase", string(syncEvent.Phase))
 	record.Set("tracks_processed", syncEvent.TracksProcessed)
+	record.Set("tracks_unmatched", syncEvent.TracksUnmatched)
 	record.Set("total_api_requests", syncEvent.TotalAPIRequests)
 
 	// Update child playlist IDs if provided (including empty slice to clear them)
@@ -102,6 +135,22 @@ func (seRepo *SyncEventRepositoryPocketbase) Update(ctx context.Context, id stri
 		}
 	}
 
+	if syncEvent.ChildSyncResults != nil {
+		record.Set("child_sync_results", syncEvent.ChildSyncResults)
+	}
+
+	if syncEvent.Anomalies != nil {
+		record.Set("anomalies", syncEvent.Anomalies)
+	}
+
+	if syncEvent.Profile != nil {
+		record.Set("profile", syncEvent.Profile)
+	}
+
+	if syncEvent.RateLimit != nil {
+		record.Set("rate_limit", syncEvent.RateLimit)
+	}
+
 	// Update optional fields
 	if syncEvent.CompletedAt != nil {
 		record.Set("completed_at", *syncEvent.CompletedAt)
@@ -109,8 +158,11 @@ func (seRepo *SyncEventRepositoryPocketbase) Update(ctx context.Context, id stri
 	if syncEvent.ErrorMessage != nil {
 		record.Set("error_message", *syncEvent.ErrorMessage)
 	}
+	if syncEvent.HeartbeatAt != nil {
+		record.Set("heartbeat_at", *syncEvent.HeartbeatAt)
+	}
 
-	err = seRepo.app.Save(record)
+	err = appFromContext(ctx, seRepo.app).Save(record)
 	if err != nil {
 		seRepo.log.ErrorContext(ctx, "unable to update sync_event record", "id", id, "error", err)
 		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
@@ -126,7 +178,7 @@ func (seRepo *SyncEventRepositoryPocketbase) GetByID(ctx context.Context, id str
 		return nil, err
 	}
 
-	record, err := seRepo.app.FindRecordById(collection, id)
+	record, err := appFromContext(ctx, seRepo.app).FindRecordById(collection, id)
 	if err != nil {
 		seRepo.log.ErrorContext(ctx, "unable to find sync_event record", "id", id, "error", err)
 		return nil, fmt.Errorf(`%w: %s`, repositories.ErrSyncEventNotFound, err.Error())
@@ -136,21 +188,51 @@ func (seRepo *SyncEventRepositoryPocketbase) GetByID(ctx context.Context, id str
 	return recordToSyncEvent(record), nil
 }
 
+func (seRepo *SyncEventRepositoryPocketbase) TransitionStatus(ctx context.Context, id string, from, to models.SyncStatus) error {
+	collection, err := seRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Transactions run on the single writer connection, so no other write lands between the check and the save
+	err = appFromContext(ctx, seRepo.app).RunInTransaction(func(txApp core.App) error {
+		record, err := txApp.FindRecordById(collection, id)
+		if err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrSyncEventNotFound, err.Error())
+		}
+
+		if record.GetString("status") != string(from) {
+			return fmt.Errorf("%w: %s is %s", repositories.ErrSyncEventStatusChanged, id, record.GetString("status"))
+		}
+
+		record.Set("status", string(to))
+		if err := txApp.Save(record); err != nil {
+			return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+
+		return nil
+	})
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to transition sync_event status", "id", id, "from", from, "to", to, "error", err)
+		return err
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_event status transitioned", "id", id, "from", from, "to", to)
+	return nil
+}
+
 func (seRepo *SyncEventRepositoryPocketbase) GetByUserID(ctx context.Context, userID string) ([]*models.SyncEvent, error) {
 	collection, err := seRepo.getCollection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	records, err := seRepo.app.FindRecordsByFilter(
+	records, err := findRecordsOnReadDB(
+		ctx,
+		seRepo.readDB,
 		collection,
-		"user_id = {:userID}",
-		"-created", // Order by created date descending (newest first)
-		0,          // limit (0 = no limit)
-		0,          // offset
-		dbx.Params{
-			"userID": userID,
-		},
+		dbx.HashExp{"user_id": userID},
+		"created DESC", // Newest first
 	)
 	if err != nil {
 		seRepo.log.ErrorContext(ctx, "unable to find sync_event records for user", "user_id", userID, "error", err)
@@ -172,15 +254,12 @@ func (seRepo *SyncEventRepositoryPocketbase) GetByBasePlaylistID(ctx context.Con
 		return nil, err
 	}
 
-	records, err := seRepo.app.FindRecordsByFilter(
+	records, err := findRecordsOnReadDB(
+		ctx,
+		seRepo.readDB,
 		collection,
-		"base_playlist_id = {:basePlaylistID}",
-		"-created", // Order by created date descending (newest first)
-		0,          // limit (0 = no limit)
-		0,          // offset
-		dbx.Params{
-			"basePlaylistID": basePlaylistID,
-		},
+		dbx.HashExp{"base_playlist_id": basePlaylistID},
+		"created DESC", // Newest first
 	)
 	if err != nil {
 		seRepo.log.ErrorContext(ctx, "unable to find sync_event records for base playlist", "base_playlist_id", basePlaylistID, "error", err)
@@ -196,8 +275,120 @@ func (seRepo *SyncEventRepositoryPocketbase) GetByBasePlaylistID(ctx context.Con
 	return syncEvents, nil
 }
 
+func (seRepo *SyncEventRepositoryPocketbase) GetRecentFailed(ctx context.Context, limit int) ([]*models.SyncEvent, error) {
+	collection, err := seRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, seRepo.app).FindRecordsByFilter(
+		collection,
+		"status = {:status}",
+		"-created",
+		limit,
+		0,
+		dbx.Params{"status": string(models.SyncStatusFailed)},
+	)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to find failed sync_event records", "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	syncEvents := make([]*models.SyncEvent, len(records))
+	for i, record := range records {
+		syncEvents[i] = recordToSyncEvent(record)
+	}
+
+	seRepo.log.InfoContext(ctx, "failed sync_events retrieved successfully", "count", len(syncEvents))
+	return syncEvents, nil
+}
+
+func (seRepo *SyncEventRepositoryPocketbase) List(ctx context.Context, filter repositories.SyncEventFilter) ([]*models.SyncEvent, error) {
+	collection, err := seRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := appFromContext(ctx, seRepo.app).RecordQuery(collection).
+		OrderBy(listOrderBy(filter.Sort)...).
+		Limit(int64(filter.Limit)).
+		Offset(int64(filter.Offset))
+	for _, condition := range syncEventFilterConditions(filter) {
+		query.AndWhere(condition)
+	}
+
+	records := []*core.Record{}
+	err = query.WithContext(ctx).All(&records)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to list sync_event records", "filter", filter, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	syncEvents := make([]*models.SyncEvent, len(records))
+	for i, record := range records {
+		syncEvents[i] = recordToSyncEvent(record)
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_events listed successfully", "filter", filter, "count", len(syncEvents))
+	return syncEvents, nil
+}
+
+func (seRepo *SyncEventRepositoryPocketbase) Count(ctx context.Context, filter repositories.SyncEventFilter) (int, error) {
+	collection, err := seRepo.getCollection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := appFromContext(ctx, seRepo.app).CountRecords(collection, syncEventFilterConditions(filter)...)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to count sync_event records", "filter", filter, "error", err)
+		return 0, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return int(total), nil
+}
+
+func (seRepo *SyncEventRepositoryPocketbase) Prune(ctx context.Context, filter repositories.SyncEventPruneFilter) (int, error) {
+	collection, err := seRepo.getCollection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	conditions := append(syncEventFilterConditions(repositories.SyncEventFilter{
+		UserID:         filter.UserID,
+		BasePlaylistID: filter.BasePlaylistID,
+		CreatedBefore:  filter.CreatedBefore,
+	}),
+		dbx.In("status", string(models.SyncStatusCompleted), string(models.SyncStatusFailed), string(models.SyncStatusConfirmed)),
+		dbx.NewExp(`id IS NOT (SELECT latest.id FROM sync_events latest
+			WHERE latest.base_playlist_id = sync_events.base_playlist_id
+			ORDER BY latest.created DESC, latest.id DESC LIMIT 1)`),
+		dbx.NewExp(`id IS NOT (SELECT latest.id FROM sync_events latest
+			WHERE latest.base_playlist_id = sync_events.base_playlist_id AND latest.status = {:completed}
+			ORDER BY latest.created DESC, latest.id DESC LIMIT 1)`, dbx.Params{"completed": string(models.SyncStatusCompleted)}),
+	)
+
+	app := appFromContext(ctx, seRepo.app)
+	records, err := app.FindAllRecords(collection, conditions...)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to find sync_event records to prune", "filter", filter, "error", err)
+		return 0, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	// Their playlist snapshots are cascade deleted along
+	for i, record := range records {
+		if err := app.Delete(record); err != nil {
+			seRepo.log.ErrorContext(ctx, "unable to prune sync_event record", "id", record.Id, "pruned", i, "error", err)
+			return i, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+		}
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_events pruned", "filter", filter, "pruned", len(records))
+	return len(records), nil
+}
+
 func (seRepo *SyncEventRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
-	collection, err := seRepo.app.FindCollectionByNameOrId(string(seRepo.collection))
+	collection, err := appFromContext(ctx, seRepo.app).FindCollectionByNameOrId(string(seRepo.collection))
 	if err != nil {
 		seRepo.log.ErrorContext(ctx, "unable to find collection", "collection", seRepo.collection, "error", err)
 		return nil, repositories.ErrCollectionNotFound
@@ -206,14 +397,43 @@ func (seRepo *SyncEventRepositoryPocketbase) getCollection(ctx context.Context)
 	return collection, nil
 }
 
+// syncEventFilterConditions match the sync events of filter, ignoring its pagination
+func syncEventFilterConditions(filter repositories.SyncEventFilter) []dbx.Expression {
+	conditions := []dbx.Expression{}
+	if filter.Status != "" {
+		conditions = append(conditions, dbx.HashExp{"status": string(filter.Status)})
+	}
+	if filter.UserID != "" {
+		conditions = append(conditions, dbx.HashExp{"user_id": filter.UserID})
+	}
+	if filter.BasePlaylistID != "" {
+		conditions = append(conditions, dbx.HashExp{"base_playlist_id": filter.BasePlaylistID})
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conditions = append(conditions, dbx.NewExp("created >= {:createdAfter}", dbx.Params{
+			"createdAfter": filter.CreatedAfter.UTC().Format(types.DefaultDateLayout),
+		}))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conditions = append(conditions, dbx.NewExp("created < {:createdBefore}", dbx.Params{
+			"createdBefore": filter.CreatedBefore.UTC().Format(types.DefaultDateLayout),
+		}))
+	}
+
+	return conditions
+}
+
 func recordToSyncEvent(record *core.Record) *models.SyncEvent {
 	syncEvent := &models.SyncEvent{
 		ID:               record.Id,
 		UserID:           record.GetString("user_id"),
 		BasePlaylistID:   record.GetString("base_playlist_id"),
 		Status:           models.SyncStatus(record.GetString("status")),
+		Phase:            models.SyncPhase(record.GetString("phase")),
 		StartedAt:        record.GetDateTime("started_at").Time(),
+		RequestID:        record.GetString("request_id"),
 		TracksProcessed:  record.GetInt("tracks_processed"),
+		TracksUnmatched:  record.GetInt("tracks_unmatched"),
 		TotalAPIRequests: record.GetInt("total_api_requests"),
 		Created:          record.GetDateTime("created").Time(),
 		Updated:          record.GetDateTime("updated").Time(),
@@ -231,10 +451,35 @@ func recordToSyncEvent(record *core.Record) *models.SyncEvent {
 		syncEvent.ChildPlaylistIDs = []string{}
 	}
 
+	syncEvent.ChildSyncResults = []models.ChildSyncResult{}
+	if err := record.UnmarshalJSONField("child_sync_results", &syncEvent.ChildSyncResults); err != nil || syncEvent.ChildSyncResults == nil {
+		syncEvent.ChildSyncResults = []models.ChildSyncResult{}
+	}
+
+	if err := record.UnmarshalJSONField("anomalies", &syncEvent.Anomalies); err != nil {
+		syncEvent.Anomalies = nil
+	}
+
+	if err := record.UnmarshalJSONField("profile", &syncEvent.Profile); err != nil {
+		syncEvent.Profile = nil
+	}
+
+	if err := record.UnmarshalJSONField("rate_limit", &syncEvent.RateLimit); err != nil {
+		syncEvent.RateLimit = nil
+	}
+
 	// Handle optional fields
 	if completedAtTime := record.GetDateTime("completed_at"); !completedAtTime.IsZero() {
 		completedAt := completedAtTime.Time()
 		syncEvent.CompletedAt = &completedAt
+
+		durationMs := completedAt.Sub(syncEvent.StartedAt).Milliseconds()
+		syncEvent.DurationMs = &durationMs
+	}
+
+	if heartbeatAtTime := record.GetDateTime("heartbeat_at"); !heartbeatAtTime.IsZero() {
+		heartbeatAt := heartbeatAtTime.Time()
+		syncEvent.HeartbeatAt = &heartbeatAt
 	}
 
 	if errorMessage := record.GetString("error_message"); errorMessage != "" {
@@ -2,12 +2,15 @@ package pb
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +25,7 @@ func TestSyncEventRepositoryPocketbase_Create_Success(t *testing.T) {
 		totalAPIRequests int
 		completedAt      *time.Time
 		errorMessage     *string
+		requestID        string
 	}{
 		{
 			name:             "successful creation with minimal data",
@@ -44,6 +48,7 @@ func TestSyncEventRepositoryPocketbase_Create_Success(t *testing.T) {
 			totalAPIRequests: 10,
 			completedAt:      nil,
 			errorMessage:     nil,
+			requestID:        "req456",
 		},
 		{
 			name:             "successful creation with completed sync",
@@ -89,6 +94,7 @@ func TestSyncEventRepositoryPocketbase_Create_Success(t *testing.T) {
 				TotalAPIRequests: tt.totalAPIRequests,
 				CompletedAt:      tt.completedAt,
 				ErrorMessage:     tt.errorMessage,
+				RequestID:        tt.requestID,
 				Created:          time.Now(),
 				Updated:          time.Now(),
 			}
@@ -108,6 +114,7 @@ func TestSyncEventRepositoryPocketbase_Create_Success(t *testing.T) {
 			assert.Equal(tt.status, createdSyncEvent.Status)
 			assert.Equal(tt.tracksProcessed, createdSyncEvent.TracksProcessed)
 			assert.Equal(tt.totalAPIRequests, createdSyncEvent.TotalAPIRequests)
+			assert.Equal(tt.requestID, createdSyncEvent.RequestID)
 			assert.NotEmpty(createdSyncEvent.ID)
 			assert.NotZero(createdSyncEvent.StartedAt)
 			assert.NotZero(createdSyncEvent.Created)
@@ -117,8 +124,11 @@ func TestSyncEventRepositoryPocketbase_Create_Success(t *testing.T) {
 			if tt.completedAt != nil {
 				assert.NotNil(createdSyncEvent.CompletedAt)
 				assert.WithinDuration(*tt.completedAt, *createdSyncEvent.CompletedAt, time.Second)
+				assert.NotNil(createdSyncEvent.DurationMs)
+				assert.Equal(createdSyncEvent.CompletedAt.Sub(createdSyncEvent.StartedAt).Milliseconds(), *createdSyncEvent.DurationMs)
 			} else {
 				assert.Nil(createdSyncEvent.CompletedAt)
+				assert.Nil(createdSyncEvent.DurationMs)
 			}
 
 			if tt.errorMessage != nil {
@@ -331,6 +341,235 @@ func TestSyncEventRepositoryPocketbase_Update_Success(t *testing.T) {
 	}
 }
 
+func TestSyncEventRepositoryPocketbase_Update_PhaseAndHeartbeat(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	createdSyncEvent, err := repo.Create(ctx, &models.SyncEvent{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Status:         models.SyncStatusInProgress,
+		Phase:          models.SyncPhaseFetchingTracks,
+		StartedAt:      time.Now(),
+	})
+	assert.NoError(err)
+	assert.Equal(models.SyncPhaseFetchingTracks, createdSyncEvent.Phase)
+	assert.Nil(createdSyncEvent.HeartbeatAt)
+
+	heartbeatAt := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	result, err := repo.Update(ctx, createdSyncEvent.ID, &models.SyncEvent{
+		Status:      models.SyncStatusInProgress,
+		Phase:       models.SyncPhaseWaitingForQuota,
+		HeartbeatAt: &heartbeatAt,
+	})
+	assert.NoError(err)
+	assert.Equal(models.SyncPhaseWaitingForQuota, result.Phase)
+	assert.NotNil(result.HeartbeatAt)
+	assert.True(heartbeatAt.Equal(*result.HeartbeatAt))
+}
+
+func TestSyncEventRepositoryPocketbase_TransitionStatus(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	heldSyncEvent, err := repo.Create(ctx, &models.SyncEvent{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Status:         models.SyncStatusNeedsConfirmation,
+		StartedAt:      time.Now(),
+	})
+	assert.NoError(err)
+
+	err = repo.TransitionStatus(ctx, heldSyncEvent.ID, models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed)
+	assert.NoError(err)
+
+	stored, err := repo.GetByID(ctx, heldSyncEvent.ID)
+	assert.NoError(err)
+	assert.Equal(models.SyncStatusConfirmed, stored.Status)
+
+	// The sync event is no longer in the from status
+	err = repo.TransitionStatus(ctx, heldSyncEvent.ID, models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed)
+	assert.ErrorIs(err, repositories.ErrSyncEventStatusChanged)
+
+	err = repo.TransitionStatus(ctx, "missing", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed)
+	assert.ErrorIs(err, repositories.ErrSyncEventNotFound)
+}
+
+func TestSyncEventRepositoryPocketbase_Update_Profile(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	createdSyncEvent, err := repo.Create(ctx, &models.SyncEvent{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Status:         models.SyncStatusInProgress,
+		StartedAt:      time.Now(),
+	})
+	assert.NoError(err)
+	assert.Nil(createdSyncEvent.Profile)
+
+	profile := &models.SyncProfileSpan{
+		Name:  "sync",
+		Value: 1500,
+		Children: []*models.SyncProfileSpan{
+			{Name: "aggregation", Value: 1200, Children: []*models.SyncProfileSpan{{Name: "rate_limit_wait", StartMs: 100, Value: 900}}},
+			{Name: "routing", StartMs: 1200, Value: 300},
+		},
+	}
+	result, err := repo.Update(ctx, createdSyncEvent.ID, &models.SyncEvent{
+		Status:  models.SyncStatusCompleted,
+		Profile: profile,
+	})
+	assert.NoError(err)
+	assert.Equal(profile, result.Profile)
+
+	storedSyncEvent, err := repo.GetByID(ctx, createdSyncEvent.ID)
+	assert.NoError(err)
+	assert.Equal(profile, storedSyncEvent.Profile)
+}
+
+func TestSyncEventRepositoryPocketbase_Update_RateLimit(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	createdSyncEvent, err := repo.Create(ctx, &models.SyncEvent{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Status:         models.SyncStatusInProgress,
+		StartedAt:      time.Now(),
+	})
+	assert.NoError(err)
+	assert.Nil(createdSyncEvent.RateLimit)
+
+	rateLimit := &models.SyncRateLimitStats{RateLimitedResponses: 2, Retries: 2, BudgetWaits: 1, WaitMs: 4500}
+	result, err := repo.Update(ctx, createdSyncEvent.ID, &models.SyncEvent{
+		Status:    models.SyncStatusCompleted,
+		RateLimit: rateLimit,
+	})
+	assert.NoError(err)
+	assert.Equal(rateLimit, result.RateLimit)
+
+	storedSyncEvent, err := repo.GetByID(ctx, createdSyncEvent.ID)
+	assert.NoError(err)
+	assert.Equal(rateLimit, storedSyncEvent.RateLimit)
+}
+
+func TestSyncEventRepositoryPocketbase_Update_ChildSyncResults(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	createdSyncEvent, err := repo.Create(ctx, &models.SyncEvent{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Status:         models.SyncStatusInProgress,
+		StartedAt:      time.Now(),
+	})
+	assert.NoError(err)
+	assert.Empty(createdSyncEvent.ChildSyncResults)
+
+	childSyncResults := []models.ChildSyncResult{
+		{
+			ChildPlaylistID:   "child1",
+			SpotifyPlaylistID: "new_spotify1",
+			Status:            models.SyncStatusCompleted,
+			TracksAdded:       12,
+			APIRequests:       3,
+		},
+		{
+			ChildPlaylistID:   "child2",
+			SpotifyPlaylistID: "spotify2",
+			Status:            models.SyncStatusFailed,
+			APIRequests:       1,
+			ErrorMessage:      ptrString("failed to create playlist"),
+		},
+	}
+
+	result, err := repo.Update(ctx, createdSyncEvent.ID, &models.SyncEvent{
+		Status:           models.SyncStatusFailed,
+		ChildSyncResults: childSyncResults,
+	})
+	assert.NoError(err)
+	assert.Equal(childSyncResults, result.ChildSyncResults)
+
+	// Verify the results are persisted
+	storedSyncEvent, err := findSyncEventInDB(t, app, createdSyncEvent.ID)
+	assert.NoError(err)
+	assert.Equal(childSyncResults, storedSyncEvent.ChildSyncResults)
+}
+
+func TestSyncEventRepositoryPocketbase_Update_Anomalies(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	createdSyncEvent, err := repo.Create(ctx, &models.SyncEvent{
+		UserID:         "user123",
+		BasePlaylistID: "base123",
+		Status:         models.SyncStatusInProgress,
+		StartedAt:      time.Now(),
+	})
+	assert.NoError(err)
+	assert.Empty(createdSyncEvent.Anomalies)
+
+	anomalies := []models.SyncAnomaly{
+		{
+			Type:            models.SyncAnomalyChildTrackDrop,
+			ChildPlaylistID: "child1",
+			PreviousCount:   500,
+			NewCount:        3,
+			Message:         "child playlist \"Child 1\" would drop from 500 to 3 tracks",
+		},
+	}
+
+	result, err := repo.Update(ctx, createdSyncEvent.ID, &models.SyncEvent{
+		Status:          models.SyncStatusNeedsConfirmation,
+		TracksProcessed: 600,
+		TracksUnmatched: 42,
+		Anomalies:       anomalies,
+	})
+	assert.NoError(err)
+	assert.Equal(models.SyncStatusNeedsConfirmation, result.Status)
+	assert.Equal(42, result.TracksUnmatched)
+	assert.Equal(anomalies, result.Anomalies)
+
+	// Verify the anomalies are persisted
+	storedSyncEvent, err := findSyncEventInDB(t, app, createdSyncEvent.ID)
+	assert.NoError(err)
+	assert.Equal(42, storedSyncEvent.TracksUnmatched)
+	assert.Equal(anomalies, storedSyncEvent.Anomalies)
+}
+
 func TestSyncEventRepositoryPocketbase_Update_NotFoundError(t *testing.T) {
 	assert := require.New(t)
 
@@ -698,3 +937,197 @@ func findSyncEventInDB(t *testing.T, app *pocketbase.PocketBase, id string) (*mo
 
 	return recordToSyncEvent(record), nil
 }
+
+func TestSyncEventRepositoryPocketbase_GetRecentFailed_Success(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	statuses := []models.SyncStatus{
+		models.SyncStatusFailed,
+		models.SyncStatusCompleted,
+		models.SyncStatusFailed,
+		models.SyncStatusFailed,
+	}
+	for i, status := range statuses {
+		_, err := repo.Create(ctx, &models.SyncEvent{
+			UserID:         fmt.Sprintf("user%d", i),
+			BasePlaylistID: "base123",
+			Status:         status,
+			StartedAt:      time.Now(),
+		})
+		assert.NoError(err)
+	}
+
+	syncEvents, err := repo.GetRecentFailed(ctx, 2)
+	assert.NoError(err)
+	assert.Len(syncEvents, 2)
+	for _, syncEvent := range syncEvents {
+		assert.Equal(models.SyncStatusFailed, syncEvent.Status)
+	}
+}
+
+func TestSyncEventRepositoryPocketbase_List(t *testing.T) {
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	seeded := []*models.SyncEvent{
+		{UserID: "user1", BasePlaylistID: "base1", Status: models.SyncStatusFailed},
+		{UserID: "user1", BasePlaylistID: "base2", Status: models.SyncStatusCompleted},
+		{UserID: "user2", BasePlaylistID: "base3", Status: models.SyncStatusFailed},
+		{UserID: "user2", BasePlaylistID: "base3", Status: models.SyncStatusInProgress},
+	}
+	for _, syncEvent := range seeded {
+		syncEvent.StartedAt = time.Now()
+		_, err := repo.Create(ctx, syncEvent)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name          string
+		filter        repositories.SyncEventFilter
+		expectedCount int
+	}{
+		{name: "no filter lists every user", filter: repositories.SyncEventFilter{Limit: 10}, expectedCount: 4},
+		{name: "by status", filter: repositories.SyncEventFilter{Status: models.SyncStatusFailed, Limit: 10}, expectedCount: 2},
+		{name: "by user", filter: repositories.SyncEventFilter{UserID: "user1", Limit: 10}, expectedCount: 2},
+		{name: "by base playlist and status", filter: repositories.SyncEventFilter{BasePlaylistID: "base3", Status: models.SyncStatusInProgress, Limit: 10}, expectedCount: 1},
+		{name: "paginated", filter: repositories.SyncEventFilter{Limit: 3, Offset: 2}, expectedCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			syncEvents, err := repo.List(ctx, tt.filter)
+
+			assert.NoError(err)
+			assert.Len(syncEvents, tt.expectedCount)
+			for _, syncEvent := range syncEvents {
+				if tt.filter.Status != "" {
+					assert.Equal(tt.filter.Status, syncEvent.Status)
+				}
+				if tt.filter.UserID != "" {
+					assert.Equal(tt.filter.UserID, syncEvent.UserID)
+				}
+				if tt.filter.BasePlaylistID != "" {
+					assert.Equal(tt.filter.BasePlaylistID, syncEvent.BasePlaylistID)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncEventRepositoryPocketbase_ListAndCount_CreatedRange(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	ids := []string{}
+	for range 3 {
+		syncEvent, err := repo.Create(ctx, &models.SyncEvent{UserID: "user1", BasePlaylistID: "base1", Status: models.SyncStatusCompleted, StartedAt: time.Now()})
+		assert.NoError(err)
+		ids = append(ids, syncEvent.ID)
+	}
+
+	filter := repositories.SyncEventFilter{
+		UserID:        "user1",
+		CreatedAfter:  time.Now().Add(-time.Hour),
+		CreatedBefore: time.Now().Add(time.Hour),
+		Sort:          models.ListSortCreatedAsc,
+		Limit:         2,
+	}
+
+	syncEvents, err := repo.List(ctx, filter)
+	assert.NoError(err)
+	assert.Len(syncEvents, 2)
+
+	total, err := repo.Count(ctx, filter)
+	assert.NoError(err)
+	assert.Equal(3, total)
+
+	// Nothing was created in the future
+	filter.CreatedAfter = time.Now().Add(time.Hour)
+	filter.CreatedBefore = time.Time{}
+
+	syncEvents, err = repo.List(ctx, filter)
+	assert.NoError(err)
+	assert.Empty(syncEvents)
+
+	total, err = repo.Count(ctx, filter)
+	assert.NoError(err)
+	assert.Zero(total)
+}
+
+func TestSyncEventRepositoryPocketbase_Prune(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupSyncEventCollection(t, app)
+	repo := NewSyncEventRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	seeded := []struct {
+		userID         string
+		basePlaylistID string
+		status         models.SyncStatus
+		daysAgo        int
+	}{
+		{"user1", "base1", models.SyncStatusCompleted, 100},         // Pruned
+		{"user1", "base1", models.SyncStatusFailed, 90},             // Pruned
+		{"user1", "base1", models.SyncStatusCompleted, 80},          // Latest completed
+		{"user1", "base1", models.SyncStatusFailed, 70},             // Latest
+		{"user1", "base2", models.SyncStatusNeedsConfirmation, 100}, // Not finished
+		{"user1", "base2", models.SyncStatusCompleted, 10},          // Not old enough
+		{"user2", "base3", models.SyncStatusFailed, 100},            // Pruned without the user filter
+		{"user2", "base3", models.SyncStatusCompleted, 1},           // Latest
+	}
+	ids := make([]string, len(seeded))
+	for i, seed := range seeded {
+		syncEvent, err := repo.Create(ctx, &models.SyncEvent{UserID: seed.userID, BasePlaylistID: seed.basePlaylistID, Status: seed.status, StartedAt: time.Now()})
+		assert.NoError(err)
+		ids[i] = syncEvent.ID
+
+		created := time.Now().AddDate(0, 0, -seed.daysAgo).UTC().Format(types.DefaultDateLayout)
+		_, err = app.DB().NewQuery("UPDATE sync_events SET created = {:created} WHERE id = {:id}").
+			Bind(dbx.Params{"created": created, "id": syncEvent.ID}).
+			Execute()
+		assert.NoError(err)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -60)
+
+	pruned, err := repo.Prune(ctx, repositories.SyncEventPruneFilter{UserID: "user1", CreatedBefore: cutoff})
+	assert.NoError(err)
+	assert.Equal(2, pruned)
+
+	pruned, err = repo.Prune(ctx, repositories.SyncEventPruneFilter{CreatedBefore: cutoff})
+	assert.NoError(err)
+	assert.Equal(1, pruned)
+
+	// Nothing else is old enough to go
+	pruned, err = repo.Prune(ctx, repositories.SyncEventPruneFilter{CreatedBefore: cutoff})
+	assert.NoError(err)
+	assert.Zero(pruned)
+
+	for i, id := range ids {
+		_, err := repo.GetByID(ctx, id)
+		if i == 0 || i == 1 || i == 6 {
+			assert.ErrorIs(err, repositories.ErrSyncEventNotFound)
+		} else {
+			assert.NoError(err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package pb
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/core"
@@ -24,6 +25,15 @@ func NewTestApp(t *testing.T) *pocketbase.PocketBase {
 	return app
 }
 
+// SetupUsersCollection adds the app specific fields to the built-in users collection for testing
+func SetupUsersCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	if err := ensureUserFields(app); err != nil {
+		t.Fatalf("failed to set up users collection: %v", err)
+	}
+}
+
 // SetupBasePlaylistCollection creates the base_playlist collection for testing
 func SetupBasePlaylistCollection(t *testing.T, app *pocketbase.PocketBase) {
 	t.Helper()
@@ -56,6 +66,39 @@ func SetupBasePlaylistCollection(t *testing.T, app *pocketbase.PocketBase) {
 		Required: false,
 	})
 
+	collection.Fields.Add(&core.TextField{
+		Name:     "dedupe_strategy",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "hook_token",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name:     "suspended",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name:     "archived",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "spotify_integration_id",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name: "deleted_at",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "provider",
+	})
+
 	// Standard timestamp fields
 	collection.Fields.Add(&core.AutodateField{
 		Name:     "created",
@@ -72,28 +115,6 @@ func SetupBasePlaylistCollection(t *testing.T, app *pocketbase.PocketBase) {
 	}
 }
 
-// CreateTestUser creates a test user and returns the user ID
-func CreateTestUser(t *testing.T, app *pocketbase.PocketBase, email, name string) string {
-	t.Helper()
-
-	collection, err := app.FindCollectionByNameOrId("users")
-	if err != nil {
-		t.Fatalf("users collection not found: %v", err)
-	}
-
-	user := core.NewRecord(collection)
-	user.Set("email", email)
-	user.Set("name", name)
-	user.Set("password", "test123456") // Required for auth collections
-	user.Set("passwordConfirm", "test123456")
-
-	if err := app.Save(user); err != nil {
-		t.Fatalf("failed to create test user: %v", err)
-	}
-
-	return user.Id
-}
-
 // SetupChildPlaylistCollection creates the child_playlists collection for testing
 func SetupChildPlaylistCollection(t *testing.T, app *pocketbase.PocketBase) {
 	t.Helper()
@@ -141,6 +162,67 @@ func SetupChildPlaylistCollection(t *testing.T, app *pocketbase.PocketBase) {
 		Required: false,
 	})
 
+	collection.Fields.Add(&core.BoolField{
+		Name:     "is_fallback",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "priority",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "share_token",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "max_tracks",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "selection_strategy",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "sync_strategy",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "source_base_playlist_ids",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "pinned_tracks",
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name:     "refollow_recreated",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name:     "suspended",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "spotify_integration_id",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name: "deleted_at",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "provider",
+	})
+
 	// Standard timestamp fields
 	collection.Fields.Add(&core.AutodateField{
 		Name:     "created",
@@ -183,6 +265,10 @@ func SetupSpotifyIntegrationsCollection(t *testing.T, app *pocketbase.PocketBase
 		CascadeDelete: true,
 	})
 
+	collection.Fields.Add(&core.TextField{
+		Name: "provider",
+	})
+
 	// Spotify user ID
 	collection.Fields.Add(&core.TextField{
 		Name:     "spotify_id",
@@ -237,6 +323,8 @@ func SetupSpotifyIntegrationsCollection(t *testing.T, app *pocketbase.PocketBase
 		OnUpdate: true,
 	})
 
+	collection.Indexes = spotifyIntegrationIndexes()
+
 	if err := app.Save(collection); err != nil {
 		t.Fatalf("failed to create collection: %v", err)
 	}
@@ -293,11 +381,51 @@ func SetupSyncEventCollection(t *testing.T, app *pocketbase.PocketBase) {
 		Required: false,
 	})
 
+	collection.Fields.Add(&core.NumberField{
+		Name:     "tracks_unmatched",
+		Required: false,
+	})
+
 	collection.Fields.Add(&core.NumberField{
 		Name:     "total_api_requests",
 		Required: false,
 	})
 
+	collection.Fields.Add(&core.JSONField{
+		Name:     "child_sync_results",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "anomalies",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "profile",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "rate_limit",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "phase",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "heartbeat_at",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "request_id",
+		Required: false,
+	})
+
 	// Standard timestamp fields
 	collection.Fields.Add(&core.AutodateField{
 		Name:     "created",
@@ -314,11 +442,968 @@ func SetupSyncEventCollection(t *testing.T, app *pocketbase.PocketBase) {
 	}
 }
 
-// SetupAllCollections sets up all collections needed for testing
-func SetupAllCollections(t *testing.T, app *pocketbase.PocketBase) {
+// SetupPlaylistSnapshotCollection creates the playlist_snapshots collection for testing
+func SetupPlaylistSnapshotCollection(t *testing.T, app *pocketbase.PocketBase) {
 	t.Helper()
-	SetupBasePlaylistCollection(t, app)
-	SetupSpotifyIntegrationsCollection(t, app)
-	SetupChildPlaylistCollection(t, app)
-	SetupSyncEventCollection(t, app)
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionPlaylistSnapshot))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionPlaylistSnapshot))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "sync_event_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "child_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "spotify_playlist_id",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "track_uris",
+		Required: false,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create playlist_snapshots collection: %v", err)
+	}
+}
+
+// SetupUserEncryptionKeyCollection creates the user_encryption_keys collection for testing
+func SetupUserEncryptionKeyCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionUserEncryptionKey))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionUserEncryptionKey))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "wrapped_key",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "key_version",
+		OnlyInt: true,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_user_encryption_keys_user ON user_encryption_keys (user_id)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create user_encryption_keys collection: %v", err)
+	}
+}
+
+// SetupEncryptionKeyRotationCollection creates the encryption_key_rotations collection for testing
+func SetupEncryptionKeyRotationCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionKeyRotation))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionKeyRotation))
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "to_version",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "keys_rotated",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "keys_failed",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create encryption_key_rotations collection: %v", err)
+	}
+}
+
+// SetupFilterRuleChangeCollection creates the filter_rule_changes collection for testing
+func SetupFilterRuleChangeCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionFilterRuleChange))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionFilterRuleChange))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "base_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "child_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "previous_filter_rules",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "new_filter_rules",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "routing_diff",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "diff_computed_at",
+		Required: false,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create filter_rule_changes collection: %v", err)
+	}
+}
+
+func SetupAPIKeyCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionAPIKey))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionAPIKey))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "name",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "key_hash",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "key_prefix",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "scopes",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "last_used_at",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "expires_at",
+		Required: false,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create api_keys collection: %v", err)
+	}
+}
+
+func SetupPlaylistMembershipCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionPlaylistMembership))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionPlaylistMembership))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "child_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "sync_event_id",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "spotify_playlist_id",
+		Required: false,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:     "track_uris",
+		Required: false,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_playlist_memberships_child ON playlist_memberships (child_playlist_id)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create playlist_memberships collection: %v", err)
+	}
+}
+
+// SetupAllCollections sets up all collections needed for testing
+func SetupAllCollections(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+	SetupBasePlaylistCollection(t, app)
+	SetupSpotifyIntegrationsCollection(t, app)
+	SetupChildPlaylistCollection(t, app)
+	SetupSyncEventCollection(t, app)
+	SetupPlaylistSnapshotCollection(t, app)
+	SetupUserEncryptionKeyCollection(t, app)
+	SetupEncryptionKeyRotationCollection(t, app)
+	SetupFilterRuleChangeCollection(t, app)
+	SetupAPIKeyCollection(t, app)
+	SetupPlaylistMembershipCollection(t, app)
+	SetupPlaylistWebhookCollection(t, app)
+	SetupBasePlaylistWatchCollection(t, app)
+}
+
+func SetupPlaylistWebhookCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionPlaylistWebhook))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionPlaylistWebhook))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "base_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.URLField{
+		Name:     "url",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "secret",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name: "is_active",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name: "last_delivered_at",
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "last_delivery_status",
+		OnlyInt: true,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create playlist_webhooks collection: %v", err)
+	}
+}
+
+func SetupBasePlaylistWatchCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionBasePlaylistWatch))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionBasePlaylistWatch))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "base_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "snapshot_id",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "track_uris",
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_base_playlist_watches_base ON base_playlist_watches (base_playlist_id)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create base_playlist_watches collection: %v", err)
+	}
+}
+
+func SetupChildPlaylistTemplateCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionChildPlaylistTemplate))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionChildPlaylistTemplate))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "name",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "description",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "children",
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create child_playlist_templates collection: %v", err)
+	}
+}
+
+func SetupBlocklistEntryCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionBlocklistEntry))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionBlocklistEntry))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "base_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "type",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "value",
+		Required: true,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create blocklist_entries collection: %v", err)
+	}
+}
+
+func SetupRoutingReportCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionRoutingReport))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionRoutingReport))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "base_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "sync_event_id",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "dedupe_strategy",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:    "tracks",
+		MaxSize: 20 << 20,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_routing_reports_base ON routing_reports (base_playlist_id)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create routing_reports collection: %v", err)
+	}
+}
+
+func SetupNotificationPreferencesCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionNotificationPreferences))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionNotificationPreferences))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name: "email_on_sync_failure",
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "sync_failure_threshold",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "sync_summary_channels",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "slack_webhook_url",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "discord_webhook_url",
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_notification_preferences_user ON notification_preferences (user_id)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create notification_preferences collection: %v", err)
+	}
+}
+
+func SetupRoutingCacheCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionRoutingCache))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionRoutingCache))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "child_playlist_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "snapshot_id",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "filter_hash",
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name:    "track_uris",
+		MaxSize: 20 << 20,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_routing_cache_entries_child ON routing_cache_entries (child_playlist_id)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create routing_cache_entries collection: %v", err)
+	}
+}
+
+func SetupFeatureFlagCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionFeatureFlag))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	collection := core.NewBaseCollection(string(CollectionFeatureFlag))
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "key",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "description",
+	})
+
+	collection.Fields.Add(&core.BoolField{
+		Name: "enabled",
+	})
+
+	collection.Fields.Add(&core.NumberField{
+		Name:    "rollout_percentage",
+		OnlyInt: true,
+	})
+
+	collection.Fields.Add(&core.JSONField{
+		Name: "user_ids",
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_feature_flags_key ON feature_flags (key)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create feature_flags collection: %v", err)
+	}
+}
+
+// SetupYouTubeMusicIntegrationCollection creates the youtube_music_integrations collection for testing
+func SetupYouTubeMusicIntegrationCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionYouTubeMusicIntegration))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	usersCollection, err := app.FindCollectionByNameOrId(string(CollectionUsers))
+	if err != nil {
+		t.Fatalf("users collection not found, make sure to call SetupUsersCollection first: %v", err)
+	}
+
+	collection := core.NewBaseCollection(string(CollectionYouTubeMusicIntegration))
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  usersCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "channel_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "access_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "refresh_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "token_type",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "expires_at",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "scope",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "display_name",
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_youtube_music_integrations_user ON youtube_music_integrations (user)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create youtube_music_integrations collection: %v", err)
+	}
+}
+
+// SetupTidalIntegrationCollection creates the tidal_integrations collection for testing
+func SetupTidalIntegrationCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionTidalIntegration))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	usersCollection, err := app.FindCollectionByNameOrId(string(CollectionUsers))
+	if err != nil {
+		t.Fatalf("users collection not found, make sure to call SetupUsersCollection first: %v", err)
+	}
+
+	collection := core.NewBaseCollection(string(CollectionTidalIntegration))
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  usersCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "tidal_user_id",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "country_code",
+		Max:  2,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "access_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "refresh_token",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "token_type",
+	})
+
+	collection.Fields.Add(&core.DateField{
+		Name:     "expires_at",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "scope",
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name: "display_name",
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_tidal_integrations_user ON tidal_integrations (user)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create tidal_integrations collection: %v", err)
+	}
+}
+
+// SetupLastFmIntegrationCollection creates the lastfm_integrations collection for testing
+func SetupLastFmIntegrationCollection(t *testing.T, app *pocketbase.PocketBase) {
+	t.Helper()
+
+	_, err := app.FindCollectionByNameOrId(string(CollectionLastFmIntegration))
+	if err == nil {
+		return // Collection already exists
+	}
+
+	usersCollection, err := app.FindCollectionByNameOrId(string(CollectionUsers))
+	if err != nil {
+		t.Fatalf("users collection not found, make sure to call SetupUsersCollection first: %v", err)
+	}
+
+	collection := core.NewBaseCollection(string(CollectionLastFmIntegration))
+
+	collection.Fields.Add(&core.RelationField{
+		Name:          "user",
+		Required:      true,
+		MaxSelect:     1,
+		CollectionId:  usersCollection.Id,
+		CascadeDelete: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "username",
+		Required: true,
+	})
+
+	collection.Fields.Add(&core.TextField{
+		Name:     "session_key",
+		Required: true,
+	})
+
+	// Standard timestamp fields
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "created",
+		OnCreate: true,
+	})
+	collection.Fields.Add(&core.AutodateField{
+		Name:     "updated",
+		OnCreate: true,
+		OnUpdate: true,
+	})
+
+	collection.Indexes = []string{
+		"CREATE UNIQUE INDEX idx_lastfm_integrations_user ON lastfm_integrations (user)",
+	}
+
+	if err := app.Save(collection); err != nil {
+		t.Fatalf("failed to create lastfm_integrations collection: %v", err)
+	}
+}
+
+// backdateDeletion moves the deleted_at of a soft deleted record to the given time
+func backdateDeletion(t *testing.T, app *pocketbase.PocketBase, collection Collection, id string, deletedAt time.Time) {
+	t.Helper()
+
+	record, err := app.FindRecordById(string(collection), id)
+	if err != nil {
+		t.Fatalf("failed to find %s record: %v", collection, err)
+	}
+
+	record.Set("deleted_at", deletedAt)
+	if err := app.Save(record); err != nil {
+		t.Fatalf("failed to backdate %s record: %v", collection, err)
+	}
 }
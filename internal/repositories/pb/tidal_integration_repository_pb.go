@@ -0,0 +1,240 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type TidalIntegrationRepositoryPocketbase struct {
+	collection  Collection
+	app         *pocketbase.PocketBase
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
+}
+
+func NewTidalIntegrationRepositoryPocketbase(pb *pocketbase.PocketBase) *TidalIntegrationRepositoryPocketbase {
+	return &TidalIntegrationRepositoryPocketbase{
+		app:        pb,
+		collection: CollectionTidalIntegration,
+		log:        pb.Logger().With("component", "TidalIntegrationRepositoryPocketbase"),
+	}
+}
+
+// WithTokenCipher encrypts the stored Tidal tokens with the data key of their user
+func (tRepo *TidalIntegrationRepositoryPocketbase) WithTokenCipher(tokenCipher repositories.TokenCipher) *TidalIntegrationRepositoryPocketbase {
+	tRepo.tokenCipher = tokenCipher
+	return tRepo
+}
+
+func (tRepo *TidalIntegrationRepositoryPocketbase) CreateOrUpdate(
+	ctx context.Context,
+	userId string,
+	integration *models.TidalIntegration,
+) (*models.TidalIntegration, error) {
+	collection, err := tRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var record *core.Record
+	existing, err := appFromContext(ctx, tRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		tRepo.log.InfoContext(ctx, "tidal_integration not found", "user", userId)
+		record = core.NewRecord(collection)
+		record.Set("user", userId)
+	} else {
+		tRepo.log.InfoContext(ctx, "tidal_integration found", "user", userId, "record", existing.Id)
+		record = existing
+	}
+
+	accessToken, err := tRepo.encryptToken(ctx, userId, integration.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := tRepo.encryptToken(ctx, userId, integration.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Set("tidal_user_id", integration.TidalUserID)
+	record.Set("country_code", integration.CountryCode)
+	record.Set("access_token", accessToken)
+	record.Set("refresh_token", refreshToken)
+	record.Set("token_type", integration.TokenType)
+	record.Set("expires_at", integration.ExpiresAt)
+	record.Set("scope", integration.Scope)
+	record.Set("display_name", integration.DisplayName)
+
+	if err := appFromContext(ctx, tRepo.app).Save(record); err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to store tidal_integration record", "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	tRepo.log.InfoContext(ctx, "tidal_integration stored successfully", "user", userId, "tidal_user_id", integration.TidalUserID)
+	return tRepo.toTidalIntegration(ctx, record)
+}
+
+func (tRepo *TidalIntegrationRepositoryPocketbase) GetByUserID(ctx context.Context, userId string) (*models.TidalIntegration, error) {
+	collection, err := tRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, tRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		tRepo.log.InfoContext(ctx, "unable to fetch tidal_integration", "user", userId, "error", err)
+		return nil, repositories.ErrTidalIntegrationNotFound
+	}
+
+	return tRepo.toTidalIntegration(ctx, record)
+}
+
+func (tRepo *TidalIntegrationRepositoryPocketbase) UpdateTokens(
+	ctx context.Context,
+	integrationId string,
+	tokens *models.TidalIntegrationTokenRefresh,
+) error {
+	collection, err := tRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, tRepo.app).FindRecordById(collection, integrationId)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to fetch tidal_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrTidalIntegrationNotFound
+	}
+
+	userId := record.GetString("user")
+
+	accessToken, err := tRepo.encryptToken(ctx, userId, tokens.AccessToken)
+	if err != nil {
+		return err
+	}
+	record.Set("access_token", accessToken)
+
+	// Tidal usually keeps the refresh token, only replacing it when it sends one
+	if tokens.RefreshToken != "" {
+		refreshToken, err := tRepo.encryptToken(ctx, userId, tokens.RefreshToken)
+		if err != nil {
+			return err
+		}
+		record.Set("refresh_token", refreshToken)
+	}
+
+	record.Set("expires_at", time.Now().Add(time.Duration(tokens.ExpiresIn)*time.Second))
+
+	if err := appFromContext(ctx, tRepo.app).Save(record); err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to update tidal_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	tRepo.log.InfoContext(ctx, "tidal_integration tokens updated", "integration_id", integrationId)
+	return nil
+}
+
+func (tRepo *TidalIntegrationRepositoryPocketbase) Delete(ctx context.Context, userId string) error {
+	collection, err := tRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, tRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "tidal_integration not found", "user", userId, "error", err)
+		return repositories.ErrTidalIntegrationNotFound
+	}
+
+	if err := appFromContext(ctx, tRepo.app).Delete(record); err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to delete tidal_integration", "user", userId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	tRepo.log.InfoContext(ctx, "tidal_integration deleted", "user", userId)
+	return nil
+}
+
+func (tRepo *TidalIntegrationRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, tRepo.app).FindCollectionByNameOrId(string(tRepo.collection))
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to find collection", "collection", tRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func (tRepo *TidalIntegrationRepositoryPocketbase) encryptToken(ctx context.Context, userId, token string) (string, error) {
+	if tRepo.tokenCipher == nil || token == "" {
+		return token, nil
+	}
+
+	encryptedToken, err := tRepo.tokenCipher.EncryptForUser(ctx, userId, token)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to encrypt tidal token", "user", userId, "error", err)
+		return "", fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return encryptedToken, nil
+}
+
+func (tRepo *TidalIntegrationRepositoryPocketbase) toTidalIntegration(ctx context.Context, record *core.Record) (*models.TidalIntegration, error) {
+	integration := recordToTidalIntegration(record)
+	if tRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	accessToken, err := tRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.AccessToken)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to decrypt tidal access token", "user", integration.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	refreshToken, err := tRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.RefreshToken)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to decrypt tidal refresh token", "user", integration.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	integration.AccessToken = accessToken
+	integration.RefreshToken = refreshToken
+	return integration, nil
+}
+
+func recordToTidalIntegration(record *core.Record) *models.TidalIntegration {
+	return &models.TidalIntegration{
+		ID:           record.Id,
+		UserID:       record.GetString("user"),
+		TidalUserID:  record.GetString("tidal_user_id"),
+		CountryCode:  record.GetString("country_code"),
+		AccessToken:  record.GetString("access_token"),
+		RefreshToken: record.GetString("refresh_token"),
+		TokenType:    record.GetString("token_type"),
+		ExpiresAt:    record.GetDateTime("expires_at").Time(),
+		Scope:        record.GetString("scope"),
+		DisplayName:  record.GetString("display_name"),
+		Created:      record.GetDateTime("created").Time(),
+		Updated:      record.GetDateTime("updated").Time(),
+	}
+}
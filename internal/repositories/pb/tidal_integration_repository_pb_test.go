@@ -0,0 +1,123 @@
+package pb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTidalIntegration(tidalUserID string) *models.TidalIntegration {
+	return &models.TidalIntegration{
+		TidalUserID:  tidalUserID,
+		CountryCode:  "NL",
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(1 * time.Hour),
+		Scope:        "r_usr w_usr",
+		DisplayName:  "listener",
+	}
+}
+
+func TestTidalIntegrationRepositoryPocketbase_CreateOrUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupTidalIntegrationCollection(t, app)
+	repo := NewTidalIntegrationRepositoryPocketbase(app)
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("tidal@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	created, err := repo.CreateOrUpdate(ctx, userID, newTestTidalIntegration("1234"))
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal(userID, created.UserID)
+	assert.Equal("1234", created.TidalUserID)
+	assert.Equal("NL", created.CountryCode)
+	assert.Equal("access_token_123", created.AccessToken)
+	assert.Equal("listener", created.DisplayName)
+
+	// Linking another account replaces the one of the user
+	updated, err := repo.CreateOrUpdate(ctx, userID, newTestTidalIntegration("5678"))
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.Equal("5678", updated.TidalUserID)
+
+	retrieved, err := repo.GetByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Equal("5678", retrieved.TidalUserID)
+}
+
+func TestTidalIntegrationRepositoryPocketbase_GetByUserID_NotFound(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupTidalIntegrationCollection(t, app)
+	repo := NewTidalIntegrationRepositoryPocketbase(app)
+
+	result, err := repo.GetByUserID(context.Background(), "nonexistent")
+
+	assert.ErrorIs(err, repositories.ErrTidalIntegrationNotFound)
+	assert.Nil(result)
+}
+
+func TestTidalIntegrationRepositoryPocketbase_UpdateTokens(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupTidalIntegrationCollection(t, app)
+	repo := NewTidalIntegrationRepositoryPocketbase(app).WithTokenCipher(prefixTokenCipher{})
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("tidal@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	created, err := repo.CreateOrUpdate(ctx, userID, newTestTidalIntegration("1234"))
+	assert.NoError(err)
+
+	// Stored values are encrypted
+	record, err := app.FindRecordById(string(CollectionTidalIntegration), created.ID)
+	assert.NoError(err)
+	assert.Equal(userID+":access_token_123", record.GetString("access_token"))
+
+	err = repo.UpdateTokens(ctx, created.ID, &models.TidalIntegrationTokenRefresh{
+		AccessToken: "access_token_456",
+		ExpiresIn:   3600,
+	})
+	assert.NoError(err)
+
+	retrieved, err := repo.GetByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Equal("access_token_456", retrieved.AccessToken)
+	assert.Equal("refresh_token_123", retrieved.RefreshToken)
+	assert.WithinDuration(time.Now().Add(time.Hour), retrieved.ExpiresAt, time.Minute)
+
+	err = repo.UpdateTokens(ctx, "nonexistent", &models.TidalIntegrationTokenRefresh{AccessToken: "token"})
+	assert.ErrorIs(err, repositories.ErrTidalIntegrationNotFound)
+}
+
+func TestTidalIntegrationRepositoryPocketbase_Delete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupTidalIntegrationCollection(t, app)
+	repo := NewTidalIntegrationRepositoryPocketbase(app)
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("tidal@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	_, err := repo.CreateOrUpdate(ctx, userID, newTestTidalIntegration("1234"))
+	assert.NoError(err)
+
+	assert.NoError(repo.Delete(ctx, userID))
+
+	_, err = repo.GetByUserID(ctx, userID)
+	assert.ErrorIs(err, repositories.ErrTidalIntegrationNotFound)
+
+	assert.ErrorIs(repo.Delete(ctx, userID), repositories.ErrTidalIntegrationNotFound)
+}
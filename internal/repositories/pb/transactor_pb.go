@@ -0,0 +1,35 @@
+package pb
+
+import (
+	"context"
+
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type txAppKey struct{}
+
+type TransactorPocketbase struct {
+	app *pocketbase.PocketBase
+}
+
+func NewTransactorPocketbase(pb *pocketbase.PocketBase) *TransactorPocketbase {
+	return &TransactorPocketbase{app: pb}
+}
+
+// RunInTransaction runs fn in a PocketBase transaction. The repositories read and write through the
+// transaction app carried by the context given to fn, writing through the app instead would wait
+// on the lock held by the transaction
+func (t *TransactorPocketbase) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return appFromContext(ctx, t.app).RunInTransaction(func(txApp core.App) error {
+		return fn(context.WithValue(ctx, txAppKey{}, txApp))
+	})
+}
+
+// appFromContext returns the app of the transaction the context runs in, or app outside of one
+func appFromContext(ctx context.Context, app core.App) core.App {
+	if txApp, ok := ctx.Value(txAppKey{}).(core.App); ok {
+		return txApp
+	}
+	return app
+}
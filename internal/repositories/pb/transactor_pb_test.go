@@ -0,0 +1,66 @@
+package pb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactorPocketbase_RunInTransaction_Commit(t *testing.T) {
+	assert := require.New(t)
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+	transactor := NewTransactorPocketbase(app)
+	ctx := context.Background()
+
+	var created *models.BasePlaylist
+	err := transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = repo.Create(ctx, "user123", "Committed", "spotify123", "", "", "")
+		if err != nil {
+			return err
+		}
+
+		// Reads inside the transaction see its writes
+		_, err = repo.GetByID(ctx, created.ID, "user123")
+		return err
+	})
+	assert.NoError(err)
+
+	stored, err := repo.GetByID(ctx, created.ID, "user123")
+	assert.NoError(err)
+	assert.Equal("Committed", stored.Name)
+}
+
+func TestTransactorPocketbase_RunInTransaction_Rollback(t *testing.T) {
+	assert := require.New(t)
+	app := NewTestApp(t)
+	SetupBasePlaylistCollection(t, app)
+	repo := NewBasePlaylistRepositoryPocketbase(app)
+	transactor := NewTransactorPocketbase(app)
+	ctx := context.Background()
+	failure := errors.New("second step failed")
+
+	var created *models.BasePlaylist
+	err := transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = repo.Create(ctx, "user123", "Rolled back", "spotify123", "", "", "")
+		if err != nil {
+			return err
+		}
+
+		// Nested units of work join the outer transaction
+		return transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+			return failure
+		})
+	})
+	assert.ErrorIs(err, failure)
+
+	_, err = repo.GetByID(ctx, created.ID, "user123")
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
@@ -0,0 +1,140 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type UserEncryptionKeyRepositoryPocketbase struct {
+	collection Collection
+	app        *pocketbase.PocketBase
+	log        *slog.Logger
+}
+
+func NewUserEncryptionKeyRepositoryPocketbase(pb *pocketbase.PocketBase) *UserEncryptionKeyRepositoryPocketbase {
+	return &UserEncryptionKeyRepositoryPocketbase{
+		collection: CollectionUserEncryptionKey,
+		app:        pb,
+		log:        pb.Logger().With("component", "UserEncryptionKeyRepositoryPocketbase"),
+	}
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPocketbase) Create(ctx context.Context, userID, wrappedKey string, keyVersion int) (*models.UserEncryptionKey, error) {
+	collection, err := ukRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record := core.NewRecord(collection)
+	record.Set("user_id", userID)
+	record.Set("wrapped_key", wrappedKey)
+	record.Set("key_version", keyVersion)
+
+	err = appFromContext(ctx, ukRepo.app).Save(record)
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to store user_encryption_key record", "user_id", userID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	ukRepo.log.InfoContext(ctx, "user_encryption_key stored successfully", "user_id", userID, "key_version", keyVersion)
+	return recordToUserEncryptionKey(record), nil
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPocketbase) GetByUserID(ctx context.Context, userID string) (*models.UserEncryptionKey, error) {
+	collection, err := ukRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, ukRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user_id = {:userID}",
+		dbx.Params{"userID": userID},
+	)
+	if err != nil {
+		return nil, repositories.ErrUserEncryptionKeyNotFound
+	}
+
+	return recordToUserEncryptionKey(record), nil
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPocketbase) GetOutdated(ctx context.Context, currentVersion int) ([]*models.UserEncryptionKey, error) {
+	collection, err := ukRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := appFromContext(ctx, ukRepo.app).FindRecordsByFilter(
+		collection,
+		"key_version != {:version}",
+		"created",
+		0, // limit (0 = no limit)
+		0, // offset
+		dbx.Params{"version": currentVersion},
+	)
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to find outdated user_encryption_key records", "current_version", currentVersion, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	keys := make([]*models.UserEncryptionKey, len(records))
+	for i, record := range records {
+		keys[i] = recordToUserEncryptionKey(record)
+	}
+
+	ukRepo.log.InfoContext(ctx, "outdated user_encryption_keys retrieved successfully", "current_version", currentVersion, "count", len(keys))
+	return keys, nil
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPocketbase) UpdateWrappedKey(ctx context.Context, id, wrappedKey string, keyVersion int) error {
+	collection, err := ukRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, ukRepo.app).FindRecordById(collection, id)
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to find user_encryption_key record", "id", id, "error", err)
+		return repositories.ErrUserEncryptionKeyNotFound
+	}
+
+	record.Set("wrapped_key", wrappedKey)
+	record.Set("key_version", keyVersion)
+
+	err = appFromContext(ctx, ukRepo.app).Save(record)
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to update user_encryption_key record", "id", id, "error", err)
+		return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	ukRepo.log.InfoContext(ctx, "user_encryption_key rewrapped successfully", "id", id, "key_version", keyVersion)
+	return nil
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, ukRepo.app).FindCollectionByNameOrId(string(ukRepo.collection))
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to find collection", "collection", ukRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func recordToUserEncryptionKey(record *core.Record) *models.UserEncryptionKey {
+	return &models.UserEncryptionKey{
+		ID:         record.Id,
+		UserID:     record.GetString("user_id"),
+		WrappedKey: record.GetString("wrapped_key"),
+		KeyVersion: record.GetInt("key_version"),
+		Created:    record.GetDateTime("created").Time(),
+		Updated:    record.GetDateTime("updated").Time(),
+	}
+}
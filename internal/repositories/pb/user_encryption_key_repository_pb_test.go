@@ -0,0 +1,108 @@
+package pb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserEncryptionKeyRepositoryPocketbase_CreateAndGetByUserID(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupUserEncryptionKeyCollection(t, app)
+	repo := NewUserEncryptionKeyRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, "user123", "wrapped-key", 1)
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal("user123", created.UserID)
+	assert.Equal("wrapped-key", created.WrappedKey)
+	assert.Equal(1, created.KeyVersion)
+
+	retrieved, err := repo.GetByUserID(ctx, "user123")
+	assert.NoError(err)
+	assert.Equal(created.ID, retrieved.ID)
+	assert.Equal("wrapped-key", retrieved.WrappedKey)
+
+	_, err = repo.GetByUserID(ctx, "user456")
+	assert.ErrorIs(err, repositories.ErrUserEncryptionKeyNotFound)
+
+	// Each user has a single data key
+	_, err = repo.Create(ctx, "user123", "other-wrapped-key", 1)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestUserEncryptionKeyRepositoryPocketbase_GetOutdatedAndUpdateWrappedKey(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupUserEncryptionKeyCollection(t, app)
+	repo := NewUserEncryptionKeyRepositoryPocketbase(app)
+
+	ctx := context.Background()
+
+	outdated, err := repo.Create(ctx, "user123", "wrapped-v1", 1)
+	assert.NoError(err)
+	_, err = repo.Create(ctx, "user456", "wrapped-v2", 2)
+	assert.NoError(err)
+
+	keys, err := repo.GetOutdated(ctx, 2)
+	assert.NoError(err)
+	assert.Len(keys, 1)
+	assert.Equal(outdated.ID, keys[0].ID)
+
+	err = repo.UpdateWrappedKey(ctx, outdated.ID, "rewrapped-v2", 2)
+	assert.NoError(err)
+
+	keys, err = repo.GetOutdated(ctx, 2)
+	assert.NoError(err)
+	assert.Empty(keys)
+
+	retrieved, err := repo.GetByUserID(ctx, "user123")
+	assert.NoError(err)
+	assert.Equal("rewrapped-v2", retrieved.WrappedKey)
+	assert.Equal(2, retrieved.KeyVersion)
+
+	err = repo.UpdateWrappedKey(ctx, "nonexistent123", "wrapped", 2)
+	assert.ErrorIs(err, repositories.ErrUserEncryptionKeyNotFound)
+}
+
+func TestUserEncryptionKeyRepositoryPocketbase_CollectionNotFound(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	repo := NewUserEncryptionKeyRepositoryPocketbase(app)
+
+	_, err := repo.Create(context.Background(), "user123", "wrapped-key", 1)
+	assert.ErrorIs(err, repositories.ErrCollectionNotFound)
+}
+
+func TestEncryptionKeyRotationRepositoryPocketbase_Create(t *testing.T) {
+	assert := require.New(t)
+
+	// Setup test environment
+	app := NewTestApp(t)
+	SetupEncryptionKeyRotationCollection(t, app)
+	repo := NewEncryptionKeyRotationRepositoryPocketbase(app)
+
+	rotation, err := repo.Create(context.Background(), &models.EncryptionKeyRotation{
+		ToVersion:   2,
+		KeysRotated: 10,
+		KeysFailed:  1,
+	})
+
+	assert.NoError(err)
+	assert.NotEmpty(rotation.ID)
+	assert.Equal(2, rotation.ToVersion)
+	assert.Equal(10, rotation.KeysRotated)
+	assert.Equal(1, rotation.KeysFailed)
+	assert.False(rotation.Created.IsZero())
+}
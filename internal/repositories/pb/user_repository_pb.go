@@ -6,9 +6,9 @@ import (
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase"
 	"github.com/pocketbase/pocketbase/core"
-	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 type UserRepositoryPocketbase struct {
@@ -35,10 +35,11 @@ func (uRepo *UserRepositoryPocketbase) Create(ctx context.Context, user *models.
 	userRecord.Set("email", user.Email)
 	userRecord.Set("username", user.Username)
 	userRecord.Set("name", user.Name)
+	userRecord.Set("roles", rolesOrDefault(user.Roles))
 	userRecord.Set("password", "systemuser123")
 	userRecord.Set("passwordConfirm", "systemuser123")
 
-	if err := uRepo.app.Save(userRecord); err != nil {
+	if err := appFromContext(ctx, uRepo.app).Save(userRecord); err != nil {
 		uRepo.log.ErrorContext(ctx, "unable to store user record", "record", userRecord, "error", err)
 		return nil, repositories.ErrDatabaseOperation
 	}
@@ -50,7 +51,7 @@ func (uRepo *UserRepositoryPocketbase) Create(ctx context.Context, user *models.
 }
 
 func (uRepo *UserRepositoryPocketbase) Update(ctx context.Context, user *models.User) (*models.User, error) {
-	userRecord, err := uRepo.app.FindRecordById(string(uRepo.collection), user.ID)
+	userRecord, err := appFromContext(ctx, uRepo.app).FindRecordById(string(uRepo.collection), user.ID)
 	if err != nil {
 		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", user.ID, "error", err)
 		return nil, repositories.ErrUseNotFound
@@ -59,8 +60,9 @@ func (uRepo *UserRepositoryPocketbase) Update(ctx context.Context, user *models.
 	userRecord.Set("email", user.Email)
 	userRecord.Set("username", user.Username)
 	userRecord.Set("name", user.Name)
+	userRecord.Set("disconnected_spotify_id", user.DisconnectedSpotifyID)
 
-	if err := uRepo.app.Save(userRecord); err != nil {
+	if err := appFromContext(ctx, uRepo.app).Save(userRecord); err != nil {
 		uRepo.log.ErrorContext(ctx, "unable to store user record", "record", userRecord, "error", err)
 		return nil, repositories.ErrDatabaseOperation
 	}
@@ -72,7 +74,7 @@ func (uRepo *UserRepositoryPocketbase) Update(ctx context.Context, user *models.
 }
 
 func (uRepo *UserRepositoryPocketbase) GetByID(ctx context.Context, userID string) (*models.User, error) {
-	record, err := uRepo.app.FindRecordById(string(uRepo.collection), userID)
+	record, err := appFromContext(ctx, uRepo.app).FindRecordById(string(uRepo.collection), userID)
 	if err != nil {
 		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", userID, "error", err)
 		return nil, repositories.ErrUseNotFound
@@ -84,14 +86,35 @@ func (uRepo *UserRepositoryPocketbase) GetByID(ctx context.Context, userID strin
 	return user, nil
 }
 
+func (uRepo *UserRepositoryPocketbase) GetByDisconnectedSpotifyID(ctx context.Context, spotifyID string) (*models.User, error) {
+	if spotifyID == "" {
+		return nil, repositories.ErrUseNotFound
+	}
+
+	record, err := appFromContext(ctx, uRepo.app).FindFirstRecordByFilter(
+		string(uRepo.collection),
+		"disconnected_spotify_id = {:spotifyID}",
+		dbx.Params{"spotifyID": spotifyID},
+	)
+	if err != nil {
+		uRepo.log.InfoContext(ctx, "no user disconnected the spotify account", "spotify_id", spotifyID)
+		return nil, repositories.ErrUseNotFound
+	}
+
+	user := recordToUser(record)
+	uRepo.log.InfoContext(ctx, "user retrieved by disconnected spotify id successfully", "user", user.ID, "spotify_id", spotifyID)
+
+	return user, nil
+}
+
 func (uRepo *UserRepositoryPocketbase) Delete(ctx context.Context, userID string) error {
-	record, err := uRepo.app.FindRecordById(string(uRepo.collection), userID)
+	record, err := appFromContext(ctx, uRepo.app).FindRecordById(string(uRepo.collection), userID)
 	if err != nil {
 		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", userID, "error", err)
 		return repositories.ErrUseNotFound
 	}
 
-	if err := uRepo.app.Delete(record); err != nil {
+	if err := appFromContext(ctx, uRepo.app).Delete(record); err != nil {
 		uRepo.log.ErrorContext(ctx, "unable to delete user record", "user", userID, "error", err)
 		return repositories.ErrDatabaseOperation
 	}
@@ -102,12 +125,17 @@ func (uRepo *UserRepositoryPocketbase) Delete(ctx context.Context, userID string
 
 func (uRepo *UserRepositoryPocketbase) GenerateAuthToken(ctx context.Context, userID string) (string, error) {
 	// Find the user record
-	record, err := uRepo.app.FindRecordById(string(uRepo.collection), userID)
+	record, err := appFromContext(ctx, uRepo.app).FindRecordById(string(uRepo.collection), userID)
 	if err != nil {
 		uRepo.log.ErrorContext(ctx, "unable to fetch user for token generation", "user", userID, "error", err)
 		return "", repositories.ErrUseNotFound
 	}
 
+	if record.GetBool("disabled") {
+		uRepo.log.WarnContext(ctx, "refusing auth token for disabled user", "user", userID)
+		return "", repositories.ErrUserDisabled
+	}
+
 	// Generate JWT token for this user using PocketBase's auth system
 	// In PocketBase v0.29, use record.NewAuthToken() method
 	token, err := record.NewAuthToken()
@@ -121,29 +149,99 @@ func (uRepo *UserRepositoryPocketbase) GenerateAuthToken(ctx context.Context, us
 }
 
 func (uRepo *UserRepositoryPocketbase) ValidateAuthToken(ctx context.Context, token string) (*models.User, error) {
-	// Parse and validate the token using PocketBase security package
-	claims, err := security.ParseUnverifiedJWT(token)
+	// The signature is verified against the user's token key, so tokens issued before a revocation
+	// are rejected along with expired and forged ones
+	record, err := appFromContext(ctx, uRepo.app).FindAuthRecordByToken(token, core.TokenTypeAuth)
 	if err != nil {
-		uRepo.log.ErrorContext(ctx, "invalid token format", "error", err)
+		uRepo.log.ErrorContext(ctx, "invalid auth token", "error", err)
 		return nil, repositories.ErrUseNotFound
 	}
 
-	// Get user ID from claims
-	userID, ok := claims["id"].(string)
-	if !ok || userID == "" {
-		uRepo.log.ErrorContext(ctx, "invalid token claims")
+	if record.Collection().Name != string(uRepo.collection) {
+		uRepo.log.ErrorContext(ctx, "auth token not issued for a user", "collection", record.Collection().Name)
 		return nil, repositories.ErrUseNotFound
 	}
 
-	// Get user record from PocketBase to verify user still exists
-	record, err := uRepo.app.FindRecordById(string(uRepo.collection), userID)
+	if record.GetBool("disabled") {
+		uRepo.log.WarnContext(ctx, "auth token issued to disabled user", "user", record.Id)
+		return nil, repositories.ErrUserDisabled
+	}
+
+	user := recordToUser(record)
+	uRepo.log.InfoContext(ctx, "auth token validated successfully", "user", user.ID)
+
+	return user, nil
+}
+
+// RevokeAuthTokens rotates the user's token key, which invalidates every auth token issued so far
+func (uRepo *UserRepositoryPocketbase) RevokeAuthTokens(ctx context.Context, userID string) error {
+	record, err := appFromContext(ctx, uRepo.app).FindRecordById(string(uRepo.collection), userID)
 	if err != nil {
-		uRepo.log.ErrorContext(ctx, "unable to fetch user from token", "user", userID, "error", err)
+		uRepo.log.ErrorContext(ctx, "unable to fetch user for token revocation", "user", userID, "error", err)
+		return repositories.ErrUseNotFound
+	}
+
+	record.RefreshTokenKey()
+	if err := appFromContext(ctx, uRepo.app).Save(record); err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to rotate user token key", "user", userID, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	uRepo.log.InfoContext(ctx, "auth tokens revoked successfully", "user", userID)
+	return nil
+}
+
+func (uRepo *UserRepositoryPocketbase) Search(ctx context.Context, query string, limit, offset int) ([]*models.User, error) {
+	filter := ""
+	params := dbx.Params{}
+	if query != "" {
+		filter = "id = {:query} || email ~ {:query} || name ~ {:query}"
+		params["query"] = query
+	}
+
+	records, err := appFromContext(ctx, uRepo.app).FindRecordsByFilter(
+		string(uRepo.collection),
+		filter,
+		"-created",
+		limit,
+		offset,
+		params,
+	)
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to search users", "query", query, "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	users := make([]*models.User, len(records))
+	for i, record := range records {
+		users[i] = recordToUser(record)
+	}
+
+	uRepo.log.InfoContext(ctx, "users searched successfully", "query", query, "count", len(users))
+	return users, nil
+}
+
+// SetDisabled stores the flag and, when disabling, rotates the token key in the same save, so the
+// sessions of the user end along with the account
+func (uRepo *UserRepositoryPocketbase) SetDisabled(ctx context.Context, userID string, disabled bool) (*models.User, error) {
+	record, err := appFromContext(ctx, uRepo.app).FindRecordById(string(uRepo.collection), userID)
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", userID, "error", err)
 		return nil, repositories.ErrUseNotFound
 	}
 
+	record.Set("disabled", disabled)
+	if disabled {
+		record.RefreshTokenKey()
+	}
+
+	if err := appFromContext(ctx, uRepo.app).Save(record); err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to store user record", "user", userID, "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
 	user := recordToUser(record)
-	uRepo.log.InfoContext(ctx, "auth token validated successfully", "user", userID)
+	uRepo.log.InfoContext(ctx, "user disabled flag updated successfully", "user", userID, "disabled", disabled)
 
 	return user, nil
 }
@@ -158,12 +256,35 @@ func recordToUser(record *core.Record) *models.User {
 		username = record.GetString("email") // Fallback to email if username is empty
 	}
 
+	roles := []models.UserRole{}
+	for _, role := range record.GetStringSlice("roles") {
+		roles = append(roles, models.UserRole(role))
+	}
+
 	return &models.User{
-		ID:       record.Id,
-		Created:  record.GetDateTime("created").Time(),
-		Username: username,
-		Email:    record.GetString("email"),
-		Name:     record.GetString("name"),
-		Updated:  record.GetDateTime("updated").Time(),
+		ID:                    record.Id,
+		Created:               record.GetDateTime("created").Time(),
+		Username:              username,
+		Email:                 record.GetString("email"),
+		Name:                  record.GetString("name"),
+		DisconnectedSpotifyID: record.GetString("disconnected_spotify_id"),
+		Roles:                 roles,
+		Disabled:              record.GetBool("disabled"),
+		Updated:               record.GetDateTime("updated").Time(),
 	}
 }
+
+// rolesOrDefault grants the user role to users created without roles. Update leaves the roles alone,
+// they are assigned by superusers from the dashboard
+func rolesOrDefault(roles []models.UserRole) []string {
+	if len(roles) == 0 {
+		return []string{string(models.RoleUser)}
+	}
+
+	values := make([]string, len(roles))
+	for i, role := range roles {
+		values[i] = string(role)
+	}
+
+	return values
+}
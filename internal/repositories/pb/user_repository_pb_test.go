@@ -7,8 +7,8 @@ import (
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/pocketbase/pocketbase"
-	"github.com/pocketbase/pocketbase/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,11 +19,7 @@ func TestUserRepositoryPocketbase_Create_Success(t *testing.T) {
 	repo := NewUserRepositoryPocketbase(app)
 	ctx := context.Background()
 
-	user := &models.User{
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-	}
+	user := testfixtures.NewUser().WithEmail("test@example.com").WithUsername("testuser").WithName("Test User").Build()
 
 	createdUser, err := repo.Create(ctx, user)
 
@@ -45,11 +41,7 @@ func TestUserRepositoryPocketbase_Create_Error(t *testing.T) {
 	ctx := context.Background()
 
 	// Create user with invalid email to trigger database error
-	user := &models.User{
-		Email:    "invalid-email",
-		Username: "testuser",
-		Name:     "Test User",
-	}
+	user := testfixtures.NewUser().WithEmail("invalid-email").WithUsername("testuser").WithName("Test User").Build()
 
 	createdUser, err := repo.Create(ctx, user)
 	assert.Error(err)
@@ -66,13 +58,8 @@ func TestUserRepositoryPocketbase_GetByID_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a test user first
-	testUser := &models.User{
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-	}
-	createdUser, err := createUserInDB(t, app, testUser)
-	assert.NoError(err)
+	testUser := testfixtures.NewUser().WithEmail("test@example.com").WithUsername("testuser").WithName("Test User").Build()
+	createdUser := testfixtures.SeedUser(t, app, testUser)
 
 	// Now test GetByID
 	retrievedUser, err := repo.GetByID(ctx, createdUser.ID)
@@ -112,16 +99,11 @@ func TestUserRepositoryPocketbase_Delete_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a test user first
-	testUser := &models.User{
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-	}
-	createdUser, err := createUserInDB(t, app, testUser)
-	assert.NoError(err)
+	testUser := testfixtures.NewUser().WithEmail("test@example.com").WithUsername("testuser").WithName("Test User").Build()
+	createdUser := testfixtures.SeedUser(t, app, testUser)
 
 	// Now test Delete
-	err = repo.Delete(ctx, createdUser.ID)
+	err := repo.Delete(ctx, createdUser.ID)
 
 	assert.NoError(err)
 
@@ -131,6 +113,25 @@ func TestUserRepositoryPocketbase_Delete_Success(t *testing.T) {
 	assert.Nil(retrievedUser)
 }
 
+func TestUserRepositoryPocketbase_Delete_CascadesOwnedRecords(t *testing.T) {
+	assert := assert.New(t)
+	app := NewTestApp(t)
+	SetupUsersCollection(t, app)
+	SetupSpotifyIntegrationsCollection(t, app)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	user := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("test@example.com").Build())
+	integration := testfixtures.SeedSpotifyIntegration(t, app, testfixtures.NewSpotifyIntegration().WithUserID(user.ID).Build())
+
+	err := repo.Delete(ctx, user.ID)
+
+	// Account deletion relies on the user relations removing every record the user owns
+	assert.NoError(err)
+	_, err = app.FindRecordById(string(CollectionSpotifyIntegration), integration.ID)
+	assert.Error(err)
+}
+
 func TestUserRepositoryPocketbase_Delete_Error(t *testing.T) {
 	assert := assert.New(t)
 	app := NewTestApp(t)
@@ -151,13 +152,8 @@ func TestUserRepositoryPocketbase_Update_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a test user first
-	testUser := &models.User{
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-	}
-	createdUser, err := createUserInDB(t, app, testUser)
-	assert.NoError(err)
+	testUser := testfixtures.NewUser().WithEmail("test@example.com").WithUsername("testuser").WithName("Test User").Build()
+	createdUser := testfixtures.SeedUser(t, app, testUser)
 
 	createdUser.Name = "Updated Test User"
 
@@ -179,12 +175,91 @@ func TestUserRepositoryPocketbase_Update_Error(t *testing.T) {
 	ctx := context.Background()
 
 	// Try to update a non-existent user
-	_, err := repo.Update(ctx, &models.User{ID: "nonexistent-id"})
+	_, err := repo.Update(ctx, testfixtures.NewUser().WithID("nonexistent-id").Build())
 
 	assert.Error(err)
 	assert.Equal(repositories.ErrUseNotFound, err)
 }
 
+func TestUserRepositoryPocketbase_GetByDisconnectedSpotifyID(t *testing.T) {
+	assert := assert.New(t)
+	app := NewTestApp(t)
+	SetupUsersCollection(t, app)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	createdUser := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("test@example.com").Build())
+
+	_, err := repo.GetByDisconnectedSpotifyID(ctx, "spotify123")
+	assert.Equal(repositories.ErrUseNotFound, err)
+
+	createdUser.DisconnectedSpotifyID = "spotify123"
+	_, err = repo.Update(ctx, createdUser)
+	assert.NoError(err)
+
+	user, err := repo.GetByDisconnectedSpotifyID(ctx, "spotify123")
+	assert.NoError(err)
+	assert.Equal(createdUser.ID, user.ID)
+	assert.Equal("spotify123", user.DisconnectedSpotifyID)
+
+	// Users that never disconnected an account are not matched by an empty id
+	_, err = repo.GetByDisconnectedSpotifyID(ctx, "")
+	assert.Equal(repositories.ErrUseNotFound, err)
+}
+
+func TestUserRepositoryPocketbase_Roles(t *testing.T) {
+	assert := assert.New(t)
+	app := NewTestApp(t)
+	SetupUsersCollection(t, app)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	// Users are created with the user role
+	createdUser, err := repo.Create(ctx, testfixtures.NewUser().WithEmail("test@example.com").Build())
+	assert.NoError(err)
+	assert.Equal([]models.UserRole{models.RoleUser}, createdUser.Roles)
+
+	admin := testfixtures.SeedUser(t, app, testfixtures.NewUser().
+		WithEmail("admin@example.com").
+		WithUsername("admin").
+		WithRoles(models.RoleUser, models.RoleAdmin).
+		Build())
+
+	retrievedAdmin, err := repo.GetByID(ctx, admin.ID)
+	assert.NoError(err)
+	assert.True(retrievedAdmin.HasRole(models.RoleAdmin))
+
+	// Updates leave the roles alone
+	retrievedAdmin.Roles = nil
+	retrievedAdmin.Name = "Renamed Admin"
+	_, err = repo.Update(ctx, retrievedAdmin)
+	assert.NoError(err)
+
+	retrievedAdmin, err = repo.GetByID(ctx, admin.ID)
+	assert.NoError(err)
+	assert.Equal([]models.UserRole{models.RoleUser, models.RoleAdmin}, retrievedAdmin.Roles)
+}
+
+func TestEnsureUserFields_AdminFieldsReadOnly(t *testing.T) {
+	assert := require.New(t)
+	app := NewTestApp(t)
+	SetupUsersCollection(t, app)
+
+	// Running the setup again doesn't stack the guard
+	assert.NoError(ensureUserFields(app))
+
+	users, err := app.FindCollectionByNameOrId(string(CollectionUsers))
+	assert.NoError(err)
+
+	for _, rule := range []*string{users.CreateRule, users.UpdateRule} {
+		if rule == nil {
+			continue
+		}
+		assert.Equal(1, strings.Count(*rule, "@request.body.roles:isset = false"))
+		assert.Equal(1, strings.Count(*rule, "@request.body.disabled:isset = false"))
+	}
+}
+
 func TestUserRepositoryPocketbase_GenerateAuthToken_Success(t *testing.T) {
 	assert := assert.New(t)
 	app := NewTestApp(t)
@@ -192,13 +267,8 @@ func TestUserRepositoryPocketbase_GenerateAuthToken_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a test user first
-	testUser := &models.User{
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-	}
-	createdUser, err := createUserInDB(t, app, testUser)
-	assert.NoError(err)
+	testUser := testfixtures.NewUser().WithEmail("test@example.com").WithUsername("testuser").WithName("Test User").Build()
+	createdUser := testfixtures.SeedUser(t, app, testUser)
 
 	// Now test GenerateAuthToken
 	token, err := repo.GenerateAuthToken(ctx, createdUser.ID)
@@ -233,13 +303,8 @@ func TestUserRepositoryPocketbase_ValidateAuthToken_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// Create a test user first
-	testUser := &models.User{
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-	}
-	createdUser, err := createUserInDB(t, app, testUser)
-	assert.NoError(err)
+	testUser := testfixtures.NewUser().WithEmail("test@example.com").WithUsername("testuser").WithName("Test User").Build()
+	createdUser := testfixtures.SeedUser(t, app, testUser)
 
 	// Generate a token for the user
 	token, err := repo.GenerateAuthToken(ctx, createdUser.ID)
@@ -287,25 +352,173 @@ func TestUserRepositoryPocketbase_ValidateAuthToken_Errors(t *testing.T) {
 	}
 }
 
-func createUserInDB(t *testing.T, app *pocketbase.PocketBase, user *models.User) (*models.User, error) {
-	t.Helper()
-	assert := require.New(t)
+func TestUserRepositoryPocketbase_ValidateAuthToken_Forged(t *testing.T) {
+	assert := assert.New(t)
+	app := NewTestApp(t)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
 
-	collection, err := app.FindCollectionByNameOrId(string(CollectionUsers))
+	createdUser := testfixtures.SeedUser(t, app, testfixtures.NewUser().Build())
+	token, err := repo.GenerateAuthToken(ctx, createdUser.ID)
 	assert.NoError(err)
 
-	userRecord := core.NewRecord(collection)
-	userRecord.Set("email", user.Email)
-	userRecord.Set("username", user.Username)
-	userRecord.Set("name", user.Name)
-	userRecord.Set("password", "systemuser123")
+	// Same claims, different signature
+	parts := strings.Split(token, ".")
+	forged := parts[0] + "." + parts[1] + ".c2lnbmF0dXJl"
 
-	err = app.Save(userRecord)
-	if err != nil {
-		return nil, err
+	validatedUser, err := repo.ValidateAuthToken(ctx, forged)
+
+	assert.Nil(validatedUser)
+	assert.Equal(repositories.ErrUseNotFound, err)
+}
+
+func TestUserRepositoryPocketbase_RevokeAuthTokens(t *testing.T) {
+	assert := assert.New(t)
+	app := NewTestApp(t)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	createdUser := testfixtures.SeedUser(t, app, testfixtures.NewUser().Build())
+	revokedToken, err := repo.GenerateAuthToken(ctx, createdUser.ID)
+	assert.NoError(err)
+
+	err = repo.RevokeAuthTokens(ctx, createdUser.ID)
+	assert.NoError(err)
+
+	// Tokens issued before the revocation are rejected, new ones are accepted
+	_, err = repo.ValidateAuthToken(ctx, revokedToken)
+	assert.Equal(repositories.ErrUseNotFound, err)
+
+	newToken, err := repo.GenerateAuthToken(ctx, createdUser.ID)
+	assert.NoError(err)
+
+	validatedUser, err := repo.ValidateAuthToken(ctx, newToken)
+	assert.NoError(err)
+	assert.Equal(createdUser.ID, validatedUser.ID)
+}
+
+func TestUserRepositoryPocketbase_RevokeAuthTokens_UserNotFound(t *testing.T) {
+	assert := assert.New(t)
+	app := NewTestApp(t)
+	repo := NewUserRepositoryPocketbase(app)
+
+	err := repo.RevokeAuthTokens(context.Background(), "nonexistent-id")
+
+	assert.Equal(repositories.ErrUseNotFound, err)
+}
+
+func TestUserRepositoryPocketbase_Search(t *testing.T) {
+	app := NewTestApp(t)
+	SetupUsersCollection(t, app)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	alice := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("alice@example.com").WithUsername("alice").WithName("Alice").Build())
+	bob := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("bob@example.com").WithUsername("bob").WithName("Bob").Build())
+
+	tests := []struct {
+		name          string
+		query         string
+		limit         int
+		offset        int
+		expectedCount int
+		expectedIDs   []string
+	}{
+		{name: "empty query lists every user", query: "", limit: 10, expectedCount: 2},
+		{name: "matches part of the email", query: "alice@", limit: 10, expectedIDs: []string{alice.ID}},
+		{name: "matches the name", query: "Bob", limit: 10, expectedIDs: []string{bob.ID}},
+		{name: "matches the id", query: bob.ID, limit: 10, expectedIDs: []string{bob.ID}},
+		{name: "no match", query: "carol", limit: 10, expectedIDs: []string{}},
+		{name: "paginates", query: "", limit: 1, offset: 1, expectedCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			users, err := repo.Search(ctx, tt.query, tt.limit, tt.offset)
+			assert.NoError(err)
+
+			if tt.expectedIDs == nil {
+				assert.Len(users, tt.expectedCount)
+				return
+			}
+
+			ids := make([]string, len(users))
+			for i, user := range users {
+				ids[i] = user.ID
+			}
+			assert.Equal(tt.expectedIDs, ids)
+		})
 	}
+}
+
+func TestUserRepositoryPocketbase_SetDisabled(t *testing.T) {
+	assert := require.New(t)
+	app := NewTestApp(t)
+	SetupUsersCollection(t, app)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	createdUser := testfixtures.SeedUser(t, app, testfixtures.NewUser().Build())
+	token, err := repo.GenerateAuthToken(ctx, createdUser.ID)
+	assert.NoError(err)
+
+	disabledUser, err := repo.SetDisabled(ctx, createdUser.ID, true)
+	assert.NoError(err)
+	assert.True(disabledUser.Disabled)
+
+	// Disabled users keep no session and can't start a new one
+	_, err = repo.ValidateAuthToken(ctx, token)
+	assert.Equal(repositories.ErrUseNotFound, err)
+
+	_, err = repo.GenerateAuthToken(ctx, createdUser.ID)
+	assert.Equal(repositories.ErrUserDisabled, err)
+
+	enabledUser, err := repo.SetDisabled(ctx, createdUser.ID, false)
+	assert.NoError(err)
+	assert.False(enabledUser.Disabled)
+
+	newToken, err := repo.GenerateAuthToken(ctx, createdUser.ID)
+	assert.NoError(err)
+
+	validatedUser, err := repo.ValidateAuthToken(ctx, newToken)
+	assert.NoError(err)
+	assert.Equal(createdUser.ID, validatedUser.ID)
+}
+
+func TestUserRepositoryPocketbase_SetDisabled_UserNotFound(t *testing.T) {
+	assert := require.New(t)
+	app := NewTestApp(t)
+	repo := NewUserRepositoryPocketbase(app)
+
+	user, err := repo.SetDisabled(context.Background(), "nonexistent-id", true)
+
+	assert.Nil(user)
+	assert.Equal(repositories.ErrUseNotFound, err)
+}
+
+func TestUserRepositoryPocketbase_ValidateAuthToken_DisabledUser(t *testing.T) {
+	assert := require.New(t)
+	app := NewTestApp(t)
+	SetupUsersCollection(t, app)
+	repo := NewUserRepositoryPocketbase(app)
+	ctx := context.Background()
+
+	createdUser := testfixtures.SeedUser(t, app, testfixtures.NewUser().Build())
+	token, err := repo.GenerateAuthToken(ctx, createdUser.ID)
+	assert.NoError(err)
+
+	// Disabled from the dashboard, which leaves the token key alone
+	record, err := app.FindRecordById(string(CollectionUsers), createdUser.ID)
+	assert.NoError(err)
+	record.Set("disabled", true)
+	assert.NoError(app.Save(record))
+
+	validatedUser, err := repo.ValidateAuthToken(ctx, token)
 
-	return recordToUser(userRecord), nil
+	assert.Nil(validatedUser)
+	assert.Equal(repositories.ErrUserDisabled, err)
 }
 
 // findUserInDB is a helper function to verify an user exists in the database
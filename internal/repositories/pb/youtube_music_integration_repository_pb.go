@@ -0,0 +1,238 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+type YouTubeMusicIntegrationRepositoryPocketbase struct {
+	collection  Collection
+	app         *pocketbase.PocketBase
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
+}
+
+func NewYouTubeMusicIntegrationRepositoryPocketbase(pb *pocketbase.PocketBase) *YouTubeMusicIntegrationRepositoryPocketbase {
+	return &YouTubeMusicIntegrationRepositoryPocketbase{
+		app:        pb,
+		collection: CollectionYouTubeMusicIntegration,
+		log:        pb.Logger().With("component", "YouTubeMusicIntegrationRepositoryPocketbase"),
+	}
+}
+
+// WithTokenCipher encrypts the stored Google tokens with the data key of their user
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) WithTokenCipher(tokenCipher repositories.TokenCipher) *YouTubeMusicIntegrationRepositoryPocketbase {
+	ymRepo.tokenCipher = tokenCipher
+	return ymRepo
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) CreateOrUpdate(
+	ctx context.Context,
+	userId string,
+	integration *models.YouTubeMusicIntegration,
+) (*models.YouTubeMusicIntegration, error) {
+	collection, err := ymRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var record *core.Record
+	existing, err := appFromContext(ctx, ymRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		ymRepo.log.InfoContext(ctx, "youtube_music_integration not found", "user", userId)
+		record = core.NewRecord(collection)
+		record.Set("user", userId)
+	} else {
+		ymRepo.log.InfoContext(ctx, "youtube_music_integration found", "user", userId, "record", existing.Id)
+		record = existing
+	}
+
+	accessToken, err := ymRepo.encryptToken(ctx, userId, integration.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := ymRepo.encryptToken(ctx, userId, integration.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Set("channel_id", integration.ChannelID)
+	record.Set("access_token", accessToken)
+	record.Set("refresh_token", refreshToken)
+	record.Set("token_type", integration.TokenType)
+	record.Set("expires_at", integration.ExpiresAt)
+	record.Set("scope", integration.Scope)
+	record.Set("display_name", integration.DisplayName)
+
+	if err := appFromContext(ctx, ymRepo.app).Save(record); err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to store youtube_music_integration record", "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	ymRepo.log.InfoContext(ctx, "youtube_music_integration stored successfully", "user", userId, "channel_id", integration.ChannelID)
+	return ymRepo.toYouTubeMusicIntegration(ctx, record)
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) GetByUserID(ctx context.Context, userId string) (*models.YouTubeMusicIntegration, error) {
+	collection, err := ymRepo.getCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := appFromContext(ctx, ymRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		ymRepo.log.InfoContext(ctx, "unable to fetch youtube_music_integration", "user", userId, "error", err)
+		return nil, repositories.ErrYouTubeMusicIntegrationNotFound
+	}
+
+	return ymRepo.toYouTubeMusicIntegration(ctx, record)
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) UpdateTokens(
+	ctx context.Context,
+	integrationId string,
+	tokens *models.YouTubeMusicIntegrationTokenRefresh,
+) error {
+	collection, err := ymRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, ymRepo.app).FindRecordById(collection, integrationId)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to fetch youtube_music_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrYouTubeMusicIntegrationNotFound
+	}
+
+	userId := record.GetString("user")
+
+	accessToken, err := ymRepo.encryptToken(ctx, userId, tokens.AccessToken)
+	if err != nil {
+		return err
+	}
+	record.Set("access_token", accessToken)
+
+	// Google only returns a new refresh token when the old one is revoked
+	if tokens.RefreshToken != "" {
+		refreshToken, err := ymRepo.encryptToken(ctx, userId, tokens.RefreshToken)
+		if err != nil {
+			return err
+		}
+		record.Set("refresh_token", refreshToken)
+	}
+
+	record.Set("expires_at", time.Now().Add(time.Duration(tokens.ExpiresIn)*time.Second))
+
+	if err := appFromContext(ctx, ymRepo.app).Save(record); err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to update youtube_music_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	ymRepo.log.InfoContext(ctx, "youtube_music_integration tokens updated", "integration_id", integrationId)
+	return nil
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) Delete(ctx context.Context, userId string) error {
+	collection, err := ymRepo.getCollection(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := appFromContext(ctx, ymRepo.app).FindFirstRecordByFilter(
+		collection,
+		"user = {:user}",
+		dbx.Params{"user": userId},
+	)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "youtube_music_integration not found", "user", userId, "error", err)
+		return repositories.ErrYouTubeMusicIntegrationNotFound
+	}
+
+	if err := appFromContext(ctx, ymRepo.app).Delete(record); err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to delete youtube_music_integration", "user", userId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	ymRepo.log.InfoContext(ctx, "youtube_music_integration deleted", "user", userId)
+	return nil
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) getCollection(ctx context.Context) (*core.Collection, error) {
+	collection, err := appFromContext(ctx, ymRepo.app).FindCollectionByNameOrId(string(ymRepo.collection))
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to find collection", "collection", ymRepo.collection, "error", err)
+		return nil, repositories.ErrCollectionNotFound
+	}
+
+	return collection, nil
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) encryptToken(ctx context.Context, userId, token string) (string, error) {
+	if ymRepo.tokenCipher == nil || token == "" {
+		return token, nil
+	}
+
+	encryptedToken, err := ymRepo.tokenCipher.EncryptForUser(ctx, userId, token)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to encrypt youtube music token", "user", userId, "error", err)
+		return "", fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	return encryptedToken, nil
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPocketbase) toYouTubeMusicIntegration(ctx context.Context, record *core.Record) (*models.YouTubeMusicIntegration, error) {
+	integration := recordToYouTubeMusicIntegration(record)
+	if ymRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	accessToken, err := ymRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.AccessToken)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to decrypt youtube music access token", "user", integration.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	refreshToken, err := ymRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.RefreshToken)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to decrypt youtube music refresh token", "user", integration.UserID, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+	}
+
+	integration.AccessToken = accessToken
+	integration.RefreshToken = refreshToken
+	return integration, nil
+}
+
+func recordToYouTubeMusicIntegration(record *core.Record) *models.YouTubeMusicIntegration {
+	return &models.YouTubeMusicIntegration{
+		ID:           record.Id,
+		UserID:       record.GetString("user"),
+		ChannelID:    record.GetString("channel_id"),
+		AccessToken:  record.GetString("access_token"),
+		RefreshToken: record.GetString("refresh_token"),
+		TokenType:    record.GetString("token_type"),
+		ExpiresAt:    record.GetDateTime("expires_at").Time(),
+		Scope:        record.GetString("scope"),
+		DisplayName:  record.GetString("display_name"),
+		Created:      record.GetDateTime("created").Time(),
+		Updated:      record.GetDateTime("updated").Time(),
+	}
+}
@@ -0,0 +1,121 @@
+package pb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestYouTubeMusicIntegration(channelID string) *models.YouTubeMusicIntegration {
+	return &models.YouTubeMusicIntegration{
+		ChannelID:    channelID,
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(1 * time.Hour),
+		Scope:        "https://www.googleapis.com/auth/youtube",
+		DisplayName:  "My Channel",
+	}
+}
+
+func TestYouTubeMusicIntegrationRepositoryPocketbase_CreateOrUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupYouTubeMusicIntegrationCollection(t, app)
+	repo := NewYouTubeMusicIntegrationRepositoryPocketbase(app)
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("youtube@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	created, err := repo.CreateOrUpdate(ctx, userID, newTestYouTubeMusicIntegration("UC123"))
+	assert.NoError(err)
+	assert.NotEmpty(created.ID)
+	assert.Equal(userID, created.UserID)
+	assert.Equal("UC123", created.ChannelID)
+	assert.Equal("access_token_123", created.AccessToken)
+	assert.Equal("My Channel", created.DisplayName)
+
+	// Linking another account replaces the one of the user
+	updated, err := repo.CreateOrUpdate(ctx, userID, newTestYouTubeMusicIntegration("UC456"))
+	assert.NoError(err)
+	assert.Equal(created.ID, updated.ID)
+	assert.Equal("UC456", updated.ChannelID)
+
+	retrieved, err := repo.GetByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Equal("UC456", retrieved.ChannelID)
+}
+
+func TestYouTubeMusicIntegrationRepositoryPocketbase_GetByUserID_NotFound(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupYouTubeMusicIntegrationCollection(t, app)
+	repo := NewYouTubeMusicIntegrationRepositoryPocketbase(app)
+
+	result, err := repo.GetByUserID(context.Background(), "nonexistent")
+
+	assert.ErrorIs(err, repositories.ErrYouTubeMusicIntegrationNotFound)
+	assert.Nil(result)
+}
+
+func TestYouTubeMusicIntegrationRepositoryPocketbase_UpdateTokens(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupYouTubeMusicIntegrationCollection(t, app)
+	repo := NewYouTubeMusicIntegrationRepositoryPocketbase(app).WithTokenCipher(prefixTokenCipher{})
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("youtube@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	created, err := repo.CreateOrUpdate(ctx, userID, newTestYouTubeMusicIntegration("UC123"))
+	assert.NoError(err)
+
+	// Stored values are encrypted
+	record, err := app.FindRecordById(string(CollectionYouTubeMusicIntegration), created.ID)
+	assert.NoError(err)
+	assert.Equal(userID+":access_token_123", record.GetString("access_token"))
+
+	err = repo.UpdateTokens(ctx, created.ID, &models.YouTubeMusicIntegrationTokenRefresh{
+		AccessToken: "access_token_456",
+		ExpiresIn:   3600,
+	})
+	assert.NoError(err)
+
+	retrieved, err := repo.GetByUserID(ctx, userID)
+	assert.NoError(err)
+	assert.Equal("access_token_456", retrieved.AccessToken)
+	assert.Equal("refresh_token_123", retrieved.RefreshToken)
+	assert.WithinDuration(time.Now().Add(time.Hour), retrieved.ExpiresAt, time.Minute)
+
+	err = repo.UpdateTokens(ctx, "nonexistent", &models.YouTubeMusicIntegrationTokenRefresh{AccessToken: "token"})
+	assert.ErrorIs(err, repositories.ErrYouTubeMusicIntegrationNotFound)
+}
+
+func TestYouTubeMusicIntegrationRepositoryPocketbase_Delete(t *testing.T) {
+	assert := require.New(t)
+
+	app := NewTestApp(t)
+	SetupYouTubeMusicIntegrationCollection(t, app)
+	repo := NewYouTubeMusicIntegrationRepositoryPocketbase(app)
+
+	userID := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("youtube@test.com").WithName("Test User").Build()).ID
+	ctx := context.Background()
+
+	_, err := repo.CreateOrUpdate(ctx, userID, newTestYouTubeMusicIntegration("UC123"))
+	assert.NoError(err)
+
+	assert.NoError(repo.Delete(ctx, userID))
+
+	_, err = repo.GetByUserID(ctx, userID)
+	assert.ErrorIs(err, repositories.ErrYouTubeMusicIntegrationNotFound)
+
+	assert.ErrorIs(repo.Delete(ctx, userID), repositories.ErrYouTubeMusicIntegrationNotFound)
+}
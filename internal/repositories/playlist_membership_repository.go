@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=playlist_membership_repository.go -destination=mocks/mock_playlist_membership_repository.go -package=mocks
+
+type PlaylistMembershipRepository interface {
+	// Upsert replaces the stored membership of the child playlist, there is at most one per child
+	Upsert(ctx context.Context, membership *models.PlaylistMembership) (*models.PlaylistMembership, error)
+	GetByChildPlaylistID(ctx context.Context, childPlaylistID, userID string) (*models.PlaylistMembership, error)
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=playlist_snapshot_repository.go -destination=mocks/mock_playlist_snapshot_repository.go -package=mocks
+
+type PlaylistSnapshotRepository interface {
+	Create(ctx context.Context, snapshot *models.PlaylistSnapshot) (*models.PlaylistSnapshot, error)
+	GetBySyncEventID(ctx context.Context, syncEventID, userID string) ([]*models.PlaylistSnapshot, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=playlist_webhook_repository.go -destination=mocks/mock_playlist_webhook_repository.go -package=mocks
+
+type PlaylistWebhookRepository interface {
+	Create(ctx context.Context, webhook *models.PlaylistWebhook) (*models.PlaylistWebhook, error)
+	GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.PlaylistWebhook, error)
+	// GetActive returns the active webhooks of every user, for the change poller
+	GetActive(ctx context.Context) ([]*models.PlaylistWebhook, error)
+	Delete(ctx context.Context, id, userID string) error
+	RecordDelivery(ctx context.Context, id string, status int, deliveredAt time.Time) error
+}
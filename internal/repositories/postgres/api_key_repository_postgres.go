@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const apiKeyColumns = "id, user_id, name, key_hash, key_prefix, scopes, last_used_at, expires_at, created, updated"
+
+type APIKeyRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewAPIKeyRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *APIKeyRepositoryPostgres {
+	return &APIKeyRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "APIKeyRepositoryPostgres"),
+	}
+}
+
+func (akRepo *APIKeyRepositoryPostgres) Create(ctx context.Context, apiKey *models.APIKey) (*models.APIKey, error) {
+	scopes, err := toJSON(apiKey.Scopes)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	row := conn(ctx, akRepo.pool).QueryRow(ctx,
+		`INSERT INTO api_keys (id, user_id, name, key_hash, key_prefix, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+apiKeyColumns,
+		newID(), apiKey.UserID, apiKey.Name, apiKey.KeyHash, apiKey.KeyPrefix, scopes, timeOrNil(apiKey.ExpiresAt),
+	)
+
+	stored, err := scanAPIKey(row)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to store api_key record", "user_id", apiKey.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	akRepo.log.InfoContext(ctx, "api_key stored successfully", "id", stored.ID, "user_id", apiKey.UserID)
+	return stored, nil
+}
+
+func (akRepo *APIKeyRepositoryPostgres) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	if keyHash == "" {
+		return nil, repositories.ErrAPIKeyNotFound
+	}
+
+	row := conn(ctx, akRepo.pool).QueryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", keyHash)
+	apiKey, err := scanAPIKey(row)
+	if err != nil {
+		return nil, repositories.ErrAPIKeyNotFound
+	}
+
+	return apiKey, nil
+}
+
+func (akRepo *APIKeyRepositoryPostgres) GetByUserID(ctx context.Context, userID string) ([]*models.APIKey, error) {
+	apiKeys, err := queryRows(ctx, akRepo.pool, scanAPIKey,
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE user_id = $1 ORDER BY created DESC", // Newest first
+		userID,
+	)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to find api_key records", "user_id", userID, "error", err)
+		return nil, dbError(err)
+	}
+
+	return apiKeys, nil
+}
+
+func (akRepo *APIKeyRepositoryPostgres) Delete(ctx context.Context, id, userID string) error {
+	ownerID, err := findOwner(ctx, akRepo.pool, "api_keys", id)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to find api_key record", "id", id, "error", err)
+		return repositories.ErrAPIKeyNotFound
+	}
+
+	// Check ownership
+	if ownerID != userID {
+		akRepo.log.ErrorContext(ctx, "unauthorized delete attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", ownerID,
+		)
+		return repositories.ErrUnauthorized
+	}
+
+	if _, err := conn(ctx, akRepo.pool).Exec(ctx, "DELETE FROM api_keys WHERE id = $1", id); err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to delete api_key record", "id", id, "error", err)
+		return dbError(err)
+	}
+
+	akRepo.log.InfoContext(ctx, "api_key deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func (akRepo *APIKeyRepositoryPostgres) UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error {
+	tag, err := conn(ctx, akRepo.pool).Exec(ctx, "UPDATE api_keys SET last_used_at = $2, updated = now() WHERE id = $1", id, lastUsedAt)
+	if err != nil {
+		akRepo.log.ErrorContext(ctx, "unable to update api_key record", "id", id, "error", err)
+		return dbError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		akRepo.log.ErrorContext(ctx, "unable to find api_key record", "id", id)
+		return repositories.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	apiKey := &models.APIKey{}
+	var scopes []byte
+	err := row.Scan(
+		&apiKey.ID, &apiKey.UserID, &apiKey.Name, &apiKey.KeyHash, &apiKey.KeyPrefix, &scopes, &apiKey.LastUsedAt,
+		&apiKey.ExpiresAt, &apiKey.Created, &apiKey.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fromJSON(scopes, &apiKey.Scopes) || apiKey.Scopes == nil {
+		apiKey.Scopes = []models.APIKeyScope{}
+	}
+
+	return apiKey, nil
+}
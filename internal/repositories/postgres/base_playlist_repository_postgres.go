@@ -0,0 +1,392 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const basePlaylistColumns = `id, user_id, name, spotify_playlist_id, is_active, dedupe_strategy, hook_token, suspended,
+	archived, spotify_integration_id, provider, created, updated`
+
+type BasePlaylistRepositoryPostgres struct {
+	pool     *pgxpool.Pool
+	readPool *pgxpool.Pool
+	log      *slog.Logger
+}
+
+func NewBasePlaylistRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *BasePlaylistRepositoryPostgres {
+	return &BasePlaylistRepositoryPostgres{
+		pool:     pool,
+		readPool: pool,
+		log:      logger.With("component", "BasePlaylistRepositoryPostgres"),
+	}
+}
+
+// WithReadPool routes the list queries of the repository through a dedicated read pool, a nil
+// pool keeps them on the primary one
+func (bpRepo *BasePlaylistRepositoryPostgres) WithReadPool(readPool *pgxpool.Pool) *BasePlaylistRepositoryPostgres {
+	if readPool != nil {
+		bpRepo.readPool = readPool
+	}
+	return bpRepo
+}
+
+func (bpRepo *BasePlaylistRepositoryPostgres) Create(ctx context.Context, userId, name, spotifyPlaylistId string, dedupeStrategy models.DedupeStrategy, spotifyIntegrationId string, provider models.MusicProvider) (*models.BasePlaylist, error) {
+	if dedupeStrategy == "" {
+		dedupeStrategy = models.DedupeStrategyAllMatches
+	}
+
+	row := conn(ctx, bpRepo.pool).QueryRow(ctx,
+		`INSERT INTO base_playlists (id, user_id, name, spotify_playlist_id, is_active, dedupe_strategy, spotify_integration_id, provider)
+		VALUES ($1, $2, $3, $4, TRUE, $5, $6, $7)
+		RETURNING `+basePlaylistColumns,
+		newID(), userId, name, spotifyPlaylistId, string(dedupeStrategy), spotifyIntegrationId, string(provider.OrDefault()),
+	)
+
+	basePlaylist, err := scanBasePlaylist(row)
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to store base_playlist record", "user_id", userId, "spotify_playlist_id", spotifyPlaylistId, "error", err)
+		return nil, dbError(err)
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlist stored successfully", "id", basePlaylist.ID)
+	return basePlaylist, nil
+}
+
+// Delete soft deletes the base playlist and its child playlists, stamping them with the same
+// deleted_at so Restore can tell them apart from children deleted on their own
+func (bpRepo *BasePlaylistRepositoryPostgres) Delete(ctx context.Context, id, userId string) error {
+	err := pgx.BeginFunc(ctx, conn(ctx, bpRepo.pool), func(tx pgx.Tx) error {
+		var ownerID string
+		err := tx.QueryRow(ctx, "SELECT user_id FROM base_playlists WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&ownerID)
+		if isNoRows(err) {
+			return repositories.ErrBasePlaylistNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+
+		// Check ownership
+		if ownerID != userId {
+			bpRepo.log.ErrorContext(ctx, "unauthorized delete attempt",
+				"id", id,
+				"requested_by", userId,
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		// now() is the start of the transaction, the same instant for the base playlist and its
+		// children and, at microsecond precision, not shared with a child deleted on its own
+		if _, err := tx.Exec(ctx, "UPDATE base_playlists SET deleted_at = now(), updated = now() WHERE id = $1", id); err != nil {
+			return dbError(err)
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE child_playlists SET deleted_at = now(), updated = now() WHERE base_playlist_id = $1 AND deleted_at IS NULL", id)
+		if err != nil {
+			return dbError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to delete base_playlist record", "id", id, "error", err)
+		return err
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlist deleted successfully", "id", id, "user_id", userId)
+	return nil
+}
+
+// Restore clears the deleted_at of the base playlist and of the child playlists deleted with it.
+// Restoring a base playlist that is not deleted returns it as is
+func (bpRepo *BasePlaylistRepositoryPostgres) Restore(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	var restored *models.BasePlaylist
+	err := pgx.BeginFunc(ctx, conn(ctx, bpRepo.pool), func(tx pgx.Tx) error {
+		var deletedAt *time.Time
+		row := tx.QueryRow(ctx, "SELECT "+basePlaylistColumns+", deleted_at FROM base_playlists WHERE id = $1 FOR UPDATE", id)
+		basePlaylist, err := scanBasePlaylist(row, &deletedAt)
+		if isNoRows(err) {
+			return repositories.ErrBasePlaylistNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+
+		// Check ownership
+		if basePlaylist.UserID != userId {
+			bpRepo.log.ErrorContext(ctx, "unauthorized restore attempt",
+				"id", id,
+				"requested_by", userId,
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		restored = basePlaylist
+		if deletedAt == nil {
+			return nil
+		}
+
+		// The spotify playlist may have been added again as a new base playlist meanwhile
+		var conflict bool
+		err = tx.QueryRow(ctx,
+			"SELECT EXISTS (SELECT 1 FROM base_playlists WHERE user_id = $1 AND spotify_playlist_id = $2 AND deleted_at IS NULL)",
+			userId, basePlaylist.SpotifyPlaylistID,
+		).Scan(&conflict)
+		if err != nil {
+			return dbError(err)
+		}
+		if conflict {
+			return repositories.ErrRestoreConflict
+		}
+
+		row = tx.QueryRow(ctx, "UPDATE base_playlists SET deleted_at = NULL, updated = now() WHERE id = $1 RETURNING "+basePlaylistColumns, id)
+		if restored, err = scanBasePlaylist(row); err != nil {
+			return dbError(err)
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE child_playlists SET deleted_at = NULL, updated = now() WHERE base_playlist_id = $1 AND deleted_at = $2", id, *deletedAt)
+		if err != nil {
+			return dbError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to restore base_playlist record", "id", id, "error", err)
+		return nil, err
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlist restored successfully", "id", id, "user_id", userId)
+	return restored, nil
+}
+
+// Purge permanently deletes the base playlists soft deleted before the cutoff. Their children and
+// history cascade, and they are dropped from the sources of the merge child playlists of others
+func (bpRepo *BasePlaylistRepositoryPostgres) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+	err := pgx.BeginFunc(ctx, conn(ctx, bpRepo.pool), func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "DELETE FROM base_playlists WHERE deleted_at < $1 RETURNING id", deletedBefore)
+		if err != nil {
+			return err
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return err
+		}
+
+		purged = len(ids)
+		if purged == 0 {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx,
+			`UPDATE child_playlists
+			SET source_base_playlist_ids = ARRAY(SELECT source FROM unnest(source_base_playlist_ids) AS source WHERE source <> ALL($1)),
+				updated = now()
+			WHERE source_base_playlist_ids && $1`,
+			ids,
+		)
+		return err
+	})
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to purge base_playlist records", "deleted_before", deletedBefore, "error", err)
+		return 0, dbError(err)
+	}
+
+	bpRepo.log.InfoContext(ctx, "deleted base_playlists purged", "deleted_before", deletedBefore, "purged", purged)
+	return purged, nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPostgres) GetByID(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	row := conn(ctx, bpRepo.pool).QueryRow(ctx, "SELECT "+basePlaylistColumns+" FROM base_playlists WHERE id = $1 AND deleted_at IS NULL", id)
+	basePlaylist, err := scanBasePlaylist(row)
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to find base_playlist record", "id", id, "error", err)
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+
+	// Check ownership
+	if basePlaylist.UserID != userId {
+		bpRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"requested_by", userId,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlist retrieved successfully", "id", id)
+	return basePlaylist, nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPostgres) GetByUserID(ctx context.Context, userId string) ([]*models.BasePlaylist, error) {
+	basePlaylists, err := bpRepo.query(ctx,
+		"SELECT "+basePlaylistColumns+" FROM base_playlists WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created DESC", // Newest first
+		userId,
+	)
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to find base_playlist records for user", "user_id", userId, "error", err)
+		return nil, dbError(err)
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlists retrieved successfully", "user_id", userId, "count", len(basePlaylists))
+	return basePlaylists, nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPostgres) List(ctx context.Context, filter repositories.BasePlaylistFilter) ([]*models.BasePlaylist, error) {
+	where, args := basePlaylistFilterCondition(filter)
+	basePlaylists, err := bpRepo.query(ctx,
+		"SELECT "+basePlaylistColumns+" FROM base_playlists WHERE "+where+" ORDER BY "+listOrderBy(filter.Sort)+limitOffset(filter.Limit, filter.Offset),
+		args...,
+	)
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to list base_playlist records", "filter", filter, "error", err)
+		return nil, dbError(err)
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlists listed successfully", "filter", filter, "count", len(basePlaylists))
+	return basePlaylists, nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPostgres) Count(ctx context.Context, filter repositories.BasePlaylistFilter) (int, error) {
+	where, args := basePlaylistFilterCondition(filter)
+
+	var total int
+	if err := conn(ctx, bpRepo.readPool).QueryRow(ctx, "SELECT count(*) FROM base_playlists WHERE "+where, args...).Scan(&total); err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to count base_playlist records", "filter", filter, "error", err)
+		return 0, dbError(err)
+	}
+
+	return total, nil
+}
+
+func (bpRepo *BasePlaylistRepositoryPostgres) Update(ctx context.Context, id, userId string, fields repositories.UpdateBasePlaylistFields) (*models.BasePlaylist, error) {
+	var dedupeStrategy *string
+	if fields.DedupeStrategy != nil {
+		value := string(*fields.DedupeStrategy)
+		dedupeStrategy = &value
+	}
+
+	var basePlaylist *models.BasePlaylist
+	err := pgx.BeginFunc(ctx, conn(ctx, bpRepo.pool), func(tx pgx.Tx) error {
+		var ownerID string
+		err := tx.QueryRow(ctx, "SELECT user_id FROM base_playlists WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&ownerID)
+		if isNoRows(err) {
+			return repositories.ErrBasePlaylistNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+
+		// Check ownership
+		if ownerID != userId {
+			bpRepo.log.ErrorContext(ctx, "unauthorized update attempt",
+				"id", id,
+				"requested_by", userId,
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		// Fields left nil keep their value
+		row := tx.QueryRow(ctx,
+			`UPDATE base_playlists SET
+				name = COALESCE($2, name),
+				spotify_playlist_id = COALESCE($3, spotify_playlist_id),
+				dedupe_strategy = COALESCE($4, dedupe_strategy),
+				hook_token = COALESCE($5, hook_token),
+				is_active = COALESCE($6, is_active),
+				suspended = COALESCE($7, suspended),
+				archived = COALESCE($8, archived),
+				updated = now()
+			WHERE id = $1
+			RETURNING `+basePlaylistColumns,
+			id, fields.Name, fields.SpotifyPlaylistID, dedupeStrategy, fields.HookToken, fields.IsActive, fields.Suspended, fields.Archived,
+		)
+		if basePlaylist, err = scanBasePlaylist(row); err != nil {
+			return dbError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		bpRepo.log.ErrorContext(ctx, "unable to update base_playlist record", "id", id, "error", err)
+		return nil, err
+	}
+
+	bpRepo.log.InfoContext(ctx, "base_playlist updated successfully", "id", id)
+	return basePlaylist, nil
+}
+
+// GetByHookToken finds the base playlist an automation hook token belongs to, without checking
+// ownership since the token is the credential
+func (bpRepo *BasePlaylistRepositoryPostgres) GetByHookToken(ctx context.Context, hookToken string) (*models.BasePlaylist, error) {
+	if hookToken == "" {
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+
+	row := conn(ctx, bpRepo.pool).QueryRow(ctx, "SELECT "+basePlaylistColumns+" FROM base_playlists WHERE hook_token = $1 AND deleted_at IS NULL LIMIT 1", hookToken)
+	basePlaylist, err := scanBasePlaylist(row)
+	if err != nil {
+		bpRepo.log.WarnContext(ctx, "unable to find base_playlist record by hook token", "error", err)
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+
+	return basePlaylist, nil
+}
+
+// query runs a list query on the read pool
+func (bpRepo *BasePlaylistRepositoryPostgres) query(ctx context.Context, sql string, args ...any) ([]*models.BasePlaylist, error) {
+	rows, err := conn(ctx, bpRepo.readPool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.BasePlaylist, error) {
+		return scanBasePlaylist(row)
+	})
+}
+
+// basePlaylistFilterCondition matches the live base playlists of filter, ignoring its pagination
+func basePlaylistFilterCondition(filter repositories.BasePlaylistFilter) (string, []any) {
+	where := "user_id = $1 AND deleted_at IS NULL"
+	args := []any{filter.UserID}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		where += fmt.Sprintf(" AND is_active = $%d", len(args))
+	}
+	if filter.Archived != nil {
+		args = append(args, *filter.Archived)
+		where += fmt.Sprintf(" AND archived = $%d", len(args))
+	}
+
+	return where, args
+}
+
+// scanBasePlaylist reads a row of basePlaylistColumns, followed by the extra columns given
+func scanBasePlaylist(row pgx.Row, extra ...any) (*models.BasePlaylist, error) {
+	basePlaylist := &models.BasePlaylist{}
+	var dedupeStrategy, provider string
+	dest := append([]any{
+		&basePlaylist.ID, &basePlaylist.UserID, &basePlaylist.Name, &basePlaylist.SpotifyPlaylistID, &basePlaylist.IsActive,
+		&dedupeStrategy, &basePlaylist.HookToken, &basePlaylist.Suspended, &basePlaylist.Archived,
+		&basePlaylist.SpotifyIntegrationID, &provider, &basePlaylist.Created, &basePlaylist.Updated,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	// Base playlists created before dedupe strategies existed route to all matches
+	basePlaylist.DedupeStrategy = models.DedupeStrategy(dedupeStrategy)
+	if basePlaylist.DedupeStrategy == "" {
+		basePlaylist.DedupeStrategy = models.DedupeStrategyAllMatches
+	}
+	basePlaylist.Provider = models.MusicProvider(provider).OrDefault()
+
+	return basePlaylist, nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const basePlaylistWatchColumns = "id, user_id, base_playlist_id, snapshot_id, track_uris, created, updated"
+
+type BasePlaylistWatchRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewBasePlaylistWatchRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *BasePlaylistWatchRepositoryPostgres {
+	return &BasePlaylistWatchRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "BasePlaylistWatchRepositoryPostgres"),
+	}
+}
+
+func (bwRepo *BasePlaylistWatchRepositoryPostgres) Upsert(ctx context.Context, watch *models.BasePlaylistWatch) (*models.BasePlaylistWatch, error) {
+	// The watch of a base playlist of another user is left untouched and nothing is returned
+	row := conn(ctx, bwRepo.pool).QueryRow(ctx,
+		`INSERT INTO base_playlist_watches (id, user_id, base_playlist_id, snapshot_id, track_uris)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (base_playlist_id) DO UPDATE SET
+			snapshot_id = EXCLUDED.snapshot_id,
+			track_uris = EXCLUDED.track_uris,
+			updated = now()
+		WHERE base_playlist_watches.user_id = EXCLUDED.user_id
+		RETURNING `+basePlaylistWatchColumns,
+		newID(), watch.UserID, watch.BasePlaylistID, watch.SnapshotID, stringsOrEmpty(watch.TrackURIs),
+	)
+
+	stored, err := scanBasePlaylistWatch(row)
+	if isNoRows(err) {
+		bwRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"base_playlist_id", watch.BasePlaylistID,
+			"user_id", watch.UserID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+	if err != nil {
+		bwRepo.log.ErrorContext(ctx, "unable to store base_playlist_watch record", "base_playlist_id", watch.BasePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	return stored, nil
+}
+
+func (bwRepo *BasePlaylistWatchRepositoryPostgres) GetByBasePlaylistID(ctx context.Context, basePlaylistID string) (*models.BasePlaylistWatch, error) {
+	row := conn(ctx, bwRepo.pool).QueryRow(ctx, "SELECT "+basePlaylistWatchColumns+" FROM base_playlist_watches WHERE base_playlist_id = $1", basePlaylistID)
+	watch, err := scanBasePlaylistWatch(row)
+	if err != nil {
+		return nil, repositories.ErrBasePlaylistWatchNotFound
+	}
+
+	return watch, nil
+}
+
+func scanBasePlaylistWatch(row pgx.Row) (*models.BasePlaylistWatch, error) {
+	watch := &models.BasePlaylistWatch{}
+	err := row.Scan(&watch.ID, &watch.UserID, &watch.BasePlaylistID, &watch.SnapshotID, &watch.TrackURIs, &watch.Created, &watch.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	watch.TrackURIs = stringsOrEmpty(watch.TrackURIs)
+	return watch, nil
+}
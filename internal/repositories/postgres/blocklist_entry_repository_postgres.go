@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const blocklistEntryColumns = "id, user_id, base_playlist_id, type, value, created, updated"
+
+type BlocklistEntryRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewBlocklistEntryRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *BlocklistEntryRepositoryPostgres {
+	return &BlocklistEntryRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "BlocklistEntryRepositoryPostgres"),
+	}
+}
+
+func (beRepo *BlocklistEntryRepositoryPostgres) Create(ctx context.Context, entry *models.BlocklistEntry) (*models.BlocklistEntry, error) {
+	row := conn(ctx, beRepo.pool).QueryRow(ctx,
+		`INSERT INTO blocklist_entries (id, user_id, base_playlist_id, type, value)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+blocklistEntryColumns,
+		newID(), entry.UserID, entry.BasePlaylistID, string(entry.Type), entry.Value,
+	)
+
+	stored, err := scanBlocklistEntry(row)
+	if err != nil {
+		beRepo.log.ErrorContext(ctx, "unable to store blocklist_entry record", "base_playlist_id", entry.BasePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	beRepo.log.InfoContext(ctx, "blocklist_entry stored successfully", "id", stored.ID, "base_playlist_id", entry.BasePlaylistID)
+	return stored, nil
+}
+
+func (beRepo *BlocklistEntryRepositoryPostgres) GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.BlocklistEntry, error) {
+	entries, err := queryRows(ctx, beRepo.pool, scanBlocklistEntry,
+		"SELECT "+blocklistEntryColumns+" FROM blocklist_entries WHERE base_playlist_id = $1 AND user_id = $2 ORDER BY created",
+		basePlaylistID, userID,
+	)
+	if err != nil {
+		beRepo.log.ErrorContext(ctx, "unable to find blocklist_entry records", "base_playlist_id", basePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	return entries, nil
+}
+
+func (beRepo *BlocklistEntryRepositoryPostgres) Delete(ctx context.Context, id, userID string) error {
+	ownerID, err := findOwner(ctx, beRepo.pool, "blocklist_entries", id)
+	if err != nil {
+		return repositories.ErrBlocklistEntryNotFound
+	}
+
+	// Check ownership
+	if ownerID != userID {
+		beRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", ownerID,
+		)
+		return repositories.ErrUnauthorized
+	}
+
+	if _, err := conn(ctx, beRepo.pool).Exec(ctx, "DELETE FROM blocklist_entries WHERE id = $1", id); err != nil {
+		beRepo.log.ErrorContext(ctx, "unable to delete blocklist_entry record", "id", id, "error", err)
+		return dbError(err)
+	}
+
+	beRepo.log.InfoContext(ctx, "blocklist_entry deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func scanBlocklistEntry(row pgx.Row) (*models.BlocklistEntry, error) {
+	entry := &models.BlocklistEntry{}
+	var entryType string
+	err := row.Scan(&entry.ID, &entry.UserID, &entry.BasePlaylistID, &entryType, &entry.Value, &entry.Created, &entry.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Type = models.BlocklistEntryType(entryType)
+	return entry, nil
+}
@@ -0,0 +1,374 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const childPlaylistColumns = `id, user_id, base_playlist_id, name, description, spotify_playlist_id, filter_rules, is_active,
+	is_fallback, priority, share_token, max_tracks, selection_strategy, sync_strategy, source_base_playlist_ids, pinned_tracks,
+	refollow_recreated, suspended, spotify_integration_id, provider, created, updated`
+
+type ChildPlaylistRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewChildPlaylistRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *ChildPlaylistRepositoryPostgres {
+	return &ChildPlaylistRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "ChildPlaylistRepositoryPostgres"),
+	}
+}
+
+func (cpRepo *ChildPlaylistRepositoryPostgres) Create(ctx context.Context, fields repositories.CreateChildPlaylistFields) (*models.ChildPlaylist, error) {
+	filterRules, err := toJSON(fields.FilterRules)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to serialize filter rules", "filter_rules", fields.FilterRules, "error", err)
+		return nil, dbError(err)
+	}
+
+	row := conn(ctx, cpRepo.pool).QueryRow(ctx,
+		`INSERT INTO child_playlists (id, user_id, base_playlist_id, name, description, spotify_playlist_id, filter_rules, is_active,
+			is_fallback, priority, max_tracks, selection_strategy, source_base_playlist_ids, refollow_recreated, spotify_integration_id,
+			provider)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING `+childPlaylistColumns,
+		newID(), fields.UserID, fields.BasePlaylistID, fields.Name, fields.Description, fields.SpotifyPlaylistID, filterRules, fields.IsActive,
+		fields.IsFallback, fields.Priority, fields.MaxTracks, string(fields.SelectionStrategy), stringsOrEmpty(fields.SourceBasePlaylistIDs),
+		fields.RefollowRecreated, fields.SpotifyIntegrationID, string(fields.Provider.OrDefault()),
+	)
+
+	childPlaylist, err := scanChildPlaylist(row)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to store child_playlist record", "base_playlist_id", fields.BasePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	cpRepo.log.InfoContext(ctx, "child_playlist stored successfully", "id", childPlaylist.ID)
+	return childPlaylist, nil
+}
+
+// Delete soft deletes the child playlist, it is purged once its retention expires
+func (cpRepo *ChildPlaylistRepositoryPostgres) Delete(ctx context.Context, id, userID string) error {
+	var ownerID string
+	err := conn(ctx, cpRepo.pool).QueryRow(ctx, "SELECT user_id FROM child_playlists WHERE id = $1 AND deleted_at IS NULL", id).Scan(&ownerID)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist record", "id", id, "error", err)
+		return repositories.ErrChildPlaylistNotFound
+	}
+
+	// Check ownership (belongs to the specified user)
+	if ownerID != userID {
+		cpRepo.log.ErrorContext(ctx, "unauthorized delete attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", ownerID,
+		)
+		return repositories.ErrUnauthorized
+	}
+
+	if _, err := conn(ctx, cpRepo.pool).Exec(ctx, "UPDATE child_playlists SET deleted_at = now(), updated = now() WHERE id = $1", id); err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to delete child_playlist record", "id", id, "error", err)
+		return dbError(err)
+	}
+
+	cpRepo.log.InfoContext(ctx, "child_playlist deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+// Restore clears the deleted_at of the child playlist. Children of a deleted base playlist come
+// back by restoring the base playlist instead. Restoring a child that is not deleted returns it as is
+func (cpRepo *ChildPlaylistRepositoryPostgres) Restore(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	var restored *models.ChildPlaylist
+	err := pgx.BeginFunc(ctx, conn(ctx, cpRepo.pool), func(tx pgx.Tx) error {
+		var deletedAt *time.Time
+		row := tx.QueryRow(ctx, "SELECT "+childPlaylistColumns+", deleted_at FROM child_playlists WHERE id = $1 FOR UPDATE", id)
+		childPlaylist, err := scanChildPlaylist(row, &deletedAt)
+		if isNoRows(err) {
+			return repositories.ErrChildPlaylistNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+
+		// Check ownership (belongs to the specified user)
+		if childPlaylist.UserID != userID {
+			cpRepo.log.ErrorContext(ctx, "unauthorized restore attempt",
+				"id", id,
+				"user_id", userID,
+				"actual_user_id", childPlaylist.UserID,
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		restored = childPlaylist
+		if deletedAt == nil {
+			return nil
+		}
+
+		var baseLive bool
+		err = tx.QueryRow(ctx,
+			"SELECT EXISTS (SELECT 1 FROM base_playlists WHERE id = $1 AND deleted_at IS NULL)",
+			childPlaylist.BasePlaylistID,
+		).Scan(&baseLive)
+		if err != nil {
+			return dbError(err)
+		}
+		if !baseLive {
+			cpRepo.log.ErrorContext(ctx, "unable to restore child_playlist of deleted base playlist", "id", id, "base_playlist_id", childPlaylist.BasePlaylistID)
+			return repositories.ErrBasePlaylistNotFound
+		}
+
+		// The spotify playlist may have been added again as a new child playlist meanwhile
+		var conflict bool
+		err = tx.QueryRow(ctx,
+			"SELECT EXISTS (SELECT 1 FROM child_playlists WHERE base_playlist_id = $1 AND spotify_playlist_id = $2 AND deleted_at IS NULL)",
+			childPlaylist.BasePlaylistID, childPlaylist.SpotifyPlaylistID,
+		).Scan(&conflict)
+		if err != nil {
+			return dbError(err)
+		}
+		if conflict {
+			return repositories.ErrRestoreConflict
+		}
+
+		row = tx.QueryRow(ctx, "UPDATE child_playlists SET deleted_at = NULL, updated = now() WHERE id = $1 RETURNING "+childPlaylistColumns, id)
+		if restored, err = scanChildPlaylist(row); err != nil {
+			return dbError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to restore child_playlist record", "id", id, "error", err)
+		return nil, err
+	}
+
+	cpRepo.log.InfoContext(ctx, "child_playlist restored successfully", "id", id, "user_id", userID)
+	return restored, nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPostgres) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	tag, err := conn(ctx, cpRepo.pool).Exec(ctx, "DELETE FROM child_playlists WHERE deleted_at < $1", deletedBefore)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to purge child_playlist records", "deleted_before", deletedBefore, "error", err)
+		return 0, dbError(err)
+	}
+
+	purged := int(tag.RowsAffected())
+	cpRepo.log.InfoContext(ctx, "deleted child_playlists purged", "deleted_before", deletedBefore, "purged", purged)
+	return purged, nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPostgres) GetByID(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	row := conn(ctx, cpRepo.pool).QueryRow(ctx, "SELECT "+childPlaylistColumns+" FROM child_playlists WHERE id = $1 AND deleted_at IS NULL", id)
+	childPlaylist, err := scanChildPlaylist(row)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist record", "id", id, "error", err)
+		return nil, repositories.ErrChildPlaylistNotFound
+	}
+
+	// Check ownership (belongs to the specified user)
+	if childPlaylist.UserID != userID {
+		cpRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", childPlaylist.UserID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	cpRepo.log.InfoContext(ctx, "child_playlist retrieved successfully", "id", id)
+	return childPlaylist, nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPostgres) GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.ChildPlaylist, error) {
+	childPlaylists, err := cpRepo.query(ctx,
+		"SELECT "+childPlaylistColumns+` FROM child_playlists
+		WHERE base_playlist_id = $1 AND user_id = $2 AND deleted_at IS NULL
+		ORDER BY created DESC`, // Newest first
+		basePlaylistID, userID,
+	)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist records for base playlist", "base_playlist_id", basePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	cpRepo.log.InfoContext(ctx, "child_playlists retrieved successfully", "base_playlist_id", basePlaylistID, "user_id", userID, "count", len(childPlaylists))
+	return childPlaylists, nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPostgres) GetBySourceBasePlaylistID(ctx context.Context, sourceBasePlaylistID, userID string) ([]*models.ChildPlaylist, error) {
+	childPlaylists, err := cpRepo.query(ctx,
+		"SELECT "+childPlaylistColumns+` FROM child_playlists
+		WHERE $1 = ANY(source_base_playlist_ids) AND user_id = $2 AND deleted_at IS NULL
+		ORDER BY created DESC`, // Newest first
+		sourceBasePlaylistID, userID,
+	)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to find child_playlist records merging base playlist", "source_base_playlist_id", sourceBasePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	cpRepo.log.InfoContext(ctx, "merge child_playlists retrieved successfully", "source_base_playlist_id", sourceBasePlaylistID, "user_id", userID, "count", len(childPlaylists))
+	return childPlaylists, nil
+}
+
+// GetByShareToken finds a shared child playlist without checking ownership, since the share token is the credential
+func (cpRepo *ChildPlaylistRepositoryPostgres) GetByShareToken(ctx context.Context, shareToken string) (*models.ChildPlaylist, error) {
+	if shareToken == "" {
+		return nil, repositories.ErrChildPlaylistNotFound
+	}
+
+	row := conn(ctx, cpRepo.pool).QueryRow(ctx, "SELECT "+childPlaylistColumns+" FROM child_playlists WHERE share_token = $1 AND deleted_at IS NULL LIMIT 1", shareToken)
+	childPlaylist, err := scanChildPlaylist(row)
+	if err != nil {
+		cpRepo.log.WarnContext(ctx, "unable to find shared child_playlist record", "error", err)
+		return nil, repositories.ErrChildPlaylistNotFound
+	}
+
+	return childPlaylist, nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPostgres) Update(ctx context.Context, id, userID string, fields repositories.UpdateChildPlaylistFields) (*models.ChildPlaylist, error) {
+	filterRules, err := toJSON(fields.FilterRules)
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to serialize filter rules", "filter_rules", fields.FilterRules, "error", err)
+		return nil, dbError(err)
+	}
+
+	var selectionStrategy, syncStrategy *string
+	if fields.SelectionStrategy != nil {
+		value := string(*fields.SelectionStrategy)
+		selectionStrategy = &value
+	}
+	if fields.SyncStrategy != nil {
+		value := string(*fields.SyncStrategy)
+		syncStrategy = &value
+	}
+
+	var childPlaylist *models.ChildPlaylist
+	err = pgx.BeginFunc(ctx, conn(ctx, cpRepo.pool), func(tx pgx.Tx) error {
+		var ownerID string
+		var updated time.Time
+		err := tx.QueryRow(ctx, "SELECT user_id, updated FROM child_playlists WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&ownerID, &updated)
+		if isNoRows(err) {
+			return repositories.ErrChildPlaylistNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+
+		// Check ownership (belongs to the specified user)
+		if ownerID != userID {
+			cpRepo.log.ErrorContext(ctx, "unauthorized update attempt",
+				"id", id,
+				"user_id", userID,
+				"actual_user_id", ownerID,
+			)
+			return repositories.ErrUnauthorized
+		}
+
+		if fields.ExpectedUpdated != nil && !updated.Equal(*fields.ExpectedUpdated) {
+			cpRepo.log.WarnContext(ctx, "child_playlist modified since it was read",
+				"id", id,
+				"expected_updated", *fields.ExpectedUpdated,
+				"updated", updated,
+			)
+			return repositories.ErrChildPlaylistModified
+		}
+
+		// Fields left nil keep their value
+		row := tx.QueryRow(ctx,
+			`UPDATE child_playlists SET
+				name = COALESCE($2, name),
+				description = COALESCE($3, description),
+				is_active = COALESCE($4, is_active),
+				spotify_playlist_id = COALESCE($5, spotify_playlist_id),
+				is_fallback = COALESCE($6, is_fallback),
+				priority = COALESCE($7, priority),
+				share_token = COALESCE($8, share_token),
+				max_tracks = COALESCE($9, max_tracks),
+				selection_strategy = COALESCE($10, selection_strategy),
+				sync_strategy = COALESCE($11, sync_strategy),
+				source_base_playlist_ids = COALESCE($12, source_base_playlist_ids),
+				pinned_tracks = COALESCE($13, pinned_tracks),
+				refollow_recreated = COALESCE($14, refollow_recreated),
+				suspended = COALESCE($15, suspended),
+				filter_rules = COALESCE($16, filter_rules),
+				updated = now()
+			WHERE id = $1
+			RETURNING `+childPlaylistColumns,
+			id, fields.Name, fields.Description, fields.IsActive, fields.SpotifyPlaylistID, fields.IsFallback, fields.Priority,
+			fields.ShareToken, fields.MaxTracks, selectionStrategy, syncStrategy, optionalStrings(fields.SourceBasePlaylistIDs),
+			optionalStrings(fields.PinnedTracks), fields.RefollowRecreated, fields.Suspended, filterRules,
+		)
+		if childPlaylist, err = scanChildPlaylist(row); err != nil {
+			return dbError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		cpRepo.log.ErrorContext(ctx, "unable to update child_playlist record", "id", id, "error", err)
+		return nil, err
+	}
+
+	cpRepo.log.InfoContext(ctx, "child_playlist updated successfully", "id", id)
+	return childPlaylist, nil
+}
+
+func (cpRepo *ChildPlaylistRepositoryPostgres) query(ctx context.Context, sql string, args ...any) ([]*models.ChildPlaylist, error) {
+	rows, err := conn(ctx, cpRepo.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.ChildPlaylist, error) {
+		return scanChildPlaylist(row)
+	})
+}
+
+// optionalStrings passes an optional list to a TEXT[] column, nil keeps the stored value and an
+// empty list clears it
+func optionalStrings(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return stringsOrEmpty(*values)
+}
+
+// scanChildPlaylist reads a row of childPlaylistColumns, followed by the extra columns given
+func scanChildPlaylist(row pgx.Row, extra ...any) (*models.ChildPlaylist, error) {
+	childPlaylist := &models.ChildPlaylist{}
+	var filterRules []byte
+	var selectionStrategy, syncStrategy, provider string
+	dest := append([]any{
+		&childPlaylist.ID, &childPlaylist.UserID, &childPlaylist.BasePlaylistID, &childPlaylist.Name, &childPlaylist.Description,
+		&childPlaylist.SpotifyPlaylistID, &filterRules, &childPlaylist.IsActive, &childPlaylist.IsFallback, &childPlaylist.Priority,
+		&childPlaylist.ShareToken, &childPlaylist.MaxTracks, &selectionStrategy, &syncStrategy, &childPlaylist.SourceBasePlaylistIDs,
+		&childPlaylist.PinnedTracks, &childPlaylist.RefollowRecreated, &childPlaylist.Suspended, &childPlaylist.SpotifyIntegrationID,
+		&provider, &childPlaylist.Created, &childPlaylist.Updated,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	childPlaylist.SelectionStrategy = models.SelectionStrategy(selectionStrategy)
+	childPlaylist.SyncStrategy = models.SyncStrategy(syncStrategy)
+	childPlaylist.Provider = models.MusicProvider(provider).OrDefault()
+
+	var rules models.AudioFeatureFilters
+	if fromJSON(filterRules, &rules) {
+		childPlaylist.FilterRules = &rules
+	}
+
+	return childPlaylist, nil
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const childPlaylistTemplateColumns = "id, user_id, name, description, children, created, updated"
+
+type ChildPlaylistTemplateRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewChildPlaylistTemplateRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *ChildPlaylistTemplateRepositoryPostgres {
+	return &ChildPlaylistTemplateRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "ChildPlaylistTemplateRepositoryPostgres"),
+	}
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPostgres) Create(ctx context.Context, template *models.ChildPlaylistTemplate) (*models.ChildPlaylistTemplate, error) {
+	children, err := toJSON(template.Children)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	row := conn(ctx, ctRepo.pool).QueryRow(ctx,
+		`INSERT INTO child_playlist_templates (id, user_id, name, description, children)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+childPlaylistTemplateColumns,
+		newID(), template.UserID, template.Name, template.Description, children,
+	)
+
+	stored, err := scanChildPlaylistTemplate(row)
+	if err != nil {
+		ctRepo.log.ErrorContext(ctx, "unable to store child_playlist_template record", "user_id", template.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	ctRepo.log.InfoContext(ctx, "child_playlist_template stored successfully", "id", stored.ID, "user_id", template.UserID)
+	return stored, nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPostgres) GetByID(ctx context.Context, id, userID string) (*models.ChildPlaylistTemplate, error) {
+	row := conn(ctx, ctRepo.pool).QueryRow(ctx, "SELECT "+childPlaylistTemplateColumns+" FROM child_playlist_templates WHERE id = $1", id)
+	template, err := scanChildPlaylistTemplate(row)
+	if err != nil {
+		return nil, repositories.ErrChildPlaylistTemplateNotFound
+	}
+
+	if err := ctRepo.checkOwner(ctx, id, userID, template.UserID); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPostgres) GetByUserID(ctx context.Context, userID string) ([]*models.ChildPlaylistTemplate, error) {
+	templates, err := queryRows(ctx, ctRepo.pool, scanChildPlaylistTemplate,
+		"SELECT "+childPlaylistTemplateColumns+" FROM child_playlist_templates WHERE user_id = $1 ORDER BY created",
+		userID,
+	)
+	if err != nil {
+		ctRepo.log.ErrorContext(ctx, "unable to find child_playlist_template records", "user_id", userID, "error", err)
+		return nil, dbError(err)
+	}
+
+	return templates, nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPostgres) Delete(ctx context.Context, id, userID string) error {
+	ownerID, err := findOwner(ctx, ctRepo.pool, "child_playlist_templates", id)
+	if err != nil {
+		return repositories.ErrChildPlaylistTemplateNotFound
+	}
+
+	if err := ctRepo.checkOwner(ctx, id, userID, ownerID); err != nil {
+		return err
+	}
+
+	if _, err := conn(ctx, ctRepo.pool).Exec(ctx, "DELETE FROM child_playlist_templates WHERE id = $1", id); err != nil {
+		ctRepo.log.ErrorContext(ctx, "unable to delete child_playlist_template record", "id", id, "error", err)
+		return dbError(err)
+	}
+
+	ctRepo.log.InfoContext(ctx, "child_playlist_template deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func (ctRepo *ChildPlaylistTemplateRepositoryPostgres) checkOwner(ctx context.Context, id, userID, ownerID string) error {
+	if ownerID != userID {
+		ctRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", ownerID,
+		)
+		return repositories.ErrUnauthorized
+	}
+
+	return nil
+}
+
+func scanChildPlaylistTemplate(row pgx.Row) (*models.ChildPlaylistTemplate, error) {
+	template := &models.ChildPlaylistTemplate{}
+	var children []byte
+	err := row.Scan(&template.ID, &template.UserID, &template.Name, &template.Description, &children, &template.Created, &template.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fromJSON(children, &template.Children) || template.Children == nil {
+		template.Children = []models.TemplateChild{}
+	}
+
+	return template, nil
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+// Ids have the shape of PocketBase record ids, so both backends hand out the same kind of id
+const (
+	ID_LENGTH   = 15
+	ID_ALPHABET = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// Open connects the pool to DB_POSTGRES_URL, failing when the server can't be reached
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	return open(ctx, cfg.PostgresURL, cfg.PostgresMaxConns)
+}
+
+// OpenRead connects the pool of the list queries to DB_POSTGRES_READ_URL. It returns no pool when
+// the url is empty, so the list queries stay on the primary pool
+func OpenRead(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	if cfg.PostgresReadURL == "" {
+		return nil, nil
+	}
+
+	return open(ctx, cfg.PostgresReadURL, cfg.ReadMaxOpenConns)
+}
+
+func open(ctx context.Context, url string, maxConns int) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid postgres url: %w", err)
+	}
+	if maxConns > 0 {
+		poolConfig.MaxConns = int32(maxConns)
+	}
+
+	// Times are read in UTC, like PocketBase returns them
+	poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+		})
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres pool: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to reach postgres: %w", err)
+	}
+
+	return pool, nil
+}
+
+// newID returns a random id of ID_LENGTH lowercase letters and digits
+func newID() string {
+	// Bytes past the last multiple of the alphabet size are skipped so every character is as likely
+	limit := 256 - 256%len(ID_ALPHABET)
+
+	id := make([]byte, 0, ID_LENGTH)
+	random := make([]byte, ID_LENGTH)
+	for len(id) < ID_LENGTH {
+		_, _ = rand.Read(random)
+		for _, b := range random {
+			if int(b) < limit && len(id) < ID_LENGTH {
+				id = append(id, ID_ALPHABET[int(b)%len(ID_ALPHABET)])
+			}
+		}
+	}
+
+	return string(id)
+}
+
+// dbError wraps a failed query like the PocketBase repositories do
+func dbError(err error) error {
+	return fmt.Errorf(`%w: %s`, repositories.ErrDatabaseOperation, err.Error())
+}
+
+// queryRows runs a query through the pool, or the transaction of ctx, and reads every row with scan
+func queryRows[T any](ctx context.Context, pool *pgxpool.Pool, scan func(pgx.Row) (T, error), sql string, args ...any) ([]T, error) {
+	rows, err := conn(ctx, pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (T, error) {
+		return scan(row)
+	})
+}
+
+// findOwner returns the user_id of a row of table, failing with pgx.ErrNoRows when there is none
+func findOwner(ctx context.Context, pool *pgxpool.Pool, table, id string) (string, error) {
+	var ownerID string
+	err := conn(ctx, pool).QueryRow(ctx, "SELECT user_id FROM "+table+" WHERE id = $1", id).Scan(&ownerID)
+	return ownerID, err
+}
+
+func isNoRows(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows)
+}
+
+// listOrderBy sorts a list query by the requested field, newest first when empty. Ties are broken by
+// id so consecutive pages don't overlap
+func listOrderBy(sort models.ListSort) string {
+	if sort == "" {
+		sort = models.ListSortCreatedDesc
+	}
+
+	field, descending := sort.Field()
+	if descending {
+		return field + " DESC, id DESC"
+	}
+	return field + " ASC, id ASC"
+}
+
+// limitOffset pages a list query, a zero limit returns every row like PocketBase does
+func limitOffset(limit, offset int) string {
+	if limit <= 0 {
+		return fmt.Sprintf(" OFFSET %d", max(offset, 0))
+	}
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, max(offset, 0))
+}
+
+// toJSON encodes a value for a JSONB column, nil values are stored as NULL
+func toJSON(value any) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+	}
+
+	return json.Marshal(value)
+}
+
+// fromJSON decodes a JSONB column, leaving the target untouched when it is NULL or can't be decoded
+func fromJSON(data []byte, target any) bool {
+	if len(data) == 0 || string(data) == "null" {
+		return false
+	}
+
+	return json.Unmarshal(data, target) == nil
+}
+
+// stringsOrEmpty keeps nil slices from being stored as NULL in TEXT[] columns
+func stringsOrEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// timeOrNil stores the zero time as NULL
+func timeOrNil(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
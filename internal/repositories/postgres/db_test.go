@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewID(t *testing.T) {
+	assert := require.New(t)
+
+	seen := map[string]bool{}
+	for range 1000 {
+		id := newID()
+		assert.Len(id, ID_LENGTH)
+		for _, char := range id {
+			assert.Contains(ID_ALPHABET, string(char))
+		}
+		assert.False(seen[id], "duplicated id %s", id)
+		seen[id] = true
+	}
+}
+
+func TestListOrderBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     models.ListSort
+		expected string
+	}{
+		{name: "defaults to newest first", sort: "", expected: "created DESC, id DESC"},
+		{name: "ascending", sort: models.ListSortCreatedAsc, expected: "created ASC, id ASC"},
+		{name: "descending", sort: models.ListSortCreatedDesc, expected: "created DESC, id DESC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, listOrderBy(tt.sort))
+		})
+	}
+}
+
+func TestLimitOffset(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(" LIMIT 20 OFFSET 40", limitOffset(20, 40))
+	assert.Equal(" OFFSET 0", limitOffset(0, 0))
+	assert.Equal(" LIMIT 5 OFFSET 0", limitOffset(5, -1))
+}
+
+func TestToJSON(t *testing.T) {
+	assert := require.New(t)
+
+	var filters *models.AudioFeatureFilters
+	data, err := toJSON(filters)
+	assert.NoError(err)
+	assert.Nil(data)
+
+	var anomalies []models.SyncAnomaly
+	data, err = toJSON(anomalies)
+	assert.NoError(err)
+	assert.Nil(data)
+
+	data, err = toJSON([]string{})
+	assert.NoError(err)
+	assert.Equal("[]", string(data))
+
+	data, err = toJSON(&models.SyncRateLimitStats{Retries: 2})
+	assert.NoError(err)
+	assert.JSONEq(`{"rate_limited_responses":0,"retries":2,"budget_waits":0,"wait_ms":0}`, string(data))
+}
+
+func TestFromJSON(t *testing.T) {
+	assert := require.New(t)
+
+	var stats *models.SyncRateLimitStats
+	assert.False(fromJSON(nil, &stats))
+	assert.False(fromJSON([]byte("null"), &stats))
+	assert.False(fromJSON([]byte("{"), &stats))
+	assert.Nil(stats)
+
+	assert.True(fromJSON([]byte(`{"retries":3}`), &stats))
+	assert.Equal(3, stats.Retries)
+}
+
+func TestTimeOrNil(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Now()
+	assert.Nil(timeOrNil(nil))
+	assert.Nil(timeOrNil(&time.Time{}))
+	assert.Equal(&now, timeOrNil(&now))
+}
+
+func TestMigrationNames(t *testing.T) {
+	assert := require.New(t)
+
+	names, err := migrationNames()
+	assert.NoError(err)
+	assert.NotEmpty(names)
+	assert.Equal("0001_init.sql", names[0])
+	assert.IsIncreasing(names)
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// LogReader reads the request and app logs, which PocketBase keeps in its own database whatever
+// the repository backend
+type LogReader interface {
+	GetRecentLogs(ctx context.Context, limit int) ([]*models.LogEntry, error)
+}
+
+type DiagnosticsRepositoryPostgres struct {
+	pool      *pgxpool.Pool
+	logReader LogReader
+	log       *slog.Logger
+}
+
+func NewDiagnosticsRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *DiagnosticsRepositoryPostgres {
+	return &DiagnosticsRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "DiagnosticsRepositoryPostgres"),
+	}
+}
+
+// WithLogReader serves the recent logs from reader, without one no logs are returned
+func (dRepo *DiagnosticsRepositoryPostgres) WithLogReader(reader LogReader) *DiagnosticsRepositoryPostgres {
+	dRepo.logReader = reader
+	return dRepo
+}
+
+func (dRepo *DiagnosticsRepositoryPostgres) GetRecentLogs(ctx context.Context, limit int) ([]*models.LogEntry, error) {
+	if dRepo.logReader == nil {
+		return []*models.LogEntry{}, nil
+	}
+
+	return dRepo.logReader.GetRecentLogs(ctx, limit)
+}
+
+// GetSchemaVersions lists the applied migrations and the tables of the schema, a table is
+// reported as changed when the last migration was applied
+func (dRepo *DiagnosticsRepositoryPostgres) GetSchemaVersions(ctx context.Context) (*models.SchemaVersions, error) {
+	migrations, err := queryRows(ctx, dRepo.pool, func(row pgx.Row) (models.AppliedMigration, error) {
+		var migration models.AppliedMigration
+		err := row.Scan(&migration.File, &migration.AppliedAt)
+		return migration, err
+	}, "SELECT file, applied_at FROM schema_migrations ORDER BY applied_at, file")
+	if err != nil {
+		dRepo.log.ErrorContext(ctx, "unable to find applied migrations", "error", err)
+		return nil, dbError(err)
+	}
+
+	var updated time.Time
+	if len(migrations) > 0 {
+		updated = migrations[len(migrations)-1].AppliedAt
+	}
+
+	tables, err := queryRows(ctx, dRepo.pool, func(row pgx.Row) (models.CollectionVersion, error) {
+		table := models.CollectionVersion{Updated: updated}
+		err := row.Scan(&table.Name, &table.Fields)
+		return table, err
+	}, `SELECT table_name, count(*) FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name <> 'schema_migrations'
+		GROUP BY table_name
+		ORDER BY table_name`)
+	if err != nil {
+		dRepo.log.ErrorContext(ctx, "unable to find tables", "error", err)
+		return nil, dbError(err)
+	}
+
+	return &models.SchemaVersions{
+		Migrations:  migrations,
+		Collections: tables,
+	}, nil
+}
+
+// Ping runs a trivial query, checking the database can still be read
+func (dRepo *DiagnosticsRepositoryPostgres) Ping(ctx context.Context) error {
+	var result int
+	if err := conn(ctx, dRepo.pool).QueryRow(ctx, "SELECT 1").Scan(&result); err != nil {
+		dRepo.log.ErrorContext(ctx, "database ping failed", "error", err)
+		return dbError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+type EncryptionKeyRotationRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewEncryptionKeyRotationRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *EncryptionKeyRotationRepositoryPostgres {
+	return &EncryptionKeyRotationRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "EncryptionKeyRotationRepositoryPostgres"),
+	}
+}
+
+func (krRepo *EncryptionKeyRotationRepositoryPostgres) Create(ctx context.Context, rotation *models.EncryptionKeyRotation) (*models.EncryptionKeyRotation, error) {
+	stored := &models.EncryptionKeyRotation{}
+	err := conn(ctx, krRepo.pool).QueryRow(ctx,
+		`INSERT INTO encryption_key_rotations (id, to_version, keys_rotated, keys_failed)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, to_version, keys_rotated, keys_failed, created`,
+		newID(), rotation.ToVersion, rotation.KeysRotated, rotation.KeysFailed,
+	).Scan(&stored.ID, &stored.ToVersion, &stored.KeysRotated, &stored.KeysFailed, &stored.Created)
+	if err != nil {
+		krRepo.log.ErrorContext(ctx, "unable to store encryption_key_rotation record", "error", err)
+		return nil, dbError(err)
+	}
+
+	krRepo.log.InfoContext(ctx, "encryption_key_rotation stored successfully", "id", stored.ID, "to_version", rotation.ToVersion)
+	return stored, nil
+}
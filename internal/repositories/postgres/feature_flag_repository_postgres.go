@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const featureFlagColumns = "id, key, description, enabled, rollout_percentage, user_ids, created, updated"
+
+type FeatureFlagRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewFeatureFlagRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *FeatureFlagRepositoryPostgres {
+	return &FeatureFlagRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "FeatureFlagRepositoryPostgres"),
+	}
+}
+
+func (ffRepo *FeatureFlagRepositoryPostgres) List(ctx context.Context) ([]*models.FeatureFlag, error) {
+	flags, err := queryRows(ctx, ffRepo.pool, scanFeatureFlag, "SELECT "+featureFlagColumns+" FROM feature_flags ORDER BY key")
+	if err != nil {
+		ffRepo.log.ErrorContext(ctx, "unable to find feature_flag records", "error", err)
+		return nil, dbError(err)
+	}
+
+	return flags, nil
+}
+
+func (ffRepo *FeatureFlagRepositoryPostgres) GetByKey(ctx context.Context, key string) (*models.FeatureFlag, error) {
+	row := conn(ctx, ffRepo.pool).QueryRow(ctx, "SELECT "+featureFlagColumns+" FROM feature_flags WHERE key = $1", key)
+	flag, err := scanFeatureFlag(row)
+	if err != nil {
+		return nil, repositories.ErrFeatureFlagNotFound
+	}
+
+	return flag, nil
+}
+
+func (ffRepo *FeatureFlagRepositoryPostgres) Upsert(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+	row := conn(ctx, ffRepo.pool).QueryRow(ctx,
+		`INSERT INTO feature_flags (id, key, description, enabled, rollout_percentage, user_ids)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (key) DO UPDATE SET
+			description = EXCLUDED.description,
+			enabled = EXCLUDED.enabled,
+			rollout_percentage = EXCLUDED.rollout_percentage,
+			user_ids = EXCLUDED.user_ids,
+			updated = now()
+		RETURNING `+featureFlagColumns,
+		newID(), flag.Key, flag.Description, flag.Enabled, flag.RolloutPercentage, stringsOrEmpty(flag.UserIDs),
+	)
+
+	stored, err := scanFeatureFlag(row)
+	if err != nil {
+		ffRepo.log.ErrorContext(ctx, "unable to store feature_flag record", "key", flag.Key, "error", err)
+		return nil, dbError(err)
+	}
+
+	return stored, nil
+}
+
+func (ffRepo *FeatureFlagRepositoryPostgres) Delete(ctx context.Context, key string) error {
+	tag, err := conn(ctx, ffRepo.pool).Exec(ctx, "DELETE FROM feature_flags WHERE key = $1", key)
+	if err != nil {
+		ffRepo.log.ErrorContext(ctx, "unable to delete feature_flag record", "key", key, "error", err)
+		return dbError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repositories.ErrFeatureFlagNotFound
+	}
+
+	ffRepo.log.InfoContext(ctx, "feature_flag deleted successfully", "key", key)
+	return nil
+}
+
+func scanFeatureFlag(row pgx.Row) (*models.FeatureFlag, error) {
+	flag := &models.FeatureFlag{}
+	err := row.Scan(&flag.ID, &flag.Key, &flag.Description, &flag.Enabled, &flag.RolloutPercentage, &flag.UserIDs, &flag.Created, &flag.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	flag.UserIDs = stringsOrEmpty(flag.UserIDs)
+	return flag, nil
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const filterRuleChangeColumns = `id, user_id, base_playlist_id, child_playlist_id, previous_filter_rules, new_filter_rules,
+	routing_diff, diff_computed_at, created, updated`
+
+type FilterRuleChangeRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewFilterRuleChangeRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *FilterRuleChangeRepositoryPostgres {
+	return &FilterRuleChangeRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "FilterRuleChangeRepositoryPostgres"),
+	}
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPostgres) Create(ctx context.Context, change *models.FilterRuleChange) (*models.FilterRuleChange, error) {
+	previousFilterRules, err := toJSON(change.PreviousFilterRules)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	newFilterRules, err := toJSON(change.NewFilterRules)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	row := conn(ctx, frcRepo.pool).QueryRow(ctx,
+		`INSERT INTO filter_rule_changes (id, user_id, base_playlist_id, child_playlist_id, previous_filter_rules, new_filter_rules)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+filterRuleChangeColumns,
+		newID(), change.UserID, change.BasePlaylistID, change.ChildPlaylistID, previousFilterRules, newFilterRules,
+	)
+
+	stored, err := scanFilterRuleChange(row)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to store filter_rule_change record", "child_playlist_id", change.ChildPlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	frcRepo.log.InfoContext(ctx, "filter_rule_change stored successfully", "id", stored.ID, "child_playlist_id", change.ChildPlaylistID)
+	return stored, nil
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPostgres) GetByID(ctx context.Context, id, userID string) (*models.FilterRuleChange, error) {
+	row := conn(ctx, frcRepo.pool).QueryRow(ctx, "SELECT "+filterRuleChangeColumns+" FROM filter_rule_changes WHERE id = $1", id)
+	change, err := scanFilterRuleChange(row)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to find filter_rule_change record", "id", id, "error", err)
+		return nil, repositories.ErrFilterRuleChangeNotFound
+	}
+
+	// Check ownership
+	if change.UserID != userID {
+		frcRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"requested_by", userID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return change, nil
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPostgres) GetByChildPlaylistID(ctx context.Context, childPlaylistID, userID string) ([]*models.FilterRuleChange, error) {
+	return frcRepo.findBy(ctx, "child_playlist_id = $1 AND user_id = $2", childPlaylistID, userID)
+}
+
+// GetPendingByBasePlaylistID returns the changes of the base playlist whose routing diff was not computed yet
+func (frcRepo *FilterRuleChangeRepositoryPostgres) GetPendingByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.FilterRuleChange, error) {
+	return frcRepo.findBy(ctx, "base_playlist_id = $1 AND user_id = $2 AND diff_computed_at IS NULL", basePlaylistID, userID)
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPostgres) UpdateRoutingDiff(ctx context.Context, id string, diff *models.RoutingDiff) (*models.FilterRuleChange, error) {
+	routingDiff, err := toJSON(diff)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	row := conn(ctx, frcRepo.pool).QueryRow(ctx,
+		"UPDATE filter_rule_changes SET routing_diff = $2, diff_computed_at = $3, updated = now() WHERE id = $1 RETURNING "+filterRuleChangeColumns,
+		id, routingDiff, diff.ComputedAt,
+	)
+	change, err := scanFilterRuleChange(row)
+	if isNoRows(err) {
+		frcRepo.log.ErrorContext(ctx, "unable to find filter_rule_change record", "id", id, "error", err)
+		return nil, repositories.ErrFilterRuleChangeNotFound
+	}
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to update filter_rule_change record", "id", id, "error", err)
+		return nil, dbError(err)
+	}
+
+	frcRepo.log.InfoContext(ctx, "filter_rule_change routing diff stored successfully", "id", id)
+	return change, nil
+}
+
+func (frcRepo *FilterRuleChangeRepositoryPostgres) findBy(ctx context.Context, condition string, args ...any) ([]*models.FilterRuleChange, error) {
+	changes, err := queryRows(ctx, frcRepo.pool, scanFilterRuleChange,
+		"SELECT "+filterRuleChangeColumns+" FROM filter_rule_changes WHERE "+condition+" ORDER BY created DESC", // Newest first
+		args...,
+	)
+	if err != nil {
+		frcRepo.log.ErrorContext(ctx, "unable to find filter_rule_change records", "filter", condition, "error", err)
+		return nil, dbError(err)
+	}
+
+	return changes, nil
+}
+
+func scanFilterRuleChange(row pgx.Row) (*models.FilterRuleChange, error) {
+	change := &models.FilterRuleChange{}
+	var previousFilterRules, newFilterRules, routingDiff []byte
+	var diffComputedAt *time.Time
+	err := row.Scan(
+		&change.ID, &change.UserID, &change.BasePlaylistID, &change.ChildPlaylistID, &previousFilterRules, &newFilterRules,
+		&routingDiff, &diffComputedAt, &change.Created, &change.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous, next models.AudioFeatureFilters
+	if fromJSON(previousFilterRules, &previous) {
+		change.PreviousFilterRules = &previous
+	}
+	if fromJSON(newFilterRules, &next) {
+		change.NewFilterRules = &next
+	}
+
+	var diff models.RoutingDiff
+	if diffComputedAt != nil && fromJSON(routingDiff, &diff) {
+		change.RoutingDiff = &diff
+	}
+
+	return change, nil
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const lastFmIntegrationColumns = `id, "user", username, session_key, created, updated`
+
+type LastFmIntegrationRepositoryPostgres struct {
+	pool        *pgxpool.Pool
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
+}
+
+func NewLastFmIntegrationRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *LastFmIntegrationRepositoryPostgres {
+	return &LastFmIntegrationRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "LastFmIntegrationRepositoryPostgres"),
+	}
+}
+
+// WithTokenCipher encrypts the stored Last.fm session keys with the data key of their user
+func (lRepo *LastFmIntegrationRepositoryPostgres) WithTokenCipher(tokenCipher repositories.TokenCipher) *LastFmIntegrationRepositoryPostgres {
+	lRepo.tokenCipher = tokenCipher
+	return lRepo
+}
+
+func (lRepo *LastFmIntegrationRepositoryPostgres) CreateOrUpdate(
+	ctx context.Context,
+	userId string,
+	integration *models.LastFmIntegration,
+) (*models.LastFmIntegration, error) {
+	sessionKey := integration.SessionKey
+	if lRepo.tokenCipher != nil {
+		encrypted, err := lRepo.tokenCipher.EncryptForUser(ctx, userId, integration.SessionKey)
+		if err != nil {
+			lRepo.log.ErrorContext(ctx, "unable to encrypt lastfm session key", "user", userId, "error", err)
+			return nil, dbError(err)
+		}
+		sessionKey = encrypted
+	}
+
+	row := conn(ctx, lRepo.pool).QueryRow(ctx,
+		`INSERT INTO lastfm_integrations (id, "user", username, session_key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("user") DO UPDATE SET
+			username = EXCLUDED.username,
+			session_key = EXCLUDED.session_key,
+			updated = now()
+		RETURNING `+lastFmIntegrationColumns,
+		newID(), userId, integration.Username, sessionKey,
+	)
+
+	stored, err := scanLastFmIntegration(row)
+	if err != nil {
+		lRepo.log.ErrorContext(ctx, "unable to store lastfm_integration record", "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	lRepo.log.InfoContext(ctx, "lastfm_integration stored successfully", "user", userId, "username", integration.Username)
+	return lRepo.decryptSessionKey(ctx, stored)
+}
+
+func (lRepo *LastFmIntegrationRepositoryPostgres) GetByUserID(ctx context.Context, userId string) (*models.LastFmIntegration, error) {
+	row := conn(ctx, lRepo.pool).QueryRow(ctx,
+		"SELECT "+lastFmIntegrationColumns+` FROM lastfm_integrations WHERE "user" = $1`,
+		userId,
+	)
+	integration, err := scanLastFmIntegration(row)
+	if err != nil {
+		lRepo.log.InfoContext(ctx, "unable to fetch lastfm_integration", "user", userId, "error", err)
+		return nil, repositories.ErrLastFmIntegrationNotFound
+	}
+
+	return lRepo.decryptSessionKey(ctx, integration)
+}
+
+func (lRepo *LastFmIntegrationRepositoryPostgres) Delete(ctx context.Context, userId string) error {
+	tag, err := conn(ctx, lRepo.pool).Exec(ctx, `DELETE FROM lastfm_integrations WHERE "user" = $1`, userId)
+	if err != nil {
+		lRepo.log.ErrorContext(ctx, "unable to delete lastfm_integration", "user", userId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+	if tag.RowsAffected() == 0 {
+		lRepo.log.ErrorContext(ctx, "lastfm_integration not found", "user", userId)
+		return repositories.ErrLastFmIntegrationNotFound
+	}
+
+	lRepo.log.InfoContext(ctx, "lastfm_integration deleted", "user", userId)
+	return nil
+}
+
+func (lRepo *LastFmIntegrationRepositoryPostgres) decryptSessionKey(ctx context.Context, integration *models.LastFmIntegration) (*models.LastFmIntegration, error) {
+	if lRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	sessionKey, err := lRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.SessionKey)
+	if err != nil {
+		lRepo.log.ErrorContext(ctx, "unable to decrypt lastfm session key", "user", integration.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	integration.SessionKey = sessionKey
+	return integration, nil
+}
+
+func scanLastFmIntegration(row pgx.Row) (*models.LastFmIntegration, error) {
+	integration := &models.LastFmIntegration{}
+	err := row.Scan(
+		&integration.ID, &integration.UserID, &integration.Username, &integration.SessionKey,
+		&integration.Created, &integration.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
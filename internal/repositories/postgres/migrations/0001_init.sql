@@ -0,0 +1,285 @@
+-- Tables mirror the PocketBase collections of internal/repositories/pb/collection_setup.go, with the
+-- same names and columns. Ids are generated by the app, relations cascade like their PocketBase fields
+
+CREATE TABLE users (
+    id                      TEXT PRIMARY KEY,
+    email                   TEXT NOT NULL DEFAULT '',
+    username                TEXT NOT NULL DEFAULT '',
+    name                    TEXT NOT NULL DEFAULT '',
+    disconnected_spotify_id TEXT NOT NULL DEFAULT '',
+    roles                   TEXT[] NOT NULL DEFAULT '{}',
+    disabled                BOOLEAN NOT NULL DEFAULT FALSE,
+    token_key               TEXT NOT NULL,
+    created                 TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated                 TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_users_email ON users (email) WHERE email <> '';
+CREATE INDEX idx_users_disconnected_spotify_id ON users (disconnected_spotify_id) WHERE disconnected_spotify_id <> '';
+
+CREATE TABLE base_playlists (
+    id                     TEXT PRIMARY KEY,
+    user_id                TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name                   TEXT NOT NULL,
+    spotify_playlist_id    TEXT NOT NULL,
+    is_active              BOOLEAN NOT NULL DEFAULT FALSE,
+    dedupe_strategy        TEXT NOT NULL DEFAULT '',
+    hook_token             TEXT NOT NULL DEFAULT '',
+    suspended              BOOLEAN NOT NULL DEFAULT FALSE,
+    archived               BOOLEAN NOT NULL DEFAULT FALSE,
+    spotify_integration_id TEXT NOT NULL DEFAULT '',
+    deleted_at             TIMESTAMPTZ,
+    created                TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated                TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_base_playlists_user ON base_playlists (user_id, created);
+CREATE UNIQUE INDEX idx_base_playlists_user_spotify ON base_playlists (user_id, spotify_playlist_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_base_playlists_hook_token ON base_playlists (hook_token) WHERE hook_token <> '';
+
+CREATE TABLE spotify_integrations (
+    id            TEXT PRIMARY KEY,
+    "user"        TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    spotify_id    TEXT NOT NULL,
+    access_token  TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    token_type    TEXT NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL,
+    scope         TEXT NOT NULL DEFAULT '',
+    display_name  TEXT NOT NULL DEFAULT '',
+    created       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_spotify_integrations_user ON spotify_integrations ("user");
+CREATE UNIQUE INDEX idx_spotify_integrations_user_spotify ON spotify_integrations ("user", spotify_id);
+CREATE INDEX idx_spotify_integrations_spotify ON spotify_integrations (spotify_id);
+CREATE INDEX idx_spotify_integrations_expires_at ON spotify_integrations (expires_at);
+
+CREATE TABLE child_playlists (
+    id                       TEXT PRIMARY KEY,
+    user_id                  TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_playlist_id         TEXT NOT NULL REFERENCES base_playlists (id) ON DELETE CASCADE,
+    name                     TEXT NOT NULL,
+    description              TEXT NOT NULL DEFAULT '',
+    spotify_playlist_id      TEXT NOT NULL,
+    filter_rules             JSONB,
+    is_active                BOOLEAN NOT NULL DEFAULT FALSE,
+    is_fallback              BOOLEAN NOT NULL DEFAULT FALSE,
+    priority                 INTEGER NOT NULL DEFAULT 0,
+    share_token              TEXT NOT NULL DEFAULT '',
+    max_tracks               INTEGER NOT NULL DEFAULT 0,
+    selection_strategy       TEXT NOT NULL DEFAULT '',
+    sync_strategy            TEXT NOT NULL DEFAULT '',
+    source_base_playlist_ids TEXT[] NOT NULL DEFAULT '{}', -- Dropped from the list when a source is purged
+    pinned_tracks            TEXT[] NOT NULL DEFAULT '{}',
+    refollow_recreated       BOOLEAN NOT NULL DEFAULT FALSE,
+    suspended                BOOLEAN NOT NULL DEFAULT FALSE,
+    spotify_integration_id   TEXT NOT NULL DEFAULT '',
+    deleted_at               TIMESTAMPTZ,
+    created                  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated                  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_child_playlists_base ON child_playlists (base_playlist_id, created);
+CREATE UNIQUE INDEX idx_child_playlists_base_spotify ON child_playlists (base_playlist_id, spotify_playlist_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_child_playlists_sources ON child_playlists USING GIN (source_base_playlist_ids);
+CREATE INDEX idx_child_playlists_share_token ON child_playlists (share_token) WHERE share_token <> '';
+
+CREATE TABLE sync_events (
+    id                 TEXT PRIMARY KEY,
+    user_id            TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_playlist_id   TEXT NOT NULL REFERENCES base_playlists (id) ON DELETE CASCADE,
+    child_playlist_ids TEXT[] NOT NULL DEFAULT '{}',
+    status             TEXT NOT NULL,
+    phase              TEXT NOT NULL DEFAULT '',
+    started_at         TIMESTAMPTZ NOT NULL,
+    completed_at       TIMESTAMPTZ,
+    heartbeat_at       TIMESTAMPTZ,
+    error_message      TEXT NOT NULL DEFAULT '',
+    request_id         TEXT NOT NULL DEFAULT '',
+    tracks_processed   INTEGER NOT NULL DEFAULT 0,
+    tracks_unmatched   INTEGER NOT NULL DEFAULT 0,
+    total_api_requests INTEGER NOT NULL DEFAULT 0,
+    child_sync_results JSONB,
+    anomalies          JSONB,
+    profile            JSONB,
+    rate_limit         JSONB,
+    created            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_sync_events_user ON sync_events (user_id, created);
+CREATE INDEX idx_sync_events_base ON sync_events (base_playlist_id, created);
+CREATE INDEX idx_sync_events_status ON sync_events (status, created);
+
+CREATE TABLE playlist_snapshots (
+    id                  TEXT PRIMARY KEY,
+    user_id             TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    sync_event_id       TEXT NOT NULL REFERENCES sync_events (id) ON DELETE CASCADE,
+    child_playlist_id   TEXT NOT NULL REFERENCES child_playlists (id) ON DELETE CASCADE,
+    spotify_playlist_id TEXT NOT NULL DEFAULT '',
+    track_uris          TEXT[] NOT NULL DEFAULT '{}',
+    created             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_playlist_snapshots_sync_child ON playlist_snapshots (sync_event_id, child_playlist_id);
+
+CREATE TABLE user_encryption_keys (
+    id          TEXT PRIMARY KEY,
+    user_id     TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    wrapped_key TEXT NOT NULL,
+    key_version INTEGER NOT NULL,
+    created     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_user_encryption_keys_user ON user_encryption_keys (user_id);
+CREATE INDEX idx_user_encryption_keys_version ON user_encryption_keys (key_version);
+
+CREATE TABLE encryption_key_rotations (
+    id           TEXT PRIMARY KEY,
+    to_version   INTEGER NOT NULL,
+    keys_rotated INTEGER NOT NULL DEFAULT 0,
+    keys_failed  INTEGER NOT NULL DEFAULT 0,
+    created      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE filter_rule_changes (
+    id                    TEXT PRIMARY KEY,
+    user_id               TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_playlist_id      TEXT NOT NULL REFERENCES base_playlists (id) ON DELETE CASCADE,
+    child_playlist_id     TEXT NOT NULL REFERENCES child_playlists (id) ON DELETE CASCADE,
+    previous_filter_rules JSONB,
+    new_filter_rules      JSONB,
+    routing_diff          JSONB,
+    diff_computed_at      TIMESTAMPTZ,
+    created               TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated               TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_filter_rule_changes_child ON filter_rule_changes (child_playlist_id);
+CREATE INDEX idx_filter_rule_changes_base_pending ON filter_rule_changes (base_playlist_id, diff_computed_at);
+
+CREATE TABLE api_keys (
+    id           TEXT PRIMARY KEY,
+    user_id      TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         TEXT NOT NULL,
+    key_hash     TEXT NOT NULL,
+    key_prefix   TEXT NOT NULL,
+    scopes       JSONB,
+    last_used_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ,
+    created      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_api_keys_key_hash ON api_keys (key_hash);
+CREATE INDEX idx_api_keys_user ON api_keys (user_id);
+
+CREATE TABLE playlist_memberships (
+    id                  TEXT PRIMARY KEY,
+    user_id             TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    child_playlist_id   TEXT NOT NULL REFERENCES child_playlists (id) ON DELETE CASCADE,
+    sync_event_id       TEXT NOT NULL DEFAULT '',
+    spotify_playlist_id TEXT NOT NULL DEFAULT '',
+    track_uris          TEXT[] NOT NULL DEFAULT '{}',
+    created             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated             TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_playlist_memberships_child ON playlist_memberships (child_playlist_id);
+
+CREATE TABLE playlist_webhooks (
+    id                   TEXT PRIMARY KEY,
+    user_id              TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_playlist_id     TEXT NOT NULL REFERENCES base_playlists (id) ON DELETE CASCADE,
+    url                  TEXT NOT NULL,
+    secret               TEXT NOT NULL,
+    is_active            BOOLEAN NOT NULL DEFAULT FALSE,
+    last_delivered_at    TIMESTAMPTZ,
+    last_delivery_status INTEGER NOT NULL DEFAULT 0,
+    created              TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated              TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_playlist_webhooks_base ON playlist_webhooks (base_playlist_id);
+CREATE INDEX idx_playlist_webhooks_active ON playlist_webhooks (is_active);
+
+CREATE TABLE base_playlist_watches (
+    id               TEXT PRIMARY KEY,
+    user_id          TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_playlist_id TEXT NOT NULL REFERENCES base_playlists (id) ON DELETE CASCADE,
+    snapshot_id      TEXT NOT NULL DEFAULT '',
+    track_uris       TEXT[] NOT NULL DEFAULT '{}',
+    created          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_base_playlist_watches_base ON base_playlist_watches (base_playlist_id);
+
+CREATE TABLE child_playlist_templates (
+    id          TEXT PRIMARY KEY,
+    user_id     TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    children    JSONB,
+    created     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_child_playlist_templates_user ON child_playlist_templates (user_id);
+
+CREATE TABLE blocklist_entries (
+    id               TEXT PRIMARY KEY,
+    user_id          TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_playlist_id TEXT NOT NULL REFERENCES base_playlists (id) ON DELETE CASCADE,
+    type             TEXT NOT NULL,
+    value            TEXT NOT NULL,
+    created          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_blocklist_entries_unique ON blocklist_entries (base_playlist_id, type, value);
+
+CREATE TABLE routing_reports (
+    id               TEXT PRIMARY KEY,
+    user_id          TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    base_playlist_id TEXT NOT NULL REFERENCES base_playlists (id) ON DELETE CASCADE,
+    sync_event_id    TEXT NOT NULL DEFAULT '',
+    dedupe_strategy  TEXT NOT NULL DEFAULT '',
+    tracks           JSONB,
+    created          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_routing_reports_base ON routing_reports (base_playlist_id);
+CREATE INDEX idx_routing_reports_sync_event ON routing_reports (sync_event_id);
+
+CREATE TABLE notification_preferences (
+    id                     TEXT PRIMARY KEY,
+    user_id                TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    email_on_sync_failure  BOOLEAN NOT NULL DEFAULT FALSE,
+    sync_failure_threshold INTEGER NOT NULL DEFAULT 0,
+    sync_summary_channels  JSONB,
+    slack_webhook_url      TEXT NOT NULL DEFAULT '',
+    discord_webhook_url    TEXT NOT NULL DEFAULT '',
+    created                TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated                TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_notification_preferences_user ON notification_preferences (user_id);
+
+CREATE TABLE routing_cache_entries (
+    id                TEXT PRIMARY KEY,
+    user_id           TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    child_playlist_id TEXT NOT NULL REFERENCES child_playlists (id) ON DELETE CASCADE,
+    snapshot_id       TEXT NOT NULL DEFAULT '',
+    filter_hash       TEXT NOT NULL DEFAULT '',
+    track_uris        TEXT[] NOT NULL DEFAULT '{}',
+    created           TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated           TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_routing_cache_entries_child ON routing_cache_entries (child_playlist_id);
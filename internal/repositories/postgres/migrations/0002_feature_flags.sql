@@ -0,0 +1,12 @@
+CREATE TABLE feature_flags (
+    id                 TEXT PRIMARY KEY,
+    key                TEXT NOT NULL,
+    description        TEXT NOT NULL DEFAULT '',
+    enabled            BOOLEAN NOT NULL DEFAULT FALSE,
+    rollout_percentage INTEGER NOT NULL DEFAULT 0,
+    user_ids           TEXT[] NOT NULL DEFAULT '{}',
+    created            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_feature_flags_key ON feature_flags (key);
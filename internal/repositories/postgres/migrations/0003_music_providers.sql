@@ -0,0 +1,3 @@
+ALTER TABLE spotify_integrations ADD COLUMN provider TEXT NOT NULL DEFAULT 'spotify';
+ALTER TABLE base_playlists ADD COLUMN provider TEXT NOT NULL DEFAULT 'spotify';
+ALTER TABLE child_playlists ADD COLUMN provider TEXT NOT NULL DEFAULT 'spotify';
@@ -0,0 +1,15 @@
+CREATE TABLE youtube_music_integrations (
+    id            TEXT PRIMARY KEY,
+    "user"        TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    channel_id    TEXT NOT NULL,
+    access_token  TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    token_type    TEXT NOT NULL DEFAULT '',
+    expires_at    TIMESTAMPTZ NOT NULL,
+    scope         TEXT NOT NULL DEFAULT '',
+    display_name  TEXT NOT NULL DEFAULT '',
+    created       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_youtube_music_integrations_user ON youtube_music_integrations ("user");
@@ -0,0 +1,16 @@
+CREATE TABLE tidal_integrations (
+    id            TEXT PRIMARY KEY,
+    "user"        TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    tidal_user_id TEXT NOT NULL,
+    country_code  TEXT NOT NULL DEFAULT '',
+    access_token  TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    token_type    TEXT NOT NULL DEFAULT '',
+    expires_at    TIMESTAMPTZ NOT NULL,
+    scope         TEXT NOT NULL DEFAULT '',
+    display_name  TEXT NOT NULL DEFAULT '',
+    created       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_tidal_integrations_user ON tidal_integrations ("user");
@@ -0,0 +1,10 @@
+CREATE TABLE lastfm_integrations (
+    id          TEXT PRIMARY KEY,
+    "user"      TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    username    TEXT NOT NULL,
+    session_key TEXT NOT NULL,
+    created     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_lastfm_integrations_user ON lastfm_integrations ("user");
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const notificationPreferencesColumns = `id, user_id, email_on_sync_failure, sync_failure_threshold, sync_summary_channels,
+	slack_webhook_url, discord_webhook_url, created, updated`
+
+type NotificationPreferencesRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewNotificationPreferencesRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *NotificationPreferencesRepositoryPostgres {
+	return &NotificationPreferencesRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "NotificationPreferencesRepositoryPostgres"),
+	}
+}
+
+func (npRepo *NotificationPreferencesRepositoryPostgres) Upsert(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	channels := preferences.SyncSummaryChannels
+	if channels == nil {
+		channels = []models.NotificationChannel{}
+	}
+
+	syncSummaryChannels, err := toJSON(channels)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	row := conn(ctx, npRepo.pool).QueryRow(ctx,
+		`INSERT INTO notification_preferences (id, user_id, email_on_sync_failure, sync_failure_threshold, sync_summary_channels,
+			slack_webhook_url, discord_webhook_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE SET
+			email_on_sync_failure = EXCLUDED.email_on_sync_failure,
+			sync_failure_threshold = EXCLUDED.sync_failure_threshold,
+			sync_summary_channels = EXCLUDED.sync_summary_channels,
+			slack_webhook_url = EXCLUDED.slack_webhook_url,
+			discord_webhook_url = EXCLUDED.discord_webhook_url,
+			updated = now()
+		RETURNING `+notificationPreferencesColumns,
+		newID(), preferences.UserID, preferences.EmailOnSyncFailure, preferences.SyncFailureThreshold, syncSummaryChannels,
+		preferences.SlackWebhookURL, preferences.DiscordWebhookURL,
+	)
+
+	stored, err := scanNotificationPreferences(row)
+	if err != nil {
+		npRepo.log.ErrorContext(ctx, "unable to store notification_preferences record", "user_id", preferences.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	return stored, nil
+}
+
+func (npRepo *NotificationPreferencesRepositoryPostgres) GetByUserID(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	row := conn(ctx, npRepo.pool).QueryRow(ctx, "SELECT "+notificationPreferencesColumns+" FROM notification_preferences WHERE user_id = $1", userID)
+	preferences, err := scanNotificationPreferences(row)
+	if err != nil {
+		return nil, repositories.ErrNotificationPreferencesNotFound
+	}
+
+	return preferences, nil
+}
+
+func scanNotificationPreferences(row pgx.Row) (*models.NotificationPreferences, error) {
+	preferences := &models.NotificationPreferences{}
+	var syncSummaryChannels []byte
+	err := row.Scan(
+		&preferences.ID, &preferences.UserID, &preferences.EmailOnSyncFailure, &preferences.SyncFailureThreshold,
+		&syncSummaryChannels, &preferences.SlackWebhookURL, &preferences.DiscordWebhookURL, &preferences.Created, &preferences.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fromJSON(syncSummaryChannels, &preferences.SyncSummaryChannels) || preferences.SyncSummaryChannels == nil {
+		preferences.SyncSummaryChannels = []models.NotificationChannel{}
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const playlistMembershipColumns = "id, user_id, child_playlist_id, sync_event_id, spotify_playlist_id, track_uris, created, updated"
+
+type PlaylistMembershipRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewPlaylistMembershipRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *PlaylistMembershipRepositoryPostgres {
+	return &PlaylistMembershipRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "PlaylistMembershipRepositoryPostgres"),
+	}
+}
+
+func (pmRepo *PlaylistMembershipRepositoryPostgres) Upsert(ctx context.Context, membership *models.PlaylistMembership) (*models.PlaylistMembership, error) {
+	trackURIs := stringsOrEmpty(membership.TrackURIs)
+
+	// The membership of a child playlist of another user is left untouched and nothing is returned
+	row := conn(ctx, pmRepo.pool).QueryRow(ctx,
+		`INSERT INTO playlist_memberships (id, user_id, child_playlist_id, sync_event_id, spotify_playlist_id, track_uris)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (child_playlist_id) DO UPDATE SET
+			sync_event_id = EXCLUDED.sync_event_id,
+			spotify_playlist_id = EXCLUDED.spotify_playlist_id,
+			track_uris = EXCLUDED.track_uris,
+			updated = now()
+		WHERE playlist_memberships.user_id = EXCLUDED.user_id
+		RETURNING `+playlistMembershipColumns,
+		newID(), membership.UserID, membership.ChildPlaylistID, membership.SyncEventID, membership.SpotifyPlaylistID, trackURIs,
+	)
+
+	stored, err := scanPlaylistMembership(row)
+	if isNoRows(err) {
+		pmRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"child_playlist_id", membership.ChildPlaylistID,
+			"user_id", membership.UserID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+	if err != nil {
+		pmRepo.log.ErrorContext(ctx, "unable to store playlist_membership record", "child_playlist_id", membership.ChildPlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	pmRepo.log.InfoContext(ctx, "playlist_membership stored successfully", "id", stored.ID, "track_count", len(trackURIs))
+	return stored, nil
+}
+
+func (pmRepo *PlaylistMembershipRepositoryPostgres) GetByChildPlaylistID(ctx context.Context, childPlaylistID, userID string) (*models.PlaylistMembership, error) {
+	row := conn(ctx, pmRepo.pool).QueryRow(ctx, "SELECT "+playlistMembershipColumns+" FROM playlist_memberships WHERE child_playlist_id = $1", childPlaylistID)
+	membership, err := scanPlaylistMembership(row)
+	if err != nil {
+		return nil, repositories.ErrPlaylistMembershipNotFound
+	}
+
+	// Check ownership
+	if membership.UserID != userID {
+		pmRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"child_playlist_id", childPlaylistID,
+			"requested_by", userID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return membership, nil
+}
+
+func scanPlaylistMembership(row pgx.Row) (*models.PlaylistMembership, error) {
+	membership := &models.PlaylistMembership{}
+	err := row.Scan(
+		&membership.ID, &membership.UserID, &membership.ChildPlaylistID, &membership.SyncEventID, &membership.SpotifyPlaylistID,
+		&membership.TrackURIs, &membership.Created, &membership.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	membership.TrackURIs = stringsOrEmpty(membership.TrackURIs)
+	return membership, nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+const playlistSnapshotColumns = "id, user_id, sync_event_id, child_playlist_id, spotify_playlist_id, track_uris, created, updated"
+
+type PlaylistSnapshotRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewPlaylistSnapshotRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *PlaylistSnapshotRepositoryPostgres {
+	return &PlaylistSnapshotRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "PlaylistSnapshotRepositoryPostgres"),
+	}
+}
+
+func (psRepo *PlaylistSnapshotRepositoryPostgres) Create(ctx context.Context, snapshot *models.PlaylistSnapshot) (*models.PlaylistSnapshot, error) {
+	trackURIs := stringsOrEmpty(snapshot.TrackURIs)
+
+	row := conn(ctx, psRepo.pool).QueryRow(ctx,
+		`INSERT INTO playlist_snapshots (id, user_id, sync_event_id, child_playlist_id, spotify_playlist_id, track_uris)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+playlistSnapshotColumns,
+		newID(), snapshot.UserID, snapshot.SyncEventID, snapshot.ChildPlaylistID, snapshot.SpotifyPlaylistID, trackURIs,
+	)
+
+	created, err := scanPlaylistSnapshot(row)
+	if err != nil {
+		psRepo.log.ErrorContext(ctx, "unable to store playlist_snapshot record", "sync_event_id", snapshot.SyncEventID, "child_playlist_id", snapshot.ChildPlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	psRepo.log.InfoContext(ctx, "playlist_snapshot stored successfully", "id", created.ID, "track_count", len(trackURIs))
+	return created, nil
+}
+
+func (psRepo *PlaylistSnapshotRepositoryPostgres) GetBySyncEventID(ctx context.Context, syncEventID, userID string) ([]*models.PlaylistSnapshot, error) {
+	snapshots, err := queryRows(ctx, psRepo.pool, scanPlaylistSnapshot,
+		"SELECT "+playlistSnapshotColumns+" FROM playlist_snapshots WHERE sync_event_id = $1 AND user_id = $2 ORDER BY created",
+		syncEventID, userID,
+	)
+	if err != nil {
+		psRepo.log.ErrorContext(ctx, "unable to find playlist_snapshot records for sync event", "sync_event_id", syncEventID, "error", err)
+		return nil, dbError(err)
+	}
+
+	psRepo.log.InfoContext(ctx, "playlist_snapshots retrieved successfully", "sync_event_id", syncEventID, "count", len(snapshots))
+	return snapshots, nil
+}
+
+func scanPlaylistSnapshot(row pgx.Row) (*models.PlaylistSnapshot, error) {
+	snapshot := &models.PlaylistSnapshot{}
+	err := row.Scan(
+		&snapshot.ID, &snapshot.UserID, &snapshot.SyncEventID, &snapshot.ChildPlaylistID, &snapshot.SpotifyPlaylistID,
+		&snapshot.TrackURIs, &snapshot.Created, &snapshot.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.TrackURIs = stringsOrEmpty(snapshot.TrackURIs)
+	return snapshot, nil
+}
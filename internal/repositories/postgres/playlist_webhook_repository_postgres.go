@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const playlistWebhookColumns = `id, user_id, base_playlist_id, url, secret, is_active, last_delivered_at, last_delivery_status,
+	created, updated`
+
+type PlaylistWebhookRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewPlaylistWebhookRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *PlaylistWebhookRepositoryPostgres {
+	return &PlaylistWebhookRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "PlaylistWebhookRepositoryPostgres"),
+	}
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPostgres) Create(ctx context.Context, webhook *models.PlaylistWebhook) (*models.PlaylistWebhook, error) {
+	row := conn(ctx, pwRepo.pool).QueryRow(ctx,
+		`INSERT INTO playlist_webhooks (id, user_id, base_playlist_id, url, secret, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+playlistWebhookColumns,
+		newID(), webhook.UserID, webhook.BasePlaylistID, webhook.URL, webhook.Secret, webhook.IsActive,
+	)
+
+	stored, err := scanPlaylistWebhook(row)
+	if err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to store playlist_webhook record", "base_playlist_id", webhook.BasePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	pwRepo.log.InfoContext(ctx, "playlist_webhook stored successfully", "id", stored.ID, "base_playlist_id", webhook.BasePlaylistID)
+	return stored, nil
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPostgres) GetByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.PlaylistWebhook, error) {
+	return pwRepo.findWebhooks(ctx, "base_playlist_id = $1 AND user_id = $2", basePlaylistID, userID)
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPostgres) GetActive(ctx context.Context) ([]*models.PlaylistWebhook, error) {
+	return pwRepo.findWebhooks(ctx, "is_active")
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPostgres) Delete(ctx context.Context, id, userID string) error {
+	ownerID, err := findOwner(ctx, pwRepo.pool, "playlist_webhooks", id)
+	if err != nil {
+		return repositories.ErrPlaylistWebhookNotFound
+	}
+
+	// Check ownership
+	if ownerID != userID {
+		pwRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"id", id,
+			"user_id", userID,
+			"actual_user_id", ownerID,
+		)
+		return repositories.ErrUnauthorized
+	}
+
+	if _, err := conn(ctx, pwRepo.pool).Exec(ctx, "DELETE FROM playlist_webhooks WHERE id = $1", id); err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to delete playlist_webhook record", "id", id, "error", err)
+		return dbError(err)
+	}
+
+	pwRepo.log.InfoContext(ctx, "playlist_webhook deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPostgres) RecordDelivery(ctx context.Context, id string, status int, deliveredAt time.Time) error {
+	tag, err := conn(ctx, pwRepo.pool).Exec(ctx,
+		"UPDATE playlist_webhooks SET last_delivery_status = $2, last_delivered_at = $3, updated = now() WHERE id = $1",
+		id, status, deliveredAt,
+	)
+	if err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to update playlist_webhook record", "id", id, "error", err)
+		return dbError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repositories.ErrPlaylistWebhookNotFound
+	}
+
+	return nil
+}
+
+func (pwRepo *PlaylistWebhookRepositoryPostgres) findWebhooks(ctx context.Context, condition string, args ...any) ([]*models.PlaylistWebhook, error) {
+	webhooks, err := queryRows(ctx, pwRepo.pool, scanPlaylistWebhook,
+		"SELECT "+playlistWebhookColumns+" FROM playlist_webhooks WHERE "+condition+" ORDER BY created",
+		args...,
+	)
+	if err != nil {
+		pwRepo.log.ErrorContext(ctx, "unable to find playlist_webhook records", "error", err)
+		return nil, dbError(err)
+	}
+
+	return webhooks, nil
+}
+
+func scanPlaylistWebhook(row pgx.Row) (*models.PlaylistWebhook, error) {
+	webhook := &models.PlaylistWebhook{}
+	err := row.Scan(
+		&webhook.ID, &webhook.UserID, &webhook.BasePlaylistID, &webhook.URL, &webhook.Secret, &webhook.IsActive,
+		&webhook.LastDeliveredAt, &webhook.LastDeliveryStatus, &webhook.Created, &webhook.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
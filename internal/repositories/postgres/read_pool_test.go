@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+// newUnreachablePool returns a pool that fails every query, pgxpool only connects on first use
+func newUnreachablePool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	pool, err := pgxpool.New(context.Background(), "postgres://user@127.0.0.1:1/db?connect_timeout=1")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// newClosedPool returns a pool whose queries fail with "closed pool", telling it apart from the
+// unreachable one
+func newClosedPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	pool := newUnreachablePool(t)
+	pool.Close()
+	return pool
+}
+
+func TestOpenRead_WithoutURL(t *testing.T) {
+	assert := require.New(t)
+
+	pool, err := OpenRead(context.Background(), config.DatabaseConfig{PostgresURL: "postgres://user@127.0.0.1:1/db"})
+
+	assert.NoError(err)
+	assert.Nil(pool)
+}
+
+func TestBasePlaylistRepositoryPostgres_WithReadPool(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	repo := NewBasePlaylistRepositoryPostgres(newUnreachablePool(t), logger).WithReadPool(newClosedPool(t))
+
+	// List queries run on the read pool
+	_, err := repo.GetByUserID(ctx, "user123")
+	assert.ErrorContains(err, "closed pool")
+	_, err = repo.List(ctx, repositories.BasePlaylistFilter{UserID: "user123"})
+	assert.ErrorContains(err, "closed pool")
+	_, err = repo.Count(ctx, repositories.BasePlaylistFilter{UserID: "user123"})
+	assert.ErrorContains(err, "closed pool")
+
+	// Everything else stays on the primary pool
+	_, err = repo.GetByID(ctx, "bp1", "user123")
+	assert.Error(err)
+	assert.NotContains(err.Error(), "closed pool")
+
+	// Without a read pool list queries stay on the primary pool
+	repo = NewBasePlaylistRepositoryPostgres(newClosedPool(t), logger).WithReadPool(nil)
+	_, err = repo.GetByUserID(ctx, "user123")
+	assert.ErrorContains(err, "closed pool")
+}
+
+func TestSyncEventRepositoryPostgres_WithReadPool(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	repo := NewSyncEventRepositoryPostgres(newUnreachablePool(t), logger).WithReadPool(newClosedPool(t))
+
+	// List queries run on the read pool
+	_, err := repo.GetByUserID(ctx, "user123")
+	assert.ErrorContains(err, "closed pool")
+	_, err = repo.GetByBasePlaylistID(ctx, "bp1")
+	assert.ErrorContains(err, "closed pool")
+
+	// Everything else stays on the primary pool
+	_, err = repo.GetRecentFailed(ctx, 10)
+	assert.Error(err)
+	assert.NotContains(err.Error(), "closed pool")
+}
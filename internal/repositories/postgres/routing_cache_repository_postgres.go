@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const routingCacheEntryColumns = "id, user_id, child_playlist_id, snapshot_id, filter_hash, track_uris, created, updated"
+
+type RoutingCacheRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewRoutingCacheRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *RoutingCacheRepositoryPostgres {
+	return &RoutingCacheRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "RoutingCacheRepositoryPostgres"),
+	}
+}
+
+func (rcRepo *RoutingCacheRepositoryPostgres) Upsert(ctx context.Context, entry *models.RoutingCacheEntry) (*models.RoutingCacheEntry, error) {
+	// The entry of a child playlist of another user is left untouched and nothing is returned
+	row := conn(ctx, rcRepo.pool).QueryRow(ctx,
+		`INSERT INTO routing_cache_entries (id, user_id, child_playlist_id, snapshot_id, filter_hash, track_uris)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (child_playlist_id) DO UPDATE SET
+			snapshot_id = EXCLUDED.snapshot_id,
+			filter_hash = EXCLUDED.filter_hash,
+			track_uris = EXCLUDED.track_uris,
+			updated = now()
+		WHERE routing_cache_entries.user_id = EXCLUDED.user_id
+		RETURNING `+routingCacheEntryColumns,
+		newID(), entry.UserID, entry.ChildPlaylistID, entry.SnapshotID, entry.FilterHash, stringsOrEmpty(entry.TrackURIs),
+	)
+
+	stored, err := scanRoutingCacheEntry(row)
+	if isNoRows(err) {
+		rcRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"child_playlist_id", entry.ChildPlaylistID,
+			"user_id", entry.UserID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+	if err != nil {
+		rcRepo.log.ErrorContext(ctx, "unable to store routing_cache record", "child_playlist_id", entry.ChildPlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	return stored, nil
+}
+
+func (rcRepo *RoutingCacheRepositoryPostgres) GetByChildPlaylistIDs(ctx context.Context, childPlaylistIDs []string, userID string) ([]*models.RoutingCacheEntry, error) {
+	if len(childPlaylistIDs) == 0 {
+		return []*models.RoutingCacheEntry{}, nil
+	}
+
+	entries, err := queryRows(ctx, rcRepo.pool, scanRoutingCacheEntry,
+		"SELECT "+routingCacheEntryColumns+" FROM routing_cache_entries WHERE child_playlist_id = ANY($1) AND user_id = $2",
+		childPlaylistIDs, userID,
+	)
+	if err != nil {
+		rcRepo.log.ErrorContext(ctx, "unable to fetch routing_cache records", "user_id", userID, "error", err)
+		return nil, dbError(err)
+	}
+
+	return entries, nil
+}
+
+func scanRoutingCacheEntry(row pgx.Row) (*models.RoutingCacheEntry, error) {
+	entry := &models.RoutingCacheEntry{}
+	err := row.Scan(&entry.ID, &entry.UserID, &entry.ChildPlaylistID, &entry.SnapshotID, &entry.FilterHash, &entry.TrackURIs, &entry.Created, &entry.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.TrackURIs = stringsOrEmpty(entry.TrackURIs)
+	return entry, nil
+}
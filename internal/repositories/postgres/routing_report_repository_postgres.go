@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const routingReportColumns = "id, user_id, base_playlist_id, sync_event_id, dedupe_strategy, tracks, created, updated"
+
+type RoutingReportRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewRoutingReportRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *RoutingReportRepositoryPostgres {
+	return &RoutingReportRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "RoutingReportRepositoryPostgres"),
+	}
+}
+
+func (rrRepo *RoutingReportRepositoryPostgres) Upsert(ctx context.Context, report *models.RoutingReport) (*models.RoutingReport, error) {
+	trackDecisions := report.Tracks
+	if trackDecisions == nil {
+		trackDecisions = []models.TrackRoutingDecision{}
+	}
+
+	tracks, err := toJSON(trackDecisions)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	// The report of a base playlist of another user is left untouched and nothing is returned
+	row := conn(ctx, rrRepo.pool).QueryRow(ctx,
+		`INSERT INTO routing_reports (id, user_id, base_playlist_id, sync_event_id, dedupe_strategy, tracks)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (base_playlist_id) DO UPDATE SET
+			sync_event_id = EXCLUDED.sync_event_id,
+			dedupe_strategy = EXCLUDED.dedupe_strategy,
+			tracks = EXCLUDED.tracks,
+			updated = now()
+		WHERE routing_reports.user_id = EXCLUDED.user_id
+		RETURNING `+routingReportColumns,
+		newID(), report.UserID, report.BasePlaylistID, report.SyncEventID, string(report.DedupeStrategy), tracks,
+	)
+
+	stored, err := scanRoutingReport(row)
+	if isNoRows(err) {
+		rrRepo.log.ErrorContext(ctx, "unauthorized upsert attempt",
+			"base_playlist_id", report.BasePlaylistID,
+			"user_id", report.UserID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+	if err != nil {
+		rrRepo.log.ErrorContext(ctx, "unable to store routing_report record", "base_playlist_id", report.BasePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	rrRepo.log.InfoContext(ctx, "routing_report stored successfully", "id", stored.ID, "track_count", len(trackDecisions))
+	return stored, nil
+}
+
+func (rrRepo *RoutingReportRepositoryPostgres) GetBySyncEventID(ctx context.Context, syncEventID, userID string) (*models.RoutingReport, error) {
+	row := conn(ctx, rrRepo.pool).QueryRow(ctx, "SELECT "+routingReportColumns+" FROM routing_reports WHERE sync_event_id = $1 LIMIT 1", syncEventID)
+	report, err := scanRoutingReport(row)
+	if err != nil {
+		return nil, repositories.ErrRoutingReportNotFound
+	}
+
+	// Check ownership
+	if report.UserID != userID {
+		rrRepo.log.ErrorContext(ctx, "unauthorized access attempt",
+			"sync_event_id", syncEventID,
+			"requested_by", userID,
+		)
+		return nil, repositories.ErrUnauthorized
+	}
+
+	return report, nil
+}
+
+func scanRoutingReport(row pgx.Row) (*models.RoutingReport, error) {
+	report := &models.RoutingReport{}
+	var dedupeStrategy string
+	var tracks []byte
+	err := row.Scan(&report.ID, &report.UserID, &report.BasePlaylistID, &report.SyncEventID, &dedupeStrategy, &tracks, &report.Created, &report.Updated)
+	if err != nil {
+		return nil, err
+	}
+
+	report.DedupeStrategy = models.DedupeStrategy(dedupeStrategy)
+	if !fromJSON(tracks, &report.Tracks) || report.Tracks == nil {
+		report.Tracks = []models.TrackRoutingDecision{}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,298 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const spotifyIntegrationColumns = `id, "user", spotify_id, access_token, refresh_token, token_type, expires_at, scope, display_name,
+	provider, created, updated`
+
+type SpotifyIntegrationRepositoryPostgres struct {
+	pool        *pgxpool.Pool
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
+}
+
+func NewSpotifyIntegrationRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *SpotifyIntegrationRepositoryPostgres {
+	return &SpotifyIntegrationRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "SpotifyIntegrationRepositoryPostgres"),
+	}
+}
+
+// WithTokenCipher encrypts the stored Spotify tokens with the data key of their user
+func (siRepo *SpotifyIntegrationRepositoryPostgres) WithTokenCipher(tokenCipher repositories.TokenCipher) *SpotifyIntegrationRepositoryPostgres {
+	siRepo.tokenCipher = tokenCipher
+	return siRepo
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) CreateOrUpdate(
+	ctx context.Context,
+	userId string,
+	integration *models.SpotifyIntegration,
+) (*models.SpotifyIntegration, error) {
+	accessToken, err := siRepo.encryptToken(ctx, userId, integration.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := siRepo.encryptToken(ctx, userId, integration.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, siRepo.pool).QueryRow(ctx,
+		`INSERT INTO spotify_integrations (id, "user", spotify_id, access_token, refresh_token, token_type, expires_at, scope, display_name, provider)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT ("user", spotify_id) DO UPDATE SET
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			token_type = EXCLUDED.token_type,
+			expires_at = EXCLUDED.expires_at,
+			scope = EXCLUDED.scope,
+			display_name = EXCLUDED.display_name,
+			updated = now()
+		RETURNING `+spotifyIntegrationColumns,
+		newID(), userId, integration.SpotifyID, accessToken, refreshToken, integration.TokenType, integration.ExpiresAt,
+		integration.Scope, integration.DisplayName, string(integration.Provider.OrDefault()),
+	)
+
+	stored, err := scanSpotifyIntegration(row)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to store spotify_integration record", "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	siRepo.log.InfoContext(ctx, "spotify_integration stored successfully", "user", userId, "spotify_id", integration.SpotifyID)
+	return siRepo.decryptTokens(ctx, stored)
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) GetByUserID(ctx context.Context, userId string) (*models.SpotifyIntegration, error) {
+	row := conn(ctx, siRepo.pool).QueryRow(ctx,
+		"SELECT "+spotifyIntegrationColumns+` FROM spotify_integrations WHERE "user" = $1 ORDER BY created, id LIMIT 1`,
+		userId,
+	)
+	integration, err := scanSpotifyIntegration(row)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integration", "user", userId, "error", err)
+		return nil, repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	siRepo.log.InfoContext(ctx, "spotify_integration found", "user", userId, "spotify_id", integration.ID)
+	return siRepo.decryptTokens(ctx, integration)
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) GetByID(ctx context.Context, id, userId string) (*models.SpotifyIntegration, error) {
+	row := conn(ctx, siRepo.pool).QueryRow(ctx, "SELECT "+spotifyIntegrationColumns+" FROM spotify_integrations WHERE id = $1", id)
+	integration, err := scanSpotifyIntegration(row)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integration", "integration_id", id, "error", err)
+		return nil, repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	// Integrations of other users are reported as not found
+	if integration.UserID != userId {
+		siRepo.log.ErrorContext(ctx, "unauthorized spotify_integration access attempt", "integration_id", id, "requested_by", userId)
+		return nil, repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	return siRepo.decryptTokens(ctx, integration)
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) ListByUserID(ctx context.Context, userId string) ([]*models.SpotifyIntegration, error) {
+	integrations, err := siRepo.query(ctx,
+		"SELECT "+spotifyIntegrationColumns+` FROM spotify_integrations WHERE "user" = $1 ORDER BY created, id`,
+		userId,
+	)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integrations", "user", userId, "error", err)
+		return nil, err
+	}
+
+	return integrations, nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) GetBySpotifyID(ctx context.Context, spotifyId string) (*models.SpotifyIntegration, error) {
+	row := conn(ctx, siRepo.pool).QueryRow(ctx,
+		"SELECT "+spotifyIntegrationColumns+" FROM spotify_integrations WHERE spotify_id = $1 ORDER BY created, id LIMIT 1",
+		spotifyId,
+	)
+	integration, err := scanSpotifyIntegration(row)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integration", "spotify_id", spotifyId, "error", err)
+		return nil, repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	siRepo.log.InfoContext(ctx, "spotify_integration found", "spotify_id", integration.ID)
+	return siRepo.decryptTokens(ctx, integration)
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) UpdateTokens(
+	ctx context.Context,
+	integrationId string,
+	tokens *models.SpotifyIntegrationTokenRefresh,
+) error {
+	var userId string
+	err := conn(ctx, siRepo.pool).QueryRow(ctx, `SELECT "user" FROM spotify_integrations WHERE id = $1`, integrationId).Scan(&userId)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch spotify_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	accessToken, err := siRepo.encryptToken(ctx, userId, tokens.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	// The refresh token is only replaced when Spotify rotated it
+	var refreshToken *string
+	if tokens.RefreshToken != "" {
+		encrypted, err := siRepo.encryptToken(ctx, userId, tokens.RefreshToken)
+		if err != nil {
+			return err
+		}
+		refreshToken = &encrypted
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+	_, err = conn(ctx, siRepo.pool).Exec(ctx,
+		`UPDATE spotify_integrations
+		SET access_token = $2, refresh_token = COALESCE($3, refresh_token), expires_at = $4, updated = now()
+		WHERE id = $1`,
+		integrationId, accessToken, refreshToken, expiresAt,
+	)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to update spotify_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	siRepo.log.InfoContext(ctx, "spotify_integration tokens updated", "spotify_id", integrationId)
+	return nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) GetExpiringBefore(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error) {
+	integrations, err := siRepo.query(ctx,
+		"SELECT "+spotifyIntegrationColumns+" FROM spotify_integrations WHERE expires_at < $1 ORDER BY expires_at",
+		before,
+	)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to fetch expiring spotify_integrations", "before", before, "error", err)
+		return nil, err
+	}
+
+	return integrations, nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) Delete(ctx context.Context, userId string) error {
+	tag, err := conn(ctx, siRepo.pool).Exec(ctx, `DELETE FROM spotify_integrations WHERE "user" = $1`, userId)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to delete spotify_integration", "user", userId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+	if tag.RowsAffected() == 0 {
+		siRepo.log.ErrorContext(ctx, "spotify_integration not found", "user", userId)
+		return repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	siRepo.log.InfoContext(ctx, "spotify_integrations deleted", "user", userId, "count", tag.RowsAffected())
+	return nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) DeleteByID(ctx context.Context, id, userId string) error {
+	tag, err := conn(ctx, siRepo.pool).Exec(ctx, `DELETE FROM spotify_integrations WHERE id = $1 AND "user" = $2`, id, userId)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to delete spotify_integration", "user", userId, "integration_id", id, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+	if tag.RowsAffected() == 0 {
+		siRepo.log.ErrorContext(ctx, "spotify_integration not found", "integration_id", id, "user", userId)
+		return repositories.ErrSpotifyIntegrationNotFound
+	}
+
+	siRepo.log.InfoContext(ctx, "spotify_integration deleted", "user", userId, "integration_id", id)
+	return nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) query(ctx context.Context, sql string, args ...any) ([]*models.SpotifyIntegration, error) {
+	rows, err := conn(ctx, siRepo.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	records, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.SpotifyIntegration, error) {
+		return scanSpotifyIntegration(row)
+	})
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	integrations := make([]*models.SpotifyIntegration, 0, len(records))
+	for _, record := range records {
+		integration, err := siRepo.decryptTokens(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, integration)
+	}
+
+	return integrations, nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) encryptToken(ctx context.Context, userId, token string) (string, error) {
+	if siRepo.tokenCipher == nil || token == "" {
+		return token, nil
+	}
+
+	encryptedToken, err := siRepo.tokenCipher.EncryptForUser(ctx, userId, token)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to encrypt spotify token", "user", userId, "error", err)
+		return "", dbError(err)
+	}
+
+	return encryptedToken, nil
+}
+
+func (siRepo *SpotifyIntegrationRepositoryPostgres) decryptTokens(ctx context.Context, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error) {
+	if siRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	accessToken, err := siRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.AccessToken)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to decrypt spotify access token", "user", integration.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	refreshToken, err := siRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.RefreshToken)
+	if err != nil {
+		siRepo.log.ErrorContext(ctx, "unable to decrypt spotify refresh token", "user", integration.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	integration.AccessToken = accessToken
+	integration.RefreshToken = refreshToken
+	return integration, nil
+}
+
+func scanSpotifyIntegration(row pgx.Row) (*models.SpotifyIntegration, error) {
+	integration := &models.SpotifyIntegration{}
+	var provider string
+	err := row.Scan(
+		&integration.ID, &integration.UserID, &integration.SpotifyID, &integration.AccessToken, &integration.RefreshToken,
+		&integration.TokenType, &integration.ExpiresAt, &integration.Scope, &integration.DisplayName,
+		&provider, &integration.Created, &integration.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	integration.Provider = models.MusicProvider(provider).OrDefault()
+
+	return integration, nil
+}
@@ -0,0 +1,346 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const syncEventColumns = `id, user_id, base_playlist_id, child_playlist_ids, status, phase, started_at, completed_at, heartbeat_at,
+	error_message, request_id, tracks_processed, tracks_unmatched, total_api_requests, child_sync_results, anomalies, profile,
+	rate_limit, created, updated`
+
+type SyncEventRepositoryPostgres struct {
+	pool     *pgxpool.Pool
+	readPool *pgxpool.Pool
+	log      *slog.Logger
+}
+
+func NewSyncEventRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *SyncEventRepositoryPostgres {
+	return &SyncEventRepositoryPostgres{
+		pool:     pool,
+		readPool: pool,
+		log:      logger.With("component", "SyncEventRepositoryPostgres"),
+	}
+}
+
+// WithReadPool routes the list queries of the repository through a dedicated read pool, a nil
+// pool keeps them on the primary one
+func (seRepo *SyncEventRepositoryPostgres) WithReadPool(readPool *pgxpool.Pool) *SyncEventRepositoryPostgres {
+	if readPool != nil {
+		seRepo.readPool = readPool
+	}
+	return seRepo
+}
+
+func (seRepo *SyncEventRepositoryPostgres) Create(ctx context.Context, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+	documents, err := syncEventDocuments(syncEvent)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to serialize sync_event", "error", err)
+		return nil, dbError(err)
+	}
+
+	row := conn(ctx, seRepo.pool).QueryRow(ctx,
+		`INSERT INTO sync_events (id, user_id, base_playlist_id, child_playlist_ids, status, phase, started_at, completed_at,
+			heartbeat_at, error_message, request_id, tracks_processed, tracks_unmatched, total_api_requests, child_sync_results,
+			anomalies, profile, rate_limit)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, ''), $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING `+syncEventColumns,
+		newID(), syncEvent.UserID, syncEvent.BasePlaylistID, stringsOrEmpty(syncEvent.ChildPlaylistIDs), string(syncEvent.Status),
+		string(syncEvent.Phase), syncEvent.StartedAt, timeOrNil(syncEvent.CompletedAt), timeOrNil(syncEvent.HeartbeatAt),
+		syncEvent.ErrorMessage, syncEvent.RequestID, syncEvent.TracksProcessed, syncEvent.TracksUnmatched, syncEvent.TotalAPIRequests,
+		documents[0], documents[1], documents[2], documents[3],
+	)
+
+	created, err := scanSyncEvent(row)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to store sync_event record", "base_playlist_id", syncEvent.BasePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_event stored successfully", "id", created.ID)
+	return created, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) Update(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+	documents, err := syncEventDocuments(syncEvent)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to serialize sync_event", "id", id, "error", err)
+		return nil, dbError(err)
+	}
+
+	// Child playlist IDs, documents and optional fields left nil keep their value, an empty list of
+	// child playlist IDs clears them
+	var childPlaylistIDs []string
+	if syncEvent.ChildPlaylistIDs != nil {
+		childPlaylistIDs = syncEvent.ChildPlaylistIDs
+	}
+
+	row := conn(ctx, seRepo.pool).QueryRow(ctx,
+		`UPDATE sync_events SET
+			status = $2,
+			phase = $3,
+			tracks_processed = $4,
+			tracks_unmatched = $5,
+			total_api_requests = $6,
+			child_playlist_ids = COALESCE($7, child_playlist_ids),
+			child_sync_results = COALESCE($8, child_sync_results),
+			anomalies = COALESCE($9, anomalies),
+			profile = COALESCE($10, profile),
+			rate_limit = COALESCE($11, rate_limit),
+			completed_at = COALESCE($12, completed_at),
+			error_message = COALESCE($13, error_message),
+			heartbeat_at = COALESCE($14, heartbeat_at),
+			updated = now()
+		WHERE id = $1
+		RETURNING `+syncEventColumns,
+		id, string(syncEvent.Status), string(syncEvent.Phase), syncEvent.TracksProcessed, syncEvent.TracksUnmatched,
+		syncEvent.TotalAPIRequests, childPlaylistIDs, documents[0], documents[1], documents[2], documents[3],
+		timeOrNil(syncEvent.CompletedAt), syncEvent.ErrorMessage, timeOrNil(syncEvent.HeartbeatAt),
+	)
+
+	updated, err := scanSyncEvent(row)
+	if isNoRows(err) {
+		seRepo.log.ErrorContext(ctx, "unable to find sync_event record", "id", id, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrSyncEventNotFound, err.Error())
+	}
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to update sync_event record", "id", id, "error", err)
+		return nil, dbError(err)
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_event updated successfully", "id", id)
+	return updated, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) GetByID(ctx context.Context, id string) (*models.SyncEvent, error) {
+	row := conn(ctx, seRepo.pool).QueryRow(ctx, "SELECT "+syncEventColumns+" FROM sync_events WHERE id = $1", id)
+	syncEvent, err := scanSyncEvent(row)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to find sync_event record", "id", id, "error", err)
+		return nil, fmt.Errorf(`%w: %s`, repositories.ErrSyncEventNotFound, err.Error())
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_event retrieved successfully", "id", id)
+	return syncEvent, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) TransitionStatus(ctx context.Context, id string, from, to models.SyncStatus) error {
+	// The row stays locked until the transaction ends, so no other write lands between the check and the update
+	err := pgx.BeginFunc(ctx, conn(ctx, seRepo.pool), func(tx pgx.Tx) error {
+		var status string
+		err := tx.QueryRow(ctx, "SELECT status FROM sync_events WHERE id = $1 FOR UPDATE", id).Scan(&status)
+		if isNoRows(err) {
+			return fmt.Errorf(`%w: %s`, repositories.ErrSyncEventNotFound, err.Error())
+		}
+		if err != nil {
+			return dbError(err)
+		}
+
+		if status != string(from) {
+			return fmt.Errorf("%w: %s is %s", repositories.ErrSyncEventStatusChanged, id, status)
+		}
+
+		if _, err := tx.Exec(ctx, "UPDATE sync_events SET status = $2, updated = now() WHERE id = $1", id, string(to)); err != nil {
+			return dbError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to transition sync_event status", "id", id, "from", from, "to", to, "error", err)
+		return err
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_event status transitioned", "id", id, "from", from, "to", to)
+	return nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) GetByUserID(ctx context.Context, userID string) ([]*models.SyncEvent, error) {
+	syncEvents, err := queryRows(ctx, seRepo.readPool, scanSyncEvent,
+		"SELECT "+syncEventColumns+" FROM sync_events WHERE user_id = $1 ORDER BY created DESC", // Newest first
+		userID,
+	)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to find sync_event records for user", "user_id", userID, "error", err)
+		return nil, dbError(err)
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_events retrieved successfully", "user_id", userID, "count", len(syncEvents))
+	return syncEvents, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) GetByBasePlaylistID(ctx context.Context, basePlaylistID string) ([]*models.SyncEvent, error) {
+	syncEvents, err := queryRows(ctx, seRepo.readPool, scanSyncEvent,
+		"SELECT "+syncEventColumns+" FROM sync_events WHERE base_playlist_id = $1 ORDER BY created DESC", // Newest first
+		basePlaylistID,
+	)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to find sync_event records for base playlist", "base_playlist_id", basePlaylistID, "error", err)
+		return nil, dbError(err)
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_events retrieved successfully", "base_playlist_id", basePlaylistID, "count", len(syncEvents))
+	return syncEvents, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) GetRecentFailed(ctx context.Context, limit int) ([]*models.SyncEvent, error) {
+	syncEvents, err := queryRows(ctx, seRepo.pool, scanSyncEvent,
+		"SELECT "+syncEventColumns+" FROM sync_events WHERE status = $1 ORDER BY created DESC"+limitOffset(limit, 0),
+		string(models.SyncStatusFailed),
+	)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to find failed sync_event records", "error", err)
+		return nil, dbError(err)
+	}
+
+	seRepo.log.InfoContext(ctx, "failed sync_events retrieved successfully", "count", len(syncEvents))
+	return syncEvents, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) List(ctx context.Context, filter repositories.SyncEventFilter) ([]*models.SyncEvent, error) {
+	where, args := syncEventFilterCondition(filter)
+	syncEvents, err := queryRows(ctx, seRepo.pool, scanSyncEvent,
+		"SELECT "+syncEventColumns+" FROM sync_events WHERE "+where+" ORDER BY "+listOrderBy(filter.Sort)+limitOffset(filter.Limit, filter.Offset),
+		args...,
+	)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to list sync_event records", "filter", filter, "error", err)
+		return nil, dbError(err)
+	}
+
+	seRepo.log.InfoContext(ctx, "sync_events listed successfully", "filter", filter, "count", len(syncEvents))
+	return syncEvents, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) Count(ctx context.Context, filter repositories.SyncEventFilter) (int, error) {
+	where, args := syncEventFilterCondition(filter)
+
+	var total int
+	if err := conn(ctx, seRepo.pool).QueryRow(ctx, "SELECT count(*) FROM sync_events WHERE "+where, args...).Scan(&total); err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to count sync_event records", "filter", filter, "error", err)
+		return 0, dbError(err)
+	}
+
+	return total, nil
+}
+
+func (seRepo *SyncEventRepositoryPostgres) Prune(ctx context.Context, filter repositories.SyncEventPruneFilter) (int, error) {
+	where, args := syncEventFilterCondition(repositories.SyncEventFilter{
+		UserID:         filter.UserID,
+		BasePlaylistID: filter.BasePlaylistID,
+		CreatedBefore:  filter.CreatedBefore,
+	})
+	args = append(args, string(models.SyncStatusCompleted), string(models.SyncStatusFailed), string(models.SyncStatusConfirmed))
+
+	// Their playlist snapshots are cascade deleted along
+	tag, err := conn(ctx, seRepo.pool).Exec(ctx, "DELETE FROM sync_events WHERE "+where+fmt.Sprintf(` AND status IN ($%[1]d, $%[2]d, $%[3]d)
+		AND id IS DISTINCT FROM (SELECT latest.id FROM sync_events latest
+			WHERE latest.base_playlist_id = sync_events.base_playlist_id
+			ORDER BY latest.created DESC, latest.id DESC LIMIT 1)
+		AND id IS DISTINCT FROM (SELECT latest.id FROM sync_events latest
+			WHERE latest.base_playlist_id = sync_events.base_playlist_id AND latest.status = $%[1]d
+			ORDER BY latest.created DESC, latest.id DESC LIMIT 1)`, len(args)-2, len(args)-1, len(args)),
+		args...,
+	)
+	if err != nil {
+		seRepo.log.ErrorContext(ctx, "unable to prune sync_event records", "filter", filter, "error", err)
+		return 0, dbError(err)
+	}
+
+	pruned := int(tag.RowsAffected())
+	seRepo.log.InfoContext(ctx, "sync_events pruned", "filter", filter, "pruned", pruned)
+	return pruned, nil
+}
+
+// syncEventFilterCondition matches the sync events of filter, ignoring its pagination
+func syncEventFilterCondition(filter repositories.SyncEventFilter) (string, []any) {
+	where := "TRUE"
+	args := []any{}
+	add := func(condition string, value any) {
+		args = append(args, value)
+		where += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", string(filter.Status))
+	}
+	if filter.UserID != "" {
+		add("user_id = $%d", filter.UserID)
+	}
+	if filter.BasePlaylistID != "" {
+		add("base_playlist_id = $%d", filter.BasePlaylistID)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		add("created >= $%d", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		add("created < $%d", filter.CreatedBefore)
+	}
+
+	return where, args
+}
+
+// syncEventDocuments encodes the child sync results, anomalies, profile and rate limit stats of the
+// sync event, in that order
+func syncEventDocuments(syncEvent *models.SyncEvent) ([4][]byte, error) {
+	var documents [4][]byte
+	for i, value := range []any{syncEvent.ChildSyncResults, syncEvent.Anomalies, syncEvent.Profile, syncEvent.RateLimit} {
+		document, err := toJSON(value)
+		if err != nil {
+			return documents, err
+		}
+		documents[i] = document
+	}
+
+	return documents, nil
+}
+
+func scanSyncEvent(row pgx.Row) (*models.SyncEvent, error) {
+	syncEvent := &models.SyncEvent{}
+	var status, phase, errorMessage string
+	var childSyncResults, anomalies, profile, rateLimit []byte
+	var completedAt, heartbeatAt *time.Time
+	err := row.Scan(
+		&syncEvent.ID, &syncEvent.UserID, &syncEvent.BasePlaylistID, &syncEvent.ChildPlaylistIDs, &status, &phase,
+		&syncEvent.StartedAt, &completedAt, &heartbeatAt, &errorMessage, &syncEvent.RequestID, &syncEvent.TracksProcessed,
+		&syncEvent.TracksUnmatched, &syncEvent.TotalAPIRequests, &childSyncResults, &anomalies, &profile, &rateLimit,
+		&syncEvent.Created, &syncEvent.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	syncEvent.Status = models.SyncStatus(status)
+	syncEvent.Phase = models.SyncPhase(phase)
+	if syncEvent.ChildPlaylistIDs == nil {
+		syncEvent.ChildPlaylistIDs = []string{}
+	}
+
+	if !fromJSON(childSyncResults, &syncEvent.ChildSyncResults) || syncEvent.ChildSyncResults == nil {
+		syncEvent.ChildSyncResults = []models.ChildSyncResult{}
+	}
+	fromJSON(anomalies, &syncEvent.Anomalies)
+	fromJSON(profile, &syncEvent.Profile)
+	fromJSON(rateLimit, &syncEvent.RateLimit)
+
+	if completedAt != nil {
+		syncEvent.CompletedAt = completedAt
+
+		durationMs := completedAt.Sub(syncEvent.StartedAt).Milliseconds()
+		syncEvent.DurationMs = &durationMs
+	}
+	syncEvent.HeartbeatAt = heartbeatAt
+
+	if errorMessage != "" {
+		syncEvent.ErrorMessage = &errorMessage
+	}
+
+	return syncEvent, nil
+}
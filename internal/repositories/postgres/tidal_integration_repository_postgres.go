@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const tidalIntegrationColumns = `id, "user", tidal_user_id, country_code, access_token, refresh_token, token_type, expires_at, scope, display_name,
+	created, updated`
+
+type TidalIntegrationRepositoryPostgres struct {
+	pool        *pgxpool.Pool
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
+}
+
+func NewTidalIntegrationRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *TidalIntegrationRepositoryPostgres {
+	return &TidalIntegrationRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "TidalIntegrationRepositoryPostgres"),
+	}
+}
+
+// WithTokenCipher encrypts the stored Tidal tokens with the data key of their user
+func (tRepo *TidalIntegrationRepositoryPostgres) WithTokenCipher(tokenCipher repositories.TokenCipher) *TidalIntegrationRepositoryPostgres {
+	tRepo.tokenCipher = tokenCipher
+	return tRepo
+}
+
+func (tRepo *TidalIntegrationRepositoryPostgres) CreateOrUpdate(
+	ctx context.Context,
+	userId string,
+	integration *models.TidalIntegration,
+) (*models.TidalIntegration, error) {
+	accessToken, err := tRepo.encryptToken(ctx, userId, integration.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := tRepo.encryptToken(ctx, userId, integration.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, tRepo.pool).QueryRow(ctx,
+		`INSERT INTO tidal_integrations (id, "user", tidal_user_id, country_code, access_token, refresh_token, token_type, expires_at, scope, display_name)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT ("user") DO UPDATE SET
+			tidal_user_id = EXCLUDED.tidal_user_id,
+			country_code = EXCLUDED.country_code,
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			token_type = EXCLUDED.token_type,
+			expires_at = EXCLUDED.expires_at,
+			scope = EXCLUDED.scope,
+			display_name = EXCLUDED.display_name,
+			updated = now()
+		RETURNING `+tidalIntegrationColumns,
+		newID(), userId, integration.TidalUserID, integration.CountryCode, accessToken, refreshToken, integration.TokenType, integration.ExpiresAt,
+		integration.Scope, integration.DisplayName,
+	)
+
+	stored, err := scanTidalIntegration(row)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to store tidal_integration record", "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	tRepo.log.InfoContext(ctx, "tidal_integration stored successfully", "user", userId, "tidal_user_id", integration.TidalUserID)
+	return tRepo.decryptTokens(ctx, stored)
+}
+
+func (tRepo *TidalIntegrationRepositoryPostgres) GetByUserID(ctx context.Context, userId string) (*models.TidalIntegration, error) {
+	row := conn(ctx, tRepo.pool).QueryRow(ctx,
+		"SELECT "+tidalIntegrationColumns+` FROM tidal_integrations WHERE "user" = $1`,
+		userId,
+	)
+	integration, err := scanTidalIntegration(row)
+	if err != nil {
+		tRepo.log.InfoContext(ctx, "unable to fetch tidal_integration", "user", userId, "error", err)
+		return nil, repositories.ErrTidalIntegrationNotFound
+	}
+
+	return tRepo.decryptTokens(ctx, integration)
+}
+
+func (tRepo *TidalIntegrationRepositoryPostgres) UpdateTokens(
+	ctx context.Context,
+	integrationId string,
+	tokens *models.TidalIntegrationTokenRefresh,
+) error {
+	var userId string
+	err := conn(ctx, tRepo.pool).QueryRow(ctx, `SELECT "user" FROM tidal_integrations WHERE id = $1`, integrationId).Scan(&userId)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to fetch tidal_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrTidalIntegrationNotFound
+	}
+
+	accessToken, err := tRepo.encryptToken(ctx, userId, tokens.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	// Tidal usually keeps the refresh token, only replacing it when it sends one
+	var refreshToken *string
+	if tokens.RefreshToken != "" {
+		encrypted, err := tRepo.encryptToken(ctx, userId, tokens.RefreshToken)
+		if err != nil {
+			return err
+		}
+		refreshToken = &encrypted
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+	_, err = conn(ctx, tRepo.pool).Exec(ctx,
+		`UPDATE tidal_integrations
+		SET access_token = $2, refresh_token = COALESCE($3, refresh_token), expires_at = $4, updated = now()
+		WHERE id = $1`,
+		integrationId, accessToken, refreshToken, expiresAt,
+	)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to update tidal_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	tRepo.log.InfoContext(ctx, "tidal_integration tokens updated", "integration_id", integrationId)
+	return nil
+}
+
+func (tRepo *TidalIntegrationRepositoryPostgres) Delete(ctx context.Context, userId string) error {
+	tag, err := conn(ctx, tRepo.pool).Exec(ctx, `DELETE FROM tidal_integrations WHERE "user" = $1`, userId)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to delete tidal_integration", "user", userId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+	if tag.RowsAffected() == 0 {
+		tRepo.log.ErrorContext(ctx, "tidal_integration not found", "user", userId)
+		return repositories.ErrTidalIntegrationNotFound
+	}
+
+	tRepo.log.InfoContext(ctx, "tidal_integration deleted", "user", userId)
+	return nil
+}
+
+func (tRepo *TidalIntegrationRepositoryPostgres) encryptToken(ctx context.Context, userId, token string) (string, error) {
+	if tRepo.tokenCipher == nil || token == "" {
+		return token, nil
+	}
+
+	encryptedToken, err := tRepo.tokenCipher.EncryptForUser(ctx, userId, token)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to encrypt tidal token", "user", userId, "error", err)
+		return "", dbError(err)
+	}
+
+	return encryptedToken, nil
+}
+
+func (tRepo *TidalIntegrationRepositoryPostgres) decryptTokens(ctx context.Context, integration *models.TidalIntegration) (*models.TidalIntegration, error) {
+	if tRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	accessToken, err := tRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.AccessToken)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to decrypt tidal access token", "user", integration.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	refreshToken, err := tRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.RefreshToken)
+	if err != nil {
+		tRepo.log.ErrorContext(ctx, "unable to decrypt tidal refresh token", "user", integration.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	integration.AccessToken = accessToken
+	integration.RefreshToken = refreshToken
+	return integration, nil
+}
+
+func scanTidalIntegration(row pgx.Row) (*models.TidalIntegration, error) {
+	integration := &models.TidalIntegration{}
+	err := row.Scan(
+		&integration.ID, &integration.UserID, &integration.TidalUserID, &integration.CountryCode, &integration.AccessToken, &integration.RefreshToken,
+		&integration.TokenType, &integration.ExpiresAt, &integration.Scope, &integration.DisplayName,
+		&integration.Created, &integration.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
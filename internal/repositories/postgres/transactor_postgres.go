@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// querier runs the queries of the repositories, either on the pool or on the transaction the context
+// runs in. Begin on a transaction opens a savepoint
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type TransactorPostgres struct {
+	pool *pgxpool.Pool
+}
+
+func NewTransactorPostgres(pool *pgxpool.Pool) *TransactorPostgres {
+	return &TransactorPostgres{pool: pool}
+}
+
+// RunInTransaction runs fn in a transaction, the repositories called with the context given to fn
+// query through it
+func (t *TransactorPostgres) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgx.BeginFunc(ctx, conn(ctx, t.pool), func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction the context runs in, or the pool outside of one
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const userEncryptionKeyColumns = "id, user_id, wrapped_key, key_version, created, updated"
+
+type UserEncryptionKeyRepositoryPostgres struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+func NewUserEncryptionKeyRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *UserEncryptionKeyRepositoryPostgres {
+	return &UserEncryptionKeyRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "UserEncryptionKeyRepositoryPostgres"),
+	}
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPostgres) Create(ctx context.Context, userID, wrappedKey string, keyVersion int) (*models.UserEncryptionKey, error) {
+	row := conn(ctx, ukRepo.pool).QueryRow(ctx,
+		`INSERT INTO user_encryption_keys (id, user_id, wrapped_key, key_version)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+userEncryptionKeyColumns,
+		newID(), userID, wrappedKey, keyVersion,
+	)
+
+	key, err := scanUserEncryptionKey(row)
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to store user_encryption_key record", "user_id", userID, "error", err)
+		return nil, dbError(err)
+	}
+
+	ukRepo.log.InfoContext(ctx, "user_encryption_key stored successfully", "user_id", userID, "key_version", keyVersion)
+	return key, nil
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPostgres) GetByUserID(ctx context.Context, userID string) (*models.UserEncryptionKey, error) {
+	row := conn(ctx, ukRepo.pool).QueryRow(ctx, "SELECT "+userEncryptionKeyColumns+" FROM user_encryption_keys WHERE user_id = $1", userID)
+	key, err := scanUserEncryptionKey(row)
+	if err != nil {
+		return nil, repositories.ErrUserEncryptionKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPostgres) GetOutdated(ctx context.Context, currentVersion int) ([]*models.UserEncryptionKey, error) {
+	keys, err := queryRows(ctx, ukRepo.pool, scanUserEncryptionKey,
+		"SELECT "+userEncryptionKeyColumns+" FROM user_encryption_keys WHERE key_version <> $1 ORDER BY created",
+		currentVersion,
+	)
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to find outdated user_encryption_key records", "current_version", currentVersion, "error", err)
+		return nil, dbError(err)
+	}
+
+	ukRepo.log.InfoContext(ctx, "outdated user_encryption_keys retrieved successfully", "current_version", currentVersion, "count", len(keys))
+	return keys, nil
+}
+
+func (ukRepo *UserEncryptionKeyRepositoryPostgres) UpdateWrappedKey(ctx context.Context, id, wrappedKey string, keyVersion int) error {
+	tag, err := conn(ctx, ukRepo.pool).Exec(ctx,
+		"UPDATE user_encryption_keys SET wrapped_key = $2, key_version = $3, updated = now() WHERE id = $1",
+		id, wrappedKey, keyVersion,
+	)
+	if err != nil {
+		ukRepo.log.ErrorContext(ctx, "unable to update user_encryption_key record", "id", id, "error", err)
+		return dbError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		ukRepo.log.ErrorContext(ctx, "unable to find user_encryption_key record", "id", id)
+		return repositories.ErrUserEncryptionKeyNotFound
+	}
+
+	ukRepo.log.InfoContext(ctx, "user_encryption_key rewrapped successfully", "id", id, "key_version", keyVersion)
+	return nil
+}
+
+func scanUserEncryptionKey(row pgx.Row) (*models.UserEncryptionKey, error) {
+	key := &models.UserEncryptionKey{}
+	if err := row.Scan(&key.ID, &key.UserID, &key.WrappedKey, &key.KeyVersion, &key.Created, &key.Updated); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
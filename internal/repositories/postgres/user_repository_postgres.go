@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+// Auth tokens last as long as the ones PocketBase issues to users by default
+const AUTH_TOKEN_DURATION = 7 * 24 * time.Hour
+
+const (
+	TOKEN_KEY_LENGTH = 50
+	AUTH_TOKEN_TYPE  = "auth"
+)
+
+const userColumns = "id, email, username, name, disconnected_spotify_id, roles, disabled, created, updated"
+
+// UserRepositoryPostgres stores users and issues their auth tokens. Tokens are signed with the auth
+// secret and the token key of their user, so rotating the key revokes them like in PocketBase
+type UserRepositoryPostgres struct {
+	pool       *pgxpool.Pool
+	authSecret string
+	log        *slog.Logger
+}
+
+type authTokenClaims struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	jwt.RegisteredClaims
+}
+
+func NewUserRepositoryPostgres(pool *pgxpool.Pool, authSecret string, logger *slog.Logger) *UserRepositoryPostgres {
+	return &UserRepositoryPostgres{
+		pool:       pool,
+		authSecret: authSecret,
+		log:        logger.With("component", "UserRepositoryPostgres"),
+	}
+}
+
+func (uRepo *UserRepositoryPostgres) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	row := conn(ctx, uRepo.pool).QueryRow(ctx,
+		`INSERT INTO users (id, email, username, name, roles, token_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+userColumns,
+		newID(), user.Email, user.Username, user.Name, rolesOrDefault(user.Roles), newTokenKey(),
+	)
+
+	createdUser, err := scanUser(row)
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to store user record", "email", user.Email, "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	uRepo.log.InfoContext(ctx, "user created successfully", "user", createdUser)
+	return createdUser, nil
+}
+
+func (uRepo *UserRepositoryPostgres) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	row := conn(ctx, uRepo.pool).QueryRow(ctx,
+		`UPDATE users SET email = $2, username = $3, name = $4, disconnected_spotify_id = $5, updated = now()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		user.ID, user.Email, user.Username, user.Name, user.DisconnectedSpotifyID,
+	)
+
+	updatedUser, err := scanUser(row)
+	if isNoRows(err) {
+		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", user.ID, "error", err)
+		return nil, repositories.ErrUseNotFound
+	}
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to store user record", "user", user.ID, "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	uRepo.log.InfoContext(ctx, "user updated successfully", "user", updatedUser)
+	return updatedUser, nil
+}
+
+func (uRepo *UserRepositoryPostgres) GetByID(ctx context.Context, userID string) (*models.User, error) {
+	user, err := scanUser(conn(ctx, uRepo.pool).QueryRow(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1", userID))
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", userID, "error", err)
+		return nil, repositories.ErrUseNotFound
+	}
+
+	uRepo.log.InfoContext(ctx, "user retrieved successfully", "user", user)
+	return user, nil
+}
+
+func (uRepo *UserRepositoryPostgres) GetByDisconnectedSpotifyID(ctx context.Context, spotifyID string) (*models.User, error) {
+	if spotifyID == "" {
+		return nil, repositories.ErrUseNotFound
+	}
+
+	user, err := scanUser(conn(ctx, uRepo.pool).QueryRow(ctx,
+		"SELECT "+userColumns+" FROM users WHERE disconnected_spotify_id = $1 ORDER BY created LIMIT 1",
+		spotifyID,
+	))
+	if err != nil {
+		uRepo.log.InfoContext(ctx, "no user disconnected the spotify account", "spotify_id", spotifyID)
+		return nil, repositories.ErrUseNotFound
+	}
+
+	uRepo.log.InfoContext(ctx, "user retrieved by disconnected spotify id successfully", "user", user.ID, "spotify_id", spotifyID)
+	return user, nil
+}
+
+// Delete removes the user, the rows of every other table owned by the user cascade
+func (uRepo *UserRepositoryPostgres) Delete(ctx context.Context, userID string) error {
+	tag, err := conn(ctx, uRepo.pool).Exec(ctx, "DELETE FROM users WHERE id = $1", userID)
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to delete user record", "user", userID, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+	if tag.RowsAffected() == 0 {
+		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", userID)
+		return repositories.ErrUseNotFound
+	}
+
+	uRepo.log.InfoContext(ctx, "user deleted successfully", "user", userID)
+	return nil
+}
+
+func (uRepo *UserRepositoryPostgres) GenerateAuthToken(ctx context.Context, userID string) (string, error) {
+	var disabled bool
+	var tokenKey string
+	err := conn(ctx, uRepo.pool).QueryRow(ctx, "SELECT disabled, token_key FROM users WHERE id = $1", userID).Scan(&disabled, &tokenKey)
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to fetch user for token generation", "user", userID, "error", err)
+		return "", repositories.ErrUseNotFound
+	}
+
+	if disabled {
+		uRepo.log.WarnContext(ctx, "refusing auth token for disabled user", "user", userID)
+		return "", repositories.ErrUserDisabled
+	}
+
+	claims := authTokenClaims{
+		ID:   userID,
+		Type: AUTH_TOKEN_TYPE,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AUTH_TOKEN_DURATION)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(uRepo.signingKey(tokenKey))
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to generate auth token", "user", userID, "error", err)
+		return "", repositories.ErrDatabaseOperation
+	}
+
+	uRepo.log.InfoContext(ctx, "auth token generated successfully", "user", userID)
+	return token, nil
+}
+
+func (uRepo *UserRepositoryPostgres) ValidateAuthToken(ctx context.Context, token string) (*models.User, error) {
+	// The signature is verified against the user's token key, so tokens issued before a revocation
+	// are rejected along with expired and forged ones
+	var user *models.User
+	var claims authTokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(parsed *jwt.Token) (any, error) {
+		if claims.Type != AUTH_TOKEN_TYPE || claims.ID == "" {
+			return nil, errors.New("not an auth token")
+		}
+
+		var tokenKey string
+		row := conn(ctx, uRepo.pool).QueryRow(ctx, "SELECT "+userColumns+", token_key FROM users WHERE id = $1", claims.ID)
+		foundUser, err := scanUser(row, &tokenKey)
+		if err != nil {
+			return nil, err
+		}
+
+		user = foundUser
+		return uRepo.signingKey(tokenKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "invalid auth token", "error", err)
+		return nil, repositories.ErrUseNotFound
+	}
+
+	if user.Disabled {
+		uRepo.log.WarnContext(ctx, "auth token issued to disabled user", "user", user.ID)
+		return nil, repositories.ErrUserDisabled
+	}
+
+	uRepo.log.InfoContext(ctx, "auth token validated successfully", "user", user.ID)
+	return user, nil
+}
+
+// RevokeAuthTokens rotates the user's token key, which invalidates every auth token issued so far
+func (uRepo *UserRepositoryPostgres) RevokeAuthTokens(ctx context.Context, userID string) error {
+	tag, err := conn(ctx, uRepo.pool).Exec(ctx, "UPDATE users SET token_key = $2, updated = now() WHERE id = $1", userID, newTokenKey())
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to rotate user token key", "user", userID, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+	if tag.RowsAffected() == 0 {
+		uRepo.log.ErrorContext(ctx, "unable to fetch user for token revocation", "user", userID)
+		return repositories.ErrUseNotFound
+	}
+
+	uRepo.log.InfoContext(ctx, "auth tokens revoked successfully", "user", userID)
+	return nil
+}
+
+func (uRepo *UserRepositoryPostgres) Search(ctx context.Context, query string, limit, offset int) ([]*models.User, error) {
+	rows, err := conn(ctx, uRepo.pool).Query(ctx,
+		`SELECT `+userColumns+` FROM users
+		WHERE $1 = '' OR id = $1 OR strpos(lower(email), lower($1)) > 0 OR strpos(lower(name), lower($1)) > 0
+		ORDER BY created DESC, id DESC`+limitOffset(limit, offset),
+		query,
+	)
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to search users", "query", query, "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	users, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.User, error) {
+		return scanUser(row)
+	})
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to search users", "query", query, "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	uRepo.log.InfoContext(ctx, "users searched successfully", "query", query, "count", len(users))
+	return users, nil
+}
+
+// SetDisabled stores the flag and, when disabling, rotates the token key in the same update, so the
+// sessions of the user end along with the account
+func (uRepo *UserRepositoryPostgres) SetDisabled(ctx context.Context, userID string, disabled bool) (*models.User, error) {
+	row := conn(ctx, uRepo.pool).QueryRow(ctx,
+		`UPDATE users SET disabled = $2, token_key = CASE WHEN $2 THEN $3 ELSE token_key END, updated = now()
+		WHERE id = $1
+		RETURNING `+userColumns,
+		userID, disabled, newTokenKey(),
+	)
+
+	user, err := scanUser(row)
+	if isNoRows(err) {
+		uRepo.log.ErrorContext(ctx, "unable to fetch user", "user", userID, "error", err)
+		return nil, repositories.ErrUseNotFound
+	}
+	if err != nil {
+		uRepo.log.ErrorContext(ctx, "unable to store user record", "user", userID, "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	uRepo.log.InfoContext(ctx, "user disabled flag updated successfully", "user", userID, "disabled", disabled)
+	return user, nil
+}
+
+func (uRepo *UserRepositoryPostgres) signingKey(tokenKey string) []byte {
+	return []byte(tokenKey + uRepo.authSecret)
+}
+
+// newTokenKey returns a random per user key, mixed into the signing key of the user's auth tokens
+func newTokenKey() string {
+	key := ""
+	for len(key) < TOKEN_KEY_LENGTH {
+		key += newID()
+	}
+	return key[:TOKEN_KEY_LENGTH]
+}
+
+// scanUser reads a row of userColumns, followed by the extra columns given
+func scanUser(row pgx.Row, extra ...any) (*models.User, error) {
+	user := &models.User{}
+	var roles []string
+	dest := append([]any{
+		&user.ID, &user.Email, &user.Username, &user.Name, &user.DisconnectedSpotifyID,
+		&roles, &user.Disabled, &user.Created, &user.Updated,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	// The username falls back to the email, like for PocketBase users created without one
+	if user.Username == "" {
+		user.Username = user.Email
+	}
+
+	user.Roles = make([]models.UserRole, len(roles))
+	for i, role := range roles {
+		user.Roles[i] = models.UserRole(role)
+	}
+
+	return user, nil
+}
+
+// rolesOrDefault grants the user role to users created without roles. Update leaves the roles alone,
+// they are assigned by admins
+func rolesOrDefault(roles []models.UserRole) []string {
+	if len(roles) == 0 {
+		return []string{string(models.RoleUser)}
+	}
+
+	values := make([]string, len(roles))
+	for i, role := range roles {
+		values[i] = string(role)
+	}
+
+	return values
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+const youTubeMusicIntegrationColumns = `id, "user", channel_id, access_token, refresh_token, token_type, expires_at, scope, display_name,
+	created, updated`
+
+type YouTubeMusicIntegrationRepositoryPostgres struct {
+	pool        *pgxpool.Pool
+	tokenCipher repositories.TokenCipher
+	log         *slog.Logger
+}
+
+func NewYouTubeMusicIntegrationRepositoryPostgres(pool *pgxpool.Pool, logger *slog.Logger) *YouTubeMusicIntegrationRepositoryPostgres {
+	return &YouTubeMusicIntegrationRepositoryPostgres{
+		pool: pool,
+		log:  logger.With("component", "YouTubeMusicIntegrationRepositoryPostgres"),
+	}
+}
+
+// WithTokenCipher encrypts the stored Google tokens with the data key of their user
+func (ymRepo *YouTubeMusicIntegrationRepositoryPostgres) WithTokenCipher(tokenCipher repositories.TokenCipher) *YouTubeMusicIntegrationRepositoryPostgres {
+	ymRepo.tokenCipher = tokenCipher
+	return ymRepo
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPostgres) CreateOrUpdate(
+	ctx context.Context,
+	userId string,
+	integration *models.YouTubeMusicIntegration,
+) (*models.YouTubeMusicIntegration, error) {
+	accessToken, err := ymRepo.encryptToken(ctx, userId, integration.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := ymRepo.encryptToken(ctx, userId, integration.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, ymRepo.pool).QueryRow(ctx,
+		`INSERT INTO youtube_music_integrations (id, "user", channel_id, access_token, refresh_token, token_type, expires_at, scope, display_name)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT ("user") DO UPDATE SET
+			channel_id = EXCLUDED.channel_id,
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			token_type = EXCLUDED.token_type,
+			expires_at = EXCLUDED.expires_at,
+			scope = EXCLUDED.scope,
+			display_name = EXCLUDED.display_name,
+			updated = now()
+		RETURNING `+youTubeMusicIntegrationColumns,
+		newID(), userId, integration.ChannelID, accessToken, refreshToken, integration.TokenType, integration.ExpiresAt,
+		integration.Scope, integration.DisplayName,
+	)
+
+	stored, err := scanYouTubeMusicIntegration(row)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to store youtube_music_integration record", "error", err)
+		return nil, repositories.ErrDatabaseOperation
+	}
+
+	ymRepo.log.InfoContext(ctx, "youtube_music_integration stored successfully", "user", userId, "channel_id", integration.ChannelID)
+	return ymRepo.decryptTokens(ctx, stored)
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPostgres) GetByUserID(ctx context.Context, userId string) (*models.YouTubeMusicIntegration, error) {
+	row := conn(ctx, ymRepo.pool).QueryRow(ctx,
+		"SELECT "+youTubeMusicIntegrationColumns+` FROM youtube_music_integrations WHERE "user" = $1`,
+		userId,
+	)
+	integration, err := scanYouTubeMusicIntegration(row)
+	if err != nil {
+		ymRepo.log.InfoContext(ctx, "unable to fetch youtube_music_integration", "user", userId, "error", err)
+		return nil, repositories.ErrYouTubeMusicIntegrationNotFound
+	}
+
+	return ymRepo.decryptTokens(ctx, integration)
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPostgres) UpdateTokens(
+	ctx context.Context,
+	integrationId string,
+	tokens *models.YouTubeMusicIntegrationTokenRefresh,
+) error {
+	var userId string
+	err := conn(ctx, ymRepo.pool).QueryRow(ctx, `SELECT "user" FROM youtube_music_integrations WHERE id = $1`, integrationId).Scan(&userId)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to fetch youtube_music_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrYouTubeMusicIntegrationNotFound
+	}
+
+	accessToken, err := ymRepo.encryptToken(ctx, userId, tokens.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	// Google only returns a new refresh token when the old one is revoked
+	var refreshToken *string
+	if tokens.RefreshToken != "" {
+		encrypted, err := ymRepo.encryptToken(ctx, userId, tokens.RefreshToken)
+		if err != nil {
+			return err
+		}
+		refreshToken = &encrypted
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+	_, err = conn(ctx, ymRepo.pool).Exec(ctx,
+		`UPDATE youtube_music_integrations
+		SET access_token = $2, refresh_token = COALESCE($3, refresh_token), expires_at = $4, updated = now()
+		WHERE id = $1`,
+		integrationId, accessToken, refreshToken, expiresAt,
+	)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to update youtube_music_integration", "integration_id", integrationId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+
+	ymRepo.log.InfoContext(ctx, "youtube_music_integration tokens updated", "integration_id", integrationId)
+	return nil
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPostgres) Delete(ctx context.Context, userId string) error {
+	tag, err := conn(ctx, ymRepo.pool).Exec(ctx, `DELETE FROM youtube_music_integrations WHERE "user" = $1`, userId)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to delete youtube_music_integration", "user", userId, "error", err)
+		return repositories.ErrDatabaseOperation
+	}
+	if tag.RowsAffected() == 0 {
+		ymRepo.log.ErrorContext(ctx, "youtube_music_integration not found", "user", userId)
+		return repositories.ErrYouTubeMusicIntegrationNotFound
+	}
+
+	ymRepo.log.InfoContext(ctx, "youtube_music_integration deleted", "user", userId)
+	return nil
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPostgres) encryptToken(ctx context.Context, userId, token string) (string, error) {
+	if ymRepo.tokenCipher == nil || token == "" {
+		return token, nil
+	}
+
+	encryptedToken, err := ymRepo.tokenCipher.EncryptForUser(ctx, userId, token)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to encrypt youtube music token", "user", userId, "error", err)
+		return "", dbError(err)
+	}
+
+	return encryptedToken, nil
+}
+
+func (ymRepo *YouTubeMusicIntegrationRepositoryPostgres) decryptTokens(ctx context.Context, integration *models.YouTubeMusicIntegration) (*models.YouTubeMusicIntegration, error) {
+	if ymRepo.tokenCipher == nil {
+		return integration, nil
+	}
+
+	accessToken, err := ymRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.AccessToken)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to decrypt youtube music access token", "user", integration.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	refreshToken, err := ymRepo.tokenCipher.DecryptForUser(ctx, integration.UserID, integration.RefreshToken)
+	if err != nil {
+		ymRepo.log.ErrorContext(ctx, "unable to decrypt youtube music refresh token", "user", integration.UserID, "error", err)
+		return nil, dbError(err)
+	}
+
+	integration.AccessToken = accessToken
+	integration.RefreshToken = refreshToken
+	return integration, nil
+}
+
+func scanYouTubeMusicIntegration(row pgx.Row) (*models.YouTubeMusicIntegration, error) {
+	integration := &models.YouTubeMusicIntegration{}
+	err := row.Scan(
+		&integration.ID, &integration.UserID, &integration.ChannelID, &integration.AccessToken, &integration.RefreshToken,
+		&integration.TokenType, &integration.ExpiresAt, &integration.Scope, &integration.DisplayName,
+		&integration.Created, &integration.Updated,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
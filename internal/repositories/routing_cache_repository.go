@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=routing_cache_repository.go -destination=mocks/mock_routing_cache_repository.go -package=mocks
+
+type RoutingCacheRepository interface {
+	// Upsert replaces the stored entry of the child playlist, only the latest one is kept
+	Upsert(ctx context.Context, entry *models.RoutingCacheEntry) (*models.RoutingCacheEntry, error)
+	// GetByChildPlaylistIDs returns the entries stored for the child playlists, skipping the ones without
+	GetByChildPlaylistIDs(ctx context.Context, childPlaylistIDs []string, userID string) ([]*models.RoutingCacheEntry, error)
+}
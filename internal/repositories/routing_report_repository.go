@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=routing_report_repository.go -destination=mocks/mock_routing_report_repository.go -package=mocks
+
+type RoutingReportRepository interface {
+	// Upsert replaces the stored report of the base playlist, only the latest one is kept
+	Upsert(ctx context.Context, report *models.RoutingReport) (*models.RoutingReport, error)
+	GetBySyncEventID(ctx context.Context, syncEventID, userID string) (*models.RoutingReport, error)
+}
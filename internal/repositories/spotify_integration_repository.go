@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 )
@@ -9,9 +10,18 @@ import (
 //go:generate mockgen -source=spotify_integration_repository.go -destination=mocks/mock_spotify_integration_repository.go -package=mocks
 
 type SpotifyIntegrationRepository interface {
+	// CreateOrUpdate upserts the integration of the user with the spotify account of the given integration
 	CreateOrUpdate(ctx context.Context, userID string, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error)
+	// GetByUserID returns the default integration of the user, the first spotify account it linked
 	GetByUserID(ctx context.Context, userID string) (*models.SpotifyIntegration, error)
+	GetByID(ctx context.Context, id, userID string) (*models.SpotifyIntegration, error)
+	// ListByUserID returns every integration of the user, the default one first
+	ListByUserID(ctx context.Context, userID string) ([]*models.SpotifyIntegration, error)
 	GetBySpotifyID(ctx context.Context, spotifyID string) (*models.SpotifyIntegration, error)
 	UpdateTokens(ctx context.Context, integrationID string, tokens *models.SpotifyIntegrationTokenRefresh) error
+	// GetExpiringBefore returns the integrations whose access token expires before the given time
+	GetExpiringBefore(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error)
+	// Delete removes every integration of the user
 	Delete(ctx context.Context, userID string) error
+	DeleteByID(ctx context.Context, id, userID string) error
 }
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 )
@@ -12,6 +13,37 @@ type SyncEventRepository interface {
 	Create(ctx context.Context, syncEvent *models.SyncEvent) (*models.SyncEvent, error)
 	Update(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error)
 	GetByID(ctx context.Context, id string) (*models.SyncEvent, error)
+	// TransitionStatus moves the sync event from one status to another, failing with
+	// ErrSyncEventStatusChanged when it is no longer in the from status
+	TransitionStatus(ctx context.Context, id string, from, to models.SyncStatus) error
 	GetByUserID(ctx context.Context, userID string) ([]*models.SyncEvent, error)
 	GetByBasePlaylistID(ctx context.Context, basePlaylistID string) ([]*models.SyncEvent, error)
+	// GetRecentFailed returns the latest failed sync events of every user, newest first
+	GetRecentFailed(ctx context.Context, limit int) ([]*models.SyncEvent, error)
+	// List returns the sync events of every user matching the filter, newest first unless sorted otherwise
+	List(ctx context.Context, filter SyncEventFilter) ([]*models.SyncEvent, error)
+	// Count returns how many sync events match the filter, ignoring its pagination
+	Count(ctx context.Context, filter SyncEventFilter) (int, error)
+	// Prune deletes the finished sync events matching the filter, always keeping the latest sync event
+	// and the latest completed one of every base playlist
+	Prune(ctx context.Context, filter SyncEventPruneFilter) (int, error)
+}
+
+// SyncEventFilter narrows down List, empty fields match every sync event
+type SyncEventFilter struct {
+	Status         models.SyncStatus
+	UserID         string
+	BasePlaylistID string
+	CreatedAfter   time.Time // Inclusive
+	CreatedBefore  time.Time // Exclusive
+	Sort           models.ListSort
+	Limit          int
+	Offset         int
+}
+
+// SyncEventPruneFilter narrows down Prune, empty fields match every sync event
+type SyncEventPruneFilter struct {
+	UserID         string
+	BasePlaylistID string
+	CreatedBefore  time.Time // Exclusive
 }
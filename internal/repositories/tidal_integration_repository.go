@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=tidal_integration_repository.go -destination=mocks/mock_tidal_integration_repository.go -package=mocks
+
+type TidalIntegrationRepository interface {
+	// CreateOrUpdate upserts the integration of the user, a user links a single tidal account
+	CreateOrUpdate(ctx context.Context, userID string, integration *models.TidalIntegration) (*models.TidalIntegration, error)
+	GetByUserID(ctx context.Context, userID string) (*models.TidalIntegration, error)
+	UpdateTokens(ctx context.Context, integrationID string, tokens *models.TidalIntegrationTokenRefresh) error
+	Delete(ctx context.Context, userID string) error
+}
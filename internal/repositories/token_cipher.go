@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+// TokenCipher encrypts and decrypts secrets with the data key of their owner
+type TokenCipher interface {
+	EncryptForUser(ctx context.Context, userID, plaintext string) (string, error)
+	DecryptForUser(ctx context.Context, userID, ciphertext string) (string, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "context"
+
+//go:generate mockgen -source=transactor.go -destination=mocks/mock_transactor.go -package=mocks
+
+// Transactor runs a unit of work spanning several repository calls. The repositories called with the
+// context given to fn write through the same transaction, which is committed when fn succeeds and
+// rolled back when it returns an error. Nested calls join the outer transaction
+type Transactor interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
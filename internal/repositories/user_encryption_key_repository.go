@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=user_encryption_key_repository.go -destination=mocks/mock_user_encryption_key_repository.go -package=mocks
+
+type UserEncryptionKeyRepository interface {
+	Create(ctx context.Context, userID, wrappedKey string, keyVersion int) (*models.UserEncryptionKey, error)
+	GetByUserID(ctx context.Context, userID string) (*models.UserEncryptionKey, error)
+	GetOutdated(ctx context.Context, currentVersion int) ([]*models.UserEncryptionKey, error)
+	UpdateWrappedKey(ctx context.Context, id, wrappedKey string, keyVersion int) error
+}
@@ -12,7 +12,16 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) (*models.User, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
 	GetByID(ctx context.Context, userID string) (*models.User, error)
+	// GetByDisconnectedSpotifyID returns the user that disconnected the given spotify account
+	GetByDisconnectedSpotifyID(ctx context.Context, spotifyID string) (*models.User, error)
 	Delete(ctx context.Context, userID string) error
 	GenerateAuthToken(ctx context.Context, userID string) (string, error)
 	ValidateAuthToken(ctx context.Context, token string) (*models.User, error)
+	// RevokeAuthTokens invalidates every auth token issued to the user
+	RevokeAuthTokens(ctx context.Context, userID string) error
+	// Search returns the users whose id is the query or whose email or name contains it, newest
+	// first. An empty query lists every user
+	Search(ctx context.Context, query string, limit, offset int) ([]*models.User, error)
+	// SetDisabled disables or enables the user. Disabling also revokes the user's auth tokens
+	SetDisabled(ctx context.Context, userID string, disabled bool) (*models.User, error)
 }
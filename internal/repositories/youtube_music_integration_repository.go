@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=youtube_music_integration_repository.go -destination=mocks/mock_youtube_music_integration_repository.go -package=mocks
+
+type YouTubeMusicIntegrationRepository interface {
+	// CreateOrUpdate upserts the integration of the user, a user links a single youtube music account
+	CreateOrUpdate(ctx context.Context, userID string, integration *models.YouTubeMusicIntegration) (*models.YouTubeMusicIntegration, error)
+	GetByUserID(ctx context.Context, userID string) (*models.YouTubeMusicIntegration, error)
+	UpdateTokens(ctx context.Context, integrationID string, tokens *models.YouTubeMusicIntegrationTokenRefresh) error
+	Delete(ctx context.Context, userID string) error
+}
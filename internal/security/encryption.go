@@ -9,8 +9,6 @@ import (
 	"io"
 )
 
-// TODO: Implement this into Spotify token storage
-
 var (
 	ErrInvalidKeySize = errors.New("encryption key must be 32 bytes")
 )
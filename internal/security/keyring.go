@@ -0,0 +1,134 @@
+package security
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const dataKeySize = 32
+
+var (
+	ErrUnknownKeyVersion = errors.New("unknown master key version")
+	ErrInvalidDataKey    = errors.New("data key must be 32 bytes")
+)
+
+// Keyring holds the versioned master keys used to wrap per-user data keys.
+// New data keys are always wrapped with the current version, older versions
+// are kept so existing keys can still be unwrapped until they are rotated.
+// The master keys can be replaced while in use, when they are rotated in the
+// secrets manager.
+type Keyring struct {
+	mu             sync.RWMutex
+	currentVersion int
+	encryptors     map[int]*Encryptor
+}
+
+func NewKeyring(currentVersion int, masterKeys map[int]string) (*Keyring, error) {
+	encryptors, err := newMasterKeyEncryptors(currentVersion, masterKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keyring{
+		currentVersion: currentVersion,
+		encryptors:     encryptors,
+	}, nil
+}
+
+func newMasterKeyEncryptors(currentVersion int, masterKeys map[int]string) (map[int]*Encryptor, error) {
+	if _, ok := masterKeys[currentVersion]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownKeyVersion, currentVersion)
+	}
+
+	encryptors := make(map[int]*Encryptor, len(masterKeys))
+	for version, key := range masterKeys {
+		encryptor, err := NewEncryptor(key)
+		if err != nil {
+			return nil, fmt.Errorf("master key version %d: %w", version, err)
+		}
+		encryptors[version] = encryptor
+	}
+
+	return encryptors, nil
+}
+
+// Update replaces the master keys. Data keys wrapped with a version that is
+// no longer present can't be unwrapped, so retired keys must be kept until
+// every data key was rotated.
+func (k *Keyring) Update(currentVersion int, masterKeys map[int]string) error {
+	encryptors, err := newMasterKeyEncryptors(currentVersion, masterKeys)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.currentVersion = currentVersion
+	k.encryptors = encryptors
+	return nil
+}
+
+func (k *Keyring) CurrentVersion() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.currentVersion
+}
+
+// WrapDataKey encrypts a data key with the current master key, returning the
+// version of the master key it was wrapped with
+func (k *Keyring) WrapDataKey(dataKey []byte) (string, int, error) {
+	if len(dataKey) != dataKeySize {
+		return "", 0, ErrInvalidDataKey
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	wrappedKey, err := k.encryptors[k.currentVersion].Encrypt(string(dataKey))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return wrappedKey, k.currentVersion, nil
+}
+
+// UnwrapDataKey decrypts a data key with the master key of the given version
+func (k *Keyring) UnwrapDataKey(wrappedKey string, version int) ([]byte, error) {
+	k.mu.RLock()
+	encryptor, ok := k.encryptors[version]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownKeyVersion, version)
+	}
+
+	dataKey, err := encryptor.Decrypt(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dataKey) != dataKeySize {
+		return nil, ErrInvalidDataKey
+	}
+
+	return []byte(dataKey), nil
+}
+
+// GenerateDataKey returns a new random 32 byte data key
+func GenerateDataKey() ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	return dataKey, nil
+}
+
+// NewDataKeyEncryptor returns an Encryptor for an unwrapped data key
+func NewDataKeyEncryptor(dataKey []byte) (*Encryptor, error) {
+	return NewEncryptor(string(dataKey))
+}
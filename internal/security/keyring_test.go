@@ -0,0 +1,123 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMasterKeyV1 = "0123456789abcdef0123456789abcdef"
+	testMasterKeyV2 = "fedcba9876543210fedcba9876543210"
+)
+
+func TestNewKeyring_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		currentVersion int
+		masterKeys     map[int]string
+		expectedErr    error
+	}{
+		{
+			name:           "current version missing",
+			currentVersion: 2,
+			masterKeys:     map[int]string{1: testMasterKeyV1},
+			expectedErr:    ErrUnknownKeyVersion,
+		},
+		{
+			name:           "invalid key size",
+			currentVersion: 1,
+			masterKeys:     map[int]string{1: "short"},
+			expectedErr:    ErrInvalidKeySize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			keyring, err := NewKeyring(tt.currentVersion, tt.masterKeys)
+
+			require.ErrorIs(err, tt.expectedErr)
+			require.Nil(keyring)
+		})
+	}
+}
+
+func TestKeyring_WrapAndUnwrapAcrossRotation(t *testing.T) {
+	require := require.New(t)
+
+	oldKeyring, err := NewKeyring(1, map[int]string{1: testMasterKeyV1})
+	require.NoError(err)
+
+	dataKey, err := GenerateDataKey()
+	require.NoError(err)
+	require.Len(dataKey, 32)
+
+	wrappedV1, version, err := oldKeyring.WrapDataKey(dataKey)
+	require.NoError(err)
+	require.Equal(1, version)
+
+	// After rotation the old master key is still available to unwrap existing keys
+	rotatedKeyring, err := NewKeyring(2, map[int]string{1: testMasterKeyV1, 2: testMasterKeyV2})
+	require.NoError(err)
+	require.Equal(2, rotatedKeyring.CurrentVersion())
+
+	unwrapped, err := rotatedKeyring.UnwrapDataKey(wrappedV1, 1)
+	require.NoError(err)
+	require.Equal(dataKey, unwrapped)
+
+	wrappedV2, version, err := rotatedKeyring.WrapDataKey(unwrapped)
+	require.NoError(err)
+	require.Equal(2, version)
+
+	_, err = rotatedKeyring.UnwrapDataKey(wrappedV2, 1)
+	require.Error(err)
+
+	unwrapped, err = rotatedKeyring.UnwrapDataKey(wrappedV2, 2)
+	require.NoError(err)
+	require.Equal(dataKey, unwrapped)
+
+	_, err = rotatedKeyring.UnwrapDataKey(wrappedV2, 3)
+	require.ErrorIs(err, ErrUnknownKeyVersion)
+}
+
+func TestKeyring_WrapDataKey_InvalidSize(t *testing.T) {
+	require := require.New(t)
+
+	keyring, err := NewKeyring(1, map[int]string{1: testMasterKeyV1})
+	require.NoError(err)
+
+	_, _, err = keyring.WrapDataKey([]byte("short"))
+	require.ErrorIs(err, ErrInvalidDataKey)
+}
+
+func TestKeyring_Update(t *testing.T) {
+	require := require.New(t)
+
+	keyring, err := NewKeyring(1, map[int]string{1: testMasterKeyV1})
+	require.NoError(err)
+
+	dataKey, err := GenerateDataKey()
+	require.NoError(err)
+
+	wrappedV1, _, err := keyring.WrapDataKey(dataKey)
+	require.NoError(err)
+
+	// A rotated master key is used for new data keys, the retired one still unwraps the old ones
+	require.NoError(keyring.Update(2, map[int]string{1: testMasterKeyV1, 2: testMasterKeyV2}))
+	require.Equal(2, keyring.CurrentVersion())
+
+	_, version, err := keyring.WrapDataKey(dataKey)
+	require.NoError(err)
+	require.Equal(2, version)
+
+	unwrapped, err := keyring.UnwrapDataKey(wrappedV1, 1)
+	require.NoError(err)
+	require.Equal(dataKey, unwrapped)
+
+	// Invalid keys leave the keyring as it was
+	err = keyring.Update(3, map[int]string{2: testMasterKeyV2})
+	require.ErrorIs(err, ErrUnknownKeyVersion)
+	require.Equal(2, keyring.CurrentVersion())
+}
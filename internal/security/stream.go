@@ -0,0 +1,141 @@
+package security
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Streams are sealed in chunks of this size, so large payloads such as backups never have to fit
+// in memory
+const STREAM_CHUNK_SIZE = 64 * 1024
+
+// streamMagic starts every encrypted stream, so other files are rejected before decrypting them
+var streamMagic = []byte("PRENC1")
+
+var (
+	ErrInvalidStream = errors.New("encrypted stream is invalid, truncated or was encrypted with another key")
+)
+
+// EncryptStream seals src into dst with AES-GCM. Each chunk is sealed with a nonce made of a random
+// prefix and its position, and the last one is flagged, so reordered or truncated streams fail to
+// decrypt
+func (e *Encryptor) EncryptStream(dst io.Writer, src io.Reader) error {
+	gcm, err := e.gcm()
+	if err != nil {
+		return err
+	}
+
+	noncePrefix := make([]byte, gcm.NonceSize()-4)
+	if _, err := io.ReadFull(rand.Reader, noncePrefix); err != nil {
+		return err
+	}
+
+	if _, err := dst.Write(append(bytes.Clone(streamMagic), noncePrefix...)); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReaderSize(src, STREAM_CHUNK_SIZE)
+	chunk := make([]byte, STREAM_CHUNK_SIZE)
+	for counter := uint32(0); ; counter++ {
+		n, err := io.ReadFull(reader, chunk)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		// A full chunk is the last one only when nothing follows it
+		last := err != nil
+		if !last {
+			if _, peekErr := reader.Peek(1); peekErr == io.EOF {
+				last = true
+			} else if peekErr != nil {
+				return peekErr
+			}
+		}
+
+		header := streamChunkHeader(last)
+		sealed := gcm.Seal(nil, streamNonce(noncePrefix, counter), chunk[:n], header)
+
+		frame := binary.BigEndian.AppendUint32(header, uint32(len(sealed)))
+		if _, err := dst.Write(append(frame, sealed...)); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// DecryptStream opens a stream sealed by EncryptStream into dst, failing with ErrInvalidStream when
+// it was tampered with, truncated or encrypted with another key. Chunks are written as they are
+// verified, so dst should be discarded when it fails
+func (e *Encryptor) DecryptStream(dst io.Writer, src io.Reader) error {
+	gcm, err := e.gcm()
+	if err != nil {
+		return err
+	}
+
+	preamble := make([]byte, len(streamMagic)+gcm.NonceSize()-4)
+	if _, err := io.ReadFull(src, preamble); err != nil || !bytes.Equal(preamble[:len(streamMagic)], streamMagic) {
+		return ErrInvalidStream
+	}
+	noncePrefix := preamble[len(streamMagic):]
+
+	frame := make([]byte, 5)
+	for counter := uint32(0); ; counter++ {
+		if _, err := io.ReadFull(src, frame); err != nil {
+			return ErrInvalidStream
+		}
+
+		size := binary.BigEndian.Uint32(frame[1:])
+		if size > STREAM_CHUNK_SIZE+uint32(gcm.Overhead()) {
+			return ErrInvalidStream
+		}
+
+		sealed := make([]byte, size)
+		if _, err := io.ReadFull(src, sealed); err != nil {
+			return ErrInvalidStream
+		}
+
+		plaintext, err := gcm.Open(nil, streamNonce(noncePrefix, counter), sealed, frame[:1])
+		if err != nil {
+			return ErrInvalidStream
+		}
+
+		if _, err := dst.Write(plaintext); err != nil {
+			return err
+		}
+
+		if frame[0] == 1 {
+			return nil
+		}
+	}
+}
+
+func (e *Encryptor) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(e.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// streamChunkHeader flags the last chunk of a stream, it is authenticated along with the chunk
+func streamChunkHeader(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+
+	return []byte{0}
+}
+
+func streamNonce(prefix []byte, counter uint32) []byte {
+	return binary.BigEndian.AppendUint32(bytes.Clone(prefix), counter)
+}
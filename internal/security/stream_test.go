@@ -0,0 +1,76 @@
+package security
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptor_Stream_RoundTrip(t *testing.T) {
+	sizes := map[string]int{
+		"empty":             0,
+		"partial chunk":     1000,
+		"exactly one chunk": STREAM_CHUNK_SIZE,
+		"several chunks":    3*STREAM_CHUNK_SIZE + 17,
+	}
+
+	for name, size := range sizes {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			encryptor, err := NewEncryptor(testMasterKeyV1)
+			assert.NoError(err)
+
+			plaintext := make([]byte, size)
+			_, err = rand.Read(plaintext)
+			assert.NoError(err)
+
+			var encrypted bytes.Buffer
+			assert.NoError(encryptor.EncryptStream(&encrypted, bytes.NewReader(plaintext)))
+
+			var decrypted bytes.Buffer
+			assert.NoError(encryptor.DecryptStream(&decrypted, &encrypted))
+			assert.True(bytes.Equal(plaintext, decrypted.Bytes()))
+		})
+	}
+}
+
+func TestEncryptor_DecryptStream_Invalid(t *testing.T) {
+	encryptor, err := NewEncryptor(testMasterKeyV1)
+	require.NoError(t, err)
+
+	plaintext := bytes.Repeat([]byte("playlist"), STREAM_CHUNK_SIZE/4)
+	var encrypted bytes.Buffer
+	require.NoError(t, encryptor.EncryptStream(&encrypted, bytes.NewReader(plaintext)))
+	sealed := encrypted.Bytes()
+
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 1
+
+	otherEncryptor, err := NewEncryptor(testMasterKeyV2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		encryptor *Encryptor
+		stream    []byte
+	}{
+		{name: "wrong key", encryptor: otherEncryptor, stream: sealed},
+		{name: "tampered", encryptor: encryptor, stream: tampered},
+		{name: "truncated after a chunk", encryptor: encryptor, stream: sealed[:len(streamMagic)+8+5+STREAM_CHUNK_SIZE+16]},
+		{name: "truncated mid chunk", encryptor: encryptor, stream: sealed[:len(sealed)-10]},
+		{name: "not encrypted", encryptor: encryptor, stream: plaintext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			err := tt.encryptor.DecryptStream(&bytes.Buffer{}, bytes.NewReader(tt.stream))
+
+			assert.ErrorIs(err, ErrInvalidStream)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=account_service.go -destination=mocks/mock_account_service.go -package=mocks
+
+type AccountServicer interface {
+	DeleteAccount(ctx context.Context, userID string, deleteSpotifyPlaylists bool) (*models.AccountDeletionReport, error)
+	ExportAccount(ctx context.Context, userID string) (*models.AccountExport, error)
+}
+
+// AccountService lets users export or erase all the data stored about them
+type AccountService struct {
+	userRepo               repositories.UserRepository
+	spotifyIntegrationRepo repositories.SpotifyIntegrationRepository
+	basePlaylistRepo       repositories.BasePlaylistRepository
+	childPlaylistRepo      repositories.ChildPlaylistRepository
+	syncEventRepo          repositories.SyncEventRepository
+	apiKeyRepo             repositories.APIKeyRepository
+	templateRepo           repositories.ChildPlaylistTemplateRepository
+	blocklistRepo          repositories.BlocklistEntryRepository
+	webhookRepo            repositories.PlaylistWebhookRepository
+	spotifyClient          spotifyclient.SpotifyAPI
+	spotifyAuth            SpotifyAuthProvider // nil when spotify playlists can't be deleted along with the account
+	logger                 *slog.Logger
+}
+
+func NewAccountService(
+	userRepo repositories.UserRepository,
+	spotifyIntegrationRepo repositories.SpotifyIntegrationRepository,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	syncEventRepo repositories.SyncEventRepository,
+	apiKeyRepo repositories.APIKeyRepository,
+	templateRepo repositories.ChildPlaylistTemplateRepository,
+	blocklistRepo repositories.BlocklistEntryRepository,
+	webhookRepo repositories.PlaylistWebhookRepository,
+	spotifyClient spotifyclient.SpotifyAPI,
+	logger *slog.Logger,
+) *AccountService {
+	return &AccountService{
+		userRepo:               userRepo,
+		spotifyIntegrationRepo: spotifyIntegrationRepo,
+		basePlaylistRepo:       basePlaylistRepo,
+		childPlaylistRepo:      childPlaylistRepo,
+		syncEventRepo:          syncEventRepo,
+		apiKeyRepo:             apiKeyRepo,
+		templateRepo:           templateRepo,
+		blocklistRepo:          blocklistRepo,
+		webhookRepo:            webhookRepo,
+		spotifyClient:          spotifyClient,
+		logger:                 logger.With("component", "AccountService"),
+	}
+}
+
+// WithSpotifyAuth lets the account deletion remove the spotify playlists generated for the child
+// playlists, loading the credentials of each linked account through spotifyAuth
+func (aService *AccountService) WithSpotifyAuth(spotifyAuth SpotifyAuthProvider) *AccountService {
+	aService.spotifyAuth = spotifyAuth
+	return aService
+}
+
+// DeleteAccount erases the user along with its spotify accounts, playlists, sync events and every
+// other record it owns, all removed by the cascading user relations. When deleteSpotifyPlaylists is
+// set, the spotify playlists of the child playlists are deleted first. That is best effort, a
+// playlist that can't be deleted is counted as failed without stopping the account deletion
+func (aService *AccountService) DeleteAccount(ctx context.Context, userID string, deleteSpotifyPlaylists bool) (*models.AccountDeletionReport, error) {
+	aService.logger.InfoContext(ctx, "deleting account", "user_id", userID, "delete_spotify_playlists", deleteSpotifyPlaylists)
+
+	if _, err := aService.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	report := &models.AccountDeletionReport{UserID: userID}
+	if deleteSpotifyPlaylists {
+		if err := aService.deleteSpotifyPlaylists(ctx, userID, report); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := aService.userRepo.Delete(ctx, userID); err != nil {
+		aService.logger.ErrorContext(ctx, "failed to delete user", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	aService.logger.InfoContext(ctx, "account deleted", "user_id", userID, "spotify_playlists_deleted", report.SpotifyPlaylistsDeleted, "spotify_playlists_failed", report.SpotifyPlaylistsFailed)
+	return report, nil
+}
+
+// deleteSpotifyPlaylists deletes the spotify playlist of every child playlist of the user, from the
+// linked account it lives in
+func (aService *AccountService) deleteSpotifyPlaylists(ctx context.Context, userID string, report *models.AccountDeletionReport) error {
+	basePlaylists, err := aService.basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get base playlists: %w", err)
+	}
+
+	// Credentials are loaded once per spotify account, an empty ID being the default one
+	accountCtxs := map[string]context.Context{}
+	for _, basePlaylist := range basePlaylists {
+		childPlaylists, err := aService.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get child playlists: %w", err)
+		}
+
+		for _, childPlaylist := range childPlaylists {
+			integrationID := childPlaylist.SpotifyIntegrationID
+			if integrationID == "" {
+				integrationID = basePlaylist.SpotifyIntegrationID
+			}
+
+			accountCtx, ok := accountCtxs[integrationID]
+			if !ok {
+				accountCtx, err = aService.accountContext(ctx, userID, integrationID)
+				if err != nil {
+					aService.logger.WarnContext(ctx, "failed to load spotify account", "user_id", userID, "spotify_integration_id", integrationID, "error", err.Error())
+					accountCtx = nil
+				}
+				accountCtxs[integrationID] = accountCtx
+			}
+			if accountCtx == nil {
+				report.SpotifyPlaylistsFailed++
+				continue
+			}
+
+			accountCtx = requestcontext.ContextWithMusicProvider(accountCtx, childPlaylist.Provider)
+			if err := aService.spotifyClient.DeletePlaylist(accountCtx, childPlaylist.SpotifyPlaylistID); err != nil {
+				aService.logger.WarnContext(ctx, "failed to delete spotify playlist", "spotify_playlist_id", childPlaylist.SpotifyPlaylistID, "error", err.Error())
+				report.SpotifyPlaylistsFailed++
+				continue
+			}
+			report.SpotifyPlaylistsDeleted++
+		}
+	}
+
+	return nil
+}
+
+// accountContext returns the context authorized for the user's linked spotify account, or for its
+// default account when integrationID is empty
+func (aService *AccountService) accountContext(ctx context.Context, userID, integrationID string) (context.Context, error) {
+	if aService.spotifyAuth == nil {
+		return ctx, nil
+	}
+	if integrationID == "" {
+		return aService.spotifyAuth.ContextWithSpotifyAuth(ctx, userID)
+	}
+
+	return contextWithSpotifyAccount(ctx, aService.spotifyAuth, userID, integrationID)
+}
+
+// ExportAccount collects every piece of data stored about the user
+func (aService *AccountService) ExportAccount(ctx context.Context, userID string) (*models.AccountExport, error) {
+	aService.logger.InfoContext(ctx, "exporting account", "user_id", userID)
+
+	user, err := aService.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	spotifyAccounts, err := aService.spotifyIntegrationRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spotify accounts: %w", err)
+	}
+
+	basePlaylists, err := aService.exportBasePlaylists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	syncEvents, err := aService.syncEventRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync events: %w", err)
+	}
+
+	apiKeys, err := aService.apiKeyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	templates, err := aService.templateRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child playlist templates: %w", err)
+	}
+
+	return &models.AccountExport{
+		ExportedAt:             time.Now().UTC(),
+		User:                   user,
+		SpotifyAccounts:        spotifyAccounts,
+		BasePlaylists:          basePlaylists,
+		SyncEvents:             syncEvents,
+		APIKeys:                apiKeys,
+		ChildPlaylistTemplates: templates,
+	}, nil
+}
+
+func (aService *AccountService) exportBasePlaylists(ctx context.Context, userID string) ([]*models.BasePlaylistExport, error) {
+	basePlaylists, err := aService.basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlists: %w", err)
+	}
+
+	exports := make([]*models.BasePlaylistExport, 0, len(basePlaylists))
+	for _, basePlaylist := range basePlaylists {
+		childPlaylists, err := aService.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get child playlists: %w", err)
+		}
+
+		blocklistEntries, err := aService.blocklistRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blocklist entries: %w", err)
+		}
+
+		webhooks, err := aService.webhookRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webhooks: %w", err)
+		}
+
+		exports = append(exports, &models.BasePlaylistExport{
+			BasePlaylist:     basePlaylist,
+			ChildPlaylists:   childPlaylists,
+			BlocklistEntries: blocklistEntries,
+			Webhooks:         webhooks,
+		})
+	}
+
+	return exports, nil
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type accountServiceMocks struct {
+	userRepo               *repositoryMocks.MockUserRepository
+	spotifyIntegrationRepo *repositoryMocks.MockSpotifyIntegrationRepository
+	basePlaylistRepo       *repositoryMocks.MockBasePlaylistRepository
+	childPlaylistRepo      *repositoryMocks.MockChildPlaylistRepository
+	syncEventRepo          *repositoryMocks.MockSyncEventRepository
+	apiKeyRepo             *repositoryMocks.MockAPIKeyRepository
+	templateRepo           *repositoryMocks.MockChildPlaylistTemplateRepository
+	blocklistRepo          *repositoryMocks.MockBlocklistEntryRepository
+	webhookRepo            *repositoryMocks.MockPlaylistWebhookRepository
+	spotifyClient          *spotifyClientMocks.MockSpotifyAPI
+	spotifyAuth            *fakeSpotifyAuthProvider
+}
+
+func setupAccountService(t *testing.T) (*AccountService, accountServiceMocks) {
+	ctrl := setupMockController(t)
+
+	mocks := accountServiceMocks{
+		userRepo:               repositoryMocks.NewMockUserRepository(ctrl),
+		spotifyIntegrationRepo: repositoryMocks.NewMockSpotifyIntegrationRepository(ctrl),
+		basePlaylistRepo:       repositoryMocks.NewMockBasePlaylistRepository(ctrl),
+		childPlaylistRepo:      repositoryMocks.NewMockChildPlaylistRepository(ctrl),
+		syncEventRepo:          repositoryMocks.NewMockSyncEventRepository(ctrl),
+		apiKeyRepo:             repositoryMocks.NewMockAPIKeyRepository(ctrl),
+		templateRepo:           repositoryMocks.NewMockChildPlaylistTemplateRepository(ctrl),
+		blocklistRepo:          repositoryMocks.NewMockBlocklistEntryRepository(ctrl),
+		webhookRepo:            repositoryMocks.NewMockPlaylistWebhookRepository(ctrl),
+		spotifyClient:          spotifyClientMocks.NewMockSpotifyAPI(ctrl),
+		spotifyAuth:            &fakeSpotifyAuthProvider{},
+	}
+
+	service := NewAccountService(
+		mocks.userRepo,
+		mocks.spotifyIntegrationRepo,
+		mocks.basePlaylistRepo,
+		mocks.childPlaylistRepo,
+		mocks.syncEventRepo,
+		mocks.apiKeyRepo,
+		mocks.templateRepo,
+		mocks.blocklistRepo,
+		mocks.webhookRepo,
+		mocks.spotifyClient,
+		createTestLogger(),
+	).WithSpotifyAuth(mocks.spotifyAuth)
+	return service, mocks
+}
+
+func TestAccountService_DeleteAccount_KeepsSpotifyPlaylists(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupAccountService(t)
+
+	mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+	mocks.userRepo.EXPECT().Delete(gomock.Any(), "user123").Return(nil)
+
+	report, err := service.DeleteAccount(context.Background(), "user123", false)
+
+	assert.NoError(err)
+	assert.Equal(&models.AccountDeletionReport{UserID: "user123"}, report)
+	assert.Zero(mocks.spotifyAuth.calls)
+}
+
+func TestAccountService_DeleteAccount_DeletesSpotifyPlaylists(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupAccountService(t)
+
+	basePlaylists := []*models.BasePlaylist{
+		{ID: "bp1", UserID: "user123"},
+		{ID: "bp2", UserID: "user123", SpotifyIntegrationID: "integration_dj"},
+	}
+
+	mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+	mocks.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(basePlaylists, nil)
+	mocks.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp1", "user123").Return([]*models.ChildPlaylist{
+		{ID: "cp1", SpotifyPlaylistID: "spotify1"},
+		{ID: "cp2", SpotifyPlaylistID: "spotify2"},
+	}, nil)
+	mocks.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp2", "user123").Return([]*models.ChildPlaylist{
+		{ID: "cp3", SpotifyPlaylistID: "spotify3"},
+	}, nil)
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify1").Return(nil)
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify2").Return(errors.New("spotify unavailable"))
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify3").Return(nil)
+	mocks.userRepo.EXPECT().Delete(gomock.Any(), "user123").Return(nil)
+
+	report, err := service.DeleteAccount(context.Background(), "user123", true)
+
+	assert.NoError(err)
+	assert.Equal(&models.AccountDeletionReport{UserID: "user123", SpotifyPlaylistsDeleted: 2, SpotifyPlaylistsFailed: 1}, report)
+	// The credentials of each account are loaded once
+	assert.Equal([]string{"", "integration_dj"}, mocks.spotifyAuth.integrationIDs)
+}
+
+func TestAccountService_DeleteAccount_SpotifyAccountUnavailable(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupAccountService(t)
+	mocks.spotifyAuth.err = ErrSpotifyIntegrationUnavailable
+
+	mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+	mocks.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return([]*models.BasePlaylist{{ID: "bp1"}}, nil)
+	mocks.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp1", "user123").Return([]*models.ChildPlaylist{
+		{ID: "cp1", SpotifyPlaylistID: "spotify1"},
+		{ID: "cp2", SpotifyPlaylistID: "spotify2"},
+	}, nil)
+	mocks.userRepo.EXPECT().Delete(gomock.Any(), "user123").Return(nil)
+
+	report, err := service.DeleteAccount(context.Background(), "user123", true)
+
+	// The account is deleted even though its spotify playlists couldn't be
+	assert.NoError(err)
+	assert.Equal(2, report.SpotifyPlaylistsFailed)
+	assert.Equal(1, mocks.spotifyAuth.calls)
+}
+
+func TestAccountService_DeleteAccount_UserNotFound(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupAccountService(t)
+
+	mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(nil, repositories.ErrUseNotFound)
+
+	report, err := service.DeleteAccount(context.Background(), "user123", true)
+
+	assert.Nil(report)
+	assert.ErrorIs(err, repositories.ErrUseNotFound)
+}
+
+func TestAccountService_DeleteAccount_DeleteError(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupAccountService(t)
+
+	mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+	mocks.userRepo.EXPECT().Delete(gomock.Any(), "user123").Return(repositories.ErrDatabaseOperation)
+
+	report, err := service.DeleteAccount(context.Background(), "user123", false)
+
+	assert.Nil(report)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestAccountService_ExportAccount(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupAccountService(t)
+
+	user := &models.User{ID: "user123", Email: "user@example.com"}
+	basePlaylist := &models.BasePlaylist{ID: "bp1", UserID: "user123"}
+	childPlaylists := []*models.ChildPlaylist{{ID: "cp1", BasePlaylistID: "bp1"}}
+	blocklistEntries := []*models.BlocklistEntry{{ID: "block1", BasePlaylistID: "bp1"}}
+	webhooks := []*models.PlaylistWebhook{{ID: "webhook1", BasePlaylistID: "bp1"}}
+	spotifyAccounts := []*models.SpotifyIntegration{{ID: "integration1", UserID: "user123"}}
+	syncEvents := []*models.SyncEvent{{ID: "sync1", UserID: "user123"}}
+	apiKeys := []*models.APIKey{{ID: "key1", UserID: "user123"}}
+	templates := []*models.ChildPlaylistTemplate{{ID: "template1", UserID: "user123"}}
+
+	mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(user, nil)
+	mocks.spotifyIntegrationRepo.EXPECT().ListByUserID(gomock.Any(), "user123").Return(spotifyAccounts, nil)
+	mocks.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return([]*models.BasePlaylist{basePlaylist}, nil)
+	mocks.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp1", "user123").Return(childPlaylists, nil)
+	mocks.blocklistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp1", "user123").Return(blocklistEntries, nil)
+	mocks.webhookRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp1", "user123").Return(webhooks, nil)
+	mocks.syncEventRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(syncEvents, nil)
+	mocks.apiKeyRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(apiKeys, nil)
+	mocks.templateRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(templates, nil)
+
+	export, err := service.ExportAccount(context.Background(), "user123")
+
+	assert.NoError(err)
+	assert.False(export.ExportedAt.IsZero())
+	assert.Equal(user, export.User)
+	assert.Equal(spotifyAccounts, export.SpotifyAccounts)
+	assert.Equal([]*models.BasePlaylistExport{{
+		BasePlaylist:     basePlaylist,
+		ChildPlaylists:   childPlaylists,
+		BlocklistEntries: blocklistEntries,
+		Webhooks:         webhooks,
+	}}, export.BasePlaylists)
+	assert.Equal(syncEvents, export.SyncEvents)
+	assert.Equal(apiKeys, export.APIKeys)
+	assert.Equal(templates, export.ChildPlaylistTemplates)
+}
+
+func TestAccountService_ExportAccount_Error(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupAccountService(t)
+
+	mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+	mocks.spotifyIntegrationRepo.EXPECT().ListByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrDatabaseOperation)
+
+	export, err := service.ExportAccount(context.Background(), "user123")
+
+	assert.Nil(export)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=admin_service.go -destination=mocks/mock_admin_service.go -package=mocks
+
+const (
+	ADMIN_DEFAULT_PAGE_SIZE = 50
+	ADMIN_MAX_PAGE_SIZE     = 200
+)
+
+type AdminServicer interface {
+	ListUsers(ctx context.Context, query string, limit, offset int) ([]*models.User, error)
+	SetUserDisabled(ctx context.Context, userID string, disabled bool) (*models.User, error)
+	ListSyncEvents(ctx context.Context, filter repositories.SyncEventFilter) ([]*models.SyncEvent, error)
+}
+
+// AdminService backs the admin API operators use to triage abusive accounts and stuck syncs. Unlike
+// the other services, its queries span every user
+type AdminService struct {
+	userRepo      repositories.UserRepository
+	syncEventRepo repositories.SyncEventRepository
+	logger        *slog.Logger
+}
+
+func NewAdminService(
+	userRepo repositories.UserRepository,
+	syncEventRepo repositories.SyncEventRepository,
+	logger *slog.Logger,
+) *AdminService {
+	return &AdminService{
+		userRepo:      userRepo,
+		syncEventRepo: syncEventRepo,
+		logger:        logger.With("component", "AdminService"),
+	}
+}
+
+// ListUsers searches the users by id, email or name. An empty query lists every user
+func (aService *AdminService) ListUsers(ctx context.Context, query string, limit, offset int) ([]*models.User, error) {
+	users, err := aService.userRepo.Search(ctx, query, adminPageSize(limit), max(offset, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	return users, nil
+}
+
+// SetUserDisabled disables or enables the user. Disabled users are logged out everywhere, can't log
+// in again and their API keys are rejected until they are enabled
+func (aService *AdminService) SetUserDisabled(ctx context.Context, userID string, disabled bool) (*models.User, error) {
+	aService.logger.InfoContext(ctx, "setting user disabled flag", "user_id", userID, "disabled", disabled)
+
+	user, err := aService.userRepo.SetDisabled(ctx, userID, disabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListSyncEvents returns the sync events of every user matching the filter, newest first
+func (aService *AdminService) ListSyncEvents(ctx context.Context, filter repositories.SyncEventFilter) ([]*models.SyncEvent, error) {
+	filter.Limit = adminPageSize(filter.Limit)
+	filter.Offset = max(filter.Offset, 0)
+
+	syncEvents, err := aService.syncEventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync events: %w", err)
+	}
+
+	return syncEvents, nil
+}
+
+// adminPageSize falls back to the default page size for unset limits and caps the others
+func adminPageSize(limit int) int {
+	if limit <= 0 {
+		return ADMIN_DEFAULT_PAGE_SIZE
+	}
+
+	return min(limit, ADMIN_MAX_PAGE_SIZE)
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminService_ListUsers(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          int
+		offset         int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{name: "default page size", limit: 0, offset: 0, expectedLimit: ADMIN_DEFAULT_PAGE_SIZE, expectedOffset: 0},
+		{name: "requested page", limit: 10, offset: 20, expectedLimit: 10, expectedOffset: 20},
+		{name: "capped page size", limit: 1000, offset: -5, expectedLimit: ADMIN_MAX_PAGE_SIZE, expectedOffset: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := setupMockController(t)
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			service := NewAdminService(mockUserRepo, mocks.NewMockSyncEventRepository(ctrl), createTestLogger())
+
+			users := []*models.User{{ID: "user123", Email: "user@example.com"}}
+			mockUserRepo.EXPECT().Search(gomock.Any(), "user@", tt.expectedLimit, tt.expectedOffset).Return(users, nil)
+
+			result, err := service.ListUsers(context.Background(), "user@", tt.limit, tt.offset)
+
+			assert.NoError(err)
+			assert.Equal(users, result)
+		})
+	}
+}
+
+func TestAdminService_ListUsers_Error(t *testing.T) {
+	assert := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	service := NewAdminService(mockUserRepo, mocks.NewMockSyncEventRepository(ctrl), createTestLogger())
+
+	mockUserRepo.EXPECT().Search(gomock.Any(), "", ADMIN_DEFAULT_PAGE_SIZE, 0).Return(nil, repositories.ErrDatabaseOperation)
+
+	result, err := service.ListUsers(context.Background(), "", 0, 0)
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestAdminService_SetUserDisabled(t *testing.T) {
+	tests := []struct {
+		name          string
+		repoUser      *models.User
+		repoErr       error
+		expectedError error
+	}{
+		{
+			name:     "disables user",
+			repoUser: &models.User{ID: "user123", Disabled: true},
+		},
+		{
+			name:          "user not found",
+			repoErr:       repositories.ErrUseNotFound,
+			expectedError: repositories.ErrUseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := setupMockController(t)
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			service := NewAdminService(mockUserRepo, mocks.NewMockSyncEventRepository(ctrl), createTestLogger())
+
+			mockUserRepo.EXPECT().SetDisabled(gomock.Any(), "user123", true).Return(tt.repoUser, tt.repoErr)
+
+			result, err := service.SetUserDisabled(context.Background(), "user123", true)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(err, tt.expectedError)
+				assert.Nil(result)
+				return
+			}
+			assert.NoError(err)
+			assert.True(result.Disabled)
+		})
+	}
+}
+
+func TestAdminService_ListSyncEvents(t *testing.T) {
+	assert := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockSyncEventRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewAdminService(mocks.NewMockUserRepository(ctrl), mockSyncEventRepo, createTestLogger())
+
+	syncEvents := []*models.SyncEvent{{ID: "sync123", Status: models.SyncStatusFailed}}
+	mockSyncEventRepo.EXPECT().List(gomock.Any(), repositories.SyncEventFilter{
+		Status: models.SyncStatusFailed,
+		UserID: "user123",
+		Limit:  ADMIN_DEFAULT_PAGE_SIZE,
+	}).Return(syncEvents, nil)
+
+	result, err := service.ListSyncEvents(context.Background(), repositories.SyncEventFilter{
+		Status: models.SyncStatusFailed,
+		UserID: "user123",
+	})
+
+	assert.NoError(err)
+	assert.Equal(syncEvents, result)
+}
+
+func TestAdminService_ListSyncEvents_Error(t *testing.T) {
+	assert := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockSyncEventRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewAdminService(mocks.NewMockUserRepository(ctrl), mockSyncEventRepo, createTestLogger())
+
+	mockSyncEventRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+
+	result, err := service.ListSyncEvents(context.Background(), repositories.SyncEventFilter{})
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
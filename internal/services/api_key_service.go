@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=api_key_service.go -destination=mocks/mock_api_key_service.go -package=mocks
+
+const (
+	// API_KEY_PREFIX marks the keys issued by the router so they are easy to recognize in automation configs
+	API_KEY_PREFIX = "prk_"
+	// API_KEY_DISPLAY_LENGTH is how many characters of a key are kept to identify it in listings
+	API_KEY_DISPLAY_LENGTH = 12
+)
+
+type APIKeyServicer interface {
+	CreateAPIKey(ctx context.Context, userID string, input *models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]*models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id, userID string) error
+	Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error)
+}
+
+type APIKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, logger *slog.Logger) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger.With("component", "APIKeyService"),
+		now:        time.Now,
+	}
+}
+
+// CreateAPIKey issues a new key for the user. The raw key is only part of the response, just its hash is stored
+func (akService *APIKeyService) CreateAPIKey(ctx context.Context, userID string, input *models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	akService.logger.InfoContext(ctx, "creating api key", "user_id", userID, "name", input.Name, "scopes", input.Scopes)
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(akService.now()) {
+		return nil, ErrInvalidAPIKeyExpiry
+	}
+
+	secret, err := generateSecretToken()
+	if err != nil {
+		akService.logger.ErrorContext(ctx, "failed to generate api key", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	rawKey := API_KEY_PREFIX + secret
+
+	apiKey, err := akService.apiKeyRepo.Create(ctx, &models.APIKey{
+		UserID:    userID,
+		Name:      input.Name,
+		KeyHash:   hashAPIKey(rawKey),
+		KeyPrefix: rawKey[:API_KEY_DISPLAY_LENGTH],
+		Scopes:    input.Scopes,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		akService.logger.ErrorContext(ctx, "failed to create api key", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	akService.logger.InfoContext(ctx, "api key created successfully", "id", apiKey.ID, "user_id", userID)
+	return &models.CreatedAPIKey{APIKey: apiKey, Key: rawKey}, nil
+}
+
+func (akService *APIKeyService) ListAPIKeys(ctx context.Context, userID string) ([]*models.APIKey, error) {
+	apiKeys, err := akService.apiKeyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		akService.logger.ErrorContext(ctx, "failed to list api keys", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (akService *APIKeyService) DeleteAPIKey(ctx context.Context, id, userID string) error {
+	akService.logger.InfoContext(ctx, "deleting api key", "id", id, "user_id", userID)
+
+	if err := akService.apiKeyRepo.Delete(ctx, id, userID); err != nil {
+		akService.logger.ErrorContext(ctx, "failed to delete api key", "id", id, "user_id", userID, "error", err.Error())
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	akService.logger.InfoContext(ctx, "api key deleted successfully", "id", id, "user_id", userID)
+	return nil
+}
+
+// Authenticate resolves a raw key to the stored key it belongs to and records its use.
+// Unknown and expired keys return ErrInvalidAPIKey
+func (akService *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*models.APIKey, error) {
+	if !strings.HasPrefix(rawKey, API_KEY_PREFIX) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := akService.apiKeyRepo.GetByKeyHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, repositories.ErrAPIKeyNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+
+		akService.logger.ErrorContext(ctx, "failed to retrieve api key", "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve api key: %w", err)
+	}
+
+	now := akService.now()
+	if apiKey.IsExpired(now) {
+		akService.logger.InfoContext(ctx, "rejected expired api key", "id", apiKey.ID, "user_id", apiKey.UserID)
+		return nil, ErrInvalidAPIKey
+	}
+
+	// Tracking usage is best effort, it must not block the automation
+	lastUsedAt := now
+	if err := akService.apiKeyRepo.UpdateLastUsed(ctx, apiKey.ID, lastUsedAt); err != nil {
+		akService.logger.WarnContext(ctx, "failed to record api key usage", "id", apiKey.ID, "error", err.Error())
+	} else {
+		apiKey.LastUsedAt = &lastUsedAt
+	}
+
+	return apiKey, nil
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
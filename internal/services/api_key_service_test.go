@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	service := NewAPIKeyService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+	input := &models.CreateAPIKeyRequest{
+		Name:   "Zapier",
+		Scopes: []models.APIKeyScope{models.APIKeyScopeSyncRead},
+	}
+
+	var stored *models.APIKey
+	mockRepo.EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, apiKey *models.APIKey) (*models.APIKey, error) {
+			stored = apiKey
+			created := *apiKey
+			created.ID = "key123"
+			return &created, nil
+		})
+
+	result, err := service.CreateAPIKey(ctx, "user123", input)
+
+	require.NoError(err)
+	require.Equal("key123", result.ID)
+	require.True(strings.HasPrefix(result.Key, API_KEY_PREFIX))
+	require.Equal(result.Key[:API_KEY_DISPLAY_LENGTH], result.KeyPrefix)
+
+	// Only the hash of the raw key is stored
+	require.Equal("user123", stored.UserID)
+	require.Equal(hashAPIKey(result.Key), stored.KeyHash)
+	require.NotContains(stored.KeyHash, result.Key)
+	require.Equal(input.Scopes, stored.Scopes)
+}
+
+func TestAPIKeyService_CreateAPIKey_Error(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	service := NewAPIKeyService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+
+	result, err := service.CreateAPIKey(ctx, "user123", &models.CreateAPIKeyRequest{Name: "Zapier"})
+
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+	require.Nil(result)
+}
+
+func TestAPIKeyService_CreateAPIKey_Expiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(30 * 24 * time.Hour)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		expiresAt   *time.Time
+		expectedErr error
+	}{
+		{
+			name:      "expiry in the future is stored",
+			expiresAt: &future,
+		},
+		{
+			name:        "expiry in the past is rejected",
+			expiresAt:   &past,
+			expectedErr: ErrInvalidAPIKeyExpiry,
+		},
+		{
+			name:        "expiry now is rejected",
+			expiresAt:   &now,
+			expectedErr: ErrInvalidAPIKeyExpiry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+			service := NewAPIKeyService(mockRepo, createTestLogger())
+			service.now = func() time.Time { return now }
+
+			input := &models.CreateAPIKeyRequest{
+				Name:      "CI",
+				Scopes:    []models.APIKeyScope{models.APIKeyScopeSyncWrite},
+				ExpiresAt: tt.expiresAt,
+			}
+
+			if tt.expectedErr == nil {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, apiKey *models.APIKey) (*models.APIKey, error) {
+						require.Equal(tt.expiresAt, apiKey.ExpiresAt)
+						return apiKey, nil
+					})
+			}
+
+			result, err := service.CreateAPIKey(context.Background(), "user123", input)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, tt.expectedErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.expiresAt, result.ExpiresAt)
+		})
+	}
+}
+
+func TestAPIKeyService_DeleteAPIKey(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	service := NewAPIKeyService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+	mockRepo.EXPECT().Delete(ctx, "key123", "user123").Return(nil)
+	mockRepo.EXPECT().Delete(ctx, "key456", "user123").Return(repositories.ErrUnauthorized)
+
+	require.NoError(service.DeleteAPIKey(ctx, "key123", "user123"))
+	require.ErrorIs(service.DeleteAPIKey(ctx, "key456", "user123"), repositories.ErrUnauthorized)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	rawKey := API_KEY_PREFIX + "secret"
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		rawKey      string
+		setupMock   func(*mocks.MockAPIKeyRepository)
+		expectedErr error
+	}{
+		{
+			name:   "valid key",
+			rawKey: rawKey,
+			setupMock: func(mockRepo *mocks.MockAPIKeyRepository) {
+				mockRepo.EXPECT().GetByKeyHash(gomock.Any(), hashAPIKey(rawKey)).Return(&models.APIKey{ID: "key123", UserID: "user123"}, nil)
+				mockRepo.EXPECT().UpdateLastUsed(gomock.Any(), "key123", now).Return(nil)
+			},
+		},
+		{
+			name:   "usage tracking failure does not block",
+			rawKey: rawKey,
+			setupMock: func(mockRepo *mocks.MockAPIKeyRepository) {
+				mockRepo.EXPECT().GetByKeyHash(gomock.Any(), hashAPIKey(rawKey)).Return(&models.APIKey{ID: "key123", UserID: "user123"}, nil)
+				mockRepo.EXPECT().UpdateLastUsed(gomock.Any(), "key123", now).Return(repositories.ErrDatabaseOperation)
+			},
+		},
+		{
+			name:   "key not expired yet",
+			rawKey: rawKey,
+			setupMock: func(mockRepo *mocks.MockAPIKeyRepository) {
+				expiresAt := now.Add(time.Hour)
+				mockRepo.EXPECT().GetByKeyHash(gomock.Any(), hashAPIKey(rawKey)).Return(&models.APIKey{ID: "key123", UserID: "user123", ExpiresAt: &expiresAt}, nil)
+				mockRepo.EXPECT().UpdateLastUsed(gomock.Any(), "key123", now).Return(nil)
+			},
+		},
+		{
+			name:   "expired key",
+			rawKey: rawKey,
+			setupMock: func(mockRepo *mocks.MockAPIKeyRepository) {
+				expiresAt := now.Add(-time.Hour)
+				mockRepo.EXPECT().GetByKeyHash(gomock.Any(), hashAPIKey(rawKey)).Return(&models.APIKey{ID: "key123", UserID: "user123", ExpiresAt: &expiresAt}, nil)
+			},
+			expectedErr: ErrInvalidAPIKey,
+		},
+		{
+			name:        "key without prefix",
+			rawKey:      "secret",
+			setupMock:   func(mockRepo *mocks.MockAPIKeyRepository) {},
+			expectedErr: ErrInvalidAPIKey,
+		},
+		{
+			name:   "unknown key",
+			rawKey: rawKey,
+			setupMock: func(mockRepo *mocks.MockAPIKeyRepository) {
+				mockRepo.EXPECT().GetByKeyHash(gomock.Any(), hashAPIKey(rawKey)).Return(nil, repositories.ErrAPIKeyNotFound)
+			},
+			expectedErr: ErrInvalidAPIKey,
+		},
+		{
+			name:   "repository error",
+			rawKey: rawKey,
+			setupMock: func(mockRepo *mocks.MockAPIKeyRepository) {
+				mockRepo.EXPECT().GetByKeyHash(gomock.Any(), hashAPIKey(rawKey)).Return(nil, repositories.ErrCollectionNotFound)
+			},
+			expectedErr: repositories.ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			service := NewAPIKeyService(mockRepo, createTestLogger())
+			service.now = func() time.Time { return now }
+
+			result, err := service.Authenticate(context.Background(), tt.rawKey)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, tt.expectedErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal("user123", result.UserID)
+		})
+	}
+}
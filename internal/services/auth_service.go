@@ -2,20 +2,29 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 )
 
 //go:generate mockgen -source=auth_service.go -destination=mocks/mock_auth_service.go -package=mocks
 
+// SPOTIFY_AUTH_STATE_TTL bounds how long a user has to approve the app on spotify, once it expires
+// the callback can't complete the PKCE exchange nor link an account anymore
+const SPOTIFY_AUTH_STATE_TTL = 10 * time.Minute
+
 type AuthServicer interface {
-	GenerateSpotifyAuthURL(state string) string
+	GenerateSpotifyAuthURL(state string) (string, error)
+	GenerateSpotifyLinkURL(userID string) (string, error)
 	HandleSpotifyCallback(ctx context.Context, code, state string) (*AuthResult, error)
+	Logout(ctx context.Context, userID string) error
 }
 
 type AuthResult struct {
@@ -28,7 +37,20 @@ type AuthService struct {
 	userService               UserServicer
 	spotifyIntegrationService SpotifyIntegrationServicer
 	spotifyClient             spotifyclient.SpotifyAPI
+	spotifyAccounts           SpotifyAccountServicer
+	transactor                repositories.Transactor // nil when a new user and its integration are stored separately
 	logger                    *slog.Logger
+
+	// authStates maps the state of pending authorizations to their PKCE code verifier and, for
+	// account links, the user linking the account
+	authStatesMu sync.Mutex
+	authStates   map[string]pendingSpotifyAuthorization
+}
+
+type pendingSpotifyAuthorization struct {
+	userID       string // empty for logins
+	codeVerifier string
+	expiresAt    time.Time
 }
 
 func NewAuthService(
@@ -42,34 +64,129 @@ func NewAuthService(
 		spotifyIntegrationService: spotifyIntegrationService,
 		spotifyClient:             spotifyClient,
 		logger:                    logger.With("component", "AuthService"),
+		authStates:                make(map[string]pendingSpotifyAuthorization),
 	}
 }
 
-func (s *AuthService) GenerateSpotifyAuthURL(state string) string {
-	authURL := s.spotifyClient.GenerateAuthURL(state)
+// WithSpotifyAccounts re-links the users that disconnected their spotify account when they log in
+// with it again, instead of creating new users for them
+func (s *AuthService) WithSpotifyAccounts(spotifyAccounts SpotifyAccountServicer) *AuthService {
+	s.spotifyAccounts = spotifyAccounts
+	return s
+}
+
+// WithTransactor stores a new user and its spotify integration together, so a failed integration
+// doesn't leave behind a user nobody can log in as
+func (s *AuthService) WithTransactor(transactor repositories.Transactor) *AuthService {
+	s.transactor = transactor
+	return s
+}
+
+// GenerateSpotifyAuthURL returns the spotify authorization URL of a login. The URL carries a PKCE
+// code challenge, its verifier is kept under the state until spotify redirects back to the callback
+func (s *AuthService) GenerateSpotifyAuthURL(state string) (string, error) {
+	authURL, err := s.startAuthorization(state, "")
+	if err != nil {
+		return "", err
+	}
+
 	s.logger.Info("generated spotify auth url", "state", state)
-	return authURL
+	return authURL, nil
+}
+
+// GenerateSpotifyLinkURL returns the spotify authorization URL that links another spotify account to
+// the user. Its state identifies the user when spotify redirects back to the callback
+func (s *AuthService) GenerateSpotifyLinkURL(userID string) (string, error) {
+	state, err := generateSecretToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate link state: %w", err)
+	}
+
+	authURL, err := s.startAuthorization(state, userID)
+	if err != nil {
+		return "", err
+	}
+
+	s.logger.Info("generated spotify link url", "user_id", userID)
+	return authURL, nil
+}
+
+// startAuthorization stores a new PKCE code verifier under the state and returns the authorization
+// URL carrying its challenge
+func (s *AuthService) startAuthorization(state, userID string) (string, error) {
+	codeVerifier, err := spotifyclient.GeneratePKCEVerifier()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pkce code verifier: %w", err)
+	}
+
+	now := time.Now()
+
+	s.authStatesMu.Lock()
+	for pendingState, authorization := range s.authStates {
+		if now.After(authorization.expiresAt) {
+			delete(s.authStates, pendingState)
+		}
+	}
+	s.authStates[state] = pendingSpotifyAuthorization{
+		userID:       userID,
+		codeVerifier: codeVerifier,
+		expiresAt:    now.Add(SPOTIFY_AUTH_STATE_TTL),
+	}
+	s.authStatesMu.Unlock()
+
+	return s.spotifyClient.GenerateAuthURL(state, spotifyclient.PKCEChallenge(codeVerifier)), nil
+}
+
+// takeAuthState returns the pending authorization started with the given state, if any. A state can
+// only be used once
+func (s *AuthService) takeAuthState(state string) (pendingSpotifyAuthorization, bool) {
+	s.authStatesMu.Lock()
+	defer s.authStatesMu.Unlock()
+
+	authorization, ok := s.authStates[state]
+	if !ok {
+		return pendingSpotifyAuthorization{}, false
+	}
+	delete(s.authStates, state)
+
+	if time.Now().After(authorization.expiresAt) {
+		return pendingSpotifyAuthorization{}, false
+	}
+
+	return authorization, true
 }
 
 func (s *AuthService) HandleSpotifyCallback(ctx context.Context, code, state string) (*AuthResult, error) {
 	s.logger.InfoContext(ctx, "handling spotify callback", "code", code, "state", state)
 
-	// Exchange code for tokens
-	tokens, err := s.spotifyClient.ExchangeCodeForTokens(ctx, code)
+	// Exchange code for tokens, proving with the PKCE verifier this app requested the authorization.
+	// Unknown states fall back to the plain exchange, which needs the client secret
+	authorization, _ := s.takeAuthState(state)
+	tokens, err := s.spotifyClient.ExchangeCodeForTokens(ctx, code, authorization.codeVerifier)
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code for tokens: %w", err)
 	}
 
-	// Get user profile from Spotify
-	profile, err := s.spotifyClient.GetUserProfile(ctx, tokens.AccessToken)
+	// Get user profile from Spotify, the integration is not stored yet so the new tokens are
+	// the only credentials available
+	spotifyCtx := requestcontext.ContextWithSpotifyAuth(ctx, &models.SpotifyIntegration{AccessToken: tokens.AccessToken})
+	profile, err := s.spotifyClient.GetUserProfile(spotifyCtx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user profile: %w", err)
 	}
 
-	// Create or update user in PocketBase
-	user, err := s.createOrUpdateUser(ctx, profile, tokens)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create/update user: %w", err)
+	// Callbacks of a link flow add the account to the user that started it, others log in with it
+	var user *models.AuthUser
+	if authorization.userID != "" {
+		user, err = s.linkSpotifyAccount(ctx, authorization.userID, profile, tokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to link spotify account: %w", err)
+		}
+	} else {
+		user, err = s.createOrUpdateUser(ctx, profile, tokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create/update user: %w", err)
+		}
 	}
 
 	// Generate PocketBase JWT token for this user
@@ -85,6 +202,64 @@ func (s *AuthService) HandleSpotifyCallback(ctx context.Context, code, state str
 	}, nil
 }
 
+// Logout terminates every session of the user by revoking the auth tokens issued to them. The spotify
+// integrations are kept, logging in again doesn't need to link the accounts again
+func (s *AuthService) Logout(ctx context.Context, userID string) error {
+	s.logger.InfoContext(ctx, "logging out user", "user_id", userID)
+
+	if err := s.userService.RevokeAuthTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke auth tokens: %w", err)
+	}
+
+	return nil
+}
+
+// linkSpotifyAccount stores the spotify account as an additional integration of the user, unless
+// another user already has it linked
+func (s *AuthService) linkSpotifyAccount(
+	ctx context.Context,
+	userID string,
+	profile *spotifyclient.SpotifyUserProfile,
+	tokens *spotifyclient.SpotifyTokenResponse,
+) (*models.AuthUser, error) {
+	s.logger.InfoContext(ctx, "linking spotify account", "user_id", userID, "spotify_id", profile.ID)
+
+	existing, err := s.spotifyIntegrationService.GetIntegrationBySpotifyID(ctx, profile.ID)
+	if err != nil && !errors.Is(err, repositories.ErrSpotifyIntegrationNotFound) {
+		s.logger.ErrorContext(ctx, "failed to fetch spotify integration", "spotify_id", profile.ID, "error", err.Error())
+		return nil, err
+	}
+	if existing != nil && existing.UserID != userID {
+		s.logger.WarnContext(ctx, "spotify account already linked to another user", "user_id", userID, "spotify_id", profile.ID)
+		return nil, ErrSpotifyAccountLinked
+	}
+
+	user, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to fetch user", "user_id", userID, "error", err.Error())
+		return nil, err
+	}
+
+	integration := &models.SpotifyIntegration{
+		SpotifyID:    profile.ID,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    tokens.TokenType,
+		ExpiresAt:    time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second),
+		Scope:        tokens.Scope,
+		DisplayName:  profile.Name,
+	}
+
+	linkedIntegration, err := s.spotifyIntegrationService.CreateOrUpdateIntegration(ctx, userID, integration)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to store linked spotify integration", "user_id", userID, "spotify_id", profile.ID, "error", err.Error())
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "spotify account linked successfully", "user_id", userID, "spotify_id", profile.ID, "integration_id", linkedIntegration.ID)
+	return user.ToAuthUser(linkedIntegration), nil
+}
+
 func (s *AuthService) createOrUpdateUser(
 	ctx context.Context,
 	profile *spotifyclient.SpotifyUserProfile,
@@ -95,11 +270,52 @@ func (s *AuthService) createOrUpdateUser(
 		return nil, err
 	}
 
+	if user == nil {
+		user, err = s.findDisconnectedUser(ctx, profile.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if user == nil {
 		return s.createNewUser(ctx, profile, tokens)
 	}
 
-	return s.updateExistingUser(ctx, user, profile, tokens)
+	authUser, err := s.updateExistingUser(ctx, user, profile, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	// The integration is stored again at this point, so the suspended playlists can be reactivated.
+	// The marker is only cleared once they are, a failed re-link is retried on the next login
+	if user.DisconnectedSpotifyID != "" && s.spotifyAccounts != nil {
+		if err := s.spotifyAccounts.Relink(ctx, user.ID); err != nil {
+			s.logger.ErrorContext(ctx, "failed to re-link spotify account", "user_id", user.ID, "spotify_id", profile.ID, "error", err.Error())
+			return nil, fmt.Errorf("failed to re-link spotify account: %w", err)
+		}
+	}
+
+	return authUser, nil
+}
+
+// findDisconnectedUser looks for the user that disconnected the spotify account, so logging in with
+// it again re-links the user instead of creating a new one
+func (s *AuthService) findDisconnectedUser(ctx context.Context, spotifyID string) (*models.User, error) {
+	if s.spotifyAccounts == nil {
+		return nil, nil
+	}
+
+	user, err := s.spotifyAccounts.FindDisconnectedUser(ctx, spotifyID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to fetch disconnected user", "spotify_id", spotifyID, "error", err.Error())
+		return nil, err
+	}
+
+	if user != nil {
+		s.logger.InfoContext(ctx, "found user that disconnected the spotify account", "user_id", user.ID, "spotify_id", spotifyID)
+	}
+
+	return user, nil
 }
 
 func (s *AuthService) findUserBySpotifyID(ctx context.Context, spotifyID string) (*models.User, error) {
@@ -141,13 +357,6 @@ func (s *AuthService) createNewUser(
 		Name:  profile.Name,
 	}
 
-	// Create the user in the database
-	createdUser, err := s.userService.CreateUser(ctx, user)
-	if err != nil {
-		s.logger.ErrorContext(ctx, "failed to create user", "spotify_id", profile.ID, "error", err.Error())
-		return nil, err
-	}
-
 	// Calculate expiration time from ExpiresIn seconds
 	expiresAt := time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
 
@@ -162,9 +371,25 @@ func (s *AuthService) createNewUser(
 		DisplayName:  profile.Name,
 	}
 
-	createdIntegration, err := s.spotifyIntegrationService.CreateOrUpdateIntegration(ctx, createdUser.ID, integration)
+	// The user and its integration are stored together, a user without one couldn't log in again
+	var createdUser *models.User
+	var createdIntegration *models.SpotifyIntegration
+	err := runInTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		createdUser, err = s.userService.CreateUser(ctx, user)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to create user", "spotify_id", profile.ID, "error", err.Error())
+			return err
+		}
+
+		createdIntegration, err = s.spotifyIntegrationService.CreateOrUpdateIntegration(ctx, createdUser.ID, integration)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to create spotify integration", "user_id", createdUser.ID, "spotify_id", profile.ID, "error", err.Error())
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.ErrorContext(ctx, "failed to create spotify integration", "user_id", createdUser.ID, "spotify_id", profile.ID, "error", err.Error())
 		return nil, err
 	}
 
@@ -189,9 +414,10 @@ func (s *AuthService) updateExistingUser(
 		s.logger.InfoContext(ctx, "user profile data changed, updating user", "user_id", user.ID, "old_email", user.Email, "new_email", profile.Email)
 
 		userToUpdate := &models.User{
-			ID:    user.ID,
-			Email: profile.Email,
-			Name:  profile.Name,
+			ID:                    user.ID,
+			Email:                 profile.Email,
+			Name:                  profile.Name,
+			DisconnectedSpotifyID: user.DisconnectedSpotifyID,
 		}
 
 		var err error
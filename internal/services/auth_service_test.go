@@ -12,6 +12,7 @@ import (
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 	repoMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -61,16 +62,27 @@ func TestAuthService_GenerateSpotifyAuthURL(t *testing.T) {
 	expectedURL := "https://accounts.spotify.com/authorize?client_id=test&state=test_state"
 
 	// Setup mock expectations
+	var codeChallenge string
 	mockSpotifyClient.EXPECT().
-		GenerateAuthURL(state).
-		Return(expectedURL).
+		GenerateAuthURL(state, gomock.Any()).
+		DoAndReturn(func(_, challenge string) string {
+			codeChallenge = challenge
+			return expectedURL
+		}).
 		Times(1)
 
 	// Execute
-	actualURL := authService.GenerateSpotifyAuthURL(state)
+	actualURL, err := authService.GenerateSpotifyAuthURL(state)
 
 	// Assert
+	assert.NoError(err)
 	assert.Equal(expectedURL, actualURL)
+
+	// The verifier matching the challenge is kept for the login callback
+	authorization, ok := authService.takeAuthState(state)
+	assert.True(ok)
+	assert.Empty(authorization.userID)
+	assert.Equal(spotifyclient.PKCEChallenge(authorization.codeVerifier), codeChallenge)
 }
 
 func TestAuthService_FindUserBySpotifyID_Success(t *testing.T) {
@@ -96,14 +108,12 @@ func TestAuthService_FindUserBySpotifyID_Success(t *testing.T) {
 		TokenType:    "Bearer",
 		ExpiresAt:    time.Now().Add(time.Hour),
 	}
-	expectedUser := &models.User{
-		ID:       "user123",
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-		Created:  time.Now().Add(-24 * time.Hour),
-		Updated:  time.Now(),
-	}
+	expectedUser := testfixtures.NewUser().
+		WithID("user123").
+		WithEmail("test@example.com").
+		WithUsername("testuser").
+		WithName("Test User").
+		Build()
 
 	// Setup mock expectations
 	mockSpotifyIntegrationRepo.EXPECT().
@@ -250,13 +260,7 @@ func TestAuthService_CreateNewUser_Success(t *testing.T) {
 		Scope:        "user-read-private user-read-email",
 	}
 
-	expectedUser := &models.User{
-		ID:      "user123",
-		Email:   profile.Email,
-		Name:    profile.Name,
-		Created: time.Now(),
-		Updated: time.Now(),
-	}
+	expectedUser := testfixtures.NewUser().WithID("user123").WithEmail(profile.Email).WithName(profile.Name).Build()
 
 	expectedIntegration := &models.SpotifyIntegration{
 		ID:           "integration123",
@@ -376,11 +380,7 @@ func TestAuthService_CreateNewUser_IntegrationCreationError(t *testing.T) {
 		Scope:        "user-read-private",
 	}
 
-	expectedUser := &models.User{
-		ID:    "user123",
-		Email: profile.Email,
-		Name:  profile.Name,
-	}
+	expectedUser := testfixtures.NewUser().WithID("user123").WithEmail(profile.Email).WithName(profile.Name).Build()
 
 	// Setup mock expectations
 	mockUserRepo.EXPECT().
@@ -402,6 +402,47 @@ func TestAuthService_CreateNewUser_IntegrationCreationError(t *testing.T) {
 	assert.Contains(err.Error(), "unable to complete db operation")
 }
 
+func TestAuthService_CreateNewUser_Transaction(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockUserRepo := repoMocks.NewMockUserRepository(ctrl)
+	mockSpotifyIntegrationRepo := repoMocks.NewMockSpotifyIntegrationRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	mockTransactor := repoMocks.NewMockTransactor(ctrl)
+	logger := createTestLogger()
+
+	userService := NewUserService(mockUserRepo, logger)
+	spotifyIntegrationService := NewSpotifyIntegrationService(mockSpotifyIntegrationRepo, logger)
+	authService := NewAuthService(userService, spotifyIntegrationService, mockSpotifyClient, logger).WithTransactor(mockTransactor)
+
+	profile := &spotifyclient.SpotifyUserProfile{ID: "spotify_user_123", Email: "test@example.com", Name: "Test User"}
+	tokens := &spotifyclient.SpotifyTokenResponse{AccessToken: "access_token_123", RefreshToken: "refresh_token_123", ExpiresIn: 3600}
+	expectedUser := testfixtures.NewUser().WithID("user123").Build()
+
+	// Both writes run with the context of the transaction, which is rolled back by its error
+	type txKey struct{}
+	mockTransactor.EXPECT().RunInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(context.WithValue(ctx, txKey{}, true))
+		})
+	mockUserRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, user *models.User) (*models.User, error) {
+			assert.Equal(true, ctx.Value(txKey{}))
+			return expectedUser, nil
+		})
+	mockSpotifyIntegrationRepo.EXPECT().CreateOrUpdate(gomock.Any(), expectedUser.ID, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID string, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error) {
+			assert.Equal(true, ctx.Value(txKey{}))
+			return nil, repositories.ErrDatabaseOperation
+		})
+
+	result, err := authService.createNewUser(context.Background(), profile, tokens)
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
 func TestAuthService_UpdateExistingUser_Success_NoUserChanges(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
@@ -416,11 +457,7 @@ func TestAuthService_UpdateExistingUser_Success_NoUserChanges(t *testing.T) {
 	authService := NewAuthService(userService, spotifyIntegrationService, mockSpotifyClient, logger)
 
 	// Test data - user profile matches existing user
-	existingUser := &models.User{
-		ID:    "user123",
-		Email: "test@example.com",
-		Name:  "Test User",
-	}
+	existingUser := testfixtures.NewUser().WithID("user123").WithEmail("test@example.com").WithName("Test User").Build()
 	profile := &spotifyclient.SpotifyUserProfile{
 		ID:    "spotify_user_123",
 		Email: "test@example.com", // Same as existing
@@ -492,11 +529,7 @@ func TestAuthService_UpdateExistingUser_Success_WithUserChanges(t *testing.T) {
 	authService := NewAuthService(userService, spotifyIntegrationService, mockSpotifyClient, logger)
 
 	// Test data - user profile has changes
-	existingUser := &models.User{
-		ID:    "user123",
-		Email: "old@example.com",
-		Name:  "Old Name",
-	}
+	existingUser := testfixtures.NewUser().WithID("user123").WithEmail("old@example.com").WithName("Old Name").Build()
 	profile := &spotifyclient.SpotifyUserProfile{
 		ID:    "spotify_user_123",
 		Email: "new@example.com", // Changed
@@ -510,13 +543,7 @@ func TestAuthService_UpdateExistingUser_Success_WithUserChanges(t *testing.T) {
 		Scope:        "user-read-private user-read-email",
 	}
 
-	expectedUpdatedUser := &models.User{
-		ID:      "user123",
-		Email:   profile.Email,
-		Name:    profile.Name,
-		Created: time.Now().Add(-24 * time.Hour),
-		Updated: time.Now(),
-	}
+	expectedUpdatedUser := testfixtures.NewUser().WithID("user123").WithEmail(profile.Email).WithName(profile.Name).Build()
 
 	expectedUpdatedIntegration := &models.SpotifyIntegration{
 		ID:           "integration123",
@@ -574,11 +601,7 @@ func TestAuthService_UpdateExistingUser_UserUpdateError(t *testing.T) {
 	spotifyIntegrationService := NewSpotifyIntegrationService(mockSpotifyIntegrationRepo, logger)
 	authService := NewAuthService(userService, spotifyIntegrationService, mockSpotifyClient, logger)
 
-	existingUser := &models.User{
-		ID:    "user123",
-		Email: "old@example.com",
-		Name:  "Old Name",
-	}
+	existingUser := testfixtures.NewUser().WithID("user123").WithEmail("old@example.com").WithName("Old Name").Build()
 	profile := &spotifyclient.SpotifyUserProfile{
 		ID:    "spotify_user_123",
 		Email: "new@example.com", // Changed
@@ -617,11 +640,7 @@ func TestAuthService_UpdateExistingUser_IntegrationUpdateError(t *testing.T) {
 	spotifyIntegrationService := NewSpotifyIntegrationService(mockSpotifyIntegrationRepo, logger)
 	authService := NewAuthService(userService, spotifyIntegrationService, mockSpotifyClient, logger)
 
-	existingUser := &models.User{
-		ID:    "user123",
-		Email: "test@example.com",
-		Name:  "Test User",
-	}
+	existingUser := testfixtures.NewUser().WithID("user123").WithEmail("test@example.com").WithName("Test User").Build()
 	profile := &spotifyclient.SpotifyUserProfile{
 		ID:    "spotify_user_123",
 		Email: "test@example.com", // No change
@@ -677,13 +696,7 @@ func TestAuthService_HandleSpotifyCallback_Success(t *testing.T) {
 					Name:  "Test User",
 				}
 
-				createdUser := &models.User{
-					ID:      "user123",
-					Email:   profile.Email,
-					Name:    profile.Name,
-					Created: time.Now(),
-					Updated: time.Now(),
-				}
+				createdUser := testfixtures.NewUser().WithID("user123").WithEmail(profile.Email).WithName(profile.Name).Build()
 
 				createdIntegration := &models.SpotifyIntegration{
 					ID:           "integration123",
@@ -700,12 +713,12 @@ func TestAuthService_HandleSpotifyCallback_Success(t *testing.T) {
 				}
 
 				mockSpotifyClient.EXPECT().
-					ExchangeCodeForTokens(gomock.Any(), "auth_code_123").
+					ExchangeCodeForTokens(gomock.Any(), "auth_code_123", "").
 					Return(tokens, nil).
 					Times(1)
 
 				mockSpotifyClient.EXPECT().
-					GetUserProfile(gomock.Any(), tokens.AccessToken).
+					GetUserProfile(gomock.Any()).
 					Return(profile, nil).
 					Times(1)
 
@@ -755,11 +768,7 @@ func TestAuthService_HandleSpotifyCallback_Success(t *testing.T) {
 					Name:  "Updated User",        // Name changed
 				}
 
-				existingUser := &models.User{
-					ID:    "user123",
-					Email: "old@example.com",
-					Name:  "Old User",
-				}
+				existingUser := testfixtures.NewUser().WithID("user123").WithEmail("old@example.com").WithName("Old User").Build()
 
 				existingIntegration := &models.SpotifyIntegration{
 					ID:        "integration123",
@@ -767,13 +776,7 @@ func TestAuthService_HandleSpotifyCallback_Success(t *testing.T) {
 					SpotifyID: profile.ID,
 				}
 
-				updatedUser := &models.User{
-					ID:      existingUser.ID,
-					Email:   profile.Email,
-					Name:    profile.Name,
-					Created: time.Now().Add(-24 * time.Hour),
-					Updated: time.Now(),
-				}
+				updatedUser := testfixtures.NewUser().WithID(existingUser.ID).WithEmail(profile.Email).WithName(profile.Name).Build()
 
 				updatedIntegration := &models.SpotifyIntegration{
 					ID:           existingIntegration.ID,
@@ -790,12 +793,12 @@ func TestAuthService_HandleSpotifyCallback_Success(t *testing.T) {
 				}
 
 				mockSpotifyClient.EXPECT().
-					ExchangeCodeForTokens(gomock.Any(), "auth_code_123").
+					ExchangeCodeForTokens(gomock.Any(), "auth_code_123", "").
 					Return(tokens, nil).
 					Times(1)
 
 				mockSpotifyClient.EXPECT().
-					GetUserProfile(gomock.Any(), tokens.AccessToken).
+					GetUserProfile(gomock.Any()).
 					Return(profile, nil).
 					Times(1)
 
@@ -879,7 +882,7 @@ func TestAuthService_HandleSpotifyCallback_Errors(t *testing.T) {
 			authCode:    "invalid_auth_code",
 			setupMocks: func(mockUserRepo *repoMocks.MockUserRepository, mockSpotifyIntegrationRepo *repoMocks.MockSpotifyIntegrationRepository, mockSpotifyClient *spotifyMocks.MockSpotifyAPI) {
 				mockSpotifyClient.EXPECT().
-					ExchangeCodeForTokens(gomock.Any(), "invalid_auth_code").
+					ExchangeCodeForTokens(gomock.Any(), "invalid_auth_code", "").
 					Return(nil, errors.New("token exchange failed")).
 					Times(1)
 			},
@@ -899,12 +902,12 @@ func TestAuthService_HandleSpotifyCallback_Errors(t *testing.T) {
 				}
 
 				mockSpotifyClient.EXPECT().
-					ExchangeCodeForTokens(gomock.Any(), "auth_code_123").
+					ExchangeCodeForTokens(gomock.Any(), "auth_code_123", "").
 					Return(tokens, nil).
 					Times(1)
 
 				mockSpotifyClient.EXPECT().
-					GetUserProfile(gomock.Any(), tokens.AccessToken).
+					GetUserProfile(gomock.Any()).
 					Return(nil, errors.New("profile fetch failed")).
 					Times(1)
 			},
@@ -930,12 +933,12 @@ func TestAuthService_HandleSpotifyCallback_Errors(t *testing.T) {
 				}
 
 				mockSpotifyClient.EXPECT().
-					ExchangeCodeForTokens(gomock.Any(), "auth_code_123").
+					ExchangeCodeForTokens(gomock.Any(), "auth_code_123", "").
 					Return(tokens, nil).
 					Times(1)
 
 				mockSpotifyClient.EXPECT().
-					GetUserProfile(gomock.Any(), tokens.AccessToken).
+					GetUserProfile(gomock.Any()).
 					Return(profile, nil).
 					Times(1)
 
@@ -970,13 +973,7 @@ func TestAuthService_HandleSpotifyCallback_Errors(t *testing.T) {
 					Name:  "Test User",
 				}
 
-				createdUser := &models.User{
-					ID:      "user123",
-					Email:   profile.Email,
-					Name:    profile.Name,
-					Created: time.Now(),
-					Updated: time.Now(),
-				}
+				createdUser := testfixtures.NewUser().WithID("user123").WithEmail(profile.Email).WithName(profile.Name).Build()
 
 				createdIntegration := &models.SpotifyIntegration{
 					ID:           "integration123",
@@ -993,12 +990,12 @@ func TestAuthService_HandleSpotifyCallback_Errors(t *testing.T) {
 				}
 
 				mockSpotifyClient.EXPECT().
-					ExchangeCodeForTokens(gomock.Any(), "auth_code_123").
+					ExchangeCodeForTokens(gomock.Any(), "auth_code_123", "").
 					Return(tokens, nil).
 					Times(1)
 
 				mockSpotifyClient.EXPECT().
-					GetUserProfile(gomock.Any(), tokens.AccessToken).
+					GetUserProfile(gomock.Any()).
 					Return(profile, nil).
 					Times(1)
 
@@ -1053,3 +1050,221 @@ func TestAuthService_HandleSpotifyCallback_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_CreateOrUpdateUser_RelinksDisconnectedUser(t *testing.T) {
+	assert := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockUserRepo := repoMocks.NewMockUserRepository(ctrl)
+	mockSpotifyIntegrationRepo := repoMocks.NewMockSpotifyIntegrationRepository(ctrl)
+	mockBasePlaylistRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	logger := createTestLogger()
+
+	userService := NewUserService(mockUserRepo, logger)
+	spotifyIntegrationService := NewSpotifyIntegrationService(mockSpotifyIntegrationRepo, logger)
+	spotifyAccountService := NewSpotifyAccountService(mockUserRepo, spotifyIntegrationService, mockBasePlaylistRepo, nil, logger)
+	authService := NewAuthService(userService, spotifyIntegrationService, mockSpotifyClient, logger).
+		WithSpotifyAccounts(spotifyAccountService)
+
+	profile := &spotifyclient.SpotifyUserProfile{
+		ID:    "spotify_user_123",
+		Email: "test@example.com",
+		Name:  "Test User",
+	}
+	tokens := &spotifyclient.SpotifyTokenResponse{
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		ExpiresIn:    3600,
+	}
+
+	disconnectedUser := testfixtures.NewUser().WithDisconnectedSpotifyID(profile.ID).Build()
+	integration := testfixtures.NewSpotifyIntegration().WithUserID(disconnectedUser.ID).Build()
+
+	// The account has no integration anymore, so the user is found by the disconnect marker
+	mockSpotifyIntegrationRepo.EXPECT().GetBySpotifyID(gomock.Any(), profile.ID).Return(nil, repositories.ErrSpotifyIntegrationNotFound)
+	mockUserRepo.EXPECT().GetByDisconnectedSpotifyID(gomock.Any(), profile.ID).Return(disconnectedUser, nil)
+
+	gomock.InOrder(
+		mockSpotifyIntegrationRepo.EXPECT().CreateOrUpdate(gomock.Any(), disconnectedUser.ID, gomock.Any()).Return(integration, nil),
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), disconnectedUser.ID).Return(disconnectedUser, nil),
+		mockBasePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), disconnectedUser.ID).Return(nil, nil),
+		mockUserRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *models.User) (*models.User, error) {
+				assert.Equal(disconnectedUser.ID, user.ID)
+				assert.Empty(user.DisconnectedSpotifyID)
+				return user, nil
+			}),
+	)
+
+	result, err := authService.createOrUpdateUser(context.Background(), profile, tokens)
+
+	assert.NoError(err)
+	assert.Equal(disconnectedUser.ID, result.ID)
+	assert.Equal(profile.ID, result.SpotifyID)
+}
+
+func TestAuthService_GenerateSpotifyLinkURL(t *testing.T) {
+	assert := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	authService := NewAuthService(nil, nil, mockSpotifyClient, createTestLogger())
+
+	var linkState, codeChallenge string
+	mockSpotifyClient.EXPECT().
+		GenerateAuthURL(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(state, challenge string) string {
+			linkState, codeChallenge = state, challenge
+			return "https://accounts.spotify.com/authorize?state=" + state
+		})
+
+	authURL, err := authService.GenerateSpotifyLinkURL("user123")
+
+	assert.NoError(err)
+	assert.Equal("https://accounts.spotify.com/authorize?state="+linkState, authURL)
+
+	// The state identifies the user only once
+	authorization, ok := authService.takeAuthState(linkState)
+	assert.True(ok)
+	assert.Equal("user123", authorization.userID)
+	assert.Equal(spotifyclient.PKCEChallenge(authorization.codeVerifier), codeChallenge)
+
+	_, ok = authService.takeAuthState(linkState)
+	assert.False(ok)
+}
+
+func TestAuthService_TakeAuthState_Expired(t *testing.T) {
+	assert := require.New(t)
+	authService := NewAuthService(nil, nil, nil, createTestLogger())
+	authService.authStates["expired_state"] = pendingSpotifyAuthorization{userID: "user123", expiresAt: time.Now().Add(-time.Second)}
+
+	_, ok := authService.takeAuthState("expired_state")
+
+	assert.False(ok)
+}
+
+func TestAuthService_HandleSpotifyCallback_LinkAccount(t *testing.T) {
+	tokens := &spotifyclient.SpotifyTokenResponse{
+		AccessToken:  "access_token_dj",
+		RefreshToken: "refresh_token_dj",
+		ExpiresIn:    3600,
+	}
+	profile := &spotifyclient.SpotifyUserProfile{ID: "spotify_dj", Name: "DJ Account"}
+
+	tests := []struct {
+		name          string
+		existingOwner string
+		expectedError error
+	}{
+		{
+			name: "new account is linked",
+		},
+		{
+			name:          "account already linked by the user is refreshed",
+			existingOwner: "user123",
+		},
+		{
+			name:          "account of another user is rejected",
+			existingOwner: "other_user",
+			expectedError: ErrSpotifyAccountLinked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := setupMockController(t)
+
+			mockUserRepo := repoMocks.NewMockUserRepository(ctrl)
+			mockSpotifyIntegrationRepo := repoMocks.NewMockSpotifyIntegrationRepository(ctrl)
+			mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+			logger := createTestLogger()
+			authService := NewAuthService(
+				NewUserService(mockUserRepo, logger),
+				NewSpotifyIntegrationService(mockSpotifyIntegrationRepo, logger),
+				mockSpotifyClient,
+				logger,
+			)
+			authService.authStates["link_state"] = pendingSpotifyAuthorization{
+				userID:       "user123",
+				codeVerifier: "code_verifier",
+				expiresAt:    time.Now().Add(time.Minute),
+			}
+
+			mockSpotifyClient.EXPECT().ExchangeCodeForTokens(gomock.Any(), "auth_code", "code_verifier").Return(tokens, nil)
+			mockSpotifyClient.EXPECT().GetUserProfile(gomock.Any()).Return(profile, nil)
+
+			if tt.existingOwner == "" {
+				mockSpotifyIntegrationRepo.EXPECT().GetBySpotifyID(gomock.Any(), profile.ID).Return(nil, repositories.ErrSpotifyIntegrationNotFound)
+			} else {
+				existing := testfixtures.NewSpotifyIntegration().WithUserID(tt.existingOwner).WithSpotifyID(profile.ID).Build()
+				mockSpotifyIntegrationRepo.EXPECT().GetBySpotifyID(gomock.Any(), profile.ID).Return(existing, nil)
+			}
+
+			if tt.expectedError == nil {
+				user := testfixtures.NewUser().Build()
+				linked := testfixtures.NewSpotifyIntegration().WithID("integration_dj").WithSpotifyID(profile.ID).Build()
+
+				mockUserRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(user, nil)
+				mockSpotifyIntegrationRepo.EXPECT().
+					CreateOrUpdate(gomock.Any(), "user123", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error) {
+						assert.Equal(profile.ID, integration.SpotifyID)
+						assert.Equal(tokens.AccessToken, integration.AccessToken)
+						return linked, nil
+					})
+				mockUserRepo.EXPECT().GenerateAuthToken(gomock.Any(), "user123").Return("jwt_auth_token", nil)
+			}
+
+			result, err := authService.HandleSpotifyCallback(context.Background(), "auth_code", "link_state")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal("user123", result.User.ID)
+			assert.Equal(profile.ID, result.User.SpotifyID)
+			assert.Equal("jwt_auth_token", result.Token)
+		})
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	tests := []struct {
+		name          string
+		repositoryErr error
+	}{
+		{
+			name: "revokes the user's auth tokens",
+		},
+		{
+			name:          "revocation error",
+			repositoryErr: repositories.ErrDatabaseOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctrl := setupMockController(t)
+
+			mockUserRepo := repoMocks.NewMockUserRepository(ctrl)
+			logger := createTestLogger()
+			authService := NewAuthService(NewUserService(mockUserRepo, logger), nil, nil, logger)
+
+			mockUserRepo.EXPECT().RevokeAuthTokens(gomock.Any(), "user123").Return(tt.repositoryErr)
+
+			err := authService.Logout(context.Background(), "user123")
+
+			if tt.repositoryErr != nil {
+				assert.ErrorIs(err, tt.repositoryErr)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
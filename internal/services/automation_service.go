@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=automation_service.go -destination=mocks/mock_automation_service.go -package=mocks
+
+// AutomationServicer builds the polling trigger feeds of automation platforms such as Zapier or IFTTT.
+// Feeds list the newest items first with stable IDs, so the platforms can detect new items
+type AutomationServicer interface {
+	GetSyncCompletedTriggers(ctx context.Context, userID string) ([]*models.SyncCompletedTrigger, error)
+	GetTracksRoutedTriggers(ctx context.Context, userID string) ([]*models.TracksRoutedTrigger, error)
+}
+
+type AutomationService struct {
+	syncEventService  SyncEventServicer
+	basePlaylistRepo  repositories.BasePlaylistRepository
+	childPlaylistRepo repositories.ChildPlaylistRepository
+	logger            *slog.Logger
+}
+
+func NewAutomationService(
+	syncEventService SyncEventServicer,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	logger *slog.Logger,
+) *AutomationService {
+	return &AutomationService{
+		syncEventService:  syncEventService,
+		basePlaylistRepo:  basePlaylistRepo,
+		childPlaylistRepo: childPlaylistRepo,
+		logger:            logger.With("component", "AutomationService"),
+	}
+}
+
+func (aService *AutomationService) GetSyncCompletedTriggers(ctx context.Context, userID string) ([]*models.SyncCompletedTrigger, error) {
+	syncEvents, err := aService.syncEventService.GetRecentCompletedSyncEvents(ctx, userID, models.MAX_AUTOMATION_TRIGGER_ITEMS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve completed syncs: %w", err)
+	}
+
+	basePlaylistNames, err := aService.getBasePlaylistNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := make([]*models.SyncCompletedTrigger, 0, len(syncEvents))
+	for _, syncEvent := range syncEvents {
+		triggers = append(triggers, &models.SyncCompletedTrigger{
+			ID:               syncEvent.ID,
+			SyncEventID:      syncEvent.ID,
+			BasePlaylistID:   syncEvent.BasePlaylistID,
+			BasePlaylistName: basePlaylistNames[syncEvent.BasePlaylistID],
+			TracksProcessed:  syncEvent.TracksProcessed,
+			TracksUnmatched:  syncEvent.TracksUnmatched,
+			CompletedAt:      completedAt(syncEvent),
+		})
+	}
+
+	return triggers, nil
+}
+
+func (aService *AutomationService) GetTracksRoutedTriggers(ctx context.Context, userID string) ([]*models.TracksRoutedTrigger, error) {
+	syncEvents, err := aService.syncEventService.GetRecentCompletedSyncEvents(ctx, userID, models.MAX_AUTOMATION_TRIGGER_ITEMS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve completed syncs: %w", err)
+	}
+
+	// Child playlist names are looked up once per base playlist
+	childPlaylistNames := map[string]string{}
+	loadedBasePlaylists := map[string]bool{}
+
+	triggers := make([]*models.TracksRoutedTrigger, 0)
+	for _, syncEvent := range syncEvents {
+		for _, result := range syncEvent.ChildSyncResults {
+			if len(triggers) == models.MAX_AUTOMATION_TRIGGER_ITEMS {
+				return triggers, nil
+			}
+			if result.TracksAdded == 0 {
+				continue
+			}
+
+			if !loadedBasePlaylists[syncEvent.BasePlaylistID] {
+				if err := aService.loadChildPlaylistNames(ctx, userID, syncEvent.BasePlaylistID, childPlaylistNames); err != nil {
+					return nil, err
+				}
+				loadedBasePlaylists[syncEvent.BasePlaylistID] = true
+			}
+
+			triggers = append(triggers, &models.TracksRoutedTrigger{
+				ID:                syncEvent.ID + ":" + result.ChildPlaylistID,
+				SyncEventID:       syncEvent.ID,
+				BasePlaylistID:    syncEvent.BasePlaylistID,
+				ChildPlaylistID:   result.ChildPlaylistID,
+				ChildPlaylistName: childPlaylistNames[result.ChildPlaylistID],
+				TracksAdded:       result.TracksAdded,
+				TracksRemoved:     result.TracksRemoved,
+				RoutedAt:          completedAt(syncEvent),
+			})
+		}
+	}
+
+	return triggers, nil
+}
+
+func (aService *AutomationService) getBasePlaylistNames(ctx context.Context, userID string) (map[string]string, error) {
+	basePlaylists, err := aService.basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		aService.logger.ErrorContext(ctx, "failed to get base playlists", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get base playlists: %w", err)
+	}
+
+	names := make(map[string]string, len(basePlaylists))
+	for _, basePlaylist := range basePlaylists {
+		names[basePlaylist.ID] = basePlaylist.Name
+	}
+
+	return names, nil
+}
+
+func (aService *AutomationService) loadChildPlaylistNames(ctx context.Context, userID, basePlaylistID string, names map[string]string) error {
+	childPlaylists, err := aService.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylistID, userID)
+	if err != nil {
+		aService.logger.ErrorContext(ctx, "failed to get child playlists", "base_playlist_id", basePlaylistID, "error", err.Error())
+		return fmt.Errorf("failed to get child playlists: %w", err)
+	}
+
+	for _, childPlaylist := range childPlaylists {
+		names[childPlaylist.ID] = childPlaylist.Name
+	}
+
+	return nil
+}
+
+func completedAt(syncEvent *models.SyncEvent) time.Time {
+	if syncEvent.CompletedAt != nil {
+		return *syncEvent.CompletedAt
+	}
+
+	return syncEvent.StartedAt
+}
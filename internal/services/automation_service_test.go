@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAutomationService(ctrl *gomock.Controller) (*AutomationService, *mocks.MockSyncEventRepository, *mocks.MockBasePlaylistRepository, *mocks.MockChildPlaylistRepository) {
+	mockSyncEventRepo := mocks.NewMockSyncEventRepository(ctrl)
+	mockBaseRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	mockChildRepo := mocks.NewMockChildPlaylistRepository(ctrl)
+
+	service := NewAutomationService(
+		NewSyncEventService(mockSyncEventRepo, createTestLogger()),
+		mockBaseRepo,
+		mockChildRepo,
+		createTestLogger(),
+	)
+
+	return service, mockSyncEventRepo, mockBaseRepo, mockChildRepo
+}
+
+func TestAutomationService_GetSyncCompletedTriggers(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockSyncEventRepo, mockBaseRepo, _ := newTestAutomationService(ctrl)
+	ctx := context.Background()
+
+	completedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockSyncEventRepo.EXPECT().GetByUserID(ctx, "user123").Return([]*models.SyncEvent{
+		testfixtures.NewSyncEvent().WithID("sync3").WithBasePlaylistID("base1").WithStatus(models.SyncStatusInProgress).Build(),
+		testfixtures.NewSyncEvent().
+			WithID("sync2").
+			WithBasePlaylistID("base1").
+			WithStatus(models.SyncStatusCompleted).
+			WithStats(10, 2, 0).
+			WithCompletedAt(completedAt).
+			Build(),
+		testfixtures.NewSyncEvent().WithID("sync1").WithBasePlaylistID("base2").WithStatus(models.SyncStatusFailed).Build(),
+	}, nil)
+	mockBaseRepo.EXPECT().GetByUserID(ctx, "user123").Return([]*models.BasePlaylist{
+		testfixtures.NewBasePlaylist().WithID("base1").WithName("Liked Songs").Build(),
+		testfixtures.NewBasePlaylist().WithID("base2").WithName("Discover").Build(),
+	}, nil)
+
+	result, err := service.GetSyncCompletedTriggers(ctx, "user123")
+
+	require.NoError(err)
+	require.Equal([]*models.SyncCompletedTrigger{
+		{
+			ID:               "sync2",
+			SyncEventID:      "sync2",
+			BasePlaylistID:   "base1",
+			BasePlaylistName: "Liked Songs",
+			TracksProcessed:  10,
+			TracksUnmatched:  2,
+			CompletedAt:      completedAt,
+		},
+	}, result)
+}
+
+func TestAutomationService_GetSyncCompletedTriggers_Error(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockSyncEventRepo, _, _ := newTestAutomationService(ctrl)
+	ctx := context.Background()
+
+	mockSyncEventRepo.EXPECT().GetByUserID(ctx, "user123").Return(nil, repositories.ErrDatabaseOperation)
+
+	result, err := service.GetSyncCompletedTriggers(ctx, "user123")
+
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+	require.Nil(result)
+}
+
+func TestAutomationService_GetTracksRoutedTriggers(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockSyncEventRepo, _, mockChildRepo := newTestAutomationService(ctrl)
+	ctx := context.Background()
+
+	completedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockSyncEventRepo.EXPECT().GetByUserID(ctx, "user123").Return([]*models.SyncEvent{
+		testfixtures.NewSyncEvent().
+			WithID("sync2").
+			WithBasePlaylistID("base1").
+			WithStatus(models.SyncStatusCompleted).
+			WithCompletedAt(completedAt).
+			WithChildSyncResults(models.ChildSyncResult{ChildPlaylistID: "child1", TracksAdded: 3, TracksRemoved: 1}, models.ChildSyncResult{ChildPlaylistID: "child2", TracksAdded: 0, TracksRemoved: 4}).
+			Build(),
+		testfixtures.NewSyncEvent().
+			WithID("sync1").
+			WithBasePlaylistID("base1").
+			WithStatus(models.SyncStatusCompleted).
+			WithCompletedAt(completedAt).
+			WithChildSyncResults(models.ChildSyncResult{ChildPlaylistID: "child2", TracksAdded: 5}).
+			Build(),
+	}, nil)
+	// Names are looked up once for the base playlist
+	mockChildRepo.EXPECT().GetByBasePlaylistID(ctx, "base1", "user123").Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child1").WithName("Rock").Build(),
+		testfixtures.NewChildPlaylist().WithID("child2").WithName("Chill").Build(),
+	}, nil).Times(1)
+
+	result, err := service.GetTracksRoutedTriggers(ctx, "user123")
+
+	require.NoError(err)
+	require.Len(result, 2)
+	require.Equal(&models.TracksRoutedTrigger{
+		ID:                "sync2:child1",
+		SyncEventID:       "sync2",
+		BasePlaylistID:    "base1",
+		ChildPlaylistID:   "child1",
+		ChildPlaylistName: "Rock",
+		TracksAdded:       3,
+		TracksRemoved:     1,
+		RoutedAt:          completedAt,
+	}, result[0])
+	require.Equal("sync1:child2", result[1].ID)
+	require.Equal("Chill", result[1].ChildPlaylistName)
+}
+
+func TestAutomationService_GetTracksRoutedTriggers_Limit(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockSyncEventRepo, _, mockChildRepo := newTestAutomationService(ctrl)
+	ctx := context.Background()
+
+	results := make([]models.ChildSyncResult, models.MAX_AUTOMATION_TRIGGER_ITEMS+5)
+	for i := range results {
+		results[i] = models.ChildSyncResult{ChildPlaylistID: fmt.Sprintf("child%d", i), TracksAdded: 1}
+	}
+	mockSyncEventRepo.EXPECT().GetByUserID(ctx, "user123").Return([]*models.SyncEvent{
+		testfixtures.NewSyncEvent().
+			WithID("sync1").
+			WithBasePlaylistID("base1").
+			WithStatus(models.SyncStatusCompleted).
+			WithChildSyncResults(results...).
+			Build(),
+	}, nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(ctx, "base1", "user123").Return([]*models.ChildPlaylist{}, nil)
+
+	result, err := service.GetTracksRoutedTriggers(ctx, "user123")
+
+	require.NoError(err)
+	require.Len(result, models.MAX_AUTOMATION_TRIGGER_ITEMS)
+}
+
+func TestAutomationService_GetTracksRoutedTriggers_ChildPlaylistsError(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockSyncEventRepo, _, mockChildRepo := newTestAutomationService(ctrl)
+	ctx := context.Background()
+
+	mockSyncEventRepo.EXPECT().GetByUserID(ctx, "user123").Return([]*models.SyncEvent{
+		testfixtures.NewSyncEvent().
+			WithID("sync1").
+			WithBasePlaylistID("base1").
+			WithStatus(models.SyncStatusCompleted).
+			WithChildSyncResults(models.ChildSyncResult{ChildPlaylistID: "child1", TracksAdded: 1}).
+			Build(),
+	}, nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(ctx, "base1", "user123").Return(nil, repositories.ErrDatabaseOperation)
+
+	result, err := service.GetTracksRoutedTriggers(ctx, "user123")
+
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+	require.Nil(result)
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=base_playlist_clone_service.go -destination=mocks/mock_base_playlist_clone_service.go -package=mocks
+
+// BasePlaylistCloneServicer copies the configuration of a base playlist and its child playlists
+// onto another spotify playlist
+type BasePlaylistCloneServicer interface {
+	CloneBasePlaylist(ctx context.Context, userID, id string, input *models.CloneBasePlaylistRequest) (*models.BasePlaylistWithChilds, error)
+}
+
+type BasePlaylistCloneService struct {
+	basePlaylistService  BasePlaylistServicer
+	childPlaylistService ChildPlaylistServicer
+	logger               *slog.Logger
+}
+
+func NewBasePlaylistCloneService(
+	basePlaylistService BasePlaylistServicer,
+	childPlaylistService ChildPlaylistServicer,
+	logger *slog.Logger,
+) *BasePlaylistCloneService {
+	return &BasePlaylistCloneService{
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		logger:               logger.With("component", "BasePlaylistCloneService"),
+	}
+}
+
+// CloneBasePlaylist creates a base playlist reading input.SpotifyPlaylistID with the settings of the
+// base playlist cloned, and a copy of each of its child playlists with a new spotify playlist. Child
+// playlists are created in priority order so the clone routes the same way. When one fails the
+// clone is deleted again, along with the child playlists already created
+func (cloneService *BasePlaylistCloneService) CloneBasePlaylist(ctx context.Context, userID, id string, input *models.CloneBasePlaylistRequest) (*models.BasePlaylistWithChilds, error) {
+	basePlaylist, err := cloneService.basePlaylistService.GetBasePlaylist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.SpotifyPlaylistID == basePlaylist.SpotifyPlaylistID {
+		return nil, fmt.Errorf("%w: %s", ErrCloneSameSpotifyPlaylist, input.SpotifyPlaylistID)
+	}
+
+	childPlaylists, err := cloneService.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	childPlaylists = slices.Clone(childPlaylists)
+	sortByPriority(childPlaylists)
+
+	name := input.Name
+	if name == "" {
+		name = basePlaylist.Name
+	}
+
+	cloneService.logger.InfoContext(ctx, "cloning base playlist",
+		"base_playlist_id", id,
+		"user_id", userID,
+		"spotify_playlist_id", input.SpotifyPlaylistID,
+		"children", len(childPlaylists),
+	)
+
+	clone, err := cloneService.basePlaylistService.CreateBasePlaylist(ctx, userID, &models.CreateBasePlaylistRequest{
+		Name:                 name,
+		SpotifyPlaylistID:    input.SpotifyPlaylistID,
+		DedupeStrategy:       basePlaylist.DedupeStrategy,
+		SpotifyIntegrationID: basePlaylist.SpotifyIntegrationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clonedChildPlaylists := make([]*models.ChildPlaylist, 0, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		clonedChildPlaylist, err := cloneService.childPlaylistService.CreateChildPlaylist(ctx, userID, clone.ID, childPlaylist.ToCreateRequest())
+		if err != nil {
+			cloneService.logger.ErrorContext(ctx, "failed to clone child playlist",
+				"base_playlist_id", id,
+				"child_playlist_id", childPlaylist.ID,
+				"error", err.Error(),
+			)
+			cloneService.removeClone(ctx, userID, clone, clonedChildPlaylists)
+			return nil, fmt.Errorf("failed to clone child playlist %q: %w", childPlaylist.Name, err)
+		}
+
+		clonedChildPlaylists = append(clonedChildPlaylists, clonedChildPlaylist)
+	}
+
+	cloneService.logger.InfoContext(ctx, "base playlist cloned", "base_playlist_id", id, "clone_id", clone.ID)
+	return &models.BasePlaylistWithChilds{
+		BasePlaylist: clone,
+		Childs:       clonedChildPlaylists,
+	}, nil
+}
+
+// removeClone undoes a partial clone, what can't be removed is only logged
+func (cloneService *BasePlaylistCloneService) removeClone(ctx context.Context, userID string, clone *models.BasePlaylist, childPlaylists []*models.ChildPlaylist) {
+	for _, childPlaylist := range childPlaylists {
+		if err := cloneService.childPlaylistService.DeleteChildPlaylist(ctx, childPlaylist.ID, userID); err != nil {
+			cloneService.logger.WarnContext(ctx, "failed to remove child playlist of failed clone",
+				"child_playlist_id", childPlaylist.ID,
+				"error", err.Error(),
+			)
+		}
+	}
+
+	if err := cloneService.basePlaylistService.DeleteBasePlaylist(ctx, clone.ID, userID); err != nil {
+		cloneService.logger.WarnContext(ctx, "failed to remove base playlist of failed clone",
+			"base_playlist_id", clone.ID,
+			"error", err.Error(),
+		)
+	}
+}
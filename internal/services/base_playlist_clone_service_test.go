@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBasePlaylistService serves basePlaylist and records the base playlists created, updated and
+// deleted by the clone and routing config services
+type fakeBasePlaylistService struct {
+	BasePlaylistServicer
+
+	basePlaylist *models.BasePlaylist
+	created      []*models.CreateBasePlaylistRequest
+	updated      []*models.UpdateBasePlaylistRequest
+	deleted      []string
+}
+
+func (f *fakeBasePlaylistService) GetBasePlaylistsByUserID(_ context.Context, _ string) ([]*models.BasePlaylist, error) {
+	if f.basePlaylist == nil {
+		return nil, nil
+	}
+	return []*models.BasePlaylist{f.basePlaylist}, nil
+}
+
+func (f *fakeBasePlaylistService) UpdateBasePlaylist(_ context.Context, _, _ string, input *models.UpdateBasePlaylistRequest) (*models.BasePlaylist, error) {
+	f.updated = append(f.updated, input)
+
+	updated := *f.basePlaylist
+	updated.Name = *input.Name
+	updated.DedupeStrategy = *input.DedupeStrategy
+	return &updated, nil
+}
+
+func (f *fakeBasePlaylistService) GetBasePlaylist(_ context.Context, id, _ string) (*models.BasePlaylist, error) {
+	if f.basePlaylist == nil || f.basePlaylist.ID != id {
+		return nil, repositories.ErrBasePlaylistNotFound
+	}
+	return f.basePlaylist, nil
+}
+
+func (f *fakeBasePlaylistService) CreateBasePlaylist(_ context.Context, userID string, input *models.CreateBasePlaylistRequest) (*models.BasePlaylist, error) {
+	f.created = append(f.created, input)
+	return testfixtures.NewBasePlaylist().
+		WithID("clone123").
+		WithUserID(userID).
+		WithName(input.Name).
+		WithSpotifyPlaylistID(input.SpotifyPlaylistID).
+		WithDedupeStrategy(input.DedupeStrategy).
+		Build(), nil
+}
+
+func (f *fakeBasePlaylistService) DeleteBasePlaylist(_ context.Context, id, _ string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func setupBasePlaylistCloneService() (*BasePlaylistCloneService, *fakeBasePlaylistService, *fakeChildPlaylistService) {
+	basePlaylistService := &fakeBasePlaylistService{
+		basePlaylist: testfixtures.NewBasePlaylist().
+			WithID("base123").
+			WithName("Liked Songs").
+			WithSpotifyPlaylistID("spotify123").
+			WithDedupeStrategy(models.DedupeStrategyFirstMatch).
+			Build(),
+	}
+	childPlaylistService := &fakeChildPlaylistService{
+		children: []*models.ChildPlaylist{
+			testfixtures.NewChildPlaylist().WithID("child2").WithName("Chill").WithPriority(2).Build(),
+			testfixtures.NewChildPlaylist().WithID("child1").WithName("Workout").WithPriority(1).WithMaxTracks(50, models.SelectionRandom).Build(),
+		},
+	}
+
+	return NewBasePlaylistCloneService(basePlaylistService, childPlaylistService, createTestLogger()), basePlaylistService, childPlaylistService
+}
+
+func TestBasePlaylistCloneService_CloneBasePlaylist(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, childPlaylistService := setupBasePlaylistCloneService()
+
+	clone, err := service.CloneBasePlaylist(context.Background(), "user123", "base123", &models.CloneBasePlaylistRequest{
+		SpotifyPlaylistID: "spotify456",
+	})
+
+	assert.NoError(err)
+	assert.Equal("clone123", clone.BasePlaylist.ID)
+	assert.Len(clone.Childs, 2)
+
+	assert.Len(basePlaylistService.created, 1)
+	assert.Equal("Liked Songs", basePlaylistService.created[0].Name)
+	assert.Equal("spotify456", basePlaylistService.created[0].SpotifyPlaylistID)
+	assert.Equal(models.DedupeStrategyFirstMatch, basePlaylistService.created[0].DedupeStrategy)
+
+	// Children are recreated in priority order, keeping their settings
+	assert.Equal("Workout", childPlaylistService.created[0].Name)
+	assert.Equal(50, childPlaylistService.created[0].MaxTracks)
+	assert.Equal("Chill", childPlaylistService.created[1].Name)
+	assert.Empty(childPlaylistService.deleted)
+	assert.Empty(basePlaylistService.deleted)
+}
+
+func TestBasePlaylistCloneService_CloneBasePlaylist_WithName(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, _ := setupBasePlaylistCloneService()
+
+	clone, err := service.CloneBasePlaylist(context.Background(), "user123", "base123", &models.CloneBasePlaylistRequest{
+		SpotifyPlaylistID: "spotify456",
+		Name:              "Liked Songs (copy)",
+	})
+
+	assert.NoError(err)
+	assert.Equal("Liked Songs (copy)", clone.BasePlaylist.Name)
+	assert.Equal("Liked Songs (copy)", basePlaylistService.created[0].Name)
+}
+
+func TestBasePlaylistCloneService_CloneBasePlaylist_SameSpotifyPlaylist(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, _ := setupBasePlaylistCloneService()
+
+	clone, err := service.CloneBasePlaylist(context.Background(), "user123", "base123", &models.CloneBasePlaylistRequest{
+		SpotifyPlaylistID: "spotify123",
+	})
+
+	assert.Nil(clone)
+	assert.ErrorIs(err, ErrCloneSameSpotifyPlaylist)
+	assert.Empty(basePlaylistService.created)
+}
+
+func TestBasePlaylistCloneService_CloneBasePlaylist_NotFound(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, _ := setupBasePlaylistCloneService()
+
+	_, err := service.CloneBasePlaylist(context.Background(), "user123", "missing", &models.CloneBasePlaylistRequest{
+		SpotifyPlaylistID: "spotify456",
+	})
+
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+	assert.Empty(basePlaylistService.created)
+}
+
+func TestBasePlaylistCloneService_CloneBasePlaylist_RemovesCloneOnFailure(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, childPlaylistService := setupBasePlaylistCloneService()
+	childPlaylistService.failOn = "Chill"
+	childPlaylistService.err = errors.New("spotify error")
+
+	clone, err := service.CloneBasePlaylist(context.Background(), "user123", "base123", &models.CloneBasePlaylistRequest{
+		SpotifyPlaylistID: "spotify456",
+	})
+
+	assert.Nil(clone)
+	assert.ErrorContains(err, `failed to clone child playlist "Chill"`)
+	assert.Equal([]string{"child1"}, childPlaylistService.deleted)
+	assert.Equal([]string{"clone123"}, basePlaylistService.deleted)
+}
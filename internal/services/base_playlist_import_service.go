@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// MAX_CONCURRENT_TRACK_SEARCHES bounds the rows of an import searched on spotify at the same time
+const MAX_CONCURRENT_TRACK_SEARCHES = 4
+
+//go:generate mockgen -source=base_playlist_import_service.go -destination=mocks/mock_base_playlist_import_service.go -package=mocks
+
+// BasePlaylistImportServicer creates base playlists from files of tracks
+type BasePlaylistImportServicer interface {
+	ImportTracks(ctx context.Context, userID string, input *models.ImportTracksRequest, tracks []models.ImportedTrack) (*models.ImportTracksResult, error)
+}
+
+type BasePlaylistImportService struct {
+	basePlaylistService BasePlaylistServicer
+	musicProvider       musicprovider.MusicProvider
+	spotifyAuth         SpotifyAuthProvider
+	logger              *slog.Logger
+}
+
+func NewBasePlaylistImportService(
+	basePlaylistService BasePlaylistServicer,
+	musicProvider musicprovider.MusicProvider,
+	spotifyAuth SpotifyAuthProvider,
+	logger *slog.Logger,
+) *BasePlaylistImportService {
+	return &BasePlaylistImportService{
+		basePlaylistService: basePlaylistService,
+		musicProvider:       musicProvider,
+		spotifyAuth:         spotifyAuth,
+		logger:              logger.With("component", "BasePlaylistImportService"),
+	}
+}
+
+// ImportTracks matches the rows of an imported file to spotify tracks, then creates a base playlist
+// reading a new spotify playlist holding them in file order. Tracks matched by several rows are
+// added once. When the tracks can't be added the base playlist and its spotify playlist are removed
+// again
+func (importService *BasePlaylistImportService) ImportTracks(ctx context.Context, userID string, input *models.ImportTracksRequest, tracks []models.ImportedTrack) (*models.ImportTracksResult, error) {
+	importService.logger.InfoContext(ctx, "importing tracks into a new base playlist", "user_id", userID, "rows", len(tracks))
+
+	accountCtx, err := contextWithPlaylistAccount(ctx, importService.spotifyAuth, userID, models.MusicProviderSpotify, input.SpotifyIntegrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spotify account: %w", err)
+	}
+
+	matched, err := importService.matchTracks(accountCtx, tracks)
+	if err != nil {
+		importService.logger.ErrorContext(ctx, "failed to match imported tracks", "user_id", userID, "error", err.Error())
+		return nil, err
+	}
+
+	trackURIs := make([]string, 0, len(tracks))
+	unmatched := make([]models.ImportedTrack, 0)
+	for i, trackURI := range matched {
+		if trackURI == "" {
+			unmatched = append(unmatched, tracks[i])
+			continue
+		}
+		if !slices.Contains(trackURIs, trackURI) {
+			trackURIs = append(trackURIs, trackURI)
+		}
+	}
+	if len(trackURIs) == 0 {
+		return nil, ErrNoTracksMatched
+	}
+
+	basePlaylist, err := importService.basePlaylistService.CreateBasePlaylist(ctx, userID, &models.CreateBasePlaylistRequest{
+		Name:                 input.Name,
+		DedupeStrategy:       input.DedupeStrategy,
+		SpotifyIntegrationID: input.SpotifyIntegrationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for batch := range slices.Chunk(trackURIs, spotifyclient.MAX_PLAYLIST_ITEMS) {
+		if err := importService.musicProvider.AddTracksToPlaylist(accountCtx, basePlaylist.SpotifyPlaylistID, batch); err != nil {
+			importService.logger.ErrorContext(ctx, "failed to add imported tracks", "base_playlist_id", basePlaylist.ID, "error", err.Error())
+			importService.removeImport(ctx, accountCtx, userID, basePlaylist)
+			return nil, fmt.Errorf("failed to add imported tracks: %w", err)
+		}
+	}
+
+	importService.logger.InfoContext(ctx, "imported tracks into a new base playlist",
+		"base_playlist_id", basePlaylist.ID,
+		"user_id", userID,
+		"matched", len(trackURIs),
+		"unmatched", len(unmatched),
+	)
+	return &models.ImportTracksResult{
+		BasePlaylist:    basePlaylist,
+		MatchedTracks:   len(trackURIs),
+		UnmatchedTracks: unmatched,
+	}, nil
+}
+
+// matchTracks returns the URI of the spotify track each row matched, empty for the rows no track
+// was found for. Spotify track URIs are looked up MAX_TRACKS at a time, the other rows and the ones
+// with an unknown URI are searched by their ISRC, then by their title and artist
+func (importService *BasePlaylistImportService) matchTracks(ctx context.Context, tracks []models.ImportedTrack) ([]string, error) {
+	matched := make([]string, len(tracks))
+
+	var uriRows []int
+	for i, track := range tracks {
+		if _, ok := spotifyTrackID(track.URI); ok {
+			uriRows = append(uriRows, i)
+		}
+	}
+	for batch := range slices.Chunk(uriRows, MAX_TRACKS) {
+		trackIDs := make([]string, 0, len(batch))
+		for _, row := range batch {
+			trackID, _ := spotifyTrackID(tracks[row].URI)
+			trackIDs = append(trackIDs, trackID)
+		}
+
+		found, err := importService.musicProvider.GetSeveralTracks(ctx, trackIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up imported tracks: %w", err)
+		}
+		for i, track := range found {
+			// Unknown tracks come back as nil
+			if i < len(batch) && track != nil {
+				matched[batch[i]] = track.URI
+			}
+		}
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(MAX_CONCURRENT_TRACK_SEARCHES)
+	for i, track := range tracks {
+		if matched[i] != "" {
+			continue
+		}
+
+		group.Go(func() error {
+			trackURI, err := importService.searchTrack(groupCtx, track)
+			if err != nil {
+				return err
+			}
+
+			matched[i] = trackURI
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to search imported tracks: %w", err)
+	}
+
+	return matched, nil
+}
+
+// searchTrack returns the URI of the best match of the row, empty when there is none
+func (importService *BasePlaylistImportService) searchTrack(ctx context.Context, track models.ImportedTrack) (string, error) {
+	var queries []string
+	if track.ISRC != "" {
+		queries = append(queries, "isrc:"+track.ISRC)
+	}
+	if track.Title != "" && track.Artist != "" {
+		queries = append(queries, fmt.Sprintf(`track:"%s" artist:"%s"`, searchTerm(track.Title), searchTerm(track.Artist)))
+	}
+
+	for _, query := range queries {
+		results, err := importService.musicProvider.SearchTracks(ctx, query, 1)
+		if err != nil {
+			return "", err
+		}
+		if len(results) > 0 && results[0] != nil {
+			return results[0].URI, nil
+		}
+	}
+
+	return "", nil
+}
+
+// removeImport undoes an import whose tracks couldn't be added, what can't be removed is only logged
+func (importService *BasePlaylistImportService) removeImport(ctx, accountCtx context.Context, userID string, basePlaylist *models.BasePlaylist) {
+	if err := importService.basePlaylistService.DeleteBasePlaylist(ctx, basePlaylist.ID, userID); err != nil {
+		importService.logger.WarnContext(ctx, "failed to remove base playlist of failed import",
+			"base_playlist_id", basePlaylist.ID,
+			"error", err.Error(),
+		)
+	}
+
+	if err := importService.musicProvider.DeletePlaylist(accountCtx, basePlaylist.SpotifyPlaylistID); err != nil {
+		importService.logger.WarnContext(ctx, "failed to remove spotify playlist of failed import",
+			"spotify_playlist_id", basePlaylist.SpotifyPlaylistID,
+			"error", err.Error(),
+		)
+	}
+}
+
+// spotifyTrackID reads the ID of spotify track URIs and open.spotify.com track links
+func spotifyTrackID(uri string) (string, bool) {
+	trackID, ok := strings.CutPrefix(uri, "spotify:track:")
+	if !ok {
+		link, isLink := strings.CutPrefix(uri, "https://open.spotify.com/track/")
+		if !isLink {
+			return "", false
+		}
+		trackID, _, _ = strings.Cut(link, "?")
+	}
+
+	// Spotify rejects the whole lookup when one of the IDs is malformed
+	if trackID == "" || strings.IndexFunc(trackID, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) >= 0 {
+		return "", false
+	}
+
+	return trackID, true
+}
+
+// searchTerm drops the quotes that would end a quoted search filter early
+func searchTerm(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+type basePlaylistImportServiceMocks struct {
+	basePlaylistRepo *repositoryMocks.MockBasePlaylistRepository
+	spotifyClient    *spotifyClientMocks.MockSpotifyAPI
+}
+
+func setupBasePlaylistImportService(t *testing.T) (*BasePlaylistImportService, basePlaylistImportServiceMocks) {
+	ctrl := setupMockController(t)
+	mocks := basePlaylistImportServiceMocks{
+		basePlaylistRepo: repositoryMocks.NewMockBasePlaylistRepository(ctrl),
+		spotifyClient:    spotifyClientMocks.NewMockSpotifyAPI(ctrl),
+	}
+
+	basePlaylistService := NewBasePlaylistService(
+		mocks.basePlaylistRepo,
+		repositoryMocks.NewMockChildPlaylistRepository(ctrl),
+		repositoryMocks.NewMockSpotifyIntegrationRepository(ctrl),
+		mocks.spotifyClient,
+		createTestLogger(),
+	)
+
+	service := NewBasePlaylistImportService(basePlaylistService, mocks.spotifyClient, &fakeSpotifyAuthProvider{}, createTestLogger())
+	return service, mocks
+}
+
+// expectCreatedBasePlaylist expects the base playlist of the import to be created reading a new
+// spotify playlist
+func expectCreatedBasePlaylist(mocks basePlaylistImportServiceMocks) {
+	mocks.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), "Imported", "", false).Return(&spotifyclient.SpotifyPlaylist{ID: "spotify_new", Name: "Imported"}, nil)
+	mocks.basePlaylistRepo.EXPECT().Create(gomock.Any(), "user123", "Imported", "spotify_new", models.DedupeStrategy(""), "", models.MusicProvider("")).Return(
+		testfixtures.NewBasePlaylist().WithID("base123").WithUserID("user123").WithName("Imported").WithSpotifyPlaylistID("spotify_new").Build(), nil)
+}
+
+func TestBasePlaylistImportService_ImportTracks(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupBasePlaylistImportService(t)
+	ctx := context.Background()
+
+	tracks := []models.ImportedTrack{
+		{URI: "spotify:track:track1"},
+		{URI: "https://open.spotify.com/track/track2?si=abc"},
+		{URI: "spotify:track:unknown", ISRC: "USUM71703861"},
+		{Title: "Mr. \"Brightside\"", Artist: "The Killers"},
+		{ISRC: "XX0000000000"},
+		{URI: "spotify:track:track1"},
+	}
+
+	mocks.spotifyClient.EXPECT().GetSeveralTracks(gomock.Any(), []string{"track1", "track2", "unknown", "track1"}).Return([]*spotifyclient.SpotifyTrack{
+		{URI: "spotify:track:track1"}, {URI: "spotify:track:track2"}, nil, {URI: "spotify:track:track1"},
+	}, nil)
+	mocks.spotifyClient.EXPECT().SearchTracks(gomock.Any(), "isrc:USUM71703861", 1).Return([]*spotifyclient.SpotifyTrack{{URI: "spotify:track:track3"}}, nil)
+	mocks.spotifyClient.EXPECT().SearchTracks(gomock.Any(), `track:"Mr. Brightside" artist:"The Killers"`, 1).Return([]*spotifyclient.SpotifyTrack{{URI: "spotify:track:track4"}}, nil)
+	mocks.spotifyClient.EXPECT().SearchTracks(gomock.Any(), "isrc:XX0000000000", 1).Return([]*spotifyclient.SpotifyTrack{}, nil)
+	expectCreatedBasePlaylist(mocks)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "spotify_new", []string{
+		"spotify:track:track1", "spotify:track:track2", "spotify:track:track3", "spotify:track:track4",
+	}).Return(nil)
+
+	result, err := service.ImportTracks(ctx, "user123", &models.ImportTracksRequest{Name: "Imported"}, tracks)
+	assert.NoError(err)
+	assert.Equal("base123", result.BasePlaylist.ID)
+	assert.Equal(4, result.MatchedTracks)
+	assert.Equal([]models.ImportedTrack{{ISRC: "XX0000000000"}}, result.UnmatchedTracks)
+}
+
+func TestBasePlaylistImportService_ImportTracks_AddsInBatches(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupBasePlaylistImportService(t)
+
+	tracks := make([]models.ImportedTrack, 0, 150)
+	found := make([]*spotifyclient.SpotifyTrack, 0, 150)
+	for i := range 150 {
+		tracks = append(tracks, models.ImportedTrack{URI: fmt.Sprintf("spotify:track:track%d", i)})
+		found = append(found, &spotifyclient.SpotifyTrack{URI: fmt.Sprintf("spotify:track:track%d", i)})
+	}
+
+	mocks.spotifyClient.EXPECT().GetSeveralTracks(gomock.Any(), gomock.Len(MAX_TRACKS)).Return(found[:50], nil)
+	mocks.spotifyClient.EXPECT().GetSeveralTracks(gomock.Any(), gomock.Len(MAX_TRACKS)).Return(found[50:100], nil)
+	mocks.spotifyClient.EXPECT().GetSeveralTracks(gomock.Any(), gomock.Len(MAX_TRACKS)).Return(found[100:], nil)
+	expectCreatedBasePlaylist(mocks)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "spotify_new", gomock.Len(spotifyclient.MAX_PLAYLIST_ITEMS)).Return(nil)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "spotify_new", gomock.Len(50)).Return(nil)
+
+	result, err := service.ImportTracks(context.Background(), "user123", &models.ImportTracksRequest{Name: "Imported"}, tracks)
+	assert.NoError(err)
+	assert.Equal(150, result.MatchedTracks)
+	assert.Empty(result.UnmatchedTracks)
+}
+
+func TestBasePlaylistImportService_ImportTracks_NoMatches(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupBasePlaylistImportService(t)
+
+	mocks.spotifyClient.EXPECT().SearchTracks(gomock.Any(), `track:"Unknown" artist:"Nobody"`, 1).Return(nil, nil)
+
+	result, err := service.ImportTracks(context.Background(), "user123", &models.ImportTracksRequest{Name: "Imported"}, []models.ImportedTrack{
+		{URI: "local:track:1", Title: "Unknown", Artist: "Nobody"},
+	})
+	assert.ErrorIs(err, ErrNoTracksMatched)
+	assert.Nil(result)
+}
+
+func TestBasePlaylistImportService_ImportTracks_SearchError(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupBasePlaylistImportService(t)
+
+	mocks.spotifyClient.EXPECT().SearchTracks(gomock.Any(), "isrc:USUM71703861", 1).Return(nil, spotifyclient.ErrRateLimited)
+
+	result, err := service.ImportTracks(context.Background(), "user123", &models.ImportTracksRequest{Name: "Imported"}, []models.ImportedTrack{
+		{ISRC: "USUM71703861"},
+	})
+	assert.ErrorIs(err, spotifyclient.ErrRateLimited)
+	assert.Nil(result)
+}
+
+func TestBasePlaylistImportService_ImportTracks_AddFails(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupBasePlaylistImportService(t)
+
+	mocks.spotifyClient.EXPECT().GetSeveralTracks(gomock.Any(), []string{"track1"}).Return([]*spotifyclient.SpotifyTrack{{URI: "spotify:track:track1"}}, nil)
+	expectCreatedBasePlaylist(mocks)
+	mocks.spotifyClient.EXPECT().AddTracksToPlaylist(gomock.Any(), "spotify_new", []string{"spotify:track:track1"}).Return(errors.New("spotify down"))
+	mocks.basePlaylistRepo.EXPECT().Delete(gomock.Any(), "base123", "user123").Return(nil)
+	mocks.spotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "spotify_new").Return(nil)
+
+	result, err := service.ImportTracks(context.Background(), "user123", &models.ImportTracksRequest{Name: "Imported"}, []models.ImportedTrack{
+		{URI: "spotify:track:track1"},
+	})
+	assert.ErrorContains(err, "failed to add imported tracks")
+	assert.Nil(result)
+}
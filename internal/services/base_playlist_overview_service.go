@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=base_playlist_overview_service.go -destination=mocks/mock_base_playlist_overview_service.go -package=mocks
+
+type BasePlaylistOverviewServicer interface {
+	GetBasePlaylistOverview(ctx context.Context, userID, id string) (*models.BasePlaylistOverview, error)
+}
+
+type BasePlaylistOverviewService struct {
+	basePlaylistService  BasePlaylistServicer
+	childPlaylistService ChildPlaylistServicer
+	syncEventService     SyncEventServicer
+	musicProvider        musicprovider.MusicProvider
+	spotifyAuth          SpotifyAuthProvider
+	logger               *slog.Logger
+}
+
+func NewBasePlaylistOverviewService(
+	basePlaylistService BasePlaylistServicer,
+	childPlaylistService ChildPlaylistServicer,
+	syncEventService SyncEventServicer,
+	musicProvider musicprovider.MusicProvider,
+	spotifyAuth SpotifyAuthProvider,
+	logger *slog.Logger,
+) *BasePlaylistOverviewService {
+	return &BasePlaylistOverviewService{
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		syncEventService:     syncEventService,
+		musicProvider:        musicProvider,
+		spotifyAuth:          spotifyAuth,
+		logger:               logger.With("component", "BasePlaylistOverviewService"),
+	}
+}
+
+// GetBasePlaylistOverview reads the counts of the last completed sync, so it stays cheap enough for
+// a dashboard. Only the track count is read from Spotify, falling back to the last sync
+func (boService *BasePlaylistOverviewService) GetBasePlaylistOverview(ctx context.Context, userID, id string) (*models.BasePlaylistOverview, error) {
+	basePlaylist, err := boService.basePlaylistService.GetBasePlaylist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	childPlaylists, err := boService.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, basePlaylist.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &models.BasePlaylistOverview{
+		BasePlaylistID: basePlaylist.ID,
+		ChildPlaylists: countChildPlaylists(childPlaylists),
+	}
+
+	latestSyncs, err := boService.syncEventService.ListSyncEvents(ctx, userID, repositories.SyncEventFilter{BasePlaylistID: basePlaylist.ID, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(latestSyncs.Items) > 0 {
+		overview.LastSync = summarizeSync(latestSyncs.Items[0])
+	}
+
+	lastCompletedSync, err := boService.syncEventService.GetLastCompletedSyncEvent(ctx, userID, basePlaylist.ID)
+	if err != nil {
+		return nil, err
+	}
+	if lastCompletedSync != nil {
+		overview.TrackCount = lastCompletedSync.TracksProcessed
+		overview.UnmatchedTracks = lastCompletedSync.TracksUnmatched
+		overview.LastCompletedAt = lastCompletedSync.CompletedAt
+	}
+
+	boService.addSpotifyTrackCount(ctx, userID, basePlaylist, overview)
+
+	return overview, nil
+}
+
+// addSpotifyTrackCount is best-effort: when the playlist can't be read the count of the last sync is kept
+func (boService *BasePlaylistOverviewService) addSpotifyTrackCount(ctx context.Context, userID string, basePlaylist *models.BasePlaylist, overview *models.BasePlaylistOverview) {
+	accountCtx, err := contextWithPlaylistAccount(ctx, boService.spotifyAuth, userID, basePlaylist.Provider, basePlaylist.SpotifyIntegrationID)
+	if err != nil {
+		boService.logger.WarnContext(ctx, "failed to load spotify account for base playlist overview", "base_playlist_id", basePlaylist.ID, "error", err.Error())
+		return
+	}
+
+	playlist, err := boService.musicProvider.GetPlaylist(accountCtx, basePlaylist.SpotifyPlaylistID)
+	if err != nil {
+		boService.logger.WarnContext(ctx, "failed to get spotify playlist for base playlist overview", "base_playlist_id", basePlaylist.ID, "error", err.Error())
+		return
+	}
+
+	if playlist.Tracks != nil {
+		overview.TrackCount = playlist.Tracks.Total
+	}
+}
+
+func countChildPlaylists(childPlaylists []*models.ChildPlaylist) models.ChildPlaylistCounts {
+	counts := models.ChildPlaylistCounts{Total: len(childPlaylists)}
+	for _, childPlaylist := range childPlaylists {
+		if childPlaylist.IsActive {
+			counts.Active++
+		} else {
+			counts.Inactive++
+		}
+	}
+	return counts
+}
+
+func summarizeSync(syncEvent *models.SyncEvent) *models.SyncSummary {
+	return &models.SyncSummary{
+		ID:           syncEvent.ID,
+		Status:       syncEvent.Status,
+		StartedAt:    syncEvent.StartedAt,
+		CompletedAt:  syncEvent.CompletedAt,
+		ErrorMessage: syncEvent.ErrorMessage,
+	}
+}
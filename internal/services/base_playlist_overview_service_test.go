@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBasePlaylistOverviewService(t *testing.T) (*BasePlaylistOverviewService, *repositoryMocks.MockSyncEventRepository, *spotifyClientMocks.MockSpotifyAPI) {
+	ctrl := setupMockController(t)
+	syncEventRepo := repositoryMocks.NewMockSyncEventRepository(ctrl)
+	spotifyClient := spotifyClientMocks.NewMockSpotifyAPI(ctrl)
+
+	basePlaylistService := &fakeBasePlaylistService{basePlaylist: testfixtures.NewBasePlaylist().WithID("base123").WithSpotifyPlaylistID("spotify_base").Build()}
+	childPlaylistService := &fakeChildPlaylistService{children: []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child1").Build(),
+		testfixtures.NewChildPlaylist().WithID("child2").Build(),
+		testfixtures.NewChildPlaylist().WithID("child3").Suspended().Build(),
+	}}
+
+	service := NewBasePlaylistOverviewService(
+		basePlaylistService,
+		childPlaylistService,
+		NewSyncEventService(syncEventRepo, createTestLogger()),
+		spotifyClient,
+		&fakeSpotifyAuthProvider{},
+		createTestLogger(),
+	)
+	return service, syncEventRepo, spotifyClient
+}
+
+func TestBasePlaylistOverviewService_GetBasePlaylistOverview(t *testing.T) {
+	assert := require.New(t)
+	service, syncEventRepo, spotifyClient := setupBasePlaylistOverviewService(t)
+	ctx := context.Background()
+
+	completedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	errorMessage := "spotify down"
+	failedSync := testfixtures.NewSyncEvent().WithID("sync2").WithUserID("user123").WithStatus(models.SyncStatusFailed).Build()
+	failedSync.ErrorMessage = &errorMessage
+	completedSync := testfixtures.NewSyncEvent().WithID("sync1").WithUserID("user123").WithStatus(models.SyncStatusCompleted).WithCompletedAt(completedAt).WithStats(100, 8, 12).Build()
+
+	filter := repositories.SyncEventFilter{UserID: "user123", BasePlaylistID: "base123", Limit: 1}
+	syncEventRepo.EXPECT().List(ctx, filter).Return([]*models.SyncEvent{failedSync}, nil)
+	syncEventRepo.EXPECT().Count(ctx, filter).Return(2, nil)
+	syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "base123").Return([]*models.SyncEvent{failedSync, completedSync}, nil)
+	spotifyClient.EXPECT().GetPlaylist(ctx, "spotify_base").Return(&spotifyclient.SpotifyPlaylist{
+		Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 104},
+	}, nil)
+
+	overview, err := service.GetBasePlaylistOverview(ctx, "user123", "base123")
+
+	assert.NoError(err)
+	assert.Equal("base123", overview.BasePlaylistID)
+	assert.Equal(104, overview.TrackCount)
+	assert.Equal(models.ChildPlaylistCounts{Total: 3, Active: 2, Inactive: 1}, overview.ChildPlaylists)
+	assert.Equal(8, overview.UnmatchedTracks)
+	assert.Equal(&completedAt, overview.LastCompletedAt)
+	assert.Equal("sync2", overview.LastSync.ID)
+	assert.Equal(models.SyncStatusFailed, overview.LastSync.Status)
+	assert.Equal(&errorMessage, overview.LastSync.ErrorMessage)
+}
+
+func TestBasePlaylistOverviewService_GetBasePlaylistOverview_NeverSynced(t *testing.T) {
+	assert := require.New(t)
+	service, syncEventRepo, spotifyClient := setupBasePlaylistOverviewService(t)
+	ctx := context.Background()
+
+	syncEventRepo.EXPECT().List(ctx, gomock.Any()).Return(nil, nil)
+	syncEventRepo.EXPECT().Count(ctx, gomock.Any()).Return(0, nil)
+	syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "base123").Return(nil, nil)
+	spotifyClient.EXPECT().GetPlaylist(ctx, "spotify_base").Return(nil, errors.New("spotify down"))
+
+	overview, err := service.GetBasePlaylistOverview(ctx, "user123", "base123")
+
+	assert.NoError(err)
+	assert.Zero(overview.TrackCount)
+	assert.Nil(overview.LastSync)
+	assert.Nil(overview.LastCompletedAt)
+}
+
+func TestBasePlaylistOverviewService_GetBasePlaylistOverview_NotFound(t *testing.T) {
+	assert := require.New(t)
+	service, _, _ := setupBasePlaylistOverviewService(t)
+
+	overview, err := service.GetBasePlaylistOverview(context.Background(), "user123", "unknown")
+
+	assert.Nil(overview)
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
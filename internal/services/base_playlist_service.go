@@ -4,27 +4,43 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
-	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 )
 
+// DELETED_PLAYLIST_RETENTION is how long deleted playlists can be restored before being purged
+const DELETED_PLAYLIST_RETENTION = 30 * 24 * time.Hour
+
 //go:generate mockgen -source=base_playlist_service.go -destination=mocks/mock_base_playlist_service.go -package=mocks
 
 type BasePlaylistServicer interface {
 	CreateBasePlaylist(ctx context.Context, userId string, input *models.CreateBasePlaylistRequest) (*models.BasePlaylist, error)
 	DeleteBasePlaylist(ctx context.Context, id, userId string) error
+	RestoreBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
+	PurgeDeletedPlaylists(ctx context.Context, retention time.Duration) (int, error)
 	GetBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
 	GetBasePlaylistsByUserID(ctx context.Context, userId string) ([]*models.BasePlaylist, error)
 	GetBasePlaylistsByUserIDWithChilds(ctx context.Context, userId string) ([]*models.BasePlaylistWithChilds, error)
+	ListBasePlaylistsWithChilds(ctx context.Context, userId string, filter repositories.BasePlaylistFilter) (*models.BasePlaylistPage, error)
+	UpdateBasePlaylist(ctx context.Context, id, userId string, input *models.UpdateBasePlaylistRequest) (*models.BasePlaylist, error)
+	GetBasePlaylistByHookToken(ctx context.Context, hookToken string) (*models.BasePlaylist, error)
+	EnableHooks(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
+	DisableHooks(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
+	ArchiveBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
+	UnarchiveBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error)
 }
 
 type BasePlaylistService struct {
 	basePlaylistRepo       repositories.BasePlaylistRepository
 	childPlaylistRepo      repositories.ChildPlaylistRepository
 	spotifyIntegrationRepo repositories.SpotifyIntegrationRepository
-	spotifyClient          spotifyclient.SpotifyAPI
+	musicProvider          musicprovider.MusicProvider
+	spotifyAuth            SpotifyAuthProvider // nil when playlists only use the account of the request
+	events                 EventPublisher      // nil when changes aren't pushed to the app
 	logger                 *slog.Logger
 }
 
@@ -32,18 +48,31 @@ func NewBasePlaylistService(
 	basePlaylistRepo repositories.BasePlaylistRepository,
 	childPlaylistRepo repositories.ChildPlaylistRepository,
 	spotifyIntegrationRepo repositories.SpotifyIntegrationRepository,
-	spotifyClient spotifyclient.SpotifyAPI,
+	musicProvider musicprovider.MusicProvider,
 	logger *slog.Logger,
 ) *BasePlaylistService {
 	return &BasePlaylistService{
 		basePlaylistRepo:       basePlaylistRepo,
 		childPlaylistRepo:      childPlaylistRepo,
 		spotifyIntegrationRepo: spotifyIntegrationRepo,
-		spotifyClient:          spotifyClient,
+		musicProvider:          musicProvider,
 		logger:                 logger.With("component", "BasePlaylistService"),
 	}
 }
 
+// WithSpotifyAuth lets base playlists be created in a spotify account other than the one of the
+// request, loading its credentials through spotifyAuth
+func (bpService *BasePlaylistService) WithSpotifyAuth(spotifyAuth SpotifyAuthProvider) *BasePlaylistService {
+	bpService.spotifyAuth = spotifyAuth
+	return bpService
+}
+
+// WithEvents pushes the created, updated and deleted base playlists to the app through events
+func (bpService *BasePlaylistService) WithEvents(events EventPublisher) *BasePlaylistService {
+	bpService.events = events
+	return bpService
+}
+
 func (bpService *BasePlaylistService) CreateBasePlaylist(ctx context.Context, userId string, input *models.CreateBasePlaylistRequest) (*models.BasePlaylist, error) {
 	bpService.logger.InfoContext(ctx, "creating base playlist", "user_id", userId, "input", input)
 
@@ -53,9 +82,15 @@ func (bpService *BasePlaylistService) CreateBasePlaylist(ctx context.Context, us
 	if spotifyPlaylistID == "" {
 		bpService.logger.InfoContext(ctx, "spotify playlist ID empty, creating new playlist in Spotify", "name", input.Name)
 
+		accountCtx, err := contextWithPlaylistAccount(ctx, bpService.spotifyAuth, userId, input.Provider, input.SpotifyIntegrationID)
+		if err != nil {
+			bpService.logger.ErrorContext(ctx, "failed to load spotify account", "spotify_integration_id", input.SpotifyIntegrationID, "error", err.Error())
+			return nil, fmt.Errorf("failed to load spotify account: %w", err)
+		}
+
 		// Create playlist in Spotify
-		spotifyPlaylist, err := bpService.spotifyClient.CreatePlaylist(
-			ctx,
+		spotifyPlaylist, err := bpService.musicProvider.CreatePlaylist(
+			accountCtx,
 			input.Name,
 			"",    // empty description for now
 			false, // private by default
@@ -73,13 +108,14 @@ func (bpService *BasePlaylistService) CreateBasePlaylist(ctx context.Context, us
 	}
 
 	// Create the base playlist record in our database
-	playlist, err := bpService.basePlaylistRepo.Create(ctx, userId, input.Name, spotifyPlaylistID)
+	playlist, err := bpService.basePlaylistRepo.Create(ctx, userId, input.Name, spotifyPlaylistID, input.DedupeStrategy, input.SpotifyIntegrationID, input.Provider)
 	if err != nil {
 		bpService.logger.ErrorContext(ctx, "failed to create base playlist", "error", err.Error())
 		return nil, fmt.Errorf("failed to create playlist: %w", err)
 	}
 
 	bpService.logger.InfoContext(ctx, "base playlist created successfully", "base_playlist", playlist)
+	publishEvent(bpService.events, userId, models.NewBasePlaylistEvent(models.AppEventPlaylistCreated, playlist))
 	return playlist, nil
 }
 
@@ -93,9 +129,49 @@ func (bpService *BasePlaylistService) DeleteBasePlaylist(ctx context.Context, id
 	}
 
 	bpService.logger.InfoContext(ctx, "base playlist deleted successfully", "id", id)
+	publishEvent(bpService.events, userId, models.NewPlaylistDeletedEvent(models.PlaylistKindBase, id, ""))
 	return nil
 }
 
+// RestoreBasePlaylist brings back a deleted base playlist along with the child playlists deleted
+// with it
+func (bpService *BasePlaylistService) RestoreBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	bpService.logger.InfoContext(ctx, "restoring base playlist", "id", id, "user_id", userId)
+
+	playlist, err := bpService.basePlaylistRepo.Restore(ctx, id, userId)
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to restore base playlist", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to restore playlist: %w", err)
+	}
+
+	bpService.logger.InfoContext(ctx, "base playlist restored successfully", "base_playlist", playlist)
+	// To the app a restored playlist is a new one, its child playlists are fetched along with it
+	publishEvent(bpService.events, userId, models.NewBasePlaylistEvent(models.AppEventPlaylistCreated, playlist))
+	return playlist, nil
+}
+
+// PurgeDeletedPlaylists permanently removes the base and child playlists deleted longer than
+// retention ago, returning how many records were removed
+func (bpService *BasePlaylistService) PurgeDeletedPlaylists(ctx context.Context, retention time.Duration) (int, error) {
+	deletedBefore := time.Now().Add(-retention)
+
+	// Children go first so none is left pointing at a purged base playlist
+	childCount, err := bpService.childPlaylistRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to purge deleted child playlists", "error", err.Error())
+		return 0, fmt.Errorf("failed to purge child playlists: %w", err)
+	}
+
+	baseCount, err := bpService.basePlaylistRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to purge deleted base playlists", "error", err.Error())
+		return childCount, fmt.Errorf("failed to purge base playlists: %w", err)
+	}
+
+	bpService.logger.InfoContext(ctx, "purged deleted playlists", "base_playlists", baseCount, "child_playlists", childCount)
+	return baseCount + childCount, nil
+}
+
 func (bpService *BasePlaylistService) GetBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
 	bpService.logger.InfoContext(ctx, "retrieving base playlist", "id", id)
 
@@ -131,6 +207,47 @@ func (bpService *BasePlaylistService) GetBasePlaylistsByUserIDWithChilds(ctx con
 		return nil, fmt.Errorf("failed to retrieve playlists: %w", err)
 	}
 
+	playlistsWithChilds, err := bpService.withChilds(ctx, userId, playlists)
+	if err != nil {
+		return nil, err
+	}
+
+	bpService.logger.InfoContext(ctx, "base playlists with childs retrieved successfully", "user_id", userId, "count", len(playlists))
+	return playlistsWithChilds, nil
+}
+
+// ListBasePlaylistsWithChilds returns a page of the base playlists of the user matching the filter,
+// along with how many match across every page
+func (bpService *BasePlaylistService) ListBasePlaylistsWithChilds(ctx context.Context, userId string, filter repositories.BasePlaylistFilter) (*models.BasePlaylistPage, error) {
+	filter.UserID = userId
+	filter.Limit = pageSize(filter.Limit)
+	filter.Offset = max(filter.Offset, 0)
+
+	playlists, err := bpService.basePlaylistRepo.List(ctx, filter)
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to list base playlists for user", "user_id", userId, "error", err.Error())
+		return nil, fmt.Errorf("failed to list playlists: %w", err)
+	}
+
+	total, err := bpService.basePlaylistRepo.Count(ctx, filter)
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to count base playlists for user", "user_id", userId, "error", err.Error())
+		return nil, fmt.Errorf("failed to count playlists: %w", err)
+	}
+
+	playlistsWithChilds, err := bpService.withChilds(ctx, userId, playlists)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BasePlaylistPage{
+		Items:    playlistsWithChilds,
+		PageInfo: models.PageInfo{Total: total, Limit: filter.Limit, Offset: filter.Offset},
+	}, nil
+}
+
+// withChilds loads the child playlists of each base playlist
+func (bpService *BasePlaylistService) withChilds(ctx context.Context, userId string, playlists []*models.BasePlaylist) ([]*models.BasePlaylistWithChilds, error) {
 	playlistsWithChilds := make([]*models.BasePlaylistWithChilds, 0, len(playlists))
 	for _, playlist := range playlists {
 		childPlaylists, err := bpService.childPlaylistRepo.GetByBasePlaylistID(ctx, playlist.ID, userId)
@@ -145,6 +262,147 @@ func (bpService *BasePlaylistService) GetBasePlaylistsByUserIDWithChilds(ctx con
 		})
 	}
 
-	bpService.logger.InfoContext(ctx, "base playlists with childs retrieved successfully", "user_id", userId, "count", len(playlists))
 	return playlistsWithChilds, nil
 }
+
+// UpdateBasePlaylist changes the fields set in the input. A new linked spotify playlist must exist
+// and be owned by the spotify account of the base playlist
+func (bpService *BasePlaylistService) UpdateBasePlaylist(ctx context.Context, id, userId string, input *models.UpdateBasePlaylistRequest) (*models.BasePlaylist, error) {
+	bpService.logger.InfoContext(ctx, "updating base playlist", "id", id, "input", input)
+
+	if input.SpotifyPlaylistID != nil {
+		current, err := bpService.basePlaylistRepo.GetByID(ctx, id, userId)
+		if err != nil {
+			bpService.logger.ErrorContext(ctx, "failed to retrieve base playlist", "id", id, "error", err.Error())
+			return nil, fmt.Errorf("failed to update playlist: %w", err)
+		}
+
+		if current.SpotifyPlaylistID != *input.SpotifyPlaylistID {
+			if err := bpService.validateSpotifyPlaylist(ctx, current, *input.SpotifyPlaylistID); err != nil {
+				return nil, fmt.Errorf("failed to update playlist: %w", err)
+			}
+		}
+	}
+
+	playlist, err := bpService.basePlaylistRepo.Update(ctx, id, userId, repositories.UpdateBasePlaylistFields{
+		Name:              input.Name,
+		SpotifyPlaylistID: input.SpotifyPlaylistID,
+		IsActive:          input.IsActive,
+		DedupeStrategy:    input.DedupeStrategy,
+	})
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to update base playlist", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to update playlist: %w", err)
+	}
+
+	bpService.logger.InfoContext(ctx, "base playlist updated successfully", "base_playlist", playlist)
+	publishEvent(bpService.events, userId, models.NewBasePlaylistEvent(models.AppEventPlaylistUpdated, playlist))
+	return playlist, nil
+}
+
+// validateSpotifyPlaylist checks the spotify playlist exists and is owned by the spotify account
+// the base playlist lives in, so syncs can read it
+func (bpService *BasePlaylistService) validateSpotifyPlaylist(ctx context.Context, basePlaylist *models.BasePlaylist, spotifyPlaylistID string) error {
+	accountCtx, err := contextWithPlaylistAccount(ctx, bpService.spotifyAuth, basePlaylist.UserID, basePlaylist.Provider, basePlaylist.SpotifyIntegrationID)
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to load spotify account", "spotify_integration_id", basePlaylist.SpotifyIntegrationID, "error", err.Error())
+		return fmt.Errorf("failed to load spotify account: %w", err)
+	}
+
+	isSpotify := basePlaylist.Provider.OrDefault() == models.MusicProviderSpotify
+	integration, ok := requestcontext.GetSpotifyAuthFromContext(accountCtx)
+	if isSpotify && !ok {
+		return ErrSpotifyIntegrationUnavailable
+	}
+
+	spotifyPlaylist, err := bpService.musicProvider.GetPlaylist(accountCtx, spotifyPlaylistID)
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to get spotify playlist", "spotify_playlist_id", spotifyPlaylistID, "error", err.Error())
+		return fmt.Errorf("failed to get spotify playlist: %w", err)
+	}
+
+	// Any youtube playlist the linked channel can read can be synced from, whoever owns it
+	if !isSpotify {
+		return nil
+	}
+
+	if spotifyPlaylist.Owner == nil || spotifyPlaylist.Owner.ID != integration.SpotifyID {
+		bpService.logger.WarnContext(ctx, "spotify playlist owned by another account", "spotify_playlist_id", spotifyPlaylistID)
+		return fmt.Errorf("%w: %s", ErrSpotifyPlaylistNotOwned, spotifyPlaylistID)
+	}
+
+	return nil
+}
+
+// GetBasePlaylistByHookToken resolves the base playlist an automation hook token belongs to
+func (bpService *BasePlaylistService) GetBasePlaylistByHookToken(ctx context.Context, hookToken string) (*models.BasePlaylist, error) {
+	playlist, err := bpService.basePlaylistRepo.GetByHookToken(ctx, hookToken)
+	if err != nil {
+		bpService.logger.WarnContext(ctx, "failed to resolve hook token", "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve playlist: %w", err)
+	}
+
+	return playlist, nil
+}
+
+// EnableHooks issues a new hook token for the base playlist, revoking any previous one
+func (bpService *BasePlaylistService) EnableHooks(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	bpService.logger.InfoContext(ctx, "enabling hooks for base playlist", "id", id, "user_id", userId)
+
+	hookToken, err := generateSecretToken()
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to generate hook token", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to generate hook token: %w", err)
+	}
+
+	playlist, err := bpService.basePlaylistRepo.Update(ctx, id, userId, repositories.UpdateBasePlaylistFields{HookToken: &hookToken})
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to enable hooks for base playlist", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to enable hooks: %w", err)
+	}
+
+	bpService.logger.InfoContext(ctx, "hooks enabled for base playlist", "id", id, "user_id", userId)
+	publishEvent(bpService.events, userId, models.NewBasePlaylistEvent(models.AppEventPlaylistUpdated, playlist))
+	return playlist, nil
+}
+
+// DisableHooks clears the hook token so the automation hooks of the base playlist stop resolving
+func (bpService *BasePlaylistService) DisableHooks(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	bpService.logger.InfoContext(ctx, "disabling hooks for base playlist", "id", id, "user_id", userId)
+
+	emptyToken := ""
+	playlist, err := bpService.basePlaylistRepo.Update(ctx, id, userId, repositories.UpdateBasePlaylistFields{HookToken: &emptyToken})
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to disable hooks for base playlist", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to disable hooks: %w", err)
+	}
+
+	bpService.logger.InfoContext(ctx, "hooks disabled for base playlist", "id", id, "user_id", userId)
+	publishEvent(bpService.events, userId, models.NewBasePlaylistEvent(models.AppEventPlaylistUpdated, playlist))
+	return playlist, nil
+}
+
+// ArchiveBasePlaylist leaves the base playlist out of bulk and automated syncs, keeping its child
+// playlists and sync history as they are
+func (bpService *BasePlaylistService) ArchiveBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	return bpService.setArchived(ctx, id, userId, true)
+}
+
+// UnarchiveBasePlaylist lets the base playlist sync again
+func (bpService *BasePlaylistService) UnarchiveBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	return bpService.setArchived(ctx, id, userId, false)
+}
+
+func (bpService *BasePlaylistService) setArchived(ctx context.Context, id, userId string, archived bool) (*models.BasePlaylist, error) {
+	bpService.logger.InfoContext(ctx, "changing base playlist archived state", "id", id, "user_id", userId, "archived", archived)
+
+	playlist, err := bpService.basePlaylistRepo.Update(ctx, id, userId, repositories.UpdateBasePlaylistFields{Archived: &archived})
+	if err != nil {
+		bpService.logger.ErrorContext(ctx, "failed to change base playlist archived state", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to update playlist: %w", err)
+	}
+
+	bpService.logger.InfoContext(ctx, "base playlist archived state changed", "id", id, "user_id", userId, "archived", archived)
+	publishEvent(bpService.events, userId, models.NewBasePlaylistEvent(models.AppEventPlaylistUpdated, playlist))
+	return playlist, nil
+}
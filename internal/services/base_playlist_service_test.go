@@ -3,13 +3,18 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	spotifyMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,10 +32,10 @@ func TestNewBasePlaylistService(t *testing.T) {
 	service := NewBasePlaylistService(mockRepo, mockChildRepo, mockSpotifyIntegrationRepo, mockSpotifyClient, logger)
 
 	require.NotNil(service)
-	require.Equal(mockRepo, service.basePlaylistRepo)	
+	require.Equal(mockRepo, service.basePlaylistRepo)
 	require.Equal(mockChildRepo, service.childPlaylistRepo)
 	require.Equal(mockSpotifyIntegrationRepo, service.spotifyIntegrationRepo)
-	require.Equal(mockSpotifyClient, service.spotifyClient)
+	require.Equal(mockSpotifyClient, service.musicProvider)
 	require.NotNil(service.logger)
 }
 
@@ -48,13 +53,12 @@ func TestBasePlaylistService_CreateBasePlaylist_Success(t *testing.T) {
 				Name:              "My Test Playlist",
 				SpotifyPlaylistID: "spotify123",
 			},
-			expected: &models.BasePlaylist{
-				ID:                "playlist123",
-				UserID:            "user123",
-				Name:              "My Test Playlist",
-				SpotifyPlaylistID: "spotify123",
-				IsActive:          true,
-			},
+			expected: testfixtures.NewBasePlaylist().
+				WithID("playlist123").
+				WithUserID("user123").
+				WithName("My Test Playlist").
+				WithSpotifyPlaylistID("spotify123").
+				Build(),
 		},
 		{
 			name:   "successful creation with minimum valid name",
@@ -63,13 +67,12 @@ func TestBasePlaylistService_CreateBasePlaylist_Success(t *testing.T) {
 				Name:              "A",
 				SpotifyPlaylistID: "spotify456",
 			},
-			expected: &models.BasePlaylist{
-				ID:                "playlist456",
-				UserID:            "user456",
-				Name:              "A",
-				SpotifyPlaylistID: "spotify456",
-				IsActive:          true,
-			},
+			expected: testfixtures.NewBasePlaylist().
+				WithID("playlist456").
+				WithUserID("user456").
+				WithName("A").
+				WithSpotifyPlaylistID("spotify456").
+				Build(),
 		},
 	}
 
@@ -92,7 +95,7 @@ func TestBasePlaylistService_CreateBasePlaylist_Success(t *testing.T) {
 
 			// Set expectations
 			mockRepo.EXPECT().
-				Create(ctx, tt.userId, tt.input.Name, tt.input.SpotifyPlaylistID).
+				Create(ctx, tt.userId, tt.input.Name, tt.input.SpotifyPlaylistID, tt.input.DedupeStrategy, tt.input.SpotifyIntegrationID, tt.input.Provider).
 				Return(tt.expected, nil).
 				Times(1)
 
@@ -148,7 +151,7 @@ func TestBasePlaylistService_CreateBasePlaylist_RepositoryError(t *testing.T) {
 
 			// Set expectations
 			mockRepo.EXPECT().
-				Create(ctx, "placeholder_user_id", tt.input.Name, tt.input.SpotifyPlaylistID).
+				Create(ctx, "placeholder_user_id", tt.input.Name, tt.input.SpotifyPlaylistID, tt.input.DedupeStrategy, tt.input.SpotifyIntegrationID, tt.input.Provider).
 				Return(nil, tt.repositoryErr).
 				Times(1)
 
@@ -163,6 +166,42 @@ func TestBasePlaylistService_CreateBasePlaylist_RepositoryError(t *testing.T) {
 	}
 }
 
+func TestBasePlaylistService_CreateBasePlaylist_SelectedSpotifyAccount(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	spotifyAuth := &fakeSpotifyAuthProvider{}
+	service := NewBasePlaylistService(mockRepo, nil, nil, mockSpotifyClient, createTestLogger()).WithSpotifyAuth(spotifyAuth)
+
+	input := &models.CreateBasePlaylistRequest{Name: "DJ Set", SpotifyIntegrationID: "integration_dj"}
+	created := testfixtures.NewBasePlaylist().WithSpotifyIntegrationID("integration_dj").Build()
+
+	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), "DJ Set", "", false).Return(&spotifyclient.SpotifyPlaylist{ID: "spotify_dj_set"}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), "user123", "DJ Set", "spotify_dj_set", input.DedupeStrategy, "integration_dj", models.MusicProvider("")).Return(created, nil)
+
+	result, err := service.CreateBasePlaylist(context.Background(), "user123", input)
+
+	require.NoError(err)
+	require.Equal(created, result)
+	require.Equal([]string{"integration_dj"}, spotifyAuth.integrationIDs)
+}
+
+func TestBasePlaylistService_CreateBasePlaylist_UnknownSpotifyAccount(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spotifyAuth := &fakeSpotifyAuthProvider{err: ErrSpotifyIntegrationUnavailable}
+	service := NewBasePlaylistService(nil, nil, nil, spotifyMocks.NewMockSpotifyAPI(ctrl), createTestLogger()).WithSpotifyAuth(spotifyAuth)
+
+	_, err := service.CreateBasePlaylist(context.Background(), "user123", &models.CreateBasePlaylistRequest{Name: "DJ Set", SpotifyIntegrationID: "integration_other"})
+
+	require.ErrorIs(err, ErrSpotifyIntegrationUnavailable)
+}
+
 func TestBasePlaylistService_DeleteBasePlaylist_Success(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -213,6 +252,124 @@ func TestBasePlaylistService_DeleteBasePlaylist_Success(t *testing.T) {
 	}
 }
 
+func TestBasePlaylistService_PublishesEvents(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	events := &recordingPublisher{}
+	service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger()).WithEvents(events)
+
+	ctx := context.Background()
+	playlist := testfixtures.NewBasePlaylist().WithID("playlist123").WithUserID("user123").Build()
+	name := "Renamed"
+
+	mockRepo.EXPECT().Create(ctx, "user123", "Road Trip", "spotify123", gomock.Any(), "", models.MusicProvider("")).Return(playlist, nil)
+	mockRepo.EXPECT().Update(ctx, "playlist123", "user123", repositories.UpdateBasePlaylistFields{Name: &name}).Return(playlist, nil)
+	mockRepo.EXPECT().Delete(ctx, "playlist123", "user123").Return(nil)
+
+	_, err := service.CreateBasePlaylist(ctx, "user123", &models.CreateBasePlaylistRequest{Name: "Road Trip", SpotifyPlaylistID: "spotify123"})
+	require.NoError(err)
+	_, err = service.UpdateBasePlaylist(ctx, "playlist123", "user123", &models.UpdateBasePlaylistRequest{Name: &name})
+	require.NoError(err)
+	require.NoError(service.DeleteBasePlaylist(ctx, "playlist123", "user123"))
+
+	require.Equal([]string{"user123", "user123", "user123"}, events.userIDs)
+	require.Equal(models.AppEventPlaylistCreated, events.events[0].Type)
+	require.Equal(playlist, events.events[0].Playlist.BasePlaylist)
+	require.Equal(models.AppEventPlaylistUpdated, events.events[1].Type)
+	require.Equal(models.AppEventPlaylistDeleted, events.events[2].Type)
+	require.Equal(&models.PlaylistChange{Kind: models.PlaylistKindBase, ID: "playlist123"}, events.events[2].Playlist)
+}
+
+func TestBasePlaylistService_RestoreBasePlaylist(t *testing.T) {
+	tests := []struct {
+		name          string
+		repositoryErr error
+		expectedErr   error
+	}{
+		{
+			name: "restores the playlist",
+		},
+		{
+			name:          "another playlist links the same spotify playlist",
+			repositoryErr: repositories.ErrRestoreConflict,
+			expectedErr:   repositories.ErrRestoreConflict,
+		},
+		{
+			name:          "playlist not found",
+			repositoryErr: repositories.ErrBasePlaylistNotFound,
+			expectedErr:   repositories.ErrBasePlaylistNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := setupMockController(t)
+
+			mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+			service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger())
+
+			var playlist *models.BasePlaylist
+			if tt.repositoryErr == nil {
+				playlist = testfixtures.NewBasePlaylist().WithID("playlist123").Build()
+			}
+			mockRepo.EXPECT().Restore(gomock.Any(), "playlist123", "user123").Return(playlist, tt.repositoryErr)
+
+			restored, err := service.RestoreBasePlaylist(context.Background(), "playlist123", "user123")
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, tt.expectedErr)
+				require.Nil(restored)
+				return
+			}
+			require.NoError(err)
+			require.Equal(playlist, restored)
+		})
+	}
+}
+
+func TestBasePlaylistService_PurgeDeletedPlaylists(t *testing.T) {
+	require := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	mockChildRepo := mocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, mockChildRepo, nil, nil, createTestLogger())
+
+	before := time.Now().Add(-DELETED_PLAYLIST_RETENTION)
+	cutoffMatcher := gomock.AssignableToTypeOf(time.Time{})
+
+	gomock.InOrder(
+		mockChildRepo.EXPECT().Purge(gomock.Any(), cutoffMatcher).DoAndReturn(func(_ context.Context, deletedBefore time.Time) (int, error) {
+			require.False(deletedBefore.Before(before))
+			return 3, nil
+		}),
+		mockRepo.EXPECT().Purge(gomock.Any(), cutoffMatcher).Return(2, nil),
+	)
+
+	purged, err := service.PurgeDeletedPlaylists(context.Background(), DELETED_PLAYLIST_RETENTION)
+
+	require.NoError(err)
+	require.Equal(5, purged)
+}
+
+func TestBasePlaylistService_PurgeDeletedPlaylists_ChildPurgeError(t *testing.T) {
+	require := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	mockChildRepo := mocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, mockChildRepo, nil, nil, createTestLogger())
+
+	mockChildRepo.EXPECT().Purge(gomock.Any(), gomock.Any()).Return(0, repositories.ErrDatabaseOperation)
+
+	_, err := service.PurgeDeletedPlaylists(context.Background(), DELETED_PLAYLIST_RETENTION)
+
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
 func TestBasePlaylistService_DeleteBasePlaylist_RepositoryErrors(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -274,25 +431,24 @@ func TestBasePlaylistService_GetBasePlaylist_Success(t *testing.T) {
 			name:   "successful retrieval with valid id",
 			id:     "playlist123",
 			userId: "user123",
-			expected: &models.BasePlaylist{
-				ID:                "playlist123",
-				UserID:            "user123",
-				Name:              "My Test Playlist",
-				SpotifyPlaylistID: "spotify123",
-				IsActive:          true,
-			},
+			expected: testfixtures.NewBasePlaylist().
+				WithID("playlist123").
+				WithUserID("user123").
+				WithName("My Test Playlist").
+				WithSpotifyPlaylistID("spotify123").
+				Build(),
 		},
 		{
 			name:   "successful retrieval with different user",
 			id:     "playlist456",
 			userId: "user456",
-			expected: &models.BasePlaylist{
-				ID:                "playlist456",
-				UserID:            "user456",
-				Name:              "Another Playlist",
-				SpotifyPlaylistID: "spotify456",
-				IsActive:          false,
-			},
+			expected: testfixtures.NewBasePlaylist().
+				WithID("playlist456").
+				WithUserID("user456").
+				WithName("Another Playlist").
+				WithSpotifyPlaylistID("spotify456").
+				Inactive().
+				Build(),
 		},
 	}
 
@@ -396,27 +552,24 @@ func TestBasePlaylistService_GetBasePlaylistsByUserID_Success(t *testing.T) {
 			name:   "user with multiple playlists",
 			userId: "user123",
 			mockPlaylists: []*models.BasePlaylist{
-				{
-					ID:                "playlist1",
-					UserID:            "user123",
-					Name:              "First Playlist",
-					SpotifyPlaylistID: "spotify1",
-					IsActive:          true,
-				},
-				{
-					ID:                "playlist2",
-					UserID:            "user123",
-					Name:              "Second Playlist",
-					SpotifyPlaylistID: "spotify2",
-					IsActive:          true,
-				},
-				{
-					ID:                "playlist3",
-					UserID:            "user123",
-					Name:              "Third Playlist",
-					SpotifyPlaylistID: "spotify3",
-					IsActive:          true,
-				},
+				testfixtures.NewBasePlaylist().
+					WithID("playlist1").
+					WithUserID("user123").
+					WithName("First Playlist").
+					WithSpotifyPlaylistID("spotify1").
+					Build(),
+				testfixtures.NewBasePlaylist().
+					WithID("playlist2").
+					WithUserID("user123").
+					WithName("Second Playlist").
+					WithSpotifyPlaylistID("spotify2").
+					Build(),
+				testfixtures.NewBasePlaylist().
+					WithID("playlist3").
+					WithUserID("user123").
+					WithName("Third Playlist").
+					WithSpotifyPlaylistID("spotify3").
+					Build(),
 			},
 			expectedCount: 3,
 		},
@@ -424,13 +577,12 @@ func TestBasePlaylistService_GetBasePlaylistsByUserID_Success(t *testing.T) {
 			name:   "user with single playlist",
 			userId: "user456",
 			mockPlaylists: []*models.BasePlaylist{
-				{
-					ID:                "playlist4",
-					UserID:            "user456",
-					Name:              "Only Playlist",
-					SpotifyPlaylistID: "spotify4",
-					IsActive:          true,
-				},
+				testfixtures.NewBasePlaylist().
+					WithID("playlist4").
+					WithUserID("user456").
+					WithName("Only Playlist").
+					WithSpotifyPlaylistID("spotify4").
+					Build(),
 			},
 			expectedCount: 1,
 		},
@@ -533,3 +685,362 @@ func TestBasePlaylistService_GetBasePlaylistsByUserID_RepositoryErrors(t *testin
 		})
 	}
 }
+
+func TestBasePlaylistService_UpdateBasePlaylist(t *testing.T) {
+	firstMatch := models.DedupeStrategyFirstMatch
+
+	tests := []struct {
+		name          string
+		repositoryErr error
+		expectedErr   string
+	}{
+		{
+			name: "successful update",
+		},
+		{
+			name:          "repository error",
+			repositoryErr: repositories.ErrUnauthorized,
+			expectedErr:   "failed to update playlist: user can not access this resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+			mockChildRepo := mocks.NewMockChildPlaylistRepository(ctrl)
+			mockSpotifyIntegrationRepo := mocks.NewMockSpotifyIntegrationRepository(ctrl)
+			mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+			logger := createTestLogger()
+			service := NewBasePlaylistService(mockRepo, mockChildRepo, mockSpotifyIntegrationRepo, mockSpotifyClient, logger)
+
+			ctx := context.Background()
+
+			var repoResult *models.BasePlaylist
+			if tt.repositoryErr == nil {
+				repoResult = testfixtures.NewBasePlaylist().WithID("playlist123").WithUserID("user123").WithDedupeStrategy(models.DedupeStrategyFirstMatch).Build()
+			}
+
+			// Set expectations
+			mockRepo.EXPECT().
+				Update(ctx, "playlist123", "user123", repositories.UpdateBasePlaylistFields{DedupeStrategy: &firstMatch}).
+				Return(repoResult, tt.repositoryErr).
+				Times(1)
+
+			// Execute
+			result, err := service.UpdateBasePlaylist(ctx, "playlist123", "user123", &models.UpdateBasePlaylistRequest{
+				DedupeStrategy: &firstMatch,
+			})
+
+			// Verify
+			if tt.expectedErr != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.expectedErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(repoResult, result)
+		})
+	}
+}
+
+func TestBasePlaylistService_UpdateBasePlaylist_SpotifyPlaylist(t *testing.T) {
+	tests := []struct {
+		name              string
+		spotifyPlaylistID string
+		spotifyPlaylist   *spotifyclient.SpotifyPlaylist
+		spotifyErr        error
+		expectedErr       error
+	}{
+		{
+			name:              "playlist owned by the spotify account",
+			spotifyPlaylistID: "new_spotify_playlist",
+			spotifyPlaylist: &spotifyclient.SpotifyPlaylist{
+				ID:    "new_spotify_playlist",
+				Owner: &spotifyclient.SpotifyPlaylistOwner{ID: "spotify_user_123"},
+			},
+		},
+		{
+			name:              "same playlist is not validated again",
+			spotifyPlaylistID: "spotify_playlist_123",
+		},
+		{
+			name:              "playlist owned by another spotify account",
+			spotifyPlaylistID: "new_spotify_playlist",
+			spotifyPlaylist: &spotifyclient.SpotifyPlaylist{
+				ID:    "new_spotify_playlist",
+				Owner: &spotifyclient.SpotifyPlaylistOwner{ID: "spotify_user_456"},
+			},
+			expectedErr: ErrSpotifyPlaylistNotOwned,
+		},
+		{
+			name:              "playlist does not exist",
+			spotifyPlaylistID: "new_spotify_playlist",
+			spotifyErr:        fmt.Errorf("%w: new_spotify_playlist", spotifyclient.ErrPlaylistNotFound),
+			expectedErr:       spotifyclient.ErrPlaylistNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := setupMockController(t)
+			mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+			mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+			service := NewBasePlaylistService(mockRepo, nil, nil, mockSpotifyClient, createTestLogger())
+
+			integration := testfixtures.NewSpotifyIntegration().WithUserID("user123").WithSpotifyID("spotify_user_123").Build()
+			ctx := requestcontext.ContextWithSpotifyAuth(context.Background(), integration)
+
+			current := testfixtures.NewBasePlaylist().
+				WithID("playlist123").
+				WithUserID("user123").
+				WithSpotifyPlaylistID("spotify_playlist_123").
+				Build()
+			mockRepo.EXPECT().GetByID(ctx, "playlist123", "user123").Return(current, nil)
+
+			if tt.spotifyPlaylist != nil || tt.spotifyErr != nil {
+				mockSpotifyClient.EXPECT().GetPlaylist(ctx, tt.spotifyPlaylistID).Return(tt.spotifyPlaylist, tt.spotifyErr)
+			}
+
+			name := "Renamed"
+			input := &models.UpdateBasePlaylistRequest{Name: &name, SpotifyPlaylistID: &tt.spotifyPlaylistID}
+
+			var updated *models.BasePlaylist
+			if tt.expectedErr == nil {
+				updated = testfixtures.NewBasePlaylist().
+					WithID("playlist123").
+					WithName(name).
+					WithSpotifyPlaylistID(tt.spotifyPlaylistID).
+					Build()
+				mockRepo.EXPECT().
+					Update(ctx, "playlist123", "user123", repositories.UpdateBasePlaylistFields{
+						Name:              &name,
+						SpotifyPlaylistID: &tt.spotifyPlaylistID,
+					}).
+					Return(updated, nil)
+			}
+
+			result, err := service.UpdateBasePlaylist(ctx, "playlist123", "user123", input)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, tt.expectedErr)
+				require.Nil(result)
+				return
+			}
+			require.NoError(err)
+			require.Equal(updated, result)
+		})
+	}
+}
+
+func TestBasePlaylistService_UpdateBasePlaylist_BasePlaylistNotFound(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger())
+
+	ctx := context.Background()
+	spotifyPlaylistID := "new_spotify_playlist"
+	mockRepo.EXPECT().GetByID(ctx, "playlist123", "user123").Return(nil, repositories.ErrBasePlaylistNotFound)
+
+	result, err := service.UpdateBasePlaylist(ctx, "playlist123", "user123", &models.UpdateBasePlaylistRequest{
+		SpotifyPlaylistID: &spotifyPlaylistID,
+	})
+
+	require.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+	require.Nil(result)
+}
+
+func TestBasePlaylistService_EnableHooks(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger())
+
+	ctx := context.Background()
+
+	var hookToken string
+	mockRepo.EXPECT().
+		Update(ctx, "playlist123", "user123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, userId string, fields repositories.UpdateBasePlaylistFields) (*models.BasePlaylist, error) {
+			hookToken = *fields.HookToken
+			return testfixtures.NewBasePlaylist().WithID(id).WithUserID(userId).WithHookToken(hookToken).Build(), nil
+		})
+
+	result, err := service.EnableHooks(ctx, "playlist123", "user123")
+
+	require.NoError(err)
+	require.Len(hookToken, 64)
+	require.Equal(hookToken, result.HookToken)
+}
+
+func TestBasePlaylistService_DisableHooks(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger())
+
+	ctx := context.Background()
+
+	emptyToken := ""
+	mockRepo.EXPECT().
+		Update(ctx, "playlist123", "user123", repositories.UpdateBasePlaylistFields{HookToken: &emptyToken}).
+		Return(nil, repositories.ErrUnauthorized)
+
+	result, err := service.DisableHooks(ctx, "playlist123", "user123")
+
+	require.ErrorIs(err, repositories.ErrUnauthorized)
+	require.Nil(result)
+}
+
+func TestBasePlaylistService_GetBasePlaylistByHookToken(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetByHookToken(ctx, "hook123").Return(testfixtures.NewBasePlaylist().WithID("playlist123").Build(), nil)
+	mockRepo.EXPECT().GetByHookToken(ctx, "unknown").Return(nil, repositories.ErrBasePlaylistNotFound)
+
+	result, err := service.GetBasePlaylistByHookToken(ctx, "hook123")
+	require.NoError(err)
+	require.Equal("playlist123", result.ID)
+
+	_, err = service.GetBasePlaylistByHookToken(ctx, "unknown")
+	require.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
+
+func TestBasePlaylistService_ListBasePlaylistsWithChilds(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	mockChildRepo := mocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, mockChildRepo, nil, nil, createTestLogger())
+
+	ctx := context.Background()
+
+	isActive := true
+	expectedFilter := repositories.BasePlaylistFilter{
+		UserID:   "user123",
+		IsActive: &isActive,
+		Sort:     models.ListSortNameAsc,
+		Limit:    2,
+		Offset:   4,
+	}
+	basePlaylist := testfixtures.NewBasePlaylist().WithID("playlist123").WithUserID("user123").Build()
+	childPlaylist := testfixtures.NewChildPlaylist().WithID("child123").Build()
+
+	mockRepo.EXPECT().List(ctx, expectedFilter).Return([]*models.BasePlaylist{basePlaylist}, nil)
+	mockRepo.EXPECT().Count(ctx, expectedFilter).Return(5, nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(ctx, "playlist123", "user123").Return([]*models.ChildPlaylist{childPlaylist}, nil)
+
+	page, err := service.ListBasePlaylistsWithChilds(ctx, "user123", repositories.BasePlaylistFilter{
+		IsActive: &isActive,
+		Sort:     models.ListSortNameAsc,
+		Limit:    2,
+		Offset:   4,
+	})
+
+	require.NoError(err)
+	require.Equal(models.PageInfo{Total: 5, Limit: 2, Offset: 4}, page.PageInfo)
+	require.Len(page.Items, 1)
+	require.Equal(basePlaylist, page.Items[0].BasePlaylist)
+	require.Equal([]*models.ChildPlaylist{childPlaylist}, page.Items[0].Childs)
+}
+
+func TestBasePlaylistService_ListBasePlaylistsWithChilds_RepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		listErr  error
+		countErr error
+		childErr error
+	}{
+		{name: "list fails", listErr: repositories.ErrDatabaseOperation},
+		{name: "count fails", countErr: repositories.ErrDatabaseOperation},
+		{name: "child playlists fail", childErr: repositories.ErrDatabaseOperation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := setupMockController(t)
+			mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+			mockChildRepo := mocks.NewMockChildPlaylistRepository(ctrl)
+			service := NewBasePlaylistService(mockRepo, mockChildRepo, nil, nil, createTestLogger())
+
+			ctx := context.Background()
+			basePlaylist := testfixtures.NewBasePlaylist().WithID("playlist123").Build()
+
+			mockRepo.EXPECT().List(ctx, gomock.Any()).Return([]*models.BasePlaylist{basePlaylist}, tt.listErr)
+			if tt.listErr == nil {
+				mockRepo.EXPECT().Count(ctx, gomock.Any()).Return(1, tt.countErr)
+			}
+			if tt.listErr == nil && tt.countErr == nil {
+				mockChildRepo.EXPECT().GetByBasePlaylistID(ctx, "playlist123", "user123").Return(nil, tt.childErr)
+			}
+
+			page, err := service.ListBasePlaylistsWithChilds(ctx, "user123", repositories.BasePlaylistFilter{})
+
+			require.Nil(page)
+			require.ErrorIs(err, repositories.ErrDatabaseOperation)
+		})
+	}
+}
+
+func TestBasePlaylistService_ArchiveBasePlaylist(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger())
+
+	ctx := context.Background()
+
+	archived := testfixtures.NewBasePlaylist().WithID("playlist123").Archived().Build()
+	archive := true
+	mockRepo.EXPECT().
+		Update(ctx, "playlist123", "user123", repositories.UpdateBasePlaylistFields{Archived: &archive}).
+		Return(archived, nil)
+
+	result, err := service.ArchiveBasePlaylist(ctx, "playlist123", "user123")
+
+	require.NoError(err)
+	require.True(result.Archived)
+}
+
+func TestBasePlaylistService_UnarchiveBasePlaylist(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockRepo := mocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewBasePlaylistService(mockRepo, nil, nil, nil, createTestLogger())
+
+	ctx := context.Background()
+
+	archive := false
+	mockRepo.EXPECT().
+		Update(ctx, "playlist123", "user123", repositories.UpdateBasePlaylistFields{Archived: &archive}).
+		Return(nil, repositories.ErrBasePlaylistNotFound)
+
+	result, err := service.UnarchiveBasePlaylist(ctx, "playlist123", "user123")
+
+	require.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+	require.Nil(result)
+}
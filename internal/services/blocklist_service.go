@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=blocklist_service.go -destination=mocks/mock_blocklist_service.go -package=mocks
+
+const (
+	spotifyTrackURIPrefix  = "spotify:track:"
+	spotifyArtistURIPrefix = "spotify:artist:"
+)
+
+// BlocklistServicer manages the tracks and artists kept out of every child playlist of a base playlist
+type BlocklistServicer interface {
+	AddEntry(ctx context.Context, userID, basePlaylistID string, input *models.CreateBlocklistEntryRequest) (*models.BlocklistEntry, error)
+	ListEntries(ctx context.Context, userID, basePlaylistID string) ([]*models.BlocklistEntry, error)
+	DeleteEntry(ctx context.Context, userID, id string) error
+}
+
+type BlocklistService struct {
+	blocklistRepo    repositories.BlocklistEntryRepository
+	basePlaylistRepo repositories.BasePlaylistRepository
+	logger           *slog.Logger
+}
+
+func NewBlocklistService(
+	blocklistRepo repositories.BlocklistEntryRepository,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	logger *slog.Logger,
+) *BlocklistService {
+	return &BlocklistService{
+		blocklistRepo:    blocklistRepo,
+		basePlaylistRepo: basePlaylistRepo,
+		logger:           logger.With("component", "BlocklistService"),
+	}
+}
+
+func (bService *BlocklistService) AddEntry(ctx context.Context, userID, basePlaylistID string, input *models.CreateBlocklistEntryRequest) (*models.BlocklistEntry, error) {
+	value, err := normalizeBlocklistValue(input.Type, input.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := bService.ListEntries(ctx, userID, basePlaylistID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Type == input.Type && entry.Value == value {
+			return nil, ErrBlocklistEntryExists
+		}
+	}
+
+	entry, err := bService.blocklistRepo.Create(ctx, &models.BlocklistEntry{
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		Type:           input.Type,
+		Value:          value,
+	})
+	if err != nil {
+		bService.logger.ErrorContext(ctx, "failed to create blocklist entry", "base_playlist_id", basePlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to create blocklist entry: %w", err)
+	}
+
+	bService.logger.InfoContext(ctx, "blocklist entry created", "id", entry.ID, "base_playlist_id", basePlaylistID, "type", entry.Type)
+	return entry, nil
+}
+
+func (bService *BlocklistService) ListEntries(ctx context.Context, userID, basePlaylistID string) ([]*models.BlocklistEntry, error) {
+	// Ensures the base playlist exists and belongs to the user
+	if _, err := bService.basePlaylistRepo.GetByID(ctx, basePlaylistID, userID); err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	entries, err := bService.blocklistRepo.GetByBasePlaylistID(ctx, basePlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve blocklist entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (bService *BlocklistService) DeleteEntry(ctx context.Context, userID, id string) error {
+	if err := bService.blocklistRepo.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to delete blocklist entry: %w", err)
+	}
+
+	bService.logger.InfoContext(ctx, "blocklist entry deleted", "id", id)
+	return nil
+}
+
+// normalizeBlocklistValue checks the value matches the entry type. Tracks are blocked by URI and
+// artists by ID, artist URIs are accepted and trimmed to the ID
+func normalizeBlocklistValue(entryType models.BlocklistEntryType, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch entryType {
+	case models.BlocklistEntryTypeTrack:
+		if !strings.HasPrefix(value, spotifyTrackURIPrefix) || value == spotifyTrackURIPrefix {
+			return "", fmt.Errorf("%w: tracks are blocked by spotify track uri", ErrInvalidBlocklistEntry)
+		}
+	case models.BlocklistEntryTypeArtist:
+		value = strings.TrimPrefix(value, spotifyArtistURIPrefix)
+		if value == "" || strings.Contains(value, ":") {
+			return "", fmt.Errorf("%w: artists are blocked by spotify artist id", ErrInvalidBlocklistEntry)
+		}
+	default:
+		return "", fmt.Errorf("%w: unknown type %q", ErrInvalidBlocklistEntry, entryType)
+	}
+
+	return value, nil
+}
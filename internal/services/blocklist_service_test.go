@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBlocklistService(t *testing.T) (*BlocklistService, *repositoryMocks.MockBlocklistEntryRepository, *repositoryMocks.MockBasePlaylistRepository) {
+	ctrl := setupMockController(t)
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(ctrl)
+	basePlaylistRepo := repositoryMocks.NewMockBasePlaylistRepository(ctrl)
+
+	return NewBlocklistService(blocklistRepo, basePlaylistRepo, createTestLogger()), blocklistRepo, basePlaylistRepo
+}
+
+func TestBlocklistService_AddEntry(t *testing.T) {
+	t.Run("normalizes artist uris to ids", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+		service, blocklistRepo, basePlaylistRepo := setupBlocklistService(t)
+
+		basePlaylistRepo.EXPECT().GetByID(ctx, "base123", "user123").Return(testfixtures.NewBasePlaylist().WithID("base123").Build(), nil)
+		blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base123", "user123").Return([]*models.BlocklistEntry{}, nil)
+		blocklistRepo.EXPECT().
+			Create(ctx, &models.BlocklistEntry{
+				UserID:         "user123",
+				BasePlaylistID: "base123",
+				Type:           models.BlocklistEntryTypeArtist,
+				Value:          "artist1",
+			}).
+			DoAndReturn(func(ctx context.Context, entry *models.BlocklistEntry) (*models.BlocklistEntry, error) {
+				entry.ID = "entry1"
+				return entry, nil
+			})
+
+		entry, err := service.AddEntry(ctx, "user123", "base123", &models.CreateBlocklistEntryRequest{
+			Type:  models.BlocklistEntryTypeArtist,
+			Value: "spotify:artist:artist1",
+		})
+
+		assert.NoError(err)
+		assert.Equal("entry1", entry.ID)
+		assert.Equal("artist1", entry.Value)
+	})
+
+	t.Run("rejects duplicate entries", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+		service, blocklistRepo, basePlaylistRepo := setupBlocklistService(t)
+
+		basePlaylistRepo.EXPECT().GetByID(ctx, "base123", "user123").Return(testfixtures.NewBasePlaylist().WithID("base123").Build(), nil)
+		blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base123", "user123").Return([]*models.BlocklistEntry{
+			{ID: "entry1", Type: models.BlocklistEntryTypeTrack, Value: "spotify:track:1"},
+		}, nil)
+
+		_, err := service.AddEntry(ctx, "user123", "base123", &models.CreateBlocklistEntryRequest{
+			Type:  models.BlocklistEntryTypeTrack,
+			Value: "spotify:track:1",
+		})
+
+		assert.ErrorIs(err, ErrBlocklistEntryExists)
+	})
+
+	t.Run("rejects values not matching the type", func(t *testing.T) {
+		assert := require.New(t)
+		service, _, _ := setupBlocklistService(t)
+
+		_, err := service.AddEntry(context.Background(), "user123", "base123", &models.CreateBlocklistEntryRequest{
+			Type:  models.BlocklistEntryTypeTrack,
+			Value: "track1",
+		})
+		assert.ErrorIs(err, ErrInvalidBlocklistEntry)
+
+		_, err = service.AddEntry(context.Background(), "user123", "base123", &models.CreateBlocklistEntryRequest{
+			Type:  models.BlocklistEntryTypeArtist,
+			Value: "spotify:track:1",
+		})
+		assert.ErrorIs(err, ErrInvalidBlocklistEntry)
+	})
+
+	t.Run("base playlist not found", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+		service, _, basePlaylistRepo := setupBlocklistService(t)
+
+		basePlaylistRepo.EXPECT().GetByID(ctx, "base123", "user123").Return(nil, repositories.ErrBasePlaylistNotFound)
+
+		_, err := service.AddEntry(ctx, "user123", "base123", &models.CreateBlocklistEntryRequest{
+			Type:  models.BlocklistEntryTypeTrack,
+			Value: "spotify:track:1",
+		})
+
+		assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+	})
+}
+
+func TestBlocklistService_DeleteEntry(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	service, blocklistRepo, _ := setupBlocklistService(t)
+
+	blocklistRepo.EXPECT().Delete(ctx, "entry1", "user123").Return(repositories.ErrUnauthorized)
+	blocklistRepo.EXPECT().Delete(ctx, "entry2", "user123").Return(nil)
+
+	assert.ErrorIs(service.DeleteEntry(ctx, "user123", "entry1"), repositories.ErrUnauthorized)
+	assert.NoError(service.DeleteEntry(ctx, "user123", "entry2"))
+}
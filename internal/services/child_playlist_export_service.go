@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/playlistfile"
+)
+
+//go:generate mockgen -source=child_playlist_export_service.go -destination=mocks/mock_child_playlist_export_service.go -package=mocks
+
+type ChildPlaylistExportServicer interface {
+	ExportChildPlaylist(ctx context.Context, userID, id string, format models.ExportFormat, w io.Writer) error
+}
+
+// ChildPlaylistExportService exports the tracks the last sync routed to a child playlist, as they
+// are in its playlist on the streaming service, so they can be taken to other tools
+type ChildPlaylistExportService struct {
+	childPlaylistService ChildPlaylistServicer
+	musicProvider        musicprovider.MusicProvider
+	spotifyAuth          SpotifyAuthProvider
+	logger               *slog.Logger
+}
+
+func NewChildPlaylistExportService(
+	childPlaylistService ChildPlaylistServicer,
+	musicProvider musicprovider.MusicProvider,
+	spotifyAuth SpotifyAuthProvider,
+	logger *slog.Logger,
+) *ChildPlaylistExportService {
+	return &ChildPlaylistExportService{
+		childPlaylistService: childPlaylistService,
+		musicProvider:        musicProvider,
+		spotifyAuth:          spotifyAuth,
+		logger:               logger.With("component", "ChildPlaylistExportService"),
+	}
+}
+
+// ExportChildPlaylist writes the tracks of the child playlist to w in the format, a page at a time.
+// Nothing is written until the first page was read, so errors finding the playlist can still be
+// reported; an error returned after that leaves the file cut short
+func (ceService *ChildPlaylistExportService) ExportChildPlaylist(ctx context.Context, userID, id string, format models.ExportFormat, w io.Writer) error {
+	childPlaylist, err := ceService.childPlaylistService.GetChildPlaylist(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	accountCtx, err := contextWithPlaylistAccount(ctx, ceService.spotifyAuth, userID, childPlaylist.Provider, childPlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return err
+	}
+
+	var writer playlistfile.Writer
+	exported := 0
+	for offset := 0; ; offset += MAX_TRACKS {
+		tracksResp, err := ceService.musicProvider.GetPlaylistTracks(accountCtx, childPlaylist.SpotifyPlaylistID, MAX_TRACKS, offset)
+		if err != nil {
+			ceService.logger.ErrorContext(ctx, "failed to fetch child playlist tracks", "child_playlist_id", id, "offset", offset, "error", err.Error())
+			return fmt.Errorf("failed to fetch child playlist tracks: %w", err)
+		}
+
+		if writer == nil {
+			writer, err = playlistfile.NewWriter(w, format, childPlaylist.Name)
+			if err != nil {
+				return err
+			}
+		}
+
+		tracks := exportedTracks(tracksResp.Items)
+		if err := writer.WriteTracks(tracks); err != nil {
+			return fmt.Errorf("failed to write exported tracks: %w", err)
+		}
+		exported += len(tracks)
+
+		if tracksResp.Next == nil {
+			break
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write exported tracks: %w", err)
+	}
+
+	ceService.logger.InfoContext(ctx, "exported child playlist", "child_playlist_id", id, "format", format, "tracks", exported)
+	return nil
+}
+
+// exportedTracks leaves out the items without a track, such as local files Spotify can't resolve
+func exportedTracks(items []musicprovider.PlaylistTrack) []models.ExportedTrack {
+	tracks := make([]models.ExportedTrack, 0, len(items))
+	for _, item := range items {
+		if item.Track == nil || item.Track.URI == "" {
+			continue
+		}
+
+		track := models.ExportedTrack{
+			URI:         item.Track.URI,
+			Name:        item.Track.Name,
+			Artists:     make([]string, 0, len(item.Track.Artists)),
+			Album:       item.Track.Album.Name,
+			ReleaseDate: item.Track.Album.ReleaseDate,
+			DurationMs:  item.Track.DurationMs,
+			Explicit:    item.Track.Explicit,
+			Popularity:  item.Track.Popularity,
+		}
+		for _, artist := range item.Track.Artists {
+			track.Artists = append(track.Artists, artist.Name)
+		}
+		if !item.AddedAt.IsZero() {
+			addedAt := item.AddedAt
+			track.AddedAt = &addedAt
+		}
+
+		tracks = append(tracks, track)
+	}
+
+	return tracks
+}
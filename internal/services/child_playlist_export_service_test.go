@@ -0,0 +1,97 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func setupChildPlaylistExportService(t *testing.T) (*ChildPlaylistExportService, *spotifyClientMocks.MockSpotifyAPI) {
+	ctrl := setupMockController(t)
+	spotifyClient := spotifyClientMocks.NewMockSpotifyAPI(ctrl)
+
+	childPlaylistService := &fakeChildPlaylistService{children: []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child1").WithName("Chill").WithSpotifyPlaylistID("spotify_child").Build(),
+	}}
+
+	service := NewChildPlaylistExportService(childPlaylistService, spotifyClient, &fakeSpotifyAuthProvider{}, createTestLogger())
+	return service, spotifyClient
+}
+
+func TestChildPlaylistExportService_ExportChildPlaylist(t *testing.T) {
+	assert := require.New(t)
+	service, spotifyClient := setupChildPlaylistExportService(t)
+	ctx := context.Background()
+
+	addedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	next := "next"
+	firstPage := &spotifyclient.SpotifyPlaylistTracksResponse{
+		Items: []spotifyclient.SpotifyPlaylistTrack{{
+			AddedAt: addedAt,
+			Track: &spotifyclient.SpotifyTrack{
+				URI:        "spotify:track:1",
+				Name:       "Song One",
+				DurationMs: 180000,
+				Popularity: 70,
+				Artists:    []spotifyclient.SpotifyArtist{{Name: "Artist A"}, {Name: "Artist B"}},
+				Album:      spotifyclient.SpotifyAlbum{Name: "Album", ReleaseDate: "2020-01-01"},
+			},
+		}},
+		Next: &next,
+	}
+	spotifyClient.EXPECT().GetPlaylistTracks(ctx, "spotify_child", MAX_TRACKS, 0).Return(firstPage, nil)
+	spotifyClient.EXPECT().GetPlaylistTracks(ctx, "spotify_child", MAX_TRACKS, MAX_TRACKS).Return(playlistTracksPage("spotify:track:2"), nil)
+
+	var out bytes.Buffer
+	err := service.ExportChildPlaylist(ctx, "user123", "child1", models.ExportFormatJSON, &out)
+	assert.NoError(err)
+
+	var tracks []models.ExportedTrack
+	assert.NoError(json.Unmarshal(out.Bytes(), &tracks))
+	assert.Len(tracks, 2)
+	assert.Equal(models.ExportedTrack{
+		URI:         "spotify:track:1",
+		Name:        "Song One",
+		Artists:     []string{"Artist A", "Artist B"},
+		Album:       "Album",
+		ReleaseDate: "2020-01-01",
+		DurationMs:  180000,
+		Popularity:  70,
+		AddedAt:     &addedAt,
+	}, tracks[0])
+	assert.Equal("spotify:track:2", tracks[1].URI)
+	assert.Nil(tracks[1].AddedAt)
+}
+
+func TestChildPlaylistExportService_ExportChildPlaylist_NotFound(t *testing.T) {
+	assert := require.New(t)
+	service, _ := setupChildPlaylistExportService(t)
+
+	var out bytes.Buffer
+	err := service.ExportChildPlaylist(context.Background(), "user123", "missing", models.ExportFormatCSV, &out)
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+	assert.Zero(out.Len())
+}
+
+func TestChildPlaylistExportService_ExportChildPlaylist_TracksError(t *testing.T) {
+	assert := require.New(t)
+	service, spotifyClient := setupChildPlaylistExportService(t)
+	ctx := context.Background()
+
+	spotifyClient.EXPECT().GetPlaylistTracks(ctx, "spotify_child", MAX_TRACKS, 0).Return(nil, errors.New("spotify down"))
+
+	var out bytes.Buffer
+	err := service.ExportChildPlaylist(ctx, "user123", "child1", models.ExportFormatM3U, &out)
+	assert.ErrorContains(err, "failed to fetch child playlist tracks")
+	assert.Zero(out.Len())
+}
@@ -1,11 +1,20 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
 	"log/slog"
+	"reflect"
+	"slices"
 
-	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 )
@@ -15,17 +24,31 @@ import (
 type ChildPlaylistServicer interface {
 	CreateChildPlaylist(ctx context.Context, userID, basePlaylistID string, input *models.CreateChildPlaylistRequest) (*models.ChildPlaylist, error)
 	DeleteChildPlaylist(ctx context.Context, id, userID string) error
+	RestoreChildPlaylist(ctx context.Context, id, userID string) (*models.ChildPlaylist, error)
 	GetChildPlaylist(ctx context.Context, id, userID string) (*models.ChildPlaylist, error)
 	GetChildPlaylistsByBasePlaylistID(ctx context.Context, basePlaylistID, userID string) ([]*models.ChildPlaylist, error)
+	GetMergeChildPlaylistsBySourceBasePlaylistID(ctx context.Context, sourceBasePlaylistID, userID string) ([]*models.ChildPlaylist, error)
 	UpdateChildPlaylist(ctx context.Context, id, userID string, input *models.UpdateChildPlaylistRequest) (*models.ChildPlaylist, error)
 	UpdateChildPlaylistSpotifyID(ctx context.Context, id, userID, spotifyID string) (*models.ChildPlaylist, error)
+	MigrateChildPlaylistToInPlace(ctx context.Context, id, userID, spotifyID string) (*models.ChildPlaylist, error)
+	ReorderChildPlaylists(ctx context.Context, basePlaylistID, userID string, childPlaylistIDs []string) ([]*models.ChildPlaylist, error)
+	EnableSharing(ctx context.Context, id, userID string) (*models.ChildPlaylist, error)
+	DisableSharing(ctx context.Context, id, userID string) (*models.ChildPlaylist, error)
+	AddExclusion(ctx context.Context, id, userID string, field models.ExclusionField, value string) (*models.ChildPlaylist, error)
+	PinTracks(ctx context.Context, id, userID string, trackURIs []string) (*models.ChildPlaylist, error)
+	UnpinTrack(ctx context.Context, id, userID, trackURI string) (*models.ChildPlaylist, error)
+	UpdateChildPlaylistCover(ctx context.Context, id, userID string, jpegBytes []byte) error
 }
 
 type ChildPlaylistService struct {
 	childPlaylistRepo      repositories.ChildPlaylistRepository
 	basePlaylistRepo       repositories.BasePlaylistRepository
 	spotifyIntegrationRepo repositories.SpotifyIntegrationRepository
-	spotifyClient          spotifyclient.SpotifyAPI
+	musicProvider          musicprovider.MusicProvider
+	filterRuleChangeRepo   repositories.FilterRuleChangeRepository
+	spotifyAuth            SpotifyAuthProvider     // nil when playlists only use the account of the request
+	events                 EventPublisher          // nil when changes aren't pushed to the app
+	transactor             repositories.Transactor // nil when multi-step writes aren't transactional
 	logger                 *slog.Logger
 }
 
@@ -33,33 +56,83 @@ func NewChildPlaylistService(
 	childPlaylistRepo repositories.ChildPlaylistRepository,
 	basePlaylistRepo repositories.BasePlaylistRepository,
 	spotifyIntegrationRepo repositories.SpotifyIntegrationRepository,
-	spotifyClient spotifyclient.SpotifyAPI,
+	musicProvider musicprovider.MusicProvider,
+	filterRuleChangeRepo repositories.FilterRuleChangeRepository,
 	logger *slog.Logger,
 ) *ChildPlaylistService {
 	return &ChildPlaylistService{
 		childPlaylistRepo:      childPlaylistRepo,
 		basePlaylistRepo:       basePlaylistRepo,
 		spotifyIntegrationRepo: spotifyIntegrationRepo,
-		spotifyClient:          spotifyClient,
+		musicProvider:          musicProvider,
+		filterRuleChangeRepo:   filterRuleChangeRepo,
 		logger:                 logger.With("component", "ChildPlaylistService"),
 	}
 }
 
+// WithSpotifyAuth lets child playlists live in a spotify account other than the one of the request,
+// loading its credentials through spotifyAuth
+func (cpService *ChildPlaylistService) WithSpotifyAuth(spotifyAuth SpotifyAuthProvider) *ChildPlaylistService {
+	cpService.spotifyAuth = spotifyAuth
+	return cpService
+}
+
+// WithEvents pushes the created, updated and deleted child playlists to the app through events
+func (cpService *ChildPlaylistService) WithEvents(events EventPublisher) *ChildPlaylistService {
+	cpService.events = events
+	return cpService
+}
+
+// WithTransactor commits the writes of a multi-step change together, so a failure halfway through
+// doesn't leave the child playlists of a base playlist half updated
+func (cpService *ChildPlaylistService) WithTransactor(transactor repositories.Transactor) *ChildPlaylistService {
+	cpService.transactor = transactor
+	return cpService
+}
+
 func (cpService *ChildPlaylistService) CreateChildPlaylist(ctx context.Context, userID, basePlaylistID string, input *models.CreateChildPlaylistRequest) (*models.ChildPlaylist, error) {
 	cpService.logger.InfoContext(ctx, "creating child playlist", "user_id", userID, "base_playlist_id", basePlaylistID, "input", input)
 
+	if err := input.FilterRules.ResolveDurations(); err != nil {
+		return nil, fmt.Errorf("invalid filter rules: %w", err)
+	}
+
 	basePlaylist, err := cpService.basePlaylistRepo.GetByID(ctx, basePlaylistID, userID)
 	if err != nil {
 		cpService.logger.ErrorContext(ctx, "failed to get base playlist", "base_playlist_id", basePlaylistID, "user_id", userID, "error", err.Error())
 		return nil, fmt.Errorf("failed to get base playlist: %w", err)
 	}
 
+	sourceBasePlaylistIDs, err := cpService.resolveSourceBasePlaylists(ctx, userID, basePlaylistID, input.SourceBasePlaylistIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// New child playlists get the lowest routing priority of their base playlist
+	siblings, err := cpService.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylistID, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to get sibling child playlists", "base_playlist_id", basePlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get child playlists: %w", err)
+	}
+
+	// Child playlists are created in the account of their base playlist unless one is picked
+	spotifyIntegrationID := input.SpotifyIntegrationID
+	if spotifyIntegrationID == "" {
+		spotifyIntegrationID = basePlaylist.SpotifyIntegrationID
+	}
+
+	accountCtx, err := contextWithPlaylistAccount(ctx, cpService.spotifyAuth, userID, basePlaylist.Provider, spotifyIntegrationID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to load spotify account", "spotify_integration_id", spotifyIntegrationID, "error", err.Error())
+		return nil, fmt.Errorf("failed to load spotify account: %w", err)
+	}
+
 	// Create playlist in Spotify with naming format: [Base Name] > Child Name
 	spotifyPlaylistName := models.BuildChildPlaylistName(basePlaylist.Name, input.Name)
 	cpService.logger.InfoContext(ctx, "creating spotify playlist", "spotify_name", spotifyPlaylistName)
 
-	spotifyPlaylist, err := cpService.spotifyClient.CreatePlaylist(
-		ctx,
+	spotifyPlaylist, err := cpService.musicProvider.CreatePlaylist(
+		accountCtx,
 		spotifyPlaylistName,
 		models.BuildChildPlaylistDescription(input.Description),
 		false, // private by default
@@ -73,21 +146,43 @@ func (cpService *ChildPlaylistService) CreateChildPlaylist(ctx context.Context,
 
 	// Create the child playlist record in our database
 	fields := repositories.CreateChildPlaylistFields{
-		UserID:            userID,
-		BasePlaylistID:    basePlaylistID,
-		Name:              input.Name,
-		Description:       input.Description,
-		SpotifyPlaylistID: spotifyPlaylist.ID,
-		FilterRules:       input.FilterRules,
-		IsActive:          true,
+		UserID:                userID,
+		BasePlaylistID:        basePlaylistID,
+		Name:                  input.Name,
+		Description:           input.Description,
+		SpotifyPlaylistID:     spotifyPlaylist.ID,
+		FilterRules:           input.FilterRules,
+		IsActive:              true,
+		IsFallback:            input.IsFallback,
+		Priority:              nextChildPlaylistPriority(siblings),
+		MaxTracks:             input.MaxTracks,
+		SelectionStrategy:     input.SelectionStrategy,
+		SourceBasePlaylistIDs: sourceBasePlaylistIDs,
+		RefollowRecreated:     input.RefollowRecreated,
+		SpotifyIntegrationID:  spotifyIntegrationID,
+		Provider:              basePlaylist.Provider,
 	}
-	childPlaylist, err := cpService.childPlaylistRepo.Create(ctx, fields)
+	var childPlaylist *models.ChildPlaylist
+	err = runInTransaction(ctx, cpService.transactor, func(ctx context.Context) error {
+		childPlaylist, err = cpService.childPlaylistRepo.Create(ctx, fields)
+		if err != nil {
+			cpService.logger.ErrorContext(ctx, "failed to create child playlist", "error", err.Error())
+			return fmt.Errorf("failed to create child playlist: %w", err)
+		}
+
+		if childPlaylist.IsFallback {
+			return cpService.unsetOtherFallbacks(ctx, childPlaylist, siblings)
+		}
+		return nil
+	})
 	if err != nil {
-		cpService.logger.ErrorContext(ctx, "failed to create child playlist", "error", err.Error())
-		return nil, fmt.Errorf("failed to create child playlist: %w", err)
+		// Without its record the spotify playlist would be left behind, never synced nor deleted
+		cpService.deleteOrphanedSpotifyPlaylist(accountCtx, spotifyPlaylist.ID)
+		return nil, err
 	}
 
 	cpService.logger.InfoContext(ctx, "child playlist created successfully", "child_playlist", childPlaylist)
+	publishEvent(cpService.events, userID, models.NewChildPlaylistEvent(models.AppEventPlaylistCreated, childPlaylist))
 	return childPlaylist, nil
 }
 
@@ -101,8 +196,13 @@ func (cpService *ChildPlaylistService) DeleteChildPlaylist(ctx context.Context,
 		return fmt.Errorf("failed to get child playlist: %w", err)
 	}
 
+	accountCtx, err := contextWithPlaylistAccount(ctx, cpService.spotifyAuth, userID, childPlaylist.Provider, childPlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return fmt.Errorf("failed to load spotify account: %w", err)
+	}
+
 	// Delete from Spotify first
-	err = cpService.spotifyClient.DeletePlaylist(ctx, childPlaylist.SpotifyPlaylistID)
+	err = cpService.musicProvider.DeletePlaylist(accountCtx, childPlaylist.SpotifyPlaylistID)
 	if err != nil {
 		cpService.logger.ErrorContext(ctx, "failed to delete playlist from spotify", "spotify_playlist_id", childPlaylist.SpotifyPlaylistID, "error", err.Error())
 		return fmt.Errorf("failed to delete spotify playlist: %w", err)
@@ -118,6 +218,46 @@ func (cpService *ChildPlaylistService) DeleteChildPlaylist(ctx context.Context,
 	}
 
 	cpService.logger.InfoContext(ctx, "child playlist deleted successfully", "id", id, "user_id", userID)
+	publishEvent(cpService.events, userID, models.NewPlaylistDeletedEvent(models.PlaylistKindChild, id, childPlaylist.BasePlaylistID))
+	return nil
+}
+
+// RestoreChildPlaylist brings back a deleted child playlist and follows its spotify playlist again.
+// Child playlists of a deleted base playlist come back by restoring the base playlist
+func (cpService *ChildPlaylistService) RestoreChildPlaylist(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "restoring child playlist", "id", id, "user_id", userID)
+
+	childPlaylist, err := cpService.childPlaylistRepo.Restore(ctx, id, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to restore child playlist", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to restore child playlist: %w", err)
+	}
+
+	if err := cpService.followRestoredPlaylist(ctx, userID, childPlaylist); err != nil {
+		// Keep the child playlist deleted so the restore can be retried
+		if deleteErr := cpService.childPlaylistRepo.Delete(ctx, id, userID); deleteErr != nil {
+			cpService.logger.ErrorContext(ctx, "failed to roll back child playlist restore", "id", id, "error", deleteErr.Error())
+		}
+		return nil, err
+	}
+
+	cpService.logger.InfoContext(ctx, "child playlist restored successfully", "child_playlist", childPlaylist)
+	publishEvent(cpService.events, userID, models.NewChildPlaylistEvent(models.AppEventPlaylistCreated, childPlaylist))
+	return childPlaylist, nil
+}
+
+// followRestoredPlaylist adds the spotify playlist unfollowed on delete back to the library of the user
+func (cpService *ChildPlaylistService) followRestoredPlaylist(ctx context.Context, userID string, childPlaylist *models.ChildPlaylist) error {
+	accountCtx, err := contextWithPlaylistAccount(ctx, cpService.spotifyAuth, userID, childPlaylist.Provider, childPlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return fmt.Errorf("failed to load spotify account: %w", err)
+	}
+
+	if err := cpService.musicProvider.FollowPlaylist(accountCtx, childPlaylist.SpotifyPlaylistID, false); err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to follow restored spotify playlist", "spotify_playlist_id", childPlaylist.SpotifyPlaylistID, "error", err.Error())
+		return fmt.Errorf("failed to follow spotify playlist: %w", err)
+	}
+
 	return nil
 }
 
@@ -147,15 +287,61 @@ func (cpService *ChildPlaylistService) GetChildPlaylistsByBasePlaylistID(ctx con
 	return childPlaylists, nil
 }
 
+// GetMergeChildPlaylistsBySourceBasePlaylistID returns the child playlists of other base playlists that
+// merge the given one
+func (cpService *ChildPlaylistService) GetMergeChildPlaylistsBySourceBasePlaylistID(ctx context.Context, sourceBasePlaylistID, userID string) ([]*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "retrieving merge child playlists", "source_base_playlist_id", sourceBasePlaylistID, "user_id", userID)
+
+	childPlaylists, err := cpService.childPlaylistRepo.GetBySourceBasePlaylistID(ctx, sourceBasePlaylistID, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to retrieve merge child playlists", "source_base_playlist_id", sourceBasePlaylistID, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve merge child playlists: %w", err)
+	}
+
+	cpService.logger.InfoContext(ctx, "merge child playlists retrieved successfully", "source_base_playlist_id", sourceBasePlaylistID, "count", len(childPlaylists))
+	return childPlaylists, nil
+}
+
 func (cpService *ChildPlaylistService) UpdateChildPlaylist(ctx context.Context, id, userID string, input *models.UpdateChildPlaylistRequest) (*models.ChildPlaylist, error) {
 	cpService.logger.InfoContext(ctx, "updating child playlist", "id", id, "user_id", userID, "input", input)
 
+	if err := input.FilterRules.ResolveDurations(); err != nil {
+		return nil, fmt.Errorf("invalid filter rules: %w", err)
+	}
+
+	// Keep the current filter rules so the change can be recorded in the rule history, the base
+	// playlist is needed to validate the merged ones
+	var previousChildPlaylist *models.ChildPlaylist
+	if input.FilterRules != nil || input.SourceBasePlaylistIDs != nil {
+		current, err := cpService.childPlaylistRepo.GetByID(ctx, id, userID)
+		if err != nil {
+			cpService.logger.ErrorContext(ctx, "failed to get child playlist", "id", id, "user_id", userID, "error", err.Error())
+			return nil, fmt.Errorf("failed to get child playlist: %w", err)
+		}
+		previousChildPlaylist = current
+	}
+
+	var sourceBasePlaylistIDs *[]string
+	if input.SourceBasePlaylistIDs != nil {
+		resolved, err := cpService.resolveSourceBasePlaylists(ctx, userID, previousChildPlaylist.BasePlaylistID, *input.SourceBasePlaylistIDs)
+		if err != nil {
+			return nil, err
+		}
+		sourceBasePlaylistIDs = &resolved
+	}
+
 	// Update the child playlist in our database first
 	updateFields := repositories.UpdateChildPlaylistFields{
-		Name:        input.Name,
-		Description: input.Description,
-		IsActive:    input.IsActive,
-		FilterRules: input.FilterRules,
+		Name:                  input.Name,
+		Description:           input.Description,
+		IsActive:              input.IsActive,
+		IsFallback:            input.IsFallback,
+		FilterRules:           input.FilterRules,
+		MaxTracks:             input.MaxTracks,
+		SelectionStrategy:     input.SelectionStrategy,
+		SourceBasePlaylistIDs: sourceBasePlaylistIDs,
+		RefollowRecreated:     input.RefollowRecreated,
+		ExpectedUpdated:       input.ExpectedUpdated,
 	}
 	updatedChildPlaylist, err := cpService.childPlaylistRepo.Update(ctx, id, userID, updateFields)
 	if err != nil {
@@ -163,6 +349,22 @@ func (cpService *ChildPlaylistService) UpdateChildPlaylist(ctx context.Context,
 		return nil, fmt.Errorf("failed to update child playlist: %w", err)
 	}
 
+	if input.FilterRules != nil {
+		cpService.recordFilterRuleChange(ctx, previousChildPlaylist, updatedChildPlaylist)
+	}
+
+	if input.IsFallback != nil && *input.IsFallback {
+		siblings, err := cpService.childPlaylistRepo.GetByBasePlaylistID(ctx, updatedChildPlaylist.BasePlaylistID, userID)
+		if err != nil {
+			cpService.logger.ErrorContext(ctx, "failed to get sibling child playlists", "base_playlist_id", updatedChildPlaylist.BasePlaylistID, "error", err.Error())
+			return nil, fmt.Errorf("failed to get child playlists: %w", err)
+		}
+
+		if err := cpService.unsetOtherFallbacks(ctx, updatedChildPlaylist, siblings); err != nil {
+			return nil, err
+		}
+	}
+
 	spotifyUpdate := struct {
 		name         string
 		description  string
@@ -185,9 +387,14 @@ func (cpService *ChildPlaylistService) UpdateChildPlaylist(ctx context.Context,
 	}
 
 	if spotifyUpdate.shouldUpdate {
+		accountCtx, err := contextWithPlaylistAccount(ctx, cpService.spotifyAuth, userID, updatedChildPlaylist.Provider, updatedChildPlaylist.SpotifyIntegrationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load spotify account: %w", err)
+		}
+
 		// Update Spotify playlist metadata
-		err = cpService.spotifyClient.UpdatePlaylist(
-			ctx,
+		err = cpService.musicProvider.UpdatePlaylist(
+			accountCtx,
 			updatedChildPlaylist.SpotifyPlaylistID,
 			spotifyUpdate.name,
 			spotifyUpdate.description,
@@ -205,6 +412,7 @@ func (cpService *ChildPlaylistService) UpdateChildPlaylist(ctx context.Context,
 	}
 
 	cpService.logger.InfoContext(ctx, "child playlist updated successfully", "child_playlist", updatedChildPlaylist)
+	publishEvent(cpService.events, userID, models.NewChildPlaylistEvent(models.AppEventPlaylistUpdated, updatedChildPlaylist))
 	return updatedChildPlaylist, nil
 }
 
@@ -222,3 +430,336 @@ func (cpService *ChildPlaylistService) UpdateChildPlaylistSpotifyID(ctx context.
 	cpService.logger.InfoContext(ctx, "child playlist updated successfully", "child_playlist", updatedChildPlaylist)
 	return updatedChildPlaylist, nil
 }
+
+// MigrateChildPlaylistToInPlace points the child playlist at the spotify playlist it will keep from
+// now on and switches it to in place syncing
+func (cpService *ChildPlaylistService) MigrateChildPlaylistToInPlace(ctx context.Context, id, userID, spotifyID string) (*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "migrating child playlist to in place sync", "id", id, "user_id", userID, "spotify_id", spotifyID)
+
+	strategy := models.SyncStrategyInPlace
+	updateFields := repositories.UpdateChildPlaylistFields{SpotifyPlaylistID: &spotifyID, SyncStrategy: &strategy}
+
+	updatedChildPlaylist, err := cpService.childPlaylistRepo.Update(ctx, id, userID, updateFields)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to migrate child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to update child playlist: %w", err)
+	}
+
+	cpService.logger.InfoContext(ctx, "child playlist migrated successfully", "child_playlist", updatedChildPlaylist)
+	return updatedChildPlaylist, nil
+}
+
+// ReorderChildPlaylists sets the routing priority of every child playlist of the base playlist
+// from the position of its ID in childPlaylistIDs, first being the highest priority
+func (cpService *ChildPlaylistService) ReorderChildPlaylists(ctx context.Context, basePlaylistID, userID string, childPlaylistIDs []string) ([]*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "reordering child playlists", "base_playlist_id", basePlaylistID, "user_id", userID, "child_playlist_ids", childPlaylistIDs)
+
+	childPlaylists, err := cpService.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylistID, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to retrieve child playlists for reorder", "base_playlist_id", basePlaylistID, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve child playlists: %w", err)
+	}
+
+	childPlaylistsByID := make(map[string]*models.ChildPlaylist, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		childPlaylistsByID[childPlaylist.ID] = childPlaylist
+	}
+
+	if len(childPlaylistIDs) != len(childPlaylists) {
+		return nil, ErrInvalidChildPlaylistOrder
+	}
+
+	seen := make(map[string]bool, len(childPlaylistIDs))
+	for _, id := range childPlaylistIDs {
+		if _, ok := childPlaylistsByID[id]; !ok || seen[id] {
+			return nil, ErrInvalidChildPlaylistOrder
+		}
+		seen[id] = true
+	}
+
+	// The priorities are written together, a failed update doesn't leave two children with the same one
+	var reordered []*models.ChildPlaylist
+	err = runInTransaction(ctx, cpService.transactor, func(ctx context.Context) error {
+		reordered = make([]*models.ChildPlaylist, 0, len(childPlaylistIDs))
+		for priority, id := range childPlaylistIDs {
+			childPlaylist := childPlaylistsByID[id]
+			if childPlaylist.Priority != priority {
+				childPlaylist, err = cpService.childPlaylistRepo.Update(ctx, id, userID, repositories.UpdateChildPlaylistFields{Priority: &priority})
+				if err != nil {
+					cpService.logger.ErrorContext(ctx, "failed to update child playlist priority", "id", id, "priority", priority, "error", err.Error())
+					return fmt.Errorf("failed to update child playlist: %w", err)
+				}
+			}
+
+			reordered = append(reordered, childPlaylist)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, childPlaylist := range reordered {
+		publishEvent(cpService.events, userID, models.NewChildPlaylistEvent(models.AppEventPlaylistUpdated, childPlaylist))
+	}
+
+	cpService.logger.InfoContext(ctx, "child playlists reordered successfully", "base_playlist_id", basePlaylistID, "user_id", userID, "count", len(reordered))
+	return reordered, nil
+}
+
+// EnableSharing issues a new share token for the child playlist, revoking any previously shared link
+func (cpService *ChildPlaylistService) EnableSharing(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "enabling sharing for child playlist", "id", id, "user_id", userID)
+
+	shareToken, err := generateSecretToken()
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to generate share token", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	childPlaylist, err := cpService.childPlaylistRepo.Update(ctx, id, userID, repositories.UpdateChildPlaylistFields{ShareToken: &shareToken})
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to enable sharing for child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to enable sharing: %w", err)
+	}
+
+	cpService.logger.InfoContext(ctx, "sharing enabled for child playlist", "id", id, "user_id", userID)
+	publishEvent(cpService.events, userID, models.NewChildPlaylistEvent(models.AppEventPlaylistUpdated, childPlaylist))
+	return childPlaylist, nil
+}
+
+// DisableSharing removes the share token so the public widget stops resolving
+func (cpService *ChildPlaylistService) DisableSharing(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "disabling sharing for child playlist", "id", id, "user_id", userID)
+
+	emptyToken := ""
+	childPlaylist, err := cpService.childPlaylistRepo.Update(ctx, id, userID, repositories.UpdateChildPlaylistFields{ShareToken: &emptyToken})
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to disable sharing for child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to disable sharing: %w", err)
+	}
+
+	cpService.logger.InfoContext(ctx, "sharing disabled for child playlist", "id", id, "user_id", userID)
+	publishEvent(cpService.events, userID, models.NewChildPlaylistEvent(models.AppEventPlaylistUpdated, childPlaylist))
+	return childPlaylist, nil
+}
+
+// AddExclusion excludes a value in one of the set filters of the child playlist rules, going
+// through UpdateChildPlaylist so the edit is recorded in the rule history
+func (cpService *ChildPlaylistService) AddExclusion(ctx context.Context, id, userID string, field models.ExclusionField, value string) (*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "adding exclusion to child playlist", "id", id, "user_id", userID, "field", field, "value", value)
+
+	childPlaylist, err := cpService.childPlaylistRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to get child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get child playlist: %w", err)
+	}
+
+	filterRules, err := childPlaylist.FilterRules.WithExclusion(field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return cpService.UpdateChildPlaylist(ctx, id, userID, &models.UpdateChildPlaylistRequest{FilterRules: filterRules})
+}
+
+// PinTracks adds tracks to the pinned tracks of the child playlist, skipping the ones already pinned
+func (cpService *ChildPlaylistService) PinTracks(ctx context.Context, id, userID string, trackURIs []string) (*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "pinning tracks to child playlist", "id", id, "user_id", userID, "tracks", len(trackURIs))
+
+	childPlaylist, err := cpService.childPlaylistRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to get child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get child playlist: %w", err)
+	}
+
+	pinnedTracks := slices.Clone(childPlaylist.PinnedTracks)
+	for _, trackURI := range trackURIs {
+		if !slices.Contains(pinnedTracks, trackURI) {
+			pinnedTracks = append(pinnedTracks, trackURI)
+		}
+	}
+
+	if len(pinnedTracks) > models.MAX_PINNED_TRACKS {
+		return nil, ErrTooManyPinnedTracks
+	}
+
+	return cpService.updatePinnedTracks(ctx, id, userID, pinnedTracks)
+}
+
+// UnpinTrack removes a track from the pinned tracks of the child playlist. Unpinning a track that
+// isn't pinned leaves the child playlist as is
+func (cpService *ChildPlaylistService) UnpinTrack(ctx context.Context, id, userID, trackURI string) (*models.ChildPlaylist, error) {
+	cpService.logger.InfoContext(ctx, "unpinning track from child playlist", "id", id, "user_id", userID, "track_uri", trackURI)
+
+	childPlaylist, err := cpService.childPlaylistRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to get child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get child playlist: %w", err)
+	}
+
+	if !slices.Contains(childPlaylist.PinnedTracks, trackURI) {
+		return childPlaylist, nil
+	}
+
+	pinnedTracks := slices.DeleteFunc(slices.Clone(childPlaylist.PinnedTracks), func(uri string) bool {
+		return uri == trackURI
+	})
+
+	return cpService.updatePinnedTracks(ctx, id, userID, pinnedTracks)
+}
+
+func (cpService *ChildPlaylistService) updatePinnedTracks(ctx context.Context, id, userID string, pinnedTracks []string) (*models.ChildPlaylist, error) {
+	childPlaylist, err := cpService.childPlaylistRepo.Update(ctx, id, userID, repositories.UpdateChildPlaylistFields{PinnedTracks: &pinnedTracks})
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to update pinned tracks of child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to update pinned tracks: %w", err)
+	}
+
+	cpService.logger.InfoContext(ctx, "pinned tracks of child playlist updated", "id", id, "user_id", userID, "pinned_tracks", len(pinnedTracks))
+	publishEvent(cpService.events, userID, models.NewChildPlaylistEvent(models.AppEventPlaylistUpdated, childPlaylist))
+	return childPlaylist, nil
+}
+
+// UpdateChildPlaylistCover uploads the cover image of the Spotify playlist of the child. Without an image
+// a solid color cover is generated from the filter rules, so children sharing rules share a color
+func (cpService *ChildPlaylistService) UpdateChildPlaylistCover(ctx context.Context, id, userID string, jpegBytes []byte) error {
+	cpService.logger.InfoContext(ctx, "updating child playlist cover", "id", id, "user_id", userID, "generated", len(jpegBytes) == 0)
+
+	childPlaylist, err := cpService.childPlaylistRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to get child playlist", "id", id, "user_id", userID, "error", err.Error())
+		return fmt.Errorf("failed to get child playlist: %w", err)
+	}
+
+	if len(jpegBytes) == 0 {
+		jpegBytes, err = generateChildPlaylistCover(childPlaylist)
+		if err != nil {
+			cpService.logger.ErrorContext(ctx, "failed to generate child playlist cover", "id", id, "error", err.Error())
+			return fmt.Errorf("failed to generate cover: %w", err)
+		}
+	}
+
+	accountCtx, err := contextWithPlaylistAccount(ctx, cpService.spotifyAuth, userID, childPlaylist.Provider, childPlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return fmt.Errorf("failed to load spotify account: %w", err)
+	}
+
+	if err := cpService.musicProvider.UploadPlaylistCover(accountCtx, childPlaylist.SpotifyPlaylistID, jpegBytes); err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to upload child playlist cover", "spotify_playlist_id", childPlaylist.SpotifyPlaylistID, "error", err.Error())
+		return fmt.Errorf("failed to upload cover: %w", err)
+	}
+
+	cpService.logger.InfoContext(ctx, "child playlist cover updated", "id", id, "user_id", userID)
+	return nil
+}
+
+// deleteOrphanedSpotifyPlaylist deletes a spotify playlist created for a child playlist that couldn't
+// be stored. Failures are only logged, the error of the store is the one returned
+func (cpService *ChildPlaylistService) deleteOrphanedSpotifyPlaylist(accountCtx context.Context, spotifyPlaylistID string) {
+	if err := cpService.musicProvider.DeletePlaylist(accountCtx, spotifyPlaylistID); err != nil {
+		cpService.logger.ErrorContext(accountCtx, "failed to delete orphaned spotify playlist", "spotify_playlist_id", spotifyPlaylistID, "error", err.Error())
+		return
+	}
+
+	cpService.logger.InfoContext(accountCtx, "deleted orphaned spotify playlist", "spotify_playlist_id", spotifyPlaylistID)
+}
+
+// unsetOtherFallbacks keeps fallbackChild as the only fallback child playlist of its base playlist
+func (cpService *ChildPlaylistService) unsetOtherFallbacks(ctx context.Context, fallbackChild *models.ChildPlaylist, siblings []*models.ChildPlaylist) error {
+	isFallback := false
+	for _, sibling := range siblings {
+		if sibling.ID == fallbackChild.ID || !sibling.IsFallback {
+			continue
+		}
+
+		if _, err := cpService.childPlaylistRepo.Update(ctx, sibling.ID, fallbackChild.UserID, repositories.UpdateChildPlaylistFields{IsFallback: &isFallback}); err != nil {
+			cpService.logger.ErrorContext(ctx, "failed to unset previous fallback child playlist", "id", sibling.ID, "error", err.Error())
+			return fmt.Errorf("failed to update child playlist: %w", err)
+		}
+
+		cpService.logger.InfoContext(ctx, "previous fallback child playlist unset", "id", sibling.ID, "fallback_child_playlist_id", fallbackChild.ID)
+	}
+
+	return nil
+}
+
+// resolveSourceBasePlaylists checks the base playlists merged into a child belong to the user. The
+// base playlist of the child and repeated ones are dropped, it already feeds the child
+func (cpService *ChildPlaylistService) resolveSourceBasePlaylists(ctx context.Context, userID, basePlaylistID string, sourceBasePlaylistIDs []string) ([]string, error) {
+	var resolved []string
+	for _, sourceID := range sourceBasePlaylistIDs {
+		if sourceID == basePlaylistID || slices.Contains(resolved, sourceID) {
+			continue
+		}
+
+		if _, err := cpService.basePlaylistRepo.GetByID(ctx, sourceID, userID); err != nil {
+			cpService.logger.ErrorContext(ctx, "failed to get source base playlist", "source_base_playlist_id", sourceID, "user_id", userID, "error", err.Error())
+			return nil, fmt.Errorf("failed to get source base playlist %s: %w", sourceID, err)
+		}
+
+		resolved = append(resolved, sourceID)
+	}
+
+	return resolved, nil
+}
+
+func nextChildPlaylistPriority(childPlaylists []*models.ChildPlaylist) int {
+	next := 0
+	for _, childPlaylist := range childPlaylists {
+		if childPlaylist.Priority >= next {
+			next = childPlaylist.Priority + 1
+		}
+	}
+
+	return next
+}
+
+// recordFilterRuleChange stores a rule history entry when the filter rules of a child playlist changed.
+// The routing diff of the entry is computed later, on the next sync of the base playlist or on demand.
+// Failures are only logged since the child playlist update itself already succeeded.
+func (cpService *ChildPlaylistService) recordFilterRuleChange(ctx context.Context, previous, updated *models.ChildPlaylist) {
+	if reflect.DeepEqual(previous.FilterRules, updated.FilterRules) {
+		return
+	}
+
+	change, err := cpService.filterRuleChangeRepo.Create(ctx, &models.FilterRuleChange{
+		UserID:              updated.UserID,
+		BasePlaylistID:      updated.BasePlaylistID,
+		ChildPlaylistID:     updated.ID,
+		PreviousFilterRules: previous.FilterRules,
+		NewFilterRules:      updated.FilterRules,
+	})
+	if err != nil {
+		cpService.logger.ErrorContext(ctx, "failed to record filter rule change", "child_playlist_id", updated.ID, "error", err.Error())
+		return
+	}
+
+	cpService.logger.InfoContext(ctx, "filter rule change recorded", "child_playlist_id", updated.ID, "filter_rule_change_id", change.ID)
+}
+
+// generateChildPlaylistCover renders a square JPEG filled with a color derived from the filter rules
+func generateChildPlaylistCover(childPlaylist *models.ChildPlaylist) ([]byte, error) {
+	rules, err := json.Marshal(childPlaylist.FilterRules)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := fnv.New32a()
+	hash.Write(rules)
+	sum := hash.Sum32()
+
+	// Keep the channels in the upper half so the playlist name stays readable over the cover
+	fill := color.RGBA{R: 128 + uint8(sum)%128, G: 128 + uint8(sum>>8)%128, B: 128 + uint8(sum>>16)%128, A: 255}
+
+	const size = 300
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: fill}, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
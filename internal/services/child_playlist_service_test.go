@@ -1,9 +1,13 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"image/jpeg"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
@@ -11,6 +15,7 @@ import (
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 	repoMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,7 +39,7 @@ func TestNewChildPlaylistService(t *testing.T) {
 	assert.Equal(mockChildRepo, service.childPlaylistRepo)
 	assert.Equal(mockBaseRepo, service.basePlaylistRepo)
 	assert.Equal(mockSpotifyIntegrationRepo, service.spotifyIntegrationRepo)
-	assert.Equal(mockSpotifyClient, service.spotifyClient)
+	assert.Equal(mockSpotifyClient, service.musicProvider)
 	assert.NotNil(service.logger)
 }
 
@@ -57,25 +62,26 @@ func TestChildPlaylistService_CreateChildPlaylist_Success(t *testing.T) {
 		Description: "Child playlist description.",
 		FilterRules: &models.AudioFeatureFilters{},
 	}
-	basePlaylist := &models.BasePlaylist{
-		ID:   basePlaylistID,
-		Name: "Base Playlist Name",
-	}
+	basePlaylist := testfixtures.NewBasePlaylist().WithID(basePlaylistID).WithName("Base Playlist Name").Build()
 	spotifyPlaylist := &spotifyclient.SpotifyPlaylist{
 		ID:   "new_spotify_playlist_id",
 		Name: models.BuildChildPlaylistName(basePlaylist.Name, input.Name),
 	}
-	expectedChildPlaylist := &models.ChildPlaylist{
-		ID:                "childPlaylist789",
-		UserID:            userID,
-		BasePlaylistID:    basePlaylistID,
-		Name:              input.Name,
-		Description:       input.Description,
-		SpotifyPlaylistID: spotifyPlaylist.ID,
-	}
+	expectedChildPlaylist := testfixtures.NewChildPlaylist().
+		WithID("childPlaylist789").
+		WithUserID(userID).
+		WithBasePlaylistID(basePlaylistID).
+		WithName(input.Name).
+		WithDescription(input.Description).
+		WithSpotifyPlaylistID(spotifyPlaylist.ID).
+		Build()
 
 	// Mock Calls
 	mockBaseRepo.EXPECT().GetByID(gomock.Any(), basePlaylistID, userID).Return(basePlaylist, nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), basePlaylistID, userID).Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("sibling1").WithPriority(0).Build(),
+		testfixtures.NewChildPlaylist().WithID("sibling2").WithPriority(3).Build(),
+	}, nil)
 	expectedPlaylistName := models.BuildChildPlaylistName(basePlaylist.Name, input.Name)
 	expectedDescription := models.BuildChildPlaylistDescription(input.Description)
 	mockSpotifyClient.EXPECT().CreatePlaylist(
@@ -94,6 +100,7 @@ func TestChildPlaylistService_CreateChildPlaylist_Success(t *testing.T) {
 			SpotifyPlaylistID: spotifyPlaylist.ID,
 			FilterRules:       input.FilterRules,
 			IsActive:          true,
+			Priority:          4,
 		},
 	).Return(expectedChildPlaylist, nil)
 
@@ -106,6 +113,185 @@ func TestChildPlaylistService_CreateChildPlaylist_Success(t *testing.T) {
 	assert.Equal(expectedChildPlaylist, result)
 }
 
+func TestChildPlaylistService_CreateChildPlaylist_Fallback(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient)
+
+	createdChildPlaylist := testfixtures.NewChildPlaylist().
+		WithID("cp_new").
+		WithUserID("uid").
+		WithBasePlaylistID("bpid").
+		Fallback().
+		Build()
+	notFallback := false
+
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bpid", "uid").Return(testfixtures.NewBasePlaylist().WithName("Base").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bpid", "uid").Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("cp_previous").Fallback().WithPriority(0).Build(),
+	}, nil)
+	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&spotifyclient.SpotifyPlaylist{ID: "sp_id"}, nil)
+	mockChildRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fields repositories.CreateChildPlaylistFields) (*models.ChildPlaylist, error) {
+			assert.True(fields.IsFallback)
+			assert.Equal(1, fields.Priority)
+			return createdChildPlaylist, nil
+		})
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp_previous", "uid", repositories.UpdateChildPlaylistFields{IsFallback: &notFallback}).
+		Return(testfixtures.NewChildPlaylist().WithID("cp_previous").Build(), nil)
+
+	result, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{Name: "Everything else", IsFallback: true})
+
+	assert.NoError(err)
+	assert.Equal(createdChildPlaylist, result)
+}
+
+func TestChildPlaylistService_CreateChildPlaylist_SpotifyAccount(t *testing.T) {
+	tests := []struct {
+		name                string
+		inputIntegrationID  string
+		expectedIntegration string
+	}{
+		{
+			name:                "defaults to the account of the base playlist",
+			expectedIntegration: "integration_personal",
+		},
+		{
+			name:                "picked account",
+			inputIntegrationID:  "integration_dj",
+			expectedIntegration: "integration_dj",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctrl := setupMockController(t)
+
+			mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+			mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+			mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+			spotifyAuth := &fakeSpotifyAuthProvider{}
+			service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient).WithSpotifyAuth(spotifyAuth)
+
+			basePlaylist := testfixtures.NewBasePlaylist().WithName("Base").WithSpotifyIntegrationID("integration_personal").Build()
+			mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bpid", "uid").Return(basePlaylist, nil)
+			mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bpid", "uid").Return(nil, nil)
+			mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&spotifyclient.SpotifyPlaylist{ID: "sp_id"}, nil)
+			mockChildRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fields repositories.CreateChildPlaylistFields) (*models.ChildPlaylist, error) {
+					assert.Equal(tt.expectedIntegration, fields.SpotifyIntegrationID)
+					return testfixtures.NewChildPlaylist().WithSpotifyIntegrationID(fields.SpotifyIntegrationID).Build(), nil
+				})
+
+			_, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{
+				Name:                 "Sets",
+				SpotifyIntegrationID: tt.inputIntegrationID,
+			})
+
+			assert.NoError(err)
+			assert.Equal([]string{tt.expectedIntegration}, spotifyAuth.integrationIDs)
+		})
+	}
+}
+
+func TestChildPlaylistService_CreateChildPlaylist_ResolvesDurations(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient)
+
+	shortPreset := models.DurationPresetShort
+	input := &models.CreateChildPlaylistRequest{
+		Name:        "Short songs",
+		FilterRules: &models.MetadataFilters{DurationRange: &models.DurationFilter{Preset: &shortPreset}},
+	}
+
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bpid", "uid").Return(testfixtures.NewBasePlaylist().WithName("Base").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bpid", "uid").Return(nil, nil)
+	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&spotifyclient.SpotifyPlaylist{ID: "sp_id"}, nil)
+	mockChildRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fields repositories.CreateChildPlaylistFields) (*models.ChildPlaylist, error) {
+			assert.Nil(fields.FilterRules.DurationRange)
+			assert.Nil(fields.FilterRules.Duration.Min)
+			assert.Equal(180000.0, *fields.FilterRules.Duration.Max)
+			return testfixtures.NewChildPlaylist().WithID("cp_new").Build(), nil
+		})
+
+	_, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", input)
+
+	assert.NoError(err)
+}
+
+func TestChildPlaylistService_CreateChildPlaylist_InvalidDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	service := createTestService(nil, nil, nil, nil)
+
+	input := &models.CreateChildPlaylistRequest{
+		Name:        "Test",
+		FilterRules: &models.MetadataFilters{DurationRange: &models.DurationFilter{}},
+	}
+	_, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", input)
+
+	assert.ErrorContains(err, "invalid filter rules")
+}
+
+func TestChildPlaylistService_CreateChildPlaylist_MergeSources(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient)
+
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bpid", "uid").Return(testfixtures.NewBasePlaylist().WithID("bpid").WithName("Base").Build(), nil)
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bp_other", "uid").Return(testfixtures.NewBasePlaylist().WithID("bp_other").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bpid", "uid").Return([]*models.ChildPlaylist{}, nil)
+	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&spotifyclient.SpotifyPlaylist{ID: "sp_id"}, nil)
+	mockChildRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fields repositories.CreateChildPlaylistFields) (*models.ChildPlaylist, error) {
+			// The own base playlist and repeated sources are dropped
+			assert.Equal([]string{"bp_other"}, fields.SourceBasePlaylistIDs)
+			return testfixtures.NewChildPlaylist().WithID("cp_new").WithSourceBasePlaylistIDs(fields.SourceBasePlaylistIDs...).Build(), nil
+		})
+
+	result, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{
+		Name:                  "Merged",
+		SourceBasePlaylistIDs: []string{"bp_other", "bpid", "bp_other"},
+	})
+
+	assert.NoError(err)
+	assert.True(result.IsMerge())
+}
+
+func TestChildPlaylistService_CreateChildPlaylist_MergeSourceNotFound(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	service := createTestService(nil, mockBaseRepo, nil, nil)
+
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bpid", "uid").Return(testfixtures.NewBasePlaylist().WithID("bpid").Build(), nil)
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bp_other", "uid").Return(nil, repositories.ErrUnauthorized)
+
+	_, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{
+		Name:                  "Merged",
+		SourceBasePlaylistIDs: []string{"bp_other"},
+	})
+
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+	assert.Contains(err.Error(), "failed to get source base playlist bp_other")
+}
+
 func TestChildPlaylistService_CreateChildPlaylist_GetBasePlaylistError(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
@@ -120,15 +306,33 @@ func TestChildPlaylistService_CreateChildPlaylist_GetBasePlaylistError(t *testin
 	assert.Contains(err.Error(), "failed to get base playlist")
 }
 
+func TestChildPlaylistService_CreateChildPlaylist_GetSiblingsError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewBasePlaylist().WithName("Base").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bpid", "uid").Return(nil, errors.New("db error"))
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, nil)
+
+	_, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{Name: "Test"})
+
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed to get child playlists")
+}
+
 func TestChildPlaylistService_CreateChildPlaylist_SpotifyError(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
 
 	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
 	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
-	mockBaseRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.BasePlaylist{Name: "Base"}, nil)
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewBasePlaylist().WithName("Base").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("spotify api error"))
-	service := createTestService(nil, mockBaseRepo, nil, mockSpotifyClient)
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient)
 
 	_, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{Name: "Test"})
 
@@ -143,9 +347,11 @@ func TestChildPlaylistService_CreateChildPlaylist_RepoError(t *testing.T) {
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
 	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
-	mockBaseRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.BasePlaylist{Name: "Base"}, nil)
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewBasePlaylist().WithName("Base").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&spotifyclient.SpotifyPlaylist{ID: "sp_id"}, nil)
 	mockChildRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+	mockSpotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "sp_id").Return(nil)
 	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient)
 
 	_, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{Name: "Test"})
@@ -154,6 +360,55 @@ func TestChildPlaylistService_CreateChildPlaylist_RepoError(t *testing.T) {
 	assert.Contains(err.Error(), "failed to create child playlist")
 }
 
+func TestChildPlaylistService_CreateChildPlaylist_CompensationError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewBasePlaylist().WithName("Base").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&spotifyclient.SpotifyPlaylist{ID: "sp_id"}, nil)
+	mockChildRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+	mockSpotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "sp_id").Return(errors.New("spotify down"))
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient)
+
+	result, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{Name: "Test"})
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestChildPlaylistService_CreateChildPlaylist_FallbackRolledBack(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	mockTransactor := repoMocks.NewMockTransactor(ctrl)
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient).WithTransactor(mockTransactor)
+
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bpid", "uid").Return(testfixtures.NewBasePlaylist().WithName("Base").Build(), nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bpid", "uid").Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("cp_previous").Fallback().WithPriority(0).Build(),
+	}, nil)
+	mockSpotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&spotifyclient.SpotifyPlaylist{ID: "sp_id"}, nil)
+	mockTransactor.EXPECT().RunInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	mockChildRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(testfixtures.NewChildPlaylist().WithID("cp_new").WithUserID("uid").Fallback().Build(), nil)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp_previous", "uid", gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+	mockSpotifyClient.EXPECT().DeletePlaylist(gomock.Any(), "sp_id").Return(nil)
+
+	result, err := service.CreateChildPlaylist(context.Background(), "uid", "bpid", &models.CreateChildPlaylistRequest{Name: "Everything else", IsFallback: true})
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
 func TestChildPlaylistService_DeleteChildPlaylist_Success(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
@@ -162,15 +417,12 @@ func TestChildPlaylistService_DeleteChildPlaylist_Success(t *testing.T) {
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
 	logger := createTestLogger()
-	service := NewChildPlaylistService(mockChildRepo, nil, nil, mockSpotifyClient, logger)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, mockSpotifyClient, nil, logger)
 
 	// Test Data
 	userID := "user123"
 	childPlaylistID := "childPlaylist789"
-	childPlaylist := &models.ChildPlaylist{
-		ID:                childPlaylistID,
-		SpotifyPlaylistID: "spotify_playlist_to_delete",
-	}
+	childPlaylist := testfixtures.NewChildPlaylist().WithID(childPlaylistID).WithSpotifyPlaylistID("spotify_playlist_to_delete").Build()
 
 	// Mock Calls
 	mockChildRepo.EXPECT().GetByID(gomock.Any(), childPlaylistID, userID).Return(childPlaylist, nil)
@@ -184,6 +436,44 @@ func TestChildPlaylistService_DeleteChildPlaylist_Success(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestChildPlaylistService_DeleteChildPlaylist_PublishesEvent(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	events := &recordingPublisher{}
+	service := createTestService(mockChildRepo, nil, nil, mockSpotifyClient).WithEvents(events)
+
+	childPlaylist := testfixtures.NewChildPlaylist().WithID("cpid").WithBasePlaylistID("bpid").Build()
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cpid", "uid").Return(childPlaylist, nil)
+	mockSpotifyClient.EXPECT().DeletePlaylist(gomock.Any(), childPlaylist.SpotifyPlaylistID).Return(nil)
+	mockChildRepo.EXPECT().Delete(gomock.Any(), "cpid", "uid").Return(nil)
+
+	err := service.DeleteChildPlaylist(context.Background(), "cpid", "uid")
+
+	assert.NoError(err)
+	assert.Equal([]string{"uid"}, events.userIDs)
+	assert.Equal(models.AppEventPlaylistDeleted, events.events[0].Type)
+	assert.Equal(&models.PlaylistChange{Kind: models.PlaylistKindChild, ID: "cpid", BasePlaylistID: "bpid"}, events.events[0].Playlist)
+	assert.False(events.events[0].Timestamp.IsZero())
+}
+
+func TestChildPlaylistService_DeleteChildPlaylist_ErrorPublishesNoEvent(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+	events := &recordingPublisher{}
+	service := createTestService(mockChildRepo, nil, nil, nil).WithEvents(events)
+
+	err := service.DeleteChildPlaylist(context.Background(), "cpid", "uid")
+
+	assert.Error(err)
+	assert.Empty(events.events)
+}
+
 func TestChildPlaylistService_DeleteChildPlaylist_GetByIDError(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
@@ -204,7 +494,7 @@ func TestChildPlaylistService_DeleteChildPlaylist_SpotifyError(t *testing.T) {
 
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
-	mockChildRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.ChildPlaylist{}, nil)
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewChildPlaylist().Build(), nil)
 	mockSpotifyClient.EXPECT().DeletePlaylist(gomock.Any(), gomock.Any()).Return(errors.New("spotify api error"))
 	service := createTestService(mockChildRepo, nil, nil, mockSpotifyClient)
 
@@ -220,7 +510,7 @@ func TestChildPlaylistService_DeleteChildPlaylist_RepoError(t *testing.T) {
 
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
-	mockChildRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.ChildPlaylist{}, nil)
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(testfixtures.NewChildPlaylist().Build(), nil)
 	mockSpotifyClient.EXPECT().DeletePlaylist(gomock.Any(), gomock.Any()).Return(nil)
 	mockChildRepo.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
 	service := createTestService(mockChildRepo, nil, nil, mockSpotifyClient)
@@ -231,15 +521,62 @@ func TestChildPlaylistService_DeleteChildPlaylist_RepoError(t *testing.T) {
 	assert.Contains(err.Error(), "failed to delete child playlist")
 }
 
+func TestChildPlaylistService_RestoreChildPlaylist_Success(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	childPlaylist := testfixtures.NewChildPlaylist().WithID("cpid").WithSpotifyPlaylistID("spotify_restored").Build()
+	mockChildRepo.EXPECT().Restore(gomock.Any(), "cpid", "uid").Return(childPlaylist, nil)
+	mockSpotifyClient.EXPECT().FollowPlaylist(gomock.Any(), "spotify_restored", false).Return(nil)
+	service := createTestService(mockChildRepo, nil, nil, mockSpotifyClient)
+
+	restored, err := service.RestoreChildPlaylist(context.Background(), "cpid", "uid")
+
+	assert.NoError(err)
+	assert.Equal(childPlaylist, restored)
+}
+
+func TestChildPlaylistService_RestoreChildPlaylist_RepoError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockChildRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repositories.ErrRestoreConflict)
+	service := createTestService(mockChildRepo, nil, nil, nil)
+
+	_, err := service.RestoreChildPlaylist(context.Background(), "cpid", "uid")
+
+	assert.ErrorIs(err, repositories.ErrRestoreConflict)
+}
+
+func TestChildPlaylistService_RestoreChildPlaylist_SpotifyErrorKeepsItDeleted(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	mockChildRepo.EXPECT().Restore(gomock.Any(), "cpid", "uid").Return(testfixtures.NewChildPlaylist().WithID("cpid").Build(), nil)
+	mockSpotifyClient.EXPECT().FollowPlaylist(gomock.Any(), gomock.Any(), false).Return(errors.New("spotify api error"))
+	mockChildRepo.EXPECT().Delete(gomock.Any(), "cpid", "uid").Return(nil)
+	service := createTestService(mockChildRepo, nil, nil, mockSpotifyClient)
+
+	_, err := service.RestoreChildPlaylist(context.Background(), "cpid", "uid")
+
+	assert.Error(err)
+	assert.Contains(err.Error(), "failed to follow spotify playlist")
+}
+
 func TestChildPlaylistService_GetChildPlaylist_Success(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
 
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	logger := createTestLogger()
-	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, logger)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, logger)
 
-	expectedPlaylist := &models.ChildPlaylist{ID: "cp123", Name: "Test"}
+	expectedPlaylist := testfixtures.NewChildPlaylist().WithID("cp123").WithName("Test").Build()
 	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp123", "user123").Return(expectedPlaylist, nil)
 
 	result, err := service.GetChildPlaylist(context.Background(), "cp123", "user123")
@@ -254,7 +591,7 @@ func TestChildPlaylistService_GetChildPlaylist_Error(t *testing.T) {
 
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	logger := createTestLogger()
-	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, logger)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, logger)
 
 	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp123", "user123").Return(nil, repositories.ErrChildPlaylistNotFound)
 
@@ -271,11 +608,11 @@ func TestChildPlaylistService_GetChildPlaylistsByBasePlaylistID_Success(t *testi
 
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	logger := createTestLogger()
-	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, logger)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, logger)
 
 	expectedPlaylists := []*models.ChildPlaylist{
-		{ID: "cp1", Name: "Child 1"},
-		{ID: "cp2", Name: "Child 2"},
+		testfixtures.NewChildPlaylist().WithID("cp1").WithName("Child 1").Build(),
+		testfixtures.NewChildPlaylist().WithID("cp2").WithName("Child 2").Build(),
 	}
 	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp123", "user123").Return(expectedPlaylists, nil)
 
@@ -291,7 +628,7 @@ func TestChildPlaylistService_GetChildPlaylistsByBasePlaylistID_Error(t *testing
 
 	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
 	logger := createTestLogger()
-	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, logger)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, logger)
 
 	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp123", "user123").Return(nil, repositories.ErrDatabaseOperation)
 
@@ -302,6 +639,32 @@ func TestChildPlaylistService_GetChildPlaylistsByBasePlaylistID_Error(t *testing
 	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
 }
 
+func TestChildPlaylistService_GetMergeChildPlaylistsBySourceBasePlaylistID(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	logger := createTestLogger()
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, logger)
+
+	expectedPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("cp1").WithBasePlaylistID("bp456").WithSourceBasePlaylistIDs("bp123").Build(),
+	}
+	mockChildRepo.EXPECT().GetBySourceBasePlaylistID(gomock.Any(), "bp123", "user123").Return(expectedPlaylists, nil)
+
+	result, err := service.GetMergeChildPlaylistsBySourceBasePlaylistID(context.Background(), "bp123", "user123")
+
+	assert.NoError(err)
+	assert.Equal(expectedPlaylists, result)
+
+	mockChildRepo.EXPECT().GetBySourceBasePlaylistID(gomock.Any(), "bp123", "user123").Return(nil, repositories.ErrDatabaseOperation)
+
+	result, err = service.GetMergeChildPlaylistsBySourceBasePlaylistID(context.Background(), "bp123", "user123")
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
 func TestChildPlaylistService_UpdateChildPlaylist_Success(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -319,17 +682,14 @@ func TestChildPlaylistService_UpdateChildPlaylist_Success(t *testing.T) {
 				Name:        stringToPointer("Updated Child Name"),
 				Description: stringToPointer("Updated description"),
 			},
-			updatedChildPlaylist: &models.ChildPlaylist{
-				ID:                "cp789",
-				BasePlaylistID:    "bp456",
-				SpotifyPlaylistID: "sp_id",
-				Name:              "Updated Child Name",
-				Description:       "Updated description",
-			},
-			basePlaylist: &models.BasePlaylist{
-				ID:   "bp456",
-				Name: "Base Playlist Name",
-			},
+			updatedChildPlaylist: testfixtures.NewChildPlaylist().
+				WithID("cp789").
+				WithBasePlaylistID("bp456").
+				WithSpotifyPlaylistID("sp_id").
+				WithName("Updated Child Name").
+				WithDescription("Updated description").
+				Build(),
+			basePlaylist:          testfixtures.NewBasePlaylist().WithID("bp456").WithName("Base Playlist Name").Build(),
 			needsBasePlaylistCall: true,
 			needsSpotifyCall:      true,
 			expectedSpotifyName:   "[Base Playlist Name] > Updated Child Name",
@@ -340,16 +700,13 @@ func TestChildPlaylistService_UpdateChildPlaylist_Success(t *testing.T) {
 			input: &models.UpdateChildPlaylistRequest{
 				Name: stringToPointer("Updated Name Only"),
 			},
-			updatedChildPlaylist: &models.ChildPlaylist{
-				ID:                "cp789",
-				BasePlaylistID:    "bp456",
-				SpotifyPlaylistID: "sp_id",
-				Name:              "Updated Name Only",
-			},
-			basePlaylist: &models.BasePlaylist{
-				ID:   "bp456",
-				Name: "Base Name",
-			},
+			updatedChildPlaylist: testfixtures.NewChildPlaylist().
+				WithID("cp789").
+				WithBasePlaylistID("bp456").
+				WithSpotifyPlaylistID("sp_id").
+				WithName("Updated Name Only").
+				Build(),
+			basePlaylist:          testfixtures.NewBasePlaylist().WithID("bp456").WithName("Base Name").Build(),
 			needsBasePlaylistCall: true,
 			needsSpotifyCall:      true,
 			expectedSpotifyName:   "[Base Name] > Updated Name Only",
@@ -360,11 +717,7 @@ func TestChildPlaylistService_UpdateChildPlaylist_Success(t *testing.T) {
 			input: &models.UpdateChildPlaylistRequest{
 				Description: stringToPointer("Updated Description Only"),
 			},
-			updatedChildPlaylist: &models.ChildPlaylist{
-				ID:                "cp789",
-				SpotifyPlaylistID: "sp_id",
-				Description:       "Updated Description Only",
-			},
+			updatedChildPlaylist:  testfixtures.NewChildPlaylist().WithID("cp789").WithSpotifyPlaylistID("sp_id").WithDescription("Updated Description Only").Build(),
 			needsBasePlaylistCall: false,
 			needsSpotifyCall:      true,
 			expectedSpotifyName:   "",
@@ -375,10 +728,7 @@ func TestChildPlaylistService_UpdateChildPlaylist_Success(t *testing.T) {
 			input: &models.UpdateChildPlaylistRequest{
 				IsActive: boolToPointer(true),
 			},
-			updatedChildPlaylist: &models.ChildPlaylist{
-				ID:       "cp789",
-				IsActive: true,
-			},
+			updatedChildPlaylist:  testfixtures.NewChildPlaylist().WithID("cp789").Build(),
 			needsBasePlaylistCall: false,
 			needsSpotifyCall:      false,
 		},
@@ -436,6 +786,147 @@ func TestChildPlaylistService_UpdateChildPlaylist_Success(t *testing.T) {
 	}
 }
 
+func TestChildPlaylistService_UpdateChildPlaylist_Fallback(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := createTestService(mockChildRepo, nil, nil, nil)
+
+	isFallback := true
+	notFallback := false
+	updatedChildPlaylist := testfixtures.NewChildPlaylist().
+		WithID("cp789").
+		WithUserID("user123").
+		WithBasePlaylistID("bp456").
+		Fallback().
+		Build()
+
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp789", "user123", repositories.UpdateChildPlaylistFields{IsFallback: &isFallback}).
+		Return(updatedChildPlaylist, nil)
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp456", "user123").Return([]*models.ChildPlaylist{
+		updatedChildPlaylist,
+		testfixtures.NewChildPlaylist().WithID("cp_previous").Fallback().Build(),
+		testfixtures.NewChildPlaylist().WithID("cp_regular").Build(),
+	}, nil)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp_previous", "user123", repositories.UpdateChildPlaylistFields{IsFallback: &notFallback}).
+		Return(testfixtures.NewChildPlaylist().WithID("cp_previous").Build(), nil)
+
+	result, err := service.UpdateChildPlaylist(context.Background(), "cp789", "user123", &models.UpdateChildPlaylistRequest{IsFallback: &isFallback})
+
+	assert.NoError(err)
+	assert.Equal(updatedChildPlaylist, result)
+}
+
+func TestChildPlaylistService_UpdateChildPlaylist_MergeSources(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockBaseRepo := repoMocks.NewMockBasePlaylistRepository(ctrl)
+	service := createTestService(mockChildRepo, mockBaseRepo, nil, nil)
+
+	current := testfixtures.NewChildPlaylist().WithID("cp789").WithUserID("user123").WithBasePlaylistID("bp456").Build()
+	sources := []string{"bp_other"}
+	resolved := []string{"bp_other"}
+	updatedChildPlaylist := testfixtures.NewChildPlaylist().WithID("cp789").WithBasePlaylistID("bp456").WithSourceBasePlaylistIDs(resolved...).Build()
+
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp789", "user123").Return(current, nil)
+	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bp_other", "user123").Return(testfixtures.NewBasePlaylist().WithID("bp_other").Build(), nil)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp789", "user123", repositories.UpdateChildPlaylistFields{SourceBasePlaylistIDs: &resolved}).
+		Return(updatedChildPlaylist, nil)
+
+	result, err := service.UpdateChildPlaylist(context.Background(), "cp789", "user123", &models.UpdateChildPlaylistRequest{SourceBasePlaylistIDs: &sources})
+
+	assert.NoError(err)
+	assert.Equal(updatedChildPlaylist, result)
+}
+
+func TestChildPlaylistService_UpdateChildPlaylist_RecordsFilterRuleChange(t *testing.T) {
+	previousRules := &models.AudioFeatureFilters{Popularity: &models.RangeFilter{Min: float64ToPointer(20)}}
+	newRules := &models.AudioFeatureFilters{Popularity: &models.RangeFilter{Min: float64ToPointer(60)}}
+
+	tests := []struct {
+		name          string
+		previousRules *models.AudioFeatureFilters
+		expectChange  bool
+	}{
+		{name: "rules changed", previousRules: previousRules, expectChange: true},
+		{name: "rules added", previousRules: nil, expectChange: true},
+		{name: "rules unchanged", previousRules: newRules, expectChange: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctrl := setupMockController(t)
+
+			mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+			mockRuleChangeRepo := repoMocks.NewMockFilterRuleChangeRepository(ctrl)
+			service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, mockRuleChangeRepo, createTestLogger())
+
+			current := testfixtures.NewChildPlaylist().
+				WithID("cp789").
+				WithUserID("user123").
+				WithBasePlaylistID("bp456").
+				WithFilters(tt.previousRules).
+				Build()
+			updated := testfixtures.NewChildPlaylist().
+				WithID("cp789").
+				WithUserID("user123").
+				WithBasePlaylistID("bp456").
+				WithFilters(newRules).
+				Build()
+
+			mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp789", "user123").Return(current, nil)
+			mockChildRepo.EXPECT().Update(gomock.Any(), "cp789", "user123", repositories.UpdateChildPlaylistFields{FilterRules: newRules}).
+				Return(updated, nil)
+
+			if tt.expectChange {
+				mockRuleChangeRepo.EXPECT().Create(gomock.Any(), &models.FilterRuleChange{
+					UserID:              "user123",
+					BasePlaylistID:      "bp456",
+					ChildPlaylistID:     "cp789",
+					PreviousFilterRules: tt.previousRules,
+					NewFilterRules:      newRules,
+				}).Return(&models.FilterRuleChange{ID: "change123"}, nil)
+			}
+
+			result, err := service.UpdateChildPlaylist(context.Background(), "cp789", "user123", &models.UpdateChildPlaylistRequest{FilterRules: newRules})
+
+			assert.NoError(err)
+			assert.Equal(updated, result)
+		})
+	}
+}
+
+func TestChildPlaylistService_UpdateChildPlaylist_RecordFilterRuleChangeError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockRuleChangeRepo := repoMocks.NewMockFilterRuleChangeRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, mockRuleChangeRepo, createTestLogger())
+
+	newRules := &models.AudioFeatureFilters{Explicit: boolToPointer(false)}
+	updated := testfixtures.NewChildPlaylist().
+		WithID("cp789").
+		WithUserID("user123").
+		WithBasePlaylistID("bp456").
+		WithFilters(newRules).
+		Build()
+
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp789", "user123").Return(testfixtures.NewChildPlaylist().WithID("cp789").Build(), nil)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp789", "user123", gomock.Any()).Return(updated, nil)
+	mockRuleChangeRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	// The rule history is best effort, the update itself still succeeds
+	result, err := service.UpdateChildPlaylist(context.Background(), "cp789", "user123", &models.UpdateChildPlaylistRequest{FilterRules: newRules})
+
+	assert.NoError(err)
+	assert.Equal(updated, result)
+}
+
 func TestChildPlaylistService_UpdateChildPlaylist_RepoError(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
@@ -450,6 +941,30 @@ func TestChildPlaylistService_UpdateChildPlaylist_RepoError(t *testing.T) {
 	assert.Contains(err.Error(), "failed to update child playlist")
 }
 
+func TestChildPlaylistService_UpdateChildPlaylist_Modified(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	expectedUpdated := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	newName := "Renamed"
+
+	// The spotify playlist isn't renamed when the update is refused
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp789", "user123", repositories.UpdateChildPlaylistFields{
+		Name:            &newName,
+		ExpectedUpdated: &expectedUpdated,
+	}).Return(nil, repositories.ErrChildPlaylistModified)
+	service := createTestService(mockChildRepo, nil, nil, nil)
+
+	result, err := service.UpdateChildPlaylist(context.Background(), "cp789", "user123", &models.UpdateChildPlaylistRequest{
+		Name:            &newName,
+		ExpectedUpdated: &expectedUpdated,
+	})
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrChildPlaylistModified)
+}
+
 func TestChildPlaylistService_UpdateChildPlaylist_GetBasePlaylistError(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := setupMockController(t)
@@ -460,7 +975,7 @@ func TestChildPlaylistService_UpdateChildPlaylist_GetBasePlaylistError(t *testin
 
 	newName := "New Name"
 	input := &models.UpdateChildPlaylistRequest{Name: &newName}
-	updatedChildPlaylist := &models.ChildPlaylist{BasePlaylistID: "bp456"}
+	updatedChildPlaylist := testfixtures.NewChildPlaylist().WithBasePlaylistID("bp456").Build()
 
 	mockChildRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(updatedChildPlaylist, nil)
 	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bp456", gomock.Any()).Return(nil, errors.New("base playlist not found"))
@@ -482,11 +997,8 @@ func TestChildPlaylistService_UpdateChildPlaylist_SpotifyError(t *testing.T) {
 
 	newName := "New Name"
 	input := &models.UpdateChildPlaylistRequest{Name: &newName}
-	basePlaylist := &models.BasePlaylist{ID: "bp456", Name: "Base"}
-	updatedChildPlaylist := &models.ChildPlaylist{
-		BasePlaylistID:    "bp456",
-		SpotifyPlaylistID: "sp_id",
-	}
+	basePlaylist := testfixtures.NewBasePlaylist().WithID("bp456").WithName("Base").Build()
+	updatedChildPlaylist := testfixtures.NewChildPlaylist().WithBasePlaylistID("bp456").WithSpotifyPlaylistID("sp_id").Build()
 
 	mockChildRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(updatedChildPlaylist, nil)
 	mockBaseRepo.EXPECT().GetByID(gomock.Any(), "bp456", gomock.Any()).Return(basePlaylist, nil)
@@ -510,11 +1022,7 @@ func TestChildPlaylistService_UpdateChildPlaylistSpotifyID_Success(t *testing.T)
 	service := createTestService(mockChildRepo, mockBaseRepo, nil, mockSpotifyClient)
 
 	// Mock expectations
-	updatedChildPlaylist := &models.ChildPlaylist{
-		ID:                "cp789",
-		UserID:            "user123",
-		SpotifyPlaylistID: "new-spotify-id",
-	}
+	updatedChildPlaylist := testfixtures.NewChildPlaylist().WithID("cp789").WithUserID("user123").WithSpotifyPlaylistID("new-spotify-id").Build()
 	expectedUpdateFields := repositories.UpdateChildPlaylistFields{
 		SpotifyPlaylistID: stringToPointer("new-spotify-id"),
 	}
@@ -553,6 +1061,32 @@ func TestChildPlaylistService_UpdateChildPlaylistSpotifyID_RepoError(t *testing.
 	assert.Contains(err.Error(), "failed to update child playlist")
 }
 
+func TestChildPlaylistService_MigrateChildPlaylistToInPlace(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := createTestService(mockChildRepo, nil, nil, nil)
+
+	strategy := models.SyncStrategyInPlace
+	migratedChildPlaylist := testfixtures.NewChildPlaylist().
+		WithID("cp789").
+		WithUserID("user123").
+		WithSpotifyPlaylistID("new-spotify-id").
+		WithSyncStrategy(strategy).
+		Build()
+	expectedUpdateFields := repositories.UpdateChildPlaylistFields{
+		SpotifyPlaylistID: stringToPointer("new-spotify-id"),
+		SyncStrategy:      &strategy,
+	}
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp789", "user123", expectedUpdateFields).Return(migratedChildPlaylist, nil)
+
+	result, err := service.MigrateChildPlaylistToInPlace(context.Background(), "cp789", "user123", "new-spotify-id")
+
+	assert.NoError(err)
+	assert.Equal(migratedChildPlaylist, result)
+}
+
 // Helper functions for common test setups
 func createTestService(
 	childRepo repositories.ChildPlaylistRepository,
@@ -560,5 +1094,359 @@ func createTestService(
 	spotifyIntegrationRepo repositories.SpotifyIntegrationRepository,
 	spotifyClient spotifyclient.SpotifyAPI,
 ) *ChildPlaylistService {
-	return NewChildPlaylistService(childRepo, baseRepo, spotifyIntegrationRepo, spotifyClient, createTestLogger())
+	return NewChildPlaylistService(childRepo, baseRepo, spotifyIntegrationRepo, spotifyClient, nil, createTestLogger())
+}
+
+func TestChildPlaylistService_ReorderChildPlaylists_Success(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp123", "user123").Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("cp1").WithPriority(0).Build(),
+		testfixtures.NewChildPlaylist().WithID("cp2").WithPriority(1).Build(),
+		testfixtures.NewChildPlaylist().WithID("cp3").WithPriority(2).Build(),
+	}, nil)
+
+	// cp1 already has the requested priority and is not updated
+	priority1, priority2 := 1, 2
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp3", "user123", repositories.UpdateChildPlaylistFields{Priority: &priority1}).
+		Return(testfixtures.NewChildPlaylist().WithID("cp3").WithPriority(1).Build(), nil)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp2", "user123", repositories.UpdateChildPlaylistFields{Priority: &priority2}).
+		Return(testfixtures.NewChildPlaylist().WithID("cp2").WithPriority(2).Build(), nil)
+
+	result, err := service.ReorderChildPlaylists(context.Background(), "bp123", "user123", []string{"cp1", "cp3", "cp2"})
+
+	assert.NoError(err)
+	assert.Len(result, 3)
+	for i, id := range []string{"cp1", "cp3", "cp2"} {
+		assert.Equal(id, result[i].ID)
+		assert.Equal(i, result[i].Priority)
+	}
+}
+
+func TestChildPlaylistService_ReorderChildPlaylists_InvalidOrder(t *testing.T) {
+	tests := []struct {
+		name             string
+		childPlaylistIDs []string
+	}{
+		{name: "missing child playlist", childPlaylistIDs: []string{"cp1"}},
+		{name: "unknown child playlist", childPlaylistIDs: []string{"cp1", "cp9"}},
+		{name: "duplicated child playlist", childPlaylistIDs: []string{"cp1", "cp1"}},
+		{name: "extra child playlist", childPlaylistIDs: []string{"cp1", "cp2", "cp3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctrl := setupMockController(t)
+
+			mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+			service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+			mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp123", "user123").Return([]*models.ChildPlaylist{
+				testfixtures.NewChildPlaylist().WithID("cp1").Build(),
+				testfixtures.NewChildPlaylist().WithID("cp2").Build(),
+			}, nil)
+
+			result, err := service.ReorderChildPlaylists(context.Background(), "bp123", "user123", tt.childPlaylistIDs)
+
+			assert.Nil(result)
+			assert.ErrorIs(err, ErrInvalidChildPlaylistOrder)
+		})
+	}
+}
+
+func TestChildPlaylistService_ReorderChildPlaylists_RepoError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp123", "user123").Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("cp1").WithPriority(0).Build(),
+		testfixtures.NewChildPlaylist().WithID("cp2").WithPriority(1).Build(),
+	}, nil)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp2", "user123", gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+
+	result, err := service.ReorderChildPlaylists(context.Background(), "bp123", "user123", []string{"cp2", "cp1"})
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestChildPlaylistService_ReorderChildPlaylists_Transaction(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockTransactor := repoMocks.NewMockTransactor(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger()).WithTransactor(mockTransactor)
+
+	mockChildRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "bp123", "user123").Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("cp1").WithPriority(0).Build(),
+		testfixtures.NewChildPlaylist().WithID("cp2").WithPriority(1).Build(),
+	}, nil)
+	mockTransactor.EXPECT().RunInTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp2", "user123", gomock.Any()).Return(testfixtures.NewChildPlaylist().WithID("cp2").WithPriority(0).Build(), nil)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp1", "user123", gomock.Any()).Return(testfixtures.NewChildPlaylist().WithID("cp1").WithPriority(1).Build(), nil)
+
+	result, err := service.ReorderChildPlaylists(context.Background(), "bp123", "user123", []string{"cp2", "cp1"})
+
+	assert.NoError(err)
+	assert.Len(result, 2)
+	assert.Equal("cp2", result[0].ID)
+}
+
+func TestChildPlaylistService_EnableSharing_Success(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	var shareToken string
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp1", "user123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id, userID string, fields repositories.UpdateChildPlaylistFields) (*models.ChildPlaylist, error) {
+			shareToken = *fields.ShareToken
+			return testfixtures.NewChildPlaylist().WithID(id).WithUserID(userID).WithShareToken(shareToken).Build(), nil
+		})
+
+	result, err := service.EnableSharing(context.Background(), "cp1", "user123")
+
+	assert.NoError(err)
+	assert.Len(shareToken, 64)
+	assert.Equal(shareToken, result.ShareToken)
+}
+
+func TestChildPlaylistService_EnableSharing_RepositoryError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp1", "user123", gomock.Any()).Return(nil, repositories.ErrUnauthorized)
+
+	result, err := service.EnableSharing(context.Background(), "cp1", "user123")
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+}
+
+func TestChildPlaylistService_DisableSharing_Success(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	emptyToken := ""
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp1", "user123", repositories.UpdateChildPlaylistFields{ShareToken: &emptyToken}).
+		Return(testfixtures.NewChildPlaylist().WithID("cp1").Build(), nil)
+
+	result, err := service.DisableSharing(context.Background(), "cp1", "user123")
+
+	assert.NoError(err)
+	assert.Empty(result.ShareToken)
+}
+
+func TestChildPlaylistService_AddExclusion_Success(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockRuleChangeRepo := repoMocks.NewMockFilterRuleChangeRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, mockRuleChangeRepo, createTestLogger())
+
+	previousRules := &models.AudioFeatureFilters{Genres: &models.SetFilter{Include: []string{"rock"}}}
+	expectedRules := &models.AudioFeatureFilters{Genres: &models.SetFilter{Include: []string{"rock"}, Exclude: []string{"metal"}}}
+	current := testfixtures.NewChildPlaylist().
+		WithID("cp789").
+		WithUserID("user123").
+		WithBasePlaylistID("bp456").
+		WithFilters(previousRules).
+		Build()
+	updated := testfixtures.NewChildPlaylist().
+		WithID("cp789").
+		WithUserID("user123").
+		WithBasePlaylistID("bp456").
+		WithFilters(expectedRules).
+		Build()
+
+	// Once to build the new rules and once more by UpdateChildPlaylist for the rule history
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp789", "user123").Return(current, nil).Times(2)
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp789", "user123", repositories.UpdateChildPlaylistFields{FilterRules: expectedRules}).
+		Return(updated, nil)
+	mockRuleChangeRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.FilterRuleChange{ID: "change123"}, nil)
+
+	result, err := service.AddExclusion(context.Background(), "cp789", "user123", models.ExclusionFieldGenres, "metal")
+
+	assert.NoError(err)
+	assert.Equal(updated, result)
+	// The stored rules of the child playlist are not modified in place
+	assert.Nil(previousRules.Genres.Exclude)
+}
+
+func TestChildPlaylistService_AddExclusion_GetByIDError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp789", "user123").Return(nil, repositories.ErrChildPlaylistNotFound)
+
+	result, err := service.AddExclusion(context.Background(), "cp789", "user123", models.ExclusionFieldGenres, "metal")
+
+	assert.Nil(result)
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+}
+
+func TestChildPlaylistService_PinTracks_Success(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp1", "user123").
+		Return(testfixtures.NewChildPlaylist().WithID("cp1").WithPinnedTracks("spotify:track:1").Build(), nil)
+
+	pinned := []string{"spotify:track:1", "spotify:track:2"}
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp1", "user123", repositories.UpdateChildPlaylistFields{PinnedTracks: &pinned}).
+		Return(testfixtures.NewChildPlaylist().WithID("cp1").WithPinnedTracks(pinned...).Build(), nil)
+
+	result, err := service.PinTracks(context.Background(), "cp1", "user123", []string{"spotify:track:2", "spotify:track:1"})
+
+	assert.NoError(err)
+	assert.Equal(pinned, result.PinnedTracks)
+}
+
+func TestChildPlaylistService_PinTracks_TooManyTracks(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	pinned := make([]string, models.MAX_PINNED_TRACKS)
+	for i := range pinned {
+		pinned[i] = fmt.Sprintf("spotify:track:%d", i)
+	}
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp1", "user123").
+		Return(testfixtures.NewChildPlaylist().WithID("cp1").WithPinnedTracks(pinned...).Build(), nil)
+
+	result, err := service.PinTracks(context.Background(), "cp1", "user123", []string{"spotify:track:new"})
+
+	assert.Nil(result)
+	assert.ErrorIs(err, ErrTooManyPinnedTracks)
+}
+
+func TestChildPlaylistService_UnpinTrack(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, nil, nil, createTestLogger())
+
+	childPlaylist := testfixtures.NewChildPlaylist().WithID("cp1").WithPinnedTracks("spotify:track:1", "spotify:track:2").Build()
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp1", "user123").Return(childPlaylist, nil).Times(2)
+
+	remaining := []string{"spotify:track:2"}
+	mockChildRepo.EXPECT().Update(gomock.Any(), "cp1", "user123", repositories.UpdateChildPlaylistFields{PinnedTracks: &remaining}).
+		Return(testfixtures.NewChildPlaylist().WithID("cp1").WithPinnedTracks(remaining...).Build(), nil)
+
+	result, err := service.UnpinTrack(context.Background(), "cp1", "user123", "spotify:track:1")
+	assert.NoError(err)
+	assert.Equal(remaining, result.PinnedTracks)
+	// The stored child playlist isn't modified in place
+	assert.Equal([]string{"spotify:track:1", "spotify:track:2"}, childPlaylist.PinnedTracks)
+
+	// Tracks that aren't pinned are ignored
+	result, err = service.UnpinTrack(context.Background(), "cp1", "user123", "spotify:track:3")
+	assert.NoError(err)
+	assert.Equal(childPlaylist, result)
+}
+
+func TestChildPlaylistService_UpdateChildPlaylistCover_UploadsImage(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, mockSpotifyClient, nil, createTestLogger())
+
+	childPlaylist := testfixtures.NewChildPlaylist().WithID("cp1").WithSpotifyPlaylistID("spotify_cp1").Build()
+	jpegBytes := []byte{0xFF, 0xD8, 0xFF, 0xE0}
+
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp1", testfixtures.DEFAULT_USER_ID).Return(childPlaylist, nil)
+	mockSpotifyClient.EXPECT().UploadPlaylistCover(gomock.Any(), "spotify_cp1", jpegBytes).Return(nil)
+
+	err := service.UpdateChildPlaylistCover(context.Background(), "cp1", testfixtures.DEFAULT_USER_ID, jpegBytes)
+
+	assert.NoError(err)
+}
+
+func TestChildPlaylistService_UpdateChildPlaylistCover_GeneratesCover(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, mockSpotifyClient, nil, createTestLogger())
+
+	childPlaylist := testfixtures.NewChildPlaylist().
+		WithID("cp1").
+		WithSpotifyPlaylistID("spotify_cp1").
+		WithFilters(testfixtures.NewFilters().WithGenres([]string{"rock"}, nil).Build()).
+		Build()
+	sibling := testfixtures.NewChildPlaylist().
+		WithID("cp2").
+		WithFilters(testfixtures.NewFilters().WithGenres([]string{"rock"}, nil).Build()).
+		Build()
+
+	var uploaded []byte
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp1", testfixtures.DEFAULT_USER_ID).Return(childPlaylist, nil)
+	mockSpotifyClient.EXPECT().UploadPlaylistCover(gomock.Any(), "spotify_cp1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, jpegBytes []byte) error {
+			uploaded = jpegBytes
+			return nil
+		})
+
+	err := service.UpdateChildPlaylistCover(context.Background(), "cp1", testfixtures.DEFAULT_USER_ID, nil)
+	assert.NoError(err)
+
+	img, err := jpeg.Decode(bytes.NewReader(uploaded))
+	assert.NoError(err)
+	assert.Equal(300, img.Bounds().Dx())
+
+	// Children with the same filter rules get the same cover
+	siblingCover, err := generateChildPlaylistCover(sibling)
+	assert.NoError(err)
+	assert.Equal(uploaded, siblingCover)
+}
+
+func TestChildPlaylistService_UpdateChildPlaylistCover_SpotifyError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := setupMockController(t)
+
+	mockChildRepo := repoMocks.NewMockChildPlaylistRepository(ctrl)
+	mockSpotifyClient := spotifyMocks.NewMockSpotifyAPI(ctrl)
+	service := NewChildPlaylistService(mockChildRepo, nil, nil, mockSpotifyClient, nil, createTestLogger())
+
+	mockChildRepo.EXPECT().GetByID(gomock.Any(), "cp1", testfixtures.DEFAULT_USER_ID).
+		Return(testfixtures.NewChildPlaylist().WithID("cp1").Build(), nil)
+	mockSpotifyClient.EXPECT().UploadPlaylistCover(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(spotifyclient.ErrCoverImageTooLarge)
+
+	err := service.UpdateChildPlaylistCover(context.Background(), "cp1", testfixtures.DEFAULT_USER_ID, []byte{0xFF})
+
+	assert.ErrorIs(err, spotifyclient.ErrCoverImageTooLarge)
 }
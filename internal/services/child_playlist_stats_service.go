@@ -0,0 +1,251 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=child_playlist_stats_service.go -destination=mocks/mock_child_playlist_stats_service.go -package=mocks
+
+// CHILD_PLAYLIST_STATS_CACHE_TTL bounds how often stats read the whole base playlist from Spotify,
+// while keeping them close to what the next sync would do
+const CHILD_PLAYLIST_STATS_CACHE_TTL = 2 * time.Minute
+
+type ChildPlaylistStatsServicer interface {
+	GetChildPlaylistStats(ctx context.Context, userID, id string) (*models.ChildPlaylistStats, error)
+}
+
+type cachedChildPlaylistStats struct {
+	stats     *models.ChildPlaylistStats
+	expiresAt time.Time
+}
+
+type ChildPlaylistStatsService struct {
+	basePlaylistService  BasePlaylistServicer
+	childPlaylistService ChildPlaylistServicer
+	syncEventService     SyncEventServicer
+	trackAggregator      TrackAggregatorServicer
+	trackRouter          TrackRouterServicer
+	musicProvider        musicprovider.MusicProvider
+	spotifyAuth          SpotifyAuthProvider
+	logger               *slog.Logger
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedChildPlaylistStats
+	now     func() time.Time
+}
+
+func NewChildPlaylistStatsService(
+	basePlaylistService BasePlaylistServicer,
+	childPlaylistService ChildPlaylistServicer,
+	syncEventService SyncEventServicer,
+	trackAggregator TrackAggregatorServicer,
+	trackRouter TrackRouterServicer,
+	musicProvider musicprovider.MusicProvider,
+	spotifyAuth SpotifyAuthProvider,
+	logger *slog.Logger,
+) *ChildPlaylistStatsService {
+	return &ChildPlaylistStatsService{
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		syncEventService:     syncEventService,
+		trackAggregator:      trackAggregator,
+		trackRouter:          trackRouter,
+		musicProvider:        musicProvider,
+		spotifyAuth:          spotifyAuth,
+		logger:               logger.With("component", "ChildPlaylistStatsService"),
+		cache:                make(map[string]cachedChildPlaylistStats),
+		now:                  time.Now,
+	}
+}
+
+// GetChildPlaylistStats routes the current tracks of the base playlist the way a sync would, with the
+// sibling child playlists, and describes the tracks the child gets. Stats are cached for
+// CHILD_PLAYLIST_STATS_CACHE_TTL
+func (csService *ChildPlaylistStatsService) GetChildPlaylistStats(ctx context.Context, userID, id string) (*models.ChildPlaylistStats, error) {
+	cacheKey := userID + "/" + id
+	if stats, ok := csService.getCached(cacheKey); ok {
+		return stats, nil
+	}
+
+	childPlaylist, err := csService.childPlaylistService.GetChildPlaylist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	basePlaylist, err := csService.basePlaylistService.GetBasePlaylist(ctx, childPlaylist.BasePlaylistID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	siblingPlaylists, err := csService.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, basePlaylist.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	trackData, err := csService.aggregateTracks(ctx, userID, basePlaylist, childPlaylist)
+	if err != nil {
+		return nil, err
+	}
+
+	routing, _, err := csService.trackRouter.RouteTracksToChildren(ctx, trackData, siblingPlaylists, basePlaylist.DedupeStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to route tracks: %w", err)
+	}
+
+	stats := buildChildPlaylistStats(childPlaylist, trackData, routing[childPlaylist.SpotifyPlaylistID])
+	stats.ComputedAt = csService.now()
+
+	lastSync, err := csService.syncEventService.GetLastCompletedSyncEvent(ctx, userID, basePlaylist.ID)
+	if err != nil {
+		csService.logger.WarnContext(ctx, "failed to get last sync for child playlist stats", "child_playlist_id", id, "error", err.Error())
+	}
+	if lastSync != nil {
+		stats.LastSyncedAt = lastSync.CompletedAt
+		for _, result := range lastSync.ChildSyncResults {
+			if result.ChildPlaylistID == childPlaylist.ID {
+				stats.TrackCount = result.TracksAdded
+			}
+		}
+	}
+
+	csService.addSpotifyTrackCount(ctx, userID, childPlaylist, stats)
+
+	csService.setCached(cacheKey, stats)
+	return stats, nil
+}
+
+// aggregateTracks reads the tracks of the base playlist through its spotify account, followed by
+// the ones of the other base playlists merged into the child
+func (csService *ChildPlaylistStatsService) aggregateTracks(ctx context.Context, userID string, basePlaylist *models.BasePlaylist, childPlaylist *models.ChildPlaylist) (*models.PlaylistTracksInfo, error) {
+	accountCtx, err := contextWithPlaylistAccount(ctx, csService.spotifyAuth, userID, basePlaylist.Provider, basePlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	trackData, err := csService.trackAggregator.AggregatePlaylistData(accountCtx, userID, basePlaylist.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate track data: %w", err)
+	}
+
+	if !childPlaylist.IsMerge() {
+		return trackData, nil
+	}
+
+	sourceData, err := csService.trackAggregator.AggregateMergedPlaylistData(accountCtx, userID, childPlaylist.SourceBasePlaylistIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate sources of child playlist %s: %w", childPlaylist.ID, err)
+	}
+
+	return models.MergePlaylistTracks(trackData, sourceData), nil
+}
+
+// addSpotifyTrackCount is best-effort: when the playlist can't be read the count of the last sync is kept
+func (csService *ChildPlaylistStatsService) addSpotifyTrackCount(ctx context.Context, userID string, childPlaylist *models.ChildPlaylist, stats *models.ChildPlaylistStats) {
+	accountCtx, err := contextWithPlaylistAccount(ctx, csService.spotifyAuth, userID, childPlaylist.Provider, childPlaylist.SpotifyIntegrationID)
+	if err != nil {
+		csService.logger.WarnContext(ctx, "failed to load spotify account for child playlist stats", "child_playlist_id", childPlaylist.ID, "error", err.Error())
+		return
+	}
+
+	playlist, err := csService.musicProvider.GetPlaylist(accountCtx, childPlaylist.SpotifyPlaylistID)
+	if err != nil {
+		csService.logger.WarnContext(ctx, "failed to get spotify playlist for child playlist stats", "child_playlist_id", childPlaylist.ID, "error", err.Error())
+		return
+	}
+
+	if playlist.Tracks != nil {
+		stats.TrackCount = playlist.Tracks.Total
+	}
+}
+
+func buildChildPlaylistStats(childPlaylist *models.ChildPlaylist, trackData *models.PlaylistTracksInfo, routedTrackURIs []string) *models.ChildPlaylistStats {
+	routed := make(map[string]bool, len(routedTrackURIs))
+	for _, trackURI := range routedTrackURIs {
+		routed[trackURI] = true
+	}
+
+	genres := map[string]int{}
+	artists := map[string]int{}
+	for _, track := range trackData.Tracks {
+		if !routed[track.URI] {
+			continue
+		}
+
+		for _, genre := range track.AllGenres {
+			genres[genre]++
+		}
+		for _, artist := range track.ArtistNames {
+			artists[artist]++
+		}
+	}
+
+	stats := &models.ChildPlaylistStats{
+		ChildPlaylistID: childPlaylist.ID,
+		BaseTrackCount:  len(trackData.Tracks),
+		MatchedTracks:   len(routedTrackURIs),
+		TopGenres:       topStatCounts(genres),
+		TopArtists:      topStatCounts(artists),
+	}
+	if stats.BaseTrackCount > 0 {
+		stats.MatchRate = float64(stats.MatchedTracks) / float64(stats.BaseTrackCount)
+	}
+
+	return stats
+}
+
+// topStatCounts returns the most frequent values, ties broken by name so the order is stable
+func topStatCounts(counts map[string]int) []models.StatCount {
+	statCounts := make([]models.StatCount, 0, len(counts))
+	for name, count := range counts {
+		statCounts = append(statCounts, models.StatCount{Name: name, Count: count})
+	}
+
+	slices.SortFunc(statCounts, func(a, b models.StatCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return statCounts[:min(len(statCounts), models.CHILD_PLAYLIST_STATS_TOP_SIZE)]
+}
+
+func (csService *ChildPlaylistStatsService) getCached(key string) (*models.ChildPlaylistStats, bool) {
+	csService.cacheMu.Lock()
+	defer csService.cacheMu.Unlock()
+
+	entry, ok := csService.cache[key]
+	if !ok {
+		return nil, false
+	}
+
+	if csService.now().After(entry.expiresAt) {
+		delete(csService.cache, key)
+		return nil, false
+	}
+
+	return entry.stats, true
+}
+
+func (csService *ChildPlaylistStatsService) setCached(key string, stats *models.ChildPlaylistStats) {
+	csService.cacheMu.Lock()
+	defer csService.cacheMu.Unlock()
+
+	now := csService.now()
+	for cachedKey, entry := range csService.cache {
+		if now.After(entry.expiresAt) {
+			delete(csService.cache, cachedKey)
+		}
+	}
+
+	csService.cache[key] = cachedChildPlaylistStats{stats: stats, expiresAt: now.Add(CHILD_PLAYLIST_STATS_CACHE_TTL)}
+}
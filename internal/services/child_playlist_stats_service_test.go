@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrackAggregator hands back the same tracks for every base playlist, counting the reads
+type fakeTrackAggregator struct {
+	tracks *models.PlaylistTracksInfo
+	calls  int
+}
+
+func (f *fakeTrackAggregator) AggregatePlaylistData(_ context.Context, _, _ string) (*models.PlaylistTracksInfo, error) {
+	f.calls++
+	return f.tracks, nil
+}
+
+func (f *fakeTrackAggregator) AggregateMergedPlaylistData(_ context.Context, _ string, _ []string) (*models.PlaylistTracksInfo, error) {
+	f.calls++
+	return f.tracks, nil
+}
+
+func (f *fakeTrackAggregator) StreamPlaylistData(_ context.Context, _, _ string, pages chan<- *models.PlaylistTracksInfo) error {
+	f.calls++
+	pages <- f.tracks
+	close(pages)
+	return nil
+}
+
+type childPlaylistStatsServiceMocks struct {
+	syncEventRepo   *repositoryMocks.MockSyncEventRepository
+	spotifyClient   *spotifyClientMocks.MockSpotifyAPI
+	trackAggregator *fakeTrackAggregator
+}
+
+func statsTrack(uri, artist string, genres ...string) models.TrackInfo {
+	return models.TrackInfo{URI: uri, ArtistNames: []string{artist}, AllGenres: genres}
+}
+
+func setupChildPlaylistStatsService(t *testing.T) (*ChildPlaylistStatsService, childPlaylistStatsServiceMocks) {
+	ctrl := setupMockController(t)
+
+	mocks := childPlaylistStatsServiceMocks{
+		syncEventRepo: repositoryMocks.NewMockSyncEventRepository(ctrl),
+		spotifyClient: spotifyClientMocks.NewMockSpotifyAPI(ctrl),
+		trackAggregator: &fakeTrackAggregator{tracks: &models.PlaylistTracksInfo{
+			PlaylistID: "base123",
+			UserID:     "user123",
+			Tracks: []models.TrackInfo{
+				statsTrack("spotify:track:1", "Band A", "rock", "indie"),
+				statsTrack("spotify:track:2", "Band B", "rock"),
+				statsTrack("spotify:track:3", "Band A", "indie"),
+				statsTrack("spotify:track:4", "Singer", "pop"),
+			},
+		}},
+	}
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(ctrl)
+	blocklistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "base123", "user123").Return(nil, nil).AnyTimes()
+
+	basePlaylistService := &fakeBasePlaylistService{basePlaylist: testfixtures.NewBasePlaylist().WithID("base123").Build()}
+	childPlaylistService := &fakeChildPlaylistService{children: []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("rock").WithBasePlaylistID("base123").WithSpotifyPlaylistID("spotify_rock").WithPriority(1).
+			WithFilters(testfixtures.NewFilters().WithGenres([]string{"rock"}, nil).Build()).Build(),
+		testfixtures.NewChildPlaylist().WithID("other").WithBasePlaylistID("base123").WithSpotifyPlaylistID("spotify_other").WithPriority(2).Fallback().Build(),
+	}}
+
+	service := NewChildPlaylistStatsService(
+		basePlaylistService,
+		childPlaylistService,
+		NewSyncEventService(mocks.syncEventRepo, createTestLogger()),
+		mocks.trackAggregator,
+		NewTrackRouterService(blocklistRepo, createTestLogger()),
+		mocks.spotifyClient,
+		&fakeSpotifyAuthProvider{},
+		createTestLogger(),
+	)
+	return service, mocks
+}
+
+func TestChildPlaylistStatsService_GetChildPlaylistStats(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupChildPlaylistStatsService(t)
+	ctx := context.Background()
+
+	completedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mocks.syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "base123").Return([]*models.SyncEvent{testfixtures.NewSyncEvent().
+		WithUserID("user123").
+		WithStatus(models.SyncStatusCompleted).
+		WithCompletedAt(completedAt).
+		WithChildSyncResults(models.ChildSyncResult{ChildPlaylistID: "rock", TracksAdded: 2}).
+		Build()}, nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(ctx, "spotify_rock").Return(&spotifyclient.SpotifyPlaylist{
+		ID:     "spotify_rock",
+		Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 3},
+	}, nil)
+
+	stats, err := service.GetChildPlaylistStats(ctx, "user123", "rock")
+
+	assert.NoError(err)
+	assert.Equal("rock", stats.ChildPlaylistID)
+	assert.Equal(3, stats.TrackCount)
+	assert.Equal(&completedAt, stats.LastSyncedAt)
+	assert.Equal(4, stats.BaseTrackCount)
+	assert.Equal(2, stats.MatchedTracks)
+	assert.Equal(0.5, stats.MatchRate)
+	assert.Equal([]models.StatCount{{Name: "rock", Count: 2}, {Name: "indie", Count: 1}}, stats.TopGenres)
+	assert.Equal([]models.StatCount{{Name: "Band A", Count: 1}, {Name: "Band B", Count: 1}}, stats.TopArtists)
+}
+
+func TestChildPlaylistStatsService_GetChildPlaylistStats_SpotifyFailureFallsBackToLastSync(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupChildPlaylistStatsService(t)
+	ctx := context.Background()
+
+	mocks.syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "base123").Return([]*models.SyncEvent{testfixtures.NewSyncEvent().
+		WithUserID("user123").
+		WithStatus(models.SyncStatusCompleted).
+		WithChildSyncResults(models.ChildSyncResult{ChildPlaylistID: "rock", TracksAdded: 2}, models.ChildSyncResult{ChildPlaylistID: "other", TracksAdded: 7}).
+		Build()}, nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(ctx, "spotify_other").Return(nil, errors.New("spotify down"))
+
+	stats, err := service.GetChildPlaylistStats(ctx, "user123", "other")
+
+	assert.NoError(err)
+	assert.Equal(7, stats.TrackCount)
+	assert.Equal(2, stats.MatchedTracks)
+	assert.Equal([]models.StatCount{{Name: "indie", Count: 1}, {Name: "pop", Count: 1}}, stats.TopGenres)
+}
+
+func TestChildPlaylistStatsService_GetChildPlaylistStats_NotFound(t *testing.T) {
+	assert := require.New(t)
+	service, _ := setupChildPlaylistStatsService(t)
+
+	stats, err := service.GetChildPlaylistStats(context.Background(), "user123", "unknown")
+
+	assert.Nil(stats)
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+}
+
+func TestChildPlaylistStatsService_GetChildPlaylistStats_Cache(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupChildPlaylistStatsService(t)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	// Each dependency is hit once per cache fill
+	mocks.syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "base123").Return(nil, nil).Times(2)
+	mocks.spotifyClient.EXPECT().GetPlaylist(ctx, "spotify_rock").Return(&spotifyclient.SpotifyPlaylist{
+		Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 3},
+	}, nil).Times(2)
+
+	first, err := service.GetChildPlaylistStats(ctx, "user123", "rock")
+	assert.NoError(err)
+
+	now = now.Add(CHILD_PLAYLIST_STATS_CACHE_TTL - time.Second)
+	second, err := service.GetChildPlaylistStats(ctx, "user123", "rock")
+	assert.NoError(err)
+	assert.Same(first, second)
+	assert.Equal(1, mocks.trackAggregator.calls)
+
+	now = now.Add(2 * time.Second)
+	third, err := service.GetChildPlaylistStats(ctx, "user123", "rock")
+	assert.NoError(err)
+	assert.NotSame(first, third)
+	assert.Equal(2, mocks.trackAggregator.calls)
+}
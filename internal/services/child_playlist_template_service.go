@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=child_playlist_template_service.go -destination=mocks/mock_child_playlist_template_service.go -package=mocks
+
+// ChildPlaylistTemplateServicer manages the built-in and user templates, and creates their child
+// playlists on a base playlist in one call
+type ChildPlaylistTemplateServicer interface {
+	ListTemplates(ctx context.Context, userID string) ([]*models.ChildPlaylistTemplate, error)
+	GetTemplate(ctx context.Context, userID, id string) (*models.ChildPlaylistTemplate, error)
+	CreateTemplate(ctx context.Context, userID string, input *models.CreateChildPlaylistTemplateRequest) (*models.ChildPlaylistTemplate, error)
+	DeleteTemplate(ctx context.Context, userID, id string) error
+	InstantiateTemplate(ctx context.Context, userID, id, basePlaylistID string) ([]*models.ChildPlaylist, error)
+}
+
+type ChildPlaylistTemplateService struct {
+	templateRepo         repositories.ChildPlaylistTemplateRepository
+	childPlaylistService ChildPlaylistServicer
+	logger               *slog.Logger
+}
+
+func NewChildPlaylistTemplateService(
+	templateRepo repositories.ChildPlaylistTemplateRepository,
+	childPlaylistService ChildPlaylistServicer,
+	logger *slog.Logger,
+) *ChildPlaylistTemplateService {
+	return &ChildPlaylistTemplateService{
+		templateRepo:         templateRepo,
+		childPlaylistService: childPlaylistService,
+		logger:               logger.With("component", "ChildPlaylistTemplateService"),
+	}
+}
+
+// ListTemplates returns the built-in templates followed by the ones created by the user
+func (ctService *ChildPlaylistTemplateService) ListTemplates(ctx context.Context, userID string) ([]*models.ChildPlaylistTemplate, error) {
+	userTemplates, err := ctService.templateRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve child playlist templates: %w", err)
+	}
+
+	return append(models.BuiltInChildPlaylistTemplates(), userTemplates...), nil
+}
+
+func (ctService *ChildPlaylistTemplateService) GetTemplate(ctx context.Context, userID, id string) (*models.ChildPlaylistTemplate, error) {
+	if template := builtInTemplate(id); template != nil {
+		return template, nil
+	}
+
+	template, err := ctService.templateRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve child playlist template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (ctService *ChildPlaylistTemplateService) CreateTemplate(ctx context.Context, userID string, input *models.CreateChildPlaylistTemplateRequest) (*models.ChildPlaylistTemplate, error) {
+	if err := validateTemplateChildren(input.Children, ErrInvalidChildPlaylistTemplate); err != nil {
+		return nil, err
+	}
+
+	template, err := ctService.templateRepo.Create(ctx, &models.ChildPlaylistTemplate{
+		UserID:      userID,
+		Name:        input.Name,
+		Description: input.Description,
+		Children:    input.Children,
+	})
+	if err != nil {
+		ctService.logger.ErrorContext(ctx, "failed to create child playlist template", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to create child playlist template: %w", err)
+	}
+
+	ctService.logger.InfoContext(ctx, "child playlist template created", "id", template.ID, "user_id", userID)
+	return template, nil
+}
+
+func (ctService *ChildPlaylistTemplateService) DeleteTemplate(ctx context.Context, userID, id string) error {
+	if builtInTemplate(id) != nil {
+		return ErrBuiltInTemplateReadOnly
+	}
+
+	if err := ctService.templateRepo.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to delete child playlist template: %w", err)
+	}
+
+	ctService.logger.InfoContext(ctx, "child playlist template deleted", "id", id, "user_id", userID)
+	return nil
+}
+
+// InstantiateTemplate creates every child playlist of the template on the base playlist, in the
+// template order so they keep it as routing priority. When one fails the ones already created are
+// deleted again, so the base playlist is left as it was
+func (ctService *ChildPlaylistTemplateService) InstantiateTemplate(ctx context.Context, userID, id, basePlaylistID string) ([]*models.ChildPlaylist, error) {
+	template, err := ctService.GetTemplate(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctService.logger.InfoContext(ctx, "instantiating child playlist template",
+		"template_id", template.ID,
+		"base_playlist_id", basePlaylistID,
+		"user_id", userID,
+		"children", len(template.Children),
+	)
+
+	childPlaylists := make([]*models.ChildPlaylist, 0, len(template.Children))
+	for _, child := range template.Children {
+		childPlaylist, err := ctService.childPlaylistService.CreateChildPlaylist(ctx, userID, basePlaylistID, child.ToCreateRequest())
+		if err != nil {
+			ctService.logger.ErrorContext(ctx, "failed to create child playlist from template",
+				"template_id", template.ID,
+				"base_playlist_id", basePlaylistID,
+				"child_name", child.Name,
+				"error", err.Error(),
+			)
+			ctService.removeChildPlaylists(ctx, userID, childPlaylists)
+			return nil, fmt.Errorf("failed to create child playlist %q: %w", child.Name, err)
+		}
+
+		childPlaylists = append(childPlaylists, childPlaylist)
+	}
+
+	ctService.logger.InfoContext(ctx, "child playlist template instantiated", "template_id", template.ID, "base_playlist_id", basePlaylistID)
+	return childPlaylists, nil
+}
+
+// removeChildPlaylists undoes a partial instantiation, a child that can't be removed is only logged
+func (ctService *ChildPlaylistTemplateService) removeChildPlaylists(ctx context.Context, userID string, childPlaylists []*models.ChildPlaylist) {
+	for _, childPlaylist := range childPlaylists {
+		if err := ctService.childPlaylistService.DeleteChildPlaylist(ctx, childPlaylist.ID, userID); err != nil {
+			ctService.logger.WarnContext(ctx, "failed to remove child playlist of failed template instantiation",
+				"child_playlist_id", childPlaylist.ID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+func builtInTemplate(id string) *models.ChildPlaylistTemplate {
+	for _, template := range models.BuiltInChildPlaylistTemplates() {
+		if template.ID == id {
+			return template
+		}
+	}
+
+	return nil
+}
+
+// validateTemplateChildren checks the filter rules of every child and that at most one is a fallback,
+// wrapping invalidErr into the errors returned
+func validateTemplateChildren(children []models.TemplateChild, invalidErr error) error {
+	fallbacks := 0
+	for i := range children {
+		child := &children[i]
+		if child.IsFallback {
+			fallbacks++
+		}
+
+		if child.FilterRules == nil {
+			continue
+		}
+
+		if err := child.FilterRules.Validate(); err != nil {
+			return fmt.Errorf("%w: child %q: %s", invalidErr, child.Name, err.Error())
+		}
+		if err := child.FilterRules.ResolveDurations(); err != nil {
+			return fmt.Errorf("%w: child %q: %s", invalidErr, child.Name, err.Error())
+		}
+	}
+
+	if fallbacks > 1 {
+		return fmt.Errorf("%w: only one child can be the fallback", invalidErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChildPlaylistService records the child playlists created, updated, reordered and deleted by
+// the template, clone and routing config services, failing the creation of the child named failOn
+type fakeChildPlaylistService struct {
+	ChildPlaylistServicer
+
+	failOn    string
+	err       error
+	children  []*models.ChildPlaylist
+	created   []*models.CreateChildPlaylistRequest
+	updated   map[string]*models.UpdateChildPlaylistRequest
+	reordered []string
+	deleted   []string
+}
+
+func (f *fakeChildPlaylistService) UpdateChildPlaylist(_ context.Context, id, _ string, input *models.UpdateChildPlaylistRequest) (*models.ChildPlaylist, error) {
+	if f.updated == nil {
+		f.updated = map[string]*models.UpdateChildPlaylistRequest{}
+	}
+	f.updated[id] = input
+
+	return testfixtures.NewChildPlaylist().WithID(id).Build(), nil
+}
+
+func (f *fakeChildPlaylistService) ReorderChildPlaylists(_ context.Context, _, _ string, childPlaylistIDs []string) ([]*models.ChildPlaylist, error) {
+	f.reordered = childPlaylistIDs
+	return nil, nil
+}
+
+func (f *fakeChildPlaylistService) GetChildPlaylist(_ context.Context, id, _ string) (*models.ChildPlaylist, error) {
+	for _, child := range f.children {
+		if child.ID == id {
+			return child, nil
+		}
+	}
+	return nil, repositories.ErrChildPlaylistNotFound
+}
+
+func (f *fakeChildPlaylistService) GetChildPlaylistsByBasePlaylistID(_ context.Context, _, _ string) ([]*models.ChildPlaylist, error) {
+	return f.children, nil
+}
+
+func (f *fakeChildPlaylistService) CreateChildPlaylist(_ context.Context, _, _ string, input *models.CreateChildPlaylistRequest) (*models.ChildPlaylist, error) {
+	if input.Name == f.failOn {
+		return nil, f.err
+	}
+
+	f.created = append(f.created, input)
+	return testfixtures.NewChildPlaylist().WithID(fmt.Sprintf("child%d", len(f.created))).WithName(input.Name).Build(), nil
+}
+
+func (f *fakeChildPlaylistService) DeleteChildPlaylist(_ context.Context, id, _ string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func setupChildPlaylistTemplateService(t *testing.T) (*ChildPlaylistTemplateService, *repositoryMocks.MockChildPlaylistTemplateRepository, *fakeChildPlaylistService) {
+	ctrl := setupMockController(t)
+
+	templateRepo := repositoryMocks.NewMockChildPlaylistTemplateRepository(ctrl)
+	childPlaylistService := &fakeChildPlaylistService{}
+
+	return NewChildPlaylistTemplateService(templateRepo, childPlaylistService, createTestLogger()), templateRepo, childPlaylistService
+}
+
+func TestChildPlaylistTemplateService_ListTemplates(t *testing.T) {
+	assert := require.New(t)
+	service, templateRepo, _ := setupChildPlaylistTemplateService(t)
+
+	userTemplate := &models.ChildPlaylistTemplate{ID: "template1", UserID: "user123", Name: "Party"}
+	templateRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return([]*models.ChildPlaylistTemplate{userTemplate}, nil)
+
+	templates, err := service.ListTemplates(context.Background(), "user123")
+
+	assert.NoError(err)
+	assert.Len(templates, 4)
+	assert.Equal(models.TemplateIDWorkout, templates[0].ID)
+	assert.Equal(models.TemplateIDChill, templates[1].ID)
+	assert.Equal(models.TemplateIDByDecade, templates[2].ID)
+	assert.True(templates[2].BuiltIn)
+	assert.Equal(userTemplate, templates[3])
+}
+
+func TestChildPlaylistTemplateService_GetTemplate(t *testing.T) {
+	assert := require.New(t)
+	service, templateRepo, _ := setupChildPlaylistTemplateService(t)
+
+	// Built-in templates never hit the repository
+	template, err := service.GetTemplate(context.Background(), "user123", models.TemplateIDByDecade)
+	assert.NoError(err)
+	assert.Equal("By decade", template.Name)
+	assert.Len(template.Children, 6)
+	assert.Equal("70s", template.Children[0].Name)
+	assert.Equal(1970.0, *template.Children[0].FilterRules.ReleaseYear.Min)
+	assert.Equal(1979.0, *template.Children[0].FilterRules.ReleaseYear.Max)
+	assert.Equal("2020s", template.Children[5].Name)
+
+	templateRepo.EXPECT().GetByID(gomock.Any(), "missing", "user123").Return(nil, repositories.ErrChildPlaylistTemplateNotFound)
+
+	_, err = service.GetTemplate(context.Background(), "user123", "missing")
+	assert.ErrorIs(err, repositories.ErrChildPlaylistTemplateNotFound)
+}
+
+func TestChildPlaylistTemplateService_CreateTemplate(t *testing.T) {
+	minEnergy := 0.8
+	maxEnergy := 2.0
+
+	tests := []struct {
+		name          string
+		children      []models.TemplateChild
+		expectCreate  bool
+		expectedError error
+	}{
+		{
+			name: "valid template",
+			children: []models.TemplateChild{
+				{Name: "Bangers", FilterRules: &models.MetadataFilters{Energy: &models.RangeFilter{Min: &minEnergy}}},
+				{Name: "Rest", IsFallback: true},
+			},
+			expectCreate: true,
+		},
+		{
+			name: "invalid filter rules",
+			children: []models.TemplateChild{
+				{Name: "Broken", FilterRules: &models.MetadataFilters{Energy: &models.RangeFilter{Min: &maxEnergy, Max: &minEnergy}}},
+			},
+			expectedError: ErrInvalidChildPlaylistTemplate,
+		},
+		{
+			name: "several fallbacks",
+			children: []models.TemplateChild{
+				{Name: "Rest", IsFallback: true},
+				{Name: "Other Rest", IsFallback: true},
+			},
+			expectedError: ErrInvalidChildPlaylistTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, templateRepo, _ := setupChildPlaylistTemplateService(t)
+
+			if tt.expectCreate {
+				templateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, template *models.ChildPlaylistTemplate) (*models.ChildPlaylistTemplate, error) {
+						assert.Equal("user123", template.UserID)
+						assert.Equal("Party", template.Name)
+						template.ID = "template1"
+						return template, nil
+					})
+			}
+
+			template, err := service.CreateTemplate(context.Background(), "user123", &models.CreateChildPlaylistTemplateRequest{
+				Name:     "Party",
+				Children: tt.children,
+			})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(err, tt.expectedError)
+				assert.Nil(template)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal("template1", template.ID)
+			assert.Len(template.Children, len(tt.children))
+		})
+	}
+}
+
+func TestChildPlaylistTemplateService_DeleteTemplate(t *testing.T) {
+	assert := require.New(t)
+	service, templateRepo, _ := setupChildPlaylistTemplateService(t)
+
+	err := service.DeleteTemplate(context.Background(), "user123", models.TemplateIDWorkout)
+	assert.ErrorIs(err, ErrBuiltInTemplateReadOnly)
+
+	templateRepo.EXPECT().Delete(gomock.Any(), "template1", "user123").Return(nil)
+	assert.NoError(service.DeleteTemplate(context.Background(), "user123", "template1"))
+}
+
+func TestChildPlaylistTemplateService_InstantiateTemplate(t *testing.T) {
+	assert := require.New(t)
+	service, _, childPlaylistService := setupChildPlaylistTemplateService(t)
+
+	childPlaylists, err := service.InstantiateTemplate(context.Background(), "user123", models.TemplateIDWorkout, "base123")
+
+	assert.NoError(err)
+	assert.Len(childPlaylists, 2)
+	assert.Equal("child1", childPlaylists[0].ID)
+	assert.Equal("Workout", childPlaylists[0].Name)
+	assert.Equal("Cool Down", childPlaylists[1].Name)
+
+	assert.Equal(0.7, *childPlaylistService.created[0].FilterRules.Energy.Min)
+	assert.Equal(120.0, *childPlaylistService.created[0].FilterRules.Tempo.Min)
+	assert.Empty(childPlaylistService.deleted)
+}
+
+func TestChildPlaylistTemplateService_InstantiateTemplate_RemovesCreatedOnFailure(t *testing.T) {
+	assert := require.New(t)
+	service, _, childPlaylistService := setupChildPlaylistTemplateService(t)
+	childPlaylistService.failOn = "Acoustic"
+	childPlaylistService.err = errors.New("spotify error")
+
+	childPlaylists, err := service.InstantiateTemplate(context.Background(), "user123", models.TemplateIDChill, "base123")
+
+	assert.Nil(childPlaylists)
+	assert.ErrorContains(err, `failed to create child playlist "Acoustic"`)
+	assert.Equal([]string{"child1"}, childPlaylistService.deleted)
+}
+
+func TestChildPlaylistTemplateService_InstantiateTemplate_BasePlaylistNotFound(t *testing.T) {
+	assert := require.New(t)
+	service, _, childPlaylistService := setupChildPlaylistTemplateService(t)
+	childPlaylistService.failOn = "Chill"
+	childPlaylistService.err = repositories.ErrBasePlaylistNotFound
+
+	_, err := service.InstantiateTemplate(context.Background(), "user123", models.TemplateIDChill, "missing")
+
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+	assert.Empty(childPlaylistService.deleted)
+}
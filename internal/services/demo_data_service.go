@@ -0,0 +1,251 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=demo_data_service.go -destination=mocks/mock_demo_data_service.go -package=mocks
+
+const (
+	// The spotify mock accepts any token, these only have to be stored so the integration looks linked
+	DEMO_ACCESS_TOKEN  = "demo-access-token"
+	DEMO_REFRESH_TOKEN = "demo-refresh-token"
+)
+
+type DemoDataServicer interface {
+	SeedDemoData(ctx context.Context) (*models.DemoData, error)
+}
+
+// DemoDataService provisions a user with playlists and sync history for local development, linked
+// to the spotify account the spotify client is pointed at. It is meant for the spotify mock, the
+// integration it stores has tokens only the mock accepts
+type DemoDataService struct {
+	userService               UserServicer
+	spotifyIntegrationService SpotifyIntegrationServicer
+	basePlaylistService       BasePlaylistServicer
+	childPlaylistService      ChildPlaylistServicer
+	syncEventService          SyncEventServicer
+	spotifyClient             spotifyclient.SpotifyAPI
+	logger                    *slog.Logger
+	now                       func() time.Time
+}
+
+func NewDemoDataService(
+	userService UserServicer,
+	spotifyIntegrationService SpotifyIntegrationServicer,
+	basePlaylistService BasePlaylistServicer,
+	childPlaylistService ChildPlaylistServicer,
+	syncEventService SyncEventServicer,
+	spotifyClient spotifyclient.SpotifyAPI,
+	logger *slog.Logger,
+) *DemoDataService {
+	return &DemoDataService{
+		userService:               userService,
+		spotifyIntegrationService: spotifyIntegrationService,
+		basePlaylistService:       basePlaylistService,
+		childPlaylistService:      childPlaylistService,
+		syncEventService:          syncEventService,
+		spotifyClient:             spotifyClient,
+		logger:                    logger.With("component", "DemoDataService"),
+		now:                       time.Now,
+	}
+}
+
+// SeedDemoData links the spotify user to a demo user, creating it unless it logged in already, and
+// turns their two largest playlists into base playlists with child playlists and past syncs. Users
+// that already have base playlists are left as they are
+func (s *DemoDataService) SeedDemoData(ctx context.Context) (*models.DemoData, error) {
+	spotifyCtx := requestcontext.ContextWithSpotifyAuth(ctx, &models.SpotifyIntegration{AccessToken: DEMO_ACCESS_TOKEN})
+	profile, err := s.spotifyClient.GetUserProfile(spotifyCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spotify profile: %w", err)
+	}
+
+	user, integration, err := s.seedUser(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.basePlaylistService.GetBasePlaylistsByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlists: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, ErrDemoDataExists
+	}
+
+	accountCtx := requestcontext.ContextWithSpotifyAuth(ctx, integration)
+	spotifyPlaylists, err := s.spotifyClient.GetAllUserPlaylists(accountCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spotify playlists: %w", err)
+	}
+
+	demo := &models.DemoData{User: user}
+	for i, spotifyPlaylist := range largestPlaylists(spotifyPlaylists, len(models.DemoChildPlaylists())) {
+		basePlaylist, err := s.basePlaylistService.CreateBasePlaylist(ctx, user.ID, &models.CreateBasePlaylistRequest{
+			Name:                 spotifyPlaylist.Name,
+			SpotifyPlaylistID:    spotifyPlaylist.ID,
+			SpotifyIntegrationID: integration.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create base playlist: %w", err)
+		}
+		demo.BasePlaylists = append(demo.BasePlaylists, basePlaylist)
+
+		var children []*models.ChildPlaylist
+		for _, child := range models.DemoChildPlaylists()[i] {
+			childPlaylist, err := s.childPlaylistService.CreateChildPlaylist(accountCtx, user.ID, basePlaylist.ID, child.ToCreateRequest())
+			if err != nil {
+				return nil, fmt.Errorf("failed to create child playlist: %w", err)
+			}
+			children = append(children, childPlaylist)
+		}
+		demo.ChildPlaylists = append(demo.ChildPlaylists, children...)
+
+		for _, syncEvent := range s.demoSyncHistory(user.ID, basePlaylist.ID, children, i) {
+			created, err := s.syncEventService.CreateSyncEvent(ctx, syncEvent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create sync event: %w", err)
+			}
+			demo.SyncEvents = append(demo.SyncEvents, created)
+		}
+	}
+
+	s.logger.InfoContext(ctx, "demo data seeded", "user_id", user.ID, "base_playlists", len(demo.BasePlaylists), "child_playlists", len(demo.ChildPlaylists), "sync_events", len(demo.SyncEvents))
+	return demo, nil
+}
+
+// seedUser returns the user the spotify account is linked to, creating it along with the integration
+// the first time
+func (s *DemoDataService) seedUser(ctx context.Context, profile *spotifyclient.SpotifyUserProfile) (*models.User, *models.SpotifyIntegration, error) {
+	integration, err := s.spotifyIntegrationService.GetIntegrationBySpotifyID(ctx, profile.ID)
+	if err != nil && !errors.Is(err, repositories.ErrSpotifyIntegrationNotFound) {
+		return nil, nil, fmt.Errorf("failed to get spotify integration: %w", err)
+	}
+
+	if integration != nil {
+		user, err := s.userService.GetUserByID(ctx, integration.UserID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		return user, integration, nil
+	}
+
+	user, err := s.userService.CreateUser(ctx, &models.User{Email: profile.Email, Name: profile.Name})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	integration, err = s.spotifyIntegrationService.CreateOrUpdateIntegration(ctx, user.ID, &models.SpotifyIntegration{
+		SpotifyID:    profile.ID,
+		AccessToken:  DEMO_ACCESS_TOKEN,
+		RefreshToken: DEMO_REFRESH_TOKEN,
+		TokenType:    "Bearer",
+		ExpiresAt:    s.now().Add(time.Hour),
+		DisplayName:  profile.Name,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create spotify integration: %w", err)
+	}
+
+	return user, integration, nil
+}
+
+// demoSyncHistory builds past syncs of the base playlist: the first one seeded gets a failure
+// between two completed syncs, the others a completed sync followed by one held for confirmation
+func (s *DemoDataService) demoSyncHistory(userID, basePlaylistID string, children []*models.ChildPlaylist, index int) []*models.SyncEvent {
+	now := s.now().UTC()
+	childIDs := make([]string, len(children))
+	for i, child := range children {
+		childIDs[i] = child.ID
+	}
+
+	event := func(status models.SyncStatus, startedAt time.Time, tracksProcessed int) *models.SyncEvent {
+		completedAt := startedAt.Add(time.Duration(2+tracksProcessed/40) * time.Second)
+		results := make([]models.ChildSyncResult, len(children))
+		for i, child := range children {
+			results[i] = models.ChildSyncResult{
+				ChildPlaylistID:   child.ID,
+				SpotifyPlaylistID: child.SpotifyPlaylistID,
+				Status:            status,
+				TracksAdded:       (tracksProcessed / (i + 2)) % 20,
+				TracksRemoved:     i % 3,
+				APIRequests:       2,
+			}
+		}
+
+		return &models.SyncEvent{
+			UserID:           userID,
+			BasePlaylistID:   basePlaylistID,
+			ChildPlaylistIDs: childIDs,
+			Status:           status,
+			StartedAt:        startedAt,
+			CompletedAt:      &completedAt,
+			TracksProcessed:  tracksProcessed,
+			TracksUnmatched:  tracksProcessed / 10,
+			TotalAPIRequests: 3 + 2*len(children),
+			ChildSyncResults: results,
+		}
+	}
+
+	if index == 0 {
+		failed := event(models.SyncStatusFailed, now.AddDate(0, 0, -3), 0)
+		errorMessage := "failed to fetch base playlist tracks: spotify returned status 503"
+		failed.ErrorMessage = &errorMessage
+		failed.ChildSyncResults = nil
+		failed.TotalAPIRequests = 1
+
+		return []*models.SyncEvent{
+			event(models.SyncStatusCompleted, now.AddDate(0, 0, -7), 96),
+			failed,
+			event(models.SyncStatusCompleted, now.AddDate(0, 0, -1), 120),
+		}
+	}
+
+	held := event(models.SyncStatusNeedsConfirmation, now.Add(-2*time.Hour), 30)
+	heldMessage := "sync held back for confirmation"
+	held.ErrorMessage = &heldMessage
+	held.ChildSyncResults = nil
+	if len(children) > 0 {
+		held.Anomalies = []models.SyncAnomaly{{
+			Type:            models.SyncAnomalyChildTrackDrop,
+			ChildPlaylistID: children[0].ID,
+			PreviousCount:   24,
+			NewCount:        3,
+			Message:         fmt.Sprintf("child playlist %q would drop from %d to %d tracks", children[0].Name, 24, 3),
+		}}
+	}
+
+	return []*models.SyncEvent{
+		event(models.SyncStatusCompleted, now.AddDate(0, 0, -5), 30),
+		held,
+	}
+}
+
+// largestPlaylists returns up to limit playlists, the ones with the most tracks first
+func largestPlaylists(playlists []*spotifyclient.SpotifyPlaylist, limit int) []*spotifyclient.SpotifyPlaylist {
+	sorted := slices.Clone(playlists)
+	slices.SortStableFunc(sorted, func(a, b *spotifyclient.SpotifyPlaylist) int {
+		return cmp.Compare(playlistTrackCount(b), playlistTrackCount(a))
+	})
+
+	return sorted[:min(limit, len(sorted))]
+}
+
+func playlistTrackCount(playlist *spotifyclient.SpotifyPlaylist) int {
+	if playlist.Tracks == nil {
+		return 0
+	}
+	return playlist.Tracks.Total
+}
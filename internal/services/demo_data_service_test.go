@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repoMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type demoDataTestMocks struct {
+	userRepo               *repoMocks.MockUserRepository
+	spotifyIntegrationRepo *repoMocks.MockSpotifyIntegrationRepository
+	basePlaylistRepo       *repoMocks.MockBasePlaylistRepository
+	childPlaylistRepo      *repoMocks.MockChildPlaylistRepository
+	syncEventRepo          *repoMocks.MockSyncEventRepository
+	spotifyClient          *spotifyMocks.MockSpotifyAPI
+}
+
+// setupDemoDataService wires the demo data service to real services over mock repositories
+func setupDemoDataService(t *testing.T) (*DemoDataService, demoDataTestMocks) {
+	ctrl := setupMockController(t)
+	logger := createTestLogger()
+
+	m := demoDataTestMocks{
+		userRepo:               repoMocks.NewMockUserRepository(ctrl),
+		spotifyIntegrationRepo: repoMocks.NewMockSpotifyIntegrationRepository(ctrl),
+		basePlaylistRepo:       repoMocks.NewMockBasePlaylistRepository(ctrl),
+		childPlaylistRepo:      repoMocks.NewMockChildPlaylistRepository(ctrl),
+		syncEventRepo:          repoMocks.NewMockSyncEventRepository(ctrl),
+		spotifyClient:          spotifyMocks.NewMockSpotifyAPI(ctrl),
+	}
+
+	service := NewDemoDataService(
+		NewUserService(m.userRepo, logger),
+		NewSpotifyIntegrationService(m.spotifyIntegrationRepo, logger),
+		NewBasePlaylistService(m.basePlaylistRepo, m.childPlaylistRepo, m.spotifyIntegrationRepo, m.spotifyClient, logger),
+		NewChildPlaylistService(m.childPlaylistRepo, m.basePlaylistRepo, m.spotifyIntegrationRepo, m.spotifyClient, nil, logger),
+		NewSyncEventService(m.syncEventRepo, logger),
+		m.spotifyClient,
+		logger,
+	)
+
+	return service, m
+}
+
+func TestDemoDataService_SeedDemoData_Success(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupDemoDataService(t)
+	ctx := context.Background()
+
+	m.spotifyClient.EXPECT().GetUserProfile(gomock.Any()).DoAndReturn(func(ctx context.Context) (*spotifyclient.SpotifyUserProfile, error) {
+		integration, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
+		assert.True(ok)
+		assert.Equal(DEMO_ACCESS_TOKEN, integration.AccessToken)
+		return &spotifyclient.SpotifyUserProfile{ID: "mockuser", Email: "mockuser@example.com", Name: "Mock User"}, nil
+	})
+	m.spotifyIntegrationRepo.EXPECT().GetBySpotifyID(gomock.Any(), "mockuser").Return(nil, repositories.ErrSpotifyIntegrationNotFound)
+	m.userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user *models.User) (*models.User, error) {
+		assert.Equal("mockuser@example.com", user.Email)
+		return &models.User{ID: "user123", Email: user.Email, Name: user.Name}, nil
+	})
+	m.spotifyIntegrationRepo.EXPECT().CreateOrUpdate(gomock.Any(), "user123", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userID string, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error) {
+			assert.Equal("mockuser", integration.SpotifyID)
+			assert.Equal(DEMO_REFRESH_TOKEN, integration.RefreshToken)
+			integration.ID = "integration123"
+			integration.UserID = userID
+			return integration, nil
+		})
+	m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, nil)
+
+	// The two largest playlists become base playlists, the largest first
+	m.spotifyClient.EXPECT().GetAllUserPlaylists(gomock.Any()).Return([]*spotifyclient.SpotifyPlaylist{
+		{ID: "favourites", Name: "Mock Favourites", Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 30}},
+		{ID: "empty", Name: "Empty"},
+		{ID: "library", Name: "Mock Library", Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 120}},
+	}, nil)
+
+	basePlaylists := map[string]*models.BasePlaylist{}
+	m.basePlaylistRepo.EXPECT().Create(gomock.Any(), "user123", gomock.Any(), gomock.Any(), models.DedupeStrategy(""), "integration123", models.MusicProvider("")).DoAndReturn(
+		func(ctx context.Context, userID, name, spotifyPlaylistID string, dedupeStrategy models.DedupeStrategy, integrationID string, provider models.MusicProvider) (*models.BasePlaylist, error) {
+			basePlaylist := &models.BasePlaylist{ID: "base_" + spotifyPlaylistID, UserID: userID, Name: name, SpotifyPlaylistID: spotifyPlaylistID, SpotifyIntegrationID: integrationID}
+			basePlaylists[basePlaylist.ID] = basePlaylist
+			return basePlaylist, nil
+		}).Times(2)
+	m.basePlaylistRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), "user123").DoAndReturn(func(ctx context.Context, id, userID string) (*models.BasePlaylist, error) {
+		return basePlaylists[id], nil
+	}).AnyTimes()
+
+	demoChildren := models.DemoChildPlaylists()
+	childCount := len(demoChildren[0]) + len(demoChildren[1])
+	m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), gomock.Any(), "user123").Return(nil, nil).Times(childCount)
+	m.spotifyClient.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any(), gomock.Any(), false).DoAndReturn(
+		func(ctx context.Context, name, description string, public bool) (*spotifyclient.SpotifyPlaylist, error) {
+			return &spotifyclient.SpotifyPlaylist{ID: "spotify_" + name, Name: name}, nil
+		}).Times(childCount)
+	m.childPlaylistRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fields repositories.CreateChildPlaylistFields) (*models.ChildPlaylist, error) {
+			return &models.ChildPlaylist{
+				ID:                "child_" + fields.Name,
+				UserID:            fields.UserID,
+				BasePlaylistID:    fields.BasePlaylistID,
+				Name:              fields.Name,
+				SpotifyPlaylistID: fields.SpotifyPlaylistID,
+				FilterRules:       fields.FilterRules,
+				IsFallback:        fields.IsFallback,
+			}, nil
+		}).Times(childCount)
+
+	m.syncEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
+		created := *syncEvent
+		created.ID = "sync_event"
+		return &created, nil
+	}).Times(5)
+
+	demo, err := service.SeedDemoData(ctx)
+
+	assert.NoError(err)
+	assert.Equal("user123", demo.User.ID)
+	assert.Len(demo.BasePlaylists, 2)
+	assert.Equal("library", demo.BasePlaylists[0].SpotifyPlaylistID)
+	assert.Equal("favourites", demo.BasePlaylists[1].SpotifyPlaylistID)
+	assert.Len(demo.ChildPlaylists, childCount)
+	assert.Equal("base_library", demo.ChildPlaylists[0].BasePlaylistID)
+	assert.Equal("spotify_[Mock Library] > Dance Floor", demo.ChildPlaylists[0].SpotifyPlaylistID)
+
+	statuses := []models.SyncStatus{}
+	for _, syncEvent := range demo.SyncEvents {
+		statuses = append(statuses, syncEvent.Status)
+		assert.True(syncEvent.StartedAt.Before(service.now()))
+	}
+	assert.Equal([]models.SyncStatus{
+		models.SyncStatusCompleted, models.SyncStatusFailed, models.SyncStatusCompleted,
+		models.SyncStatusCompleted, models.SyncStatusNeedsConfirmation,
+	}, statuses)
+	assert.NotNil(demo.SyncEvents[1].ErrorMessage)
+	assert.Len(demo.SyncEvents[2].ChildSyncResults, len(demoChildren[0]))
+	assert.Len(demo.SyncEvents[4].Anomalies, 1)
+}
+
+func TestDemoDataService_SeedDemoData_AlreadySeeded(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupDemoDataService(t)
+
+	m.spotifyClient.EXPECT().GetUserProfile(gomock.Any()).Return(&spotifyclient.SpotifyUserProfile{ID: "mockuser"}, nil)
+	m.spotifyIntegrationRepo.EXPECT().GetBySpotifyID(gomock.Any(), "mockuser").Return(&models.SpotifyIntegration{ID: "integration123", UserID: "user123"}, nil)
+	m.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123"}, nil)
+	m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return([]*models.BasePlaylist{{ID: "base123"}}, nil)
+
+	demo, err := service.SeedDemoData(context.Background())
+
+	assert.ErrorIs(err, ErrDemoDataExists)
+	assert.Nil(demo)
+}
+
+func TestDemoDataService_SeedDemoData_SpotifyUnavailable(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupDemoDataService(t)
+
+	m.spotifyClient.EXPECT().GetUserProfile(gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	demo, err := service.SeedDemoData(context.Background())
+
+	assert.ErrorContains(err, "failed to get spotify profile")
+	assert.Nil(demo)
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/security"
+)
+
+//go:generate mockgen -source=encryption_key_service.go -destination=mocks/mock_encryption_key_service.go -package=mocks
+
+// encryptedValuePrefix marks values encrypted with a user data key, so values
+// stored before encryption was enabled can still be read as plaintext
+const encryptedValuePrefix = "enc:"
+
+type EncryptionKeyServicer interface {
+	EncryptForUser(ctx context.Context, userID, plaintext string) (string, error)
+	DecryptForUser(ctx context.Context, userID, ciphertext string) (string, error)
+	RotateKeys(ctx context.Context) (*models.EncryptionKeyRotation, error)
+}
+
+type EncryptionKeyService struct {
+	userKeyRepo  repositories.UserEncryptionKeyRepository
+	rotationRepo repositories.EncryptionKeyRotationRepository
+	keyring      *security.Keyring
+	logger       *slog.Logger
+}
+
+func NewEncryptionKeyService(
+	userKeyRepo repositories.UserEncryptionKeyRepository,
+	rotationRepo repositories.EncryptionKeyRotationRepository,
+	keyring *security.Keyring,
+	logger *slog.Logger,
+) *EncryptionKeyService {
+	return &EncryptionKeyService{
+		userKeyRepo:  userKeyRepo,
+		rotationRepo: rotationRepo,
+		keyring:      keyring,
+		logger:       logger.With("component", "EncryptionKeyService"),
+	}
+}
+
+func (ekService *EncryptionKeyService) EncryptForUser(ctx context.Context, userID, plaintext string) (string, error) {
+	encryptor, err := ekService.getOrCreateUserEncryptor(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := encryptor.Encrypt(plaintext)
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to encrypt value", "user_id", userID, "error", err.Error())
+		return "", fmt.Errorf("failed to encrypt value: %w", err)
+	}
+
+	return encryptedValuePrefix + ciphertext, nil
+}
+
+func (ekService *EncryptionKeyService) DecryptForUser(ctx context.Context, userID, ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, encryptedValuePrefix) {
+		return ciphertext, nil
+	}
+
+	userKey, err := ekService.userKeyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to retrieve user encryption key", "user_id", userID, "error", err.Error())
+		return "", fmt.Errorf("failed to retrieve encryption key: %w", err)
+	}
+
+	encryptor, err := ekService.unwrapUserKey(userKey)
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to unwrap user encryption key", "user_id", userID, "key_version", userKey.KeyVersion, "error", err.Error())
+		return "", fmt.Errorf("failed to unwrap encryption key: %w", err)
+	}
+
+	plaintext, err := encryptor.Decrypt(strings.TrimPrefix(ciphertext, encryptedValuePrefix))
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to decrypt value", "user_id", userID, "error", err.Error())
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// RotateKeys re-wraps every data key that is not wrapped with the current master key.
+// Encrypted values keep using the same data keys, so no rows besides the keys are rewritten.
+func (ekService *EncryptionKeyService) RotateKeys(ctx context.Context) (*models.EncryptionKeyRotation, error) {
+	currentVersion := ekService.keyring.CurrentVersion()
+	ekService.logger.InfoContext(ctx, "rotating user encryption keys", "to_version", currentVersion)
+
+	outdatedKeys, err := ekService.userKeyRepo.GetOutdated(ctx, currentVersion)
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to retrieve outdated encryption keys", "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve outdated encryption keys: %w", err)
+	}
+
+	rotation := &models.EncryptionKeyRotation{ToVersion: currentVersion}
+	for _, userKey := range outdatedKeys {
+		if err := ekService.rewrapUserKey(ctx, userKey); err != nil {
+			ekService.logger.ErrorContext(ctx, "failed to rotate user encryption key", "id", userKey.ID, "user_id", userKey.UserID, "key_version", userKey.KeyVersion, "error", err.Error())
+			rotation.KeysFailed++
+			continue
+		}
+		rotation.KeysRotated++
+	}
+
+	createdRotation, err := ekService.rotationRepo.Create(ctx, rotation)
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to record encryption key rotation", "error", err.Error())
+		return nil, fmt.Errorf("failed to record key rotation: %w", err)
+	}
+
+	ekService.logger.InfoContext(ctx, "user encryption keys rotated",
+		"to_version", currentVersion,
+		"keys_rotated", createdRotation.KeysRotated,
+		"keys_failed", createdRotation.KeysFailed,
+	)
+	return createdRotation, nil
+}
+
+func (ekService *EncryptionKeyService) rewrapUserKey(ctx context.Context, userKey *models.UserEncryptionKey) error {
+	dataKey, err := ekService.keyring.UnwrapDataKey(userKey.WrappedKey, userKey.KeyVersion)
+	if err != nil {
+		return err
+	}
+
+	wrappedKey, keyVersion, err := ekService.keyring.WrapDataKey(dataKey)
+	if err != nil {
+		return err
+	}
+
+	return ekService.userKeyRepo.UpdateWrappedKey(ctx, userKey.ID, wrappedKey, keyVersion)
+}
+
+func (ekService *EncryptionKeyService) getOrCreateUserEncryptor(ctx context.Context, userID string) (*security.Encryptor, error) {
+	userKey, err := ekService.userKeyRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrUserEncryptionKeyNotFound) {
+		userKey, err = ekService.createUserKey(ctx, userID)
+		if err != nil {
+			// A concurrent first write may have stored the user's key first, the unique index on
+			// user_id rejects ours. Use the stored key so every value shares a single data key
+			if storedKey, getErr := ekService.userKeyRepo.GetByUserID(ctx, userID); getErr == nil {
+				ekService.logger.InfoContext(ctx, "user encryption key created concurrently, using stored key", "user_id", userID)
+				userKey, err = storedKey, nil
+			}
+		}
+	}
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to retrieve user encryption key", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve encryption key: %w", err)
+	}
+
+	encryptor, err := ekService.unwrapUserKey(userKey)
+	if err != nil {
+		ekService.logger.ErrorContext(ctx, "failed to unwrap user encryption key", "user_id", userID, "key_version", userKey.KeyVersion, "error", err.Error())
+		return nil, fmt.Errorf("failed to unwrap encryption key: %w", err)
+	}
+
+	return encryptor, nil
+}
+
+func (ekService *EncryptionKeyService) createUserKey(ctx context.Context, userID string) (*models.UserEncryptionKey, error) {
+	dataKey, err := security.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, keyVersion, err := ekService.keyring.WrapDataKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ekService.logger.InfoContext(ctx, "creating user encryption key", "user_id", userID, "key_version", keyVersion)
+	return ekService.userKeyRepo.Create(ctx, userID, wrappedKey, keyVersion)
+}
+
+func (ekService *EncryptionKeyService) unwrapUserKey(userKey *models.UserEncryptionKey) (*security.Encryptor, error) {
+	dataKey, err := ekService.keyring.UnwrapDataKey(userKey.WrappedKey, userKey.KeyVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return security.NewDataKeyEncryptor(dataKey)
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/security"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMasterKeyV1 = "0123456789abcdef0123456789abcdef"
+	testMasterKeyV2 = "fedcba9876543210fedcba9876543210"
+)
+
+func createTestKeyring(t *testing.T, currentVersion int) *security.Keyring {
+	t.Helper()
+
+	keyring, err := security.NewKeyring(currentVersion, map[int]string{1: testMasterKeyV1, 2: testMasterKeyV2})
+	require.NoError(t, err)
+
+	return keyring
+}
+
+func createWrappedUserKey(t *testing.T, keyring *security.Keyring, id, userID string) *models.UserEncryptionKey {
+	t.Helper()
+
+	dataKey, err := security.GenerateDataKey()
+	require.NoError(t, err)
+
+	wrappedKey, keyVersion, err := keyring.WrapDataKey(dataKey)
+	require.NoError(t, err)
+
+	return &models.UserEncryptionKey{ID: id, UserID: userID, WrappedKey: wrappedKey, KeyVersion: keyVersion}
+}
+
+func TestEncryptionKeyService_EncryptDecrypt_CreatesUserKey(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockUserKeyRepo := mocks.NewMockUserEncryptionKeyRepository(ctrl)
+	mockRotationRepo := mocks.NewMockEncryptionKeyRotationRepository(ctrl)
+	service := NewEncryptionKeyService(mockUserKeyRepo, mockRotationRepo, createTestKeyring(t, 1), createTestLogger())
+
+	ctx := context.Background()
+
+	var storedKey *models.UserEncryptionKey
+	mockUserKeyRepo.EXPECT().GetByUserID(ctx, "user123").Return(nil, repositories.ErrUserEncryptionKeyNotFound)
+	mockUserKeyRepo.EXPECT().Create(ctx, "user123", gomock.Any(), 1).
+		DoAndReturn(func(ctx context.Context, userID, wrappedKey string, keyVersion int) (*models.UserEncryptionKey, error) {
+			storedKey = &models.UserEncryptionKey{ID: "key123", UserID: userID, WrappedKey: wrappedKey, KeyVersion: keyVersion}
+			return storedKey, nil
+		})
+
+	ciphertext, err := service.EncryptForUser(ctx, "user123", "access_token_123")
+	require.NoError(err)
+	require.True(strings.HasPrefix(ciphertext, encryptedValuePrefix))
+	require.NotContains(ciphertext, "access_token_123")
+
+	mockUserKeyRepo.EXPECT().GetByUserID(ctx, "user123").Return(storedKey, nil)
+
+	plaintext, err := service.DecryptForUser(ctx, "user123", ciphertext)
+	require.NoError(err)
+	require.Equal("access_token_123", plaintext)
+}
+
+func TestEncryptionKeyService_EncryptForUser_ConcurrentKeyCreation(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockUserKeyRepo := mocks.NewMockUserEncryptionKeyRepository(ctrl)
+	mockRotationRepo := mocks.NewMockEncryptionKeyRotationRepository(ctrl)
+	keyring := createTestKeyring(t, 1)
+	service := NewEncryptionKeyService(mockUserKeyRepo, mockRotationRepo, keyring, createTestLogger())
+
+	ctx := context.Background()
+
+	// Another request stored the user's key between our lookup and our create
+	winningKey := createWrappedUserKey(t, keyring, "key123", "user123")
+	gomock.InOrder(
+		mockUserKeyRepo.EXPECT().GetByUserID(ctx, "user123").Return(nil, repositories.ErrUserEncryptionKeyNotFound),
+		mockUserKeyRepo.EXPECT().Create(ctx, "user123", gomock.Any(), 1).Return(nil, repositories.ErrDatabaseOperation),
+		mockUserKeyRepo.EXPECT().GetByUserID(ctx, "user123").Return(winningKey, nil).Times(2),
+	)
+
+	ciphertext, err := service.EncryptForUser(ctx, "user123", "access_token_123")
+	require.NoError(err)
+
+	plaintext, err := service.DecryptForUser(ctx, "user123", ciphertext)
+	require.NoError(err)
+	require.Equal("access_token_123", plaintext)
+}
+
+func TestEncryptionKeyService_EncryptForUser_CreateError(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockUserKeyRepo := mocks.NewMockUserEncryptionKeyRepository(ctrl)
+	mockRotationRepo := mocks.NewMockEncryptionKeyRotationRepository(ctrl)
+	service := NewEncryptionKeyService(mockUserKeyRepo, mockRotationRepo, createTestKeyring(t, 1), createTestLogger())
+
+	ctx := context.Background()
+	mockUserKeyRepo.EXPECT().GetByUserID(ctx, "user123").Return(nil, repositories.ErrUserEncryptionKeyNotFound).Times(2)
+	mockUserKeyRepo.EXPECT().Create(ctx, "user123", gomock.Any(), 1).Return(nil, repositories.ErrDatabaseOperation)
+
+	_, err := service.EncryptForUser(ctx, "user123", "access_token_123")
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestEncryptionKeyService_DecryptForUser_LegacyPlaintext(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockUserKeyRepo := mocks.NewMockUserEncryptionKeyRepository(ctrl)
+	mockRotationRepo := mocks.NewMockEncryptionKeyRotationRepository(ctrl)
+	service := NewEncryptionKeyService(mockUserKeyRepo, mockRotationRepo, createTestKeyring(t, 1), createTestLogger())
+
+	plaintext, err := service.DecryptForUser(context.Background(), "user123", "plain_token")
+
+	require.NoError(err)
+	require.Equal("plain_token", plaintext)
+}
+
+func TestEncryptionKeyService_RotateKeys(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := setupMockController(t)
+	mockUserKeyRepo := mocks.NewMockUserEncryptionKeyRepository(ctrl)
+	mockRotationRepo := mocks.NewMockEncryptionKeyRotationRepository(ctrl)
+
+	ctx := context.Background()
+
+	// Encrypt a value with a key wrapped by the old master key
+	oldKeyring := createTestKeyring(t, 1)
+	userKey := createWrappedUserKey(t, oldKeyring, "key123", "user123")
+	corruptedKey := &models.UserEncryptionKey{ID: "key456", UserID: "user456", WrappedKey: "corrupted", KeyVersion: 1}
+
+	oldService := NewEncryptionKeyService(mockUserKeyRepo, mockRotationRepo, oldKeyring, createTestLogger())
+	mockUserKeyRepo.EXPECT().GetByUserID(ctx, "user123").Return(userKey, nil)
+	ciphertext, err := oldService.EncryptForUser(ctx, "user123", "refresh_token_123")
+	require.NoError(err)
+
+	// Rotate to the new master key
+	service := NewEncryptionKeyService(mockUserKeyRepo, mockRotationRepo, createTestKeyring(t, 2), createTestLogger())
+
+	var rewrappedKey string
+	mockUserKeyRepo.EXPECT().GetOutdated(ctx, 2).Return([]*models.UserEncryptionKey{userKey, corruptedKey}, nil)
+	mockUserKeyRepo.EXPECT().UpdateWrappedKey(ctx, "key123", gomock.Any(), 2).
+		DoAndReturn(func(ctx context.Context, id, wrappedKey string, keyVersion int) error {
+			rewrappedKey = wrappedKey
+			return nil
+		})
+	mockRotationRepo.EXPECT().Create(ctx, &models.EncryptionKeyRotation{ToVersion: 2, KeysRotated: 1, KeysFailed: 1}).
+		DoAndReturn(func(ctx context.Context, rotation *models.EncryptionKeyRotation) (*models.EncryptionKeyRotation, error) {
+			rotation.ID = "rotation123"
+			return rotation, nil
+		})
+
+	rotation, err := service.RotateKeys(ctx)
+	require.NoError(err)
+	require.Equal("rotation123", rotation.ID)
+	require.Equal(1, rotation.KeysRotated)
+	require.Equal(1, rotation.KeysFailed)
+	require.NotEqual(userKey.WrappedKey, rewrappedKey)
+
+	// Values encrypted before the rotation are still readable with the re-wrapped key
+	mockUserKeyRepo.EXPECT().GetByUserID(ctx, "user123").Return(&models.UserEncryptionKey{
+		ID: "key123", UserID: "user123", WrappedKey: rewrappedKey, KeyVersion: 2,
+	}, nil)
+
+	plaintext, err := service.DecryptForUser(ctx, "user123", ciphertext)
+	require.NoError(err)
+	require.Equal("refresh_token_123", plaintext)
+}
+
+func TestEncryptionKeyService_RotateKeys_RepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.MockUserEncryptionKeyRepository, *mocks.MockEncryptionKeyRotationRepository)
+		expectedErr string
+	}{
+		{
+			name: "outdated keys lookup fails",
+			setupMocks: func(userKeyRepo *mocks.MockUserEncryptionKeyRepository, rotationRepo *mocks.MockEncryptionKeyRotationRepository) {
+				userKeyRepo.EXPECT().GetOutdated(gomock.Any(), 2).Return(nil, repositories.ErrDatabaseOperation)
+			},
+			expectedErr: "failed to retrieve outdated encryption keys",
+		},
+		{
+			name: "audit record fails",
+			setupMocks: func(userKeyRepo *mocks.MockUserEncryptionKeyRepository, rotationRepo *mocks.MockEncryptionKeyRotationRepository) {
+				userKeyRepo.EXPECT().GetOutdated(gomock.Any(), 2).Return([]*models.UserEncryptionKey{}, nil)
+				rotationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+			},
+			expectedErr: "failed to record key rotation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := setupMockController(t)
+			mockUserKeyRepo := mocks.NewMockUserEncryptionKeyRepository(ctrl)
+			mockRotationRepo := mocks.NewMockEncryptionKeyRotationRepository(ctrl)
+			service := NewEncryptionKeyService(mockUserKeyRepo, mockRotationRepo, createTestKeyring(t, 2), createTestLogger())
+
+			tt.setupMocks(mockUserKeyRepo, mockRotationRepo)
+
+			rotation, err := service.RotateKeys(context.Background())
+
+			require.Error(err)
+			require.Contains(err.Error(), tt.expectedErr)
+			require.Nil(rotation)
+		})
+	}
+}
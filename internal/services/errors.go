@@ -0,0 +1,51 @@
+package services
+
+import "errors"
+
+var (
+	ErrInvalidChildPlaylistOrder = errors.New("child playlist order must list every child playlist of the base playlist exactly once")
+	ErrInvalidAPIKey             = errors.New("invalid api key")
+	ErrInvalidAPIKeyExpiry       = errors.New("api key expiry must be in the future")
+
+	ErrInvalidChildPlaylistTemplate = errors.New("invalid child playlist template")
+	ErrBuiltInTemplateReadOnly      = errors.New("built-in templates can not be modified")
+	ErrInvalidRoutingConfig         = errors.New("invalid routing config")
+
+	ErrTooManyPinnedTracks = errors.New("child playlists can have at most 100 pinned tracks")
+
+	ErrInvalidNotificationPreferences = errors.New("invalid notification preferences")
+
+	ErrInvalidFeatureFlag = errors.New("invalid feature flag")
+
+	ErrInvalidWebhookURL = errors.New("webhook url must be https and resolve to a public address")
+
+	ErrInvalidBlocklistEntry = errors.New("invalid blocklist entry")
+	ErrBlocklistEntryExists  = errors.New("blocklist entry already exists")
+
+	ErrSpotifyIntegrationUnavailable = errors.New("no spotify integration available for user")
+	ErrSpotifyTokenRefresh           = errors.New("failed to refresh spotify tokens")
+	ErrSpotifyAccountLinked          = errors.New("spotify account is linked to another user")
+	ErrDefaultSpotifyAccount         = errors.New("the default spotify account can not be unlinked")
+	ErrSpotifyAccountInUse           = errors.New("spotify account is used by playlists")
+	ErrSpotifyPlaylistNotOwned       = errors.New("spotify playlist is owned by another spotify account")
+
+	ErrYouTubeMusicIntegrationUnavailable = errors.New("no youtube music integration available for user")
+	ErrYouTubeMusicTokenRefresh           = errors.New("failed to refresh youtube music tokens")
+	ErrYouTubeMusicAccountInUse           = errors.New("youtube music account is used by playlists")
+	ErrYouTubeMusicAuthStateInvalid       = errors.New("youtube music authorization is unknown or expired")
+
+	ErrTidalIntegrationUnavailable = errors.New("no tidal integration available for user")
+	ErrTidalTokenRefresh           = errors.New("failed to refresh tidal tokens")
+	ErrTidalAccountInUse           = errors.New("tidal account is used by playlists")
+	ErrTidalLinkInvalid            = errors.New("tidal account link was not started or expired")
+	ErrTidalLinkPending            = errors.New("tidal account link is waiting for the user to authorize it")
+
+	ErrLastFmIntegrationUnavailable = errors.New("no lastfm integration available for user")
+	ErrLastFmAuthStateInvalid       = errors.New("lastfm authorization is unknown or expired")
+
+	ErrCloneSameSpotifyPlaylist = errors.New("a clone must be linked to another spotify playlist")
+
+	ErrNoTracksMatched = errors.New("no track of the imported file was found on spotify")
+
+	ErrDemoDataExists = errors.New("demo data already seeded, the demo user has base playlists")
+)
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=event_publisher.go -destination=mocks/mock_event_publisher.go -package=mocks
+
+// EventPublisher pushes events to the open app connections of a user. Publishing never blocks
+// on the connections, and events of users without one are dropped
+type EventPublisher interface {
+	Publish(userID string, event *models.AppEvent)
+}
+
+// publishEvent stamps the event and publishes it, doing nothing when no publisher is set
+func publishEvent(publisher EventPublisher, userID string, event *models.AppEvent) {
+	if publisher == nil {
+		return
+	}
+
+	event.Timestamp = time.Now()
+	publisher.Publish(userID, event)
+}
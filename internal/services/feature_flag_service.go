@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=feature_flag_service.go -destination=mocks/mock_feature_flag_service.go -package=mocks
+
+// FEATURE_FLAG_CACHE_TTL bounds how long a flag changed on another instance takes to apply here,
+// flags are checked on every sync so they aren't read from the database each time
+const FEATURE_FLAG_CACHE_TTL = 30 * time.Second
+
+var featureFlagKeyPattern = regexp.MustCompile(`^[a-z0-9_]{1,100}$`)
+
+var errMaintenanceModeFlag = fmt.Errorf("%w: %s is toggled through the maintenance mode", ErrInvalidFeatureFlag, models.MaintenanceModeFlag)
+
+type FeatureFlagServicer interface {
+	// IsEnabled reports whether the feature is on for the user. Features without a stored flag
+	// return fallback, as do all of them when the flags were never loaded
+	IsEnabled(ctx context.Context, key, userID string, fallback bool) bool
+	ListFeatureFlags(ctx context.Context) ([]*models.FeatureFlag, error)
+	UpdateFeatureFlag(ctx context.Context, key string, req *models.UpdateFeatureFlagRequest) (*models.FeatureFlag, error)
+	DeleteFeatureFlag(ctx context.Context, key string) error
+}
+
+type FeatureFlagService struct {
+	featureFlagRepo repositories.FeatureFlagRepository
+	logger          *slog.Logger
+
+	cacheMu   sync.Mutex
+	flags     map[string]*models.FeatureFlag
+	expiresAt time.Time
+	now       func() time.Time
+}
+
+func NewFeatureFlagService(featureFlagRepo repositories.FeatureFlagRepository, logger *slog.Logger) *FeatureFlagService {
+	return &FeatureFlagService{
+		featureFlagRepo: featureFlagRepo,
+		logger:          logger.With("component", "FeatureFlagService"),
+		now:             time.Now,
+	}
+}
+
+func (ffService *FeatureFlagService) IsEnabled(ctx context.Context, key, userID string, fallback bool) bool {
+	flag, ok := ffService.cachedFlags(ctx)[key]
+	if !ok {
+		return fallback
+	}
+
+	return flag.EnabledFor(userID)
+}
+
+func (ffService *FeatureFlagService) ListFeatureFlags(ctx context.Context) ([]*models.FeatureFlag, error) {
+	flags, err := ffService.featureFlagRepo.List(ctx)
+	if err != nil {
+		ffService.logger.ErrorContext(ctx, "failed to list feature flags", "error", err.Error())
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// UpdateFeatureFlag changes the fields set in the request, creating the flag when it doesn't exist
+func (ffService *FeatureFlagService) UpdateFeatureFlag(ctx context.Context, key string, req *models.UpdateFeatureFlagRequest) (*models.FeatureFlag, error) {
+	if !featureFlagKeyPattern.MatchString(key) {
+		return nil, fmt.Errorf("%w: key must be 1 to 100 lowercase letters, digits or underscores", ErrInvalidFeatureFlag)
+	}
+	if key == models.MaintenanceModeFlag {
+		return nil, errMaintenanceModeFlag
+	}
+
+	flag, err := ffService.featureFlagRepo.GetByKey(ctx, key)
+	if errors.Is(err, repositories.ErrFeatureFlagNotFound) {
+		flag = &models.FeatureFlag{Key: key, UserIDs: []string{}}
+	} else if err != nil {
+		ffService.logger.ErrorContext(ctx, "failed to get feature flag", "key", key, "error", err.Error())
+		return nil, fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	if req.Description != nil {
+		flag.Description = *req.Description
+	}
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.RolloutPercentage != nil {
+		flag.RolloutPercentage = *req.RolloutPercentage
+	}
+	if req.UserIDs != nil {
+		flag.UserIDs = *req.UserIDs
+	}
+
+	flag, err = ffService.featureFlagRepo.Upsert(ctx, flag)
+	if err != nil {
+		ffService.logger.ErrorContext(ctx, "failed to update feature flag", "key", key, "error", err.Error())
+		return nil, fmt.Errorf("failed to update feature flag: %w", err)
+	}
+
+	ffService.invalidateCache()
+	ffService.logger.InfoContext(ctx, "feature flag updated", "key", key, "enabled", flag.Enabled, "rollout_percentage", flag.RolloutPercentage, "user_ids", len(flag.UserIDs))
+	return flag, nil
+}
+
+// DeleteFeatureFlag drops the flag, so the feature falls back to its config
+func (ffService *FeatureFlagService) DeleteFeatureFlag(ctx context.Context, key string) error {
+	if key == models.MaintenanceModeFlag {
+		return errMaintenanceModeFlag
+	}
+
+	if err := ffService.featureFlagRepo.Delete(ctx, key); err != nil {
+		if !errors.Is(err, repositories.ErrFeatureFlagNotFound) {
+			ffService.logger.ErrorContext(ctx, "failed to delete feature flag", "key", key, "error", err.Error())
+		}
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+
+	ffService.invalidateCache()
+	ffService.logger.InfoContext(ctx, "feature flag deleted", "key", key)
+	return nil
+}
+
+// cachedFlags returns the flags by key, loading them when the cache expired. A failed load keeps
+// the flags loaded before until the next attempt, so a database hiccup doesn't flip features
+func (ffService *FeatureFlagService) cachedFlags(ctx context.Context) map[string]*models.FeatureFlag {
+	ffService.cacheMu.Lock()
+	defer ffService.cacheMu.Unlock()
+
+	now := ffService.now()
+	if now.Before(ffService.expiresAt) {
+		return ffService.flags
+	}
+	ffService.expiresAt = now.Add(FEATURE_FLAG_CACHE_TTL)
+
+	flags, err := ffService.featureFlagRepo.List(ctx)
+	if err != nil {
+		ffService.logger.WarnContext(ctx, "failed to load feature flags, keeping the previous ones", "error", err.Error())
+		return ffService.flags
+	}
+
+	ffService.flags = make(map[string]*models.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		ffService.flags[flag.Key] = flag
+	}
+
+	return ffService.flags
+}
+
+func (ffService *FeatureFlagService) invalidateCache() {
+	ffService.cacheMu.Lock()
+	defer ffService.cacheMu.Unlock()
+
+	ffService.expiresAt = time.Time{}
+}
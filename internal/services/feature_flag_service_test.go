@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFeatureFlagService(t *testing.T) (*FeatureFlagService, *repositoryMocks.MockFeatureFlagRepository) {
+	ctrl := setupMockController(t)
+	featureFlagRepo := repositoryMocks.NewMockFeatureFlagRepository(ctrl)
+
+	return NewFeatureFlagService(featureFlagRepo, createTestLogger()), featureFlagRepo
+}
+
+func TestFeatureFlagService_IsEnabled(t *testing.T) {
+	assert := require.New(t)
+	service, featureFlagRepo := setupFeatureFlagService(t)
+	ctx := context.Background()
+
+	featureFlagRepo.EXPECT().List(ctx).Return([]*models.FeatureFlag{
+		{Key: models.FeatureConcurrentSync, Enabled: true, UserIDs: []string{"beta_user"}},
+		{Key: models.FeatureStreamingSync, Enabled: false, UserIDs: []string{"beta_user"}},
+		{Key: models.FeatureIncrementalSync, Enabled: true, RolloutPercentage: 100},
+	}, nil)
+
+	assert.True(service.IsEnabled(ctx, models.FeatureConcurrentSync, "beta_user", false))
+	assert.False(service.IsEnabled(ctx, models.FeatureConcurrentSync, "user123", true))
+	assert.False(service.IsEnabled(ctx, models.FeatureStreamingSync, "beta_user", true))
+	assert.True(service.IsEnabled(ctx, models.FeatureIncrementalSync, "user123", false))
+
+	// Features without a flag keep their fallback
+	assert.True(service.IsEnabled(ctx, "unknown_feature", "user123", true))
+	assert.False(service.IsEnabled(ctx, "unknown_feature", "user123", false))
+}
+
+func TestFeatureFlagService_IsEnabled_Cache(t *testing.T) {
+	assert := require.New(t)
+	service, featureFlagRepo := setupFeatureFlagService(t)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	gomock.InOrder(
+		featureFlagRepo.EXPECT().List(ctx).Return([]*models.FeatureFlag{{Key: models.FeatureConcurrentSync, Enabled: true, RolloutPercentage: 100}}, nil),
+		featureFlagRepo.EXPECT().List(ctx).Return(nil, repositories.ErrDatabaseOperation),
+		featureFlagRepo.EXPECT().List(ctx).Return([]*models.FeatureFlag{}, nil),
+	)
+
+	assert.True(service.IsEnabled(ctx, models.FeatureConcurrentSync, "user123", false))
+
+	now = now.Add(FEATURE_FLAG_CACHE_TTL - time.Second)
+	assert.True(service.IsEnabled(ctx, models.FeatureConcurrentSync, "user123", false))
+
+	// A failed reload keeps the flags loaded before
+	now = now.Add(2 * time.Second)
+	assert.True(service.IsEnabled(ctx, models.FeatureConcurrentSync, "user123", false))
+
+	now = now.Add(FEATURE_FLAG_CACHE_TTL)
+	assert.False(service.IsEnabled(ctx, models.FeatureConcurrentSync, "user123", false))
+}
+
+func TestFeatureFlagService_IsEnabled_NeverLoaded(t *testing.T) {
+	assert := require.New(t)
+	service, featureFlagRepo := setupFeatureFlagService(t)
+	ctx := context.Background()
+
+	featureFlagRepo.EXPECT().List(ctx).Return(nil, repositories.ErrCollectionNotFound)
+
+	assert.True(service.IsEnabled(ctx, models.FeatureConcurrentSync, "user123", true))
+	assert.False(service.IsEnabled(ctx, models.FeatureConcurrentSync, "user123", false))
+}
+
+func TestFeatureFlagService_UpdateFeatureFlag(t *testing.T) {
+	t.Run("creates missing flag", func(t *testing.T) {
+		assert := require.New(t)
+		service, featureFlagRepo := setupFeatureFlagService(t)
+		ctx := context.Background()
+
+		rollout := 25
+		featureFlagRepo.EXPECT().GetByKey(ctx, models.FeatureConcurrentSync).Return(nil, repositories.ErrFeatureFlagNotFound)
+		featureFlagRepo.EXPECT().
+			Upsert(ctx, &models.FeatureFlag{Key: models.FeatureConcurrentSync, RolloutPercentage: 25, UserIDs: []string{}}).
+			DoAndReturn(func(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+				flag.ID = "flag1"
+				return flag, nil
+			})
+
+		flag, err := service.UpdateFeatureFlag(ctx, models.FeatureConcurrentSync, &models.UpdateFeatureFlagRequest{RolloutPercentage: &rollout})
+
+		assert.NoError(err)
+		assert.Equal("flag1", flag.ID)
+		assert.False(flag.Enabled)
+	})
+
+	t.Run("keeps fields not in the request and refreshes the cache", func(t *testing.T) {
+		assert := require.New(t)
+		service, featureFlagRepo := setupFeatureFlagService(t)
+		ctx := context.Background()
+
+		stored := &models.FeatureFlag{ID: "flag1", Key: models.FeatureStreamingSync, Description: "Stream large playlists", RolloutPercentage: 100, UserIDs: []string{}}
+		enabled := true
+
+		gomock.InOrder(
+			featureFlagRepo.EXPECT().List(ctx).Return([]*models.FeatureFlag{stored}, nil),
+			featureFlagRepo.EXPECT().GetByKey(ctx, models.FeatureStreamingSync).Return(stored, nil),
+			featureFlagRepo.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+				return flag, nil
+			}),
+			featureFlagRepo.EXPECT().List(ctx).Return([]*models.FeatureFlag{{Key: models.FeatureStreamingSync, Enabled: true, RolloutPercentage: 100}}, nil),
+		)
+
+		assert.False(service.IsEnabled(ctx, models.FeatureStreamingSync, "user123", true))
+
+		flag, err := service.UpdateFeatureFlag(ctx, models.FeatureStreamingSync, &models.UpdateFeatureFlagRequest{Enabled: &enabled})
+
+		assert.NoError(err)
+		assert.True(flag.Enabled)
+		assert.Equal("Stream large playlists", flag.Description)
+		assert.Equal(100, flag.RolloutPercentage)
+		assert.True(service.IsEnabled(ctx, models.FeatureStreamingSync, "user123", false))
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		assert := require.New(t)
+		service, _ := setupFeatureFlagService(t)
+
+		flag, err := service.UpdateFeatureFlag(context.Background(), "Concurrent Sync", &models.UpdateFeatureFlagRequest{})
+
+		assert.ErrorIs(err, ErrInvalidFeatureFlag)
+		assert.Nil(flag)
+	})
+
+	t.Run("maintenance mode flag", func(t *testing.T) {
+		assert := require.New(t)
+		service, _ := setupFeatureFlagService(t)
+
+		flag, err := service.UpdateFeatureFlag(context.Background(), models.MaintenanceModeFlag, &models.UpdateFeatureFlagRequest{})
+
+		assert.ErrorIs(err, ErrInvalidFeatureFlag)
+		assert.Nil(flag)
+	})
+}
+
+func TestFeatureFlagService_DeleteFeatureFlag(t *testing.T) {
+	assert := require.New(t)
+	service, featureFlagRepo := setupFeatureFlagService(t)
+	ctx := context.Background()
+
+	featureFlagRepo.EXPECT().Delete(ctx, models.FeatureConcurrentSync).Return(nil)
+	featureFlagRepo.EXPECT().Delete(ctx, models.FeatureStreamingSync).Return(repositories.ErrFeatureFlagNotFound)
+
+	assert.NoError(service.DeleteFeatureFlag(ctx, models.FeatureConcurrentSync))
+	assert.ErrorIs(service.DeleteFeatureFlag(ctx, models.FeatureStreamingSync), repositories.ErrFeatureFlagNotFound)
+	assert.ErrorIs(service.DeleteFeatureFlag(ctx, models.MaintenanceModeFlag), ErrInvalidFeatureFlag)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=filter_rule_history_service.go -destination=mocks/mock_filter_rule_history_service.go -package=mocks
+
+type FilterRuleHistoryServicer interface {
+	GetHistory(ctx context.Context, childPlaylistID, userID string) ([]*models.FilterRuleChange, error)
+	GetChange(ctx context.Context, id, userID string) (*models.FilterRuleChange, error)
+	GetPendingChanges(ctx context.Context, basePlaylistID, userID string) ([]*models.FilterRuleChange, error)
+	SaveRoutingDiff(ctx context.Context, id string, diff *models.RoutingDiff) (*models.FilterRuleChange, error)
+}
+
+type FilterRuleHistoryService struct {
+	filterRuleChangeRepo repositories.FilterRuleChangeRepository
+	logger               *slog.Logger
+}
+
+func NewFilterRuleHistoryService(
+	filterRuleChangeRepo repositories.FilterRuleChangeRepository,
+	logger *slog.Logger,
+) *FilterRuleHistoryService {
+	return &FilterRuleHistoryService{
+		filterRuleChangeRepo: filterRuleChangeRepo,
+		logger:               logger.With("component", "FilterRuleHistoryService"),
+	}
+}
+
+// GetHistory returns the filter rule changes of a child playlist, newest first
+func (frhService *FilterRuleHistoryService) GetHistory(ctx context.Context, childPlaylistID, userID string) ([]*models.FilterRuleChange, error) {
+	frhService.logger.InfoContext(ctx, "retrieving filter rule history", "child_playlist_id", childPlaylistID, "user_id", userID)
+
+	changes, err := frhService.filterRuleChangeRepo.GetByChildPlaylistID(ctx, childPlaylistID, userID)
+	if err != nil {
+		frhService.logger.ErrorContext(ctx, "failed to retrieve filter rule history", "child_playlist_id", childPlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve filter rule history: %w", err)
+	}
+
+	frhService.logger.InfoContext(ctx, "filter rule history retrieved successfully", "child_playlist_id", childPlaylistID, "count", len(changes))
+	return changes, nil
+}
+
+func (frhService *FilterRuleHistoryService) GetChange(ctx context.Context, id, userID string) (*models.FilterRuleChange, error) {
+	change, err := frhService.filterRuleChangeRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		frhService.logger.ErrorContext(ctx, "failed to retrieve filter rule change", "id", id, "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve filter rule change: %w", err)
+	}
+
+	return change, nil
+}
+
+// GetPendingChanges returns the filter rule changes of a base playlist that have no routing diff yet
+func (frhService *FilterRuleHistoryService) GetPendingChanges(ctx context.Context, basePlaylistID, userID string) ([]*models.FilterRuleChange, error) {
+	changes, err := frhService.filterRuleChangeRepo.GetPendingByBasePlaylistID(ctx, basePlaylistID, userID)
+	if err != nil {
+		frhService.logger.ErrorContext(ctx, "failed to retrieve pending filter rule changes", "base_playlist_id", basePlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve pending filter rule changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (frhService *FilterRuleHistoryService) SaveRoutingDiff(ctx context.Context, id string, diff *models.RoutingDiff) (*models.FilterRuleChange, error) {
+	frhService.logger.InfoContext(ctx, "saving routing diff", "id", id, "tracks_added", len(diff.AddedTrackURIs), "tracks_removed", len(diff.RemovedTrackURIs))
+
+	change, err := frhService.filterRuleChangeRepo.UpdateRoutingDiff(ctx, id, diff)
+	if err != nil {
+		frhService.logger.ErrorContext(ctx, "failed to save routing diff", "id", id, "error", err.Error())
+		return nil, fmt.Errorf("failed to save routing diff: %w", err)
+	}
+
+	return change, nil
+}
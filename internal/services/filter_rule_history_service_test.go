@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRuleHistoryService_GetHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectError bool
+	}{
+		{name: "success"},
+		{name: "repository error", repoErr: repositories.ErrDatabaseOperation, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockFilterRuleChangeRepository(ctrl)
+			service := NewFilterRuleHistoryService(mockRepo, createTestLogger())
+
+			ctx := context.Background()
+			changes := []*models.FilterRuleChange{
+				{ID: "change2", ChildPlaylistID: "child123"},
+				{ID: "change1", ChildPlaylistID: "child123"},
+			}
+
+			if tt.repoErr != nil {
+				mockRepo.EXPECT().GetByChildPlaylistID(ctx, "child123", "user123").Return(nil, tt.repoErr)
+			} else {
+				mockRepo.EXPECT().GetByChildPlaylistID(ctx, "child123", "user123").Return(changes, nil)
+			}
+
+			result, err := service.GetHistory(ctx, "child123", "user123")
+
+			if tt.expectError {
+				require.ErrorIs(err, tt.repoErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(changes, result)
+		})
+	}
+}
+
+func TestFilterRuleHistoryService_GetChange(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilterRuleChangeRepository(ctrl)
+	service := NewFilterRuleHistoryService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+	change := &models.FilterRuleChange{ID: "change123", UserID: "user123"}
+
+	mockRepo.EXPECT().GetByID(ctx, "change123", "user123").Return(change, nil)
+	mockRepo.EXPECT().GetByID(ctx, "missing", "user123").Return(nil, repositories.ErrFilterRuleChangeNotFound)
+
+	result, err := service.GetChange(ctx, "change123", "user123")
+	require.NoError(err)
+	require.Equal(change, result)
+
+	result, err = service.GetChange(ctx, "missing", "user123")
+	require.ErrorIs(err, repositories.ErrFilterRuleChangeNotFound)
+	require.Nil(result)
+}
+
+func TestFilterRuleHistoryService_GetPendingChanges(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilterRuleChangeRepository(ctrl)
+	service := NewFilterRuleHistoryService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+	changes := []*models.FilterRuleChange{{ID: "change123", BasePlaylistID: "base123"}}
+
+	mockRepo.EXPECT().GetPendingByBasePlaylistID(ctx, "base123", "user123").Return(changes, nil)
+
+	result, err := service.GetPendingChanges(ctx, "base123", "user123")
+	require.NoError(err)
+	require.Equal(changes, result)
+}
+
+func TestFilterRuleHistoryService_SaveRoutingDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectError bool
+	}{
+		{name: "success"},
+		{name: "repository error", repoErr: repositories.ErrDatabaseOperation, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockFilterRuleChangeRepository(ctrl)
+			service := NewFilterRuleHistoryService(mockRepo, createTestLogger())
+
+			ctx := context.Background()
+			diff := &models.RoutingDiff{
+				SyncEventID:    "sync123",
+				TracksBefore:   1,
+				TracksAfter:    2,
+				AddedTrackURIs: []string{"spotify:track:2"},
+				ComputedAt:     time.Now(),
+			}
+
+			if tt.repoErr != nil {
+				mockRepo.EXPECT().UpdateRoutingDiff(ctx, "change123", diff).Return(nil, tt.repoErr)
+			} else {
+				mockRepo.EXPECT().UpdateRoutingDiff(ctx, "change123", diff).Return(&models.FilterRuleChange{ID: "change123", RoutingDiff: diff}, nil)
+			}
+
+			result, err := service.SaveRoutingDiff(ctx, "change123", diff)
+
+			if tt.expectError {
+				require.ErrorIs(err, tt.repoErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(diff, result.RoutingDiff)
+		})
+	}
+}
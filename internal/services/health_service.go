@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=health_service.go -destination=mocks/mock_health_service.go -package=mocks
+
+const (
+	// HEALTH_CHECK_TIMEOUT bounds each component check, so a probe answers before the orchestrator
+	// gives up on it
+	HEALTH_CHECK_TIMEOUT = 3 * time.Second
+	// HEALTH_SPOTIFY_CHECK_TTL is how long the outcome of the spotify check is reused, so frequent
+	// probes don't send a request to spotify each
+	HEALTH_SPOTIFY_CHECK_TTL = 30 * time.Second
+	// WORKER_HEARTBEAT_STALE_AFTER is how long the workers can go without a heartbeat, which is
+	// recorded every minute, before they are considered stuck
+	WORKER_HEARTBEAT_STALE_AFTER = 3 * time.Minute
+)
+
+type HealthServicer interface {
+	Liveness(ctx context.Context) *models.HealthReport
+	Readiness(ctx context.Context) *models.HealthReport
+	RecordWorkerHeartbeat()
+}
+
+// HealthService checks the components the app depends on for the container probes. The database and
+// the workers are critical, spotify being unreachable only degrades the app: it can still serve the
+// playlists it stores, and restarting it wouldn't bring spotify back
+type HealthService struct {
+	diagnosticsRepo repositories.DiagnosticsRepository
+	spotifyClient   spotifyclient.SpotifyAPI
+	logger          *slog.Logger
+	now             func() time.Time
+
+	mu               sync.Mutex
+	lastHeartbeat    time.Time
+	spotifyCheck     models.ComponentHealth
+	spotifyCheckedAt time.Time
+}
+
+func NewHealthService(
+	diagnosticsRepo repositories.DiagnosticsRepository,
+	spotifyClient spotifyclient.SpotifyAPI,
+	logger *slog.Logger,
+) *HealthService {
+	return &HealthService{
+		diagnosticsRepo: diagnosticsRepo,
+		spotifyClient:   spotifyClient,
+		logger:          logger.With("component", "HealthService"),
+		now:             time.Now,
+		// The first heartbeat is only due a minute after the workers start
+		lastHeartbeat: time.Now(),
+	}
+}
+
+// RecordWorkerHeartbeat is called by a job scheduled every minute, showing the workers still run
+func (hService *HealthService) RecordWorkerHeartbeat() {
+	hService.mu.Lock()
+	defer hService.mu.Unlock()
+
+	hService.lastHeartbeat = hService.now()
+}
+
+// Liveness reports whether the app must be restarted, which only stuck workers call for
+func (hService *HealthService) Liveness(ctx context.Context) *models.HealthReport {
+	return hService.report(map[string]models.ComponentHealth{
+		models.HealthComponentWorkers: hService.checkWorkers(),
+	})
+}
+
+// Readiness reports whether the app can serve requests, checking every component it depends on
+func (hService *HealthService) Readiness(ctx context.Context) *models.HealthReport {
+	return hService.report(map[string]models.ComponentHealth{
+		models.HealthComponentDatabase: hService.checkDatabase(ctx),
+		models.HealthComponentSpotify:  hService.checkSpotify(ctx),
+		models.HealthComponentWorkers:  hService.checkWorkers(),
+	})
+}
+
+func (hService *HealthService) report(components map[string]models.ComponentHealth) *models.HealthReport {
+	status := models.HealthStatusOK
+	for name, component := range components {
+		if component.Status == models.HealthStatusOK {
+			continue
+		}
+
+		hService.logger.Warn("health check failed", "health_component", name, "error", component.Error)
+		if component.Critical {
+			status = models.HealthStatusDown
+		} else if status == models.HealthStatusOK {
+			status = models.HealthStatusDegraded
+		}
+	}
+
+	return &models.HealthReport{
+		Status:     status,
+		Components: components,
+		CheckedAt:  hService.now(),
+	}
+}
+
+func (hService *HealthService) checkDatabase(ctx context.Context) models.ComponentHealth {
+	return hService.check(ctx, true, hService.diagnosticsRepo.Ping)
+}
+
+// checkSpotify reuses the last outcome for HEALTH_SPOTIFY_CHECK_TTL
+func (hService *HealthService) checkSpotify(ctx context.Context) models.ComponentHealth {
+	hService.mu.Lock()
+	if !hService.spotifyCheckedAt.IsZero() && hService.now().Sub(hService.spotifyCheckedAt) < HEALTH_SPOTIFY_CHECK_TTL {
+		defer hService.mu.Unlock()
+		return hService.spotifyCheck
+	}
+	hService.mu.Unlock()
+
+	health := hService.check(ctx, false, hService.spotifyClient.Ping)
+
+	hService.mu.Lock()
+	defer hService.mu.Unlock()
+	hService.spotifyCheck = health
+	hService.spotifyCheckedAt = hService.now()
+
+	return health
+}
+
+func (hService *HealthService) checkWorkers() models.ComponentHealth {
+	hService.mu.Lock()
+	lastHeartbeat := hService.lastHeartbeat
+	hService.mu.Unlock()
+
+	health := models.ComponentHealth{
+		Status:        models.HealthStatusOK,
+		Critical:      true,
+		LastHeartbeat: &lastHeartbeat,
+	}
+	if hService.now().Sub(lastHeartbeat) > WORKER_HEARTBEAT_STALE_AFTER {
+		health.Status = models.HealthStatusDown
+		health.Error = "no worker heartbeat since " + lastHeartbeat.UTC().Format(time.RFC3339)
+	}
+
+	return health
+}
+
+// check runs ping within HEALTH_CHECK_TIMEOUT, timing it
+func (hService *HealthService) check(ctx context.Context, critical bool, ping func(ctx context.Context) error) models.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+
+	start := hService.now()
+	err := ping(ctx)
+
+	health := models.ComponentHealth{
+		Status:    models.HealthStatusOK,
+		Critical:  critical,
+		LatencyMs: hService.now().Sub(start).Milliseconds(),
+	}
+	if err != nil {
+		health.Status = models.HealthStatusDown
+		health.Error = err.Error()
+	}
+
+	return health
+}
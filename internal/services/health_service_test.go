@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHealthService(t *testing.T, now *time.Time) (*HealthService, *mocks.MockDiagnosticsRepository, *spotifyClientMocks.MockSpotifyAPI) {
+	ctrl := gomock.NewController(t)
+	diagnosticsRepo := mocks.NewMockDiagnosticsRepository(ctrl)
+	spotifyClient := spotifyClientMocks.NewMockSpotifyAPI(ctrl)
+
+	service := NewHealthService(diagnosticsRepo, spotifyClient, createTestLogger())
+	service.now = func() time.Time { return *now }
+	service.lastHeartbeat = *now
+
+	return service, diagnosticsRepo, spotifyClient
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	tests := []struct {
+		name           string
+		databaseErr    error
+		spotifyErr     error
+		expectedStatus models.HealthStatus
+	}{
+		{name: "every component up", expectedStatus: models.HealthStatusOK},
+		{name: "spotify down", spotifyErr: errors.New("spotify unreachable"), expectedStatus: models.HealthStatusDegraded},
+		{name: "database down", databaseErr: errors.New("database is locked"), expectedStatus: models.HealthStatusDown},
+		{
+			name:           "database and spotify down",
+			databaseErr:    errors.New("database is locked"),
+			spotifyErr:     errors.New("spotify unreachable"),
+			expectedStatus: models.HealthStatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+			service, diagnosticsRepo, spotifyClient := newTestHealthService(t, &now)
+
+			diagnosticsRepo.EXPECT().Ping(gomock.Any()).Return(tt.databaseErr)
+			spotifyClient.EXPECT().Ping(gomock.Any()).Return(tt.spotifyErr)
+
+			report := service.Readiness(context.Background())
+
+			assert.Equal(tt.expectedStatus, report.Status)
+			assert.Equal(now, report.CheckedAt)
+			assert.Len(report.Components, 3)
+
+			database := report.Components[models.HealthComponentDatabase]
+			assert.True(database.Critical)
+			if tt.databaseErr != nil {
+				assert.Equal(models.HealthStatusDown, database.Status)
+				assert.Equal(tt.databaseErr.Error(), database.Error)
+			} else {
+				assert.Equal(models.HealthStatusOK, database.Status)
+			}
+
+			spotify := report.Components[models.HealthComponentSpotify]
+			assert.False(spotify.Critical)
+			if tt.spotifyErr != nil {
+				assert.Equal(models.HealthStatusDown, spotify.Status)
+				assert.Equal(tt.spotifyErr.Error(), spotify.Error)
+			} else {
+				assert.Equal(models.HealthStatusOK, spotify.Status)
+			}
+
+			assert.Equal(models.HealthStatusOK, report.Components[models.HealthComponentWorkers].Status)
+		})
+	}
+}
+
+func TestHealthService_Readiness_ReusesSpotifyCheck(t *testing.T) {
+	assert := require.New(t)
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	service, diagnosticsRepo, spotifyClient := newTestHealthService(t, &now)
+
+	diagnosticsRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(3)
+	spotifyClient.EXPECT().Ping(gomock.Any()).Return(errors.New("spotify unreachable"))
+	spotifyClient.EXPECT().Ping(gomock.Any()).Return(nil)
+
+	assert.Equal(models.HealthStatusDegraded, service.Readiness(context.Background()).Status)
+
+	now = now.Add(HEALTH_SPOTIFY_CHECK_TTL - time.Second)
+	assert.Equal(models.HealthStatusDegraded, service.Readiness(context.Background()).Status)
+
+	now = now.Add(time.Second)
+	assert.Equal(models.HealthStatusOK, service.Readiness(context.Background()).Status)
+}
+
+func TestHealthService_Liveness(t *testing.T) {
+	assert := require.New(t)
+	start := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	now := start
+	service, _, _ := newTestHealthService(t, &now)
+
+	report := service.Liveness(context.Background())
+	assert.Equal(models.HealthStatusOK, report.Status)
+	assert.Len(report.Components, 1)
+
+	// Missed heartbeats
+	now = start.Add(WORKER_HEARTBEAT_STALE_AFTER + time.Second)
+	report = service.Liveness(context.Background())
+	assert.Equal(models.HealthStatusDown, report.Status)
+
+	workers := report.Components[models.HealthComponentWorkers]
+	assert.Equal(models.HealthStatusDown, workers.Status)
+	assert.Equal(start, *workers.LastHeartbeat)
+	assert.Equal("no worker heartbeat since 2024-06-15T12:00:00Z", workers.Error)
+
+	service.RecordWorkerHeartbeat()
+	report = service.Liveness(context.Background())
+	assert.Equal(models.HealthStatusOK, report.Status)
+	assert.Equal(now, *report.Components[models.HealthComponentWorkers].LastHeartbeat)
+}
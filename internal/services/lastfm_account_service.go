@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	lastfmclient "github.com/ngomez18/playlist-router/internal/clients/lastfm"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=lastfm_account_service.go -destination=mocks/mock_lastfm_account_service.go -package=mocks
+
+// LASTFM_AUTH_STATE_TTL bounds how long a user has to approve the app on Last.fm, once it expires
+// the callback can't link the account anymore
+const LASTFM_AUTH_STATE_TTL = 10 * time.Minute
+
+type LastFmAccountServicer interface {
+	GenerateLinkURL(userID string) (string, error)
+	GetAccount(ctx context.Context, userID string) (*models.LastFmIntegration, error)
+	HandleCallback(ctx context.Context, token, state string) (*models.LastFmIntegration, error)
+	Unlink(ctx context.Context, userID string) error
+}
+
+// LastFmAccountService links the last.fm account of a user, whose scrobbles enrich the tracks
+// routed to their child playlists with play counts and tags
+type LastFmAccountService struct {
+	integrationRepo repositories.LastFmIntegrationRepository
+	lastFm          lastfmclient.LastFmAPI
+	logger          *slog.Logger
+
+	// authStates maps the state of pending links to the user linking the account
+	authStatesMu sync.Mutex
+	authStates   map[string]pendingLastFmLink
+}
+
+type pendingLastFmLink struct {
+	userID    string
+	expiresAt time.Time
+}
+
+func NewLastFmAccountService(
+	integrationRepo repositories.LastFmIntegrationRepository,
+	lastFm lastfmclient.LastFmAPI,
+	logger *slog.Logger,
+) *LastFmAccountService {
+	return &LastFmAccountService{
+		integrationRepo: integrationRepo,
+		lastFm:          lastFm,
+		logger:          logger.With("component", "LastFmAccountService"),
+		authStates:      make(map[string]pendingLastFmLink),
+	}
+}
+
+// GenerateLinkURL returns the Last.fm page linking a last.fm account to the user. Its state
+// identifies the user when Last.fm redirects back to the callback
+func (s *LastFmAccountService) GenerateLinkURL(userID string) (string, error) {
+	state, err := generateSecretToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate link state: %w", err)
+	}
+
+	now := time.Now()
+
+	s.authStatesMu.Lock()
+	for pendingState, link := range s.authStates {
+		if now.After(link.expiresAt) {
+			delete(s.authStates, pendingState)
+		}
+	}
+	s.authStates[state] = pendingLastFmLink{userID: userID, expiresAt: now.Add(LASTFM_AUTH_STATE_TTL)}
+	s.authStatesMu.Unlock()
+
+	s.logger.Info("generated lastfm link url", "user_id", userID)
+	return s.lastFm.GenerateAuthURL(state), nil
+}
+
+// takeAuthState returns the pending link started with the given state, if any. A state can only be
+// used once
+func (s *LastFmAccountService) takeAuthState(state string) (pendingLastFmLink, bool) {
+	s.authStatesMu.Lock()
+	defer s.authStatesMu.Unlock()
+
+	link, ok := s.authStates[state]
+	if !ok {
+		return pendingLastFmLink{}, false
+	}
+	delete(s.authStates, state)
+
+	if time.Now().After(link.expiresAt) {
+		return pendingLastFmLink{}, false
+	}
+
+	return link, true
+}
+
+// HandleCallback completes a link, storing the session of the last.fm account as the integration
+// of the user that started it. Linking another account replaces the previous one
+func (s *LastFmAccountService) HandleCallback(ctx context.Context, token, state string) (*models.LastFmIntegration, error) {
+	link, ok := s.takeAuthState(state)
+	if !ok {
+		s.logger.WarnContext(ctx, "lastfm callback with unknown state")
+		return nil, ErrLastFmAuthStateInvalid
+	}
+
+	s.logger.InfoContext(ctx, "handling lastfm callback", "user_id", link.userID)
+
+	session, err := s.lastFm.GetSession(ctx, token)
+	if errors.Is(err, lastfmclient.ErrInvalidToken) {
+		return nil, ErrLastFmAuthStateInvalid
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get lastfm session", "user_id", link.userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get lastfm session: %w", err)
+	}
+
+	integration := &models.LastFmIntegration{Username: session.Username, SessionKey: session.Key}
+	stored, err := s.integrationRepo.CreateOrUpdate(ctx, link.userID, integration)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to store lastfm integration", "user_id", link.userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to store lastfm integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "lastfm account linked successfully", "user_id", link.userID, "username", stored.Username)
+	return stored, nil
+}
+
+// GetAccount returns the last.fm account linked by the user
+func (s *LastFmAccountService) GetAccount(ctx context.Context, userID string) (*models.LastFmIntegration, error) {
+	integration, err := s.integrationRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrLastFmIntegrationNotFound) {
+		return nil, ErrLastFmIntegrationUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve lastfm integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+// Unlink removes the last.fm account of the user. Child playlists filtering on scrobbles stay as
+// they are, their play count and tag rules just stop matching
+func (s *LastFmAccountService) Unlink(ctx context.Context, userID string) error {
+	s.logger.InfoContext(ctx, "unlinking lastfm account", "user_id", userID)
+
+	err := s.integrationRepo.Delete(ctx, userID)
+	if errors.Is(err, repositories.ErrLastFmIntegrationNotFound) {
+		return ErrLastFmIntegrationUnavailable
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete lastfm integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "lastfm account unlinked successfully", "user_id", userID)
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	lastfmclient "github.com/ngomez18/playlist-router/internal/clients/lastfm"
+	lastFmMocks "github.com/ngomez18/playlist-router/internal/clients/lastfm/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type lastFmAccountServiceMocks struct {
+	integrationRepo *repositoryMocks.MockLastFmIntegrationRepository
+	lastFm          *lastFmMocks.MockLastFmAPI
+}
+
+func setupLastFmAccountService(t *testing.T) (*LastFmAccountService, *lastFmAccountServiceMocks) {
+	ctrl := setupMockController(t)
+	m := &lastFmAccountServiceMocks{
+		integrationRepo: repositoryMocks.NewMockLastFmIntegrationRepository(ctrl),
+		lastFm:          lastFmMocks.NewMockLastFmAPI(ctrl),
+	}
+
+	service := NewLastFmAccountService(m.integrationRepo, m.lastFm, createTestLogger())
+	return service, m
+}
+
+// startLastFmLink generates a link url and returns the state Last.fm would send back
+func startLastFmLink(t *testing.T, service *LastFmAccountService, m *lastFmAccountServiceMocks) string {
+	var state string
+	m.lastFm.EXPECT().GenerateAuthURL(gomock.Any()).DoAndReturn(func(s string) string {
+		state = s
+		return "https://www.last.fm/api/auth/?" + url.Values{"cb": {"https://app.example.com/auth/lastfm/callback?state=" + s}}.Encode()
+	})
+
+	authURL, err := service.GenerateLinkURL("user123")
+	require.NoError(t, err)
+	require.Contains(t, authURL, state)
+
+	return state
+}
+
+func TestLastFmAccountService_HandleCallback(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupLastFmAccountService(t)
+	ctx := context.Background()
+
+	state := startLastFmLink(t, service, m)
+
+	m.lastFm.EXPECT().GetSession(ctx, "lastfm_token").Return(&lastfmclient.Session{Username: "listener", Key: "session_key"}, nil)
+	m.integrationRepo.EXPECT().CreateOrUpdate(ctx, "user123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, integration *models.LastFmIntegration) (*models.LastFmIntegration, error) {
+			assert.Equal("listener", integration.Username)
+			assert.Equal("session_key", integration.SessionKey)
+			stored := *integration
+			stored.ID = "lastfm_integration123"
+			stored.UserID = userID
+			return &stored, nil
+		})
+
+	integration, err := service.HandleCallback(ctx, "lastfm_token", state)
+
+	assert.NoError(err)
+	assert.Equal("lastfm_integration123", integration.ID)
+
+	// A state links a single account
+	_, err = service.HandleCallback(ctx, "lastfm_token", state)
+	assert.ErrorIs(err, ErrLastFmAuthStateInvalid)
+}
+
+func TestLastFmAccountService_HandleCallback_Errors(t *testing.T) {
+	t.Run("unknown state", func(t *testing.T) {
+		assert := require.New(t)
+		service, _ := setupLastFmAccountService(t)
+
+		integration, err := service.HandleCallback(context.Background(), "lastfm_token", "unknown_state")
+
+		assert.Nil(integration)
+		assert.ErrorIs(err, ErrLastFmAuthStateInvalid)
+	})
+
+	t.Run("token never approved", func(t *testing.T) {
+		assert := require.New(t)
+		service, m := setupLastFmAccountService(t)
+		ctx := context.Background()
+
+		state := startLastFmLink(t, service, m)
+		m.lastFm.EXPECT().GetSession(ctx, "lastfm_token").Return(nil, lastfmclient.ErrInvalidToken)
+
+		integration, err := service.HandleCallback(ctx, "lastfm_token", state)
+
+		assert.Nil(integration)
+		assert.ErrorIs(err, ErrLastFmAuthStateInvalid)
+	})
+}
+
+func TestLastFmAccountService_GetAccount_NotLinked(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupLastFmAccountService(t)
+	ctx := context.Background()
+
+	m.integrationRepo.EXPECT().GetByUserID(ctx, "user123").Return(nil, repositories.ErrLastFmIntegrationNotFound)
+
+	integration, err := service.GetAccount(ctx, "user123")
+
+	assert.Nil(integration)
+	assert.ErrorIs(err, ErrLastFmIntegrationUnavailable)
+}
+
+func TestLastFmAccountService_Unlink(t *testing.T) {
+	tests := []struct {
+		name        string
+		deleteErr   error
+		expectedErr error
+	}{
+		{
+			name: "unlinks the account",
+		},
+		{
+			name:        "no linked account",
+			deleteErr:   repositories.ErrLastFmIntegrationNotFound,
+			expectedErr: ErrLastFmIntegrationUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, m := setupLastFmAccountService(t)
+			ctx := context.Background()
+
+			m.integrationRepo.EXPECT().Delete(ctx, "user123").Return(tt.deleteErr)
+
+			err := service.Unlink(ctx, "user123")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(err, tt.expectedErr)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	lastfmclient "github.com/ngomez18/playlist-router/internal/clients/lastfm"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"golang.org/x/sync/errgroup"
+)
+
+// MAX_CONCURRENT_LASTFM_LOOKUPS bounds the tracks looked up on Last.fm at the same time, which
+// allows a handful of requests per second per api key
+const MAX_CONCURRENT_LASTFM_LOOKUPS = 4
+
+//go:generate mockgen -source=lastfm_enrichment_service.go -destination=mocks/mock_lastfm_enrichment_service.go -package=mocks
+
+type LastFmEnricher interface {
+	EnrichTracks(ctx context.Context, userID string, tracks []models.TrackInfo) error
+}
+
+// LastFmEnrichmentService attaches the scrobbles of the linked last.fm account of a user to their
+// tracks: how many times they played each one and its top tags. Tracks are matched by their first
+// artist and name, the client caches the lookups
+type LastFmEnrichmentService struct {
+	integrationRepo repositories.LastFmIntegrationRepository
+	lastFm          lastfmclient.LastFmAPI
+	logger          *slog.Logger
+}
+
+func NewLastFmEnrichmentService(
+	integrationRepo repositories.LastFmIntegrationRepository,
+	lastFm lastfmclient.LastFmAPI,
+	logger *slog.Logger,
+) *LastFmEnrichmentService {
+	return &LastFmEnrichmentService{
+		integrationRepo: integrationRepo,
+		lastFm:          lastFm,
+		logger:          logger.With("component", "LastFmEnrichmentService"),
+	}
+}
+
+// lastFmLookup is a track to look up, shared by the tracks with the same artist and name
+type lastFmLookup struct {
+	artist string
+	track  string
+	info   *lastfmclient.TrackInfo
+}
+
+// EnrichTracks sets the play count and last.fm tags of the tracks, which must already carry their
+// artist names. Users without a linked account are left as they are. Tracks failing to be looked up
+// are skipped, a rate limited api key stops the enrichment and returns ErrRateLimited
+func (s *LastFmEnrichmentService) EnrichTracks(ctx context.Context, userID string, tracks []models.TrackInfo) error {
+	integration, err := s.integrationRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrLastFmIntegrationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve lastfm integration: %w", err)
+	}
+
+	lookups := make(map[string]*lastFmLookup)
+	for _, track := range tracks {
+		key, ok := lastFmLookupKey(track)
+		if !ok {
+			continue
+		}
+		if _, exists := lookups[key]; !exists {
+			lookups[key] = &lastFmLookup{artist: track.ArtistNames[0], track: track.Name}
+		}
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(MAX_CONCURRENT_LASTFM_LOOKUPS)
+	for _, lookup := range lookups {
+		group.Go(func() error {
+			info, err := s.lastFm.GetTrackInfo(groupCtx, integration.Username, lookup.artist, lookup.track)
+			if errors.Is(err, lastfmclient.ErrRateLimited) || groupCtx.Err() != nil {
+				return err
+			}
+			if err != nil {
+				s.logger.WarnContext(ctx, "failed to look up track on lastfm, skipping it",
+					"artist", lookup.artist, "track", lookup.track, "error", err.Error())
+				return nil
+			}
+
+			lookup.info = info
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return fmt.Errorf("failed to look up tracks on lastfm: %w", err)
+	}
+
+	enriched := 0
+	for i := range tracks {
+		key, ok := lastFmLookupKey(tracks[i])
+		if !ok || lookups[key].info == nil {
+			continue
+		}
+
+		playCount := lookups[key].info.UserPlayCount
+		tracks[i].PlayCount = &playCount
+		tracks[i].LastFmTags = lookups[key].info.Tags
+		enriched++
+	}
+
+	s.logger.InfoContext(ctx, "enriched tracks with lastfm scrobbles",
+		"user_id", userID,
+		"tracks", len(tracks),
+		"enriched", enriched,
+		"lookups", len(lookups),
+	)
+
+	return nil
+}
+
+// lastFmLookupKey identifies the tracks sharing a lookup. Tracks without artist names can't be
+// looked up
+func lastFmLookupKey(track models.TrackInfo) (string, bool) {
+	if len(track.ArtistNames) == 0 || track.Name == "" {
+		return "", false
+	}
+
+	return strings.ToLower(track.ArtistNames[0]) + "\x00" + strings.ToLower(track.Name), true
+}
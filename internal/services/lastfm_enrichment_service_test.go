@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	lastfmclient "github.com/ngomez18/playlist-router/internal/clients/lastfm"
+	lastFmMocks "github.com/ngomez18/playlist-router/internal/clients/lastfm/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLastFmEnrichmentService(t *testing.T) (*LastFmEnrichmentService, *repositoryMocks.MockLastFmIntegrationRepository, *lastFmMocks.MockLastFmAPI) {
+	ctrl := setupMockController(t)
+	integrationRepo := repositoryMocks.NewMockLastFmIntegrationRepository(ctrl)
+	lastFm := lastFmMocks.NewMockLastFmAPI(ctrl)
+
+	return NewLastFmEnrichmentService(integrationRepo, lastFm, createTestLogger()), integrationRepo, lastFm
+}
+
+func TestLastFmEnrichmentService_EnrichTracks(t *testing.T) {
+	assert := require.New(t)
+	service, integrationRepo, lastFm := setupLastFmEnrichmentService(t)
+	ctx := context.Background()
+
+	tracks := []models.TrackInfo{
+		{ID: "track1", Name: "Song", ArtistNames: []string{"Artist", "Featured"}},
+		{ID: "track2", Name: "Other Song", ArtistNames: []string{"Artist"}},
+		{ID: "track3", Name: "song", ArtistNames: []string{"artist"}}, // Same song on another album
+		{ID: "track4", Name: "Unknown", ArtistNames: []string{}},
+		{ID: "track5", Name: "Failing", ArtistNames: []string{"Artist"}},
+	}
+
+	integrationRepo.EXPECT().GetByUserID(ctx, "user123").Return(&models.LastFmIntegration{Username: "listener"}, nil)
+	lastFm.EXPECT().GetTrackInfo(gomock.Any(), "listener", "Artist", "Song").
+		Return(&lastfmclient.TrackInfo{UserPlayCount: 12, Tags: []string{"rock"}}, nil)
+	lastFm.EXPECT().GetTrackInfo(gomock.Any(), "listener", "Artist", "Other Song").
+		Return(&lastfmclient.TrackInfo{UserPlayCount: 0, Tags: []string{}}, nil)
+	lastFm.EXPECT().GetTrackInfo(gomock.Any(), "listener", "Artist", "Failing").
+		Return(nil, errors.New("lastfm unreachable"))
+
+	err := service.EnrichTracks(ctx, "user123", tracks)
+
+	assert.NoError(err)
+	assert.Equal(intToPointer(12), tracks[0].PlayCount)
+	assert.Equal([]string{"rock"}, tracks[0].LastFmTags)
+	assert.Equal(intToPointer(0), tracks[1].PlayCount)
+	assert.Equal(intToPointer(12), tracks[2].PlayCount)
+	assert.Nil(tracks[3].PlayCount)
+	assert.Nil(tracks[4].PlayCount)
+}
+
+func TestLastFmEnrichmentService_EnrichTracks_NotLinked(t *testing.T) {
+	assert := require.New(t)
+	service, integrationRepo, _ := setupLastFmEnrichmentService(t)
+	ctx := context.Background()
+
+	tracks := []models.TrackInfo{{ID: "track1", Name: "Song", ArtistNames: []string{"Artist"}}}
+	integrationRepo.EXPECT().GetByUserID(ctx, "user123").Return(nil, repositories.ErrLastFmIntegrationNotFound)
+
+	err := service.EnrichTracks(ctx, "user123", tracks)
+
+	assert.NoError(err)
+	assert.Nil(tracks[0].PlayCount)
+}
+
+func TestLastFmEnrichmentService_EnrichTracks_RateLimited(t *testing.T) {
+	assert := require.New(t)
+	service, integrationRepo, lastFm := setupLastFmEnrichmentService(t)
+	ctx := context.Background()
+
+	tracks := []models.TrackInfo{{ID: "track1", Name: "Song", ArtistNames: []string{"Artist"}}}
+	integrationRepo.EXPECT().GetByUserID(ctx, "user123").Return(&models.LastFmIntegration{Username: "listener"}, nil)
+	lastFm.EXPECT().GetTrackInfo(gomock.Any(), "listener", "Artist", "Song").Return(nil, lastfmclient.ErrRateLimited)
+
+	err := service.EnrichTracks(ctx, "user123", tracks)
+
+	assert.ErrorIs(err, lastfmclient.ErrRateLimited)
+	assert.Nil(tracks[0].PlayCount)
+}
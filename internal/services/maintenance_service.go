@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=maintenance_service.go -destination=mocks/mock_maintenance_service.go -package=mocks
+
+// MAINTENANCE_CACHE_TTL bounds how long maintenance mode toggled on another instance takes to apply
+// here, it is checked on every change so it isn't read from the database each time
+const MAINTENANCE_CACHE_TTL = 10 * time.Second
+
+type MaintenanceServicer interface {
+	// Status returns the current maintenance mode, off while it was never loaded
+	Status(ctx context.Context) *models.MaintenanceMode
+	SetMaintenanceMode(ctx context.Context, req *models.UpdateMaintenanceModeRequest) (*models.MaintenanceMode, error)
+}
+
+type MaintenanceService struct {
+	featureFlagRepo repositories.FeatureFlagRepository
+	logger          *slog.Logger
+
+	cacheMu   sync.Mutex
+	status    *models.MaintenanceMode
+	expiresAt time.Time
+	now       func() time.Time
+}
+
+func NewMaintenanceService(featureFlagRepo repositories.FeatureFlagRepository, logger *slog.Logger) *MaintenanceService {
+	return &MaintenanceService{
+		featureFlagRepo: featureFlagRepo,
+		logger:          logger.With("component", "MaintenanceService"),
+		status:          &models.MaintenanceMode{Message: models.DEFAULT_MAINTENANCE_MESSAGE},
+		now:             time.Now,
+	}
+}
+
+// Status keeps the last loaded mode when it can't be loaded again, so a database hiccup doesn't
+// toggle maintenance mode
+func (mService *MaintenanceService) Status(ctx context.Context) *models.MaintenanceMode {
+	mService.cacheMu.Lock()
+	defer mService.cacheMu.Unlock()
+
+	now := mService.now()
+	if now.Before(mService.expiresAt) {
+		return mService.status
+	}
+	mService.expiresAt = now.Add(MAINTENANCE_CACHE_TTL)
+
+	flag, err := mService.featureFlagRepo.GetByKey(ctx, models.MaintenanceModeFlag)
+	switch {
+	case errors.Is(err, repositories.ErrFeatureFlagNotFound):
+		mService.status = &models.MaintenanceMode{Message: models.DEFAULT_MAINTENANCE_MESSAGE}
+	case err != nil:
+		mService.logger.WarnContext(ctx, "failed to load maintenance mode, keeping the previous one", "error", err.Error())
+	default:
+		mService.status = flagToMaintenanceMode(flag)
+	}
+
+	return mService.status
+}
+
+func (mService *MaintenanceService) SetMaintenanceMode(ctx context.Context, req *models.UpdateMaintenanceModeRequest) (*models.MaintenanceMode, error) {
+	message := req.Message
+	if message == "" {
+		message = models.DEFAULT_MAINTENANCE_MESSAGE
+	}
+
+	flag, err := mService.featureFlagRepo.Upsert(ctx, &models.FeatureFlag{
+		Key:               models.MaintenanceModeFlag,
+		Description:       message,
+		Enabled:           *req.Enabled,
+		RolloutPercentage: 100,
+		UserIDs:           []string{},
+	})
+	if err != nil {
+		mService.logger.ErrorContext(ctx, "failed to update maintenance mode", "enabled", *req.Enabled, "error", err.Error())
+		return nil, fmt.Errorf("failed to update maintenance mode: %w", err)
+	}
+
+	status := flagToMaintenanceMode(flag)
+
+	mService.cacheMu.Lock()
+	mService.status = status
+	mService.expiresAt = mService.now().Add(MAINTENANCE_CACHE_TTL)
+	mService.cacheMu.Unlock()
+
+	if status.Enabled {
+		mService.logger.WarnContext(ctx, "maintenance mode enabled, changes and syncs are paused", "message", status.Message)
+	} else {
+		mService.logger.InfoContext(ctx, "maintenance mode disabled")
+	}
+	return status, nil
+}
+
+func flagToMaintenanceMode(flag *models.FeatureFlag) *models.MaintenanceMode {
+	status := &models.MaintenanceMode{Enabled: flag.Enabled, Message: flag.Description}
+	if status.Message == "" {
+		status.Message = models.DEFAULT_MAINTENANCE_MESSAGE
+	}
+	if status.Enabled && !flag.Updated.IsZero() {
+		since := flag.Updated
+		status.Since = &since
+	}
+
+	return status
+}
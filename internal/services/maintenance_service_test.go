@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMaintenanceService(t *testing.T) (*MaintenanceService, *repositoryMocks.MockFeatureFlagRepository) {
+	ctrl := setupMockController(t)
+	featureFlagRepo := repositoryMocks.NewMockFeatureFlagRepository(ctrl)
+
+	return NewMaintenanceService(featureFlagRepo, createTestLogger()), featureFlagRepo
+}
+
+func TestMaintenanceService_Status(t *testing.T) {
+	assert := require.New(t)
+	service, featureFlagRepo := setupMaintenanceService(t)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	updated := now.Add(-time.Hour)
+
+	gomock.InOrder(
+		featureFlagRepo.EXPECT().GetByKey(ctx, models.MaintenanceModeFlag).Return(&models.FeatureFlag{Key: models.MaintenanceModeFlag, Description: "Upgrading", Enabled: true, Updated: updated}, nil),
+		featureFlagRepo.EXPECT().GetByKey(ctx, models.MaintenanceModeFlag).Return(nil, repositories.ErrDatabaseOperation),
+		featureFlagRepo.EXPECT().GetByKey(ctx, models.MaintenanceModeFlag).Return(nil, repositories.ErrFeatureFlagNotFound),
+	)
+
+	status := service.Status(ctx)
+	assert.True(status.Enabled)
+	assert.Equal("Upgrading", status.Message)
+	assert.Equal(&updated, status.Since)
+
+	now = now.Add(MAINTENANCE_CACHE_TTL - time.Second)
+	assert.True(service.Status(ctx).Enabled)
+
+	// A failed reload keeps the mode loaded before
+	now = now.Add(2 * time.Second)
+	assert.True(service.Status(ctx).Enabled)
+
+	// Without the flag maintenance mode was never turned on
+	now = now.Add(MAINTENANCE_CACHE_TTL)
+	status = service.Status(ctx)
+	assert.False(status.Enabled)
+	assert.Equal(models.DEFAULT_MAINTENANCE_MESSAGE, status.Message)
+	assert.Nil(status.Since)
+}
+
+func TestMaintenanceService_Status_NeverLoaded(t *testing.T) {
+	assert := require.New(t)
+	service, featureFlagRepo := setupMaintenanceService(t)
+	ctx := context.Background()
+
+	featureFlagRepo.EXPECT().GetByKey(ctx, models.MaintenanceModeFlag).Return(nil, repositories.ErrCollectionNotFound)
+
+	assert.False(service.Status(ctx).Enabled)
+}
+
+func TestMaintenanceService_SetMaintenanceMode(t *testing.T) {
+	t.Run("enable with the default message", func(t *testing.T) {
+		assert := require.New(t)
+		service, featureFlagRepo := setupMaintenanceService(t)
+		ctx := context.Background()
+		enabled := true
+		updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		featureFlagRepo.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, flag *models.FeatureFlag) (*models.FeatureFlag, error) {
+			assert.Equal(models.MaintenanceModeFlag, flag.Key)
+			assert.Equal(models.DEFAULT_MAINTENANCE_MESSAGE, flag.Description)
+			assert.True(flag.Enabled)
+			flag.Updated = updated
+			return flag, nil
+		})
+
+		status, err := service.SetMaintenanceMode(ctx, &models.UpdateMaintenanceModeRequest{Enabled: &enabled})
+
+		assert.NoError(err)
+		assert.True(status.Enabled)
+		assert.Equal(models.DEFAULT_MAINTENANCE_MESSAGE, status.Message)
+		assert.Equal(&updated, status.Since)
+
+		// The new mode applies right away, without waiting for the cache to expire
+		assert.True(service.Status(ctx).Enabled)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		assert := require.New(t)
+		service, featureFlagRepo := setupMaintenanceService(t)
+		ctx := context.Background()
+		enabled := false
+
+		featureFlagRepo.EXPECT().Upsert(ctx, gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+
+		status, err := service.SetMaintenanceMode(ctx, &models.UpdateMaintenanceModeRequest{Enabled: &enabled, Message: "Back soon"})
+
+		assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+		assert.Nil(status)
+	})
+}
@@ -36,11 +36,12 @@ func (m *MockAuthServicer) EXPECT() *MockAuthServicerMockRecorder {
 }
 
 // GenerateSpotifyAuthURL mocks base method.
-func (m *MockAuthServicer) GenerateSpotifyAuthURL(state string) string {
+func (m *MockAuthServicer) GenerateSpotifyAuthURL(state string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateSpotifyAuthURL", state)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateSpotifyAuthURL indicates an expected call of GenerateSpotifyAuthURL.
@@ -49,6 +50,21 @@ func (mr *MockAuthServicerMockRecorder) GenerateSpotifyAuthURL(state interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSpotifyAuthURL", reflect.TypeOf((*MockAuthServicer)(nil).GenerateSpotifyAuthURL), state)
 }
 
+// GenerateSpotifyLinkURL mocks base method.
+func (m *MockAuthServicer) GenerateSpotifyLinkURL(userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSpotifyLinkURL", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSpotifyLinkURL indicates an expected call of GenerateSpotifyLinkURL.
+func (mr *MockAuthServicerMockRecorder) GenerateSpotifyLinkURL(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSpotifyLinkURL", reflect.TypeOf((*MockAuthServicer)(nil).GenerateSpotifyLinkURL), userID)
+}
+
 // HandleSpotifyCallback mocks base method.
 func (m *MockAuthServicer) HandleSpotifyCallback(ctx context.Context, code, state string) (*services.AuthResult, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +79,17 @@ func (mr *MockAuthServicerMockRecorder) HandleSpotifyCallback(ctx, code, state i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSpotifyCallback", reflect.TypeOf((*MockAuthServicer)(nil).HandleSpotifyCallback), ctx, code, state)
 }
+
+// Logout mocks base method.
+func (m *MockAuthServicer) Logout(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthServicerMockRecorder) Logout(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthServicer)(nil).Logout), ctx, userID)
+}
@@ -7,9 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ngomez18/playlist-router/internal/models"
+	repositories "github.com/ngomez18/playlist-router/internal/repositories"
 )
 
 // MockBasePlaylistServicer is a mock of BasePlaylistServicer interface.
@@ -35,6 +37,21 @@ func (m *MockBasePlaylistServicer) EXPECT() *MockBasePlaylistServicerMockRecorde
 	return m.recorder
 }
 
+// ArchiveBasePlaylist mocks base method.
+func (m *MockBasePlaylistServicer) ArchiveBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveBasePlaylist", ctx, id, userId)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveBasePlaylist indicates an expected call of ArchiveBasePlaylist.
+func (mr *MockBasePlaylistServicerMockRecorder) ArchiveBasePlaylist(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveBasePlaylist", reflect.TypeOf((*MockBasePlaylistServicer)(nil).ArchiveBasePlaylist), ctx, id, userId)
+}
+
 // CreateBasePlaylist mocks base method.
 func (m *MockBasePlaylistServicer) CreateBasePlaylist(ctx context.Context, userId string, input *models.CreateBasePlaylistRequest) (*models.BasePlaylist, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +81,36 @@ func (mr *MockBasePlaylistServicerMockRecorder) DeleteBasePlaylist(ctx, id, user
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBasePlaylist", reflect.TypeOf((*MockBasePlaylistServicer)(nil).DeleteBasePlaylist), ctx, id, userId)
 }
 
+// DisableHooks mocks base method.
+func (m *MockBasePlaylistServicer) DisableHooks(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableHooks", ctx, id, userId)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableHooks indicates an expected call of DisableHooks.
+func (mr *MockBasePlaylistServicerMockRecorder) DisableHooks(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableHooks", reflect.TypeOf((*MockBasePlaylistServicer)(nil).DisableHooks), ctx, id, userId)
+}
+
+// EnableHooks mocks base method.
+func (m *MockBasePlaylistServicer) EnableHooks(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableHooks", ctx, id, userId)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableHooks indicates an expected call of EnableHooks.
+func (mr *MockBasePlaylistServicerMockRecorder) EnableHooks(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableHooks", reflect.TypeOf((*MockBasePlaylistServicer)(nil).EnableHooks), ctx, id, userId)
+}
+
 // GetBasePlaylist mocks base method.
 func (m *MockBasePlaylistServicer) GetBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +126,21 @@ func (mr *MockBasePlaylistServicerMockRecorder) GetBasePlaylist(ctx, id, userId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBasePlaylist", reflect.TypeOf((*MockBasePlaylistServicer)(nil).GetBasePlaylist), ctx, id, userId)
 }
 
+// GetBasePlaylistByHookToken mocks base method.
+func (m *MockBasePlaylistServicer) GetBasePlaylistByHookToken(ctx context.Context, hookToken string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBasePlaylistByHookToken", ctx, hookToken)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBasePlaylistByHookToken indicates an expected call of GetBasePlaylistByHookToken.
+func (mr *MockBasePlaylistServicerMockRecorder) GetBasePlaylistByHookToken(ctx, hookToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBasePlaylistByHookToken", reflect.TypeOf((*MockBasePlaylistServicer)(nil).GetBasePlaylistByHookToken), ctx, hookToken)
+}
+
 // GetBasePlaylistsByUserID mocks base method.
 func (m *MockBasePlaylistServicer) GetBasePlaylistsByUserID(ctx context.Context, userId string) ([]*models.BasePlaylist, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +170,78 @@ func (mr *MockBasePlaylistServicerMockRecorder) GetBasePlaylistsByUserIDWithChil
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBasePlaylistsByUserIDWithChilds", reflect.TypeOf((*MockBasePlaylistServicer)(nil).GetBasePlaylistsByUserIDWithChilds), ctx, userId)
 }
+
+// ListBasePlaylistsWithChilds mocks base method.
+func (m *MockBasePlaylistServicer) ListBasePlaylistsWithChilds(ctx context.Context, userId string, filter repositories.BasePlaylistFilter) (*models.BasePlaylistPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBasePlaylistsWithChilds", ctx, userId, filter)
+	ret0, _ := ret[0].(*models.BasePlaylistPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBasePlaylistsWithChilds indicates an expected call of ListBasePlaylistsWithChilds.
+func (mr *MockBasePlaylistServicerMockRecorder) ListBasePlaylistsWithChilds(ctx, userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBasePlaylistsWithChilds", reflect.TypeOf((*MockBasePlaylistServicer)(nil).ListBasePlaylistsWithChilds), ctx, userId, filter)
+}
+
+// PurgeDeletedPlaylists mocks base method.
+func (m *MockBasePlaylistServicer) PurgeDeletedPlaylists(ctx context.Context, retention time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedPlaylists", ctx, retention)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedPlaylists indicates an expected call of PurgeDeletedPlaylists.
+func (mr *MockBasePlaylistServicerMockRecorder) PurgeDeletedPlaylists(ctx, retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedPlaylists", reflect.TypeOf((*MockBasePlaylistServicer)(nil).PurgeDeletedPlaylists), ctx, retention)
+}
+
+// RestoreBasePlaylist mocks base method.
+func (m *MockBasePlaylistServicer) RestoreBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBasePlaylist", ctx, id, userId)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBasePlaylist indicates an expected call of RestoreBasePlaylist.
+func (mr *MockBasePlaylistServicerMockRecorder) RestoreBasePlaylist(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBasePlaylist", reflect.TypeOf((*MockBasePlaylistServicer)(nil).RestoreBasePlaylist), ctx, id, userId)
+}
+
+// UnarchiveBasePlaylist mocks base method.
+func (m *MockBasePlaylistServicer) UnarchiveBasePlaylist(ctx context.Context, id, userId string) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveBasePlaylist", ctx, id, userId)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnarchiveBasePlaylist indicates an expected call of UnarchiveBasePlaylist.
+func (mr *MockBasePlaylistServicerMockRecorder) UnarchiveBasePlaylist(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveBasePlaylist", reflect.TypeOf((*MockBasePlaylistServicer)(nil).UnarchiveBasePlaylist), ctx, id, userId)
+}
+
+// UpdateBasePlaylist mocks base method.
+func (m *MockBasePlaylistServicer) UpdateBasePlaylist(ctx context.Context, id, userId string, input *models.UpdateBasePlaylistRequest) (*models.BasePlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBasePlaylist", ctx, id, userId, input)
+	ret0, _ := ret[0].(*models.BasePlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBasePlaylist indicates an expected call of UpdateBasePlaylist.
+func (mr *MockBasePlaylistServicerMockRecorder) UpdateBasePlaylist(ctx, id, userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBasePlaylist", reflect.TypeOf((*MockBasePlaylistServicer)(nil).UpdateBasePlaylist), ctx, id, userId, input)
+}
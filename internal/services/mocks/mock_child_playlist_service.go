@@ -35,6 +35,21 @@ func (m *MockChildPlaylistServicer) EXPECT() *MockChildPlaylistServicerMockRecor
 	return m.recorder
 }
 
+// AddExclusion mocks base method.
+func (m *MockChildPlaylistServicer) AddExclusion(ctx context.Context, id, userID string, field models.ExclusionField, value string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddExclusion", ctx, id, userID, field, value)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddExclusion indicates an expected call of AddExclusion.
+func (mr *MockChildPlaylistServicerMockRecorder) AddExclusion(ctx, id, userID, field, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddExclusion", reflect.TypeOf((*MockChildPlaylistServicer)(nil).AddExclusion), ctx, id, userID, field, value)
+}
+
 // CreateChildPlaylist mocks base method.
 func (m *MockChildPlaylistServicer) CreateChildPlaylist(ctx context.Context, userID, basePlaylistID string, input *models.CreateChildPlaylistRequest) (*models.ChildPlaylist, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +79,36 @@ func (mr *MockChildPlaylistServicerMockRecorder) DeleteChildPlaylist(ctx, id, us
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChildPlaylist", reflect.TypeOf((*MockChildPlaylistServicer)(nil).DeleteChildPlaylist), ctx, id, userID)
 }
 
+// DisableSharing mocks base method.
+func (m *MockChildPlaylistServicer) DisableSharing(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableSharing", ctx, id, userID)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableSharing indicates an expected call of DisableSharing.
+func (mr *MockChildPlaylistServicerMockRecorder) DisableSharing(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableSharing", reflect.TypeOf((*MockChildPlaylistServicer)(nil).DisableSharing), ctx, id, userID)
+}
+
+// EnableSharing mocks base method.
+func (m *MockChildPlaylistServicer) EnableSharing(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableSharing", ctx, id, userID)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableSharing indicates an expected call of EnableSharing.
+func (mr *MockChildPlaylistServicerMockRecorder) EnableSharing(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableSharing", reflect.TypeOf((*MockChildPlaylistServicer)(nil).EnableSharing), ctx, id, userID)
+}
+
 // GetChildPlaylist mocks base method.
 func (m *MockChildPlaylistServicer) GetChildPlaylist(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +139,96 @@ func (mr *MockChildPlaylistServicerMockRecorder) GetChildPlaylistsByBasePlaylist
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildPlaylistsByBasePlaylistID", reflect.TypeOf((*MockChildPlaylistServicer)(nil).GetChildPlaylistsByBasePlaylistID), ctx, basePlaylistID, userID)
 }
 
+// GetMergeChildPlaylistsBySourceBasePlaylistID mocks base method.
+func (m *MockChildPlaylistServicer) GetMergeChildPlaylistsBySourceBasePlaylistID(ctx context.Context, sourceBasePlaylistID, userID string) ([]*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergeChildPlaylistsBySourceBasePlaylistID", ctx, sourceBasePlaylistID, userID)
+	ret0, _ := ret[0].([]*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergeChildPlaylistsBySourceBasePlaylistID indicates an expected call of GetMergeChildPlaylistsBySourceBasePlaylistID.
+func (mr *MockChildPlaylistServicerMockRecorder) GetMergeChildPlaylistsBySourceBasePlaylistID(ctx, sourceBasePlaylistID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeChildPlaylistsBySourceBasePlaylistID", reflect.TypeOf((*MockChildPlaylistServicer)(nil).GetMergeChildPlaylistsBySourceBasePlaylistID), ctx, sourceBasePlaylistID, userID)
+}
+
+// MigrateChildPlaylistToInPlace mocks base method.
+func (m *MockChildPlaylistServicer) MigrateChildPlaylistToInPlace(ctx context.Context, id, userID, spotifyID string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateChildPlaylistToInPlace", ctx, id, userID, spotifyID)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateChildPlaylistToInPlace indicates an expected call of MigrateChildPlaylistToInPlace.
+func (mr *MockChildPlaylistServicerMockRecorder) MigrateChildPlaylistToInPlace(ctx, id, userID, spotifyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateChildPlaylistToInPlace", reflect.TypeOf((*MockChildPlaylistServicer)(nil).MigrateChildPlaylistToInPlace), ctx, id, userID, spotifyID)
+}
+
+// PinTracks mocks base method.
+func (m *MockChildPlaylistServicer) PinTracks(ctx context.Context, id, userID string, trackURIs []string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinTracks", ctx, id, userID, trackURIs)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinTracks indicates an expected call of PinTracks.
+func (mr *MockChildPlaylistServicerMockRecorder) PinTracks(ctx, id, userID, trackURIs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinTracks", reflect.TypeOf((*MockChildPlaylistServicer)(nil).PinTracks), ctx, id, userID, trackURIs)
+}
+
+// ReorderChildPlaylists mocks base method.
+func (m *MockChildPlaylistServicer) ReorderChildPlaylists(ctx context.Context, basePlaylistID, userID string, childPlaylistIDs []string) ([]*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChildPlaylists", ctx, basePlaylistID, userID, childPlaylistIDs)
+	ret0, _ := ret[0].([]*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderChildPlaylists indicates an expected call of ReorderChildPlaylists.
+func (mr *MockChildPlaylistServicerMockRecorder) ReorderChildPlaylists(ctx, basePlaylistID, userID, childPlaylistIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChildPlaylists", reflect.TypeOf((*MockChildPlaylistServicer)(nil).ReorderChildPlaylists), ctx, basePlaylistID, userID, childPlaylistIDs)
+}
+
+// RestoreChildPlaylist mocks base method.
+func (m *MockChildPlaylistServicer) RestoreChildPlaylist(ctx context.Context, id, userID string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreChildPlaylist", ctx, id, userID)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreChildPlaylist indicates an expected call of RestoreChildPlaylist.
+func (mr *MockChildPlaylistServicerMockRecorder) RestoreChildPlaylist(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreChildPlaylist", reflect.TypeOf((*MockChildPlaylistServicer)(nil).RestoreChildPlaylist), ctx, id, userID)
+}
+
+// UnpinTrack mocks base method.
+func (m *MockChildPlaylistServicer) UnpinTrack(ctx context.Context, id, userID, trackURI string) (*models.ChildPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinTrack", ctx, id, userID, trackURI)
+	ret0, _ := ret[0].(*models.ChildPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpinTrack indicates an expected call of UnpinTrack.
+func (mr *MockChildPlaylistServicerMockRecorder) UnpinTrack(ctx, id, userID, trackURI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinTrack", reflect.TypeOf((*MockChildPlaylistServicer)(nil).UnpinTrack), ctx, id, userID, trackURI)
+}
+
 // UpdateChildPlaylist mocks base method.
 func (m *MockChildPlaylistServicer) UpdateChildPlaylist(ctx context.Context, id, userID string, input *models.UpdateChildPlaylistRequest) (*models.ChildPlaylist, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +244,20 @@ func (mr *MockChildPlaylistServicerMockRecorder) UpdateChildPlaylist(ctx, id, us
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChildPlaylist", reflect.TypeOf((*MockChildPlaylistServicer)(nil).UpdateChildPlaylist), ctx, id, userID, input)
 }
 
+// UpdateChildPlaylistCover mocks base method.
+func (m *MockChildPlaylistServicer) UpdateChildPlaylistCover(ctx context.Context, id, userID string, jpegBytes []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChildPlaylistCover", ctx, id, userID, jpegBytes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChildPlaylistCover indicates an expected call of UpdateChildPlaylistCover.
+func (mr *MockChildPlaylistServicerMockRecorder) UpdateChildPlaylistCover(ctx, id, userID, jpegBytes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChildPlaylistCover", reflect.TypeOf((*MockChildPlaylistServicer)(nil).UpdateChildPlaylistCover), ctx, id, userID, jpegBytes)
+}
+
 // UpdateChildPlaylistSpotifyID mocks base method.
 func (m *MockChildPlaylistServicer) UpdateChildPlaylistSpotifyID(ctx context.Context, id, userID, spotifyID string) (*models.ChildPlaylist, error) {
 	m.ctrl.T.Helper()
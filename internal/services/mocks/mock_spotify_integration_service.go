@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ngomez18/playlist-router/internal/models"
@@ -64,6 +65,35 @@ func (mr *MockSpotifyIntegrationServicerMockRecorder) DeleteIntegration(ctx, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIntegration", reflect.TypeOf((*MockSpotifyIntegrationServicer)(nil).DeleteIntegration), ctx, userID)
 }
 
+// DeleteIntegrationByID mocks base method.
+func (m *MockSpotifyIntegrationServicer) DeleteIntegrationByID(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIntegrationByID", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIntegrationByID indicates an expected call of DeleteIntegrationByID.
+func (mr *MockSpotifyIntegrationServicerMockRecorder) DeleteIntegrationByID(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIntegrationByID", reflect.TypeOf((*MockSpotifyIntegrationServicer)(nil).DeleteIntegrationByID), ctx, id, userID)
+}
+
+// GetIntegrationByID mocks base method.
+func (m *MockSpotifyIntegrationServicer) GetIntegrationByID(ctx context.Context, id, userID string) (*models.SpotifyIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntegrationByID", ctx, id, userID)
+	ret0, _ := ret[0].(*models.SpotifyIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIntegrationByID indicates an expected call of GetIntegrationByID.
+func (mr *MockSpotifyIntegrationServicerMockRecorder) GetIntegrationByID(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntegrationByID", reflect.TypeOf((*MockSpotifyIntegrationServicer)(nil).GetIntegrationByID), ctx, id, userID)
+}
+
 // GetIntegrationBySpotifyID mocks base method.
 func (m *MockSpotifyIntegrationServicer) GetIntegrationBySpotifyID(ctx context.Context, spotifyID string) (*models.SpotifyIntegration, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +124,36 @@ func (mr *MockSpotifyIntegrationServicerMockRecorder) GetIntegrationByUserID(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntegrationByUserID", reflect.TypeOf((*MockSpotifyIntegrationServicer)(nil).GetIntegrationByUserID), ctx, userID)
 }
 
+// GetIntegrationsExpiringBefore mocks base method.
+func (m *MockSpotifyIntegrationServicer) GetIntegrationsExpiringBefore(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntegrationsExpiringBefore", ctx, before)
+	ret0, _ := ret[0].([]*models.SpotifyIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIntegrationsExpiringBefore indicates an expected call of GetIntegrationsExpiringBefore.
+func (mr *MockSpotifyIntegrationServicerMockRecorder) GetIntegrationsExpiringBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntegrationsExpiringBefore", reflect.TypeOf((*MockSpotifyIntegrationServicer)(nil).GetIntegrationsExpiringBefore), ctx, before)
+}
+
+// ListIntegrationsByUserID mocks base method.
+func (m *MockSpotifyIntegrationServicer) ListIntegrationsByUserID(ctx context.Context, userID string) ([]*models.SpotifyIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIntegrationsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*models.SpotifyIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIntegrationsByUserID indicates an expected call of ListIntegrationsByUserID.
+func (mr *MockSpotifyIntegrationServicerMockRecorder) ListIntegrationsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIntegrationsByUserID", reflect.TypeOf((*MockSpotifyIntegrationServicer)(nil).ListIntegrationsByUserID), ctx, userID)
+}
+
 // UpdateTokens mocks base method.
 func (m *MockSpotifyIntegrationServicer) UpdateTokens(ctx context.Context, integrationID string, tokens *models.SpotifyIntegrationTokenRefresh) error {
 	m.ctrl.T.Helper()
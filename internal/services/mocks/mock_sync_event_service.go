@@ -7,9 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ngomez18/playlist-router/internal/models"
+	repositories "github.com/ngomez18/playlist-router/internal/repositories"
 )
 
 // MockSyncEventServicer is a mock of SyncEventServicer interface.
@@ -50,6 +52,36 @@ func (mr *MockSyncEventServicerMockRecorder) CreateSyncEvent(ctx, syncEvent inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSyncEvent", reflect.TypeOf((*MockSyncEventServicer)(nil).CreateSyncEvent), ctx, syncEvent)
 }
 
+// GetLastCompletedSyncEvent mocks base method.
+func (m *MockSyncEventServicer) GetLastCompletedSyncEvent(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCompletedSyncEvent", ctx, userID, basePlaylistID)
+	ret0, _ := ret[0].(*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCompletedSyncEvent indicates an expected call of GetLastCompletedSyncEvent.
+func (mr *MockSyncEventServicerMockRecorder) GetLastCompletedSyncEvent(ctx, userID, basePlaylistID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCompletedSyncEvent", reflect.TypeOf((*MockSyncEventServicer)(nil).GetLastCompletedSyncEvent), ctx, userID, basePlaylistID)
+}
+
+// GetRecentCompletedSyncEvents mocks base method.
+func (m *MockSyncEventServicer) GetRecentCompletedSyncEvents(ctx context.Context, userID string, limit int) ([]*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentCompletedSyncEvents", ctx, userID, limit)
+	ret0, _ := ret[0].([]*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentCompletedSyncEvents indicates an expected call of GetRecentCompletedSyncEvents.
+func (mr *MockSyncEventServicerMockRecorder) GetRecentCompletedSyncEvents(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentCompletedSyncEvents", reflect.TypeOf((*MockSyncEventServicer)(nil).GetRecentCompletedSyncEvents), ctx, userID, limit)
+}
+
 // GetSyncEvent mocks base method.
 func (m *MockSyncEventServicer) GetSyncEvent(ctx context.Context, id string) (*models.SyncEvent, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +97,21 @@ func (mr *MockSyncEventServicerMockRecorder) GetSyncEvent(ctx, id interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncEvent", reflect.TypeOf((*MockSyncEventServicer)(nil).GetSyncEvent), ctx, id)
 }
 
+// GetUserSyncEvent mocks base method.
+func (m *MockSyncEventServicer) GetUserSyncEvent(ctx context.Context, userID, id string) (*models.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSyncEvent", ctx, userID, id)
+	ret0, _ := ret[0].(*models.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSyncEvent indicates an expected call of GetUserSyncEvent.
+func (mr *MockSyncEventServicerMockRecorder) GetUserSyncEvent(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSyncEvent", reflect.TypeOf((*MockSyncEventServicer)(nil).GetUserSyncEvent), ctx, userID, id)
+}
+
 // HasActiveSyncForBasePlaylist mocks base method.
 func (m *MockSyncEventServicer) HasActiveSyncForBasePlaylist(ctx context.Context, userID, basePlaylistID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +142,65 @@ func (mr *MockSyncEventServicerMockRecorder) HasActiveSyncForUser(ctx, userID in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveSyncForUser", reflect.TypeOf((*MockSyncEventServicer)(nil).HasActiveSyncForUser), ctx, userID)
 }
 
+// ListSyncEvents mocks base method.
+func (m *MockSyncEventServicer) ListSyncEvents(ctx context.Context, userID string, filter repositories.SyncEventFilter) (*models.SyncEventPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncEvents", ctx, userID, filter)
+	ret0, _ := ret[0].(*models.SyncEventPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSyncEvents indicates an expected call of ListSyncEvents.
+func (mr *MockSyncEventServicerMockRecorder) ListSyncEvents(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncEvents", reflect.TypeOf((*MockSyncEventServicer)(nil).ListSyncEvents), ctx, userID, filter)
+}
+
+// PruneSyncEvents mocks base method.
+func (m *MockSyncEventServicer) PruneSyncEvents(ctx context.Context, userID, basePlaylistID string, olderThan time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneSyncEvents", ctx, userID, basePlaylistID, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneSyncEvents indicates an expected call of PruneSyncEvents.
+func (mr *MockSyncEventServicerMockRecorder) PruneSyncEvents(ctx, userID, basePlaylistID, olderThan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneSyncEvents", reflect.TypeOf((*MockSyncEventServicer)(nil).PruneSyncEvents), ctx, userID, basePlaylistID, olderThan)
+}
+
+// PurgeExpiredSyncEvents mocks base method.
+func (m *MockSyncEventServicer) PurgeExpiredSyncEvents(ctx context.Context, retention time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredSyncEvents", ctx, retention)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredSyncEvents indicates an expected call of PurgeExpiredSyncEvents.
+func (mr *MockSyncEventServicerMockRecorder) PurgeExpiredSyncEvents(ctx, retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredSyncEvents", reflect.TypeOf((*MockSyncEventServicer)(nil).PurgeExpiredSyncEvents), ctx, retention)
+}
+
+// TransitionSyncEventStatus mocks base method.
+func (m *MockSyncEventServicer) TransitionSyncEventStatus(ctx context.Context, id string, from, to models.SyncStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionSyncEventStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransitionSyncEventStatus indicates an expected call of TransitionSyncEventStatus.
+func (mr *MockSyncEventServicerMockRecorder) TransitionSyncEventStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionSyncEventStatus", reflect.TypeOf((*MockSyncEventServicer)(nil).TransitionSyncEventStatus), ctx, id, from, to)
+}
+
 // UpdateSyncEvent mocks base method.
 func (m *MockSyncEventServicer) UpdateSyncEvent(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error) {
 	m.ctrl.T.Helper()
@@ -35,6 +35,21 @@ func (m *MockTrackAggregatorServicer) EXPECT() *MockTrackAggregatorServicerMockR
 	return m.recorder
 }
 
+// AggregateMergedPlaylistData mocks base method.
+func (m *MockTrackAggregatorServicer) AggregateMergedPlaylistData(ctx context.Context, userID string, basePlaylistIDs []string) (*models.PlaylistTracksInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateMergedPlaylistData", ctx, userID, basePlaylistIDs)
+	ret0, _ := ret[0].(*models.PlaylistTracksInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateMergedPlaylistData indicates an expected call of AggregateMergedPlaylistData.
+func (mr *MockTrackAggregatorServicerMockRecorder) AggregateMergedPlaylistData(ctx, userID, basePlaylistIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateMergedPlaylistData", reflect.TypeOf((*MockTrackAggregatorServicer)(nil).AggregateMergedPlaylistData), ctx, userID, basePlaylistIDs)
+}
+
 // AggregatePlaylistData mocks base method.
 func (m *MockTrackAggregatorServicer) AggregatePlaylistData(ctx context.Context, userID, basePlaylistID string) (*models.PlaylistTracksInfo, error) {
 	m.ctrl.T.Helper()
@@ -49,3 +64,17 @@ func (mr *MockTrackAggregatorServicerMockRecorder) AggregatePlaylistData(ctx, us
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregatePlaylistData", reflect.TypeOf((*MockTrackAggregatorServicer)(nil).AggregatePlaylistData), ctx, userID, basePlaylistID)
 }
+
+// StreamPlaylistData mocks base method.
+func (m *MockTrackAggregatorServicer) StreamPlaylistData(ctx context.Context, userID, basePlaylistID string, pages chan<- *models.PlaylistTracksInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPlaylistData", ctx, userID, basePlaylistID, pages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPlaylistData indicates an expected call of StreamPlaylistData.
+func (mr *MockTrackAggregatorServicerMockRecorder) StreamPlaylistData(ctx, userID, basePlaylistID, pages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPlaylistData", reflect.TypeOf((*MockTrackAggregatorServicer)(nil).StreamPlaylistData), ctx, userID, basePlaylistID, pages)
+}
@@ -35,17 +35,33 @@ func (m *MockTrackRouterServicer) EXPECT() *MockTrackRouterServicerMockRecorder
 	return m.recorder
 }
 
-// RouteTracksToChildren mocks base method.
-func (m *MockTrackRouterServicer) RouteTracksToChildren(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist) (map[string][]string, error) {
+// RouteTrackPages mocks base method.
+func (m *MockTrackRouterServicer) RouteTrackPages(ctx context.Context, userID, basePlaylistID string, pages <-chan *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, dedupeStrategy models.DedupeStrategy, routed chan<- models.RoutedTracks) (*models.StreamRoutingSummary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RouteTracksToChildren", ctx, tracks, childPlaylists)
-	ret0, _ := ret[0].(map[string][]string)
+	ret := m.ctrl.Call(m, "RouteTrackPages", ctx, userID, basePlaylistID, pages, childPlaylists, dedupeStrategy, routed)
+	ret0, _ := ret[0].(*models.StreamRoutingSummary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// RouteTrackPages indicates an expected call of RouteTrackPages.
+func (mr *MockTrackRouterServicerMockRecorder) RouteTrackPages(ctx, userID, basePlaylistID, pages, childPlaylists, dedupeStrategy, routed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RouteTrackPages", reflect.TypeOf((*MockTrackRouterServicer)(nil).RouteTrackPages), ctx, userID, basePlaylistID, pages, childPlaylists, dedupeStrategy, routed)
+}
+
+// RouteTracksToChildren mocks base method.
+func (m *MockTrackRouterServicer) RouteTracksToChildren(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, dedupeStrategy models.DedupeStrategy) (map[string][]string, *models.RoutingReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RouteTracksToChildren", ctx, tracks, childPlaylists, dedupeStrategy)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(*models.RoutingReport)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // RouteTracksToChildren indicates an expected call of RouteTracksToChildren.
-func (mr *MockTrackRouterServicerMockRecorder) RouteTracksToChildren(ctx, tracks, childPlaylists interface{}) *gomock.Call {
+func (mr *MockTrackRouterServicerMockRecorder) RouteTracksToChildren(ctx, tracks, childPlaylists, dedupeStrategy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RouteTracksToChildren", reflect.TypeOf((*MockTrackRouterServicer)(nil).RouteTracksToChildren), ctx, tracks, childPlaylists)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RouteTracksToChildren", reflect.TypeOf((*MockTrackRouterServicer)(nil).RouteTracksToChildren), ctx, tracks, childPlaylists, dedupeStrategy)
 }
@@ -94,6 +94,20 @@ func (mr *MockUserServicerMockRecorder) GetUserByID(ctx, userID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserServicer)(nil).GetUserByID), ctx, userID)
 }
 
+// RevokeAuthTokens mocks base method.
+func (m *MockUserServicer) RevokeAuthTokens(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAuthTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAuthTokens indicates an expected call of RevokeAuthTokens.
+func (mr *MockUserServicerMockRecorder) RevokeAuthTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAuthTokens", reflect.TypeOf((*MockUserServicer)(nil).RevokeAuthTokens), ctx, userID)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserServicer) UpdateUser(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"slices"
+
+	mailclient "github.com/ngomez18/playlist-router/internal/clients/mail"
+	notifierclient "github.com/ngomez18/playlist-router/internal/clients/notifier"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=notification_service.go -destination=mocks/mock_notification_service.go -package=mocks
+
+var syncFailureEmailTemplate = template.Must(template.New("sync_failure").Parse(`<p>Hi{{if .Name}} {{.Name}}{{end}},</p>
+<p>The last {{.FailureCount}} scheduled syncs of your playlist <strong>{{.BasePlaylistName}}</strong> failed, so its child playlists are no longer kept up to date.</p>
+{{if .ErrorMessage}}<p>The latest sync failed with: <code>{{.ErrorMessage}}</code></p>
+{{end}}<p>Reconnecting your Spotify account or syncing the playlist manually usually fixes it. We won't email you again about this playlist until a sync succeeds.</p>
+<p>You can turn these emails off in your notification settings.</p>
+`))
+
+type NotificationServicer interface {
+	// GetPreferences returns the stored preferences of the user, or the defaults
+	GetPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID string, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error)
+	// NotifySyncCompleted posts a summary of the finished sync to the chat services picked by the
+	// user, and emails the user when a failed scheduled sync completes a streak of failures of the
+	// base playlist as long as the threshold of the user
+	NotifySyncCompleted(ctx context.Context, syncEvent *models.SyncEvent) error
+}
+
+type NotificationService struct {
+	preferencesRepo   repositories.NotificationPreferencesRepository
+	syncEventRepo     repositories.SyncEventRepository
+	userRepo          repositories.UserRepository
+	basePlaylistRepo  repositories.BasePlaylistRepository
+	childPlaylistRepo repositories.ChildPlaylistRepository
+	mailer            mailclient.Mailer
+	notifiers         map[models.NotificationChannel]notifierclient.Notifier
+	logger            *slog.Logger
+}
+
+func NewNotificationService(
+	preferencesRepo repositories.NotificationPreferencesRepository,
+	syncEventRepo repositories.SyncEventRepository,
+	userRepo repositories.UserRepository,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	mailer mailclient.Mailer,
+	logger *slog.Logger,
+) *NotificationService {
+	return &NotificationService{
+		preferencesRepo:   preferencesRepo,
+		syncEventRepo:     syncEventRepo,
+		userRepo:          userRepo,
+		basePlaylistRepo:  basePlaylistRepo,
+		childPlaylistRepo: childPlaylistRepo,
+		mailer:            mailer,
+		notifiers:         map[models.NotificationChannel]notifierclient.Notifier{},
+		logger:            logger.With("component", "NotificationService"),
+	}
+}
+
+// WithNotifiers lets users pick the chat services of the notifiers to receive sync summaries
+func (nService *NotificationService) WithNotifiers(notifiers ...notifierclient.Notifier) *NotificationService {
+	for _, notifier := range notifiers {
+		nService.notifiers[notifier.Channel()] = notifier
+	}
+	return nService
+}
+
+func (nService *NotificationService) GetPreferences(ctx context.Context, userID string) (*models.NotificationPreferences, error) {
+	preferences, err := nService.preferencesRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotificationPreferencesNotFound) {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	if err != nil {
+		nService.logger.ErrorContext(ctx, "failed to get notification preferences", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+func (nService *NotificationService) UpdatePreferences(ctx context.Context, userID string, req *models.UpdateNotificationPreferencesRequest) (*models.NotificationPreferences, error) {
+	preferences, err := nService.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.EmailOnSyncFailure != nil {
+		preferences.EmailOnSyncFailure = *req.EmailOnSyncFailure
+	}
+	if req.SyncFailureThreshold != nil {
+		preferences.SyncFailureThreshold = *req.SyncFailureThreshold
+	}
+	if req.SyncSummaryChannels != nil {
+		preferences.SyncSummaryChannels = slices.Compact(slices.Sorted(slices.Values(*req.SyncSummaryChannels)))
+	}
+	if req.SlackWebhookURL != nil {
+		preferences.SlackWebhookURL = *req.SlackWebhookURL
+	}
+	if req.DiscordWebhookURL != nil {
+		preferences.DiscordWebhookURL = *req.DiscordWebhookURL
+	}
+
+	if err := nService.validateChannels(preferences); err != nil {
+		return nil, err
+	}
+
+	preferences, err = nService.preferencesRepo.Upsert(ctx, preferences)
+	if err != nil {
+		nService.logger.ErrorContext(ctx, "failed to update notification preferences", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	nService.logger.InfoContext(ctx, "notification preferences updated", "user_id", userID)
+	return preferences, nil
+}
+
+// validateChannels checks every picked chat service has a notifier and a webhook url, and that
+// the webhook urls point to their chat service
+func (nService *NotificationService) validateChannels(preferences *models.NotificationPreferences) error {
+	for _, channel := range []models.NotificationChannel{models.NotificationChannelSlack, models.NotificationChannelDiscord} {
+		webhookURL := preferences.WebhookURL(channel)
+		picked := slices.Contains(preferences.SyncSummaryChannels, channel)
+		if webhookURL == "" && !picked {
+			continue
+		}
+
+		notifier, ok := nService.notifiers[channel]
+		if !ok {
+			return fmt.Errorf("%w: %s notifications are not available", ErrInvalidNotificationPreferences, channel)
+		}
+		if webhookURL == "" {
+			return fmt.Errorf("%w: %s_webhook_url is required to post sync summaries to %s", ErrInvalidNotificationPreferences, channel, channel)
+		}
+		if !notifier.AcceptsWebhookURL(webhookURL) {
+			return fmt.Errorf("%w: %s_webhook_url is not a %s webhook url", ErrInvalidNotificationPreferences, channel, channel)
+		}
+	}
+
+	return nil
+}
+
+// NotifySyncCompleted reports failed notifications without failing the others
+func (nService *NotificationService) NotifySyncCompleted(ctx context.Context, syncEvent *models.SyncEvent) error {
+	preferences, err := nService.GetPreferences(ctx, syncEvent.UserID)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(
+		nService.emailSyncFailure(ctx, syncEvent, preferences),
+		nService.postSyncSummary(ctx, syncEvent, preferences),
+	)
+}
+
+// emailSyncFailure only emails once per streak: the email goes out when the streak reaches the
+// threshold, the following failures are as long as the threshold plus one or more
+func (nService *NotificationService) emailSyncFailure(ctx context.Context, syncEvent *models.SyncEvent, preferences *models.NotificationPreferences) error {
+	if !requestcontext.IsBackgroundJob(ctx) || syncEvent.Status != models.SyncStatusFailed || !preferences.EmailOnSyncFailure {
+		return nil
+	}
+
+	failureCount, err := nService.countConsecutiveFailures(ctx, syncEvent, preferences.SyncFailureThreshold+1)
+	if err != nil {
+		return err
+	}
+	if failureCount != preferences.SyncFailureThreshold {
+		return nil
+	}
+
+	user, err := nService.userRepo.GetByID(ctx, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Email == "" {
+		nService.logger.WarnContext(ctx, "user without email, skipping sync failure notification", "user_id", user.ID)
+		return nil
+	}
+
+	basePlaylist, err := nService.basePlaylistRepo.GetByID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	data := struct {
+		Name             string
+		BasePlaylistName string
+		FailureCount     int
+		ErrorMessage     string
+	}{
+		Name:             user.Name,
+		BasePlaylistName: basePlaylist.Name,
+		FailureCount:     failureCount,
+	}
+	if syncEvent.ErrorMessage != nil {
+		data.ErrorMessage = *syncEvent.ErrorMessage
+	}
+
+	var body bytes.Buffer
+	if err := syncFailureEmailTemplate.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to render sync failure email: %w", err)
+	}
+
+	subject := fmt.Sprintf("Syncs of %s are failing", basePlaylist.Name)
+	if err := nService.mailer.SendEmail(ctx, user.Email, subject, body.String()); err != nil {
+		nService.logger.ErrorContext(ctx, "failed to send sync failure email", "user_id", user.ID, "base_playlist_id", basePlaylist.ID, "error", err.Error())
+		return err
+	}
+
+	nService.logger.InfoContext(ctx, "sync failure email sent",
+		"user_id", user.ID,
+		"base_playlist_id", basePlaylist.ID,
+		"failure_count", failureCount,
+	)
+	return nil
+}
+
+// countConsecutiveFailures counts the latest syncs of the base playlist that failed, up to limit.
+// Syncs of every kind count, so a successful manual sync ends the streak too
+func (nService *NotificationService) countConsecutiveFailures(ctx context.Context, syncEvent *models.SyncEvent, limit int) (int, error) {
+	syncEvents, err := nService.syncEventRepo.List(ctx, repositories.SyncEventFilter{
+		UserID:         syncEvent.UserID,
+		BasePlaylistID: syncEvent.BasePlaylistID,
+		Limit:          limit,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list sync events: %w", err)
+	}
+
+	failureCount := 0
+	for _, event := range syncEvents {
+		if event.Status != models.SyncStatusFailed {
+			break
+		}
+		failureCount++
+	}
+
+	return failureCount, nil
+}
+
+// postSyncSummary posts to every picked chat service, the ones failing don't stop the others
+func (nService *NotificationService) postSyncSummary(ctx context.Context, syncEvent *models.SyncEvent, preferences *models.NotificationPreferences) error {
+	if len(preferences.SyncSummaryChannels) == 0 {
+		return nil
+	}
+
+	summary, err := nService.buildSyncNotification(ctx, syncEvent)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channel := range preferences.SyncSummaryChannels {
+		notifier, ok := nService.notifiers[channel]
+		webhookURL := preferences.WebhookURL(channel)
+		if !ok || webhookURL == "" {
+			continue
+		}
+
+		if err := notifier.NotifySync(ctx, webhookURL, summary); err != nil {
+			nService.logger.WarnContext(ctx, "failed to post sync summary", "user_id", syncEvent.UserID, "channel", channel, "error", err.Error())
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (nService *NotificationService) buildSyncNotification(ctx context.Context, syncEvent *models.SyncEvent) (*models.SyncNotification, error) {
+	basePlaylist, err := nService.basePlaylistRepo.GetByID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	childPlaylists, err := nService.childPlaylistRepo.GetByBasePlaylistID(ctx, syncEvent.BasePlaylistID, syncEvent.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child playlists: %w", err)
+	}
+
+	childNames := make(map[string]string, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		childNames[childPlaylist.ID] = childPlaylist.Name
+	}
+
+	summary := &models.SyncNotification{
+		SyncEventID:      syncEvent.ID,
+		BasePlaylistID:   basePlaylist.ID,
+		BasePlaylistName: basePlaylist.Name,
+		Status:           syncEvent.Status,
+		TracksProcessed:  syncEvent.TracksProcessed,
+		TracksUnmatched:  syncEvent.TracksUnmatched,
+		Children:         make([]models.ChildSyncNotification, 0, len(syncEvent.ChildSyncResults)),
+	}
+	if syncEvent.CompletedAt != nil {
+		summary.Duration = syncEvent.CompletedAt.Sub(syncEvent.StartedAt)
+	}
+	if syncEvent.ErrorMessage != nil {
+		summary.ErrorMessage = *syncEvent.ErrorMessage
+	}
+
+	for _, result := range syncEvent.ChildSyncResults {
+		name, ok := childNames[result.ChildPlaylistID]
+		if !ok {
+			// Deleted since the sync started
+			name = result.ChildPlaylistID
+		}
+
+		child := models.ChildSyncNotification{
+			Name:          name,
+			Status:        result.Status,
+			TracksAdded:   result.TracksAdded,
+			TracksRemoved: result.TracksRemoved,
+		}
+		if result.ErrorMessage != nil {
+			child.ErrorMessage = *result.ErrorMessage
+		}
+		summary.Children = append(summary.Children, child)
+	}
+
+	return summary, nil
+}
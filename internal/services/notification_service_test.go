@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mailmocks "github.com/ngomez18/playlist-router/internal/clients/mail/mocks"
+	notifiermocks "github.com/ngomez18/playlist-router/internal/clients/notifier/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+type notificationServiceMocks struct {
+	preferencesRepo   *repositoryMocks.MockNotificationPreferencesRepository
+	syncEventRepo     *repositoryMocks.MockSyncEventRepository
+	userRepo          *repositoryMocks.MockUserRepository
+	basePlaylistRepo  *repositoryMocks.MockBasePlaylistRepository
+	childPlaylistRepo *repositoryMocks.MockChildPlaylistRepository
+	mailer            *mailmocks.MockMailer
+	slack             *notifiermocks.MockNotifier
+	discord           *notifiermocks.MockNotifier
+}
+
+func setupNotificationService(t *testing.T) (*NotificationService, *notificationServiceMocks) {
+	ctrl := setupMockController(t)
+
+	mocks := &notificationServiceMocks{
+		preferencesRepo:   repositoryMocks.NewMockNotificationPreferencesRepository(ctrl),
+		syncEventRepo:     repositoryMocks.NewMockSyncEventRepository(ctrl),
+		userRepo:          repositoryMocks.NewMockUserRepository(ctrl),
+		basePlaylistRepo:  repositoryMocks.NewMockBasePlaylistRepository(ctrl),
+		childPlaylistRepo: repositoryMocks.NewMockChildPlaylistRepository(ctrl),
+		mailer:            mailmocks.NewMockMailer(ctrl),
+		slack:             notifiermocks.NewMockNotifier(ctrl),
+		discord:           notifiermocks.NewMockNotifier(ctrl),
+	}
+	mocks.slack.EXPECT().Channel().Return(models.NotificationChannelSlack).AnyTimes()
+	mocks.discord.EXPECT().Channel().Return(models.NotificationChannelDiscord).AnyTimes()
+
+	service := NewNotificationService(
+		mocks.preferencesRepo,
+		mocks.syncEventRepo,
+		mocks.userRepo,
+		mocks.basePlaylistRepo,
+		mocks.childPlaylistRepo,
+		mocks.mailer,
+		createTestLogger(),
+	).WithNotifiers(mocks.slack, mocks.discord)
+	return service, mocks
+}
+
+func failedSyncEvents(count int) []*models.SyncEvent {
+	syncEvents := make([]*models.SyncEvent, 0, count+1)
+	for range count {
+		syncEvents = append(syncEvents, testfixtures.NewSyncEvent().Failed("spotify error").Build())
+	}
+	return syncEvents
+}
+
+func TestNotificationService_GetPreferences(t *testing.T) {
+	t.Run("defaults when none are stored", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrNotificationPreferencesNotFound)
+
+		preferences, err := service.GetPreferences(context.Background(), "user123")
+
+		assert.NoError(err)
+		assert.Equal(models.DefaultNotificationPreferences("user123"), preferences)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrDatabaseOperation)
+
+		_, err := service.GetPreferences(context.Background(), "user123")
+
+		assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+	})
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupNotificationService(t)
+
+	threshold := 5
+	mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrNotificationPreferencesNotFound)
+	mocks.preferencesRepo.EXPECT().
+		Upsert(gomock.Any(), &models.NotificationPreferences{
+			UserID:               "user123",
+			EmailOnSyncFailure:   true,
+			SyncFailureThreshold: 5,
+			SyncSummaryChannels:  []models.NotificationChannel{},
+		}).
+		DoAndReturn(func(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+			preferences.ID = "prefs1"
+			return preferences, nil
+		})
+
+	preferences, err := service.UpdatePreferences(context.Background(), "user123", &models.UpdateNotificationPreferencesRequest{SyncFailureThreshold: &threshold})
+
+	assert.NoError(err)
+	assert.Equal("prefs1", preferences.ID)
+	assert.True(preferences.EmailOnSyncFailure)
+}
+
+func TestNotificationService_UpdatePreferences_Channels(t *testing.T) {
+	slackURL := "https://hooks.slack.com/services/T000/B000/XXXX"
+
+	tests := []struct {
+		name        string
+		req         *models.UpdateNotificationPreferencesRequest
+		acceptsURL  bool
+		expectedErr error
+	}{
+		{
+			name:       "picks slack",
+			req:        &models.UpdateNotificationPreferencesRequest{SyncSummaryChannels: &[]models.NotificationChannel{models.NotificationChannelSlack}, SlackWebhookURL: &slackURL},
+			acceptsURL: true,
+		},
+		{
+			name:        "picked channel without webhook url",
+			req:         &models.UpdateNotificationPreferencesRequest{SyncSummaryChannels: &[]models.NotificationChannel{models.NotificationChannelDiscord}},
+			expectedErr: ErrInvalidNotificationPreferences,
+		},
+		{
+			name:        "webhook url of another service",
+			req:         &models.UpdateNotificationPreferencesRequest{SlackWebhookURL: &slackURL},
+			expectedErr: ErrInvalidNotificationPreferences,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, mocks := setupNotificationService(t)
+
+			mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrNotificationPreferencesNotFound)
+			mocks.slack.EXPECT().AcceptsWebhookURL(slackURL).Return(tt.acceptsURL).AnyTimes()
+			if tt.expectedErr == nil {
+				mocks.preferencesRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+						return preferences, nil
+					})
+			}
+
+			preferences, err := service.UpdatePreferences(context.Background(), "user123", tt.req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(err, tt.expectedErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal([]models.NotificationChannel{models.NotificationChannelSlack}, preferences.SyncSummaryChannels)
+			assert.Equal(slackURL, preferences.SlackWebhookURL)
+		})
+	}
+}
+
+func TestNotificationService_NotifySyncCompleted_SyncSummary(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupNotificationService(t)
+
+	startedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	syncEvent := testfixtures.NewSyncEvent().
+		Completed().
+		WithID("sync123").
+		WithUserID("user123").
+		WithBasePlaylistID("base123").
+		WithStartedAt(startedAt).
+		WithCompletedAt(startedAt.Add(12*time.Second)).
+		WithStats(150, 7, 20).
+		WithChildSyncResults(
+			models.ChildSyncResult{ChildPlaylistID: "child1", Status: models.SyncStatusCompleted, TracksAdded: 5, TracksRemoved: 2},
+			models.ChildSyncResult{ChildPlaylistID: "deleted", Status: models.SyncStatusCompleted},
+		).
+		Build()
+
+	mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(&models.NotificationPreferences{
+		UserID:              "user123",
+		EmailOnSyncFailure:  true,
+		SyncSummaryChannels: []models.NotificationChannel{models.NotificationChannelDiscord, models.NotificationChannelSlack},
+		SlackWebhookURL:     "https://hooks.slack.com/services/T000/B000/XXXX",
+		DiscordWebhookURL:   "https://discord.com/api/webhooks/123/token",
+	}, nil)
+	mocks.basePlaylistRepo.EXPECT().GetByID(gomock.Any(), "base123", "user123").Return(testfixtures.NewBasePlaylist().WithID("base123").WithName("Liked Songs").Build(), nil)
+	mocks.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "base123", "user123").Return([]*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child1").WithName("Workout").Build(),
+	}, nil)
+
+	expectedSummary := &models.SyncNotification{
+		SyncEventID:      "sync123",
+		BasePlaylistID:   "base123",
+		BasePlaylistName: "Liked Songs",
+		Status:           models.SyncStatusCompleted,
+		Duration:         12 * time.Second,
+		TracksProcessed:  150,
+		TracksUnmatched:  7,
+		Children: []models.ChildSyncNotification{
+			{Name: "Workout", Status: models.SyncStatusCompleted, TracksAdded: 5, TracksRemoved: 2},
+			{Name: "deleted", Status: models.SyncStatusCompleted},
+		},
+	}
+
+	// A failing chat service doesn't keep the summary from the others
+	mocks.discord.EXPECT().NotifySync(gomock.Any(), "https://discord.com/api/webhooks/123/token", expectedSummary).Return(errors.New("discord error"))
+	mocks.slack.EXPECT().NotifySync(gomock.Any(), "https://hooks.slack.com/services/T000/B000/XXXX", expectedSummary).Return(nil)
+
+	err := service.NotifySyncCompleted(context.Background(), syncEvent)
+
+	assert.ErrorContains(err, "discord error")
+}
+
+func TestNotificationService_NotifySyncCompleted_Email(t *testing.T) {
+	backgroundCtx := requestcontext.ContextWithBackgroundJob(context.Background())
+	syncEvent := testfixtures.NewSyncEvent().WithUserID("user123").WithBasePlaylistID("base123").Failed("spotify error").Build()
+
+	t.Run("emails when the streak reaches the threshold", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrNotificationPreferencesNotFound)
+		mocks.syncEventRepo.EXPECT().
+			List(gomock.Any(), repositories.SyncEventFilter{UserID: "user123", BasePlaylistID: "base123", Limit: 4}).
+			Return(append(failedSyncEvents(3), testfixtures.NewSyncEvent().Completed().Build()), nil)
+		mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123", Email: "user@example.com", Name: "Ana"}, nil)
+		mocks.basePlaylistRepo.EXPECT().GetByID(gomock.Any(), "base123", "user123").Return(testfixtures.NewBasePlaylist().WithID("base123").WithName("Liked <Songs>").Build(), nil)
+
+		var body string
+		mocks.mailer.EXPECT().
+			SendEmail(gomock.Any(), "user@example.com", "Syncs of Liked <Songs> are failing", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, to, subject, html string) error {
+				body = html
+				return nil
+			})
+
+		err := service.NotifySyncCompleted(backgroundCtx, syncEvent)
+
+		assert.NoError(err)
+		assert.Contains(body, "The last 3 scheduled syncs")
+		assert.Contains(body, "Liked &lt;Songs&gt;")
+		assert.Contains(body, "spotify error")
+	})
+
+	t.Run("no email before the threshold", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(models.DefaultNotificationPreferences("user123"), nil)
+		mocks.syncEventRepo.EXPECT().List(gomock.Any(), gomock.Any()).
+			Return(append(failedSyncEvents(2), testfixtures.NewSyncEvent().Completed().Build()), nil)
+
+		assert.NoError(service.NotifySyncCompleted(backgroundCtx, syncEvent))
+	})
+
+	t.Run("no email past the threshold", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(models.DefaultNotificationPreferences("user123"), nil)
+		mocks.syncEventRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(failedSyncEvents(4), nil)
+
+		assert.NoError(service.NotifySyncCompleted(backgroundCtx, syncEvent))
+	})
+
+	t.Run("no email when disabled", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").
+			Return(&models.NotificationPreferences{UserID: "user123", SyncFailureThreshold: 1}, nil)
+
+		assert.NoError(service.NotifySyncCompleted(backgroundCtx, syncEvent))
+	})
+
+	t.Run("no email for syncs started by the user", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(models.DefaultNotificationPreferences("user123"), nil)
+
+		assert.NoError(service.NotifySyncCompleted(context.Background(), syncEvent))
+	})
+
+	t.Run("mailer error", func(t *testing.T) {
+		assert := require.New(t)
+		service, mocks := setupNotificationService(t)
+
+		mocks.preferencesRepo.EXPECT().GetByUserID(gomock.Any(), "user123").
+			Return(&models.NotificationPreferences{UserID: "user123", EmailOnSyncFailure: true, SyncFailureThreshold: 1}, nil)
+		mocks.syncEventRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(failedSyncEvents(1), nil)
+		mocks.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(&models.User{ID: "user123", Email: "user@example.com"}, nil)
+		mocks.basePlaylistRepo.EXPECT().GetByID(gomock.Any(), "base123", "user123").Return(testfixtures.NewBasePlaylist().WithID("base123").Build(), nil)
+		mocks.mailer.EXPECT().SendEmail(gomock.Any(), "user@example.com", gomock.Any(), gomock.Any()).Return(errors.New("smtp error"))
+
+		assert.Error(service.NotifySyncCompleted(backgroundCtx, syncEvent))
+	})
+}
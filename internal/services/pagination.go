@@ -0,0 +1,15 @@
+package services
+
+const (
+	DEFAULT_PAGE_SIZE = 50
+	MAX_PAGE_SIZE     = 100
+)
+
+// pageSize falls back to the default page size for unset limits and caps the others
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return DEFAULT_PAGE_SIZE
+	}
+
+	return min(limit, MAX_PAGE_SIZE)
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=playlist_snapshot_service.go -destination=mocks/mock_playlist_snapshot_service.go -package=mocks
+
+type PlaylistSnapshotServicer interface {
+	CreateSnapshot(ctx context.Context, snapshot *models.PlaylistSnapshot) (*models.PlaylistSnapshot, error)
+	GetSnapshotsBySyncEventID(ctx context.Context, syncEventID, userID string) ([]*models.PlaylistSnapshot, error)
+	RecordMembership(ctx context.Context, membership *models.PlaylistMembership) (*models.PlaylistMembership, error)
+	GetMembership(ctx context.Context, childPlaylistID, userID string) (*models.PlaylistMembership, error)
+	RecordRoutingReport(ctx context.Context, report *models.RoutingReport) (*models.RoutingReport, error)
+	GetRoutingReport(ctx context.Context, syncEventID, userID string) (*models.RoutingReport, error)
+}
+
+type PlaylistSnapshotService struct {
+	snapshotRepo   repositories.PlaylistSnapshotRepository
+	membershipRepo repositories.PlaylistMembershipRepository
+	reportRepo     repositories.RoutingReportRepository
+	logger         *slog.Logger
+}
+
+func NewPlaylistSnapshotService(
+	snapshotRepo repositories.PlaylistSnapshotRepository,
+	membershipRepo repositories.PlaylistMembershipRepository,
+	reportRepo repositories.RoutingReportRepository,
+	logger *slog.Logger,
+) *PlaylistSnapshotService {
+	return &PlaylistSnapshotService{
+		snapshotRepo:   snapshotRepo,
+		membershipRepo: membershipRepo,
+		reportRepo:     reportRepo,
+		logger:         logger.With("component", "PlaylistSnapshotService"),
+	}
+}
+
+func (psService *PlaylistSnapshotService) CreateSnapshot(ctx context.Context, snapshot *models.PlaylistSnapshot) (*models.PlaylistSnapshot, error) {
+	psService.logger.InfoContext(ctx, "creating playlist snapshot", "sync_event_id", snapshot.SyncEventID, "child_playlist_id", snapshot.ChildPlaylistID)
+
+	createdSnapshot, err := psService.snapshotRepo.Create(ctx, snapshot)
+	if err != nil {
+		psService.logger.ErrorContext(ctx, "failed to create playlist snapshot", "sync_event_id", snapshot.SyncEventID, "child_playlist_id", snapshot.ChildPlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to create playlist snapshot: %w", err)
+	}
+
+	psService.logger.InfoContext(ctx, "playlist snapshot created successfully", "snapshot_id", createdSnapshot.ID, "track_count", len(createdSnapshot.TrackURIs))
+	return createdSnapshot, nil
+}
+
+func (psService *PlaylistSnapshotService) GetSnapshotsBySyncEventID(ctx context.Context, syncEventID, userID string) ([]*models.PlaylistSnapshot, error) {
+	psService.logger.InfoContext(ctx, "retrieving playlist snapshots", "sync_event_id", syncEventID, "user_id", userID)
+
+	snapshots, err := psService.snapshotRepo.GetBySyncEventID(ctx, syncEventID, userID)
+	if err != nil {
+		psService.logger.ErrorContext(ctx, "failed to retrieve playlist snapshots", "sync_event_id", syncEventID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve playlist snapshots: %w", err)
+	}
+
+	psService.logger.InfoContext(ctx, "playlist snapshots retrieved successfully", "sync_event_id", syncEventID, "count", len(snapshots))
+	return snapshots, nil
+}
+
+// RecordMembership stores the tracks a sync wrote to a child playlist, replacing the previous membership
+func (psService *PlaylistSnapshotService) RecordMembership(ctx context.Context, membership *models.PlaylistMembership) (*models.PlaylistMembership, error) {
+	psService.logger.InfoContext(ctx, "recording playlist membership", "sync_event_id", membership.SyncEventID, "child_playlist_id", membership.ChildPlaylistID)
+
+	storedMembership, err := psService.membershipRepo.Upsert(ctx, membership)
+	if err != nil {
+		psService.logger.ErrorContext(ctx, "failed to record playlist membership", "child_playlist_id", membership.ChildPlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to record playlist membership: %w", err)
+	}
+
+	return storedMembership, nil
+}
+
+func (psService *PlaylistSnapshotService) GetMembership(ctx context.Context, childPlaylistID, userID string) (*models.PlaylistMembership, error) {
+	membership, err := psService.membershipRepo.GetByChildPlaylistID(ctx, childPlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve playlist membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+// RecordRoutingReport stores how a sync routed the tracks of a base playlist, replacing the report of its previous sync
+func (psService *PlaylistSnapshotService) RecordRoutingReport(ctx context.Context, report *models.RoutingReport) (*models.RoutingReport, error) {
+	psService.logger.InfoContext(ctx, "recording routing report", "sync_event_id", report.SyncEventID, "base_playlist_id", report.BasePlaylistID)
+
+	storedReport, err := psService.reportRepo.Upsert(ctx, report)
+	if err != nil {
+		psService.logger.ErrorContext(ctx, "failed to record routing report", "base_playlist_id", report.BasePlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to record routing report: %w", err)
+	}
+
+	return storedReport, nil
+}
+
+func (psService *PlaylistSnapshotService) GetRoutingReport(ctx context.Context, syncEventID, userID string) (*models.RoutingReport, error) {
+	report, err := psService.reportRepo.GetBySyncEventID(ctx, syncEventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve routing report: %w", err)
+	}
+
+	return report, nil
+}
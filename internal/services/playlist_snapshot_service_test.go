@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylistSnapshotService_CreateSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectError bool
+	}{
+		{name: "success"},
+		{name: "repository error", repoErr: repositories.ErrDatabaseOperation, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockPlaylistSnapshotRepository(ctrl)
+			service := NewPlaylistSnapshotService(mockRepo, mocks.NewMockPlaylistMembershipRepository(ctrl), mocks.NewMockRoutingReportRepository(ctrl), createTestLogger())
+
+			ctx := context.Background()
+			snapshot := &models.PlaylistSnapshot{
+				UserID:          "user123",
+				SyncEventID:     "sync123",
+				ChildPlaylistID: "child123",
+				TrackURIs:       []string{"spotify:track:1"},
+			}
+
+			if tt.repoErr != nil {
+				mockRepo.EXPECT().Create(ctx, snapshot).Return(nil, tt.repoErr)
+			} else {
+				created := *snapshot
+				created.ID = "snapshot123"
+				mockRepo.EXPECT().Create(ctx, snapshot).Return(&created, nil)
+			}
+
+			result, err := service.CreateSnapshot(ctx, snapshot)
+
+			if tt.expectError {
+				require.Error(err)
+				require.ErrorIs(err, tt.repoErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal("snapshot123", result.ID)
+		})
+	}
+}
+
+func TestPlaylistSnapshotService_GetSnapshotsBySyncEventID(t *testing.T) {
+	tests := []struct {
+		name        string
+		snapshots   []*models.PlaylistSnapshot
+		repoErr     error
+		expectError bool
+	}{
+		{
+			name: "success",
+			snapshots: []*models.PlaylistSnapshot{
+				{ID: "snapshot1", SyncEventID: "sync123", ChildPlaylistID: "child1"},
+				{ID: "snapshot2", SyncEventID: "sync123", ChildPlaylistID: "child2"},
+			},
+		},
+		{name: "repository error", repoErr: errors.New("db error"), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockPlaylistSnapshotRepository(ctrl)
+			service := NewPlaylistSnapshotService(mockRepo, mocks.NewMockPlaylistMembershipRepository(ctrl), mocks.NewMockRoutingReportRepository(ctrl), createTestLogger())
+
+			ctx := context.Background()
+			mockRepo.EXPECT().GetBySyncEventID(ctx, "sync123", "user123").Return(tt.snapshots, tt.repoErr)
+
+			result, err := service.GetSnapshotsBySyncEventID(ctx, "sync123", "user123")
+
+			if tt.expectError {
+				require.Error(err)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.snapshots, result)
+		})
+	}
+}
+
+func TestPlaylistSnapshotService_RecordMembership(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectError bool
+	}{
+		{name: "success"},
+		{name: "repository error", repoErr: repositories.ErrUnauthorized, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMembershipRepo := mocks.NewMockPlaylistMembershipRepository(ctrl)
+			service := NewPlaylistSnapshotService(mocks.NewMockPlaylistSnapshotRepository(ctrl), mockMembershipRepo, mocks.NewMockRoutingReportRepository(ctrl), createTestLogger())
+
+			ctx := context.Background()
+			membership := &models.PlaylistMembership{
+				UserID:            "user123",
+				ChildPlaylistID:   "child123",
+				SyncEventID:       "sync123",
+				SpotifyPlaylistID: "spotify123",
+				TrackURIs:         []string{"spotify:track:1"},
+			}
+
+			if tt.repoErr != nil {
+				mockMembershipRepo.EXPECT().Upsert(ctx, membership).Return(nil, tt.repoErr)
+			} else {
+				stored := *membership
+				stored.ID = "membership123"
+				mockMembershipRepo.EXPECT().Upsert(ctx, membership).Return(&stored, nil)
+			}
+
+			result, err := service.RecordMembership(ctx, membership)
+
+			if tt.expectError {
+				require.ErrorIs(err, tt.repoErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal("membership123", result.ID)
+		})
+	}
+}
+
+func TestPlaylistSnapshotService_GetMembership(t *testing.T) {
+	tests := []struct {
+		name       string
+		membership *models.PlaylistMembership
+		repoErr    error
+	}{
+		{name: "success", membership: &models.PlaylistMembership{ID: "membership123", ChildPlaylistID: "child123"}},
+		{name: "not found", repoErr: repositories.ErrPlaylistMembershipNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMembershipRepo := mocks.NewMockPlaylistMembershipRepository(ctrl)
+			service := NewPlaylistSnapshotService(mocks.NewMockPlaylistSnapshotRepository(ctrl), mockMembershipRepo, mocks.NewMockRoutingReportRepository(ctrl), createTestLogger())
+
+			ctx := context.Background()
+			mockMembershipRepo.EXPECT().GetByChildPlaylistID(ctx, "child123", "user123").Return(tt.membership, tt.repoErr)
+
+			result, err := service.GetMembership(ctx, "child123", "user123")
+
+			if tt.repoErr != nil {
+				require.ErrorIs(err, tt.repoErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.membership, result)
+		})
+	}
+}
+
+func TestPlaylistSnapshotService_RecordRoutingReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+	}{
+		{name: "success"},
+		{name: "repository error", repoErr: repositories.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := mocks.NewMockRoutingReportRepository(ctrl)
+			service := NewPlaylistSnapshotService(mocks.NewMockPlaylistSnapshotRepository(ctrl), mocks.NewMockPlaylistMembershipRepository(ctrl), mockReportRepo, createTestLogger())
+
+			ctx := context.Background()
+			report := &models.RoutingReport{
+				UserID:         "user123",
+				BasePlaylistID: "base123",
+				SyncEventID:    "sync123",
+				Tracks: []models.TrackRoutingDecision{
+					{TrackURI: "spotify:track:1", Outcome: models.RoutingOutcomeUnmatched},
+				},
+			}
+
+			if tt.repoErr != nil {
+				mockReportRepo.EXPECT().Upsert(ctx, report).Return(nil, tt.repoErr)
+			} else {
+				stored := *report
+				stored.ID = "report123"
+				mockReportRepo.EXPECT().Upsert(ctx, report).Return(&stored, nil)
+			}
+
+			result, err := service.RecordRoutingReport(ctx, report)
+
+			if tt.repoErr != nil {
+				require.ErrorIs(err, tt.repoErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal("report123", result.ID)
+		})
+	}
+}
+
+func TestPlaylistSnapshotService_GetRoutingReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  *models.RoutingReport
+		repoErr error
+	}{
+		{name: "success", report: &models.RoutingReport{ID: "report123", SyncEventID: "sync123"}},
+		{name: "not found", repoErr: repositories.ErrRoutingReportNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReportRepo := mocks.NewMockRoutingReportRepository(ctrl)
+			service := NewPlaylistSnapshotService(mocks.NewMockPlaylistSnapshotRepository(ctrl), mocks.NewMockPlaylistMembershipRepository(ctrl), mockReportRepo, createTestLogger())
+
+			ctx := context.Background()
+			mockReportRepo.EXPECT().GetBySyncEventID(ctx, "sync123", "user123").Return(tt.report, tt.repoErr)
+
+			result, err := service.GetRoutingReport(ctx, "sync123", "user123")
+
+			if tt.repoErr != nil {
+				require.ErrorIs(err, tt.repoErr)
+				require.Nil(result)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.report, result)
+		})
+	}
+}
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=playlist_webhook_service.go -destination=mocks/mock_playlist_webhook_service.go -package=mocks
+
+// WEBHOOK_DELIVERY_TIMEOUT bounds each delivery, so a slow receiver can't stall the poller
+const WEBHOOK_DELIVERY_TIMEOUT = 10 * time.Second
+
+const (
+	WEBHOOK_EVENT_HEADER     = "X-PlaylistRouter-Event"
+	WEBHOOK_SIGNATURE_HEADER = "X-PlaylistRouter-Signature"
+)
+
+// PlaylistWebhookServicer manages the webhooks of base playlists and notifies them when the
+// base playlist changes outside of the router, e.g. tracks added from the Spotify app
+type PlaylistWebhookServicer interface {
+	CreateWebhook(ctx context.Context, userID, basePlaylistID, url string) (*models.CreatedPlaylistWebhook, error)
+	ListWebhooks(ctx context.Context, userID, basePlaylistID string) ([]*models.PlaylistWebhook, error)
+	DeleteWebhook(ctx context.Context, userID, id string) error
+	PollBasePlaylistChanges(ctx context.Context) (*models.PlaylistChangePollReport, error)
+}
+
+type PlaylistWebhookService struct {
+	webhookRepo      repositories.PlaylistWebhookRepository
+	watchRepo        repositories.BasePlaylistWatchRepository
+	basePlaylistRepo repositories.BasePlaylistRepository
+	userRepo         repositories.UserRepository
+	musicProvider    musicprovider.MusicProvider
+	spotifyAuth      SpotifyAuthProvider
+	httpClient       clients.HTTPClient
+	resolver         clients.IPResolver
+	logger           *slog.Logger
+
+	now func() time.Time
+}
+
+func NewPlaylistWebhookService(
+	webhookRepo repositories.PlaylistWebhookRepository,
+	watchRepo repositories.BasePlaylistWatchRepository,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	userRepo repositories.UserRepository,
+	musicProvider musicprovider.MusicProvider,
+	spotifyAuth SpotifyAuthProvider,
+	httpClient clients.HTTPClient,
+	logger *slog.Logger,
+) *PlaylistWebhookService {
+	return &PlaylistWebhookService{
+		webhookRepo:      webhookRepo,
+		watchRepo:        watchRepo,
+		basePlaylistRepo: basePlaylistRepo,
+		userRepo:         userRepo,
+		musicProvider:    musicProvider,
+		spotifyAuth:      spotifyAuth,
+		httpClient:       httpClient,
+		resolver:         net.DefaultResolver,
+		logger:           logger.With("component", "PlaylistWebhookService"),
+		now:              time.Now,
+	}
+}
+
+// CreateWebhook registers url on the base playlist. Webhooks are called from the server, so urls of
+// internal addresses are refused; the http client given to the service must refuse them too, in
+// case the host resolves to another address by the time a change is delivered
+func (pwService *PlaylistWebhookService) CreateWebhook(ctx context.Context, userID, basePlaylistID, url string) (*models.CreatedPlaylistWebhook, error) {
+	if err := clients.ValidatePublicURL(ctx, pwService.resolver, url); err != nil {
+		pwService.logger.WarnContext(ctx, "refused playlist webhook url", "base_playlist_id", basePlaylistID, "error", err.Error())
+		return nil, ErrInvalidWebhookURL
+	}
+
+	// Ensures the base playlist exists and belongs to the user
+	if _, err := pwService.basePlaylistRepo.GetByID(ctx, basePlaylistID, userID); err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	secret, err := generateSecretToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	webhook, err := pwService.webhookRepo.Create(ctx, &models.PlaylistWebhook{
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		URL:            url,
+		Secret:         secret,
+		IsActive:       true,
+	})
+	if err != nil {
+		pwService.logger.ErrorContext(ctx, "failed to create playlist webhook", "base_playlist_id", basePlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to create playlist webhook: %w", err)
+	}
+
+	pwService.logger.InfoContext(ctx, "playlist webhook created", "id", webhook.ID, "base_playlist_id", basePlaylistID)
+	return &models.CreatedPlaylistWebhook{PlaylistWebhook: webhook, Secret: secret}, nil
+}
+
+func (pwService *PlaylistWebhookService) ListWebhooks(ctx context.Context, userID, basePlaylistID string) ([]*models.PlaylistWebhook, error) {
+	if _, err := pwService.basePlaylistRepo.GetByID(ctx, basePlaylistID, userID); err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	webhooks, err := pwService.webhookRepo.GetByBasePlaylistID(ctx, basePlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve playlist webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (pwService *PlaylistWebhookService) DeleteWebhook(ctx context.Context, userID, id string) error {
+	if err := pwService.webhookRepo.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to delete playlist webhook: %w", err)
+	}
+
+	pwService.logger.InfoContext(ctx, "playlist webhook deleted", "id", id)
+	return nil
+}
+
+// PollBasePlaylistChanges checks the base playlists with active webhooks and delivers the tracks
+// added and removed since the last poll. The Spotify snapshot ID is compared first, so unchanged
+// playlists cost a single API call. The first poll of a playlist only records its baseline.
+// Playlists of disabled users are skipped until the user is enabled again
+func (pwService *PlaylistWebhookService) PollBasePlaylistChanges(ctx context.Context) (*models.PlaylistChangePollReport, error) {
+	webhooks, err := pwService.webhookRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve active playlist webhooks: %w", err)
+	}
+
+	basePlaylistIDs := []string{}
+	webhooksByBase := make(map[string][]*models.PlaylistWebhook)
+	for _, webhook := range webhooks {
+		if _, ok := webhooksByBase[webhook.BasePlaylistID]; !ok {
+			basePlaylistIDs = append(basePlaylistIDs, webhook.BasePlaylistID)
+		}
+		webhooksByBase[webhook.BasePlaylistID] = append(webhooksByBase[webhook.BasePlaylistID], webhook)
+	}
+
+	report := &models.PlaylistChangePollReport{}
+	disabledUsers := make(map[string]bool)
+	for _, basePlaylistID := range basePlaylistIDs {
+		baseWebhooks := webhooksByBase[basePlaylistID]
+		userID := baseWebhooks[0].UserID
+
+		disabled, ok := disabledUsers[userID]
+		if !ok {
+			user, err := pwService.userRepo.GetByID(ctx, userID)
+			if err != nil {
+				pwService.logger.WarnContext(ctx, "failed to get base playlist owner", "base_playlist_id", basePlaylistID, "error", err.Error())
+				report.BasePlaylistsChecked++
+				report.Failed++
+				continue
+			}
+			disabled = user.Disabled
+			disabledUsers[userID] = disabled
+		}
+		if disabled {
+			pwService.logger.InfoContext(ctx, "skipping base playlist of disabled user", "base_playlist_id", basePlaylistID, "user_id", userID)
+			continue
+		}
+
+		report.BasePlaylistsChecked++
+
+		change, err := pwService.detectChange(ctx, userID, basePlaylistID)
+		if err != nil {
+			pwService.logger.WarnContext(ctx, "failed to check base playlist for changes", "base_playlist_id", basePlaylistID, "error", err.Error())
+			report.Failed++
+			continue
+		}
+
+		if change == nil {
+			continue
+		}
+
+		report.BasePlaylistsChanged++
+		for _, webhook := range baseWebhooks {
+			report.Deliveries++
+			if !pwService.deliver(ctx, webhook, change) {
+				report.FailedDeliveries++
+			}
+		}
+	}
+
+	pwService.logger.InfoContext(ctx, "base playlist change poll finished",
+		"checked", report.BasePlaylistsChecked,
+		"changed", report.BasePlaylistsChanged,
+		"deliveries", report.Deliveries,
+		"failed_deliveries", report.FailedDeliveries,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
+
+// detectChange returns the delta of the base playlist since the last poll, or nil when there
+// is nothing to deliver
+func (pwService *PlaylistWebhookService) detectChange(ctx context.Context, userID, basePlaylistID string) (*models.BasePlaylistChange, error) {
+	basePlaylist, err := pwService.basePlaylistRepo.GetByID(ctx, basePlaylistID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base playlist: %w", err)
+	}
+
+	spotifyCtx, err := pwService.spotifyAuth.ContextWithSpotifyAccount(ctx, userID, basePlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with spotify: %w", err)
+	}
+	spotifyCtx = requestcontext.ContextWithMusicProvider(spotifyCtx, basePlaylist.Provider)
+	// The poller runs in the background, it can wait for the request budget
+	spotifyCtx = spotifyclient.ContextWithQuotaQueue(spotifyCtx, nil)
+
+	playlist, err := pwService.musicProvider.GetPlaylist(spotifyCtx, basePlaylist.SpotifyPlaylistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spotify playlist: %w", err)
+	}
+
+	watch, err := pwService.watchRepo.GetByBasePlaylistID(ctx, basePlaylistID)
+	if err != nil && !errors.Is(err, repositories.ErrBasePlaylistWatchNotFound) {
+		return nil, fmt.Errorf("failed to get base playlist watch: %w", err)
+	}
+
+	if watch != nil && watch.SnapshotID == playlist.SnapshotID {
+		return nil, nil
+	}
+
+	trackURIs, err := pwService.getAllTrackURIs(spotifyCtx, basePlaylist.SpotifyPlaylistID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pwService.watchRepo.Upsert(ctx, &models.BasePlaylistWatch{
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		SnapshotID:     playlist.SnapshotID,
+		TrackURIs:      trackURIs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store base playlist watch: %w", err)
+	}
+
+	if watch == nil {
+		pwService.logger.InfoContext(ctx, "recorded base playlist baseline", "base_playlist_id", basePlaylistID, "tracks", len(trackURIs))
+		return nil, nil
+	}
+
+	added, removed := diffTrackURIs(watch.TrackURIs, trackURIs)
+	// The snapshot also changes on reorders and renames, which aren't delivered
+	if len(added) == 0 && len(removed) == 0 {
+		return nil, nil
+	}
+
+	return &models.BasePlaylistChange{
+		Event:              models.PlaylistWebhookEventBaseChanged,
+		BasePlaylistID:     basePlaylistID,
+		BasePlaylistName:   basePlaylist.Name,
+		SpotifyPlaylistID:  basePlaylist.SpotifyPlaylistID,
+		PreviousSnapshotID: watch.SnapshotID,
+		SnapshotID:         playlist.SnapshotID,
+		AddedTrackURIs:     added,
+		RemovedTrackURIs:   removed,
+		TrackCount:         len(trackURIs),
+		DetectedAt:         pwService.now().UTC(),
+	}, nil
+}
+
+func (pwService *PlaylistWebhookService) getAllTrackURIs(ctx context.Context, playlistID string) ([]string, error) {
+	trackURIs := []string{}
+	offset := 0
+
+	for {
+		tracksResp, err := pwService.musicProvider.GetPlaylistTracks(ctx, playlistID, MAX_TRACKS, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch playlist tracks: %w", err)
+		}
+
+		for _, item := range tracksResp.Items {
+			if item.Track != nil && item.Track.URI != "" {
+				trackURIs = append(trackURIs, item.Track.URI)
+			}
+		}
+
+		if tracksResp.Next == nil {
+			break
+		}
+
+		offset += MAX_TRACKS
+	}
+
+	return trackURIs, nil
+}
+
+// deliver posts the change to the webhook and records the outcome. Failed deliveries aren't
+// retried, the next change is delivered as usual
+func (pwService *PlaylistWebhookService) deliver(ctx context.Context, webhook *models.PlaylistWebhook, change *models.BasePlaylistChange) bool {
+	status, err := pwService.post(ctx, webhook, change)
+	if err != nil {
+		pwService.logger.WarnContext(ctx, "failed to deliver playlist webhook", "id", webhook.ID, "status", status, "error", err.Error())
+	}
+
+	if recordErr := pwService.webhookRepo.RecordDelivery(ctx, webhook.ID, status, pwService.now()); recordErr != nil {
+		pwService.logger.WarnContext(ctx, "failed to record playlist webhook delivery", "id", webhook.ID, "error", recordErr.Error())
+	}
+
+	return err == nil
+}
+
+func (pwService *PlaylistWebhookService) post(ctx context.Context, webhook *models.PlaylistWebhook, change *models.BasePlaylistChange) (int, error) {
+	body, err := json.Marshal(change)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode change: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, WEBHOOK_DELIVERY_TIMEOUT)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WEBHOOK_EVENT_HEADER, change.Event)
+	req.Header.Set(WEBHOOK_SIGNATURE_HEADER, signWebhookPayload(webhook.Secret, body))
+
+	resp, err := pwService.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// signWebhookPayload signs the body with HMAC-SHA256, so receivers can check the delivery
+// comes from the router
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// diffTrackURIs returns the tracks of current missing from previous and the tracks of previous
+// missing from current, keeping the playlist order
+func diffTrackURIs(previous, current []string) (added, removed []string) {
+	previousSet := make(map[string]struct{}, len(previous))
+	for _, uri := range previous {
+		previousSet[uri] = struct{}{}
+	}
+
+	currentSet := make(map[string]struct{}, len(current))
+	for _, uri := range current {
+		currentSet[uri] = struct{}{}
+	}
+
+	added = []string{}
+	for _, uri := range current {
+		if _, ok := previousSet[uri]; !ok {
+			added = append(added, uri)
+			previousSet[uri] = struct{}{} // Only reports duplicates once
+		}
+	}
+
+	removed = []string{}
+	for _, uri := range previous {
+		if _, ok := currentSet[uri]; !ok {
+			removed = append(removed, uri)
+			currentSet[uri] = struct{}{}
+		}
+	}
+
+	return added, removed
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+type playlistWebhookServiceMocks struct {
+	webhookRepo      *repositoryMocks.MockPlaylistWebhookRepository
+	watchRepo        *repositoryMocks.MockBasePlaylistWatchRepository
+	basePlaylistRepo *repositoryMocks.MockBasePlaylistRepository
+	userRepo         *repositoryMocks.MockUserRepository
+	spotifyClient    *spotifyClientMocks.MockSpotifyAPI
+	spotifyAuth      *fakeSpotifyAuthProvider
+}
+
+// fakeResolver resolves every host to the same address
+type fakeResolver struct {
+	ip string
+}
+
+func (f *fakeResolver) LookupIPAddr(_ context.Context, _ string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.ParseIP(f.ip)}}, nil
+}
+
+func setupPlaylistWebhookService(t *testing.T) (*PlaylistWebhookService, playlistWebhookServiceMocks) {
+	ctrl := setupMockController(t)
+
+	mocks := playlistWebhookServiceMocks{
+		webhookRepo:      repositoryMocks.NewMockPlaylistWebhookRepository(ctrl),
+		watchRepo:        repositoryMocks.NewMockBasePlaylistWatchRepository(ctrl),
+		basePlaylistRepo: repositoryMocks.NewMockBasePlaylistRepository(ctrl),
+		userRepo:         repositoryMocks.NewMockUserRepository(ctrl),
+		spotifyClient:    spotifyClientMocks.NewMockSpotifyAPI(ctrl),
+		spotifyAuth:      &fakeSpotifyAuthProvider{},
+	}
+
+	service := NewPlaylistWebhookService(
+		mocks.webhookRepo,
+		mocks.watchRepo,
+		mocks.basePlaylistRepo,
+		mocks.userRepo,
+		mocks.spotifyClient,
+		mocks.spotifyAuth,
+		http.DefaultClient,
+		createTestLogger(),
+	)
+	service.resolver = &fakeResolver{ip: "93.184.216.34"}
+	return service, mocks
+}
+
+func playlistTracksPage(uris ...string) *spotifyclient.SpotifyPlaylistTracksResponse {
+	items := make([]spotifyclient.SpotifyPlaylistTrack, 0, len(uris))
+	for _, uri := range uris {
+		items = append(items, spotifyclient.SpotifyPlaylistTrack{Track: &spotifyclient.SpotifyTrack{URI: uri}})
+	}
+	// Unavailable tracks come back without a track
+	items = append(items, spotifyclient.SpotifyPlaylistTrack{})
+	return &spotifyclient.SpotifyPlaylistTracksResponse{Items: items, Total: len(items)}
+}
+
+func TestPlaylistWebhookService_CreateWebhook(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupPlaylistWebhookService(t)
+	ctx := context.Background()
+
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp1", "user123").Return(testfixtures.NewBasePlaylist().WithID("bp1").Build(), nil)
+	mocks.webhookRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, webhook *models.PlaylistWebhook) (*models.PlaylistWebhook, error) {
+			assert.Equal("user123", webhook.UserID)
+			assert.Equal("bp1", webhook.BasePlaylistID)
+			assert.Equal("https://example.com/hook", webhook.URL)
+			assert.Len(webhook.Secret, 64)
+			assert.True(webhook.IsActive)
+			webhook.ID = "wh1"
+			return webhook, nil
+		},
+	)
+
+	created, err := service.CreateWebhook(ctx, "user123", "bp1", "https://example.com/hook")
+	assert.NoError(err)
+	assert.Equal("wh1", created.ID)
+	assert.Len(created.Secret, 64)
+}
+
+func TestPlaylistWebhookService_CreateWebhook_BasePlaylistNotOwned(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupPlaylistWebhookService(t)
+	ctx := context.Background()
+
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp1", "user123").Return(nil, repositories.ErrUnauthorized)
+
+	created, err := service.CreateWebhook(ctx, "user123", "bp1", "https://example.com/hook")
+	assert.ErrorIs(err, repositories.ErrUnauthorized)
+	assert.Nil(created)
+}
+
+func TestPlaylistWebhookService_CreateWebhook_RefusesInternalURLs(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		resolvesTo string
+	}{
+		{name: "plain http", url: "http://example.com/hook", resolvesTo: "93.184.216.34"},
+		{name: "loopback", url: "https://127.0.0.1:8090/hook"},
+		{name: "cloud metadata", url: "https://169.254.169.254/latest/meta-data"},
+		{name: "host resolving to a private address", url: "https://intranet.example.com/hook", resolvesTo: "10.0.0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, _ := setupPlaylistWebhookService(t)
+			service.resolver = &fakeResolver{ip: tt.resolvesTo}
+
+			created, err := service.CreateWebhook(context.Background(), "user123", "bp1", tt.url)
+			assert.ErrorIs(err, ErrInvalidWebhookURL)
+			assert.Nil(created)
+		})
+	}
+}
+
+func TestPlaylistWebhookService_PollBasePlaylistChanges_DeliversDelta(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupPlaylistWebhookService(t)
+	ctx := context.Background()
+
+	var received models.BasePlaylistChange
+	var signature, event string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(WEBHOOK_SIGNATURE_HEADER)
+		event = r.Header.Get(WEBHOOK_EVENT_HEADER)
+		assert.Equal(signWebhookPayload("secret", body), signature)
+		assert.NoError(json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhook := &models.PlaylistWebhook{ID: "wh1", UserID: "user123", BasePlaylistID: "bp1", URL: server.URL, Secret: "secret", IsActive: true}
+	mocks.webhookRepo.EXPECT().GetActive(ctx).Return([]*models.PlaylistWebhook{webhook}, nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil)
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp1", "user123").Return(testfixtures.NewBasePlaylist().WithID("bp1").WithName("Liked").WithSpotifyPlaylistID("spotify1").WithSpotifyIntegrationID("integration2").Build(), nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(gomock.Any(), "spotify1").Return(&spotifyclient.SpotifyPlaylist{SnapshotID: "snap2"}, nil)
+	mocks.watchRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return(&models.BasePlaylistWatch{
+		SnapshotID: "snap1",
+		TrackURIs:  []string{"spotify:track:1", "spotify:track:2"},
+	}, nil)
+	mocks.spotifyClient.EXPECT().GetPlaylistTracks(gomock.Any(), "spotify1", MAX_TRACKS, 0).Return(playlistTracksPage("spotify:track:2", "spotify:track:3"), nil)
+	mocks.watchRepo.EXPECT().Upsert(ctx, &models.BasePlaylistWatch{
+		UserID:         "user123",
+		BasePlaylistID: "bp1",
+		SnapshotID:     "snap2",
+		TrackURIs:      []string{"spotify:track:2", "spotify:track:3"},
+	}).Return(&models.BasePlaylistWatch{}, nil)
+	mocks.webhookRepo.EXPECT().RecordDelivery(ctx, "wh1", http.StatusNoContent, gomock.Any()).Return(nil)
+
+	report, err := service.PollBasePlaylistChanges(ctx)
+	assert.NoError(err)
+	assert.Equal(&models.PlaylistChangePollReport{BasePlaylistsChecked: 1, BasePlaylistsChanged: 1, Deliveries: 1}, report)
+
+	assert.Equal(models.PlaylistWebhookEventBaseChanged, event)
+	assert.Equal("bp1", received.BasePlaylistID)
+	assert.Equal("Liked", received.BasePlaylistName)
+	assert.Equal("snap1", received.PreviousSnapshotID)
+	assert.Equal("snap2", received.SnapshotID)
+	assert.Equal([]string{"spotify:track:3"}, received.AddedTrackURIs)
+	assert.Equal([]string{"spotify:track:1"}, received.RemovedTrackURIs)
+	assert.Equal(2, received.TrackCount)
+	// Read with the spotify account the base playlist lives in
+	assert.Equal([]string{"integration2"}, mocks.spotifyAuth.integrationIDs)
+}
+
+func TestPlaylistWebhookService_PollBasePlaylistChanges_FirstPollRecordsBaseline(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupPlaylistWebhookService(t)
+	ctx := context.Background()
+
+	webhook := &models.PlaylistWebhook{ID: "wh1", UserID: "user123", BasePlaylistID: "bp1", URL: "http://unused.invalid", IsActive: true}
+	mocks.webhookRepo.EXPECT().GetActive(ctx).Return([]*models.PlaylistWebhook{webhook}, nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil)
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp1", "user123").Return(testfixtures.NewBasePlaylist().WithID("bp1").WithSpotifyPlaylistID("spotify1").Build(), nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(gomock.Any(), "spotify1").Return(&spotifyclient.SpotifyPlaylist{SnapshotID: "snap1"}, nil)
+	mocks.watchRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return(nil, repositories.ErrBasePlaylistWatchNotFound)
+	mocks.spotifyClient.EXPECT().GetPlaylistTracks(gomock.Any(), "spotify1", MAX_TRACKS, 0).Return(playlistTracksPage("spotify:track:1"), nil)
+	mocks.watchRepo.EXPECT().Upsert(ctx, gomock.Any()).Return(&models.BasePlaylistWatch{}, nil)
+
+	report, err := service.PollBasePlaylistChanges(ctx)
+	assert.NoError(err)
+	assert.Equal(&models.PlaylistChangePollReport{BasePlaylistsChecked: 1}, report)
+}
+
+func TestPlaylistWebhookService_PollBasePlaylistChanges_UnchangedSnapshot(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupPlaylistWebhookService(t)
+	ctx := context.Background()
+
+	webhooks := []*models.PlaylistWebhook{
+		{ID: "wh1", UserID: "user123", BasePlaylistID: "bp1", IsActive: true},
+		{ID: "wh2", UserID: "user123", BasePlaylistID: "bp1", IsActive: true},
+	}
+	mocks.webhookRepo.EXPECT().GetActive(ctx).Return(webhooks, nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil)
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp1", "user123").Return(testfixtures.NewBasePlaylist().WithID("bp1").WithSpotifyPlaylistID("spotify1").Build(), nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(gomock.Any(), "spotify1").Return(&spotifyclient.SpotifyPlaylist{SnapshotID: "snap1"}, nil)
+	mocks.watchRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return(&models.BasePlaylistWatch{SnapshotID: "snap1"}, nil)
+
+	report, err := service.PollBasePlaylistChanges(ctx)
+	assert.NoError(err)
+	assert.Equal(&models.PlaylistChangePollReport{BasePlaylistsChecked: 1}, report)
+}
+
+func TestPlaylistWebhookService_PollBasePlaylistChanges_FailedDelivery(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupPlaylistWebhookService(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhooks := []*models.PlaylistWebhook{
+		{ID: "wh1", UserID: "user123", BasePlaylistID: "bp1", URL: server.URL, IsActive: true},
+		{ID: "wh2", UserID: "user456", BasePlaylistID: "bp2", IsActive: true},
+	}
+	mocks.webhookRepo.EXPECT().GetActive(ctx).Return(webhooks, nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil)
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp1", "user123").Return(testfixtures.NewBasePlaylist().WithID("bp1").WithSpotifyPlaylistID("spotify1").Build(), nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(gomock.Any(), "spotify1").Return(&spotifyclient.SpotifyPlaylist{SnapshotID: "snap2"}, nil)
+	mocks.watchRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return(&models.BasePlaylistWatch{SnapshotID: "snap1"}, nil)
+	mocks.spotifyClient.EXPECT().GetPlaylistTracks(gomock.Any(), "spotify1", MAX_TRACKS, 0).Return(playlistTracksPage("spotify:track:1"), nil)
+	mocks.watchRepo.EXPECT().Upsert(ctx, gomock.Any()).Return(&models.BasePlaylistWatch{}, nil)
+	mocks.webhookRepo.EXPECT().RecordDelivery(ctx, "wh1", http.StatusInternalServerError, gomock.Any()).Return(nil)
+	// A base playlist that can't be checked doesn't stop the others
+	mocks.userRepo.EXPECT().GetByID(ctx, "user456").Return(testfixtures.NewUser().WithID("user456").Build(), nil)
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp2", "user456").Return(nil, errors.New("db error"))
+
+	report, err := service.PollBasePlaylistChanges(ctx)
+	assert.NoError(err)
+	assert.Equal(&models.PlaylistChangePollReport{
+		BasePlaylistsChecked: 2,
+		BasePlaylistsChanged: 1,
+		Deliveries:           1,
+		FailedDeliveries:     1,
+		Failed:               1,
+	}, report)
+}
+
+func TestPlaylistWebhookService_PollBasePlaylistChanges_SkipsDisabledUsers(t *testing.T) {
+	assert := require.New(t)
+	service, mocks := setupPlaylistWebhookService(t)
+	ctx := context.Background()
+
+	webhooks := []*models.PlaylistWebhook{
+		{ID: "wh1", UserID: "user123", BasePlaylistID: "bp1", IsActive: true},
+		{ID: "wh2", UserID: "user123", BasePlaylistID: "bp2", IsActive: true},
+		{ID: "wh3", UserID: "user456", BasePlaylistID: "bp3", IsActive: true},
+	}
+	mocks.webhookRepo.EXPECT().GetActive(ctx).Return(webhooks, nil)
+	// The owner is looked up once per poll
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").WithDisabled(true).Build(), nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user456").Return(testfixtures.NewUser().WithID("user456").Build(), nil)
+	mocks.basePlaylistRepo.EXPECT().GetByID(ctx, "bp3", "user456").Return(testfixtures.NewBasePlaylist().WithID("bp3").WithSpotifyPlaylistID("spotify3").Build(), nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(gomock.Any(), "spotify3").Return(&spotifyclient.SpotifyPlaylist{SnapshotID: "snap1"}, nil)
+	mocks.watchRepo.EXPECT().GetByBasePlaylistID(ctx, "bp3").Return(&models.BasePlaylistWatch{SnapshotID: "snap1"}, nil)
+
+	report, err := service.PollBasePlaylistChanges(ctx)
+	assert.NoError(err)
+	assert.Equal(&models.PlaylistChangePollReport{BasePlaylistsChecked: 1}, report)
+	assert.Equal(1, mocks.spotifyAuth.calls)
+}
+
+func TestDiffTrackURIs(t *testing.T) {
+	assert := require.New(t)
+
+	added, removed := diffTrackURIs(
+		[]string{"a", "b", "c"},
+		[]string{"c", "d", "d", "a"},
+	)
+	assert.Equal([]string{"d"}, added)
+	assert.Equal([]string{"b"}, removed)
+
+	added, removed = diffTrackURIs(nil, nil)
+	assert.Empty(added)
+	assert.Empty(removed)
+}
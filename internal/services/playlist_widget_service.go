@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=playlist_widget_service.go -destination=mocks/mock_playlist_widget_service.go -package=mocks
+
+// WIDGET_CACHE_TTL bounds how often a shared widget hits the database and Spotify,
+// since embedding sites can generate a lot of anonymous traffic
+const WIDGET_CACHE_TTL = 5 * time.Minute
+
+// providerPlaylistURLs are the links to a playlist in the app of each provider, by playlist ID
+var providerPlaylistURLs = map[models.MusicProvider]string{
+	models.MusicProviderSpotify:      "https://open.spotify.com/playlist/",
+	models.MusicProviderYouTubeMusic: "https://music.youtube.com/playlist?list=",
+	models.MusicProviderTidal:        "https://tidal.com/browse/playlist/",
+}
+
+// SpotifyAuthProvider builds a context carrying the spotify credentials of a user, so public
+// requests can read the owner's playlist without an authenticated session
+type SpotifyAuthProvider interface {
+	ContextWithSpotifyAuth(ctx context.Context, userID string) (context.Context, error)
+	ContextWithSpotifyAccount(ctx context.Context, userID, integrationID string) (context.Context, error)
+}
+
+type PlaylistWidgetServicer interface {
+	GetWidget(ctx context.Context, shareToken string) (*models.PlaylistWidget, error)
+}
+
+type cachedWidget struct {
+	widget    *models.PlaylistWidget
+	expiresAt time.Time
+}
+
+type PlaylistWidgetService struct {
+	childPlaylistRepo repositories.ChildPlaylistRepository
+	userRepo          repositories.UserRepository
+	syncEventService  SyncEventServicer
+	musicProvider     musicprovider.MusicProvider
+	spotifyAuth       SpotifyAuthProvider
+	logger            *slog.Logger
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedWidget
+	now     func() time.Time
+}
+
+func NewPlaylistWidgetService(
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	userRepo repositories.UserRepository,
+	syncEventService SyncEventServicer,
+	musicProvider musicprovider.MusicProvider,
+	spotifyAuth SpotifyAuthProvider,
+	logger *slog.Logger,
+) *PlaylistWidgetService {
+	return &PlaylistWidgetService{
+		childPlaylistRepo: childPlaylistRepo,
+		userRepo:          userRepo,
+		syncEventService:  syncEventService,
+		musicProvider:     musicProvider,
+		spotifyAuth:       spotifyAuth,
+		logger:            logger.With("component", "PlaylistWidgetService"),
+		cache:             make(map[string]cachedWidget),
+		now:               time.Now,
+	}
+}
+
+// GetWidget resolves a share token into the public widget data of the child playlist.
+// Spotify details are best-effort: when they can't be fetched the widget falls back to
+// the data of the last sync instead of failing. Widgets of disabled users are not found, although
+// a cached widget is served until it expires
+func (pwService *PlaylistWidgetService) GetWidget(ctx context.Context, shareToken string) (*models.PlaylistWidget, error) {
+	if widget, ok := pwService.getCached(shareToken); ok {
+		return widget, nil
+	}
+
+	childPlaylist, err := pwService.childPlaylistRepo.GetByShareToken(ctx, shareToken)
+	if err != nil {
+		pwService.logger.WarnContext(ctx, "failed to resolve share token", "error", err.Error())
+		return nil, fmt.Errorf("failed to get shared child playlist: %w", err)
+	}
+
+	owner, err := pwService.userRepo.GetByID(ctx, childPlaylist.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared child playlist owner: %w", err)
+	}
+	if owner.Disabled {
+		pwService.logger.InfoContext(ctx, "refused widget of disabled user", "child_playlist_id", childPlaylist.ID)
+		return nil, fmt.Errorf("failed to get shared child playlist: %w", repositories.ErrChildPlaylistNotFound)
+	}
+
+	provider := childPlaylist.Provider.OrDefault()
+	widget := &models.PlaylistWidget{
+		Name:        childPlaylist.Name,
+		Description: childPlaylist.Description,
+		Provider:    provider,
+	}
+	if playlistURL, ok := providerPlaylistURLs[provider]; ok {
+		widget.PlaylistURL = playlistURL + childPlaylist.SpotifyPlaylistID
+	}
+
+	lastSync, err := pwService.syncEventService.GetLastCompletedSyncEvent(ctx, childPlaylist.UserID, childPlaylist.BasePlaylistID)
+	if err != nil {
+		pwService.logger.WarnContext(ctx, "failed to get last sync for widget", "child_playlist_id", childPlaylist.ID, "error", err.Error())
+	}
+	if lastSync != nil {
+		widget.LastSyncedAt = lastSync.CompletedAt
+		for _, result := range lastSync.ChildSyncResults {
+			if result.ChildPlaylistID == childPlaylist.ID {
+				widget.TrackCount = result.TracksAdded
+			}
+		}
+	}
+
+	pwService.addSpotifyDetails(ctx, childPlaylist, widget)
+
+	pwService.setCached(shareToken, widget)
+	return widget, nil
+}
+
+func (pwService *PlaylistWidgetService) addSpotifyDetails(ctx context.Context, childPlaylist *models.ChildPlaylist, widget *models.PlaylistWidget) {
+	spotifyCtx, err := pwService.spotifyAuth.ContextWithSpotifyAccount(ctx, childPlaylist.UserID, childPlaylist.SpotifyIntegrationID)
+	if err != nil {
+		pwService.logger.WarnContext(ctx, "failed to load owner spotify credentials for widget", "child_playlist_id", childPlaylist.ID, "error", err.Error())
+		return
+	}
+	spotifyCtx = requestcontext.ContextWithMusicProvider(spotifyCtx, childPlaylist.Provider)
+
+	playlist, err := pwService.musicProvider.GetPlaylist(spotifyCtx, childPlaylist.SpotifyPlaylistID)
+	if err != nil {
+		pwService.logger.WarnContext(ctx, "failed to get spotify playlist for widget", "child_playlist_id", childPlaylist.ID, "error", err.Error())
+		return
+	}
+
+	if len(playlist.Images) > 0 && playlist.Images[0] != nil {
+		widget.CoverImageURL = playlist.Images[0].URL
+	}
+	if playlist.Tracks != nil {
+		widget.TrackCount = playlist.Tracks.Total
+	}
+}
+
+func (pwService *PlaylistWidgetService) getCached(shareToken string) (*models.PlaylistWidget, bool) {
+	pwService.cacheMu.Lock()
+	defer pwService.cacheMu.Unlock()
+
+	entry, ok := pwService.cache[shareToken]
+	if !ok {
+		return nil, false
+	}
+
+	if pwService.now().After(entry.expiresAt) {
+		delete(pwService.cache, shareToken)
+		return nil, false
+	}
+
+	return entry.widget, true
+}
+
+func (pwService *PlaylistWidgetService) setCached(shareToken string, widget *models.PlaylistWidget) {
+	pwService.cacheMu.Lock()
+	defer pwService.cacheMu.Unlock()
+
+	pwService.cache[shareToken] = cachedWidget{widget: widget, expiresAt: pwService.now().Add(WIDGET_CACHE_TTL)}
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSpotifyAuthProvider hands back the incoming context, or err when set
+type fakeSpotifyAuthProvider struct {
+	err            error
+	calls          int
+	integrationIDs []string
+}
+
+func (f *fakeSpotifyAuthProvider) ContextWithSpotifyAuth(ctx context.Context, userID string) (context.Context, error) {
+	return f.ContextWithSpotifyAccount(ctx, userID, "")
+}
+
+func (f *fakeSpotifyAuthProvider) ContextWithSpotifyAccount(ctx context.Context, userID, integrationID string) (context.Context, error) {
+	f.calls++
+	f.integrationIDs = append(f.integrationIDs, integrationID)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return ctx, nil
+}
+
+type playlistWidgetServiceMocks struct {
+	childPlaylistRepo *repositoryMocks.MockChildPlaylistRepository
+	userRepo          *repositoryMocks.MockUserRepository
+	syncEventRepo     *repositoryMocks.MockSyncEventRepository
+	spotifyClient     *spotifyClientMocks.MockSpotifyAPI
+	spotifyAuth       *fakeSpotifyAuthProvider
+}
+
+func setupPlaylistWidgetService(t *testing.T) (*PlaylistWidgetService, playlistWidgetServiceMocks) {
+	ctrl := setupMockController(t)
+
+	mocks := playlistWidgetServiceMocks{
+		childPlaylistRepo: repositoryMocks.NewMockChildPlaylistRepository(ctrl),
+		userRepo:          repositoryMocks.NewMockUserRepository(ctrl),
+		syncEventRepo:     repositoryMocks.NewMockSyncEventRepository(ctrl),
+		spotifyClient:     spotifyClientMocks.NewMockSpotifyAPI(ctrl),
+		spotifyAuth:       &fakeSpotifyAuthProvider{},
+	}
+
+	syncEventService := NewSyncEventService(mocks.syncEventRepo, createTestLogger())
+	service := NewPlaylistWidgetService(mocks.childPlaylistRepo, mocks.userRepo, syncEventService, mocks.spotifyClient, mocks.spotifyAuth, createTestLogger())
+	return service, mocks
+}
+
+func sharedChildPlaylist() *models.ChildPlaylist {
+	return testfixtures.NewChildPlaylist().
+		WithID("cp1").
+		WithUserID("user123").
+		WithBasePlaylistID("bp1").
+		WithName("Workout").
+		WithDescription("High energy").
+		WithSpotifyPlaylistID("spotify1").
+		WithShareToken("token123").
+		WithSpotifyIntegrationID("integration2").
+		Build()
+}
+
+func TestPlaylistWidgetService_GetWidget_Success(t *testing.T) {
+	assert := assert.New(t)
+	service, mocks := setupPlaylistWidgetService(t)
+	ctx := context.Background()
+
+	completedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mocks.childPlaylistRepo.EXPECT().GetByShareToken(ctx, "token123").Return(sharedChildPlaylist(), nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil)
+	mocks.syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return([]*models.SyncEvent{testfixtures.NewSyncEvent().
+		WithUserID("user123").
+		WithStatus(models.SyncStatusCompleted).
+		WithCompletedAt(completedAt).
+		WithChildSyncResults(models.ChildSyncResult{ChildPlaylistID: "cp1", TracksAdded: 10}).
+		Build()}, nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(ctx, "spotify1").Return(&spotifyclient.SpotifyPlaylist{
+		ID:     "spotify1",
+		Images: []*spotifyclient.SpotifyPlaylistImage{{URL: "https://i.scdn.co/cover.jpg"}},
+		Tracks: &spotifyclient.SpotifyPlaylistTracks{Total: 12},
+	}, nil)
+
+	widget, err := service.GetWidget(ctx, "token123")
+
+	assert.NoError(err)
+	assert.Equal("Workout", widget.Name)
+	assert.Equal("High energy", widget.Description)
+	assert.Equal("https://i.scdn.co/cover.jpg", widget.CoverImageURL)
+	assert.Equal(12, widget.TrackCount)
+	assert.Equal(&completedAt, widget.LastSyncedAt)
+	assert.Equal(models.MusicProviderSpotify, widget.Provider)
+	assert.Equal("https://open.spotify.com/playlist/spotify1", widget.PlaylistURL)
+	// Read with the spotify account the child playlist lives in
+	assert.Equal([]string{"integration2"}, mocks.spotifyAuth.integrationIDs)
+}
+
+func TestPlaylistWidgetService_GetWidget_SpotifyFailureFallsBackToLastSync(t *testing.T) {
+	assert := assert.New(t)
+	service, mocks := setupPlaylistWidgetService(t)
+	ctx := context.Background()
+
+	mocks.childPlaylistRepo.EXPECT().GetByShareToken(ctx, "token123").Return(sharedChildPlaylist(), nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil)
+	mocks.syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return([]*models.SyncEvent{testfixtures.NewSyncEvent().WithUserID("user123").WithStatus(models.SyncStatusCompleted).WithChildSyncResults(models.ChildSyncResult{ChildPlaylistID: "other", TracksAdded: 3}, models.ChildSyncResult{ChildPlaylistID: "cp1", TracksAdded: 10}).Build()}, nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(ctx, "spotify1").Return(nil, errors.New("spotify down"))
+
+	widget, err := service.GetWidget(ctx, "token123")
+
+	assert.NoError(err)
+	assert.Equal(10, widget.TrackCount)
+	assert.Empty(widget.CoverImageURL)
+}
+
+func TestPlaylistWidgetService_GetWidget_ProviderLink(t *testing.T) {
+	assert := assert.New(t)
+	service, mocks := setupPlaylistWidgetService(t)
+	ctx := context.Background()
+
+	childPlaylist := testfixtures.NewChildPlaylist().
+		WithUserID("user123").
+		WithBasePlaylistID("bp1").
+		WithSpotifyPlaylistID("tidal-uuid-1").
+		WithProvider(models.MusicProviderTidal).
+		WithShareToken("token123").
+		Build()
+	mocks.childPlaylistRepo.EXPECT().GetByShareToken(ctx, "token123").Return(childPlaylist, nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil)
+	mocks.syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return(nil, nil)
+	mocks.spotifyClient.EXPECT().GetPlaylist(gomock.Any(), "tidal-uuid-1").Return(nil, errors.New("tidal down"))
+
+	widget, err := service.GetWidget(ctx, "token123")
+
+	assert.NoError(err)
+	assert.Equal(models.MusicProviderTidal, widget.Provider)
+	assert.Equal("https://tidal.com/browse/playlist/tidal-uuid-1", widget.PlaylistURL)
+}
+
+func TestPlaylistWidgetService_GetWidget_NotShared(t *testing.T) {
+	assert := assert.New(t)
+	service, mocks := setupPlaylistWidgetService(t)
+	ctx := context.Background()
+
+	mocks.childPlaylistRepo.EXPECT().GetByShareToken(ctx, "unknown").Return(nil, repositories.ErrChildPlaylistNotFound)
+
+	widget, err := service.GetWidget(ctx, "unknown")
+
+	assert.Nil(widget)
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+}
+
+func TestPlaylistWidgetService_GetWidget_DisabledOwner(t *testing.T) {
+	assert := assert.New(t)
+	service, mocks := setupPlaylistWidgetService(t)
+	ctx := context.Background()
+
+	mocks.childPlaylistRepo.EXPECT().GetByShareToken(ctx, "token123").Return(sharedChildPlaylist(), nil)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").WithDisabled(true).Build(), nil)
+
+	widget, err := service.GetWidget(ctx, "token123")
+
+	assert.Nil(widget)
+	assert.ErrorIs(err, repositories.ErrChildPlaylistNotFound)
+	assert.Zero(mocks.spotifyAuth.calls)
+}
+
+func TestPlaylistWidgetService_GetWidget_Cache(t *testing.T) {
+	assert := assert.New(t)
+	service, mocks := setupPlaylistWidgetService(t)
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	// Each dependency is hit once per cache fill
+	mocks.childPlaylistRepo.EXPECT().GetByShareToken(ctx, "token123").Return(sharedChildPlaylist(), nil).Times(2)
+	mocks.userRepo.EXPECT().GetByID(ctx, "user123").Return(testfixtures.NewUser().WithID("user123").Build(), nil).Times(2)
+	mocks.syncEventRepo.EXPECT().GetByBasePlaylistID(ctx, "bp1").Return(nil, nil).Times(2)
+	mocks.spotifyAuth.err = errors.New("no integration")
+
+	first, err := service.GetWidget(ctx, "token123")
+	assert.NoError(err)
+
+	cached, err := service.GetWidget(ctx, "token123")
+	assert.NoError(err)
+	assert.Same(first, cached)
+
+	now = now.Add(WIDGET_CACHE_TTL + time.Second)
+	refreshed, err := service.GetWidget(ctx, "token123")
+	assert.NoError(err)
+	assert.NotSame(first, refreshed)
+	assert.Equal(2, mocks.spotifyAuth.calls)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// providerIntegrationRepository is the part of the integration repository of a music provider the
+// token manager needs. Providers link a single account per user
+type providerIntegrationRepository[I any] interface {
+	GetByUserID(ctx context.Context, userID string) (*I, error)
+	UpdateTokens(ctx context.Context, integrationID string, tokens *models.OAuthTokenRefresh) error
+}
+
+// oauthTokens points to the ID and token fields of an integration, so they can be read and
+// refreshed whatever the integration type of the provider
+type oauthTokens struct {
+	id           string
+	accessToken  *string
+	refreshToken *string
+	expiresAt    *time.Time
+}
+
+// providerTokenManager hands out the integrations of a music provider with tokens valid for at least
+// the refresh window, refreshing them ahead of expiry through the auth of the provider. The token
+// managers of the providers only tell it how to reach their integrations
+type providerTokenManager[I any] struct {
+	provider        string // Name of the provider in logs
+	integrationRepo providerIntegrationRepository[I]
+	auth            musicprovider.Auth
+	tokens          func(integration *I) oauthTokens
+	fromContext     func(ctx context.Context) (*I, bool)
+	errUnavailable  error // Wraps the errors of integrations that can't be read
+	errRefresh      error // Wraps the errors of refreshes
+	refreshWindow   time.Duration
+	refreshes       singleflight.Group
+	logger          *slog.Logger
+}
+
+// Credentials returns the integration carried by the context, or the one of the user the context
+// acts as. Requests and syncs carry the user, or at least its spotify account, so playlists on
+// other providers don't need their credentials loaded up front
+func (tm *providerTokenManager[I]) Credentials(ctx context.Context) (*I, error) {
+	if integration, ok := tm.fromContext(ctx); ok {
+		return integration, nil
+	}
+
+	var userID string
+	if user, ok := requestcontext.GetUserFromContext(ctx); ok {
+		userID = user.ID
+	} else if spotifyAuth, ok := requestcontext.GetSpotifyAuthFromContext(ctx); ok {
+		userID = spotifyAuth.UserID
+	}
+	if userID == "" {
+		return nil, tm.errUnavailable
+	}
+
+	return tm.refreshIfExpiring(ctx, userID)
+}
+
+// refreshIfExpiring reads the integration of the user and refreshes its tokens when they expire
+// within the refresh window. Concurrent calls for the same user share a single read and refresh
+func (tm *providerTokenManager[I]) refreshIfExpiring(ctx context.Context, userID string) (*I, error) {
+	// The refresh is shared by every waiting caller, it must not be cancelled along with the first
+	sharedCtx := context.WithoutCancel(ctx)
+
+	value, err, _ := tm.refreshes.Do(userID, func() (any, error) {
+		integration, err := tm.integrationRepo.GetByUserID(sharedCtx, userID)
+		if err != nil {
+			tm.logger.ErrorContext(sharedCtx, "failed to get "+tm.provider+" integration", "user_id", userID, "error", err)
+			return nil, fmt.Errorf("%w: %w", tm.errUnavailable, err)
+		}
+
+		expiresAt := *tm.tokens(integration).expiresAt
+		if !expiresAt.Before(time.Now().Add(tm.refreshWindow)) {
+			return integration, nil
+		}
+
+		tm.logger.InfoContext(sharedCtx, "refreshing "+tm.provider+" tokens", "user_id", userID, "expires_at", expiresAt)
+
+		refreshed, err := tm.refreshTokens(sharedCtx, integration)
+		if err != nil {
+			tm.logger.ErrorContext(sharedCtx, "failed to refresh "+tm.provider+" tokens", "user_id", userID, "error", err)
+			return nil, fmt.Errorf("%w: %w", tm.errRefresh, err)
+		}
+
+		return refreshed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every caller gets its own copy of the shared integration
+	integration := *value.(*I)
+	return &integration, nil
+}
+
+// refreshTokens exchanges the refresh token and persists the new tokens. The refresh token is kept
+// unless the provider sends a new one
+func (tm *providerTokenManager[I]) refreshTokens(ctx context.Context, integration *I) (*I, error) {
+	current := tm.tokens(integration)
+
+	tokenResponse, err := tm.auth.RefreshTokens(ctx, *current.refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenUpdate := &models.OAuthTokenRefresh{
+		AccessToken:  tokenResponse.AccessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+		ExpiresIn:    tokenResponse.ExpiresIn,
+	}
+
+	if err := tm.integrationRepo.UpdateTokens(ctx, current.id, tokenUpdate); err != nil {
+		return nil, err
+	}
+
+	updatedIntegration := *integration
+	updated := tm.tokens(&updatedIntegration)
+	*updated.accessToken = tokenUpdate.AccessToken
+	if tokenUpdate.RefreshToken != "" {
+		*updated.refreshToken = tokenUpdate.RefreshToken
+	}
+	*updated.expiresAt = time.Now().Add(time.Duration(tokenUpdate.ExpiresIn) * time.Second)
+
+	return &updatedIntegration, nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	musicProviderMocks "github.com/ngomez18/playlist-router/internal/clients/musicprovider/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errTestIntegrationUnavailable = errors.New("test integration unavailable")
+	errTestTokenRefresh           = errors.New("test token refresh failed")
+)
+
+// testIntegration stands for the integration of any music provider
+type testIntegration struct {
+	ID           string
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// fakeProviderIntegrationRepo holds the integration of user123
+type fakeProviderIntegrationRepo struct {
+	integration *testIntegration
+	getErr      error
+	updateErr   error
+	updates     []*models.OAuthTokenRefresh
+}
+
+func (f *fakeProviderIntegrationRepo) GetByUserID(ctx context.Context, userID string) (*testIntegration, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	if userID != "user123" {
+		return nil, errors.New("not found")
+	}
+	return f.integration, nil
+}
+
+func (f *fakeProviderIntegrationRepo) UpdateTokens(ctx context.Context, integrationID string, tokens *models.OAuthTokenRefresh) error {
+	f.updates = append(f.updates, tokens)
+	return f.updateErr
+}
+
+func setupProviderTokenManager(t *testing.T, expiresIn time.Duration) (*providerTokenManager[testIntegration], *fakeProviderIntegrationRepo, *musicProviderMocks.MockAuth) {
+	ctrl := setupMockController(t)
+	auth := musicProviderMocks.NewMockAuth(ctrl)
+	integrationRepo := &fakeProviderIntegrationRepo{integration: &testIntegration{
+		ID:           "integration123",
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		ExpiresAt:    time.Now().Add(expiresIn),
+	}}
+
+	tokenManager := &providerTokenManager[testIntegration]{
+		provider:        "test",
+		integrationRepo: integrationRepo,
+		auth:            auth,
+		tokens: func(integration *testIntegration) oauthTokens {
+			return oauthTokens{
+				id:           integration.ID,
+				accessToken:  &integration.AccessToken,
+				refreshToken: &integration.RefreshToken,
+				expiresAt:    &integration.ExpiresAt,
+			}
+		},
+		fromContext:    func(ctx context.Context) (*testIntegration, bool) { return nil, false },
+		errUnavailable: errTestIntegrationUnavailable,
+		errRefresh:     errTestTokenRefresh,
+		refreshWindow:  15 * time.Minute,
+		logger:         createTestLogger(),
+	}
+
+	return tokenManager, integrationRepo, auth
+}
+
+func TestProviderTokenManager_Credentials_LoadsUserIntegration(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{
+			name: "user of the request",
+			ctx:  requestcontext.ContextWithUser(context.Background(), &models.User{ID: "user123"}),
+		},
+		{
+			name: "user of the spotify account of a sync",
+			ctx:  requestcontext.ContextWithSpotifyAuth(context.Background(), &models.SpotifyIntegration{UserID: "user123"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			tokenManager, integrationRepo, _ := setupProviderTokenManager(t, time.Hour)
+
+			credentials, err := tokenManager.Credentials(tt.ctx)
+
+			assert.NoError(err)
+			assert.Equal("access_token_123", credentials.AccessToken)
+			// Callers get their own copy
+			assert.NotSame(integrationRepo.integration, credentials)
+			assert.Empty(integrationRepo.updates)
+		})
+	}
+}
+
+func TestProviderTokenManager_Credentials_NoUser(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, _, _ := setupProviderTokenManager(t, time.Hour)
+
+	credentials, err := tokenManager.Credentials(context.Background())
+
+	assert.Nil(credentials)
+	assert.ErrorIs(err, errTestIntegrationUnavailable)
+}
+
+func TestProviderTokenManager_RefreshIfExpiring(t *testing.T) {
+	tests := []struct {
+		name                 string
+		tokenResponse        *musicprovider.TokenResponse
+		expectedRefreshToken string
+	}{
+		{
+			name:                 "refresh token kept",
+			tokenResponse:        &musicprovider.TokenResponse{AccessToken: "new_access", ExpiresIn: 3600},
+			expectedRefreshToken: "refresh_token_123",
+		},
+		{
+			name:                 "refresh token rotated",
+			tokenResponse:        &musicprovider.TokenResponse{AccessToken: "new_access", RefreshToken: "new_refresh", ExpiresIn: 3600},
+			expectedRefreshToken: "new_refresh",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			tokenManager, integrationRepo, auth := setupProviderTokenManager(t, 5*time.Minute)
+
+			auth.EXPECT().RefreshTokens(gomock.Any(), "refresh_token_123").Return(tt.tokenResponse, nil)
+
+			integration, err := tokenManager.refreshIfExpiring(context.Background(), "user123")
+
+			assert.NoError(err)
+			assert.Equal("new_access", integration.AccessToken)
+			assert.Equal(tt.expectedRefreshToken, integration.RefreshToken)
+			assert.True(integration.ExpiresAt.After(time.Now().Add(50 * time.Minute)))
+			assert.Equal([]*models.OAuthTokenRefresh{{
+				AccessToken:  "new_access",
+				RefreshToken: tt.tokenResponse.RefreshToken,
+				ExpiresIn:    3600,
+			}}, integrationRepo.updates)
+			// The stored integration is left as it was read
+			assert.Equal("access_token_123", integrationRepo.integration.AccessToken)
+		})
+	}
+}
+
+func TestProviderTokenManager_RefreshIfExpiring_Errors(t *testing.T) {
+	t.Run("no integration", func(t *testing.T) {
+		assert := require.New(t)
+		tokenManager, integrationRepo, _ := setupProviderTokenManager(t, time.Hour)
+		integrationRepo.getErr = errors.New("not found")
+
+		_, err := tokenManager.refreshIfExpiring(context.Background(), "user123")
+		assert.ErrorIs(err, errTestIntegrationUnavailable)
+	})
+
+	t.Run("refresh fails", func(t *testing.T) {
+		assert := require.New(t)
+		tokenManager, _, auth := setupProviderTokenManager(t, -time.Minute)
+		auth.EXPECT().RefreshTokens(gomock.Any(), "refresh_token_123").Return(nil, errors.New("invalid_grant"))
+
+		_, err := tokenManager.refreshIfExpiring(context.Background(), "user123")
+		assert.ErrorIs(err, errTestTokenRefresh)
+	})
+
+	t.Run("storing the tokens fails", func(t *testing.T) {
+		assert := require.New(t)
+		tokenManager, integrationRepo, auth := setupProviderTokenManager(t, -time.Minute)
+		integrationRepo.updateErr = errors.New("db error")
+		auth.EXPECT().RefreshTokens(gomock.Any(), "refresh_token_123").Return(&musicprovider.TokenResponse{AccessToken: "new_access", ExpiresIn: 3600}, nil)
+
+		_, err := tokenManager.refreshIfExpiring(context.Background(), "user123")
+		assert.ErrorIs(err, errTestTokenRefresh)
+	})
+}
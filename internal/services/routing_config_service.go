@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=routing_config_service.go -destination=mocks/mock_routing_config_service.go -package=mocks
+
+// RoutingConfigServicer exports the routing setup of a base playlist and imports it back, for
+// backups and to share filter setups between users
+type RoutingConfigServicer interface {
+	ExportRoutingConfig(ctx context.Context, userID, id string) (*models.RoutingConfig, error)
+	ImportRoutingConfig(ctx context.Context, userID string, config *models.RoutingConfig) (*models.BasePlaylistWithChilds, error)
+}
+
+type RoutingConfigService struct {
+	basePlaylistService  BasePlaylistServicer
+	childPlaylistService ChildPlaylistServicer
+	logger               *slog.Logger
+}
+
+func NewRoutingConfigService(
+	basePlaylistService BasePlaylistServicer,
+	childPlaylistService ChildPlaylistServicer,
+	logger *slog.Logger,
+) *RoutingConfigService {
+	return &RoutingConfigService{
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		logger:               logger.With("component", "RoutingConfigService"),
+	}
+}
+
+func (rcService *RoutingConfigService) ExportRoutingConfig(ctx context.Context, userID, id string) (*models.RoutingConfig, error) {
+	basePlaylist, err := rcService.basePlaylistService.GetBasePlaylist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	childPlaylists, err := rcService.sortedChildPlaylists(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make([]models.TemplateChild, 0, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		children = append(children, childPlaylist.ToTemplateChild())
+	}
+
+	return &models.RoutingConfig{
+		Version:           models.ROUTING_CONFIG_VERSION,
+		Name:              basePlaylist.Name,
+		SpotifyPlaylistID: basePlaylist.SpotifyPlaylistID,
+		DedupeStrategy:    basePlaylist.DedupeStrategy,
+		Children:          children,
+	}, nil
+}
+
+// ImportRoutingConfig applies the config to the base playlist of the user reading its spotify
+// playlist, creating the base playlist when there is none. Child playlists are matched by name:
+// the ones in the config are updated or created, with a new spotify playlist, and reordered to the
+// config order. Child playlists missing from the config are kept, after the others. Importing the
+// same config again changes nothing, so an import that failed halfway can simply be retried
+func (rcService *RoutingConfigService) ImportRoutingConfig(ctx context.Context, userID string, config *models.RoutingConfig) (*models.BasePlaylistWithChilds, error) {
+	if err := validateRoutingConfig(config); err != nil {
+		return nil, err
+	}
+
+	basePlaylist, err := rcService.importBasePlaylist(ctx, userID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	childPlaylists, err := rcService.sortedChildPlaylists(ctx, userID, basePlaylist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	childPlaylistsByName := make(map[string]*models.ChildPlaylist, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		childPlaylistsByName[childPlaylist.Name] = childPlaylist
+	}
+
+	order := make([]string, 0, len(childPlaylists)+len(config.Children))
+	imported := make(map[string]bool, len(config.Children))
+	for _, child := range config.Children {
+		childPlaylist, err := rcService.importChildPlaylist(ctx, userID, basePlaylist.ID, child, childPlaylistsByName[child.Name])
+		if err != nil {
+			return nil, err
+		}
+
+		order = append(order, childPlaylist.ID)
+		imported[childPlaylist.ID] = true
+	}
+
+	currentOrder := make([]string, 0, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		currentOrder = append(currentOrder, childPlaylist.ID)
+		if !imported[childPlaylist.ID] {
+			order = append(order, childPlaylist.ID)
+		}
+	}
+
+	if !slices.Equal(order, currentOrder) {
+		if _, err := rcService.childPlaylistService.ReorderChildPlaylists(ctx, basePlaylist.ID, userID, order); err != nil {
+			return nil, err
+		}
+	}
+
+	childPlaylists, err = rcService.sortedChildPlaylists(ctx, userID, basePlaylist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rcService.logger.InfoContext(ctx, "routing config imported", "base_playlist_id", basePlaylist.ID, "user_id", userID, "children", len(config.Children))
+	return &models.BasePlaylistWithChilds{
+		BasePlaylist: basePlaylist,
+		Childs:       childPlaylists,
+	}, nil
+}
+
+// importBasePlaylist returns the base playlist of the user reading the spotify playlist of the
+// config, renamed and with the dedupe strategy of the config, or creates it
+func (rcService *RoutingConfigService) importBasePlaylist(ctx context.Context, userID string, config *models.RoutingConfig) (*models.BasePlaylist, error) {
+	basePlaylists, err := rcService.basePlaylistService.GetBasePlaylistsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dedupeStrategy := config.DedupeStrategy
+	if dedupeStrategy == "" {
+		dedupeStrategy = models.DedupeStrategyAllMatches
+	}
+
+	for _, basePlaylist := range basePlaylists {
+		if basePlaylist.SpotifyPlaylistID != config.SpotifyPlaylistID {
+			continue
+		}
+
+		if basePlaylist.Name == config.Name && basePlaylist.DedupeStrategy == dedupeStrategy {
+			return basePlaylist, nil
+		}
+
+		rcService.logger.InfoContext(ctx, "updating base playlist from routing config", "base_playlist_id", basePlaylist.ID, "user_id", userID)
+		return rcService.basePlaylistService.UpdateBasePlaylist(ctx, basePlaylist.ID, userID, &models.UpdateBasePlaylistRequest{
+			Name:           &config.Name,
+			DedupeStrategy: &dedupeStrategy,
+		})
+	}
+
+	rcService.logger.InfoContext(ctx, "creating base playlist from routing config", "user_id", userID, "spotify_playlist_id", config.SpotifyPlaylistID)
+	return rcService.basePlaylistService.CreateBasePlaylist(ctx, userID, &models.CreateBasePlaylistRequest{
+		Name:              config.Name,
+		SpotifyPlaylistID: config.SpotifyPlaylistID,
+		DedupeStrategy:    dedupeStrategy,
+	})
+}
+
+// importChildPlaylist creates the child of the config when the base playlist has no child playlist
+// by that name, and otherwise updates the existing one if it differs from the config
+func (rcService *RoutingConfigService) importChildPlaylist(ctx context.Context, userID, basePlaylistID string, child models.TemplateChild, childPlaylist *models.ChildPlaylist) (*models.ChildPlaylist, error) {
+	if childPlaylist == nil {
+		created, err := rcService.childPlaylistService.CreateChildPlaylist(ctx, userID, basePlaylistID, child.ToCreateRequest())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create child playlist %q: %w", child.Name, err)
+		}
+		return created, nil
+	}
+
+	if sameTemplateChild(childPlaylist.ToTemplateChild(), child) {
+		return childPlaylist, nil
+	}
+
+	updated, err := rcService.childPlaylistService.UpdateChildPlaylist(ctx, childPlaylist.ID, userID, child.ToUpdateRequest())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update child playlist %q: %w", child.Name, err)
+	}
+	return updated, nil
+}
+
+func (rcService *RoutingConfigService) sortedChildPlaylists(ctx context.Context, userID, basePlaylistID string) ([]*models.ChildPlaylist, error) {
+	childPlaylists, err := rcService.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, basePlaylistID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	childPlaylists = slices.Clone(childPlaylists)
+	sortByPriority(childPlaylists)
+	return childPlaylists, nil
+}
+
+// validateRoutingConfig checks the filter rules of the children, resolving their durations, and
+// that their names, which child playlists are matched on, are unique. The selection strategy of the
+// children is set the way child playlists store it, so unchanged children compare equal
+func validateRoutingConfig(config *models.RoutingConfig) error {
+	if err := validateTemplateChildren(config.Children, ErrInvalidRoutingConfig); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(config.Children))
+	for i := range config.Children {
+		child := &config.Children[i]
+		if names[child.Name] {
+			return fmt.Errorf("%w: child %q is listed more than once", ErrInvalidRoutingConfig, child.Name)
+		}
+		names[child.Name] = true
+
+		switch {
+		case child.MaxTracks == 0:
+			child.SelectionStrategy = ""
+		case child.SelectionStrategy == "":
+			child.SelectionStrategy = models.SelectionMostPopular
+		}
+	}
+
+	return nil
+}
+
+// sameTemplateChild compares the json encoding of the children, so filter rules holding the same
+// conditions are equal however they were built
+func sameTemplateChild(a, b models.TemplateChild) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(encodedA) == string(encodedB)
+}
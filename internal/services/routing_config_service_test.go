@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func energyAbove(min float64) *models.MetadataFilters {
+	return &models.MetadataFilters{Energy: &models.RangeFilter{Min: &min}}
+}
+
+func energyBetween(min, max float64) *models.MetadataFilters {
+	return &models.MetadataFilters{Energy: &models.RangeFilter{Min: &min, Max: &max}}
+}
+
+func setupRoutingConfigService(basePlaylist *models.BasePlaylist, children ...*models.ChildPlaylist) (*RoutingConfigService, *fakeBasePlaylistService, *fakeChildPlaylistService) {
+	basePlaylistService := &fakeBasePlaylistService{basePlaylist: basePlaylist}
+	childPlaylistService := &fakeChildPlaylistService{children: children}
+
+	return NewRoutingConfigService(basePlaylistService, childPlaylistService, createTestLogger()), basePlaylistService, childPlaylistService
+}
+
+func TestRoutingConfigService_ExportRoutingConfig(t *testing.T) {
+	assert := require.New(t)
+	service, _, _ := setupRoutingConfigService(
+		testfixtures.NewBasePlaylist().WithID("base123").WithName("Liked Songs").WithDedupeStrategy(models.DedupeStrategyFirstMatch).Build(),
+		testfixtures.NewChildPlaylist().WithID("child2").WithName("Everything Else").WithPriority(2).WithFilters(&models.MetadataFilters{}).Fallback().Build(),
+		testfixtures.NewChildPlaylist().WithID("child1").WithName("Workout").WithPriority(1).WithFilters(energyAbove(0.7)).WithMaxTracks(50, models.SelectionNewest).Build(),
+	)
+
+	config, err := service.ExportRoutingConfig(context.Background(), "user123", "base123")
+
+	assert.NoError(err)
+	assert.Equal(models.ROUTING_CONFIG_VERSION, config.Version)
+	assert.Equal("Liked Songs", config.Name)
+	assert.Equal("spotify_base123", config.SpotifyPlaylistID)
+	assert.Equal(models.DedupeStrategyFirstMatch, config.DedupeStrategy)
+
+	// Children are exported in priority order, without filter rules matching every track
+	assert.Equal([]models.TemplateChild{
+		{Name: "Workout", FilterRules: energyAbove(0.7), MaxTracks: 50, SelectionStrategy: models.SelectionNewest},
+		{Name: "Everything Else", IsFallback: true},
+	}, config.Children)
+}
+
+func TestRoutingConfigService_ImportRoutingConfig_CreatesBasePlaylist(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, childPlaylistService := setupRoutingConfigService(nil)
+
+	result, err := service.ImportRoutingConfig(context.Background(), "user123", &models.RoutingConfig{
+		Version:           models.ROUTING_CONFIG_VERSION,
+		Name:              "Liked Songs",
+		SpotifyPlaylistID: "spotify456",
+		Children: []models.TemplateChild{
+			{Name: "Workout", FilterRules: energyAbove(0.7), MaxTracks: 50},
+			{Name: "Chill"},
+		},
+	})
+
+	assert.NoError(err)
+	assert.Equal("clone123", result.ID)
+	assert.Len(basePlaylistService.created, 1)
+	assert.Equal("spotify456", basePlaylistService.created[0].SpotifyPlaylistID)
+	assert.Equal(models.DedupeStrategyAllMatches, basePlaylistService.created[0].DedupeStrategy)
+
+	assert.Len(childPlaylistService.created, 2)
+	assert.Equal("Workout", childPlaylistService.created[0].Name)
+	assert.Equal(models.SelectionMostPopular, childPlaylistService.created[0].SelectionStrategy)
+	assert.Equal("Chill", childPlaylistService.created[1].Name)
+	assert.Equal([]string{"child1", "child2"}, childPlaylistService.reordered)
+}
+
+func TestRoutingConfigService_ImportRoutingConfig_Unchanged(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, childPlaylistService := setupRoutingConfigService(
+		testfixtures.NewBasePlaylist().WithID("base123").WithName("Liked Songs").WithSpotifyPlaylistID("spotify456").Build(),
+		testfixtures.NewChildPlaylist().WithID("child1").WithName("Workout").WithPriority(1).WithFilters(energyAbove(0.7)).WithMaxTracks(50, models.SelectionMostPopular).Build(),
+		testfixtures.NewChildPlaylist().WithID("child2").WithName("Chill").WithPriority(2).Build(),
+	)
+
+	result, err := service.ImportRoutingConfig(context.Background(), "user123", &models.RoutingConfig{
+		Version:           models.ROUTING_CONFIG_VERSION,
+		Name:              "Liked Songs",
+		SpotifyPlaylistID: "spotify456",
+		Children: []models.TemplateChild{
+			{Name: "Workout", FilterRules: energyAbove(0.7), MaxTracks: 50},
+			{Name: "Chill"},
+		},
+	})
+
+	assert.NoError(err)
+	assert.Equal("base123", result.ID)
+	assert.Len(result.Childs, 2)
+	assert.Empty(basePlaylistService.created)
+	assert.Empty(basePlaylistService.updated)
+	assert.Empty(childPlaylistService.created)
+	assert.Empty(childPlaylistService.updated)
+	assert.Nil(childPlaylistService.reordered)
+}
+
+func TestRoutingConfigService_ImportRoutingConfig_UpdatesExisting(t *testing.T) {
+	assert := require.New(t)
+	service, basePlaylistService, childPlaylistService := setupRoutingConfigService(
+		testfixtures.NewBasePlaylist().WithID("base123").WithName("Old Name").WithSpotifyPlaylistID("spotify456").Build(),
+		testfixtures.NewChildPlaylist().WithID("workout").WithName("Workout").WithPriority(1).WithFilters(energyAbove(0.5)).Build(),
+		testfixtures.NewChildPlaylist().WithID("kept").WithName("Kept").WithPriority(2).Build(),
+	)
+
+	_, err := service.ImportRoutingConfig(context.Background(), "user123", &models.RoutingConfig{
+		Version:           models.ROUTING_CONFIG_VERSION,
+		Name:              "Liked Songs",
+		SpotifyPlaylistID: "spotify456",
+		DedupeStrategy:    models.DedupeStrategyFirstMatch,
+		Children: []models.TemplateChild{
+			{Name: "Chill"},
+			{Name: "Workout", FilterRules: energyAbove(0.7)},
+		},
+	})
+
+	assert.NoError(err)
+	assert.Len(basePlaylistService.updated, 1)
+	assert.Equal("Liked Songs", *basePlaylistService.updated[0].Name)
+	assert.Equal(models.DedupeStrategyFirstMatch, *basePlaylistService.updated[0].DedupeStrategy)
+
+	assert.Len(childPlaylistService.created, 1)
+	assert.Equal("Chill", childPlaylistService.created[0].Name)
+	assert.Len(childPlaylistService.updated, 1)
+	assert.Equal(energyAbove(0.7), childPlaylistService.updated["workout"].FilterRules)
+
+	// Config order first, children missing from the config last
+	assert.Equal([]string{"child1", "workout", "kept"}, childPlaylistService.reordered)
+}
+
+func TestRoutingConfigService_ImportRoutingConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []models.TemplateChild
+	}{
+		{
+			name:     "duplicate child names",
+			children: []models.TemplateChild{{Name: "Workout"}, {Name: "Workout"}},
+		},
+		{
+			name:     "invalid filter rules",
+			children: []models.TemplateChild{{Name: "Workout", FilterRules: energyBetween(0.8, 0.2)}},
+		},
+		{
+			name:     "two fallbacks",
+			children: []models.TemplateChild{{Name: "A", IsFallback: true}, {Name: "B", IsFallback: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, basePlaylistService, childPlaylistService := setupRoutingConfigService(nil)
+
+			_, err := service.ImportRoutingConfig(context.Background(), "user123", &models.RoutingConfig{
+				Version:           models.ROUTING_CONFIG_VERSION,
+				Name:              "Liked Songs",
+				SpotifyPlaylistID: "spotify456",
+				Children:          tt.children,
+			})
+
+			assert.ErrorIs(err, ErrInvalidRoutingConfig)
+			assert.Empty(basePlaylistService.created)
+			assert.Empty(childPlaylistService.created)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=spotify_account_service.go -destination=mocks/mock_spotify_account_service.go -package=mocks
+
+type SpotifyAccountServicer interface {
+	Disconnect(ctx context.Context, userID string) error
+	FindDisconnectedUser(ctx context.Context, spotifyID string) (*models.User, error)
+	ListAccounts(ctx context.Context, userID string) ([]*models.SpotifyIntegration, error)
+	Relink(ctx context.Context, userID string) error
+	UnlinkAccount(ctx context.Context, userID, integrationID string) error
+}
+
+// SpotifyAccountService manages the spotify accounts linked by a user. It disconnects them and
+// re-links them when the same account logs in again, suspending and then reactivating the user's
+// playlists, and unlinks the additional accounts no playlist uses anymore
+type SpotifyAccountService struct {
+	userRepo                  repositories.UserRepository
+	spotifyIntegrationService SpotifyIntegrationServicer
+	basePlaylistRepo          repositories.BasePlaylistRepository
+	childPlaylistRepo         repositories.ChildPlaylistRepository
+	logger                    *slog.Logger
+}
+
+func NewSpotifyAccountService(
+	userRepo repositories.UserRepository,
+	spotifyIntegrationService SpotifyIntegrationServicer,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	logger *slog.Logger,
+) *SpotifyAccountService {
+	return &SpotifyAccountService{
+		userRepo:                  userRepo,
+		spotifyIntegrationService: spotifyIntegrationService,
+		basePlaylistRepo:          basePlaylistRepo,
+		childPlaylistRepo:         childPlaylistRepo,
+		logger:                    logger.With("component", "SpotifyAccountService"),
+	}
+}
+
+// Disconnect deactivates the user's playlists and deletes the stored spotify integration. Spotify has
+// no token revocation endpoint, so discarding the tokens is all the app can do, the user revokes
+// the app access itself from their spotify account settings
+func (s *SpotifyAccountService) Disconnect(ctx context.Context, userID string) error {
+	s.logger.InfoContext(ctx, "disconnecting spotify account", "user_id", userID)
+
+	integration, err := s.spotifyIntegrationService.GetIntegrationByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrSpotifyIntegrationNotFound) {
+		return ErrSpotifyIntegrationUnavailable
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve spotify integration: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	if err := s.setPlaylistsSuspended(ctx, userID, true); err != nil {
+		return err
+	}
+
+	// The marker is stored before the integration goes away, so a failed disconnect can be retried
+	// and the user is still found when the same account logs in again
+	user.DisconnectedSpotifyID = integration.SpotifyID
+	if _, err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := s.spotifyIntegrationService.DeleteIntegration(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete spotify integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "spotify account disconnected successfully", "user_id", userID, "spotify_id", integration.SpotifyID)
+	return nil
+}
+
+// FindDisconnectedUser returns the user that disconnected the given spotify account, or nil when
+// no user did
+func (s *SpotifyAccountService) FindDisconnectedUser(ctx context.Context, spotifyID string) (*models.User, error) {
+	user, err := s.userRepo.GetByDisconnectedSpotifyID(ctx, spotifyID)
+	if errors.Is(err, repositories.ErrUseNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve disconnected user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListAccounts returns the spotify accounts linked by the user, the default one first
+func (s *SpotifyAccountService) ListAccounts(ctx context.Context, userID string) ([]*models.SpotifyIntegration, error) {
+	integrations, err := s.spotifyIntegrationService.ListIntegrationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve spotify integrations: %w", err)
+	}
+
+	return integrations, nil
+}
+
+// UnlinkAccount removes one of the additional spotify accounts of the user. The default account is
+// removed through Disconnect, and accounts still used by playlists must be freed first, since
+// their playlists could not be synced anymore
+func (s *SpotifyAccountService) UnlinkAccount(ctx context.Context, userID, integrationID string) error {
+	s.logger.InfoContext(ctx, "unlinking spotify account", "user_id", userID, "integration_id", integrationID)
+
+	integration, err := s.spotifyIntegrationService.GetIntegrationByID(ctx, integrationID, userID)
+	if errors.Is(err, repositories.ErrSpotifyIntegrationNotFound) {
+		return ErrSpotifyIntegrationUnavailable
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve spotify integration: %w", err)
+	}
+
+	defaultIntegration, err := s.spotifyIntegrationService.GetIntegrationByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve default spotify integration: %w", err)
+	}
+	if defaultIntegration.ID == integration.ID {
+		return ErrDefaultSpotifyAccount
+	}
+
+	inUse, err := s.isAccountInUse(ctx, userID, integration.ID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrSpotifyAccountInUse
+	}
+
+	if err := s.spotifyIntegrationService.DeleteIntegrationByID(ctx, integration.ID, userID); err != nil {
+		return fmt.Errorf("failed to delete spotify integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "spotify account unlinked successfully", "user_id", userID, "spotify_id", integration.SpotifyID)
+	return nil
+}
+
+// isAccountInUse reports whether any base or child playlist of the user lives in the account
+func (s *SpotifyAccountService) isAccountInUse(ctx context.Context, userID, integrationID string) (bool, error) {
+	basePlaylists, err := s.basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve base playlists: %w", err)
+	}
+
+	for _, basePlaylist := range basePlaylists {
+		if basePlaylist.SpotifyIntegrationID == integrationID {
+			return true, nil
+		}
+
+		childPlaylists, err := s.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			return false, fmt.Errorf("failed to retrieve child playlists: %w", err)
+		}
+
+		for _, childPlaylist := range childPlaylists {
+			if childPlaylist.SpotifyIntegrationID == integrationID {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// Relink reactivates the playlists suspended by a disconnect and clears the user's disconnect marker,
+// once the spotify integration has been stored again
+func (s *SpotifyAccountService) Relink(ctx context.Context, userID string) error {
+	s.logger.InfoContext(ctx, "re-linking spotify account", "user_id", userID)
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	if err := s.setPlaylistsSuspended(ctx, userID, false); err != nil {
+		return err
+	}
+
+	spotifyID := user.DisconnectedSpotifyID
+	user.DisconnectedSpotifyID = ""
+	if _, err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "spotify account re-linked successfully", "user_id", userID, "spotify_id", spotifyID)
+	return nil
+}
+
+// setPlaylistsSuspended suspends the user's active playlists, or reactivates the suspended ones. Only
+// the playlists the disconnect deactivated are reactivated, the ones the user turned off stay off
+func (s *SpotifyAccountService) setPlaylistsSuspended(ctx context.Context, userID string, suspended bool) error {
+	basePlaylists, err := s.basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve base playlists: %w", err)
+	}
+
+	isActive := !suspended
+	for _, basePlaylist := range basePlaylists {
+		childPlaylists, err := s.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve child playlists: %w", err)
+		}
+
+		for _, childPlaylist := range childPlaylists {
+			if !shouldToggleSuspension(childPlaylist.IsActive, childPlaylist.Suspended, suspended) {
+				continue
+			}
+
+			_, err := s.childPlaylistRepo.Update(ctx, childPlaylist.ID, userID, repositories.UpdateChildPlaylistFields{
+				IsActive:  &isActive,
+				Suspended: &suspended,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update child playlist: %w", err)
+			}
+		}
+
+		if !shouldToggleSuspension(basePlaylist.IsActive, basePlaylist.Suspended, suspended) {
+			continue
+		}
+
+		_, err = s.basePlaylistRepo.Update(ctx, basePlaylist.ID, userID, repositories.UpdateBasePlaylistFields{
+			IsActive:  &isActive,
+			Suspended: &suspended,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update base playlist: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func shouldToggleSuspension(isActive, isSuspended, suspend bool) bool {
+	if suspend {
+		return isActive
+	}
+
+	return isSuspended
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repoMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+type spotifyAccountServiceMocks struct {
+	userRepo          *repoMocks.MockUserRepository
+	integrationRepo   *repoMocks.MockSpotifyIntegrationRepository
+	basePlaylistRepo  *repoMocks.MockBasePlaylistRepository
+	childPlaylistRepo *repoMocks.MockChildPlaylistRepository
+}
+
+func newTestSpotifyAccountService(t *testing.T) (*SpotifyAccountService, spotifyAccountServiceMocks) {
+	ctrl := setupMockController(t)
+	logger := createTestLogger()
+
+	m := spotifyAccountServiceMocks{
+		userRepo:          repoMocks.NewMockUserRepository(ctrl),
+		integrationRepo:   repoMocks.NewMockSpotifyIntegrationRepository(ctrl),
+		basePlaylistRepo:  repoMocks.NewMockBasePlaylistRepository(ctrl),
+		childPlaylistRepo: repoMocks.NewMockChildPlaylistRepository(ctrl),
+	}
+
+	service := NewSpotifyAccountService(
+		m.userRepo,
+		NewSpotifyIntegrationService(m.integrationRepo, logger),
+		m.basePlaylistRepo,
+		m.childPlaylistRepo,
+		logger,
+	)
+
+	return service, m
+}
+
+func TestSpotifyAccountService_Disconnect_Success(t *testing.T) {
+	assert := require.New(t)
+	service, m := newTestSpotifyAccountService(t)
+	ctx := context.Background()
+
+	user := testfixtures.NewUser().Build()
+	integration := testfixtures.NewSpotifyIntegration().WithUserID(user.ID).Build()
+	activeBase := testfixtures.NewBasePlaylist().WithID("base1").Build()
+	inactiveBase := testfixtures.NewBasePlaylist().WithID("base2").Inactive().Build()
+	activeChild := testfixtures.NewChildPlaylist().WithID("child1").WithBasePlaylistID("base1").Build()
+	inactiveChild := testfixtures.NewChildPlaylist().WithID("child2").WithBasePlaylistID("base1").Inactive().Build()
+
+	m.integrationRepo.EXPECT().GetByUserID(ctx, user.ID).Return(integration, nil)
+	m.userRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil)
+	m.basePlaylistRepo.EXPECT().GetByUserID(ctx, user.ID).Return([]*models.BasePlaylist{activeBase, inactiveBase}, nil)
+	m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(ctx, "base1", user.ID).Return([]*models.ChildPlaylist{activeChild, inactiveChild}, nil)
+	m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(ctx, "base2", user.ID).Return(nil, nil)
+
+	// Only the active playlists get suspended, the ones turned off stay as they are
+	m.childPlaylistRepo.EXPECT().
+		Update(ctx, "child1", user.ID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, fields repositories.UpdateChildPlaylistFields) (*models.ChildPlaylist, error) {
+			assert.False(*fields.IsActive)
+			assert.True(*fields.Suspended)
+			return activeChild, nil
+		})
+	m.basePlaylistRepo.EXPECT().
+		Update(ctx, "base1", user.ID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, fields repositories.UpdateBasePlaylistFields) (*models.BasePlaylist, error) {
+			assert.False(*fields.IsActive)
+			assert.True(*fields.Suspended)
+			return activeBase, nil
+		})
+
+	m.userRepo.EXPECT().
+		Update(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, updated *models.User) (*models.User, error) {
+			assert.Equal(integration.SpotifyID, updated.DisconnectedSpotifyID)
+			return updated, nil
+		})
+	m.integrationRepo.EXPECT().Delete(ctx, user.ID).Return(nil)
+
+	err := service.Disconnect(ctx, user.ID)
+	assert.NoError(err)
+}
+
+func TestSpotifyAccountService_Disconnect_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(m spotifyAccountServiceMocks)
+		expectedError error
+	}{
+		{
+			name: "integration not found",
+			setupMocks: func(m spotifyAccountServiceMocks) {
+				m.integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrSpotifyIntegrationNotFound)
+			},
+			expectedError: ErrSpotifyIntegrationUnavailable,
+		},
+		{
+			name: "base playlists error keeps the integration",
+			setupMocks: func(m spotifyAccountServiceMocks) {
+				m.integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(testfixtures.NewSpotifyIntegration().Build(), nil)
+				m.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(testfixtures.NewUser().Build(), nil)
+				m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrDatabaseOperation)
+			},
+			expectedError: repositories.ErrDatabaseOperation,
+		},
+		{
+			name: "delete error",
+			setupMocks: func(m spotifyAccountServiceMocks) {
+				m.integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(testfixtures.NewSpotifyIntegration().Build(), nil)
+				m.userRepo.EXPECT().GetByID(gomock.Any(), "user123").Return(testfixtures.NewUser().Build(), nil)
+				m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, nil)
+				m.userRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(testfixtures.NewUser().Build(), nil)
+				m.integrationRepo.EXPECT().Delete(gomock.Any(), "user123").Return(repositories.ErrDatabaseOperation)
+			},
+			expectedError: repositories.ErrDatabaseOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, m := newTestSpotifyAccountService(t)
+			tt.setupMocks(m)
+
+			err := service.Disconnect(context.Background(), "user123")
+			assert.ErrorIs(err, tt.expectedError)
+		})
+	}
+}
+
+func TestSpotifyAccountService_FindDisconnectedUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		repoUser      *models.User
+		repoError     error
+		expectedUser  bool
+		expectedError bool
+	}{
+		{
+			name:         "user found",
+			repoUser:     testfixtures.NewUser().WithDisconnectedSpotifyID("spotify123").Build(),
+			expectedUser: true,
+		},
+		{
+			name:      "no user disconnected the account",
+			repoError: repositories.ErrUseNotFound,
+		},
+		{
+			name:          "repository error",
+			repoError:     errors.New("database error"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, m := newTestSpotifyAccountService(t)
+
+			m.userRepo.EXPECT().GetByDisconnectedSpotifyID(gomock.Any(), "spotify123").Return(tt.repoUser, tt.repoError)
+
+			user, err := service.FindDisconnectedUser(context.Background(), "spotify123")
+			if tt.expectedError {
+				assert.Error(err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.expectedUser, user != nil)
+		})
+	}
+}
+
+func TestSpotifyAccountService_Relink_Success(t *testing.T) {
+	assert := require.New(t)
+	service, m := newTestSpotifyAccountService(t)
+	ctx := context.Background()
+
+	user := testfixtures.NewUser().WithDisconnectedSpotifyID("spotify123").Build()
+	suspendedBase := testfixtures.NewBasePlaylist().WithID("base1").Suspended().Build()
+	suspendedChild := testfixtures.NewChildPlaylist().WithID("child1").WithBasePlaylistID("base1").Suspended().Build()
+	inactiveChild := testfixtures.NewChildPlaylist().WithID("child2").WithBasePlaylistID("base1").Inactive().Build()
+
+	m.userRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil)
+	m.basePlaylistRepo.EXPECT().GetByUserID(ctx, user.ID).Return([]*models.BasePlaylist{suspendedBase}, nil)
+	m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(ctx, "base1", user.ID).Return([]*models.ChildPlaylist{suspendedChild, inactiveChild}, nil)
+
+	// The child playlist the user turned off before disconnecting stays off
+	m.childPlaylistRepo.EXPECT().
+		Update(ctx, "child1", user.ID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, fields repositories.UpdateChildPlaylistFields) (*models.ChildPlaylist, error) {
+			assert.True(*fields.IsActive)
+			assert.False(*fields.Suspended)
+			return suspendedChild, nil
+		})
+	m.basePlaylistRepo.EXPECT().
+		Update(ctx, "base1", user.ID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, fields repositories.UpdateBasePlaylistFields) (*models.BasePlaylist, error) {
+			assert.True(*fields.IsActive)
+			assert.False(*fields.Suspended)
+			return suspendedBase, nil
+		})
+
+	m.userRepo.EXPECT().
+		Update(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, updated *models.User) (*models.User, error) {
+			assert.Empty(updated.DisconnectedSpotifyID)
+			return updated, nil
+		})
+
+	err := service.Relink(ctx, user.ID)
+	assert.NoError(err)
+}
+
+func TestSpotifyAccountService_Relink_UpdateError(t *testing.T) {
+	assert := require.New(t)
+	service, m := newTestSpotifyAccountService(t)
+
+	user := testfixtures.NewUser().WithDisconnectedSpotifyID("spotify123").Build()
+
+	m.userRepo.EXPECT().GetByID(gomock.Any(), user.ID).Return(user, nil)
+	m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), user.ID).Return([]*models.BasePlaylist{testfixtures.NewBasePlaylist().Suspended().Build()}, nil)
+	m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "base123", user.ID).Return(nil, nil)
+	m.basePlaylistRepo.EXPECT().Update(gomock.Any(), "base123", user.ID, gomock.Any()).Return(nil, repositories.ErrDatabaseOperation)
+
+	err := service.Relink(context.Background(), user.ID)
+	assert.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestSpotifyAccountService_ListAccounts(t *testing.T) {
+	assert := require.New(t)
+	service, m := newTestSpotifyAccountService(t)
+
+	integrations := []*models.SpotifyIntegration{
+		testfixtures.NewSpotifyIntegration().Build(),
+		testfixtures.NewSpotifyIntegration().WithID("integration_dj").WithSpotifyID("spotify_dj").Build(),
+	}
+	m.integrationRepo.EXPECT().ListByUserID(gomock.Any(), "user123").Return(integrations, nil)
+
+	result, err := service.ListAccounts(context.Background(), "user123")
+
+	assert.NoError(err)
+	assert.Equal(integrations, result)
+}
+
+func TestSpotifyAccountService_UnlinkAccount(t *testing.T) {
+	defaultIntegration := testfixtures.NewSpotifyIntegration().Build()
+	djIntegration := testfixtures.NewSpotifyIntegration().WithID("integration_dj").WithSpotifyID("spotify_dj").Build()
+
+	tests := []struct {
+		name          string
+		integrationID string
+		setupMocks    func(m spotifyAccountServiceMocks)
+		expectedError error
+	}{
+		{
+			name:          "unused additional account is unlinked",
+			integrationID: djIntegration.ID,
+			setupMocks: func(m spotifyAccountServiceMocks) {
+				m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return([]*models.BasePlaylist{testfixtures.NewBasePlaylist().Build()}, nil)
+				m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "base123", "user123").Return([]*models.ChildPlaylist{testfixtures.NewChildPlaylist().Build()}, nil)
+				m.integrationRepo.EXPECT().DeleteByID(gomock.Any(), djIntegration.ID, "user123").Return(nil)
+			},
+		},
+		{
+			name:          "default account",
+			integrationID: defaultIntegration.ID,
+			expectedError: ErrDefaultSpotifyAccount,
+		},
+		{
+			name:          "account used by a child playlist",
+			integrationID: djIntegration.ID,
+			setupMocks: func(m spotifyAccountServiceMocks) {
+				m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return([]*models.BasePlaylist{testfixtures.NewBasePlaylist().Build()}, nil)
+				m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "base123", "user123").
+					Return([]*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithSpotifyIntegrationID(djIntegration.ID).Build()}, nil)
+			},
+			expectedError: ErrSpotifyAccountInUse,
+		},
+		{
+			name:          "account used by a base playlist",
+			integrationID: djIntegration.ID,
+			setupMocks: func(m spotifyAccountServiceMocks) {
+				m.basePlaylistRepo.EXPECT().GetByUserID(gomock.Any(), "user123").
+					Return([]*models.BasePlaylist{testfixtures.NewBasePlaylist().WithSpotifyIntegrationID(djIntegration.ID).Build()}, nil)
+			},
+			expectedError: ErrSpotifyAccountInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, m := newTestSpotifyAccountService(t)
+
+			integration := djIntegration
+			if tt.integrationID == defaultIntegration.ID {
+				integration = defaultIntegration
+			}
+			m.integrationRepo.EXPECT().GetByID(gomock.Any(), tt.integrationID, "user123").Return(integration, nil)
+			m.integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(defaultIntegration, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(m)
+			}
+
+			err := service.UnlinkAccount(context.Background(), "user123", tt.integrationID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(err, tt.expectedError)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSpotifyAccountService_UnlinkAccount_NotFound(t *testing.T) {
+	assert := require.New(t)
+	service, m := newTestSpotifyAccountService(t)
+
+	m.integrationRepo.EXPECT().GetByID(gomock.Any(), "integration_other", "user123").Return(nil, repositories.ErrSpotifyIntegrationNotFound)
+
+	err := service.UnlinkAccount(context.Background(), "user123", "integration_other")
+
+	assert.ErrorIs(err, ErrSpotifyIntegrationUnavailable)
+}
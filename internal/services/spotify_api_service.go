@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
@@ -45,46 +46,53 @@ func (sas *SpotifyAPIService) GetFilteredUserPlaylists(ctx context.Context, user
 		return nil, err
 	}
 
-	// Get existing base playlists to exclude their Spotify IDs
-	basePlaylists, err := sas.basePlaylistRepo.GetByUserID(ctx, userID)
+	filteredPlaylists, err := unusedPlaylists(ctx, sas.basePlaylistRepo, sas.childPlaylistRepo, userID, allPlaylists)
 	if err != nil {
-		sas.logger.ErrorContext(ctx, "failed to fetch base playlists", "user_id", userID, "error", err.Error())
-		return nil, fmt.Errorf("failed to fetch base playlists: %w", err)
+		sas.logger.ErrorContext(ctx, "failed to filter user playlists", "user_id", userID, "error", err.Error())
+		return nil, err
 	}
 
-	// Get existing child playlists to exclude their Spotify IDs
-	var childPlaylists []*models.ChildPlaylist
-	for _, basePlaylist := range basePlaylists {
-		baseChildPlaylists, err := sas.childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
-		if err != nil {
-			sas.logger.ErrorContext(ctx, "failed to fetch child playlists for base playlist",
-				"user_id", userID, "base_playlist_id", basePlaylist.ID, "error", err.Error())
+	sas.logger.InfoContext(ctx, "successfully filtered user playlists",
+		"user_id", userID,
+		"total_playlists", len(allPlaylists),
+		"filtered_playlists", len(filteredPlaylists))
 
-			return nil, fmt.Errorf("failed to fetch child playlists: %w", err)
-		}
-		childPlaylists = append(childPlaylists, baseChildPlaylists...)
+	return filteredPlaylists, nil
+}
+
+// unusedPlaylists returns the playlists of the provider no base or child playlist of the user is
+// linked to yet
+func unusedPlaylists(
+	ctx context.Context,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	userID string,
+	playlists []*musicprovider.Playlist,
+) ([]*models.SpotifyPlaylist, error) {
+	basePlaylists, err := basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch base playlists: %w", err)
 	}
 
 	usedPlaylistIDs := make(map[string]bool)
 	for _, basePlaylist := range basePlaylists {
 		usedPlaylistIDs[basePlaylist.SpotifyPlaylistID] = true
-	}
-	for _, childPlaylist := range childPlaylists {
-		usedPlaylistIDs[childPlaylist.SpotifyPlaylistID] = true
+
+		childPlaylists, err := childPlaylistRepo.GetByBasePlaylistID(ctx, basePlaylist.ID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch child playlists: %w", err)
+		}
+		for _, childPlaylist := range childPlaylists {
+			usedPlaylistIDs[childPlaylist.SpotifyPlaylistID] = true
+		}
 	}
 
 	filteredPlaylists := make([]*models.SpotifyPlaylist, 0)
-	for _, playlist := range allPlaylists {
+	for _, playlist := range playlists {
 		if !usedPlaylistIDs[playlist.ID] {
-			filteredPlaylists = append(filteredPlaylists, spotifyclient.ParseSpotifyPlaylist(playlist))
+			filteredPlaylists = append(filteredPlaylists, musicprovider.ParsePlaylist(playlist))
 		}
 	}
 
-	sas.logger.InfoContext(ctx, "successfully filtered user playlists",
-		"user_id", userID,
-		"total_playlists", len(allPlaylists),
-		"filtered_playlists", len(filteredPlaylists),
-		"excluded_count", len(usedPlaylistIDs))
-
 	return filteredPlaylists, nil
 }
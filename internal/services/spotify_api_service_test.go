@@ -10,6 +10,7 @@ import (
 	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
 	"github.com/ngomez18/playlist-router/internal/models"
 	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,7 +80,12 @@ func TestSpotifyAPIService_GetFilteredUserPlaylists_Success(t *testing.T) {
 				},
 			},
 			basePlaylists: []*models.BasePlaylist{
-				{ID: "base1", SpotifyPlaylistID: "playlist1", UserID: "user123", Name: "My Rock Playlist"},
+				testfixtures.NewBasePlaylist().
+					WithID("base1").
+					WithSpotifyPlaylistID("playlist1").
+					WithUserID("user123").
+					WithName("My Rock Playlist").
+					Build(),
 			},
 			childPlaylistsMap: map[string][]*models.ChildPlaylist{
 				"base1": {},
@@ -104,7 +110,12 @@ func TestSpotifyAPIService_GetFilteredUserPlaylists_Success(t *testing.T) {
 				},
 			},
 			basePlaylists: []*models.BasePlaylist{
-				{ID: "base1", SpotifyPlaylistID: "playlist1", UserID: "user123", Name: "My Rock Playlist"},
+				testfixtures.NewBasePlaylist().
+					WithID("base1").
+					WithSpotifyPlaylistID("playlist1").
+					WithUserID("user123").
+					WithName("My Rock Playlist").
+					Build(),
 			},
 			childPlaylistsMap: map[string][]*models.ChildPlaylist{
 				"base1": {
@@ -229,7 +240,12 @@ func TestSpotifyAPIService_GetFilteredUserPlaylists_Errors(t *testing.T) {
 					Times(1)
 			} else if tt.childErr != nil {
 				basePlaylists := []*models.BasePlaylist{
-					{ID: "base1", SpotifyPlaylistID: "playlist1", UserID: tt.userID, Name: "Test Base"},
+					testfixtures.NewBasePlaylist().
+						WithID("base1").
+						WithSpotifyPlaylistID("playlist1").
+						WithUserID(tt.userID).
+						WithName("Test Base").
+						Build(),
 				}
 
 				mockSpotifyClient.EXPECT().
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
@@ -12,10 +13,15 @@ import (
 
 type SpotifyIntegrationServicer interface {
 	CreateOrUpdateIntegration(ctx context.Context, userID string, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error)
+	// GetIntegrationByUserID returns the default integration of the user
 	GetIntegrationByUserID(ctx context.Context, userID string) (*models.SpotifyIntegration, error)
+	GetIntegrationByID(ctx context.Context, id, userID string) (*models.SpotifyIntegration, error)
+	ListIntegrationsByUserID(ctx context.Context, userID string) ([]*models.SpotifyIntegration, error)
 	GetIntegrationBySpotifyID(ctx context.Context, spotifyID string) (*models.SpotifyIntegration, error)
 	UpdateTokens(ctx context.Context, integrationID string, tokens *models.SpotifyIntegrationTokenRefresh) error
+	GetIntegrationsExpiringBefore(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error)
 	DeleteIntegration(ctx context.Context, userID string) error
+	DeleteIntegrationByID(ctx context.Context, id, userID string) error
 }
 
 type SpotifyIntegrationService struct {
@@ -56,6 +62,29 @@ func (sis *SpotifyIntegrationService) GetIntegrationByUserID(ctx context.Context
 	return integration, nil
 }
 
+func (sis *SpotifyIntegrationService) GetIntegrationByID(ctx context.Context, id, userID string) (*models.SpotifyIntegration, error) {
+	sis.logger.InfoContext(ctx, "retrieving spotify integration by ID", "integration_id", id, "user_id", userID)
+
+	integration, err := sis.integrationRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		sis.logger.ErrorContext(ctx, "unable to fetch spotify integration by ID", "integration_id", id, "user_id", userID, "error", err.Error())
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+func (sis *SpotifyIntegrationService) ListIntegrationsByUserID(ctx context.Context, userID string) ([]*models.SpotifyIntegration, error) {
+	integrations, err := sis.integrationRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		sis.logger.ErrorContext(ctx, "unable to list spotify integrations", "user_id", userID, "error", err.Error())
+		return nil, err
+	}
+
+	sis.logger.InfoContext(ctx, "spotify integrations retrieved successfully", "user_id", userID, "count", len(integrations))
+	return integrations, nil
+}
+
 func (sis *SpotifyIntegrationService) GetIntegrationBySpotifyID(ctx context.Context, spotifyID string) (*models.SpotifyIntegration, error) {
 	sis.logger.InfoContext(ctx, "retrieving spotify integration by spotify ID", "spotify_id", spotifyID)
 
@@ -82,6 +111,17 @@ func (sis *SpotifyIntegrationService) UpdateTokens(ctx context.Context, integrat
 	return nil
 }
 
+func (sis *SpotifyIntegrationService) GetIntegrationsExpiringBefore(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error) {
+	integrations, err := sis.integrationRepo.GetExpiringBefore(ctx, before)
+	if err != nil {
+		sis.logger.ErrorContext(ctx, "unable to fetch expiring spotify integrations", "before", before, "error", err.Error())
+		return nil, err
+	}
+
+	sis.logger.InfoContext(ctx, "expiring spotify integrations retrieved successfully", "before", before, "count", len(integrations))
+	return integrations, nil
+}
+
 func (sis *SpotifyIntegrationService) DeleteIntegration(ctx context.Context, userID string) error {
 	sis.logger.InfoContext(ctx, "deleting spotify integration", "user_id", userID)
 
@@ -94,3 +134,16 @@ func (sis *SpotifyIntegrationService) DeleteIntegration(ctx context.Context, use
 	sis.logger.InfoContext(ctx, "spotify integration deleted successfully", "user_id", userID)
 	return nil
 }
+
+func (sis *SpotifyIntegrationService) DeleteIntegrationByID(ctx context.Context, id, userID string) error {
+	sis.logger.InfoContext(ctx, "deleting spotify integration by ID", "integration_id", id, "user_id", userID)
+
+	err := sis.integrationRepo.DeleteByID(ctx, id, userID)
+	if err != nil {
+		sis.logger.ErrorContext(ctx, "failed to delete spotify integration", "integration_id", id, "user_id", userID, "error", err.Error())
+		return err
+	}
+
+	sis.logger.InfoContext(ctx, "spotify integration deleted successfully", "integration_id", id, "user_id", userID)
+	return nil
+}
@@ -397,6 +397,48 @@ func TestSpotifyIntegrationService_UpdateTokens_Error(t *testing.T) {
 	}
 }
 
+func TestSpotifyIntegrationService_GetIntegrationsExpiringBefore(t *testing.T) {
+	tests := []struct {
+		name         string
+		integrations []*models.SpotifyIntegration
+		repoErr      error
+	}{
+		{
+			name:         "success",
+			integrations: []*models.SpotifyIntegration{{ID: "integration1"}, {ID: "integration2"}},
+		},
+		{
+			name:    "repository error",
+			repoErr: repositories.ErrDatabaseOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSpotifyIntegrationRepository(ctrl)
+			service := NewSpotifyIntegrationService(mockRepo, createTestLogger())
+
+			before := time.Now().Add(time.Hour)
+			mockRepo.EXPECT().GetExpiringBefore(gomock.Any(), before).Return(tt.integrations, tt.repoErr)
+
+			result, err := service.GetIntegrationsExpiringBefore(context.Background(), before)
+
+			if tt.repoErr != nil {
+				assert.ErrorIs(err, tt.repoErr)
+				assert.Nil(result)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.integrations, result)
+		})
+	}
+}
+
 func TestSpotifyIntegrationService_DeleteIntegration_Success(t *testing.T) {
 	assert := assert.New(t)
 	ctrl := gomock.NewController(t)
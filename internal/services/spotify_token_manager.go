@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+// TOKEN_REFRESH_JOB_WINDOW is how far ahead the refresh job renews expiring tokens
+const TOKEN_REFRESH_JOB_WINDOW = time.Hour
+
+// SpotifyTokenManager hands out spotify integrations with tokens valid for at least the refresh
+// window, refreshing them ahead of expiry. It is shared by the API middleware and background
+// jobs so every refresh of an integration goes through the same place
+type SpotifyTokenManager struct {
+	spotifyIntegrationService SpotifyIntegrationServicer
+	spotifyClient             spotifyclient.SpotifyAPI
+	refreshWindow             time.Duration
+	refreshes                 singleflight.Group
+	logger                    *slog.Logger
+}
+
+type tokenRefreshResult struct {
+	integration *models.SpotifyIntegration
+	refreshed   bool
+}
+
+func NewSpotifyTokenManager(
+	spotifyIntegrationService SpotifyIntegrationServicer,
+	spotifyClient spotifyclient.SpotifyAPI,
+	refreshWindow time.Duration,
+	logger *slog.Logger,
+) *SpotifyTokenManager {
+	return &SpotifyTokenManager{
+		spotifyIntegrationService: spotifyIntegrationService,
+		spotifyClient:             spotifyClient,
+		refreshWindow:             refreshWindow,
+		logger:                    logger.With("component", "SpotifyTokenManager"),
+	}
+}
+
+// ContextWithSpotifyAuth loads the user's default spotify integration, refreshing its tokens when
+// they expire within the refresh window, and returns a context carrying it for the spotify client
+func (tm *SpotifyTokenManager) ContextWithSpotifyAuth(ctx context.Context, userID string) (context.Context, error) {
+	return tm.ContextWithSpotifyAccount(ctx, userID, "")
+}
+
+// ContextWithSpotifyAccount is ContextWithSpotifyAuth for one of the spotify accounts linked by the
+// user, the default one when integrationID is empty
+func (tm *SpotifyTokenManager) ContextWithSpotifyAccount(ctx context.Context, userID, integrationID string) (context.Context, error) {
+	integration, _, err := tm.refreshIfExpiring(ctx, userID, integrationID, time.Now().Add(tm.refreshWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	return requestcontext.ContextWithSpotifyAuth(ctx, integration), nil
+}
+
+// contextWithSpotifyAccount returns a context acting as the given spotify account of the user. The
+// context is kept as is without an account or provider, or when it already carries that account
+func contextWithSpotifyAccount(ctx context.Context, spotifyAuth SpotifyAuthProvider, userID, integrationID string) (context.Context, error) {
+	if integrationID == "" || spotifyAuth == nil {
+		return ctx, nil
+	}
+	if integration, ok := requestcontext.GetSpotifyAuthFromContext(ctx); ok && integration.ID == integrationID {
+		return ctx, nil
+	}
+
+	return spotifyAuth.ContextWithSpotifyAccount(ctx, userID, integrationID)
+}
+
+// contextWithPlaylistAccount returns a context acting as the account a playlist lives in, routing the
+// music provider calls to its streaming service. Only spotify playlists pick one of several accounts,
+// the youtube music client loads the single account of the user on its own
+func contextWithPlaylistAccount(
+	ctx context.Context,
+	spotifyAuth SpotifyAuthProvider,
+	userID string,
+	provider models.MusicProvider,
+	integrationID string,
+) (context.Context, error) {
+	ctx = requestcontext.ContextWithMusicProvider(ctx, provider)
+	if provider.OrDefault() != models.MusicProviderSpotify {
+		return ctx, nil
+	}
+
+	return contextWithSpotifyAccount(ctx, spotifyAuth, userID, integrationID)
+}
+
+// RefreshExpiringTokens renews the tokens of every integration expiring within the window, so
+// syncs don't pay the refresh latency. Integrations refreshed meanwhile are skipped.
+func (tm *SpotifyTokenManager) RefreshExpiringTokens(ctx context.Context, window time.Duration) (*models.TokenRefreshReport, error) {
+	cutoff := time.Now().Add(window)
+
+	integrations, err := tm.spotifyIntegrationService.GetIntegrationsExpiringBefore(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring spotify integrations: %w", err)
+	}
+
+	report := &models.TokenRefreshReport{Checked: len(integrations)}
+	for _, integration := range integrations {
+		_, refreshed, err := tm.refreshIfExpiring(ctx, integration.UserID, integration.ID, cutoff)
+		if err != nil {
+			report.Failed++
+			tm.logger.ErrorContext(ctx, "failed to proactively refresh spotify tokens",
+				"user_id", integration.UserID,
+				"integration_id", integration.ID,
+				"error", err,
+			)
+			continue
+		}
+		if refreshed {
+			report.Refreshed++
+		}
+	}
+
+	tm.logger.InfoContext(ctx, "proactive spotify token refresh completed",
+		"checked", report.Checked,
+		"refreshed", report.Refreshed,
+		"failed", report.Failed,
+	)
+
+	return report, nil
+}
+
+// refreshIfExpiring reads the integration of the user and refreshes its tokens when they expire
+// before the cutoff, the default integration when integrationID is empty. Concurrent calls of the
+// user for the same integration share a single read and refresh, so a rotated refresh token is never
+// used twice; a call joining one in flight gets its result even when the cutoffs differ
+func (tm *SpotifyTokenManager) refreshIfExpiring(ctx context.Context, userID, integrationID string, cutoff time.Time) (*models.SpotifyIntegration, bool, error) {
+	// Refreshes are shared per integration, so the default one is resolved to its ID first
+	if integrationID == "" {
+		integration, err := tm.spotifyIntegrationService.GetIntegrationByUserID(ctx, userID)
+		if err != nil {
+			tm.logger.ErrorContext(ctx, "failed to get default spotify integration", "user_id", userID, "error", err)
+			return nil, false, fmt.Errorf("%w: %w", ErrSpotifyIntegrationUnavailable, err)
+		}
+		integrationID = integration.ID
+	}
+
+	// The refresh is shared by every waiting caller, it must not be cancelled along with the first
+	sharedCtx := context.WithoutCancel(ctx)
+
+	// Keyed by user too, the ownership check runs inside the shared call so a call naming the
+	// integration of another user must never join it
+	value, err, _ := tm.refreshes.Do(userID+"/"+integrationID, func() (any, error) {
+		integration, err := tm.spotifyIntegrationService.GetIntegrationByID(sharedCtx, integrationID, userID)
+		if err != nil {
+			tm.logger.ErrorContext(sharedCtx, "failed to get spotify integration", "user_id", userID, "integration_id", integrationID, "error", err)
+			return nil, fmt.Errorf("%w: %w", ErrSpotifyIntegrationUnavailable, err)
+		}
+
+		if !integration.ExpiresAt.Before(cutoff) {
+			return &tokenRefreshResult{integration: integration}, nil
+		}
+
+		tm.logger.InfoContext(sharedCtx, "refreshing spotify tokens", "user_id", userID, "expires_at", integration.ExpiresAt)
+
+		refreshed, err := tm.refreshTokens(sharedCtx, integration)
+		if err != nil {
+			tm.logger.ErrorContext(sharedCtx, "failed to refresh spotify tokens",
+				"user_id", userID,
+				"integration_id", integration.ID,
+				"error", err,
+			)
+			return nil, fmt.Errorf("%w: %w", ErrSpotifyTokenRefresh, err)
+		}
+
+		tm.logger.InfoContext(sharedCtx, "successfully refreshed spotify tokens", "user_id", userID, "new_expires_at", refreshed.ExpiresAt)
+		return &tokenRefreshResult{integration: refreshed, refreshed: true}, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Every caller gets its own copy of the shared integration
+	result := value.(*tokenRefreshResult)
+	integration := *result.integration
+
+	return &integration, result.refreshed, nil
+}
+
+// refreshTokens exchanges the refresh token and persists the new tokens, keeping the current
+// refresh token when Spotify doesn't rotate it
+func (tm *SpotifyTokenManager) refreshTokens(ctx context.Context, integration *models.SpotifyIntegration) (*models.SpotifyIntegration, error) {
+	tokenResponse, err := tm.spotifyClient.RefreshTokens(ctx, integration.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenUpdate := &models.SpotifyIntegrationTokenRefresh{
+		AccessToken:  tokenResponse.AccessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+		ExpiresIn:    tokenResponse.ExpiresIn,
+	}
+
+	if tokenUpdate.RefreshToken == "" {
+		tokenUpdate.RefreshToken = integration.RefreshToken
+	}
+
+	err = tm.spotifyIntegrationService.UpdateTokens(ctx, integration.ID, tokenUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedIntegration := *integration
+	updatedIntegration.AccessToken = tokenUpdate.AccessToken
+	updatedIntegration.RefreshToken = tokenUpdate.RefreshToken
+	updatedIntegration.ExpiresAt = time.Now().Add(time.Duration(tokenUpdate.ExpiresIn) * time.Second)
+
+	return &updatedIntegration, nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	spotifyClientMocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSpotifyTokenManager(t *testing.T) (*SpotifyTokenManager, *repositoryMocks.MockSpotifyIntegrationRepository, *spotifyClientMocks.MockSpotifyAPI) {
+	ctrl := setupMockController(t)
+	integrationRepo := repositoryMocks.NewMockSpotifyIntegrationRepository(ctrl)
+	spotifyClient := spotifyClientMocks.NewMockSpotifyAPI(ctrl)
+
+	integrationService := NewSpotifyIntegrationService(integrationRepo, createTestLogger())
+	tokenManager := NewSpotifyTokenManager(integrationService, spotifyClient, 15*time.Minute, createTestLogger())
+
+	return tokenManager, integrationRepo, spotifyClient
+}
+
+func TestSpotifyTokenManager_ContextWithSpotifyAuth(t *testing.T) {
+	tests := []struct {
+		name             string
+		expiresIn        time.Duration
+		refreshResponse  *spotifyclient.SpotifyTokenResponse
+		expectedAccess   string
+		expectedRefresh  string
+		expectPersisting bool
+	}{
+		{
+			name:            "token valid past the window is used as is",
+			expiresIn:       time.Hour,
+			expectedAccess:  "access_token_123",
+			expectedRefresh: "refresh_token_123",
+		},
+		{
+			name:             "token expiring within the window is refreshed and the rotated refresh token persisted",
+			expiresIn:        5 * time.Minute,
+			refreshResponse:  &spotifyclient.SpotifyTokenResponse{AccessToken: "new_access", RefreshToken: "new_refresh", ExpiresIn: 3600},
+			expectedAccess:   "new_access",
+			expectedRefresh:  "new_refresh",
+			expectPersisting: true,
+		},
+		{
+			name:             "refresh token is kept when spotify does not rotate it",
+			expiresIn:        5 * time.Minute,
+			refreshResponse:  &spotifyclient.SpotifyTokenResponse{AccessToken: "new_access", ExpiresIn: 3600},
+			expectedAccess:   "new_access",
+			expectedRefresh:  "refresh_token_123",
+			expectPersisting: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			tokenManager, integrationRepo, spotifyClient := setupSpotifyTokenManager(t)
+
+			integration := testfixtures.NewSpotifyIntegration().
+				WithTokens("access_token_123", "refresh_token_123").
+				WithExpiresAt(time.Now().Add(tt.expiresIn)).
+				Build()
+			integrationRepo.EXPECT().GetByUserID(gomock.Any(), integration.UserID).Return(integration, nil)
+			integrationRepo.EXPECT().GetByID(gomock.Any(), integration.ID, integration.UserID).Return(integration, nil)
+
+			if tt.refreshResponse != nil {
+				spotifyClient.EXPECT().RefreshTokens(gomock.Any(), "refresh_token_123").Return(tt.refreshResponse, nil)
+				integrationRepo.EXPECT().
+					UpdateTokens(gomock.Any(), integration.ID, &models.SpotifyIntegrationTokenRefresh{
+						AccessToken:  tt.expectedAccess,
+						RefreshToken: tt.expectedRefresh,
+						ExpiresIn:    3600,
+					}).
+					Return(nil)
+			}
+
+			ctx, err := tokenManager.ContextWithSpotifyAuth(context.Background(), integration.UserID)
+			assert.NoError(err)
+
+			spotifyAuth, ok := requestcontext.GetSpotifyAuthFromContext(ctx)
+			assert.True(ok)
+			assert.Equal(tt.expectedAccess, spotifyAuth.AccessToken)
+			assert.Equal(tt.expectedRefresh, spotifyAuth.RefreshToken)
+			if tt.expectPersisting {
+				assert.True(spotifyAuth.ExpiresAt.After(time.Now().Add(59 * time.Minute)))
+			}
+		})
+	}
+}
+
+func TestSpotifyTokenManager_ContextWithSpotifyAuth_WrapsErrors(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, spotifyClient := setupSpotifyTokenManager(t)
+
+	decryptErr := errors.New("cipher: message authentication failed")
+	integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, decryptErr)
+
+	_, err := tokenManager.ContextWithSpotifyAuth(context.Background(), "user123")
+	assert.ErrorIs(err, ErrSpotifyIntegrationUnavailable)
+	assert.ErrorIs(err, decryptErr)
+
+	refreshErr := errors.New("invalid_grant")
+	integrationRepo.EXPECT().GetByID(gomock.Any(), "integration123", "user123").
+		Return(testfixtures.NewSpotifyIntegration().WithExpiresAt(time.Now()).Build(), nil)
+	spotifyClient.EXPECT().RefreshTokens(gomock.Any(), gomock.Any()).Return(nil, refreshErr)
+
+	_, err = tokenManager.ContextWithSpotifyAccount(context.Background(), "user123", "integration123")
+	assert.ErrorIs(err, ErrSpotifyTokenRefresh)
+	assert.ErrorIs(err, refreshErr)
+}
+
+func TestSpotifyTokenManager_ContextWithSpotifyAuth_ConcurrentRefreshesShareOne(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, spotifyClient := setupSpotifyTokenManager(t)
+
+	integration := testfixtures.NewSpotifyIntegration().
+		WithTokens("access_token_123", "refresh_token_123").
+		WithExpiresAt(time.Now()).
+		Build()
+
+	const callers = 5
+	refreshStarted := make(chan struct{})
+	releaseRefresh := make(chan struct{})
+
+	// Every caller resolves the default integration, they share the read and refresh of its tokens
+	integrationRepo.EXPECT().GetByUserID(gomock.Any(), integration.UserID).Return(integration, nil).Times(callers)
+	integrationRepo.EXPECT().GetByID(gomock.Any(), integration.ID, integration.UserID).Return(integration, nil).Times(1)
+	spotifyClient.EXPECT().
+		RefreshTokens(gomock.Any(), "refresh_token_123").
+		DoAndReturn(func(ctx context.Context, refreshToken string) (*spotifyclient.SpotifyTokenResponse, error) {
+			close(refreshStarted)
+			<-releaseRefresh
+			return &spotifyclient.SpotifyTokenResponse{AccessToken: "new_access", RefreshToken: "new_refresh", ExpiresIn: 3600}, nil
+		}).
+		Times(1)
+	integrationRepo.EXPECT().UpdateTokens(gomock.Any(), integration.ID, gomock.Any()).Return(nil).Times(1)
+
+	var wg sync.WaitGroup
+	accessTokens := make(chan string, callers)
+	startCaller := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, err := tokenManager.ContextWithSpotifyAuth(context.Background(), integration.UserID)
+			assert.NoError(err)
+			spotifyAuth, _ := requestcontext.GetSpotifyAuthFromContext(ctx)
+			accessTokens <- spotifyAuth.AccessToken
+		}()
+	}
+
+	startCaller()
+	<-refreshStarted
+	for i := 1; i < callers; i++ {
+		startCaller()
+	}
+
+	// Give the other callers time to join the refresh in flight
+	time.Sleep(20 * time.Millisecond)
+	close(releaseRefresh)
+	wg.Wait()
+	close(accessTokens)
+
+	for accessToken := range accessTokens {
+		assert.Equal("new_access", accessToken)
+	}
+}
+
+func TestSpotifyTokenManager_ContextWithSpotifyAccount_OtherUserNeverJoinsRefresh(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, spotifyClient := setupSpotifyTokenManager(t)
+
+	integration := testfixtures.NewSpotifyIntegration().
+		WithTokens("access_token_123", "refresh_token_123").
+		WithExpiresAt(time.Now()).
+		Build()
+
+	refreshStarted := make(chan struct{})
+	releaseRefresh := make(chan struct{})
+
+	integrationRepo.EXPECT().GetByID(gomock.Any(), integration.ID, integration.UserID).Return(integration, nil)
+	integrationRepo.EXPECT().GetByID(gomock.Any(), integration.ID, "attacker").Return(nil, repositories.ErrSpotifyIntegrationNotFound)
+	spotifyClient.EXPECT().
+		RefreshTokens(gomock.Any(), "refresh_token_123").
+		DoAndReturn(func(ctx context.Context, refreshToken string) (*spotifyclient.SpotifyTokenResponse, error) {
+			close(refreshStarted)
+			<-releaseRefresh
+			return &spotifyclient.SpotifyTokenResponse{AccessToken: "new_access", ExpiresIn: 3600}, nil
+		})
+	integrationRepo.EXPECT().UpdateTokens(gomock.Any(), integration.ID, gomock.Any()).Return(nil)
+
+	ownerDone := make(chan error, 1)
+	go func() {
+		_, err := tokenManager.ContextWithSpotifyAccount(context.Background(), integration.UserID, integration.ID)
+		ownerDone <- err
+	}()
+	<-refreshStarted
+
+	// The other user names the integration while the owner's refresh is in flight, joining it would
+	// block until the refresh is released and hand over the owner's tokens
+	attackerDone := make(chan error, 1)
+	go func() {
+		_, err := tokenManager.ContextWithSpotifyAccount(context.Background(), "attacker", integration.ID)
+		attackerDone <- err
+	}()
+
+	select {
+	case err := <-attackerDone:
+		assert.ErrorIs(err, ErrSpotifyIntegrationUnavailable)
+	case <-time.After(time.Second):
+		assert.Fail("the other user joined the refresh in flight")
+	}
+
+	close(releaseRefresh)
+	assert.NoError(<-ownerDone)
+}
+
+func TestSpotifyTokenManager_RefreshExpiringTokens(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, spotifyClient := setupSpotifyTokenManager(t)
+
+	expiring := testfixtures.NewSpotifyIntegration().WithID("integration1").WithUserID("user1").WithTokens("access1", "refresh1").WithExpiresAt(time.Now().Add(30 * time.Minute)).Build()
+	refreshedMeanwhile := testfixtures.NewSpotifyIntegration().WithID("integration2").WithUserID("user2").WithTokens("access2", "refresh2").WithExpiresAt(time.Now().Add(40 * time.Minute)).Build()
+	failing := testfixtures.NewSpotifyIntegration().WithID("integration3").WithUserID("user3").WithTokens("access3", "refresh3").WithExpiresAt(time.Now().Add(-time.Minute)).Build()
+
+	integrationRepo.EXPECT().
+		GetExpiringBefore(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, before time.Time) ([]*models.SpotifyIntegration, error) {
+			assert.WithinDuration(time.Now().Add(TOKEN_REFRESH_JOB_WINDOW), before, time.Minute)
+			return []*models.SpotifyIntegration{expiring, refreshedMeanwhile, failing}, nil
+		})
+
+	// Each integration is read again before refreshing
+	integrationRepo.EXPECT().GetByID(gomock.Any(), "integration1", "user1").Return(expiring, nil)
+	spotifyClient.EXPECT().RefreshTokens(gomock.Any(), "refresh1").
+		Return(&spotifyclient.SpotifyTokenResponse{AccessToken: "new_access1", ExpiresIn: 3600}, nil)
+	integrationRepo.EXPECT().UpdateTokens(gomock.Any(), "integration1", gomock.Any()).Return(nil)
+
+	renewed := *refreshedMeanwhile
+	renewed.ExpiresAt = time.Now().Add(2 * time.Hour)
+	integrationRepo.EXPECT().GetByID(gomock.Any(), "integration2", "user2").Return(&renewed, nil)
+
+	integrationRepo.EXPECT().GetByID(gomock.Any(), "integration3", "user3").Return(failing, nil)
+	spotifyClient.EXPECT().RefreshTokens(gomock.Any(), "refresh3").Return(nil, errors.New("invalid_grant"))
+
+	report, err := tokenManager.RefreshExpiringTokens(context.Background(), TOKEN_REFRESH_JOB_WINDOW)
+	assert.NoError(err)
+	assert.Equal(&models.TokenRefreshReport{Checked: 3, Refreshed: 1, Failed: 1}, report)
+}
+
+func TestSpotifyTokenManager_RefreshExpiringTokens_ListError(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, _ := setupSpotifyTokenManager(t)
+
+	integrationRepo.EXPECT().GetExpiringBefore(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	report, err := tokenManager.RefreshExpiringTokens(context.Background(), TOKEN_REFRESH_JOB_WINDOW)
+	assert.Error(err)
+	assert.Nil(report)
+}
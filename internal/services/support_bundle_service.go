@@ -0,0 +1,211 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=support_bundle_service.go -destination=mocks/mock_support_bundle_service.go -package=mocks
+
+const (
+	SUPPORT_BUNDLE_LOG_LIMIT         = 500
+	SUPPORT_BUNDLE_FAILED_SYNC_LIMIT = 50
+
+	pocketbaseModulePath = "github.com/pocketbase/pocketbase"
+)
+
+// secretKeyMarkers flag the structured fields whose values are always credentials
+var secretKeyMarkers = []string{"token", "secret", "password", "authorization", "api_key", "cookie"}
+
+// secretPatterns match credentials embedded in free text such as log messages or errors,
+// keeping the scheme or parameter name so the context stays readable
+var secretPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/=]+`), "$1 " + config.RedactedValue},
+	{regexp.MustCompile(`(?i)\b(access_token|refresh_token|client_secret|code|token|password)=[^&\s"]+`), "$1=" + config.RedactedValue},
+	{regexp.MustCompile(`\b` + API_KEY_PREFIX + `[A-Za-z0-9_\-]+`), config.RedactedValue},
+}
+
+type SupportBundleServicer interface {
+	WriteBundle(ctx context.Context, w io.Writer) error
+}
+
+type SupportBundleService struct {
+	diagnosticsRepo repositories.DiagnosticsRepository
+	syncEventRepo   repositories.SyncEventRepository
+	config          *config.Config
+	logger          *slog.Logger
+}
+
+func NewSupportBundleService(
+	diagnosticsRepo repositories.DiagnosticsRepository,
+	syncEventRepo repositories.SyncEventRepository,
+	cfg *config.Config,
+	logger *slog.Logger,
+) *SupportBundleService {
+	return &SupportBundleService{
+		diagnosticsRepo: diagnosticsRepo,
+		syncEventRepo:   syncEventRepo,
+		config:          cfg,
+		logger:          logger.With("component", "SupportBundleService"),
+	}
+}
+
+// WriteBundle writes a zip archive with the redacted config, recent logs, schema versions,
+// recent failed sync events and environment info. A section that can't be collected is
+// listed in errors.json instead of failing the whole bundle.
+func (sbService *SupportBundleService) WriteBundle(ctx context.Context, w io.Writer) error {
+	sbService.logger.InfoContext(ctx, "generating support bundle")
+
+	collectErrors := map[string]string{}
+	sections := []struct {
+		file    string
+		collect func() (any, error)
+	}{
+		{"config.json", func() (any, error) { return sbService.config.Redacted(), nil }},
+		{"environment.json", func() (any, error) { return sbService.environmentInfo(), nil }},
+		{"schema.json", func() (any, error) { return sbService.diagnosticsRepo.GetSchemaVersions(ctx) }},
+		{"logs.json", func() (any, error) { return sbService.diagnosticsRepo.GetRecentLogs(ctx, SUPPORT_BUNDLE_LOG_LIMIT) }},
+		{"failed_sync_events.json", func() (any, error) {
+			return sbService.syncEventRepo.GetRecentFailed(ctx, SUPPORT_BUNDLE_FAILED_SYNC_LIMIT)
+		}},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, section := range sections {
+		content, err := section.collect()
+		if err != nil {
+			sbService.logger.WarnContext(ctx, "failed to collect support bundle section", "file", section.file, "error", err.Error())
+			collectErrors[section.file] = err.Error()
+			continue
+		}
+
+		if err := sbService.writeJSON(archive, section.file, content); err != nil {
+			return err
+		}
+	}
+
+	if len(collectErrors) > 0 {
+		if err := sbService.writeJSON(archive, "errors.json", collectErrors); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	sbService.logger.InfoContext(ctx, "support bundle generated", "failed_sections", len(collectErrors))
+	return nil
+}
+
+// writeJSON scrubs the content of secrets and stores it as an indented json file of the archive
+func (sbService *SupportBundleService) writeJSON(archive *zip.Writer, file string, content any) error {
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", file, err)
+	}
+
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", file, err)
+	}
+
+	fileWriter, err := archive.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	// Logs are read by humans, so urls and queries are kept unescaped
+	encoder := json.NewEncoder(fileWriter)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(sbService.scrub(decoded)); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	return nil
+}
+
+// scrub masks the values of secret fields and the credentials found in any text
+func (sbService *SupportBundleService) scrub(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if text, ok := field.(string); ok && text != "" && isSecretKey(key) {
+				v[key] = config.RedactedValue
+				continue
+			}
+			v[key] = sbService.scrub(field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = sbService.scrub(item)
+		}
+		return v
+	case string:
+		return sbService.scrubText(v)
+	default:
+		return v
+	}
+}
+
+func (sbService *SupportBundleService) scrubText(text string) string {
+	for _, secret := range sbService.config.Secrets() {
+		text = strings.ReplaceAll(text, secret, config.RedactedValue)
+	}
+
+	for _, secret := range secretPatterns {
+		text = secret.pattern.ReplaceAllString(text, secret.replacement)
+	}
+
+	return text
+}
+
+func (sbService *SupportBundleService) environmentInfo() *models.EnvironmentInfo {
+	info := &models.EnvironmentInfo{
+		AppEnv:      sbService.config.AppEnv,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		NumCPU:      runtime.NumCPU(),
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range buildInfo.Deps {
+			if dep.Path == pocketbaseModulePath {
+				info.PocketBaseVersion = dep.Version
+			}
+		}
+	}
+
+	return info
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func createSupportBundleTestConfig() *config.Config {
+	return &config.Config{
+		AppEnv:        "prod",
+		AdminEmail:    "admin@example.com",
+		AdminPassword: "admin-password",
+		Auth: config.AuthConfig{
+			SpotifyClientID:        "client-id",
+			SpotifyClientSecret:    "client-secret-value",
+			EncryptionKey:          "master-key-value",
+			EncryptionKeyVersion:   2,
+			PreviousEncryptionKeys: map[int]string{1: "retired-key-value"},
+		},
+	}
+}
+
+func readBundle(t *testing.T, bundle *bytes.Buffer) map[string]string {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(bundle.Bytes()), int64(bundle.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, file := range reader.File {
+		content, err := file.Open()
+		require.NoError(t, err)
+
+		raw, err := io.ReadAll(content)
+		require.NoError(t, err)
+		require.NoError(t, content.Close())
+
+		files[file.Name] = string(raw)
+	}
+
+	return files
+}
+
+func TestSupportBundleService_WriteBundle(t *testing.T) {
+	assert := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockDiagnosticsRepo := mocks.NewMockDiagnosticsRepository(ctrl)
+	mockSyncEventRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSupportBundleService(mockDiagnosticsRepo, mockSyncEventRepo, createSupportBundleTestConfig(), createTestLogger())
+
+	mockDiagnosticsRepo.EXPECT().GetSchemaVersions(gomock.Any()).Return(&models.SchemaVersions{
+		Collections: []models.CollectionVersion{{Name: "sync_events", Fields: 12}},
+	}, nil)
+	mockDiagnosticsRepo.EXPECT().GetRecentLogs(gomock.Any(), SUPPORT_BUNDLE_LOG_LIMIT).Return([]*models.LogEntry{
+		{
+			ID:      "log1",
+			Message: "GET /auth/spotify/callback?code=auth-code-value&state=abc",
+			Data: map[string]any{
+				"access_token": "spotify-access-token",
+				"header":       "Bearer eyJhbGciOi.payload.sig",
+				"error":        "invalid client secret client-secret-value",
+				"user_id":      "user123",
+			},
+		},
+	}, nil)
+	mockSyncEventRepo.EXPECT().GetRecentFailed(gomock.Any(), SUPPORT_BUNDLE_FAILED_SYNC_LIMIT).Return([]*models.SyncEvent{
+		testfixtures.NewSyncEvent().WithID("sync1").WithUserID("user123").Failed("hook called with prk_abcdef123456").Build(),
+	}, nil)
+
+	var bundle bytes.Buffer
+	err := service.WriteBundle(context.Background(), &bundle)
+	assert.NoError(err)
+
+	files := readBundle(t, &bundle)
+	assert.Len(files, 5)
+	assert.NotContains(files, "errors.json")
+
+	for name, content := range files {
+		for _, secret := range []string{
+			"admin-password", "client-secret-value", "master-key-value", "retired-key-value",
+			"auth-code-value", "spotify-access-token", "eyJhbGciOi", "prk_abcdef123456",
+		} {
+			assert.NotContains(content, secret, "%s leaks a secret", name)
+		}
+	}
+
+	var redactedConfig map[string]any
+	assert.NoError(json.Unmarshal([]byte(files["config.json"]), &redactedConfig))
+	assert.Equal("client-id", redactedConfig["SPOTIFY_CLIENT_ID"])
+	assert.Equal(config.RedactedValue, redactedConfig["SPOTIFY_CLIENT_SECRET"])
+	assert.Equal(float64(2), redactedConfig["ENCRYPTION_KEY_VERSION"])
+	// Unset secrets stay empty so their absence is visible
+	assert.Equal("", redactedConfig["INTERNAL_API_TOKEN"])
+
+	assert.Contains(files["logs.json"], "code=[REDACTED]&state=abc")
+	assert.Contains(files["logs.json"], "Bearer [REDACTED]")
+	assert.Contains(files["logs.json"], "user123")
+	assert.Contains(files["failed_sync_events.json"], "sync1")
+	assert.Contains(files["schema.json"], "sync_events")
+	assert.Contains(files["environment.json"], `"app_env": "prod"`)
+}
+
+func TestSupportBundleService_WriteBundle_SectionErrors(t *testing.T) {
+	assert := require.New(t)
+	ctrl := setupMockController(t)
+
+	mockDiagnosticsRepo := mocks.NewMockDiagnosticsRepository(ctrl)
+	mockSyncEventRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSupportBundleService(mockDiagnosticsRepo, mockSyncEventRepo, createSupportBundleTestConfig(), createTestLogger())
+
+	mockDiagnosticsRepo.EXPECT().GetSchemaVersions(gomock.Any()).Return(&models.SchemaVersions{}, nil)
+	mockDiagnosticsRepo.EXPECT().GetRecentLogs(gomock.Any(), SUPPORT_BUNDLE_LOG_LIMIT).Return(nil, errors.New("logs db locked"))
+	mockSyncEventRepo.EXPECT().GetRecentFailed(gomock.Any(), SUPPORT_BUNDLE_FAILED_SYNC_LIMIT).Return(nil, errors.New("db error"))
+
+	var bundle bytes.Buffer
+	err := service.WriteBundle(context.Background(), &bundle)
+	assert.NoError(err)
+
+	files := readBundle(t, &bundle)
+	assert.NotContains(files, "logs.json")
+	assert.NotContains(files, "failed_sync_events.json")
+	assert.Contains(files, "config.json")
+
+	var collectErrors map[string]string
+	assert.NoError(json.Unmarshal([]byte(files["errors.json"]), &collectErrors))
+	assert.Equal(map[string]string{
+		"logs.json":               "logs db locked",
+		"failed_sync_events.json": "db error",
+	}, collectErrors)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
@@ -15,8 +16,15 @@ type SyncEventServicer interface {
 	CreateSyncEvent(ctx context.Context, syncEvent *models.SyncEvent) (*models.SyncEvent, error)
 	UpdateSyncEvent(ctx context.Context, id string, syncEvent *models.SyncEvent) (*models.SyncEvent, error)
 	GetSyncEvent(ctx context.Context, id string) (*models.SyncEvent, error)
+	GetUserSyncEvent(ctx context.Context, userID, id string) (*models.SyncEvent, error)
+	TransitionSyncEventStatus(ctx context.Context, id string, from, to models.SyncStatus) error
 	HasActiveSyncForBasePlaylist(ctx context.Context, userID, basePlaylistID string) (bool, error)
 	HasActiveSyncForUser(ctx context.Context, userID string) (bool, error)
+	GetLastCompletedSyncEvent(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error)
+	GetRecentCompletedSyncEvents(ctx context.Context, userID string, limit int) ([]*models.SyncEvent, error)
+	ListSyncEvents(ctx context.Context, userID string, filter repositories.SyncEventFilter) (*models.SyncEventPage, error)
+	PruneSyncEvents(ctx context.Context, userID, basePlaylistID string, olderThan time.Duration) (int, error)
+	PurgeExpiredSyncEvents(ctx context.Context, retention time.Duration) (int, error)
 }
 
 type SyncEventService struct {
@@ -73,6 +81,34 @@ func (seService *SyncEventService) GetSyncEvent(ctx context.Context, id string)
 	return syncEvent, nil
 }
 
+// GetUserSyncEvent returns the sync event when it belongs to the user. Sync events of other users
+// are reported as not found
+func (seService *SyncEventService) GetUserSyncEvent(ctx context.Context, userID, id string) (*models.SyncEvent, error) {
+	syncEvent, err := seService.GetSyncEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if syncEvent.UserID != userID {
+		seService.logger.WarnContext(ctx, "sync event belongs to another user", "sync_event_id", id, "user_id", userID)
+		return nil, repositories.ErrSyncEventNotFound
+	}
+
+	return syncEvent, nil
+}
+
+// TransitionSyncEventStatus moves the sync event to another status only while it is still in the from status
+func (seService *SyncEventService) TransitionSyncEventStatus(ctx context.Context, id string, from, to models.SyncStatus) error {
+	seService.logger.InfoContext(ctx, "transitioning sync event status", "sync_event_id", id, "from", from, "to", to)
+
+	if err := seService.syncEventRepo.TransitionStatus(ctx, id, from, to); err != nil {
+		seService.logger.ErrorContext(ctx, "failed to transition sync event status", "sync_event_id", id, "error", err.Error())
+		return fmt.Errorf("failed to transition sync event status: %w", err)
+	}
+
+	return nil
+}
+
 func (seService *SyncEventService) HasActiveSyncForBasePlaylist(ctx context.Context, userID, basePlaylistID string) (bool, error) {
 	seService.logger.InfoContext(ctx, "checking for active sync", "user_id", userID, "base_playlist_id", basePlaylistID)
 
@@ -113,3 +149,105 @@ func (seService *SyncEventService) HasActiveSyncForUser(ctx context.Context, use
 	seService.logger.InfoContext(ctx, "no active sync found", "user_id", userID)
 	return false, nil
 }
+
+// GetLastCompletedSyncEvent returns the most recent completed sync of the base playlist,
+// or nil when it was never synced successfully
+func (seService *SyncEventService) GetLastCompletedSyncEvent(ctx context.Context, userID, basePlaylistID string) (*models.SyncEvent, error) {
+	seService.logger.InfoContext(ctx, "retrieving last completed sync event", "user_id", userID, "base_playlist_id", basePlaylistID)
+
+	syncEvents, err := seService.syncEventRepo.GetByBasePlaylistID(ctx, basePlaylistID)
+	if err != nil {
+		seService.logger.ErrorContext(ctx, "failed to get sync events for base playlist", "user_id", userID, "base_playlist_id", basePlaylistID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve sync events: %w", err)
+	}
+
+	// Sync events are sorted newest first
+	for _, syncEvent := range syncEvents {
+		if syncEvent.UserID == userID && syncEvent.Status == models.SyncStatusCompleted {
+			return syncEvent, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetRecentCompletedSyncEvents returns up to limit completed syncs of the user across all their
+// base playlists, newest first
+func (seService *SyncEventService) GetRecentCompletedSyncEvents(ctx context.Context, userID string, limit int) ([]*models.SyncEvent, error) {
+	syncEvents, err := seService.syncEventRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		seService.logger.ErrorContext(ctx, "failed to get sync events for user", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to retrieve sync events: %w", err)
+	}
+
+	// Sync events are sorted newest first
+	completed := make([]*models.SyncEvent, 0, limit)
+	for _, syncEvent := range syncEvents {
+		if len(completed) == limit {
+			break
+		}
+		if syncEvent.Status == models.SyncStatusCompleted {
+			completed = append(completed, syncEvent)
+		}
+	}
+
+	return completed, nil
+}
+
+// ListSyncEvents returns a page of the sync events of the user matching the filter, along with how
+// many match across every page
+func (seService *SyncEventService) ListSyncEvents(ctx context.Context, userID string, filter repositories.SyncEventFilter) (*models.SyncEventPage, error) {
+	filter.UserID = userID
+	filter.Limit = pageSize(filter.Limit)
+	filter.Offset = max(filter.Offset, 0)
+
+	syncEvents, err := seService.syncEventRepo.List(ctx, filter)
+	if err != nil {
+		seService.logger.ErrorContext(ctx, "failed to list sync events for user", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to list sync events: %w", err)
+	}
+
+	total, err := seService.syncEventRepo.Count(ctx, filter)
+	if err != nil {
+		seService.logger.ErrorContext(ctx, "failed to count sync events for user", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to count sync events: %w", err)
+	}
+
+	return &models.SyncEventPage{
+		Items:    syncEvents,
+		PageInfo: models.PageInfo{Total: total, Limit: filter.Limit, Offset: filter.Offset},
+	}, nil
+}
+
+// PruneSyncEvents deletes the finished sync events of the user created longer than olderThan ago,
+// only those of basePlaylistID when set. The latest sync and the latest completed sync of every
+// base playlist are kept, so their status and rollback keep working
+func (seService *SyncEventService) PruneSyncEvents(ctx context.Context, userID, basePlaylistID string, olderThan time.Duration) (int, error) {
+	pruned, err := seService.syncEventRepo.Prune(ctx, repositories.SyncEventPruneFilter{
+		UserID:         userID,
+		BasePlaylistID: basePlaylistID,
+		CreatedBefore:  time.Now().Add(-olderThan),
+	})
+	if err != nil {
+		seService.logger.ErrorContext(ctx, "failed to prune sync events for user", "user_id", userID, "base_playlist_id", basePlaylistID, "error", err.Error())
+		return pruned, fmt.Errorf("failed to prune sync events: %w", err)
+	}
+
+	seService.logger.InfoContext(ctx, "pruned sync events for user", "user_id", userID, "base_playlist_id", basePlaylistID, "pruned", pruned)
+	return pruned, nil
+}
+
+// PurgeExpiredSyncEvents deletes the finished sync events of every user created longer than
+// retention ago, keeping the same sync events as PruneSyncEvents
+func (seService *SyncEventService) PurgeExpiredSyncEvents(ctx context.Context, retention time.Duration) (int, error) {
+	pruned, err := seService.syncEventRepo.Prune(ctx, repositories.SyncEventPruneFilter{
+		CreatedBefore: time.Now().Add(-retention),
+	})
+	if err != nil {
+		seService.logger.ErrorContext(ctx, "failed to purge expired sync events", "error", err.Error())
+		return pruned, fmt.Errorf("failed to purge sync events: %w", err)
+	}
+
+	seService.logger.InfoContext(ctx, "purged expired sync events", "pruned", pruned)
+	return pruned, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,30 +44,24 @@ func TestSyncEventService_CreateSyncEvent_Success(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
 
-	inputSyncEvent := &models.SyncEvent{
-		UserID:           "user123",
-		BasePlaylistID:   "base123",
-		ChildPlaylistIDs: []string{"child1", "child2"},
-		Status:           models.SyncStatusInProgress,
-		StartedAt:        now,
-		TracksProcessed:  0,
-		TotalAPIRequests: 0,
-		Created:          now,
-		Updated:          now,
-	}
-
-	expectedSyncEvent := &models.SyncEvent{
-		ID:               "sync123",
-		UserID:           "user123",
-		BasePlaylistID:   "base123",
-		ChildPlaylistIDs: []string{"child1", "child2"},
-		Status:           models.SyncStatusInProgress,
-		StartedAt:        now,
-		TracksProcessed:  0,
-		TotalAPIRequests: 0,
-		Created:          now,
-		Updated:          now,
-	}
+	inputSyncEvent := testfixtures.NewSyncEvent().
+		WithUserID("user123").
+		WithBasePlaylistID("base123").
+		WithChildPlaylistIDs("child1", "child2").
+		WithStatus(models.SyncStatusInProgress).
+		WithStartedAt(now).
+		WithStats(0, 0, 0).
+		Build()
+
+	expectedSyncEvent := testfixtures.NewSyncEvent().
+		WithID("sync123").
+		WithUserID("user123").
+		WithBasePlaylistID("base123").
+		WithChildPlaylistIDs("child1", "child2").
+		WithStatus(models.SyncStatusInProgress).
+		WithStartedAt(now).
+		WithStats(0, 0, 0).
+		Build()
 
 	// Set expectations
 	mockRepo.EXPECT().
@@ -97,16 +92,13 @@ func TestSyncEventService_CreateSyncEvent_Error(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
 
-	inputSyncEvent := &models.SyncEvent{
-		UserID:           "user123",
-		BasePlaylistID:   "base123",
-		Status:           models.SyncStatusInProgress,
-		StartedAt:        now,
-		TracksProcessed:  0,
-		TotalAPIRequests: 0,
-		Created:          now,
-		Updated:          now,
-	}
+	inputSyncEvent := testfixtures.NewSyncEvent().
+		WithUserID("user123").
+		WithBasePlaylistID("base123").
+		WithStatus(models.SyncStatusInProgress).
+		WithStartedAt(now).
+		WithStats(0, 0, 0).
+		Build()
 
 	// Set expectations
 	mockRepo.EXPECT().
@@ -139,22 +131,16 @@ func TestSyncEventService_UpdateSyncEvent_Success(t *testing.T) {
 	syncID := "sync123"
 	now := time.Now()
 
-	updateSyncEvent := &models.SyncEvent{
-		Status:           models.SyncStatusCompleted,
-		TracksProcessed:  100,
-		TotalAPIRequests: 25,
-		CompletedAt:      &now,
-	}
+	updateSyncEvent := testfixtures.NewSyncEvent().WithStatus(models.SyncStatusCompleted).WithStats(100, 0, 25).WithCompletedAt(now).Build()
 
-	expectedSyncEvent := &models.SyncEvent{
-		ID:               syncID,
-		UserID:           "user123",
-		BasePlaylistID:   "base123",
-		Status:           models.SyncStatusCompleted,
-		TracksProcessed:  100,
-		TotalAPIRequests: 25,
-		CompletedAt:      &now,
-	}
+	expectedSyncEvent := testfixtures.NewSyncEvent().
+		WithID(syncID).
+		WithUserID("user123").
+		WithBasePlaylistID("base123").
+		WithStatus(models.SyncStatusCompleted).
+		WithStats(100, 0, 25).
+		WithCompletedAt(now).
+		Build()
 
 	// Set expectations
 	mockRepo.EXPECT().
@@ -185,9 +171,7 @@ func TestSyncEventService_UpdateSyncEvent_Error(t *testing.T) {
 	ctx := context.Background()
 	syncID := "nonexistent"
 
-	updateSyncEvent := &models.SyncEvent{
-		Status: models.SyncStatusCompleted,
-	}
+	updateSyncEvent := testfixtures.NewSyncEvent().WithStatus(models.SyncStatusCompleted).Build()
 
 	// Set expectations
 	mockRepo.EXPECT().
@@ -219,14 +203,13 @@ func TestSyncEventService_GetSyncEvent_Success(t *testing.T) {
 	ctx := context.Background()
 	syncID := "sync123"
 
-	expectedSyncEvent := &models.SyncEvent{
-		ID:               syncID,
-		UserID:           "user123",
-		BasePlaylistID:   "base123",
-		Status:           models.SyncStatusCompleted,
-		TracksProcessed:  100,
-		TotalAPIRequests: 25,
-	}
+	expectedSyncEvent := testfixtures.NewSyncEvent().
+		WithID(syncID).
+		WithUserID("user123").
+		WithBasePlaylistID("base123").
+		WithStatus(models.SyncStatusCompleted).
+		WithStats(100, 0, 25).
+		Build()
 
 	// Set expectations
 	mockRepo.EXPECT().
@@ -243,6 +226,73 @@ func TestSyncEventService_GetSyncEvent_Success(t *testing.T) {
 	require.Equal(expectedSyncEvent, result)
 }
 
+func TestSyncEventService_GetUserSyncEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		userID        string
+		repoErr       error
+		expectedError error
+	}{
+		{name: "owned by the user", userID: "user123"},
+		{name: "owned by another user", userID: "user456", expectedError: repositories.ErrSyncEventNotFound},
+		{name: "not found", userID: "user123", repoErr: repositories.ErrSyncEventNotFound, expectedError: repositories.ErrSyncEventNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+			service := NewSyncEventService(mockRepo, createTestLogger())
+
+			ctx := context.Background()
+			syncEvent := testfixtures.NewSyncEvent().WithID("sync123").WithUserID("user123").Build()
+			if tt.repoErr != nil {
+				mockRepo.EXPECT().GetByID(ctx, "sync123").Return(nil, tt.repoErr)
+			} else {
+				mockRepo.EXPECT().GetByID(ctx, "sync123").Return(syncEvent, nil)
+			}
+
+			result, err := service.GetUserSyncEvent(ctx, tt.userID, "sync123")
+
+			if tt.expectedError != nil {
+				require.ErrorIs(err, tt.expectedError)
+				require.Nil(result)
+				return
+			}
+			require.NoError(err)
+			require.Equal(syncEvent, result)
+		})
+	}
+}
+
+func TestSyncEventService_TransitionSyncEventStatus(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		TransitionStatus(ctx, "sync123", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed).
+		Return(nil)
+	err := service.TransitionSyncEventStatus(ctx, "sync123", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed)
+	require.NoError(err)
+
+	mockRepo.EXPECT().
+		TransitionStatus(ctx, "sync123", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed).
+		Return(repositories.ErrSyncEventStatusChanged)
+	err = service.TransitionSyncEventStatus(ctx, "sync123", models.SyncStatusNeedsConfirmation, models.SyncStatusConfirmed)
+	require.ErrorIs(err, repositories.ErrSyncEventStatusChanged)
+}
+
 func TestSyncEventService_GetSyncEvent_Error(t *testing.T) {
 	require := require.New(t)
 
@@ -286,18 +336,18 @@ func TestSyncEventService_HasActiveSyncForBasePlaylist_Success(t *testing.T) {
 			userID:         "user123",
 			basePlaylistID: "base123",
 			syncEvents: []*models.SyncEvent{
-				{
-					ID:             "sync1",
-					UserID:         "user123",
-					BasePlaylistID: "base123",
-					Status:         models.SyncStatusInProgress,
-				},
-				{
-					ID:             "sync2",
-					UserID:         "user123",
-					BasePlaylistID: "base123",
-					Status:         models.SyncStatusCompleted,
-				},
+				testfixtures.NewSyncEvent().
+					WithID("sync1").
+					WithUserID("user123").
+					WithBasePlaylistID("base123").
+					WithStatus(models.SyncStatusInProgress).
+					Build(),
+				testfixtures.NewSyncEvent().
+					WithID("sync2").
+					WithUserID("user123").
+					WithBasePlaylistID("base123").
+					WithStatus(models.SyncStatusCompleted).
+					Build(),
 			},
 			expected: true,
 		},
@@ -306,12 +356,12 @@ func TestSyncEventService_HasActiveSyncForBasePlaylist_Success(t *testing.T) {
 			userID:         "user123",
 			basePlaylistID: "base123",
 			syncEvents: []*models.SyncEvent{
-				{
-					ID:             "sync1",
-					UserID:         "user456",
-					BasePlaylistID: "base123",
-					Status:         models.SyncStatusInProgress,
-				},
+				testfixtures.NewSyncEvent().
+					WithID("sync1").
+					WithUserID("user456").
+					WithBasePlaylistID("base123").
+					WithStatus(models.SyncStatusInProgress).
+					Build(),
 			},
 			expected: false,
 		},
@@ -320,18 +370,18 @@ func TestSyncEventService_HasActiveSyncForBasePlaylist_Success(t *testing.T) {
 			userID:         "user123",
 			basePlaylistID: "base123",
 			syncEvents: []*models.SyncEvent{
-				{
-					ID:             "sync1",
-					UserID:         "user123",
-					BasePlaylistID: "base123",
-					Status:         models.SyncStatusCompleted,
-				},
-				{
-					ID:             "sync2",
-					UserID:         "user123",
-					BasePlaylistID: "base123",
-					Status:         models.SyncStatusFailed,
-				},
+				testfixtures.NewSyncEvent().
+					WithID("sync1").
+					WithUserID("user123").
+					WithBasePlaylistID("base123").
+					WithStatus(models.SyncStatusCompleted).
+					Build(),
+				testfixtures.NewSyncEvent().
+					WithID("sync2").
+					WithUserID("user123").
+					WithBasePlaylistID("base123").
+					WithStatus(models.SyncStatusFailed).
+					Build(),
 			},
 			expected: false,
 		},
@@ -416,16 +466,8 @@ func TestSyncEventService_HasActiveSyncForUser_Success(t *testing.T) {
 			name:   "has active sync for user",
 			userID: "user123",
 			syncEvents: []*models.SyncEvent{
-				{
-					ID:     "sync1",
-					UserID: "user123",
-					Status: models.SyncStatusInProgress,
-				},
-				{
-					ID:     "sync2",
-					UserID: "user123",
-					Status: models.SyncStatusCompleted,
-				},
+				testfixtures.NewSyncEvent().WithID("sync1").WithUserID("user123").WithStatus(models.SyncStatusInProgress).Build(),
+				testfixtures.NewSyncEvent().WithID("sync2").WithUserID("user123").WithStatus(models.SyncStatusCompleted).Build(),
 			},
 			expected: true,
 		},
@@ -433,16 +475,8 @@ func TestSyncEventService_HasActiveSyncForUser_Success(t *testing.T) {
 			name:   "no active sync - all completed/failed",
 			userID: "user123",
 			syncEvents: []*models.SyncEvent{
-				{
-					ID:     "sync1",
-					UserID: "user123",
-					Status: models.SyncStatusCompleted,
-				},
-				{
-					ID:     "sync2",
-					UserID: "user123",
-					Status: models.SyncStatusFailed,
-				},
+				testfixtures.NewSyncEvent().WithID("sync1").WithUserID("user123").WithStatus(models.SyncStatusCompleted).Build(),
+				testfixtures.NewSyncEvent().WithID("sync2").WithUserID("user123").WithStatus(models.SyncStatusFailed).Build(),
 			},
 			expected: false,
 		},
@@ -512,3 +546,275 @@ func TestSyncEventService_HasActiveSyncForUser_Error(t *testing.T) {
 	require.False(result)
 	require.Contains(err.Error(), "failed to check for active sync")
 }
+
+func TestSyncEventService_GetLastCompletedSyncEvent_Success(t *testing.T) {
+	tests := []struct {
+		name       string
+		syncEvents []*models.SyncEvent
+		expectedID string
+	}{
+		{
+			name: "returns newest completed sync of the user",
+			syncEvents: []*models.SyncEvent{
+				testfixtures.NewSyncEvent().WithID("sync1").WithUserID("user123").WithStatus(models.SyncStatusNeedsConfirmation).Build(),
+				testfixtures.NewSyncEvent().WithID("sync2").WithUserID("user456").WithStatus(models.SyncStatusCompleted).Build(),
+				testfixtures.NewSyncEvent().WithID("sync3").WithUserID("user123").WithStatus(models.SyncStatusCompleted).Build(),
+				testfixtures.NewSyncEvent().WithID("sync4").WithUserID("user123").WithStatus(models.SyncStatusCompleted).Build(),
+			},
+			expectedID: "sync3",
+		},
+		{
+			name: "no completed sync",
+			syncEvents: []*models.SyncEvent{
+				testfixtures.NewSyncEvent().WithID("sync1").WithUserID("user123").WithStatus(models.SyncStatusFailed).Build(),
+			},
+		},
+		{
+			name:       "no sync events",
+			syncEvents: []*models.SyncEvent{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+			service := NewSyncEventService(mockRepo, createTestLogger())
+
+			ctx := context.Background()
+
+			mockRepo.EXPECT().
+				GetByBasePlaylistID(ctx, "base123").
+				Return(tt.syncEvents, nil).
+				Times(1)
+
+			result, err := service.GetLastCompletedSyncEvent(ctx, "user123", "base123")
+
+			require.NoError(err)
+			if tt.expectedID == "" {
+				require.Nil(result)
+				return
+			}
+			require.Equal(tt.expectedID, result.ID)
+		})
+	}
+}
+
+func TestSyncEventService_GetLastCompletedSyncEvent_Error(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		GetByBasePlaylistID(ctx, "base123").
+		Return(nil, repositories.ErrDatabaseOperation).
+		Times(1)
+
+	result, err := service.GetLastCompletedSyncEvent(ctx, "user123", "base123")
+
+	require.Nil(result)
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestSyncEventService_GetRecentCompletedSyncEvents(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		GetByUserID(ctx, "user123").
+		Return([]*models.SyncEvent{
+			testfixtures.NewSyncEvent().WithID("sync1").WithStatus(models.SyncStatusInProgress).Build(),
+			testfixtures.NewSyncEvent().WithID("sync2").WithStatus(models.SyncStatusCompleted).Build(),
+			testfixtures.NewSyncEvent().WithID("sync3").WithStatus(models.SyncStatusFailed).Build(),
+			testfixtures.NewSyncEvent().WithID("sync4").WithStatus(models.SyncStatusCompleted).Build(),
+			testfixtures.NewSyncEvent().WithID("sync5").WithStatus(models.SyncStatusCompleted).Build(),
+		}, nil).
+		Times(1)
+
+	result, err := service.GetRecentCompletedSyncEvents(ctx, "user123", 2)
+
+	require.NoError(err)
+	require.Len(result, 2)
+	require.Equal("sync2", result[0].ID)
+	require.Equal("sync4", result[1].ID)
+}
+
+func TestSyncEventService_GetRecentCompletedSyncEvents_Error(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().
+		GetByUserID(ctx, "user123").
+		Return(nil, repositories.ErrDatabaseOperation).
+		Times(1)
+
+	result, err := service.GetRecentCompletedSyncEvents(ctx, "user123", 10)
+
+	require.Nil(result)
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+}
+
+func TestSyncEventService_ListSyncEvents(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	// The user can't be overridden and unset limits fall back to the default page size
+	expectedFilter := repositories.SyncEventFilter{
+		UserID: "user123",
+		Status: models.SyncStatusFailed,
+		Limit:  DEFAULT_PAGE_SIZE,
+		Offset: 0,
+	}
+	syncEvents := []*models.SyncEvent{testfixtures.NewSyncEvent().WithID("sync1").Build()}
+	mockRepo.EXPECT().List(ctx, expectedFilter).Return(syncEvents, nil)
+	mockRepo.EXPECT().Count(ctx, expectedFilter).Return(12, nil)
+
+	page, err := service.ListSyncEvents(ctx, "user123", repositories.SyncEventFilter{
+		UserID: "other_user",
+		Status: models.SyncStatusFailed,
+		Offset: -5,
+	})
+
+	require.NoError(err)
+	require.Equal(syncEvents, page.Items)
+	require.Equal(models.PageInfo{Total: 12, Limit: DEFAULT_PAGE_SIZE, Offset: 0}, page.PageInfo)
+}
+
+func TestSyncEventService_ListSyncEvents_CapsPageSize(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	expectedFilter := repositories.SyncEventFilter{UserID: "user123", Limit: MAX_PAGE_SIZE}
+	mockRepo.EXPECT().List(ctx, expectedFilter).Return([]*models.SyncEvent{}, nil)
+	mockRepo.EXPECT().Count(ctx, expectedFilter).Return(0, nil)
+
+	page, err := service.ListSyncEvents(ctx, "user123", repositories.SyncEventFilter{Limit: 5000})
+
+	require.NoError(err)
+	require.Equal(MAX_PAGE_SIZE, page.Limit)
+}
+
+func TestSyncEventService_ListSyncEvents_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		listErr   error
+		countErr  error
+		expectErr string
+	}{
+		{name: "list fails", listErr: repositories.ErrDatabaseOperation, expectErr: "failed to list sync events"},
+		{name: "count fails", countErr: repositories.ErrDatabaseOperation, expectErr: "failed to count sync events"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+			service := NewSyncEventService(mockRepo, createTestLogger())
+
+			ctx := context.Background()
+
+			mockRepo.EXPECT().List(ctx, gomock.Any()).Return([]*models.SyncEvent{}, tt.listErr)
+			if tt.listErr == nil {
+				mockRepo.EXPECT().Count(ctx, gomock.Any()).Return(0, tt.countErr)
+			}
+
+			page, err := service.ListSyncEvents(ctx, "user123", repositories.SyncEventFilter{})
+
+			require.Nil(page)
+			require.ErrorIs(err, repositories.ErrDatabaseOperation)
+			require.ErrorContains(err, tt.expectErr)
+		})
+	}
+}
+
+func TestSyncEventService_PruneSyncEvents(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	mockRepo.EXPECT().Prune(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, filter repositories.SyncEventPruneFilter) (int, error) {
+		require.Equal("user123", filter.UserID)
+		require.Equal("base123", filter.BasePlaylistID)
+		require.WithinDuration(time.Now().Add(-30*24*time.Hour), filter.CreatedBefore, time.Minute)
+		return 4, nil
+	})
+
+	pruned, err := service.PruneSyncEvents(ctx, "user123", "base123", 30*24*time.Hour)
+
+	require.NoError(err)
+	require.Equal(4, pruned)
+}
+
+func TestSyncEventService_PurgeExpiredSyncEvents(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSyncEventRepository(ctrl)
+	service := NewSyncEventService(mockRepo, createTestLogger())
+
+	ctx := context.Background()
+
+	// Every user is purged
+	mockRepo.EXPECT().Prune(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, filter repositories.SyncEventPruneFilter) (int, error) {
+		require.Empty(filter.UserID)
+		require.Empty(filter.BasePlaylistID)
+		require.WithinDuration(time.Now().Add(-90*24*time.Hour), filter.CreatedBefore, time.Minute)
+		return 2, repositories.ErrDatabaseOperation
+	})
+
+	pruned, err := service.PurgeExpiredSyncEvents(ctx, 90*24*time.Hour)
+
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+	require.Contains(err.Error(), "failed to purge sync events")
+	require.Equal(2, pruned)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
 )
 
 func createTestLogger() *slog.Logger {
@@ -24,6 +25,10 @@ func float64ToPointer(f float64) *float64 {
 	return &f
 }
 
+func intToPointer(i int) *int {
+	return &i
+}
+
 // setupMockController creates a new gomock controller with cleanup
 func setupMockController(t *testing.T) *gomock.Controller {
 	t.Helper()
@@ -35,3 +40,15 @@ func setupMockController(t *testing.T) *gomock.Controller {
 
 	return ctrl
 }
+
+// recordingPublisher collects the published events. The generated mock can't be used from this
+// package, other mocks of the mocks package import it
+type recordingPublisher struct {
+	userIDs []string
+	events  []*models.AppEvent
+}
+
+func (p *recordingPublisher) Publish(userID string, event *models.AppEvent) {
+	p.userIDs = append(p.userIDs, userID)
+	p.events = append(p.events, event)
+}
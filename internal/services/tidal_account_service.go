@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	tidalclient "github.com/ngomez18/playlist-router/internal/clients/tidal"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=tidal_account_service.go -destination=mocks/mock_tidal_account_service.go -package=mocks
+
+type TidalAccountServicer interface {
+	GetAccount(ctx context.Context, userID string) (*models.TidalIntegration, error)
+	ListPlaylists(ctx context.Context, userID string) ([]*models.SpotifyPlaylist, error)
+	PollLink(ctx context.Context, userID string) (*models.TidalIntegration, error)
+	StartLink(ctx context.Context, userID string) (*models.TidalDeviceLink, error)
+	Unlink(ctx context.Context, userID string) error
+}
+
+// TidalAccountService links the tidal account of a user, which keeps the base and child playlists
+// living on tidal. Accounts are linked through the OAuth device flow: the user enters a code on the
+// tidal site while the frontend polls the link until tidal hands out the tokens
+type TidalAccountService struct {
+	integrationRepo   repositories.TidalIntegrationRepository
+	basePlaylistRepo  repositories.BasePlaylistRepository
+	childPlaylistRepo repositories.ChildPlaylistRepository
+	tidal             tidalclient.TidalAPI
+	logger            *slog.Logger
+
+	// pendingLinks maps the users linking an account to the device code of their link
+	pendingLinksMu sync.Mutex
+	pendingLinks   map[string]pendingTidalLink
+}
+
+type pendingTidalLink struct {
+	deviceCode string
+	expiresAt  time.Time
+}
+
+func NewTidalAccountService(
+	integrationRepo repositories.TidalIntegrationRepository,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	tidal tidalclient.TidalAPI,
+	logger *slog.Logger,
+) *TidalAccountService {
+	return &TidalAccountService{
+		integrationRepo:   integrationRepo,
+		basePlaylistRepo:  basePlaylistRepo,
+		childPlaylistRepo: childPlaylistRepo,
+		tidal:             tidal,
+		logger:            logger.With("component", "TidalAccountService"),
+		pendingLinks:      make(map[string]pendingTidalLink),
+	}
+}
+
+// StartLink starts linking a tidal account to the user, returning the code the user enters on the
+// tidal site. Starting again replaces the pending link of the user
+func (s *TidalAccountService) StartLink(ctx context.Context, userID string) (*models.TidalDeviceLink, error) {
+	authorization, err := s.tidal.StartDeviceAuthorization(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to start tidal device authorization", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to start tidal device authorization: %w", err)
+	}
+
+	now := time.Now()
+
+	s.pendingLinksMu.Lock()
+	for pendingUserID, link := range s.pendingLinks {
+		if now.After(link.expiresAt) {
+			delete(s.pendingLinks, pendingUserID)
+		}
+	}
+	s.pendingLinks[userID] = pendingTidalLink{
+		deviceCode: authorization.DeviceCode,
+		expiresAt:  now.Add(time.Duration(authorization.ExpiresIn) * time.Second),
+	}
+	s.pendingLinksMu.Unlock()
+
+	s.logger.InfoContext(ctx, "started tidal account link", "user_id", userID)
+	return &models.TidalDeviceLink{
+		UserCode:                authorization.UserCode,
+		VerificationURI:         authorization.VerificationURI,
+		VerificationURIComplete: authorization.VerificationURIComplete,
+		ExpiresIn:               authorization.ExpiresIn,
+		Interval:                authorization.Interval,
+	}, nil
+}
+
+// PollLink completes the pending link of the user once they authorized it on tidal, storing the
+// account as their integration. It returns ErrTidalLinkPending until then. Linking another account
+// replaces the previous one
+func (s *TidalAccountService) PollLink(ctx context.Context, userID string) (*models.TidalIntegration, error) {
+	link, ok := s.pendingLink(userID)
+	if !ok {
+		return nil, ErrTidalLinkInvalid
+	}
+
+	token, err := s.tidal.PollDeviceAuthorization(ctx, link.deviceCode)
+	if errors.Is(err, tidalclient.ErrAuthorizationPending) {
+		return nil, ErrTidalLinkPending
+	}
+	if errors.Is(err, tidalclient.ErrDeviceCodeExpired) {
+		s.dropPendingLink(userID)
+		return nil, ErrTidalLinkInvalid
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to poll tidal device authorization", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to poll tidal device authorization: %w", err)
+	}
+	s.dropPendingLink(userID)
+
+	integration := &models.TidalIntegration{
+		TidalUserID:  strconv.FormatInt(token.User.UserID, 10),
+		CountryCode:  token.User.CountryCode,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		ExpiresAt:    time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+		Scope:        token.Scope,
+		DisplayName:  token.User.Username,
+	}
+
+	stored, err := s.integrationRepo.CreateOrUpdate(ctx, userID, integration)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to store tidal integration", "user_id", userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to store tidal integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "tidal account linked successfully", "user_id", userID, "tidal_user_id", stored.TidalUserID)
+	return stored, nil
+}
+
+func (s *TidalAccountService) pendingLink(userID string) (pendingTidalLink, bool) {
+	s.pendingLinksMu.Lock()
+	defer s.pendingLinksMu.Unlock()
+
+	link, ok := s.pendingLinks[userID]
+	if !ok || time.Now().After(link.expiresAt) {
+		return pendingTidalLink{}, false
+	}
+
+	return link, true
+}
+
+func (s *TidalAccountService) dropPendingLink(userID string) {
+	s.pendingLinksMu.Lock()
+	defer s.pendingLinksMu.Unlock()
+
+	delete(s.pendingLinks, userID)
+}
+
+// GetAccount returns the tidal account linked by the user
+func (s *TidalAccountService) GetAccount(ctx context.Context, userID string) (*models.TidalIntegration, error) {
+	integration, err := s.integrationRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrTidalIntegrationNotFound) {
+		return nil, ErrTidalIntegrationUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tidal integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+// Unlink removes the tidal account of the user. Accounts still used by playlists must be freed
+// first, since their playlists could not be synced anymore
+func (s *TidalAccountService) Unlink(ctx context.Context, userID string) error {
+	s.logger.InfoContext(ctx, "unlinking tidal account", "user_id", userID)
+
+	basePlaylists, err := s.basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve base playlists: %w", err)
+	}
+	for _, basePlaylist := range basePlaylists {
+		if basePlaylist.Provider == models.MusicProviderTidal {
+			return ErrTidalAccountInUse
+		}
+	}
+
+	err = s.integrationRepo.Delete(ctx, userID)
+	if errors.Is(err, repositories.ErrTidalIntegrationNotFound) {
+		return ErrTidalIntegrationUnavailable
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete tidal integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "tidal account unlinked successfully", "user_id", userID)
+	return nil
+}
+
+// ListPlaylists returns the tidal playlists of the user no base or child playlist is linked to yet
+func (s *TidalAccountService) ListPlaylists(ctx context.Context, userID string) ([]*models.SpotifyPlaylist, error) {
+	s.logger.InfoContext(ctx, "fetching user playlists from tidal", "user_id", userID)
+
+	playlists, err := s.tidal.GetAllUserPlaylists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredPlaylists, err := unusedPlaylists(ctx, s.basePlaylistRepo, s.childPlaylistRepo, userID, playlists)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to filter user playlists", "user_id", userID, "error", err.Error())
+		return nil, err
+	}
+
+	return filteredPlaylists, nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	tidalclient "github.com/ngomez18/playlist-router/internal/clients/tidal"
+	tidalMocks "github.com/ngomez18/playlist-router/internal/clients/tidal/mocks"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type tidalAccountServiceMocks struct {
+	integrationRepo   *repositoryMocks.MockTidalIntegrationRepository
+	basePlaylistRepo  *repositoryMocks.MockBasePlaylistRepository
+	childPlaylistRepo *repositoryMocks.MockChildPlaylistRepository
+	tidal             *tidalMocks.MockTidalAPI
+}
+
+func setupTidalAccountService(t *testing.T) (*TidalAccountService, *tidalAccountServiceMocks) {
+	ctrl := setupMockController(t)
+	m := &tidalAccountServiceMocks{
+		integrationRepo:   repositoryMocks.NewMockTidalIntegrationRepository(ctrl),
+		basePlaylistRepo:  repositoryMocks.NewMockBasePlaylistRepository(ctrl),
+		childPlaylistRepo: repositoryMocks.NewMockChildPlaylistRepository(ctrl),
+		tidal:             tidalMocks.NewMockTidalAPI(ctrl),
+	}
+
+	service := NewTidalAccountService(m.integrationRepo, m.basePlaylistRepo, m.childPlaylistRepo, m.tidal, createTestLogger())
+	return service, m
+}
+
+// startTidalLink starts the link of user123 with the device code device123
+func startTidalLink(t *testing.T, service *TidalAccountService, m *tidalAccountServiceMocks, expiresIn int) {
+	m.tidal.EXPECT().StartDeviceAuthorization(gomock.Any()).Return(&tidalclient.DeviceAuthorization{
+		DeviceCode:      "device123",
+		UserCode:        "ABCDE",
+		VerificationURI: "https://link.tidal.com",
+		ExpiresIn:       expiresIn,
+		Interval:        2,
+	}, nil)
+
+	link, err := service.StartLink(context.Background(), "user123")
+	require.NoError(t, err)
+	require.Equal(t, "ABCDE", link.UserCode)
+	require.Equal(t, "https://link.tidal.com", link.VerificationURI)
+}
+
+func TestTidalAccountService_PollLink(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupTidalAccountService(t)
+	ctx := context.Background()
+
+	startTidalLink(t, service, m, 300)
+
+	token := &tidalclient.DeviceToken{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 3600}
+	token.User.UserID = 1234
+	token.User.CountryCode = "NL"
+	token.User.Username = "listener"
+
+	gomock.InOrder(
+		m.tidal.EXPECT().PollDeviceAuthorization(ctx, "device123").Return(nil, tidalclient.ErrAuthorizationPending),
+		m.tidal.EXPECT().PollDeviceAuthorization(ctx, "device123").Return(token, nil),
+	)
+	m.integrationRepo.EXPECT().CreateOrUpdate(ctx, "user123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, integration *models.TidalIntegration) (*models.TidalIntegration, error) {
+			assert.Equal("1234", integration.TidalUserID)
+			assert.Equal("NL", integration.CountryCode)
+			assert.Equal("listener", integration.DisplayName)
+			assert.Equal("refresh", integration.RefreshToken)
+			assert.WithinDuration(time.Now().Add(time.Hour), integration.ExpiresAt, time.Minute)
+			stored := *integration
+			stored.ID = "tidal_integration123"
+			stored.UserID = userID
+			return &stored, nil
+		})
+
+	_, err := service.PollLink(ctx, "user123")
+	assert.ErrorIs(err, ErrTidalLinkPending)
+
+	integration, err := service.PollLink(ctx, "user123")
+	assert.NoError(err)
+	assert.Equal("tidal_integration123", integration.ID)
+
+	// A link stores a single account
+	_, err = service.PollLink(ctx, "user123")
+	assert.ErrorIs(err, ErrTidalLinkInvalid)
+}
+
+func TestTidalAccountService_PollLink_Errors(t *testing.T) {
+	t.Run("not started", func(t *testing.T) {
+		assert := require.New(t)
+		service, _ := setupTidalAccountService(t)
+
+		integration, err := service.PollLink(context.Background(), "user123")
+
+		assert.Nil(integration)
+		assert.ErrorIs(err, ErrTidalLinkInvalid)
+	})
+
+	t.Run("device code expired", func(t *testing.T) {
+		assert := require.New(t)
+		service, m := setupTidalAccountService(t)
+		startTidalLink(t, service, m, 300)
+
+		m.tidal.EXPECT().PollDeviceAuthorization(gomock.Any(), "device123").Return(nil, tidalclient.ErrDeviceCodeExpired)
+
+		_, err := service.PollLink(context.Background(), "user123")
+		assert.ErrorIs(err, ErrTidalLinkInvalid)
+
+		// The expired link is dropped
+		_, err = service.PollLink(context.Background(), "user123")
+		assert.ErrorIs(err, ErrTidalLinkInvalid)
+	})
+
+	t.Run("link expired locally", func(t *testing.T) {
+		assert := require.New(t)
+		service, m := setupTidalAccountService(t)
+		startTidalLink(t, service, m, 0)
+
+		time.Sleep(time.Millisecond)
+		_, err := service.PollLink(context.Background(), "user123")
+
+		assert.ErrorIs(err, ErrTidalLinkInvalid)
+	})
+
+	t.Run("poll failure", func(t *testing.T) {
+		assert := require.New(t)
+		service, m := setupTidalAccountService(t)
+		startTidalLink(t, service, m, 300)
+
+		m.tidal.EXPECT().PollDeviceAuthorization(gomock.Any(), "device123").Return(nil, errors.New("tidal down"))
+
+		_, err := service.PollLink(context.Background(), "user123")
+
+		assert.ErrorContains(err, "tidal down")
+	})
+}
+
+func TestTidalAccountService_Unlink(t *testing.T) {
+	tests := []struct {
+		name          string
+		basePlaylists []*models.BasePlaylist
+		deleteErr     error
+		expectDelete  bool
+		expectedErr   error
+	}{
+		{
+			name:          "unlinks unused account",
+			basePlaylists: []*models.BasePlaylist{{ID: "base1", Provider: models.MusicProviderYouTubeMusic}},
+			expectDelete:  true,
+		},
+		{
+			name:          "account used by a base playlist",
+			basePlaylists: []*models.BasePlaylist{{ID: "base1", Provider: models.MusicProviderTidal}},
+			expectedErr:   ErrTidalAccountInUse,
+		},
+		{
+			name:         "no linked account",
+			deleteErr:    repositories.ErrTidalIntegrationNotFound,
+			expectDelete: true,
+			expectedErr:  ErrTidalIntegrationUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, m := setupTidalAccountService(t)
+			ctx := context.Background()
+
+			m.basePlaylistRepo.EXPECT().GetByUserID(ctx, "user123").Return(tt.basePlaylists, nil)
+			if tt.expectDelete {
+				m.integrationRepo.EXPECT().Delete(ctx, "user123").Return(tt.deleteErr)
+			}
+
+			err := service.Unlink(ctx, "user123")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(err, tt.expectedErr)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestTidalAccountService_ListPlaylists(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupTidalAccountService(t)
+	ctx := context.Background()
+
+	m.tidal.EXPECT().GetAllUserPlaylists(ctx).Return([]*musicprovider.Playlist{
+		{ID: "pl-base", Name: "Base"},
+		{ID: "pl-free", Name: "Free"},
+	}, nil)
+	m.basePlaylistRepo.EXPECT().GetByUserID(ctx, "user123").Return([]*models.BasePlaylist{{ID: "base1", SpotifyPlaylistID: "pl-base"}}, nil)
+	m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(ctx, "base1", "user123").Return(nil, nil)
+
+	playlists, err := service.ListPlaylists(ctx, "user123")
+
+	assert.NoError(err)
+	assert.Len(playlists, 1)
+	assert.Equal("pl-free", playlists[0].ID)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	tidalclient "github.com/ngomez18/playlist-router/internal/clients/tidal"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+// TidalTokenManager hands out tidal integrations with tokens valid for at least the refresh window,
+// refreshing them ahead of expiry. It resolves the credentials of the tidal client, which acts as
+// the user of the request or sync it is called for
+type TidalTokenManager struct {
+	*providerTokenManager[models.TidalIntegration]
+}
+
+var _ tidalclient.CredentialsProvider = (*TidalTokenManager)(nil)
+
+func NewTidalTokenManager(
+	integrationRepo repositories.TidalIntegrationRepository,
+	auth musicprovider.Auth,
+	refreshWindow time.Duration,
+	logger *slog.Logger,
+) *TidalTokenManager {
+	return &TidalTokenManager{
+		providerTokenManager: &providerTokenManager[models.TidalIntegration]{
+			provider:        "tidal",
+			integrationRepo: integrationRepo,
+			auth:            auth,
+			tokens:          tidalTokens,
+			fromContext:     requestcontext.GetTidalAuthFromContext,
+			errUnavailable:  ErrTidalIntegrationUnavailable,
+			errRefresh:      ErrTidalTokenRefresh,
+			refreshWindow:   refreshWindow,
+			logger:          logger.With("component", "TidalTokenManager"),
+		},
+	}
+}
+
+// ContextWithTidalAuth loads the tidal integration of the user, refreshing its tokens when they
+// expire within the refresh window, and returns a context carrying it
+func (tm *TidalTokenManager) ContextWithTidalAuth(ctx context.Context, userID string) (context.Context, error) {
+	integration, err := tm.refreshIfExpiring(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return requestcontext.ContextWithTidalAuth(ctx, integration), nil
+}
+
+func tidalTokens(integration *models.TidalIntegration) oauthTokens {
+	return oauthTokens{
+		id:           integration.ID,
+		accessToken:  &integration.AccessToken,
+		refreshToken: &integration.RefreshToken,
+		expiresAt:    &integration.ExpiresAt,
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	musicProviderMocks "github.com/ngomez18/playlist-router/internal/clients/musicprovider/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTidalTokenManager(t *testing.T) (*TidalTokenManager, *repositoryMocks.MockTidalIntegrationRepository, *musicProviderMocks.MockAuth) {
+	ctrl := setupMockController(t)
+	integrationRepo := repositoryMocks.NewMockTidalIntegrationRepository(ctrl)
+	auth := musicProviderMocks.NewMockAuth(ctrl)
+
+	return NewTidalTokenManager(integrationRepo, auth, 15*time.Minute, createTestLogger()), integrationRepo, auth
+}
+
+func newTidalIntegration(expiresIn time.Duration) *models.TidalIntegration {
+	return &models.TidalIntegration{
+		ID:           "tidal_integration123",
+		UserID:       "user123",
+		TidalUserID:  "1234",
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		ExpiresAt:    time.Now().Add(expiresIn),
+	}
+}
+
+func TestTidalTokenManager_Credentials_FromContextIntegration(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, _, _ := setupTidalTokenManager(t)
+
+	integration := newTidalIntegration(time.Hour)
+	ctx := requestcontext.ContextWithTidalAuth(context.Background(), integration)
+
+	credentials, err := tokenManager.Credentials(ctx)
+
+	assert.NoError(err)
+	assert.Same(integration, credentials)
+}
+
+func TestTidalTokenManager_ContextWithTidalAuth_RefreshesExpiringTokens(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, auth := setupTidalTokenManager(t)
+
+	integration := newTidalIntegration(5 * time.Minute)
+	integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(integration, nil)
+	auth.EXPECT().RefreshTokens(gomock.Any(), "refresh_token_123").Return(&musicprovider.TokenResponse{AccessToken: "new_access", ExpiresIn: 3600}, nil)
+	integrationRepo.EXPECT().
+		UpdateTokens(gomock.Any(), "tidal_integration123", &models.TidalIntegrationTokenRefresh{AccessToken: "new_access", ExpiresIn: 3600}).
+		Return(nil)
+
+	ctx, err := tokenManager.ContextWithTidalAuth(context.Background(), "user123")
+	assert.NoError(err)
+
+	stored, ok := requestcontext.GetTidalAuthFromContext(ctx)
+	assert.True(ok)
+	assert.Equal("new_access", stored.AccessToken)
+	assert.Equal("refresh_token_123", stored.RefreshToken)
+	assert.True(stored.ExpiresAt.After(time.Now().Add(50 * time.Minute)))
+}
+
+func TestTidalTokenManager_ContextWithTidalAuth_NoIntegration(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, _ := setupTidalTokenManager(t)
+
+	integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrTidalIntegrationNotFound)
+
+	_, err := tokenManager.ContextWithTidalAuth(context.Background(), "user123")
+	assert.ErrorIs(err, ErrTidalIntegrationUnavailable)
+}
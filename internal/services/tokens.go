@@ -0,0 +1,17 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// generateSecretToken returns a random hex token for public links and hooks, where
+// the token alone authorizes the request
+func generateSecretToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
@@ -2,41 +2,55 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/profiling"
 	"github.com/ngomez18/playlist-router/internal/repositories"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
-	MAX_TRACKS  = 50
-	MAX_ARTISTS = 50
+	MAX_TRACKS         = 50
+	MAX_AUDIO_FEATURES = 100
 )
 
 //go:generate mockgen -source=track_aggregator_service.go -destination=mocks/mock_track_aggregator_service.go -package=mocks
 
 type TrackAggregatorServicer interface {
 	AggregatePlaylistData(ctx context.Context, userID, basePlaylistID string) (*models.PlaylistTracksInfo, error)
+	AggregateMergedPlaylistData(ctx context.Context, userID string, basePlaylistIDs []string) (*models.PlaylistTracksInfo, error)
+	StreamPlaylistData(ctx context.Context, userID, basePlaylistID string, pages chan<- *models.PlaylistTracksInfo) error
 }
 
 type TrackAggregatorService struct {
-	spotifyClient    spotifyclient.SpotifyAPI
+	musicProvider    musicprovider.MusicProvider
 	basePlaylistRepo repositories.BasePlaylistRepository
+	lastFm           LastFmEnricher
 	logger           *slog.Logger
 }
 
-func NewTrackAggregatorService(spotifyClient spotifyclient.SpotifyAPI, basePlaylistRepo repositories.BasePlaylistRepository, log *slog.Logger) *TrackAggregatorService {
+func NewTrackAggregatorService(musicProvider musicprovider.MusicProvider, basePlaylistRepo repositories.BasePlaylistRepository, log *slog.Logger) *TrackAggregatorService {
 	return &TrackAggregatorService{
-		spotifyClient:    spotifyClient,
+		musicProvider:    musicProvider,
 		basePlaylistRepo: basePlaylistRepo,
 		logger:           log,
 	}
 }
 
+// WithLastFm attaches the scrobbles of the users' linked last.fm accounts to their tracks
+func (taService *TrackAggregatorService) WithLastFm(enricher LastFmEnricher) *TrackAggregatorService {
+	taService.lastFm = enricher
+	return taService
+}
+
 func (taService *TrackAggregatorService) AggregatePlaylistData(ctx context.Context, userID, basePlaylistID string) (*models.PlaylistTracksInfo, error) {
 	taService.logger.InfoContext(ctx, "aggregating playlist data", "user", userID, "base_playlist", basePlaylistID)
 
@@ -46,7 +60,9 @@ func (taService *TrackAggregatorService) AggregatePlaylistData(ctx context.Conte
 		return nil, fmt.Errorf("failed to fetch base playlist: %w", err)
 	}
 
-	tracks, err := taService.getAllPlaylistTracks(ctx, basePlaylist.SpotifyPlaylistID)
+	fetchCtx, endFetch := profiling.StartSpan(ctx, "fetch_tracks")
+	tracks, err := taService.getAllPlaylistTracks(fetchCtx, basePlaylist.SpotifyPlaylistID)
+	endFetch()
 	if err != nil {
 		taService.logger.ErrorContext(ctx, "failed to fetch playlist tracks", "error", err.Error())
 		return nil, fmt.Errorf("failed to fetch playlist tracks: %w", err)
@@ -60,19 +76,15 @@ func (taService *TrackAggregatorService) AggregatePlaylistData(ctx context.Conte
 		"tracks", len(tracks.Tracks),
 	)
 
-	artistInfo, apiCallCount, err := taService.getAllPlaylistArtists(ctx, tracks.GetAllArtists())
-	if err != nil {
-		taService.logger.ErrorContext(ctx, "failed to fetch playlist artists", "error", err.Error())
-		return nil, fmt.Errorf("failed to fetch playlist artists: %w", err)
-	}
-
-	tracks.Artists = artistInfo
-	tracks.APICallCount = tracks.APICallCount + apiCallCount
 	tracks.PlaylistID = basePlaylistID
 	tracks.UserID = userID
 
-	// Pre-process tracks for efficient filtering
-	taService.preprocessTracksForFiltering(tracks)
+	enrichCtx, endEnrich := profiling.StartSpan(ctx, "enrichment")
+	defer endEnrich()
+
+	if err := taService.enrichPlaylistTracks(enrichCtx, tracks); err != nil {
+		return nil, err
+	}
 
 	taService.logger.InfoContext(
 		ctx,
@@ -85,50 +97,223 @@ func (taService *TrackAggregatorService) AggregatePlaylistData(ctx context.Conte
 	return tracks, nil
 }
 
-func (taService *TrackAggregatorService) getAllPlaylistTracks(ctx context.Context, playlistID string) (*models.PlaylistTracksInfo, error) {
-	playlistTracks := models.PlaylistTracksInfo{Tracks: make([]models.TrackInfo, 0)}
-	offset := 0
+// StreamPlaylistData sends the tracks of the base playlist to pages one page at a time, each
+// enriched as AggregatePlaylistData does, so only a page of track data is held at once. pages is
+// closed once every page was sent; on error it is left open and the caller is expected to cancel ctx
+func (taService *TrackAggregatorService) StreamPlaylistData(ctx context.Context, userID, basePlaylistID string, pages chan<- *models.PlaylistTracksInfo) error {
+	taService.logger.InfoContext(ctx, "streaming playlist data", "user", userID, "base_playlist", basePlaylistID)
+
+	basePlaylist, err := taService.basePlaylistRepo.GetByID(ctx, basePlaylistID, userID)
+	if err != nil {
+		taService.logger.ErrorContext(ctx, "failed to fetch base playlist", "error", err.Error())
+		return fmt.Errorf("failed to fetch base playlist: %w", err)
+	}
 
-	for {
-		tracksResp, err := taService.spotifyClient.GetPlaylistTracks(ctx, playlistID, MAX_TRACKS, offset)
+	for offset := 0; ; offset += MAX_TRACKS {
+		tracksResp, err := taService.getPlaylistTracksPage(ctx, basePlaylist.SpotifyPlaylistID, offset)
 		if err != nil {
-			taService.logger.ErrorContext(ctx, "failed to fetch playlist tracks", "error", err.Error())
-			return nil, fmt.Errorf("failed to fetch playlist tracks: %w", err)
+			return err
 		}
 
-		playlistTracks.Tracks = append(playlistTracks.Tracks, spotifyclient.ParseManyPlaylistTracks(tracksResp.Items)...)
-		playlistTracks.APICallCount++
+		page := &models.PlaylistTracksInfo{
+			PlaylistID:   basePlaylistID,
+			UserID:       userID,
+			Tracks:       musicprovider.ParseManyPlaylistTracks(tracksResp.Items),
+			APICallCount: 1,
+		}
+		if err := taService.enrichPlaylistTracks(ctx, page); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case pages <- page:
+		}
 
 		if tracksResp.Next == nil {
-			break
+			close(pages)
+			return nil
+		}
+	}
+}
+
+// enrichPlaylistTracks attaches the artists, audio features and last.fm scrobbles of the tracks and
+// prepares them for filtering, counting the spotify requests made
+func (taService *TrackAggregatorService) enrichPlaylistTracks(ctx context.Context, tracks *models.PlaylistTracksInfo) error {
+	artistsCtx, endArtists := profiling.StartSpan(ctx, "fetch_artists")
+	artistInfo, apiCallCount, err := taService.getAllPlaylistArtists(artistsCtx, tracks.GetAllArtists())
+	endArtists()
+	if err != nil {
+		taService.logger.ErrorContext(ctx, "failed to fetch playlist artists", "error", err.Error())
+		return fmt.Errorf("failed to fetch playlist artists: %w", err)
+	}
+
+	tracks.Artists = artistInfo
+	tracks.APICallCount = tracks.APICallCount + apiCallCount
+
+	// Audio features are best effort: Spotify restricts the endpoint for some apps, and a
+	// missing analysis only means the track never matches audio feature filters
+	featuresCtx, endFeatures := profiling.StartSpan(ctx, "fetch_audio_features")
+	audioFeatures, apiCallCount, err := taService.getAllAudioFeatures(featuresCtx, tracks.GetAllTrackIDs())
+	endFeatures()
+	tracks.APICallCount = tracks.APICallCount + apiCallCount
+	if err != nil {
+		taService.logger.WarnContext(ctx, "failed to fetch audio features, continuing without them", "error", err.Error())
+	}
+	for i := range tracks.Tracks {
+		if features, exists := audioFeatures[tracks.Tracks[i].ID]; exists {
+			tracks.Tracks[i].AudioFeatures = &features
+		}
+	}
+
+	// Pre-process tracks for efficient filtering
+	taService.preprocessTracksForFiltering(tracks)
+
+	// Scrobbles are best effort as well, tracks without them never match play count or tag filters.
+	// They are looked up by artist name, so only once the tracks were pre-processed
+	if taService.lastFm != nil {
+		lastFmCtx, endLastFm := profiling.StartSpan(ctx, "fetch_lastfm_scrobbles")
+		err := taService.lastFm.EnrichTracks(lastFmCtx, tracks.UserID, tracks.Tracks)
+		endLastFm()
+		if err != nil {
+			taService.logger.WarnContext(ctx, "failed to fetch lastfm scrobbles, continuing without them", "error", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// AggregateMergedPlaylistData aggregates several base playlists into a single track list, in the
+// given order. Tracks found in more than one base playlist are only kept once
+func (taService *TrackAggregatorService) AggregateMergedPlaylistData(ctx context.Context, userID string, basePlaylistIDs []string) (*models.PlaylistTracksInfo, error) {
+	playlists := make([]*models.PlaylistTracksInfo, 0, len(basePlaylistIDs))
+	for _, basePlaylistID := range basePlaylistIDs {
+		tracks, err := taService.AggregatePlaylistData(ctx, userID, basePlaylistID)
+		// Deleted sources stay referenced until purged, they just stop feeding the merge
+		if errors.Is(err, repositories.ErrBasePlaylistNotFound) {
+			taService.logger.WarnContext(ctx, "skipping deleted source base playlist", "base_playlist", basePlaylistID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate base playlist %s: %w", basePlaylistID, err)
 		}
 
-		offset += MAX_TRACKS
+		playlists = append(playlists, tracks)
+	}
+
+	merged := models.MergePlaylistTracks(playlists...)
+
+	taService.logger.InfoContext(ctx, "aggregated merged playlist data",
+		"user", userID,
+		"base_playlists", len(basePlaylistIDs),
+		"tracks", len(merged.Tracks),
+	)
+
+	return merged, nil
+}
+
+// getAllPlaylistTracks reads the first page of the playlist, then the pages left according to its
+// total concurrently, up to spotifyclient.MAX_CONCURRENT_PAGES at a time, keeping the playlist order
+func (taService *TrackAggregatorService) getAllPlaylistTracks(ctx context.Context, playlistID string) (*models.PlaylistTracksInfo, error) {
+	firstPage, err := taService.getPlaylistTracksPage(ctx, playlistID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	playlistTracks := models.PlaylistTracksInfo{Tracks: make([]models.TrackInfo, 0, firstPage.Total)}
+	playlistTracks.Tracks = append(playlistTracks.Tracks, musicprovider.ParseManyPlaylistTracks(firstPage.Items)...)
+	playlistTracks.APICallCount++
+
+	if firstPage.Next == nil {
+		return &playlistTracks, nil
+	}
+
+	pages := make([][]models.TrackInfo, 0, firstPage.Total/MAX_TRACKS)
+	for offset := MAX_TRACKS; offset < firstPage.Total; offset += MAX_TRACKS {
+		pages = append(pages, nil)
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(spotifyclient.MAX_CONCURRENT_PAGES)
+	for i := range pages {
+		group.Go(func() error {
+			tracksResp, err := taService.getPlaylistTracksPage(groupCtx, playlistID, (i+1)*MAX_TRACKS)
+			if err != nil {
+				return err
+			}
+
+			pages[i] = musicprovider.ParseManyPlaylistTracks(tracksResp.Items)
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	for _, page := range pages {
+		playlistTracks.Tracks = append(playlistTracks.Tracks, page...)
+		playlistTracks.APICallCount++
 	}
 
 	return &playlistTracks, nil
 }
 
+func (taService *TrackAggregatorService) getPlaylistTracksPage(ctx context.Context, playlistID string, offset int) (*musicprovider.PlaylistTracksResponse, error) {
+	tracksResp, err := taService.musicProvider.GetPlaylistTracks(ctx, playlistID, MAX_TRACKS, offset)
+	if err != nil {
+		taService.logger.ErrorContext(ctx, "failed to fetch playlist tracks", "offset", offset, "error", err.Error())
+		return nil, fmt.Errorf("failed to fetch playlist tracks: %w", err)
+	}
+
+	return tracksResp, nil
+}
+
+// getAllPlaylistArtists resolves the artists of the playlist to attach their genres and popularity to
+// the tracks. The client batches and caches artist lookups, so they are counted as a single API call
 func (taService *TrackAggregatorService) getAllPlaylistArtists(ctx context.Context, artistIDs []string) (map[string]models.ArtistInfo, int, error) {
 	artists := make(map[string]models.ArtistInfo, len(artistIDs))
+	if len(artistIDs) == 0 {
+		return artists, 0, nil
+	}
+
+	artistsResp, err := taService.musicProvider.GetArtists(ctx, artistIDs)
+	if err != nil {
+		taService.logger.ErrorContext(ctx, "failed to fetch playlist artists", "error", err.Error())
+		return nil, 1, fmt.Errorf("failed to fetch playlist artists: %w", err)
+	}
+
+	for _, artist := range artistsResp {
+		artists[artist.ID] = *musicprovider.ParseArtist(artist)
+	}
+
+	return artists, 1, nil
+}
+
+// getAllAudioFeatures returns the audio features fetched before any error, keyed by track ID
+func (taService *TrackAggregatorService) getAllAudioFeatures(ctx context.Context, trackIDs []string) (map[string]models.AudioFeatures, int, error) {
+	audioFeatures := make(map[string]models.AudioFeatures, len(trackIDs))
 	apiCallCount := 0
 
-	for offset := 0; offset < len(artistIDs); offset += MAX_ARTISTS {
-		endIndex := min(offset+MAX_ARTISTS, len(artistIDs))
-		artistsResp, err := taService.spotifyClient.GetSeveralArtists(ctx, artistIDs[offset:endIndex])
+	for offset := 0; offset < len(trackIDs); offset += MAX_AUDIO_FEATURES {
+		endIndex := min(offset+MAX_AUDIO_FEATURES, len(trackIDs))
+		audioFeaturesResp, err := taService.musicProvider.GetAudioFeatures(ctx, trackIDs[offset:endIndex])
 		if err != nil {
-			taService.logger.ErrorContext(ctx, "failed to fetch playlist artists", "error", err.Error())
-			return nil, apiCallCount, fmt.Errorf("failed to fetch playlist artists: %w", err)
+			return audioFeatures, apiCallCount, fmt.Errorf("failed to fetch audio features: %w", err)
 		}
 
-		for _, artist := range artistsResp {
-			artists[artist.ID] = *spotifyclient.ParseArtist(artist)
+		for _, features := range audioFeaturesResp {
+			// Spotify returns null for tracks without an analysis
+			if features == nil {
+				continue
+			}
+			audioFeatures[features.ID] = *musicprovider.ParseAudioFeatures(features)
 		}
 
 		apiCallCount++
 	}
 
-	return artists, apiCallCount, nil
+	return audioFeatures, apiCallCount, nil
 }
 
 func (taService *TrackAggregatorService) preprocessTracksForFiltering(playlistData *models.PlaylistTracksInfo) {
@@ -137,6 +322,7 @@ func (taService *TrackAggregatorService) preprocessTracksForFiltering(playlistDa
 
 		// Extract release year from album release date
 		track.ReleaseYear = taService.parseReleaseYear(track.Album.ReleaseDate)
+		track.ReleaseDate = taService.parseReleaseDate(track.Album.ReleaseDate)
 
 		// Collect all genres from track's artists
 		genreSet := make(map[string]bool)
@@ -185,3 +371,15 @@ func (taService *TrackAggregatorService) parseReleaseYear(releaseDate string) in
 
 	return 0
 }
+
+// parseReleaseDate parses a Spotify release date, which depending on its precision is a day,
+// a month ("2006-01") or only a year, into the first day it covers
+func (taService *TrackAggregatorService) parseReleaseDate(releaseDate string) time.Time {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if date, err := time.Parse(layout, releaseDate); err == nil {
+			return date
+		}
+	}
+
+	return time.Time{}
+}
@@ -3,14 +3,19 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	lastfmclient "github.com/ngomez18/playlist-router/internal/clients/lastfm"
 	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
 	clientmocks "github.com/ngomez18/playlist-router/internal/clients/spotify/mocks"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 	repomocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,7 +32,7 @@ func TestNewTrackAggregatorService(t *testing.T) {
 	service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, logger)
 
 	assert.NotNil(service)
-	assert.Equal(mockSpotifyClient, service.spotifyClient)
+	assert.Equal(mockSpotifyClient, service.musicProvider)
 	assert.Equal(mockBasePlaylistRepo, service.basePlaylistRepo)
 	assert.Equal(logger, service.logger)
 }
@@ -40,6 +45,7 @@ func TestTrackAggregatorService_AggregatePlaylistData_Success(t *testing.T) {
 		basePlaylist        *models.BasePlaylist
 		tracksResponse      *spotifyclient.SpotifyPlaylistTracksResponse
 		artistsResponse     []*spotifyclient.SpotifyArtist
+		audioFeatures       []*spotifyclient.SpotifyAudioFeatures
 		expectedAPICount    int
 		expectedTrackCount  int
 		expectedArtistCount int
@@ -48,12 +54,12 @@ func TestTrackAggregatorService_AggregatePlaylistData_Success(t *testing.T) {
 			name:           "single page with two tracks and artists",
 			userID:         "user123",
 			basePlaylistID: "base456",
-			basePlaylist: &models.BasePlaylist{
-				ID:                "base456",
-				UserID:            "user123",
-				SpotifyPlaylistID: "spotify789",
-				Name:              "Test Playlist",
-			},
+			basePlaylist: testfixtures.NewBasePlaylist().
+				WithID("base456").
+				WithUserID("user123").
+				WithSpotifyPlaylistID("spotify789").
+				WithName("Test Playlist").
+				Build(),
 			tracksResponse: &spotifyclient.SpotifyPlaylistTracksResponse{
 				Items: []spotifyclient.SpotifyPlaylistTrack{
 					{
@@ -116,7 +122,11 @@ func TestTrackAggregatorService_AggregatePlaylistData_Success(t *testing.T) {
 					URI:        "spotify:artist:artist3",
 				},
 			},
-			expectedAPICount:    2, // 1 for tracks + 1 for artists
+			audioFeatures: []*spotifyclient.SpotifyAudioFeatures{
+				{ID: "track1", Tempo: 128, Energy: 0.8, Key: 5, Mode: 1},
+				nil, // No analysis available for track2
+			},
+			expectedAPICount:    3, // 1 for tracks + 1 for artists + 1 for audio features
 			expectedTrackCount:  2,
 			expectedArtistCount: 3, // artist1, artist2, artist3 (deduplicated)
 		},
@@ -147,10 +157,15 @@ func TestTrackAggregatorService_AggregatePlaylistData_Success(t *testing.T) {
 				Times(1)
 
 			mockSpotifyClient.EXPECT().
-				GetSeveralArtists(ctx, gomock.Any()).
+				GetArtists(ctx, gomock.Any()).
 				Return(tt.artistsResponse, nil).
 				Times(1)
 
+			mockSpotifyClient.EXPECT().
+				GetAudioFeatures(ctx, []string{"track1", "track2"}).
+				Return(tt.audioFeatures, nil).
+				Times(1)
+
 			// Execute
 			service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, logger)
 			result, err := service.AggregatePlaylistData(ctx, tt.userID, tt.basePlaylistID)
@@ -188,6 +203,10 @@ func TestTrackAggregatorService_AggregatePlaylistData_Success(t *testing.T) {
 			assert.Equal(70, track2.MaxArtistPop) // artist2 has 70, artist3 has 60
 			assert.Contains(track2.AllGenres, "jazz")
 			assert.Len(track2.AllGenres, 1) // only jazz (artist3 has no genres)
+
+			// Verify audio features
+			assert.Equal(&models.AudioFeatures{Tempo: 128, Energy: 0.8, Key: 5, Mode: 1}, track1.AudioFeatures)
+			assert.Nil(track2.AudioFeatures)
 		})
 	}
 }
@@ -244,12 +263,12 @@ func TestTrackAggregatorService_AggregatePlaylistData_Errors(t *testing.T) {
 					Return(nil, tt.basePlaylistError).
 					Times(1)
 			} else {
-				basePlaylist := &models.BasePlaylist{
-					ID:                tt.basePlaylistID,
-					UserID:            tt.userID,
-					SpotifyPlaylistID: "spotify789",
-					Name:              "Test Playlist",
-				}
+				basePlaylist := testfixtures.NewBasePlaylist().
+					WithID(tt.basePlaylistID).
+					WithUserID(tt.userID).
+					WithSpotifyPlaylistID("spotify789").
+					WithName("Test Playlist").
+					Build()
 				mockBasePlaylistRepo.EXPECT().
 					GetByID(ctx, tt.basePlaylistID, tt.userID).
 					Return(basePlaylist, nil).
@@ -279,7 +298,7 @@ func TestTrackAggregatorService_AggregatePlaylistData_Errors(t *testing.T) {
 						Times(1)
 
 					mockSpotifyClient.EXPECT().
-						GetSeveralArtists(ctx, []string{"artist1"}).
+						GetArtists(ctx, []string{"artist1"}).
 						Return(nil, tt.artistsError).
 						Times(1)
 				}
@@ -308,12 +327,12 @@ func TestTrackAggregatorService_EmptyPlaylist(t *testing.T) {
 	mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
 	logger := createTestLogger()
 
-	basePlaylist := &models.BasePlaylist{
-		ID:                "base123",
-		UserID:            "user123",
-		SpotifyPlaylistID: "spotify456",
-		Name:              "Empty Playlist",
-	}
+	basePlaylist := testfixtures.NewBasePlaylist().
+		WithID("base123").
+		WithUserID("user123").
+		WithSpotifyPlaylistID("spotify456").
+		WithName("Empty Playlist").
+		Build()
 
 	emptyTracksResponse := &spotifyclient.SpotifyPlaylistTracksResponse{
 		Items: []spotifyclient.SpotifyPlaylistTrack{},
@@ -346,11 +365,167 @@ func TestTrackAggregatorService_EmptyPlaylist(t *testing.T) {
 	assert.Equal(1, result.APICallCount) // Only tracks call
 }
 
+// tracksPage returns the page of a playlist of total tracks starting at offset
+func tracksPage(total, offset int) *spotifyclient.SpotifyPlaylistTracksResponse {
+	page := &spotifyclient.SpotifyPlaylistTracksResponse{Total: total, Limit: MAX_TRACKS, Offset: offset}
+	for i := offset; i < min(offset+MAX_TRACKS, total); i++ {
+		page.Items = append(page.Items, spotifyclient.SpotifyPlaylistTrack{
+			Track: &spotifyclient.SpotifyTrack{ID: fmt.Sprintf("track%d", i)},
+		})
+	}
+	if offset+MAX_TRACKS < total {
+		next := fmt.Sprintf("next?offset=%d", offset+MAX_TRACKS)
+		page.Next = &next
+	}
+
+	return page
+}
+
+func TestTrackAggregatorService_GetAllPlaylistTracks_ConcurrentPages(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+	service := NewTrackAggregatorService(mockSpotifyClient, repomocks.NewMockBasePlaylistRepository(ctrl), createTestLogger())
+
+	total := 5*MAX_TRACKS + 10
+	for offset := 0; offset < total; offset += MAX_TRACKS {
+		mockSpotifyClient.EXPECT().
+			GetPlaylistTracks(gomock.Any(), "spotify456", MAX_TRACKS, offset).
+			Return(tracksPage(total, offset), nil)
+	}
+
+	result, err := service.getAllPlaylistTracks(ctx, "spotify456")
+
+	assert.NoError(err)
+	assert.Equal(6, result.APICallCount)
+	assert.Len(result.Tracks, total)
+	for i, track := range result.Tracks {
+		assert.Equal(fmt.Sprintf("track%d", i), track.ID)
+	}
+}
+
+func TestTrackAggregatorService_GetAllPlaylistTracks_PageError(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+	service := NewTrackAggregatorService(mockSpotifyClient, repomocks.NewMockBasePlaylistRepository(ctrl), createTestLogger())
+
+	total := 3 * MAX_TRACKS
+	mockSpotifyClient.EXPECT().
+		GetPlaylistTracks(gomock.Any(), "spotify456", MAX_TRACKS, 0).
+		Return(tracksPage(total, 0), nil)
+	mockSpotifyClient.EXPECT().
+		GetPlaylistTracks(gomock.Any(), "spotify456", MAX_TRACKS, MAX_TRACKS).
+		Return(nil, errors.New("spotify unavailable"))
+	// The last page may or may not be requested before the failure cancels the others
+	mockSpotifyClient.EXPECT().
+		GetPlaylistTracks(gomock.Any(), "spotify456", MAX_TRACKS, 2*MAX_TRACKS).
+		Return(tracksPage(total, 2*MAX_TRACKS), nil).
+		MaxTimes(1)
+
+	result, err := service.getAllPlaylistTracks(ctx, "spotify456")
+
+	assert.Nil(result)
+	assert.ErrorContains(err, "spotify unavailable")
+}
+
+func TestTrackAggregatorService_StreamPlaylistData(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+	mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, createTestLogger())
+
+	mockBasePlaylistRepo.EXPECT().
+		GetByID(ctx, "base123", "user123").
+		Return(testfixtures.NewBasePlaylist().WithID("base123").WithSpotifyPlaylistID("spotify456").Build(), nil)
+
+	// Each page is enriched on its own
+	total := MAX_TRACKS + 10
+	for offset := 0; offset < total; offset += MAX_TRACKS {
+		page := tracksPage(total, offset)
+		trackIDs := make([]string, 0, len(page.Items))
+		for _, item := range page.Items {
+			trackIDs = append(trackIDs, item.Track.ID)
+		}
+
+		mockSpotifyClient.EXPECT().
+			GetPlaylistTracks(ctx, "spotify456", MAX_TRACKS, offset).
+			Return(page, nil)
+		mockSpotifyClient.EXPECT().
+			GetAudioFeatures(ctx, trackIDs).
+			Return([]*spotifyclient.SpotifyAudioFeatures{{ID: trackIDs[0], Tempo: 120}}, nil)
+	}
+
+	pages := make(chan *models.PlaylistTracksInfo, 2)
+	err := service.StreamPlaylistData(ctx, "user123", "base123", pages)
+	assert.NoError(err)
+
+	received := make([]*models.PlaylistTracksInfo, 0)
+	for page := range pages {
+		received = append(received, page)
+	}
+
+	assert.Len(received, 2)
+	assert.Len(received[0].Tracks, MAX_TRACKS)
+	assert.Len(received[1].Tracks, 10)
+	for _, page := range received {
+		assert.Equal("base123", page.PlaylistID)
+		assert.Equal("user123", page.UserID)
+		assert.Equal(2, page.APICallCount)
+		assert.NotNil(page.Tracks[0].AudioFeatures)
+	}
+	assert.Equal("track50", received[1].Tracks[0].ID)
+}
+
+func TestTrackAggregatorService_StreamPlaylistData_Error(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+	mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
+	service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, createTestLogger())
+
+	mockBasePlaylistRepo.EXPECT().
+		GetByID(ctx, "base123", "user123").
+		Return(testfixtures.NewBasePlaylist().WithID("base123").WithSpotifyPlaylistID("spotify456").Build(), nil)
+	mockSpotifyClient.EXPECT().
+		GetPlaylistTracks(ctx, "spotify456", MAX_TRACKS, 0).
+		Return(nil, errors.New("spotify unavailable"))
+
+	pages := make(chan *models.PlaylistTracksInfo, 1)
+	err := service.StreamPlaylistData(ctx, "user123", "base123", pages)
+
+	assert.ErrorContains(err, "spotify unavailable")
+	// pages is left open, so the consumer can't take the sync for complete
+	select {
+	case <-pages:
+		assert.Fail("no page expected")
+	default:
+	}
+}
+
 func TestTrackAggregatorService_PreprocessingEdgeCases(t *testing.T) {
 	tests := []struct {
 		name               string
 		releaseDate        string
 		expectedYear       int
+		expectedDate       time.Time
 		artistGenres       []string
 		artistPopularity   int
 		expectedMaxPop     int
@@ -369,6 +544,27 @@ func TestTrackAggregatorService_PreprocessingEdgeCases(t *testing.T) {
 			name:               "year only release date",
 			releaseDate:        "2019",
 			expectedYear:       2019,
+			expectedDate:       time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+			artistGenres:       []string{},
+			artistPopularity:   0,
+			expectedMaxPop:     0,
+			expectedGenreCount: 0,
+		},
+		{
+			name:               "month precision release date",
+			releaseDate:        "2021-03",
+			expectedYear:       2021,
+			expectedDate:       time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+			artistGenres:       []string{},
+			artistPopularity:   0,
+			expectedMaxPop:     0,
+			expectedGenreCount: 0,
+		},
+		{
+			name:               "day precision release date",
+			releaseDate:        "2021-03-14",
+			expectedYear:       2021,
+			expectedDate:       time.Date(2021, time.March, 14, 0, 0, 0, 0, time.UTC),
 			artistGenres:       []string{},
 			artistPopularity:   0,
 			expectedMaxPop:     0,
@@ -397,12 +593,12 @@ func TestTrackAggregatorService_PreprocessingEdgeCases(t *testing.T) {
 			mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
 			logger := createTestLogger()
 
-			basePlaylist := &models.BasePlaylist{
-				ID:                "base123",
-				UserID:            "user123",
-				SpotifyPlaylistID: "spotify456",
-				Name:              "Test Playlist",
-			}
+			basePlaylist := testfixtures.NewBasePlaylist().
+				WithID("base123").
+				WithUserID("user123").
+				WithSpotifyPlaylistID("spotify456").
+				WithName("Test Playlist").
+				Build()
 
 			tracksResponse := &spotifyclient.SpotifyPlaylistTracksResponse{
 				Items: []spotifyclient.SpotifyPlaylistTrack{
@@ -446,10 +642,15 @@ func TestTrackAggregatorService_PreprocessingEdgeCases(t *testing.T) {
 				Times(1)
 
 			mockSpotifyClient.EXPECT().
-				GetSeveralArtists(ctx, []string{"artist1"}).
+				GetArtists(ctx, []string{"artist1"}).
 				Return(artistsResponse, nil).
 				Times(1)
 
+			mockSpotifyClient.EXPECT().
+				GetAudioFeatures(ctx, []string{"track1"}).
+				Return([]*spotifyclient.SpotifyAudioFeatures{}, nil).
+				Times(1)
+
 			// Execute
 			service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, logger)
 			result, err := service.AggregatePlaylistData(ctx, "user123", "base123")
@@ -461,6 +662,7 @@ func TestTrackAggregatorService_PreprocessingEdgeCases(t *testing.T) {
 
 			track := result.Tracks[0]
 			assert.Equal(tt.expectedYear, track.ReleaseYear)
+			assert.Equal(tt.expectedDate, track.ReleaseDate)
 			assert.Equal(tt.expectedMaxPop, track.MaxArtistPop)
 			assert.Len(track.AllGenres, tt.expectedGenreCount)
 
@@ -473,3 +675,241 @@ func TestTrackAggregatorService_PreprocessingEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackAggregatorService_AudioFeatures(t *testing.T) {
+	t.Run("batches audio feature requests", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+		service := NewTrackAggregatorService(mockSpotifyClient, nil, createTestLogger())
+
+		trackIDs := make([]string, MAX_AUDIO_FEATURES+1)
+		for i := range trackIDs {
+			trackIDs[i] = fmt.Sprintf("track%d", i)
+		}
+
+		mockSpotifyClient.EXPECT().
+			GetAudioFeatures(ctx, trackIDs[:MAX_AUDIO_FEATURES]).
+			Return([]*spotifyclient.SpotifyAudioFeatures{{ID: "track0", Tempo: 100}}, nil).
+			Times(1)
+		mockSpotifyClient.EXPECT().
+			GetAudioFeatures(ctx, trackIDs[MAX_AUDIO_FEATURES:]).
+			Return([]*spotifyclient.SpotifyAudioFeatures{{ID: trackIDs[MAX_AUDIO_FEATURES], Tempo: 140}}, nil).
+			Times(1)
+
+		audioFeatures, apiCallCount, err := service.getAllAudioFeatures(ctx, trackIDs)
+
+		assert.NoError(err)
+		assert.Equal(2, apiCallCount)
+		assert.Len(audioFeatures, 2)
+		assert.Equal(100.0, audioFeatures["track0"].Tempo)
+		assert.Equal(140.0, audioFeatures[trackIDs[MAX_AUDIO_FEATURES]].Tempo)
+	})
+
+	t.Run("audio feature errors do not fail the aggregation", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+		mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
+		service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, createTestLogger())
+
+		mockBasePlaylistRepo.EXPECT().
+			GetByID(ctx, "base123", "user123").
+			Return(testfixtures.NewBasePlaylist().WithID("base123").WithSpotifyPlaylistID("spotify456").Build(), nil)
+		mockSpotifyClient.EXPECT().
+			GetPlaylistTracks(ctx, "spotify456", MAX_TRACKS, 0).
+			Return(&spotifyclient.SpotifyPlaylistTracksResponse{
+				Items: []spotifyclient.SpotifyPlaylistTrack{
+					{Track: &spotifyclient.SpotifyTrack{ID: "track1", URI: "spotify:track:track1"}},
+				},
+			}, nil)
+		mockSpotifyClient.EXPECT().
+			GetArtists(ctx, gomock.Any()).
+			Return([]*spotifyclient.SpotifyArtist{}, nil).
+			AnyTimes()
+		mockSpotifyClient.EXPECT().
+			GetAudioFeatures(ctx, []string{"track1"}).
+			Return(nil, errors.New("spotify audio features fetch failed (status 403)"))
+
+		result, err := service.AggregatePlaylistData(ctx, "user123", "base123")
+
+		assert.NoError(err)
+		assert.Len(result.Tracks, 1)
+		assert.Nil(result.Tracks[0].AudioFeatures)
+	})
+}
+
+// fakeLastFmEnricher sets the play count of the tracks, recording the artist names they carried
+type fakeLastFmEnricher struct {
+	playCount   int
+	err         error
+	artistNames [][]string
+}
+
+func (f *fakeLastFmEnricher) EnrichTracks(_ context.Context, _ string, tracks []models.TrackInfo) error {
+	for i := range tracks {
+		f.artistNames = append(f.artistNames, tracks[i].ArtistNames)
+	}
+	if f.err != nil {
+		return f.err
+	}
+	for i := range tracks {
+		tracks[i].PlayCount = &f.playCount
+	}
+	return nil
+}
+
+func TestTrackAggregatorService_LastFm(t *testing.T) {
+	tests := []struct {
+		name              string
+		enrichErr         error
+		expectedPlayCount *int
+	}{
+		{
+			name:              "attaches the scrobbles once the artist names are known",
+			expectedPlayCount: intToPointer(12),
+		},
+		{
+			name:      "lastfm errors do not fail the aggregation",
+			enrichErr: lastfmclient.ErrRateLimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+			mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
+			lastFm := &fakeLastFmEnricher{playCount: 12, err: tt.enrichErr}
+			service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, createTestLogger()).WithLastFm(lastFm)
+
+			mockBasePlaylistRepo.EXPECT().
+				GetByID(ctx, "base123", "user123").
+				Return(testfixtures.NewBasePlaylist().WithID("base123").WithSpotifyPlaylistID("spotify456").Build(), nil)
+			mockSpotifyClient.EXPECT().
+				GetPlaylistTracks(ctx, "spotify456", MAX_TRACKS, 0).
+				Return(&spotifyclient.SpotifyPlaylistTracksResponse{
+					Items: []spotifyclient.SpotifyPlaylistTrack{
+						{Track: &spotifyclient.SpotifyTrack{ID: "track1", Name: "Song", URI: "spotify:track:track1", Artists: []spotifyclient.SpotifyArtist{{ID: "artist1"}}}},
+					},
+				}, nil)
+			mockSpotifyClient.EXPECT().
+				GetArtists(gomock.Any(), []string{"artist1"}).
+				Return([]*spotifyclient.SpotifyArtist{{ID: "artist1", Name: "Artist One"}}, nil)
+			mockSpotifyClient.EXPECT().
+				GetAudioFeatures(gomock.Any(), []string{"track1"}).
+				Return([]*spotifyclient.SpotifyAudioFeatures{}, nil)
+			result, err := service.AggregatePlaylistData(ctx, "user123", "base123")
+
+			assert.NoError(err)
+			assert.Len(result.Tracks, 1)
+			assert.Equal(tt.expectedPlayCount, result.Tracks[0].PlayCount)
+			assert.Equal([][]string{{"Artist One"}}, lastFm.artistNames)
+		})
+	}
+}
+
+func TestTrackAggregatorService_AggregateMergedPlaylistData(t *testing.T) {
+	setupBase := func(ctx context.Context, mockBasePlaylistRepo *repomocks.MockBasePlaylistRepository, mockSpotifyClient *clientmocks.MockSpotifyAPI, baseID string, trackIDs ...string) {
+		spotifyID := "spotify_" + baseID
+		mockBasePlaylistRepo.EXPECT().
+			GetByID(ctx, baseID, "user123").
+			Return(testfixtures.NewBasePlaylist().WithID(baseID).WithUserID("user123").WithSpotifyPlaylistID(spotifyID).Build(), nil)
+
+		items := make([]spotifyclient.SpotifyPlaylistTrack, 0, len(trackIDs))
+		for _, id := range trackIDs {
+			items = append(items, spotifyclient.SpotifyPlaylistTrack{
+				Track: &spotifyclient.SpotifyTrack{ID: id, URI: "spotify:track:" + id},
+			})
+		}
+		mockSpotifyClient.EXPECT().
+			GetPlaylistTracks(ctx, spotifyID, MAX_TRACKS, 0).
+			Return(&spotifyclient.SpotifyPlaylistTracksResponse{Items: items}, nil)
+	}
+
+	t.Run("merges base playlists and dedupes tracks", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+		mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
+		service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, createTestLogger())
+
+		setupBase(ctx, mockBasePlaylistRepo, mockSpotifyClient, "base1", "track1", "track2")
+		setupBase(ctx, mockBasePlaylistRepo, mockSpotifyClient, "base2", "track2", "track3")
+		mockSpotifyClient.EXPECT().
+			GetAudioFeatures(gomock.Any(), gomock.Any()).
+			Return([]*spotifyclient.SpotifyAudioFeatures{}, nil).
+			Times(2)
+
+		result, err := service.AggregateMergedPlaylistData(ctx, "user123", []string{"base1", "base2"})
+
+		assert.NoError(err)
+		assert.Equal("base1", result.PlaylistID)
+		assert.Equal([]string{"track1", "track2", "track3"}, result.GetAllTrackIDs())
+		assert.Equal(4, result.APICallCount)
+	})
+
+	t.Run("fails when a base playlist cannot be aggregated", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+		mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
+		service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, createTestLogger())
+
+		mockBasePlaylistRepo.EXPECT().
+			GetByID(ctx, "missing", "user123").
+			Return(nil, errors.New("playlist not found"))
+
+		result, err := service.AggregateMergedPlaylistData(ctx, "user123", []string{"missing", "base2"})
+
+		assert.Error(err)
+		assert.Nil(result)
+		assert.Contains(err.Error(), "failed to aggregate base playlist missing")
+	})
+
+	t.Run("skips deleted base playlists", func(t *testing.T) {
+		assert := require.New(t)
+		ctx := context.Background()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSpotifyClient := clientmocks.NewMockSpotifyAPI(ctrl)
+		mockBasePlaylistRepo := repomocks.NewMockBasePlaylistRepository(ctrl)
+		service := NewTrackAggregatorService(mockSpotifyClient, mockBasePlaylistRepo, createTestLogger())
+
+		mockBasePlaylistRepo.EXPECT().
+			GetByID(ctx, "deleted", "user123").
+			Return(nil, repositories.ErrBasePlaylistNotFound)
+		setupBase(ctx, mockBasePlaylistRepo, mockSpotifyClient, "base2", "track2", "track3")
+		mockSpotifyClient.EXPECT().
+			GetAudioFeatures(gomock.Any(), gomock.Any()).
+			Return([]*spotifyclient.SpotifyAudioFeatures{}, nil)
+
+		result, err := service.AggregateMergedPlaylistData(ctx, "user123", []string{"deleted", "base2"})
+
+		assert.NoError(err)
+		assert.Equal([]string{"track2", "track3"}, result.GetAllTrackIDs())
+	})
+}
@@ -2,55 +2,94 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ngomez18/playlist-router/internal/filters"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
 )
 
 //go:generate mockgen -source=track_router_service.go -destination=mocks/mock_track_router_service.go -package=mocks
 
+// ROUTING_CACHE_MAX_AGE bounds how long cached matches are reused. The snapshot of the base playlist
+// and the filter rules key the cache, but the popularity, genres and play counts of tracks drift
+// without either changing
+const ROUTING_CACHE_MAX_AGE = 24 * time.Hour
+
 type TrackRouterServicer interface {
-	RouteTracksToChildren(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist) (map[string][]string, error)
+	RouteTracksToChildren(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, dedupeStrategy models.DedupeStrategy) (map[string][]string, *models.RoutingReport, error)
+	RouteTrackPages(ctx context.Context, userID, basePlaylistID string, pages <-chan *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, dedupeStrategy models.DedupeStrategy, routed chan<- models.RoutedTracks) (*models.StreamRoutingSummary, error)
 }
 
 type TrackRouterService struct {
-	logger *slog.Logger
+	blocklistRepo    repositories.BlocklistEntryRepository
+	routingCacheRepo repositories.RoutingCacheRepository
+	logger           *slog.Logger
 }
 
-func NewTrackRouterService(logger *slog.Logger) *TrackRouterService {
+func NewTrackRouterService(blocklistRepo repositories.BlocklistEntryRepository, logger *slog.Logger) *TrackRouterService {
 	return &TrackRouterService{
-		logger: logger.With("component", "TrackRouterService"),
+		blocklistRepo: blocklistRepo,
+		logger:        logger.With("component", "TrackRouterService"),
 	}
 }
 
-func (r *TrackRouterService) RouteTracksToChildren(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist) (map[string][]string, error) {
+// WithRoutingCache keeps the tracks matching each child playlist, so routing the same snapshot of a
+// base playlist again skips evaluating the filter rules that didn't change
+func (r *TrackRouterService) WithRoutingCache(routingCacheRepo repositories.RoutingCacheRepository) *TrackRouterService {
+	r.routingCacheRepo = routingCacheRepo
+	return r
+}
+
+// RouteTracksToChildren returns the track URIs routed to each child playlist, keyed by its Spotify
+// playlist ID, along with a report explaining the routing of every track
+func (r *TrackRouterService) RouteTracksToChildren(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, dedupeStrategy models.DedupeStrategy) (map[string][]string, *models.RoutingReport, error) {
 	r.logger.InfoContext(ctx, "routing tracks to child playlists",
 		"total_tracks", len(tracks.Tracks),
 		"child_playlists", len(childPlaylists),
 		"base_playlist", tracks.PlaylistID,
+		"dedupe_strategy", dedupeStrategy,
 	)
 
-	filterEngines := map[string]filters.FilterEngine{}
-
-	for _, child := range childPlaylists {
-		if !child.IsActive {
-			continue
-		}
+	// Merged tracks are kept out by the blocklist of any of the base playlists they come from
+	router, err := r.newTrackRouting(ctx, tracks.UserID, tracks.BasePlaylistIDs(), childPlaylists, dedupeStrategy)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		filterEngines[child.SpotifyPlaylistID] = *filters.NewFilterEngine(child)
+	// Only tracks read from a single base playlist at a known snapshot are cached
+	cached := r.routingCacheRepo != nil && tracks.SnapshotID != "" && len(tracks.SourcePlaylistIDs) == 0
+	if cached {
+		r.useRoutingCache(ctx, tracks, childPlaylists, router)
 	}
 
 	routing := make(map[string][]string)
+	decisions := make([]models.TrackRoutingDecision, len(tracks.Tracks))
+	blocked := 0
 
-	for _, track := range tracks.Tracks {
-		for childPlaylistId, filterEngine := range filterEngines {
-			if filterEngine.MatchTrack(track) {
-				routing[childPlaylistId] = append(routing[childPlaylistId], track.URI)
-			}
+	for i, track := range tracks.Tracks {
+		decisions[i] = router.route(track, routing)
+		if decisions[i].Outcome == models.RoutingOutcomeBlocked {
+			blocked++
 		}
 	}
 
+	if cached {
+		r.storeRoutingCache(ctx, tracks, router)
+	}
+
+	capChildPlaylists(routing, tracks.Tracks, childPlaylists)
+	completeRoutingDecisions(decisions, routing, childPlaylists, router.fallbackChild)
+
 	totalRouted := 0
 	for playlistID, trackIDs := range routing {
 		totalRouted += len(trackIDs)
@@ -62,8 +101,448 @@ func (r *TrackRouterService) RouteTracksToChildren(ctx context.Context, tracks *
 
 	r.logger.InfoContext(ctx, "routing completed",
 		"total_tracks_routed", totalRouted,
+		"blocked_tracks", blocked,
 		"child_playlists_with_matches", len(routing),
 	)
 
-	return routing, nil
+	report := &models.RoutingReport{
+		UserID:         tracks.UserID,
+		BasePlaylistID: tracks.PlaylistID,
+		DedupeStrategy: dedupeStrategy,
+		Tracks:         decisions,
+	}
+
+	return routing, report, nil
+}
+
+// RouteTrackPages routes the pages of a streamed base playlist as they are received from pages,
+// sending the tracks each child playlist gets from a page to routed. Caps need every track to pick
+// from, so max tracks aren't applied, and no routing report is built. routed is closed once pages
+// is closed and drained; on error it is left open and the caller is expected to cancel ctx
+func (r *TrackRouterService) RouteTrackPages(
+	ctx context.Context,
+	userID, basePlaylistID string,
+	pages <-chan *models.PlaylistTracksInfo,
+	childPlaylists []*models.ChildPlaylist,
+	dedupeStrategy models.DedupeStrategy,
+	routed chan<- models.RoutedTracks,
+) (*models.StreamRoutingSummary, error) {
+	r.logger.InfoContext(ctx, "routing streamed tracks to child playlists",
+		"child_playlists", len(childPlaylists),
+		"base_playlist", basePlaylistID,
+		"dedupe_strategy", dedupeStrategy,
+	)
+
+	router, err := r.newTrackRouting(ctx, userID, []string{basePlaylistID}, childPlaylists, dedupeStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.StreamRoutingSummary{}
+	for {
+		var page *models.PlaylistTracksInfo
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case received, ok := <-pages:
+			if !ok {
+				close(routed)
+				r.logger.InfoContext(ctx, "streamed routing completed",
+					"tracks_processed", summary.TracksProcessed,
+					"tracks_unmatched", summary.TracksUnmatched,
+				)
+				return summary, nil
+			}
+			page = received
+		}
+
+		pageRouting := make(map[string][]string)
+		for _, track := range page.Tracks {
+			if decision := router.route(track, pageRouting); len(decision.MatchedChildIDs) == 0 {
+				summary.TracksUnmatched++
+			}
+		}
+		summary.TracksProcessed += len(page.Tracks)
+		summary.APICallCount += page.APICallCount
+
+		// Children are sent in priority order, fallback last, so their writes start in that order
+		for _, spotifyPlaylistID := range router.spotifyPlaylistIDs() {
+			trackURIs, ok := pageRouting[spotifyPlaylistID]
+			if !ok {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case routed <- models.RoutedTracks{SpotifyPlaylistID: spotifyPlaylistID, TrackURIs: trackURIs}:
+			}
+		}
+	}
+}
+
+// trackRouting routes the tracks of a sync one at a time
+type trackRouting struct {
+	blocklist      *models.Blocklist
+	blocklistKey   string // Identifies the blocklist entries in the routing cache
+	filterEngines  []prioritizedFilterEngine
+	fallbackChild  *models.ChildPlaylist
+	dedupeStrategy models.DedupeStrategy
+}
+
+func (r *TrackRouterService) newTrackRouting(
+	ctx context.Context,
+	userID string,
+	basePlaylistIDs []string,
+	childPlaylists []*models.ChildPlaylist,
+	dedupeStrategy models.DedupeStrategy,
+) (*trackRouting, error) {
+	var blocklistEntries []*models.BlocklistEntry
+	for _, basePlaylistID := range basePlaylistIDs {
+		entries, err := r.blocklistRepo.GetByBasePlaylistID(ctx, basePlaylistID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blocklist of base playlist %s: %w", basePlaylistID, err)
+		}
+		blocklistEntries = append(blocklistEntries, entries...)
+	}
+
+	blocklistValues := make([]string, len(blocklistEntries))
+	for i, entry := range blocklistEntries {
+		blocklistValues[i] = string(entry.Type) + ":" + entry.Value
+	}
+	slices.Sort(blocklistValues)
+
+	return &trackRouting{
+		blocklist:      models.NewBlocklist(blocklistEntries),
+		blocklistKey:   strings.Join(blocklistValues, "\n"),
+		filterEngines:  buildPrioritizedFilterEngines(childPlaylists),
+		fallbackChild:  findFallbackChild(childPlaylists),
+		dedupeStrategy: dedupeStrategy,
+	}, nil
+}
+
+// route adds the track to the child playlists it is routed to in routing. The returned decision
+// only lists the matched children, and the outcome of blocked tracks
+func (tr *trackRouting) route(track models.TrackInfo, routing map[string][]string) models.TrackRoutingDecision {
+	decision := models.TrackRoutingDecision{
+		TrackURI:        track.URI,
+		TrackName:       track.Name,
+		MatchedChildIDs: []string{},
+	}
+
+	// Blocked tracks never reach a child playlist, not even the fallback
+	if tr.blocklist.Blocks(track) {
+		decision.Outcome = models.RoutingOutcomeBlocked
+		return decision
+	}
+
+	// Every filter is evaluated, even once the track is routed, so the report lists all matches
+	for i := range tr.filterEngines {
+		engine := &tr.filterEngines[i]
+		if !engine.matchTrack(track) {
+			continue
+		}
+
+		if len(decision.MatchedChildIDs) == 0 || tr.dedupeStrategy != models.DedupeStrategyFirstMatch {
+			routing[engine.spotifyPlaylistID] = append(routing[engine.spotifyPlaylistID], track.URI)
+		}
+		decision.MatchedChildIDs = append(decision.MatchedChildIDs, engine.childPlaylistID)
+	}
+
+	// Tracks matching no filter land in the fallback child instead of being dropped
+	if len(decision.MatchedChildIDs) == 0 && tr.fallbackChild != nil {
+		routing[tr.fallbackChild.SpotifyPlaylistID] = append(routing[tr.fallbackChild.SpotifyPlaylistID], track.URI)
+	}
+
+	return decision
+}
+
+// spotifyPlaylistIDs returns the playlists tracks can be routed to, by priority and fallback last
+func (tr *trackRouting) spotifyPlaylistIDs() []string {
+	spotifyPlaylistIDs := make([]string, 0, len(tr.filterEngines)+1)
+	for _, engine := range tr.filterEngines {
+		spotifyPlaylistIDs = append(spotifyPlaylistIDs, engine.spotifyPlaylistID)
+	}
+	if tr.fallbackChild != nil {
+		spotifyPlaylistIDs = append(spotifyPlaylistIDs, tr.fallbackChild.SpotifyPlaylistID)
+	}
+
+	return spotifyPlaylistIDs
+}
+
+// useRoutingCache replaces the filter engines of the child playlists with the matches cached for the
+// snapshot of the base playlist, when their filter rules and the blocklist didn't change. The other
+// engines record their matches to be cached. A failing lookup only means evaluating every filter
+func (r *TrackRouterService) useRoutingCache(ctx context.Context, tracks *models.PlaylistTracksInfo, childPlaylists []*models.ChildPlaylist, router *trackRouting) {
+	filterRules := make(map[string]*models.MetadataFilters, len(childPlaylists))
+	for _, child := range childPlaylists {
+		filterRules[child.ID] = child.FilterRules
+	}
+
+	childPlaylistIDs := make([]string, len(router.filterEngines))
+	for i, engine := range router.filterEngines {
+		childPlaylistIDs[i] = engine.childPlaylistID
+	}
+
+	entries, err := r.routingCacheRepo.GetByChildPlaylistIDs(ctx, childPlaylistIDs, tracks.UserID)
+	if err != nil {
+		r.logger.WarnContext(ctx, "failed to read routing cache, evaluating every filter", "error", err.Error())
+	}
+
+	entriesByChild := make(map[string]*models.RoutingCacheEntry, len(entries))
+	for _, entry := range entries {
+		entriesByChild[entry.ChildPlaylistID] = entry
+	}
+
+	now := time.Now()
+	hits := 0
+	for i := range router.filterEngines {
+		engine := &router.filterEngines[i]
+		engine.filterHash = routingCacheFilterHash(filterRules[engine.childPlaylistID], router.blocklistKey, now)
+
+		entry, found := entriesByChild[engine.childPlaylistID]
+		if !found || entry.SnapshotID != tracks.SnapshotID || entry.FilterHash != engine.filterHash || now.Sub(entry.Updated) >= ROUTING_CACHE_MAX_AGE {
+			engine.recordMatches = true
+			continue
+		}
+
+		engine.cachedMatches = make(map[string]bool, len(entry.TrackURIs))
+		for _, uri := range entry.TrackURIs {
+			engine.cachedMatches[uri] = true
+		}
+		hits++
+	}
+
+	r.logger.InfoContext(ctx, "routing cache looked up",
+		"base_playlist", tracks.PlaylistID,
+		"snapshot_id", tracks.SnapshotID,
+		"cached_child_playlists", hits,
+		"evaluated_child_playlists", len(router.filterEngines)-hits,
+	)
+}
+
+// storeRoutingCache stores the matches recorded by the filter engines evaluated during the routing
+func (r *TrackRouterService) storeRoutingCache(ctx context.Context, tracks *models.PlaylistTracksInfo, router *trackRouting) {
+	for _, engine := range router.filterEngines {
+		if !engine.recordMatches {
+			continue
+		}
+
+		_, err := r.routingCacheRepo.Upsert(ctx, &models.RoutingCacheEntry{
+			UserID:          tracks.UserID,
+			ChildPlaylistID: engine.childPlaylistID,
+			SnapshotID:      tracks.SnapshotID,
+			FilterHash:      engine.filterHash,
+			TrackURIs:       engine.matched,
+		})
+		if err != nil {
+			r.logger.WarnContext(ctx, "failed to store routing cache",
+				"child_playlist_id", engine.childPlaylistID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+// routingCacheFilterHash identifies what the matches of a child playlist depend on besides the tracks:
+// its filter rules, the blocklist keeping tracks from being evaluated, and the current day for rules
+// with date windows
+func routingCacheFilterHash(filterRules *models.MetadataFilters, blocklistKey string, now time.Time) string {
+	// Filter rules are plain structs, which always encode the same way
+	rules, _ := json.Marshal(filterRules)
+
+	hash := sha256.New()
+	hash.Write(rules)
+	hash.Write([]byte(blocklistKey))
+	if filterRules.IsRelative() {
+		hash.Write([]byte(now.Format(models.DATE_FILTER_LAYOUT)))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// completeRoutingDecisions fills in the child playlists that received each track once the caps are
+// applied, and the outcome of the tracks that aren't blocked
+func completeRoutingDecisions(
+	decisions []models.TrackRoutingDecision,
+	routing map[string][]string,
+	childPlaylists []*models.ChildPlaylist,
+	fallbackChild *models.ChildPlaylist,
+) {
+	routedTo := make(map[string][]string)
+	for _, child := range childPlaylists {
+		for _, uri := range routing[child.SpotifyPlaylistID] {
+			if !slices.Contains(routedTo[uri], child.ID) {
+				routedTo[uri] = append(routedTo[uri], child.ID)
+			}
+		}
+	}
+
+	for i := range decisions {
+		decision := &decisions[i]
+		if decision.Outcome == models.RoutingOutcomeBlocked {
+			decision.RoutedChildIDs = []string{}
+			continue
+		}
+
+		decision.RoutedChildIDs = routedTo[decision.TrackURI]
+		if decision.RoutedChildIDs == nil {
+			decision.RoutedChildIDs = []string{}
+		}
+
+		switch {
+		case len(decision.MatchedChildIDs) == 0 && len(decision.RoutedChildIDs) > 0 && fallbackChild != nil:
+			decision.Outcome = models.RoutingOutcomeFallback
+		case len(decision.MatchedChildIDs) == 0:
+			decision.Outcome = models.RoutingOutcomeUnmatched
+		case len(decision.RoutedChildIDs) == 0:
+			decision.Outcome = models.RoutingOutcomeCapped
+		default:
+			decision.Outcome = models.RoutingOutcomeRouted
+		}
+	}
+}
+
+// capChildPlaylists trims the tracks routed to size capped child playlists down to their max_tracks.
+// Tracks dropped by a cap are not routed anywhere else, even with the first_match dedupe strategy.
+func capChildPlaylists(routing map[string][]string, tracks []models.TrackInfo, childPlaylists []*models.ChildPlaylist) {
+	tracksByURI := make(map[string]*models.TrackInfo, len(tracks))
+	for i := range tracks {
+		tracksByURI[tracks[i].URI] = &tracks[i]
+	}
+
+	for _, child := range childPlaylists {
+		routed := routing[child.SpotifyPlaylistID]
+		if !child.IsActive || child.MaxTracks <= 0 || len(routed) <= child.MaxTracks {
+			continue
+		}
+		routing[child.SpotifyPlaylistID] = selectTracks(routed, tracksByURI, child.MaxTracks, child.SelectionStrategy)
+	}
+}
+
+// selectTracks keeps the maxTracks tracks ranked first by the strategy, in their base playlist order
+func selectTracks(uris []string, tracksByURI map[string]*models.TrackInfo, maxTracks int, strategy models.SelectionStrategy) []string {
+	ranked := slices.Clone(uris)
+	switch strategy {
+	case models.SelectionRandom:
+		rand.Shuffle(len(ranked), func(i, j int) {
+			ranked[i], ranked[j] = ranked[j], ranked[i]
+		})
+	case models.SelectionNewest:
+		// Latest release first, most recently added first on ties
+		slices.SortStableFunc(ranked, func(a, b string) int {
+			if order := tracksByURI[b].ReleaseDate.Compare(tracksByURI[a].ReleaseDate); order != 0 {
+				return order
+			}
+			return tracksByURI[b].AddedAt.Compare(tracksByURI[a].AddedAt)
+		})
+	case models.SelectionMostPlayed:
+		// Tracks without a play count rank after the ones never played
+		playCount := func(uri string) int {
+			if count := tracksByURI[uri].PlayCount; count != nil {
+				return *count
+			}
+			return -1
+		}
+		slices.SortStableFunc(ranked, func(a, b string) int {
+			return playCount(b) - playCount(a)
+		})
+	default:
+		// Capped child playlists without a strategy keep their most popular tracks
+		slices.SortStableFunc(ranked, func(a, b string) int {
+			return tracksByURI[b].Popularity - tracksByURI[a].Popularity
+		})
+	}
+
+	kept := make(map[string]bool, maxTracks)
+	for _, uri := range ranked[:maxTracks] {
+		kept[uri] = true
+	}
+
+	selected := make([]string, 0, maxTracks)
+	for _, uri := range uris {
+		if kept[uri] {
+			selected = append(selected, uri)
+			delete(kept, uri)
+		}
+	}
+
+	return selected
+}
+
+type prioritizedFilterEngine struct {
+	childPlaylistID   string
+	spotifyPlaylistID string
+	filterEngine      *filters.FilterEngine
+
+	// Routing cache: the tracks known to match replace the filter engine when cached, otherwise the
+	// tracks matching it are recorded when recordMatches is set
+	filterHash    string
+	cachedMatches map[string]bool
+	recordMatches bool
+	matched       []string
+}
+
+func (e *prioritizedFilterEngine) matchTrack(track models.TrackInfo) bool {
+	if e.cachedMatches != nil {
+		return e.cachedMatches[track.URI]
+	}
+
+	matches := e.filterEngine.MatchTrack(track)
+	if matches && e.recordMatches {
+		e.matched = append(e.matched, track.URI)
+	}
+
+	return matches
+}
+
+// buildPrioritizedFilterEngines returns the filter engines of the active, non fallback child
+// playlists, ordered from highest to lowest priority
+func buildPrioritizedFilterEngines(childPlaylists []*models.ChildPlaylist) []prioritizedFilterEngine {
+	activeChildren := make([]*models.ChildPlaylist, 0, len(childPlaylists))
+	for _, child := range childPlaylists {
+		if child.IsActive && !child.IsFallback {
+			activeChildren = append(activeChildren, child)
+		}
+	}
+	sortByPriority(activeChildren)
+
+	engines := make([]prioritizedFilterEngine, len(activeChildren))
+	for i, child := range activeChildren {
+		engines[i] = prioritizedFilterEngine{
+			childPlaylistID:   child.ID,
+			spotifyPlaylistID: child.SpotifyPlaylistID,
+			filterEngine:      filters.NewFilterEngine(child),
+		}
+	}
+
+	return engines
+}
+
+// findFallbackChild returns the highest priority active fallback child playlist, if any
+func findFallbackChild(childPlaylists []*models.ChildPlaylist) *models.ChildPlaylist {
+	fallbackChildren := make([]*models.ChildPlaylist, 0, 1)
+	for _, child := range childPlaylists {
+		if child.IsActive && child.IsFallback {
+			fallbackChildren = append(fallbackChildren, child)
+		}
+	}
+
+	if len(fallbackChildren) == 0 {
+		return nil
+	}
+
+	sortByPriority(fallbackChildren)
+	return fallbackChildren[0]
+}
+
+// sortByPriority orders child playlists from highest to lowest priority
+// (lowest priority value first, oldest child on ties)
+func sortByPriority(childPlaylists []*models.ChildPlaylist) {
+	sort.SliceStable(childPlaylists, func(i, j int) bool {
+		if childPlaylists[i].Priority != childPlaylists[j].Priority {
+			return childPlaylists[i].Priority < childPlaylists[j].Priority
+		}
+		return childPlaylists[i].Created.Before(childPlaylists[j].Created)
+	})
 }
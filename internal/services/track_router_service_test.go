@@ -3,16 +3,28 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/require"
 )
 
+// emptyBlocklistRepo returns a blocklist repository without entries for any base playlist
+func emptyBlocklistRepo(t *testing.T) *repositoryMocks.MockBlocklistEntryRepository {
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(setupMockController(t))
+	blocklistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	return blocklistRepo
+}
+
 func TestNewTrackRouterService(t *testing.T) {
 	require := require.New(t)
 
 	logger := createTestLogger()
-	service := NewTrackRouterService(logger)
+	service := NewTrackRouterService(emptyBlocklistRepo(t), logger)
 
 	require.NotNil(service)
 	require.NotNil(service.logger)
@@ -35,12 +47,7 @@ func TestTrackRouterService_RouteTracksToChildren_Success(t *testing.T) {
 				},
 			},
 			childPlaylists: []*models.ChildPlaylist{
-				{
-					ID:                "child1",
-					SpotifyPlaylistID: "spotify-child1",
-					IsActive:          false,
-					FilterRules:       nil,
-				},
+				testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify-child1").Inactive().Build(),
 			},
 			expectedRouting: map[string][]string{},
 			expectedMatches: 0,
@@ -59,17 +66,16 @@ func TestTrackRouterService_RouteTracksToChildren_Success(t *testing.T) {
 				},
 			},
 			childPlaylists: []*models.ChildPlaylist{
-				{
-					ID:                "child1",
-					SpotifyPlaylistID: "spotify-child1",
-					IsActive:          true,
-					FilterRules: &models.MetadataFilters{
+				testfixtures.NewChildPlaylist().
+					WithID("child1").
+					WithSpotifyPlaylistID("spotify-child1").
+					WithFilters(&models.MetadataFilters{
 						Duration: &models.RangeFilter{
 							Min: float64ToPointer(120000),
 							Max: float64ToPointer(240000),
 						},
-					},
-				},
+					}).
+					Build(),
 			},
 			expectedRouting: map[string][]string{
 				"spotify-child1": {"track1"},
@@ -90,22 +96,20 @@ func TestTrackRouterService_RouteTracksToChildren_Success(t *testing.T) {
 				},
 			},
 			childPlaylists: []*models.ChildPlaylist{
-				{
-					ID:                "child1",
-					SpotifyPlaylistID: "spotify-child1",
-					IsActive:          true,
-					FilterRules: &models.MetadataFilters{
+				testfixtures.NewChildPlaylist().
+					WithID("child1").
+					WithSpotifyPlaylistID("spotify-child1").
+					WithFilters(&models.MetadataFilters{
 						Duration: &models.RangeFilter{Min: float64ToPointer(120000)},
-					},
-				},
-				{
-					ID:                "child2",
-					SpotifyPlaylistID: "spotify-child2",
-					IsActive:          true,
-					FilterRules: &models.MetadataFilters{
+					}).
+					Build(),
+				testfixtures.NewChildPlaylist().
+					WithID("child2").
+					WithSpotifyPlaylistID("spotify-child2").
+					WithFilters(&models.MetadataFilters{
 						Genres: &models.SetFilter{Include: []string{"rock"}},
-					},
-				},
+					}).
+					Build(),
 			},
 			expectedRouting: map[string][]string{
 				"spotify-child1": {"track1"},
@@ -126,15 +130,14 @@ func TestTrackRouterService_RouteTracksToChildren_Success(t *testing.T) {
 				},
 			},
 			childPlaylists: []*models.ChildPlaylist{
-				{
-					ID:                "child1",
-					SpotifyPlaylistID: "spotify-child1",
-					IsActive:          true,
-					FilterRules: &models.MetadataFilters{
+				testfixtures.NewChildPlaylist().
+					WithID("child1").
+					WithSpotifyPlaylistID("spotify-child1").
+					WithFilters(&models.MetadataFilters{
 						Duration:   &models.RangeFilter{Min: float64ToPointer(120000)},
 						Popularity: &models.RangeFilter{Min: float64ToPointer(50)},
-					},
-				},
+					}).
+					Build(),
 			},
 			expectedRouting: map[string][]string{},
 			expectedMatches: 0,
@@ -165,15 +168,14 @@ func TestTrackRouterService_RouteTracksToChildren_Success(t *testing.T) {
 				},
 			},
 			childPlaylists: []*models.ChildPlaylist{
-				{
-					ID:                "child1",
-					SpotifyPlaylistID: "spotify-child1",
-					IsActive:          true,
-					FilterRules: &models.MetadataFilters{
+				testfixtures.NewChildPlaylist().
+					WithID("child1").
+					WithSpotifyPlaylistID("spotify-child1").
+					WithFilters(&models.MetadataFilters{
 						Duration: &models.RangeFilter{Min: float64ToPointer(120000)},
 						Genres:   &models.SetFilter{Include: []string{"rock"}},
-					},
-				},
+					}).
+					Build(),
 			},
 			expectedRouting: map[string][]string{
 				"spotify-child1": {"track1"}, // Only track1 matches both criteria
@@ -190,12 +192,7 @@ func TestTrackRouterService_RouteTracksToChildren_Success(t *testing.T) {
 				},
 			},
 			childPlaylists: []*models.ChildPlaylist{
-				{
-					ID:                "child1",
-					SpotifyPlaylistID: "spotify-child1",
-					IsActive:          true,
-					FilterRules:       nil, // No filters
-				},
+				testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify-child1").Build(),
 			},
 			expectedRouting: map[string][]string{
 				"spotify-child1": {"track1", "track2"},
@@ -210,9 +207,9 @@ func TestTrackRouterService_RouteTracksToChildren_Success(t *testing.T) {
 			ctx := context.Background()
 
 			logger := createTestLogger()
-			service := NewTrackRouterService(logger)
+			service := NewTrackRouterService(emptyBlocklistRepo(t), logger)
 
-			routing, err := service.RouteTracksToChildren(ctx, tt.tracks, tt.childPlaylists)
+			routing, _, err := service.RouteTracksToChildren(ctx, tt.tracks, tt.childPlaylists, models.DedupeStrategyAllMatches)
 
 			require.NoError(err)
 			require.Equal(tt.expectedRouting, routing)
@@ -231,7 +228,7 @@ func TestTrackRouterService_RouteTracksToChildren_EmptyInputs(t *testing.T) {
 	require := require.New(t)
 	ctx := context.Background()
 	logger := createTestLogger()
-	service := NewTrackRouterService(logger)
+	service := NewTrackRouterService(emptyBlocklistRepo(t), logger)
 
 	t.Run("empty tracks", func(t *testing.T) {
 		tracks := &models.PlaylistTracksInfo{
@@ -239,15 +236,10 @@ func TestTrackRouterService_RouteTracksToChildren_EmptyInputs(t *testing.T) {
 			Tracks:     []models.TrackInfo{},
 		}
 		childPlaylists := []*models.ChildPlaylist{
-			{
-				ID:                "child1",
-				SpotifyPlaylistID: "spotify-child1",
-				IsActive:          true,
-				FilterRules:       nil,
-			},
+			testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify-child1").Build(),
 		}
 
-		routing, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists)
+		routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
 
 		require.NoError(err)
 		require.Empty(routing)
@@ -262,7 +254,7 @@ func TestTrackRouterService_RouteTracksToChildren_EmptyInputs(t *testing.T) {
 		}
 		childPlaylists := []*models.ChildPlaylist{}
 
-		routing, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists)
+		routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
 
 		require.NoError(err)
 		require.Empty(routing)
@@ -273,7 +265,7 @@ func TestTrackRouterService_RouteTracksToChildren_ComplexFilters(t *testing.T) {
 	require := require.New(t)
 	ctx := context.Background()
 	logger := createTestLogger()
-	service := NewTrackRouterService(logger)
+	service := NewTrackRouterService(emptyBlocklistRepo(t), logger)
 
 	tracks := &models.PlaylistTracksInfo{
 		PlaylistID: "base123",
@@ -293,11 +285,10 @@ func TestTrackRouterService_RouteTracksToChildren_ComplexFilters(t *testing.T) {
 	}
 
 	childPlaylists := []*models.ChildPlaylist{
-		{
-			ID:                "child1",
-			SpotifyPlaylistID: "spotify-child1",
-			IsActive:          true,
-			FilterRules: &models.MetadataFilters{
+		testfixtures.NewChildPlaylist().
+			WithID("child1").
+			WithSpotifyPlaylistID("spotify-child1").
+			WithFilters(&models.MetadataFilters{
 				Duration:         &models.RangeFilter{Min: float64ToPointer(120000), Max: float64ToPointer(240000)},
 				Popularity:       &models.RangeFilter{Min: float64ToPointer(70)},
 				Explicit:         boolToPointer(false),
@@ -306,14 +297,726 @@ func TestTrackRouterService_RouteTracksToChildren_ComplexFilters(t *testing.T) {
 				ArtistPopularity: &models.RangeFilter{Min: float64ToPointer(80)},
 				TrackKeywords:    &models.SetFilter{Include: []string{"love"}},
 				ArtistKeywords:   &models.SetFilter{Include: []string{"beatles"}},
+			}).
+			Build(),
+	}
+
+	routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"spotify-child1": {"track1"},
+	}, routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_ComposedFilters(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", AllGenres: []string{"rock"}, Explicit: false},
+			{URI: "track2", AllGenres: []string{"jazz"}, Explicit: false},
+			{URI: "track3", AllGenres: []string{"rock"}, Explicit: true},
+			{URI: "track4", AllGenres: []string{"pop"}, Explicit: false},
+		},
+	}
+
+	// (rock OR jazz) AND NOT explicit
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().
+			WithID("child1").
+			WithSpotifyPlaylistID("spotify-child1").
+			WithFilters(&models.MetadataFilters{
+				Or: []*models.MetadataFilters{
+					{Genres: &models.SetFilter{Include: []string{"rock"}}},
+					{Genres: &models.SetFilter{Include: []string{"jazz"}}},
+				},
+				Not: &models.MetadataFilters{Explicit: boolToPointer(true)},
+			}).
+			Build(),
+	}
+
+	routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"spotify-child1": {"track1", "track2"},
+	}, routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_ExplicitSplit(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", Explicit: false},
+			{URI: "track2", Explicit: true},
+			{URI: "track3", Explicit: false},
+		},
+	}
+
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("clean").WithSpotifyPlaylistID("spotify-clean").WithFilters(&models.MetadataFilters{Explicit: boolToPointer(false)}).Build(),
+		testfixtures.NewChildPlaylist().WithID("explicit").WithSpotifyPlaylistID("spotify-explicit").WithFilters(&models.MetadataFilters{Explicit: boolToPointer(true)}).Build(),
+	}
+
+	routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"spotify-clean":    {"track1", "track3"},
+		"spotify-explicit": {"track2"},
+	}, routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_ArtistLists(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", Artists: []string{"artist1"}, ArtistNames: []string{"Radiohead"}},
+			{URI: "track2", Artists: []string{"artist2", "artist3"}, ArtistNames: []string{"Massive Attack", "Tricky"}},
+			{URI: "track3", Artists: []string{"artist4"}, ArtistNames: []string{"Portishead"}},
+		},
+	}
+
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("favorites").WithSpotifyPlaylistID("spotify-favorites").WithFilters(&models.MetadataFilters{Artists: &models.SetFilter{Include: []string{"artist1", "Portishead"}}}).Build(),
+		testfixtures.NewChildPlaylist().WithID("no-tricky").WithSpotifyPlaylistID("spotify-no-tricky").WithFilters(&models.MetadataFilters{Artists: &models.SetFilter{Exclude: []string{"spotify:artist:artist3"}}}).Build(),
+	}
+
+	routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"spotify-favorites": {"track1", "track3"},
+		"spotify-no-tricky": {"track1", "track3"},
+	}, routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_DedupeStrategy(t *testing.T) {
+	now := time.Now()
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", DurationMs: 180000},
+			{URI: "track2", DurationMs: 300000},
+		},
+	}
+
+	// Children are passed newest first, as returned by the repository
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child-newest").WithSpotifyPlaylistID("spotify-newest").WithCreated(now).Build(),
+		testfixtures.NewChildPlaylist().
+			WithID("child-oldest").
+			WithSpotifyPlaylistID("spotify-oldest").
+			WithFilters(&models.MetadataFilters{
+				Duration: &models.RangeFilter{Max: float64ToPointer(240000)},
+			}).
+			WithCreated(now.Add(-time.Hour)).
+			Build(),
+	}
+
+	tests := []struct {
+		name            string
+		dedupeStrategy  models.DedupeStrategy
+		expectedRouting map[string][]string
+	}{
+		{
+			name:           "all matches routes track to every matching child",
+			dedupeStrategy: models.DedupeStrategyAllMatches,
+			expectedRouting: map[string][]string{
+				"spotify-oldest": {"track1"},
+				"spotify-newest": {"track1", "track2"},
+			},
+		},
+		{
+			name:           "first match routes track only to highest priority child",
+			dedupeStrategy: models.DedupeStrategyFirstMatch,
+			expectedRouting: map[string][]string{
+				"spotify-oldest": {"track1"},
+				"spotify-newest": {"track2"},
 			},
 		},
 	}
 
-	routing, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+			routing, _, err := service.RouteTracksToChildren(context.Background(), tracks, childPlaylists, tt.dedupeStrategy)
+
+			require.NoError(err)
+			require.Equal(tt.expectedRouting, routing)
+		})
+	}
+}
+
+func TestTrackRouterService_RouteTracksToChildren_Priority(t *testing.T) {
+	require := require.New(t)
+	now := time.Now()
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", DurationMs: 180000},
+		},
+	}
+
+	// The newest child has the highest priority, overriding the creation order
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().
+			WithID("child-oldest").
+			WithSpotifyPlaylistID("spotify-oldest").
+			WithPriority(1).
+			WithCreated(now.Add(-time.Hour)).
+			Build(),
+		testfixtures.NewChildPlaylist().
+			WithID("child-newest").
+			WithSpotifyPlaylistID("spotify-newest").
+			WithPriority(0).
+			WithCreated(now).
+			Build(),
+	}
+
+	service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+	routing, _, err := service.RouteTracksToChildren(context.Background(), tracks, childPlaylists, models.DedupeStrategyFirstMatch)
 
 	require.NoError(err)
 	require.Equal(map[string][]string{
-		"spotify-child1": {"track1"},
+		"spotify-newest": {"track1"},
+	}, routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_Fallback(t *testing.T) {
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", DurationMs: 180000},
+			{URI: "track2", DurationMs: 300000},
+			{URI: "track3", DurationMs: 400000},
+		},
+	}
+
+	shortChild := testfixtures.NewChildPlaylist().
+		WithID("child-short").
+		WithSpotifyPlaylistID("spotify-short").
+		WithFilters(&models.MetadataFilters{
+			Duration: &models.RangeFilter{Max: float64ToPointer(240000)},
+		}).
+		Build()
+
+	tests := []struct {
+		name            string
+		childPlaylists  []*models.ChildPlaylist
+		expectedRouting map[string][]string
+	}{
+		{
+			name:           "unmatched tracks land in the fallback child",
+			childPlaylists: []*models.ChildPlaylist{shortChild, testfixtures.NewChildPlaylist().WithID("child-fallback").WithSpotifyPlaylistID("spotify-fallback").Fallback().Build()},
+			expectedRouting: map[string][]string{
+				"spotify-short":    {"track1"},
+				"spotify-fallback": {"track2", "track3"},
+			},
+		},
+		{
+			name: "inactive fallback child is ignored",
+			childPlaylists: []*models.ChildPlaylist{shortChild, testfixtures.NewChildPlaylist().
+				WithID("child-fallback").
+				WithSpotifyPlaylistID("spotify-fallback").
+				Inactive().
+				Fallback().
+				Build()},
+			expectedRouting: map[string][]string{
+				"spotify-short": {"track1"},
+			},
+		},
+		{
+			name: "highest priority fallback child wins",
+			childPlaylists: []*models.ChildPlaylist{
+				shortChild,
+				testfixtures.NewChildPlaylist().
+					WithID("child-fallback-low").
+					WithSpotifyPlaylistID("spotify-fallback-low").
+					Fallback().
+					WithPriority(2).
+					Build(),
+				testfixtures.NewChildPlaylist().
+					WithID("child-fallback-high").
+					WithSpotifyPlaylistID("spotify-fallback-high").
+					Fallback().
+					WithPriority(1).
+					Build(),
+			},
+			expectedRouting: map[string][]string{
+				"spotify-short":         {"track1"},
+				"spotify-fallback-high": {"track2", "track3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+			routing, _, err := service.RouteTracksToChildren(context.Background(), tracks, tt.childPlaylists, models.DedupeStrategyAllMatches)
+
+			require.NoError(err)
+			require.Equal(tt.expectedRouting, routing)
+		})
+	}
+}
+
+func TestTrackRouterService_RouteTracksToChildren_MaxTracks(t *testing.T) {
+	date := func(year int) time.Time {
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", Popularity: 40, ReleaseDate: date(2024)},
+			{URI: "track2", Popularity: 90, ReleaseDate: date(2001), PlayCount: intToPointer(0)},
+			{URI: "track3", Popularity: 60, ReleaseDate: date(2019), AddedAt: date(2023), PlayCount: intToPointer(12)},
+			{URI: "track4", Popularity: 80, ReleaseDate: date(2019), AddedAt: date(2022), PlayCount: intToPointer(5)},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		child           *models.ChildPlaylist
+		expectedRouting []string
+	}{
+		{
+			name:            "most popular tracks kept in base playlist order",
+			child:           testfixtures.NewChildPlaylist().WithMaxTracks(2, models.SelectionMostPopular).Build(),
+			expectedRouting: []string{"track2", "track4"},
+		},
+		{
+			name:            "strategy defaults to most popular",
+			child:           testfixtures.NewChildPlaylist().WithMaxTracks(1, "").Build(),
+			expectedRouting: []string{"track2"},
+		},
+		{
+			name:            "newest releases, most recently added on ties",
+			child:           testfixtures.NewChildPlaylist().WithMaxTracks(2, models.SelectionNewest).Build(),
+			expectedRouting: []string{"track1", "track3"},
+		},
+		{
+			name:            "most played tracks, tracks without a play count last",
+			child:           testfixtures.NewChildPlaylist().WithMaxTracks(3, models.SelectionMostPlayed).Build(),
+			expectedRouting: []string{"track2", "track3", "track4"},
+		},
+		{
+			name:            "cap larger than the matches",
+			child:           testfixtures.NewChildPlaylist().WithMaxTracks(10, models.SelectionNewest).Build(),
+			expectedRouting: []string{"track1", "track2", "track3", "track4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+			tt.child.SpotifyPlaylistID = "spotify-capped"
+			tt.child.IsActive = true
+
+			routing, _, err := service.RouteTracksToChildren(context.Background(), tracks, []*models.ChildPlaylist{tt.child}, models.DedupeStrategyAllMatches)
+
+			require.NoError(err)
+			require.Equal(tt.expectedRouting, routing["spotify-capped"])
+		})
+	}
+
+	t.Run("random sample", func(t *testing.T) {
+		require := require.New(t)
+		service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+		child := testfixtures.NewChildPlaylist().WithSpotifyPlaylistID("spotify-capped").WithMaxTracks(3, models.SelectionRandom).Build()
+
+		routing, _, err := service.RouteTracksToChildren(context.Background(), tracks, []*models.ChildPlaylist{child}, models.DedupeStrategyAllMatches)
+
+		require.NoError(err)
+		require.Len(routing["spotify-capped"], 3)
+		require.Subset([]string{"track1", "track2", "track3", "track4"}, routing["spotify-capped"])
+	})
+}
+
+func TestTrackRouterService_RouteTracksToChildren_RoutingCache(t *testing.T) {
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		UserID:     "user123",
+		SnapshotID: "snap2",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", DurationMs: 180000},
+			{URI: "track2", DurationMs: 300000},
+		},
+	}
+
+	shortFilters := &models.MetadataFilters{Duration: &models.RangeFilter{Max: float64ToPointer(240000)}}
+	longFilters := &models.MetadataFilters{Duration: &models.RangeFilter{Min: float64ToPointer(240000)}}
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child-short").WithSpotifyPlaylistID("spotify-short").WithFilters(shortFilters).Build(),
+		testfixtures.NewChildPlaylist().WithID("child-long").WithSpotifyPlaylistID("spotify-long").WithFilters(longFilters).Build(),
+	}
+	shortHash := routingCacheFilterHash(shortFilters, "", time.Now())
+
+	tests := []struct {
+		name            string
+		entry           *models.RoutingCacheEntry
+		expectedRouting map[string][]string
+		expectedStored  map[string][]string
+	}{
+		{
+			// The cached matches differ from the filters, showing they aren't evaluated
+			name:            "cached matches replace the filter rules",
+			entry:           &models.RoutingCacheEntry{ChildPlaylistID: "child-short", SnapshotID: "snap2", FilterHash: shortHash, TrackURIs: []string{"track2"}, Updated: time.Now()},
+			expectedRouting: map[string][]string{"spotify-short": {"track2"}, "spotify-long": {"track2"}},
+			expectedStored:  map[string][]string{"child-long": {"track2"}},
+		},
+		{
+			name:            "other snapshot",
+			entry:           &models.RoutingCacheEntry{ChildPlaylistID: "child-short", SnapshotID: "snap1", FilterHash: shortHash, TrackURIs: []string{"track2"}, Updated: time.Now()},
+			expectedRouting: map[string][]string{"spotify-short": {"track1"}, "spotify-long": {"track2"}},
+			expectedStored:  map[string][]string{"child-short": {"track1"}, "child-long": {"track2"}},
+		},
+		{
+			name:            "other filter rules",
+			entry:           &models.RoutingCacheEntry{ChildPlaylistID: "child-short", SnapshotID: "snap2", FilterHash: "old-hash", TrackURIs: []string{"track2"}, Updated: time.Now()},
+			expectedRouting: map[string][]string{"spotify-short": {"track1"}, "spotify-long": {"track2"}},
+			expectedStored:  map[string][]string{"child-short": {"track1"}, "child-long": {"track2"}},
+		},
+		{
+			name:            "expired entry",
+			entry:           &models.RoutingCacheEntry{ChildPlaylistID: "child-short", SnapshotID: "snap2", FilterHash: shortHash, TrackURIs: []string{"track2"}, Updated: time.Now().Add(-ROUTING_CACHE_MAX_AGE)},
+			expectedRouting: map[string][]string{"spotify-short": {"track1"}, "spotify-long": {"track2"}},
+			expectedStored:  map[string][]string{"child-short": {"track1"}, "child-long": {"track2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			routingCacheRepo := repositoryMocks.NewMockRoutingCacheRepository(setupMockController(t))
+			routingCacheRepo.EXPECT().
+				GetByChildPlaylistIDs(ctx, []string{"child-short", "child-long"}, "user123").
+				Return([]*models.RoutingCacheEntry{tt.entry}, nil)
+
+			stored := make(map[string][]string)
+			routingCacheRepo.EXPECT().
+				Upsert(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, entry *models.RoutingCacheEntry) (*models.RoutingCacheEntry, error) {
+					require.Equal("snap2", entry.SnapshotID)
+					stored[entry.ChildPlaylistID] = entry.TrackURIs
+					return entry, nil
+				}).
+				Times(len(tt.expectedStored))
+
+			service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger()).WithRoutingCache(routingCacheRepo)
+			routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+			require.NoError(err)
+			require.Equal(tt.expectedRouting, routing)
+			require.Equal(tt.expectedStored, stored)
+		})
+	}
+}
+
+func TestTrackRouterService_RouteTracksToChildren_RoutingCacheWithoutSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	// Any call to the routing cache fails the test
+	routingCacheRepo := repositoryMocks.NewMockRoutingCacheRepository(setupMockController(t))
+	service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger()).WithRoutingCache(routingCacheRepo)
+
+	tracks := &models.PlaylistTracksInfo{PlaylistID: "base123", Tracks: []models.TrackInfo{{URI: "track1"}}}
+	childPlaylists := []*models.ChildPlaylist{testfixtures.NewChildPlaylist().WithSpotifyPlaylistID("spotify1").Build()}
+
+	routing, _, err := service.RouteTracksToChildren(context.Background(), tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{"spotify1": {"track1"}}, routing)
+}
+
+func TestRoutingCacheFilterHash(t *testing.T) {
+	require := require.New(t)
+
+	withinDays := 30
+	today := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+	filterRules := &models.MetadataFilters{Popularity: &models.RangeFilter{Min: float64ToPointer(50)}}
+	relativeRules := &models.MetadataFilters{AddedDate: &models.DateFilter{WithinDays: &withinDays}}
+
+	require.Equal(routingCacheFilterHash(filterRules, "", today), routingCacheFilterHash(filterRules, "", tomorrow))
+	require.NotEqual(routingCacheFilterHash(filterRules, "", today), routingCacheFilterHash(filterRules, "track:spotify:track:1", today))
+	require.NotEqual(routingCacheFilterHash(filterRules, "", today), routingCacheFilterHash(relativeRules, "", today))
+
+	// Date windows move with the current day
+	require.Equal(routingCacheFilterHash(relativeRules, "", today), routingCacheFilterHash(relativeRules, "", today.Add(time.Hour)))
+	require.NotEqual(routingCacheFilterHash(relativeRules, "", today), routingCacheFilterHash(relativeRules, "", tomorrow))
+}
+
+func TestTrackRouterService_RouteTrackPages(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(setupMockController(t))
+	blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base123", "user123").Return([]*models.BlocklistEntry{
+		{Type: models.BlocklistEntryTypeTrack, Value: "track4"},
+	}, nil)
+	service := NewTrackRouterService(blocklistRepo, createTestLogger())
+
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child-short").WithSpotifyPlaylistID("spotify-short").WithFilters(&models.MetadataFilters{
+			Duration: &models.RangeFilter{Max: float64ToPointer(240000)},
+		}).Build(),
+		testfixtures.NewChildPlaylist().WithID("child-fallback").WithSpotifyPlaylistID("spotify-fallback").Fallback().Build(),
+	}
+
+	pages := make(chan *models.PlaylistTracksInfo, 2)
+	pages <- &models.PlaylistTracksInfo{
+		Tracks:       []models.TrackInfo{{URI: "track1", DurationMs: 180000}, {URI: "track2", DurationMs: 300000}},
+		APICallCount: 2,
+	}
+	pages <- &models.PlaylistTracksInfo{
+		Tracks:       []models.TrackInfo{{URI: "track3", DurationMs: 200000}, {URI: "track4", DurationMs: 200000}},
+		APICallCount: 1,
+	}
+	close(pages)
+
+	routed := make(chan models.RoutedTracks, 10)
+	summary, err := service.RouteTrackPages(ctx, "user123", "base123", pages, childPlaylists, models.DedupeStrategyAllMatches, routed)
+
+	require.NoError(err)
+	require.Equal(&models.StreamRoutingSummary{TracksProcessed: 4, TracksUnmatched: 2, APICallCount: 3}, summary)
+
+	received := make([]models.RoutedTracks, 0)
+	for routedTracks := range routed {
+		received = append(received, routedTracks)
+	}
+	require.Equal([]models.RoutedTracks{
+		{SpotifyPlaylistID: "spotify-short", TrackURIs: []string{"track1"}},
+		{SpotifyPlaylistID: "spotify-fallback", TrackURIs: []string{"track2"}},
+		{SpotifyPlaylistID: "spotify-short", TrackURIs: []string{"track3"}},
+	}, received)
+}
+
+func TestTrackRouterService_RouteTrackPages_Cancelled(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+	cancel()
+	routed := make(chan models.RoutedTracks)
+	summary, err := service.RouteTrackPages(ctx, "user123", "base123", make(chan *models.PlaylistTracksInfo), nil, models.DedupeStrategyAllMatches, routed)
+
+	require.ErrorIs(err, context.Canceled)
+	require.Nil(summary)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_Blocklist(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(setupMockController(t))
+	blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base123", "user123").Return([]*models.BlocklistEntry{
+		{Type: models.BlocklistEntryTypeTrack, Value: "track1"},
+		{Type: models.BlocklistEntryTypeArtist, Value: "artist2"},
+	}, nil)
+	service := NewTrackRouterService(blocklistRepo, createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		UserID:     "user123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", Artists: []string{"artist1"}},
+			{URI: "track2", Artists: []string{"artist1", "artist2"}},
+			{URI: "track3", Artists: []string{"artist1"}},
+			{URI: "track4", Artists: []string{"artist3"}, Explicit: true},
+		},
+	}
+	explicit := false
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify1").WithFilters(&models.MetadataFilters{Explicit: &explicit}).Build(),
+		testfixtures.NewChildPlaylist().WithID("child2").WithSpotifyPlaylistID("spotify2").Fallback().Build(),
+	}
+
+	routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"spotify1": {"track3"},
+		"spotify2": {"track4"},
+	}, routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_MergedBlocklists(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(setupMockController(t))
+	blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base123", "user123").Return([]*models.BlocklistEntry{
+		{Type: models.BlocklistEntryTypeTrack, Value: "track1"},
+	}, nil)
+	blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base456", "user123").Return([]*models.BlocklistEntry{
+		{Type: models.BlocklistEntryTypeArtist, Value: "artist2"},
+	}, nil)
+	service := NewTrackRouterService(blocklistRepo, createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID:        "base123",
+		SourcePlaylistIDs: []string{"base456"},
+		UserID:            "user123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", Artists: []string{"artist1"}},
+			{URI: "track2", Artists: []string{"artist2"}},
+			{URI: "track3", Artists: []string{"artist1"}},
+		},
+	}
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child1").WithSpotifyPlaylistID("spotify1").Build(),
+	}
+
+	routing, _, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{"spotify1": {"track3"}}, routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_BlocklistError(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(setupMockController(t))
+	blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base123", "user123").Return(nil, repositories.ErrDatabaseOperation)
+	service := NewTrackRouterService(blocklistRepo, createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{PlaylistID: "base123", UserID: "user123"}
+	routing, _, err := service.RouteTracksToChildren(ctx, tracks, []*models.ChildPlaylist{}, models.DedupeStrategyAllMatches)
+
+	require.ErrorIs(err, repositories.ErrDatabaseOperation)
+	require.Nil(routing)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_Report(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(setupMockController(t))
+	blocklistRepo.EXPECT().GetByBasePlaylistID(ctx, "base123", "user123").Return([]*models.BlocklistEntry{
+		{Type: models.BlocklistEntryTypeTrack, Value: "track5"},
+	}, nil)
+	service := NewTrackRouterService(blocklistRepo, createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		UserID:     "user123",
+		Tracks: []models.TrackInfo{
+			{URI: "track1", Name: "Short and popular", DurationMs: 180000, Popularity: 90},
+			{URI: "track2", Name: "Short", DurationMs: 200000, Popularity: 10},
+			{URI: "track3", Name: "Long", DurationMs: 300000},
+			{URI: "track4", Name: "Very long", DurationMs: 600000},
+			{URI: "track5", Name: "Blocked", DurationMs: 180000},
+		},
+	}
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().
+			WithID("child-short").
+			WithSpotifyPlaylistID("spotify-short").
+			WithPriority(0).
+			WithMaxTracks(1, "").
+			WithFilters(&models.MetadataFilters{Duration: &models.RangeFilter{Max: float64ToPointer(240000)}}).
+			Build(),
+		testfixtures.NewChildPlaylist().
+			WithID("child-medium").
+			WithSpotifyPlaylistID("spotify-medium").
+			WithPriority(1).
+			WithFilters(&models.MetadataFilters{Duration: &models.RangeFilter{Max: float64ToPointer(400000)}}).
+			Build(),
+		testfixtures.NewChildPlaylist().WithID("child-fallback").WithSpotifyPlaylistID("spotify-fallback").Fallback().Build(),
+	}
+
+	routing, report, err := service.RouteTracksToChildren(ctx, tracks, childPlaylists, models.DedupeStrategyFirstMatch)
+
+	require.NoError(err)
+	require.Equal(map[string][]string{
+		"spotify-short":    {"track1"},
+		"spotify-medium":   {"track3"},
+		"spotify-fallback": {"track4"},
 	}, routing)
+
+	require.Equal("user123", report.UserID)
+	require.Equal("base123", report.BasePlaylistID)
+	require.Equal(models.DedupeStrategyFirstMatch, report.DedupeStrategy)
+	require.Equal([]models.TrackRoutingDecision{
+		{
+			TrackURI:        "track1",
+			TrackName:       "Short and popular",
+			MatchedChildIDs: []string{"child-short", "child-medium"},
+			RoutedChildIDs:  []string{"child-short"},
+			Outcome:         models.RoutingOutcomeRouted,
+		},
+		{
+			// First match keeps the track out of the lower priority match, even once the cap drops it
+			TrackURI:        "track2",
+			TrackName:       "Short",
+			MatchedChildIDs: []string{"child-short", "child-medium"},
+			RoutedChildIDs:  []string{},
+			Outcome:         models.RoutingOutcomeCapped,
+		},
+		{
+			TrackURI:        "track3",
+			TrackName:       "Long",
+			MatchedChildIDs: []string{"child-medium"},
+			RoutedChildIDs:  []string{"child-medium"},
+			Outcome:         models.RoutingOutcomeRouted,
+		},
+		{
+			TrackURI:        "track4",
+			TrackName:       "Very long",
+			MatchedChildIDs: []string{},
+			RoutedChildIDs:  []string{"child-fallback"},
+			Outcome:         models.RoutingOutcomeFallback,
+		},
+		{
+			TrackURI:        "track5",
+			TrackName:       "Blocked",
+			MatchedChildIDs: []string{},
+			RoutedChildIDs:  []string{},
+			Outcome:         models.RoutingOutcomeBlocked,
+		},
+	}, report.Tracks)
+}
+
+func TestTrackRouterService_RouteTracksToChildren_ReportUnmatched(t *testing.T) {
+	require := require.New(t)
+	service := NewTrackRouterService(emptyBlocklistRepo(t), createTestLogger())
+
+	tracks := &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		Tracks:     []models.TrackInfo{{URI: "track1", DurationMs: 600000}},
+	}
+	childPlaylists := []*models.ChildPlaylist{
+		testfixtures.NewChildPlaylist().WithID("child-short").WithSpotifyPlaylistID("spotify-short").WithFilters(&models.MetadataFilters{Duration: &models.RangeFilter{Max: float64ToPointer(240000)}}).Build(),
+	}
+
+	_, report, err := service.RouteTracksToChildren(context.Background(), tracks, childPlaylists, models.DedupeStrategyAllMatches)
+
+	require.NoError(err)
+	require.Len(report.Tracks, 1)
+	require.Equal(models.RoutingOutcomeUnmatched, report.Tracks[0].Outcome)
+	require.Empty(report.Tracks[0].RoutedChildIDs)
 }
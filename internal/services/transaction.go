@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+// runInTransaction runs fn as a unit of work through the transactor, or directly when none is set
+func runInTransaction(ctx context.Context, transactor repositories.Transactor, fn func(ctx context.Context) error) error {
+	if transactor == nil {
+		return fn(ctx)
+	}
+
+	return transactor.RunInTransaction(ctx, fn)
+}
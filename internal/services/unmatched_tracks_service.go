@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+//go:generate mockgen -source=unmatched_tracks_service.go -destination=mocks/mock_unmatched_tracks_service.go -package=mocks
+
+// UNMATCHED_TRACKS_CACHE_TTL bounds how often the whole base playlist is read from Spotify. Editing
+// a child playlist routes the tracks again right away
+const UNMATCHED_TRACKS_CACHE_TTL = 2 * time.Minute
+
+type UnmatchedTracksServicer interface {
+	GetUnmatchedTracks(ctx context.Context, userID, basePlaylistID string) (*models.UnmatchedTracks, error)
+}
+
+type cachedUnmatchedTracks struct {
+	unmatched *models.UnmatchedTracks
+	version   string
+	expiresAt time.Time
+}
+
+type UnmatchedTracksService struct {
+	basePlaylistService  BasePlaylistServicer
+	childPlaylistService ChildPlaylistServicer
+	trackAggregator      TrackAggregatorServicer
+	trackRouter          TrackRouterServicer
+	spotifyAuth          SpotifyAuthProvider
+	logger               *slog.Logger
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedUnmatchedTracks
+	now     func() time.Time
+}
+
+func NewUnmatchedTracksService(
+	basePlaylistService BasePlaylistServicer,
+	childPlaylistService ChildPlaylistServicer,
+	trackAggregator TrackAggregatorServicer,
+	trackRouter TrackRouterServicer,
+	spotifyAuth SpotifyAuthProvider,
+	logger *slog.Logger,
+) *UnmatchedTracksService {
+	return &UnmatchedTracksService{
+		basePlaylistService:  basePlaylistService,
+		childPlaylistService: childPlaylistService,
+		trackAggregator:      trackAggregator,
+		trackRouter:          trackRouter,
+		spotifyAuth:          spotifyAuth,
+		logger:               logger.With("component", "UnmatchedTracksService"),
+		cache:                make(map[string]cachedUnmatchedTracks),
+		now:                  time.Now,
+	}
+}
+
+// GetUnmatchedTracks routes the current tracks of the base playlist the way a sync would and
+// returns the ones no filter rules matched, blocked tracks aside. Results are cached for
+// UNMATCHED_TRACKS_CACHE_TTL as long as the child playlists stay the same
+func (utService *UnmatchedTracksService) GetUnmatchedTracks(ctx context.Context, userID, basePlaylistID string) (*models.UnmatchedTracks, error) {
+	basePlaylist, err := utService.basePlaylistService.GetBasePlaylist(ctx, basePlaylistID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	childPlaylists, err := utService.childPlaylistService.GetChildPlaylistsByBasePlaylistID(ctx, basePlaylist.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := userID + "/" + basePlaylist.ID
+	version := childPlaylistsVersion(basePlaylist, childPlaylists)
+	if unmatched, ok := utService.getCached(cacheKey, version); ok {
+		return unmatched, nil
+	}
+
+	accountCtx, err := contextWithPlaylistAccount(ctx, utService.spotifyAuth, userID, basePlaylist.Provider, basePlaylist.SpotifyIntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	trackData, err := utService.trackAggregator.AggregatePlaylistData(accountCtx, userID, basePlaylist.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate track data: %w", err)
+	}
+
+	_, report, err := utService.trackRouter.RouteTracksToChildren(ctx, trackData, childPlaylists, basePlaylist.DedupeStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to route tracks: %w", err)
+	}
+
+	unmatched := buildUnmatchedTracks(basePlaylist.ID, trackData, report, childPlaylists)
+	unmatched.ComputedAt = utService.now()
+
+	utService.logger.InfoContext(ctx, "computed unmatched tracks", "base_playlist_id", basePlaylist.ID, "total_tracks", unmatched.TotalTracks, "unmatched_tracks", len(unmatched.Tracks))
+
+	utService.setCached(cacheKey, version, unmatched)
+	return unmatched, nil
+}
+
+// buildUnmatchedTracks evaluates the filter rules of every child playlist against the tracks that
+// matched none of them. The report lists the tracks in base playlist order
+func buildUnmatchedTracks(basePlaylistID string, trackData *models.PlaylistTracksInfo, report *models.RoutingReport, childPlaylists []*models.ChildPlaylist) *models.UnmatchedTracks {
+	childNames := make(map[string]string, len(childPlaylists))
+	for _, childPlaylist := range childPlaylists {
+		childNames[childPlaylist.ID] = childPlaylist.Name
+	}
+	filterEngines := buildPrioritizedFilterEngines(childPlaylists)
+
+	unmatched := &models.UnmatchedTracks{
+		BasePlaylistID: basePlaylistID,
+		TotalTracks:    len(trackData.Tracks),
+		Tracks:         []models.UnmatchedTrack{},
+	}
+
+	for i, decision := range report.Tracks {
+		if decision.Outcome != models.RoutingOutcomeUnmatched && decision.Outcome != models.RoutingOutcomeFallback {
+			continue
+		}
+
+		track := trackData.Tracks[i]
+		evaluations := make([]models.ChildFilterEvaluation, len(filterEngines))
+		for j, engine := range filterEngines {
+			evaluations[j] = models.ChildFilterEvaluation{
+				ChildPlaylistID:   engine.childPlaylistID,
+				ChildPlaylistName: childNames[engine.childPlaylistID],
+				FailedRules:       engine.filterEngine.FailedRules(track),
+			}
+		}
+
+		unmatched.Tracks = append(unmatched.Tracks, models.UnmatchedTrack{
+			TrackURI:         track.URI,
+			TrackName:        track.Name,
+			Artists:          track.ArtistNames,
+			Outcome:          decision.Outcome,
+			ChildEvaluations: evaluations,
+		})
+	}
+
+	return unmatched
+}
+
+// childPlaylistsVersion changes whenever the base playlist or any of its child playlists is
+// updated, created or deleted
+func childPlaylistsVersion(basePlaylist *models.BasePlaylist, childPlaylists []*models.ChildPlaylist) string {
+	var version strings.Builder
+	version.WriteString(basePlaylist.Updated.Format(time.RFC3339Nano))
+	for _, childPlaylist := range childPlaylists {
+		fmt.Fprintf(&version, "|%s@%s", childPlaylist.ID, childPlaylist.Updated.Format(time.RFC3339Nano))
+	}
+	return version.String()
+}
+
+func (utService *UnmatchedTracksService) getCached(key, version string) (*models.UnmatchedTracks, bool) {
+	utService.cacheMu.Lock()
+	defer utService.cacheMu.Unlock()
+
+	entry, ok := utService.cache[key]
+	if !ok {
+		return nil, false
+	}
+
+	if entry.version != version || utService.now().After(entry.expiresAt) {
+		delete(utService.cache, key)
+		return nil, false
+	}
+
+	return entry.unmatched, true
+}
+
+func (utService *UnmatchedTracksService) setCached(key, version string, unmatched *models.UnmatchedTracks) {
+	utService.cacheMu.Lock()
+	defer utService.cacheMu.Unlock()
+
+	now := utService.now()
+	for cachedKey, entry := range utService.cache {
+		if now.After(entry.expiresAt) {
+			delete(utService.cache, cachedKey)
+		}
+	}
+
+	utService.cache[key] = cachedUnmatchedTracks{unmatched: unmatched, version: version, expiresAt: now.Add(UNMATCHED_TRACKS_CACHE_TTL)}
+}
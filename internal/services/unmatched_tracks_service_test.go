@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUnmatchedTracksService(t *testing.T, childPlaylists ...*models.ChildPlaylist) (*UnmatchedTracksService, *fakeTrackAggregator, *fakeChildPlaylistService) {
+	ctrl := setupMockController(t)
+
+	blocklistRepo := repositoryMocks.NewMockBlocklistEntryRepository(ctrl)
+	blocklistRepo.EXPECT().GetByBasePlaylistID(gomock.Any(), "base123", "user123").Return([]*models.BlocklistEntry{
+		{Type: models.BlocklistEntryTypeTrack, Value: "spotify:track:blocked"},
+	}, nil).AnyTimes()
+
+	trackAggregator := &fakeTrackAggregator{tracks: &models.PlaylistTracksInfo{
+		PlaylistID: "base123",
+		UserID:     "user123",
+		Tracks: []models.TrackInfo{
+			{URI: "spotify:track:rock", Name: "Rock Song", Popularity: 80, AllGenres: []string{"rock"}, ArtistNames: []string{"Band"}},
+			{URI: "spotify:track:quiet", Name: "Quiet Rock", Popularity: 10, AllGenres: []string{"rock"}, ArtistNames: []string{"Band"}},
+			{URI: "spotify:track:jazz", Name: "Jazz Song", Popularity: 10, AllGenres: []string{"jazz"}, ArtistNames: []string{"Trio"}},
+			{URI: "spotify:track:blocked", Name: "Blocked Song", AllGenres: []string{"jazz"}},
+		},
+	}}
+
+	childPlaylistService := &fakeChildPlaylistService{children: childPlaylists}
+	service := NewUnmatchedTracksService(
+		&fakeBasePlaylistService{basePlaylist: testfixtures.NewBasePlaylist().WithID("base123").Build()},
+		childPlaylistService,
+		trackAggregator,
+		NewTrackRouterService(blocklistRepo, createTestLogger()),
+		&fakeSpotifyAuthProvider{},
+		createTestLogger(),
+	)
+	return service, trackAggregator, childPlaylistService
+}
+
+func popularRockChild() *models.ChildPlaylist {
+	return testfixtures.NewChildPlaylist().WithID("popular_rock").WithName("Popular Rock").WithSpotifyPlaylistID("spotify_popular_rock").WithPriority(1).
+		WithFilters(testfixtures.NewFilters().WithGenres([]string{"rock"}, nil).WithPopularity(50, 100).Build()).Build()
+}
+
+func TestUnmatchedTracksService_GetUnmatchedTracks(t *testing.T) {
+	assert := require.New(t)
+	service, _, _ := setupUnmatchedTracksService(t,
+		popularRockChild(),
+		testfixtures.NewChildPlaylist().WithID("indie").WithName("Indie").WithSpotifyPlaylistID("spotify_indie").WithPriority(2).
+			WithFilters(testfixtures.NewFilters().WithGenres([]string{"indie"}, nil).Build()).Build(),
+	)
+
+	unmatched, err := service.GetUnmatchedTracks(context.Background(), "user123", "base123")
+
+	assert.NoError(err)
+	assert.Equal("base123", unmatched.BasePlaylistID)
+	assert.Equal(4, unmatched.TotalTracks)
+
+	// Routed and blocked tracks are left out
+	assert.Len(unmatched.Tracks, 2)
+	assert.Equal(models.UnmatchedTrack{
+		TrackURI:  "spotify:track:quiet",
+		TrackName: "Quiet Rock",
+		Artists:   []string{"Band"},
+		Outcome:   models.RoutingOutcomeUnmatched,
+		ChildEvaluations: []models.ChildFilterEvaluation{
+			{ChildPlaylistID: "popular_rock", ChildPlaylistName: "Popular Rock", FailedRules: []string{"popularity"}},
+			{ChildPlaylistID: "indie", ChildPlaylistName: "Indie", FailedRules: []string{"genres"}},
+		},
+	}, unmatched.Tracks[0])
+	assert.Equal("spotify:track:jazz", unmatched.Tracks[1].TrackURI)
+	assert.Equal([]string{"popularity", "genres"}, unmatched.Tracks[1].ChildEvaluations[0].FailedRules)
+}
+
+func TestUnmatchedTracksService_GetUnmatchedTracks_Fallback(t *testing.T) {
+	assert := require.New(t)
+	service, _, _ := setupUnmatchedTracksService(t,
+		popularRockChild(),
+		testfixtures.NewChildPlaylist().WithID("other").WithSpotifyPlaylistID("spotify_other").WithPriority(2).Fallback().Build(),
+	)
+
+	unmatched, err := service.GetUnmatchedTracks(context.Background(), "user123", "base123")
+
+	assert.NoError(err)
+	assert.Len(unmatched.Tracks, 2)
+	for _, track := range unmatched.Tracks {
+		assert.Equal(models.RoutingOutcomeFallback, track.Outcome)
+		// The fallback child playlist has no filter rules to evaluate
+		assert.Len(track.ChildEvaluations, 1)
+	}
+}
+
+func TestUnmatchedTracksService_GetUnmatchedTracks_NotFound(t *testing.T) {
+	assert := require.New(t)
+	service, _, _ := setupUnmatchedTracksService(t)
+
+	unmatched, err := service.GetUnmatchedTracks(context.Background(), "user123", "unknown")
+
+	assert.Nil(unmatched)
+	assert.ErrorIs(err, repositories.ErrBasePlaylistNotFound)
+}
+
+func TestUnmatchedTracksService_GetUnmatchedTracks_Cache(t *testing.T) {
+	assert := require.New(t)
+	service, trackAggregator, childPlaylistService := setupUnmatchedTracksService(t, popularRockChild())
+	ctx := context.Background()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	first, err := service.GetUnmatchedTracks(ctx, "user123", "base123")
+	assert.NoError(err)
+
+	now = now.Add(UNMATCHED_TRACKS_CACHE_TTL - time.Second)
+	second, err := service.GetUnmatchedTracks(ctx, "user123", "base123")
+	assert.NoError(err)
+	assert.Same(first, second)
+	assert.Equal(1, trackAggregator.calls)
+
+	// Editing a child playlist routes the tracks again before the cache expires
+	childPlaylistService.children[0].Updated = now
+	third, err := service.GetUnmatchedTracks(ctx, "user123", "base123")
+	assert.NoError(err)
+	assert.NotSame(first, third)
+	assert.Equal(2, trackAggregator.calls)
+
+	now = now.Add(UNMATCHED_TRACKS_CACHE_TTL + time.Second)
+	_, err = service.GetUnmatchedTracks(ctx, "user123", "base123")
+	assert.NoError(err)
+	assert.Equal(3, trackAggregator.calls)
+}
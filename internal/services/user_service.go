@@ -18,6 +18,7 @@ type UserServicer interface {
 	DeleteUser(ctx context.Context, userID string) error
 	GenerateAuthToken(ctx context.Context, userID string) (string, error)
 	ValidateAuthToken(ctx context.Context, token string) (*models.User, error)
+	RevokeAuthTokens(ctx context.Context, userID string) error
 }
 
 type UserService struct {
@@ -115,3 +116,16 @@ func (us *UserService) ValidateAuthToken(ctx context.Context, token string) (*mo
 
 	return user, nil
 }
+
+func (us *UserService) RevokeAuthTokens(ctx context.Context, userID string) error {
+	us.logger.InfoContext(ctx, "revoking auth tokens", "user_id", userID)
+
+	if err := us.userRepo.RevokeAuthTokens(ctx, userID); err != nil {
+		us.logger.ErrorContext(ctx, "failed to revoke auth tokens", "user_id", userID, "error", err.Error())
+		return fmt.Errorf("failed to revoke auth tokens: %w", err)
+	}
+
+	us.logger.InfoContext(ctx, "auth tokens revoked successfully", "user_id", userID)
+
+	return nil
+}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
-	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/ngomez18/playlist-router/internal/models"
 	"github.com/ngomez18/playlist-router/internal/repositories"
 	"github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,34 +35,19 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 		expected *models.User
 	}{
 		{
-			name: "successful creation with complete user data",
-			input: &models.User{
-				Email:    "test@example.com",
-				Username: "testuser",
-				Name:     "Test User",
-			},
-			expected: &models.User{
-				ID:       "user123",
-				Email:    "test@example.com",
-				Username: "testuser",
-				Name:     "Test User",
-				Created:  time.Now(),
-				Updated:  time.Now(),
-			},
+			name:  "successful creation with complete user data",
+			input: testfixtures.NewUser().WithEmail("test@example.com").WithUsername("testuser").WithName("Test User").Build(),
+			expected: testfixtures.NewUser().
+				WithID("user123").
+				WithEmail("test@example.com").
+				WithUsername("testuser").
+				WithName("Test User").
+				Build(),
 		},
 		{
-			name: "successful creation with minimal user data",
-			input: &models.User{
-				Email: "minimal@example.com",
-				Name:  "Minimal User",
-			},
-			expected: &models.User{
-				ID:      "user456",
-				Email:   "minimal@example.com",
-				Name:    "Minimal User",
-				Created: time.Now(),
-				Updated: time.Now(),
-			},
+			name:     "successful creation with minimal user data",
+			input:    testfixtures.NewUser().WithEmail("minimal@example.com").WithName("Minimal User").Build(),
+			expected: testfixtures.NewUser().WithID("user456").WithEmail("minimal@example.com").WithName("Minimal User").Build(),
 		},
 	}
 
@@ -96,20 +81,14 @@ func TestUserService_CreateUser_Error(t *testing.T) {
 		expectedErr string
 	}{
 		{
-			name: "database operation error",
-			input: &models.User{
-				Email: "test@example.com",
-				Name:  "Test User",
-			},
+			name:        "database operation error",
+			input:       testfixtures.NewUser().WithEmail("test@example.com").WithName("Test User").Build(),
 			repoError:   repositories.ErrDatabaseOperation,
 			expectedErr: "failed to create user: unable to complete db operation",
 		},
 		{
-			name: "generic repository error",
-			input: &models.User{
-				Email: "error@example.com",
-				Name:  "Error User",
-			},
+			name:        "generic repository error",
+			input:       testfixtures.NewUser().WithEmail("error@example.com").WithName("Error User").Build(),
 			repoError:   errors.New("connection timeout"),
 			expectedErr: "failed to create user: connection timeout",
 		},
@@ -146,21 +125,19 @@ func TestUserService_UpdateUser_Success(t *testing.T) {
 	logger := createTestLogger()
 	service := NewUserService(mockRepo, logger)
 
-	input := &models.User{
-		ID:       "user123",
-		Email:    "updated@example.com",
-		Username: "updateduser",
-		Name:     "Updated User",
-	}
+	input := testfixtures.NewUser().
+		WithID("user123").
+		WithEmail("updated@example.com").
+		WithUsername("updateduser").
+		WithName("Updated User").
+		Build()
 
-	expected := &models.User{
-		ID:       "user123",
-		Email:    "updated@example.com",
-		Username: "updateduser",
-		Name:     "Updated User",
-		Created:  time.Now().Add(-24 * time.Hour),
-		Updated:  time.Now(),
-	}
+	expected := testfixtures.NewUser().
+		WithID("user123").
+		WithEmail("updated@example.com").
+		WithUsername("updateduser").
+		WithName("Updated User").
+		Build()
 
 	mockRepo.EXPECT().
 		Update(gomock.Any(), input).
@@ -181,22 +158,14 @@ func TestUserService_UpdateUser_Error(t *testing.T) {
 		expectedErr string
 	}{
 		{
-			name: "user not found error",
-			input: &models.User{
-				ID:    "nonexistent",
-				Email: "test@example.com",
-				Name:  "Test User",
-			},
+			name:        "user not found error",
+			input:       testfixtures.NewUser().WithID("nonexistent").WithEmail("test@example.com").WithName("Test User").Build(),
 			repoError:   repositories.ErrUseNotFound,
 			expectedErr: "failed to update user: user not found",
 		},
 		{
-			name: "database operation error",
-			input: &models.User{
-				ID:    "user123",
-				Email: "test@example.com",
-				Name:  "Test User",
-			},
+			name:        "database operation error",
+			input:       testfixtures.NewUser().WithID("user123").WithEmail("test@example.com").WithName("Test User").Build(),
 			repoError:   repositories.ErrDatabaseOperation,
 			expectedErr: "failed to update user: unable to complete db operation",
 		},
@@ -234,14 +203,12 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 	service := NewUserService(mockRepo, logger)
 
 	userID := "user123"
-	expected := &models.User{
-		ID:       userID,
-		Email:    "test@example.com",
-		Username: "testuser",
-		Name:     "Test User",
-		Created:  time.Now().Add(-24 * time.Hour),
-		Updated:  time.Now(),
-	}
+	expected := testfixtures.NewUser().
+		WithID(userID).
+		WithEmail("test@example.com").
+		WithUsername("testuser").
+		WithName("Test User").
+		Build()
 
 	mockRepo.EXPECT().
 		GetByID(gomock.Any(), userID).
@@ -409,11 +376,7 @@ func TestUserService_ValidateAuthToken_Success(t *testing.T) {
 	service := NewUserService(mockRepo, logger)
 
 	token := "valid_token_123"
-	expectedUser := &models.User{
-		ID:    "user123",
-		Email: "test@example.com",
-		Name:  "Test User",
-	}
+	expectedUser := testfixtures.NewUser().WithID("user123").WithEmail("test@example.com").WithName("Test User").Build()
 
 	mockRepo.EXPECT().
 		ValidateAuthToken(gomock.Any(), token).
@@ -463,3 +426,41 @@ func TestUserService_ValidateAuthToken_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_RevokeAuthTokens(t *testing.T) {
+	tests := []struct {
+		name          string
+		repositoryErr error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:          "user not found",
+			repositoryErr: repositories.ErrUseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctrl := setupMockController(t)
+
+			mockRepo := mocks.NewMockUserRepository(ctrl)
+			service := NewUserService(mockRepo, createTestLogger())
+
+			mockRepo.EXPECT().
+				RevokeAuthTokens(gomock.Any(), "user123").
+				Return(tt.repositoryErr).
+				Times(1)
+
+			err := service.RevokeAuthTokens(context.Background(), "user123")
+
+			if tt.repositoryErr != nil {
+				assert.ErrorIs(err, tt.repositoryErr)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	spotifyclient "github.com/ngomez18/playlist-router/internal/clients/spotify"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+//go:generate mockgen -source=youtube_music_account_service.go -destination=mocks/mock_youtube_music_account_service.go -package=mocks
+
+// YOUTUBE_MUSIC_AUTH_STATE_TTL bounds how long a user has to approve the app on Google, once it
+// expires the callback can't link the account anymore
+const YOUTUBE_MUSIC_AUTH_STATE_TTL = 10 * time.Minute
+
+type YouTubeMusicAccountServicer interface {
+	GenerateLinkURL(userID string) (string, error)
+	GetAccount(ctx context.Context, userID string) (*models.YouTubeMusicIntegration, error)
+	HandleCallback(ctx context.Context, code, state string) (*models.YouTubeMusicIntegration, error)
+	ListPlaylists(ctx context.Context, userID string) ([]*models.SpotifyPlaylist, error)
+	Unlink(ctx context.Context, userID string) error
+}
+
+// YouTubeMusicAccountService links the youtube music account of a user, whose channel keeps the
+// base and child playlists living on youtube music. Users keep logging in with spotify
+type YouTubeMusicAccountService struct {
+	integrationRepo   repositories.YouTubeMusicIntegrationRepository
+	basePlaylistRepo  repositories.BasePlaylistRepository
+	childPlaylistRepo repositories.ChildPlaylistRepository
+	youTubeMusic      musicprovider.MusicProvider
+	logger            *slog.Logger
+
+	// authStates maps the state of pending links to the user linking the account and its PKCE
+	// code verifier
+	authStatesMu sync.Mutex
+	authStates   map[string]pendingYouTubeMusicLink
+}
+
+type pendingYouTubeMusicLink struct {
+	userID       string
+	codeVerifier string
+	expiresAt    time.Time
+}
+
+func NewYouTubeMusicAccountService(
+	integrationRepo repositories.YouTubeMusicIntegrationRepository,
+	basePlaylistRepo repositories.BasePlaylistRepository,
+	childPlaylistRepo repositories.ChildPlaylistRepository,
+	youTubeMusic musicprovider.MusicProvider,
+	logger *slog.Logger,
+) *YouTubeMusicAccountService {
+	return &YouTubeMusicAccountService{
+		integrationRepo:   integrationRepo,
+		basePlaylistRepo:  basePlaylistRepo,
+		childPlaylistRepo: childPlaylistRepo,
+		youTubeMusic:      youTubeMusic,
+		logger:            logger.With("component", "YouTubeMusicAccountService"),
+		authStates:        make(map[string]pendingYouTubeMusicLink),
+	}
+}
+
+// GenerateLinkURL returns the Google authorization URL linking a youtube music account to the user.
+// Its state identifies the user when Google redirects back to the callback
+func (s *YouTubeMusicAccountService) GenerateLinkURL(userID string) (string, error) {
+	state, err := generateSecretToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate link state: %w", err)
+	}
+
+	codeVerifier, err := spotifyclient.GeneratePKCEVerifier()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pkce code verifier: %w", err)
+	}
+
+	now := time.Now()
+
+	s.authStatesMu.Lock()
+	for pendingState, link := range s.authStates {
+		if now.After(link.expiresAt) {
+			delete(s.authStates, pendingState)
+		}
+	}
+	s.authStates[state] = pendingYouTubeMusicLink{
+		userID:       userID,
+		codeVerifier: codeVerifier,
+		expiresAt:    now.Add(YOUTUBE_MUSIC_AUTH_STATE_TTL),
+	}
+	s.authStatesMu.Unlock()
+
+	s.logger.Info("generated youtube music link url", "user_id", userID)
+	return s.youTubeMusic.GenerateAuthURL(state, spotifyclient.PKCEChallenge(codeVerifier)), nil
+}
+
+// takeAuthState returns the pending link started with the given state, if any. A state can only be
+// used once
+func (s *YouTubeMusicAccountService) takeAuthState(state string) (pendingYouTubeMusicLink, bool) {
+	s.authStatesMu.Lock()
+	defer s.authStatesMu.Unlock()
+
+	link, ok := s.authStates[state]
+	if !ok {
+		return pendingYouTubeMusicLink{}, false
+	}
+	delete(s.authStates, state)
+
+	if time.Now().After(link.expiresAt) {
+		return pendingYouTubeMusicLink{}, false
+	}
+
+	return link, true
+}
+
+// HandleCallback completes a link, storing the youtube music account as the integration of the user
+// that started it. Linking another account replaces the previous one
+func (s *YouTubeMusicAccountService) HandleCallback(ctx context.Context, code, state string) (*models.YouTubeMusicIntegration, error) {
+	link, ok := s.takeAuthState(state)
+	if !ok {
+		s.logger.WarnContext(ctx, "youtube music callback with unknown state")
+		return nil, ErrYouTubeMusicAuthStateInvalid
+	}
+
+	s.logger.InfoContext(ctx, "handling youtube music callback", "user_id", link.userID)
+
+	tokens, err := s.youTubeMusic.ExchangeCodeForTokens(ctx, code, link.codeVerifier)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to exchange youtube music code", "user_id", link.userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to exchange code for tokens: %w", err)
+	}
+
+	integration := &models.YouTubeMusicIntegration{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    tokens.TokenType,
+		ExpiresAt:    time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second),
+		Scope:        tokens.Scope,
+	}
+
+	// The channel is read with the new tokens, the account isn't stored yet
+	profile, err := s.youTubeMusic.GetUserProfile(requestcontext.ContextWithYouTubeMusicAuth(ctx, integration))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to get youtube music channel", "user_id", link.userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to get youtube music channel: %w", err)
+	}
+	integration.ChannelID = profile.ID
+	integration.DisplayName = profile.Name
+
+	stored, err := s.integrationRepo.CreateOrUpdate(ctx, link.userID, integration)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to store youtube music integration", "user_id", link.userID, "error", err.Error())
+		return nil, fmt.Errorf("failed to store youtube music integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "youtube music account linked successfully", "user_id", link.userID, "channel_id", stored.ChannelID)
+	return stored, nil
+}
+
+// GetAccount returns the youtube music account linked by the user
+func (s *YouTubeMusicAccountService) GetAccount(ctx context.Context, userID string) (*models.YouTubeMusicIntegration, error) {
+	integration, err := s.integrationRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrYouTubeMusicIntegrationNotFound) {
+		return nil, ErrYouTubeMusicIntegrationUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve youtube music integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+// Unlink removes the youtube music account of the user. Accounts still used by playlists must be
+// freed first, since their playlists could not be synced anymore
+func (s *YouTubeMusicAccountService) Unlink(ctx context.Context, userID string) error {
+	s.logger.InfoContext(ctx, "unlinking youtube music account", "user_id", userID)
+
+	basePlaylists, err := s.basePlaylistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve base playlists: %w", err)
+	}
+	for _, basePlaylist := range basePlaylists {
+		if basePlaylist.Provider == models.MusicProviderYouTubeMusic {
+			return ErrYouTubeMusicAccountInUse
+		}
+	}
+
+	err = s.integrationRepo.Delete(ctx, userID)
+	if errors.Is(err, repositories.ErrYouTubeMusicIntegrationNotFound) {
+		return ErrYouTubeMusicIntegrationUnavailable
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete youtube music integration: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "youtube music account unlinked successfully", "user_id", userID)
+	return nil
+}
+
+// ListPlaylists returns the youtube music playlists of the user no base or child playlist is linked
+// to yet
+func (s *YouTubeMusicAccountService) ListPlaylists(ctx context.Context, userID string) ([]*models.SpotifyPlaylist, error) {
+	s.logger.InfoContext(ctx, "fetching user playlists from youtube music", "user_id", userID)
+
+	playlists, err := s.youTubeMusic.GetAllUserPlaylists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredPlaylists, err := unusedPlaylists(ctx, s.basePlaylistRepo, s.childPlaylistRepo, userID, playlists)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to filter user playlists", "user_id", userID, "error", err.Error())
+		return nil, err
+	}
+
+	return filteredPlaylists, nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	musicProviderMocks "github.com/ngomez18/playlist-router/internal/clients/musicprovider/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type youTubeMusicAccountServiceMocks struct {
+	integrationRepo   *repositoryMocks.MockYouTubeMusicIntegrationRepository
+	basePlaylistRepo  *repositoryMocks.MockBasePlaylistRepository
+	childPlaylistRepo *repositoryMocks.MockChildPlaylistRepository
+	youTubeMusic      *musicProviderMocks.MockMusicProvider
+}
+
+func setupYouTubeMusicAccountService(t *testing.T) (*YouTubeMusicAccountService, *youTubeMusicAccountServiceMocks) {
+	ctrl := setupMockController(t)
+	m := &youTubeMusicAccountServiceMocks{
+		integrationRepo:   repositoryMocks.NewMockYouTubeMusicIntegrationRepository(ctrl),
+		basePlaylistRepo:  repositoryMocks.NewMockBasePlaylistRepository(ctrl),
+		childPlaylistRepo: repositoryMocks.NewMockChildPlaylistRepository(ctrl),
+		youTubeMusic:      musicProviderMocks.NewMockMusicProvider(ctrl),
+	}
+
+	service := NewYouTubeMusicAccountService(m.integrationRepo, m.basePlaylistRepo, m.childPlaylistRepo, m.youTubeMusic, createTestLogger())
+	return service, m
+}
+
+// startYouTubeMusicLink generates a link url and returns the state Google would send back
+func startYouTubeMusicLink(t *testing.T, service *YouTubeMusicAccountService, m *youTubeMusicAccountServiceMocks) string {
+	var state string
+	m.youTubeMusic.EXPECT().GenerateAuthURL(gomock.Any(), gomock.Any()).DoAndReturn(func(s, codeChallenge string) string {
+		state = s
+		return "https://accounts.google.com/o/oauth2/v2/auth?" + url.Values{"state": {s}}.Encode()
+	})
+
+	authURL, err := service.GenerateLinkURL("user123")
+	require.NoError(t, err)
+	require.Contains(t, authURL, state)
+
+	return state
+}
+
+func TestYouTubeMusicAccountService_HandleCallback(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupYouTubeMusicAccountService(t)
+	ctx := context.Background()
+
+	state := startYouTubeMusicLink(t, service, m)
+
+	m.youTubeMusic.EXPECT().ExchangeCodeForTokens(ctx, "auth_code", gomock.Not("")).
+		Return(&musicprovider.TokenResponse{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 3600}, nil)
+	m.youTubeMusic.EXPECT().GetUserProfile(gomock.Any()).DoAndReturn(func(ctx context.Context) (*musicprovider.UserProfile, error) {
+		integration, ok := requestcontext.GetYouTubeMusicAuthFromContext(ctx)
+		assert.True(ok)
+		assert.Equal("access", integration.AccessToken)
+		return &musicprovider.UserProfile{ID: "channel123", Name: "My Channel"}, nil
+	})
+	m.integrationRepo.EXPECT().CreateOrUpdate(ctx, "user123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, integration *models.YouTubeMusicIntegration) (*models.YouTubeMusicIntegration, error) {
+			assert.Equal("channel123", integration.ChannelID)
+			assert.Equal("My Channel", integration.DisplayName)
+			assert.Equal("refresh", integration.RefreshToken)
+			assert.WithinDuration(time.Now().Add(time.Hour), integration.ExpiresAt, time.Minute)
+			stored := *integration
+			stored.ID = "yt_integration123"
+			stored.UserID = userID
+			return &stored, nil
+		})
+
+	integration, err := service.HandleCallback(ctx, "auth_code", state)
+
+	assert.NoError(err)
+	assert.Equal("yt_integration123", integration.ID)
+
+	// A state links a single account
+	_, err = service.HandleCallback(ctx, "auth_code", state)
+	assert.ErrorIs(err, ErrYouTubeMusicAuthStateInvalid)
+}
+
+func TestYouTubeMusicAccountService_HandleCallback_UnknownState(t *testing.T) {
+	assert := require.New(t)
+	service, _ := setupYouTubeMusicAccountService(t)
+
+	integration, err := service.HandleCallback(context.Background(), "auth_code", "unknown_state")
+
+	assert.Nil(integration)
+	assert.ErrorIs(err, ErrYouTubeMusicAuthStateInvalid)
+}
+
+func TestYouTubeMusicAccountService_Unlink(t *testing.T) {
+	tests := []struct {
+		name          string
+		basePlaylists []*models.BasePlaylist
+		deleteErr     error
+		expectDelete  bool
+		expectedErr   error
+	}{
+		{
+			name:          "unlinks unused account",
+			basePlaylists: []*models.BasePlaylist{{ID: "base1", Provider: models.MusicProviderSpotify}},
+			expectDelete:  true,
+		},
+		{
+			name:          "account used by a base playlist",
+			basePlaylists: []*models.BasePlaylist{{ID: "base1", Provider: models.MusicProviderYouTubeMusic}},
+			expectedErr:   ErrYouTubeMusicAccountInUse,
+		},
+		{
+			name:         "no linked account",
+			deleteErr:    repositories.ErrYouTubeMusicIntegrationNotFound,
+			expectDelete: true,
+			expectedErr:  ErrYouTubeMusicIntegrationUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			service, m := setupYouTubeMusicAccountService(t)
+			ctx := context.Background()
+
+			m.basePlaylistRepo.EXPECT().GetByUserID(ctx, "user123").Return(tt.basePlaylists, nil)
+			if tt.expectDelete {
+				m.integrationRepo.EXPECT().Delete(ctx, "user123").Return(tt.deleteErr)
+			}
+
+			err := service.Unlink(ctx, "user123")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(err, tt.expectedErr)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestYouTubeMusicAccountService_ListPlaylists(t *testing.T) {
+	assert := require.New(t)
+	service, m := setupYouTubeMusicAccountService(t)
+	ctx := context.Background()
+
+	m.youTubeMusic.EXPECT().GetAllUserPlaylists(ctx).Return([]*musicprovider.Playlist{
+		{ID: "PLbase", Name: "Base"},
+		{ID: "PLfree", Name: "Free"},
+	}, nil)
+	m.basePlaylistRepo.EXPECT().GetByUserID(ctx, "user123").Return([]*models.BasePlaylist{{ID: "base1", SpotifyPlaylistID: "PLbase"}}, nil)
+	m.childPlaylistRepo.EXPECT().GetByBasePlaylistID(ctx, "base1", "user123").Return(nil, nil)
+
+	playlists, err := service.ListPlaylists(ctx, "user123")
+
+	assert.NoError(err)
+	assert.Len(playlists, 1)
+	assert.Equal("PLfree", playlists[0].ID)
+}
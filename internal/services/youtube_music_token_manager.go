@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	youtubemusicclient "github.com/ngomez18/playlist-router/internal/clients/youtubemusic"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+)
+
+// YouTubeMusicTokenManager hands out youtube music integrations with tokens valid for at least the
+// refresh window, refreshing them ahead of expiry. It resolves the credentials of the youtube music
+// client, which acts as the user of the request or sync it is called for
+type YouTubeMusicTokenManager struct {
+	*providerTokenManager[models.YouTubeMusicIntegration]
+}
+
+var _ youtubemusicclient.CredentialsProvider = (*YouTubeMusicTokenManager)(nil)
+
+func NewYouTubeMusicTokenManager(
+	integrationRepo repositories.YouTubeMusicIntegrationRepository,
+	auth musicprovider.Auth,
+	refreshWindow time.Duration,
+	logger *slog.Logger,
+) *YouTubeMusicTokenManager {
+	return &YouTubeMusicTokenManager{
+		providerTokenManager: &providerTokenManager[models.YouTubeMusicIntegration]{
+			provider:        "youtube music",
+			integrationRepo: integrationRepo,
+			auth:            auth,
+			tokens:          youTubeMusicTokens,
+			fromContext:     requestcontext.GetYouTubeMusicAuthFromContext,
+			errUnavailable:  ErrYouTubeMusicIntegrationUnavailable,
+			errRefresh:      ErrYouTubeMusicTokenRefresh,
+			refreshWindow:   refreshWindow,
+			logger:          logger.With("component", "YouTubeMusicTokenManager"),
+		},
+	}
+}
+
+// ContextWithYouTubeMusicAuth loads the youtube music integration of the user, refreshing its tokens
+// when they expire within the refresh window, and returns a context carrying it
+func (tm *YouTubeMusicTokenManager) ContextWithYouTubeMusicAuth(ctx context.Context, userID string) (context.Context, error) {
+	integration, err := tm.refreshIfExpiring(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return requestcontext.ContextWithYouTubeMusicAuth(ctx, integration), nil
+}
+
+func youTubeMusicTokens(integration *models.YouTubeMusicIntegration) oauthTokens {
+	return oauthTokens{
+		id:           integration.ID,
+		accessToken:  &integration.AccessToken,
+		refreshToken: &integration.RefreshToken,
+		expiresAt:    &integration.ExpiresAt,
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ngomez18/playlist-router/internal/clients/musicprovider"
+	musicProviderMocks "github.com/ngomez18/playlist-router/internal/clients/musicprovider/mocks"
+	requestcontext "github.com/ngomez18/playlist-router/internal/context"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories"
+	repositoryMocks "github.com/ngomez18/playlist-router/internal/repositories/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupYouTubeMusicTokenManager(t *testing.T) (*YouTubeMusicTokenManager, *repositoryMocks.MockYouTubeMusicIntegrationRepository, *musicProviderMocks.MockAuth) {
+	ctrl := setupMockController(t)
+	integrationRepo := repositoryMocks.NewMockYouTubeMusicIntegrationRepository(ctrl)
+	auth := musicProviderMocks.NewMockAuth(ctrl)
+
+	return NewYouTubeMusicTokenManager(integrationRepo, auth, 15*time.Minute, createTestLogger()), integrationRepo, auth
+}
+
+func newYouTubeMusicIntegration(expiresIn time.Duration) *models.YouTubeMusicIntegration {
+	return &models.YouTubeMusicIntegration{
+		ID:           "yt_integration123",
+		UserID:       "user123",
+		ChannelID:    "channel123",
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		ExpiresAt:    time.Now().Add(expiresIn),
+	}
+}
+
+func TestYouTubeMusicTokenManager_Credentials_FromContextIntegration(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, _, _ := setupYouTubeMusicTokenManager(t)
+
+	integration := newYouTubeMusicIntegration(time.Hour)
+	ctx := requestcontext.ContextWithYouTubeMusicAuth(context.Background(), integration)
+
+	credentials, err := tokenManager.Credentials(ctx)
+
+	assert.NoError(err)
+	assert.Same(integration, credentials)
+}
+
+func TestYouTubeMusicTokenManager_ContextWithYouTubeMusicAuth_RefreshesExpiringTokens(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, auth := setupYouTubeMusicTokenManager(t)
+
+	integration := newYouTubeMusicIntegration(5 * time.Minute)
+	integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(integration, nil)
+	auth.EXPECT().RefreshTokens(gomock.Any(), "refresh_token_123").Return(&musicprovider.TokenResponse{AccessToken: "new_access", ExpiresIn: 3600}, nil)
+	integrationRepo.EXPECT().
+		UpdateTokens(gomock.Any(), "yt_integration123", &models.YouTubeMusicIntegrationTokenRefresh{AccessToken: "new_access", ExpiresIn: 3600}).
+		Return(nil)
+
+	ctx, err := tokenManager.ContextWithYouTubeMusicAuth(context.Background(), "user123")
+	assert.NoError(err)
+
+	stored, ok := requestcontext.GetYouTubeMusicAuthFromContext(ctx)
+	assert.True(ok)
+	assert.Equal("new_access", stored.AccessToken)
+	assert.Equal("refresh_token_123", stored.RefreshToken)
+	assert.True(stored.ExpiresAt.After(time.Now().Add(50 * time.Minute)))
+}
+
+func TestYouTubeMusicTokenManager_ContextWithYouTubeMusicAuth_NoIntegration(t *testing.T) {
+	assert := require.New(t)
+	tokenManager, integrationRepo, _ := setupYouTubeMusicTokenManager(t)
+
+	integrationRepo.EXPECT().GetByUserID(gomock.Any(), "user123").Return(nil, repositories.ErrYouTubeMusicIntegrationNotFound)
+
+	_, err := tokenManager.ContextWithYouTubeMusicAuth(context.Background(), "user123")
+	assert.ErrorIs(err, ErrYouTubeMusicIntegrationUnavailable)
+}
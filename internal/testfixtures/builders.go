@@ -0,0 +1,508 @@
+package testfixtures
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+)
+
+const (
+	DEFAULT_USER_ID          = "user123"
+	DEFAULT_BASE_PLAYLIST_ID = "base123"
+)
+
+// Now is the fixed timestamp used by every builder, so built models compare equal across calls
+var Now = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+type UserBuilder struct {
+	user models.User
+}
+
+// NewUser starts a user builder with the default test user
+func NewUser() *UserBuilder {
+	return &UserBuilder{user: models.User{
+		ID:       DEFAULT_USER_ID,
+		Username: "testuser",
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Created:  Now,
+		Updated:  Now,
+	}}
+}
+
+func (b *UserBuilder) WithID(id string) *UserBuilder {
+	b.user.ID = id
+	return b
+}
+
+func (b *UserBuilder) WithUsername(username string) *UserBuilder {
+	b.user.Username = username
+	return b
+}
+
+func (b *UserBuilder) WithEmail(email string) *UserBuilder {
+	b.user.Email = email
+	return b
+}
+
+func (b *UserBuilder) WithName(name string) *UserBuilder {
+	b.user.Name = name
+	return b
+}
+
+func (b *UserBuilder) WithDisconnectedSpotifyID(spotifyID string) *UserBuilder {
+	b.user.DisconnectedSpotifyID = spotifyID
+	return b
+}
+
+func (b *UserBuilder) WithRoles(roles ...models.UserRole) *UserBuilder {
+	b.user.Roles = roles
+	return b
+}
+
+func (b *UserBuilder) WithDisabled(disabled bool) *UserBuilder {
+	b.user.Disabled = disabled
+	return b
+}
+
+// Build returns a new user every call, so a builder can be reused as a template
+func (b *UserBuilder) Build() *models.User {
+	user := b.user
+	return &user
+}
+
+type BasePlaylistBuilder struct {
+	basePlaylist models.BasePlaylist
+}
+
+// NewBasePlaylist starts a builder with an active base playlist owned by the default test user
+func NewBasePlaylist() *BasePlaylistBuilder {
+	return &BasePlaylistBuilder{basePlaylist: models.BasePlaylist{
+		ID:                DEFAULT_BASE_PLAYLIST_ID,
+		UserID:            DEFAULT_USER_ID,
+		Name:              "Test Base Playlist",
+		SpotifyPlaylistID: "spotify_base123",
+		IsActive:          true,
+		DedupeStrategy:    models.DedupeStrategyAllMatches,
+		Created:           Now,
+		Updated:           Now,
+	}}
+}
+
+func (b *BasePlaylistBuilder) WithID(id string) *BasePlaylistBuilder {
+	b.basePlaylist.ID = id
+	return b
+}
+
+func (b *BasePlaylistBuilder) WithUserID(userID string) *BasePlaylistBuilder {
+	b.basePlaylist.UserID = userID
+	return b
+}
+
+func (b *BasePlaylistBuilder) WithName(name string) *BasePlaylistBuilder {
+	b.basePlaylist.Name = name
+	return b
+}
+
+func (b *BasePlaylistBuilder) WithSpotifyPlaylistID(spotifyPlaylistID string) *BasePlaylistBuilder {
+	b.basePlaylist.SpotifyPlaylistID = spotifyPlaylistID
+	return b
+}
+
+func (b *BasePlaylistBuilder) WithDedupeStrategy(strategy models.DedupeStrategy) *BasePlaylistBuilder {
+	b.basePlaylist.DedupeStrategy = strategy
+	return b
+}
+
+func (b *BasePlaylistBuilder) WithSpotifyIntegrationID(integrationID string) *BasePlaylistBuilder {
+	b.basePlaylist.SpotifyIntegrationID = integrationID
+	return b
+}
+
+func (b *BasePlaylistBuilder) WithHookToken(token string) *BasePlaylistBuilder {
+	b.basePlaylist.HookToken = token
+	return b
+}
+
+func (b *BasePlaylistBuilder) Inactive() *BasePlaylistBuilder {
+	b.basePlaylist.IsActive = false
+	return b
+}
+
+func (b *BasePlaylistBuilder) Suspended() *BasePlaylistBuilder {
+	b.basePlaylist.IsActive = false
+	b.basePlaylist.Suspended = true
+	return b
+}
+
+func (b *BasePlaylistBuilder) Archived() *BasePlaylistBuilder {
+	b.basePlaylist.Archived = true
+	return b
+}
+
+func (b *BasePlaylistBuilder) Build() *models.BasePlaylist {
+	basePlaylist := b.basePlaylist
+	return &basePlaylist
+}
+
+type ChildPlaylistBuilder struct {
+	childPlaylist models.ChildPlaylist
+}
+
+// NewChildPlaylist starts a builder with an active child playlist of the default base playlist
+func NewChildPlaylist() *ChildPlaylistBuilder {
+	return &ChildPlaylistBuilder{childPlaylist: models.ChildPlaylist{
+		ID:                "child123",
+		UserID:            DEFAULT_USER_ID,
+		BasePlaylistID:    DEFAULT_BASE_PLAYLIST_ID,
+		Name:              "Test Child Playlist",
+		SpotifyPlaylistID: "spotify_child123",
+		IsActive:          true,
+		Created:           Now,
+		Updated:           Now,
+	}}
+}
+
+func (b *ChildPlaylistBuilder) WithID(id string) *ChildPlaylistBuilder {
+	b.childPlaylist.ID = id
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithUserID(userID string) *ChildPlaylistBuilder {
+	b.childPlaylist.UserID = userID
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithBasePlaylistID(basePlaylistID string) *ChildPlaylistBuilder {
+	b.childPlaylist.BasePlaylistID = basePlaylistID
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithName(name string) *ChildPlaylistBuilder {
+	b.childPlaylist.Name = name
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithDescription(description string) *ChildPlaylistBuilder {
+	b.childPlaylist.Description = description
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithSpotifyPlaylistID(spotifyPlaylistID string) *ChildPlaylistBuilder {
+	b.childPlaylist.SpotifyPlaylistID = spotifyPlaylistID
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithFilters(filters *models.MetadataFilters) *ChildPlaylistBuilder {
+	b.childPlaylist.FilterRules = filters
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithPriority(priority int) *ChildPlaylistBuilder {
+	b.childPlaylist.Priority = priority
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithShareToken(token string) *ChildPlaylistBuilder {
+	b.childPlaylist.ShareToken = token
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithMaxTracks(maxTracks int, strategy models.SelectionStrategy) *ChildPlaylistBuilder {
+	b.childPlaylist.MaxTracks = maxTracks
+	b.childPlaylist.SelectionStrategy = strategy
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithSyncStrategy(strategy models.SyncStrategy) *ChildPlaylistBuilder {
+	b.childPlaylist.SyncStrategy = strategy
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithSpotifyIntegrationID(integrationID string) *ChildPlaylistBuilder {
+	b.childPlaylist.SpotifyIntegrationID = integrationID
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithProvider(provider models.MusicProvider) *ChildPlaylistBuilder {
+	b.childPlaylist.Provider = provider
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithRefollowRecreated(refollow bool) *ChildPlaylistBuilder {
+	b.childPlaylist.RefollowRecreated = refollow
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithSourceBasePlaylistIDs(basePlaylistIDs ...string) *ChildPlaylistBuilder {
+	b.childPlaylist.SourceBasePlaylistIDs = basePlaylistIDs
+	return b
+}
+
+func (b *ChildPlaylistBuilder) WithPinnedTracks(trackURIs ...string) *ChildPlaylistBuilder {
+	b.childPlaylist.PinnedTracks = trackURIs
+	return b
+}
+
+// WithCreated sets when the child playlist was created, which orders siblings of the same priority
+func (b *ChildPlaylistBuilder) WithCreated(created time.Time) *ChildPlaylistBuilder {
+	b.childPlaylist.Created = created
+	return b
+}
+
+func (b *ChildPlaylistBuilder) Fallback() *ChildPlaylistBuilder {
+	b.childPlaylist.IsFallback = true
+	return b
+}
+
+func (b *ChildPlaylistBuilder) Inactive() *ChildPlaylistBuilder {
+	b.childPlaylist.IsActive = false
+	return b
+}
+
+func (b *ChildPlaylistBuilder) Suspended() *ChildPlaylistBuilder {
+	b.childPlaylist.IsActive = false
+	b.childPlaylist.Suspended = true
+	return b
+}
+
+func (b *ChildPlaylistBuilder) Build() *models.ChildPlaylist {
+	childPlaylist := b.childPlaylist
+	return &childPlaylist
+}
+
+// FiltersBuilder composes the filter rules of a child playlist one condition at a time
+type FiltersBuilder struct {
+	filters models.MetadataFilters
+}
+
+func NewFilters() *FiltersBuilder {
+	return &FiltersBuilder{}
+}
+
+func (b *FiltersBuilder) WithGenres(include []string, exclude []string) *FiltersBuilder {
+	b.filters.Genres = &models.SetFilter{Include: include, Exclude: exclude}
+	return b
+}
+
+func (b *FiltersBuilder) WithArtists(include []string, exclude []string) *FiltersBuilder {
+	b.filters.Artists = &models.SetFilter{Include: include, Exclude: exclude}
+	return b
+}
+
+func (b *FiltersBuilder) WithPopularity(min, max float64) *FiltersBuilder {
+	b.filters.Popularity = Range(min, max)
+	return b
+}
+
+func (b *FiltersBuilder) WithDuration(minMs, maxMs float64) *FiltersBuilder {
+	b.filters.Duration = Range(minMs, maxMs)
+	return b
+}
+
+func (b *FiltersBuilder) WithReleaseYear(min, max float64) *FiltersBuilder {
+	b.filters.ReleaseYear = Range(min, max)
+	return b
+}
+
+func (b *FiltersBuilder) WithExplicit(explicit bool) *FiltersBuilder {
+	b.filters.Explicit = &explicit
+	return b
+}
+
+func (b *FiltersBuilder) WithTrackName(patterns ...models.TextPattern) *FiltersBuilder {
+	b.filters.TrackName = &models.TextFilter{Include: patterns}
+	return b
+}
+
+func (b *FiltersBuilder) WithAddedDate(filter *models.DateFilter) *FiltersBuilder {
+	b.filters.AddedDate = filter
+	return b
+}
+
+func (b *FiltersBuilder) Or(groups ...*models.MetadataFilters) *FiltersBuilder {
+	b.filters.Or = append(b.filters.Or, groups...)
+	return b
+}
+
+func (b *FiltersBuilder) And(groups ...*models.MetadataFilters) *FiltersBuilder {
+	b.filters.And = append(b.filters.And, groups...)
+	return b
+}
+
+func (b *FiltersBuilder) Not(group *models.MetadataFilters) *FiltersBuilder {
+	b.filters.Not = group
+	return b
+}
+
+func (b *FiltersBuilder) Build() *models.MetadataFilters {
+	filters := b.filters
+	return &filters
+}
+
+// Range builds a range filter with both bounds set
+func Range(min, max float64) *models.RangeFilter {
+	return &models.RangeFilter{Min: &min, Max: &max}
+}
+
+type SyncEventBuilder struct {
+	syncEvent models.SyncEvent
+}
+
+// NewSyncEvent starts a builder with an in progress sync of the default base playlist
+func NewSyncEvent() *SyncEventBuilder {
+	return &SyncEventBuilder{syncEvent: models.SyncEvent{
+		ID:             "sync123",
+		UserID:         DEFAULT_USER_ID,
+		BasePlaylistID: DEFAULT_BASE_PLAYLIST_ID,
+		Status:         models.SyncStatusInProgress,
+		StartedAt:      Now,
+		Created:        Now,
+		Updated:        Now,
+	}}
+}
+
+func (b *SyncEventBuilder) WithID(id string) *SyncEventBuilder {
+	b.syncEvent.ID = id
+	return b
+}
+
+func (b *SyncEventBuilder) WithUserID(userID string) *SyncEventBuilder {
+	b.syncEvent.UserID = userID
+	return b
+}
+
+func (b *SyncEventBuilder) WithBasePlaylistID(basePlaylistID string) *SyncEventBuilder {
+	b.syncEvent.BasePlaylistID = basePlaylistID
+	return b
+}
+
+func (b *SyncEventBuilder) WithChildPlaylistIDs(childPlaylistIDs ...string) *SyncEventBuilder {
+	b.syncEvent.ChildPlaylistIDs = childPlaylistIDs
+	return b
+}
+
+func (b *SyncEventBuilder) WithStatus(status models.SyncStatus) *SyncEventBuilder {
+	b.syncEvent.Status = status
+	return b
+}
+
+func (b *SyncEventBuilder) WithStartedAt(startedAt time.Time) *SyncEventBuilder {
+	b.syncEvent.StartedAt = startedAt
+	return b
+}
+
+func (b *SyncEventBuilder) WithStats(tracksProcessed, tracksUnmatched, totalAPIRequests int) *SyncEventBuilder {
+	b.syncEvent.TracksProcessed = tracksProcessed
+	b.syncEvent.TracksUnmatched = tracksUnmatched
+	b.syncEvent.TotalAPIRequests = totalAPIRequests
+	return b
+}
+
+func (b *SyncEventBuilder) WithChildSyncResults(results ...models.ChildSyncResult) *SyncEventBuilder {
+	b.syncEvent.ChildSyncResults = results
+	return b
+}
+
+func (b *SyncEventBuilder) WithAnomalies(anomalies ...models.SyncAnomaly) *SyncEventBuilder {
+	b.syncEvent.Anomalies = anomalies
+	return b
+}
+
+func (b *SyncEventBuilder) WithCompletedAt(completedAt time.Time) *SyncEventBuilder {
+	b.syncEvent.CompletedAt = &completedAt
+	return b
+}
+
+func (b *SyncEventBuilder) WithErrorMessage(errorMessage string) *SyncEventBuilder {
+	b.syncEvent.ErrorMessage = &errorMessage
+	return b
+}
+
+// Completed marks the sync completed an hour after it started
+func (b *SyncEventBuilder) Completed() *SyncEventBuilder {
+	completedAt := b.syncEvent.StartedAt.Add(time.Hour)
+	b.syncEvent.Status = models.SyncStatusCompleted
+	b.syncEvent.CompletedAt = &completedAt
+	return b
+}
+
+// Failed marks the sync failed an hour after it started with the given error
+func (b *SyncEventBuilder) Failed(errorMessage string) *SyncEventBuilder {
+	completedAt := b.syncEvent.StartedAt.Add(time.Hour)
+	b.syncEvent.Status = models.SyncStatusFailed
+	b.syncEvent.CompletedAt = &completedAt
+	b.syncEvent.ErrorMessage = &errorMessage
+	return b
+}
+
+func (b *SyncEventBuilder) Build() *models.SyncEvent {
+	syncEvent := b.syncEvent
+	syncEvent.ChildPlaylistIDs = slices.Clone(b.syncEvent.ChildPlaylistIDs)
+	syncEvent.ChildSyncResults = slices.Clone(b.syncEvent.ChildSyncResults)
+	syncEvent.Anomalies = slices.Clone(b.syncEvent.Anomalies)
+	return &syncEvent
+}
+
+type SpotifyIntegrationBuilder struct {
+	integration models.SpotifyIntegration
+}
+
+// NewSpotifyIntegration starts a builder with a Bearer token integration of the default test user
+func NewSpotifyIntegration() *SpotifyIntegrationBuilder {
+	return &SpotifyIntegrationBuilder{integration: models.SpotifyIntegration{
+		ID:           "integration123",
+		UserID:       DEFAULT_USER_ID,
+		SpotifyID:    "spotify_user_123",
+		AccessToken:  "access_token_123",
+		RefreshToken: "refresh_token_123",
+		TokenType:    "Bearer",
+		ExpiresAt:    Now.Add(time.Hour),
+		Scope:        "user-read-email",
+		DisplayName:  "Test User",
+		Created:      Now,
+		Updated:      Now,
+	}}
+}
+
+func (b *SpotifyIntegrationBuilder) WithID(id string) *SpotifyIntegrationBuilder {
+	b.integration.ID = id
+	return b
+}
+
+func (b *SpotifyIntegrationBuilder) WithUserID(userID string) *SpotifyIntegrationBuilder {
+	b.integration.UserID = userID
+	return b
+}
+
+func (b *SpotifyIntegrationBuilder) WithSpotifyID(spotifyID string) *SpotifyIntegrationBuilder {
+	b.integration.SpotifyID = spotifyID
+	return b
+}
+
+func (b *SpotifyIntegrationBuilder) WithTokens(accessToken, refreshToken string) *SpotifyIntegrationBuilder {
+	b.integration.AccessToken = accessToken
+	b.integration.RefreshToken = refreshToken
+	return b
+}
+
+func (b *SpotifyIntegrationBuilder) WithExpiresAt(expiresAt time.Time) *SpotifyIntegrationBuilder {
+	b.integration.ExpiresAt = expiresAt
+	return b
+}
+
+func (b *SpotifyIntegrationBuilder) WithScope(scope string) *SpotifyIntegrationBuilder {
+	b.integration.Scope = scope
+	return b
+}
+
+func (b *SpotifyIntegrationBuilder) WithDisplayName(displayName string) *SpotifyIntegrationBuilder {
+	b.integration.DisplayName = displayName
+	return b
+}
+
+func (b *SpotifyIntegrationBuilder) Build() *models.SpotifyIntegration {
+	integration := b.integration
+	return &integration
+}
@@ -0,0 +1,70 @@
+package testfixtures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasePlaylistBuilder_Defaults(t *testing.T) {
+	assert := require.New(t)
+
+	basePlaylist := NewBasePlaylist().Build()
+
+	assert.Equal(DEFAULT_BASE_PLAYLIST_ID, basePlaylist.ID)
+	assert.Equal(DEFAULT_USER_ID, basePlaylist.UserID)
+	assert.True(basePlaylist.IsActive)
+	assert.Equal(models.DedupeStrategyAllMatches, basePlaylist.DedupeStrategy)
+}
+
+func TestChildPlaylistBuilder_WithFilters(t *testing.T) {
+	assert := require.New(t)
+
+	childPlaylist := NewChildPlaylist().
+		WithID("child1").
+		WithBasePlaylistID("base1").
+		WithFilters(NewFilters().WithGenres([]string{"rock"}, nil).WithPopularity(50, 100).Build()).
+		Fallback().
+		Inactive().
+		Build()
+
+	assert.Equal("child1", childPlaylist.ID)
+	assert.Equal("base1", childPlaylist.BasePlaylistID)
+	assert.True(childPlaylist.IsFallback)
+	assert.False(childPlaylist.IsActive)
+	assert.Equal([]string{"rock"}, childPlaylist.FilterRules.Genres.Include)
+	assert.Equal(50.0, *childPlaylist.FilterRules.Popularity.Min)
+	assert.Equal(100.0, *childPlaylist.FilterRules.Popularity.Max)
+}
+
+func TestSyncEventBuilder_States(t *testing.T) {
+	assert := require.New(t)
+
+	completed := NewSyncEvent().WithStats(10, 2, 5).Completed().Build()
+	assert.Equal(models.SyncStatusCompleted, completed.Status)
+	assert.Equal(Now.Add(time.Hour), *completed.CompletedAt)
+	assert.Equal(10, completed.TracksProcessed)
+	assert.Nil(completed.ErrorMessage)
+
+	failed := NewSyncEvent().Failed("boom").Build()
+	assert.Equal(models.SyncStatusFailed, failed.Status)
+	assert.Equal("boom", *failed.ErrorMessage)
+}
+
+func TestBuilders_BuildReturnsIndependentCopies(t *testing.T) {
+	assert := require.New(t)
+
+	builder := NewSyncEvent().WithChildPlaylistIDs("child1")
+	first := builder.Build()
+	first.ChildPlaylistIDs[0] = "changed"
+	first.Status = models.SyncStatusFailed
+
+	second := builder.Build()
+	assert.Equal([]string{"child1"}, second.ChildPlaylistIDs)
+	assert.Equal(models.SyncStatusInProgress, second.Status)
+
+	user := NewUser().WithID("user1")
+	assert.NotSame(user.Build(), user.Build())
+}
@@ -0,0 +1,175 @@
+package testfixtures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// Seeders write built models straight into the PocketBase collections, bypassing the repositories so
+// tests can set up any state (inactive playlists, completed syncs, ...) in a single call. The
+// collections must already exist. PocketBase generates the record IDs, the returned models carry them.
+
+func SeedUser(t testing.TB, app core.App, user *models.User) *models.User {
+	t.Helper()
+
+	record := newRecord(t, app, "users")
+	record.Set("email", user.Email)
+	record.Set("name", user.Name)
+	record.Set("username", user.Username)
+	record.Set("roles", user.Roles)
+	record.Set("disabled", user.Disabled)
+	record.Set("password", "test123456")
+	record.Set("passwordConfirm", "test123456")
+	save(t, app, record)
+
+	seeded := *user
+	seeded.ID = record.Id
+	return &seeded
+}
+
+func SeedBasePlaylist(t testing.TB, app core.App, basePlaylist *models.BasePlaylist) *models.BasePlaylist {
+	t.Helper()
+
+	record := newRecord(t, app, "base_playlists")
+	record.Set("user_id", basePlaylist.UserID)
+	record.Set("name", basePlaylist.Name)
+	record.Set("spotify_playlist_id", basePlaylist.SpotifyPlaylistID)
+	record.Set("spotify_integration_id", basePlaylist.SpotifyIntegrationID)
+	record.Set("is_active", basePlaylist.IsActive)
+	record.Set("suspended", basePlaylist.Suspended)
+	record.Set("archived", basePlaylist.Archived)
+	record.Set("dedupe_strategy", string(basePlaylist.DedupeStrategy))
+	record.Set("hook_token", basePlaylist.HookToken)
+	save(t, app, record)
+
+	seeded := *basePlaylist
+	seeded.ID = record.Id
+	return &seeded
+}
+
+func SeedChildPlaylist(t testing.TB, app core.App, childPlaylist *models.ChildPlaylist) *models.ChildPlaylist {
+	t.Helper()
+
+	record := newRecord(t, app, "child_playlists")
+	record.Set("user_id", childPlaylist.UserID)
+	record.Set("base_playlist_id", childPlaylist.BasePlaylistID)
+	record.Set("name", childPlaylist.Name)
+	record.Set("description", childPlaylist.Description)
+	record.Set("spotify_playlist_id", childPlaylist.SpotifyPlaylistID)
+	record.Set("spotify_integration_id", childPlaylist.SpotifyIntegrationID)
+	record.Set("is_active", childPlaylist.IsActive)
+	record.Set("suspended", childPlaylist.Suspended)
+	record.Set("is_fallback", childPlaylist.IsFallback)
+	record.Set("priority", childPlaylist.Priority)
+	record.Set("share_token", childPlaylist.ShareToken)
+	record.Set("max_tracks", childPlaylist.MaxTracks)
+	record.Set("selection_strategy", string(childPlaylist.SelectionStrategy))
+	record.Set("sync_strategy", string(childPlaylist.SyncStrategy))
+	if len(childPlaylist.SourceBasePlaylistIDs) > 0 {
+		record.Set("source_base_playlist_ids", childPlaylist.SourceBasePlaylistIDs)
+	}
+	if len(childPlaylist.PinnedTracks) > 0 {
+		record.Set("pinned_tracks", childPlaylist.PinnedTracks)
+	}
+	if childPlaylist.FilterRules != nil {
+		record.Set("filter_rules", marshal(t, childPlaylist.FilterRules))
+	}
+	save(t, app, record)
+
+	seeded := *childPlaylist
+	seeded.ID = record.Id
+	return &seeded
+}
+
+func SeedSyncEvent(t testing.TB, app core.App, syncEvent *models.SyncEvent) *models.SyncEvent {
+	t.Helper()
+
+	record := newRecord(t, app, "sync_events")
+	record.Set("user_id", syncEvent.UserID)
+	record.Set("base_playlist_id", syncEvent.BasePlaylistID)
+	record.Set("status", string(syncEvent.Status))
+	record.Set("phase", string(syncEvent.Phase))
+	record.Set("started_at", syncEvent.StartedAt)
+	record.Set("tracks_processed", syncEvent.TracksProcessed)
+	record.Set("tracks_unmatched", syncEvent.TracksUnmatched)
+	record.Set("total_api_requests", syncEvent.TotalAPIRequests)
+	if len(syncEvent.ChildPlaylistIDs) > 0 {
+		record.Set("child_playlist_ids", marshal(t, syncEvent.ChildPlaylistIDs))
+	}
+	if syncEvent.ChildSyncResults != nil {
+		record.Set("child_sync_results", syncEvent.ChildSyncResults)
+	}
+	if syncEvent.Anomalies != nil {
+		record.Set("anomalies", syncEvent.Anomalies)
+	}
+	if syncEvent.Profile != nil {
+		record.Set("profile", syncEvent.Profile)
+	}
+	if syncEvent.CompletedAt != nil {
+		record.Set("completed_at", *syncEvent.CompletedAt)
+	}
+	if syncEvent.ErrorMessage != nil {
+		record.Set("error_message", *syncEvent.ErrorMessage)
+	}
+	if syncEvent.HeartbeatAt != nil {
+		record.Set("heartbeat_at", *syncEvent.HeartbeatAt)
+	}
+	save(t, app, record)
+
+	seeded := *syncEvent
+	seeded.ID = record.Id
+	return &seeded
+}
+
+// SeedSpotifyIntegration stores the tokens as given, without going through the token cipher
+func SeedSpotifyIntegration(t testing.TB, app core.App, integration *models.SpotifyIntegration) *models.SpotifyIntegration {
+	t.Helper()
+
+	record := newRecord(t, app, "spotify_integrations")
+	record.Set("user", integration.UserID)
+	record.Set("spotify_id", integration.SpotifyID)
+	record.Set("access_token", integration.AccessToken)
+	record.Set("refresh_token", integration.RefreshToken)
+	record.Set("token_type", integration.TokenType)
+	record.Set("expires_at", integration.ExpiresAt)
+	record.Set("scope", integration.Scope)
+	record.Set("display_name", integration.DisplayName)
+	save(t, app, record)
+
+	seeded := *integration
+	seeded.ID = record.Id
+	return &seeded
+}
+
+func newRecord(t testing.TB, app core.App, collectionName string) *core.Record {
+	t.Helper()
+
+	collection, err := app.FindCollectionByNameOrId(collectionName)
+	if err != nil {
+		t.Fatalf("%s collection not found: %v", collectionName, err)
+	}
+
+	return core.NewRecord(collection)
+}
+
+func save(t testing.TB, app core.App, record *core.Record) {
+	t.Helper()
+
+	if err := app.Save(record); err != nil {
+		t.Fatalf("failed to seed %s record: %v", record.Collection().Name, err)
+	}
+}
+
+func marshal(t testing.TB, value any) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to serialize seed value: %v", err)
+	}
+
+	return string(data)
+}
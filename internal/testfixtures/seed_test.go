@@ -0,0 +1,114 @@
+package testfixtures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/ngomez18/playlist-router/internal/models"
+	"github.com/ngomez18/playlist-router/internal/repositories/pb"
+	"github.com/ngomez18/playlist-router/internal/testfixtures"
+	"github.com/pocketbase/pocketbase"
+	"github.com/stretchr/testify/require"
+)
+
+func newSeededApp(t *testing.T) *pocketbase.PocketBase {
+	t.Helper()
+
+	app := pocketbase.NewWithConfig(pocketbase.Config{DefaultDataDir: t.TempDir()})
+	if err := app.Bootstrap(); err != nil {
+		t.Fatalf("failed to bootstrap test app: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.AdminEmail = "admin@example.com"
+	cfg.AdminPassword = "admin123456"
+	if err := pb.InitCollections(app, cfg); err != nil {
+		t.Fatalf("failed to create collections: %v", err)
+	}
+
+	return app
+}
+
+func TestSeed_RoundTripsThroughRepositories(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	app := newSeededApp(t)
+
+	user := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("seed@example.com").Build())
+	basePlaylist := testfixtures.SeedBasePlaylist(t, app, testfixtures.NewBasePlaylist().WithUserID(user.ID).Inactive().Build())
+	childPlaylist := testfixtures.SeedChildPlaylist(t, app, testfixtures.NewChildPlaylist().
+		WithUserID(user.ID).
+		WithBasePlaylistID(basePlaylist.ID).
+		WithFilters(testfixtures.NewFilters().WithGenres([]string{"rock"}, nil).Build()).
+		Build())
+	syncEvent := testfixtures.SeedSyncEvent(t, app, testfixtures.NewSyncEvent().
+		WithUserID(user.ID).
+		WithBasePlaylistID(basePlaylist.ID).
+		WithChildPlaylistIDs(childPlaylist.ID).
+		Completed().
+		Build())
+
+	storedUser, err := pb.NewUserRepositoryPocketbase(app).GetByID(ctx, user.ID)
+	assert.NoError(err)
+	assert.Equal("seed@example.com", storedUser.Email)
+
+	storedBase, err := pb.NewBasePlaylistRepositoryPocketbase(app).GetByID(ctx, basePlaylist.ID, user.ID)
+	assert.NoError(err)
+	assert.False(storedBase.IsActive)
+	assert.Equal(basePlaylist.SpotifyPlaylistID, storedBase.SpotifyPlaylistID)
+
+	storedChild, err := pb.NewChildPlaylistRepositoryPocketbase(app).GetByID(ctx, childPlaylist.ID, user.ID)
+	assert.NoError(err)
+	assert.Equal(basePlaylist.ID, storedChild.BasePlaylistID)
+	assert.Equal([]string{"rock"}, storedChild.FilterRules.Genres.Include)
+
+	storedSync, err := pb.NewSyncEventRepositoryPocketbase(app).GetByID(ctx, syncEvent.ID)
+	assert.NoError(err)
+	assert.Equal(models.SyncStatusCompleted, storedSync.Status)
+	assert.Equal([]string{childPlaylist.ID}, storedSync.ChildPlaylistIDs)
+	assert.NotNil(storedSync.CompletedAt)
+}
+
+func TestSeed_MergeChildPlaylistSources(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+	app := newSeededApp(t)
+
+	user := testfixtures.SeedUser(t, app, testfixtures.NewUser().WithEmail("merge@example.com").Build())
+	basePlaylist := testfixtures.SeedBasePlaylist(t, app, testfixtures.NewBasePlaylist().WithUserID(user.ID).Build())
+	firstSource := testfixtures.SeedBasePlaylist(t, app, testfixtures.NewBasePlaylist().WithUserID(user.ID).WithSpotifyPlaylistID("spotify_source1").Build())
+	secondSource := testfixtures.SeedBasePlaylist(t, app, testfixtures.NewBasePlaylist().WithUserID(user.ID).WithSpotifyPlaylistID("spotify_source2").Build())
+	childPlaylist := testfixtures.SeedChildPlaylist(t, app, testfixtures.NewChildPlaylist().
+		WithUserID(user.ID).
+		WithBasePlaylistID(basePlaylist.ID).
+		WithSourceBasePlaylistIDs(firstSource.ID, secondSource.ID).
+		Build())
+
+	childRepo := pb.NewChildPlaylistRepositoryPocketbase(app)
+	storedChild, err := childRepo.GetByID(ctx, childPlaylist.ID, user.ID)
+	assert.NoError(err)
+	assert.Equal([]string{firstSource.ID, secondSource.ID}, storedChild.SourceBasePlaylistIDs)
+
+	merges, err := childRepo.GetBySourceBasePlaylistID(ctx, secondSource.ID, user.ID)
+	assert.NoError(err)
+	assert.Len(merges, 1)
+	assert.Equal(childPlaylist.ID, merges[0].ID)
+
+	// A deleted source stays referenced until purged, which only drops it from the merge child
+	baseRepo := pb.NewBasePlaylistRepositoryPocketbase(app)
+	assert.NoError(baseRepo.Delete(ctx, firstSource.ID, user.ID))
+
+	storedChild, err = childRepo.GetByID(ctx, childPlaylist.ID, user.ID)
+	assert.NoError(err)
+	assert.Equal([]string{firstSource.ID, secondSource.ID}, storedChild.SourceBasePlaylistIDs)
+
+	purged, err := baseRepo.Purge(ctx, time.Now().Add(time.Minute))
+	assert.NoError(err)
+	assert.Equal(1, purged)
+
+	storedChild, err = childRepo.GetByID(ctx, childPlaylist.ID, user.ID)
+	assert.NoError(err)
+	assert.Equal([]string{secondSource.ID}, storedChild.SourceBasePlaylistIDs)
+}
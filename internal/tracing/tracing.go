@@ -0,0 +1,82 @@
+// Package tracing sets up the OpenTelemetry traces of the app: a server span for each request,
+// spans for the steps of each sync, and client spans for the calls to spotify. Without an
+// exporter configured the global tracer provider is a no-op, and so is every span.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TRACER_NAME = "github.com/ngomez18/playlist-router"
+
+// Tracer returns the tracer of the app from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(TRACER_NAME)
+}
+
+// Setup installs the global tracer provider exporting to the OTLP collector of cfg. The returned
+// shutdown flushes the pending spans, it must be called before the app exits
+func Setup(ctx context.Context, cfg config.TracingConfig, appEnv string) (func(context.Context) error, error) {
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", cfg.ServiceName),
+			attribute.String("deployment.environment", appEnv),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Middleware opens a server span for each request, named after its route pattern so the requests
+// of a route are grouped together. Requests to the untraced patterns, e.g. long lived connections,
+// get no span
+func Middleware(untracedPatterns ...string) func(http.Handler) http.Handler {
+	return otelhttp.NewMiddleware("http",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			if r.Pattern == "" {
+				return r.Method
+			}
+			return r.Pattern
+		}),
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !slices.Contains(untracedPatterns, r.Pattern)
+		}),
+	)
+}
+
+// Transport opens a client span for each request sent through base, a child of the span of the
+// request context
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Host
+		}),
+	)
+}
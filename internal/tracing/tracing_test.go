@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ngomez18/playlist-router/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a global tracer provider keeping the ended spans, for the length of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func spanNames(recorder *tracetest.SpanRecorder) []string {
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+
+	return names
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	assert := require.New(t)
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{}, "dev")
+
+	assert.NoError(err)
+	assert.NoError(shutdown(context.Background()))
+	assert.Equal(previous, otel.GetTracerProvider())
+}
+
+func TestMiddleware(t *testing.T) {
+	assert := require.New(t)
+	recorder := recordSpans(t)
+
+	var handlerSpan trace.SpanContext
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/base_playlist/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// As in the app the middleware runs once the route is matched, so the pattern is known
+	middleware := Middleware("GET /health")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pattern := mux.Handler(r)
+		r.Pattern = pattern
+		middleware(route).ServeHTTP(w, r)
+	})
+
+	for _, path := range []string{"/api/base_playlist/abc123", "/health"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal([]string{"GET /api/base_playlist/{id}"}, spanNames(recorder))
+	assert.Equal(recorder.Ended()[0].SpanContext().SpanID(), handlerSpan.SpanID())
+	assert.Equal(trace.SpanKindServer, recorder.Ended()[0].SpanKind())
+}
+
+func TestTransport(t *testing.T) {
+	assert := require.New(t)
+	recorder := recordSpans(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	ctx, parent := Tracer().Start(context.Background(), "sync")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/me", nil)
+	assert.NoError(err)
+
+	client := &http.Client{Transport: Transport(http.DefaultTransport)}
+	resp, err := client.Do(req)
+	assert.NoError(err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(spans, 2)
+	assert.Equal("GET "+req.URL.Host, spans[0].Name())
+	assert.Equal(trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(traceparent, spans[0].SpanContext().TraceID().String())
+}
@@ -36,10 +36,16 @@ export function AuthProvider({ children }: AuthProviderProps) {
     validateToken();
   };
 
-  const logout = () => {
-    removeAuthToken();
-    setUser(null);
-    window.location.reload();
+  const logout = async () => {
+    try {
+      await apiClient.logout();
+    } catch {
+      // The session could not be revoked server side, the local token is dropped anyway
+    } finally {
+      removeAuthToken();
+      setUser(null);
+      window.location.reload();
+    }
   };
 
   useEffect(() => {
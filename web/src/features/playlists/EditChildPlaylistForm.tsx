@@ -1,6 +1,6 @@
 import { useState } from 'react'
 import { useMutation } from '@tanstack/react-query'
-import { apiClient } from '../../lib/api'
+import { apiClient, ApiError } from '../../lib/api'
 import { Button, Input, Alert } from '../../components/ui'
 import type { 
   ChildPlaylist, 
@@ -87,6 +87,7 @@ export function EditChildPlaylistForm({
       description: formData.description?.trim() || undefined,
       is_active: formData.is_active,
       filter_rules: cleanedFilters,
+      expected_updated: childPlaylist.updated,
     }
     
     updateMutation.mutate(updateData)
@@ -184,7 +185,9 @@ export function EditChildPlaylistForm({
 
         {updateMutation.error && (
           <Alert type="error" className="mb-4">
-            {updateMutation.error.message || 'Failed to update child playlist'}
+            {updateMutation.error instanceof ApiError && updateMutation.error.code === 'child_playlist_modified'
+              ? 'This child playlist was changed in another tab. Reload it before saving your edits again.'
+              : updateMutation.error.message || 'Failed to update child playlist'}
           </Alert>
         )}
 
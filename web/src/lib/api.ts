@@ -1,9 +1,15 @@
 import { getAuthToken, removeAuthToken } from './auth'
-import type { User } from '../types/auth'
+import type { User, NotificationPreferences, UpdateNotificationPreferencesRequest } from '../types/auth'
 import type { 
   BasePlaylist, 
+  BasePlaylistOverview,
+  UnmatchedTracks,
   CreateBasePlaylistRequest,
+  CloneBasePlaylistRequest,
+  BasePlaylistWithChilds,
+  RoutingConfig,
   ChildPlaylist,
+  ChildPlaylistStats,
   CreateChildPlaylistRequest,
   UpdateChildPlaylistRequest
 } from '../types/playlist'
@@ -11,6 +17,56 @@ import type { SpotifyPlaylist } from '../types/spotify'
 import type { SyncEvent } from '../types/playlist'
 
 const API_BASE_URL = import.meta.env.VITE_API_BASE_URL || ''
+const MAX_PAGE_SIZE = 100
+
+// Page is the envelope of the paginated list endpoints
+interface Page<T> {
+  items: T[]
+  total: number
+  limit: number
+  offset: number
+}
+
+// FieldError points at an invalid input of a request that failed validation
+export interface FieldError {
+  field: string
+  rule: string
+  message: string
+}
+
+// ApiError carries the problem details returned by the API, code identifies the kind of error
+export class ApiError extends Error {
+  status: number
+  code?: string
+  errors: FieldError[]
+
+  constructor(status: number, message: string, code?: string, errors: FieldError[] = []) {
+    super(message)
+    this.name = 'ApiError'
+    this.status = status
+    this.code = code
+    this.errors = errors
+  }
+
+  // fieldError returns the message for the given json field, if it was invalid
+  fieldError(field: string): string | undefined {
+    return this.errors.find((error) => error.field === field)?.message
+  }
+}
+
+async function toApiError(response: Response): Promise<ApiError> {
+  const fallback = `HTTP error! status: ${response.status}`
+  if (!response.headers.get('Content-Type')?.includes('application/problem+json')) {
+    return new ApiError(response.status, fallback)
+  }
+
+  try {
+    const problem = await response.json()
+    return new ApiError(response.status, problem.detail || problem.title || fallback, problem.code, problem.errors)
+  } catch {
+    return new ApiError(response.status, fallback)
+  }
+}
 
 class ApiClient {
   private baseURL: string
@@ -51,7 +107,7 @@ class ApiClient {
         removeAuthToken()
         window.location.href = '/'
       }
-      throw new Error(`HTTP error! status: ${response.status}`)
+      throw await toApiError(response)
     }
 
     // Handle empty responses (like DELETE operations)
@@ -74,13 +130,41 @@ class ApiClient {
     return this.request<User>('/auth/validate')
   }
 
+  async logout(): Promise<void> {
+    return this.request<void>('/auth/logout', {
+      method: 'POST',
+    })
+  }
+
   // Base playlist endpoints
   async getBasePlaylist(id: string): Promise<BasePlaylist> {
     return this.request<BasePlaylist>(`/api/base_playlist/${id}`)
   }
 
+  async getBasePlaylistOverview(id: string): Promise<BasePlaylistOverview> {
+    return this.request<BasePlaylistOverview>(`/api/base_playlist/${id}/overview`)
+  }
+
+  async getUnmatchedTracks(id: string): Promise<UnmatchedTracks> {
+    return this.request<UnmatchedTracks>(`/api/base_playlist/${id}/unmatched`)
+  }
+
   async getUserBasePlaylists(): Promise<BasePlaylist[]> {
-    return this.request<BasePlaylist[]>('/api/base_playlist')
+    // The list is paginated, walk every page so the dashboard shows all the playlists
+    const basePlaylists: BasePlaylist[] = []
+    let total = 0
+    do {
+      const page = await this.request<Page<BasePlaylist>>(
+        `/api/base_playlist?limit=${MAX_PAGE_SIZE}&offset=${basePlaylists.length}`
+      )
+      basePlaylists.push(...page.items)
+      total = page.total
+      if (page.items.length === 0) {
+        break
+      }
+    } while (basePlaylists.length < total)
+
+    return basePlaylists
   }
 
   async createBasePlaylist(data: CreateBasePlaylistRequest): Promise<BasePlaylist> {
@@ -96,6 +180,42 @@ class ApiClient {
     })
   }
 
+  async restoreBasePlaylist(id: string): Promise<BasePlaylist> {
+    return this.request<BasePlaylist>(`/api/base_playlist/${id}/restore`, {
+      method: 'POST',
+    })
+  }
+
+  async cloneBasePlaylist(id: string, data: CloneBasePlaylistRequest): Promise<BasePlaylistWithChilds> {
+    return this.request<BasePlaylistWithChilds>(`/api/base_playlist/${id}/clone`, {
+      method: 'POST',
+      body: JSON.stringify(data),
+    })
+  }
+
+  async exportRoutingConfig(id: string): Promise<RoutingConfig> {
+    return this.request<RoutingConfig>(`/api/base_playlist/${id}/config/export`)
+  }
+
+  async importRoutingConfig(config: RoutingConfig): Promise<BasePlaylistWithChilds> {
+    return this.request<BasePlaylistWithChilds>('/api/base_playlist/import', {
+      method: 'POST',
+      body: JSON.stringify(config),
+    })
+  }
+
+  async archiveBasePlaylist(id: string): Promise<BasePlaylist> {
+    return this.request<BasePlaylist>(`/api/base_playlist/${id}/archive`, {
+      method: 'POST',
+    })
+  }
+
+  async unarchiveBasePlaylist(id: string): Promise<BasePlaylist> {
+    return this.request<BasePlaylist>(`/api/base_playlist/${id}/archive`, {
+      method: 'DELETE',
+    })
+  }
+
   // Child playlist endpoints
   async getChildPlaylists(basePlaylistId: string): Promise<ChildPlaylist[]> {
     return this.request<ChildPlaylist[]>(`/api/base_playlist/${basePlaylistId}/child_playlist`)
@@ -105,6 +225,10 @@ class ApiClient {
     return this.request<ChildPlaylist>(`/api/child_playlist/${id}`)
   }
 
+  async getChildPlaylistStats(id: string): Promise<ChildPlaylistStats> {
+    return this.request<ChildPlaylistStats>(`/api/child_playlist/${id}/stats`)
+  }
+
   async createChildPlaylist(
     basePlaylistId: string, 
     data: CreateChildPlaylistRequest
@@ -131,6 +255,12 @@ class ApiClient {
     })
   }
 
+  async restoreChildPlaylist(id: string): Promise<ChildPlaylist> {
+    return this.request<ChildPlaylist>(`/api/child_playlist/${id}/restore`, {
+      method: 'POST',
+    })
+  }
+
   // Sync endpoints
   async syncBasePlaylist(basePlaylistId: string): Promise<SyncEvent> {
     return this.request<SyncEvent>(`/api/base_playlist/${basePlaylistId}/sync`, {
@@ -138,6 +268,18 @@ class ApiClient {
     })
   }
 
+  // Settings endpoints
+  async getNotificationSettings(): Promise<NotificationPreferences> {
+    return this.request<NotificationPreferences>('/api/settings/notifications')
+  }
+
+  async updateNotificationSettings(data: UpdateNotificationPreferencesRequest): Promise<NotificationPreferences> {
+    return this.request<NotificationPreferences>('/api/settings/notifications', {
+      method: 'PUT',
+      body: JSON.stringify(data),
+    })
+  }
+
   // Spotify endpoints
   async getSpotifyPlaylists(): Promise<SpotifyPlaylist[]> {
     return this.request<SpotifyPlaylist[]>('/api/spotify/playlists')
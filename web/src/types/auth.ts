@@ -11,4 +11,26 @@ export interface AuthState {
   user: User | null
   isAuthenticated: boolean
   isLoading: boolean
+}
+
+export type NotificationChannel = 'slack' | 'discord'
+
+export interface NotificationPreferences {
+  id?: string
+  user_id: string
+  email_on_sync_failure: boolean
+  sync_failure_threshold: number
+  sync_summary_channels: NotificationChannel[]
+  slack_webhook_url: string
+  discord_webhook_url: string
+  created: string
+  updated: string
+}
+
+export interface UpdateNotificationPreferencesRequest {
+  email_on_sync_failure?: boolean
+  sync_failure_threshold?: number
+  sync_summary_channels?: NotificationChannel[]
+  slack_webhook_url?: string
+  discord_webhook_url?: string
 }
\ No newline at end of file
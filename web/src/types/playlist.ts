@@ -1,17 +1,94 @@
+export type DedupeStrategy = 'all_matches' | 'first_match'
+
 export interface BasePlaylist {
   id: string
   user_id: string
   name: string
   spotify_playlist_id: string
   is_active: boolean
+  archived: boolean
+  dedupe_strategy: DedupeStrategy
+  hook_token?: string
   created: string
   updated: string
   childs?: ChildPlaylist[]
 }
 
+// BasePlaylistOverview gathers what the dashboard shows about a base playlist
+export interface BasePlaylistOverview {
+  base_playlist_id: string
+  track_count: number
+  child_playlists: ChildPlaylistCounts
+  unmatched_tracks: number // Routed to no child playlist by the last completed sync
+  last_sync?: SyncSummary
+  last_completed_at?: string
+}
+
+export interface ChildPlaylistCounts {
+  total: number
+  active: number
+  inactive: number
+}
+
+export interface SyncSummary {
+  id: string
+  status: SyncEvent['status']
+  started_at: string
+  completed_at?: string
+  error_message?: string
+}
+
+// UnmatchedTracks lists the base playlist tracks no child playlist filter rules match
+export interface UnmatchedTracks {
+  base_playlist_id: string
+  total_tracks: number
+  tracks: UnmatchedTrack[]
+  computed_at: string
+}
+
+export interface UnmatchedTrack {
+  track_uri: string
+  track_name: string
+  artists: string[]
+  outcome: 'unmatched' | 'fallback'
+  child_evaluations: ChildFilterEvaluation[]
+}
+
+export interface ChildFilterEvaluation {
+  child_playlist_id: string
+  child_playlist_name: string
+  failed_rules: string[] // Top level filter rule names, like popularity or or
+}
+
 export interface CreateBasePlaylistRequest {
   name: string
   spotify_playlist_id?: string
+  dedupe_strategy?: DedupeStrategy
+}
+
+export interface UpdateBasePlaylistRequest {
+  name?: string
+  is_active?: boolean
+  spotify_playlist_id?: string
+  dedupe_strategy?: DedupeStrategy
+}
+
+export interface CloneBasePlaylistRequest {
+  spotify_playlist_id: string
+  name?: string
+}
+
+export interface BasePlaylistWithChilds extends BasePlaylist {
+  childs: ChildPlaylist[]
+}
+
+export interface HookSyncStatus {
+  playlist: string
+  status: 'in_progress' | 'completed' | 'failed' | 'needs_confirmation' | 'never_synced'
+  message: string
+  sync_event_id?: string
+  tracks_processed: number
+  last_synced_at?: string
 }
 
 // Audio Feature Filter Types
@@ -25,20 +102,71 @@ export interface SetFilter {
   exclude?: string[]
 }
 
+export interface DateFilter {
+  after?: string // YYYY-MM-DD, inclusive
+  before?: string // YYYY-MM-DD, inclusive
+  within_days?: number // Released in the last N days
+  within_years?: number // Released in the last N years
+  decade?: number // First year of the decade, e.g. 2020 for the 2020s
+}
+
+export type TextMatchType = 'contains' | 'prefix' | 'regex'
+
+export interface TextPattern {
+  type: TextMatchType
+  value: string // Matched ignoring case, regex uses Go syntax
+}
+
+export interface DurationFilter {
+  preset?: 'short' | 'standard' | 'long'
+  min?: string | number // "3m30s" or seconds
+  max?: string | number
+}
+
+export interface TextFilter {
+  include?: TextPattern[] // At least one must match when set
+  exclude?: TextPattern[] // None may match
+}
+
 export interface MetadataFilters {
   // Track Information
   duration_ms?: RangeFilter
+  duration?: DurationFilter // Request only, saved as duration_ms
   popularity?: RangeFilter
   explicit?: boolean // true = explicit only, false = clean only, undefined = both
+  added_date?: DateFilter // Date the track was added to the base playlist, tracks without one never match
   
   // Artist & Album Information
   genres?: SetFilter
+  artists?: SetFilter // Spotify artist IDs, URIs or names, matching any of the track artists
   release_year?: RangeFilter
+  release_date?: DateFilter // Album release date, tracks without one never match
   artist_popularity?: RangeFilter
   
   // Search-based Filters
   track_keywords?: SetFilter // Keywords to search for in track names
   artist_keywords?: SetFilter // Keywords to search for in artist names
+  track_name?: TextFilter
+  artist_name?: TextFilter // Matches when any of the track artists matches
+  album_name?: TextFilter
+
+  // Audio Features (tracks without audio features never match these)
+  tempo?: RangeFilter // Beats per minute
+  energy?: RangeFilter // 0.0 - 1.0
+  danceability?: RangeFilter // 0.0 - 1.0
+  valence?: RangeFilter // 0.0 - 1.0
+  acousticness?: RangeFilter // 0.0 - 1.0
+  instrumentalness?: RangeFilter // 0.0 - 1.0
+  liveness?: RangeFilter // 0.0 - 1.0
+  speechiness?: RangeFilter // 0.0 - 1.0
+  loudness?: RangeFilter // Decibels
+  key?: RangeFilter // Pitch class, 0 = C ... 11 = B
+  mode?: RangeFilter // 1 = major, 0 = minor
+
+  // Boolean Composition
+  and?: MetadataFilters[] // Every group must match
+  or?: MetadataFilters[] // At least one group must match
+  not?: MetadataFilters // The group must not match
 }
 
 // Child Playlist Types
@@ -51,14 +179,107 @@ export interface ChildPlaylist {
   spotify_playlist_id: string
   filter_rules?: MetadataFilters
   is_active: boolean
+  is_fallback: boolean
+  priority: number
+  share_token?: string
+  max_tracks?: number
+  selection_strategy?: SelectionStrategy
+  sync_strategy?: SyncStrategy
+  source_base_playlist_ids?: string[]
+  pinned_tracks?: string[] // Track URIs synced whatever the filter rules
+  created: string
+  updated: string
+}
+
+// ChildPlaylistStats describe how a child playlist routes the current tracks of its base playlist
+export interface ChildPlaylistStats {
+  child_playlist_id: string
+  track_count: number
+  last_synced_at?: string
+  base_track_count: number
+  matched_tracks: number
+  match_rate: number // 0 - 1
+  top_genres: StatCount[]
+  top_artists: StatCount[]
+  computed_at: string
+}
+
+export interface StatCount {
+  name: string
+  count: number
+}
+
+export type SelectionStrategy = 'most_popular' | 'newest' | 'random'
+
+export type SyncStrategy = 'recreate' | 'in_place'
+
+export interface FilterPreviewRequest {
+  filter_rules: MetadataFilters
+  sample_size?: number
+}
+
+export interface FilterPreview {
+  base_playlist_id: string
+  total_tracks: number
+  matched_tracks: number
+  unmatched_tracks: number
+  sample: FilterPreviewTrack[]
+}
+
+export interface FilterPreviewTrack {
+  id: string
+  name: string
+  uri: string
+  artists: string[]
+  album: string
+}
+
+export type MusicProvider = 'spotify' | 'youtube_music' | 'tidal'
+
+export interface PlaylistWidget {
+  name: string
+  description?: string
+  cover_image_url?: string
+  track_count: number
+  last_synced_at?: string
+  provider: MusicProvider
+  playlist_url?: string
+}
+
+export type BlocklistEntryType = 'track' | 'artist'
+
+// A track or artist kept out of every child playlist of a base playlist
+export interface BlocklistEntry {
+  id: string
+  user_id: string
+  base_playlist_id: string
+  type: BlocklistEntryType
+  value: string // Spotify track URI or artist ID
   created: string
   updated: string
 }
 
+export interface CreateBlocklistEntryRequest {
+  type: BlocklistEntryType
+  value: string
+}
+
+export interface PinTracksRequest {
+  track_uris: string[]
+}
+
+export interface ReorderChildPlaylistsRequest {
+  child_playlist_ids: string[]
+}
+
 export interface CreateChildPlaylistRequest {
   name: string
   description?: string
   filter_rules?: MetadataFilters
+  is_fallback?: boolean
+  max_tracks?: number
+  selection_strategy?: SelectionStrategy
+  source_base_playlist_ids?: string[]
 }
 
 export interface UpdateChildPlaylistRequest {
@@ -66,6 +287,65 @@ export interface UpdateChildPlaylistRequest {
   description?: string
   filter_rules?: MetadataFilters
   is_active?: boolean
+  is_fallback?: boolean
+  max_tracks?: number // 0 removes the cap
+  selection_strategy?: SelectionStrategy
+  source_base_playlist_ids?: string[] // [] stops merging
+  expected_updated?: string // updated of the edited version, refused with a 409 when it changed since
+}
+
+// A child playlist created when a template is instantiated
+export type TemplateChild = CreateChildPlaylistRequest
+
+export interface ChildPlaylistTemplate {
+  id: string
+  user_id?: string // Empty for built-in templates
+  name: string
+  description?: string
+  children: TemplateChild[]
+  built_in: boolean
+  created: string
+  updated: string
+}
+
+export interface CreateChildPlaylistTemplateRequest {
+  name: string
+  description?: string
+  children: TemplateChild[]
+}
+
+export interface InstantiateChildPlaylistTemplateRequest {
+  base_playlist_id: string
+}
+
+// RoutingConfig is the portable routing setup of a base playlist, children in priority order
+export interface RoutingConfig {
+  version: number
+  name: string
+  spotify_playlist_id: string
+  dedupe_strategy?: DedupeStrategy
+  children: TemplateChild[]
+}
+
+export interface FilterRuleChange {
+  id: string
+  user_id: string
+  base_playlist_id: string
+  child_playlist_id: string
+  previous_filter_rules?: MetadataFilters
+  new_filter_rules?: MetadataFilters
+  routing_diff?: RoutingDiff
+  created: string
+  updated: string
+}
+
+export interface RoutingDiff {
+  sync_event_id?: string
+  tracks_before: number
+  tracks_after: number
+  added_track_uris: string[]
+  removed_track_uris: string[]
+  computed_at: string
 }
 
 // Sync Event Types
@@ -74,10 +354,134 @@ export interface SyncEvent {
   user_id: string
   base_playlist_id: string
   child_playlist_ids?: string[]
-  status: 'in_progress' | 'completed' | 'failed'
+  status: 'in_progress' | 'completed' | 'failed' | 'needs_confirmation'
   tracks_processed?: number
+  tracks_unmatched?: number
   total_api_requests?: number
   started_at: string
   completed_at?: string
+  duration_ms?: number
   error_message?: string
-}
\ No newline at end of file
+  child_sync_results?: ChildSyncResult[]
+  anomalies?: SyncAnomaly[]
+  phase?: 'fetching_tracks' | 'routing_tracks' | 'updating_playlists' | 'waiting_for_quota'
+  heartbeat_at?: string
+  profile?: SyncProfileSpan
+  rate_limit?: SyncRateLimitStats
+}
+
+export interface SyncRateLimitStats {
+  rate_limited_responses: number // 429 responses received from Spotify
+  retries: number
+  budget_waits: number // Requests queued on the request budget
+  wait_ms: number
+}
+
+export type RoutingOutcome = 'routed' | 'fallback' | 'unmatched' | 'capped' | 'blocked'
+
+// Where each track of the base playlist ended up during the latest sync
+export interface RoutingReport {
+  id: string
+  user_id: string
+  base_playlist_id: string
+  sync_event_id: string
+  dedupe_strategy: DedupeStrategy
+  tracks: TrackRoutingDecision[]
+  created: string
+  updated: string
+}
+
+export interface TrackRoutingDecision {
+  track_uri: string
+  track_name: string
+  matched_child_ids: string[] // Highest priority first
+  routed_child_ids: string[]
+  outcome: RoutingOutcome
+}
+
+export interface SyncProfileSpan {
+  name: string
+  value: number
+  start_ms: number
+  children?: SyncProfileSpan[]
+}
+
+export interface SyncAnomaly {
+  type: 'child_track_drop' | 'unmatched_spike'
+  child_playlist_id?: string
+  previous_count: number
+  new_count: number
+  message: string
+}
+
+export interface ChildSyncResult {
+  child_playlist_id: string
+  spotify_playlist_id?: string
+  status: 'in_progress' | 'completed' | 'failed'
+  tracks_added: number
+  tracks_removed: number
+  api_requests: number
+  error_message?: string
+}
+export type PlaylistDriftType =
+  | 'playlist_missing'
+  | 'never_synced'
+  | 'content_mismatch'
+  | 'name_mismatch'
+  | 'description_mismatch'
+
+export interface ChildPlaylistAudit {
+  child_playlist_id: string
+  child_playlist_name: string
+  spotify_playlist_id: string
+  in_sync: boolean
+  drift: PlaylistDriftType[]
+  expected_track_count: number
+  actual_track_count: number
+  missing_track_uris?: string[]
+  unexpected_track_uris?: string[]
+  expected_name: string
+  actual_name?: string
+  expected_description: string
+  actual_description?: string
+  error_message?: string
+}
+
+export interface BasePlaylistAudit {
+  base_playlist_id: string
+  base_playlist_name: string
+  children: ChildPlaylistAudit[]
+  drifted: number
+  error_message?: string
+}
+
+export interface AuditReport {
+  user_id: string
+  audited_at: string
+  base_playlists: BasePlaylistAudit[]
+  total_children: number
+  drifted_children: number
+}
+
+// Automation Types
+export type APIKeyScope = 'sync:read' | 'sync:write' | 'rules:write'
+
+export interface APIKey {
+  id: string
+  user_id: string
+  name: string
+  key_prefix: string
+  scopes: APIKeyScope[]
+  last_used_at?: string
+  created: string
+  updated: string
+}
+
+export interface CreatedAPIKey extends APIKey {
+  key: string // Only returned when the key is created
+}
+
+export interface CreateAPIKeyRequest {
+  name: string
+  scopes: APIKeyScope[]
+}